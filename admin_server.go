@@ -0,0 +1,322 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/dlintw/goconf"
+	"github.com/gorilla/mux"
+)
+
+const (
+	// GeoIP overrides may contain large lists of ranges.
+	maxGeoIPOverridesBodySize = 16 * 1024 * 1024
+
+	// Snapshots of the state contain all sessions and rooms of the server.
+	maxStateBodySize = 256 * 1024 * 1024
+)
+
+var (
+	ErrAdminTokenMissing = errors.New("need a token for the admin API")
+)
+
+// ConfigReloadHandler reloads the configuration and returns the results per
+// changed section.
+type ConfigReloadHandler func() (ConfigReloadResults, error)
+
+// AdminServer provides an HTTP API to inspect and manage the sessions and
+// rooms of the hub. It is served on a separate listener and requires a token.
+type AdminServer struct {
+	hub *Hub
+
+	token atomic.Value // string
+
+	configReloadHandler atomic.Pointer[ConfigReloadHandler]
+}
+
+func getAdminToken(config *goconf.ConfigFile) (string, error) {
+	token, _ := GetStringOptionWithEnv(config, "admin", "token")
+	if token == "" {
+		return "", ErrAdminTokenMissing
+	}
+
+	return token, nil
+}
+
+func NewAdminServer(config *goconf.ConfigFile, hub *Hub) (*AdminServer, error) {
+	token, err := getAdminToken(config)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &AdminServer{
+		hub: hub,
+	}
+	result.token.Store(token)
+	return result, nil
+}
+
+func (s *AdminServer) Reload(config *goconf.ConfigFile) error {
+	token, err := getAdminToken(config)
+	if err != nil {
+		backendLog.Errorf("Could not reload admin API settings: %s", err)
+		return NewConfigSectionError("admin", err)
+	}
+
+	s.token.Store(token)
+	return nil
+}
+
+func (s *AdminServer) Start(r *mux.Router) {
+	a := r.PathPrefix("/api/v1").Subrouter()
+	a.HandleFunc("/sessions", s.validateRequest(s.sessionsHandler)).Methods("GET")
+	a.HandleFunc("/sessions/{sessionid}", s.validateRequest(s.sessionHandler)).Methods("GET")
+	a.HandleFunc("/sessions/{sessionid}", s.validateRequest(s.disconnectHandler)).Methods("DELETE")
+	a.HandleFunc("/rooms", s.validateRequest(s.roomsHandler)).Methods("GET")
+	a.HandleFunc("/geoip/overrides", s.validateRequest(s.geoipOverridesHandler)).Methods("PUT")
+	a.HandleFunc("/reload", s.validateRequest(s.reloadHandler)).Methods("POST")
+	a.HandleFunc("/drain", s.validateRequest(s.drainHandler)).Methods("POST")
+	a.HandleFunc("/state", s.validateRequest(s.stateHandler)).Methods("GET")
+	a.HandleFunc("/state", s.validateRequest(s.restoreStateHandler)).Methods("POST")
+}
+
+func (s *AdminServer) validateRequest(f func(http.ResponseWriter, *http.Request)) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, _ := s.token.Load().(string)
+		if token == "" || !checkStatsToken(token, getBearerToken(r)) {
+			WriteStatsAccessError(w, ErrStatsTokenInvalid)
+			return
+		}
+
+		w.Header().Set("Server", "nextcloud-spreed-signaling/"+s.hub.version)
+		f(w, r)
+	}
+}
+
+func writeAdminResponse(w http.ResponseWriter, response any) {
+	data, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		backendLog.Errorf("Could not serialize admin response %+v: %s", response, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data) // nolint
+}
+
+func (s *AdminServer) getSession(r *http.Request) Session {
+	sessionId := mux.Vars(r)["sessionid"]
+	session := s.hub.GetSessionByPublicId(PublicSessionId(sessionId))
+	if session == nil {
+		session = s.hub.GetSessionByResumeId(PrivateSessionId(sessionId))
+	}
+	return session
+}
+
+func (s *AdminServer) sessionsHandler(w http.ResponseWriter, r *http.Request) {
+	sessions := s.hub.GetSessions()
+	response := &AdminServerSessions{
+		Sessions: make([]*BackendServerSessionInfo, 0, len(sessions)),
+	}
+	for _, session := range sessions {
+		response.Sessions = append(response.Sessions, getSessionInfo(session))
+	}
+	slices.SortFunc(response.Sessions, func(a *BackendServerSessionInfo, b *BackendServerSessionInfo) int {
+		return a.Created.Compare(b.Created)
+	})
+	writeAdminResponse(w, response)
+}
+
+func (s *AdminServer) sessionHandler(w http.ResponseWriter, r *http.Request) {
+	if session := s.getSession(r); session != nil {
+		writeAdminResponse(w, getSessionInfo(session))
+		return
+	}
+
+	// The session might be connected to a different server that shares the
+	// session storage.
+	sessionId := mux.Vars(r)["sessionid"]
+	stored, err := s.hub.storage.GetSession(r.Context(), PublicSessionId(sessionId))
+	if err != nil {
+		if !errors.Is(err, ErrNoSuchStoredSession) {
+			backendLog.Errorf("Could not get session %s from storage: %s", sessionId, err)
+		}
+		http.Error(w, "Session not found", http.StatusNotFound)
+		return
+	}
+
+	writeAdminResponse(w, getStoredSessionInfo(stored))
+}
+
+func (s *AdminServer) disconnectHandler(w http.ResponseWriter, r *http.Request) {
+	session := s.getSession(r)
+	if session == nil {
+		http.Error(w, "Session not found", http.StatusNotFound)
+		return
+	}
+
+	backendLog.Infof("Disconnecting session %s through admin API from %s", session.PublicId(), s.hub.getRealUserIP(r))
+	s.hub.DisconnectSession(session, ByeReasonDisconnected)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *AdminServer) roomsHandler(w http.ResponseWriter, r *http.Request) {
+	rooms := s.hub.GetRooms()
+	response := &AdminServerRooms{
+		Rooms: make([]*AdminServerRoom, 0, len(rooms)),
+	}
+	for _, room := range rooms {
+		sessions, inCall := room.GetSessionCounts()
+		response.Rooms = append(response.Rooms, &AdminServerRoom{
+			RoomId:   room.Id(),
+			Backend:  room.Backend().Id(),
+			Sessions: sessions,
+			InCall:   inCall,
+		})
+	}
+	slices.SortFunc(response.Rooms, func(a *AdminServerRoom, b *AdminServerRoom) int {
+		if c := strings.Compare(a.Backend, b.Backend); c != 0 {
+			return c
+		}
+		return strings.Compare(a.RoomId, b.RoomId)
+	})
+	writeAdminResponse(w, response)
+}
+
+func (s *AdminServer) drainHandler(w http.ResponseWriter, r *http.Request) {
+	if s.hub.Drain() {
+		backendLog.Infof("Drain requested through admin API from %s", s.hub.getRealUserIP(r))
+	}
+
+	writeAdminResponse(w, s.hub.GetDrainStatus())
+}
+
+func (s *AdminServer) stateHandler(w http.ResponseWriter, r *http.Request) {
+	writeAdminResponse(w, s.hub.GetHandoffState())
+}
+
+func (s *AdminServer) restoreStateHandler(w http.ResponseWriter, r *http.Request) {
+	if r.ContentLength > maxStateBodySize {
+		http.Error(w, "Request entity too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	var state HandoffState
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxStateBodySize)).Decode(&state); err != nil {
+		http.Error(w, "Could not parse request", http.StatusBadRequest)
+		return
+	}
+
+	backendLog.Infof("Restoring %d sessions in %d rooms through admin API from %s", len(state.Sessions), len(state.Rooms), s.hub.getRealUserIP(r))
+	// Snapshots may have been modified, so only sessions of regular clients
+	// with valid ids are restored.
+	sessions, rooms := s.hub.RestoreHandoffState(&state, false)
+	writeAdminResponse(w, &BackendServerStateRestored{
+		Sessions: sessions,
+		Rooms:    rooms,
+	})
+}
+
+func (s *AdminServer) geoipOverridesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.ContentLength > maxGeoIPOverridesBodySize {
+		http.Error(w, "Request entity too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	var request BackendServerGeoIPOverrides
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxGeoIPOverridesBodySize)).Decode(&request); err != nil {
+		http.Error(w, "Could not parse request", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.hub.geoipOverrides.SetApiOverrides(request.Overrides); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	backendLog.Infof("Updated %d GeoIP overrides through admin API from %s", len(request.Overrides), s.hub.getRealUserIP(r))
+	writeAdminResponse(w, &BackendServerGeoIPOverrides{
+		Overrides: s.hub.geoipOverrides.GetOverrides(),
+	})
+}
+
+// SetConfigReloadHandler sets the handler that is called for requests to the
+// reload endpoint.
+func (s *AdminServer) SetConfigReloadHandler(handler ConfigReloadHandler) {
+	if handler == nil {
+		s.configReloadHandler.Store(nil)
+	} else {
+		s.configReloadHandler.Store(&handler)
+	}
+}
+
+func (s *AdminServer) reloadHandler(w http.ResponseWriter, r *http.Request) {
+	handler := s.configReloadHandler.Load()
+	if handler == nil {
+		http.Error(w, "Reloading is not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	status := http.StatusOK
+	response := &BackendServerReloadResponse{
+		Success: true,
+	}
+	if results, err := (*handler)(); err != nil {
+		status = http.StatusInternalServerError
+		response.Success = false
+		response.Error = err.Error()
+	} else {
+		for _, section := range slices.Sorted(maps.Keys(results)) {
+			entry := BackendServerReloadSection{
+				Section: section,
+				Success: results[section] == nil,
+			}
+			if err := results[section]; err != nil {
+				entry.Error = err.Error()
+				response.Success = false
+			}
+			response.Sections = append(response.Sections, entry)
+		}
+	}
+
+	responseData, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		backendLog.Errorf("Could not serialize reload response %+v: %s", response, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	w.Write(responseData) // nolint
+}
@@ -0,0 +1,512 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dlintw/goconf"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminServer_Config(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+
+	config := goconf.NewConfigFile()
+	_, err := NewAdminServer(config, nil)
+	assert.ErrorIs(err, ErrAdminTokenMissing)
+
+	config.AddOption("admin", "token", "the-token")
+	admin, err := NewAdminServer(config, nil)
+	require.NoError(err)
+	assert.Equal("the-token", admin.token.Load())
+
+	// The previous token is kept if the new configuration is invalid.
+	assert.Error(admin.Reload(goconf.NewConfigFile()))
+	assert.Equal("the-token", admin.token.Load())
+
+	config.AddOption("admin", "token", "other-token")
+	assert.NoError(admin.Reload(config))
+	assert.Equal("other-token", admin.token.Load())
+}
+
+func NewAdminServerForTest(t *testing.T, hub *Hub) (*AdminServer, string) {
+	config := goconf.NewConfigFile()
+	config.AddOption("admin", "token", "the-token")
+	admin, err := NewAdminServer(config, hub)
+	require.NoError(t, err)
+	r := mux.NewRouter()
+	admin.Start(r)
+	server := httptest.NewServer(r)
+	t.Cleanup(server.Close)
+	return admin, server.URL
+}
+
+func performAdminRequest(ctx context.Context, t *testing.T, method string, url string, token string, body io.Reader) (int, []byte) {
+	require := require.New(t)
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	require.NoError(err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(err)
+	defer res.Body.Close()
+	data, err := io.ReadAll(res.Body)
+	require.NoError(err)
+	return res.StatusCode, data
+}
+
+func TestAdminServer(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	hub, _, _, server := CreateHubForTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	_, adminUrl := NewAdminServerForTest(t, hub)
+	performRequest := func(method string, path string, token string) (int, []byte) {
+		return performAdminRequest(ctx, t, method, adminUrl+path, token, nil)
+	}
+
+	status, _ := performRequest(http.MethodGet, "/api/v1/sessions", "")
+	assert.Equal(http.StatusUnauthorized, status)
+	status, _ = performRequest(http.MethodGet, "/api/v1/sessions", "invalid-token")
+	assert.Equal(http.StatusUnauthorized, status)
+
+	client1, hello1 := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId+"1")
+	client2, hello2 := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId+"2")
+	defer client2.CloseWithBye()
+
+	roomId := "test-room"
+	roomMsg := MustSucceed2(t, client1.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+	client1.RunUntilJoined(ctx, hello1.Hello)
+
+	status, body := performRequest(http.MethodGet, "/api/v1/sessions", "the-token")
+	require.Equal(http.StatusOK, status, string(body))
+	var sessions AdminServerSessions
+	require.NoError(json.Unmarshal(body, &sessions), string(body))
+	if assert.Len(sessions.Sessions, 2) {
+		assert.Equal(hello1.Hello.SessionId, sessions.Sessions[0].SessionId)
+		assert.Equal(roomId, sessions.Sessions[0].RoomId)
+		assert.Equal(hello2.Hello.SessionId, sessions.Sessions[1].SessionId)
+		assert.Empty(sessions.Sessions[1].RoomId)
+	}
+
+	status, body = performRequest(http.MethodGet, "/api/v1/sessions/"+string(hello1.Hello.SessionId), "the-token")
+	require.Equal(http.StatusOK, status, string(body))
+	var info BackendServerSessionInfo
+	require.NoError(json.Unmarshal(body, &info), string(body))
+	assert.Equal(hello1.Hello.SessionId, info.SessionId)
+	assert.Equal(testDefaultUserId+"1", info.UserId)
+	assert.Equal(roomId, info.RoomId)
+	assert.True(info.Connected)
+
+	status, body = performRequest(http.MethodGet, "/api/v1/rooms", "the-token")
+	require.Equal(http.StatusOK, status, string(body))
+	var rooms AdminServerRooms
+	require.NoError(json.Unmarshal(body, &rooms), string(body))
+	if assert.Len(rooms.Rooms, 1) {
+		assert.Equal(roomId, rooms.Rooms[0].RoomId)
+		assert.Equal(1, rooms.Rooms[0].Sessions)
+		assert.Equal(0, rooms.Rooms[0].InCall)
+	}
+
+	status, body = performRequest(http.MethodDelete, "/api/v1/sessions/"+string(hello1.Hello.SessionId), "the-token")
+	assert.Equal(http.StatusNoContent, status, string(body))
+	if message, ok := client1.RunUntilMessage(ctx); ok && checkMessageType(t, message, "bye") {
+		assert.Equal(ByeReasonDisconnected, message.Bye.Reason)
+		assert.False(message.Bye.Reconnect)
+	}
+	client1.RunUntilClosed(ctx)
+
+	status, _ = performRequest(http.MethodGet, "/api/v1/sessions/"+string(hello1.Hello.SessionId), "the-token")
+	assert.Equal(http.StatusNotFound, status)
+	status, _ = performRequest(http.MethodDelete, "/api/v1/sessions/"+string(hello1.Hello.SessionId), "the-token")
+	assert.Equal(http.StatusNotFound, status)
+}
+
+func TestAdminServer_Reload(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	hub, _, _, server := CreateHubForTest(t)
+	admin, adminUrl := NewAdminServerForTest(t, hub)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	performReload := func() (int, *BackendServerReloadResponse) {
+		status, body := performAdminRequest(ctx, t, http.MethodPost, adminUrl+"/api/v1/reload", "the-token", nil)
+		if status != http.StatusOK && status != http.StatusInternalServerError {
+			return status, nil
+		}
+
+		var response BackendServerReloadResponse
+		require.NoError(json.Unmarshal(body, &response), string(body))
+		return status, &response
+	}
+
+	status, _ := performReload()
+	assert.Equal(http.StatusServiceUnavailable, status)
+
+	admin.SetConfigReloadHandler(func() (ConfigReloadResults, error) {
+		return ConfigReloadResults{
+			"app":  nil,
+			"turn": errors.New("missing secret"),
+		}, nil
+	})
+	status, response := performReload()
+	assert.Equal(http.StatusOK, status)
+	assert.False(response.Success)
+	assert.Equal([]BackendServerReloadSection{
+		{Section: "app", Success: true},
+		{Section: "turn", Error: "missing secret"},
+	}, response.Sections)
+
+	admin.SetConfigReloadHandler(func() (ConfigReloadResults, error) {
+		return nil, errors.New("could not read server.conf")
+	})
+	status, response = performReload()
+	assert.Equal(http.StatusInternalServerError, status)
+	assert.False(response.Success)
+	assert.Equal("could not read server.conf", response.Error)
+
+	// The token is required and the endpoint is no longer available on the
+	// public listener.
+	status, _ = performAdminRequest(ctx, t, http.MethodPost, adminUrl+"/api/v1/reload", "", nil)
+	assert.Equal(http.StatusUnauthorized, status)
+	status, _ = performAdminRequest(ctx, t, http.MethodPost, server.URL+"/api/v1/reload", "", nil)
+	assert.Equal(http.StatusNotFound, status)
+}
+
+func TestAdminServer_StateDumpRestore(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	hub1, _, _, server1 := CreateHubForTest(t)
+	// The other server uses the same backends and session keys.
+	hub2, _, _, server2 := CreateHubForTestWithConfig(t, func(*httptest.Server) (*goconf.ConfigFile, error) {
+		return getTestConfig(server1)
+	})
+	_, adminUrl1 := NewAdminServerForTest(t, hub1)
+	_, adminUrl2 := NewAdminServerForTest(t, hub2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client1, hello1 := NewTestClientWithHello(ctx, t, server1, hub1, testDefaultUserId)
+	roomId := "test-room"
+	roomMsg := MustSucceed2(t, client1.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+	client1.RunUntilJoined(ctx, hello1.Hello)
+
+	session1 := hub1.GetSessionByPublicId(hello1.Hello.SessionId)
+	require.NotNil(session1)
+	room1 := hub1.GetRoomForBackend(roomId, session1.Backend())
+	require.NotNil(room1)
+	room1.SetTransientData("foo", "bar")
+	checkMessageTransientSet(t, MustSucceed1(t, client1.RunUntilMessage, ctx), "foo", "bar", nil)
+
+	status, _ := performAdminRequest(ctx, t, http.MethodGet, adminUrl1+"/api/v1/state", "", nil)
+	assert.Equal(http.StatusUnauthorized, status)
+	// The state is not available on the public listener.
+	res, err := http.Get(server1.URL + "/api/v1/state")
+	require.NoError(err)
+	res.Body.Close()
+	assert.Equal(http.StatusNotFound, res.StatusCode)
+
+	status, dump := performAdminRequest(ctx, t, http.MethodGet, adminUrl1+"/api/v1/state", "the-token", nil)
+	require.Equal(http.StatusOK, status, string(dump))
+
+	var state HandoffState
+	require.NoError(json.Unmarshal(dump, &state), string(dump))
+	if assert.Len(state.Sessions, 1) {
+		assert.Equal(hello1.Hello.SessionId, state.Sessions[0].PublicId)
+		assert.Equal(roomId, state.Sessions[0].RoomId)
+	}
+	if assert.Len(state.Rooms, 1) && assert.Contains(state.Rooms[0].TransientData, "foo") {
+		assert.Equal("bar", state.Rooms[0].TransientData["foo"].Value)
+	}
+
+	// The client moves to the other server.
+	client1.CloseWithBye()
+
+	performRestore := func(data []byte) (int, *BackendServerStateRestored) {
+		status, body := performAdminRequest(ctx, t, http.MethodPost, adminUrl2+"/api/v1/state", "the-token", bytes.NewReader(data))
+		if status != http.StatusOK {
+			return status, nil
+		}
+
+		var response BackendServerStateRestored
+		require.NoError(json.Unmarshal(body, &response), string(body))
+		return status, &response
+	}
+
+	// Sessions with ids that don't match their data or of internal clients
+	// are not restored.
+	modified := func(f func(s *HandoffSession)) []byte {
+		var state HandoffState
+		require.NoError(json.Unmarshal(dump, &state))
+		f(state.Sessions[0])
+		data, err := json.Marshal(state)
+		require.NoError(err)
+		return data
+	}
+	for _, data := range [][]byte{
+		modified(func(s *HandoffSession) { s.Sid++ }),
+		modified(func(s *HandoffSession) { s.PrivateId = "forged-private-id" }),
+		modified(func(s *HandoffSession) {
+			publicId, err := hub2.cookie.EncodePublic(hub2.newSessionIdData(session1.Backend()))
+			require.NoError(err)
+			s.PublicId = publicId
+		}),
+		modified(func(s *HandoffSession) { s.ClientType = HelloClientTypeInternal }),
+	} {
+		if status, restored := performRestore(data); assert.Equal(http.StatusOK, status) {
+			assert.Equal(0, restored.Sessions)
+			assert.Equal(0, restored.Rooms)
+		}
+	}
+
+	if status, restored := performRestore(dump); assert.Equal(http.StatusOK, status) {
+		assert.Equal(1, restored.Sessions)
+		assert.Equal(1, restored.Rooms)
+	}
+	// Existing sessions are not replaced.
+	if status, restored := performRestore(dump); assert.Equal(http.StatusOK, status) {
+		assert.Equal(0, restored.Sessions)
+		assert.Equal(0, restored.Rooms)
+	}
+	status, _ = performRestore([]byte("invalid-json"))
+	assert.Equal(http.StatusBadRequest, status)
+
+	client2 := NewTestClient(t, server2, hub2)
+	defer client2.CloseWithBye()
+	require.NoError(client2.SendHelloResume(hello1.Hello.ResumeId))
+	if hello, ok := client2.RunUntilHello(ctx); ok {
+		assert.Equal(hello1.Hello.SessionId, hello.Hello.SessionId)
+	}
+
+	session2 := hub2.GetSessionByPublicId(hello1.Hello.SessionId)
+	require.NotNil(session2)
+	room2 := session2.(*ClientSession).GetRoom()
+	if assert.NotNil(room2) {
+		assert.Equal(roomId, room2.Id())
+		assert.Equal(StringMap{"foo": "bar"}, room2.transientData.GetData())
+	}
+}
+
+func TestAdminServer_Drain(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	_, _, _, hub, _, server := CreateBackendServerForTest(t)
+	_, adminUrl := NewAdminServerForTest(t, hub)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client, _ := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId)
+	defer client.CloseWithBye()
+
+	performDrain := func() *BackendServerDrainStatus {
+		status, body := performAdminRequest(ctx, t, http.MethodPost, adminUrl+"/api/v1/drain", "the-token", nil)
+		require.Equal(http.StatusOK, status, string(body))
+
+		var response BackendServerDrainStatus
+		require.NoError(json.Unmarshal(body, &response), string(body))
+		return &response
+	}
+
+	// The token is required, only POST is supported and the endpoint is not
+	// available on the public listener.
+	status, _ := performAdminRequest(ctx, t, http.MethodPost, adminUrl+"/api/v1/drain", "", nil)
+	assert.Equal(http.StatusUnauthorized, status)
+	status, _ = performAdminRequest(ctx, t, http.MethodGet, adminUrl+"/api/v1/drain", "the-token", nil)
+	assert.NotEqual(http.StatusOK, status)
+	res, err := http.Post(server.URL+"/api/v1/drain", "application/json", nil)
+	require.NoError(err)
+	res.Body.Close()
+	assert.Equal(http.StatusNotFound, res.StatusCode)
+	assert.False(hub.GetDrainStatus().Draining)
+
+	drain := performDrain()
+	assert.True(drain.Draining)
+
+	if message, ok := client.RunUntilMessage(ctx); ok && checkMessageType(t, message, "bye") {
+		assert.Equal(ByeReasonDraining, message.Bye.Reason)
+	}
+	client.RunUntilClosed(ctx)
+
+	select {
+	case <-hub.ShutdownChannel():
+	case <-ctx.Done():
+		require.Fail("should have shutdown")
+	}
+	// Repeated requests return the current status.
+	drain = performDrain()
+	assert.True(drain.Draining)
+	assert.Equal(0, drain.Sessions)
+
+	res, err = http.Get(server.URL + "/ready")
+	require.NoError(err)
+	defer res.Body.Close()
+	assert.Equal(http.StatusServiceUnavailable, res.StatusCode)
+}
+
+func TestAdminServer_SessionInfo(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	hub, _, _, server := CreateHubForTest(t)
+	_, adminUrl := NewAdminServerForTest(t, hub)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client, hello := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId)
+	defer client.CloseWithBye()
+	roomId := "test-room"
+	roomMsg := MustSucceed2(t, client.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+	client.RunUntilJoined(ctx, hello.Hello)
+
+	getSessionInfo := func(sessionId string) (int, *BackendServerSessionInfo) {
+		status, body := performAdminRequest(ctx, t, http.MethodGet, adminUrl+"/api/v1/sessions/"+url.PathEscape(sessionId), "the-token", nil)
+		if status != http.StatusOK {
+			return status, nil
+		}
+
+		var info BackendServerSessionInfo
+		require.NoError(json.Unmarshal(body, &info), string(body))
+		return status, &info
+	}
+
+	for _, sessionId := range []string{string(hello.Hello.SessionId), string(hello.Hello.ResumeId)} {
+		if status, info := getSessionInfo(sessionId); assert.Equal(http.StatusOK, status) {
+			assert.Equal(hello.Hello.SessionId, info.SessionId)
+			assert.Equal(HelloClientTypeClient, info.ClientType)
+			assert.Equal(testDefaultUserId, info.UserId)
+			assert.Equal(roomId, info.RoomId)
+			assert.True(info.Connected)
+			assert.False(info.Created.IsZero())
+			assert.Empty(info.Node)
+		}
+	}
+
+	status, _ := getSessionInfo("unknown-session")
+	assert.Equal(http.StatusNotFound, status)
+
+	// The session and its room are also available in the storage.
+	if stored, err := hub.storage.GetSession(ctx, hello.Hello.SessionId); assert.NoError(err) {
+		assert.Equal(GrpcServerId, stored.Node)
+		assert.Equal(roomId, stored.RoomId)
+		assert.Equal(testDefaultUserId, stored.UserId)
+	}
+
+	// Sessions of other servers are returned from the storage.
+	remote := &HubStorageSession{
+		PublicId:   "remote-session",
+		Node:       "other-node",
+		Created:    time.Now().Truncate(time.Second).UTC(),
+		BackendId:  "backend1",
+		ClientType: HelloClientTypeClient,
+		UserId:     "remote-user",
+		RoomId:     roomId,
+	}
+	hub.storage.SetSession(remote)
+	defer hub.storage.RemoveSession(remote.PublicId)
+	if status, info := getSessionInfo(string(remote.PublicId)); assert.Equal(http.StatusOK, status) {
+		assert.Equal(remote.PublicId, info.SessionId)
+		assert.Equal(remote.Node, info.Node)
+		assert.Equal(remote.UserId, info.UserId)
+		assert.Equal(roomId, info.RoomId)
+		assert.True(remote.Created.Equal(info.Created))
+		assert.False(info.Connected)
+	}
+}
+
+func TestAdminServer_GeoIPOverrides(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	_, _, _, hub, _, server := CreateBackendServerForTest(t)
+	_, adminUrl := NewAdminServerForTest(t, hub)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	performRequest := func(url string, method string, body string) (int, string) {
+		status, data := performAdminRequest(ctx, t, method, url+"/api/v1/geoip/overrides", "the-token", strings.NewReader(body))
+		return status, string(data)
+	}
+
+	// The overrides can only be changed through the admin API.
+	status, body := performRequest(server.URL, http.MethodPut, `{"overrides":{"10.0.0.0/8":"DE"}}`)
+	assert.NotEqual(http.StatusOK, status, body)
+	assert.Empty(hub.geoipOverrides.GetOverrides())
+
+	status, body = performRequest(adminUrl, http.MethodPut, `{"overrides":{"invalid":"DE"}}`)
+	assert.Equal(http.StatusBadRequest, status, body)
+
+	status, body = performRequest(adminUrl, http.MethodPut, `{"overrides":{"10.0.0.0/8":"DE","10.1.0.0/16":"it"}}`)
+	require.Equal(http.StatusOK, status, body)
+	var overrides BackendServerGeoIPOverrides
+	require.NoError(json.Unmarshal([]byte(body), &overrides))
+	assert.Equal(map[string]string{
+		"10.0.0.0/8":  "DE",
+		"10.1.0.0/16": "IT",
+	}, overrides.Overrides)
+
+	assert.Equal("DE", hub.OnLookupCountry(&Client{addr: "10.2.3.4"}))
+	assert.Equal("IT", hub.OnLookupCountry(&Client{addr: "10.1.2.3"}))
+
+	status, body = performRequest(server.URL, http.MethodGet, "")
+	require.Equal(http.StatusOK, status, body)
+	var overrides2 BackendServerGeoIPOverrides
+	require.NoError(json.Unmarshal([]byte(body), &overrides2))
+	assert.Equal(overrides, overrides2)
+}
@@ -115,10 +115,13 @@ var (
 	privateIpNets = []string{
 		// Loopback addresses.
 		"127.0.0.0/8",
+		"::1/128",
 		// Private addresses.
 		"10.0.0.0/8",
 		"172.16.0.0/12",
 		"192.168.0.0/16",
+		// Unique local addresses.
+		"fc00::/7",
 	}
 )
 
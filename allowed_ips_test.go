@@ -65,3 +65,35 @@ func TestAllowedIps(t *testing.T) {
 		})
 	}
 }
+
+func TestDefaultPrivateIps(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	a := DefaultPrivateIps()
+
+	allowed := []string{
+		"127.0.0.1",
+		"10.1.2.3",
+		"172.16.1.2",
+		"192.168.1.2",
+		"::ffff:192.168.1.2",
+		"::1",
+		"fd00::1",
+		"fc12:3456::1",
+	}
+	notAllowed := []string{
+		"1.2.3.4",
+		"172.32.1.2",
+		"::ffff:1.2.3.4",
+		"::2",
+		"2001:db8::1",
+		"fe80::1",
+	}
+
+	for _, addr := range allowed {
+		assert.True(a.Allowed(net.ParseIP(addr)), "should allow %s", addr)
+	}
+	for _, addr := range notAllowed {
+		assert.False(a.Allowed(net.ParseIP(addr)), "should not allow %s", addr)
+	}
+}
@@ -0,0 +1,141 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/dlintw/goconf"
+)
+
+// AllowedOrigins checks the "Origin" header of websocket upgrade requests
+// against a list of patterns.
+type AllowedOrigins struct {
+	patterns    []string
+	browserOnly bool
+}
+
+// ParseAllowedOrigins parses a comma-separated list of origin patterns. A
+// pattern can either be a full origin ("https://cloud.domain.invalid") or a
+// host ("cloud.domain.invalid") and may contain shell wildcards as supported
+// by path.Match (e.g. "https://*.domain.invalid").
+func ParseAllowedOrigins(s string) (*AllowedOrigins, error) {
+	var patterns []string
+	for pattern := range SplitEntries(s, ",") {
+		pattern = strings.ToLower(pattern)
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid origin pattern %s: %w", pattern, err)
+		}
+
+		patterns = append(patterns, strings.TrimSuffix(pattern, "/"))
+	}
+
+	return &AllowedOrigins{
+		patterns: patterns,
+	}, nil
+}
+
+// LoadAllowedOrigins returns the allowed origins from the configuration or
+// nil if all origins are allowed.
+func LoadAllowedOrigins(config *goconf.ConfigFile) (*AllowedOrigins, error) {
+	value, _ := config.GetString("app", "allowedorigins")
+	if value == "" {
+		return nil, nil
+	}
+
+	result, err := ParseAllowedOrigins(value)
+	if err != nil {
+		return nil, err
+	} else if result.Empty() {
+		return nil, nil
+	}
+
+	result.browserOnly, _ = config.GetBool("app", "allowedoriginsbrowseronly")
+	return result, nil
+}
+
+func (o *AllowedOrigins) Empty() bool {
+	return o == nil || len(o.patterns) == 0
+}
+
+func (o *AllowedOrigins) String() string {
+	if o.Empty() {
+		return "any"
+	}
+
+	result := strings.Join(o.patterns, ", ")
+	if o.browserOnly {
+		result += " (browsers only)"
+	}
+	return result
+}
+
+func isBrowserUserAgent(agent string) bool {
+	// All major browsers send a user agent that starts with "Mozilla/".
+	return strings.HasPrefix(agent, "Mozilla/")
+}
+
+// IsAllowed checks if a request with the given origin and user agent may
+// connect.
+func (o *AllowedOrigins) IsAllowed(origin string, agent string) bool {
+	if o.Empty() {
+		return true
+	}
+
+	if o.browserOnly && !isBrowserUserAgent(agent) {
+		return true
+	}
+
+	if origin == "" {
+		return false
+	}
+
+	u, err := url.Parse(strings.ToLower(origin))
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return false
+	}
+
+	if strings.Contains(u.Host, ":") && hasStandardPort(u) {
+		u.Host = u.Hostname()
+	}
+	normalized := u.Scheme + "://" + u.Host
+
+	for _, pattern := range o.patterns {
+		if pattern == "*" {
+			return true
+		}
+
+		value := normalized
+		if !strings.Contains(pattern, "://") {
+			value = u.Host
+		}
+
+		if matched, _ := path.Match(pattern, value); matched {
+			return true
+		}
+	}
+
+	return false
+}
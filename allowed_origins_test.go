@@ -0,0 +1,115 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"testing"
+
+	"github.com/dlintw/goconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testBrowserUserAgent = "Mozilla/5.0 (X11; Linux x86_64; rv:140.0) Gecko/20100101 Firefox/140.0"
+	testAppUserAgent     = "Nextcloud-Talk v21.0.0"
+)
+
+func TestAllowedOrigins(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+	o, err := ParseAllowedOrigins("https://cloud.domain.invalid/, https://*.other.invalid, Third.invalid:8443")
+	require.NoError(err)
+	require.False(o.Empty())
+	assert.Equal("https://cloud.domain.invalid, https://*.other.invalid, third.invalid:8443", o.String())
+
+	allowed := []string{
+		"https://cloud.domain.invalid",
+		"https://CLOUD.domain.invalid:443",
+		"https://one.other.invalid",
+		"https://third.invalid:8443",
+		"http://third.invalid:8443",
+	}
+	notAllowed := []string{
+		"",
+		"null",
+		"http://cloud.domain.invalid",
+		"https://cloud.domain.invalid:8443",
+		"https://other.invalid",
+		"https://one.two.other.invalid.evil",
+		"https://third.invalid",
+	}
+
+	for _, origin := range allowed {
+		assert.True(o.IsAllowed(origin, testBrowserUserAgent), "should allow %s", origin)
+		assert.True(o.IsAllowed(origin, testAppUserAgent), "should allow %s", origin)
+	}
+	for _, origin := range notAllowed {
+		assert.False(o.IsAllowed(origin, testBrowserUserAgent), "should not allow %s", origin)
+		assert.False(o.IsAllowed(origin, testAppUserAgent), "should not allow %s", origin)
+	}
+
+	_, err = ParseAllowedOrigins("https://[invalid")
+	assert.Error(err)
+}
+
+func TestAllowedOrigins_Any(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var empty *AllowedOrigins
+	assert.True(empty.Empty())
+	assert.Equal("any", empty.String())
+	assert.True(empty.IsAllowed("", testBrowserUserAgent))
+	assert.True(empty.IsAllowed("https://cloud.domain.invalid", testBrowserUserAgent))
+
+	o, err := ParseAllowedOrigins("*")
+	require.NoError(err)
+	assert.True(o.IsAllowed("https://cloud.domain.invalid", testBrowserUserAgent))
+	assert.False(o.IsAllowed("", testBrowserUserAgent))
+}
+
+func TestAllowedOrigins_BrowserOnly(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+
+	config := goconf.NewConfigFile()
+	o, err := LoadAllowedOrigins(config)
+	require.NoError(err)
+	assert.Nil(o)
+
+	config.AddOption("app", "allowedorigins", "https://cloud.domain.invalid")
+	config.AddOption("app", "allowedoriginsbrowseronly", "true")
+	o, err = LoadAllowedOrigins(config)
+	require.NoError(err)
+	require.NotNil(o)
+	assert.Equal("https://cloud.domain.invalid (browsers only)", o.String())
+
+	assert.True(o.IsAllowed("https://cloud.domain.invalid", testBrowserUserAgent))
+	assert.False(o.IsAllowed("https://other.invalid", testBrowserUserAgent))
+	assert.False(o.IsAllowed("", testBrowserUserAgent))
+	assert.True(o.IsAllowed("https://other.invalid", testAppUserAgent))
+	assert.True(o.IsAllowed("", testAppUserAgent))
+}
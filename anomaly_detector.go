@@ -0,0 +1,264 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dlintw/goconf"
+)
+
+type AnomalyType string
+
+const (
+	// A session joins rooms in rapid succession.
+	AnomalyRoomCycling AnomalyType = "room_cycling"
+	// A session sends messages to many different recipients.
+	AnomalyMessageFlood AnomalyType = "message_flood"
+	// A session repeatedly uses session ids that can't be decoded.
+	AnomalyInvalidSessionIds AnomalyType = "invalid_session_ids"
+)
+
+func init() {
+	RegisterAnomalyDetectorStats()
+}
+
+type anomalyRule struct {
+	// Number of events (or distinct keys) in the window to trigger the rule.
+	limit  int
+	window time.Duration
+	// Count distinct keys instead of events.
+	distinct bool
+}
+
+func (r anomalyRule) String() string {
+	return fmt.Sprintf("%d in %s", r.limit, r.window)
+}
+
+var (
+	anomalyRuleDefaults = map[AnomalyType]anomalyRule{
+		AnomalyRoomCycling: {
+			window: time.Minute,
+		},
+		AnomalyMessageFlood: {
+			window:   10 * time.Second,
+			distinct: true,
+		},
+		AnomalyInvalidSessionIds: {
+			window: time.Minute,
+		},
+	}
+
+	anomalyConfigKeys = map[AnomalyType]string{
+		AnomalyRoomCycling:       "roomcycling",
+		AnomalyMessageFlood:      "messageflood",
+		AnomalyInvalidSessionIds: "invalidsessionids",
+	}
+)
+
+// AnomalyListener will be notified if suspicious behaviour of a session has
+// been detected.
+type AnomalyListener interface {
+	OnAnomalyDetected(session Session, anomaly AnomalyType, count int)
+}
+
+type anomalyEvent struct {
+	when time.Time
+	key  string
+}
+
+type anomalySessionState struct {
+	events         map[AnomalyType][]anomalyEvent
+	throttledUntil time.Time
+}
+
+// AnomalyDetector keeps track of events of sessions and flags sessions that
+// exceed the configured limits.
+type AnomalyDetector struct {
+	mu sync.Mutex
+
+	rules    map[AnomalyType]anomalyRule
+	throttle time.Duration
+
+	sessions  map[PublicSessionId]*anomalySessionState
+	listeners []AnomalyListener
+}
+
+func NewAnomalyDetector(config *goconf.ConfigFile) *AnomalyDetector {
+	result := &AnomalyDetector{
+		sessions: make(map[PublicSessionId]*anomalySessionState),
+	}
+	result.load(config)
+	return result
+}
+
+func (d *AnomalyDetector) load(config *goconf.ConfigFile) {
+	rules := make(map[AnomalyType]anomalyRule)
+	for anomaly, rule := range anomalyRuleDefaults {
+		key := anomalyConfigKeys[anomaly]
+		if limit, _ := config.GetInt("anomaly", key); limit > 0 {
+			rule.limit = limit
+		} else {
+			continue
+		}
+
+		if windowSeconds, _ := config.GetInt("anomaly", key+"window"); windowSeconds > 0 {
+			rule.window = time.Duration(windowSeconds) * time.Second
+		}
+		rules[anomaly] = rule
+	}
+
+	var throttle time.Duration
+	if throttleSeconds, _ := config.GetInt("anomaly", "throttle"); throttleSeconds > 0 {
+		throttle = time.Duration(throttleSeconds) * time.Second
+	}
+
+	if len(rules) > 0 {
+		var enabled []string
+		for _, anomaly := range slices.Sorted(maps.Keys(rules)) {
+			enabled = append(enabled, fmt.Sprintf("%s (%s)", anomaly, rules[anomaly]))
+		}
+		hubLog.Infof("Detecting anomalies: %s", strings.Join(enabled, ", "))
+		if throttle > 0 {
+			hubLog.Infof("Throttling sessions with anomalies for %s", throttle)
+		}
+	} else {
+		hubLog.Infof("Anomaly detection is disabled")
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.rules = rules
+	d.throttle = throttle
+	if len(rules) == 0 {
+		clear(d.sessions)
+	}
+}
+
+func (d *AnomalyDetector) Reload(config *goconf.ConfigFile) {
+	d.load(config)
+}
+
+func (d *AnomalyDetector) AddListener(listener AnomalyListener) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.listeners = append(d.listeners, listener)
+}
+
+func (d *AnomalyDetector) RemoveListener(listener AnomalyListener) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.listeners = slices.DeleteFunc(d.listeners, func(l AnomalyListener) bool {
+		return l == listener
+	})
+}
+
+// Record stores an event of the given type for the session. The key is used
+// for rules that count distinct values (e.g. recipients of messages).
+func (d *AnomalyDetector) Record(session Session, anomaly AnomalyType, key string) {
+	d.record(session, anomaly, key, time.Now())
+}
+
+func (d *AnomalyDetector) record(session Session, anomaly AnomalyType, key string, now time.Time) {
+	d.mu.Lock()
+	rule, found := d.rules[anomaly]
+	if !found {
+		d.mu.Unlock()
+		return
+	}
+
+	id := session.PublicId()
+	state, found := d.sessions[id]
+	if !found {
+		state = &anomalySessionState{
+			events: make(map[AnomalyType][]anomalyEvent),
+		}
+		d.sessions[id] = state
+	}
+
+	minTime := now.Add(-rule.window)
+	events := slices.DeleteFunc(state.events[anomaly], func(e anomalyEvent) bool {
+		return !e.when.After(minTime)
+	})
+	events = append(events, anomalyEvent{
+		when: now,
+		key:  key,
+	})
+
+	count := len(events)
+	if rule.distinct {
+		keys := make(map[string]bool)
+		for _, e := range events {
+			keys[e.key] = true
+		}
+		count = len(keys)
+	}
+
+	if count < rule.limit {
+		state.events[anomaly] = events
+		d.mu.Unlock()
+		return
+	}
+
+	// Start counting again so the anomaly is only reported once per window.
+	delete(state.events, anomaly)
+	if d.throttle > 0 {
+		state.throttledUntil = now.Add(d.throttle)
+	}
+	listeners := slices.Clone(d.listeners)
+	d.mu.Unlock()
+
+	statsAnomaliesDetectedTotal.WithLabelValues(statsBackendLabel(session.Backend()), string(anomaly)).Inc()
+	hubLog.Eventf(RecentEventTypeAnomaly, slog.LevelWarn, "Detected anomaly %s for session %s (%d in %s)", anomaly, id, count, rule.window)
+	for _, listener := range listeners {
+		listener.OnAnomalyDetected(session, anomaly, count)
+	}
+}
+
+// IsThrottled returns true if the session should be throttled because of a
+// previously detected anomaly.
+func (d *AnomalyDetector) IsThrottled(session Session) bool {
+	return d.isThrottled(session, time.Now())
+}
+
+func (d *AnomalyDetector) isThrottled(session Session, now time.Time) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	state, found := d.sessions[session.PublicId()]
+	return found && now.Before(state.throttledUntil)
+}
+
+func (d *AnomalyDetector) RemoveSession(session Session) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.sessions, session.PublicId())
+}
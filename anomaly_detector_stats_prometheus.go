@@ -0,0 +1,50 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	statsAnomaliesDetectedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "signaling",
+		Subsystem: "hub",
+		Name:      "anomalies_detected_total",
+		Help:      "The total number of detected anomalies of sessions",
+	}, []string{"backend", "type"})
+	statsAnomaliesThrottledTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "signaling",
+		Subsystem: "hub",
+		Name:      "anomalies_throttled_total",
+		Help:      "The total number of messages dropped from throttled sessions",
+	}, []string{"backend"})
+
+	anomalyDetectorStats = []prometheus.Collector{
+		statsAnomaliesDetectedTotal,
+		statsAnomaliesThrottledTotal,
+	}
+)
+
+func RegisterAnomalyDetectorStats() {
+	registerAll(anomalyDetectorStats...)
+}
@@ -0,0 +1,189 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dlintw/goconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testAnomalyListener struct {
+	mu        sync.Mutex
+	anomalies []AnomalyType
+}
+
+func (l *testAnomalyListener) OnAnomalyDetected(session Session, anomaly AnomalyType, count int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.anomalies = append(l.anomalies, anomaly)
+}
+
+func (l *testAnomalyListener) Anomalies() []AnomalyType {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.anomalies
+}
+
+func TestAnomalyDetector_Disabled(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	detector := NewAnomalyDetector(goconf.NewConfigFile())
+	listener := &testAnomalyListener{}
+	detector.AddListener(listener)
+
+	session := &DummySession{
+		publicId: "session1",
+	}
+	for range 100 {
+		detector.Record(session, AnomalyRoomCycling, "room")
+	}
+	assert.Empty(listener.Anomalies())
+	assert.False(detector.IsThrottled(session))
+}
+
+func TestAnomalyDetector(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	config := goconf.NewConfigFile()
+	config.AddOption("anomaly", "roomcycling", "3")
+	config.AddOption("anomaly", "roomcyclingwindow", "10")
+	config.AddOption("anomaly", "messageflood", "2")
+	detector := NewAnomalyDetector(config)
+	listener := &testAnomalyListener{}
+	detector.AddListener(listener)
+
+	session := &DummySession{
+		publicId: "session1",
+	}
+	now := time.Now()
+	detector.record(session, AnomalyRoomCycling, "room1", now)
+	detector.record(session, AnomalyRoomCycling, "room2", now.Add(time.Second))
+	assert.Empty(listener.Anomalies())
+	// Events outside of the window are ignored.
+	detector.record(session, AnomalyRoomCycling, "room1", now.Add(15*time.Second))
+	assert.Empty(listener.Anomalies())
+	detector.record(session, AnomalyRoomCycling, "room2", now.Add(16*time.Second))
+	detector.record(session, AnomalyRoomCycling, "room1", now.Add(17*time.Second))
+	assert.Equal([]AnomalyType{AnomalyRoomCycling}, listener.Anomalies())
+	// Throttling is disabled by default.
+	assert.False(detector.isThrottled(session, now.Add(17*time.Second)))
+
+	// Rules with distinct keys only count different values.
+	for range 10 {
+		detector.record(session, AnomalyMessageFlood, "session:one", now)
+	}
+	assert.Equal([]AnomalyType{AnomalyRoomCycling}, listener.Anomalies())
+	detector.record(session, AnomalyMessageFlood, "session:two", now)
+	assert.Equal([]AnomalyType{AnomalyRoomCycling, AnomalyMessageFlood}, listener.Anomalies())
+
+	// Rules that are not configured are ignored.
+	for range 10 {
+		detector.record(session, AnomalyInvalidSessionIds, "invalid", now)
+	}
+	assert.Equal([]AnomalyType{AnomalyRoomCycling, AnomalyMessageFlood}, listener.Anomalies())
+
+	detector.RemoveListener(listener)
+	detector.record(session, AnomalyMessageFlood, "session:three", now)
+	detector.record(session, AnomalyMessageFlood, "session:four", now)
+	assert.Equal([]AnomalyType{AnomalyRoomCycling, AnomalyMessageFlood}, listener.Anomalies())
+}
+
+func TestAnomalyDetector_Throttle(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	config := goconf.NewConfigFile()
+	config.AddOption("anomaly", "invalidsessionids", "2")
+	config.AddOption("anomaly", "throttle", "30")
+	detector := NewAnomalyDetector(config)
+
+	session1 := &DummySession{
+		publicId: "session1",
+	}
+	session2 := &DummySession{
+		publicId: "session2",
+	}
+	now := time.Now()
+	detector.record(session1, AnomalyInvalidSessionIds, "foo", now)
+	detector.record(session2, AnomalyInvalidSessionIds, "foo", now)
+	assert.False(detector.isThrottled(session1, now))
+	detector.record(session1, AnomalyInvalidSessionIds, "bar", now)
+	assert.True(detector.isThrottled(session1, now))
+	assert.True(detector.isThrottled(session1, now.Add(29*time.Second)))
+	assert.False(detector.isThrottled(session1, now.Add(30*time.Second)))
+	assert.False(detector.isThrottled(session2, now))
+
+	detector.record(session1, AnomalyInvalidSessionIds, "foo", now)
+	detector.record(session1, AnomalyInvalidSessionIds, "bar", now)
+	assert.True(detector.isThrottled(session1, now))
+	detector.RemoveSession(session1)
+	assert.False(detector.isThrottled(session1, now))
+}
+
+func TestHubAnomalyThrottle(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	hub, _, _, server := CreateHubForTestWithConfig(t, func(server *httptest.Server) (*goconf.ConfigFile, error) {
+		config, err := getTestConfig(server)
+		if err != nil {
+			return nil, err
+		}
+
+		config.AddOption("anomaly", "roomcycling", "2")
+		config.AddOption("anomaly", "throttle", "60")
+		return config, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client, hello := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId)
+
+	roomMsg := MustSucceed2(t, client.JoinRoom, ctx, "room1")
+	require.Equal("room1", roomMsg.Room.RoomId)
+	if msg, ok := client.RunUntilMessage(ctx); ok {
+		client.checkMessageJoined(msg, hello.Hello)
+	}
+
+	// The second join triggers the anomaly, following messages are rejected.
+	roomMsg = MustSucceed2(t, client.JoinRoom, ctx, "room2")
+	require.Equal("room2", roomMsg.Room.RoomId)
+	if msg, ok := client.RunUntilMessage(ctx); ok {
+		client.checkMessageJoined(msg, hello.Hello)
+	}
+
+	require.NoError(client.SendMessage(MessageClientMessageRecipient{
+		Type:      "session",
+		SessionId: hello.Hello.SessionId,
+	}, "hello"))
+	client.RunUntilError(ctx, TooManyRequests.Code) // nolint
+}
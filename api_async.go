@@ -29,6 +29,8 @@ import (
 
 type AsyncMessage struct {
 	SendTime time.Time `json:"sendtime"`
+	// Id of the server that sent the message.
+	SendServerId string `json:"sendserverid,omitempty"`
 
 	Type string `json:"type"`
 
@@ -42,7 +44,36 @@ type AsyncMessage struct {
 
 	SendOffer *SendOfferMessage `json:"sendoffer,omitempty"`
 
+	Receipt *AsyncMessageReceipt `json:"receipt,omitempty"`
+
 	Id string `json:"id"`
+
+	// Trace of the sender, so the receivers can continue it.
+	TraceContext map[string]string `json:"tracecontext,omitempty"`
+
+	// Serialized server message that is shared by all local recipients.
+	prepared *PreparedServerMessage
+}
+
+// prepare makes sure the server message is only serialized once if it is sent
+// to multiple local recipients.
+func (m *AsyncMessage) prepare() {
+	if m.Type == "message" && m.Message != nil && m.prepared == nil {
+		m.prepared = NewPreparedServerMessage(m.Message)
+	}
+}
+
+// AsyncMessageReceipt requests a delivery receipt for a message that is sent to
+// a single session.
+type AsyncMessageReceipt struct {
+	// Id of the message from the sender.
+	MessageId string `json:"messageid"`
+	// Type of the message ("message" or "control").
+	Type string `json:"type"`
+	// Session id of the sender.
+	SessionId PublicSessionId `json:"sessionid"`
+	// Recipient of the message as sent by the sender.
+	Recipient MessageClientMessageRecipient `json:"recipient"`
 }
 
 func (m *AsyncMessage) String() string {
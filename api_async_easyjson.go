@@ -195,7 +195,94 @@ func (v *AsyncRoomMessage) UnmarshalJSON(data []byte) error {
 func (v *AsyncRoomMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson9289e183DecodeGithubComStrukturagNextcloudSpreedSignaling1(l, v)
 }
-func easyjson9289e183DecodeGithubComStrukturagNextcloudSpreedSignaling2(in *jlexer.Lexer, out *AsyncMessage) {
+func easyjson9289e183DecodeGithubComStrukturagNextcloudSpreedSignaling2(in *jlexer.Lexer, out *AsyncMessageReceipt) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "messageid":
+			out.MessageId = string(in.String())
+		case "type":
+			out.Type = string(in.String())
+		case "sessionid":
+			out.SessionId = PublicSessionId(in.String())
+		case "recipient":
+			(out.Recipient).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9289e183EncodeGithubComStrukturagNextcloudSpreedSignaling2(out *jwriter.Writer, in AsyncMessageReceipt) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"messageid\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.MessageId))
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"sessionid\":"
+		out.RawString(prefix)
+		out.String(string(in.SessionId))
+	}
+	{
+		const prefix string = ",\"recipient\":"
+		out.RawString(prefix)
+		(in.Recipient).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AsyncMessageReceipt) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9289e183EncodeGithubComStrukturagNextcloudSpreedSignaling2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AsyncMessageReceipt) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9289e183EncodeGithubComStrukturagNextcloudSpreedSignaling2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AsyncMessageReceipt) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9289e183DecodeGithubComStrukturagNextcloudSpreedSignaling2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AsyncMessageReceipt) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9289e183DecodeGithubComStrukturagNextcloudSpreedSignaling2(l, v)
+}
+func easyjson9289e183DecodeGithubComStrukturagNextcloudSpreedSignaling3(in *jlexer.Lexer, out *AsyncMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -218,6 +305,8 @@ func easyjson9289e183DecodeGithubComStrukturagNextcloudSpreedSignaling2(in *jlex
 			if data := in.Raw(); in.Ok() {
 				in.AddError((out.SendTime).UnmarshalJSON(data))
 			}
+		case "sendserverid":
+			out.SendServerId = string(in.String())
 		case "type":
 			out.Type = string(in.String())
 		case "message":
@@ -283,8 +372,38 @@ func easyjson9289e183DecodeGithubComStrukturagNextcloudSpreedSignaling2(in *jlex
 				}
 				(*out.SendOffer).UnmarshalEasyJSON(in)
 			}
+		case "receipt":
+			if in.IsNull() {
+				in.Skip()
+				out.Receipt = nil
+			} else {
+				if out.Receipt == nil {
+					out.Receipt = new(AsyncMessageReceipt)
+				}
+				(*out.Receipt).UnmarshalEasyJSON(in)
+			}
 		case "id":
 			out.Id = string(in.String())
+		case "tracecontext":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.TraceContext = make(map[string]string)
+				} else {
+					out.TraceContext = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v2 string
+					v2 = string(in.String())
+					(out.TraceContext)[key] = v2
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -295,7 +414,7 @@ func easyjson9289e183DecodeGithubComStrukturagNextcloudSpreedSignaling2(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson9289e183EncodeGithubComStrukturagNextcloudSpreedSignaling2(out *jwriter.Writer, in AsyncMessage) {
+func easyjson9289e183EncodeGithubComStrukturagNextcloudSpreedSignaling3(out *jwriter.Writer, in AsyncMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -304,6 +423,11 @@ func easyjson9289e183EncodeGithubComStrukturagNextcloudSpreedSignaling2(out *jwr
 		out.RawString(prefix[1:])
 		out.Raw((in.SendTime).MarshalJSON())
 	}
+	if in.SendServerId != "" {
+		const prefix string = ",\"sendserverid\":"
+		out.RawString(prefix)
+		out.String(string(in.SendServerId))
+	}
 	{
 		const prefix string = ",\"type\":"
 		out.RawString(prefix)
@@ -324,11 +448,11 @@ func easyjson9289e183EncodeGithubComStrukturagNextcloudSpreedSignaling2(out *jwr
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v2, v3 := range in.Permissions {
-				if v2 > 0 {
+			for v3, v4 := range in.Permissions {
+				if v3 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v3))
+				out.String(string(v4))
 			}
 			out.RawByte(']')
 		}
@@ -343,34 +467,58 @@ func easyjson9289e183EncodeGithubComStrukturagNextcloudSpreedSignaling2(out *jwr
 		out.RawString(prefix)
 		(*in.SendOffer).MarshalEasyJSON(out)
 	}
+	if in.Receipt != nil {
+		const prefix string = ",\"receipt\":"
+		out.RawString(prefix)
+		(*in.Receipt).MarshalEasyJSON(out)
+	}
 	{
 		const prefix string = ",\"id\":"
 		out.RawString(prefix)
 		out.String(string(in.Id))
 	}
+	if len(in.TraceContext) != 0 {
+		const prefix string = ",\"tracecontext\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('{')
+			v5First := true
+			for v5Name, v5Value := range in.TraceContext {
+				if v5First {
+					v5First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v5Name))
+				out.RawByte(':')
+				out.String(string(v5Value))
+			}
+			out.RawByte('}')
+		}
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v AsyncMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson9289e183EncodeGithubComStrukturagNextcloudSpreedSignaling2(&w, v)
+	easyjson9289e183EncodeGithubComStrukturagNextcloudSpreedSignaling3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v AsyncMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson9289e183EncodeGithubComStrukturagNextcloudSpreedSignaling2(w, v)
+	easyjson9289e183EncodeGithubComStrukturagNextcloudSpreedSignaling3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *AsyncMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson9289e183DecodeGithubComStrukturagNextcloudSpreedSignaling2(&r, v)
+	easyjson9289e183DecodeGithubComStrukturagNextcloudSpreedSignaling3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *AsyncMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson9289e183DecodeGithubComStrukturagNextcloudSpreedSignaling2(l, v)
+	easyjson9289e183DecodeGithubComStrukturagNextcloudSpreedSignaling3(l, v)
 }
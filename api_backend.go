@@ -43,6 +43,9 @@ const (
 	HeaderBackendSignalingRandom   = "Spreed-Signaling-Random"
 	HeaderBackendSignalingChecksum = "Spreed-Signaling-Checksum"
 	HeaderBackendServer            = "Spreed-Signaling-Backend"
+	// Optional header containing the unix timestamp of the request. If present,
+	// the timestamp is prefixed to the random value when calculating the checksum.
+	HeaderBackendSignalingTimestamp = "Spreed-Signaling-Timestamp"
 
 	ConfigGroupSignaling = "signaling"
 
@@ -73,8 +76,19 @@ func AddBackendChecksum(r *http.Request, body []byte, secret []byte) {
 	r.Header.Set(HeaderBackendSignalingChecksum, checksum)
 }
 
+// GetBackendChecksumRandom returns the value that is signed together with the
+// body of a request. The timestamp is separated from the random string, so the
+// values can't be moved between the headers without invalidating the checksum.
+func GetBackendChecksumRandom(timestamp string, random string) string {
+	if timestamp == "" {
+		return random
+	}
+
+	return timestamp + ":" + random
+}
+
 func ValidateBackendChecksum(r *http.Request, body []byte, secret []byte) bool {
-	rnd := r.Header.Get(HeaderBackendSignalingRandom)
+	rnd := GetBackendChecksumRandom(r.Header.Get(HeaderBackendSignalingTimestamp), r.Header.Get(HeaderBackendSignalingRandom))
 	checksum := r.Header.Get(HeaderBackendSignalingChecksum)
 	return ValidateBackendChecksumValue(checksum, rnd, body, secret)
 }
@@ -111,6 +125,10 @@ type BackendServerRoomRequest struct {
 
 	Transient *BackendRoomTransientRequest `json:"transient,omitempty"`
 
+	Recording *BackendRoomRecordingRequest `json:"recording,omitempty"`
+
+	Breakout *BackendRoomBreakoutRequest `json:"breakout,omitempty"`
+
 	// Internal properties
 	ReceivedTime int64 `json:"received,omitempty"`
 }
@@ -130,6 +148,9 @@ type BackendRoomDisinviteRequest struct {
 	// notify existing users the room has changed and they need to update it.
 	AllUserIds []string        `json:"alluserids,omitempty"`
 	Properties json.RawMessage `json:"properties,omitempty"`
+	// Reason is sent in a "bye" message to the sessions in "sessionids" which
+	// will be closed. Supported values are "kicked" and "banned".
+	Reason string `json:"reason,omitempty"`
 }
 
 type BackendRoomUpdateRequest struct {
@@ -180,6 +201,48 @@ type BackendRoomSwitchToMessageRequest struct {
 	SessionsMap  BackendRoomSwitchToPublicSessionsMap  `json:"sessionsmap,omitempty"`
 }
 
+const (
+	BreakoutActionStart = "start"
+	BreakoutActionStop  = "stop"
+)
+
+type BackendRoomBreakoutRequest struct {
+	// One of "start" or "stop".
+	Action string `json:"action"`
+
+	// Rooms maps the ids of the breakout rooms to the (Nextcloud) session ids
+	// that should switch to them. Required when starting breakout rooms, if
+	// omitted when stopping, the breakout rooms that were started before will
+	// be used.
+	Rooms map[string]BackendRoomSwitchToSessionsList `json:"rooms,omitempty"`
+
+	// Internal properties
+	ParentRoomId string                                           `json:"parentroomid,omitempty"`
+	RoomIds      []string                                         `json:"roomids,omitempty"`
+	SessionsMap  map[string]BackendRoomSwitchToPublicSessionsList `json:"sessionsmap,omitempty"`
+}
+
+func (r *BackendRoomBreakoutRequest) CheckValid(roomId string) error {
+	switch r.Action {
+	case BreakoutActionStart:
+		if len(r.Rooms) == 0 {
+			return fmt.Errorf("breakout rooms missing")
+		}
+	case BreakoutActionStop:
+	default:
+		return fmt.Errorf("unsupported breakout action: %s", r.Action)
+	}
+
+	for id := range r.Rooms {
+		if id == "" {
+			return fmt.Errorf("empty breakout room id")
+		} else if id == roomId {
+			return fmt.Errorf("breakout room can't be the parent room")
+		}
+	}
+	return nil
+}
+
 type BackendRoomDialoutRequest struct {
 	// E.164 number to dial (e.g. "+1234567890")
 	Number string `json:"number"`
@@ -188,18 +251,34 @@ type BackendRoomDialoutRequest struct {
 }
 
 var (
-	checkE164Number = regexp.MustCompile(`^\+\d{2,}$`)
+	checkE164Number = regexp.MustCompile(`^\+[1-9]\d{1,14}$`)
+
+	// Characters that are commonly used to format phone numbers.
+	numberFormatReplacer = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "", "/", "")
 )
 
 func isValidNumber(s string) bool {
 	return checkE164Number.MatchString(s)
 }
 
+// NormalizeNumber removes formatting characters from a phone number and
+// replaces the international call prefix "00" with "+".
+func NormalizeNumber(s string) string {
+	s = numberFormatReplacer.Replace(strings.TrimSpace(s))
+	if rest, found := strings.CutPrefix(s, "00"); found {
+		s = "+" + rest
+	}
+	return s
+}
+
+// ValidateNumber normalizes the number of the request and checks that it is a
+// valid E.164 number.
 func (r *BackendRoomDialoutRequest) ValidateNumber() *Error {
 	if r.Number == "" {
 		return NewError("number_missing", "No number provided")
 	}
 
+	r.Number = NormalizeNumber(r.Number)
 	if !isValidNumber(r.Number) {
 		return NewError("invalid_number", "Expected E.164 number.")
 	}
@@ -215,6 +294,14 @@ func (r *BackendRoomDialoutRequest) String() string {
 	return string(data)
 }
 
+type BackendRoomRecordingRequest struct {
+	// One of "start", "stop", "pause" or "resume".
+	Action string `json:"action"`
+
+	// Options are passed to the recording client.
+	Options json.RawMessage `json:"options,omitempty"`
+}
+
 type TransientAction string
 
 const (
@@ -233,6 +320,8 @@ type BackendServerRoomResponse struct {
 	Type string `json:"type"`
 
 	Dialout *BackendRoomDialoutResponse `json:"dialout,omitempty"`
+
+	Recording *BackendRoomRecordingResponse `json:"recording,omitempty"`
 }
 
 type BackendRoomDialoutError struct {
@@ -246,6 +335,12 @@ type BackendRoomDialoutResponse struct {
 	Error *Error `json:"error,omitempty"`
 }
 
+type BackendRoomRecordingResponse struct {
+	Status RecordingStatus `json:"status,omitempty"`
+
+	Error *Error `json:"error,omitempty"`
+}
+
 // Requests from the signaling server to the Nextcloud backend.
 
 type BackendClientAuthRequest struct {
@@ -266,6 +361,8 @@ type BackendClientRequest struct {
 	Ping *BackendClientPingRequest `json:"ping,omitempty"`
 
 	Session *BackendClientSessionRequest `json:"session,omitempty"`
+
+	Dialout *BackendClientDialoutRequest `json:"dialout,omitempty"`
 }
 
 func NewBackendClientAuthRequest(params json.RawMessage) *BackendClientRequest {
@@ -293,6 +390,8 @@ type BackendClientResponse struct {
 	Ping *BackendClientRingResponse `json:"ping,omitempty"`
 
 	Session *BackendClientSessionResponse `json:"session,omitempty"`
+
+	Dialout *BackendClientDialoutResponse `json:"dialout,omitempty"`
 }
 
 type BackendClientAuthResponse struct {
@@ -415,6 +514,30 @@ func NewBackendClientSessionRequest(roomid string, action string, sessionid Publ
 	return request
 }
 
+type BackendClientDialoutRequest struct {
+	Version string `json:"version"`
+	RoomId  string `json:"roomid"`
+
+	DialoutStatusInternalClientMessage
+}
+
+type BackendClientDialoutResponse struct {
+	Version string `json:"version"`
+	RoomId  string `json:"roomid"`
+}
+
+func NewBackendClientDialoutRequest(roomid string, status *DialoutStatusInternalClientMessage) *BackendClientRequest {
+	return &BackendClientRequest{
+		Type: "dialout",
+		Dialout: &BackendClientDialoutRequest{
+			Version: BackendVersion,
+			RoomId:  roomid,
+
+			DialoutStatusInternalClientMessage: *status,
+		},
+	}
+}
+
 type OcsMeta struct {
 	Status     string `json:"status"`
 	StatusCode int    `json:"statuscode"`
@@ -455,6 +578,19 @@ type BackendInformationEtcd struct {
 	MaxScreenBitrate int `json:"maxscreenbitrate,omitempty"`
 
 	SessionLimit uint64 `json:"sessionlimit,omitempty"`
+
+	ConcurrentRequests int `json:"concurrentrequests,omitempty"`
+	ConcurrentMessages int `json:"concurrentmessages,omitempty"`
+
+	MessageRate int `json:"messagerate,omitempty"`
+	JoinRate    int `json:"joinrate,omitempty"`
+
+	DisabledFeatures []string `json:"disabledfeatures,omitempty"`
+
+	DialoutAllowedPrefixes []string `json:"dialoutallowedprefixes,omitempty"`
+	DialoutDeniedPatterns  []string `json:"dialoutdeniedpatterns,omitempty"`
+
+	OidcIssuer string `json:"oidcissuer,omitempty"`
 }
 
 func (p *BackendInformationEtcd) CheckValid() (err error) {
@@ -507,6 +643,12 @@ func (p *BackendInformationEtcd) CheckValid() (err error) {
 		return fmt.Errorf("urls missing")
 	}
 
+	if p.OidcIssuer != "" {
+		if p.OidcIssuer, err = ParseOidcIssuer(p.OidcIssuer); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -550,18 +692,26 @@ type BackendServerInfoSfuProxy struct {
 	Bandwidth *EventProxyServerBandwidth `json:"bandwidth,omitempty"`
 }
 
+type BackendServerInfoSfuLivekit struct {
+	Url string `json:"url"`
+
+	Connected bool `json:"connected"`
+}
+
 type SfuMode string
 
 const (
-	SfuModeJanus SfuMode = "janus"
-	SfuModeProxy SfuMode = "proxy"
+	SfuModeJanus   SfuMode = "janus"
+	SfuModeProxy   SfuMode = "proxy"
+	SfuModeLivekit SfuMode = "livekit"
 )
 
 type BackendServerInfoSfu struct {
 	Mode SfuMode `json:"mode"`
 
-	Janus   *BackendServerInfoSfuJanus  `json:"janus,omitempty"`
-	Proxies []BackendServerInfoSfuProxy `json:"proxies,omitempty"`
+	Janus   *BackendServerInfoSfuJanus   `json:"janus,omitempty"`
+	Proxies []BackendServerInfoSfuProxy  `json:"proxies,omitempty"`
+	Livekit *BackendServerInfoSfuLivekit `json:"livekit,omitempty"`
 }
 
 type BackendServerInfoDialout struct {
@@ -571,6 +721,9 @@ type BackendServerInfoDialout struct {
 	UserAgent string          `json:"useragent,omitempty"`
 	Version   string          `json:"version,omitempty"`
 	Features  []string        `json:"features,omitempty"`
+	// Number of active calls and the maximum number of calls (if limited).
+	Calls    int `json:"calls"`
+	MaxCalls int `json:"maxcalls,omitempty"`
 }
 
 type BackendServerInfoNats struct {
@@ -583,6 +736,11 @@ type BackendServerInfoNats struct {
 	ClusterName   string `json:"clustername,omitempty"`
 }
 
+type BackendServerInfoRedis struct {
+	Url       string `json:"url"`
+	Connected bool   `json:"connected"`
+}
+
 type BackendServerInfoGrpc struct {
 	Target    string `json:"target"`
 	IP        string `json:"ip,omitempty"`
@@ -605,7 +763,170 @@ type BackendServerInfo struct {
 	Sfu     *BackendServerInfoSfu      `json:"sfu,omitempty"`
 	Dialout []BackendServerInfoDialout `json:"dialout,omitempty"`
 
-	Nats *BackendServerInfoNats  `json:"nats,omitempty"`
-	Grpc []BackendServerInfoGrpc `json:"grpc,omitempty"`
-	Etcd *BackendServerInfoEtcd  `json:"etcd,omitempty"`
+	Nats  *BackendServerInfoNats  `json:"nats,omitempty"`
+	Redis *BackendServerInfoRedis `json:"redis,omitempty"`
+	Grpc  []BackendServerInfoGrpc `json:"grpc,omitempty"`
+	Etcd  *BackendServerInfoEtcd  `json:"etcd,omitempty"`
+}
+
+type BackendServerConfigBackend struct {
+	Id   string   `json:"id"`
+	Urls []string `json:"urls"`
+
+	AllowHttp          bool     `json:"allowhttp,omitempty"`
+	SessionLimit       uint64   `json:"sessionlimit,omitempty"`
+	ConcurrentRequests int      `json:"concurrentrequests,omitempty"`
+	ConcurrentMessages int      `json:"concurrentmessages,omitempty"`
+	MessageRate        int      `json:"messagerate,omitempty"`
+	JoinRate           int      `json:"joinrate,omitempty"`
+	MaxStreamBitrate   int      `json:"maxstreambitrate,omitempty"`
+	MaxScreenBitrate   int      `json:"maxscreenbitrate,omitempty"`
+	DisabledFeatures   []string `json:"disabledfeatures,omitempty"`
+
+	DialoutAllowedPrefixes []string `json:"dialoutallowedprefixes,omitempty"`
+	DialoutDeniedPatterns  []string `json:"dialoutdeniedpatterns,omitempty"`
+
+	OidcIssuer string `json:"oidcissuer,omitempty"`
+}
+
+type BackendServerConfig struct {
+	Version string    `json:"version"`
+	Loaded  time.Time `json:"loaded"`
+
+	Config   map[string]map[string]string `json:"config"`
+	Backends []BackendServerConfigBackend `json:"backends"`
+}
+
+type HealthStatus string
+
+const (
+	HealthStatusOk       HealthStatus = "ok"
+	HealthStatusDegraded HealthStatus = "degraded"
+	HealthStatusError    HealthStatus = "error"
+	HealthStatusDisabled HealthStatus = "disabled"
+)
+
+type BackendServerHealthComponent struct {
+	Status  HealthStatus `json:"status"`
+	Message string       `json:"message,omitempty"`
+}
+
+type BackendServerHealth struct {
+	Status     HealthStatus                            `json:"status"`
+	Components map[string]BackendServerHealthComponent `json:"components"`
+}
+
+type RecentEventType string
+
+const (
+	RecentEventTypeError      RecentEventType = "error"
+	RecentEventTypeConnection RecentEventType = "connection"
+	RecentEventTypeBan        RecentEventType = "ban"
+	RecentEventTypeAnomaly    RecentEventType = "anomaly"
+	RecentEventTypeBackend    RecentEventType = "backend"
+)
+
+type RecentEvent struct {
+	Time      time.Time       `json:"time"`
+	Type      RecentEventType `json:"type"`
+	Subsystem string          `json:"subsystem"`
+	Message   string          `json:"message"`
+}
+
+type BackendServerEvents struct {
+	Events []RecentEvent `json:"events"`
+}
+
+type BackendServerGeoIPLookup struct {
+	IP      string `json:"ip"`
+	Country string `json:"country"`
+
+	// Only set if the proxy MCU is used.
+	Continents []string                    `json:"continents,omitempty"`
+	Proxies    []BackendServerInfoSfuProxy `json:"proxies,omitempty"`
+}
+
+type BackendServerLoad struct {
+	// Utilization in percent of the capacity minus the headroom. Values above
+	// 100 mean that additional nodes are required.
+	Score    float64 `json:"score"`
+	Headroom int     `json:"headroom"`
+
+	Sessions      int     `json:"sessions"`
+	Rooms         int     `json:"rooms"`
+	MessageRate   float64 `json:"messagerate"`
+	PendingWrites int     `json:"pendingwrites"`
+
+	// Only set if the proxy MCU is used.
+	McuLoad *int64 `json:"mcuload,omitempty"`
+}
+
+type BackendServerSessionInfo struct {
+	SessionId  PublicSessionId `json:"sessionid"`
+	Sid        uint64          `json:"sid"`
+	Created    time.Time       `json:"created"`
+	ClientType ClientType      `json:"clienttype"`
+	UserId     string          `json:"userid,omitempty"`
+	Backend    string          `json:"backend,omitempty"`
+	BackendUrl string          `json:"backendurl,omitempty"`
+
+	RoomId        string        `json:"roomid,omitempty"`
+	RoomSessionId RoomSessionId `json:"roomsessionid,omitempty"`
+	InCall        int           `json:"incall"`
+	Features      []string      `json:"features,omitempty"`
+	Publishers    []StreamType  `json:"publishers,omitempty"`
+
+	// Only set if a client is connected to the session.
+	Connected  bool   `json:"connected"`
+	RemoteAddr string `json:"remoteaddr,omitempty"`
+	Country    string `json:"country,omitempty"`
+	UserAgent  string `json:"useragent,omitempty"`
+
+	// Only set if the session is connected to a different server.
+	Node string `json:"node,omitempty"`
+}
+
+type AdminServerSessions struct {
+	Sessions []*BackendServerSessionInfo `json:"sessions"`
+}
+
+type AdminServerRoom struct {
+	RoomId   string `json:"roomid"`
+	Backend  string `json:"backend"`
+	Sessions int    `json:"sessions"`
+	InCall   int    `json:"incall"`
+}
+
+type AdminServerRooms struct {
+	Rooms []*AdminServerRoom `json:"rooms"`
+}
+
+type BackendServerReloadSection struct {
+	Section string `json:"section"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+}
+
+type BackendServerReloadResponse struct {
+	Success bool `json:"success"`
+	// Only set if the configuration could not be read.
+	Error string `json:"error,omitempty"`
+
+	Sections []BackendServerReloadSection `json:"sections,omitempty"`
+}
+
+type BackendServerDrainStatus struct {
+	Draining bool `json:"draining"`
+	// Number of sessions that are still connected to the server.
+	Sessions int `json:"sessions"`
+}
+
+type BackendServerGeoIPOverrides struct {
+	// Mapping of IP address / CIDR range to country.
+	Overrides map[string]string `json:"overrides"`
+}
+
+type BackendServerStateRestored struct {
+	Sessions int `json:"sessions"`
+	Rooms    int `json:"rooms"`
 }
@@ -4,6 +4,7 @@ package signaling
 
 import (
 	json "encoding/json"
+	jsontext "encoding/json/jsontext"
 	easyjson "github.com/mailru/easyjson"
 	jlexer "github.com/mailru/easyjson/jlexer"
 	jwriter "github.com/mailru/easyjson/jwriter"
@@ -204,7 +205,96 @@ func (v *RoomSessionData) UnmarshalJSON(data []byte) error {
 func (v *RoomSessionData) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling1(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling2(in *jlexer.Lexer, out *OcsResponse) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling2(in *jlexer.Lexer, out *RecentEvent) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "time":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Time).UnmarshalJSON(data))
+			}
+		case "type":
+			out.Type = RecentEventType(in.String())
+		case "subsystem":
+			out.Subsystem = string(in.String())
+		case "message":
+			out.Message = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling2(out *jwriter.Writer, in RecentEvent) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"time\":"
+		out.RawString(prefix[1:])
+		out.Raw((in.Time).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"subsystem\":"
+		out.RawString(prefix)
+		out.String(string(in.Subsystem))
+	}
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v RecentEvent) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v RecentEvent) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *RecentEvent) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *RecentEvent) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling2(l, v)
+}
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling3(in *jlexer.Lexer, out *OcsResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -243,7 +333,7 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling2(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling2(out *jwriter.Writer, in OcsResponse) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling3(out *jwriter.Writer, in OcsResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -262,27 +352,27 @@ func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling2(out *jwr
 // MarshalJSON supports json.Marshaler interface
 func (v OcsResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling2(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v OcsResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling2(w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *OcsResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling2(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *OcsResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling2(l, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling3(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling3(in *jlexer.Lexer, out *OcsMeta) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling4(in *jlexer.Lexer, out *OcsMeta) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -317,7 +407,7 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling3(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling3(out *jwriter.Writer, in OcsMeta) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling4(out *jwriter.Writer, in OcsMeta) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -342,27 +432,27 @@ func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling3(out *jwr
 // MarshalJSON supports json.Marshaler interface
 func (v OcsMeta) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling3(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling4(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v OcsMeta) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling3(w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling4(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *OcsMeta) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling3(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling4(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *OcsMeta) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling3(l, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling4(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling4(in *jlexer.Lexer, out *OcsBody) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling5(in *jlexer.Lexer, out *OcsBody) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -397,7 +487,7 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling4(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling4(out *jwriter.Writer, in OcsBody) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling5(out *jwriter.Writer, in OcsBody) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -417,27 +507,27 @@ func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling4(out *jwr
 // MarshalJSON supports json.Marshaler interface
 func (v OcsBody) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling4(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling5(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v OcsBody) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling4(w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling5(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *OcsBody) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling4(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling5(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *OcsBody) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling4(l, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling5(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling5(in *jlexer.Lexer, out *BackendServerRoomResponse) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling6(in *jlexer.Lexer, out *BackendServerStateRestored) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -456,18 +546,10 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling5(in *jlex
 			continue
 		}
 		switch key {
-		case "type":
-			out.Type = string(in.String())
-		case "dialout":
-			if in.IsNull() {
-				in.Skip()
-				out.Dialout = nil
-			} else {
-				if out.Dialout == nil {
-					out.Dialout = new(BackendRoomDialoutResponse)
-				}
-				(*out.Dialout).UnmarshalEasyJSON(in)
-			}
+		case "sessions":
+			out.Sessions = int(in.Int())
+		case "rooms":
+			out.Rooms = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -478,47 +560,47 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling5(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling5(out *jwriter.Writer, in BackendServerRoomResponse) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling6(out *jwriter.Writer, in BackendServerStateRestored) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"type\":"
+		const prefix string = ",\"sessions\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Type))
+		out.Int(int(in.Sessions))
 	}
-	if in.Dialout != nil {
-		const prefix string = ",\"dialout\":"
+	{
+		const prefix string = ",\"rooms\":"
 		out.RawString(prefix)
-		(*in.Dialout).MarshalEasyJSON(out)
+		out.Int(int(in.Rooms))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendServerRoomResponse) MarshalJSON() ([]byte, error) {
+func (v BackendServerStateRestored) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling5(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling6(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendServerRoomResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling5(w, v)
+func (v BackendServerStateRestored) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling6(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendServerRoomResponse) UnmarshalJSON(data []byte) error {
+func (v *BackendServerStateRestored) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling5(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling6(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendServerRoomResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling5(l, v)
+func (v *BackendServerStateRestored) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling6(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling6(in *jlexer.Lexer, out *BackendServerRoomRequest) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling7(in *jlexer.Lexer, out *BackendServerSessionInfo) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -537,113 +619,87 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling6(in *jlex
 			continue
 		}
 		switch key {
-		case "type":
-			out.Type = string(in.String())
-		case "invite":
-			if in.IsNull() {
-				in.Skip()
-				out.Invite = nil
-			} else {
-				if out.Invite == nil {
-					out.Invite = new(BackendRoomInviteRequest)
-				}
-				(*out.Invite).UnmarshalEasyJSON(in)
-			}
-		case "disinvite":
-			if in.IsNull() {
-				in.Skip()
-				out.Disinvite = nil
-			} else {
-				if out.Disinvite == nil {
-					out.Disinvite = new(BackendRoomDisinviteRequest)
-				}
-				(*out.Disinvite).UnmarshalEasyJSON(in)
-			}
-		case "update":
-			if in.IsNull() {
-				in.Skip()
-				out.Update = nil
-			} else {
-				if out.Update == nil {
-					out.Update = new(BackendRoomUpdateRequest)
-				}
-				(*out.Update).UnmarshalEasyJSON(in)
-			}
-		case "delete":
-			if in.IsNull() {
-				in.Skip()
-				out.Delete = nil
-			} else {
-				if out.Delete == nil {
-					out.Delete = new(BackendRoomDeleteRequest)
-				}
-				(*out.Delete).UnmarshalEasyJSON(in)
+		case "sessionid":
+			out.SessionId = PublicSessionId(in.String())
+		case "sid":
+			out.Sid = uint64(in.Uint64())
+		case "created":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Created).UnmarshalJSON(data))
 			}
+		case "clienttype":
+			out.ClientType = ClientType(in.String())
+		case "userid":
+			out.UserId = string(in.String())
+		case "backend":
+			out.Backend = string(in.String())
+		case "backendurl":
+			out.BackendUrl = string(in.String())
+		case "roomid":
+			out.RoomId = string(in.String())
+		case "roomsessionid":
+			out.RoomSessionId = RoomSessionId(in.String())
 		case "incall":
+			out.InCall = int(in.Int())
+		case "features":
 			if in.IsNull() {
 				in.Skip()
-				out.InCall = nil
-			} else {
-				if out.InCall == nil {
-					out.InCall = new(BackendRoomInCallRequest)
-				}
-				(*out.InCall).UnmarshalEasyJSON(in)
-			}
-		case "participants":
-			if in.IsNull() {
-				in.Skip()
-				out.Participants = nil
-			} else {
-				if out.Participants == nil {
-					out.Participants = new(BackendRoomParticipantsRequest)
-				}
-				(*out.Participants).UnmarshalEasyJSON(in)
-			}
-		case "message":
-			if in.IsNull() {
-				in.Skip()
-				out.Message = nil
+				out.Features = nil
 			} else {
-				if out.Message == nil {
-					out.Message = new(BackendRoomMessageRequest)
+				in.Delim('[')
+				if out.Features == nil {
+					if !in.IsDelim(']') {
+						out.Features = make([]string, 0, 4)
+					} else {
+						out.Features = []string{}
+					}
+				} else {
+					out.Features = (out.Features)[:0]
 				}
-				(*out.Message).UnmarshalEasyJSON(in)
-			}
-		case "switchto":
-			if in.IsNull() {
-				in.Skip()
-				out.SwitchTo = nil
-			} else {
-				if out.SwitchTo == nil {
-					out.SwitchTo = new(BackendRoomSwitchToMessageRequest)
+				for !in.IsDelim(']') {
+					var v4 string
+					v4 = string(in.String())
+					out.Features = append(out.Features, v4)
+					in.WantComma()
 				}
-				(*out.SwitchTo).UnmarshalEasyJSON(in)
+				in.Delim(']')
 			}
-		case "dialout":
+		case "publishers":
 			if in.IsNull() {
 				in.Skip()
-				out.Dialout = nil
+				out.Publishers = nil
 			} else {
-				if out.Dialout == nil {
-					out.Dialout = new(BackendRoomDialoutRequest)
+				in.Delim('[')
+				if out.Publishers == nil {
+					if !in.IsDelim(']') {
+						out.Publishers = make([]StreamType, 0, 4)
+					} else {
+						out.Publishers = []StreamType{}
+					}
+				} else {
+					out.Publishers = (out.Publishers)[:0]
 				}
-				(*out.Dialout).UnmarshalEasyJSON(in)
-			}
-		case "transient":
-			if in.IsNull() {
-				in.Skip()
-				out.Transient = nil
-			} else {
-				if out.Transient == nil {
-					out.Transient = new(BackendRoomTransientRequest)
+				for !in.IsDelim(']') {
+					var v5 StreamType
+					v5 = StreamType(in.String())
+					out.Publishers = append(out.Publishers, v5)
+					in.WantComma()
 				}
-				(*out.Transient).UnmarshalEasyJSON(in)
+				in.Delim(']')
 			}
-		case "received":
-			out.ReceivedTime = int64(in.Int64())
-		default:
-			in.SkipRecursive()
-		}
+		case "connected":
+			out.Connected = bool(in.Bool())
+		case "remoteaddr":
+			out.RemoteAddr = string(in.String())
+		case "country":
+			out.Country = string(in.String())
+		case "useragent":
+			out.UserAgent = string(in.String())
+		case "node":
+			out.Node = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
 		in.WantComma()
 	}
 	in.Delim('}')
@@ -651,97 +707,140 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling6(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling6(out *jwriter.Writer, in BackendServerRoomRequest) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling7(out *jwriter.Writer, in BackendServerSessionInfo) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"type\":"
+		const prefix string = ",\"sessionid\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Type))
+		out.String(string(in.SessionId))
 	}
-	if in.Invite != nil {
-		const prefix string = ",\"invite\":"
+	{
+		const prefix string = ",\"sid\":"
 		out.RawString(prefix)
-		(*in.Invite).MarshalEasyJSON(out)
+		out.Uint64(uint64(in.Sid))
 	}
-	if in.Disinvite != nil {
-		const prefix string = ",\"disinvite\":"
+	{
+		const prefix string = ",\"created\":"
 		out.RawString(prefix)
-		(*in.Disinvite).MarshalEasyJSON(out)
+		out.Raw((in.Created).MarshalJSON())
 	}
-	if in.Update != nil {
-		const prefix string = ",\"update\":"
+	{
+		const prefix string = ",\"clienttype\":"
 		out.RawString(prefix)
-		(*in.Update).MarshalEasyJSON(out)
+		out.String(string(in.ClientType))
 	}
-	if in.Delete != nil {
-		const prefix string = ",\"delete\":"
+	if in.UserId != "" {
+		const prefix string = ",\"userid\":"
 		out.RawString(prefix)
-		(*in.Delete).MarshalEasyJSON(out)
+		out.String(string(in.UserId))
 	}
-	if in.InCall != nil {
+	if in.Backend != "" {
+		const prefix string = ",\"backend\":"
+		out.RawString(prefix)
+		out.String(string(in.Backend))
+	}
+	if in.BackendUrl != "" {
+		const prefix string = ",\"backendurl\":"
+		out.RawString(prefix)
+		out.String(string(in.BackendUrl))
+	}
+	if in.RoomId != "" {
+		const prefix string = ",\"roomid\":"
+		out.RawString(prefix)
+		out.String(string(in.RoomId))
+	}
+	if in.RoomSessionId != "" {
+		const prefix string = ",\"roomsessionid\":"
+		out.RawString(prefix)
+		out.String(string(in.RoomSessionId))
+	}
+	{
 		const prefix string = ",\"incall\":"
 		out.RawString(prefix)
-		(*in.InCall).MarshalEasyJSON(out)
+		out.Int(int(in.InCall))
 	}
-	if in.Participants != nil {
-		const prefix string = ",\"participants\":"
+	if len(in.Features) != 0 {
+		const prefix string = ",\"features\":"
 		out.RawString(prefix)
-		(*in.Participants).MarshalEasyJSON(out)
+		{
+			out.RawByte('[')
+			for v6, v7 := range in.Features {
+				if v6 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v7))
+			}
+			out.RawByte(']')
+		}
 	}
-	if in.Message != nil {
-		const prefix string = ",\"message\":"
+	if len(in.Publishers) != 0 {
+		const prefix string = ",\"publishers\":"
 		out.RawString(prefix)
-		(*in.Message).MarshalEasyJSON(out)
+		{
+			out.RawByte('[')
+			for v8, v9 := range in.Publishers {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v9))
+			}
+			out.RawByte(']')
+		}
 	}
-	if in.SwitchTo != nil {
-		const prefix string = ",\"switchto\":"
+	{
+		const prefix string = ",\"connected\":"
 		out.RawString(prefix)
-		(*in.SwitchTo).MarshalEasyJSON(out)
+		out.Bool(bool(in.Connected))
 	}
-	if in.Dialout != nil {
-		const prefix string = ",\"dialout\":"
+	if in.RemoteAddr != "" {
+		const prefix string = ",\"remoteaddr\":"
 		out.RawString(prefix)
-		(*in.Dialout).MarshalEasyJSON(out)
+		out.String(string(in.RemoteAddr))
 	}
-	if in.Transient != nil {
-		const prefix string = ",\"transient\":"
+	if in.Country != "" {
+		const prefix string = ",\"country\":"
 		out.RawString(prefix)
-		(*in.Transient).MarshalEasyJSON(out)
+		out.String(string(in.Country))
 	}
-	if in.ReceivedTime != 0 {
-		const prefix string = ",\"received\":"
+	if in.UserAgent != "" {
+		const prefix string = ",\"useragent\":"
 		out.RawString(prefix)
-		out.Int64(int64(in.ReceivedTime))
+		out.String(string(in.UserAgent))
+	}
+	if in.Node != "" {
+		const prefix string = ",\"node\":"
+		out.RawString(prefix)
+		out.String(string(in.Node))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendServerRoomRequest) MarshalJSON() ([]byte, error) {
+func (v BackendServerSessionInfo) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling6(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling7(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendServerRoomRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling6(w, v)
+func (v BackendServerSessionInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling7(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendServerRoomRequest) UnmarshalJSON(data []byte) error {
+func (v *BackendServerSessionInfo) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling6(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling7(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendServerRoomRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling6(l, v)
+func (v *BackendServerSessionInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling7(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling7(in *jlexer.Lexer, out *BackendServerInfoVideoRoom) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling8(in *jlexer.Lexer, out *BackendServerRoomResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -760,12 +859,28 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling7(in *jlex
 			continue
 		}
 		switch key {
-		case "name":
-			out.Name = string(in.String())
-		case "version":
-			out.Version = string(in.String())
-		case "author":
-			out.Author = string(in.String())
+		case "type":
+			out.Type = string(in.String())
+		case "dialout":
+			if in.IsNull() {
+				in.Skip()
+				out.Dialout = nil
+			} else {
+				if out.Dialout == nil {
+					out.Dialout = new(BackendRoomDialoutResponse)
+				}
+				(*out.Dialout).UnmarshalEasyJSON(in)
+			}
+		case "recording":
+			if in.IsNull() {
+				in.Skip()
+				out.Recording = nil
+			} else {
+				if out.Recording == nil {
+					out.Recording = new(BackendRoomRecordingResponse)
+				}
+				(*out.Recording).UnmarshalEasyJSON(in)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -776,63 +891,52 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling7(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling7(out *jwriter.Writer, in BackendServerInfoVideoRoom) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling8(out *jwriter.Writer, in BackendServerRoomResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	if in.Name != "" {
-		const prefix string = ",\"name\":"
-		first = false
+	{
+		const prefix string = ",\"type\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Name))
+		out.String(string(in.Type))
 	}
-	if in.Version != "" {
-		const prefix string = ",\"version\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		out.String(string(in.Version))
+	if in.Dialout != nil {
+		const prefix string = ",\"dialout\":"
+		out.RawString(prefix)
+		(*in.Dialout).MarshalEasyJSON(out)
 	}
-	if in.Author != "" {
-		const prefix string = ",\"author\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		out.String(string(in.Author))
+	if in.Recording != nil {
+		const prefix string = ",\"recording\":"
+		out.RawString(prefix)
+		(*in.Recording).MarshalEasyJSON(out)
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendServerInfoVideoRoom) MarshalJSON() ([]byte, error) {
+func (v BackendServerRoomResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling7(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling8(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendServerInfoVideoRoom) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling7(w, v)
+func (v BackendServerRoomResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling8(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendServerInfoVideoRoom) UnmarshalJSON(data []byte) error {
+func (v *BackendServerRoomResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling7(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling8(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendServerInfoVideoRoom) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling7(l, v)
+func (v *BackendServerRoomResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling8(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling8(in *jlexer.Lexer, out *BackendServerInfoSfuProxy) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling9(in *jlexer.Lexer, out *BackendServerRoomRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -851,188 +955,241 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling8(in *jlex
 			continue
 		}
 		switch key {
-		case "url":
-			out.Url = string(in.String())
-		case "ip":
-			out.IP = string(in.String())
-		case "connected":
-			out.Connected = bool(in.Bool())
-		case "temporary":
-			out.Temporary = bool(in.Bool())
-		case "shutdown":
+		case "type":
+			out.Type = string(in.String())
+		case "invite":
 			if in.IsNull() {
 				in.Skip()
-				out.Shutdown = nil
+				out.Invite = nil
 			} else {
-				if out.Shutdown == nil {
-					out.Shutdown = new(bool)
+				if out.Invite == nil {
+					out.Invite = new(BackendRoomInviteRequest)
 				}
-				*out.Shutdown = bool(in.Bool())
+				(*out.Invite).UnmarshalEasyJSON(in)
 			}
-		case "uptime":
+		case "disinvite":
 			if in.IsNull() {
 				in.Skip()
-				out.Uptime = nil
+				out.Disinvite = nil
 			} else {
-				if out.Uptime == nil {
-					out.Uptime = new(time.Time)
-				}
-				if data := in.Raw(); in.Ok() {
-					in.AddError((*out.Uptime).UnmarshalJSON(data))
+				if out.Disinvite == nil {
+					out.Disinvite = new(BackendRoomDisinviteRequest)
 				}
+				(*out.Disinvite).UnmarshalEasyJSON(in)
 			}
-		case "version":
-			out.Version = string(in.String())
-		case "features":
+		case "update":
 			if in.IsNull() {
 				in.Skip()
-				out.Features = nil
+				out.Update = nil
 			} else {
-				in.Delim('[')
-				if out.Features == nil {
-					if !in.IsDelim(']') {
-						out.Features = make([]string, 0, 4)
-					} else {
-						out.Features = []string{}
-					}
-				} else {
-					out.Features = (out.Features)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v4 string
-					v4 = string(in.String())
-					out.Features = append(out.Features, v4)
-					in.WantComma()
+				if out.Update == nil {
+					out.Update = new(BackendRoomUpdateRequest)
 				}
-				in.Delim(']')
+				(*out.Update).UnmarshalEasyJSON(in)
 			}
-		case "country":
-			out.Country = string(in.String())
-		case "load":
+		case "delete":
 			if in.IsNull() {
 				in.Skip()
-				out.Load = nil
+				out.Delete = nil
 			} else {
-				if out.Load == nil {
-					out.Load = new(int64)
+				if out.Delete == nil {
+					out.Delete = new(BackendRoomDeleteRequest)
 				}
-				*out.Load = int64(in.Int64())
+				(*out.Delete).UnmarshalEasyJSON(in)
 			}
-		case "bandwidth":
+		case "incall":
 			if in.IsNull() {
 				in.Skip()
-				out.Bandwidth = nil
+				out.InCall = nil
 			} else {
-				if out.Bandwidth == nil {
-					out.Bandwidth = new(EventProxyServerBandwidth)
+				if out.InCall == nil {
+					out.InCall = new(BackendRoomInCallRequest)
 				}
-				(*out.Bandwidth).UnmarshalEasyJSON(in)
+				(*out.InCall).UnmarshalEasyJSON(in)
 			}
-		default:
-			in.SkipRecursive()
-		}
-		in.WantComma()
-	}
+		case "participants":
+			if in.IsNull() {
+				in.Skip()
+				out.Participants = nil
+			} else {
+				if out.Participants == nil {
+					out.Participants = new(BackendRoomParticipantsRequest)
+				}
+				(*out.Participants).UnmarshalEasyJSON(in)
+			}
+		case "message":
+			if in.IsNull() {
+				in.Skip()
+				out.Message = nil
+			} else {
+				if out.Message == nil {
+					out.Message = new(BackendRoomMessageRequest)
+				}
+				(*out.Message).UnmarshalEasyJSON(in)
+			}
+		case "switchto":
+			if in.IsNull() {
+				in.Skip()
+				out.SwitchTo = nil
+			} else {
+				if out.SwitchTo == nil {
+					out.SwitchTo = new(BackendRoomSwitchToMessageRequest)
+				}
+				(*out.SwitchTo).UnmarshalEasyJSON(in)
+			}
+		case "dialout":
+			if in.IsNull() {
+				in.Skip()
+				out.Dialout = nil
+			} else {
+				if out.Dialout == nil {
+					out.Dialout = new(BackendRoomDialoutRequest)
+				}
+				(*out.Dialout).UnmarshalEasyJSON(in)
+			}
+		case "transient":
+			if in.IsNull() {
+				in.Skip()
+				out.Transient = nil
+			} else {
+				if out.Transient == nil {
+					out.Transient = new(BackendRoomTransientRequest)
+				}
+				(*out.Transient).UnmarshalEasyJSON(in)
+			}
+		case "recording":
+			if in.IsNull() {
+				in.Skip()
+				out.Recording = nil
+			} else {
+				if out.Recording == nil {
+					out.Recording = new(BackendRoomRecordingRequest)
+				}
+				(*out.Recording).UnmarshalEasyJSON(in)
+			}
+		case "breakout":
+			if in.IsNull() {
+				in.Skip()
+				out.Breakout = nil
+			} else {
+				if out.Breakout == nil {
+					out.Breakout = new(BackendRoomBreakoutRequest)
+				}
+				(*out.Breakout).UnmarshalEasyJSON(in)
+			}
+		case "received":
+			out.ReceivedTime = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
 	in.Delim('}')
 	if isTopLevel {
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling8(out *jwriter.Writer, in BackendServerInfoSfuProxy) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling9(out *jwriter.Writer, in BackendServerRoomRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"url\":"
+		const prefix string = ",\"type\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Url))
+		out.String(string(in.Type))
 	}
-	if in.IP != "" {
-		const prefix string = ",\"ip\":"
+	if in.Invite != nil {
+		const prefix string = ",\"invite\":"
 		out.RawString(prefix)
-		out.String(string(in.IP))
+		(*in.Invite).MarshalEasyJSON(out)
 	}
-	{
-		const prefix string = ",\"connected\":"
+	if in.Disinvite != nil {
+		const prefix string = ",\"disinvite\":"
 		out.RawString(prefix)
-		out.Bool(bool(in.Connected))
+		(*in.Disinvite).MarshalEasyJSON(out)
 	}
-	{
-		const prefix string = ",\"temporary\":"
+	if in.Update != nil {
+		const prefix string = ",\"update\":"
 		out.RawString(prefix)
-		out.Bool(bool(in.Temporary))
+		(*in.Update).MarshalEasyJSON(out)
 	}
-	if in.Shutdown != nil {
-		const prefix string = ",\"shutdown\":"
+	if in.Delete != nil {
+		const prefix string = ",\"delete\":"
 		out.RawString(prefix)
-		out.Bool(bool(*in.Shutdown))
+		(*in.Delete).MarshalEasyJSON(out)
 	}
-	if in.Uptime != nil {
-		const prefix string = ",\"uptime\":"
+	if in.InCall != nil {
+		const prefix string = ",\"incall\":"
 		out.RawString(prefix)
-		out.Raw((*in.Uptime).MarshalJSON())
+		(*in.InCall).MarshalEasyJSON(out)
 	}
-	if in.Version != "" {
-		const prefix string = ",\"version\":"
+	if in.Participants != nil {
+		const prefix string = ",\"participants\":"
 		out.RawString(prefix)
-		out.String(string(in.Version))
+		(*in.Participants).MarshalEasyJSON(out)
 	}
-	if len(in.Features) != 0 {
-		const prefix string = ",\"features\":"
+	if in.Message != nil {
+		const prefix string = ",\"message\":"
 		out.RawString(prefix)
-		{
-			out.RawByte('[')
-			for v5, v6 := range in.Features {
-				if v5 > 0 {
-					out.RawByte(',')
-				}
-				out.String(string(v6))
-			}
-			out.RawByte(']')
-		}
+		(*in.Message).MarshalEasyJSON(out)
 	}
-	if in.Country != "" {
-		const prefix string = ",\"country\":"
+	if in.SwitchTo != nil {
+		const prefix string = ",\"switchto\":"
 		out.RawString(prefix)
-		out.String(string(in.Country))
+		(*in.SwitchTo).MarshalEasyJSON(out)
 	}
-	if in.Load != nil {
-		const prefix string = ",\"load\":"
+	if in.Dialout != nil {
+		const prefix string = ",\"dialout\":"
 		out.RawString(prefix)
-		out.Int64(int64(*in.Load))
+		(*in.Dialout).MarshalEasyJSON(out)
 	}
-	if in.Bandwidth != nil {
-		const prefix string = ",\"bandwidth\":"
+	if in.Transient != nil {
+		const prefix string = ",\"transient\":"
 		out.RawString(prefix)
-		(*in.Bandwidth).MarshalEasyJSON(out)
+		(*in.Transient).MarshalEasyJSON(out)
+	}
+	if in.Recording != nil {
+		const prefix string = ",\"recording\":"
+		out.RawString(prefix)
+		(*in.Recording).MarshalEasyJSON(out)
+	}
+	if in.Breakout != nil {
+		const prefix string = ",\"breakout\":"
+		out.RawString(prefix)
+		(*in.Breakout).MarshalEasyJSON(out)
+	}
+	if in.ReceivedTime != 0 {
+		const prefix string = ",\"received\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.ReceivedTime))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendServerInfoSfuProxy) MarshalJSON() ([]byte, error) {
+func (v BackendServerRoomRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling8(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling9(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendServerInfoSfuProxy) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling8(w, v)
+func (v BackendServerRoomRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling9(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendServerInfoSfuProxy) UnmarshalJSON(data []byte) error {
+func (v *BackendServerRoomRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling8(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling9(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendServerInfoSfuProxy) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling8(l, v)
+func (v *BackendServerRoomRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling9(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling9(in *jlexer.Lexer, out *BackendServerInfoSfuJanus) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling10(in *jlexer.Lexer, out *BackendServerReloadSection) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1051,58 +1208,12 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling9(in *jlex
 			continue
 		}
 		switch key {
-		case "url":
-			out.Url = string(in.String())
-		case "connected":
-			out.Connected = bool(in.Bool())
-		case "name":
-			out.Name = string(in.String())
-		case "version":
-			out.Version = string(in.String())
-		case "author":
-			out.Author = string(in.String())
-		case "datachannels":
-			if in.IsNull() {
-				in.Skip()
-				out.DataChannels = nil
-			} else {
-				if out.DataChannels == nil {
-					out.DataChannels = new(bool)
-				}
-				*out.DataChannels = bool(in.Bool())
-			}
-		case "fulltrickle":
-			if in.IsNull() {
-				in.Skip()
-				out.FullTrickle = nil
-			} else {
-				if out.FullTrickle == nil {
-					out.FullTrickle = new(bool)
-				}
-				*out.FullTrickle = bool(in.Bool())
-			}
-		case "localip":
-			out.LocalIP = string(in.String())
-		case "ipv6":
-			if in.IsNull() {
-				in.Skip()
-				out.IPv6 = nil
-			} else {
-				if out.IPv6 == nil {
-					out.IPv6 = new(bool)
-				}
-				*out.IPv6 = bool(in.Bool())
-			}
-		case "videoroom":
-			if in.IsNull() {
-				in.Skip()
-				out.VideoRoom = nil
-			} else {
-				if out.VideoRoom == nil {
-					out.VideoRoom = new(BackendServerInfoVideoRoom)
-				}
-				(*out.VideoRoom).UnmarshalEasyJSON(in)
-			}
+		case "section":
+			out.Section = string(in.String())
+		case "success":
+			out.Success = bool(in.Bool())
+		case "error":
+			out.Error = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -1113,87 +1224,3233 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling9(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling9(out *jwriter.Writer, in BackendServerInfoSfuJanus) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling10(out *jwriter.Writer, in BackendServerReloadSection) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"url\":"
+		const prefix string = ",\"section\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Url))
+		out.String(string(in.Section))
 	}
 	{
-		const prefix string = ",\"connected\":"
+		const prefix string = ",\"success\":"
 		out.RawString(prefix)
-		out.Bool(bool(in.Connected))
+		out.Bool(bool(in.Success))
 	}
-	if in.Name != "" {
-		const prefix string = ",\"name\":"
+	if in.Error != "" {
+		const prefix string = ",\"error\":"
 		out.RawString(prefix)
-		out.String(string(in.Name))
+		out.String(string(in.Error))
 	}
-	if in.Version != "" {
-		const prefix string = ",\"version\":"
-		out.RawString(prefix)
-		out.String(string(in.Version))
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BackendServerReloadSection) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling10(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BackendServerReloadSection) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling10(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BackendServerReloadSection) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling10(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BackendServerReloadSection) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling10(l, v)
+}
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling11(in *jlexer.Lexer, out *BackendServerReloadResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "success":
+			out.Success = bool(in.Bool())
+		case "error":
+			out.Error = string(in.String())
+		case "sections":
+			if in.IsNull() {
+				in.Skip()
+				out.Sections = nil
+			} else {
+				in.Delim('[')
+				if out.Sections == nil {
+					if !in.IsDelim(']') {
+						out.Sections = make([]BackendServerReloadSection, 0, 1)
+					} else {
+						out.Sections = []BackendServerReloadSection{}
+					}
+				} else {
+					out.Sections = (out.Sections)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v10 BackendServerReloadSection
+					(v10).UnmarshalEasyJSON(in)
+					out.Sections = append(out.Sections, v10)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling11(out *jwriter.Writer, in BackendServerReloadResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"success\":"
+		out.RawString(prefix[1:])
+		out.Bool(bool(in.Success))
+	}
+	if in.Error != "" {
+		const prefix string = ",\"error\":"
+		out.RawString(prefix)
+		out.String(string(in.Error))
+	}
+	if len(in.Sections) != 0 {
+		const prefix string = ",\"sections\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v11, v12 := range in.Sections {
+				if v11 > 0 {
+					out.RawByte(',')
+				}
+				(v12).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BackendServerReloadResponse) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling11(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BackendServerReloadResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling11(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BackendServerReloadResponse) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling11(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BackendServerReloadResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling11(l, v)
+}
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling12(in *jlexer.Lexer, out *BackendServerLoad) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "score":
+			out.Score = float64(in.Float64())
+		case "headroom":
+			out.Headroom = int(in.Int())
+		case "sessions":
+			out.Sessions = int(in.Int())
+		case "rooms":
+			out.Rooms = int(in.Int())
+		case "messagerate":
+			out.MessageRate = float64(in.Float64())
+		case "pendingwrites":
+			out.PendingWrites = int(in.Int())
+		case "mcuload":
+			if in.IsNull() {
+				in.Skip()
+				out.McuLoad = nil
+			} else {
+				if out.McuLoad == nil {
+					out.McuLoad = new(int64)
+				}
+				*out.McuLoad = int64(in.Int64())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling12(out *jwriter.Writer, in BackendServerLoad) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"score\":"
+		out.RawString(prefix[1:])
+		out.Float64(float64(in.Score))
+	}
+	{
+		const prefix string = ",\"headroom\":"
+		out.RawString(prefix)
+		out.Int(int(in.Headroom))
+	}
+	{
+		const prefix string = ",\"sessions\":"
+		out.RawString(prefix)
+		out.Int(int(in.Sessions))
+	}
+	{
+		const prefix string = ",\"rooms\":"
+		out.RawString(prefix)
+		out.Int(int(in.Rooms))
+	}
+	{
+		const prefix string = ",\"messagerate\":"
+		out.RawString(prefix)
+		out.Float64(float64(in.MessageRate))
+	}
+	{
+		const prefix string = ",\"pendingwrites\":"
+		out.RawString(prefix)
+		out.Int(int(in.PendingWrites))
+	}
+	if in.McuLoad != nil {
+		const prefix string = ",\"mcuload\":"
+		out.RawString(prefix)
+		out.Int64(int64(*in.McuLoad))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BackendServerLoad) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling12(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BackendServerLoad) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling12(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BackendServerLoad) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling12(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BackendServerLoad) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling12(l, v)
+}
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling13(in *jlexer.Lexer, out *BackendServerInfoVideoRoom) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "name":
+			out.Name = string(in.String())
+		case "version":
+			out.Version = string(in.String())
+		case "author":
+			out.Author = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling13(out *jwriter.Writer, in BackendServerInfoVideoRoom) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Name != "" {
+		const prefix string = ",\"name\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Name))
+	}
+	if in.Version != "" {
+		const prefix string = ",\"version\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Version))
 	}
 	if in.Author != "" {
 		const prefix string = ",\"author\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Author))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BackendServerInfoVideoRoom) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling13(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BackendServerInfoVideoRoom) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling13(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BackendServerInfoVideoRoom) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling13(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BackendServerInfoVideoRoom) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling13(l, v)
+}
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling14(in *jlexer.Lexer, out *BackendServerInfoSfuProxy) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "url":
+			out.Url = string(in.String())
+		case "ip":
+			out.IP = string(in.String())
+		case "connected":
+			out.Connected = bool(in.Bool())
+		case "temporary":
+			out.Temporary = bool(in.Bool())
+		case "shutdown":
+			if in.IsNull() {
+				in.Skip()
+				out.Shutdown = nil
+			} else {
+				if out.Shutdown == nil {
+					out.Shutdown = new(bool)
+				}
+				*out.Shutdown = bool(in.Bool())
+			}
+		case "uptime":
+			if in.IsNull() {
+				in.Skip()
+				out.Uptime = nil
+			} else {
+				if out.Uptime == nil {
+					out.Uptime = new(time.Time)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.Uptime).UnmarshalJSON(data))
+				}
+			}
+		case "version":
+			out.Version = string(in.String())
+		case "features":
+			if in.IsNull() {
+				in.Skip()
+				out.Features = nil
+			} else {
+				in.Delim('[')
+				if out.Features == nil {
+					if !in.IsDelim(']') {
+						out.Features = make([]string, 0, 4)
+					} else {
+						out.Features = []string{}
+					}
+				} else {
+					out.Features = (out.Features)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v13 string
+					v13 = string(in.String())
+					out.Features = append(out.Features, v13)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "country":
+			out.Country = string(in.String())
+		case "load":
+			if in.IsNull() {
+				in.Skip()
+				out.Load = nil
+			} else {
+				if out.Load == nil {
+					out.Load = new(int64)
+				}
+				*out.Load = int64(in.Int64())
+			}
+		case "bandwidth":
+			if in.IsNull() {
+				in.Skip()
+				out.Bandwidth = nil
+			} else {
+				if out.Bandwidth == nil {
+					out.Bandwidth = new(EventProxyServerBandwidth)
+				}
+				(*out.Bandwidth).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling14(out *jwriter.Writer, in BackendServerInfoSfuProxy) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Url))
+	}
+	if in.IP != "" {
+		const prefix string = ",\"ip\":"
+		out.RawString(prefix)
+		out.String(string(in.IP))
+	}
+	{
+		const prefix string = ",\"connected\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Connected))
+	}
+	{
+		const prefix string = ",\"temporary\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Temporary))
+	}
+	if in.Shutdown != nil {
+		const prefix string = ",\"shutdown\":"
+		out.RawString(prefix)
+		out.Bool(bool(*in.Shutdown))
+	}
+	if in.Uptime != nil {
+		const prefix string = ",\"uptime\":"
+		out.RawString(prefix)
+		out.Raw((*in.Uptime).MarshalJSON())
+	}
+	if in.Version != "" {
+		const prefix string = ",\"version\":"
+		out.RawString(prefix)
+		out.String(string(in.Version))
+	}
+	if len(in.Features) != 0 {
+		const prefix string = ",\"features\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v14, v15 := range in.Features {
+				if v14 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v15))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Country != "" {
+		const prefix string = ",\"country\":"
+		out.RawString(prefix)
+		out.String(string(in.Country))
+	}
+	if in.Load != nil {
+		const prefix string = ",\"load\":"
+		out.RawString(prefix)
+		out.Int64(int64(*in.Load))
+	}
+	if in.Bandwidth != nil {
+		const prefix string = ",\"bandwidth\":"
+		out.RawString(prefix)
+		(*in.Bandwidth).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BackendServerInfoSfuProxy) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling14(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BackendServerInfoSfuProxy) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling14(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BackendServerInfoSfuProxy) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling14(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BackendServerInfoSfuProxy) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling14(l, v)
+}
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling15(in *jlexer.Lexer, out *BackendServerInfoSfuLivekit) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "url":
+			out.Url = string(in.String())
+		case "connected":
+			out.Connected = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling15(out *jwriter.Writer, in BackendServerInfoSfuLivekit) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Url))
+	}
+	{
+		const prefix string = ",\"connected\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Connected))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BackendServerInfoSfuLivekit) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling15(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BackendServerInfoSfuLivekit) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling15(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BackendServerInfoSfuLivekit) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling15(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BackendServerInfoSfuLivekit) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling15(l, v)
+}
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling16(in *jlexer.Lexer, out *BackendServerInfoSfuJanus) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "url":
+			out.Url = string(in.String())
+		case "connected":
+			out.Connected = bool(in.Bool())
+		case "name":
+			out.Name = string(in.String())
+		case "version":
+			out.Version = string(in.String())
+		case "author":
+			out.Author = string(in.String())
+		case "datachannels":
+			if in.IsNull() {
+				in.Skip()
+				out.DataChannels = nil
+			} else {
+				if out.DataChannels == nil {
+					out.DataChannels = new(bool)
+				}
+				*out.DataChannels = bool(in.Bool())
+			}
+		case "fulltrickle":
+			if in.IsNull() {
+				in.Skip()
+				out.FullTrickle = nil
+			} else {
+				if out.FullTrickle == nil {
+					out.FullTrickle = new(bool)
+				}
+				*out.FullTrickle = bool(in.Bool())
+			}
+		case "localip":
+			out.LocalIP = string(in.String())
+		case "ipv6":
+			if in.IsNull() {
+				in.Skip()
+				out.IPv6 = nil
+			} else {
+				if out.IPv6 == nil {
+					out.IPv6 = new(bool)
+				}
+				*out.IPv6 = bool(in.Bool())
+			}
+		case "videoroom":
+			if in.IsNull() {
+				in.Skip()
+				out.VideoRoom = nil
+			} else {
+				if out.VideoRoom == nil {
+					out.VideoRoom = new(BackendServerInfoVideoRoom)
+				}
+				(*out.VideoRoom).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling16(out *jwriter.Writer, in BackendServerInfoSfuJanus) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Url))
+	}
+	{
+		const prefix string = ",\"connected\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Connected))
+	}
+	if in.Name != "" {
+		const prefix string = ",\"name\":"
+		out.RawString(prefix)
+		out.String(string(in.Name))
+	}
+	if in.Version != "" {
+		const prefix string = ",\"version\":"
+		out.RawString(prefix)
+		out.String(string(in.Version))
+	}
+	if in.Author != "" {
+		const prefix string = ",\"author\":"
+		out.RawString(prefix)
+		out.String(string(in.Author))
+	}
+	if in.DataChannels != nil {
+		const prefix string = ",\"datachannels\":"
+		out.RawString(prefix)
+		out.Bool(bool(*in.DataChannels))
+	}
+	if in.FullTrickle != nil {
+		const prefix string = ",\"fulltrickle\":"
+		out.RawString(prefix)
+		out.Bool(bool(*in.FullTrickle))
+	}
+	if in.LocalIP != "" {
+		const prefix string = ",\"localip\":"
+		out.RawString(prefix)
+		out.String(string(in.LocalIP))
+	}
+	if in.IPv6 != nil {
+		const prefix string = ",\"ipv6\":"
+		out.RawString(prefix)
+		out.Bool(bool(*in.IPv6))
+	}
+	if in.VideoRoom != nil {
+		const prefix string = ",\"videoroom\":"
+		out.RawString(prefix)
+		(*in.VideoRoom).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BackendServerInfoSfuJanus) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling16(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BackendServerInfoSfuJanus) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling16(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BackendServerInfoSfuJanus) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling16(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BackendServerInfoSfuJanus) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling16(l, v)
+}
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling17(in *jlexer.Lexer, out *BackendServerInfoSfu) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "mode":
+			out.Mode = SfuMode(in.String())
+		case "janus":
+			if in.IsNull() {
+				in.Skip()
+				out.Janus = nil
+			} else {
+				if out.Janus == nil {
+					out.Janus = new(BackendServerInfoSfuJanus)
+				}
+				(*out.Janus).UnmarshalEasyJSON(in)
+			}
+		case "proxies":
+			if in.IsNull() {
+				in.Skip()
+				out.Proxies = nil
+			} else {
+				in.Delim('[')
+				if out.Proxies == nil {
+					if !in.IsDelim(']') {
+						out.Proxies = make([]BackendServerInfoSfuProxy, 0, 0)
+					} else {
+						out.Proxies = []BackendServerInfoSfuProxy{}
+					}
+				} else {
+					out.Proxies = (out.Proxies)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v16 BackendServerInfoSfuProxy
+					(v16).UnmarshalEasyJSON(in)
+					out.Proxies = append(out.Proxies, v16)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "livekit":
+			if in.IsNull() {
+				in.Skip()
+				out.Livekit = nil
+			} else {
+				if out.Livekit == nil {
+					out.Livekit = new(BackendServerInfoSfuLivekit)
+				}
+				(*out.Livekit).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling17(out *jwriter.Writer, in BackendServerInfoSfu) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"mode\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Mode))
+	}
+	if in.Janus != nil {
+		const prefix string = ",\"janus\":"
+		out.RawString(prefix)
+		(*in.Janus).MarshalEasyJSON(out)
+	}
+	if len(in.Proxies) != 0 {
+		const prefix string = ",\"proxies\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v17, v18 := range in.Proxies {
+				if v17 > 0 {
+					out.RawByte(',')
+				}
+				(v18).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Livekit != nil {
+		const prefix string = ",\"livekit\":"
+		out.RawString(prefix)
+		(*in.Livekit).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BackendServerInfoSfu) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling17(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BackendServerInfoSfu) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling17(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BackendServerInfoSfu) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling17(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BackendServerInfoSfu) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling17(l, v)
+}
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling18(in *jlexer.Lexer, out *BackendServerInfoRedis) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "url":
+			out.Url = string(in.String())
+		case "connected":
+			out.Connected = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling18(out *jwriter.Writer, in BackendServerInfoRedis) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Url))
+	}
+	{
+		const prefix string = ",\"connected\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Connected))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BackendServerInfoRedis) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling18(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BackendServerInfoRedis) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling18(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BackendServerInfoRedis) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling18(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BackendServerInfoRedis) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling18(l, v)
+}
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling19(in *jlexer.Lexer, out *BackendServerInfoNats) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "urls":
+			if in.IsNull() {
+				in.Skip()
+				out.Urls = nil
+			} else {
+				in.Delim('[')
+				if out.Urls == nil {
+					if !in.IsDelim(']') {
+						out.Urls = make([]string, 0, 4)
+					} else {
+						out.Urls = []string{}
+					}
+				} else {
+					out.Urls = (out.Urls)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v19 string
+					v19 = string(in.String())
+					out.Urls = append(out.Urls, v19)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "connected":
+			out.Connected = bool(in.Bool())
+		case "serverurl":
+			out.ServerUrl = string(in.String())
+		case "serverid":
+			out.ServerID = string(in.String())
+		case "version":
+			out.ServerVersion = string(in.String())
+		case "clustername":
+			out.ClusterName = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling19(out *jwriter.Writer, in BackendServerInfoNats) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"urls\":"
+		out.RawString(prefix[1:])
+		if in.Urls == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v20, v21 := range in.Urls {
+				if v20 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v21))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"connected\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Connected))
+	}
+	if in.ServerUrl != "" {
+		const prefix string = ",\"serverurl\":"
+		out.RawString(prefix)
+		out.String(string(in.ServerUrl))
+	}
+	if in.ServerID != "" {
+		const prefix string = ",\"serverid\":"
+		out.RawString(prefix)
+		out.String(string(in.ServerID))
+	}
+	if in.ServerVersion != "" {
+		const prefix string = ",\"version\":"
+		out.RawString(prefix)
+		out.String(string(in.ServerVersion))
+	}
+	if in.ClusterName != "" {
+		const prefix string = ",\"clustername\":"
+		out.RawString(prefix)
+		out.String(string(in.ClusterName))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BackendServerInfoNats) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling19(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BackendServerInfoNats) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling19(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BackendServerInfoNats) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling19(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BackendServerInfoNats) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling19(l, v)
+}
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling20(in *jlexer.Lexer, out *BackendServerInfoGrpc) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "target":
+			out.Target = string(in.String())
+		case "ip":
+			out.IP = string(in.String())
+		case "connected":
+			out.Connected = bool(in.Bool())
+		case "version":
+			out.Version = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling20(out *jwriter.Writer, in BackendServerInfoGrpc) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"target\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Target))
+	}
+	if in.IP != "" {
+		const prefix string = ",\"ip\":"
+		out.RawString(prefix)
+		out.String(string(in.IP))
+	}
+	{
+		const prefix string = ",\"connected\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Connected))
+	}
+	if in.Version != "" {
+		const prefix string = ",\"version\":"
+		out.RawString(prefix)
+		out.String(string(in.Version))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BackendServerInfoGrpc) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling20(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BackendServerInfoGrpc) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling20(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BackendServerInfoGrpc) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling20(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BackendServerInfoGrpc) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling20(l, v)
+}
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling21(in *jlexer.Lexer, out *BackendServerInfoEtcd) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "endpoints":
+			if in.IsNull() {
+				in.Skip()
+				out.Endpoints = nil
+			} else {
+				in.Delim('[')
+				if out.Endpoints == nil {
+					if !in.IsDelim(']') {
+						out.Endpoints = make([]string, 0, 4)
+					} else {
+						out.Endpoints = []string{}
+					}
+				} else {
+					out.Endpoints = (out.Endpoints)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v22 string
+					v22 = string(in.String())
+					out.Endpoints = append(out.Endpoints, v22)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "active":
+			out.Active = string(in.String())
+		case "connected":
+			if in.IsNull() {
+				in.Skip()
+				out.Connected = nil
+			} else {
+				if out.Connected == nil {
+					out.Connected = new(bool)
+				}
+				*out.Connected = bool(in.Bool())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling21(out *jwriter.Writer, in BackendServerInfoEtcd) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"endpoints\":"
+		out.RawString(prefix[1:])
+		if in.Endpoints == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v23, v24 := range in.Endpoints {
+				if v23 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v24))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Active != "" {
+		const prefix string = ",\"active\":"
+		out.RawString(prefix)
+		out.String(string(in.Active))
+	}
+	if in.Connected != nil {
+		const prefix string = ",\"connected\":"
+		out.RawString(prefix)
+		out.Bool(bool(*in.Connected))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BackendServerInfoEtcd) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling21(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BackendServerInfoEtcd) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling21(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BackendServerInfoEtcd) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling21(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BackendServerInfoEtcd) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling21(l, v)
+}
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling22(in *jlexer.Lexer, out *BackendServerInfoDialout) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "sessionid":
+			out.SessionId = PublicSessionId(in.String())
+		case "connected":
+			out.Connected = bool(in.Bool())
+		case "address":
+			out.Address = string(in.String())
+		case "useragent":
+			out.UserAgent = string(in.String())
+		case "version":
+			out.Version = string(in.String())
+		case "features":
+			if in.IsNull() {
+				in.Skip()
+				out.Features = nil
+			} else {
+				in.Delim('[')
+				if out.Features == nil {
+					if !in.IsDelim(']') {
+						out.Features = make([]string, 0, 4)
+					} else {
+						out.Features = []string{}
+					}
+				} else {
+					out.Features = (out.Features)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v25 string
+					v25 = string(in.String())
+					out.Features = append(out.Features, v25)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "calls":
+			out.Calls = int(in.Int())
+		case "maxcalls":
+			out.MaxCalls = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling22(out *jwriter.Writer, in BackendServerInfoDialout) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"sessionid\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.SessionId))
+	}
+	{
+		const prefix string = ",\"connected\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Connected))
+	}
+	if in.Address != "" {
+		const prefix string = ",\"address\":"
+		out.RawString(prefix)
+		out.String(string(in.Address))
+	}
+	if in.UserAgent != "" {
+		const prefix string = ",\"useragent\":"
+		out.RawString(prefix)
+		out.String(string(in.UserAgent))
+	}
+	if in.Version != "" {
+		const prefix string = ",\"version\":"
+		out.RawString(prefix)
+		out.String(string(in.Version))
+	}
+	if len(in.Features) != 0 {
+		const prefix string = ",\"features\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v26, v27 := range in.Features {
+				if v26 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v27))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"calls\":"
+		out.RawString(prefix)
+		out.Int(int(in.Calls))
+	}
+	if in.MaxCalls != 0 {
+		const prefix string = ",\"maxcalls\":"
+		out.RawString(prefix)
+		out.Int(int(in.MaxCalls))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BackendServerInfoDialout) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling22(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BackendServerInfoDialout) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling22(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BackendServerInfoDialout) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling22(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BackendServerInfoDialout) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling22(l, v)
+}
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling23(in *jlexer.Lexer, out *BackendServerInfo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "version":
+			out.Version = string(in.String())
+		case "features":
+			if in.IsNull() {
+				in.Skip()
+				out.Features = nil
+			} else {
+				in.Delim('[')
+				if out.Features == nil {
+					if !in.IsDelim(']') {
+						out.Features = make([]string, 0, 4)
+					} else {
+						out.Features = []string{}
+					}
+				} else {
+					out.Features = (out.Features)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v28 string
+					v28 = string(in.String())
+					out.Features = append(out.Features, v28)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "sfu":
+			if in.IsNull() {
+				in.Skip()
+				out.Sfu = nil
+			} else {
+				if out.Sfu == nil {
+					out.Sfu = new(BackendServerInfoSfu)
+				}
+				(*out.Sfu).UnmarshalEasyJSON(in)
+			}
+		case "dialout":
+			if in.IsNull() {
+				in.Skip()
+				out.Dialout = nil
+			} else {
+				in.Delim('[')
+				if out.Dialout == nil {
+					if !in.IsDelim(']') {
+						out.Dialout = make([]BackendServerInfoDialout, 0, 0)
+					} else {
+						out.Dialout = []BackendServerInfoDialout{}
+					}
+				} else {
+					out.Dialout = (out.Dialout)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v29 BackendServerInfoDialout
+					(v29).UnmarshalEasyJSON(in)
+					out.Dialout = append(out.Dialout, v29)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "nats":
+			if in.IsNull() {
+				in.Skip()
+				out.Nats = nil
+			} else {
+				if out.Nats == nil {
+					out.Nats = new(BackendServerInfoNats)
+				}
+				(*out.Nats).UnmarshalEasyJSON(in)
+			}
+		case "redis":
+			if in.IsNull() {
+				in.Skip()
+				out.Redis = nil
+			} else {
+				if out.Redis == nil {
+					out.Redis = new(BackendServerInfoRedis)
+				}
+				(*out.Redis).UnmarshalEasyJSON(in)
+			}
+		case "grpc":
+			if in.IsNull() {
+				in.Skip()
+				out.Grpc = nil
+			} else {
+				in.Delim('[')
+				if out.Grpc == nil {
+					if !in.IsDelim(']') {
+						out.Grpc = make([]BackendServerInfoGrpc, 0, 1)
+					} else {
+						out.Grpc = []BackendServerInfoGrpc{}
+					}
+				} else {
+					out.Grpc = (out.Grpc)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v30 BackendServerInfoGrpc
+					(v30).UnmarshalEasyJSON(in)
+					out.Grpc = append(out.Grpc, v30)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "etcd":
+			if in.IsNull() {
+				in.Skip()
+				out.Etcd = nil
+			} else {
+				if out.Etcd == nil {
+					out.Etcd = new(BackendServerInfoEtcd)
+				}
+				(*out.Etcd).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling23(out *jwriter.Writer, in BackendServerInfo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"version\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Version))
+	}
+	{
+		const prefix string = ",\"features\":"
+		out.RawString(prefix)
+		if in.Features == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v31, v32 := range in.Features {
+				if v31 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v32))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Sfu != nil {
+		const prefix string = ",\"sfu\":"
+		out.RawString(prefix)
+		(*in.Sfu).MarshalEasyJSON(out)
+	}
+	if len(in.Dialout) != 0 {
+		const prefix string = ",\"dialout\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v33, v34 := range in.Dialout {
+				if v33 > 0 {
+					out.RawByte(',')
+				}
+				(v34).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Nats != nil {
+		const prefix string = ",\"nats\":"
+		out.RawString(prefix)
+		(*in.Nats).MarshalEasyJSON(out)
+	}
+	if in.Redis != nil {
+		const prefix string = ",\"redis\":"
+		out.RawString(prefix)
+		(*in.Redis).MarshalEasyJSON(out)
+	}
+	if len(in.Grpc) != 0 {
+		const prefix string = ",\"grpc\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v35, v36 := range in.Grpc {
+				if v35 > 0 {
+					out.RawByte(',')
+				}
+				(v36).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Etcd != nil {
+		const prefix string = ",\"etcd\":"
+		out.RawString(prefix)
+		(*in.Etcd).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BackendServerInfo) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling23(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BackendServerInfo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling23(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BackendServerInfo) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling23(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BackendServerInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling23(l, v)
+}
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling24(in *jlexer.Lexer, out *BackendServerHealthComponent) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "status":
+			out.Status = HealthStatus(in.String())
+		case "message":
+			out.Message = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling24(out *jwriter.Writer, in BackendServerHealthComponent) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Status))
+	}
+	if in.Message != "" {
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BackendServerHealthComponent) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling24(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BackendServerHealthComponent) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling24(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BackendServerHealthComponent) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling24(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BackendServerHealthComponent) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling24(l, v)
+}
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling25(in *jlexer.Lexer, out *BackendServerHealth) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "status":
+			out.Status = HealthStatus(in.String())
+		case "components":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Components = make(map[string]BackendServerHealthComponent)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v37 BackendServerHealthComponent
+					(v37).UnmarshalEasyJSON(in)
+					(out.Components)[key] = v37
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling25(out *jwriter.Writer, in BackendServerHealth) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Status))
+	}
+	{
+		const prefix string = ",\"components\":"
+		out.RawString(prefix)
+		if in.Components == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v38First := true
+			for v38Name, v38Value := range in.Components {
+				if v38First {
+					v38First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v38Name))
+				out.RawByte(':')
+				(v38Value).MarshalEasyJSON(out)
+			}
+			out.RawByte('}')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BackendServerHealth) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling25(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BackendServerHealth) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling25(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BackendServerHealth) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling25(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BackendServerHealth) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling25(l, v)
+}
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling26(in *jlexer.Lexer, out *BackendServerGeoIPOverrides) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "overrides":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Overrides = make(map[string]string)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v39 string
+					v39 = string(in.String())
+					(out.Overrides)[key] = v39
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling26(out *jwriter.Writer, in BackendServerGeoIPOverrides) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"overrides\":"
+		out.RawString(prefix[1:])
+		if in.Overrides == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v40First := true
+			for v40Name, v40Value := range in.Overrides {
+				if v40First {
+					v40First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v40Name))
+				out.RawByte(':')
+				out.String(string(v40Value))
+			}
+			out.RawByte('}')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BackendServerGeoIPOverrides) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling26(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BackendServerGeoIPOverrides) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling26(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BackendServerGeoIPOverrides) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling26(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BackendServerGeoIPOverrides) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling26(l, v)
+}
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling27(in *jlexer.Lexer, out *BackendServerGeoIPLookup) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "ip":
+			out.IP = string(in.String())
+		case "country":
+			out.Country = string(in.String())
+		case "continents":
+			if in.IsNull() {
+				in.Skip()
+				out.Continents = nil
+			} else {
+				in.Delim('[')
+				if out.Continents == nil {
+					if !in.IsDelim(']') {
+						out.Continents = make([]string, 0, 4)
+					} else {
+						out.Continents = []string{}
+					}
+				} else {
+					out.Continents = (out.Continents)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v41 string
+					v41 = string(in.String())
+					out.Continents = append(out.Continents, v41)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "proxies":
+			if in.IsNull() {
+				in.Skip()
+				out.Proxies = nil
+			} else {
+				in.Delim('[')
+				if out.Proxies == nil {
+					if !in.IsDelim(']') {
+						out.Proxies = make([]BackendServerInfoSfuProxy, 0, 0)
+					} else {
+						out.Proxies = []BackendServerInfoSfuProxy{}
+					}
+				} else {
+					out.Proxies = (out.Proxies)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v42 BackendServerInfoSfuProxy
+					(v42).UnmarshalEasyJSON(in)
+					out.Proxies = append(out.Proxies, v42)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling27(out *jwriter.Writer, in BackendServerGeoIPLookup) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"ip\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.IP))
+	}
+	{
+		const prefix string = ",\"country\":"
+		out.RawString(prefix)
+		out.String(string(in.Country))
+	}
+	if len(in.Continents) != 0 {
+		const prefix string = ",\"continents\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v43, v44 := range in.Continents {
+				if v43 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v44))
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Proxies) != 0 {
+		const prefix string = ",\"proxies\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v45, v46 := range in.Proxies {
+				if v45 > 0 {
+					out.RawByte(',')
+				}
+				(v46).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BackendServerGeoIPLookup) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling27(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BackendServerGeoIPLookup) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling27(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BackendServerGeoIPLookup) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling27(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BackendServerGeoIPLookup) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling27(l, v)
+}
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling28(in *jlexer.Lexer, out *BackendServerEvents) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "events":
+			if in.IsNull() {
+				in.Skip()
+				out.Events = nil
+			} else {
+				in.Delim('[')
+				if out.Events == nil {
+					if !in.IsDelim(']') {
+						out.Events = make([]RecentEvent, 0, 0)
+					} else {
+						out.Events = []RecentEvent{}
+					}
+				} else {
+					out.Events = (out.Events)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v47 RecentEvent
+					(v47).UnmarshalEasyJSON(in)
+					out.Events = append(out.Events, v47)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling28(out *jwriter.Writer, in BackendServerEvents) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"events\":"
+		out.RawString(prefix[1:])
+		if in.Events == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v48, v49 := range in.Events {
+				if v48 > 0 {
+					out.RawByte(',')
+				}
+				(v49).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BackendServerEvents) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling28(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BackendServerEvents) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling28(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BackendServerEvents) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling28(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BackendServerEvents) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling28(l, v)
+}
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling29(in *jlexer.Lexer, out *BackendServerDrainStatus) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "draining":
+			out.Draining = bool(in.Bool())
+		case "sessions":
+			out.Sessions = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling29(out *jwriter.Writer, in BackendServerDrainStatus) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"draining\":"
+		out.RawString(prefix[1:])
+		out.Bool(bool(in.Draining))
+	}
+	{
+		const prefix string = ",\"sessions\":"
+		out.RawString(prefix)
+		out.Int(int(in.Sessions))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BackendServerDrainStatus) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling29(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BackendServerDrainStatus) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling29(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BackendServerDrainStatus) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling29(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BackendServerDrainStatus) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling29(l, v)
+}
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling30(in *jlexer.Lexer, out *BackendServerConfigBackend) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.Id = string(in.String())
+		case "urls":
+			if in.IsNull() {
+				in.Skip()
+				out.Urls = nil
+			} else {
+				in.Delim('[')
+				if out.Urls == nil {
+					if !in.IsDelim(']') {
+						out.Urls = make([]string, 0, 4)
+					} else {
+						out.Urls = []string{}
+					}
+				} else {
+					out.Urls = (out.Urls)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v50 string
+					v50 = string(in.String())
+					out.Urls = append(out.Urls, v50)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "allowhttp":
+			out.AllowHttp = bool(in.Bool())
+		case "sessionlimit":
+			out.SessionLimit = uint64(in.Uint64())
+		case "concurrentrequests":
+			out.ConcurrentRequests = int(in.Int())
+		case "concurrentmessages":
+			out.ConcurrentMessages = int(in.Int())
+		case "messagerate":
+			out.MessageRate = int(in.Int())
+		case "joinrate":
+			out.JoinRate = int(in.Int())
+		case "maxstreambitrate":
+			out.MaxStreamBitrate = int(in.Int())
+		case "maxscreenbitrate":
+			out.MaxScreenBitrate = int(in.Int())
+		case "disabledfeatures":
+			if in.IsNull() {
+				in.Skip()
+				out.DisabledFeatures = nil
+			} else {
+				in.Delim('[')
+				if out.DisabledFeatures == nil {
+					if !in.IsDelim(']') {
+						out.DisabledFeatures = make([]string, 0, 4)
+					} else {
+						out.DisabledFeatures = []string{}
+					}
+				} else {
+					out.DisabledFeatures = (out.DisabledFeatures)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v51 string
+					v51 = string(in.String())
+					out.DisabledFeatures = append(out.DisabledFeatures, v51)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "dialoutallowedprefixes":
+			if in.IsNull() {
+				in.Skip()
+				out.DialoutAllowedPrefixes = nil
+			} else {
+				in.Delim('[')
+				if out.DialoutAllowedPrefixes == nil {
+					if !in.IsDelim(']') {
+						out.DialoutAllowedPrefixes = make([]string, 0, 4)
+					} else {
+						out.DialoutAllowedPrefixes = []string{}
+					}
+				} else {
+					out.DialoutAllowedPrefixes = (out.DialoutAllowedPrefixes)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v52 string
+					v52 = string(in.String())
+					out.DialoutAllowedPrefixes = append(out.DialoutAllowedPrefixes, v52)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "dialoutdeniedpatterns":
+			if in.IsNull() {
+				in.Skip()
+				out.DialoutDeniedPatterns = nil
+			} else {
+				in.Delim('[')
+				if out.DialoutDeniedPatterns == nil {
+					if !in.IsDelim(']') {
+						out.DialoutDeniedPatterns = make([]string, 0, 4)
+					} else {
+						out.DialoutDeniedPatterns = []string{}
+					}
+				} else {
+					out.DialoutDeniedPatterns = (out.DialoutDeniedPatterns)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v53 string
+					v53 = string(in.String())
+					out.DialoutDeniedPatterns = append(out.DialoutDeniedPatterns, v53)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "oidcissuer":
+			out.OidcIssuer = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling30(out *jwriter.Writer, in BackendServerConfigBackend) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Id))
+	}
+	{
+		const prefix string = ",\"urls\":"
+		out.RawString(prefix)
+		if in.Urls == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v54, v55 := range in.Urls {
+				if v54 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v55))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.AllowHttp {
+		const prefix string = ",\"allowhttp\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.AllowHttp))
+	}
+	if in.SessionLimit != 0 {
+		const prefix string = ",\"sessionlimit\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.SessionLimit))
+	}
+	if in.ConcurrentRequests != 0 {
+		const prefix string = ",\"concurrentrequests\":"
+		out.RawString(prefix)
+		out.Int(int(in.ConcurrentRequests))
+	}
+	if in.ConcurrentMessages != 0 {
+		const prefix string = ",\"concurrentmessages\":"
+		out.RawString(prefix)
+		out.Int(int(in.ConcurrentMessages))
+	}
+	if in.MessageRate != 0 {
+		const prefix string = ",\"messagerate\":"
+		out.RawString(prefix)
+		out.Int(int(in.MessageRate))
+	}
+	if in.JoinRate != 0 {
+		const prefix string = ",\"joinrate\":"
+		out.RawString(prefix)
+		out.Int(int(in.JoinRate))
+	}
+	if in.MaxStreamBitrate != 0 {
+		const prefix string = ",\"maxstreambitrate\":"
+		out.RawString(prefix)
+		out.Int(int(in.MaxStreamBitrate))
+	}
+	if in.MaxScreenBitrate != 0 {
+		const prefix string = ",\"maxscreenbitrate\":"
+		out.RawString(prefix)
+		out.Int(int(in.MaxScreenBitrate))
+	}
+	if len(in.DisabledFeatures) != 0 {
+		const prefix string = ",\"disabledfeatures\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v56, v57 := range in.DisabledFeatures {
+				if v56 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v57))
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.DialoutAllowedPrefixes) != 0 {
+		const prefix string = ",\"dialoutallowedprefixes\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v58, v59 := range in.DialoutAllowedPrefixes {
+				if v58 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v59))
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.DialoutDeniedPatterns) != 0 {
+		const prefix string = ",\"dialoutdeniedpatterns\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v60, v61 := range in.DialoutDeniedPatterns {
+				if v60 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v61))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.OidcIssuer != "" {
+		const prefix string = ",\"oidcissuer\":"
+		out.RawString(prefix)
+		out.String(string(in.OidcIssuer))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BackendServerConfigBackend) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling30(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BackendServerConfigBackend) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling30(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BackendServerConfigBackend) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling30(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BackendServerConfigBackend) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling30(l, v)
+}
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling31(in *jlexer.Lexer, out *BackendServerConfig) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "version":
+			out.Version = string(in.String())
+		case "loaded":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Loaded).UnmarshalJSON(data))
+			}
+		case "config":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				out.Config = make(map[string]map[string]string)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v62 map[string]string
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						in.Delim('{')
+						v62 = make(map[string]string)
+						for !in.IsDelim('}') {
+							key := string(in.String())
+							in.WantColon()
+							var v63 string
+							v63 = string(in.String())
+							(v62)[key] = v63
+							in.WantComma()
+						}
+						in.Delim('}')
+					}
+					(out.Config)[key] = v62
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "backends":
+			if in.IsNull() {
+				in.Skip()
+				out.Backends = nil
+			} else {
+				in.Delim('[')
+				if out.Backends == nil {
+					if !in.IsDelim(']') {
+						out.Backends = make([]BackendServerConfigBackend, 0, 0)
+					} else {
+						out.Backends = []BackendServerConfigBackend{}
+					}
+				} else {
+					out.Backends = (out.Backends)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v64 BackendServerConfigBackend
+					(v64).UnmarshalEasyJSON(in)
+					out.Backends = append(out.Backends, v64)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling31(out *jwriter.Writer, in BackendServerConfig) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"version\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Version))
+	}
+	{
+		const prefix string = ",\"loaded\":"
+		out.RawString(prefix)
+		out.Raw((in.Loaded).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"config\":"
+		out.RawString(prefix)
+		if in.Config == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v65First := true
+			for v65Name, v65Value := range in.Config {
+				if v65First {
+					v65First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v65Name))
+				out.RawByte(':')
+				if v65Value == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+					out.RawString(`null`)
+				} else {
+					out.RawByte('{')
+					v66First := true
+					for v66Name, v66Value := range v65Value {
+						if v66First {
+							v66First = false
+						} else {
+							out.RawByte(',')
+						}
+						out.String(string(v66Name))
+						out.RawByte(':')
+						out.String(string(v66Value))
+					}
+					out.RawByte('}')
+				}
+			}
+			out.RawByte('}')
+		}
+	}
+	{
+		const prefix string = ",\"backends\":"
+		out.RawString(prefix)
+		if in.Backends == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v67, v68 := range in.Backends {
+				if v67 > 0 {
+					out.RawByte(',')
+				}
+				(v68).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BackendServerConfig) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling31(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BackendServerConfig) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling31(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BackendServerConfig) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling31(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BackendServerConfig) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling31(l, v)
+}
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling32(in *jlexer.Lexer, out *BackendRoomUpdateRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "userids":
+			if in.IsNull() {
+				in.Skip()
+				out.UserIds = nil
+			} else {
+				in.Delim('[')
+				if out.UserIds == nil {
+					if !in.IsDelim(']') {
+						out.UserIds = make([]string, 0, 4)
+					} else {
+						out.UserIds = []string{}
+					}
+				} else {
+					out.UserIds = (out.UserIds)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v69 string
+					v69 = string(in.String())
+					out.UserIds = append(out.UserIds, v69)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "properties":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Properties).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling32(out *jwriter.Writer, in BackendRoomUpdateRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if len(in.UserIds) != 0 {
+		const prefix string = ",\"userids\":"
+		first = false
+		out.RawString(prefix[1:])
+		{
+			out.RawByte('[')
+			for v70, v71 := range in.UserIds {
+				if v70 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v71))
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Properties) != 0 {
+		const prefix string = ",\"properties\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.Properties).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BackendRoomUpdateRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling32(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BackendRoomUpdateRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling32(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BackendRoomUpdateRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling32(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BackendRoomUpdateRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling32(l, v)
+}
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling33(in *jlexer.Lexer, out *BackendRoomTransientRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "action":
+			out.Action = TransientAction(in.String())
+		case "key":
+			out.Key = string(in.String())
+		case "value":
+			if m, ok := out.Value.(easyjson.Unmarshaler); ok {
+				m.UnmarshalEasyJSON(in)
+			} else if m, ok := out.Value.(json.Unmarshaler); ok {
+				_ = m.UnmarshalJSON(in.Raw())
+			} else {
+				out.Value = in.Interface()
+			}
+		case "ttl":
+			out.TTL = time.Duration(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling33(out *jwriter.Writer, in BackendRoomTransientRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"action\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Action))
+	}
+	{
+		const prefix string = ",\"key\":"
 		out.RawString(prefix)
-		out.String(string(in.Author))
+		out.String(string(in.Key))
 	}
-	if in.DataChannels != nil {
-		const prefix string = ",\"datachannels\":"
+	if in.Value != nil {
+		const prefix string = ",\"value\":"
 		out.RawString(prefix)
-		out.Bool(bool(*in.DataChannels))
+		if m, ok := in.Value.(easyjson.Marshaler); ok {
+			m.MarshalEasyJSON(out)
+		} else if m, ok := in.Value.(json.Marshaler); ok {
+			out.Raw(m.MarshalJSON())
+		} else {
+			out.Raw(json.Marshal(in.Value))
+		}
 	}
-	if in.FullTrickle != nil {
-		const prefix string = ",\"fulltrickle\":"
+	if in.TTL != 0 {
+		const prefix string = ",\"ttl\":"
 		out.RawString(prefix)
-		out.Bool(bool(*in.FullTrickle))
+		out.Int64(int64(in.TTL))
 	}
-	if in.LocalIP != "" {
-		const prefix string = ",\"localip\":"
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BackendRoomTransientRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling33(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BackendRoomTransientRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling33(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BackendRoomTransientRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling33(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BackendRoomTransientRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling33(l, v)
+}
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling34(in *jlexer.Lexer, out *BackendRoomSwitchToMessageRequest) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "roomid":
+			out.RoomId = string(in.String())
+		case "sessions":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Sessions).UnmarshalJSON(data))
+			}
+		case "sessionslist":
+			if in.IsNull() {
+				in.Skip()
+				out.SessionsList = nil
+			} else {
+				in.Delim('[')
+				if out.SessionsList == nil {
+					if !in.IsDelim(']') {
+						out.SessionsList = make(BackendRoomSwitchToPublicSessionsList, 0, 4)
+					} else {
+						out.SessionsList = BackendRoomSwitchToPublicSessionsList{}
+					}
+				} else {
+					out.SessionsList = (out.SessionsList)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v72 PublicSessionId
+					v72 = PublicSessionId(in.String())
+					out.SessionsList = append(out.SessionsList, v72)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "sessionsmap":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.SessionsMap = make(BackendRoomSwitchToPublicSessionsMap)
+				} else {
+					out.SessionsMap = nil
+				}
+				for !in.IsDelim('}') {
+					key := PublicSessionId(in.String())
+					in.WantColon()
+					var v73 jsontext.Value
+					if data := in.Raw(); in.Ok() {
+						in.AddError((v73).UnmarshalJSON(data))
+					}
+					(out.SessionsMap)[key] = v73
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling34(out *jwriter.Writer, in BackendRoomSwitchToMessageRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"roomid\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.RoomId))
+	}
+	if len(in.Sessions) != 0 {
+		const prefix string = ",\"sessions\":"
 		out.RawString(prefix)
-		out.String(string(in.LocalIP))
+		out.Raw((in.Sessions).MarshalJSON())
 	}
-	if in.IPv6 != nil {
-		const prefix string = ",\"ipv6\":"
+	if len(in.SessionsList) != 0 {
+		const prefix string = ",\"sessionslist\":"
 		out.RawString(prefix)
-		out.Bool(bool(*in.IPv6))
+		{
+			out.RawByte('[')
+			for v74, v75 := range in.SessionsList {
+				if v74 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v75))
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.SessionsMap) != 0 {
+		const prefix string = ",\"sessionsmap\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('{')
+			v76First := true
+			for v76Name, v76Value := range in.SessionsMap {
+				if v76First {
+					v76First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v76Name))
+				out.RawByte(':')
+				out.Raw((v76Value).MarshalJSON())
+			}
+			out.RawByte('}')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BackendRoomSwitchToMessageRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling34(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BackendRoomSwitchToMessageRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling34(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BackendRoomSwitchToMessageRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling34(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BackendRoomSwitchToMessageRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling34(l, v)
+}
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling35(in *jlexer.Lexer, out *BackendRoomRecordingResponse) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "status":
+			out.Status = RecordingStatus(in.String())
+		case "error":
+			if in.IsNull() {
+				in.Skip()
+				out.Error = nil
+			} else {
+				if out.Error == nil {
+					out.Error = new(Error)
+				}
+				(*out.Error).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
 	}
-	if in.VideoRoom != nil {
-		const prefix string = ",\"videoroom\":"
-		out.RawString(prefix)
-		(*in.VideoRoom).MarshalEasyJSON(out)
+}
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling35(out *jwriter.Writer, in BackendRoomRecordingResponse) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Status != "" {
+		const prefix string = ",\"status\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Status))
+	}
+	if in.Error != nil {
+		const prefix string = ",\"error\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(*in.Error).MarshalEasyJSON(out)
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendServerInfoSfuJanus) MarshalJSON() ([]byte, error) {
+func (v BackendRoomRecordingResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling9(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling35(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendServerInfoSfuJanus) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling9(w, v)
+func (v BackendRoomRecordingResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling35(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendServerInfoSfuJanus) UnmarshalJSON(data []byte) error {
+func (v *BackendRoomRecordingResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling9(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling35(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendServerInfoSfuJanus) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling9(l, v)
+func (v *BackendRoomRecordingResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling35(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling10(in *jlexer.Lexer, out *BackendServerInfoSfu) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling36(in *jlexer.Lexer, out *BackendRoomRecordingRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1212,40 +4469,11 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling10(in *jle
 			continue
 		}
 		switch key {
-		case "mode":
-			out.Mode = SfuMode(in.String())
-		case "janus":
-			if in.IsNull() {
-				in.Skip()
-				out.Janus = nil
-			} else {
-				if out.Janus == nil {
-					out.Janus = new(BackendServerInfoSfuJanus)
-				}
-				(*out.Janus).UnmarshalEasyJSON(in)
-			}
-		case "proxies":
-			if in.IsNull() {
-				in.Skip()
-				out.Proxies = nil
-			} else {
-				in.Delim('[')
-				if out.Proxies == nil {
-					if !in.IsDelim(']') {
-						out.Proxies = make([]BackendServerInfoSfuProxy, 0, 0)
-					} else {
-						out.Proxies = []BackendServerInfoSfuProxy{}
-					}
-				} else {
-					out.Proxies = (out.Proxies)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v7 BackendServerInfoSfuProxy
-					(v7).UnmarshalEasyJSON(in)
-					out.Proxies = append(out.Proxies, v7)
-					in.WantComma()
-				}
-				in.Delim(']')
+		case "action":
+			out.Action = string(in.String())
+		case "options":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Options).UnmarshalJSON(data))
 			}
 		default:
 			in.SkipRecursive()
@@ -1257,61 +4485,47 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling10(in *jle
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling10(out *jwriter.Writer, in BackendServerInfoSfu) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling36(out *jwriter.Writer, in BackendRoomRecordingRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"mode\":"
+		const prefix string = ",\"action\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Mode))
-	}
-	if in.Janus != nil {
-		const prefix string = ",\"janus\":"
-		out.RawString(prefix)
-		(*in.Janus).MarshalEasyJSON(out)
+		out.String(string(in.Action))
 	}
-	if len(in.Proxies) != 0 {
-		const prefix string = ",\"proxies\":"
+	if len(in.Options) != 0 {
+		const prefix string = ",\"options\":"
 		out.RawString(prefix)
-		{
-			out.RawByte('[')
-			for v8, v9 := range in.Proxies {
-				if v8 > 0 {
-					out.RawByte(',')
-				}
-				(v9).MarshalEasyJSON(out)
-			}
-			out.RawByte(']')
-		}
+		out.Raw((in.Options).MarshalJSON())
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendServerInfoSfu) MarshalJSON() ([]byte, error) {
+func (v BackendRoomRecordingRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling10(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling36(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendServerInfoSfu) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling10(w, v)
+func (v BackendRoomRecordingRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling36(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendServerInfoSfu) UnmarshalJSON(data []byte) error {
+func (v *BackendRoomRecordingRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling10(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling36(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendServerInfoSfu) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling10(l, v)
+func (v *BackendRoomRecordingRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling36(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling11(in *jlexer.Lexer, out *BackendServerInfoNats) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling37(in *jlexer.Lexer, out *BackendRoomParticipantsRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1330,39 +4544,100 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling11(in *jle
 			continue
 		}
 		switch key {
-		case "urls":
+		case "changed":
 			if in.IsNull() {
 				in.Skip()
-				out.Urls = nil
+				out.Changed = nil
 			} else {
 				in.Delim('[')
-				if out.Urls == nil {
+				if out.Changed == nil {
 					if !in.IsDelim(']') {
-						out.Urls = make([]string, 0, 4)
+						out.Changed = make([]StringMap, 0, 8)
 					} else {
-						out.Urls = []string{}
+						out.Changed = []StringMap{}
 					}
 				} else {
-					out.Urls = (out.Urls)[:0]
+					out.Changed = (out.Changed)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v77 StringMap
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						in.Delim('{')
+						if !in.IsDelim('}') {
+							v77 = make(StringMap)
+						} else {
+							v77 = nil
+						}
+						for !in.IsDelim('}') {
+							key := string(in.String())
+							in.WantColon()
+							var v78 interface{}
+							if m, ok := v78.(easyjson.Unmarshaler); ok {
+								m.UnmarshalEasyJSON(in)
+							} else if m, ok := v78.(json.Unmarshaler); ok {
+								_ = m.UnmarshalJSON(in.Raw())
+							} else {
+								v78 = in.Interface()
+							}
+							(v77)[key] = v78
+							in.WantComma()
+						}
+						in.Delim('}')
+					}
+					out.Changed = append(out.Changed, v77)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "users":
+			if in.IsNull() {
+				in.Skip()
+				out.Users = nil
+			} else {
+				in.Delim('[')
+				if out.Users == nil {
+					if !in.IsDelim(']') {
+						out.Users = make([]StringMap, 0, 8)
+					} else {
+						out.Users = []StringMap{}
+					}
+				} else {
+					out.Users = (out.Users)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v10 string
-					v10 = string(in.String())
-					out.Urls = append(out.Urls, v10)
+					var v79 StringMap
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						in.Delim('{')
+						if !in.IsDelim('}') {
+							v79 = make(StringMap)
+						} else {
+							v79 = nil
+						}
+						for !in.IsDelim('}') {
+							key := string(in.String())
+							in.WantColon()
+							var v80 interface{}
+							if m, ok := v80.(easyjson.Unmarshaler); ok {
+								m.UnmarshalEasyJSON(in)
+							} else if m, ok := v80.(json.Unmarshaler); ok {
+								_ = m.UnmarshalJSON(in.Raw())
+							} else {
+								v80 = in.Interface()
+							}
+							(v79)[key] = v80
+							in.WantComma()
+						}
+						in.Delim('}')
+					}
+					out.Users = append(out.Users, v79)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "connected":
-			out.Connected = bool(in.Bool())
-		case "serverurl":
-			out.ServerUrl = string(in.String())
-		case "serverid":
-			out.ServerID = string(in.String())
-		case "version":
-			out.ServerVersion = string(in.String())
-		case "clustername":
-			out.ClusterName = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -1373,78 +4648,115 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling11(in *jle
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling11(out *jwriter.Writer, in BackendServerInfoNats) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling37(out *jwriter.Writer, in BackendRoomParticipantsRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	{
-		const prefix string = ",\"urls\":"
+	if len(in.Changed) != 0 {
+		const prefix string = ",\"changed\":"
+		first = false
 		out.RawString(prefix[1:])
-		if in.Urls == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
+		{
 			out.RawByte('[')
-			for v11, v12 := range in.Urls {
-				if v11 > 0 {
+			for v81, v82 := range in.Changed {
+				if v81 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v12))
+				if v82 == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+					out.RawString(`null`)
+				} else {
+					out.RawByte('{')
+					v83First := true
+					for v83Name, v83Value := range v82 {
+						if v83First {
+							v83First = false
+						} else {
+							out.RawByte(',')
+						}
+						out.String(string(v83Name))
+						out.RawByte(':')
+						if m, ok := v83Value.(easyjson.Marshaler); ok {
+							m.MarshalEasyJSON(out)
+						} else if m, ok := v83Value.(json.Marshaler); ok {
+							out.Raw(m.MarshalJSON())
+						} else {
+							out.Raw(json.Marshal(v83Value))
+						}
+					}
+					out.RawByte('}')
+				}
 			}
 			out.RawByte(']')
 		}
 	}
-	{
-		const prefix string = ",\"connected\":"
-		out.RawString(prefix)
-		out.Bool(bool(in.Connected))
-	}
-	if in.ServerUrl != "" {
-		const prefix string = ",\"serverurl\":"
-		out.RawString(prefix)
-		out.String(string(in.ServerUrl))
-	}
-	if in.ServerID != "" {
-		const prefix string = ",\"serverid\":"
-		out.RawString(prefix)
-		out.String(string(in.ServerID))
-	}
-	if in.ServerVersion != "" {
-		const prefix string = ",\"version\":"
-		out.RawString(prefix)
-		out.String(string(in.ServerVersion))
-	}
-	if in.ClusterName != "" {
-		const prefix string = ",\"clustername\":"
-		out.RawString(prefix)
-		out.String(string(in.ClusterName))
+	if len(in.Users) != 0 {
+		const prefix string = ",\"users\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		{
+			out.RawByte('[')
+			for v84, v85 := range in.Users {
+				if v84 > 0 {
+					out.RawByte(',')
+				}
+				if v85 == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+					out.RawString(`null`)
+				} else {
+					out.RawByte('{')
+					v86First := true
+					for v86Name, v86Value := range v85 {
+						if v86First {
+							v86First = false
+						} else {
+							out.RawByte(',')
+						}
+						out.String(string(v86Name))
+						out.RawByte(':')
+						if m, ok := v86Value.(easyjson.Marshaler); ok {
+							m.MarshalEasyJSON(out)
+						} else if m, ok := v86Value.(json.Marshaler); ok {
+							out.Raw(m.MarshalJSON())
+						} else {
+							out.Raw(json.Marshal(v86Value))
+						}
+					}
+					out.RawByte('}')
+				}
+			}
+			out.RawByte(']')
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendServerInfoNats) MarshalJSON() ([]byte, error) {
+func (v BackendRoomParticipantsRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling11(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling37(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendServerInfoNats) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling11(w, v)
+func (v BackendRoomParticipantsRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling37(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendServerInfoNats) UnmarshalJSON(data []byte) error {
+func (v *BackendRoomParticipantsRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling11(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling37(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendServerInfoNats) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling11(l, v)
+func (v *BackendRoomParticipantsRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling37(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling12(in *jlexer.Lexer, out *BackendServerInfoGrpc) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling38(in *jlexer.Lexer, out *BackendRoomMessageRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1463,14 +4775,10 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling12(in *jle
 			continue
 		}
 		switch key {
-		case "target":
-			out.Target = string(in.String())
-		case "ip":
-			out.IP = string(in.String())
-		case "connected":
-			out.Connected = bool(in.Bool())
-		case "version":
-			out.Version = string(in.String())
+		case "data":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Data).UnmarshalJSON(data))
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -1481,57 +4789,43 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling12(in *jle
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling12(out *jwriter.Writer, in BackendServerInfoGrpc) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling38(out *jwriter.Writer, in BackendRoomMessageRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	{
-		const prefix string = ",\"target\":"
+	if len(in.Data) != 0 {
+		const prefix string = ",\"data\":"
+		first = false
 		out.RawString(prefix[1:])
-		out.String(string(in.Target))
-	}
-	if in.IP != "" {
-		const prefix string = ",\"ip\":"
-		out.RawString(prefix)
-		out.String(string(in.IP))
-	}
-	{
-		const prefix string = ",\"connected\":"
-		out.RawString(prefix)
-		out.Bool(bool(in.Connected))
-	}
-	if in.Version != "" {
-		const prefix string = ",\"version\":"
-		out.RawString(prefix)
-		out.String(string(in.Version))
+		out.Raw((in.Data).MarshalJSON())
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendServerInfoGrpc) MarshalJSON() ([]byte, error) {
+func (v BackendRoomMessageRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling12(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling38(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendServerInfoGrpc) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling12(w, v)
+func (v BackendRoomMessageRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling38(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendServerInfoGrpc) UnmarshalJSON(data []byte) error {
+func (v *BackendRoomMessageRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling12(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling38(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendServerInfoGrpc) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling12(l, v)
+func (v *BackendRoomMessageRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling38(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling13(in *jlexer.Lexer, out *BackendServerInfoEtcd) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling39(in *jlexer.Lexer, out *BackendRoomInviteRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1550,159 +4844,56 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling13(in *jle
 			continue
 		}
 		switch key {
-		case "endpoints":
+		case "userids":
 			if in.IsNull() {
 				in.Skip()
-				out.Endpoints = nil
+				out.UserIds = nil
 			} else {
 				in.Delim('[')
-				if out.Endpoints == nil {
+				if out.UserIds == nil {
 					if !in.IsDelim(']') {
-						out.Endpoints = make([]string, 0, 4)
+						out.UserIds = make([]string, 0, 4)
 					} else {
-						out.Endpoints = []string{}
+						out.UserIds = []string{}
 					}
 				} else {
-					out.Endpoints = (out.Endpoints)[:0]
+					out.UserIds = (out.UserIds)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v13 string
-					v13 = string(in.String())
-					out.Endpoints = append(out.Endpoints, v13)
+					var v87 string
+					v87 = string(in.String())
+					out.UserIds = append(out.UserIds, v87)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "active":
-			out.Active = string(in.String())
-		case "connected":
-			if in.IsNull() {
-				in.Skip()
-				out.Connected = nil
-			} else {
-				if out.Connected == nil {
-					out.Connected = new(bool)
-				}
-				*out.Connected = bool(in.Bool())
-			}
-		default:
-			in.SkipRecursive()
-		}
-		in.WantComma()
-	}
-	in.Delim('}')
-	if isTopLevel {
-		in.Consumed()
-	}
-}
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling13(out *jwriter.Writer, in BackendServerInfoEtcd) {
-	out.RawByte('{')
-	first := true
-	_ = first
-	{
-		const prefix string = ",\"endpoints\":"
-		out.RawString(prefix[1:])
-		if in.Endpoints == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
-		} else {
-			out.RawByte('[')
-			for v14, v15 := range in.Endpoints {
-				if v14 > 0 {
-					out.RawByte(',')
-				}
-				out.String(string(v15))
-			}
-			out.RawByte(']')
-		}
-	}
-	if in.Active != "" {
-		const prefix string = ",\"active\":"
-		out.RawString(prefix)
-		out.String(string(in.Active))
-	}
-	if in.Connected != nil {
-		const prefix string = ",\"connected\":"
-		out.RawString(prefix)
-		out.Bool(bool(*in.Connected))
-	}
-	out.RawByte('}')
-}
-
-// MarshalJSON supports json.Marshaler interface
-func (v BackendServerInfoEtcd) MarshalJSON() ([]byte, error) {
-	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling13(&w, v)
-	return w.Buffer.BuildBytes(), w.Error
-}
-
-// MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendServerInfoEtcd) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling13(w, v)
-}
-
-// UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendServerInfoEtcd) UnmarshalJSON(data []byte) error {
-	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling13(&r, v)
-	return r.Error()
-}
-
-// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendServerInfoEtcd) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling13(l, v)
-}
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling14(in *jlexer.Lexer, out *BackendServerInfoDialout) {
-	isTopLevel := in.IsStart()
-	if in.IsNull() {
-		if isTopLevel {
-			in.Consumed()
-		}
-		in.Skip()
-		return
-	}
-	in.Delim('{')
-	for !in.IsDelim('}') {
-		key := in.UnsafeFieldName(false)
-		in.WantColon()
-		if in.IsNull() {
-			in.Skip()
-			in.WantComma()
-			continue
-		}
-		switch key {
-		case "sessionid":
-			out.SessionId = PublicSessionId(in.String())
-		case "connected":
-			out.Connected = bool(in.Bool())
-		case "address":
-			out.Address = string(in.String())
-		case "useragent":
-			out.UserAgent = string(in.String())
-		case "version":
-			out.Version = string(in.String())
-		case "features":
+		case "alluserids":
 			if in.IsNull() {
 				in.Skip()
-				out.Features = nil
+				out.AllUserIds = nil
 			} else {
 				in.Delim('[')
-				if out.Features == nil {
+				if out.AllUserIds == nil {
 					if !in.IsDelim(']') {
-						out.Features = make([]string, 0, 4)
+						out.AllUserIds = make([]string, 0, 4)
 					} else {
-						out.Features = []string{}
+						out.AllUserIds = []string{}
 					}
 				} else {
-					out.Features = (out.Features)[:0]
+					out.AllUserIds = (out.AllUserIds)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v16 string
-					v16 = string(in.String())
-					out.Features = append(out.Features, v16)
+					var v88 string
+					v88 = string(in.String())
+					out.AllUserIds = append(out.AllUserIds, v88)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
+		case "properties":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Properties).UnmarshalJSON(data))
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -1713,76 +4904,81 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling14(in *jle
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling14(out *jwriter.Writer, in BackendServerInfoDialout) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling39(out *jwriter.Writer, in BackendRoomInviteRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	{
-		const prefix string = ",\"sessionid\":"
+	if len(in.UserIds) != 0 {
+		const prefix string = ",\"userids\":"
+		first = false
 		out.RawString(prefix[1:])
-		out.String(string(in.SessionId))
-	}
-	{
-		const prefix string = ",\"connected\":"
-		out.RawString(prefix)
-		out.Bool(bool(in.Connected))
-	}
-	if in.Address != "" {
-		const prefix string = ",\"address\":"
-		out.RawString(prefix)
-		out.String(string(in.Address))
-	}
-	if in.UserAgent != "" {
-		const prefix string = ",\"useragent\":"
-		out.RawString(prefix)
-		out.String(string(in.UserAgent))
-	}
-	if in.Version != "" {
-		const prefix string = ",\"version\":"
-		out.RawString(prefix)
-		out.String(string(in.Version))
+		{
+			out.RawByte('[')
+			for v89, v90 := range in.UserIds {
+				if v89 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v90))
+			}
+			out.RawByte(']')
+		}
 	}
-	if len(in.Features) != 0 {
-		const prefix string = ",\"features\":"
-		out.RawString(prefix)
+	if len(in.AllUserIds) != 0 {
+		const prefix string = ",\"alluserids\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
 		{
 			out.RawByte('[')
-			for v17, v18 := range in.Features {
-				if v17 > 0 {
+			for v91, v92 := range in.AllUserIds {
+				if v91 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v18))
+				out.String(string(v92))
 			}
 			out.RawByte(']')
 		}
 	}
+	if len(in.Properties) != 0 {
+		const prefix string = ",\"properties\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.Properties).MarshalJSON())
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendServerInfoDialout) MarshalJSON() ([]byte, error) {
+func (v BackendRoomInviteRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling14(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling39(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendServerInfoDialout) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling14(w, v)
+func (v BackendRoomInviteRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling39(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendServerInfoDialout) UnmarshalJSON(data []byte) error {
+func (v *BackendRoomInviteRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling14(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling39(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendServerInfoDialout) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling14(l, v)
+func (v *BackendRoomInviteRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling39(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling15(in *jlexer.Lexer, out *BackendServerInfo) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling40(in *jlexer.Lexer, out *BackendRoomInCallRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1801,107 +4997,106 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling15(in *jle
 			continue
 		}
 		switch key {
-		case "version":
-			out.Version = string(in.String())
-		case "features":
+		case "incall":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.InCall).UnmarshalJSON(data))
+			}
+		case "all":
+			out.All = bool(in.Bool())
+		case "changed":
 			if in.IsNull() {
 				in.Skip()
-				out.Features = nil
+				out.Changed = nil
 			} else {
 				in.Delim('[')
-				if out.Features == nil {
+				if out.Changed == nil {
 					if !in.IsDelim(']') {
-						out.Features = make([]string, 0, 4)
+						out.Changed = make([]StringMap, 0, 8)
 					} else {
-						out.Features = []string{}
+						out.Changed = []StringMap{}
 					}
 				} else {
-					out.Features = (out.Features)[:0]
+					out.Changed = (out.Changed)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v19 string
-					v19 = string(in.String())
-					out.Features = append(out.Features, v19)
-					in.WantComma()
-				}
-				in.Delim(']')
-			}
-		case "sfu":
-			if in.IsNull() {
-				in.Skip()
-				out.Sfu = nil
-			} else {
-				if out.Sfu == nil {
-					out.Sfu = new(BackendServerInfoSfu)
-				}
-				(*out.Sfu).UnmarshalEasyJSON(in)
-			}
-		case "dialout":
-			if in.IsNull() {
-				in.Skip()
-				out.Dialout = nil
-			} else {
-				in.Delim('[')
-				if out.Dialout == nil {
-					if !in.IsDelim(']') {
-						out.Dialout = make([]BackendServerInfoDialout, 0, 0)
+					var v93 StringMap
+					if in.IsNull() {
+						in.Skip()
 					} else {
-						out.Dialout = []BackendServerInfoDialout{}
+						in.Delim('{')
+						if !in.IsDelim('}') {
+							v93 = make(StringMap)
+						} else {
+							v93 = nil
+						}
+						for !in.IsDelim('}') {
+							key := string(in.String())
+							in.WantColon()
+							var v94 interface{}
+							if m, ok := v94.(easyjson.Unmarshaler); ok {
+								m.UnmarshalEasyJSON(in)
+							} else if m, ok := v94.(json.Unmarshaler); ok {
+								_ = m.UnmarshalJSON(in.Raw())
+							} else {
+								v94 = in.Interface()
+							}
+							(v93)[key] = v94
+							in.WantComma()
+						}
+						in.Delim('}')
 					}
-				} else {
-					out.Dialout = (out.Dialout)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v20 BackendServerInfoDialout
-					(v20).UnmarshalEasyJSON(in)
-					out.Dialout = append(out.Dialout, v20)
+					out.Changed = append(out.Changed, v93)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "nats":
-			if in.IsNull() {
-				in.Skip()
-				out.Nats = nil
-			} else {
-				if out.Nats == nil {
-					out.Nats = new(BackendServerInfoNats)
-				}
-				(*out.Nats).UnmarshalEasyJSON(in)
-			}
-		case "grpc":
+		case "users":
 			if in.IsNull() {
 				in.Skip()
-				out.Grpc = nil
+				out.Users = nil
 			} else {
 				in.Delim('[')
-				if out.Grpc == nil {
+				if out.Users == nil {
 					if !in.IsDelim(']') {
-						out.Grpc = make([]BackendServerInfoGrpc, 0, 1)
+						out.Users = make([]StringMap, 0, 8)
 					} else {
-						out.Grpc = []BackendServerInfoGrpc{}
+						out.Users = []StringMap{}
 					}
 				} else {
-					out.Grpc = (out.Grpc)[:0]
+					out.Users = (out.Users)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v21 BackendServerInfoGrpc
-					(v21).UnmarshalEasyJSON(in)
-					out.Grpc = append(out.Grpc, v21)
+					var v95 StringMap
+					if in.IsNull() {
+						in.Skip()
+					} else {
+						in.Delim('{')
+						if !in.IsDelim('}') {
+							v95 = make(StringMap)
+						} else {
+							v95 = nil
+						}
+						for !in.IsDelim('}') {
+							key := string(in.String())
+							in.WantColon()
+							var v96 interface{}
+							if m, ok := v96.(easyjson.Unmarshaler); ok {
+								m.UnmarshalEasyJSON(in)
+							} else if m, ok := v96.(json.Unmarshaler); ok {
+								_ = m.UnmarshalJSON(in.Raw())
+							} else {
+								v96 = in.Interface()
+							}
+							(v95)[key] = v96
+							in.WantComma()
+						}
+						in.Delim('}')
+					}
+					out.Users = append(out.Users, v95)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "etcd":
-			if in.IsNull() {
-				in.Skip()
-				out.Etcd = nil
-			} else {
-				if out.Etcd == nil {
-					out.Etcd = new(BackendServerInfoEtcd)
-				}
-				(*out.Etcd).UnmarshalEasyJSON(in)
-			}
 		default:
 			in.SkipRecursive()
 		}
@@ -1912,101 +5107,135 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling15(in *jle
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling15(out *jwriter.Writer, in BackendServerInfo) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling40(out *jwriter.Writer, in BackendRoomInCallRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	{
-		const prefix string = ",\"version\":"
+	if len(in.InCall) != 0 {
+		const prefix string = ",\"incall\":"
+		first = false
 		out.RawString(prefix[1:])
-		out.String(string(in.Version))
+		out.Raw((in.InCall).MarshalJSON())
 	}
-	{
-		const prefix string = ",\"features\":"
-		out.RawString(prefix)
-		if in.Features == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
-			out.RawString("null")
+	if in.All {
+		const prefix string = ",\"all\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
 		} else {
-			out.RawByte('[')
-			for v22, v23 := range in.Features {
-				if v22 > 0 {
-					out.RawByte(',')
-				}
-				out.String(string(v23))
-			}
-			out.RawByte(']')
+			out.RawString(prefix)
 		}
+		out.Bool(bool(in.All))
 	}
-	if in.Sfu != nil {
-		const prefix string = ",\"sfu\":"
-		out.RawString(prefix)
-		(*in.Sfu).MarshalEasyJSON(out)
-	}
-	if len(in.Dialout) != 0 {
-		const prefix string = ",\"dialout\":"
-		out.RawString(prefix)
+	if len(in.Changed) != 0 {
+		const prefix string = ",\"changed\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
 		{
 			out.RawByte('[')
-			for v24, v25 := range in.Dialout {
-				if v24 > 0 {
+			for v97, v98 := range in.Changed {
+				if v97 > 0 {
 					out.RawByte(',')
 				}
-				(v25).MarshalEasyJSON(out)
+				if v98 == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+					out.RawString(`null`)
+				} else {
+					out.RawByte('{')
+					v99First := true
+					for v99Name, v99Value := range v98 {
+						if v99First {
+							v99First = false
+						} else {
+							out.RawByte(',')
+						}
+						out.String(string(v99Name))
+						out.RawByte(':')
+						if m, ok := v99Value.(easyjson.Marshaler); ok {
+							m.MarshalEasyJSON(out)
+						} else if m, ok := v99Value.(json.Marshaler); ok {
+							out.Raw(m.MarshalJSON())
+						} else {
+							out.Raw(json.Marshal(v99Value))
+						}
+					}
+					out.RawByte('}')
+				}
 			}
 			out.RawByte(']')
 		}
 	}
-	if in.Nats != nil {
-		const prefix string = ",\"nats\":"
-		out.RawString(prefix)
-		(*in.Nats).MarshalEasyJSON(out)
-	}
-	if len(in.Grpc) != 0 {
-		const prefix string = ",\"grpc\":"
-		out.RawString(prefix)
+	if len(in.Users) != 0 {
+		const prefix string = ",\"users\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
 		{
 			out.RawByte('[')
-			for v26, v27 := range in.Grpc {
-				if v26 > 0 {
+			for v100, v101 := range in.Users {
+				if v100 > 0 {
 					out.RawByte(',')
 				}
-				(v27).MarshalEasyJSON(out)
+				if v101 == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+					out.RawString(`null`)
+				} else {
+					out.RawByte('{')
+					v102First := true
+					for v102Name, v102Value := range v101 {
+						if v102First {
+							v102First = false
+						} else {
+							out.RawByte(',')
+						}
+						out.String(string(v102Name))
+						out.RawByte(':')
+						if m, ok := v102Value.(easyjson.Marshaler); ok {
+							m.MarshalEasyJSON(out)
+						} else if m, ok := v102Value.(json.Marshaler); ok {
+							out.Raw(m.MarshalJSON())
+						} else {
+							out.Raw(json.Marshal(v102Value))
+						}
+					}
+					out.RawByte('}')
+				}
 			}
 			out.RawByte(']')
 		}
 	}
-	if in.Etcd != nil {
-		const prefix string = ",\"etcd\":"
-		out.RawString(prefix)
-		(*in.Etcd).MarshalEasyJSON(out)
-	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendServerInfo) MarshalJSON() ([]byte, error) {
+func (v BackendRoomInCallRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling15(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling40(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendServerInfo) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling15(w, v)
+func (v BackendRoomInCallRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling40(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendServerInfo) UnmarshalJSON(data []byte) error {
+func (v *BackendRoomInCallRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling15(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling40(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendServerInfo) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling15(l, v)
+func (v *BackendRoomInCallRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling40(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling16(in *jlexer.Lexer, out *BackendRoomUpdateRequest) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling41(in *jlexer.Lexer, out *BackendRoomDisinviteRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2028,22 +5257,68 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling16(in *jle
 		case "userids":
 			if in.IsNull() {
 				in.Skip()
-				out.UserIds = nil
+				out.UserIds = nil
+			} else {
+				in.Delim('[')
+				if out.UserIds == nil {
+					if !in.IsDelim(']') {
+						out.UserIds = make([]string, 0, 4)
+					} else {
+						out.UserIds = []string{}
+					}
+				} else {
+					out.UserIds = (out.UserIds)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v103 string
+					v103 = string(in.String())
+					out.UserIds = append(out.UserIds, v103)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "sessionids":
+			if in.IsNull() {
+				in.Skip()
+				out.SessionIds = nil
 			} else {
 				in.Delim('[')
-				if out.UserIds == nil {
+				if out.SessionIds == nil {
 					if !in.IsDelim(']') {
-						out.UserIds = make([]string, 0, 4)
+						out.SessionIds = make([]RoomSessionId, 0, 4)
 					} else {
-						out.UserIds = []string{}
+						out.SessionIds = []RoomSessionId{}
 					}
 				} else {
-					out.UserIds = (out.UserIds)[:0]
+					out.SessionIds = (out.SessionIds)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v28 string
-					v28 = string(in.String())
-					out.UserIds = append(out.UserIds, v28)
+					var v104 RoomSessionId
+					v104 = RoomSessionId(in.String())
+					out.SessionIds = append(out.SessionIds, v104)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "alluserids":
+			if in.IsNull() {
+				in.Skip()
+				out.AllUserIds = nil
+			} else {
+				in.Delim('[')
+				if out.AllUserIds == nil {
+					if !in.IsDelim(']') {
+						out.AllUserIds = make([]string, 0, 4)
+					} else {
+						out.AllUserIds = []string{}
+					}
+				} else {
+					out.AllUserIds = (out.AllUserIds)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v105 string
+					v105 = string(in.String())
+					out.AllUserIds = append(out.AllUserIds, v105)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -2052,6 +5327,8 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling16(in *jle
 			if data := in.Raw(); in.Ok() {
 				in.AddError((out.Properties).UnmarshalJSON(data))
 			}
+		case "reason":
+			out.Reason = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -2062,7 +5339,7 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling16(in *jle
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling16(out *jwriter.Writer, in BackendRoomUpdateRequest) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling41(out *jwriter.Writer, in BackendRoomDisinviteRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -2072,151 +5349,100 @@ func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling16(out *jw
 		out.RawString(prefix[1:])
 		{
 			out.RawByte('[')
-			for v29, v30 := range in.UserIds {
-				if v29 > 0 {
+			for v106, v107 := range in.UserIds {
+				if v106 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v30))
+				out.String(string(v107))
 			}
 			out.RawByte(']')
 		}
 	}
-	if len(in.Properties) != 0 {
-		const prefix string = ",\"properties\":"
+	if len(in.SessionIds) != 0 {
+		const prefix string = ",\"sessionids\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
-		out.Raw((in.Properties).MarshalJSON())
-	}
-	out.RawByte('}')
-}
-
-// MarshalJSON supports json.Marshaler interface
-func (v BackendRoomUpdateRequest) MarshalJSON() ([]byte, error) {
-	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling16(&w, v)
-	return w.Buffer.BuildBytes(), w.Error
-}
-
-// MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendRoomUpdateRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling16(w, v)
-}
-
-// UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendRoomUpdateRequest) UnmarshalJSON(data []byte) error {
-	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling16(&r, v)
-	return r.Error()
-}
-
-// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendRoomUpdateRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling16(l, v)
-}
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling17(in *jlexer.Lexer, out *BackendRoomTransientRequest) {
-	isTopLevel := in.IsStart()
-	if in.IsNull() {
-		if isTopLevel {
-			in.Consumed()
+		{
+			out.RawByte('[')
+			for v108, v109 := range in.SessionIds {
+				if v108 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v109))
+			}
+			out.RawByte(']')
 		}
-		in.Skip()
-		return
 	}
-	in.Delim('{')
-	for !in.IsDelim('}') {
-		key := in.UnsafeFieldName(false)
-		in.WantColon()
-		if in.IsNull() {
-			in.Skip()
-			in.WantComma()
-			continue
+	if len(in.AllUserIds) != 0 {
+		const prefix string = ",\"alluserids\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
 		}
-		switch key {
-		case "action":
-			out.Action = TransientAction(in.String())
-		case "key":
-			out.Key = string(in.String())
-		case "value":
-			if m, ok := out.Value.(easyjson.Unmarshaler); ok {
-				m.UnmarshalEasyJSON(in)
-			} else if m, ok := out.Value.(json.Unmarshaler); ok {
-				_ = m.UnmarshalJSON(in.Raw())
-			} else {
-				out.Value = in.Interface()
+		{
+			out.RawByte('[')
+			for v110, v111 := range in.AllUserIds {
+				if v110 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v111))
 			}
-		case "ttl":
-			out.TTL = time.Duration(in.Int64())
-		default:
-			in.SkipRecursive()
+			out.RawByte(']')
 		}
-		in.WantComma()
-	}
-	in.Delim('}')
-	if isTopLevel {
-		in.Consumed()
-	}
-}
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling17(out *jwriter.Writer, in BackendRoomTransientRequest) {
-	out.RawByte('{')
-	first := true
-	_ = first
-	{
-		const prefix string = ",\"action\":"
-		out.RawString(prefix[1:])
-		out.String(string(in.Action))
 	}
-	{
-		const prefix string = ",\"key\":"
-		out.RawString(prefix)
-		out.String(string(in.Key))
-	}
-	if in.Value != nil {
-		const prefix string = ",\"value\":"
-		out.RawString(prefix)
-		if m, ok := in.Value.(easyjson.Marshaler); ok {
-			m.MarshalEasyJSON(out)
-		} else if m, ok := in.Value.(json.Marshaler); ok {
-			out.Raw(m.MarshalJSON())
+	if len(in.Properties) != 0 {
+		const prefix string = ",\"properties\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
 		} else {
-			out.Raw(json.Marshal(in.Value))
+			out.RawString(prefix)
 		}
+		out.Raw((in.Properties).MarshalJSON())
 	}
-	if in.TTL != 0 {
-		const prefix string = ",\"ttl\":"
-		out.RawString(prefix)
-		out.Int64(int64(in.TTL))
+	if in.Reason != "" {
+		const prefix string = ",\"reason\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Reason))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendRoomTransientRequest) MarshalJSON() ([]byte, error) {
+func (v BackendRoomDisinviteRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling17(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling41(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendRoomTransientRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling17(w, v)
+func (v BackendRoomDisinviteRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling41(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendRoomTransientRequest) UnmarshalJSON(data []byte) error {
+func (v *BackendRoomDisinviteRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling17(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling41(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendRoomTransientRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling17(l, v)
+func (v *BackendRoomDisinviteRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling41(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling18(in *jlexer.Lexer, out *BackendRoomSwitchToMessageRequest) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling42(in *jlexer.Lexer, out *BackendRoomDialoutResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2235,56 +5461,17 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling18(in *jle
 			continue
 		}
 		switch key {
-		case "roomid":
-			out.RoomId = string(in.String())
-		case "sessions":
-			if data := in.Raw(); in.Ok() {
-				in.AddError((out.Sessions).UnmarshalJSON(data))
-			}
-		case "sessionslist":
-			if in.IsNull() {
-				in.Skip()
-				out.SessionsList = nil
-			} else {
-				in.Delim('[')
-				if out.SessionsList == nil {
-					if !in.IsDelim(']') {
-						out.SessionsList = make(BackendRoomSwitchToPublicSessionsList, 0, 4)
-					} else {
-						out.SessionsList = BackendRoomSwitchToPublicSessionsList{}
-					}
-				} else {
-					out.SessionsList = (out.SessionsList)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v31 PublicSessionId
-					v31 = PublicSessionId(in.String())
-					out.SessionsList = append(out.SessionsList, v31)
-					in.WantComma()
-				}
-				in.Delim(']')
-			}
-		case "sessionsmap":
+		case "callid":
+			out.CallId = string(in.String())
+		case "error":
 			if in.IsNull() {
 				in.Skip()
+				out.Error = nil
 			} else {
-				in.Delim('{')
-				if !in.IsDelim('}') {
-					out.SessionsMap = make(BackendRoomSwitchToPublicSessionsMap)
-				} else {
-					out.SessionsMap = nil
-				}
-				for !in.IsDelim('}') {
-					key := PublicSessionId(in.String())
-					in.WantColon()
-					var v32 json.RawMessage
-					if data := in.Raw(); in.Ok() {
-						in.AddError((v32).UnmarshalJSON(data))
-					}
-					(out.SessionsMap)[key] = v32
-					in.WantComma()
+				if out.Error == nil {
+					out.Error = new(Error)
 				}
-				in.Delim('}')
+				(*out.Error).UnmarshalEasyJSON(in)
 			}
 		default:
 			in.SkipRecursive()
@@ -2296,80 +5483,53 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling18(in *jle
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling18(out *jwriter.Writer, in BackendRoomSwitchToMessageRequest) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling42(out *jwriter.Writer, in BackendRoomDialoutResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	{
-		const prefix string = ",\"roomid\":"
+	if in.CallId != "" {
+		const prefix string = ",\"callid\":"
+		first = false
 		out.RawString(prefix[1:])
-		out.String(string(in.RoomId))
-	}
-	if len(in.Sessions) != 0 {
-		const prefix string = ",\"sessions\":"
-		out.RawString(prefix)
-		out.Raw((in.Sessions).MarshalJSON())
-	}
-	if len(in.SessionsList) != 0 {
-		const prefix string = ",\"sessionslist\":"
-		out.RawString(prefix)
-		{
-			out.RawByte('[')
-			for v33, v34 := range in.SessionsList {
-				if v33 > 0 {
-					out.RawByte(',')
-				}
-				out.String(string(v34))
-			}
-			out.RawByte(']')
-		}
+		out.String(string(in.CallId))
 	}
-	if len(in.SessionsMap) != 0 {
-		const prefix string = ",\"sessionsmap\":"
-		out.RawString(prefix)
-		{
-			out.RawByte('{')
-			v35First := true
-			for v35Name, v35Value := range in.SessionsMap {
-				if v35First {
-					v35First = false
-				} else {
-					out.RawByte(',')
-				}
-				out.String(string(v35Name))
-				out.RawByte(':')
-				out.Raw((v35Value).MarshalJSON())
-			}
-			out.RawByte('}')
+	if in.Error != nil {
+		const prefix string = ",\"error\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
 		}
+		(*in.Error).MarshalEasyJSON(out)
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendRoomSwitchToMessageRequest) MarshalJSON() ([]byte, error) {
+func (v BackendRoomDialoutResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling18(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling42(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendRoomSwitchToMessageRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling18(w, v)
+func (v BackendRoomDialoutResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling42(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendRoomSwitchToMessageRequest) UnmarshalJSON(data []byte) error {
+func (v *BackendRoomDialoutResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling18(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling42(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendRoomSwitchToMessageRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling18(l, v)
+func (v *BackendRoomDialoutResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling42(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling19(in *jlexer.Lexer, out *BackendRoomParticipantsRequest) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling43(in *jlexer.Lexer, out *BackendRoomDialoutRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2388,99 +5548,11 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling19(in *jle
 			continue
 		}
 		switch key {
-		case "changed":
-			if in.IsNull() {
-				in.Skip()
-				out.Changed = nil
-			} else {
-				in.Delim('[')
-				if out.Changed == nil {
-					if !in.IsDelim(']') {
-						out.Changed = make([]StringMap, 0, 8)
-					} else {
-						out.Changed = []StringMap{}
-					}
-				} else {
-					out.Changed = (out.Changed)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v36 StringMap
-					if in.IsNull() {
-						in.Skip()
-					} else {
-						in.Delim('{')
-						if !in.IsDelim('}') {
-							v36 = make(StringMap)
-						} else {
-							v36 = nil
-						}
-						for !in.IsDelim('}') {
-							key := string(in.String())
-							in.WantColon()
-							var v37 interface{}
-							if m, ok := v37.(easyjson.Unmarshaler); ok {
-								m.UnmarshalEasyJSON(in)
-							} else if m, ok := v37.(json.Unmarshaler); ok {
-								_ = m.UnmarshalJSON(in.Raw())
-							} else {
-								v37 = in.Interface()
-							}
-							(v36)[key] = v37
-							in.WantComma()
-						}
-						in.Delim('}')
-					}
-					out.Changed = append(out.Changed, v36)
-					in.WantComma()
-				}
-				in.Delim(']')
-			}
-		case "users":
-			if in.IsNull() {
-				in.Skip()
-				out.Users = nil
-			} else {
-				in.Delim('[')
-				if out.Users == nil {
-					if !in.IsDelim(']') {
-						out.Users = make([]StringMap, 0, 8)
-					} else {
-						out.Users = []StringMap{}
-					}
-				} else {
-					out.Users = (out.Users)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v38 StringMap
-					if in.IsNull() {
-						in.Skip()
-					} else {
-						in.Delim('{')
-						if !in.IsDelim('}') {
-							v38 = make(StringMap)
-						} else {
-							v38 = nil
-						}
-						for !in.IsDelim('}') {
-							key := string(in.String())
-							in.WantColon()
-							var v39 interface{}
-							if m, ok := v39.(easyjson.Unmarshaler); ok {
-								m.UnmarshalEasyJSON(in)
-							} else if m, ok := v39.(json.Unmarshaler); ok {
-								_ = m.UnmarshalJSON(in.Raw())
-							} else {
-								v39 = in.Interface()
-							}
-							(v38)[key] = v39
-							in.WantComma()
-						}
-						in.Delim('}')
-					}
-					out.Users = append(out.Users, v38)
-					in.WantComma()
-				}
-				in.Delim(']')
+		case "number":
+			out.Number = string(in.String())
+		case "options":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Options).UnmarshalJSON(data))
 			}
 		default:
 			in.SkipRecursive()
@@ -2492,115 +5564,47 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling19(in *jle
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling19(out *jwriter.Writer, in BackendRoomParticipantsRequest) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling43(out *jwriter.Writer, in BackendRoomDialoutRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	if len(in.Changed) != 0 {
-		const prefix string = ",\"changed\":"
-		first = false
+	{
+		const prefix string = ",\"number\":"
 		out.RawString(prefix[1:])
-		{
-			out.RawByte('[')
-			for v40, v41 := range in.Changed {
-				if v40 > 0 {
-					out.RawByte(',')
-				}
-				if v41 == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
-					out.RawString(`null`)
-				} else {
-					out.RawByte('{')
-					v42First := true
-					for v42Name, v42Value := range v41 {
-						if v42First {
-							v42First = false
-						} else {
-							out.RawByte(',')
-						}
-						out.String(string(v42Name))
-						out.RawByte(':')
-						if m, ok := v42Value.(easyjson.Marshaler); ok {
-							m.MarshalEasyJSON(out)
-						} else if m, ok := v42Value.(json.Marshaler); ok {
-							out.Raw(m.MarshalJSON())
-						} else {
-							out.Raw(json.Marshal(v42Value))
-						}
-					}
-					out.RawByte('}')
-				}
-			}
-			out.RawByte(']')
-		}
+		out.String(string(in.Number))
 	}
-	if len(in.Users) != 0 {
-		const prefix string = ",\"users\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		{
-			out.RawByte('[')
-			for v43, v44 := range in.Users {
-				if v43 > 0 {
-					out.RawByte(',')
-				}
-				if v44 == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
-					out.RawString(`null`)
-				} else {
-					out.RawByte('{')
-					v45First := true
-					for v45Name, v45Value := range v44 {
-						if v45First {
-							v45First = false
-						} else {
-							out.RawByte(',')
-						}
-						out.String(string(v45Name))
-						out.RawByte(':')
-						if m, ok := v45Value.(easyjson.Marshaler); ok {
-							m.MarshalEasyJSON(out)
-						} else if m, ok := v45Value.(json.Marshaler); ok {
-							out.Raw(m.MarshalJSON())
-						} else {
-							out.Raw(json.Marshal(v45Value))
-						}
-					}
-					out.RawByte('}')
-				}
-			}
-			out.RawByte(']')
-		}
+	if len(in.Options) != 0 {
+		const prefix string = ",\"options\":"
+		out.RawString(prefix)
+		out.Raw((in.Options).MarshalJSON())
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendRoomParticipantsRequest) MarshalJSON() ([]byte, error) {
+func (v BackendRoomDialoutRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling19(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling43(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendRoomParticipantsRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling19(w, v)
+func (v BackendRoomDialoutRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling43(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendRoomParticipantsRequest) UnmarshalJSON(data []byte) error {
+func (v *BackendRoomDialoutRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling19(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling43(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendRoomParticipantsRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling19(l, v)
+func (v *BackendRoomDialoutRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling43(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling20(in *jlexer.Lexer, out *BackendRoomMessageRequest) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling44(in *jlexer.Lexer, out *BackendRoomDialoutError) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2619,10 +5623,10 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling20(in *jle
 			continue
 		}
 		switch key {
-		case "data":
-			if data := in.Raw(); in.Ok() {
-				in.AddError((out.Data).UnmarshalJSON(data))
-			}
+		case "code":
+			out.Code = string(in.String())
+		case "message":
+			out.Message = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -2633,43 +5637,47 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling20(in *jle
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling20(out *jwriter.Writer, in BackendRoomMessageRequest) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling44(out *jwriter.Writer, in BackendRoomDialoutError) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	if len(in.Data) != 0 {
-		const prefix string = ",\"data\":"
-		first = false
+	{
+		const prefix string = ",\"code\":"
 		out.RawString(prefix[1:])
-		out.Raw((in.Data).MarshalJSON())
+		out.String(string(in.Code))
+	}
+	if in.Message != "" {
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendRoomMessageRequest) MarshalJSON() ([]byte, error) {
+func (v BackendRoomDialoutError) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling20(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling44(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendRoomMessageRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling20(w, v)
+func (v BackendRoomDialoutError) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling44(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendRoomMessageRequest) UnmarshalJSON(data []byte) error {
+func (v *BackendRoomDialoutError) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling20(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling44(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendRoomMessageRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling20(l, v)
+func (v *BackendRoomDialoutError) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling44(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling21(in *jlexer.Lexer, out *BackendRoomInviteRequest) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling45(in *jlexer.Lexer, out *BackendRoomDeleteRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2704,125 +5712,69 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling21(in *jle
 					out.UserIds = (out.UserIds)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v46 string
-					v46 = string(in.String())
-					out.UserIds = append(out.UserIds, v46)
-					in.WantComma()
-				}
-				in.Delim(']')
-			}
-		case "alluserids":
-			if in.IsNull() {
-				in.Skip()
-				out.AllUserIds = nil
-			} else {
-				in.Delim('[')
-				if out.AllUserIds == nil {
-					if !in.IsDelim(']') {
-						out.AllUserIds = make([]string, 0, 4)
-					} else {
-						out.AllUserIds = []string{}
-					}
-				} else {
-					out.AllUserIds = (out.AllUserIds)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v47 string
-					v47 = string(in.String())
-					out.AllUserIds = append(out.AllUserIds, v47)
+					var v112 string
+					v112 = string(in.String())
+					out.UserIds = append(out.UserIds, v112)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "properties":
-			if data := in.Raw(); in.Ok() {
-				in.AddError((out.Properties).UnmarshalJSON(data))
-			}
 		default:
 			in.SkipRecursive()
 		}
-		in.WantComma()
-	}
-	in.Delim('}')
-	if isTopLevel {
-		in.Consumed()
-	}
-}
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling21(out *jwriter.Writer, in BackendRoomInviteRequest) {
-	out.RawByte('{')
-	first := true
-	_ = first
-	if len(in.UserIds) != 0 {
-		const prefix string = ",\"userids\":"
-		first = false
-		out.RawString(prefix[1:])
-		{
-			out.RawByte('[')
-			for v48, v49 := range in.UserIds {
-				if v48 > 0 {
-					out.RawByte(',')
-				}
-				out.String(string(v49))
-			}
-			out.RawByte(']')
-		}
-	}
-	if len(in.AllUserIds) != 0 {
-		const prefix string = ",\"alluserids\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling45(out *jwriter.Writer, in BackendRoomDeleteRequest) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if len(in.UserIds) != 0 {
+		const prefix string = ",\"userids\":"
+		first = false
+		out.RawString(prefix[1:])
 		{
 			out.RawByte('[')
-			for v50, v51 := range in.AllUserIds {
-				if v50 > 0 {
+			for v113, v114 := range in.UserIds {
+				if v113 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v51))
+				out.String(string(v114))
 			}
 			out.RawByte(']')
 		}
 	}
-	if len(in.Properties) != 0 {
-		const prefix string = ",\"properties\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		out.Raw((in.Properties).MarshalJSON())
-	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendRoomInviteRequest) MarshalJSON() ([]byte, error) {
+func (v BackendRoomDeleteRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling21(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling45(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendRoomInviteRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling21(w, v)
+func (v BackendRoomDeleteRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling45(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendRoomInviteRequest) UnmarshalJSON(data []byte) error {
+func (v *BackendRoomDeleteRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling21(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling45(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendRoomInviteRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling21(l, v)
+func (v *BackendRoomDeleteRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling45(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling22(in *jlexer.Lexer, out *BackendRoomInCallRequest) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling46(in *jlexer.Lexer, out *BackendRoomBreakoutRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2841,105 +5793,114 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling22(in *jle
 			continue
 		}
 		switch key {
-		case "incall":
-			if data := in.Raw(); in.Ok() {
-				in.AddError((out.InCall).UnmarshalJSON(data))
-			}
-		case "all":
-			out.All = bool(in.Bool())
-		case "changed":
+		case "action":
+			out.Action = string(in.String())
+		case "rooms":
 			if in.IsNull() {
 				in.Skip()
-				out.Changed = nil
 			} else {
-				in.Delim('[')
-				if out.Changed == nil {
-					if !in.IsDelim(']') {
-						out.Changed = make([]StringMap, 0, 8)
-					} else {
-						out.Changed = []StringMap{}
-					}
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Rooms = make(map[string]BackendRoomSwitchToSessionsList)
 				} else {
-					out.Changed = (out.Changed)[:0]
+					out.Rooms = nil
 				}
-				for !in.IsDelim(']') {
-					var v52 StringMap
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v115 BackendRoomSwitchToSessionsList
 					if in.IsNull() {
 						in.Skip()
+						v115 = nil
 					} else {
-						in.Delim('{')
-						if !in.IsDelim('}') {
-							v52 = make(StringMap)
-						} else {
-							v52 = nil
-						}
-						for !in.IsDelim('}') {
-							key := string(in.String())
-							in.WantColon()
-							var v53 interface{}
-							if m, ok := v53.(easyjson.Unmarshaler); ok {
-								m.UnmarshalEasyJSON(in)
-							} else if m, ok := v53.(json.Unmarshaler); ok {
-								_ = m.UnmarshalJSON(in.Raw())
+						in.Delim('[')
+						if v115 == nil {
+							if !in.IsDelim(']') {
+								v115 = make(BackendRoomSwitchToSessionsList, 0, 4)
 							} else {
-								v53 = in.Interface()
+								v115 = BackendRoomSwitchToSessionsList{}
 							}
-							(v52)[key] = v53
+						} else {
+							v115 = (v115)[:0]
+						}
+						for !in.IsDelim(']') {
+							var v116 RoomSessionId
+							v116 = RoomSessionId(in.String())
+							v115 = append(v115, v116)
 							in.WantComma()
 						}
-						in.Delim('}')
+						in.Delim(']')
 					}
-					out.Changed = append(out.Changed, v52)
+					(out.Rooms)[key] = v115
 					in.WantComma()
 				}
-				in.Delim(']')
+				in.Delim('}')
 			}
-		case "users":
+		case "parentroomid":
+			out.ParentRoomId = string(in.String())
+		case "roomids":
 			if in.IsNull() {
 				in.Skip()
-				out.Users = nil
+				out.RoomIds = nil
 			} else {
 				in.Delim('[')
-				if out.Users == nil {
+				if out.RoomIds == nil {
 					if !in.IsDelim(']') {
-						out.Users = make([]StringMap, 0, 8)
+						out.RoomIds = make([]string, 0, 4)
 					} else {
-						out.Users = []StringMap{}
+						out.RoomIds = []string{}
 					}
 				} else {
-					out.Users = (out.Users)[:0]
+					out.RoomIds = (out.RoomIds)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v54 StringMap
+					var v117 string
+					v117 = string(in.String())
+					out.RoomIds = append(out.RoomIds, v117)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "sessionsmap":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.SessionsMap = make(map[string]BackendRoomSwitchToPublicSessionsList)
+				} else {
+					out.SessionsMap = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v118 BackendRoomSwitchToPublicSessionsList
 					if in.IsNull() {
 						in.Skip()
+						v118 = nil
 					} else {
-						in.Delim('{')
-						if !in.IsDelim('}') {
-							v54 = make(StringMap)
-						} else {
-							v54 = nil
-						}
-						for !in.IsDelim('}') {
-							key := string(in.String())
-							in.WantColon()
-							var v55 interface{}
-							if m, ok := v55.(easyjson.Unmarshaler); ok {
-								m.UnmarshalEasyJSON(in)
-							} else if m, ok := v55.(json.Unmarshaler); ok {
-								_ = m.UnmarshalJSON(in.Raw())
+						in.Delim('[')
+						if v118 == nil {
+							if !in.IsDelim(']') {
+								v118 = make(BackendRoomSwitchToPublicSessionsList, 0, 4)
 							} else {
-								v55 = in.Interface()
+								v118 = BackendRoomSwitchToPublicSessionsList{}
 							}
-							(v54)[key] = v55
+						} else {
+							v118 = (v118)[:0]
+						}
+						for !in.IsDelim(']') {
+							var v119 PublicSessionId
+							v119 = PublicSessionId(in.String())
+							v118 = append(v118, v119)
 							in.WantComma()
 						}
-						in.Delim('}')
+						in.Delim(']')
 					}
-					out.Users = append(out.Users, v54)
+					(out.SessionsMap)[key] = v118
 					in.WantComma()
 				}
-				in.Delim(']')
+				in.Delim('}')
 			}
 		default:
 			in.SkipRecursive()
@@ -2951,135 +5912,200 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling22(in *jle
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling22(out *jwriter.Writer, in BackendRoomInCallRequest) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling46(out *jwriter.Writer, in BackendRoomBreakoutRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	if len(in.InCall) != 0 {
-		const prefix string = ",\"incall\":"
-		first = false
+	{
+		const prefix string = ",\"action\":"
 		out.RawString(prefix[1:])
-		out.Raw((in.InCall).MarshalJSON())
-	}
-	if in.All {
-		const prefix string = ",\"all\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		out.Bool(bool(in.All))
+		out.String(string(in.Action))
 	}
-	if len(in.Changed) != 0 {
-		const prefix string = ",\"changed\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
+	if len(in.Rooms) != 0 {
+		const prefix string = ",\"rooms\":"
+		out.RawString(prefix)
 		{
-			out.RawByte('[')
-			for v56, v57 := range in.Changed {
-				if v56 > 0 {
+			out.RawByte('{')
+			v120First := true
+			for v120Name, v120Value := range in.Rooms {
+				if v120First {
+					v120First = false
+				} else {
 					out.RawByte(',')
 				}
-				if v57 == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
-					out.RawString(`null`)
+				out.String(string(v120Name))
+				out.RawByte(':')
+				if v120Value == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+					out.RawString("null")
 				} else {
-					out.RawByte('{')
-					v58First := true
-					for v58Name, v58Value := range v57 {
-						if v58First {
-							v58First = false
-						} else {
+					out.RawByte('[')
+					for v121, v122 := range v120Value {
+						if v121 > 0 {
 							out.RawByte(',')
 						}
-						out.String(string(v58Name))
-						out.RawByte(':')
-						if m, ok := v58Value.(easyjson.Marshaler); ok {
-							m.MarshalEasyJSON(out)
-						} else if m, ok := v58Value.(json.Marshaler); ok {
-							out.Raw(m.MarshalJSON())
-						} else {
-							out.Raw(json.Marshal(v58Value))
-						}
+						out.String(string(v122))
 					}
-					out.RawByte('}')
+					out.RawByte(']')
 				}
 			}
-			out.RawByte(']')
+			out.RawByte('}')
 		}
 	}
-	if len(in.Users) != 0 {
-		const prefix string = ",\"users\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
+	if in.ParentRoomId != "" {
+		const prefix string = ",\"parentroomid\":"
+		out.RawString(prefix)
+		out.String(string(in.ParentRoomId))
+	}
+	if len(in.RoomIds) != 0 {
+		const prefix string = ",\"roomids\":"
+		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v59, v60 := range in.Users {
-				if v59 > 0 {
+			for v123, v124 := range in.RoomIds {
+				if v123 > 0 {
 					out.RawByte(',')
 				}
-				if v60 == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
-					out.RawString(`null`)
+				out.String(string(v124))
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.SessionsMap) != 0 {
+		const prefix string = ",\"sessionsmap\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('{')
+			v125First := true
+			for v125Name, v125Value := range in.SessionsMap {
+				if v125First {
+					v125First = false
 				} else {
-					out.RawByte('{')
-					v61First := true
-					for v61Name, v61Value := range v60 {
-						if v61First {
-							v61First = false
-						} else {
+					out.RawByte(',')
+				}
+				out.String(string(v125Name))
+				out.RawByte(':')
+				if v125Value == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+					out.RawString("null")
+				} else {
+					out.RawByte('[')
+					for v126, v127 := range v125Value {
+						if v126 > 0 {
 							out.RawByte(',')
 						}
-						out.String(string(v61Name))
-						out.RawByte(':')
-						if m, ok := v61Value.(easyjson.Marshaler); ok {
-							m.MarshalEasyJSON(out)
-						} else if m, ok := v61Value.(json.Marshaler); ok {
-							out.Raw(m.MarshalJSON())
-						} else {
-							out.Raw(json.Marshal(v61Value))
-						}
+						out.String(string(v127))
 					}
-					out.RawByte('}')
+					out.RawByte(']')
 				}
 			}
-			out.RawByte(']')
+			out.RawByte('}')
 		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendRoomInCallRequest) MarshalJSON() ([]byte, error) {
+func (v BackendRoomBreakoutRequest) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling46(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BackendRoomBreakoutRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling46(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BackendRoomBreakoutRequest) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling46(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BackendRoomBreakoutRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling46(l, v)
+}
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling47(in *jlexer.Lexer, out *BackendPingEntry) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "userid":
+			out.UserId = string(in.String())
+		case "sessionid":
+			out.SessionId = RoomSessionId(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling47(out *jwriter.Writer, in BackendPingEntry) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.UserId != "" {
+		const prefix string = ",\"userid\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.UserId))
+	}
+	{
+		const prefix string = ",\"sessionid\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.SessionId))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BackendPingEntry) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling22(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling47(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendRoomInCallRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling22(w, v)
+func (v BackendPingEntry) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling47(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendRoomInCallRequest) UnmarshalJSON(data []byte) error {
+func (v *BackendPingEntry) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling22(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling47(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendRoomInCallRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling22(l, v)
+func (v *BackendPingEntry) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling47(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling23(in *jlexer.Lexer, out *BackendRoomDisinviteRequest) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling48(in *jlexer.Lexer, out *BackendInformationEtcd) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3098,79 +6124,118 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling23(in *jle
 			continue
 		}
 		switch key {
-		case "userids":
+		case "url":
+			out.Url = string(in.String())
+		case "urls":
 			if in.IsNull() {
 				in.Skip()
-				out.UserIds = nil
+				out.Urls = nil
 			} else {
 				in.Delim('[')
-				if out.UserIds == nil {
+				if out.Urls == nil {
 					if !in.IsDelim(']') {
-						out.UserIds = make([]string, 0, 4)
+						out.Urls = make([]string, 0, 4)
 					} else {
-						out.UserIds = []string{}
+						out.Urls = []string{}
 					}
 				} else {
-					out.UserIds = (out.UserIds)[:0]
+					out.Urls = (out.Urls)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v62 string
-					v62 = string(in.String())
-					out.UserIds = append(out.UserIds, v62)
+					var v128 string
+					v128 = string(in.String())
+					out.Urls = append(out.Urls, v128)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "sessionids":
+		case "secret":
+			out.Secret = string(in.String())
+		case "maxstreambitrate":
+			out.MaxStreamBitrate = int(in.Int())
+		case "maxscreenbitrate":
+			out.MaxScreenBitrate = int(in.Int())
+		case "sessionlimit":
+			out.SessionLimit = uint64(in.Uint64())
+		case "concurrentrequests":
+			out.ConcurrentRequests = int(in.Int())
+		case "concurrentmessages":
+			out.ConcurrentMessages = int(in.Int())
+		case "messagerate":
+			out.MessageRate = int(in.Int())
+		case "joinrate":
+			out.JoinRate = int(in.Int())
+		case "disabledfeatures":
 			if in.IsNull() {
 				in.Skip()
-				out.SessionIds = nil
+				out.DisabledFeatures = nil
 			} else {
 				in.Delim('[')
-				if out.SessionIds == nil {
+				if out.DisabledFeatures == nil {
 					if !in.IsDelim(']') {
-						out.SessionIds = make([]RoomSessionId, 0, 4)
+						out.DisabledFeatures = make([]string, 0, 4)
 					} else {
-						out.SessionIds = []RoomSessionId{}
+						out.DisabledFeatures = []string{}
 					}
 				} else {
-					out.SessionIds = (out.SessionIds)[:0]
+					out.DisabledFeatures = (out.DisabledFeatures)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v63 RoomSessionId
-					v63 = RoomSessionId(in.String())
-					out.SessionIds = append(out.SessionIds, v63)
+					var v129 string
+					v129 = string(in.String())
+					out.DisabledFeatures = append(out.DisabledFeatures, v129)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "alluserids":
+		case "dialoutallowedprefixes":
 			if in.IsNull() {
 				in.Skip()
-				out.AllUserIds = nil
+				out.DialoutAllowedPrefixes = nil
 			} else {
 				in.Delim('[')
-				if out.AllUserIds == nil {
+				if out.DialoutAllowedPrefixes == nil {
 					if !in.IsDelim(']') {
-						out.AllUserIds = make([]string, 0, 4)
+						out.DialoutAllowedPrefixes = make([]string, 0, 4)
 					} else {
-						out.AllUserIds = []string{}
+						out.DialoutAllowedPrefixes = []string{}
 					}
 				} else {
-					out.AllUserIds = (out.AllUserIds)[:0]
+					out.DialoutAllowedPrefixes = (out.DialoutAllowedPrefixes)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v64 string
-					v64 = string(in.String())
-					out.AllUserIds = append(out.AllUserIds, v64)
+					var v130 string
+					v130 = string(in.String())
+					out.DialoutAllowedPrefixes = append(out.DialoutAllowedPrefixes, v130)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "properties":
-			if data := in.Raw(); in.Ok() {
-				in.AddError((out.Properties).UnmarshalJSON(data))
+		case "dialoutdeniedpatterns":
+			if in.IsNull() {
+				in.Skip()
+				out.DialoutDeniedPatterns = nil
+			} else {
+				in.Delim('[')
+				if out.DialoutDeniedPatterns == nil {
+					if !in.IsDelim(']') {
+						out.DialoutDeniedPatterns = make([]string, 0, 4)
+					} else {
+						out.DialoutDeniedPatterns = []string{}
+					}
+				} else {
+					out.DialoutDeniedPatterns = (out.DialoutDeniedPatterns)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v131 string
+					v131 = string(in.String())
+					out.DialoutDeniedPatterns = append(out.DialoutDeniedPatterns, v131)
+					in.WantComma()
+				}
+				in.Delim(']')
 			}
+		case "oidcissuer":
+			out.OidcIssuer = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -3181,27 +6246,18 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling23(in *jle
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling23(out *jwriter.Writer, in BackendRoomDisinviteRequest) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling48(out *jwriter.Writer, in BackendInformationEtcd) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	if len(in.UserIds) != 0 {
-		const prefix string = ",\"userids\":"
+	if in.Url != "" {
+		const prefix string = ",\"url\":"
 		first = false
 		out.RawString(prefix[1:])
-		{
-			out.RawByte('[')
-			for v65, v66 := range in.UserIds {
-				if v65 > 0 {
-					out.RawByte(',')
-				}
-				out.String(string(v66))
-			}
-			out.RawByte(']')
-		}
+		out.String(string(in.Url))
 	}
-	if len(in.SessionIds) != 0 {
-		const prefix string = ",\"sessionids\":"
+	if len(in.Urls) != 0 {
+		const prefix string = ",\"urls\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
@@ -3210,158 +6266,134 @@ func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling23(out *jw
 		}
 		{
 			out.RawByte('[')
-			for v67, v68 := range in.SessionIds {
-				if v67 > 0 {
+			for v132, v133 := range in.Urls {
+				if v132 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v68))
+				out.String(string(v133))
 			}
 			out.RawByte(']')
 		}
 	}
-	if len(in.AllUserIds) != 0 {
-		const prefix string = ",\"alluserids\":"
+	{
+		const prefix string = ",\"secret\":"
 		if first {
 			first = false
 			out.RawString(prefix[1:])
 		} else {
 			out.RawString(prefix)
 		}
+		out.String(string(in.Secret))
+	}
+	if in.MaxStreamBitrate != 0 {
+		const prefix string = ",\"maxstreambitrate\":"
+		out.RawString(prefix)
+		out.Int(int(in.MaxStreamBitrate))
+	}
+	if in.MaxScreenBitrate != 0 {
+		const prefix string = ",\"maxscreenbitrate\":"
+		out.RawString(prefix)
+		out.Int(int(in.MaxScreenBitrate))
+	}
+	if in.SessionLimit != 0 {
+		const prefix string = ",\"sessionlimit\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.SessionLimit))
+	}
+	if in.ConcurrentRequests != 0 {
+		const prefix string = ",\"concurrentrequests\":"
+		out.RawString(prefix)
+		out.Int(int(in.ConcurrentRequests))
+	}
+	if in.ConcurrentMessages != 0 {
+		const prefix string = ",\"concurrentmessages\":"
+		out.RawString(prefix)
+		out.Int(int(in.ConcurrentMessages))
+	}
+	if in.MessageRate != 0 {
+		const prefix string = ",\"messagerate\":"
+		out.RawString(prefix)
+		out.Int(int(in.MessageRate))
+	}
+	if in.JoinRate != 0 {
+		const prefix string = ",\"joinrate\":"
+		out.RawString(prefix)
+		out.Int(int(in.JoinRate))
+	}
+	if len(in.DisabledFeatures) != 0 {
+		const prefix string = ",\"disabledfeatures\":"
+		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v69, v70 := range in.AllUserIds {
-				if v69 > 0 {
+			for v134, v135 := range in.DisabledFeatures {
+				if v134 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v70))
+				out.String(string(v135))
 			}
 			out.RawByte(']')
 		}
 	}
-	if len(in.Properties) != 0 {
-		const prefix string = ",\"properties\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		out.Raw((in.Properties).MarshalJSON())
-	}
-	out.RawByte('}')
-}
-
-// MarshalJSON supports json.Marshaler interface
-func (v BackendRoomDisinviteRequest) MarshalJSON() ([]byte, error) {
-	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling23(&w, v)
-	return w.Buffer.BuildBytes(), w.Error
-}
-
-// MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendRoomDisinviteRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling23(w, v)
-}
-
-// UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendRoomDisinviteRequest) UnmarshalJSON(data []byte) error {
-	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling23(&r, v)
-	return r.Error()
-}
-
-// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendRoomDisinviteRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling23(l, v)
-}
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling24(in *jlexer.Lexer, out *BackendRoomDialoutResponse) {
-	isTopLevel := in.IsStart()
-	if in.IsNull() {
-		if isTopLevel {
-			in.Consumed()
+	if len(in.DialoutAllowedPrefixes) != 0 {
+		const prefix string = ",\"dialoutallowedprefixes\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v136, v137 := range in.DialoutAllowedPrefixes {
+				if v136 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v137))
+			}
+			out.RawByte(']')
 		}
-		in.Skip()
-		return
 	}
-	in.Delim('{')
-	for !in.IsDelim('}') {
-		key := in.UnsafeFieldName(false)
-		in.WantColon()
-		if in.IsNull() {
-			in.Skip()
-			in.WantComma()
-			continue
-		}
-		switch key {
-		case "callid":
-			out.CallId = string(in.String())
-		case "error":
-			if in.IsNull() {
-				in.Skip()
-				out.Error = nil
-			} else {
-				if out.Error == nil {
-					out.Error = new(Error)
+	if len(in.DialoutDeniedPatterns) != 0 {
+		const prefix string = ",\"dialoutdeniedpatterns\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v138, v139 := range in.DialoutDeniedPatterns {
+				if v138 > 0 {
+					out.RawByte(',')
 				}
-				(*out.Error).UnmarshalEasyJSON(in)
+				out.String(string(v139))
 			}
-		default:
-			in.SkipRecursive()
+			out.RawByte(']')
 		}
-		in.WantComma()
-	}
-	in.Delim('}')
-	if isTopLevel {
-		in.Consumed()
-	}
-}
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling24(out *jwriter.Writer, in BackendRoomDialoutResponse) {
-	out.RawByte('{')
-	first := true
-	_ = first
-	if in.CallId != "" {
-		const prefix string = ",\"callid\":"
-		first = false
-		out.RawString(prefix[1:])
-		out.String(string(in.CallId))
 	}
-	if in.Error != nil {
-		const prefix string = ",\"error\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		(*in.Error).MarshalEasyJSON(out)
+	if in.OidcIssuer != "" {
+		const prefix string = ",\"oidcissuer\":"
+		out.RawString(prefix)
+		out.String(string(in.OidcIssuer))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendRoomDialoutResponse) MarshalJSON() ([]byte, error) {
+func (v BackendInformationEtcd) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling24(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling48(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendRoomDialoutResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling24(w, v)
+func (v BackendInformationEtcd) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling48(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendRoomDialoutResponse) UnmarshalJSON(data []byte) error {
+func (v *BackendInformationEtcd) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling24(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling48(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendRoomDialoutResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling24(l, v)
+func (v *BackendInformationEtcd) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling48(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling25(in *jlexer.Lexer, out *BackendRoomDialoutRequest) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling49(in *jlexer.Lexer, out *BackendClientSessionResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3380,12 +6412,10 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling25(in *jle
 			continue
 		}
 		switch key {
-		case "number":
-			out.Number = string(in.String())
-		case "options":
-			if data := in.Raw(); in.Ok() {
-				in.AddError((out.Options).UnmarshalJSON(data))
-			}
+		case "version":
+			out.Version = string(in.String())
+		case "roomid":
+			out.RoomId = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -3396,47 +6426,47 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling25(in *jle
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling25(out *jwriter.Writer, in BackendRoomDialoutRequest) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling49(out *jwriter.Writer, in BackendClientSessionResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"number\":"
+		const prefix string = ",\"version\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Number))
+		out.String(string(in.Version))
 	}
-	if len(in.Options) != 0 {
-		const prefix string = ",\"options\":"
+	{
+		const prefix string = ",\"roomid\":"
 		out.RawString(prefix)
-		out.Raw((in.Options).MarshalJSON())
+		out.String(string(in.RoomId))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendRoomDialoutRequest) MarshalJSON() ([]byte, error) {
+func (v BackendClientSessionResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling25(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling49(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendRoomDialoutRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling25(w, v)
+func (v BackendClientSessionResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling49(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendRoomDialoutRequest) UnmarshalJSON(data []byte) error {
+func (v *BackendClientSessionResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling25(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling49(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendRoomDialoutRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling25(l, v)
+func (v *BackendClientSessionResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling49(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling26(in *jlexer.Lexer, out *BackendRoomDialoutError) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling50(in *jlexer.Lexer, out *BackendClientSessionRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3455,10 +6485,20 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling26(in *jle
 			continue
 		}
 		switch key {
-		case "code":
-			out.Code = string(in.String())
-		case "message":
-			out.Message = string(in.String())
+		case "version":
+			out.Version = string(in.String())
+		case "roomid":
+			out.RoomId = string(in.String())
+		case "action":
+			out.Action = string(in.String())
+		case "sessionid":
+			out.SessionId = PublicSessionId(in.String())
+		case "userid":
+			out.UserId = string(in.String())
+		case "user":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.User).UnmarshalJSON(data))
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -3469,47 +6509,67 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling26(in *jle
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling26(out *jwriter.Writer, in BackendRoomDialoutError) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling50(out *jwriter.Writer, in BackendClientSessionRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"code\":"
+		const prefix string = ",\"version\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Code))
+		out.String(string(in.Version))
 	}
-	if in.Message != "" {
-		const prefix string = ",\"message\":"
+	{
+		const prefix string = ",\"roomid\":"
 		out.RawString(prefix)
-		out.String(string(in.Message))
+		out.String(string(in.RoomId))
+	}
+	{
+		const prefix string = ",\"action\":"
+		out.RawString(prefix)
+		out.String(string(in.Action))
+	}
+	{
+		const prefix string = ",\"sessionid\":"
+		out.RawString(prefix)
+		out.String(string(in.SessionId))
+	}
+	if in.UserId != "" {
+		const prefix string = ",\"userid\":"
+		out.RawString(prefix)
+		out.String(string(in.UserId))
+	}
+	if len(in.User) != 0 {
+		const prefix string = ",\"user\":"
+		out.RawString(prefix)
+		out.Raw((in.User).MarshalJSON())
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendRoomDialoutError) MarshalJSON() ([]byte, error) {
+func (v BackendClientSessionRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling26(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling50(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendRoomDialoutError) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling26(w, v)
+func (v BackendClientSessionRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling50(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendRoomDialoutError) UnmarshalJSON(data []byte) error {
+func (v *BackendClientSessionRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling26(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling50(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendRoomDialoutError) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling26(l, v)
+func (v *BackendClientSessionRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling50(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling27(in *jlexer.Lexer, out *BackendRoomDeleteRequest) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling51(in *jlexer.Lexer, out *BackendClientRoomResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3528,28 +6588,48 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling27(in *jle
 			continue
 		}
 		switch key {
-		case "userids":
+		case "version":
+			out.Version = string(in.String())
+		case "roomid":
+			out.RoomId = string(in.String())
+		case "properties":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Properties).UnmarshalJSON(data))
+			}
+		case "session":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Session).UnmarshalJSON(data))
+			}
+		case "permissions":
 			if in.IsNull() {
 				in.Skip()
-				out.UserIds = nil
+				out.Permissions = nil
 			} else {
-				in.Delim('[')
-				if out.UserIds == nil {
-					if !in.IsDelim(']') {
-						out.UserIds = make([]string, 0, 4)
+				if out.Permissions == nil {
+					out.Permissions = new([]Permission)
+				}
+				if in.IsNull() {
+					in.Skip()
+					*out.Permissions = nil
+				} else {
+					in.Delim('[')
+					if *out.Permissions == nil {
+						if !in.IsDelim(']') {
+							*out.Permissions = make([]Permission, 0, 4)
+						} else {
+							*out.Permissions = []Permission{}
+						}
 					} else {
-						out.UserIds = []string{}
+						*out.Permissions = (*out.Permissions)[:0]
 					}
-				} else {
-					out.UserIds = (out.UserIds)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v71 string
-					v71 = string(in.String())
-					out.UserIds = append(out.UserIds, v71)
-					in.WantComma()
+					for !in.IsDelim(']') {
+						var v140 Permission
+						v140 = Permission(in.String())
+						*out.Permissions = append(*out.Permissions, v140)
+						in.WantComma()
+					}
+					in.Delim(']')
 				}
-				in.Delim(']')
 			}
 		default:
 			in.SkipRecursive()
@@ -3561,21 +6641,42 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling27(in *jle
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling27(out *jwriter.Writer, in BackendRoomDeleteRequest) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling51(out *jwriter.Writer, in BackendClientRoomResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	if len(in.UserIds) != 0 {
-		const prefix string = ",\"userids\":"
-		first = false
+	{
+		const prefix string = ",\"version\":"
 		out.RawString(prefix[1:])
-		{
+		out.String(string(in.Version))
+	}
+	{
+		const prefix string = ",\"roomid\":"
+		out.RawString(prefix)
+		out.String(string(in.RoomId))
+	}
+	{
+		const prefix string = ",\"properties\":"
+		out.RawString(prefix)
+		out.Raw((in.Properties).MarshalJSON())
+	}
+	if len(in.Session) != 0 {
+		const prefix string = ",\"session\":"
+		out.RawString(prefix)
+		out.Raw((in.Session).MarshalJSON())
+	}
+	if in.Permissions != nil {
+		const prefix string = ",\"permissions\":"
+		out.RawString(prefix)
+		if *in.Permissions == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
 			out.RawByte('[')
-			for v72, v73 := range in.UserIds {
-				if v72 > 0 {
+			for v141, v142 := range *in.Permissions {
+				if v141 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v73))
+				out.String(string(v142))
 			}
 			out.RawByte(']')
 		}
@@ -3584,29 +6685,29 @@ func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling27(out *jw
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendRoomDeleteRequest) MarshalJSON() ([]byte, error) {
+func (v BackendClientRoomResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling27(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling51(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendRoomDeleteRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling27(w, v)
+func (v BackendClientRoomResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling51(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendRoomDeleteRequest) UnmarshalJSON(data []byte) error {
+func (v *BackendClientRoomResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling27(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling51(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendRoomDeleteRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling27(l, v)
+func (v *BackendClientRoomResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling51(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling28(in *jlexer.Lexer, out *BackendPingEntry) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling52(in *jlexer.Lexer, out *BackendClientRoomRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3625,10 +6726,22 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling28(in *jle
 			continue
 		}
 		switch key {
+		case "version":
+			out.Version = string(in.String())
+		case "roomid":
+			out.RoomId = string(in.String())
+		case "action":
+			out.Action = string(in.String())
 		case "userid":
 			out.UserId = string(in.String())
 		case "sessionid":
 			out.SessionId = RoomSessionId(in.String())
+		case "actorid":
+			out.ActorId = string(in.String())
+		case "actortype":
+			out.ActorType = string(in.String())
+		case "incall":
+			out.InCall = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -3639,104 +6752,99 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling28(in *jle
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling28(out *jwriter.Writer, in BackendPingEntry) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling52(out *jwriter.Writer, in BackendClientRoomRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	if in.UserId != "" {
-		const prefix string = ",\"userid\":"
-		first = false
+	{
+		const prefix string = ",\"version\":"
 		out.RawString(prefix[1:])
+		out.String(string(in.Version))
+	}
+	{
+		const prefix string = ",\"roomid\":"
+		out.RawString(prefix)
+		out.String(string(in.RoomId))
+	}
+	if in.Action != "" {
+		const prefix string = ",\"action\":"
+		out.RawString(prefix)
+		out.String(string(in.Action))
+	}
+	{
+		const prefix string = ",\"userid\":"
+		out.RawString(prefix)
 		out.String(string(in.UserId))
 	}
 	{
 		const prefix string = ",\"sessionid\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
+		out.RawString(prefix)
 		out.String(string(in.SessionId))
 	}
+	if in.ActorId != "" {
+		const prefix string = ",\"actorid\":"
+		out.RawString(prefix)
+		out.String(string(in.ActorId))
+	}
+	if in.ActorType != "" {
+		const prefix string = ",\"actortype\":"
+		out.RawString(prefix)
+		out.String(string(in.ActorType))
+	}
+	if in.InCall != 0 {
+		const prefix string = ",\"incall\":"
+		out.RawString(prefix)
+		out.Int(int(in.InCall))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendPingEntry) MarshalJSON() ([]byte, error) {
+func (v BackendClientRoomRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling28(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling52(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendPingEntry) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling28(w, v)
+func (v BackendClientRoomRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling52(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendPingEntry) UnmarshalJSON(data []byte) error {
+func (v *BackendClientRoomRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling28(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling52(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendPingEntry) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling28(l, v)
+func (v *BackendClientRoomRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling52(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling29(in *jlexer.Lexer, out *BackendInformationEtcd) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling53(in *jlexer.Lexer, out *BackendClientRingResponse) {
 	isTopLevel := in.IsStart()
-	if in.IsNull() {
-		if isTopLevel {
-			in.Consumed()
-		}
-		in.Skip()
-		return
-	}
-	in.Delim('{')
-	for !in.IsDelim('}') {
-		key := in.UnsafeFieldName(false)
-		in.WantColon()
-		if in.IsNull() {
-			in.Skip()
-			in.WantComma()
-			continue
-		}
-		switch key {
-		case "url":
-			out.Url = string(in.String())
-		case "urls":
-			if in.IsNull() {
-				in.Skip()
-				out.Urls = nil
-			} else {
-				in.Delim('[')
-				if out.Urls == nil {
-					if !in.IsDelim(']') {
-						out.Urls = make([]string, 0, 4)
-					} else {
-						out.Urls = []string{}
-					}
-				} else {
-					out.Urls = (out.Urls)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v74 string
-					v74 = string(in.String())
-					out.Urls = append(out.Urls, v74)
-					in.WantComma()
-				}
-				in.Delim(']')
-			}
-		case "secret":
-			out.Secret = string(in.String())
-		case "maxstreambitrate":
-			out.MaxStreamBitrate = int(in.Int())
-		case "maxscreenbitrate":
-			out.MaxScreenBitrate = int(in.Int())
-		case "sessionlimit":
-			out.SessionLimit = uint64(in.Uint64())
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "version":
+			out.Version = string(in.String())
+		case "roomid":
+			out.RoomId = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -3747,87 +6855,47 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling29(in *jle
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling29(out *jwriter.Writer, in BackendInformationEtcd) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling53(out *jwriter.Writer, in BackendClientRingResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	if in.Url != "" {
-		const prefix string = ",\"url\":"
-		first = false
+	{
+		const prefix string = ",\"version\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Url))
-	}
-	if len(in.Urls) != 0 {
-		const prefix string = ",\"urls\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		{
-			out.RawByte('[')
-			for v75, v76 := range in.Urls {
-				if v75 > 0 {
-					out.RawByte(',')
-				}
-				out.String(string(v76))
-			}
-			out.RawByte(']')
-		}
+		out.String(string(in.Version))
 	}
 	{
-		const prefix string = ",\"secret\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		out.String(string(in.Secret))
-	}
-	if in.MaxStreamBitrate != 0 {
-		const prefix string = ",\"maxstreambitrate\":"
-		out.RawString(prefix)
-		out.Int(int(in.MaxStreamBitrate))
-	}
-	if in.MaxScreenBitrate != 0 {
-		const prefix string = ",\"maxscreenbitrate\":"
-		out.RawString(prefix)
-		out.Int(int(in.MaxScreenBitrate))
-	}
-	if in.SessionLimit != 0 {
-		const prefix string = ",\"sessionlimit\":"
+		const prefix string = ",\"roomid\":"
 		out.RawString(prefix)
-		out.Uint64(uint64(in.SessionLimit))
+		out.String(string(in.RoomId))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendInformationEtcd) MarshalJSON() ([]byte, error) {
+func (v BackendClientRingResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling29(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling53(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendInformationEtcd) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling29(w, v)
+func (v BackendClientRingResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling53(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendInformationEtcd) UnmarshalJSON(data []byte) error {
+func (v *BackendClientRingResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling29(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling53(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendInformationEtcd) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling29(l, v)
+func (v *BackendClientRingResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling53(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling30(in *jlexer.Lexer, out *BackendClientSessionResponse) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling54(in *jlexer.Lexer, out *BackendClientResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3846,10 +6914,68 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling30(in *jle
 			continue
 		}
 		switch key {
-		case "version":
-			out.Version = string(in.String())
-		case "roomid":
-			out.RoomId = string(in.String())
+		case "type":
+			out.Type = string(in.String())
+		case "error":
+			if in.IsNull() {
+				in.Skip()
+				out.Error = nil
+			} else {
+				if out.Error == nil {
+					out.Error = new(Error)
+				}
+				(*out.Error).UnmarshalEasyJSON(in)
+			}
+		case "auth":
+			if in.IsNull() {
+				in.Skip()
+				out.Auth = nil
+			} else {
+				if out.Auth == nil {
+					out.Auth = new(BackendClientAuthResponse)
+				}
+				(*out.Auth).UnmarshalEasyJSON(in)
+			}
+		case "room":
+			if in.IsNull() {
+				in.Skip()
+				out.Room = nil
+			} else {
+				if out.Room == nil {
+					out.Room = new(BackendClientRoomResponse)
+				}
+				(*out.Room).UnmarshalEasyJSON(in)
+			}
+		case "ping":
+			if in.IsNull() {
+				in.Skip()
+				out.Ping = nil
+			} else {
+				if out.Ping == nil {
+					out.Ping = new(BackendClientRingResponse)
+				}
+				(*out.Ping).UnmarshalEasyJSON(in)
+			}
+		case "session":
+			if in.IsNull() {
+				in.Skip()
+				out.Session = nil
+			} else {
+				if out.Session == nil {
+					out.Session = new(BackendClientSessionResponse)
+				}
+				(*out.Session).UnmarshalEasyJSON(in)
+			}
+		case "dialout":
+			if in.IsNull() {
+				in.Skip()
+				out.Dialout = nil
+			} else {
+				if out.Dialout == nil {
+					out.Dialout = new(BackendClientDialoutResponse)
+				}
+				(*out.Dialout).UnmarshalEasyJSON(in)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -3860,47 +6986,72 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling30(in *jle
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling30(out *jwriter.Writer, in BackendClientSessionResponse) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling54(out *jwriter.Writer, in BackendClientResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"version\":"
+		const prefix string = ",\"type\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Version))
+		out.String(string(in.Type))
 	}
-	{
-		const prefix string = ",\"roomid\":"
+	if in.Error != nil {
+		const prefix string = ",\"error\":"
 		out.RawString(prefix)
-		out.String(string(in.RoomId))
+		(*in.Error).MarshalEasyJSON(out)
+	}
+	if in.Auth != nil {
+		const prefix string = ",\"auth\":"
+		out.RawString(prefix)
+		(*in.Auth).MarshalEasyJSON(out)
+	}
+	if in.Room != nil {
+		const prefix string = ",\"room\":"
+		out.RawString(prefix)
+		(*in.Room).MarshalEasyJSON(out)
+	}
+	if in.Ping != nil {
+		const prefix string = ",\"ping\":"
+		out.RawString(prefix)
+		(*in.Ping).MarshalEasyJSON(out)
+	}
+	if in.Session != nil {
+		const prefix string = ",\"session\":"
+		out.RawString(prefix)
+		(*in.Session).MarshalEasyJSON(out)
+	}
+	if in.Dialout != nil {
+		const prefix string = ",\"dialout\":"
+		out.RawString(prefix)
+		(*in.Dialout).MarshalEasyJSON(out)
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendClientSessionResponse) MarshalJSON() ([]byte, error) {
+func (v BackendClientResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling30(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling54(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendClientSessionResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling30(w, v)
+func (v BackendClientResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling54(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendClientSessionResponse) UnmarshalJSON(data []byte) error {
+func (v *BackendClientResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling30(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling54(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendClientSessionResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling30(l, v)
+func (v *BackendClientResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling54(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling31(in *jlexer.Lexer, out *BackendClientSessionRequest) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling55(in *jlexer.Lexer, out *BackendClientRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3919,19 +7070,57 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling31(in *jle
 			continue
 		}
 		switch key {
-		case "version":
-			out.Version = string(in.String())
-		case "roomid":
-			out.RoomId = string(in.String())
-		case "action":
-			out.Action = string(in.String())
-		case "sessionid":
-			out.SessionId = PublicSessionId(in.String())
-		case "userid":
-			out.UserId = string(in.String())
-		case "user":
-			if data := in.Raw(); in.Ok() {
-				in.AddError((out.User).UnmarshalJSON(data))
+		case "type":
+			out.Type = string(in.String())
+		case "auth":
+			if in.IsNull() {
+				in.Skip()
+				out.Auth = nil
+			} else {
+				if out.Auth == nil {
+					out.Auth = new(BackendClientAuthRequest)
+				}
+				(*out.Auth).UnmarshalEasyJSON(in)
+			}
+		case "room":
+			if in.IsNull() {
+				in.Skip()
+				out.Room = nil
+			} else {
+				if out.Room == nil {
+					out.Room = new(BackendClientRoomRequest)
+				}
+				(*out.Room).UnmarshalEasyJSON(in)
+			}
+		case "ping":
+			if in.IsNull() {
+				in.Skip()
+				out.Ping = nil
+			} else {
+				if out.Ping == nil {
+					out.Ping = new(BackendClientPingRequest)
+				}
+				(*out.Ping).UnmarshalEasyJSON(in)
+			}
+		case "session":
+			if in.IsNull() {
+				in.Skip()
+				out.Session = nil
+			} else {
+				if out.Session == nil {
+					out.Session = new(BackendClientSessionRequest)
+				}
+				(*out.Session).UnmarshalEasyJSON(in)
+			}
+		case "dialout":
+			if in.IsNull() {
+				in.Skip()
+				out.Dialout = nil
+			} else {
+				if out.Dialout == nil {
+					out.Dialout = new(BackendClientDialoutRequest)
+				}
+				(*out.Dialout).UnmarshalEasyJSON(in)
 			}
 		default:
 			in.SkipRecursive()
@@ -3943,67 +7132,67 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling31(in *jle
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling31(out *jwriter.Writer, in BackendClientSessionRequest) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling55(out *jwriter.Writer, in BackendClientRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"version\":"
+		const prefix string = ",\"type\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Version))
+		out.String(string(in.Type))
 	}
-	{
-		const prefix string = ",\"roomid\":"
+	if in.Auth != nil {
+		const prefix string = ",\"auth\":"
 		out.RawString(prefix)
-		out.String(string(in.RoomId))
+		(*in.Auth).MarshalEasyJSON(out)
 	}
-	{
-		const prefix string = ",\"action\":"
+	if in.Room != nil {
+		const prefix string = ",\"room\":"
 		out.RawString(prefix)
-		out.String(string(in.Action))
+		(*in.Room).MarshalEasyJSON(out)
 	}
-	{
-		const prefix string = ",\"sessionid\":"
+	if in.Ping != nil {
+		const prefix string = ",\"ping\":"
 		out.RawString(prefix)
-		out.String(string(in.SessionId))
+		(*in.Ping).MarshalEasyJSON(out)
 	}
-	if in.UserId != "" {
-		const prefix string = ",\"userid\":"
+	if in.Session != nil {
+		const prefix string = ",\"session\":"
 		out.RawString(prefix)
-		out.String(string(in.UserId))
+		(*in.Session).MarshalEasyJSON(out)
 	}
-	if len(in.User) != 0 {
-		const prefix string = ",\"user\":"
+	if in.Dialout != nil {
+		const prefix string = ",\"dialout\":"
 		out.RawString(prefix)
-		out.Raw((in.User).MarshalJSON())
+		(*in.Dialout).MarshalEasyJSON(out)
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendClientSessionRequest) MarshalJSON() ([]byte, error) {
+func (v BackendClientRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling31(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling55(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendClientSessionRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling31(w, v)
+func (v BackendClientRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling55(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendClientSessionRequest) UnmarshalJSON(data []byte) error {
+func (v *BackendClientRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling31(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling55(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendClientSessionRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling31(l, v)
+func (v *BackendClientRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling55(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling32(in *jlexer.Lexer, out *BackendClientRoomResponse) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling56(in *jlexer.Lexer, out *BackendClientPingRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -4026,44 +7215,28 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling32(in *jle
 			out.Version = string(in.String())
 		case "roomid":
 			out.RoomId = string(in.String())
-		case "properties":
-			if data := in.Raw(); in.Ok() {
-				in.AddError((out.Properties).UnmarshalJSON(data))
-			}
-		case "session":
-			if data := in.Raw(); in.Ok() {
-				in.AddError((out.Session).UnmarshalJSON(data))
-			}
-		case "permissions":
+		case "entries":
 			if in.IsNull() {
 				in.Skip()
-				out.Permissions = nil
+				out.Entries = nil
 			} else {
-				if out.Permissions == nil {
-					out.Permissions = new([]Permission)
-				}
-				if in.IsNull() {
-					in.Skip()
-					*out.Permissions = nil
-				} else {
-					in.Delim('[')
-					if *out.Permissions == nil {
-						if !in.IsDelim(']') {
-							*out.Permissions = make([]Permission, 0, 4)
-						} else {
-							*out.Permissions = []Permission{}
-						}
+				in.Delim('[')
+				if out.Entries == nil {
+					if !in.IsDelim(']') {
+						out.Entries = make([]BackendPingEntry, 0, 2)
 					} else {
-						*out.Permissions = (*out.Permissions)[:0]
-					}
-					for !in.IsDelim(']') {
-						var v77 Permission
-						v77 = Permission(in.String())
-						*out.Permissions = append(*out.Permissions, v77)
-						in.WantComma()
+						out.Entries = []BackendPingEntry{}
 					}
-					in.Delim(']')
+				} else {
+					out.Entries = (out.Entries)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v143 BackendPingEntry
+					(v143).UnmarshalEasyJSON(in)
+					out.Entries = append(out.Entries, v143)
+					in.WantComma()
 				}
+				in.Delim(']')
 			}
 		default:
 			in.SkipRecursive()
@@ -4075,7 +7248,7 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling32(in *jle
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling32(out *jwriter.Writer, in BackendClientRoomResponse) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling56(out *jwriter.Writer, in BackendClientPingRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -4090,27 +7263,17 @@ func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling32(out *jw
 		out.String(string(in.RoomId))
 	}
 	{
-		const prefix string = ",\"properties\":"
-		out.RawString(prefix)
-		out.Raw((in.Properties).MarshalJSON())
-	}
-	if len(in.Session) != 0 {
-		const prefix string = ",\"session\":"
-		out.RawString(prefix)
-		out.Raw((in.Session).MarshalJSON())
-	}
-	if in.Permissions != nil {
-		const prefix string = ",\"permissions\":"
+		const prefix string = ",\"entries\":"
 		out.RawString(prefix)
-		if *in.Permissions == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		if in.Entries == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v78, v79 := range *in.Permissions {
-				if v78 > 0 {
+			for v144, v145 := range in.Entries {
+				if v144 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v79))
+				(v145).MarshalEasyJSON(out)
 			}
 			out.RawByte(']')
 		}
@@ -4119,29 +7282,29 @@ func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling32(out *jw
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendClientRoomResponse) MarshalJSON() ([]byte, error) {
+func (v BackendClientPingRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling32(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling56(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendClientRoomResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling32(w, v)
+func (v BackendClientPingRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling56(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendClientRoomResponse) UnmarshalJSON(data []byte) error {
+func (v *BackendClientPingRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling32(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling56(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendClientRoomResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling32(l, v)
+func (v *BackendClientPingRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling56(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling33(in *jlexer.Lexer, out *BackendClientRoomRequest) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling57(in *jlexer.Lexer, out *BackendClientDialoutResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -4164,18 +7327,6 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling33(in *jle
 			out.Version = string(in.String())
 		case "roomid":
 			out.RoomId = string(in.String())
-		case "action":
-			out.Action = string(in.String())
-		case "userid":
-			out.UserId = string(in.String())
-		case "sessionid":
-			out.SessionId = RoomSessionId(in.String())
-		case "actorid":
-			out.ActorId = string(in.String())
-		case "actortype":
-			out.ActorType = string(in.String())
-		case "incall":
-			out.InCall = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -4186,7 +7337,7 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling33(in *jle
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling33(out *jwriter.Writer, in BackendClientRoomRequest) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling57(out *jwriter.Writer, in BackendClientDialoutResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -4200,63 +7351,33 @@ func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling33(out *jw
 		out.RawString(prefix)
 		out.String(string(in.RoomId))
 	}
-	if in.Action != "" {
-		const prefix string = ",\"action\":"
-		out.RawString(prefix)
-		out.String(string(in.Action))
-	}
-	{
-		const prefix string = ",\"userid\":"
-		out.RawString(prefix)
-		out.String(string(in.UserId))
-	}
-	{
-		const prefix string = ",\"sessionid\":"
-		out.RawString(prefix)
-		out.String(string(in.SessionId))
-	}
-	if in.ActorId != "" {
-		const prefix string = ",\"actorid\":"
-		out.RawString(prefix)
-		out.String(string(in.ActorId))
-	}
-	if in.ActorType != "" {
-		const prefix string = ",\"actortype\":"
-		out.RawString(prefix)
-		out.String(string(in.ActorType))
-	}
-	if in.InCall != 0 {
-		const prefix string = ",\"incall\":"
-		out.RawString(prefix)
-		out.Int(int(in.InCall))
-	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendClientRoomRequest) MarshalJSON() ([]byte, error) {
+func (v BackendClientDialoutResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling33(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling57(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendClientRoomRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling33(w, v)
+func (v BackendClientDialoutResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling57(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendClientRoomRequest) UnmarshalJSON(data []byte) error {
+func (v *BackendClientDialoutResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling33(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling57(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendClientRoomRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling33(l, v)
+func (v *BackendClientDialoutResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling57(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling34(in *jlexer.Lexer, out *BackendClientRingResponse) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling58(in *jlexer.Lexer, out *BackendClientDialoutRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -4279,6 +7400,18 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling34(in *jle
 			out.Version = string(in.String())
 		case "roomid":
 			out.RoomId = string(in.String())
+		case "callid":
+			out.CallId = string(in.String())
+		case "status":
+			out.Status = DialoutStatus(in.String())
+		case "cause":
+			out.Cause = string(in.String())
+		case "code":
+			out.Code = int(in.Int())
+		case "message":
+			out.Message = string(in.String())
+		case "reason":
+			out.Reason = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -4289,7 +7422,7 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling34(in *jle
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling34(out *jwriter.Writer, in BackendClientRingResponse) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling58(out *jwriter.Writer, in BackendClientDialoutRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -4303,33 +7436,63 @@ func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling34(out *jw
 		out.RawString(prefix)
 		out.String(string(in.RoomId))
 	}
+	{
+		const prefix string = ",\"callid\":"
+		out.RawString(prefix)
+		out.String(string(in.CallId))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	if in.Cause != "" {
+		const prefix string = ",\"cause\":"
+		out.RawString(prefix)
+		out.String(string(in.Cause))
+	}
+	if in.Code != 0 {
+		const prefix string = ",\"code\":"
+		out.RawString(prefix)
+		out.Int(int(in.Code))
+	}
+	if in.Message != "" {
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	if in.Reason != "" {
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendClientRingResponse) MarshalJSON() ([]byte, error) {
+func (v BackendClientDialoutRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling34(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling58(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendClientRingResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling34(w, v)
+func (v BackendClientDialoutRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling58(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendClientRingResponse) UnmarshalJSON(data []byte) error {
+func (v *BackendClientDialoutRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling34(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling58(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendClientRingResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling34(l, v)
+func (v *BackendClientDialoutRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling58(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling35(in *jlexer.Lexer, out *BackendClientResponse) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling59(in *jlexer.Lexer, out *BackendClientAuthResponse) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -4348,57 +7511,13 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling35(in *jle
 			continue
 		}
 		switch key {
-		case "type":
-			out.Type = string(in.String())
-		case "error":
-			if in.IsNull() {
-				in.Skip()
-				out.Error = nil
-			} else {
-				if out.Error == nil {
-					out.Error = new(Error)
-				}
-				(*out.Error).UnmarshalEasyJSON(in)
-			}
-		case "auth":
-			if in.IsNull() {
-				in.Skip()
-				out.Auth = nil
-			} else {
-				if out.Auth == nil {
-					out.Auth = new(BackendClientAuthResponse)
-				}
-				(*out.Auth).UnmarshalEasyJSON(in)
-			}
-		case "room":
-			if in.IsNull() {
-				in.Skip()
-				out.Room = nil
-			} else {
-				if out.Room == nil {
-					out.Room = new(BackendClientRoomResponse)
-				}
-				(*out.Room).UnmarshalEasyJSON(in)
-			}
-		case "ping":
-			if in.IsNull() {
-				in.Skip()
-				out.Ping = nil
-			} else {
-				if out.Ping == nil {
-					out.Ping = new(BackendClientRingResponse)
-				}
-				(*out.Ping).UnmarshalEasyJSON(in)
-			}
-		case "session":
-			if in.IsNull() {
-				in.Skip()
-				out.Session = nil
-			} else {
-				if out.Session == nil {
-					out.Session = new(BackendClientSessionResponse)
-				}
-				(*out.Session).UnmarshalEasyJSON(in)
+		case "version":
+			out.Version = string(in.String())
+		case "userid":
+			out.UserId = string(in.String())
+		case "user":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.User).UnmarshalJSON(data))
 			}
 		default:
 			in.SkipRecursive()
@@ -4410,67 +7529,52 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling35(in *jle
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling35(out *jwriter.Writer, in BackendClientResponse) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling59(out *jwriter.Writer, in BackendClientAuthResponse) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"type\":"
+		const prefix string = ",\"version\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Type))
-	}
-	if in.Error != nil {
-		const prefix string = ",\"error\":"
-		out.RawString(prefix)
-		(*in.Error).MarshalEasyJSON(out)
-	}
-	if in.Auth != nil {
-		const prefix string = ",\"auth\":"
-		out.RawString(prefix)
-		(*in.Auth).MarshalEasyJSON(out)
-	}
-	if in.Room != nil {
-		const prefix string = ",\"room\":"
-		out.RawString(prefix)
-		(*in.Room).MarshalEasyJSON(out)
+		out.String(string(in.Version))
 	}
-	if in.Ping != nil {
-		const prefix string = ",\"ping\":"
+	{
+		const prefix string = ",\"userid\":"
 		out.RawString(prefix)
-		(*in.Ping).MarshalEasyJSON(out)
+		out.String(string(in.UserId))
 	}
-	if in.Session != nil {
-		const prefix string = ",\"session\":"
+	{
+		const prefix string = ",\"user\":"
 		out.RawString(prefix)
-		(*in.Session).MarshalEasyJSON(out)
+		out.Raw((in.User).MarshalJSON())
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendClientResponse) MarshalJSON() ([]byte, error) {
+func (v BackendClientAuthResponse) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling35(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling59(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendClientResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling35(w, v)
+func (v BackendClientAuthResponse) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling59(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendClientResponse) UnmarshalJSON(data []byte) error {
+func (v *BackendClientAuthResponse) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling35(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling59(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendClientResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling35(l, v)
+func (v *BackendClientAuthResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling59(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling36(in *jlexer.Lexer, out *BackendClientRequest) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling60(in *jlexer.Lexer, out *BackendClientAuthRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -4489,47 +7593,11 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling36(in *jle
 			continue
 		}
 		switch key {
-		case "type":
-			out.Type = string(in.String())
-		case "auth":
-			if in.IsNull() {
-				in.Skip()
-				out.Auth = nil
-			} else {
-				if out.Auth == nil {
-					out.Auth = new(BackendClientAuthRequest)
-				}
-				(*out.Auth).UnmarshalEasyJSON(in)
-			}
-		case "room":
-			if in.IsNull() {
-				in.Skip()
-				out.Room = nil
-			} else {
-				if out.Room == nil {
-					out.Room = new(BackendClientRoomRequest)
-				}
-				(*out.Room).UnmarshalEasyJSON(in)
-			}
-		case "ping":
-			if in.IsNull() {
-				in.Skip()
-				out.Ping = nil
-			} else {
-				if out.Ping == nil {
-					out.Ping = new(BackendClientPingRequest)
-				}
-				(*out.Ping).UnmarshalEasyJSON(in)
-			}
-		case "session":
-			if in.IsNull() {
-				in.Skip()
-				out.Session = nil
-			} else {
-				if out.Session == nil {
-					out.Session = new(BackendClientSessionRequest)
-				}
-				(*out.Session).UnmarshalEasyJSON(in)
+		case "version":
+			out.Version = string(in.String())
+		case "params":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Params).UnmarshalJSON(data))
 			}
 		default:
 			in.SkipRecursive()
@@ -4541,62 +7609,47 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling36(in *jle
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling36(out *jwriter.Writer, in BackendClientRequest) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling60(out *jwriter.Writer, in BackendClientAuthRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"type\":"
+		const prefix string = ",\"version\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Type))
-	}
-	if in.Auth != nil {
-		const prefix string = ",\"auth\":"
-		out.RawString(prefix)
-		(*in.Auth).MarshalEasyJSON(out)
-	}
-	if in.Room != nil {
-		const prefix string = ",\"room\":"
-		out.RawString(prefix)
-		(*in.Room).MarshalEasyJSON(out)
-	}
-	if in.Ping != nil {
-		const prefix string = ",\"ping\":"
-		out.RawString(prefix)
-		(*in.Ping).MarshalEasyJSON(out)
+		out.String(string(in.Version))
 	}
-	if in.Session != nil {
-		const prefix string = ",\"session\":"
+	{
+		const prefix string = ",\"params\":"
 		out.RawString(prefix)
-		(*in.Session).MarshalEasyJSON(out)
+		out.Raw((in.Params).MarshalJSON())
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendClientRequest) MarshalJSON() ([]byte, error) {
+func (v BackendClientAuthRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling36(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling60(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendClientRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling36(w, v)
+func (v BackendClientAuthRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling60(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendClientRequest) UnmarshalJSON(data []byte) error {
+func (v *BackendClientAuthRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling36(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling60(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendClientRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling36(l, v)
+func (v *BackendClientAuthRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling60(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling37(in *jlexer.Lexer, out *BackendClientPingRequest) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling61(in *jlexer.Lexer, out *AdminServerSessions) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -4615,29 +7668,33 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling37(in *jle
 			continue
 		}
 		switch key {
-		case "version":
-			out.Version = string(in.String())
-		case "roomid":
-			out.RoomId = string(in.String())
-		case "entries":
+		case "sessions":
 			if in.IsNull() {
 				in.Skip()
-				out.Entries = nil
+				out.Sessions = nil
 			} else {
 				in.Delim('[')
-				if out.Entries == nil {
+				if out.Sessions == nil {
 					if !in.IsDelim(']') {
-						out.Entries = make([]BackendPingEntry, 0, 2)
+						out.Sessions = make([]*BackendServerSessionInfo, 0, 8)
 					} else {
-						out.Entries = []BackendPingEntry{}
+						out.Sessions = []*BackendServerSessionInfo{}
 					}
 				} else {
-					out.Entries = (out.Entries)[:0]
+					out.Sessions = (out.Sessions)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v80 BackendPingEntry
-					(v80).UnmarshalEasyJSON(in)
-					out.Entries = append(out.Entries, v80)
+					var v146 *BackendServerSessionInfo
+					if in.IsNull() {
+						in.Skip()
+						v146 = nil
+					} else {
+						if v146 == nil {
+							v146 = new(BackendServerSessionInfo)
+						}
+						(*v146).UnmarshalEasyJSON(in)
+					}
+					out.Sessions = append(out.Sessions, v146)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -4652,32 +7709,26 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling37(in *jle
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling37(out *jwriter.Writer, in BackendClientPingRequest) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling61(out *jwriter.Writer, in AdminServerSessions) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"version\":"
+		const prefix string = ",\"sessions\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Version))
-	}
-	{
-		const prefix string = ",\"roomid\":"
-		out.RawString(prefix)
-		out.String(string(in.RoomId))
-	}
-	{
-		const prefix string = ",\"entries\":"
-		out.RawString(prefix)
-		if in.Entries == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		if in.Sessions == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 			out.RawString("null")
 		} else {
 			out.RawByte('[')
-			for v81, v82 := range in.Entries {
-				if v81 > 0 {
+			for v147, v148 := range in.Sessions {
+				if v147 > 0 {
 					out.RawByte(',')
 				}
-				(v82).MarshalEasyJSON(out)
+				if v148 == nil {
+					out.RawString("null")
+				} else {
+					(*v148).MarshalEasyJSON(out)
+				}
 			}
 			out.RawByte(']')
 		}
@@ -4686,29 +7737,29 @@ func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling37(out *jw
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendClientPingRequest) MarshalJSON() ([]byte, error) {
+func (v AdminServerSessions) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling37(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling61(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendClientPingRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling37(w, v)
+func (v AdminServerSessions) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling61(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendClientPingRequest) UnmarshalJSON(data []byte) error {
+func (v *AdminServerSessions) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling37(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling61(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendClientPingRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling37(l, v)
+func (v *AdminServerSessions) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling61(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling38(in *jlexer.Lexer, out *BackendClientAuthResponse) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling62(in *jlexer.Lexer, out *AdminServerRooms) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -4727,13 +7778,36 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling38(in *jle
 			continue
 		}
 		switch key {
-		case "version":
-			out.Version = string(in.String())
-		case "userid":
-			out.UserId = string(in.String())
-		case "user":
-			if data := in.Raw(); in.Ok() {
-				in.AddError((out.User).UnmarshalJSON(data))
+		case "rooms":
+			if in.IsNull() {
+				in.Skip()
+				out.Rooms = nil
+			} else {
+				in.Delim('[')
+				if out.Rooms == nil {
+					if !in.IsDelim(']') {
+						out.Rooms = make([]*AdminServerRoom, 0, 8)
+					} else {
+						out.Rooms = []*AdminServerRoom{}
+					}
+				} else {
+					out.Rooms = (out.Rooms)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v149 *AdminServerRoom
+					if in.IsNull() {
+						in.Skip()
+						v149 = nil
+					} else {
+						if v149 == nil {
+							v149 = new(AdminServerRoom)
+						}
+						(*v149).UnmarshalEasyJSON(in)
+					}
+					out.Rooms = append(out.Rooms, v149)
+					in.WantComma()
+				}
+				in.Delim(']')
 			}
 		default:
 			in.SkipRecursive()
@@ -4745,52 +7819,57 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling38(in *jle
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling38(out *jwriter.Writer, in BackendClientAuthResponse) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling62(out *jwriter.Writer, in AdminServerRooms) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"version\":"
+		const prefix string = ",\"rooms\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Version))
-	}
-	{
-		const prefix string = ",\"userid\":"
-		out.RawString(prefix)
-		out.String(string(in.UserId))
-	}
-	{
-		const prefix string = ",\"user\":"
-		out.RawString(prefix)
-		out.Raw((in.User).MarshalJSON())
+		if in.Rooms == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v150, v151 := range in.Rooms {
+				if v150 > 0 {
+					out.RawByte(',')
+				}
+				if v151 == nil {
+					out.RawString("null")
+				} else {
+					(*v151).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendClientAuthResponse) MarshalJSON() ([]byte, error) {
+func (v AdminServerRooms) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling38(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling62(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendClientAuthResponse) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling38(w, v)
+func (v AdminServerRooms) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling62(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendClientAuthResponse) UnmarshalJSON(data []byte) error {
+func (v *AdminServerRooms) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling38(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling62(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendClientAuthResponse) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling38(l, v)
+func (v *AdminServerRooms) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling62(l, v)
 }
-func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling39(in *jlexer.Lexer, out *BackendClientAuthRequest) {
+func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling63(in *jlexer.Lexer, out *AdminServerRoom) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -4809,12 +7888,14 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling39(in *jle
 			continue
 		}
 		switch key {
-		case "version":
-			out.Version = string(in.String())
-		case "params":
-			if data := in.Raw(); in.Ok() {
-				in.AddError((out.Params).UnmarshalJSON(data))
-			}
+		case "roomid":
+			out.RoomId = string(in.String())
+		case "backend":
+			out.Backend = string(in.String())
+		case "sessions":
+			out.Sessions = int(in.Int())
+		case "incall":
+			out.InCall = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -4825,43 +7906,53 @@ func easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling39(in *jle
 		in.Consumed()
 	}
 }
-func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling39(out *jwriter.Writer, in BackendClientAuthRequest) {
+func easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling63(out *jwriter.Writer, in AdminServerRoom) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"version\":"
+		const prefix string = ",\"roomid\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Version))
+		out.String(string(in.RoomId))
 	}
 	{
-		const prefix string = ",\"params\":"
+		const prefix string = ",\"backend\":"
 		out.RawString(prefix)
-		out.Raw((in.Params).MarshalJSON())
+		out.String(string(in.Backend))
+	}
+	{
+		const prefix string = ",\"sessions\":"
+		out.RawString(prefix)
+		out.Int(int(in.Sessions))
+	}
+	{
+		const prefix string = ",\"incall\":"
+		out.RawString(prefix)
+		out.Int(int(in.InCall))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v BackendClientAuthRequest) MarshalJSON() ([]byte, error) {
+func (v AdminServerRoom) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling39(&w, v)
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling63(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v BackendClientAuthRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling39(w, v)
+func (v AdminServerRoom) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson4354c623EncodeGithubComStrukturagNextcloudSpreedSignaling63(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *BackendClientAuthRequest) UnmarshalJSON(data []byte) error {
+func (v *AdminServerRoom) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling39(&r, v)
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling63(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *BackendClientAuthRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling39(l, v)
+func (v *AdminServerRoom) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson4354c623DecodeGithubComStrukturagNextcloudSpreedSignaling63(l, v)
 }
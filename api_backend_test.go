@@ -73,6 +73,35 @@ func TestValidNumbers(t *testing.T) {
 	}
 }
 
+func TestNormalizeNumber(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	testcases := map[string]string{
+		"+12345":              "+12345",
+		" +12345 ":            "+12345",
+		"+123-45":             "+12345",
+		"0049 (30) 1234/5678": "+493012345678",
+		"+1.555.123.4567":     "+15551234567",
+		"12345":               "12345",
+	}
+	for number, expected := range testcases {
+		assert.Equal(expected, NormalizeNumber(number), "failed for %s", number)
+	}
+
+	request := &BackendRoomDialoutRequest{
+		Number: "0049 30 1234",
+	}
+	assert.Nil(request.ValidateNumber())
+	assert.Equal("+49301234", request.Number)
+
+	for _, number := range []string{"", "12345", "+0123", "+1234567890123456", "+12a45"} {
+		request := &BackendRoomDialoutRequest{
+			Number: number,
+		}
+		assert.NotNil(request.ValidateNumber(), "number %s should be rejected", number)
+	}
+}
+
 func TestValidateBackendInformationEtcd(t *testing.T) {
 	t.Parallel()
 	assert := assert.New(t)
@@ -0,0 +1,207 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+const (
+	jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+)
+
+var (
+	jsonRawMessageType = reflect.TypeFor[json.RawMessage]()
+	timeTimeType       = reflect.TypeFor[time.Time]()
+	timeDurationType   = reflect.TypeFor[time.Duration]()
+)
+
+// jsonSchemaGenerator creates JSON schema definitions from Go types using the
+// same field names as the JSON encoder.
+type jsonSchemaGenerator struct {
+	defs map[string]any
+}
+
+func jsonSchemaName(t reflect.Type) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, t.Name())
+}
+
+func (g *jsonSchemaGenerator) schemaFor(t reflect.Type) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t {
+	case jsonRawMessageType:
+		return map[string]any{}
+	case timeTimeType:
+		return map[string]any{
+			"type":   "string",
+			"format": "date-time",
+		}
+	case timeDurationType:
+		return map[string]any{
+			"type": "integer",
+		}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{
+			"type": "boolean",
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]any{
+			"type": "integer",
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{
+			"type":    "integer",
+			"minimum": 0,
+		}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{
+			"type": "number",
+		}
+	case reflect.String:
+		return map[string]any{
+			"type": "string",
+		}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// Encoded as base64 string.
+			return map[string]any{
+				"type": "string",
+			}
+		}
+		return map[string]any{
+			"type":  "array",
+			"items": g.schemaFor(t.Elem()),
+		}
+	case reflect.Map:
+		return map[string]any{
+			"type":                 "object",
+			"additionalProperties": g.schemaFor(t.Elem()),
+		}
+	case reflect.Struct:
+		name := jsonSchemaName(t)
+		if name == "" {
+			return g.structSchema(t)
+		}
+
+		if _, found := g.defs[name]; !found {
+			// Register before generating the properties to support recursive types.
+			g.defs[name] = nil
+			g.defs[name] = g.structSchema(t)
+		}
+		return map[string]any{
+			"$ref": "#/$defs/" + name,
+		}
+	default:
+		// Interfaces can contain any value.
+		return map[string]any{}
+	}
+}
+
+func (g *jsonSchemaGenerator) addStructFields(t reflect.Type, properties map[string]any, required *[]string) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			switch ft.Kind() {
+			case reflect.Struct:
+				g.addStructFields(ft, properties, required)
+				continue
+			case reflect.Interface:
+				// Embedded interfaces like "json.Marshaler" are not serialized.
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = g.schemaFor(field.Type)
+		if !strings.Contains(options, "omitempty") && !strings.Contains(options, "omitzero") && field.Type.Kind() != reflect.Pointer {
+			*required = append(*required, name)
+		}
+	}
+}
+
+func (g *jsonSchemaGenerator) structSchema(t reflect.Type) map[string]any {
+	properties := make(map[string]any)
+	var required []string
+	g.addStructFields(t, properties, &required)
+
+	result := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		result["required"] = required
+	}
+	return result
+}
+
+// GetSignalingJsonSchema returns a JSON schema of the messages that can be
+// exchanged between clients and the signaling server.
+func GetSignalingJsonSchema(version string) ([]byte, error) {
+	g := &jsonSchemaGenerator{
+		defs: make(map[string]any),
+	}
+
+	client := g.schemaFor(reflect.TypeFor[ClientMessage]())
+	server := g.schemaFor(reflect.TypeFor[ServerMessage]())
+	schema := map[string]any{
+		"$schema":     jsonSchemaDialect,
+		"title":       "nextcloud-spreed-signaling " + version,
+		"description": "Messages of the standalone signaling API v1/v2.",
+		"anyOf": []any{
+			client,
+			server,
+		},
+		"$defs": g.defs,
+	}
+	return json.MarshalIndent(schema, "", "  ")
+}
@@ -0,0 +1,125 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSchemaEmbedded struct {
+	Embedded string `json:"embedded"`
+}
+
+type testSchemaMessage struct {
+	json.Marshaler
+
+	testSchemaEmbedded
+
+	Id       string             `json:"id,omitempty"`
+	Count    uint32             `json:"count"`
+	Data     json.RawMessage    `json:"data,omitempty"`
+	Values   []PublicSessionId  `json:"values,omitempty"`
+	Child    *testSchemaMessage `json:"child,omitempty"`
+	Ignored  string             `json:"-"`
+	internal string
+}
+
+func TestJsonSchemaGenerator(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	g := &jsonSchemaGenerator{
+		defs: make(map[string]any),
+	}
+
+	assert.Equal(map[string]any{
+		"$ref": "#/$defs/testSchemaMessage",
+	}, g.schemaFor(reflect.TypeFor[*testSchemaMessage]()))
+	assert.Equal(map[string]any{
+		"testSchemaMessage": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"embedded": map[string]any{
+					"type": "string",
+				},
+				"id": map[string]any{
+					"type": "string",
+				},
+				"count": map[string]any{
+					"type":    "integer",
+					"minimum": 0,
+				},
+				"data": map[string]any{},
+				"values": map[string]any{
+					"type": "array",
+					"items": map[string]any{
+						"type": "string",
+					},
+				},
+				"child": map[string]any{
+					"$ref": "#/$defs/testSchemaMessage",
+				},
+			},
+			"required": []string{
+				"embedded",
+				"count",
+			},
+		},
+	}, g.defs)
+}
+
+func TestGetSignalingJsonSchema(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	assert := assert.New(t)
+
+	data, err := GetSignalingJsonSchema("1.2.3")
+	require.NoError(err)
+
+	var schema struct {
+		Title string                    `json:"title"`
+		AnyOf []map[string]string       `json:"anyOf"`
+		Defs  map[string]map[string]any `json:"$defs"`
+	}
+	require.NoError(json.Unmarshal(data, &schema))
+	assert.Equal("nextcloud-spreed-signaling 1.2.3", schema.Title)
+	assert.Equal([]map[string]string{
+		{"$ref": "#/$defs/ClientMessage"},
+		{"$ref": "#/$defs/ServerMessage"},
+	}, schema.AnyOf)
+	for _, name := range []string{
+		"ClientMessage",
+		"HelloClientMessage",
+		"ServerMessage",
+		"EventServerMessage",
+	} {
+		assert.Contains(schema.Defs, name)
+	}
+	if properties, ok := schema.Defs["ClientMessage"]["properties"].(map[string]any); assert.True(ok) {
+		assert.Contains(properties, "hello")
+		assert.NotContains(properties, "Marshaler")
+	}
+}
@@ -25,7 +25,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"net"
 	"net/url"
 	"slices"
@@ -101,6 +100,17 @@ type ClientMessage struct {
 	Internal *InternalClientMessage `json:"internal,omitempty"`
 
 	TransientData *TransientDataClientMessage `json:"transient,omitempty"`
+
+	Participants *ParticipantsClientMessage `json:"participants,omitempty"`
+
+	Echo *EchoClientMessage `json:"echo,omitempty"`
+
+	Dtmf *DtmfClientMessage `json:"dtmf,omitempty"`
+
+	Recording *RecordingClientMessage `json:"recording,omitempty"`
+
+	// Detached signature of messages sent by federated sessions (optional).
+	Signature string `json:"signature,omitempty"`
 }
 
 func (m *ClientMessage) CheckValid() error {
@@ -145,6 +155,30 @@ func (m *ClientMessage) CheckValid() error {
 		} else if err := m.TransientData.CheckValid(); err != nil {
 			return err
 		}
+	case "participants":
+		if m.Participants == nil {
+			return fmt.Errorf("participants missing")
+		} else if err := m.Participants.CheckValid(); err != nil {
+			return err
+		}
+	case "echo":
+		if m.Echo == nil {
+			return fmt.Errorf("echo missing")
+		} else if err := m.Echo.CheckValid(); err != nil {
+			return err
+		}
+	case "dtmf":
+		if m.Dtmf == nil {
+			return fmt.Errorf("dtmf missing")
+		} else if err := m.Dtmf.CheckValid(); err != nil {
+			return err
+		}
+	case "recording":
+		if m.Recording == nil {
+			return fmt.Errorf("recording missing")
+		} else if err := m.Recording.CheckValid(); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -158,6 +192,10 @@ func (m ClientMessage) String() string {
 }
 
 func (m *ClientMessage) NewErrorServerMessage(e *Error) *ServerMessage {
+	if m.Type == "hello" && m.Hello != nil && m.Hello.Language != "" {
+		// No session exists yet that could localize the error.
+		e = e.Localize(GetErrorLanguage(m.Hello.Language))
+	}
 	return &ServerMessage{
 		Id:    m.Id,
 		Type:  "error",
@@ -203,6 +241,8 @@ type ServerMessage struct {
 	Internal *InternalServerMessage `json:"internal,omitempty"`
 
 	Dialout *DialoutInternalClientMessage `json:"dialout,omitempty"`
+
+	Echo *EchoServerMessage `json:"echo,omitempty"`
 }
 
 func (r *ServerMessage) CloseAfterSend(session Session) bool {
@@ -252,6 +292,34 @@ func (r *ServerMessage) IsParticipantsUpdate() bool {
 	return true
 }
 
+// IsPriority returns true for control-plane messages (e.g. call control or
+// requests to internal clients) that should be delivered before bulk messages
+// like chat or signaling if a session receives many messages.
+func (r *ServerMessage) IsPriority() bool {
+	switch r.Type {
+	case "bye":
+		fallthrough
+	case "control":
+		fallthrough
+	case "internal":
+		return true
+	case "event":
+		if r.Event == nil {
+			return false
+		}
+
+		switch r.Event.Type {
+		case "dtmf":
+			fallthrough
+		case "recording":
+			fallthrough
+		case "degraded":
+			return true
+		}
+	}
+	return false
+}
+
 func (r *ServerMessage) String() string {
 	data, err := json.Marshal(r)
 	if err != nil {
@@ -261,29 +329,48 @@ func (r *ServerMessage) String() string {
 }
 
 type Error struct {
-	Code    string          `json:"code"`
-	Message string          `json:"message"`
-	Details json.RawMessage `json:"details,omitempty"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// Optional structured parameters of the error, e.g. limits that were hit.
+	Params StringMap `json:"params,omitempty"`
+	// Optional message in the language the client requested.
+	Localized string          `json:"localized,omitempty"`
+	Details   json.RawMessage `json:"details,omitempty"`
+
+	// Message before the parameters were applied, used for translations.
+	template string
 }
 
 func NewError(code string, message string) *Error {
 	return NewErrorDetail(code, message, nil)
 }
 
+// NewErrorWithParams creates an error with structured parameters. Placeholders
+// like "{name}" in the message are replaced with the value of the parameter.
+func NewErrorWithParams(code string, message string, params StringMap) *Error {
+	return &Error{
+		Code:     code,
+		Message:  formatErrorMessage(message, params),
+		Params:   params,
+		template: message,
+	}
+}
+
 func NewErrorDetail(code string, message string, details any) *Error {
 	var rawDetails json.RawMessage
 	if details != nil {
 		var err error
 		if rawDetails, err = json.Marshal(details); err != nil {
-			log.Printf("Could not marshal details %+v for error %s with %s: %s", details, code, message, err)
+			hubLog.Errorf("Could not marshal details %+v for error %s with %s: %s", details, code, message, err)
 			return NewError("internal_error", "Could not marshal error details")
 		}
 	}
 
 	return &Error{
-		Code:    code,
-		Message: message,
-		Details: rawDetails,
+		Code:     code,
+		Message:  message,
+		Details:  rawDetails,
+		template: message,
 	}
 }
 
@@ -292,9 +379,20 @@ func (e *Error) Error() string {
 }
 
 type WelcomeServerMessage struct {
-	Version  string   `json:"version"`
-	Features []string `json:"features,omitempty"`
-	Country  string   `json:"country,omitempty"`
+	Version  string             `json:"version"`
+	Features []string           `json:"features,omitempty"`
+	Country  string             `json:"country,omitempty"`
+	Time     *ServerTimeMessage `json:"time,omitempty"`
+}
+
+// ServerTimeMessage contains the current time of the server so clients can
+// calculate the offset of their clock.
+type ServerTimeMessage struct {
+	// Time in milliseconds since the epoch.
+	Now int64 `json:"now"`
+	// Time in milliseconds since the server was started. This is based on a
+	// monotonic clock and not affected by changes of the system time.
+	Monotonic int64 `json:"monotonic"`
 }
 
 func NewWelcomeServerMessage(version string, feature ...string) *WelcomeServerMessage {
@@ -394,6 +492,9 @@ func (p *ClientTypeInternalAuthParams) CheckValid() error {
 
 type HelloV2AuthParams struct {
 	Token string `json:"token"`
+
+	// Proof of possession of the key the token is bound to (if any).
+	Proof string `json:"proof,omitempty"`
 }
 
 func (p *HelloV2AuthParams) CheckValid() error {
@@ -409,10 +510,18 @@ type AuthTokenClaims interface {
 	GetUserData() json.RawMessage
 }
 
+// HelloV2TokenConfirmation is the "cnf" claim (see RFC 7800) that binds a
+// Hello v2 token to a key generated by the client.
+type HelloV2TokenConfirmation struct {
+	// Base64url-encoded SHA-256 JWK thumbprint (see RFC 7638) of the client key.
+	JwkThumbprint string `json:"jkt,omitempty"`
+}
+
 type HelloV2TokenClaims struct {
 	jwt.RegisteredClaims
 
-	UserData json.RawMessage `json:"userdata,omitempty"`
+	UserData     json.RawMessage           `json:"userdata,omitempty"`
+	Confirmation *HelloV2TokenConfirmation `json:"cnf,omitempty"`
 }
 
 func (c *HelloV2TokenClaims) GetUserData() json.RawMessage {
@@ -464,14 +573,48 @@ type HelloClientMessage struct {
 
 	Features []string `json:"features,omitempty"`
 
+	// Optional interval in seconds in which the client wants to receive pings.
+	PingInterval int `json:"pinginterval,omitempty"`
+
+	// Optional hints about the client that are used when subscribing streams.
+	Hints *HelloClientMessageHints `json:"hints,omitempty"`
+
+	// Optional list of preferred languages for error messages, using the
+	// format of the "Accept-Language" HTTP header.
+	Language string `json:"language,omitempty"`
+
 	// The authentication credentials.
 	Auth *HelloClientMessageAuth `json:"auth,omitempty"`
 }
 
+type HelloClientMessageHints struct {
+	// Maximum downlink bandwidth of the client in bits per second.
+	MaxDownlink int `json:"maxdownlink,omitempty"`
+	// The client is running in battery saving mode.
+	BatterySaver bool `json:"batterysaver,omitempty"`
+	// The client is running on a mobile device.
+	Mobile bool `json:"mobile,omitempty"`
+}
+
+func (h *HelloClientMessageHints) CheckValid() error {
+	if h.MaxDownlink < 0 {
+		return fmt.Errorf("invalid max downlink")
+	}
+	return nil
+}
+
 func (m *HelloClientMessage) CheckValid() error {
 	if m.Version != HelloVersionV1 && m.Version != HelloVersionV2 {
 		return InvalidHelloVersion
 	}
+	if m.PingInterval < 0 {
+		return fmt.Errorf("invalid ping interval")
+	}
+	if m.Hints != nil {
+		if err := m.Hints.CheckValid(); err != nil {
+			return err
+		}
+	}
 	if m.ResumeId == "" {
 		if m.Auth == nil || len(m.Auth.Params) == 0 {
 			return fmt.Errorf("params missing")
@@ -547,68 +690,213 @@ const (
 	ServerFeatureInCallAll             = "incall-all"
 	ServerFeatureWelcome               = "welcome"
 	ServerFeatureHelloV2               = "hello-v2"
+	ServerFeatureHelloV2Confirmation   = "hello-v2-cnf"
 	ServerFeatureSwitchTo              = "switchto"
+	ServerFeatureBreakoutRooms         = "breakout-rooms"
 	ServerFeatureDialout               = "dialout"
 	ServerFeatureFederation            = "federation"
 	ServerFeatureRecipientCall         = "recipient-call"
 	ServerFeatureJoinFeatures          = "join-features"
 	ServerFeatureOfferCodecs           = "offer-codecs"
 	ServerFeatureServerInfo            = "serverinfo"
+	ServerFeatureCbor                  = "cbor"
+	ServerFeatureResync                = "resync"
+	ServerFeaturePingInterval          = "ping-interval"
+	ServerFeatureReceipts              = "receipts"
+	ServerFeatureMultipleRecipients    = "multiple-recipients"
+	ServerFeatureJoinChunks            = "join-chunks"
+	ServerFeatureParticipantsDelta     = "participants-delta"
+	ServerFeatureClientHints           = "client-hints"
+	ServerFeatureEcho                  = "echo"
+	ServerFeatureSchema                = "schema"
+	ServerFeatureSse                   = "sse"
+	ServerFeatureWebTransport          = "webtransport"
+	ServerFeatureSecondaryRooms        = "secondary-rooms"
+	ServerFeaturePermissionsEvent      = "permissions-event"
+	ServerFeatureServerTime            = "server-time"
+	ServerFeatureFeatureNegotiation    = "feature-negotiation"
+	ServerFeatureDtmf                  = "dtmf"
+	ServerFeatureRecording             = "recording"
+	ServerFeatureRecordingControl      = "recording-control"
+	ServerFeatureTranscription         = "transcription"
+	ServerFeatureTransientNamespaces   = "transient-namespaces"
+	ServerFeatureBatching              = "batching"
 
 	// Features to send to internal clients only.
-	ServerFeatureInternalVirtualSessions = "virtual-sessions"
+	ServerFeatureInternalVirtualSessions     = "virtual-sessions"
+	ServerFeatureInternalVirtualSessionsBulk = "virtual-sessions-bulk"
 
 	// Possible client features from the "hello" request.
-	ClientFeatureInternalInCall = "internal-incall"
-	ClientFeatureStartDialout   = "start-dialout"
+	ClientFeatureInternalInCall    = "internal-incall"
+	ClientFeatureStartDialout      = "start-dialout"
+	ClientFeatureRecording         = "recording"
+	ClientFeatureTranscription     = "transcription"
+	ClientFeatureResync            = "resync"
+	ClientFeatureReceipts          = "receipts"
+	ClientFeatureParticipantsDelta = "participants-delta"
+	ClientFeatureBatching          = "batching"
 )
 
 var (
+	knownClientFeatures = []string{
+		ClientFeatureInternalInCall,
+		ClientFeatureStartDialout,
+		ClientFeatureRecording,
+		ClientFeatureTranscription,
+		ClientFeatureResync,
+		ClientFeatureReceipts,
+		ClientFeatureParticipantsDelta,
+		ClientFeatureBatching,
+	}
+	// Client features that are only supported for internal clients.
+	internalClientFeatures = []string{
+		ClientFeatureInternalInCall,
+		ClientFeatureStartDialout,
+		ClientFeatureRecording,
+		ClientFeatureTranscription,
+	}
+	// Server features that must be available for client features to be honored.
+	clientFeatureRequirements = map[string]string{
+		ClientFeatureStartDialout:      ServerFeatureDialout,
+		ClientFeatureRecording:         ServerFeatureRecording,
+		ClientFeatureTranscription:     ServerFeatureTranscription,
+		ClientFeatureResync:            ServerFeatureResync,
+		ClientFeatureReceipts:          ServerFeatureReceipts,
+		ClientFeatureParticipantsDelta: ServerFeatureParticipantsDelta,
+		ClientFeatureBatching:          ServerFeatureBatching,
+	}
+
 	DefaultFeatures = []string{
 		ServerFeatureAudioVideoPermissions,
 		ServerFeatureTransientData,
 		ServerFeatureInCallAll,
 		ServerFeatureWelcome,
 		ServerFeatureHelloV2,
+		ServerFeatureHelloV2Confirmation,
 		ServerFeatureSwitchTo,
+		ServerFeatureBreakoutRooms,
 		ServerFeatureDialout,
 		ServerFeatureFederation,
 		ServerFeatureRecipientCall,
 		ServerFeatureJoinFeatures,
 		ServerFeatureOfferCodecs,
 		ServerFeatureServerInfo,
+		ServerFeatureCbor,
+		ServerFeatureResync,
+		ServerFeaturePingInterval,
+		ServerFeatureReceipts,
+		ServerFeatureMultipleRecipients,
+		ServerFeatureJoinChunks,
+		ServerFeatureParticipantsDelta,
+		ServerFeatureClientHints,
+		ServerFeatureEcho,
+		ServerFeatureSchema,
+		ServerFeatureSse,
+		ServerFeaturePermissionsEvent,
+		ServerFeatureServerTime,
+		ServerFeatureFeatureNegotiation,
+		ServerFeatureDtmf,
+		ServerFeatureRecording,
+		ServerFeatureTranscription,
+		ServerFeatureTransientNamespaces,
+		ServerFeatureBatching,
 	}
 	DefaultFeaturesInternal = []string{
 		ServerFeatureInternalVirtualSessions,
+		ServerFeatureInternalVirtualSessionsBulk,
 		ServerFeatureTransientData,
 		ServerFeatureInCallAll,
 		ServerFeatureWelcome,
 		ServerFeatureHelloV2,
+		ServerFeatureHelloV2Confirmation,
 		ServerFeatureSwitchTo,
+		ServerFeatureBreakoutRooms,
 		ServerFeatureDialout,
 		ServerFeatureFederation,
 		ServerFeatureRecipientCall,
 		ServerFeatureJoinFeatures,
 		ServerFeatureOfferCodecs,
 		ServerFeatureServerInfo,
+		ServerFeatureCbor,
+		ServerFeatureResync,
+		ServerFeaturePingInterval,
+		ServerFeatureReceipts,
+		ServerFeatureMultipleRecipients,
+		ServerFeatureJoinChunks,
+		ServerFeatureParticipantsDelta,
+		ServerFeatureClientHints,
+		ServerFeatureEcho,
+		ServerFeatureSchema,
+		ServerFeatureSse,
+		ServerFeaturePermissionsEvent,
+		ServerFeatureServerTime,
+		ServerFeatureFeatureNegotiation,
+		ServerFeatureDtmf,
+		ServerFeatureRecording,
+		ServerFeatureTranscription,
+		ServerFeatureTransientNamespaces,
+		ServerFeatureBatching,
 	}
 	DefaultWelcomeFeatures = []string{
 		ServerFeatureAudioVideoPermissions,
 		ServerFeatureInternalVirtualSessions,
+		ServerFeatureInternalVirtualSessionsBulk,
 		ServerFeatureTransientData,
 		ServerFeatureInCallAll,
 		ServerFeatureWelcome,
 		ServerFeatureHelloV2,
+		ServerFeatureHelloV2Confirmation,
 		ServerFeatureSwitchTo,
+		ServerFeatureBreakoutRooms,
 		ServerFeatureDialout,
 		ServerFeatureFederation,
 		ServerFeatureRecipientCall,
 		ServerFeatureJoinFeatures,
 		ServerFeatureOfferCodecs,
 		ServerFeatureServerInfo,
+		ServerFeatureCbor,
+		ServerFeatureResync,
+		ServerFeaturePingInterval,
+		ServerFeatureReceipts,
+		ServerFeatureMultipleRecipients,
+		ServerFeatureJoinChunks,
+		ServerFeatureParticipantsDelta,
+		ServerFeatureClientHints,
+		ServerFeatureEcho,
+		ServerFeatureSchema,
+		ServerFeatureSse,
+		ServerFeaturePermissionsEvent,
+		ServerFeatureServerTime,
+		ServerFeatureFeatureNegotiation,
+		ServerFeatureDtmf,
+		ServerFeatureRecording,
+		ServerFeatureTranscription,
+		ServerFeatureTransientNamespaces,
+		ServerFeatureBatching,
 	}
 )
 
+// NegotiateClientFeatures returns the sorted list of features requested by a
+// client that will be honored by a server supporting the given features.
+func NegotiateClientFeatures(server *WelcomeServerMessage, clientType ClientType, requested []string) []string {
+	var result []string
+	for _, feature := range requested {
+		if !slices.Contains(knownClientFeatures, feature) || slices.Contains(result, feature) {
+			continue
+		} else if clientType != HelloClientTypeInternal && slices.Contains(internalClientFeatures, feature) {
+			continue
+		}
+
+		if serverFeature, found := clientFeatureRequirements[feature]; found && !server.HasFeature(serverFeature) {
+			continue
+		}
+
+		result = append(result, feature)
+	}
+	slices.Sort(result)
+	return result
+}
+
 type HelloServerMessage struct {
 	Version string `json:"version"`
 
@@ -616,6 +904,18 @@ type HelloServerMessage struct {
 	ResumeId  PrivateSessionId `json:"resumeid"`
 	UserId    string           `json:"userid"`
 
+	// Interval in seconds in which pings will be sent if the client requested
+	// a custom interval.
+	PingInterval int `json:"pinginterval,omitempty"`
+
+	// Interval in seconds in which internal clients must send heartbeats.
+	Heartbeat int `json:"heartbeat,omitempty"`
+
+	Time *ServerTimeMessage `json:"time,omitempty"`
+
+	// The optional features requested by the client that are supported.
+	Features []string `json:"features,omitempty"`
+
 	// TODO: Remove once all clients have switched to the "welcome" message.
 	Server *WelcomeServerMessage `json:"server,omitempty"`
 }
@@ -630,8 +930,150 @@ func (m *ByeClientMessage) CheckValid() error {
 	return nil
 }
 
+const (
+	// The client didn't send a "hello" request in time.
+	ByeReasonHelloTimeout = "hello_timeout"
+	// The (anonymous) session didn't join a room in time.
+	ByeReasonRoomJoinTimeout = "room_join_timeout"
+	// The session was resumed from a different connection.
+	ByeReasonSessionResumed = "session_resumed"
+	// A different session connected with the same room session id.
+	ByeReasonRoomSessionReconnected = "room_session_reconnected"
+	// The session was removed from the room.
+	ByeReasonKicked = "kicked"
+	// The session was banned from the room.
+	ByeReasonBanned = "banned"
+	// The backend of the session is no longer configured.
+	ByeReasonBackendGone = "backend_gone"
+	// The server is shutting down.
+	ByeReasonDraining = "draining"
+	// The client didn't respond to pings in time. Only sent as reason when
+	// closing the websocket connection, the session can still be resumed.
+	ByeReasonIdleTimeout = "idle_timeout"
+	// The internal client didn't send heartbeats in time.
+	ByeReasonHeartbeatTimeout = "heartbeat_timeout"
+	// The session was disconnected through the admin API.
+	ByeReasonDisconnected = "disconnected"
+)
+
 type ByeServerMessage struct {
 	Reason string `json:"reason"`
+
+	// The client may connect again (e.g. to a different server).
+	Reconnect bool `json:"reconnect,omitempty"`
+
+	// Number of seconds the client should wait before connecting again.
+	RetryAfter int `json:"retryafter,omitempty"`
+}
+
+// NewByeServerMessage returns the "bye" message for the given reason.
+func NewByeServerMessage(reason string) *ByeServerMessage {
+	if reason == "" {
+		return nil
+	}
+
+	result := &ByeServerMessage{
+		Reason: reason,
+	}
+	switch reason {
+	case ByeReasonHelloTimeout:
+		fallthrough
+	case ByeReasonDraining:
+		fallthrough
+	case ByeReasonIdleTimeout:
+		fallthrough
+	case ByeReasonHeartbeatTimeout:
+		result.Reconnect = true
+	}
+	return result
+}
+
+// DrainingErrorDetails are sent with the "server_draining" error if a client
+// tries to connect to a server that is draining.
+type DrainingErrorDetails struct {
+	// Number of seconds the client should wait before connecting again.
+	RetryAfter int `json:"retryafter"`
+}
+
+// Type "echo"
+
+type EchoClientMessage struct {
+	// Timestamp of the client in milliseconds, will be returned unmodified.
+	Timestamp int64 `json:"timestamp,omitempty"`
+
+	// Optional round trip time in milliseconds the client measured with a
+	// previous "echo" request.
+	Rtt int64 `json:"rtt,omitempty"`
+}
+
+func (m *EchoClientMessage) CheckValid() error {
+	if m.Rtt < 0 {
+		return fmt.Errorf("invalid rtt")
+	}
+	return nil
+}
+
+// Type "dtmf"
+
+const (
+	// Maximum number of DTMF digits in a single message.
+	maxDtmfDigits = 32
+)
+
+func checkDtmfDigits(digits string) error {
+	if digits == "" {
+		return errors.New("digits missing")
+	} else if len(digits) > maxDtmfDigits {
+		return fmt.Errorf("too many digits, at most %d are allowed", maxDtmfDigits)
+	}
+
+	for _, c := range digits {
+		if (c < '0' || c > '9') && (c < 'A' || c > 'D') && c != '*' && c != '#' {
+			return fmt.Errorf("invalid digit %q", c)
+		}
+	}
+	return nil
+}
+
+// DtmfClientMessage is sent by moderators to send DTMF digits to the SIP bridge
+// of a dial-in participant.
+type DtmfClientMessage struct {
+	// Public id of the virtual session of the dial-in participant.
+	SessionId PublicSessionId `json:"sessionid"`
+	Digits    string          `json:"digits"`
+}
+
+func (m *DtmfClientMessage) CheckValid() error {
+	if m.SessionId == "" {
+		return errors.New("sessionid missing")
+	}
+	return checkDtmfDigits(m.Digits)
+}
+
+type EchoServerMessage struct {
+	// Timestamp from the request of the client.
+	Timestamp int64 `json:"timestamp,omitempty"`
+
+	// Time (in milliseconds since the epoch) when the server received the
+	// request and sent the response.
+	Received int64 `json:"received"`
+	Sent     int64 `json:"sent"`
+}
+
+// Type "recording"
+
+// RecordingClientMessage is sent by moderators to control the recording of the
+// room they joined.
+type RecordingClientMessage struct {
+	// One of "start", "stop", "pause" or "resume".
+	Action string `json:"action"`
+
+	// Options are passed to the recording client when starting.
+	Options json.RawMessage `json:"options,omitempty"`
+}
+
+func (m *RecordingClientMessage) CheckValid() error {
+	return checkRecordingAction(m.Action)
 }
 
 // Type "room"
@@ -641,10 +1083,24 @@ type RoomClientMessage struct {
 	SessionId RoomSessionId `json:"sessionid,omitempty"`
 
 	Federation *RoomFederationMessage `json:"federation,omitempty"`
+
+	// Join (or leave) the room in addition to the primary room of the session.
+	// Secondary rooms only receive events, media is only possible in the
+	// primary room.
+	Secondary bool `json:"secondary,omitempty"`
+	Leave     bool `json:"leave,omitempty"`
 }
 
 func (m *RoomClientMessage) CheckValid() error {
-	// No additional validation required.
+	if m.Secondary {
+		if m.RoomId == "" {
+			return errors.New("roomid missing")
+		} else if m.Federation != nil {
+			return errors.New("secondary rooms can't be federated")
+		}
+	} else if m.Leave {
+		return errors.New("leave is only supported for secondary rooms")
+	}
 	if m.Federation != nil {
 		if err := m.Federation.CheckValid(); err != nil {
 			return err
@@ -695,6 +1151,7 @@ func (m *RoomFederationMessage) CheckValid() error {
 type RoomServerMessage struct {
 	RoomId     string          `json:"roomid"`
 	Properties json.RawMessage `json:"properties,omitempty"`
+	Secondary  bool            `json:"secondary,omitempty"`
 }
 
 type RoomErrorDetails struct {
@@ -704,17 +1161,23 @@ type RoomErrorDetails struct {
 // Type "message"
 
 const (
-	RecipientTypeSession = "session"
-	RecipientTypeUser    = "user"
-	RecipientTypeRoom    = "room"
-	RecipientTypeCall    = "call"
+	RecipientTypeSession  = "session"
+	RecipientTypeSessions = "sessions"
+	RecipientTypeUser     = "user"
+	RecipientTypeRoom     = "room"
+	RecipientTypeCall     = "call"
+	RecipientTypeBreakout = "breakout"
+
+	// Maximum number of sessions a single message can be sent to.
+	maxMessageRecipientSessions = 100
 )
 
 type MessageClientMessageRecipient struct {
 	Type string `json:"type"`
 
-	SessionId PublicSessionId `json:"sessionid,omitempty"`
-	UserId    string          `json:"userid,omitempty"`
+	SessionId  PublicSessionId   `json:"sessionid,omitempty"`
+	SessionIds []PublicSessionId `json:"sessionids,omitempty"`
+	UserId     string            `json:"userid,omitempty"`
 }
 
 type MessageClientMessage struct {
@@ -890,11 +1353,21 @@ func (m *MessageClientMessage) CheckValid() error {
 	case RecipientTypeRoom:
 		fallthrough
 	case RecipientTypeCall:
+		fallthrough
+	case RecipientTypeBreakout:
 		// No additional checks required.
 	case RecipientTypeSession:
 		if m.Recipient.SessionId == "" {
 			return fmt.Errorf("session id missing")
 		}
+	case RecipientTypeSessions:
+		if len(m.Recipient.SessionIds) == 0 {
+			return fmt.Errorf("session ids missing")
+		} else if len(m.Recipient.SessionIds) > maxMessageRecipientSessions {
+			return fmt.Errorf("too many session ids, at most %d are allowed", maxMessageRecipientSessions)
+		} else if slices.Contains(m.Recipient.SessionIds, "") {
+			return fmt.Errorf("empty session id")
+		}
 	case RecipientTypeUser:
 		if m.Recipient.UserId == "" {
 			return fmt.Errorf("user id missing")
@@ -977,6 +1450,10 @@ type AddSessionInternalClientMessage struct {
 	Flags  uint32          `json:"flags,omitempty"`
 	InCall *int            `json:"incall,omitempty"`
 
+	// Permissions of the virtual session, all permissions are granted if
+	// this is omitted.
+	Permissions *[]Permission `json:"permissions,omitempty"`
+
 	Options *AddSessionOptions `json:"options,omitempty"`
 }
 
@@ -987,8 +1464,9 @@ func (m *AddSessionInternalClientMessage) CheckValid() error {
 type UpdateSessionInternalClientMessage struct {
 	CommonSessionInternalClientMessage
 
-	Flags  *uint32 `json:"flags,omitempty"`
-	InCall *int    `json:"incall,omitempty"`
+	Flags       *uint32       `json:"flags,omitempty"`
+	InCall      *int          `json:"incall,omitempty"`
+	Permissions *[]Permission `json:"permissions,omitempty"`
 }
 
 func (m *UpdateSessionInternalClientMessage) CheckValid() error {
@@ -1005,6 +1483,87 @@ func (m *RemoveSessionInternalClientMessage) CheckValid() error {
 	return m.CommonSessionInternalClientMessage.CheckValid()
 }
 
+const (
+	// Maximum number of virtual sessions that can be added or removed with a
+	// single message.
+	maxVirtualSessionsPerMessage = 1000
+)
+
+// AddSessionsInternalClientMessage adds multiple virtual sessions to a room.
+// The room id can be omitted for the individual sessions.
+type AddSessionsInternalClientMessage struct {
+	RoomId string `json:"roomid"`
+
+	Sessions []*AddSessionInternalClientMessage `json:"sessions"`
+}
+
+func (m *AddSessionsInternalClientMessage) CheckValid() error {
+	if m.RoomId == "" {
+		return errors.New("roomid missing")
+	} else if len(m.Sessions) == 0 {
+		return errors.New("sessions missing")
+	} else if len(m.Sessions) > maxVirtualSessionsPerMessage {
+		return fmt.Errorf("too many sessions, at most %d are allowed", maxVirtualSessionsPerMessage)
+	}
+
+	seen := make(map[PublicSessionId]bool, len(m.Sessions))
+	for _, session := range m.Sessions {
+		if session == nil {
+			return errors.New("session missing")
+		}
+
+		if session.RoomId == "" {
+			session.RoomId = m.RoomId
+		} else if session.RoomId != m.RoomId {
+			return fmt.Errorf("session %s is for a different room", session.SessionId)
+		}
+
+		if err := session.CheckValid(); err != nil {
+			return err
+		} else if seen[session.SessionId] {
+			return fmt.Errorf("duplicate session %s", session.SessionId)
+		}
+		seen[session.SessionId] = true
+	}
+	return nil
+}
+
+// RemoveSessionsInternalClientMessage removes multiple virtual sessions from a
+// room.
+type RemoveSessionsInternalClientMessage struct {
+	RoomId string `json:"roomid"`
+
+	SessionIds []PublicSessionId `json:"sessionids"`
+}
+
+func (m *RemoveSessionsInternalClientMessage) CheckValid() error {
+	if m.RoomId == "" {
+		return errors.New("roomid missing")
+	} else if len(m.SessionIds) == 0 {
+		return errors.New("sessionids missing")
+	} else if len(m.SessionIds) > maxVirtualSessionsPerMessage {
+		return fmt.Errorf("too many sessions, at most %d are allowed", maxVirtualSessionsPerMessage)
+	} else if slices.Contains(m.SessionIds, "") {
+		return errors.New("sessionid missing")
+	}
+	return nil
+}
+
+// DtmfInternalClientMessage is sent by the SIP bridge with DTMF digits that
+// were received from a dial-in participant.
+type DtmfInternalClientMessage struct {
+	CommonSessionInternalClientMessage
+
+	Digits string `json:"digits"`
+}
+
+func (m *DtmfInternalClientMessage) CheckValid() error {
+	if err := m.CommonSessionInternalClientMessage.CheckValid(); err != nil {
+		return err
+	}
+	return checkDtmfDigits(m.Digits)
+}
+
 type InCallInternalClientMessage struct {
 	InCall int `json:"incall"`
 }
@@ -1016,21 +1575,61 @@ func (m *InCallInternalClientMessage) CheckValid() error {
 type DialoutStatus string
 
 var (
-	DialoutStatusAccepted  DialoutStatus = "accepted"
-	DialoutStatusRinging   DialoutStatus = "ringing"
+	DialoutStatusAccepted   DialoutStatus = "accepted"
+	DialoutStatusRinging    DialoutStatus = "ringing"
+	DialoutStatusEarlyMedia DialoutStatus = "earlymedia"
+	// The call was answered by the remote party.
 	DialoutStatusConnected DialoutStatus = "connected"
 	DialoutStatusRejected  DialoutStatus = "rejected"
+	DialoutStatusBusy      DialoutStatus = "busy"
+	DialoutStatusFailed    DialoutStatus = "failed"
 	DialoutStatusCleared   DialoutStatus = "cleared"
+
+	knownDialoutStatus = []DialoutStatus{
+		DialoutStatusAccepted,
+		DialoutStatusRinging,
+		DialoutStatusEarlyMedia,
+		DialoutStatusConnected,
+		DialoutStatusRejected,
+		DialoutStatusBusy,
+		DialoutStatusFailed,
+		DialoutStatusCleared,
+	}
 )
 
+// IsFinal returns true if the call has ended with the status.
+func (s DialoutStatus) IsFinal() bool {
+	switch s {
+	case DialoutStatusRejected, DialoutStatusBusy, DialoutStatusFailed, DialoutStatusCleared:
+		return true
+	default:
+		return false
+	}
+}
+
 type DialoutStatusInternalClientMessage struct {
 	CallId string        `json:"callid"`
 	Status DialoutStatus `json:"status"`
 
-	// Cause is set if Status is "cleared" or "rejected".
-	Cause   string `json:"cause,omitempty"`
+	// Cause is set if Status is "cleared", "rejected", "busy" or "failed".
+	Cause string `json:"cause,omitempty"`
+	// SIP status code and reason phrase of the final response.
 	Code    int    `json:"code,omitempty"`
 	Message string `json:"message,omitempty"`
+	// Reason is set if Status is "cleared" and contains the party that hung up
+	// the call (e.g. "local" or "remote").
+	Reason string `json:"reason,omitempty"`
+}
+
+func (m *DialoutStatusInternalClientMessage) CheckValid() error {
+	if m.CallId == "" {
+		return errors.New("callid missing")
+	} else if !slices.Contains(knownDialoutStatus, m.Status) {
+		return fmt.Errorf("unsupported status %s", m.Status)
+	} else if m.Code != 0 && (m.Code < 100 || m.Code > 699) {
+		return fmt.Errorf("invalid code %d", m.Code)
+	}
+	return nil
 }
 
 type DialoutInternalClientMessage struct {
@@ -1054,7 +1653,139 @@ func (m *DialoutInternalClientMessage) CheckValid() error {
 	case "status":
 		if m.Status == nil {
 			return errors.New("status missing")
+		} else if err := m.Status.CheckValid(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type RecordingStatus string
+
+const (
+	RecordingStatusStarted RecordingStatus = "started"
+	RecordingStatusPaused  RecordingStatus = "paused"
+	RecordingStatusStopped RecordingStatus = "stopped"
+	RecordingStatusFailed  RecordingStatus = "failed"
+)
+
+const (
+	RecordingActionStart  = "start"
+	RecordingActionStop   = "stop"
+	RecordingActionPause  = "pause"
+	RecordingActionResume = "resume"
+)
+
+func checkRecordingAction(action string) error {
+	switch action {
+	case "":
+		return errors.New("action missing")
+	case RecordingActionStart, RecordingActionStop, RecordingActionPause, RecordingActionResume:
+		return nil
+	default:
+		return fmt.Errorf("unsupported action %s", action)
+	}
+}
+
+// RecordingInternalClientMessage is sent by recording clients as response to
+// a recording request, to report the status of a recording or as heartbeat
+// while a room is being recorded.
+type RecordingInternalClientMessage struct {
+	Type string `json:"type"`
+
+	RoomId string `json:"roomid,omitempty"`
+
+	Error *Error `json:"error,omitempty"`
+
+	Status RecordingStatus `json:"status,omitempty"`
+}
+
+func (m *RecordingInternalClientMessage) CheckValid() error {
+	switch m.Type {
+	case "":
+		return errors.New("type missing")
+	case "error":
+		if m.Error == nil {
+			return errors.New("error missing")
+		}
+	case "status":
+		switch m.Status {
+		case "":
+			return errors.New("status missing")
+		case RecordingStatusStarted, RecordingStatusPaused, RecordingStatusStopped, RecordingStatusFailed:
+		default:
+			return fmt.Errorf("unsupported status %s", m.Status)
+		}
+		fallthrough
+	case "heartbeat":
+		if m.RoomId == "" {
+			return errors.New("roomid missing")
+		}
+	}
+	return nil
+}
+
+const (
+	// Maximum length of a caption text.
+	maxCaptionLength = 1024
+)
+
+type TranscriptionCaption struct {
+	Text     string `json:"text"`
+	Language string `json:"language,omitempty"`
+	// Interim captions may be replaced by following captions of the same
+	// speaker until a final caption is sent.
+	Final bool `json:"final,omitempty"`
+}
+
+// TranscriptionInternalClientMessage is sent by transcription clients to
+// receive the audio of a publisher (types "forward" and "stopforward") or to
+// send captions of a speaker to the room (type "caption").
+type TranscriptionInternalClientMessage struct {
+	Type string `json:"type"`
+
+	RoomId    string          `json:"roomid"`
+	SessionId PublicSessionId `json:"sessionid"`
+
+	// Used for types "forward" and "stopforward".
+	Hostname string `json:"hostname,omitempty"`
+	Port     int    `json:"port,omitempty"`
+	RtcpPort int    `json:"rtcpport,omitempty"`
+
+	// Used for type "caption".
+	Caption *TranscriptionCaption `json:"caption,omitempty"`
+}
+
+func (m *TranscriptionInternalClientMessage) CheckValid() error {
+	if m.RoomId == "" {
+		return errors.New("roomid missing")
+	} else if m.SessionId == "" {
+		return errors.New("sessionid missing")
+	}
+
+	switch m.Type {
+	case "":
+		return errors.New("type missing")
+	case "forward":
+		fallthrough
+	case "stopforward":
+		if m.Hostname == "" {
+			return errors.New("hostname missing")
+		} else if m.Port <= 0 || m.Port > 65535 {
+			return fmt.Errorf("invalid port %d", m.Port)
+		} else if m.RtcpPort < 0 || m.RtcpPort > 65535 {
+			return fmt.Errorf("invalid rtcp port %d", m.RtcpPort)
 		}
+	case "caption":
+		if m.Caption == nil {
+			return errors.New("caption missing")
+		} else if m.Caption.Text == "" {
+			return errors.New("text missing")
+		} else if len(m.Caption.Text) > maxCaptionLength {
+			return fmt.Errorf("text too long, at most %d bytes are allowed", maxCaptionLength)
+		}
+	default:
+		return fmt.Errorf("unsupported type %s", m.Type)
 	}
 	return nil
 }
@@ -1068,9 +1799,19 @@ type InternalClientMessage struct {
 
 	RemoveSession *RemoveSessionInternalClientMessage `json:"removesession,omitempty"`
 
+	AddSessions *AddSessionsInternalClientMessage `json:"addsessions,omitempty"`
+
+	RemoveSessions *RemoveSessionsInternalClientMessage `json:"removesessions,omitempty"`
+
 	InCall *InCallInternalClientMessage `json:"incall,omitempty"`
 
 	Dialout *DialoutInternalClientMessage `json:"dialout,omitempty"`
+
+	Dtmf *DtmfInternalClientMessage `json:"dtmf,omitempty"`
+
+	Recording *RecordingInternalClientMessage `json:"recording,omitempty"`
+
+	Transcription *TranscriptionInternalClientMessage `json:"transcription,omitempty"`
 }
 
 func (m *InternalClientMessage) CheckValid() error {
@@ -1095,6 +1836,18 @@ func (m *InternalClientMessage) CheckValid() error {
 		} else if err := m.RemoveSession.CheckValid(); err != nil {
 			return err
 		}
+	case "addsessions":
+		if m.AddSessions == nil {
+			return fmt.Errorf("addsessions missing")
+		} else if err := m.AddSessions.CheckValid(); err != nil {
+			return err
+		}
+	case "removesessions":
+		if m.RemoveSessions == nil {
+			return fmt.Errorf("removesessions missing")
+		} else if err := m.RemoveSessions.CheckValid(); err != nil {
+			return err
+		}
 	case "incall":
 		if m.InCall == nil {
 			return fmt.Errorf("incall missing")
@@ -1107,6 +1860,24 @@ func (m *InternalClientMessage) CheckValid() error {
 		} else if err := m.Dialout.CheckValid(); err != nil {
 			return err
 		}
+	case "dtmf":
+		if m.Dtmf == nil {
+			return fmt.Errorf("dtmf missing")
+		} else if err := m.Dtmf.CheckValid(); err != nil {
+			return err
+		}
+	case "recording":
+		if m.Recording == nil {
+			return fmt.Errorf("recording missing")
+		} else if err := m.Recording.CheckValid(); err != nil {
+			return err
+		}
+	case "transcription":
+		if m.Transcription == nil {
+			return fmt.Errorf("transcription missing")
+		} else if err := m.Transcription.CheckValid(); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -1118,10 +1889,28 @@ type InternalServerDialoutRequest struct {
 	Request *BackendRoomDialoutRequest `json:"request"`
 }
 
+type InternalServerRecordingRequest struct {
+	// One of "start", "stop", "pause" or "resume".
+	Action  string `json:"action"`
+	RoomId  string `json:"roomid"`
+	Backend string `json:"backend"`
+
+	// Interval in seconds in which heartbeats must be sent while recording.
+	Heartbeat int `json:"heartbeat,omitempty"`
+
+	// The recording must be started paused (e.g. when a paused recording is
+	// moved to a different client).
+	Paused bool `json:"paused,omitempty"`
+
+	Options json.RawMessage `json:"options,omitempty"`
+}
+
 type InternalServerMessage struct {
 	Type string `json:"type"`
 
 	Dialout *InternalServerDialoutRequest `json:"dialout,omitempty"`
+
+	Recording *InternalServerRecordingRequest `json:"recording,omitempty"`
 }
 
 // Type "event"
@@ -1135,6 +1924,11 @@ type RoomEventServerMessage struct {
 	Users   []StringMap     `json:"users,omitempty"`
 
 	All bool `json:"all,omitempty"`
+
+	// Only set for clients that support the "participants-delta" feature.
+	Version uint64            `json:"version,omitempty"`
+	Delta   bool              `json:"delta,omitempty"`
+	Removed []PublicSessionId `json:"removed,omitempty"`
 }
 
 func (m *RoomEventServerMessage) String() string {
@@ -1183,12 +1977,20 @@ type EventServerMessage struct {
 	Target string `json:"target"`
 	Type   string `json:"type"`
 
+	// Set for events of secondary rooms.
+	RoomId string `json:"roomid,omitempty"`
+
 	// Used for target "room"
-	Join     []*EventServerMessageSessionEntry `json:"join,omitempty"`
-	Leave    []PublicSessionId                 `json:"leave,omitempty"`
-	Change   []*EventServerMessageSessionEntry `json:"change,omitempty"`
-	SwitchTo *EventServerMessageSwitchTo       `json:"switchto,omitempty"`
-	Resumed  *bool                             `json:"resumed,omitempty"`
+	Join      []*EventServerMessageSessionEntry `json:"join,omitempty"`
+	Leave     []PublicSessionId                 `json:"leave,omitempty"`
+	Change    []*EventServerMessageSessionEntry `json:"change,omitempty"`
+	SwitchTo  *EventServerMessageSwitchTo       `json:"switchto,omitempty"`
+	Dtmf      *EventServerMessageDtmf           `json:"dtmf,omitempty"`
+	Recording *EventServerMessageRecording      `json:"recording,omitempty"`
+	Caption   *EventServerMessageCaption        `json:"caption,omitempty"`
+	Degraded  *EventServerMessageDegraded       `json:"degraded,omitempty"`
+	Resumed   *bool                             `json:"resumed,omitempty"`
+	Chunk     *EventServerMessageChunk          `json:"chunk,omitempty"`
 
 	// Used for target "roomlist" / "participants"
 	Invite    *RoomEventServerMessage          `json:"invite,omitempty"`
@@ -1198,6 +2000,12 @@ type EventServerMessage struct {
 
 	// Used for target "message"
 	Message *RoomEventMessage `json:"message,omitempty"`
+
+	// Used for target "session"
+	Lagging     *EventServerMessageLagging     `json:"lagging,omitempty"`
+	Permissions *EventServerMessagePermissions `json:"permissions,omitempty"`
+	Resync      *EventServerMessageResync      `json:"resync,omitempty"`
+	Receipt     *EventServerMessageReceipt     `json:"receipt,omitempty"`
 }
 
 func (m *EventServerMessage) String() string {
@@ -1228,11 +2036,106 @@ func (e *EventServerMessageSessionEntry) Clone() *EventServerMessageSessionEntry
 	}
 }
 
+// EventServerMessageDtmf contains DTMF digits of a dial-in participant (target
+// "room") or digits to send to a dial-in participant (target "session").
+type EventServerMessageDtmf struct {
+	RoomId    string          `json:"roomid"`
+	SessionId PublicSessionId `json:"sessionid"`
+	Digits    string          `json:"digits"`
+	// Session that sent the digits to a dial-in participant.
+	Sender PublicSessionId `json:"sender,omitempty"`
+}
+
+// EventServerMessageRecording notifies the participants of a room about
+// changes to the recording status.
+type EventServerMessageRecording struct {
+	RoomId string          `json:"roomid"`
+	Status RecordingStatus `json:"status"`
+}
+
+// EventServerMessageDegraded notifies the participants of a room that virtual
+// sessions are degraded because their internal client stopped sending
+// heartbeats, or that they recovered.
+type EventServerMessageDegraded struct {
+	RoomId     string            `json:"roomid"`
+	SessionIds []PublicSessionId `json:"sessionids"`
+	Degraded   bool              `json:"degraded"`
+}
+
+// EventServerMessageCaption contains a caption of a speaker in the room that
+// was generated by a transcription client.
+type EventServerMessageCaption struct {
+	RoomId    string          `json:"roomid"`
+	SessionId PublicSessionId `json:"sessionid"`
+	UserId    string          `json:"userid,omitempty"`
+
+	TranscriptionCaption
+}
+
 type EventServerMessageSwitchTo struct {
 	RoomId  string          `json:"roomid"`
 	Details json.RawMessage `json:"details,omitempty"`
 }
 
+// EventServerMessageChunk is included in events that were split into multiple
+// messages.
+type EventServerMessageChunk struct {
+	// Sequence number of the chunk, starting at 1.
+	Sequence int `json:"sequence"`
+	// More chunks will follow.
+	More bool `json:"more"`
+	// Total number of entries in all chunks.
+	Total int `json:"total"`
+}
+
+type EventServerMessageLagging struct {
+	Pending int       `json:"pending"`
+	Since   time.Time `json:"since"`
+}
+
+// EventServerMessagePermissions is sent to a session if its permissions changed.
+type EventServerMessagePermissions struct {
+	// The current permissions of the session.
+	Permissions []Permission `json:"permissions"`
+
+	Added   []Permission `json:"added,omitempty"`
+	Removed []Permission `json:"removed,omitempty"`
+
+	// Streams that were stopped because the session is no longer allowed to
+	// publish them and must be renegotiated.
+	Renegotiate []StreamType `json:"renegotiate,omitempty"`
+}
+
+const (
+	// Reasons why state must be resynchronized.
+	ResyncReasonMcuReconnected    = "mcu_reconnected"
+	ResyncReasonEventsReconnected = "events_reconnected"
+
+	// State that might be stale and should be refreshed by the client.
+	ResyncStateParticipants = "participants"
+	ResyncStateInCall       = "incall"
+	ResyncStatePublishers   = "publishers"
+)
+
+type EventServerMessageResync struct {
+	Reason string   `json:"reason"`
+	Stale  []string `json:"stale"`
+}
+
+const (
+	// The message was sent to the connection of the recipient.
+	ReceiptStatusDelivered = "delivered"
+	// The recipient is currently not connected, the message will be sent once
+	// the session is resumed.
+	ReceiptStatusQueued = "queued"
+)
+
+type EventServerMessageReceipt struct {
+	Type      string                         `json:"type"`
+	Recipient *MessageClientMessageRecipient `json:"recipient"`
+	Status    string                         `json:"status"`
+}
+
 // MCU-related types
 
 type AnswerOfferMessage struct {
@@ -1269,6 +2172,18 @@ func (m *TransientDataClientMessage) CheckValid() error {
 	return nil
 }
 
+// Type "participants"
+
+type ParticipantsClientMessage struct {
+	// Version of the participants list the client has, changes since this
+	// version will be returned. The full list is returned if omitted.
+	Version uint64 `json:"version,omitempty"`
+}
+
+func (m *ParticipantsClientMessage) CheckValid() error {
+	return nil
+}
+
 type TransientDataServerMessage struct {
 	Type string `json:"type"`
 
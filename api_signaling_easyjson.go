@@ -65,6 +65,16 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling(in *jlexe
 			}
 		case "country":
 			out.Country = string(in.String())
+		case "time":
+			if in.IsNull() {
+				in.Skip()
+				out.Time = nil
+			} else {
+				if out.Time == nil {
+					out.Time = new(ServerTimeMessage)
+				}
+				(*out.Time).UnmarshalEasyJSON(in)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -103,6 +113,11 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling(out *jwri
 		out.RawString(prefix)
 		out.String(string(in.Country))
 	}
+	if in.Time != nil {
+		const prefix string = ",\"time\":"
+		out.RawString(prefix)
+		(*in.Time).MarshalEasyJSON(out)
+	}
 	out.RawByte('}')
 }
 
@@ -168,6 +183,37 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling1(in *jlex
 				}
 				*out.InCall = int(in.Int())
 			}
+		case "permissions":
+			if in.IsNull() {
+				in.Skip()
+				out.Permissions = nil
+			} else {
+				if out.Permissions == nil {
+					out.Permissions = new([]Permission)
+				}
+				if in.IsNull() {
+					in.Skip()
+					*out.Permissions = nil
+				} else {
+					in.Delim('[')
+					if *out.Permissions == nil {
+						if !in.IsDelim(']') {
+							*out.Permissions = make([]Permission, 0, 4)
+						} else {
+							*out.Permissions = []Permission{}
+						}
+					} else {
+						*out.Permissions = (*out.Permissions)[:0]
+					}
+					for !in.IsDelim(']') {
+						var v4 Permission
+						v4 = Permission(in.String())
+						*out.Permissions = append(*out.Permissions, v4)
+						in.WantComma()
+					}
+					in.Delim(']')
+				}
+			}
 		case "sessionid":
 			out.SessionId = PublicSessionId(in.String())
 		case "roomid":
@@ -202,6 +248,27 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling1(out *jwr
 		}
 		out.Int(int(*in.InCall))
 	}
+	if in.Permissions != nil {
+		const prefix string = ",\"permissions\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if *in.Permissions == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range *in.Permissions {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v6))
+			}
+			out.RawByte(']')
+		}
+	}
 	{
 		const prefix string = ",\"sessionid\":"
 		if first {
@@ -295,15 +362,15 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling2(in *jlex
 				for !in.IsDelim('}') {
 					key := string(in.String())
 					in.WantColon()
-					var v4 interface{}
-					if m, ok := v4.(easyjson.Unmarshaler); ok {
+					var v7 interface{}
+					if m, ok := v7.(easyjson.Unmarshaler); ok {
 						m.UnmarshalEasyJSON(in)
-					} else if m, ok := v4.(json.Unmarshaler); ok {
+					} else if m, ok := v7.(json.Unmarshaler); ok {
 						_ = m.UnmarshalJSON(in.Raw())
 					} else {
-						v4 = in.Interface()
+						v7 = in.Interface()
 					}
-					(out.Data)[key] = v4
+					(out.Data)[key] = v7
 					in.WantComma()
 				}
 				in.Delim('}')
@@ -359,21 +426,21 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling2(out *jwr
 		out.RawString(prefix)
 		{
 			out.RawByte('{')
-			v5First := true
-			for v5Name, v5Value := range in.Data {
-				if v5First {
-					v5First = false
+			v8First := true
+			for v8Name, v8Value := range in.Data {
+				if v8First {
+					v8First = false
 				} else {
 					out.RawByte(',')
 				}
-				out.String(string(v5Name))
+				out.String(string(v8Name))
 				out.RawByte(':')
-				if m, ok := v5Value.(easyjson.Marshaler); ok {
+				if m, ok := v8Value.(easyjson.Marshaler); ok {
 					m.MarshalEasyJSON(out)
-				} else if m, ok := v5Value.(json.Marshaler); ok {
+				} else if m, ok := v8Value.(json.Marshaler); ok {
 					out.Raw(m.MarshalJSON())
 				} else {
-					out.Raw(json.Marshal(v5Value))
+					out.Raw(json.Marshal(v8Value))
 				}
 			}
 			out.RawByte('}')
@@ -494,7 +561,276 @@ func (v *TransientDataClientMessage) UnmarshalJSON(data []byte) error {
 func (v *TransientDataClientMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling3(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling4(in *jlexer.Lexer, out *ServerMessage) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling4(in *jlexer.Lexer, out *TranscriptionInternalClientMessage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "type":
+			out.Type = string(in.String())
+		case "roomid":
+			out.RoomId = string(in.String())
+		case "sessionid":
+			out.SessionId = PublicSessionId(in.String())
+		case "hostname":
+			out.Hostname = string(in.String())
+		case "port":
+			out.Port = int(in.Int())
+		case "rtcpport":
+			out.RtcpPort = int(in.Int())
+		case "caption":
+			if in.IsNull() {
+				in.Skip()
+				out.Caption = nil
+			} else {
+				if out.Caption == nil {
+					out.Caption = new(TranscriptionCaption)
+				}
+				(*out.Caption).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling4(out *jwriter.Writer, in TranscriptionInternalClientMessage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"roomid\":"
+		out.RawString(prefix)
+		out.String(string(in.RoomId))
+	}
+	{
+		const prefix string = ",\"sessionid\":"
+		out.RawString(prefix)
+		out.String(string(in.SessionId))
+	}
+	if in.Hostname != "" {
+		const prefix string = ",\"hostname\":"
+		out.RawString(prefix)
+		out.String(string(in.Hostname))
+	}
+	if in.Port != 0 {
+		const prefix string = ",\"port\":"
+		out.RawString(prefix)
+		out.Int(int(in.Port))
+	}
+	if in.RtcpPort != 0 {
+		const prefix string = ",\"rtcpport\":"
+		out.RawString(prefix)
+		out.Int(int(in.RtcpPort))
+	}
+	if in.Caption != nil {
+		const prefix string = ",\"caption\":"
+		out.RawString(prefix)
+		(*in.Caption).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TranscriptionInternalClientMessage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TranscriptionInternalClientMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TranscriptionInternalClientMessage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TranscriptionInternalClientMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling4(l, v)
+}
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling5(in *jlexer.Lexer, out *TranscriptionCaption) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "text":
+			out.Text = string(in.String())
+		case "language":
+			out.Language = string(in.String())
+		case "final":
+			out.Final = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling5(out *jwriter.Writer, in TranscriptionCaption) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"text\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Text))
+	}
+	if in.Language != "" {
+		const prefix string = ",\"language\":"
+		out.RawString(prefix)
+		out.String(string(in.Language))
+	}
+	if in.Final {
+		const prefix string = ",\"final\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Final))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TranscriptionCaption) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TranscriptionCaption) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TranscriptionCaption) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TranscriptionCaption) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling5(l, v)
+}
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling6(in *jlexer.Lexer, out *ServerTimeMessage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "now":
+			out.Now = int64(in.Int64())
+		case "monotonic":
+			out.Monotonic = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling6(out *jwriter.Writer, in ServerTimeMessage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"now\":"
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.Now))
+	}
+	{
+		const prefix string = ",\"monotonic\":"
+		out.RawString(prefix)
+		out.Int64(int64(in.Monotonic))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ServerTimeMessage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling6(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ServerTimeMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling6(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ServerTimeMessage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling6(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ServerTimeMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling6(l, v)
+}
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling7(in *jlexer.Lexer, out *ServerMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -627,6 +963,16 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling4(in *jlex
 				}
 				(*out.Dialout).UnmarshalEasyJSON(in)
 			}
+		case "echo":
+			if in.IsNull() {
+				in.Skip()
+				out.Echo = nil
+			} else {
+				if out.Echo == nil {
+					out.Echo = new(EchoServerMessage)
+				}
+				(*out.Echo).UnmarshalEasyJSON(in)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -637,7 +983,7 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling4(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling4(out *jwriter.Writer, in ServerMessage) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling7(out *jwriter.Writer, in ServerMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -712,33 +1058,38 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling4(out *jwr
 		out.RawString(prefix)
 		(*in.Dialout).MarshalEasyJSON(out)
 	}
+	if in.Echo != nil {
+		const prefix string = ",\"echo\":"
+		out.RawString(prefix)
+		(*in.Echo).MarshalEasyJSON(out)
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v ServerMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling4(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling7(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ServerMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling4(w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling7(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ServerMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling4(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling7(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ServerMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling4(l, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling7(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling5(in *jlexer.Lexer, out *RoomServerMessage) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling8(in *jlexer.Lexer, out *RoomServerMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -763,6 +1114,8 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling5(in *jlex
 			if data := in.Raw(); in.Ok() {
 				in.AddError((out.Properties).UnmarshalJSON(data))
 			}
+		case "secondary":
+			out.Secondary = bool(in.Bool())
 		default:
 			in.SkipRecursive()
 		}
@@ -773,7 +1126,7 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling5(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling5(out *jwriter.Writer, in RoomServerMessage) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling8(out *jwriter.Writer, in RoomServerMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -787,33 +1140,38 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling5(out *jwr
 		out.RawString(prefix)
 		out.Raw((in.Properties).MarshalJSON())
 	}
+	if in.Secondary {
+		const prefix string = ",\"secondary\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Secondary))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v RoomServerMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling5(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling8(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v RoomServerMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling5(w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling8(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *RoomServerMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling5(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling8(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *RoomServerMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling5(l, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling8(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling6(in *jlexer.Lexer, out *RoomFlagsServerMessage) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling9(in *jlexer.Lexer, out *RoomFlagsServerMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -848,7 +1206,7 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling6(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling6(out *jwriter.Writer, in RoomFlagsServerMessage) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling9(out *jwriter.Writer, in RoomFlagsServerMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -873,27 +1231,27 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling6(out *jwr
 // MarshalJSON supports json.Marshaler interface
 func (v RoomFlagsServerMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling6(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling9(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v RoomFlagsServerMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling6(w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling9(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *RoomFlagsServerMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling6(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling9(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *RoomFlagsServerMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling6(l, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling9(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling7(in *jlexer.Lexer, out *RoomFederationMessage) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling10(in *jlexer.Lexer, out *RoomFederationMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -930,7 +1288,7 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling7(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling7(out *jwriter.Writer, in RoomFederationMessage) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling10(out *jwriter.Writer, in RoomFederationMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -960,27 +1318,27 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling7(out *jwr
 // MarshalJSON supports json.Marshaler interface
 func (v RoomFederationMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling7(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling10(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v RoomFederationMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling7(w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling10(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *RoomFederationMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling7(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling10(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *RoomFederationMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling7(l, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling10(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling8(in *jlexer.Lexer, out *RoomEventServerMessage) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling11(in *jlexer.Lexer, out *RoomEventServerMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1025,33 +1383,33 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling8(in *jlex
 					out.Changed = (out.Changed)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v6 StringMap
+					var v9 StringMap
 					if in.IsNull() {
 						in.Skip()
 					} else {
 						in.Delim('{')
 						if !in.IsDelim('}') {
-							v6 = make(StringMap)
+							v9 = make(StringMap)
 						} else {
-							v6 = nil
+							v9 = nil
 						}
 						for !in.IsDelim('}') {
 							key := string(in.String())
 							in.WantColon()
-							var v7 interface{}
-							if m, ok := v7.(easyjson.Unmarshaler); ok {
+							var v10 interface{}
+							if m, ok := v10.(easyjson.Unmarshaler); ok {
 								m.UnmarshalEasyJSON(in)
-							} else if m, ok := v7.(json.Unmarshaler); ok {
+							} else if m, ok := v10.(json.Unmarshaler); ok {
 								_ = m.UnmarshalJSON(in.Raw())
 							} else {
-								v7 = in.Interface()
+								v10 = in.Interface()
 							}
-							(v6)[key] = v7
+							(v9)[key] = v10
 							in.WantComma()
 						}
 						in.Delim('}')
 					}
-					out.Changed = append(out.Changed, v6)
+					out.Changed = append(out.Changed, v9)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -1072,39 +1430,66 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling8(in *jlex
 					out.Users = (out.Users)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v8 StringMap
+					var v11 StringMap
 					if in.IsNull() {
 						in.Skip()
 					} else {
 						in.Delim('{')
 						if !in.IsDelim('}') {
-							v8 = make(StringMap)
+							v11 = make(StringMap)
 						} else {
-							v8 = nil
+							v11 = nil
 						}
 						for !in.IsDelim('}') {
 							key := string(in.String())
 							in.WantColon()
-							var v9 interface{}
-							if m, ok := v9.(easyjson.Unmarshaler); ok {
+							var v12 interface{}
+							if m, ok := v12.(easyjson.Unmarshaler); ok {
 								m.UnmarshalEasyJSON(in)
-							} else if m, ok := v9.(json.Unmarshaler); ok {
+							} else if m, ok := v12.(json.Unmarshaler); ok {
 								_ = m.UnmarshalJSON(in.Raw())
 							} else {
-								v9 = in.Interface()
+								v12 = in.Interface()
 							}
-							(v8)[key] = v9
+							(v11)[key] = v12
 							in.WantComma()
 						}
 						in.Delim('}')
 					}
-					out.Users = append(out.Users, v8)
+					out.Users = append(out.Users, v11)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
 		case "all":
 			out.All = bool(in.Bool())
+		case "version":
+			out.Version = uint64(in.Uint64())
+		case "delta":
+			out.Delta = bool(in.Bool())
+		case "removed":
+			if in.IsNull() {
+				in.Skip()
+				out.Removed = nil
+			} else {
+				in.Delim('[')
+				if out.Removed == nil {
+					if !in.IsDelim(']') {
+						out.Removed = make([]PublicSessionId, 0, 4)
+					} else {
+						out.Removed = []PublicSessionId{}
+					}
+				} else {
+					out.Removed = (out.Removed)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v13 PublicSessionId
+					v13 = PublicSessionId(in.String())
+					out.Removed = append(out.Removed, v13)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -1115,7 +1500,7 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling8(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling8(out *jwriter.Writer, in RoomEventServerMessage) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling11(out *jwriter.Writer, in RoomEventServerMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1139,29 +1524,29 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling8(out *jwr
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v10, v11 := range in.Changed {
-				if v10 > 0 {
+			for v14, v15 := range in.Changed {
+				if v14 > 0 {
 					out.RawByte(',')
 				}
-				if v11 == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+				if v15 == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
 					out.RawString(`null`)
 				} else {
 					out.RawByte('{')
-					v12First := true
-					for v12Name, v12Value := range v11 {
-						if v12First {
-							v12First = false
+					v16First := true
+					for v16Name, v16Value := range v15 {
+						if v16First {
+							v16First = false
 						} else {
 							out.RawByte(',')
 						}
-						out.String(string(v12Name))
+						out.String(string(v16Name))
 						out.RawByte(':')
-						if m, ok := v12Value.(easyjson.Marshaler); ok {
+						if m, ok := v16Value.(easyjson.Marshaler); ok {
 							m.MarshalEasyJSON(out)
-						} else if m, ok := v12Value.(json.Marshaler); ok {
+						} else if m, ok := v16Value.(json.Marshaler); ok {
 							out.Raw(m.MarshalJSON())
 						} else {
-							out.Raw(json.Marshal(v12Value))
+							out.Raw(json.Marshal(v16Value))
 						}
 					}
 					out.RawByte('}')
@@ -1175,29 +1560,29 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling8(out *jwr
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v13, v14 := range in.Users {
-				if v13 > 0 {
+			for v17, v18 := range in.Users {
+				if v17 > 0 {
 					out.RawByte(',')
 				}
-				if v14 == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+				if v18 == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
 					out.RawString(`null`)
 				} else {
 					out.RawByte('{')
-					v15First := true
-					for v15Name, v15Value := range v14 {
-						if v15First {
-							v15First = false
+					v19First := true
+					for v19Name, v19Value := range v18 {
+						if v19First {
+							v19First = false
 						} else {
 							out.RawByte(',')
 						}
-						out.String(string(v15Name))
+						out.String(string(v19Name))
 						out.RawByte(':')
-						if m, ok := v15Value.(easyjson.Marshaler); ok {
+						if m, ok := v19Value.(easyjson.Marshaler); ok {
 							m.MarshalEasyJSON(out)
-						} else if m, ok := v15Value.(json.Marshaler); ok {
+						} else if m, ok := v19Value.(json.Marshaler); ok {
 							out.Raw(m.MarshalJSON())
 						} else {
-							out.Raw(json.Marshal(v15Value))
+							out.Raw(json.Marshal(v19Value))
 						}
 					}
 					out.RawByte('}')
@@ -1211,33 +1596,57 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling8(out *jwr
 		out.RawString(prefix)
 		out.Bool(bool(in.All))
 	}
+	if in.Version != 0 {
+		const prefix string = ",\"version\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.Version))
+	}
+	if in.Delta {
+		const prefix string = ",\"delta\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Delta))
+	}
+	if len(in.Removed) != 0 {
+		const prefix string = ",\"removed\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v20, v21 := range in.Removed {
+				if v20 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v21))
+			}
+			out.RawByte(']')
+		}
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v RoomEventServerMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling8(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling11(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v RoomEventServerMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling8(w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling11(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *RoomEventServerMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling8(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling11(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *RoomEventServerMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling8(l, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling11(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling9(in *jlexer.Lexer, out *RoomEventMessageDataChat) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling12(in *jlexer.Lexer, out *RoomEventMessageDataChat) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1276,15 +1685,15 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling9(in *jlex
 					for !in.IsDelim('}') {
 						key := string(in.String())
 						in.WantColon()
-						var v16 interface{}
-						if m, ok := v16.(easyjson.Unmarshaler); ok {
+						var v22 interface{}
+						if m, ok := v22.(easyjson.Unmarshaler); ok {
 							m.UnmarshalEasyJSON(in)
-						} else if m, ok := v16.(json.Unmarshaler); ok {
+						} else if m, ok := v22.(json.Unmarshaler); ok {
 							_ = m.UnmarshalJSON(in.Raw())
 						} else {
-							v16 = in.Interface()
+							v22 = in.Interface()
 						}
-						(*out.Comment)[key] = v16
+						(*out.Comment)[key] = v22
 						in.WantComma()
 					}
 					in.Delim('}')
@@ -1300,7 +1709,7 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling9(in *jlex
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling9(out *jwriter.Writer, in RoomEventMessageDataChat) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling12(out *jwriter.Writer, in RoomEventMessageDataChat) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1312,21 +1721,21 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling9(out *jwr
 			out.RawString(`null`)
 		} else {
 			out.RawByte('{')
-			v17First := true
-			for v17Name, v17Value := range *in.Comment {
-				if v17First {
-					v17First = false
+			v23First := true
+			for v23Name, v23Value := range *in.Comment {
+				if v23First {
+					v23First = false
 				} else {
 					out.RawByte(',')
 				}
-				out.String(string(v17Name))
+				out.String(string(v23Name))
 				out.RawByte(':')
-				if m, ok := v17Value.(easyjson.Marshaler); ok {
+				if m, ok := v23Value.(easyjson.Marshaler); ok {
 					m.MarshalEasyJSON(out)
-				} else if m, ok := v17Value.(json.Marshaler); ok {
+				} else if m, ok := v23Value.(json.Marshaler); ok {
 					out.Raw(m.MarshalJSON())
 				} else {
-					out.Raw(json.Marshal(v17Value))
+					out.Raw(json.Marshal(v23Value))
 				}
 			}
 			out.RawByte('}')
@@ -1338,27 +1747,27 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling9(out *jwr
 // MarshalJSON supports json.Marshaler interface
 func (v RoomEventMessageDataChat) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling9(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling12(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v RoomEventMessageDataChat) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling9(w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling12(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *RoomEventMessageDataChat) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling9(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling12(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *RoomEventMessageDataChat) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling9(l, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling12(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling10(in *jlexer.Lexer, out *RoomEventMessageData) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling13(in *jlexer.Lexer, out *RoomEventMessageData) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1399,7 +1808,7 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling10(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling10(out *jwriter.Writer, in RoomEventMessageData) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling13(out *jwriter.Writer, in RoomEventMessageData) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1419,27 +1828,27 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling10(out *jw
 // MarshalJSON supports json.Marshaler interface
 func (v RoomEventMessageData) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling10(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling13(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v RoomEventMessageData) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling10(w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling13(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *RoomEventMessageData) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling10(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling13(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *RoomEventMessageData) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling10(l, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling13(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling11(in *jlexer.Lexer, out *RoomEventMessage) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling14(in *jlexer.Lexer, out *RoomEventMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1474,7 +1883,7 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling11(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling11(out *jwriter.Writer, in RoomEventMessage) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling14(out *jwriter.Writer, in RoomEventMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1494,27 +1903,27 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling11(out *jw
 // MarshalJSON supports json.Marshaler interface
 func (v RoomEventMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling11(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling14(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v RoomEventMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling11(w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling14(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *RoomEventMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling11(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling14(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *RoomEventMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling11(l, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling14(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling12(in *jlexer.Lexer, out *RoomErrorDetails) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling15(in *jlexer.Lexer, out *RoomErrorDetails) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1553,7 +1962,7 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling12(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling12(out *jwriter.Writer, in RoomErrorDetails) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling15(out *jwriter.Writer, in RoomErrorDetails) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1572,27 +1981,27 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling12(out *jw
 // MarshalJSON supports json.Marshaler interface
 func (v RoomErrorDetails) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling12(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling15(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v RoomErrorDetails) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling12(w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling15(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *RoomErrorDetails) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling12(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling15(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *RoomErrorDetails) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling12(l, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling15(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling13(in *jlexer.Lexer, out *RoomDisinviteEventServerMessage) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling16(in *jlexer.Lexer, out *RoomDisinviteEventServerMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1639,33 +2048,33 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling13(in *jle
 					out.Changed = (out.Changed)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v18 StringMap
+					var v24 StringMap
 					if in.IsNull() {
 						in.Skip()
 					} else {
 						in.Delim('{')
 						if !in.IsDelim('}') {
-							v18 = make(StringMap)
+							v24 = make(StringMap)
 						} else {
-							v18 = nil
+							v24 = nil
 						}
 						for !in.IsDelim('}') {
 							key := string(in.String())
 							in.WantColon()
-							var v19 interface{}
-							if m, ok := v19.(easyjson.Unmarshaler); ok {
+							var v25 interface{}
+							if m, ok := v25.(easyjson.Unmarshaler); ok {
 								m.UnmarshalEasyJSON(in)
-							} else if m, ok := v19.(json.Unmarshaler); ok {
+							} else if m, ok := v25.(json.Unmarshaler); ok {
 								_ = m.UnmarshalJSON(in.Raw())
 							} else {
-								v19 = in.Interface()
+								v25 = in.Interface()
 							}
-							(v18)[key] = v19
+							(v24)[key] = v25
 							in.WantComma()
 						}
 						in.Delim('}')
 					}
-					out.Changed = append(out.Changed, v18)
+					out.Changed = append(out.Changed, v24)
 					in.WantComma()
 				}
 				in.Delim(']')
@@ -1686,39 +2095,66 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling13(in *jle
 					out.Users = (out.Users)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v20 StringMap
+					var v26 StringMap
 					if in.IsNull() {
 						in.Skip()
 					} else {
 						in.Delim('{')
 						if !in.IsDelim('}') {
-							v20 = make(StringMap)
+							v26 = make(StringMap)
 						} else {
-							v20 = nil
+							v26 = nil
 						}
 						for !in.IsDelim('}') {
 							key := string(in.String())
 							in.WantColon()
-							var v21 interface{}
-							if m, ok := v21.(easyjson.Unmarshaler); ok {
+							var v27 interface{}
+							if m, ok := v27.(easyjson.Unmarshaler); ok {
 								m.UnmarshalEasyJSON(in)
-							} else if m, ok := v21.(json.Unmarshaler); ok {
+							} else if m, ok := v27.(json.Unmarshaler); ok {
 								_ = m.UnmarshalJSON(in.Raw())
 							} else {
-								v21 = in.Interface()
+								v27 = in.Interface()
 							}
-							(v20)[key] = v21
+							(v26)[key] = v27
 							in.WantComma()
 						}
 						in.Delim('}')
 					}
-					out.Users = append(out.Users, v20)
+					out.Users = append(out.Users, v26)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
 		case "all":
 			out.All = bool(in.Bool())
+		case "version":
+			out.Version = uint64(in.Uint64())
+		case "delta":
+			out.Delta = bool(in.Bool())
+		case "removed":
+			if in.IsNull() {
+				in.Skip()
+				out.Removed = nil
+			} else {
+				in.Delim('[')
+				if out.Removed == nil {
+					if !in.IsDelim(']') {
+						out.Removed = make([]PublicSessionId, 0, 4)
+					} else {
+						out.Removed = []PublicSessionId{}
+					}
+				} else {
+					out.Removed = (out.Removed)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v28 PublicSessionId
+					v28 = PublicSessionId(in.String())
+					out.Removed = append(out.Removed, v28)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -1729,7 +2165,7 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling13(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling13(out *jwriter.Writer, in RoomDisinviteEventServerMessage) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling16(out *jwriter.Writer, in RoomDisinviteEventServerMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1758,29 +2194,29 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling13(out *jw
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v22, v23 := range in.Changed {
-				if v22 > 0 {
+			for v29, v30 := range in.Changed {
+				if v29 > 0 {
 					out.RawByte(',')
 				}
-				if v23 == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+				if v30 == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
 					out.RawString(`null`)
 				} else {
 					out.RawByte('{')
-					v24First := true
-					for v24Name, v24Value := range v23 {
-						if v24First {
-							v24First = false
+					v31First := true
+					for v31Name, v31Value := range v30 {
+						if v31First {
+							v31First = false
 						} else {
 							out.RawByte(',')
 						}
-						out.String(string(v24Name))
+						out.String(string(v31Name))
 						out.RawByte(':')
-						if m, ok := v24Value.(easyjson.Marshaler); ok {
+						if m, ok := v31Value.(easyjson.Marshaler); ok {
 							m.MarshalEasyJSON(out)
-						} else if m, ok := v24Value.(json.Marshaler); ok {
+						} else if m, ok := v31Value.(json.Marshaler); ok {
 							out.Raw(m.MarshalJSON())
 						} else {
-							out.Raw(json.Marshal(v24Value))
+							out.Raw(json.Marshal(v31Value))
 						}
 					}
 					out.RawByte('}')
@@ -1794,29 +2230,29 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling13(out *jw
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v25, v26 := range in.Users {
-				if v25 > 0 {
+			for v32, v33 := range in.Users {
+				if v32 > 0 {
 					out.RawByte(',')
 				}
-				if v26 == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+				if v33 == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
 					out.RawString(`null`)
 				} else {
 					out.RawByte('{')
-					v27First := true
-					for v27Name, v27Value := range v26 {
-						if v27First {
-							v27First = false
+					v34First := true
+					for v34Name, v34Value := range v33 {
+						if v34First {
+							v34First = false
 						} else {
 							out.RawByte(',')
 						}
-						out.String(string(v27Name))
+						out.String(string(v34Name))
 						out.RawByte(':')
-						if m, ok := v27Value.(easyjson.Marshaler); ok {
+						if m, ok := v34Value.(easyjson.Marshaler); ok {
 							m.MarshalEasyJSON(out)
-						} else if m, ok := v27Value.(json.Marshaler); ok {
+						} else if m, ok := v34Value.(json.Marshaler); ok {
 							out.Raw(m.MarshalJSON())
 						} else {
-							out.Raw(json.Marshal(v27Value))
+							out.Raw(json.Marshal(v34Value))
 						}
 					}
 					out.RawByte('}')
@@ -1830,33 +2266,57 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling13(out *jw
 		out.RawString(prefix)
 		out.Bool(bool(in.All))
 	}
+	if in.Version != 0 {
+		const prefix string = ",\"version\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.Version))
+	}
+	if in.Delta {
+		const prefix string = ",\"delta\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Delta))
+	}
+	if len(in.Removed) != 0 {
+		const prefix string = ",\"removed\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v35, v36 := range in.Removed {
+				if v35 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v36))
+			}
+			out.RawByte(']')
+		}
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v RoomDisinviteEventServerMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling13(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling16(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v RoomDisinviteEventServerMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling13(w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling16(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *RoomDisinviteEventServerMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling13(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling16(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *RoomDisinviteEventServerMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling13(l, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling16(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling14(in *jlexer.Lexer, out *RoomClientMessage) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling17(in *jlexer.Lexer, out *RoomClientMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1889,6 +2349,10 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling14(in *jle
 				}
 				(*out.Federation).UnmarshalEasyJSON(in)
 			}
+		case "secondary":
+			out.Secondary = bool(in.Bool())
+		case "leave":
+			out.Leave = bool(in.Bool())
 		default:
 			in.SkipRecursive()
 		}
@@ -1899,7 +2363,7 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling14(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling14(out *jwriter.Writer, in RoomClientMessage) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling17(out *jwriter.Writer, in RoomClientMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -1918,33 +2382,43 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling14(out *jw
 		out.RawString(prefix)
 		(*in.Federation).MarshalEasyJSON(out)
 	}
+	if in.Secondary {
+		const prefix string = ",\"secondary\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Secondary))
+	}
+	if in.Leave {
+		const prefix string = ",\"leave\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Leave))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v RoomClientMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling14(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling17(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v RoomClientMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling14(w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling17(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *RoomClientMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling14(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling17(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *RoomClientMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling14(l, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling17(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling15(in *jlexer.Lexer, out *RemoveSessionInternalClientMessage) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling18(in *jlexer.Lexer, out *RemoveSessionsInternalClientMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -1963,12 +2437,31 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling15(in *jle
 			continue
 		}
 		switch key {
-		case "userid":
-			out.UserId = string(in.String())
-		case "sessionid":
-			out.SessionId = PublicSessionId(in.String())
 		case "roomid":
 			out.RoomId = string(in.String())
+		case "sessionids":
+			if in.IsNull() {
+				in.Skip()
+				out.SessionIds = nil
+			} else {
+				in.Delim('[')
+				if out.SessionIds == nil {
+					if !in.IsDelim(']') {
+						out.SessionIds = make([]PublicSessionId, 0, 4)
+					} else {
+						out.SessionIds = []PublicSessionId{}
+					}
+				} else {
+					out.SessionIds = (out.SessionIds)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v37 PublicSessionId
+					v37 = PublicSessionId(in.String())
+					out.SessionIds = append(out.SessionIds, v37)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -1979,58 +2472,58 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling15(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling15(out *jwriter.Writer, in RemoveSessionInternalClientMessage) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling18(out *jwriter.Writer, in RemoveSessionsInternalClientMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	if in.UserId != "" {
-		const prefix string = ",\"userid\":"
-		first = false
+	{
+		const prefix string = ",\"roomid\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.UserId))
+		out.String(string(in.RoomId))
 	}
 	{
-		const prefix string = ",\"sessionid\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
+		const prefix string = ",\"sessionids\":"
+		out.RawString(prefix)
+		if in.SessionIds == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
 		} else {
-			out.RawString(prefix)
+			out.RawByte('[')
+			for v38, v39 := range in.SessionIds {
+				if v38 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v39))
+			}
+			out.RawByte(']')
 		}
-		out.String(string(in.SessionId))
-	}
-	{
-		const prefix string = ",\"roomid\":"
-		out.RawString(prefix)
-		out.String(string(in.RoomId))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v RemoveSessionInternalClientMessage) MarshalJSON() ([]byte, error) {
+func (v RemoveSessionsInternalClientMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling15(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling18(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v RemoveSessionInternalClientMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling15(w, v)
+func (v RemoveSessionsInternalClientMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling18(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *RemoveSessionInternalClientMessage) UnmarshalJSON(data []byte) error {
+func (v *RemoveSessionsInternalClientMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling15(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling18(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *RemoveSessionInternalClientMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling15(l, v)
+func (v *RemoveSessionsInternalClientMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling18(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling16(in *jlexer.Lexer, out *MessageServerMessageSender) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling19(in *jlexer.Lexer, out *RemoveSessionInternalClientMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2049,12 +2542,12 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling16(in *jle
 			continue
 		}
 		switch key {
-		case "type":
-			out.Type = string(in.String())
-		case "sessionid":
-			out.SessionId = PublicSessionId(in.String())
 		case "userid":
 			out.UserId = string(in.String())
+		case "sessionid":
+			out.SessionId = PublicSessionId(in.String())
+		case "roomid":
+			out.RoomId = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -2065,52 +2558,58 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling16(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling16(out *jwriter.Writer, in MessageServerMessageSender) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling19(out *jwriter.Writer, in RemoveSessionInternalClientMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	{
-		const prefix string = ",\"type\":"
+	if in.UserId != "" {
+		const prefix string = ",\"userid\":"
+		first = false
 		out.RawString(prefix[1:])
-		out.String(string(in.Type))
+		out.String(string(in.UserId))
 	}
-	if in.SessionId != "" {
+	{
 		const prefix string = ",\"sessionid\":"
-		out.RawString(prefix)
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
 		out.String(string(in.SessionId))
 	}
-	if in.UserId != "" {
-		const prefix string = ",\"userid\":"
+	{
+		const prefix string = ",\"roomid\":"
 		out.RawString(prefix)
-		out.String(string(in.UserId))
+		out.String(string(in.RoomId))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v MessageServerMessageSender) MarshalJSON() ([]byte, error) {
+func (v RemoveSessionInternalClientMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling16(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling19(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v MessageServerMessageSender) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling16(w, v)
+func (v RemoveSessionInternalClientMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling19(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *MessageServerMessageSender) UnmarshalJSON(data []byte) error {
+func (v *RemoveSessionInternalClientMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling16(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling19(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *MessageServerMessageSender) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling16(l, v)
+func (v *RemoveSessionInternalClientMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling19(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling17(in *jlexer.Lexer, out *MessageServerMessageDataChat) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling20(in *jlexer.Lexer, out *RecordingInternalClientMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2129,11 +2628,25 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling17(in *jle
 			continue
 		}
 		switch key {
-		case "refresh":
-			out.Refresh = bool(in.Bool())
-		default:
-			in.SkipRecursive()
-		}
+		case "type":
+			out.Type = string(in.String())
+		case "roomid":
+			out.RoomId = string(in.String())
+		case "error":
+			if in.IsNull() {
+				in.Skip()
+				out.Error = nil
+			} else {
+				if out.Error == nil {
+					out.Error = new(Error)
+				}
+				(*out.Error).UnmarshalEasyJSON(in)
+			}
+		case "status":
+			out.Status = RecordingStatus(in.String())
+		default:
+			in.SkipRecursive()
+		}
 		in.WantComma()
 	}
 	in.Delim('}')
@@ -2141,42 +2654,57 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling17(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling17(out *jwriter.Writer, in MessageServerMessageDataChat) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling20(out *jwriter.Writer, in RecordingInternalClientMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"refresh\":"
+		const prefix string = ",\"type\":"
 		out.RawString(prefix[1:])
-		out.Bool(bool(in.Refresh))
+		out.String(string(in.Type))
+	}
+	if in.RoomId != "" {
+		const prefix string = ",\"roomid\":"
+		out.RawString(prefix)
+		out.String(string(in.RoomId))
+	}
+	if in.Error != nil {
+		const prefix string = ",\"error\":"
+		out.RawString(prefix)
+		(*in.Error).MarshalEasyJSON(out)
+	}
+	if in.Status != "" {
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v MessageServerMessageDataChat) MarshalJSON() ([]byte, error) {
+func (v RecordingInternalClientMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling17(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling20(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v MessageServerMessageDataChat) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling17(w, v)
+func (v RecordingInternalClientMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling20(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *MessageServerMessageDataChat) UnmarshalJSON(data []byte) error {
+func (v *RecordingInternalClientMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling17(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling20(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *MessageServerMessageDataChat) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling17(l, v)
+func (v *RecordingInternalClientMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling20(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling18(in *jlexer.Lexer, out *MessageServerMessageData) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling21(in *jlexer.Lexer, out *RecordingClientMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2195,17 +2723,11 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling18(in *jle
 			continue
 		}
 		switch key {
-		case "type":
-			out.Type = string(in.String())
-		case "chat":
-			if in.IsNull() {
-				in.Skip()
-				out.Chat = nil
-			} else {
-				if out.Chat == nil {
-					out.Chat = new(MessageServerMessageDataChat)
-				}
-				(*out.Chat).UnmarshalEasyJSON(in)
+		case "action":
+			out.Action = string(in.String())
+		case "options":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Options).UnmarshalJSON(data))
 			}
 		default:
 			in.SkipRecursive()
@@ -2217,47 +2739,47 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling18(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling18(out *jwriter.Writer, in MessageServerMessageData) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling21(out *jwriter.Writer, in RecordingClientMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"type\":"
+		const prefix string = ",\"action\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Type))
+		out.String(string(in.Action))
 	}
-	if in.Chat != nil {
-		const prefix string = ",\"chat\":"
+	if len(in.Options) != 0 {
+		const prefix string = ",\"options\":"
 		out.RawString(prefix)
-		(*in.Chat).MarshalEasyJSON(out)
+		out.Raw((in.Options).MarshalJSON())
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v MessageServerMessageData) MarshalJSON() ([]byte, error) {
+func (v RecordingClientMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling18(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling21(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v MessageServerMessageData) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling18(w, v)
+func (v RecordingClientMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling21(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *MessageServerMessageData) UnmarshalJSON(data []byte) error {
+func (v *RecordingClientMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling18(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling21(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *MessageServerMessageData) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling18(l, v)
+func (v *RecordingClientMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling21(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling19(in *jlexer.Lexer, out *MessageServerMessage) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling22(in *jlexer.Lexer, out *ParticipantsClientMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2276,30 +2798,8 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling19(in *jle
 			continue
 		}
 		switch key {
-		case "sender":
-			if in.IsNull() {
-				in.Skip()
-				out.Sender = nil
-			} else {
-				if out.Sender == nil {
-					out.Sender = new(MessageServerMessageSender)
-				}
-				(*out.Sender).UnmarshalEasyJSON(in)
-			}
-		case "recipient":
-			if in.IsNull() {
-				in.Skip()
-				out.Recipient = nil
-			} else {
-				if out.Recipient == nil {
-					out.Recipient = new(MessageClientMessageRecipient)
-				}
-				(*out.Recipient).UnmarshalEasyJSON(in)
-			}
-		case "data":
-			if data := in.Raw(); in.Ok() {
-				in.AddError((out.Data).UnmarshalJSON(data))
-			}
+		case "version":
+			out.Version = uint64(in.Uint64())
 		default:
 			in.SkipRecursive()
 		}
@@ -2310,56 +2810,43 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling19(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling19(out *jwriter.Writer, in MessageServerMessage) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling22(out *jwriter.Writer, in ParticipantsClientMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	{
-		const prefix string = ",\"sender\":"
+	if in.Version != 0 {
+		const prefix string = ",\"version\":"
+		first = false
 		out.RawString(prefix[1:])
-		if in.Sender == nil {
-			out.RawString("null")
-		} else {
-			(*in.Sender).MarshalEasyJSON(out)
-		}
-	}
-	if in.Recipient != nil {
-		const prefix string = ",\"recipient\":"
-		out.RawString(prefix)
-		(*in.Recipient).MarshalEasyJSON(out)
-	}
-	{
-		const prefix string = ",\"data\":"
-		out.RawString(prefix)
-		out.Raw((in.Data).MarshalJSON())
+		out.Uint64(uint64(in.Version))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v MessageServerMessage) MarshalJSON() ([]byte, error) {
+func (v ParticipantsClientMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling19(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling22(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v MessageServerMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling19(w, v)
+func (v ParticipantsClientMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling22(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *MessageServerMessage) UnmarshalJSON(data []byte) error {
+func (v *ParticipantsClientMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling19(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling22(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *MessageServerMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling19(l, v)
+func (v *ParticipantsClientMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling22(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling20(in *jlexer.Lexer, out *MessageClientMessageRecipient) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling23(in *jlexer.Lexer, out *MessageServerMessageSender) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2394,7 +2881,7 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling20(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling20(out *jwriter.Writer, in MessageClientMessageRecipient) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling23(out *jwriter.Writer, in MessageServerMessageSender) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -2417,29 +2904,29 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling20(out *jw
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v MessageClientMessageRecipient) MarshalJSON() ([]byte, error) {
+func (v MessageServerMessageSender) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling20(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling23(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v MessageClientMessageRecipient) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling20(w, v)
+func (v MessageServerMessageSender) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling23(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *MessageClientMessageRecipient) UnmarshalJSON(data []byte) error {
+func (v *MessageServerMessageSender) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling20(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling23(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *MessageClientMessageRecipient) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling20(l, v)
+func (v *MessageServerMessageSender) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling23(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling21(in *jlexer.Lexer, out *MessageClientMessageData) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling24(in *jlexer.Lexer, out *MessageServerMessageDataChat) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2458,44 +2945,8 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling21(in *jle
 			continue
 		}
 		switch key {
-		case "type":
-			out.Type = string(in.String())
-		case "sid":
-			out.Sid = string(in.String())
-		case "roomType":
-			out.RoomType = string(in.String())
-		case "payload":
-			if in.IsNull() {
-				in.Skip()
-			} else {
-				in.Delim('{')
-				out.Payload = make(StringMap)
-				for !in.IsDelim('}') {
-					key := string(in.String())
-					in.WantColon()
-					var v28 interface{}
-					if m, ok := v28.(easyjson.Unmarshaler); ok {
-						m.UnmarshalEasyJSON(in)
-					} else if m, ok := v28.(json.Unmarshaler); ok {
-						_ = m.UnmarshalJSON(in.Raw())
-					} else {
-						v28 = in.Interface()
-					}
-					(out.Payload)[key] = v28
-					in.WantComma()
-				}
-				in.Delim('}')
-			}
-		case "bitrate":
-			out.Bitrate = int(in.Int())
-		case "audiocodec":
-			out.AudioCodec = string(in.String())
-		case "videocodec":
-			out.VideoCodec = string(in.String())
-		case "vp9profile":
-			out.VP9Profile = string(in.String())
-		case "h264profile":
-			out.H264Profile = string(in.String())
+		case "refresh":
+			out.Refresh = bool(in.Bool())
 		default:
 			in.SkipRecursive()
 		}
@@ -2506,104 +2957,42 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling21(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling21(out *jwriter.Writer, in MessageClientMessageData) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling24(out *jwriter.Writer, in MessageServerMessageDataChat) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"type\":"
+		const prefix string = ",\"refresh\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Type))
-	}
-	{
-		const prefix string = ",\"sid\":"
-		out.RawString(prefix)
-		out.String(string(in.Sid))
-	}
-	{
-		const prefix string = ",\"roomType\":"
-		out.RawString(prefix)
-		out.String(string(in.RoomType))
-	}
-	{
-		const prefix string = ",\"payload\":"
-		out.RawString(prefix)
-		if in.Payload == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
-			out.RawString(`null`)
-		} else {
-			out.RawByte('{')
-			v29First := true
-			for v29Name, v29Value := range in.Payload {
-				if v29First {
-					v29First = false
-				} else {
-					out.RawByte(',')
-				}
-				out.String(string(v29Name))
-				out.RawByte(':')
-				if m, ok := v29Value.(easyjson.Marshaler); ok {
-					m.MarshalEasyJSON(out)
-				} else if m, ok := v29Value.(json.Marshaler); ok {
-					out.Raw(m.MarshalJSON())
-				} else {
-					out.Raw(json.Marshal(v29Value))
-				}
-			}
-			out.RawByte('}')
-		}
-	}
-	if in.Bitrate != 0 {
-		const prefix string = ",\"bitrate\":"
-		out.RawString(prefix)
-		out.Int(int(in.Bitrate))
-	}
-	if in.AudioCodec != "" {
-		const prefix string = ",\"audiocodec\":"
-		out.RawString(prefix)
-		out.String(string(in.AudioCodec))
-	}
-	if in.VideoCodec != "" {
-		const prefix string = ",\"videocodec\":"
-		out.RawString(prefix)
-		out.String(string(in.VideoCodec))
-	}
-	if in.VP9Profile != "" {
-		const prefix string = ",\"vp9profile\":"
-		out.RawString(prefix)
-		out.String(string(in.VP9Profile))
-	}
-	if in.H264Profile != "" {
-		const prefix string = ",\"h264profile\":"
-		out.RawString(prefix)
-		out.String(string(in.H264Profile))
+		out.Bool(bool(in.Refresh))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v MessageClientMessageData) MarshalJSON() ([]byte, error) {
+func (v MessageServerMessageDataChat) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling21(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling24(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v MessageClientMessageData) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling21(w, v)
+func (v MessageServerMessageDataChat) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling24(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *MessageClientMessageData) UnmarshalJSON(data []byte) error {
+func (v *MessageServerMessageDataChat) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling21(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling24(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *MessageClientMessageData) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling21(l, v)
+func (v *MessageServerMessageDataChat) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling24(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling22(in *jlexer.Lexer, out *MessageClientMessage) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling25(in *jlexer.Lexer, out *MessageServerMessageData) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2622,11 +3011,17 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling22(in *jle
 			continue
 		}
 		switch key {
-		case "recipient":
-			(out.Recipient).UnmarshalEasyJSON(in)
-		case "data":
-			if data := in.Raw(); in.Ok() {
-				in.AddError((out.Data).UnmarshalJSON(data))
+		case "type":
+			out.Type = string(in.String())
+		case "chat":
+			if in.IsNull() {
+				in.Skip()
+				out.Chat = nil
+			} else {
+				if out.Chat == nil {
+					out.Chat = new(MessageServerMessageDataChat)
+				}
+				(*out.Chat).UnmarshalEasyJSON(in)
 			}
 		default:
 			in.SkipRecursive()
@@ -2638,47 +3033,47 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling22(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling22(out *jwriter.Writer, in MessageClientMessage) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling25(out *jwriter.Writer, in MessageServerMessageData) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"recipient\":"
+		const prefix string = ",\"type\":"
 		out.RawString(prefix[1:])
-		(in.Recipient).MarshalEasyJSON(out)
+		out.String(string(in.Type))
 	}
-	{
-		const prefix string = ",\"data\":"
+	if in.Chat != nil {
+		const prefix string = ",\"chat\":"
 		out.RawString(prefix)
-		out.Raw((in.Data).MarshalJSON())
+		(*in.Chat).MarshalEasyJSON(out)
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v MessageClientMessage) MarshalJSON() ([]byte, error) {
+func (v MessageServerMessageData) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling22(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling25(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v MessageClientMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling22(w, v)
+func (v MessageServerMessageData) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling25(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *MessageClientMessage) UnmarshalJSON(data []byte) error {
+func (v *MessageServerMessageData) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling22(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling25(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *MessageClientMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling22(l, v)
+func (v *MessageServerMessageData) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling25(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling23(in *jlexer.Lexer, out *InternalServerMessage) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling26(in *jlexer.Lexer, out *MessageServerMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2697,17 +3092,29 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling23(in *jle
 			continue
 		}
 		switch key {
-		case "type":
-			out.Type = string(in.String())
-		case "dialout":
+		case "sender":
 			if in.IsNull() {
 				in.Skip()
-				out.Dialout = nil
+				out.Sender = nil
 			} else {
-				if out.Dialout == nil {
-					out.Dialout = new(InternalServerDialoutRequest)
+				if out.Sender == nil {
+					out.Sender = new(MessageServerMessageSender)
 				}
-				(*out.Dialout).UnmarshalEasyJSON(in)
+				(*out.Sender).UnmarshalEasyJSON(in)
+			}
+		case "recipient":
+			if in.IsNull() {
+				in.Skip()
+				out.Recipient = nil
+			} else {
+				if out.Recipient == nil {
+					out.Recipient = new(MessageClientMessageRecipient)
+				}
+				(*out.Recipient).UnmarshalEasyJSON(in)
+			}
+		case "data":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Data).UnmarshalJSON(data))
 			}
 		default:
 			in.SkipRecursive()
@@ -2719,47 +3126,56 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling23(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling23(out *jwriter.Writer, in InternalServerMessage) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling26(out *jwriter.Writer, in MessageServerMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"type\":"
+		const prefix string = ",\"sender\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Type))
+		if in.Sender == nil {
+			out.RawString("null")
+		} else {
+			(*in.Sender).MarshalEasyJSON(out)
+		}
 	}
-	if in.Dialout != nil {
-		const prefix string = ",\"dialout\":"
+	if in.Recipient != nil {
+		const prefix string = ",\"recipient\":"
 		out.RawString(prefix)
-		(*in.Dialout).MarshalEasyJSON(out)
+		(*in.Recipient).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"data\":"
+		out.RawString(prefix)
+		out.Raw((in.Data).MarshalJSON())
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v InternalServerMessage) MarshalJSON() ([]byte, error) {
+func (v MessageServerMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling23(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling26(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v InternalServerMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling23(w, v)
+func (v MessageServerMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling26(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *InternalServerMessage) UnmarshalJSON(data []byte) error {
+func (v *MessageServerMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling23(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling26(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *InternalServerMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling23(l, v)
+func (v *MessageServerMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling26(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling24(in *jlexer.Lexer, out *InternalServerDialoutRequest) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling27(in *jlexer.Lexer, out *MessageClientMessageRecipient) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2778,20 +3194,35 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling24(in *jle
 			continue
 		}
 		switch key {
-		case "roomid":
-			out.RoomId = string(in.String())
-		case "backend":
-			out.Backend = string(in.String())
-		case "request":
+		case "type":
+			out.Type = string(in.String())
+		case "sessionid":
+			out.SessionId = PublicSessionId(in.String())
+		case "sessionids":
 			if in.IsNull() {
 				in.Skip()
-				out.Request = nil
+				out.SessionIds = nil
 			} else {
-				if out.Request == nil {
-					out.Request = new(BackendRoomDialoutRequest)
+				in.Delim('[')
+				if out.SessionIds == nil {
+					if !in.IsDelim(']') {
+						out.SessionIds = make([]PublicSessionId, 0, 4)
+					} else {
+						out.SessionIds = []PublicSessionId{}
+					}
+				} else {
+					out.SessionIds = (out.SessionIds)[:0]
 				}
-				(*out.Request).UnmarshalEasyJSON(in)
+				for !in.IsDelim(']') {
+					var v40 PublicSessionId
+					v40 = PublicSessionId(in.String())
+					out.SessionIds = append(out.SessionIds, v40)
+					in.WantComma()
+				}
+				in.Delim(']')
 			}
+		case "userid":
+			out.UserId = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -2802,56 +3233,66 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling24(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling24(out *jwriter.Writer, in InternalServerDialoutRequest) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling27(out *jwriter.Writer, in MessageClientMessageRecipient) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"roomid\":"
+		const prefix string = ",\"type\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.RoomId))
+		out.String(string(in.Type))
 	}
-	{
-		const prefix string = ",\"backend\":"
+	if in.SessionId != "" {
+		const prefix string = ",\"sessionid\":"
 		out.RawString(prefix)
-		out.String(string(in.Backend))
+		out.String(string(in.SessionId))
 	}
-	{
-		const prefix string = ",\"request\":"
+	if len(in.SessionIds) != 0 {
+		const prefix string = ",\"sessionids\":"
 		out.RawString(prefix)
-		if in.Request == nil {
-			out.RawString("null")
-		} else {
-			(*in.Request).MarshalEasyJSON(out)
+		{
+			out.RawByte('[')
+			for v41, v42 := range in.SessionIds {
+				if v41 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v42))
+			}
+			out.RawByte(']')
 		}
 	}
+	if in.UserId != "" {
+		const prefix string = ",\"userid\":"
+		out.RawString(prefix)
+		out.String(string(in.UserId))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v InternalServerDialoutRequest) MarshalJSON() ([]byte, error) {
+func (v MessageClientMessageRecipient) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling24(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling27(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v InternalServerDialoutRequest) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling24(w, v)
+func (v MessageClientMessageRecipient) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling27(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *InternalServerDialoutRequest) UnmarshalJSON(data []byte) error {
+func (v *MessageClientMessageRecipient) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling24(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling27(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *InternalServerDialoutRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling24(l, v)
+func (v *MessageClientMessageRecipient) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling27(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling25(in *jlexer.Lexer, out *InternalClientMessage) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling28(in *jlexer.Lexer, out *MessageClientMessageData) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -2872,56 +3313,42 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling25(in *jle
 		switch key {
 		case "type":
 			out.Type = string(in.String())
-		case "addsession":
-			if in.IsNull() {
-				in.Skip()
-				out.AddSession = nil
-			} else {
-				if out.AddSession == nil {
-					out.AddSession = new(AddSessionInternalClientMessage)
-				}
-				(*out.AddSession).UnmarshalEasyJSON(in)
-			}
-		case "updatesession":
-			if in.IsNull() {
-				in.Skip()
-				out.UpdateSession = nil
-			} else {
-				if out.UpdateSession == nil {
-					out.UpdateSession = new(UpdateSessionInternalClientMessage)
-				}
-				(*out.UpdateSession).UnmarshalEasyJSON(in)
-			}
-		case "removesession":
-			if in.IsNull() {
-				in.Skip()
-				out.RemoveSession = nil
-			} else {
-				if out.RemoveSession == nil {
-					out.RemoveSession = new(RemoveSessionInternalClientMessage)
-				}
-				(*out.RemoveSession).UnmarshalEasyJSON(in)
-			}
-		case "incall":
-			if in.IsNull() {
-				in.Skip()
-				out.InCall = nil
-			} else {
-				if out.InCall == nil {
-					out.InCall = new(InCallInternalClientMessage)
-				}
-				(*out.InCall).UnmarshalEasyJSON(in)
-			}
-		case "dialout":
+		case "sid":
+			out.Sid = string(in.String())
+		case "roomType":
+			out.RoomType = string(in.String())
+		case "payload":
 			if in.IsNull() {
 				in.Skip()
-				out.Dialout = nil
 			} else {
-				if out.Dialout == nil {
-					out.Dialout = new(DialoutInternalClientMessage)
+				in.Delim('{')
+				out.Payload = make(StringMap)
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v43 interface{}
+					if m, ok := v43.(easyjson.Unmarshaler); ok {
+						m.UnmarshalEasyJSON(in)
+					} else if m, ok := v43.(json.Unmarshaler); ok {
+						_ = m.UnmarshalJSON(in.Raw())
+					} else {
+						v43 = in.Interface()
+					}
+					(out.Payload)[key] = v43
+					in.WantComma()
 				}
-				(*out.Dialout).UnmarshalEasyJSON(in)
+				in.Delim('}')
 			}
+		case "bitrate":
+			out.Bitrate = int(in.Int())
+		case "audiocodec":
+			out.AudioCodec = string(in.String())
+		case "videocodec":
+			out.VideoCodec = string(in.String())
+		case "vp9profile":
+			out.VP9Profile = string(in.String())
+		case "h264profile":
+			out.H264Profile = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -2932,7 +3359,7 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling25(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling25(out *jwriter.Writer, in InternalClientMessage) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling28(out *jwriter.Writer, in MessageClientMessageData) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -2941,58 +3368,95 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling25(out *jw
 		out.RawString(prefix[1:])
 		out.String(string(in.Type))
 	}
-	if in.AddSession != nil {
-		const prefix string = ",\"addsession\":"
+	{
+		const prefix string = ",\"sid\":"
 		out.RawString(prefix)
-		(*in.AddSession).MarshalEasyJSON(out)
+		out.String(string(in.Sid))
 	}
-	if in.UpdateSession != nil {
-		const prefix string = ",\"updatesession\":"
-		out.RawString(prefix)
-		(*in.UpdateSession).MarshalEasyJSON(out)
+	{
+		const prefix string = ",\"roomType\":"
+		out.RawString(prefix)
+		out.String(string(in.RoomType))
 	}
-	if in.RemoveSession != nil {
-		const prefix string = ",\"removesession\":"
+	{
+		const prefix string = ",\"payload\":"
 		out.RawString(prefix)
-		(*in.RemoveSession).MarshalEasyJSON(out)
+		if in.Payload == nil && (out.Flags&jwriter.NilMapAsEmpty) == 0 {
+			out.RawString(`null`)
+		} else {
+			out.RawByte('{')
+			v44First := true
+			for v44Name, v44Value := range in.Payload {
+				if v44First {
+					v44First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v44Name))
+				out.RawByte(':')
+				if m, ok := v44Value.(easyjson.Marshaler); ok {
+					m.MarshalEasyJSON(out)
+				} else if m, ok := v44Value.(json.Marshaler); ok {
+					out.Raw(m.MarshalJSON())
+				} else {
+					out.Raw(json.Marshal(v44Value))
+				}
+			}
+			out.RawByte('}')
+		}
 	}
-	if in.InCall != nil {
-		const prefix string = ",\"incall\":"
+	if in.Bitrate != 0 {
+		const prefix string = ",\"bitrate\":"
 		out.RawString(prefix)
-		(*in.InCall).MarshalEasyJSON(out)
+		out.Int(int(in.Bitrate))
 	}
-	if in.Dialout != nil {
-		const prefix string = ",\"dialout\":"
+	if in.AudioCodec != "" {
+		const prefix string = ",\"audiocodec\":"
 		out.RawString(prefix)
-		(*in.Dialout).MarshalEasyJSON(out)
+		out.String(string(in.AudioCodec))
+	}
+	if in.VideoCodec != "" {
+		const prefix string = ",\"videocodec\":"
+		out.RawString(prefix)
+		out.String(string(in.VideoCodec))
+	}
+	if in.VP9Profile != "" {
+		const prefix string = ",\"vp9profile\":"
+		out.RawString(prefix)
+		out.String(string(in.VP9Profile))
+	}
+	if in.H264Profile != "" {
+		const prefix string = ",\"h264profile\":"
+		out.RawString(prefix)
+		out.String(string(in.H264Profile))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v InternalClientMessage) MarshalJSON() ([]byte, error) {
+func (v MessageClientMessageData) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling25(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling28(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v InternalClientMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling25(w, v)
+func (v MessageClientMessageData) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling28(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *InternalClientMessage) UnmarshalJSON(data []byte) error {
+func (v *MessageClientMessageData) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling25(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling28(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *InternalClientMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling25(l, v)
+func (v *MessageClientMessageData) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling28(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling26(in *jlexer.Lexer, out *InCallInternalClientMessage) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling29(in *jlexer.Lexer, out *MessageClientMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3011,8 +3475,12 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling26(in *jle
 			continue
 		}
 		switch key {
-		case "incall":
-			out.InCall = int(in.Int())
+		case "recipient":
+			(out.Recipient).UnmarshalEasyJSON(in)
+		case "data":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Data).UnmarshalJSON(data))
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -3023,42 +3491,47 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling26(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling26(out *jwriter.Writer, in InCallInternalClientMessage) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling29(out *jwriter.Writer, in MessageClientMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"incall\":"
+		const prefix string = ",\"recipient\":"
 		out.RawString(prefix[1:])
-		out.Int(int(in.InCall))
+		(in.Recipient).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"data\":"
+		out.RawString(prefix)
+		out.Raw((in.Data).MarshalJSON())
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v InCallInternalClientMessage) MarshalJSON() ([]byte, error) {
+func (v MessageClientMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling26(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling29(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v InCallInternalClientMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling26(w, v)
+func (v MessageClientMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling29(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *InCallInternalClientMessage) UnmarshalJSON(data []byte) error {
+func (v *MessageClientMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling26(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling29(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *InCallInternalClientMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling26(l, v)
+func (v *MessageClientMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling29(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling27(in *jlexer.Lexer, out *HelloV2TokenClaims) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling30(in *jlexer.Lexer, out *InternalServerRecordingRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3077,56 +3550,20 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling27(in *jle
 			continue
 		}
 		switch key {
-		case "userdata":
-			if data := in.Raw(); in.Ok() {
-				in.AddError((out.UserData).UnmarshalJSON(data))
-			}
-		case "iss":
-			out.Issuer = string(in.String())
-		case "sub":
-			out.Subject = string(in.String())
-		case "aud":
+		case "action":
+			out.Action = string(in.String())
+		case "roomid":
+			out.RoomId = string(in.String())
+		case "backend":
+			out.Backend = string(in.String())
+		case "heartbeat":
+			out.Heartbeat = int(in.Int())
+		case "paused":
+			out.Paused = bool(in.Bool())
+		case "options":
 			if data := in.Raw(); in.Ok() {
-				in.AddError((out.Audience).UnmarshalJSON(data))
-			}
-		case "exp":
-			if in.IsNull() {
-				in.Skip()
-				out.ExpiresAt = nil
-			} else {
-				if out.ExpiresAt == nil {
-					out.ExpiresAt = new(_v5.NumericDate)
-				}
-				if data := in.Raw(); in.Ok() {
-					in.AddError((*out.ExpiresAt).UnmarshalJSON(data))
-				}
+				in.AddError((out.Options).UnmarshalJSON(data))
 			}
-		case "nbf":
-			if in.IsNull() {
-				in.Skip()
-				out.NotBefore = nil
-			} else {
-				if out.NotBefore == nil {
-					out.NotBefore = new(_v5.NumericDate)
-				}
-				if data := in.Raw(); in.Ok() {
-					in.AddError((*out.NotBefore).UnmarshalJSON(data))
-				}
-			}
-		case "iat":
-			if in.IsNull() {
-				in.Skip()
-				out.IssuedAt = nil
-			} else {
-				if out.IssuedAt == nil {
-					out.IssuedAt = new(_v5.NumericDate)
-				}
-				if data := in.Raw(); in.Ok() {
-					in.AddError((*out.IssuedAt).UnmarshalJSON(data))
-				}
-			}
-		case "jti":
-			out.ID = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -3137,113 +3574,67 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling27(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling27(out *jwriter.Writer, in HelloV2TokenClaims) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling30(out *jwriter.Writer, in InternalServerRecordingRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	if len(in.UserData) != 0 {
-		const prefix string = ",\"userdata\":"
-		first = false
+	{
+		const prefix string = ",\"action\":"
 		out.RawString(prefix[1:])
-		out.Raw((in.UserData).MarshalJSON())
-	}
-	if in.Issuer != "" {
-		const prefix string = ",\"iss\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		out.String(string(in.Issuer))
-	}
-	if in.Subject != "" {
-		const prefix string = ",\"sub\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		out.String(string(in.Subject))
+		out.String(string(in.Action))
 	}
-	if len(in.Audience) != 0 {
-		const prefix string = ",\"aud\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		out.Raw((in.Audience).MarshalJSON())
+	{
+		const prefix string = ",\"roomid\":"
+		out.RawString(prefix)
+		out.String(string(in.RoomId))
 	}
-	if in.ExpiresAt != nil {
-		const prefix string = ",\"exp\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		out.Raw((*in.ExpiresAt).MarshalJSON())
+	{
+		const prefix string = ",\"backend\":"
+		out.RawString(prefix)
+		out.String(string(in.Backend))
 	}
-	if in.NotBefore != nil {
-		const prefix string = ",\"nbf\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		out.Raw((*in.NotBefore).MarshalJSON())
+	if in.Heartbeat != 0 {
+		const prefix string = ",\"heartbeat\":"
+		out.RawString(prefix)
+		out.Int(int(in.Heartbeat))
 	}
-	if in.IssuedAt != nil {
-		const prefix string = ",\"iat\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		out.Raw((*in.IssuedAt).MarshalJSON())
+	if in.Paused {
+		const prefix string = ",\"paused\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Paused))
 	}
-	if in.ID != "" {
-		const prefix string = ",\"jti\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		out.String(string(in.ID))
+	if len(in.Options) != 0 {
+		const prefix string = ",\"options\":"
+		out.RawString(prefix)
+		out.Raw((in.Options).MarshalJSON())
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v HelloV2TokenClaims) MarshalJSON() ([]byte, error) {
+func (v InternalServerRecordingRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling27(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling30(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v HelloV2TokenClaims) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling27(w, v)
+func (v InternalServerRecordingRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling30(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *HelloV2TokenClaims) UnmarshalJSON(data []byte) error {
+func (v *InternalServerRecordingRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling27(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling30(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *HelloV2TokenClaims) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling27(l, v)
+func (v *InternalServerRecordingRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling30(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling28(in *jlexer.Lexer, out *HelloV2AuthParams) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling31(in *jlexer.Lexer, out *InternalServerMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3262,54 +3653,84 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling28(in *jle
 			continue
 		}
 		switch key {
-		case "token":
-			out.Token = string(in.String())
-		default:
-			in.SkipRecursive()
-		}
-		in.WantComma()
-	}
-	in.Delim('}')
+		case "type":
+			out.Type = string(in.String())
+		case "dialout":
+			if in.IsNull() {
+				in.Skip()
+				out.Dialout = nil
+			} else {
+				if out.Dialout == nil {
+					out.Dialout = new(InternalServerDialoutRequest)
+				}
+				(*out.Dialout).UnmarshalEasyJSON(in)
+			}
+		case "recording":
+			if in.IsNull() {
+				in.Skip()
+				out.Recording = nil
+			} else {
+				if out.Recording == nil {
+					out.Recording = new(InternalServerRecordingRequest)
+				}
+				(*out.Recording).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
 	if isTopLevel {
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling28(out *jwriter.Writer, in HelloV2AuthParams) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling31(out *jwriter.Writer, in InternalServerMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"token\":"
+		const prefix string = ",\"type\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Token))
+		out.String(string(in.Type))
+	}
+	if in.Dialout != nil {
+		const prefix string = ",\"dialout\":"
+		out.RawString(prefix)
+		(*in.Dialout).MarshalEasyJSON(out)
+	}
+	if in.Recording != nil {
+		const prefix string = ",\"recording\":"
+		out.RawString(prefix)
+		(*in.Recording).MarshalEasyJSON(out)
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v HelloV2AuthParams) MarshalJSON() ([]byte, error) {
+func (v InternalServerMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling28(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling31(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v HelloV2AuthParams) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling28(w, v)
+func (v InternalServerMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling31(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *HelloV2AuthParams) UnmarshalJSON(data []byte) error {
+func (v *InternalServerMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling28(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling31(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *HelloV2AuthParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling28(l, v)
+func (v *InternalServerMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling31(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling29(in *jlexer.Lexer, out *HelloServerMessage) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling32(in *jlexer.Lexer, out *InternalServerDialoutRequest) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3328,23 +3749,19 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling29(in *jle
 			continue
 		}
 		switch key {
-		case "version":
-			out.Version = string(in.String())
-		case "sessionid":
-			out.SessionId = PublicSessionId(in.String())
-		case "resumeid":
-			out.ResumeId = PrivateSessionId(in.String())
-		case "userid":
-			out.UserId = string(in.String())
-		case "server":
+		case "roomid":
+			out.RoomId = string(in.String())
+		case "backend":
+			out.Backend = string(in.String())
+		case "request":
 			if in.IsNull() {
 				in.Skip()
-				out.Server = nil
+				out.Request = nil
 			} else {
-				if out.Server == nil {
-					out.Server = new(WelcomeServerMessage)
+				if out.Request == nil {
+					out.Request = new(BackendRoomDialoutRequest)
 				}
-				(*out.Server).UnmarshalEasyJSON(in)
+				(*out.Request).UnmarshalEasyJSON(in)
 			}
 		default:
 			in.SkipRecursive()
@@ -3356,62 +3773,56 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling29(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling29(out *jwriter.Writer, in HelloServerMessage) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling32(out *jwriter.Writer, in InternalServerDialoutRequest) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"version\":"
+		const prefix string = ",\"roomid\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Version))
-	}
-	{
-		const prefix string = ",\"sessionid\":"
-		out.RawString(prefix)
-		out.String(string(in.SessionId))
+		out.String(string(in.RoomId))
 	}
 	{
-		const prefix string = ",\"resumeid\":"
+		const prefix string = ",\"backend\":"
 		out.RawString(prefix)
-		out.String(string(in.ResumeId))
+		out.String(string(in.Backend))
 	}
 	{
-		const prefix string = ",\"userid\":"
-		out.RawString(prefix)
-		out.String(string(in.UserId))
-	}
-	if in.Server != nil {
-		const prefix string = ",\"server\":"
+		const prefix string = ",\"request\":"
 		out.RawString(prefix)
-		(*in.Server).MarshalEasyJSON(out)
+		if in.Request == nil {
+			out.RawString("null")
+		} else {
+			(*in.Request).MarshalEasyJSON(out)
+		}
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v HelloServerMessage) MarshalJSON() ([]byte, error) {
+func (v InternalServerDialoutRequest) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling29(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling32(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v HelloServerMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling29(w, v)
+func (v InternalServerDialoutRequest) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling32(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *HelloServerMessage) UnmarshalJSON(data []byte) error {
+func (v *InternalServerDialoutRequest) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling29(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling32(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *HelloServerMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling29(l, v)
+func (v *InternalServerDialoutRequest) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling32(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling30(in *jlexer.Lexer, out *HelloClientMessageAuth) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling33(in *jlexer.Lexer, out *InternalClientMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3431,13 +3842,107 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling30(in *jle
 		}
 		switch key {
 		case "type":
-			out.Type = ClientType(in.String())
-		case "params":
-			if data := in.Raw(); in.Ok() {
-				in.AddError((out.Params).UnmarshalJSON(data))
+			out.Type = string(in.String())
+		case "addsession":
+			if in.IsNull() {
+				in.Skip()
+				out.AddSession = nil
+			} else {
+				if out.AddSession == nil {
+					out.AddSession = new(AddSessionInternalClientMessage)
+				}
+				(*out.AddSession).UnmarshalEasyJSON(in)
+			}
+		case "updatesession":
+			if in.IsNull() {
+				in.Skip()
+				out.UpdateSession = nil
+			} else {
+				if out.UpdateSession == nil {
+					out.UpdateSession = new(UpdateSessionInternalClientMessage)
+				}
+				(*out.UpdateSession).UnmarshalEasyJSON(in)
+			}
+		case "removesession":
+			if in.IsNull() {
+				in.Skip()
+				out.RemoveSession = nil
+			} else {
+				if out.RemoveSession == nil {
+					out.RemoveSession = new(RemoveSessionInternalClientMessage)
+				}
+				(*out.RemoveSession).UnmarshalEasyJSON(in)
+			}
+		case "addsessions":
+			if in.IsNull() {
+				in.Skip()
+				out.AddSessions = nil
+			} else {
+				if out.AddSessions == nil {
+					out.AddSessions = new(AddSessionsInternalClientMessage)
+				}
+				(*out.AddSessions).UnmarshalEasyJSON(in)
+			}
+		case "removesessions":
+			if in.IsNull() {
+				in.Skip()
+				out.RemoveSessions = nil
+			} else {
+				if out.RemoveSessions == nil {
+					out.RemoveSessions = new(RemoveSessionsInternalClientMessage)
+				}
+				(*out.RemoveSessions).UnmarshalEasyJSON(in)
+			}
+		case "incall":
+			if in.IsNull() {
+				in.Skip()
+				out.InCall = nil
+			} else {
+				if out.InCall == nil {
+					out.InCall = new(InCallInternalClientMessage)
+				}
+				(*out.InCall).UnmarshalEasyJSON(in)
+			}
+		case "dialout":
+			if in.IsNull() {
+				in.Skip()
+				out.Dialout = nil
+			} else {
+				if out.Dialout == nil {
+					out.Dialout = new(DialoutInternalClientMessage)
+				}
+				(*out.Dialout).UnmarshalEasyJSON(in)
+			}
+		case "dtmf":
+			if in.IsNull() {
+				in.Skip()
+				out.Dtmf = nil
+			} else {
+				if out.Dtmf == nil {
+					out.Dtmf = new(DtmfInternalClientMessage)
+				}
+				(*out.Dtmf).UnmarshalEasyJSON(in)
+			}
+		case "recording":
+			if in.IsNull() {
+				in.Skip()
+				out.Recording = nil
+			} else {
+				if out.Recording == nil {
+					out.Recording = new(RecordingInternalClientMessage)
+				}
+				(*out.Recording).UnmarshalEasyJSON(in)
+			}
+		case "transcription":
+			if in.IsNull() {
+				in.Skip()
+				out.Transcription = nil
+			} else {
+				if out.Transcription == nil {
+					out.Transcription = new(TranscriptionInternalClientMessage)
+				}
+				(*out.Transcription).UnmarshalEasyJSON(in)
 			}
-		case "url":
-			out.Url = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -3448,58 +3953,92 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling30(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling30(out *jwriter.Writer, in HelloClientMessageAuth) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling33(out *jwriter.Writer, in InternalClientMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	if in.Type != "" {
+	{
 		const prefix string = ",\"type\":"
-		first = false
 		out.RawString(prefix[1:])
 		out.String(string(in.Type))
 	}
-	{
-		const prefix string = ",\"params\":"
-		if first {
-			first = false
-			out.RawString(prefix[1:])
-		} else {
-			out.RawString(prefix)
-		}
-		out.Raw((in.Params).MarshalJSON())
+	if in.AddSession != nil {
+		const prefix string = ",\"addsession\":"
+		out.RawString(prefix)
+		(*in.AddSession).MarshalEasyJSON(out)
 	}
-	{
-		const prefix string = ",\"url\":"
+	if in.UpdateSession != nil {
+		const prefix string = ",\"updatesession\":"
 		out.RawString(prefix)
-		out.String(string(in.Url))
+		(*in.UpdateSession).MarshalEasyJSON(out)
+	}
+	if in.RemoveSession != nil {
+		const prefix string = ",\"removesession\":"
+		out.RawString(prefix)
+		(*in.RemoveSession).MarshalEasyJSON(out)
+	}
+	if in.AddSessions != nil {
+		const prefix string = ",\"addsessions\":"
+		out.RawString(prefix)
+		(*in.AddSessions).MarshalEasyJSON(out)
+	}
+	if in.RemoveSessions != nil {
+		const prefix string = ",\"removesessions\":"
+		out.RawString(prefix)
+		(*in.RemoveSessions).MarshalEasyJSON(out)
+	}
+	if in.InCall != nil {
+		const prefix string = ",\"incall\":"
+		out.RawString(prefix)
+		(*in.InCall).MarshalEasyJSON(out)
+	}
+	if in.Dialout != nil {
+		const prefix string = ",\"dialout\":"
+		out.RawString(prefix)
+		(*in.Dialout).MarshalEasyJSON(out)
+	}
+	if in.Dtmf != nil {
+		const prefix string = ",\"dtmf\":"
+		out.RawString(prefix)
+		(*in.Dtmf).MarshalEasyJSON(out)
+	}
+	if in.Recording != nil {
+		const prefix string = ",\"recording\":"
+		out.RawString(prefix)
+		(*in.Recording).MarshalEasyJSON(out)
+	}
+	if in.Transcription != nil {
+		const prefix string = ",\"transcription\":"
+		out.RawString(prefix)
+		(*in.Transcription).MarshalEasyJSON(out)
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v HelloClientMessageAuth) MarshalJSON() ([]byte, error) {
+func (v InternalClientMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling30(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling33(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v HelloClientMessageAuth) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling30(w, v)
+func (v InternalClientMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling33(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *HelloClientMessageAuth) UnmarshalJSON(data []byte) error {
+func (v *InternalClientMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling30(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling33(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *HelloClientMessageAuth) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling30(l, v)
+func (v *InternalClientMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling33(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling31(in *jlexer.Lexer, out *HelloClientMessage) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling34(in *jlexer.Lexer, out *InCallInternalClientMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3518,43 +4057,8 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling31(in *jle
 			continue
 		}
 		switch key {
-		case "version":
-			out.Version = string(in.String())
-		case "resumeid":
-			out.ResumeId = PrivateSessionId(in.String())
-		case "features":
-			if in.IsNull() {
-				in.Skip()
-				out.Features = nil
-			} else {
-				in.Delim('[')
-				if out.Features == nil {
-					if !in.IsDelim(']') {
-						out.Features = make([]string, 0, 4)
-					} else {
-						out.Features = []string{}
-					}
-				} else {
-					out.Features = (out.Features)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v30 string
-					v30 = string(in.String())
-					out.Features = append(out.Features, v30)
-					in.WantComma()
-				}
-				in.Delim(']')
-			}
-		case "auth":
-			if in.IsNull() {
-				in.Skip()
-				out.Auth = nil
-			} else {
-				if out.Auth == nil {
-					out.Auth = new(HelloClientMessageAuth)
-				}
-				(*out.Auth).UnmarshalEasyJSON(in)
-			}
+		case "incall":
+			out.InCall = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -3565,66 +4069,109 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling31(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling31(out *jwriter.Writer, in HelloClientMessage) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling34(out *jwriter.Writer, in InCallInternalClientMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"version\":"
+		const prefix string = ",\"incall\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Version))
+		out.Int(int(in.InCall))
 	}
-	{
-		const prefix string = ",\"resumeid\":"
-		out.RawString(prefix)
-		out.String(string(in.ResumeId))
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v InCallInternalClientMessage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling34(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v InCallInternalClientMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling34(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *InCallInternalClientMessage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling34(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *InCallInternalClientMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling34(l, v)
+}
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling35(in *jlexer.Lexer, out *HelloV2TokenConfirmation) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
 	}
-	if len(in.Features) != 0 {
-		const prefix string = ",\"features\":"
-		out.RawString(prefix)
-		{
-			out.RawByte('[')
-			for v31, v32 := range in.Features {
-				if v31 > 0 {
-					out.RawByte(',')
-				}
-				out.String(string(v32))
-			}
-			out.RawByte(']')
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
 		}
+		switch key {
+		case "jkt":
+			out.JwkThumbprint = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
 	}
-	if in.Auth != nil {
-		const prefix string = ",\"auth\":"
-		out.RawString(prefix)
-		(*in.Auth).MarshalEasyJSON(out)
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling35(out *jwriter.Writer, in HelloV2TokenConfirmation) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.JwkThumbprint != "" {
+		const prefix string = ",\"jkt\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.JwkThumbprint))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v HelloClientMessage) MarshalJSON() ([]byte, error) {
+func (v HelloV2TokenConfirmation) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling31(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling35(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v HelloClientMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling31(w, v)
+func (v HelloV2TokenConfirmation) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling35(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *HelloClientMessage) UnmarshalJSON(data []byte) error {
+func (v *HelloV2TokenConfirmation) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling31(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling35(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *HelloClientMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling31(l, v)
+func (v *HelloV2TokenConfirmation) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling35(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling32(in *jlexer.Lexer, out *FederationTokenClaims) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling36(in *jlexer.Lexer, out *HelloV2TokenClaims) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3647,6 +4194,16 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling32(in *jle
 			if data := in.Raw(); in.Ok() {
 				in.AddError((out.UserData).UnmarshalJSON(data))
 			}
+		case "cnf":
+			if in.IsNull() {
+				in.Skip()
+				out.Confirmation = nil
+			} else {
+				if out.Confirmation == nil {
+					out.Confirmation = new(HelloV2TokenConfirmation)
+				}
+				(*out.Confirmation).UnmarshalEasyJSON(in)
+			}
 		case "iss":
 			out.Issuer = string(in.String())
 		case "sub":
@@ -3703,7 +4260,7 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling32(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling32(out *jwriter.Writer, in FederationTokenClaims) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling36(out *jwriter.Writer, in HelloV2TokenClaims) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -3713,6 +4270,16 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling32(out *jw
 		out.RawString(prefix[1:])
 		out.Raw((in.UserData).MarshalJSON())
 	}
+	if in.Confirmation != nil {
+		const prefix string = ",\"cnf\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		(*in.Confirmation).MarshalEasyJSON(out)
+	}
 	if in.Issuer != "" {
 		const prefix string = ",\"iss\":"
 		if first {
@@ -3787,29 +4354,29 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling32(out *jw
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v FederationTokenClaims) MarshalJSON() ([]byte, error) {
+func (v HelloV2TokenClaims) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling32(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling36(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v FederationTokenClaims) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling32(w, v)
+func (v HelloV2TokenClaims) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling36(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *FederationTokenClaims) UnmarshalJSON(data []byte) error {
+func (v *HelloV2TokenClaims) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling32(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling36(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *FederationTokenClaims) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling32(l, v)
+func (v *HelloV2TokenClaims) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling36(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling33(in *jlexer.Lexer, out *FederationAuthParams) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling37(in *jlexer.Lexer, out *HelloV2AuthParams) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3830,6 +4397,8 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling33(in *jle
 		switch key {
 		case "token":
 			out.Token = string(in.String())
+		case "proof":
+			out.Proof = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -3840,7 +4409,7 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling33(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling33(out *jwriter.Writer, in FederationAuthParams) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling37(out *jwriter.Writer, in HelloV2AuthParams) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -3849,108 +4418,38 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling33(out *jw
 		out.RawString(prefix[1:])
 		out.String(string(in.Token))
 	}
-	out.RawByte('}')
-}
-
-// MarshalJSON supports json.Marshaler interface
-func (v FederationAuthParams) MarshalJSON() ([]byte, error) {
-	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling33(&w, v)
-	return w.Buffer.BuildBytes(), w.Error
-}
-
-// MarshalEasyJSON supports easyjson.Marshaler interface
-func (v FederationAuthParams) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling33(w, v)
-}
-
-// UnmarshalJSON supports json.Unmarshaler interface
-func (v *FederationAuthParams) UnmarshalJSON(data []byte) error {
-	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling33(&r, v)
-	return r.Error()
-}
-
-// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *FederationAuthParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling33(l, v)
-}
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling34(in *jlexer.Lexer, out *EventServerMessageSwitchTo) {
-	isTopLevel := in.IsStart()
-	if in.IsNull() {
-		if isTopLevel {
-			in.Consumed()
-		}
-		in.Skip()
-		return
-	}
-	in.Delim('{')
-	for !in.IsDelim('}') {
-		key := in.UnsafeFieldName(false)
-		in.WantColon()
-		if in.IsNull() {
-			in.Skip()
-			in.WantComma()
-			continue
-		}
-		switch key {
-		case "roomid":
-			out.RoomId = string(in.String())
-		case "details":
-			if data := in.Raw(); in.Ok() {
-				in.AddError((out.Details).UnmarshalJSON(data))
-			}
-		default:
-			in.SkipRecursive()
-		}
-		in.WantComma()
-	}
-	in.Delim('}')
-	if isTopLevel {
-		in.Consumed()
-	}
-}
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling34(out *jwriter.Writer, in EventServerMessageSwitchTo) {
-	out.RawByte('{')
-	first := true
-	_ = first
-	{
-		const prefix string = ",\"roomid\":"
-		out.RawString(prefix[1:])
-		out.String(string(in.RoomId))
-	}
-	if len(in.Details) != 0 {
-		const prefix string = ",\"details\":"
+	if in.Proof != "" {
+		const prefix string = ",\"proof\":"
 		out.RawString(prefix)
-		out.Raw((in.Details).MarshalJSON())
+		out.String(string(in.Proof))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v EventServerMessageSwitchTo) MarshalJSON() ([]byte, error) {
+func (v HelloV2AuthParams) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling34(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling37(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v EventServerMessageSwitchTo) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling34(w, v)
+func (v HelloV2AuthParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling37(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *EventServerMessageSwitchTo) UnmarshalJSON(data []byte) error {
+func (v *HelloV2AuthParams) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling34(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling37(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *EventServerMessageSwitchTo) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling34(l, v)
+func (v *HelloV2AuthParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling37(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling35(in *jlexer.Lexer, out *EventServerMessageSessionEntry) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling38(in *jlexer.Lexer, out *HelloServerMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -3969,10 +4468,28 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling35(in *jle
 			continue
 		}
 		switch key {
+		case "version":
+			out.Version = string(in.String())
 		case "sessionid":
 			out.SessionId = PublicSessionId(in.String())
+		case "resumeid":
+			out.ResumeId = PrivateSessionId(in.String())
 		case "userid":
 			out.UserId = string(in.String())
+		case "pinginterval":
+			out.PingInterval = int(in.Int())
+		case "heartbeat":
+			out.Heartbeat = int(in.Int())
+		case "time":
+			if in.IsNull() {
+				in.Skip()
+				out.Time = nil
+			} else {
+				if out.Time == nil {
+					out.Time = new(ServerTimeMessage)
+				}
+				(*out.Time).UnmarshalEasyJSON(in)
+			}
 		case "features":
 			if in.IsNull() {
 				in.Skip()
@@ -3989,21 +4506,23 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling35(in *jle
 					out.Features = (out.Features)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v33 string
-					v33 = string(in.String())
-					out.Features = append(out.Features, v33)
+					var v45 string
+					v45 = string(in.String())
+					out.Features = append(out.Features, v45)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "user":
-			if data := in.Raw(); in.Ok() {
-				in.AddError((out.User).UnmarshalJSON(data))
-			}
-		case "roomsessionid":
-			out.RoomSessionId = RoomSessionId(in.String())
-		case "federated":
-			out.Federated = bool(in.Bool())
+		case "server":
+			if in.IsNull() {
+				in.Skip()
+				out.Server = nil
+			} else {
+				if out.Server == nil {
+					out.Server = new(WelcomeServerMessage)
+				}
+				(*out.Server).UnmarshalEasyJSON(in)
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -4014,76 +4533,182 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling35(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling35(out *jwriter.Writer, in EventServerMessageSessionEntry) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling38(out *jwriter.Writer, in HelloServerMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"sessionid\":"
+		const prefix string = ",\"version\":"
 		out.RawString(prefix[1:])
+		out.String(string(in.Version))
+	}
+	{
+		const prefix string = ",\"sessionid\":"
+		out.RawString(prefix)
 		out.String(string(in.SessionId))
 	}
+	{
+		const prefix string = ",\"resumeid\":"
+		out.RawString(prefix)
+		out.String(string(in.ResumeId))
+	}
 	{
 		const prefix string = ",\"userid\":"
 		out.RawString(prefix)
 		out.String(string(in.UserId))
 	}
+	if in.PingInterval != 0 {
+		const prefix string = ",\"pinginterval\":"
+		out.RawString(prefix)
+		out.Int(int(in.PingInterval))
+	}
+	if in.Heartbeat != 0 {
+		const prefix string = ",\"heartbeat\":"
+		out.RawString(prefix)
+		out.Int(int(in.Heartbeat))
+	}
+	if in.Time != nil {
+		const prefix string = ",\"time\":"
+		out.RawString(prefix)
+		(*in.Time).MarshalEasyJSON(out)
+	}
 	if len(in.Features) != 0 {
 		const prefix string = ",\"features\":"
 		out.RawString(prefix)
 		{
 			out.RawByte('[')
-			for v34, v35 := range in.Features {
-				if v34 > 0 {
+			for v46, v47 := range in.Features {
+				if v46 > 0 {
 					out.RawByte(',')
 				}
-				out.String(string(v35))
+				out.String(string(v47))
 			}
 			out.RawByte(']')
 		}
 	}
-	if len(in.User) != 0 {
-		const prefix string = ",\"user\":"
+	if in.Server != nil {
+		const prefix string = ",\"server\":"
 		out.RawString(prefix)
-		out.Raw((in.User).MarshalJSON())
+		(*in.Server).MarshalEasyJSON(out)
 	}
-	if in.RoomSessionId != "" {
-		const prefix string = ",\"roomsessionid\":"
-		out.RawString(prefix)
-		out.String(string(in.RoomSessionId))
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v HelloServerMessage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling38(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v HelloServerMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling38(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *HelloServerMessage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling38(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *HelloServerMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling38(l, v)
+}
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling39(in *jlexer.Lexer, out *HelloClientMessageHints) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
 	}
-	if in.Federated {
-		const prefix string = ",\"federated\":"
-		out.RawString(prefix)
-		out.Bool(bool(in.Federated))
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "maxdownlink":
+			out.MaxDownlink = int(in.Int())
+		case "batterysaver":
+			out.BatterySaver = bool(in.Bool())
+		case "mobile":
+			out.Mobile = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling39(out *jwriter.Writer, in HelloClientMessageHints) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.MaxDownlink != 0 {
+		const prefix string = ",\"maxdownlink\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.Int(int(in.MaxDownlink))
+	}
+	if in.BatterySaver {
+		const prefix string = ",\"batterysaver\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.BatterySaver))
+	}
+	if in.Mobile {
+		const prefix string = ",\"mobile\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(in.Mobile))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v EventServerMessageSessionEntry) MarshalJSON() ([]byte, error) {
+func (v HelloClientMessageHints) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling35(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling39(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v EventServerMessageSessionEntry) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling35(w, v)
+func (v HelloClientMessageHints) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling39(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *EventServerMessageSessionEntry) UnmarshalJSON(data []byte) error {
+func (v *HelloClientMessageHints) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling35(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling39(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *EventServerMessageSessionEntry) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling35(l, v)
+func (v *HelloClientMessageHints) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling39(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling36(in *jlexer.Lexer, out *EventServerMessage) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling40(in *jlexer.Lexer, out *HelloClientMessageAuth) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -4102,165 +4727,298 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling36(in *jle
 			continue
 		}
 		switch key {
-		case "target":
-			out.Target = string(in.String())
 		case "type":
-			out.Type = string(in.String())
-		case "join":
+			out.Type = ClientType(in.String())
+		case "params":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Params).UnmarshalJSON(data))
+			}
+		case "url":
+			out.Url = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling40(out *jwriter.Writer, in HelloClientMessageAuth) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Type != "" {
+		const prefix string = ",\"type\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"params\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((in.Params).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"url\":"
+		out.RawString(prefix)
+		out.String(string(in.Url))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v HelloClientMessageAuth) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling40(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v HelloClientMessageAuth) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling40(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *HelloClientMessageAuth) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling40(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *HelloClientMessageAuth) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling40(l, v)
+}
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling41(in *jlexer.Lexer, out *HelloClientMessage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "version":
+			out.Version = string(in.String())
+		case "resumeid":
+			out.ResumeId = PrivateSessionId(in.String())
+		case "features":
 			if in.IsNull() {
 				in.Skip()
-				out.Join = nil
+				out.Features = nil
 			} else {
 				in.Delim('[')
-				if out.Join == nil {
+				if out.Features == nil {
 					if !in.IsDelim(']') {
-						out.Join = make([]*EventServerMessageSessionEntry, 0, 8)
+						out.Features = make([]string, 0, 4)
 					} else {
-						out.Join = []*EventServerMessageSessionEntry{}
+						out.Features = []string{}
 					}
 				} else {
-					out.Join = (out.Join)[:0]
+					out.Features = (out.Features)[:0]
 				}
 				for !in.IsDelim(']') {
-					var v36 *EventServerMessageSessionEntry
-					if in.IsNull() {
-						in.Skip()
-						v36 = nil
-					} else {
-						if v36 == nil {
-							v36 = new(EventServerMessageSessionEntry)
-						}
-						(*v36).UnmarshalEasyJSON(in)
-					}
-					out.Join = append(out.Join, v36)
+					var v48 string
+					v48 = string(in.String())
+					out.Features = append(out.Features, v48)
 					in.WantComma()
 				}
 				in.Delim(']')
 			}
-		case "leave":
+		case "pinginterval":
+			out.PingInterval = int(in.Int())
+		case "hints":
 			if in.IsNull() {
 				in.Skip()
-				out.Leave = nil
+				out.Hints = nil
 			} else {
-				in.Delim('[')
-				if out.Leave == nil {
-					if !in.IsDelim(']') {
-						out.Leave = make([]PublicSessionId, 0, 4)
-					} else {
-						out.Leave = []PublicSessionId{}
-					}
-				} else {
-					out.Leave = (out.Leave)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v37 PublicSessionId
-					v37 = PublicSessionId(in.String())
-					out.Leave = append(out.Leave, v37)
-					in.WantComma()
+				if out.Hints == nil {
+					out.Hints = new(HelloClientMessageHints)
 				}
-				in.Delim(']')
+				(*out.Hints).UnmarshalEasyJSON(in)
 			}
-		case "change":
+		case "language":
+			out.Language = string(in.String())
+		case "auth":
 			if in.IsNull() {
 				in.Skip()
-				out.Change = nil
+				out.Auth = nil
 			} else {
-				in.Delim('[')
-				if out.Change == nil {
-					if !in.IsDelim(']') {
-						out.Change = make([]*EventServerMessageSessionEntry, 0, 8)
-					} else {
-						out.Change = []*EventServerMessageSessionEntry{}
-					}
-				} else {
-					out.Change = (out.Change)[:0]
-				}
-				for !in.IsDelim(']') {
-					var v38 *EventServerMessageSessionEntry
-					if in.IsNull() {
-						in.Skip()
-						v38 = nil
-					} else {
-						if v38 == nil {
-							v38 = new(EventServerMessageSessionEntry)
-						}
-						(*v38).UnmarshalEasyJSON(in)
-					}
-					out.Change = append(out.Change, v38)
-					in.WantComma()
+				if out.Auth == nil {
+					out.Auth = new(HelloClientMessageAuth)
 				}
-				in.Delim(']')
+				(*out.Auth).UnmarshalEasyJSON(in)
 			}
-		case "switchto":
-			if in.IsNull() {
-				in.Skip()
-				out.SwitchTo = nil
-			} else {
-				if out.SwitchTo == nil {
-					out.SwitchTo = new(EventServerMessageSwitchTo)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling41(out *jwriter.Writer, in HelloClientMessage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"version\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Version))
+	}
+	{
+		const prefix string = ",\"resumeid\":"
+		out.RawString(prefix)
+		out.String(string(in.ResumeId))
+	}
+	if len(in.Features) != 0 {
+		const prefix string = ",\"features\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v49, v50 := range in.Features {
+				if v49 > 0 {
+					out.RawByte(',')
 				}
-				(*out.SwitchTo).UnmarshalEasyJSON(in)
+				out.String(string(v50))
 			}
-		case "resumed":
-			if in.IsNull() {
-				in.Skip()
-				out.Resumed = nil
-			} else {
-				if out.Resumed == nil {
-					out.Resumed = new(bool)
-				}
-				*out.Resumed = bool(in.Bool())
+			out.RawByte(']')
+		}
+	}
+	if in.PingInterval != 0 {
+		const prefix string = ",\"pinginterval\":"
+		out.RawString(prefix)
+		out.Int(int(in.PingInterval))
+	}
+	if in.Hints != nil {
+		const prefix string = ",\"hints\":"
+		out.RawString(prefix)
+		(*in.Hints).MarshalEasyJSON(out)
+	}
+	if in.Language != "" {
+		const prefix string = ",\"language\":"
+		out.RawString(prefix)
+		out.String(string(in.Language))
+	}
+	if in.Auth != nil {
+		const prefix string = ",\"auth\":"
+		out.RawString(prefix)
+		(*in.Auth).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v HelloClientMessage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling41(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v HelloClientMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling41(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *HelloClientMessage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling41(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *HelloClientMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling41(l, v)
+}
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling42(in *jlexer.Lexer, out *FederationTokenClaims) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "userdata":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.UserData).UnmarshalJSON(data))
 			}
-		case "invite":
-			if in.IsNull() {
-				in.Skip()
-				out.Invite = nil
-			} else {
-				if out.Invite == nil {
-					out.Invite = new(RoomEventServerMessage)
-				}
-				(*out.Invite).UnmarshalEasyJSON(in)
+		case "iss":
+			out.Issuer = string(in.String())
+		case "sub":
+			out.Subject = string(in.String())
+		case "aud":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Audience).UnmarshalJSON(data))
 			}
-		case "disinvite":
+		case "exp":
 			if in.IsNull() {
 				in.Skip()
-				out.Disinvite = nil
+				out.ExpiresAt = nil
 			} else {
-				if out.Disinvite == nil {
-					out.Disinvite = new(RoomDisinviteEventServerMessage)
+				if out.ExpiresAt == nil {
+					out.ExpiresAt = new(_v5.NumericDate)
 				}
-				(*out.Disinvite).UnmarshalEasyJSON(in)
-			}
-		case "update":
-			if in.IsNull() {
-				in.Skip()
-				out.Update = nil
-			} else {
-				if out.Update == nil {
-					out.Update = new(RoomEventServerMessage)
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.ExpiresAt).UnmarshalJSON(data))
 				}
-				(*out.Update).UnmarshalEasyJSON(in)
 			}
-		case "flags":
+		case "nbf":
 			if in.IsNull() {
 				in.Skip()
-				out.Flags = nil
+				out.NotBefore = nil
 			} else {
-				if out.Flags == nil {
-					out.Flags = new(RoomFlagsServerMessage)
+				if out.NotBefore == nil {
+					out.NotBefore = new(_v5.NumericDate)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.NotBefore).UnmarshalJSON(data))
 				}
-				(*out.Flags).UnmarshalEasyJSON(in)
 			}
-		case "message":
+		case "iat":
 			if in.IsNull() {
 				in.Skip()
-				out.Message = nil
+				out.IssuedAt = nil
 			} else {
-				if out.Message == nil {
-					out.Message = new(RoomEventMessage)
+				if out.IssuedAt == nil {
+					out.IssuedAt = new(_v5.NumericDate)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.IssuedAt).UnmarshalJSON(data))
 				}
-				(*out.Message).UnmarshalEasyJSON(in)
 			}
+		case "jti":
+			out.ID = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -4271,132 +5029,2234 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling36(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling36(out *jwriter.Writer, in EventServerMessage) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling42(out *jwriter.Writer, in FederationTokenClaims) {
 	out.RawByte('{')
 	first := true
 	_ = first
-	{
-		const prefix string = ",\"target\":"
+	if len(in.UserData) != 0 {
+		const prefix string = ",\"userdata\":"
+		first = false
 		out.RawString(prefix[1:])
-		out.String(string(in.Target))
+		out.Raw((in.UserData).MarshalJSON())
 	}
-	{
-		const prefix string = ",\"type\":"
-		out.RawString(prefix)
-		out.String(string(in.Type))
+	if in.Issuer != "" {
+		const prefix string = ",\"iss\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.Issuer))
 	}
-	if len(in.Join) != 0 {
-		const prefix string = ",\"join\":"
-		out.RawString(prefix)
-		{
-			out.RawByte('[')
-			for v39, v40 := range in.Join {
-				if v39 > 0 {
-					out.RawByte(',')
-				}
-				if v40 == nil {
-					out.RawString("null")
-				} else {
-					(*v40).MarshalEasyJSON(out)
-				}
-			}
-			out.RawByte(']')
+	if in.Subject != "" {
+		const prefix string = ",\"sub\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
 		}
+		out.String(string(in.Subject))
 	}
-	if len(in.Leave) != 0 {
-		const prefix string = ",\"leave\":"
-		out.RawString(prefix)
-		{
-			out.RawByte('[')
-			for v41, v42 := range in.Leave {
-				if v41 > 0 {
-					out.RawByte(',')
-				}
-				out.String(string(v42))
-			}
-			out.RawByte(']')
+	if len(in.Audience) != 0 {
+		const prefix string = ",\"aud\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
 		}
+		out.Raw((in.Audience).MarshalJSON())
 	}
-	if len(in.Change) != 0 {
-		const prefix string = ",\"change\":"
-		out.RawString(prefix)
-		{
+	if in.ExpiresAt != nil {
+		const prefix string = ",\"exp\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((*in.ExpiresAt).MarshalJSON())
+	}
+	if in.NotBefore != nil {
+		const prefix string = ",\"nbf\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((*in.NotBefore).MarshalJSON())
+	}
+	if in.IssuedAt != nil {
+		const prefix string = ",\"iat\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Raw((*in.IssuedAt).MarshalJSON())
+	}
+	if in.ID != "" {
+		const prefix string = ",\"jti\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.String(string(in.ID))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FederationTokenClaims) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling42(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FederationTokenClaims) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling42(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FederationTokenClaims) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling42(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FederationTokenClaims) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling42(l, v)
+}
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling43(in *jlexer.Lexer, out *FederationAuthParams) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "token":
+			out.Token = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling43(out *jwriter.Writer, in FederationAuthParams) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"token\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Token))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v FederationAuthParams) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling43(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v FederationAuthParams) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling43(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *FederationAuthParams) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling43(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *FederationAuthParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling43(l, v)
+}
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling44(in *jlexer.Lexer, out *EventServerMessageSwitchTo) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "roomid":
+			out.RoomId = string(in.String())
+		case "details":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Details).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling44(out *jwriter.Writer, in EventServerMessageSwitchTo) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"roomid\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.RoomId))
+	}
+	if len(in.Details) != 0 {
+		const prefix string = ",\"details\":"
+		out.RawString(prefix)
+		out.Raw((in.Details).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v EventServerMessageSwitchTo) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling44(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v EventServerMessageSwitchTo) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling44(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *EventServerMessageSwitchTo) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling44(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *EventServerMessageSwitchTo) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling44(l, v)
+}
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling45(in *jlexer.Lexer, out *EventServerMessageSessionEntry) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "sessionid":
+			out.SessionId = PublicSessionId(in.String())
+		case "userid":
+			out.UserId = string(in.String())
+		case "features":
+			if in.IsNull() {
+				in.Skip()
+				out.Features = nil
+			} else {
+				in.Delim('[')
+				if out.Features == nil {
+					if !in.IsDelim(']') {
+						out.Features = make([]string, 0, 4)
+					} else {
+						out.Features = []string{}
+					}
+				} else {
+					out.Features = (out.Features)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v51 string
+					v51 = string(in.String())
+					out.Features = append(out.Features, v51)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "user":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.User).UnmarshalJSON(data))
+			}
+		case "roomsessionid":
+			out.RoomSessionId = RoomSessionId(in.String())
+		case "federated":
+			out.Federated = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling45(out *jwriter.Writer, in EventServerMessageSessionEntry) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"sessionid\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.SessionId))
+	}
+	{
+		const prefix string = ",\"userid\":"
+		out.RawString(prefix)
+		out.String(string(in.UserId))
+	}
+	if len(in.Features) != 0 {
+		const prefix string = ",\"features\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v52, v53 := range in.Features {
+				if v52 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v53))
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.User) != 0 {
+		const prefix string = ",\"user\":"
+		out.RawString(prefix)
+		out.Raw((in.User).MarshalJSON())
+	}
+	if in.RoomSessionId != "" {
+		const prefix string = ",\"roomsessionid\":"
+		out.RawString(prefix)
+		out.String(string(in.RoomSessionId))
+	}
+	if in.Federated {
+		const prefix string = ",\"federated\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Federated))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v EventServerMessageSessionEntry) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling45(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v EventServerMessageSessionEntry) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling45(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *EventServerMessageSessionEntry) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling45(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *EventServerMessageSessionEntry) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling45(l, v)
+}
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling46(in *jlexer.Lexer, out *EventServerMessageResync) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "reason":
+			out.Reason = string(in.String())
+		case "stale":
+			if in.IsNull() {
+				in.Skip()
+				out.Stale = nil
+			} else {
+				in.Delim('[')
+				if out.Stale == nil {
+					if !in.IsDelim(']') {
+						out.Stale = make([]string, 0, 4)
+					} else {
+						out.Stale = []string{}
+					}
+				} else {
+					out.Stale = (out.Stale)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v54 string
+					v54 = string(in.String())
+					out.Stale = append(out.Stale, v54)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling46(out *jwriter.Writer, in EventServerMessageResync) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Reason))
+	}
+	{
+		const prefix string = ",\"stale\":"
+		out.RawString(prefix)
+		if in.Stale == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v55, v56 := range in.Stale {
+				if v55 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v56))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v EventServerMessageResync) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling46(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v EventServerMessageResync) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling46(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *EventServerMessageResync) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling46(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *EventServerMessageResync) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling46(l, v)
+}
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling47(in *jlexer.Lexer, out *EventServerMessageRecording) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "roomid":
+			out.RoomId = string(in.String())
+		case "status":
+			out.Status = RecordingStatus(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling47(out *jwriter.Writer, in EventServerMessageRecording) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"roomid\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.RoomId))
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v EventServerMessageRecording) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling47(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v EventServerMessageRecording) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling47(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *EventServerMessageRecording) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling47(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *EventServerMessageRecording) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling47(l, v)
+}
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling48(in *jlexer.Lexer, out *EventServerMessageReceipt) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "type":
+			out.Type = string(in.String())
+		case "recipient":
+			if in.IsNull() {
+				in.Skip()
+				out.Recipient = nil
+			} else {
+				if out.Recipient == nil {
+					out.Recipient = new(MessageClientMessageRecipient)
+				}
+				(*out.Recipient).UnmarshalEasyJSON(in)
+			}
+		case "status":
+			out.Status = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling48(out *jwriter.Writer, in EventServerMessageReceipt) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"recipient\":"
+		out.RawString(prefix)
+		if in.Recipient == nil {
+			out.RawString("null")
+		} else {
+			(*in.Recipient).MarshalEasyJSON(out)
+		}
+	}
+	{
+		const prefix string = ",\"status\":"
+		out.RawString(prefix)
+		out.String(string(in.Status))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v EventServerMessageReceipt) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling48(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v EventServerMessageReceipt) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling48(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *EventServerMessageReceipt) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling48(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *EventServerMessageReceipt) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling48(l, v)
+}
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling49(in *jlexer.Lexer, out *EventServerMessagePermissions) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "permissions":
+			if in.IsNull() {
+				in.Skip()
+				out.Permissions = nil
+			} else {
+				in.Delim('[')
+				if out.Permissions == nil {
+					if !in.IsDelim(']') {
+						out.Permissions = make([]Permission, 0, 4)
+					} else {
+						out.Permissions = []Permission{}
+					}
+				} else {
+					out.Permissions = (out.Permissions)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v57 Permission
+					v57 = Permission(in.String())
+					out.Permissions = append(out.Permissions, v57)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "added":
+			if in.IsNull() {
+				in.Skip()
+				out.Added = nil
+			} else {
+				in.Delim('[')
+				if out.Added == nil {
+					if !in.IsDelim(']') {
+						out.Added = make([]Permission, 0, 4)
+					} else {
+						out.Added = []Permission{}
+					}
+				} else {
+					out.Added = (out.Added)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v58 Permission
+					v58 = Permission(in.String())
+					out.Added = append(out.Added, v58)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "removed":
+			if in.IsNull() {
+				in.Skip()
+				out.Removed = nil
+			} else {
+				in.Delim('[')
+				if out.Removed == nil {
+					if !in.IsDelim(']') {
+						out.Removed = make([]Permission, 0, 4)
+					} else {
+						out.Removed = []Permission{}
+					}
+				} else {
+					out.Removed = (out.Removed)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v59 Permission
+					v59 = Permission(in.String())
+					out.Removed = append(out.Removed, v59)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "renegotiate":
+			if in.IsNull() {
+				in.Skip()
+				out.Renegotiate = nil
+			} else {
+				in.Delim('[')
+				if out.Renegotiate == nil {
+					if !in.IsDelim(']') {
+						out.Renegotiate = make([]StreamType, 0, 4)
+					} else {
+						out.Renegotiate = []StreamType{}
+					}
+				} else {
+					out.Renegotiate = (out.Renegotiate)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v60 StreamType
+					v60 = StreamType(in.String())
+					out.Renegotiate = append(out.Renegotiate, v60)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling49(out *jwriter.Writer, in EventServerMessagePermissions) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"permissions\":"
+		out.RawString(prefix[1:])
+		if in.Permissions == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v61, v62 := range in.Permissions {
+				if v61 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v62))
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Added) != 0 {
+		const prefix string = ",\"added\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v63, v64 := range in.Added {
+				if v63 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v64))
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Removed) != 0 {
+		const prefix string = ",\"removed\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v65, v66 := range in.Removed {
+				if v65 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v66))
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Renegotiate) != 0 {
+		const prefix string = ",\"renegotiate\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v67, v68 := range in.Renegotiate {
+				if v67 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v68))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v EventServerMessagePermissions) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling49(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v EventServerMessagePermissions) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling49(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *EventServerMessagePermissions) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling49(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *EventServerMessagePermissions) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling49(l, v)
+}
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling50(in *jlexer.Lexer, out *EventServerMessageLagging) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "pending":
+			out.Pending = int(in.Int())
+		case "since":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Since).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling50(out *jwriter.Writer, in EventServerMessageLagging) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"pending\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Pending))
+	}
+	{
+		const prefix string = ",\"since\":"
+		out.RawString(prefix)
+		out.Raw((in.Since).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v EventServerMessageLagging) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling50(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v EventServerMessageLagging) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling50(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *EventServerMessageLagging) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling50(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *EventServerMessageLagging) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling50(l, v)
+}
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling51(in *jlexer.Lexer, out *EventServerMessageDtmf) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "roomid":
+			out.RoomId = string(in.String())
+		case "sessionid":
+			out.SessionId = PublicSessionId(in.String())
+		case "digits":
+			out.Digits = string(in.String())
+		case "sender":
+			out.Sender = PublicSessionId(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling51(out *jwriter.Writer, in EventServerMessageDtmf) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"roomid\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.RoomId))
+	}
+	{
+		const prefix string = ",\"sessionid\":"
+		out.RawString(prefix)
+		out.String(string(in.SessionId))
+	}
+	{
+		const prefix string = ",\"digits\":"
+		out.RawString(prefix)
+		out.String(string(in.Digits))
+	}
+	if in.Sender != "" {
+		const prefix string = ",\"sender\":"
+		out.RawString(prefix)
+		out.String(string(in.Sender))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v EventServerMessageDtmf) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling51(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v EventServerMessageDtmf) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling51(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *EventServerMessageDtmf) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling51(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *EventServerMessageDtmf) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling51(l, v)
+}
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling52(in *jlexer.Lexer, out *EventServerMessageDegraded) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "roomid":
+			out.RoomId = string(in.String())
+		case "sessionids":
+			if in.IsNull() {
+				in.Skip()
+				out.SessionIds = nil
+			} else {
+				in.Delim('[')
+				if out.SessionIds == nil {
+					if !in.IsDelim(']') {
+						out.SessionIds = make([]PublicSessionId, 0, 4)
+					} else {
+						out.SessionIds = []PublicSessionId{}
+					}
+				} else {
+					out.SessionIds = (out.SessionIds)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v69 PublicSessionId
+					v69 = PublicSessionId(in.String())
+					out.SessionIds = append(out.SessionIds, v69)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "degraded":
+			out.Degraded = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling52(out *jwriter.Writer, in EventServerMessageDegraded) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"roomid\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.RoomId))
+	}
+	{
+		const prefix string = ",\"sessionids\":"
+		out.RawString(prefix)
+		if in.SessionIds == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v70, v71 := range in.SessionIds {
+				if v70 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v71))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"degraded\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Degraded))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v EventServerMessageDegraded) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling52(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v EventServerMessageDegraded) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling52(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *EventServerMessageDegraded) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling52(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *EventServerMessageDegraded) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling52(l, v)
+}
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling53(in *jlexer.Lexer, out *EventServerMessageChunk) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "sequence":
+			out.Sequence = int(in.Int())
+		case "more":
+			out.More = bool(in.Bool())
+		case "total":
+			out.Total = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling53(out *jwriter.Writer, in EventServerMessageChunk) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"sequence\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Sequence))
+	}
+	{
+		const prefix string = ",\"more\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.More))
+	}
+	{
+		const prefix string = ",\"total\":"
+		out.RawString(prefix)
+		out.Int(int(in.Total))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v EventServerMessageChunk) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling53(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v EventServerMessageChunk) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling53(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *EventServerMessageChunk) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling53(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *EventServerMessageChunk) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling53(l, v)
+}
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling54(in *jlexer.Lexer, out *EventServerMessageCaption) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "roomid":
+			out.RoomId = string(in.String())
+		case "sessionid":
+			out.SessionId = PublicSessionId(in.String())
+		case "userid":
+			out.UserId = string(in.String())
+		case "text":
+			out.Text = string(in.String())
+		case "language":
+			out.Language = string(in.String())
+		case "final":
+			out.Final = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling54(out *jwriter.Writer, in EventServerMessageCaption) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"roomid\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.RoomId))
+	}
+	{
+		const prefix string = ",\"sessionid\":"
+		out.RawString(prefix)
+		out.String(string(in.SessionId))
+	}
+	if in.UserId != "" {
+		const prefix string = ",\"userid\":"
+		out.RawString(prefix)
+		out.String(string(in.UserId))
+	}
+	{
+		const prefix string = ",\"text\":"
+		out.RawString(prefix)
+		out.String(string(in.Text))
+	}
+	if in.Language != "" {
+		const prefix string = ",\"language\":"
+		out.RawString(prefix)
+		out.String(string(in.Language))
+	}
+	if in.Final {
+		const prefix string = ",\"final\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Final))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v EventServerMessageCaption) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling54(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v EventServerMessageCaption) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling54(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *EventServerMessageCaption) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling54(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *EventServerMessageCaption) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling54(l, v)
+}
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling55(in *jlexer.Lexer, out *EventServerMessage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "target":
+			out.Target = string(in.String())
+		case "type":
+			out.Type = string(in.String())
+		case "roomid":
+			out.RoomId = string(in.String())
+		case "join":
+			if in.IsNull() {
+				in.Skip()
+				out.Join = nil
+			} else {
+				in.Delim('[')
+				if out.Join == nil {
+					if !in.IsDelim(']') {
+						out.Join = make([]*EventServerMessageSessionEntry, 0, 8)
+					} else {
+						out.Join = []*EventServerMessageSessionEntry{}
+					}
+				} else {
+					out.Join = (out.Join)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v72 *EventServerMessageSessionEntry
+					if in.IsNull() {
+						in.Skip()
+						v72 = nil
+					} else {
+						if v72 == nil {
+							v72 = new(EventServerMessageSessionEntry)
+						}
+						(*v72).UnmarshalEasyJSON(in)
+					}
+					out.Join = append(out.Join, v72)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "leave":
+			if in.IsNull() {
+				in.Skip()
+				out.Leave = nil
+			} else {
+				in.Delim('[')
+				if out.Leave == nil {
+					if !in.IsDelim(']') {
+						out.Leave = make([]PublicSessionId, 0, 4)
+					} else {
+						out.Leave = []PublicSessionId{}
+					}
+				} else {
+					out.Leave = (out.Leave)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v73 PublicSessionId
+					v73 = PublicSessionId(in.String())
+					out.Leave = append(out.Leave, v73)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "change":
+			if in.IsNull() {
+				in.Skip()
+				out.Change = nil
+			} else {
+				in.Delim('[')
+				if out.Change == nil {
+					if !in.IsDelim(']') {
+						out.Change = make([]*EventServerMessageSessionEntry, 0, 8)
+					} else {
+						out.Change = []*EventServerMessageSessionEntry{}
+					}
+				} else {
+					out.Change = (out.Change)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v74 *EventServerMessageSessionEntry
+					if in.IsNull() {
+						in.Skip()
+						v74 = nil
+					} else {
+						if v74 == nil {
+							v74 = new(EventServerMessageSessionEntry)
+						}
+						(*v74).UnmarshalEasyJSON(in)
+					}
+					out.Change = append(out.Change, v74)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "switchto":
+			if in.IsNull() {
+				in.Skip()
+				out.SwitchTo = nil
+			} else {
+				if out.SwitchTo == nil {
+					out.SwitchTo = new(EventServerMessageSwitchTo)
+				}
+				(*out.SwitchTo).UnmarshalEasyJSON(in)
+			}
+		case "dtmf":
+			if in.IsNull() {
+				in.Skip()
+				out.Dtmf = nil
+			} else {
+				if out.Dtmf == nil {
+					out.Dtmf = new(EventServerMessageDtmf)
+				}
+				(*out.Dtmf).UnmarshalEasyJSON(in)
+			}
+		case "recording":
+			if in.IsNull() {
+				in.Skip()
+				out.Recording = nil
+			} else {
+				if out.Recording == nil {
+					out.Recording = new(EventServerMessageRecording)
+				}
+				(*out.Recording).UnmarshalEasyJSON(in)
+			}
+		case "caption":
+			if in.IsNull() {
+				in.Skip()
+				out.Caption = nil
+			} else {
+				if out.Caption == nil {
+					out.Caption = new(EventServerMessageCaption)
+				}
+				(*out.Caption).UnmarshalEasyJSON(in)
+			}
+		case "degraded":
+			if in.IsNull() {
+				in.Skip()
+				out.Degraded = nil
+			} else {
+				if out.Degraded == nil {
+					out.Degraded = new(EventServerMessageDegraded)
+				}
+				(*out.Degraded).UnmarshalEasyJSON(in)
+			}
+		case "resumed":
+			if in.IsNull() {
+				in.Skip()
+				out.Resumed = nil
+			} else {
+				if out.Resumed == nil {
+					out.Resumed = new(bool)
+				}
+				*out.Resumed = bool(in.Bool())
+			}
+		case "chunk":
+			if in.IsNull() {
+				in.Skip()
+				out.Chunk = nil
+			} else {
+				if out.Chunk == nil {
+					out.Chunk = new(EventServerMessageChunk)
+				}
+				(*out.Chunk).UnmarshalEasyJSON(in)
+			}
+		case "invite":
+			if in.IsNull() {
+				in.Skip()
+				out.Invite = nil
+			} else {
+				if out.Invite == nil {
+					out.Invite = new(RoomEventServerMessage)
+				}
+				(*out.Invite).UnmarshalEasyJSON(in)
+			}
+		case "disinvite":
+			if in.IsNull() {
+				in.Skip()
+				out.Disinvite = nil
+			} else {
+				if out.Disinvite == nil {
+					out.Disinvite = new(RoomDisinviteEventServerMessage)
+				}
+				(*out.Disinvite).UnmarshalEasyJSON(in)
+			}
+		case "update":
+			if in.IsNull() {
+				in.Skip()
+				out.Update = nil
+			} else {
+				if out.Update == nil {
+					out.Update = new(RoomEventServerMessage)
+				}
+				(*out.Update).UnmarshalEasyJSON(in)
+			}
+		case "flags":
+			if in.IsNull() {
+				in.Skip()
+				out.Flags = nil
+			} else {
+				if out.Flags == nil {
+					out.Flags = new(RoomFlagsServerMessage)
+				}
+				(*out.Flags).UnmarshalEasyJSON(in)
+			}
+		case "message":
+			if in.IsNull() {
+				in.Skip()
+				out.Message = nil
+			} else {
+				if out.Message == nil {
+					out.Message = new(RoomEventMessage)
+				}
+				(*out.Message).UnmarshalEasyJSON(in)
+			}
+		case "lagging":
+			if in.IsNull() {
+				in.Skip()
+				out.Lagging = nil
+			} else {
+				if out.Lagging == nil {
+					out.Lagging = new(EventServerMessageLagging)
+				}
+				(*out.Lagging).UnmarshalEasyJSON(in)
+			}
+		case "permissions":
+			if in.IsNull() {
+				in.Skip()
+				out.Permissions = nil
+			} else {
+				if out.Permissions == nil {
+					out.Permissions = new(EventServerMessagePermissions)
+				}
+				(*out.Permissions).UnmarshalEasyJSON(in)
+			}
+		case "resync":
+			if in.IsNull() {
+				in.Skip()
+				out.Resync = nil
+			} else {
+				if out.Resync == nil {
+					out.Resync = new(EventServerMessageResync)
+				}
+				(*out.Resync).UnmarshalEasyJSON(in)
+			}
+		case "receipt":
+			if in.IsNull() {
+				in.Skip()
+				out.Receipt = nil
+			} else {
+				if out.Receipt == nil {
+					out.Receipt = new(EventServerMessageReceipt)
+				}
+				(*out.Receipt).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling55(out *jwriter.Writer, in EventServerMessage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"target\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Target))
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	if in.RoomId != "" {
+		const prefix string = ",\"roomid\":"
+		out.RawString(prefix)
+		out.String(string(in.RoomId))
+	}
+	if len(in.Join) != 0 {
+		const prefix string = ",\"join\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v75, v76 := range in.Join {
+				if v75 > 0 {
+					out.RawByte(',')
+				}
+				if v76 == nil {
+					out.RawString("null")
+				} else {
+					(*v76).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Leave) != 0 {
+		const prefix string = ",\"leave\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v77, v78 := range in.Leave {
+				if v77 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v78))
+			}
+			out.RawByte(']')
+		}
+	}
+	if len(in.Change) != 0 {
+		const prefix string = ",\"change\":"
+		out.RawString(prefix)
+		{
 			out.RawByte('[')
-			for v43, v44 := range in.Change {
-				if v43 > 0 {
+			for v79, v80 := range in.Change {
+				if v79 > 0 {
 					out.RawByte(',')
 				}
-				if v44 == nil {
+				if v80 == nil {
 					out.RawString("null")
 				} else {
-					(*v44).MarshalEasyJSON(out)
+					(*v80).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.SwitchTo != nil {
+		const prefix string = ",\"switchto\":"
+		out.RawString(prefix)
+		(*in.SwitchTo).MarshalEasyJSON(out)
+	}
+	if in.Dtmf != nil {
+		const prefix string = ",\"dtmf\":"
+		out.RawString(prefix)
+		(*in.Dtmf).MarshalEasyJSON(out)
+	}
+	if in.Recording != nil {
+		const prefix string = ",\"recording\":"
+		out.RawString(prefix)
+		(*in.Recording).MarshalEasyJSON(out)
+	}
+	if in.Caption != nil {
+		const prefix string = ",\"caption\":"
+		out.RawString(prefix)
+		(*in.Caption).MarshalEasyJSON(out)
+	}
+	if in.Degraded != nil {
+		const prefix string = ",\"degraded\":"
+		out.RawString(prefix)
+		(*in.Degraded).MarshalEasyJSON(out)
+	}
+	if in.Resumed != nil {
+		const prefix string = ",\"resumed\":"
+		out.RawString(prefix)
+		out.Bool(bool(*in.Resumed))
+	}
+	if in.Chunk != nil {
+		const prefix string = ",\"chunk\":"
+		out.RawString(prefix)
+		(*in.Chunk).MarshalEasyJSON(out)
+	}
+	if in.Invite != nil {
+		const prefix string = ",\"invite\":"
+		out.RawString(prefix)
+		(*in.Invite).MarshalEasyJSON(out)
+	}
+	if in.Disinvite != nil {
+		const prefix string = ",\"disinvite\":"
+		out.RawString(prefix)
+		(*in.Disinvite).MarshalEasyJSON(out)
+	}
+	if in.Update != nil {
+		const prefix string = ",\"update\":"
+		out.RawString(prefix)
+		(*in.Update).MarshalEasyJSON(out)
+	}
+	if in.Flags != nil {
+		const prefix string = ",\"flags\":"
+		out.RawString(prefix)
+		(*in.Flags).MarshalEasyJSON(out)
+	}
+	if in.Message != nil {
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		(*in.Message).MarshalEasyJSON(out)
+	}
+	if in.Lagging != nil {
+		const prefix string = ",\"lagging\":"
+		out.RawString(prefix)
+		(*in.Lagging).MarshalEasyJSON(out)
+	}
+	if in.Permissions != nil {
+		const prefix string = ",\"permissions\":"
+		out.RawString(prefix)
+		(*in.Permissions).MarshalEasyJSON(out)
+	}
+	if in.Resync != nil {
+		const prefix string = ",\"resync\":"
+		out.RawString(prefix)
+		(*in.Resync).MarshalEasyJSON(out)
+	}
+	if in.Receipt != nil {
+		const prefix string = ",\"receipt\":"
+		out.RawString(prefix)
+		(*in.Receipt).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v EventServerMessage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling55(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v EventServerMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling55(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *EventServerMessage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling55(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *EventServerMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling55(l, v)
+}
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling56(in *jlexer.Lexer, out *Error) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "code":
+			out.Code = string(in.String())
+		case "message":
+			out.Message = string(in.String())
+		case "params":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Params = make(StringMap)
+				} else {
+					out.Params = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v81 interface{}
+					if m, ok := v81.(easyjson.Unmarshaler); ok {
+						m.UnmarshalEasyJSON(in)
+					} else if m, ok := v81.(json.Unmarshaler); ok {
+						_ = m.UnmarshalJSON(in.Raw())
+					} else {
+						v81 = in.Interface()
+					}
+					(out.Params)[key] = v81
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
+		case "localized":
+			out.Localized = string(in.String())
+		case "details":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Details).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling56(out *jwriter.Writer, in Error) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"code\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Code))
+	}
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	if len(in.Params) != 0 {
+		const prefix string = ",\"params\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('{')
+			v82First := true
+			for v82Name, v82Value := range in.Params {
+				if v82First {
+					v82First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v82Name))
+				out.RawByte(':')
+				if m, ok := v82Value.(easyjson.Marshaler); ok {
+					m.MarshalEasyJSON(out)
+				} else if m, ok := v82Value.(json.Marshaler); ok {
+					out.Raw(m.MarshalJSON())
+				} else {
+					out.Raw(json.Marshal(v82Value))
 				}
 			}
-			out.RawByte(']')
+			out.RawByte('}')
 		}
 	}
-	if in.SwitchTo != nil {
-		const prefix string = ",\"switchto\":"
+	if in.Localized != "" {
+		const prefix string = ",\"localized\":"
 		out.RawString(prefix)
-		(*in.SwitchTo).MarshalEasyJSON(out)
+		out.String(string(in.Localized))
 	}
-	if in.Resumed != nil {
-		const prefix string = ",\"resumed\":"
+	if len(in.Details) != 0 {
+		const prefix string = ",\"details\":"
 		out.RawString(prefix)
-		out.Bool(bool(*in.Resumed))
+		out.Raw((in.Details).MarshalJSON())
 	}
-	if in.Invite != nil {
-		const prefix string = ",\"invite\":"
-		out.RawString(prefix)
-		(*in.Invite).MarshalEasyJSON(out)
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Error) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling56(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Error) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling56(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Error) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling56(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Error) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling56(l, v)
+}
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling57(in *jlexer.Lexer, out *EchoServerMessage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
 	}
-	if in.Disinvite != nil {
-		const prefix string = ",\"disinvite\":"
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "timestamp":
+			out.Timestamp = int64(in.Int64())
+		case "received":
+			out.Received = int64(in.Int64())
+		case "sent":
+			out.Sent = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling57(out *jwriter.Writer, in EchoServerMessage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Timestamp != 0 {
+		const prefix string = ",\"timestamp\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.Timestamp))
+	}
+	{
+		const prefix string = ",\"received\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Received))
+	}
+	{
+		const prefix string = ",\"sent\":"
 		out.RawString(prefix)
-		(*in.Disinvite).MarshalEasyJSON(out)
+		out.Int64(int64(in.Sent))
 	}
-	if in.Update != nil {
-		const prefix string = ",\"update\":"
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v EchoServerMessage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling57(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v EchoServerMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling57(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *EchoServerMessage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling57(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *EchoServerMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling57(l, v)
+}
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling58(in *jlexer.Lexer, out *EchoClientMessage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "timestamp":
+			out.Timestamp = int64(in.Int64())
+		case "rtt":
+			out.Rtt = int64(in.Int64())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling58(out *jwriter.Writer, in EchoClientMessage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Timestamp != 0 {
+		const prefix string = ",\"timestamp\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.Int64(int64(in.Timestamp))
+	}
+	if in.Rtt != 0 {
+		const prefix string = ",\"rtt\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Int64(int64(in.Rtt))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v EchoClientMessage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling58(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v EchoClientMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling58(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *EchoClientMessage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling58(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *EchoClientMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling58(l, v)
+}
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling59(in *jlexer.Lexer, out *DtmfInternalClientMessage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "digits":
+			out.Digits = string(in.String())
+		case "sessionid":
+			out.SessionId = PublicSessionId(in.String())
+		case "roomid":
+			out.RoomId = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling59(out *jwriter.Writer, in DtmfInternalClientMessage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"digits\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Digits))
+	}
+	{
+		const prefix string = ",\"sessionid\":"
 		out.RawString(prefix)
-		(*in.Update).MarshalEasyJSON(out)
+		out.String(string(in.SessionId))
 	}
-	if in.Flags != nil {
-		const prefix string = ",\"flags\":"
+	{
+		const prefix string = ",\"roomid\":"
 		out.RawString(prefix)
-		(*in.Flags).MarshalEasyJSON(out)
+		out.String(string(in.RoomId))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DtmfInternalClientMessage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling59(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DtmfInternalClientMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling59(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DtmfInternalClientMessage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling59(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DtmfInternalClientMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling59(l, v)
+}
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling60(in *jlexer.Lexer, out *DtmfClientMessage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "sessionid":
+			out.SessionId = PublicSessionId(in.String())
+		case "digits":
+			out.Digits = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
 	}
-	if in.Message != nil {
-		const prefix string = ",\"message\":"
+}
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling60(out *jwriter.Writer, in DtmfClientMessage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"sessionid\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.SessionId))
+	}
+	{
+		const prefix string = ",\"digits\":"
 		out.RawString(prefix)
-		(*in.Message).MarshalEasyJSON(out)
+		out.String(string(in.Digits))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v EventServerMessage) MarshalJSON() ([]byte, error) {
+func (v DtmfClientMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling36(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling60(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v EventServerMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling36(w, v)
+func (v DtmfClientMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling60(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *EventServerMessage) UnmarshalJSON(data []byte) error {
+func (v *DtmfClientMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling36(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling60(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *EventServerMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling36(l, v)
+func (v *DtmfClientMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling60(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling37(in *jlexer.Lexer, out *Error) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling61(in *jlexer.Lexer, out *DrainingErrorDetails) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -4415,14 +7275,8 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling37(in *jle
 			continue
 		}
 		switch key {
-		case "code":
-			out.Code = string(in.String())
-		case "message":
-			out.Message = string(in.String())
-		case "details":
-			if data := in.Raw(); in.Ok() {
-				in.AddError((out.Details).UnmarshalJSON(data))
-			}
+		case "retryafter":
+			out.RetryAfter = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -4433,52 +7287,42 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling37(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling37(out *jwriter.Writer, in Error) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling61(out *jwriter.Writer, in DrainingErrorDetails) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"code\":"
+		const prefix string = ",\"retryafter\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Code))
-	}
-	{
-		const prefix string = ",\"message\":"
-		out.RawString(prefix)
-		out.String(string(in.Message))
-	}
-	if len(in.Details) != 0 {
-		const prefix string = ",\"details\":"
-		out.RawString(prefix)
-		out.Raw((in.Details).MarshalJSON())
+		out.Int(int(in.RetryAfter))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v Error) MarshalJSON() ([]byte, error) {
+func (v DrainingErrorDetails) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling37(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling61(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Error) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling37(w, v)
+func (v DrainingErrorDetails) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling61(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *Error) UnmarshalJSON(data []byte) error {
+func (v *DrainingErrorDetails) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling37(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling61(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Error) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling37(l, v)
+func (v *DrainingErrorDetails) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling61(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling38(in *jlexer.Lexer, out *DialoutStatusInternalClientMessage) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling62(in *jlexer.Lexer, out *DialoutStatusInternalClientMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -4507,6 +7351,8 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling38(in *jle
 			out.Code = int(in.Int())
 		case "message":
 			out.Message = string(in.String())
+		case "reason":
+			out.Reason = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -4517,7 +7363,7 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling38(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling38(out *jwriter.Writer, in DialoutStatusInternalClientMessage) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling62(out *jwriter.Writer, in DialoutStatusInternalClientMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -4546,33 +7392,38 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling38(out *jw
 		out.RawString(prefix)
 		out.String(string(in.Message))
 	}
+	if in.Reason != "" {
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v DialoutStatusInternalClientMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling38(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling62(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v DialoutStatusInternalClientMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling38(w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling62(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *DialoutStatusInternalClientMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling38(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling62(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *DialoutStatusInternalClientMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling38(l, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling62(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling39(in *jlexer.Lexer, out *DialoutInternalClientMessage) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling63(in *jlexer.Lexer, out *DialoutInternalClientMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -4625,7 +7476,7 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling39(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling39(out *jwriter.Writer, in DialoutInternalClientMessage) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling63(out *jwriter.Writer, in DialoutInternalClientMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -4655,27 +7506,27 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling39(out *jw
 // MarshalJSON supports json.Marshaler interface
 func (v DialoutInternalClientMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling39(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling63(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v DialoutInternalClientMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling39(w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling63(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *DialoutInternalClientMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling39(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling63(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *DialoutInternalClientMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling39(l, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling63(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling40(in *jlexer.Lexer, out *ControlServerMessage) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling64(in *jlexer.Lexer, out *ControlServerMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -4728,7 +7579,7 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling40(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling40(out *jwriter.Writer, in ControlServerMessage) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling64(out *jwriter.Writer, in ControlServerMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -4757,27 +7608,27 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling40(out *jw
 // MarshalJSON supports json.Marshaler interface
 func (v ControlServerMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling40(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling64(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ControlServerMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling40(w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling64(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ControlServerMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling40(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling64(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ControlServerMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling40(l, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling64(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling41(in *jlexer.Lexer, out *ControlClientMessage) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling65(in *jlexer.Lexer, out *ControlClientMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -4812,7 +7663,7 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling41(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling41(out *jwriter.Writer, in ControlClientMessage) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling65(out *jwriter.Writer, in ControlClientMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -4832,27 +7683,27 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling41(out *jw
 // MarshalJSON supports json.Marshaler interface
 func (v ControlClientMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling41(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling65(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ControlClientMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling41(w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling65(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ControlClientMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling41(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling65(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ControlClientMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling41(l, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling65(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling42(in *jlexer.Lexer, out *CommonSessionInternalClientMessage) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling66(in *jlexer.Lexer, out *CommonSessionInternalClientMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -4885,7 +7736,7 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling42(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling42(out *jwriter.Writer, in CommonSessionInternalClientMessage) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling66(out *jwriter.Writer, in CommonSessionInternalClientMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -4905,27 +7756,27 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling42(out *jw
 // MarshalJSON supports json.Marshaler interface
 func (v CommonSessionInternalClientMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling42(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling66(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v CommonSessionInternalClientMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling42(w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling66(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *CommonSessionInternalClientMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling42(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling66(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *CommonSessionInternalClientMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling42(l, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling66(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling43(in *jlexer.Lexer, out *ClientTypeInternalAuthParams) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling67(in *jlexer.Lexer, out *ClientTypeInternalAuthParams) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -4960,7 +7811,7 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling43(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling43(out *jwriter.Writer, in ClientTypeInternalAuthParams) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling67(out *jwriter.Writer, in ClientTypeInternalAuthParams) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -4985,27 +7836,27 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling43(out *jw
 // MarshalJSON supports json.Marshaler interface
 func (v ClientTypeInternalAuthParams) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling43(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling67(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ClientTypeInternalAuthParams) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling43(w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling67(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ClientTypeInternalAuthParams) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling43(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling67(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ClientTypeInternalAuthParams) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling43(l, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling67(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling44(in *jlexer.Lexer, out *ClientMessage) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling68(in *jlexer.Lexer, out *ClientMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -5098,6 +7949,48 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling44(in *jle
 				}
 				(*out.TransientData).UnmarshalEasyJSON(in)
 			}
+		case "participants":
+			if in.IsNull() {
+				in.Skip()
+				out.Participants = nil
+			} else {
+				if out.Participants == nil {
+					out.Participants = new(ParticipantsClientMessage)
+				}
+				(*out.Participants).UnmarshalEasyJSON(in)
+			}
+		case "echo":
+			if in.IsNull() {
+				in.Skip()
+				out.Echo = nil
+			} else {
+				if out.Echo == nil {
+					out.Echo = new(EchoClientMessage)
+				}
+				(*out.Echo).UnmarshalEasyJSON(in)
+			}
+		case "dtmf":
+			if in.IsNull() {
+				in.Skip()
+				out.Dtmf = nil
+			} else {
+				if out.Dtmf == nil {
+					out.Dtmf = new(DtmfClientMessage)
+				}
+				(*out.Dtmf).UnmarshalEasyJSON(in)
+			}
+		case "recording":
+			if in.IsNull() {
+				in.Skip()
+				out.Recording = nil
+			} else {
+				if out.Recording == nil {
+					out.Recording = new(RecordingClientMessage)
+				}
+				(*out.Recording).UnmarshalEasyJSON(in)
+			}
+		case "signature":
+			out.Signature = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -5108,7 +8001,7 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling44(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling44(out *jwriter.Writer, in ClientMessage) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling68(out *jwriter.Writer, in ClientMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -5163,33 +8056,58 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling44(out *jw
 		out.RawString(prefix)
 		(*in.TransientData).MarshalEasyJSON(out)
 	}
+	if in.Participants != nil {
+		const prefix string = ",\"participants\":"
+		out.RawString(prefix)
+		(*in.Participants).MarshalEasyJSON(out)
+	}
+	if in.Echo != nil {
+		const prefix string = ",\"echo\":"
+		out.RawString(prefix)
+		(*in.Echo).MarshalEasyJSON(out)
+	}
+	if in.Dtmf != nil {
+		const prefix string = ",\"dtmf\":"
+		out.RawString(prefix)
+		(*in.Dtmf).MarshalEasyJSON(out)
+	}
+	if in.Recording != nil {
+		const prefix string = ",\"recording\":"
+		out.RawString(prefix)
+		(*in.Recording).MarshalEasyJSON(out)
+	}
+	if in.Signature != "" {
+		const prefix string = ",\"signature\":"
+		out.RawString(prefix)
+		out.String(string(in.Signature))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v ClientMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling44(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling68(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ClientMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling44(w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling68(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ClientMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling44(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling68(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ClientMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling44(l, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling68(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling45(in *jlexer.Lexer, out *ByeServerMessage) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling69(in *jlexer.Lexer, out *ByeServerMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -5210,6 +8128,10 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling45(in *jle
 		switch key {
 		case "reason":
 			out.Reason = string(in.String())
+		case "reconnect":
+			out.Reconnect = bool(in.Bool())
+		case "retryafter":
+			out.RetryAfter = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -5220,7 +8142,7 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling45(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling45(out *jwriter.Writer, in ByeServerMessage) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling69(out *jwriter.Writer, in ByeServerMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -5229,33 +8151,43 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling45(out *jw
 		out.RawString(prefix[1:])
 		out.String(string(in.Reason))
 	}
+	if in.Reconnect {
+		const prefix string = ",\"reconnect\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Reconnect))
+	}
+	if in.RetryAfter != 0 {
+		const prefix string = ",\"retryafter\":"
+		out.RawString(prefix)
+		out.Int(int(in.RetryAfter))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v ByeServerMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling45(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling69(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ByeServerMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling45(w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling69(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ByeServerMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling45(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling69(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ByeServerMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling45(l, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling69(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling46(in *jlexer.Lexer, out *ByeClientMessage) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling70(in *jlexer.Lexer, out *ByeClientMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -5284,7 +8216,7 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling46(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling46(out *jwriter.Writer, in ByeClientMessage) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling70(out *jwriter.Writer, in ByeClientMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -5294,27 +8226,27 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling46(out *jw
 // MarshalJSON supports json.Marshaler interface
 func (v ByeClientMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling46(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling70(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ByeClientMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling46(w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling70(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ByeClientMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling46(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling70(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ByeClientMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling46(l, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling70(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling47(in *jlexer.Lexer, out *AnswerOfferMessage) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling71(in *jlexer.Lexer, out *AnswerOfferMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -5350,15 +8282,15 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling47(in *jle
 				for !in.IsDelim('}') {
 					key := string(in.String())
 					in.WantColon()
-					var v45 interface{}
-					if m, ok := v45.(easyjson.Unmarshaler); ok {
+					var v83 interface{}
+					if m, ok := v83.(easyjson.Unmarshaler); ok {
 						m.UnmarshalEasyJSON(in)
-					} else if m, ok := v45.(json.Unmarshaler); ok {
+					} else if m, ok := v83.(json.Unmarshaler); ok {
 						_ = m.UnmarshalJSON(in.Raw())
 					} else {
-						v45 = in.Interface()
+						v83 = in.Interface()
 					}
-					(out.Payload)[key] = v45
+					(out.Payload)[key] = v83
 					in.WantComma()
 				}
 				in.Delim('}')
@@ -5375,7 +8307,7 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling47(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling47(out *jwriter.Writer, in AnswerOfferMessage) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling71(out *jwriter.Writer, in AnswerOfferMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -5406,21 +8338,21 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling47(out *jw
 			out.RawString(`null`)
 		} else {
 			out.RawByte('{')
-			v46First := true
-			for v46Name, v46Value := range in.Payload {
-				if v46First {
-					v46First = false
+			v84First := true
+			for v84Name, v84Value := range in.Payload {
+				if v84First {
+					v84First = false
 				} else {
 					out.RawByte(',')
 				}
-				out.String(string(v46Name))
+				out.String(string(v84Name))
 				out.RawByte(':')
-				if m, ok := v46Value.(easyjson.Marshaler); ok {
+				if m, ok := v84Value.(easyjson.Marshaler); ok {
 					m.MarshalEasyJSON(out)
-				} else if m, ok := v46Value.(json.Marshaler); ok {
+				} else if m, ok := v84Value.(json.Marshaler); ok {
 					out.Raw(m.MarshalJSON())
 				} else {
-					out.Raw(json.Marshal(v46Value))
+					out.Raw(json.Marshal(v84Value))
 				}
 			}
 			out.RawByte('}')
@@ -5437,27 +8369,144 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling47(out *jw
 // MarshalJSON supports json.Marshaler interface
 func (v AnswerOfferMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling47(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling71(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v AnswerOfferMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling47(w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling71(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *AnswerOfferMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling47(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling71(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *AnswerOfferMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling47(l, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling71(l, v)
+}
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling72(in *jlexer.Lexer, out *AddSessionsInternalClientMessage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "roomid":
+			out.RoomId = string(in.String())
+		case "sessions":
+			if in.IsNull() {
+				in.Skip()
+				out.Sessions = nil
+			} else {
+				in.Delim('[')
+				if out.Sessions == nil {
+					if !in.IsDelim(']') {
+						out.Sessions = make([]*AddSessionInternalClientMessage, 0, 8)
+					} else {
+						out.Sessions = []*AddSessionInternalClientMessage{}
+					}
+				} else {
+					out.Sessions = (out.Sessions)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v85 *AddSessionInternalClientMessage
+					if in.IsNull() {
+						in.Skip()
+						v85 = nil
+					} else {
+						if v85 == nil {
+							v85 = new(AddSessionInternalClientMessage)
+						}
+						(*v85).UnmarshalEasyJSON(in)
+					}
+					out.Sessions = append(out.Sessions, v85)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling72(out *jwriter.Writer, in AddSessionsInternalClientMessage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"roomid\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.RoomId))
+	}
+	{
+		const prefix string = ",\"sessions\":"
+		out.RawString(prefix)
+		if in.Sessions == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v86, v87 := range in.Sessions {
+				if v86 > 0 {
+					out.RawByte(',')
+				}
+				if v87 == nil {
+					out.RawString("null")
+				} else {
+					(*v87).MarshalEasyJSON(out)
+				}
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AddSessionsInternalClientMessage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling72(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AddSessionsInternalClientMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling72(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AddSessionsInternalClientMessage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling72(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AddSessionsInternalClientMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling72(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling48(in *jlexer.Lexer, out *AddSessionOptions) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling73(in *jlexer.Lexer, out *AddSessionOptions) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -5490,7 +8539,7 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling48(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling48(out *jwriter.Writer, in AddSessionOptions) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling73(out *jwriter.Writer, in AddSessionOptions) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -5516,27 +8565,27 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling48(out *jw
 // MarshalJSON supports json.Marshaler interface
 func (v AddSessionOptions) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling48(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling73(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v AddSessionOptions) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling48(w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling73(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *AddSessionOptions) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling48(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling73(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *AddSessionOptions) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling48(l, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling73(l, v)
 }
-func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling49(in *jlexer.Lexer, out *AddSessionInternalClientMessage) {
+func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling74(in *jlexer.Lexer, out *AddSessionInternalClientMessage) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -5573,6 +8622,37 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling49(in *jle
 				}
 				*out.InCall = int(in.Int())
 			}
+		case "permissions":
+			if in.IsNull() {
+				in.Skip()
+				out.Permissions = nil
+			} else {
+				if out.Permissions == nil {
+					out.Permissions = new([]Permission)
+				}
+				if in.IsNull() {
+					in.Skip()
+					*out.Permissions = nil
+				} else {
+					in.Delim('[')
+					if *out.Permissions == nil {
+						if !in.IsDelim(']') {
+							*out.Permissions = make([]Permission, 0, 4)
+						} else {
+							*out.Permissions = []Permission{}
+						}
+					} else {
+						*out.Permissions = (*out.Permissions)[:0]
+					}
+					for !in.IsDelim(']') {
+						var v88 Permission
+						v88 = Permission(in.String())
+						*out.Permissions = append(*out.Permissions, v88)
+						in.WantComma()
+					}
+					in.Delim(']')
+				}
+			}
 		case "options":
 			if in.IsNull() {
 				in.Skip()
@@ -5597,7 +8677,7 @@ func easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling49(in *jle
 		in.Consumed()
 	}
 }
-func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling49(out *jwriter.Writer, in AddSessionInternalClientMessage) {
+func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling74(out *jwriter.Writer, in AddSessionInternalClientMessage) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -5637,6 +8717,27 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling49(out *jw
 		}
 		out.Int(int(*in.InCall))
 	}
+	if in.Permissions != nil {
+		const prefix string = ",\"permissions\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		if *in.Permissions == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v89, v90 := range *in.Permissions {
+				if v89 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v90))
+			}
+			out.RawByte(']')
+		}
+	}
 	if in.Options != nil {
 		const prefix string = ",\"options\":"
 		if first {
@@ -5668,23 +8769,23 @@ func easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling49(out *jw
 // MarshalJSON supports json.Marshaler interface
 func (v AddSessionInternalClientMessage) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling49(&w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling74(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v AddSessionInternalClientMessage) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling49(w, v)
+	easyjson29f189fbEncodeGithubComStrukturagNextcloudSpreedSignaling74(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *AddSessionInternalClientMessage) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling49(&r, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling74(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *AddSessionInternalClientMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling49(l, v)
+	easyjson29f189fbDecodeGithubComStrukturagNextcloudSpreedSignaling74(l, v)
 }
@@ -50,6 +50,12 @@ func wrapMessage(messageType string, msg testCheckValid) *ClientMessage {
 		wrapped.Bye = msg.(*ByeClientMessage)
 	case "room":
 		wrapped.Room = msg.(*RoomClientMessage)
+	case "participants":
+		wrapped.Participants = msg.(*ParticipantsClientMessage)
+	case "echo":
+		wrapped.Echo = msg.(*EchoClientMessage)
+	case "dtmf":
+		wrapped.Dtmf = msg.(*DtmfClientMessage)
 	default:
 		return nil
 	}
@@ -139,6 +145,18 @@ func TestHelloClientMessage(t *testing.T) {
 			Version:  HelloVersionV2,
 			ResumeId: "the-resume-id",
 		},
+		&HelloClientMessage{
+			Version: HelloVersionV2,
+			Auth: &HelloClientMessageAuth{
+				Params: tokenAuthParams,
+				Url:    "https://domain.invalid",
+			},
+			Hints: &HelloClientMessageHints{
+				MaxDownlink:  1000000,
+				BatterySaver: true,
+				Mobile:       true,
+			},
+		},
 	}
 	invalid_messages := []testCheckValid{
 		// Hello version 1
@@ -219,6 +237,16 @@ func TestHelloClientMessage(t *testing.T) {
 				Url:    "https://domain.invalid",
 			},
 		},
+		&HelloClientMessage{
+			Version: HelloVersionV2,
+			Auth: &HelloClientMessageAuth{
+				Params: tokenAuthParams,
+				Url:    "https://domain.invalid",
+			},
+			Hints: &HelloClientMessageHints{
+				MaxDownlink: -1,
+			},
+		},
 	}
 
 	testMessages(t, "hello", valid_messages, invalid_messages)
@@ -254,9 +282,36 @@ func TestMessageClientMessage(t *testing.T) {
 			},
 			Data: json.RawMessage("{}"),
 		},
+		&MessageClientMessage{
+			Recipient: MessageClientMessageRecipient{
+				Type:       "sessions",
+				SessionIds: []PublicSessionId{"session-1", "session-2"},
+			},
+			Data: json.RawMessage("{}"),
+		},
 	}
 	invalid_messages := []testCheckValid{
 		&MessageClientMessage{},
+		&MessageClientMessage{
+			Recipient: MessageClientMessageRecipient{
+				Type: "sessions",
+			},
+			Data: json.RawMessage("{}"),
+		},
+		&MessageClientMessage{
+			Recipient: MessageClientMessageRecipient{
+				Type:       "sessions",
+				SessionIds: []PublicSessionId{"session-1", ""},
+			},
+			Data: json.RawMessage("{}"),
+		},
+		&MessageClientMessage{
+			Recipient: MessageClientMessageRecipient{
+				Type:       "sessions",
+				SessionIds: make([]PublicSessionId, maxMessageRecipientSessions+1),
+			},
+			Data: json.RawMessage("{}"),
+		},
 		&MessageClientMessage{
 			Recipient: MessageClientMessageRecipient{
 				Type:      "session",
@@ -348,6 +403,257 @@ func TestRoomClientMessage(t *testing.T) {
 	assert.Error(msg.CheckValid())
 }
 
+func TestParticipantsClientMessage(t *testing.T) {
+	t.Parallel()
+	// Any "participants" message is valid.
+	valid_messages := []testCheckValid{
+		&ParticipantsClientMessage{},
+		&ParticipantsClientMessage{
+			Version: 1,
+		},
+	}
+	invalid_messages := []testCheckValid{}
+
+	testMessages(t, "participants", valid_messages, invalid_messages)
+
+	// But a "participants" message must be present
+	msg := ClientMessage{
+		Type: "participants",
+	}
+	assert := assert.New(t)
+	assert.Error(msg.CheckValid())
+}
+
+func TestNewByeServerMessage(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	assert.Nil(NewByeServerMessage(""))
+
+	testcases := map[string]bool{
+		ByeReasonHelloTimeout:           true,
+		ByeReasonRoomJoinTimeout:        false,
+		ByeReasonSessionResumed:         false,
+		ByeReasonRoomSessionReconnected: false,
+		ByeReasonKicked:                 false,
+		ByeReasonBanned:                 false,
+		ByeReasonBackendGone:            false,
+		ByeReasonDraining:               true,
+		ByeReasonIdleTimeout:            true,
+		ByeReasonDisconnected:           false,
+	}
+	for reason, reconnect := range testcases {
+		if bye := NewByeServerMessage(reason); assert.NotNil(bye, "failed for %s", reason) {
+			assert.Equal(reason, bye.Reason)
+			assert.Equal(reconnect, bye.Reconnect, "failed for %s", reason)
+		}
+	}
+}
+
+func TestEchoClientMessage(t *testing.T) {
+	t.Parallel()
+	valid_messages := []testCheckValid{
+		&EchoClientMessage{},
+		&EchoClientMessage{
+			Timestamp: 1234,
+		},
+		&EchoClientMessage{
+			Timestamp: 1234,
+			Rtt:       20,
+		},
+	}
+	invalid_messages := []testCheckValid{
+		&EchoClientMessage{
+			Rtt: -1,
+		},
+	}
+
+	testMessages(t, "echo", valid_messages, invalid_messages)
+
+	// But an "echo" message must be present
+	msg := ClientMessage{
+		Type: "echo",
+	}
+	assert := assert.New(t)
+	assert.Error(msg.CheckValid())
+}
+
+func TestDtmfClientMessage(t *testing.T) {
+	t.Parallel()
+	valid_messages := []testCheckValid{
+		&DtmfClientMessage{
+			SessionId: "the-session-id",
+			Digits:    "0123456789ABCD*#",
+		},
+	}
+	invalid_messages := []testCheckValid{
+		&DtmfClientMessage{},
+		&DtmfClientMessage{
+			Digits: "1",
+		},
+		&DtmfClientMessage{
+			SessionId: "the-session-id",
+		},
+		&DtmfClientMessage{
+			SessionId: "the-session-id",
+			Digits:    "12e",
+		},
+		&DtmfClientMessage{
+			SessionId: "the-session-id",
+			Digits:    strings.Repeat("1", maxDtmfDigits+1),
+		},
+	}
+
+	testMessages(t, "dtmf", valid_messages, invalid_messages)
+
+	// But a "dtmf" message must be present
+	msg := ClientMessage{
+		Type: "dtmf",
+	}
+	assert := assert.New(t)
+	assert.Error(msg.CheckValid())
+}
+
+func TestDialoutStatusInternalClientMessage(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	valid_messages := []testCheckValid{
+		&DialoutStatusInternalClientMessage{
+			CallId: "call-id",
+			Status: DialoutStatusEarlyMedia,
+		},
+		&DialoutStatusInternalClientMessage{
+			CallId:  "call-id",
+			Status:  DialoutStatusFailed,
+			Cause:   "server-error",
+			Code:    503,
+			Message: "Service Unavailable",
+		},
+	}
+	invalid_messages := []testCheckValid{
+		&DialoutStatusInternalClientMessage{
+			Status: DialoutStatusRinging,
+		},
+		&DialoutStatusInternalClientMessage{
+			CallId: "call-id",
+			Status: "unknown",
+		},
+		&DialoutStatusInternalClientMessage{
+			CallId: "call-id",
+			Status: DialoutStatusBusy,
+			Code:   42,
+		},
+	}
+	for _, msg := range valid_messages {
+		assert.NoError(msg.CheckValid(), "Message %+v should be valid", msg)
+	}
+	for _, msg := range invalid_messages {
+		assert.Error(msg.CheckValid(), "Message %+v should not be valid", msg)
+	}
+
+	for _, status := range knownDialoutStatus {
+		final := status == DialoutStatusRejected ||
+			status == DialoutStatusBusy ||
+			status == DialoutStatusFailed ||
+			status == DialoutStatusCleared
+		assert.Equal(final, status.IsFinal(), "failed for %s", status)
+	}
+}
+
+func TestVirtualSessionsInternalClientMessages(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	add := &AddSessionsInternalClientMessage{
+		RoomId: "the-room",
+		Sessions: []*AddSessionInternalClientMessage{
+			{
+				CommonSessionInternalClientMessage: CommonSessionInternalClientMessage{
+					SessionId: "session1",
+				},
+			},
+			{
+				CommonSessionInternalClientMessage: CommonSessionInternalClientMessage{
+					SessionId: "session2",
+					RoomId:    "the-room",
+				},
+			},
+		},
+	}
+	if assert.NoError(add.CheckValid()) {
+		// The room id of the message is used for the sessions.
+		assert.Equal("the-room", add.Sessions[0].RoomId)
+	}
+
+	invalid_messages := []testCheckValid{
+		&AddSessionsInternalClientMessage{},
+		&AddSessionsInternalClientMessage{
+			RoomId: "the-room",
+		},
+		&AddSessionsInternalClientMessage{
+			RoomId: "the-room",
+			Sessions: []*AddSessionInternalClientMessage{
+				nil,
+			},
+		},
+		&AddSessionsInternalClientMessage{
+			RoomId: "the-room",
+			Sessions: []*AddSessionInternalClientMessage{
+				{},
+			},
+		},
+		&AddSessionsInternalClientMessage{
+			RoomId: "the-room",
+			Sessions: []*AddSessionInternalClientMessage{
+				{
+					CommonSessionInternalClientMessage: CommonSessionInternalClientMessage{
+						SessionId: "session1",
+						RoomId:    "other-room",
+					},
+				},
+			},
+		},
+		&AddSessionsInternalClientMessage{
+			RoomId: "the-room",
+			Sessions: []*AddSessionInternalClientMessage{
+				{
+					CommonSessionInternalClientMessage: CommonSessionInternalClientMessage{
+						SessionId: "session1",
+					},
+				},
+				{
+					CommonSessionInternalClientMessage: CommonSessionInternalClientMessage{
+						SessionId: "session1",
+					},
+				},
+			},
+		},
+		&AddSessionsInternalClientMessage{
+			RoomId:   "the-room",
+			Sessions: make([]*AddSessionInternalClientMessage, maxVirtualSessionsPerMessage+1),
+		},
+		&RemoveSessionsInternalClientMessage{},
+		&RemoveSessionsInternalClientMessage{
+			RoomId: "the-room",
+		},
+		&RemoveSessionsInternalClientMessage{
+			RoomId:     "the-room",
+			SessionIds: []PublicSessionId{"session1", ""},
+		},
+		&RemoveSessionsInternalClientMessage{
+			RoomId:     "the-room",
+			SessionIds: make([]PublicSessionId, maxVirtualSessionsPerMessage+1),
+		},
+	}
+	for _, msg := range invalid_messages {
+		assert.Error(msg.CheckValid(), "Message %+v should not be valid", msg)
+	}
+
+	remove := &RemoveSessionsInternalClientMessage{
+		RoomId:     "the-room",
+		SessionIds: []PublicSessionId{"session1", "session2"},
+	}
+	assert.NoError(remove.CheckValid())
+}
+
 func TestErrorMessages(t *testing.T) {
 	t.Parallel()
 	assert := assert.New(t)
@@ -393,6 +699,34 @@ func TestIsChatRefresh(t *testing.T) {
 	assert.False(t, msg.IsChatRefresh())
 }
 
+func TestIsPriority(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	priority := []*ServerMessage{
+		{Type: "bye"},
+		{Type: "control"},
+		{Type: "internal"},
+		{Type: "event", Event: &EventServerMessage{Target: "session", Type: "dtmf"}},
+		{Type: "event", Event: &EventServerMessage{Target: "room", Type: "recording"}},
+		{Type: "event", Event: &EventServerMessage{Target: "room", Type: "degraded"}},
+	}
+	for _, msg := range priority {
+		assert.True(msg.IsPriority(), "expected %+v to have priority", msg)
+	}
+
+	normal := []*ServerMessage{
+		{Type: "message"},
+		{Type: "room"},
+		{Type: "event"},
+		{Type: "event", Event: &EventServerMessage{Target: "participants", Type: "update"}},
+		{Type: "event", Event: &EventServerMessage{Target: "room", Type: "join"}},
+	}
+	for _, msg := range normal {
+		assert.False(msg.IsPriority(), "expected %+v to have no priority", msg)
+	}
+}
+
 func assertEqualStrings(t *testing.T, expected, result []string) {
 	t.Helper()
 
@@ -428,6 +762,41 @@ func Test_Welcome_AddRemoveFeature(t *testing.T) {
 	assertEqualStrings(t, []string{"two"}, msg.Features)
 }
 
+func TestNegotiateClientFeatures(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	server := NewWelcomeServerMessage("1.0", ServerFeatureDialout, ServerFeatureReceipts, ServerFeatureParticipantsDelta)
+
+	requested := []string{
+		ClientFeatureReceipts,
+		ClientFeatureResync,
+		ClientFeatureStartDialout,
+		ClientFeatureInternalInCall,
+		ClientFeatureParticipantsDelta,
+		ClientFeatureReceipts,
+		"unknown-feature",
+	}
+	assert.Equal([]string{
+		ClientFeatureParticipantsDelta,
+		ClientFeatureReceipts,
+	}, NegotiateClientFeatures(server, HelloClientTypeClient, requested))
+	assert.Equal([]string{
+		ClientFeatureInternalInCall,
+		ClientFeatureParticipantsDelta,
+		ClientFeatureReceipts,
+		ClientFeatureStartDialout,
+	}, NegotiateClientFeatures(server, HelloClientTypeInternal, requested))
+
+	server.RemoveFeature(ServerFeatureDialout)
+	assert.Equal([]string{
+		ClientFeatureInternalInCall,
+		ClientFeatureParticipantsDelta,
+		ClientFeatureReceipts,
+	}, NegotiateClientFeatures(server, HelloClientTypeInternal, requested))
+
+	assert.Empty(NegotiateClientFeatures(server, HelloClientTypeClient, nil))
+}
+
 func TestFilterCandidates(t *testing.T) {
 	t.Parallel()
 	assert := assert.New(t)
@@ -21,7 +21,23 @@
  */
 package signaling
 
-import "sync"
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dlintw/goconf"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+const (
+	AsyncEventsTypeNats  = "nats"
+	AsyncEventsTypeRedis = "redis"
+
+	DefaultAsyncEventsType = AsyncEventsTypeNats
+)
 
 type AsyncBackendRoomEventListener interface {
 	ProcessBackendRoomRequest(message *AsyncMessage)
@@ -54,10 +70,16 @@ type AsyncEvents interface {
 	RegisterSessionListener(sessionId PublicSessionId, backend *Backend, listener AsyncSessionEventListener) error
 	UnregisterSessionListener(sessionId PublicSessionId, backend *Backend, listener AsyncSessionEventListener)
 
+	// The messages are serialized before the "Publish*" methods return, so
+	// callers may reuse them afterwards.
 	PublishBackendRoomMessage(roomId string, backend *Backend, message *AsyncMessage) error
 	PublishRoomMessage(roomId string, backend *Backend, message *AsyncMessage) error
 	PublishUserMessage(userId string, backend *Backend, message *AsyncMessage) error
 	PublishSessionMessage(sessionId PublicSessionId, backend *Backend, message *AsyncMessage) error
+
+	// SetOnReconnected sets a function that will be called after the
+	// connection to the events server was restored.
+	SetOnReconnected(f func())
 }
 
 func NewAsyncEvents(url string) (AsyncEvents, error) {
@@ -69,6 +91,55 @@ func NewAsyncEvents(url string) (AsyncEvents, error) {
 	return NewAsyncEventsNats(client)
 }
 
+// NewAsyncEventsFromConfig creates the events implementation configured in
+// section "events".
+func NewAsyncEventsFromConfig(config *goconf.ConfigFile) (AsyncEvents, error) {
+	eventsType, _ := config.GetString("events", "type")
+	if eventsType == "" {
+		eventsType = DefaultAsyncEventsType
+	}
+
+	switch eventsType {
+	case AsyncEventsTypeNats:
+		natsUrl, _ := GetStringOptionWithEnv(config, "nats", "url")
+		if natsUrl == "" {
+			natsUrl = nats.DefaultURL
+		}
+
+		client, err := NewNatsClient(natsUrl)
+		if err != nil {
+			return nil, err
+		}
+
+		if useJetStream, _ := config.GetBool("nats", "jetstream"); useJetStream {
+			if err := enableNatsJetStream(client, config); err != nil {
+				client.Close()
+				return nil, err
+			}
+		}
+
+		return NewAsyncEventsNats(client)
+	case AsyncEventsTypeRedis:
+		redisUrl, _ := GetStringOptionWithEnv(config, "redis", "url")
+		if redisUrl == "" {
+			redisUrl = DefaultRedisUrl
+		}
+		prefix, err := config.GetString("redis", "prefix")
+		if err != nil {
+			prefix = DefaultRedisPrefix
+		}
+
+		client, err := NewRedisClient(redisUrl)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewAsyncEventsRedis(client, prefix)
+	default:
+		return nil, fmt.Errorf("unsupported events type: %s", eventsType)
+	}
+}
+
 type asyncBackendRoomSubscriber struct {
 	mu sync.Mutex
 
@@ -76,6 +147,9 @@ type asyncBackendRoomSubscriber struct {
 }
 
 func (s *asyncBackendRoomSubscriber) processBackendRoomRequest(message *AsyncMessage) {
+	span := startAsyncEventSpan(message, "events.backendroom")
+	defer span.End()
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -111,9 +185,16 @@ type asyncRoomSubscriber struct {
 }
 
 func (s *asyncRoomSubscriber) processAsyncRoomMessage(message *AsyncMessage) {
+	span := startAsyncEventSpan(message, "events.room")
+	defer span.End()
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if len(s.listeners) > 1 {
+		message.prepare()
+	}
+
 	for listener := range s.listeners {
 		s.mu.Unlock()
 		listener.ProcessAsyncRoomMessage(message)
@@ -146,9 +227,16 @@ type asyncUserSubscriber struct {
 }
 
 func (s *asyncUserSubscriber) processAsyncUserMessage(message *AsyncMessage) {
+	span := startAsyncEventSpan(message, "events.user")
+	defer span.End()
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if len(s.listeners) > 1 {
+		message.prepare()
+	}
+
 	for listener := range s.listeners {
 		s.mu.Unlock()
 		listener.ProcessAsyncUserMessage(message)
@@ -181,6 +269,9 @@ type asyncSessionSubscriber struct {
 }
 
 func (s *asyncSessionSubscriber) processAsyncSessionMessage(message *AsyncMessage) {
+	span := startAsyncEventSpan(message, "events.session")
+	defer span.End()
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -208,3 +299,36 @@ func (s *asyncSessionSubscriber) removeListener(listener AsyncSessionEventListen
 	delete(s.listeners, listener)
 	return len(s.listeners) > 0
 }
+
+func enableNatsJetStream(client NatsClient, config *goconf.ConfigFile) error {
+	c, ok := client.(*natsClient)
+	if !ok {
+		appLog.Warnf("JetStream is not supported by the NATS loopback client, ignoring")
+		return nil
+	}
+
+	jsConfig := &NatsJetStreamConfig{
+		Subjects: []string{"session.>"},
+		MaxAge:   DefaultNatsJetStreamMaxAge,
+	}
+	jsConfig.Name, _ = config.GetString("nats", "stream")
+	if jsConfig.Name == "" {
+		jsConfig.Name = DefaultNatsJetStreamName
+	}
+
+	retention, _ := config.GetString("nats", "retention")
+	switch strings.ToLower(retention) {
+	case "", "limits":
+		jsConfig.Retention = jetstream.LimitsPolicy
+	case "interest":
+		jsConfig.Retention = jetstream.InterestPolicy
+	default:
+		return fmt.Errorf("unsupported JetStream retention policy: %s", retention)
+	}
+
+	if maxAge, _ := config.GetInt("nats", "maxage"); maxAge > 0 {
+		jsConfig.MaxAge = time.Duration(maxAge) * time.Second
+	}
+
+	return c.EnableJetStream(jsConfig)
+}
@@ -23,7 +23,6 @@ package signaling
 
 import (
 	"fmt"
-	"log"
 	"sync"
 	"time"
 
@@ -70,8 +69,12 @@ type asyncSubscriberNats struct {
 }
 
 func newAsyncSubscriberNats(key string, client NatsClient) (*asyncSubscriberNats, error) {
+	return newAsyncSubscriberNatsWithSubscribe(key, client, client.Subscribe)
+}
+
+func newAsyncSubscriberNatsWithSubscribe(key string, client NatsClient, subscribe func(string, chan *nats.Msg) (NatsSubscription, error)) (*asyncSubscriberNats, error) {
 	receiver := make(chan *nats.Msg, 64)
-	sub, err := client.Subscribe(key, receiver)
+	sub, err := subscribe(key, receiver)
 	if err != nil {
 		return nil, err
 	}
@@ -90,7 +93,7 @@ func newAsyncSubscriberNats(key string, client NatsClient) (*asyncSubscriberNats
 func (s *asyncSubscriberNats) run() {
 	defer func() {
 		if err := s.subscription.Unsubscribe(); err != nil {
-			log.Printf("Error unsubscribing %s: %s", s.key, err)
+			appLog.Errorf("Error unsubscribing %s: %s", s.key, err)
 		}
 	}()
 
@@ -133,7 +136,7 @@ func newAsyncBackendRoomSubscriberNats(key string, client NatsClient) (*asyncBac
 func (s *asyncBackendRoomSubscriberNats) doProcessMessage(msg *nats.Msg) {
 	var message AsyncMessage
 	if err := s.client.Decode(msg, &message); err != nil {
-		log.Printf("Could not decode NATS message %+v, %s", msg, err)
+		appLog.Errorf("Could not decode NATS message %+v, %s", msg, err)
 		return
 	}
 
@@ -162,7 +165,7 @@ func newAsyncRoomSubscriberNats(key string, client NatsClient) (*asyncRoomSubscr
 func (s *asyncRoomSubscriberNats) doProcessMessage(msg *nats.Msg) {
 	var message AsyncMessage
 	if err := s.client.Decode(msg, &message); err != nil {
-		log.Printf("Could not decode nats message %+v, %s", msg, err)
+		appLog.Errorf("Could not decode nats message %+v, %s", msg, err)
 		return
 	}
 
@@ -191,7 +194,7 @@ func newAsyncUserSubscriberNats(key string, client NatsClient) (*asyncUserSubscr
 func (s *asyncUserSubscriberNats) doProcessMessage(msg *nats.Msg) {
 	var message AsyncMessage
 	if err := s.client.Decode(msg, &message); err != nil {
-		log.Printf("Could not decode nats message %+v, %s", msg, err)
+		appLog.Errorf("Could not decode nats message %+v, %s", msg, err)
 		return
 	}
 
@@ -204,7 +207,14 @@ type asyncSessionSubscriberNats struct {
 }
 
 func newAsyncSessionSubscriberNats(key string, client NatsClient) (*asyncSessionSubscriberNats, error) {
-	sub, err := newAsyncSubscriberNats(key, client)
+	subscribe := client.Subscribe
+	if durable, ok := client.(NatsDurableClient); ok && durable.IsDurable() {
+		// Messages to sessions are buffered in JetStream and will be replayed
+		// if the connection to the NATS server was interrupted.
+		subscribe = durable.SubscribeDurable
+	}
+
+	sub, err := newAsyncSubscriberNatsWithSubscribe(key, client, subscribe)
 	if err != nil {
 		return nil, err
 	}
@@ -220,7 +230,7 @@ func newAsyncSessionSubscriberNats(key string, client NatsClient) (*asyncSession
 func (s *asyncSessionSubscriberNats) doProcessMessage(msg *nats.Msg) {
 	var message AsyncMessage
 	if err := s.client.Decode(msg, &message); err != nil {
-		log.Printf("Could not decode nats message %+v, %s", msg, err)
+		appLog.Errorf("Could not decode nats message %+v, %s", msg, err)
 		return
 	}
 
@@ -454,9 +464,23 @@ func (e *asyncEventsNats) UnregisterSessionListener(sessionId PublicSessionId, b
 
 func (e *asyncEventsNats) publish(subject string, message *AsyncMessage) error {
 	message.SendTime = time.Now()
+	message.SendServerId = GrpcServerId
 	return e.client.Publish(subject, message)
 }
 
+// publishDurable stores the message in JetStream if enabled, so it can be
+// replayed to subscribers that were disconnected from the NATS server.
+func (e *asyncEventsNats) publishDurable(subject string, message *AsyncMessage) error {
+	durable, ok := e.client.(NatsDurableClient)
+	if !ok || !durable.IsDurable() {
+		return e.publish(subject, message)
+	}
+
+	message.SendTime = time.Now()
+	message.SendServerId = GrpcServerId
+	return durable.PublishDurable(subject, message)
+}
+
 func (e *asyncEventsNats) PublishBackendRoomMessage(roomId string, backend *Backend, message *AsyncMessage) error {
 	subject := GetSubjectForBackendRoomId(roomId, backend)
 	return e.publish(subject, message)
@@ -474,5 +498,9 @@ func (e *asyncEventsNats) PublishUserMessage(userId string, backend *Backend, me
 
 func (e *asyncEventsNats) PublishSessionMessage(sessionId PublicSessionId, backend *Backend, message *AsyncMessage) error {
 	subject := GetSubjectForSessionId(sessionId, backend)
-	return e.publish(subject, message)
+	return e.publishDurable(subject, message)
+}
+
+func (e *asyncEventsNats) SetOnReconnected(f func()) {
+	e.client.SetOnReconnected(f)
 }
@@ -0,0 +1,419 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultRedisPrefix = "signaling:"
+)
+
+type asyncSubscriberRedis struct {
+	key    string
+	client *RedisClient
+
+	receiver     chan []byte
+	closeChan    chan struct{}
+	subscription RedisSubscription
+
+	processMessage func([]byte)
+}
+
+func newAsyncSubscriberRedis(key string, client *RedisClient) (*asyncSubscriberRedis, error) {
+	receiver := make(chan []byte, 64)
+	sub, err := client.Subscribe(key, receiver)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &asyncSubscriberRedis{
+		key:    key,
+		client: client,
+
+		receiver:     receiver,
+		closeChan:    make(chan struct{}),
+		subscription: sub,
+	}
+	return result, nil
+}
+
+func (s *asyncSubscriberRedis) run() {
+	defer func() {
+		if err := s.subscription.Unsubscribe(); err != nil {
+			appLog.Errorf("Error unsubscribing %s: %s", s.key, err)
+		}
+	}()
+
+	for {
+		select {
+		case msg := <-s.receiver:
+			s.processMessage(msg)
+			for count := len(s.receiver); count > 0; count-- {
+				s.processMessage(<-s.receiver)
+			}
+		case <-s.closeChan:
+			return
+		}
+	}
+}
+
+func (s *asyncSubscriberRedis) close() {
+	close(s.closeChan)
+}
+
+func (s *asyncSubscriberRedis) decode(data []byte) (*AsyncMessage, bool) {
+	var message AsyncMessage
+	if err := json.Unmarshal(data, &message); err != nil {
+		appLog.Errorf("Could not decode redis message %s, %s", string(data), err)
+		return nil, false
+	}
+
+	return &message, true
+}
+
+type asyncBackendRoomSubscriberRedis struct {
+	*asyncSubscriberRedis
+	asyncBackendRoomSubscriber
+}
+
+func newAsyncBackendRoomSubscriberRedis(key string, client *RedisClient) (*asyncBackendRoomSubscriberRedis, error) {
+	sub, err := newAsyncSubscriberRedis(key, client)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &asyncBackendRoomSubscriberRedis{
+		asyncSubscriberRedis: sub,
+	}
+	result.processMessage = result.doProcessMessage
+	go result.run()
+	return result, nil
+}
+
+func (s *asyncBackendRoomSubscriberRedis) doProcessMessage(data []byte) {
+	if message, ok := s.decode(data); ok {
+		s.processBackendRoomRequest(message)
+	}
+}
+
+type asyncRoomSubscriberRedis struct {
+	asyncRoomSubscriber
+	*asyncSubscriberRedis
+}
+
+func newAsyncRoomSubscriberRedis(key string, client *RedisClient) (*asyncRoomSubscriberRedis, error) {
+	sub, err := newAsyncSubscriberRedis(key, client)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &asyncRoomSubscriberRedis{
+		asyncSubscriberRedis: sub,
+	}
+	result.processMessage = result.doProcessMessage
+	go result.run()
+	return result, nil
+}
+
+func (s *asyncRoomSubscriberRedis) doProcessMessage(data []byte) {
+	if message, ok := s.decode(data); ok {
+		s.processAsyncRoomMessage(message)
+	}
+}
+
+type asyncUserSubscriberRedis struct {
+	*asyncSubscriberRedis
+	asyncUserSubscriber
+}
+
+func newAsyncUserSubscriberRedis(key string, client *RedisClient) (*asyncUserSubscriberRedis, error) {
+	sub, err := newAsyncSubscriberRedis(key, client)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &asyncUserSubscriberRedis{
+		asyncSubscriberRedis: sub,
+	}
+	result.processMessage = result.doProcessMessage
+	go result.run()
+	return result, nil
+}
+
+func (s *asyncUserSubscriberRedis) doProcessMessage(data []byte) {
+	if message, ok := s.decode(data); ok {
+		s.processAsyncUserMessage(message)
+	}
+}
+
+type asyncSessionSubscriberRedis struct {
+	*asyncSubscriberRedis
+	asyncSessionSubscriber
+}
+
+func newAsyncSessionSubscriberRedis(key string, client *RedisClient) (*asyncSessionSubscriberRedis, error) {
+	sub, err := newAsyncSubscriberRedis(key, client)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &asyncSessionSubscriberRedis{
+		asyncSubscriberRedis: sub,
+	}
+	result.processMessage = result.doProcessMessage
+	go result.run()
+	return result, nil
+}
+
+func (s *asyncSessionSubscriberRedis) doProcessMessage(data []byte) {
+	if message, ok := s.decode(data); ok {
+		s.processAsyncSessionMessage(message)
+	}
+}
+
+// asyncEventsRedis distributes the events through the pub/sub channels of a
+// Redis server. The channels use the same names as the NATS subjects with an
+// additional prefix.
+type asyncEventsRedis struct {
+	mu     sync.Mutex
+	client *RedisClient
+	prefix string
+
+	backendRoomSubscriptions map[string]*asyncBackendRoomSubscriberRedis
+	roomSubscriptions        map[string]*asyncRoomSubscriberRedis
+	userSubscriptions        map[string]*asyncUserSubscriberRedis
+	sessionSubscriptions     map[string]*asyncSessionSubscriberRedis
+}
+
+func NewAsyncEventsRedis(client *RedisClient, prefix string) (AsyncEvents, error) {
+	events := &asyncEventsRedis{
+		client: client,
+		prefix: prefix,
+
+		backendRoomSubscriptions: make(map[string]*asyncBackendRoomSubscriberRedis),
+		roomSubscriptions:        make(map[string]*asyncRoomSubscriberRedis),
+		userSubscriptions:        make(map[string]*asyncUserSubscriberRedis),
+		sessionSubscriptions:     make(map[string]*asyncSessionSubscriberRedis),
+	}
+	return events, nil
+}
+
+func (e *asyncEventsRedis) GetServerInfoRedis() *BackendServerInfoRedis {
+	return &BackendServerInfoRedis{
+		Url:       e.client.String(),
+		Connected: e.client.IsConnected(),
+	}
+}
+
+func (e *asyncEventsRedis) Close() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, sub := range e.backendRoomSubscriptions {
+		sub.close()
+	}
+	for _, sub := range e.roomSubscriptions {
+		sub.close()
+	}
+	for _, sub := range e.userSubscriptions {
+		sub.close()
+	}
+	for _, sub := range e.sessionSubscriptions {
+		sub.close()
+	}
+	clear(e.backendRoomSubscriptions)
+	clear(e.roomSubscriptions)
+	clear(e.userSubscriptions)
+	clear(e.sessionSubscriptions)
+	e.client.Close()
+}
+
+func (e *asyncEventsRedis) RegisterBackendRoomListener(roomId string, backend *Backend, listener AsyncBackendRoomEventListener) error {
+	key := e.prefix + GetSubjectForBackendRoomId(roomId, backend)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	sub, found := e.backendRoomSubscriptions[key]
+	if !found {
+		var err error
+		if sub, err = newAsyncBackendRoomSubscriberRedis(key, e.client); err != nil {
+			return err
+		}
+
+		e.backendRoomSubscriptions[key] = sub
+	}
+	sub.addListener(listener)
+	return nil
+}
+
+func (e *asyncEventsRedis) UnregisterBackendRoomListener(roomId string, backend *Backend, listener AsyncBackendRoomEventListener) {
+	key := e.prefix + GetSubjectForBackendRoomId(roomId, backend)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	sub, found := e.backendRoomSubscriptions[key]
+	if !found {
+		return
+	}
+
+	if !sub.removeListener(listener) {
+		delete(e.backendRoomSubscriptions, key)
+		sub.close()
+	}
+}
+
+func (e *asyncEventsRedis) RegisterRoomListener(roomId string, backend *Backend, listener AsyncRoomEventListener) error {
+	key := e.prefix + GetSubjectForRoomId(roomId, backend)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	sub, found := e.roomSubscriptions[key]
+	if !found {
+		var err error
+		if sub, err = newAsyncRoomSubscriberRedis(key, e.client); err != nil {
+			return err
+		}
+
+		e.roomSubscriptions[key] = sub
+	}
+	sub.addListener(listener)
+	return nil
+}
+
+func (e *asyncEventsRedis) UnregisterRoomListener(roomId string, backend *Backend, listener AsyncRoomEventListener) {
+	key := e.prefix + GetSubjectForRoomId(roomId, backend)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	sub, found := e.roomSubscriptions[key]
+	if !found {
+		return
+	}
+
+	if !sub.removeListener(listener) {
+		delete(e.roomSubscriptions, key)
+		sub.close()
+	}
+}
+
+func (e *asyncEventsRedis) RegisterUserListener(userId string, backend *Backend, listener AsyncUserEventListener) error {
+	key := e.prefix + GetSubjectForUserId(userId, backend)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	sub, found := e.userSubscriptions[key]
+	if !found {
+		var err error
+		if sub, err = newAsyncUserSubscriberRedis(key, e.client); err != nil {
+			return err
+		}
+
+		e.userSubscriptions[key] = sub
+	}
+	sub.addListener(listener)
+	return nil
+}
+
+func (e *asyncEventsRedis) UnregisterUserListener(userId string, backend *Backend, listener AsyncUserEventListener) {
+	key := e.prefix + GetSubjectForUserId(userId, backend)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	sub, found := e.userSubscriptions[key]
+	if !found {
+		return
+	}
+
+	if !sub.removeListener(listener) {
+		delete(e.userSubscriptions, key)
+		sub.close()
+	}
+}
+
+func (e *asyncEventsRedis) RegisterSessionListener(sessionId PublicSessionId, backend *Backend, listener AsyncSessionEventListener) error {
+	key := e.prefix + GetSubjectForSessionId(sessionId, backend)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	sub, found := e.sessionSubscriptions[key]
+	if !found {
+		var err error
+		if sub, err = newAsyncSessionSubscriberRedis(key, e.client); err != nil {
+			return err
+		}
+
+		e.sessionSubscriptions[key] = sub
+	}
+	sub.addListener(listener)
+	return nil
+}
+
+func (e *asyncEventsRedis) UnregisterSessionListener(sessionId PublicSessionId, backend *Backend, listener AsyncSessionEventListener) {
+	key := e.prefix + GetSubjectForSessionId(sessionId, backend)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	sub, found := e.sessionSubscriptions[key]
+	if !found {
+		return
+	}
+
+	if !sub.removeListener(listener) {
+		delete(e.sessionSubscriptions, key)
+		sub.close()
+	}
+}
+
+func (e *asyncEventsRedis) publish(channel string, message *AsyncMessage) error {
+	message.SendTime = time.Now()
+	message.SendServerId = GrpcServerId
+	data, err := bufferPool.MarshalMessage(message)
+	if err != nil {
+		return err
+	}
+	defer bufferPool.Put(data)
+
+	return e.client.Publish(e.prefix+channel, data.Bytes())
+}
+
+func (e *asyncEventsRedis) PublishBackendRoomMessage(roomId string, backend *Backend, message *AsyncMessage) error {
+	return e.publish(GetSubjectForBackendRoomId(roomId, backend), message)
+}
+
+func (e *asyncEventsRedis) PublishRoomMessage(roomId string, backend *Backend, message *AsyncMessage) error {
+	return e.publish(GetSubjectForRoomId(roomId, backend), message)
+}
+
+func (e *asyncEventsRedis) PublishUserMessage(userId string, backend *Backend, message *AsyncMessage) error {
+	return e.publish(GetSubjectForUserId(userId, backend), message)
+}
+
+func (e *asyncEventsRedis) PublishSessionMessage(sessionId PublicSessionId, backend *Backend, message *AsyncMessage) error {
+	return e.publish(GetSubjectForSessionId(sessionId, backend), message)
+}
+
+func (e *asyncEventsRedis) SetOnReconnected(f func()) {
+	e.client.SetOnReconnected(f)
+}
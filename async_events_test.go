@@ -25,7 +25,11 @@ import (
 	"context"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/dlintw/goconf"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -33,6 +37,7 @@ var (
 	eventBackendsForTest = []string{
 		"loopback",
 		"nats",
+		"redis",
 	}
 )
 
@@ -40,6 +45,8 @@ func getAsyncEventsForTest(t *testing.T) AsyncEvents {
 	var events AsyncEvents
 	if strings.HasSuffix(t.Name(), "/nats") {
 		events = getRealAsyncEventsForTest(t)
+	} else if strings.HasSuffix(t.Name(), "/redis") {
+		events = getRedisAsyncEventsForTest(t)
 	} else {
 		events = getLoopbackAsyncEventsForTest(t)
 	}
@@ -58,6 +65,16 @@ func getRealAsyncEventsForTest(t *testing.T) AsyncEvents {
 	return events
 }
 
+func getRedisAsyncEventsForTest(t *testing.T) AsyncEvents {
+	server := startLocalRedisServer(t)
+	config := goconf.NewConfigFile()
+	config.AddOption("events", "type", AsyncEventsTypeRedis)
+	config.AddOption("redis", "url", server.URL())
+	events, err := NewAsyncEventsFromConfig(config)
+	require.NoError(t, err)
+	return events
+}
+
 func getLoopbackAsyncEventsForTest(t *testing.T) AsyncEvents {
 	events, err := NewAsyncEvents(NatsLoopbackUrl)
 	if err != nil {
@@ -73,3 +90,99 @@ func getLoopbackAsyncEventsForTest(t *testing.T) AsyncEvents {
 	})
 	return events
 }
+
+func TestAsyncEventsFromConfig(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	require := require.New(t)
+
+	config := goconf.NewConfigFile()
+	config.AddOption("events", "type", "unknown")
+	_, err := NewAsyncEventsFromConfig(config)
+	assert.Error(err)
+
+	config.AddOption("nats", "url", NatsLoopbackUrl)
+	config.AddOption("events", "type", AsyncEventsTypeNats)
+	events, err := NewAsyncEventsFromConfig(config)
+	require.NoError(err)
+	assert.IsType(&asyncEventsNats{}, events)
+	events.Close()
+
+	server := startLocalRedisServer(t)
+	config.AddOption("events", "type", AsyncEventsTypeRedis)
+	config.AddOption("redis", "url", server.URL())
+	events, err = NewAsyncEventsFromConfig(config)
+	require.NoError(err)
+	defer events.Close()
+	if assert.IsType(&asyncEventsRedis{}, events) {
+		redis := events.(*asyncEventsRedis)
+		assert.Equal(DefaultRedisPrefix, redis.prefix)
+		info := redis.GetServerInfoRedis()
+		assert.Equal(server.URL(), info.Url)
+		assert.True(info.Connected)
+	}
+	assert.Equal(healthOk("connected"), getHealthEvents(events))
+}
+
+type testAsyncSessionListener struct {
+	ch chan *AsyncMessage
+}
+
+func (l *testAsyncSessionListener) ProcessAsyncSessionMessage(message *AsyncMessage) {
+	l.ch <- message
+}
+
+func TestAsyncEventsNatsJetStream(t *testing.T) {
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	require := require.New(t)
+	server := startLocalNatsServerJetStream(t)
+
+	config := goconf.NewConfigFile()
+	config.AddOption("events", "type", AsyncEventsTypeNats)
+	config.AddOption("nats", "url", server.ClientURL())
+	config.AddOption("nats", "jetstream", "true")
+	config.AddOption("nats", "retention", "unknown")
+	_, err := NewAsyncEventsFromConfig(config)
+	assert.ErrorContains(err, "unsupported JetStream retention policy")
+
+	config.AddOption("nats", "retention", "interest")
+	config.AddOption("nats", "maxage", "30")
+	events, err := NewAsyncEventsFromConfig(config)
+	require.NoError(err)
+	defer events.Close()
+
+	client, ok := events.(*asyncEventsNats).client.(*natsClient)
+	require.True(ok)
+	require.True(client.IsDurable())
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	stream, err := client.js.Stream(ctx, DefaultNatsJetStreamName)
+	require.NoError(err)
+	info, err := stream.Info(ctx)
+	require.NoError(err)
+	assert.Equal(jetstream.InterestPolicy, info.Config.Retention)
+	assert.Equal(30*time.Second, info.Config.MaxAge)
+
+	sessionId := PublicSessionId("the-session")
+	listener := &testAsyncSessionListener{
+		ch: make(chan *AsyncMessage, 1),
+	}
+	require.NoError(events.RegisterSessionListener(sessionId, nil, listener))
+	defer events.UnregisterSessionListener(sessionId, nil, listener)
+
+	require.NoError(events.PublishSessionMessage(sessionId, nil, &AsyncMessage{
+		Type:    "message",
+		Message: &ServerMessage{Type: "event"},
+	}))
+	select {
+	case msg := <-listener.ch:
+		assert.Equal("message", msg.Type)
+		assert.Equal(GrpcServerId, msg.SendServerId)
+	case <-ctx.Done():
+		assert.Fail("no message received")
+	}
+}
@@ -26,13 +26,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/dlintw/goconf"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var (
@@ -54,6 +57,7 @@ type BackendClient struct {
 
 	pool         *HttpClientPool
 	capabilities *Capabilities
+	oidcKeys     *OidcKeys
 	buffers      BufferPool
 }
 
@@ -65,7 +69,7 @@ func NewBackendClient(config *goconf.ConfigFile, maxConcurrentRequestsPerHost in
 
 	skipverify, _ := config.GetBool("backend", "skipverify")
 	if skipverify {
-		log.Println("WARNING: Backend verification is disabled!")
+		backendLog.Warnf("Backend verification is disabled!")
 	}
 
 	pool, err := NewHttpClientPool(maxConcurrentRequestsPerHost, skipverify)
@@ -84,6 +88,7 @@ func NewBackendClient(config *goconf.ConfigFile, maxConcurrentRequestsPerHost in
 
 		pool:         pool,
 		capabilities: capabilities,
+		oidcKeys:     NewOidcKeys(version, pool),
 	}, nil
 }
 
@@ -117,7 +122,19 @@ func isOcsRequest(u *url.URL) bool {
 
 // PerformJSONRequest sends a JSON POST request to the given url and decodes
 // the result into "response".
-func (b *BackendClient) PerformJSONRequest(ctx context.Context, u *url.URL, request any, response any) error {
+// getBackendClientEndpoint returns the type of request that is sent to the
+// backend, used as label in metrics.
+func getBackendClientEndpoint(request any) string {
+	if r, ok := request.(*BackendClientRequest); ok {
+		switch r.Type {
+		case "auth", "room", "ping", "session":
+			return r.Type
+		}
+	}
+	return "other"
+}
+
+func (b *BackendClient) PerformJSONRequest(ctx context.Context, u *url.URL, request any, response any) (err error) {
 	if u == nil {
 		return fmt.Errorf("no url passed to perform JSON request %+v", request)
 	}
@@ -127,6 +144,17 @@ func (b *BackendClient) PerformJSONRequest(ctx context.Context, u *url.URL, requ
 		return fmt.Errorf("no backend configured for %s", u)
 	}
 
+	ctx, span := tracer.Start(ctx, "backend.request",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("signaling.backend", backend.Id()),
+			attribute.String("server.address", u.Host),
+		),
+	)
+	defer func() {
+		endSpan(span, err)
+	}()
+
 	var requestUrl *url.URL
 	if b.capabilities.HasCapabilityFeature(ctx, u, FeatureSignalingV3Api) {
 		newUrl := *u
@@ -137,23 +165,30 @@ func (b *BackendClient) PerformJSONRequest(ctx context.Context, u *url.URL, requ
 		requestUrl = u
 	}
 
+	release, err := backend.AcquireRequest(ctx)
+	if err != nil {
+		backendLog.Errorf("Could not send request to backend %s: %s", backend.Id(), err)
+		return err
+	}
+	defer release()
+
 	c, pool, err := b.pool.Get(ctx, u)
 	if err != nil {
-		log.Printf("Could not get client for host %s: %s", u.Host, err)
+		backendLog.Errorf("Could not get client for host %s: %s", u.Host, err)
 		return err
 	}
 	defer pool.Put(c)
 
 	data, err := b.buffers.MarshalAsJSON(request)
 	if err != nil {
-		log.Printf("Could not marshal request %+v: %s", request, err)
+		backendLog.Errorf("Could not marshal request %+v: %s", request, err)
 		return err
 	}
 
 	defer b.buffers.Put(data)
 	req, err := http.NewRequestWithContext(ctx, "POST", requestUrl.String(), data)
 	if err != nil {
-		log.Printf("Could not create request to %s: %s", requestUrl, err)
+		backendLog.Errorf("Could not create request to %s: %s", requestUrl, err)
 		return err
 	}
 	req.Header.Set("Content-Type", "application/json")
@@ -163,39 +198,48 @@ func (b *BackendClient) PerformJSONRequest(ctx context.Context, u *url.URL, requ
 	if b.hub != nil {
 		req.Header.Set("X-Spreed-Signaling-Features", strings.Join(b.hub.info.Features, ", "))
 	}
+	injectTraceHeaders(ctx, req.Header)
 
 	// Add checksum so the backend can validate the request.
 	AddBackendChecksum(req, data.Bytes(), backend.Secret())
 
+	backendLabel := statsBackendLabel(backend)
+	endpoint := getBackendClientEndpoint(request)
+	inFlight := statsBackendClientRequestsInFlight.WithLabelValues(backendLabel, endpoint)
+	inFlight.Inc()
+	defer inFlight.Dec()
+
 	start := time.Now()
 	resp, err := c.Do(req)
 	end := time.Now()
 	duration := end.Sub(start)
 	statsBackendClientRequests.WithLabelValues(backend.Id()).Inc()
-	statsBackendClientDuration.WithLabelValues(backend.Id()).Observe(duration.Seconds())
+	observeWithExemplar(ctx, statsBackendClientDuration.WithLabelValues(backend.Id()), duration.Seconds())
 	if err != nil {
+		status := "unknown"
 		if errors.Is(err, context.DeadlineExceeded) {
-			statsBackendClientError.WithLabelValues(backend.Id(), "timeout").Inc()
+			status = "timeout"
 		} else if errors.Is(err, context.Canceled) {
-			statsBackendClientError.WithLabelValues(backend.Id(), "canceled").Inc()
-		} else {
-			statsBackendClientError.WithLabelValues(backend.Id(), "unknown").Inc()
+			status = "canceled"
 		}
-		log.Printf("Could not send request %s to %s: %s", data.String(), req.URL, err)
+		statsBackendClientError.WithLabelValues(backend.Id(), status).Inc()
+		observeWithExemplar(ctx, statsBackendClientEndpointDuration.WithLabelValues(backendLabel, endpoint, status), duration.Seconds())
+		backendLog.Eventf(RecentEventTypeBackend, slog.LevelError, "Could not send request %s to %s: %s", data.String(), req.URL, err)
 		return err
 	}
 	defer resp.Body.Close()
+	observeWithExemplar(ctx, statsBackendClientEndpointDuration.WithLabelValues(backendLabel, endpoint, strconv.Itoa(resp.StatusCode)), duration.Seconds())
 
 	ct := resp.Header.Get("Content-Type")
 	if !strings.HasPrefix(ct, "application/json") {
-		log.Printf("Received unsupported content-type from %s for %s: %s (%s)", req.URL, data.String(), ct, resp.Status)
+		backendLog.Eventf(RecentEventTypeBackend, slog.LevelWarn, "Received unsupported content-type from %s for %s: %s (%s)", req.URL, data.String(), ct, resp.Status)
 		statsBackendClientError.WithLabelValues(backend.Id(), "invalid_content_type").Inc()
 		return ErrUnsupportedContentType
 	}
 
 	body, err := b.buffers.ReadAll(resp.Body)
 	if err != nil {
-		log.Printf("Could not read response body from %s for %s: %s", req.URL, data.String(), err)
+		backendLog.Errorf("Could not read response body from %s for %s: %s", req.URL, data.String(), err)
 		statsBackendClientError.WithLabelValues(backend.Id(), "error_reading_body").Inc()
 		return err
 	}
@@ -213,29 +257,29 @@ func (b *BackendClient) PerformJSONRequest(ctx context.Context, u *url.URL, requ
 		// }
 		var ocs OcsResponse
 		if err := json.Unmarshal(body.Bytes(), &ocs); err != nil {
-			log.Printf("Could not decode OCS response %s from %s: %s", body.String(), req.URL, err)
+			backendLog.Errorf("Could not decode OCS response %s from %s: %s", body.String(), req.URL, err)
 			statsBackendClientError.WithLabelValues(backend.Id(), "error_decoding_ocs").Inc()
 			return err
 		} else if ocs.Ocs == nil || len(ocs.Ocs.Data) == 0 {
-			log.Printf("Incomplete OCS response %s from %s", body.String(), req.URL)
+			backendLog.Infof("Incomplete OCS response %s from %s", body.String(), req.URL)
 			statsBackendClientError.WithLabelValues(backend.Id(), "error_incomplete_ocs").Inc()
 			return ErrIncompleteResponse
 		}
 
 		switch ocs.Ocs.Meta.StatusCode {
 		case http.StatusTooManyRequests:
-			log.Printf("Throttled OCS response %s from %s", body.String(), req.URL)
+			backendLog.Eventf(RecentEventTypeBackend, slog.LevelInfo, "Throttled OCS response %s from %s", body.String(), req.URL)
 			statsBackendClientError.WithLabelValues(backend.Id(), "throttled").Inc()
 			return ErrThrottledResponse
 		}
 
 		if err := json.Unmarshal(ocs.Ocs.Data, response); err != nil {
-			log.Printf("Could not decode OCS response body %s from %s: %s", string(ocs.Ocs.Data), req.URL, err)
+			backendLog.Errorf("Could not decode OCS response body %s from %s: %s", string(ocs.Ocs.Data), req.URL, err)
 			statsBackendClientError.WithLabelValues(backend.Id(), "error_decoding_ocs_data").Inc()
 			return err
 		}
 	} else if err := json.Unmarshal(body.Bytes(), response); err != nil {
-		log.Printf("Could not decode response body %s from %s: %s", body.String(), req.URL, err)
+		backendLog.Errorf("Could not decode response body %s from %s: %s", body.String(), req.URL, err)
 		statsBackendClientError.WithLabelValues(backend.Id(), "error_decoding_body").Inc()
 		return err
 	}
@@ -45,11 +45,26 @@ var (
 		Name:      "requests_errors_total",
 		Help:      "The total number of backend client requests that had an error",
 	}, []string{"backend", "error"})
+	statsBackendClientEndpointDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "signaling",
+		Subsystem: "backend_client",
+		Name:      "endpoint_requests_duration",
+		Help:      "The duration of backend client requests in seconds by endpoint and status",
+		Buckets:   prometheus.ExponentialBucketsRange(0.01, 30, 30),
+	}, []string{"backend", "endpoint", "status"})
+	statsBackendClientRequestsInFlight = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "signaling",
+		Subsystem: "backend_client",
+		Name:      "requests_in_flight",
+		Help:      "The current number of backend client requests in flight",
+	}, []string{"backend", "endpoint"})
 
 	backendClientStats = []prometheus.Collector{
 		statsBackendClientRequests,
 		statsBackendClientDuration,
 		statsBackendClientError,
+		statsBackendClientEndpointDuration,
+		statsBackendClientRequestsInFlight,
 	}
 )
 
@@ -30,6 +30,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/dlintw/goconf"
 	"github.com/gorilla/mux"
@@ -225,3 +226,108 @@ func TestHandleThrottled(t *testing.T) {
 		assert.ErrorIs(err, ErrThrottledResponse)
 	}
 }
+
+func TestBackendClientEndpointStats(t *testing.T) {
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	r := mux.NewRouter()
+	r.HandleFunc("/ocs/v2.php/one", func(w http.ResponseWriter, r *http.Request) {
+		returnOCS(t, w, []byte("{}"))
+	})
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	u, err := url.Parse(server.URL + "/ocs/v2.php/one")
+	require.NoError(err)
+
+	config := goconf.NewConfigFile()
+	config.AddOption("backend", "allowed", u.Host)
+	config.AddOption("backend", "secret", string(testBackendSecret))
+	if u.Scheme == "http" {
+		config.AddOption("backend", "allowhttp", "true")
+	}
+	client, err := NewBackendClient(config, 1, "0.0", nil)
+	require.NoError(err)
+
+	backend := statsBackendLabel(client.backends.GetBackend(u))
+	ctx := context.Background()
+	request := NewBackendClientPingRequest("room", nil)
+	var response BackendClientResponse
+	require.NoError(client.PerformJSONRequest(ctx, u, request, &response))
+
+	assert.True(statsBackendClientEndpointDuration.DeleteLabelValues(backend, "ping", "200"))
+	checkStatsValue(t, statsBackendClientRequestsInFlight.WithLabelValues(backend, "ping"), 0)
+
+	assert.Equal("auth", getBackendClientEndpoint(NewBackendClientAuthRequest(nil)))
+	assert.Equal("other", getBackendClientEndpoint(map[string]string{"type": "auth"}))
+}
+
+func TestBackendClient_RequestPool(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+
+	blocked := make(chan struct{})
+	r := mux.NewRouter()
+	r.HandleFunc("/{backend}/ocs/v2.php/{action}", func(w http.ResponseWriter, r *http.Request) {
+		if mux.Vars(r)["action"] == "slow" {
+			<-blocked
+		}
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(err)
+		returnOCS(t, w, body)
+	})
+
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	config := goconf.NewConfigFile()
+	config.AddOption("backend", "backends", "backend1, backend2")
+	config.AddOption("backend1", "url", server.URL+"/one")
+	config.AddOption("backend1", "secret", string(testBackendSecret))
+	config.AddOption("backend1", "concurrentrequests", "1")
+	config.AddOption("backend2", "url", server.URL+"/two")
+	config.AddOption("backend2", "secret", string(testBackendSecret))
+	client, err := NewBackendClient(config, 4, "0.0", nil)
+	require.NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	request := map[string]string{
+		"foo": "bar",
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var response map[string]string
+		if assert.NoError(client.PerformJSONRequest(ctx, mustParse(server.URL+"/one/ocs/v2.php/slow"), request, &response)) {
+			assert.Equal(request, response)
+		}
+	}()
+
+	assert.Eventually(func() bool {
+		return len(client.GetBackend(mustParse(server.URL+"/one/")).requestPool.slots) == 1
+	}, testTimeout, time.Millisecond)
+
+	// The slow request is blocking further requests to the same backend.
+	ctx2, cancel2 := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel2()
+	var response map[string]string
+	err = client.PerformJSONRequest(ctx2, mustParse(server.URL+"/one/ocs/v2.php/fast"), request, &response)
+	assert.ErrorIs(err, context.DeadlineExceeded)
+
+	// Requests to other backends on the same host are not affected.
+	if assert.NoError(client.PerformJSONRequest(ctx, mustParse(server.URL+"/two/ocs/v2.php/fast"), request, &response)) {
+		assert.Equal(request, response)
+	}
+
+	close(blocked)
+	<-done
+	if assert.NoError(client.PerformJSONRequest(ctx, mustParse(server.URL+"/one/ocs/v2.php/fast"), request, &response)) {
+		assert.Equal(request, response)
+	}
+}
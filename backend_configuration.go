@@ -23,11 +23,14 @@ package signaling
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"net/url"
+	"path"
 	"slices"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/dlintw/goconf"
 )
@@ -37,12 +40,98 @@ const (
 	BackendTypeEtcd   = "etcd"
 
 	DefaultBackendType = BackendTypeStatic
+
+	// Features that can be disabled for a backend.
+	BackendFeatureDialout         = "dialout"
+	BackendFeatureTransientData   = "transient-data"
+	BackendFeatureVirtualSessions = "virtual-sessions"
+	BackendFeatureFederation      = "federation"
+	BackendFeatureScreensharing   = "screensharing"
 )
 
 var (
 	SessionLimitExceeded = NewError("session_limit_exceeded", "Too many sessions connected for this backend.")
+	FeatureDisabled      = NewError("feature_disabled", "The feature is disabled for this backend.")
+	NumberNotAllowed     = NewError("number_not_allowed", "Dialout to this number is not allowed.")
+
+	knownBackendFeatures = []string{
+		BackendFeatureDialout,
+		BackendFeatureTransientData,
+		BackendFeatureVirtualSessions,
+		BackendFeatureFederation,
+		BackendFeatureScreensharing,
+	}
+
+	// Server features that will not be announced to clients of backends that
+	// have the corresponding feature disabled.
+	backendServerFeatures = map[string][]string{
+		BackendFeatureDialout:         {ServerFeatureDialout},
+		BackendFeatureTransientData:   {ServerFeatureTransientData, ServerFeatureTransientNamespaces},
+		BackendFeatureVirtualSessions: {ServerFeatureInternalVirtualSessions, ServerFeatureInternalVirtualSessionsBulk},
+		BackendFeatureFederation:      {ServerFeatureFederation},
+	}
 )
 
+// parseDisabledBackendFeatures returns the sorted list of known features from
+// the given list. Unknown features are logged and ignored.
+func parseDisabledBackendFeatures(id string, features []string) []string {
+	var result []string
+	for _, feature := range features {
+		feature = strings.ToLower(strings.TrimSpace(feature))
+		if feature == "" {
+			continue
+		} else if !slices.Contains(knownBackendFeatures, feature) {
+			backendLog.Infof("Backend %s has unknown feature %s disabled, ignoring", id, feature)
+			continue
+		}
+
+		result = append(result, feature)
+	}
+
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+// parseDialoutAllowedPrefixes returns the sorted list of normalized number
+// prefixes from the given list. Invalid prefixes are logged and ignored.
+func parseDialoutAllowedPrefixes(id string, prefixes []string) []string {
+	var result []string
+	for _, prefix := range prefixes {
+		prefix = NormalizeNumber(prefix)
+		if prefix == "" {
+			continue
+		} else if prefix != "+" && !isValidNumber(prefix) && !isValidNumber(prefix+"0") {
+			backendLog.Warnf("Backend %s has invalid dialout prefix %s, ignoring", id, prefix)
+			continue
+		}
+
+		result = append(result, prefix)
+	}
+
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+// parseDialoutDeniedPatterns returns the sorted list of valid patterns from the
+// given list. Invalid patterns are logged and ignored.
+func parseDialoutDeniedPatterns(id string, patterns []string) []string {
+	var result []string
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		} else if _, err := path.Match(pattern, ""); err != nil {
+			backendLog.Warnf("Backend %s has invalid dialout pattern %s (%s), ignoring", id, pattern, err)
+			continue
+		}
+
+		result = append(result, pattern)
+	}
+
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
 type Backend struct {
 	id     string
 	urls   []string
@@ -53,10 +142,23 @@ type Backend struct {
 	maxStreamBitrate int
 	maxScreenBitrate int
 
+	disabledFeatures []string
+
+	dialoutAllowedPrefixes []string
+	dialoutDeniedPatterns  []string
+
+	oidcIssuer string
+
 	sessionLimit uint64
 	sessionsLock sync.Mutex
 	sessions     map[PublicSessionId]bool
 
+	requestPool *backendPool
+	messagePool *backendPool
+
+	messageRate *rateLimiter
+	joinRate    *rateLimiter
+
 	counted bool
 }
 
@@ -83,7 +185,15 @@ func (b *Backend) Equal(other *Backend) bool {
 		b.allowHttp == other.allowHttp &&
 		b.maxStreamBitrate == other.maxStreamBitrate &&
 		b.maxScreenBitrate == other.maxScreenBitrate &&
+		b.oidcIssuer == other.oidcIssuer &&
 		b.sessionLimit == other.sessionLimit &&
+		b.requestPool.Size() == other.requestPool.Size() &&
+		b.messagePool.Size() == other.messagePool.Size() &&
+		b.messageRate.Limit() == other.messageRate.Limit() &&
+		b.joinRate.Limit() == other.joinRate.Limit() &&
+		slices.Equal(b.disabledFeatures, other.disabledFeatures) &&
+		slices.Equal(b.dialoutAllowedPrefixes, other.dialoutAllowedPrefixes) &&
+		slices.Equal(b.dialoutDeniedPatterns, other.dialoutDeniedPatterns) &&
 		bytes.Equal(b.secret, other.secret) &&
 		slices.Equal(b.urls, other.urls)
 }
@@ -118,6 +228,95 @@ func (b *Backend) Urls() []string {
 	return b.urls
 }
 
+// OidcIssuer returns the OpenID Connect issuer that signs the Hello v2 tokens
+// of the backend, or an empty string if the tokens are signed with the key from
+// the capabilities of the backend.
+func (b *Backend) OidcIssuer() string {
+	if b == nil {
+		return ""
+	}
+
+	return b.oidcIssuer
+}
+
+// HasFeature returns false if the given feature is disabled for the backend.
+func (b *Backend) HasFeature(feature string) bool {
+	if b == nil {
+		return true
+	}
+
+	_, found := slices.BinarySearch(b.disabledFeatures, feature)
+	return !found
+}
+
+// CheckDialoutNumber returns an error if dialout to the given normalized number
+// is not allowed for the backend.
+func (b *Backend) CheckDialoutNumber(number string) *Error {
+	if b == nil {
+		return nil
+	}
+
+	if len(b.dialoutAllowedPrefixes) > 0 && !slices.ContainsFunc(b.dialoutAllowedPrefixes, func(prefix string) bool {
+		return strings.HasPrefix(number, prefix)
+	}) {
+		return NumberNotAllowed
+	}
+
+	for _, pattern := range b.dialoutDeniedPatterns {
+		if matched, _ := path.Match(pattern, number); matched {
+			return NumberNotAllowed
+		}
+	}
+
+	return nil
+}
+
+// FilterServerInfo removes server features from the welcome message that are
+// disabled for the backend.
+func (b *Backend) FilterServerInfo(info *WelcomeServerMessage) *WelcomeServerMessage {
+	if b == nil || len(b.disabledFeatures) == 0 {
+		return info
+	}
+
+	var remove []string
+	for _, feature := range b.disabledFeatures {
+		if serverFeatures, found := backendServerFeatures[feature]; found {
+			remove = append(remove, serverFeatures...)
+		}
+	}
+	if len(remove) == 0 {
+		return info
+	}
+
+	result := *info
+	result.RemoveFeature(remove...)
+	return &result
+}
+
+// GetServerConfig returns the effective settings of the backend without the
+// shared secret.
+func (b *Backend) GetServerConfig() BackendServerConfigBackend {
+	return BackendServerConfigBackend{
+		Id:   b.id,
+		Urls: slices.Clone(b.urls),
+
+		AllowHttp:          b.allowHttp,
+		SessionLimit:       b.sessionLimit,
+		ConcurrentRequests: b.requestPool.Size(),
+		ConcurrentMessages: b.messagePool.Size(),
+		MessageRate:        b.messageRate.Limit(),
+		JoinRate:           b.joinRate.Limit(),
+		MaxStreamBitrate:   b.maxStreamBitrate,
+		MaxScreenBitrate:   b.maxScreenBitrate,
+		DisabledFeatures:   slices.Clone(b.disabledFeatures),
+
+		DialoutAllowedPrefixes: slices.Clone(b.dialoutAllowedPrefixes),
+		DialoutDeniedPatterns:  slices.Clone(b.dialoutDeniedPatterns),
+
+		OidcIssuer: b.oidcIssuer,
+	}
+}
+
 func (b *Backend) Limit() int {
 	return int(b.sessionLimit)
 }
@@ -128,6 +327,15 @@ func (b *Backend) Len() int {
 	return len(b.sessions)
 }
 
+// NewSessionLimitExceeded returns the error for an exceeded session limit with
+// the number of connected sessions and the limit as parameters.
+func NewSessionLimitExceeded(count uint64, limit uint64) *Error {
+	return NewErrorWithParams(SessionLimitExceeded.Code, "Too many sessions connected for this backend ({count}/{limit}).", StringMap{
+		"count": count,
+		"limit": limit,
+	})
+}
+
 func (b *Backend) AddSession(session Session) error {
 	if session.ClientType() == HelloClientTypeInternal || session.ClientType() == HelloClientTypeVirtual {
 		// Internal and virtual sessions are not counting to the limit.
@@ -143,15 +351,58 @@ func (b *Backend) AddSession(session Session) error {
 	defer b.sessionsLock.Unlock()
 	if b.sessions == nil {
 		b.sessions = make(map[PublicSessionId]bool)
-	} else if uint64(len(b.sessions)) >= b.sessionLimit {
+	} else if count := uint64(len(b.sessions)); count >= b.sessionLimit {
 		statsBackendLimitExceededTotal.WithLabelValues(b.id).Inc()
-		return SessionLimitExceeded
+		return NewSessionLimitExceeded(count, b.sessionLimit)
 	}
 
 	b.sessions[session.PublicId()] = true
 	return nil
 }
 
+// AcquireRequest waits until a request may be sent to the backend. The returned
+// function must be called after the request was performed.
+func (b *Backend) AcquireRequest(ctx context.Context) (func(), error) {
+	if b == nil {
+		return noopRelease, nil
+	}
+
+	return b.requestPool.Acquire(ctx)
+}
+
+// AcquireMessage waits until a message from a session of the backend may be
+// processed. The returned function must be called after the message was
+// processed.
+func (b *Backend) AcquireMessage(ctx context.Context) (func(), error) {
+	if b == nil {
+		return noopRelease, nil
+	}
+
+	return b.messagePool.Acquire(ctx)
+}
+
+// AllowMessage returns false if the sessions of the backend sent more messages
+// than the configured rate allows.
+func (b *Backend) AllowMessage(now time.Time) bool {
+	if b == nil || b.messageRate.Allow(now) {
+		return true
+	}
+
+	statsBackendThrottledTotal.WithLabelValues(b.id, "message").Inc()
+	return false
+}
+
+// AllowJoin returns false if the sessions of the backend joined more rooms
+// than the configured rate allows.
+func (b *Backend) AllowJoin(now time.Time) bool {
+	if b == nil || b.joinRate.Allow(now) {
+		return true
+	}
+
+	statsBackendThrottledTotal.WithLabelValues(b.id, "join").Inc()
+	return false
+}
+
 func (b *Backend) RemoveSession(session Session) {
 	b.sessionsLock.Lock()
 	defer b.sessionsLock.Unlock()
@@ -220,6 +471,18 @@ type BackendConfiguration struct {
 	storage BackendStorage
 }
 
+// WaitForInitialized waits until the initial list of backends was loaded if
+// they are retrieved from a remote storage.
+func (c *BackendConfiguration) WaitForInitialized(ctx context.Context) error {
+	if s, ok := c.storage.(interface {
+		WaitForInitialized(ctx context.Context) error
+	}); ok {
+		return s.WaitForInitialized(ctx)
+	}
+
+	return nil
+}
+
 func NewBackendConfiguration(config *goconf.ConfigFile, etcdClient *EtcdClient) (*BackendConfiguration, error) {
 	backendType, _ := config.GetString("backend", "backendtype")
 	if backendType == "" {
@@ -271,6 +534,20 @@ func (b *BackendConfiguration) GetBackends() []*Backend {
 	return b.storage.GetBackends()
 }
 
+// GetServerConfigBackends returns the settings of all backends, including
+// backends loaded from etcd.
+func (b *BackendConfiguration) GetServerConfigBackends() []BackendServerConfigBackend {
+	backends := b.GetBackends()
+	result := make([]BackendServerConfigBackend, 0, len(backends))
+	for _, backend := range backends {
+		result = append(result, backend.GetServerConfig())
+	}
+	slices.SortFunc(result, func(a, b BackendServerConfigBackend) int {
+		return strings.Compare(a.Id, b.Id)
+	})
+	return result
+}
+
 func (b *BackendConfiguration) IsUrlAllowed(u *url.URL) bool {
 	if u == nil {
 		// Reject all invalid URLs.
@@ -38,6 +38,30 @@ var (
 		Name:      "session_limit_exceeded_total",
 		Help:      "The number of times the session limit exceeded",
 	}, []string{"backend"})
+	statsBackendPoolSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "signaling",
+		Subsystem: "backend",
+		Name:      "pool_size",
+		Help:      "The number of concurrent operations allowed for a backend",
+	}, []string{"backend", "pool"})
+	statsBackendPoolWaitedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "signaling",
+		Subsystem: "backend",
+		Name:      "pool_waited_total",
+		Help:      "The total number of operations that had to wait for a free slot in the pool of a backend",
+	}, []string{"backend", "pool"})
+	statsBackendPoolRejectedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "signaling",
+		Subsystem: "backend",
+		Name:      "pool_rejected_total",
+		Help:      "The total number of operations that were aborted while waiting for a free slot in the pool of a backend",
+	}, []string{"backend", "pool"})
+	statsBackendThrottledTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "signaling",
+		Subsystem: "backend",
+		Name:      "throttled_total",
+		Help:      "The total number of requests rejected because the rate limit of a backend was exceeded",
+	}, []string{"backend", "type"})
 	statsBackendsCurrent = prometheus.NewGauge(prometheus.GaugeOpts{
 		Namespace: "signaling",
 		Subsystem: "backend",
@@ -48,6 +72,10 @@ var (
 	backendConfigurationStats = []prometheus.Collector{
 		statsBackendLimit,
 		statsBackendLimitExceededTotal,
+		statsBackendPoolSize,
+		statsBackendPoolWaitedTotal,
+		statsBackendPoolRejectedTotal,
+		statsBackendThrottledTotal,
 		statsBackendsCurrent,
 	}
 )
@@ -62,8 +90,19 @@ func updateBackendStats(backend *Backend) {
 	} else {
 		statsBackendLimit.DeleteLabelValues(backend.id)
 	}
+	for poolType, pool := range map[BackendPoolType]*backendPool{
+		BackendPoolRequests: backend.requestPool,
+		BackendPoolMessages: backend.messagePool,
+	} {
+		if size := pool.Size(); size > 0 {
+			statsBackendPoolSize.WithLabelValues(backend.id, string(poolType)).Set(float64(size))
+		} else {
+			statsBackendPoolSize.DeleteLabelValues(backend.id, string(poolType))
+		}
+	}
 }
 
 func deleteBackendStats(backend *Backend) {
 	statsBackendLimit.DeleteLabelValues(backend.id)
+	statsBackendPoolSize.DeletePartialMatch(prometheus.Labels{"backend": backend.id})
 }
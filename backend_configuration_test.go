@@ -28,6 +28,7 @@ import (
 	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/dlintw/goconf"
 	"github.com/stretchr/testify/assert"
@@ -809,3 +810,181 @@ func TestBackendConfiguration_EtcdChangeUrls(t *testing.T) {
 	_, found := storage.backends["domain1.invalid"]
 	assert.False(found, "Should have removed host information")
 }
+
+func TestBackendDisabledFeatures(t *testing.T) {
+	ResetStatsValue(t, statsBackendsCurrent)
+
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	require := require.New(t)
+	config := goconf.NewConfigFile()
+	config.AddOption("backend", "backends", "backend1, backend2")
+	config.AddOption("backend", "allowall", "false")
+	config.AddOption("backend1", "url", "http://domain1.invalid")
+	config.AddOption("backend1", "secret", string(testBackendSecret)+"-backend1")
+	config.AddOption("backend1", "disabledfeatures", "Dialout, federation, unknown-feature, screensharing, dialout")
+	config.AddOption("backend2", "url", "http://domain2.invalid")
+	config.AddOption("backend2", "secret", string(testBackendSecret)+"-backend2")
+	cfg, err := NewBackendConfiguration(config, nil)
+	require.NoError(err)
+
+	backend1 := cfg.GetBackend(mustParse("http://domain1.invalid"))
+	require.NotNil(backend1)
+	assert.Equal([]string{
+		BackendFeatureDialout,
+		BackendFeatureFederation,
+		BackendFeatureScreensharing,
+	}, backend1.disabledFeatures)
+	assert.False(backend1.HasFeature(BackendFeatureDialout))
+	assert.False(backend1.HasFeature(BackendFeatureFederation))
+	assert.False(backend1.HasFeature(BackendFeatureScreensharing))
+	assert.True(backend1.HasFeature(BackendFeatureTransientData))
+	assert.True(backend1.HasFeature(BackendFeatureVirtualSessions))
+
+	info := NewWelcomeServerMessage("1.0", DefaultFeatures...)
+	filtered := backend1.FilterServerInfo(info)
+	assert.False(filtered.HasFeature(ServerFeatureDialout))
+	assert.False(filtered.HasFeature(ServerFeatureFederation))
+	assert.True(filtered.HasFeature(ServerFeatureTransientData))
+	// The original message must not be modified.
+	assert.True(info.HasFeature(ServerFeatureDialout))
+	assert.True(info.HasFeature(ServerFeatureFederation))
+
+	backend2 := cfg.GetBackend(mustParse("http://domain2.invalid"))
+	require.NotNil(backend2)
+	assert.Empty(backend2.disabledFeatures)
+	for _, feature := range knownBackendFeatures {
+		assert.True(backend2.HasFeature(feature), "should have feature %s", feature)
+	}
+	assert.Same(info, backend2.FilterServerInfo(info))
+}
+
+func TestBackendPools(t *testing.T) {
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	require := require.New(t)
+	config := goconf.NewConfigFile()
+	config.AddOption("backend", "backends", "backend1, backend2")
+	config.AddOption("backend", "allowall", "false")
+	config.AddOption("backend1", "url", "http://domain1.invalid")
+	config.AddOption("backend1", "secret", string(testBackendSecret)+"-backend1")
+	config.AddOption("backend1", "concurrentrequests", "4")
+	config.AddOption("backend1", "concurrentmessages", "10")
+	config.AddOption("backend2", "url", "http://domain2.invalid")
+	config.AddOption("backend2", "secret", string(testBackendSecret)+"-backend2")
+	config.AddOption("backend2", "concurrentrequests", "-1")
+	cfg, err := NewBackendConfiguration(config, nil)
+	require.NoError(err)
+
+	backend1 := cfg.GetBackend(mustParse("http://domain1.invalid"))
+	require.NotNil(backend1)
+	assert.Equal(4, backend1.requestPool.Size())
+	assert.Equal(10, backend1.messagePool.Size())
+	serverConfig := backend1.GetServerConfig()
+	assert.Equal(4, serverConfig.ConcurrentRequests)
+	assert.Equal(10, serverConfig.ConcurrentMessages)
+
+	backend2 := cfg.GetBackend(mustParse("http://domain2.invalid"))
+	require.NotNil(backend2)
+	assert.Nil(backend2.requestPool)
+	assert.Nil(backend2.messagePool)
+
+	// Changing the pools will update the backend.
+	config.AddOption("backend1", "concurrentmessages", "20")
+	cfg.Reload(config)
+	updated := cfg.GetBackend(mustParse("http://domain1.invalid"))
+	require.NotNil(updated)
+	assert.NotSame(backend1, updated)
+	assert.Equal(4, updated.requestPool.Size())
+	assert.Equal(20, updated.messagePool.Size())
+	assert.Same(backend2, cfg.GetBackend(mustParse("http://domain2.invalid")))
+}
+
+func TestBackendRateLimits(t *testing.T) {
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	require := require.New(t)
+	config := goconf.NewConfigFile()
+	config.AddOption("backend", "backends", "backend1, backend2")
+	config.AddOption("backend", "allowall", "false")
+	config.AddOption("backend1", "url", "http://domain1.invalid")
+	config.AddOption("backend1", "secret", string(testBackendSecret)+"-backend1")
+	config.AddOption("backend1", "messagerate", "2")
+	config.AddOption("backend1", "joinrate", "1")
+	config.AddOption("backend2", "url", "http://domain2.invalid")
+	config.AddOption("backend2", "secret", string(testBackendSecret)+"-backend2")
+	config.AddOption("backend2", "messagerate", "-1")
+	cfg, err := NewBackendConfiguration(config, nil)
+	require.NoError(err)
+
+	backend1 := cfg.GetBackend(mustParse("http://domain1.invalid"))
+	require.NotNil(backend1)
+	serverConfig := backend1.GetServerConfig()
+	assert.Equal(2, serverConfig.MessageRate)
+	assert.Equal(1, serverConfig.JoinRate)
+
+	now := time.Now()
+	assert.True(backend1.AllowMessage(now))
+	assert.True(backend1.AllowMessage(now))
+	assert.False(backend1.AllowMessage(now))
+	assert.True(backend1.AllowMessage(now.Add(time.Second)))
+	assert.True(backend1.AllowJoin(now))
+	assert.False(backend1.AllowJoin(now.Add(time.Second)))
+	assert.True(backend1.AllowJoin(now.Add(time.Minute)))
+
+	backend2 := cfg.GetBackend(mustParse("http://domain2.invalid"))
+	require.NotNil(backend2)
+	assert.Nil(backend2.messageRate)
+	assert.Nil(backend2.joinRate)
+	for range 100 {
+		assert.True(backend2.AllowMessage(now))
+		assert.True(backend2.AllowJoin(now))
+	}
+
+	// Changing the limits will update the backend.
+	config.AddOption("backend1", "messagerate", "5")
+	cfg.Reload(config)
+	updated := cfg.GetBackend(mustParse("http://domain1.invalid"))
+	require.NotNil(updated)
+	assert.NotSame(backend1, updated)
+	assert.Equal(5, updated.GetServerConfig().MessageRate)
+	assert.Same(backend2, cfg.GetBackend(mustParse("http://domain2.invalid")))
+}
+
+func TestBackendDialoutNumbers(t *testing.T) {
+	ResetStatsValue(t, statsBackendsCurrent)
+
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	require := require.New(t)
+	config := goconf.NewConfigFile()
+	config.AddOption("backend", "backends", "backend1, backend2")
+	config.AddOption("backend", "allowall", "false")
+	config.AddOption("backend1", "url", "http://domain1.invalid")
+	config.AddOption("backend1", "secret", string(testBackendSecret)+"-backend1")
+	config.AddOption("backend1", "dialoutallowedprefixes", "0049, +43 1, invalid, +49")
+	config.AddOption("backend1", "dialoutdeniedpatterns", "+49900*, +49137?????, [invalid")
+	config.AddOption("backend2", "url", "http://domain2.invalid")
+	config.AddOption("backend2", "secret", string(testBackendSecret)+"-backend2")
+	cfg, err := NewBackendConfiguration(config, nil)
+	require.NoError(err)
+
+	backend1 := cfg.GetBackend(mustParse("http://domain1.invalid"))
+	require.NotNil(backend1)
+	assert.Equal([]string{"+431", "+49"}, backend1.dialoutAllowedPrefixes)
+	assert.Equal([]string{"+49137?????", "+49900*"}, backend1.dialoutDeniedPatterns)
+	assert.Nil(backend1.CheckDialoutNumber("+49301234567"))
+	assert.Nil(backend1.CheckDialoutNumber("+431234567"))
+	assert.Nil(backend1.CheckDialoutNumber("+491371234"))
+	assert.Equal(NumberNotAllowed, backend1.CheckDialoutNumber("+4321234567"))
+	assert.Equal(NumberNotAllowed, backend1.CheckDialoutNumber("+1234567890"))
+	assert.Equal(NumberNotAllowed, backend1.CheckDialoutNumber("+499001234"))
+	assert.Equal(NumberNotAllowed, backend1.CheckDialoutNumber("+4913712345"))
+
+	backend2 := cfg.GetBackend(mustParse("http://domain2.invalid"))
+	require.NotNil(backend2)
+	assert.Empty(backend2.dialoutAllowedPrefixes)
+	assert.Empty(backend2.dialoutDeniedPatterns)
+	assert.Nil(backend2.CheckDialoutNumber("+1234567890"))
+	assert.False(backend1.Equal(backend2))
+}
@@ -0,0 +1,93 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2020 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+)
+
+type BackendPoolType string
+
+const (
+	// Requests that are sent to the backend.
+	BackendPoolRequests BackendPoolType = "requests"
+	// Messages from sessions of the backend that are processed.
+	BackendPoolMessages BackendPoolType = "messages"
+)
+
+// backendPool limits the number of operations of a backend that are processed
+// concurrently, so a busy or slow backend can't starve other backends.
+type backendPool struct {
+	backend  string
+	poolType BackendPoolType
+	slots    chan struct{}
+}
+
+func newBackendPool(backend string, poolType BackendPoolType, size int) *backendPool {
+	if size <= 0 {
+		// Not limited
+		return nil
+	}
+
+	return &backendPool{
+		backend:  backend,
+		poolType: poolType,
+		slots:    make(chan struct{}, size),
+	}
+}
+
+func (p *backendPool) Size() int {
+	if p == nil {
+		return 0
+	}
+
+	return cap(p.slots)
+}
+
+func (p *backendPool) release() {
+	<-p.slots
+}
+
+func noopRelease() {}
+
+// Acquire waits until a slot in the pool is available or the context is done.
+// The returned function must be called to release the slot.
+func (p *backendPool) Acquire(ctx context.Context) (func(), error) {
+	if p == nil {
+		return noopRelease, nil
+	}
+
+	select {
+	case p.slots <- struct{}{}:
+		return p.release, nil
+	default:
+	}
+
+	statsBackendPoolWaitedTotal.WithLabelValues(p.backend, string(p.poolType)).Inc()
+	select {
+	case p.slots <- struct{}{}:
+		return p.release, nil
+	case <-ctx.Done():
+		statsBackendPoolRejectedTotal.WithLabelValues(p.backend, string(p.poolType)).Inc()
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,108 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2020 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackendPool_Unlimited(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	pool := newBackendPool("backend", BackendPoolRequests, 0)
+	assert.Nil(pool)
+	assert.Equal(0, pool.Size())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	// Unlimited pools never wait, even if the context is done.
+	for range 10 {
+		release, err := pool.Acquire(ctx)
+		if assert.NoError(err) {
+			release()
+		}
+	}
+
+	var backend *Backend
+	release, err := backend.AcquireMessage(ctx)
+	if assert.NoError(err) {
+		release()
+	}
+}
+
+func TestBackendPool(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	assert := assert.New(t)
+
+	pool := newBackendPool("backend", BackendPoolMessages, 2)
+	require.NotNil(pool)
+	assert.Equal(2, pool.Size())
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	release1, err := pool.Acquire(ctx)
+	require.NoError(err)
+	release2, err := pool.Acquire(ctx)
+	require.NoError(err)
+
+	// The pool is exhausted, further operations wait until the context is done.
+	ctx2, cancel2 := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel2()
+	_, err = pool.Acquire(ctx2)
+	assert.ErrorIs(err, context.DeadlineExceeded)
+
+	acquired := make(chan struct{})
+	go func() {
+		defer close(acquired)
+		if release, err := pool.Acquire(ctx); assert.NoError(err) {
+			release()
+		}
+	}()
+
+	select {
+	case <-acquired:
+		assert.Fail("should wait for a free slot")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	release1()
+	select {
+	case <-acquired:
+	case <-ctx.Done():
+		assert.Fail("should have acquired a free slot")
+	}
+	release2()
+
+	// Other pools are not affected.
+	other := newBackendPool("other", BackendPoolMessages, 1)
+	if release, err := other.Acquire(ctx); assert.NoError(err) {
+		release()
+	}
+}
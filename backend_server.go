@@ -31,12 +31,14 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
+	"maps"
 	"net"
 	"net/http"
+	"net/http/pprof"
 	"net/url"
 	"reflect"
 	"regexp"
+	runtimepprof "runtime/pprof"
 	"slices"
 	"strings"
 	"sync"
@@ -45,7 +47,8 @@ import (
 
 	"github.com/dlintw/goconf"
 	"github.com/gorilla/mux"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
@@ -58,6 +61,10 @@ const (
 	startDialoutTimeout = 45 * time.Second
 )
 
+func init() {
+	RegisterBackendServerStats()
+}
+
 type BackendServer struct {
 	hub          *Hub
 	events       AsyncEvents
@@ -65,19 +72,26 @@ type BackendServer struct {
 
 	version        string
 	welcomeMessage string
+	schemaMessage  []byte
+
+	turn atomic.Pointer[backendServerTurnSettings]
 
-	turnapikey  string
-	turnsecret  []byte
-	turnvalid   time.Duration
-	turnservers []string
+	statsAccess   atomic.Pointer[StatsAccess]
+	invalidSecret []byte
 
-	statsAllowedIps atomic.Pointer[AllowedIps]
-	invalidSecret   []byte
+	replayCache *backendReplayCache
 
 	buffers BufferPool
 }
 
-func NewBackendServer(config *goconf.ConfigFile, hub *Hub, version string) (*BackendServer, error) {
+type backendServerTurnSettings struct {
+	apikey  string
+	secret  []byte
+	valid   time.Duration
+	servers []string
+}
+
+func loadBackendServerTurnSettings(config *goconf.ConfigFile) (*backendServerTurnSettings, error) {
 	turnapikey, _ := GetStringOptionWithEnv(config, "turn", "apikey")
 	turnsecret, _ := GetStringOptionWithEnv(config, "turn", "secret")
 	turnservers, _ := config.GetString("turn", "servers")
@@ -93,24 +107,30 @@ func NewBackendServer(config *goconf.ConfigFile, hub *Hub, version string) (*Bac
 			return nil, fmt.Errorf("need a shared TURN secret if TURN servers are configured")
 		}
 
-		log.Printf("Using configured TURN API key")
-		log.Printf("Using configured shared TURN secret")
+		backendLog.Infof("Using configured TURN API key")
+		backendLog.Infof("Using configured shared TURN secret")
 		for _, s := range turnserverslist {
-			log.Printf("Adding \"%s\" as TURN server", s)
+			backendLog.Infof("Adding \"%s\" as TURN server", s)
 		}
 	}
 
-	statsAllowed, _ := config.GetString("stats", "allowed_ips")
-	statsAllowedIps, err := ParseAllowedIps(statsAllowed)
+	return &backendServerTurnSettings{
+		apikey:  turnapikey,
+		secret:  []byte(turnsecret),
+		valid:   turnvalid,
+		servers: turnserverslist,
+	}, nil
+}
+
+func NewBackendServer(config *goconf.ConfigFile, hub *Hub, version string) (*BackendServer, error) {
+	turn, err := loadBackendServerTurnSettings(config)
 	if err != nil {
 		return nil, err
 	}
 
-	if !statsAllowedIps.Empty() {
-		log.Printf("Only allowing access to the stats endpoint from %s", statsAllowed)
-	} else {
-		log.Printf("No IPs configured for the stats endpoint, only allowing access from 127.0.0.1")
-		statsAllowedIps = DefaultAllowedIps()
+	statsAccess, err := NewStatsAccessFromConfig(config, backendLog)
+	if err != nil {
+		return nil, err
 	}
 
 	invalidSecret := make([]byte, 32)
@@ -118,38 +138,60 @@ func NewBackendServer(config *goconf.ConfigFile, hub *Hub, version string) (*Bac
 		return nil, err
 	}
 
+	replayWindow := getBackendReplayWindow(config)
+
 	result := &BackendServer{
 		hub:          hub,
 		events:       hub.events,
 		roomSessions: hub.roomSessions,
 		version:      version,
 
-		turnapikey:  turnapikey,
-		turnsecret:  []byte(turnsecret),
-		turnvalid:   turnvalid,
-		turnservers: turnserverslist,
-
 		invalidSecret: invalidSecret,
+
+		replayCache: newBackendReplayCache(replayWindow),
 	}
 
-	result.statsAllowedIps.Store(statsAllowedIps)
+	result.statsAccess.Store(statsAccess)
+	result.turn.Store(turn)
 
 	return result, nil
 }
 
-func (b *BackendServer) Reload(config *goconf.ConfigFile) {
-	statsAllowed, _ := config.GetString("stats", "allowed_ips")
-	if statsAllowedIps, err := ParseAllowedIps(statsAllowed); err == nil {
-		if !statsAllowedIps.Empty() {
-			log.Printf("Only allowing access to the stats endpoint from %s", statsAllowed)
-		} else {
-			log.Printf("No IPs configured for the stats endpoint, only allowing access from 127.0.0.1")
-			statsAllowedIps = DefaultAllowedIps()
-		}
-		b.statsAllowedIps.Store(statsAllowedIps)
+// Reload applies the changed configuration to the backend server. Errors are
+// returned as ConfigSectionError for the affected sections.
+func (b *BackendServer) Reload(config *goconf.ConfigFile) error {
+	var errs []error
+	if statsAccess, err := NewStatsAccessFromConfig(config, backendLog); err == nil {
+		b.statsAccess.Store(statsAccess)
 	} else {
-		log.Printf("Error parsing allowed stats ips from \"%s\": %s", statsAllowedIps, err)
+		backendLog.Errorf("Error parsing allowed stats ips: %s", err)
+		errs = append(errs, NewConfigSectionError("stats", fmt.Errorf("invalid allowed ips: %w", err)))
 	}
+
+	if turn, err := loadBackendServerTurnSettings(config); err == nil {
+		b.turn.Store(turn)
+	} else {
+		backendLog.Errorf("Could not reload TURN settings: %s", err)
+		errs = append(errs, NewConfigSectionError("turn", err))
+	}
+
+	b.replayCache.SetWindow(getBackendReplayWindow(config))
+	return errors.Join(errs...)
+}
+
+func getBackendReplayWindow(config *goconf.ConfigFile) time.Duration {
+	replayWindowSeconds, err := config.GetInt("backend", "replaywindow")
+	if err != nil {
+		replayWindowSeconds = defaultBackendReplayWindowSeconds
+	}
+	if replayWindowSeconds <= 0 {
+		backendLog.Infof("Replay protection for backend requests is disabled")
+		return 0
+	}
+
+	replayWindow := time.Duration(replayWindowSeconds) * time.Second
+	backendLog.Infof("Rejecting replayed backend requests and requests without timestamp within %s", replayWindow)
+	return replayWindow
 }
 
 func (b *BackendServer) Start(r *mux.Router) error {
@@ -165,14 +207,32 @@ func (b *BackendServer) Start(r *mux.Router) error {
 
 	b.welcomeMessage = string(welcomeMessage) + "\n"
 
+	schemaMessage, err := GetSignalingJsonSchema(b.version)
+	if err != nil {
+		// Should never happen.
+		return err
+	}
+
+	b.schemaMessage = append(schemaMessage, '\n')
+
 	s := r.PathPrefix("/api/v1").Subrouter()
 	s.HandleFunc("/welcome", b.setComonHeaders(b.welcomeFunc)).Methods("GET")
+	s.HandleFunc("/schema", b.setComonHeaders(b.schemaFunc)).Methods("GET")
 	s.HandleFunc("/room/{roomid}", b.setComonHeaders(b.parseRequestBody(b.roomHandler))).Methods("POST")
 	s.HandleFunc("/stats", b.setComonHeaders(b.validateStatsRequest(b.statsHandler))).Methods("GET")
 	s.HandleFunc("/serverinfo", b.setComonHeaders(b.validateStatsRequest(b.serverinfoHandler))).Methods("GET")
+	s.HandleFunc("/config", b.setComonHeaders(b.validateStatsRequest(b.configHandler))).Methods("GET")
+	s.HandleFunc("/events", b.setComonHeaders(b.validateStatsRequest(b.eventsHandler))).Methods("GET")
+	s.HandleFunc("/load", b.setComonHeaders(b.validateStatsRequest(b.loadHandler))).Methods("GET")
+	s.HandleFunc("/geoip/lookup", b.setComonHeaders(b.validateStatsRequest(b.geoipLookupHandler))).Methods("GET")
+	s.HandleFunc("/geoip/overrides", b.setComonHeaders(b.validateStatsRequest(b.geoipOverridesHandler))).Methods("GET")
 
 	// Expose prometheus metrics at "/metrics".
-	r.HandleFunc("/metrics", b.setComonHeaders(b.validateStatsRequest(b.metricsHandler))).Methods("GET")
+	r.HandleFunc("/metrics", b.setComonHeaders(b.validateStatsRequestWithPermission(StatsPermissionMetrics, b.metricsHandler))).Methods("GET")
+
+	// Endpoints for liveness / readiness probes (e.g. in Kubernetes).
+	r.HandleFunc("/live", b.setComonHeaders(b.liveHandler)).Methods("GET")
+	r.HandleFunc("/ready", b.setComonHeaders(b.readyHandler)).Methods("GET")
 
 	// Provide a REST service to get TURN credentials.
 	// See https://tools.ietf.org/html/draft-uberti-behave-turn-rest-00
@@ -180,6 +240,20 @@ func (b *BackendServer) Start(r *mux.Router) error {
 	return nil
 }
 
+// InstallDebugHandlers registers the pprof endpoints in "/debug/pprof", they
+// require the same access as the stats endpoints.
+func (b *BackendServer) InstallDebugHandlers(r *mux.Router) {
+	r.HandleFunc("/debug/pprof/", b.validateStatsRequest(pprof.Index))
+	r.HandleFunc("/debug/pprof/cmdline", b.validateStatsRequest(pprof.Cmdline))
+	r.HandleFunc("/debug/pprof/profile", b.validateStatsRequest(pprof.Profile))
+	r.HandleFunc("/debug/pprof/symbol", b.validateStatsRequest(pprof.Symbol))
+	r.HandleFunc("/debug/pprof/trace", b.validateStatsRequest(pprof.Trace))
+	for _, profile := range runtimepprof.Profiles() {
+		name := profile.Name()
+		r.HandleFunc("/debug/pprof/"+name, b.validateStatsRequest(pprof.Handler(name).ServeHTTP))
+	}
+}
+
 func (b *BackendServer) setComonHeaders(f func(http.ResponseWriter, *http.Request)) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Server", "nextcloud-spreed-signaling/"+b.version)
@@ -194,6 +268,12 @@ func (b *BackendServer) welcomeFunc(w http.ResponseWriter, r *http.Request) {
 	io.WriteString(w, b.welcomeMessage) // nolint
 }
 
+func (b *BackendServer) schemaFunc(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/schema+json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(b.schemaMessage) // nolint
+}
+
 func calculateTurnSecret(username string, secret []byte, valid time.Duration) (string, string) {
 	expires := time.Now().Add(valid)
 	username = fmt.Sprintf("%d:%s", expires.Unix(), username)
@@ -218,13 +298,14 @@ func (b *BackendServer) getTurnCredentials(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	if key != b.turnapikey {
+	turn := b.turn.Load()
+	if key != turn.apikey {
 		w.WriteHeader(http.StatusForbidden)
 		io.WriteString(w, "Not allowed to access this service.\n") // nolint
 		return
 	}
 
-	if len(b.turnservers) == 0 {
+	if len(turn.servers) == 0 {
 		w.WriteHeader(http.StatusNotFound)
 		io.WriteString(w, "No TURN servers available.\n") // nolint
 		return
@@ -235,17 +316,17 @@ func (b *BackendServer) getTurnCredentials(w http.ResponseWriter, r *http.Reques
 		username = newRandomString(randomUsernameLength)
 	}
 
-	username, password := calculateTurnSecret(username, b.turnsecret, b.turnvalid)
+	username, password := calculateTurnSecret(username, turn.secret, turn.valid)
 	result := TurnCredentials{
 		Username: username,
 		Password: password,
-		TTL:      int64(b.turnvalid.Seconds()),
-		URIs:     b.turnservers,
+		TTL:      int64(turn.valid.Seconds()),
+		URIs:     turn.servers,
 	}
 
 	data, err := json.Marshal(result)
 	if err != nil {
-		log.Printf("Could not serialize TURN credentials: %s", err)
+		backendLog.Errorf("Could not serialize TURN credentials: %s", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		io.WriteString(w, "Could not serialize credentials.") // nolint
 		return
@@ -272,7 +353,7 @@ func (b *BackendServer) parseRequestBody(f func(http.ResponseWriter, *http.Reque
 		}
 		ct := r.Header.Get("Content-Type")
 		if !strings.HasPrefix(ct, "application/json") {
-			log.Printf("Received unsupported content-type: %s", ct)
+			backendLog.Warnf("Received unsupported content-type: %s", ct)
 			http.Error(w, "Unsupported Content-Type", http.StatusBadRequest)
 			return
 		}
@@ -285,7 +366,7 @@ func (b *BackendServer) parseRequestBody(f func(http.ResponseWriter, *http.Reque
 
 		body, err := b.buffers.ReadAll(r.Body)
 		if err != nil {
-			log.Println("Error reading body: ", err)
+			backendLog.Errorf("Error reading body: %s", err)
 			http.Error(w, "Could not read body", http.StatusBadRequest)
 			return
 		}
@@ -312,12 +393,12 @@ func (b *BackendServer) sendRoomInvite(roomid string, backend *Backend, userids
 	}
 	for _, userid := range userids {
 		if err := b.events.PublishUserMessage(userid, backend, msg); err != nil {
-			log.Printf("Could not publish room invite for user %s in backend %s: %s", userid, backend.Id(), err)
+			backendLog.Errorf("Could not publish room invite for user %s in backend %s: %s", userid, backend.Id(), err)
 		}
 	}
 }
 
-func (b *BackendServer) sendRoomDisinvite(roomid string, backend *Backend, reason string, userids []string, sessionids []RoomSessionId) {
+func (b *BackendServer) sendRoomDisinvite(roomid string, backend *Backend, reason string, byeReason string, userids []string, sessionids []RoomSessionId) {
 	msg := &AsyncMessage{
 		Type: "message",
 		Message: &ServerMessage{
@@ -336,7 +417,20 @@ func (b *BackendServer) sendRoomDisinvite(roomid string, backend *Backend, reaso
 	}
 	for _, userid := range userids {
 		if err := b.events.PublishUserMessage(userid, backend, msg); err != nil {
-			log.Printf("Could not publish room disinvite for user %s in backend %s: %s", userid, backend.Id(), err)
+			backendLog.Errorf("Could not publish room disinvite for user %s in backend %s: %s", userid, backend.Id(), err)
+		}
+	}
+
+	sessionMsg := msg
+	if byeReason != "" {
+		// Sessions that were kicked or banned are closed with a reason the
+		// clients can show to the user.
+		sessionMsg = &AsyncMessage{
+			Type: "message",
+			Message: &ServerMessage{
+				Type: "bye",
+				Bye:  NewByeServerMessage(byeReason),
+			},
 		}
 	}
 
@@ -354,10 +448,10 @@ func (b *BackendServer) sendRoomDisinvite(roomid string, backend *Backend, reaso
 		go func(sessionid RoomSessionId) {
 			defer wg.Done()
 			if sid, err := b.lookupByRoomSessionId(ctx, sessionid, nil); err != nil {
-				log.Printf("Could not lookup by room session %s: %s", sessionid, err)
+				backendLog.Errorf("Could not lookup by room session %s: %s", sessionid, err)
 			} else if sid != "" {
-				if err := b.events.PublishSessionMessage(sid, backend, msg); err != nil {
-					log.Printf("Could not publish room disinvite for session %s: %s", sid, err)
+				if err := b.events.PublishSessionMessage(sid, backend, sessionMsg); err != nil {
+					backendLog.Errorf("Could not publish room disinvite for session %s: %s", sid, err)
 				}
 			}
 		}(sessionid)
@@ -391,14 +485,14 @@ func (b *BackendServer) sendRoomUpdate(roomid string, backend *Backend, notified
 		}
 
 		if err := b.events.PublishUserMessage(userid, backend, msg); err != nil {
-			log.Printf("Could not publish room update for user %s in backend %s: %s", userid, backend.Id(), err)
+			backendLog.Errorf("Could not publish room update for user %s in backend %s: %s", userid, backend.Id(), err)
 		}
 	}
 }
 
 func (b *BackendServer) lookupByRoomSessionId(ctx context.Context, roomSessionId RoomSessionId, cache *ConcurrentMap[RoomSessionId, PublicSessionId]) (PublicSessionId, error) {
 	if roomSessionId == sessionIdNotInMeeting {
-		log.Printf("Trying to lookup empty room session id: %s", roomSessionId)
+		backendLog.Infof("Trying to lookup empty room session id: %s", roomSessionId)
 		return "", nil
 	}
 
@@ -430,13 +524,13 @@ func (b *BackendServer) fixupUserSessions(ctx context.Context, cache *Concurrent
 	for _, user := range users {
 		roomSessionId, found := GetStringMapString[RoomSessionId](user, "sessionId")
 		if !found {
-			log.Printf("User %+v has invalid room session id, ignoring", user)
+			backendLog.Infof("User %+v has invalid room session id, ignoring", user)
 			delete(user, "sessionId")
 			continue
 		}
 
 		if roomSessionId == sessionIdNotInMeeting {
-			log.Printf("User %+v is not in the meeting, ignoring", user)
+			backendLog.Infof("User %+v is not in the meeting, ignoring", user)
 			delete(user, "sessionId")
 			continue
 		}
@@ -445,7 +539,7 @@ func (b *BackendServer) fixupUserSessions(ctx context.Context, cache *Concurrent
 		go func(roomSessionId RoomSessionId, u StringMap) {
 			defer wg.Done()
 			if sessionId, err := b.lookupByRoomSessionId(ctx, roomSessionId, cache); err != nil {
-				log.Printf("Could not lookup by room session %s: %s", roomSessionId, err)
+				backendLog.Errorf("Could not lookup by room session %s: %s", roomSessionId, err)
 				delete(u, "sessionId")
 			} else if sessionId != "" {
 				u["sessionId"] = sessionId
@@ -466,11 +560,11 @@ func (b *BackendServer) fixupUserSessions(ctx context.Context, cache *Concurrent
 	return result
 }
 
-func (b *BackendServer) sendRoomIncall(roomid string, backend *Backend, request *BackendServerRoomRequest) error {
+func (b *BackendServer) sendRoomIncall(ctx context.Context, roomid string, backend *Backend, request *BackendServerRoomRequest) error {
 	if !request.InCall.All {
 		timeout := time.Second
 
-		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		ctx, cancel := context.WithTimeout(ctx, timeout)
 		defer cancel()
 		var cache ConcurrentMap[RoomSessionId, PublicSessionId]
 		// Convert (Nextcloud) session ids to signaling session ids.
@@ -487,14 +581,15 @@ func (b *BackendServer) sendRoomIncall(roomid string, backend *Backend, request
 		Type: "room",
 		Room: request,
 	}
+	injectAsyncTraceContext(ctx, message)
 	return b.events.PublishBackendRoomMessage(roomid, backend, message)
 }
 
-func (b *BackendServer) sendRoomParticipantsUpdate(roomid string, backend *Backend, request *BackendServerRoomRequest) error {
+func (b *BackendServer) sendRoomParticipantsUpdate(ctx context.Context, roomid string, backend *Backend, request *BackendServerRoomRequest) error {
 	timeout := time.Second
 
 	// Convert (Nextcloud) session ids to signaling session ids.
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 	var cache ConcurrentMap[RoomSessionId, PublicSessionId]
 	request.Participants.Users = b.fixupUserSessions(ctx, &cache, request.Participants.Users)
@@ -514,20 +609,20 @@ loop:
 
 		sessionId, found := GetStringMapString[PublicSessionId](user, "sessionId")
 		if !found {
-			log.Printf("User entry has no session id: %+v", user)
+			backendLog.Infof("User entry has no session id: %+v", user)
 			continue
 		}
 
 		permissionsList, ok := permissionsInterface.([]any)
 		if !ok {
-			log.Printf("Received invalid permissions %+v (%s) for session %s", permissionsInterface, reflect.TypeOf(permissionsInterface), sessionId)
+			backendLog.Warnf("Received invalid permissions %+v (%s) for session %s", permissionsInterface, reflect.TypeOf(permissionsInterface), sessionId)
 			continue
 		}
 		var permissions []Permission
 		for idx, ob := range permissionsList {
 			permission, ok := ob.(string)
 			if !ok {
-				log.Printf("Received invalid permission at position %d %+v (%s) for session %s", idx, ob, reflect.TypeOf(ob), sessionId)
+				backendLog.Warnf("Received invalid permission at position %d %+v (%s) for session %s", idx, ob, reflect.TypeOf(ob), sessionId)
 				continue loop
 			}
 			permissions = append(permissions, Permission(permission))
@@ -541,7 +636,7 @@ loop:
 				Permissions: permissions,
 			}
 			if err := b.events.PublishSessionMessage(sessionId, backend, message); err != nil {
-				log.Printf("Could not send permissions update (%+v) to session %s: %s", permissions, sessionId, err)
+				backendLog.Errorf("Could not send permissions update (%+v) to session %s: %s", permissions, sessionId, err)
 			}
 		}(sessionId, permissions)
 	}
@@ -551,22 +646,51 @@ loop:
 		Type: "room",
 		Room: request,
 	}
+	injectAsyncTraceContext(ctx, message)
 	return b.events.PublishBackendRoomMessage(roomid, backend, message)
 }
 
-func (b *BackendServer) sendRoomMessage(roomid string, backend *Backend, request *BackendServerRoomRequest) error {
+func (b *BackendServer) sendRoomMessage(ctx context.Context, roomid string, backend *Backend, request *BackendServerRoomRequest) error {
 	message := &AsyncMessage{
 		Type: "room",
 		Room: request,
 	}
+	injectAsyncTraceContext(ctx, message)
 	return b.events.PublishBackendRoomMessage(roomid, backend, message)
 }
 
-func (b *BackendServer) sendRoomSwitchTo(roomid string, backend *Backend, request *BackendServerRoomRequest) error {
+// lookupSwitchToSessionsList converts (Nextcloud) session ids to signaling
+// session ids. Sessions that are not connected will be skipped.
+func (b *BackendServer) lookupSwitchToSessionsList(ctx context.Context, sessionsList BackendRoomSwitchToSessionsList) BackendRoomSwitchToPublicSessionsList {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var result BackendRoomSwitchToPublicSessionsList
+	for _, roomSessionId := range sessionsList {
+		if roomSessionId == sessionIdNotInMeeting {
+			continue
+		}
+
+		wg.Add(1)
+		go func(roomSessionId RoomSessionId) {
+			defer wg.Done()
+			if sessionId, err := b.lookupByRoomSessionId(ctx, roomSessionId, nil); err != nil {
+				backendLog.Errorf("Could not lookup by room session %s: %s", roomSessionId, err)
+			} else if sessionId != "" {
+				mu.Lock()
+				defer mu.Unlock()
+				result = append(result, sessionId)
+			}
+		}(roomSessionId)
+	}
+	wg.Wait()
+	return result
+}
+
+func (b *BackendServer) sendRoomSwitchTo(ctx context.Context, roomid string, backend *Backend, request *BackendServerRoomRequest) error {
 	timeout := time.Second
 
 	// Convert (Nextcloud) session ids to signaling session ids.
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	var wg sync.WaitGroup
@@ -583,28 +707,7 @@ func (b *BackendServer) sendRoomSwitchTo(roomid string, backend *Backend, reques
 				return nil
 			}
 
-			var internalSessionsList BackendRoomSwitchToPublicSessionsList
-			for _, roomSessionId := range sessionsList {
-				if roomSessionId == sessionIdNotInMeeting {
-					continue
-				}
-
-				wg.Add(1)
-				go func(roomSessionId RoomSessionId) {
-					defer wg.Done()
-					if sessionId, err := b.lookupByRoomSessionId(ctx, roomSessionId, nil); err != nil {
-						log.Printf("Could not lookup by room session %s: %s", roomSessionId, err)
-					} else if sessionId != "" {
-						mu.Lock()
-						defer mu.Unlock()
-						internalSessionsList = append(internalSessionsList, sessionId)
-					}
-				}(roomSessionId)
-			}
-			wg.Wait()
-
-			mu.Lock()
-			defer mu.Unlock()
+			internalSessionsList := b.lookupSwitchToSessionsList(ctx, sessionsList)
 			if len(internalSessionsList) == 0 {
 				return nil
 			}
@@ -631,7 +734,7 @@ func (b *BackendServer) sendRoomSwitchTo(roomid string, backend *Backend, reques
 				go func(roomSessionId RoomSessionId, details json.RawMessage) {
 					defer wg.Done()
 					if sessionId, err := b.lookupByRoomSessionId(ctx, roomSessionId, nil); err != nil {
-						log.Printf("Could not lookup by room session %s: %s", roomSessionId, err)
+						backendLog.Errorf("Could not lookup by room session %s: %s", roomSessionId, err)
 					} else if sessionId != "" {
 						mu.Lock()
 						defer mu.Unlock()
@@ -657,6 +760,58 @@ func (b *BackendServer) sendRoomSwitchTo(roomid string, backend *Backend, reques
 		Type: "room",
 		Room: request,
 	}
+	injectAsyncTraceContext(ctx, message)
+	return b.events.PublishBackendRoomMessage(roomid, backend, message)
+}
+
+func (b *BackendServer) sendRoomBreakout(ctx context.Context, roomid string, backend *Backend, request *BackendServerRoomRequest) error {
+	breakout := request.Breakout
+	roomIds := slices.Sorted(maps.Keys(breakout.Rooms))
+	switch breakout.Action {
+	case BreakoutActionStart:
+		// Convert (Nextcloud) session ids to signaling session ids.
+		lookupCtx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+
+		breakout.SessionsMap = make(map[string]BackendRoomSwitchToPublicSessionsList)
+		for _, id := range roomIds {
+			if sessions := b.lookupSwitchToSessionsList(lookupCtx, breakout.Rooms[id]); len(sessions) > 0 {
+				breakout.SessionsMap[id] = sessions
+			}
+		}
+		b.hub.breakout.Start(roomid, backend, roomIds)
+	case BreakoutActionStop:
+		if started := b.hub.breakout.Stop(roomid, backend); len(roomIds) == 0 {
+			roomIds = started
+		}
+
+		// Sessions in the breakout rooms will switch back to the parent room.
+		for _, id := range roomIds {
+			message := &AsyncMessage{
+				Type: "room",
+				Room: &BackendServerRoomRequest{
+					Type: "breakout",
+					Breakout: &BackendRoomBreakoutRequest{
+						Action:       BreakoutActionStop,
+						ParentRoomId: roomid,
+					},
+					ReceivedTime: request.ReceivedTime,
+				},
+			}
+			injectAsyncTraceContext(ctx, message)
+			if err := b.events.PublishBackendRoomMessage(id, backend, message); err != nil {
+				return err
+			}
+		}
+	}
+	breakout.Rooms = nil
+	breakout.RoomIds = roomIds
+
+	message := &AsyncMessage{
+		Type: "room",
+		Room: request,
+	}
+	injectAsyncTraceContext(ctx, message)
 	return b.events.PublishBackendRoomMessage(roomid, backend, message)
 }
 
@@ -694,7 +849,9 @@ func isNumeric(s string) bool {
 	return checkNumeric.MatchString(s)
 }
 
-func (b *BackendServer) startDialoutInSession(ctx context.Context, session *ClientSession, roomid string, backend *Backend, backendUrl string, request *BackendServerRoomRequest) (any, error) {
+// getInternalBackendUrl returns the url of the backend that should be sent to
+// internal clients.
+func getInternalBackendUrl(backend *Backend, backendUrl string) string {
 	url := backendUrl
 	if url != "" && url[len(url)-1] != '/' {
 		url += "/"
@@ -707,6 +864,11 @@ func (b *BackendServer) startDialoutInSession(ctx context.Context, session *Clie
 			url = urls[0]
 		}
 	}
+	return url
+}
+
+func (b *BackendServer) startDialoutInSession(ctx context.Context, session *ClientSession, roomid string, backend *Backend, backendUrl string, request *BackendServerRoomRequest) (any, error) {
+	url := getInternalBackendUrl(backend, backendUrl)
 	id := newRandomString(32)
 	msg := &ServerMessage{
 		Id:   id,
@@ -773,16 +935,33 @@ func (b *BackendServer) startDialoutInSession(ctx context.Context, session *Clie
 }
 
 func (b *BackendServer) startDialout(ctx context.Context, roomid string, backend *Backend, backendUrl string, request *BackendServerRoomRequest) (any, error) {
+	if !backend.HasFeature(BackendFeatureDialout) {
+		return returnDialoutError(http.StatusForbidden, FeatureDisabled)
+	}
+
 	if err := request.Dialout.ValidateNumber(); err != nil {
 		return returnDialoutError(http.StatusBadRequest, err)
 	}
 
+	if err := backend.CheckDialoutNumber(request.Dialout.Number); err != nil {
+		backendLog.Warnf("Dialout to %s is not allowed for backend %s", request.Dialout.Number, backend.Id())
+		statsHubDialoutRequestsTotal.WithLabelValues(statsBackendLabel(backend), "denied").Inc()
+		return returnDialoutError(http.StatusForbidden, err)
+	}
+
 	if !isNumeric(roomid) {
 		return returnDialoutError(http.StatusBadRequest, NewError("invalid_roomid", "The room id must be numeric."))
 	}
 
+	backendLabel := statsBackendLabel(backend)
+	available := b.hub.GetDialoutSessions(roomid, backend)
+	sessions := b.hub.dialoutBalancer.Select(available)
+	if len(sessions) == 0 && len(available) > 0 {
+		statsHubDialoutRequestsTotal.WithLabelValues(backendLabel, "busy").Inc()
+		return returnDialoutError(http.StatusServiceUnavailable, NewError("dialout_busy", "All clients that support dialout are busy."))
+	}
+
 	var sessionError *Error
-	sessions := b.hub.GetDialoutSessions(roomid, backend)
 	for _, session := range sessions {
 		if ctx.Err() != nil {
 			// Upstream request was cancelled.
@@ -791,7 +970,9 @@ func (b *BackendServer) startDialout(ctx context.Context, roomid string, backend
 
 		response, err := b.startDialoutInSession(ctx, session, roomid, backend, backendUrl, request)
 		if err != nil {
-			log.Printf("Error starting dialout request %+v in session %s: %+v", request.Dialout, session.PublicId(), err)
+			// Try the next available client.
+			statsHubDialoutRequestsTotal.WithLabelValues(backendLabel, "failover").Inc()
+			backendLog.Errorf("Error starting dialout request %+v in session %s: %+v", request.Dialout, session.PublicId(), err)
 			var e *Error
 			if sessionError == nil && errors.As(err, &e) {
 				sessionError = e
@@ -799,23 +980,125 @@ func (b *BackendServer) startDialout(ctx context.Context, roomid string, backend
 			continue
 		}
 
+		statsHubDialoutRequestsTotal.WithLabelValues(backendLabel, "success").Inc()
 		return response, nil
 	}
 
 	if sessionError != nil {
+		statsHubDialoutRequestsTotal.WithLabelValues(backendLabel, "failed").Inc()
 		return returnDialoutError(http.StatusBadGateway, sessionError)
 	}
 
+	statsHubDialoutRequestsTotal.WithLabelValues(backendLabel, "unavailable").Inc()
 	return returnDialoutError(http.StatusNotFound, NewError("no_client_available", "No available client found to trigger dialout."))
 }
 
+type RecordingErrorResponse struct {
+	BackendServerRoomResponse
+
+	status int
+}
+
+func (r *RecordingErrorResponse) Status() int {
+	return r.status
+}
+
+func returnRecordingError(status int, err *Error) (any, error) {
+	response := &RecordingErrorResponse{
+		BackendServerRoomResponse: BackendServerRoomResponse{
+			Type: "recording",
+			Recording: &BackendRoomRecordingResponse{
+				Error: err,
+			},
+		},
+
+		status: status,
+	}
+	return response, nil
+}
+
+func (b *BackendServer) handleRecording(ctx context.Context, roomid string, backend *Backend, backendUrl string, request *BackendServerRoomRequest) (any, error) {
+	if request.Recording == nil {
+		return returnRecordingError(http.StatusBadRequest, NewError("invalid_request", "Recording request missing."))
+	}
+
+	var err error
+	var status RecordingStatus
+	switch request.Recording.Action {
+	case RecordingActionStart:
+		err = b.hub.recording.Start(ctx, roomid, backend, getInternalBackendUrl(backend, backendUrl), request.Recording.Options)
+		status = RecordingStatusStarted
+	case RecordingActionStop:
+		err = b.hub.recording.Stop(ctx, roomid, backend)
+		status = RecordingStatusStopped
+	case RecordingActionPause:
+		err = b.hub.recording.Pause(ctx, roomid, backend)
+		status = RecordingStatusPaused
+	case RecordingActionResume:
+		err = b.hub.recording.Resume(ctx, roomid, backend)
+		status = RecordingStatusStarted
+	default:
+		return returnRecordingError(http.StatusBadRequest, NewError("invalid_action", "Unsupported recording action: "+request.Recording.Action))
+	}
+
+	var e *Error
+	switch {
+	case err == nil:
+		return &BackendServerRoomResponse{
+			Type: "recording",
+			Recording: &BackendRoomRecordingResponse{
+				Status: status,
+			},
+		}, nil
+	case errors.Is(err, ErrRecordingActive):
+		return returnRecordingError(http.StatusConflict, ErrRecordingActive)
+	case errors.Is(err, ErrRecordingNotActive):
+		return returnRecordingError(http.StatusNotFound, ErrRecordingNotActive)
+	case errors.Is(err, ErrRecordingPaused):
+		return returnRecordingError(http.StatusConflict, ErrRecordingPaused)
+	case errors.Is(err, ErrRecordingNotPaused):
+		return returnRecordingError(http.StatusConflict, ErrRecordingNotPaused)
+	case errors.Is(err, ErrNoRecordingClient):
+		return returnRecordingError(http.StatusNotFound, ErrNoRecordingClient)
+	case errors.As(err, &e):
+		return returnRecordingError(http.StatusBadGateway, e)
+	default:
+		return nil, err
+	}
+}
+
+func (b *BackendServer) checkReplay(r *http.Request, backend *Backend) error {
+	timestamp, err := parseBackendTimestamp(r.Header.Get(HeaderBackendSignalingTimestamp))
+	if err != nil {
+		statsBackendServerRejectedRequestsTotal.WithLabelValues(backend.Id(), "invalid_timestamp").Inc()
+		return err
+	}
+
+	nonce := backend.Id() + "|" + r.Header.Get(HeaderBackendSignalingRandom)
+	switch err := b.replayCache.Check(nonce, timestamp, time.Now()); err {
+	case nil:
+		return nil
+	case ErrBackendRequestReplayed:
+		statsBackendServerRejectedRequestsTotal.WithLabelValues(backend.Id(), "replayed").Inc()
+		return err
+	case ErrBackendRequestExpired:
+		statsBackendServerRejectedRequestsTotal.WithLabelValues(backend.Id(), "expired").Inc()
+		return err
+	case ErrMissingTimestamp:
+		statsBackendServerRejectedRequestsTotal.WithLabelValues(backend.Id(), "missing_timestamp").Inc()
+		return err
+	default:
+		return err
+	}
+}
+
 func (b *BackendServer) roomHandler(w http.ResponseWriter, r *http.Request, body []byte) {
 	throttle, err := b.hub.throttler.CheckBruteforce(r.Context(), b.hub.getRealUserIP(r), "BackendRoomAuth")
 	if err == ErrBruteforceDetected {
 		http.Error(w, "Too many requests", http.StatusTooManyRequests)
 		return
 	} else if err != nil {
-		log.Printf("Error checking for bruteforce: %s", err)
+		backendLog.Errorf("Error checking for bruteforce: %s", err)
 		http.Error(w, "Could not check for bruteforce", http.StatusInternalServerError)
 		return
 	}
@@ -866,28 +1149,51 @@ func (b *BackendServer) roomHandler(w http.ResponseWriter, r *http.Request, body
 		return
 	}
 
+	if err := b.checkReplay(r, backend); err != nil {
+		backendLog.Warnf("Rejecting backend request from %s for room %s: %s", b.hub.getRealUserIP(r), roomid, err)
+		http.Error(w, "Authentication check failed", http.StatusForbidden)
+		return
+	}
+
 	var request BackendServerRoomRequest
 	if err := json.Unmarshal(body, &request); err != nil {
-		log.Printf("Error decoding body %s: %s", string(body), err)
+		backendLog.Errorf("Error decoding body %s: %s", string(body), err)
 		http.Error(w, "Could not read body", http.StatusBadRequest)
 		return
 	}
 
 	request.ReceivedTime = time.Now().UnixNano()
 
+	ctx, span := tracer.Start(extractTraceHeaders(r.Context(), r.Header), "backend.room",
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("signaling.backend", backend.Id()),
+			attribute.String("signaling.room", roomid),
+			attribute.String("signaling.request.type", request.Type),
+		),
+	)
+	defer span.End()
+
 	var response any
 	switch request.Type {
 	case "invite":
 		b.sendRoomInvite(roomid, backend, request.Invite.UserIds, request.Invite.Properties)
 		b.sendRoomUpdate(roomid, backend, request.Invite.UserIds, request.Invite.AllUserIds, request.Invite.Properties)
 	case "disinvite":
-		b.sendRoomDisinvite(roomid, backend, DisinviteReasonDisinvited, request.Disinvite.UserIds, request.Disinvite.SessionIds)
+		switch request.Disinvite.Reason {
+		case "", ByeReasonKicked, ByeReasonBanned:
+		default:
+			http.Error(w, "Unsupported disinvite reason: "+request.Disinvite.Reason, http.StatusBadRequest)
+			return
+		}
+		b.sendRoomDisinvite(roomid, backend, DisinviteReasonDisinvited, request.Disinvite.Reason, request.Disinvite.UserIds, request.Disinvite.SessionIds)
 		b.sendRoomUpdate(roomid, backend, request.Disinvite.UserIds, request.Disinvite.AllUserIds, request.Disinvite.Properties)
 	case "update":
 		message := &AsyncMessage{
 			Type: "room",
 			Room: &request,
 		}
+		injectAsyncTraceContext(ctx, message)
 		err = b.events.PublishBackendRoomMessage(roomid, backend, message)
 		b.sendRoomUpdate(roomid, backend, nil, request.Update.UserIds, request.Update.Properties)
 	case "delete":
@@ -895,25 +1201,38 @@ func (b *BackendServer) roomHandler(w http.ResponseWriter, r *http.Request, body
 			Type: "room",
 			Room: &request,
 		}
+		injectAsyncTraceContext(ctx, message)
 		err = b.events.PublishBackendRoomMessage(roomid, backend, message)
-		b.sendRoomDisinvite(roomid, backend, DisinviteReasonDeleted, request.Delete.UserIds, nil)
+		b.sendRoomDisinvite(roomid, backend, DisinviteReasonDeleted, "", request.Delete.UserIds, nil)
 	case "incall":
-		err = b.sendRoomIncall(roomid, backend, &request)
+		err = b.sendRoomIncall(ctx, roomid, backend, &request)
 	case "participants":
-		err = b.sendRoomParticipantsUpdate(roomid, backend, &request)
+		err = b.sendRoomParticipantsUpdate(ctx, roomid, backend, &request)
 	case "message":
-		err = b.sendRoomMessage(roomid, backend, &request)
+		err = b.sendRoomMessage(ctx, roomid, backend, &request)
 	case "switchto":
-		err = b.sendRoomSwitchTo(roomid, backend, &request)
+		err = b.sendRoomSwitchTo(ctx, roomid, backend, &request)
+	case "breakout":
+		if request.Breakout == nil {
+			http.Error(w, "Breakout request missing", http.StatusBadRequest)
+			return
+		} else if err := request.Breakout.CheckValid(roomid); err != nil {
+			http.Error(w, "Invalid breakout request: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		err = b.sendRoomBreakout(ctx, roomid, backend, &request)
 	case "dialout":
-		response, err = b.startDialout(r.Context(), roomid, backend, backendUrl, &request)
+		response, err = b.startDialout(ctx, roomid, backend, backendUrl, &request)
+	case "recording":
+		response, err = b.handleRecording(ctx, roomid, backend, backendUrl, &request)
 	default:
 		http.Error(w, "Unsupported request type: "+request.Type, http.StatusBadRequest)
 		return
 	}
 
 	if err != nil {
-		log.Printf("Error processing %s for room %s: %s", string(body), roomid, err)
+		span.RecordError(err)
+		backendLog.Errorf("Error processing %s for room %s: %s", string(body), roomid, err)
 		http.Error(w, "Error while processing", http.StatusInternalServerError)
 		return
 	}
@@ -929,7 +1248,7 @@ func (b *BackendServer) roomHandler(w http.ResponseWriter, r *http.Request, body
 		}
 		responseData, err = json.Marshal(response)
 		if err != nil {
-			log.Printf("Could not serialize backend response %+v: %s", response, err)
+			backendLog.Errorf("Could not serialize backend response %+v: %s", response, err)
 			responseStatus = http.StatusInternalServerError
 			responseData = []byte("{\"error\":\"could_not_serialize\"}")
 		}
@@ -941,21 +1260,21 @@ func (b *BackendServer) roomHandler(w http.ResponseWriter, r *http.Request, body
 	w.Write(responseData) // nolint
 }
 
-func (b *BackendServer) allowStatsAccess(r *http.Request) bool {
+func (b *BackendServer) checkStatsAccess(r *http.Request, permission StatsPermission) error {
 	addr := b.hub.getRealUserIP(r)
 	ip := net.ParseIP(addr)
-	if len(ip) == 0 {
-		return false
+	access := b.statsAccess.Load()
+	if access == nil {
+		return ErrStatsAddressNotAllowed
 	}
 
-	allowed := b.statsAllowedIps.Load()
-	return allowed != nil && allowed.Allowed(ip)
+	return access.Check(r, ip, permission)
 }
 
-func (b *BackendServer) validateStatsRequest(f func(http.ResponseWriter, *http.Request)) func(http.ResponseWriter, *http.Request) {
+func (b *BackendServer) validateStatsRequestWithPermission(permission StatsPermission, f func(http.ResponseWriter, *http.Request)) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if !b.allowStatsAccess(r) {
-			http.Error(w, "Authentication check failed", http.StatusForbidden)
+		if err := b.checkStatsAccess(r, permission); err != nil {
+			WriteStatsAccessError(w, err)
 			return
 		}
 
@@ -963,11 +1282,15 @@ func (b *BackendServer) validateStatsRequest(f func(http.ResponseWriter, *http.R
 	}
 }
 
+func (b *BackendServer) validateStatsRequest(f func(http.ResponseWriter, *http.Request)) func(http.ResponseWriter, *http.Request) {
+	return b.validateStatsRequestWithPermission(StatsPermissionAdmin, f)
+}
+
 func (b *BackendServer) statsHandler(w http.ResponseWriter, r *http.Request) {
 	stats := b.hub.GetStats()
 	statsData, err := json.MarshalIndent(stats, "", "  ")
 	if err != nil {
-		log.Printf("Could not serialize stats %+v: %s", stats, err)
+		backendLog.Errorf("Could not serialize stats %+v: %s", stats, err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
@@ -988,8 +1311,11 @@ func (b *BackendServer) serverinfoHandler(w http.ResponseWriter, r *http.Request
 	if mcu := b.hub.mcu; mcu != nil {
 		info.Sfu = mcu.GetServerInfoSfu()
 	}
-	if e, ok := b.events.(*asyncEventsNats); ok {
+	switch e := b.events.(type) {
+	case *asyncEventsNats:
 		info.Nats = e.GetServerInfoNats()
+	case *asyncEventsRedis:
+		info.Redis = e.GetServerInfoRedis()
 	}
 	if rpcClients := b.hub.rpcClients; rpcClients != nil {
 		info.Grpc = rpcClients.GetServerInfoGrpc()
@@ -1000,7 +1326,7 @@ func (b *BackendServer) serverinfoHandler(w http.ResponseWriter, r *http.Request
 
 	infoData, err := json.MarshalIndent(info, "", "  ")
 	if err != nil {
-		log.Printf("Could not serialize server info %+v: %s", info, err)
+		backendLog.Errorf("Could not serialize server info %+v: %s", info, err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
@@ -1011,6 +1337,184 @@ func (b *BackendServer) serverinfoHandler(w http.ResponseWriter, r *http.Request
 	w.Write(infoData) // nolint
 }
 
+func (b *BackendServer) configHandler(w http.ResponseWriter, r *http.Request) {
+	config := b.hub.GetServerConfig()
+	configData, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		backendLog.Errorf("Could not serialize configuration: %s", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	w.Write(configData) // nolint
+}
+
+func (b *BackendServer) eventsHandler(w http.ResponseWriter, r *http.Request) {
+	var since time.Time
+	if value := r.URL.Query().Get("since"); value != "" {
+		var err error
+		if since, err = time.Parse(time.RFC3339, value); err != nil {
+			http.Error(w, "Invalid since parameter", http.StatusBadRequest)
+			return
+		}
+	}
+
+	var types []RecentEventType
+	for t := range SplitEntries(r.URL.Query().Get("type"), ",") {
+		types = append(types, RecentEventType(t))
+	}
+
+	events := BackendServerEvents{
+		Events: recentEvents.Get(since, types...),
+	}
+	eventsData, err := json.MarshalIndent(events, "", "  ")
+	if err != nil {
+		backendLog.Errorf("Could not serialize recent events: %s", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	w.Write(eventsData) // nolint
+}
+
+func (b *BackendServer) loadHandler(w http.ResponseWriter, r *http.Request) {
+	load := b.hub.GetLoad()
+	loadData, err := json.MarshalIndent(load, "", "  ")
+	if err != nil {
+		backendLog.Errorf("Could not serialize load %+v: %s", load, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	w.Write(loadData) // nolint
+}
+
+func getSessionInfo(session Session) *BackendServerSessionInfo {
+	info := &BackendServerSessionInfo{
+		SessionId:  session.PublicId(),
+		ClientType: session.ClientType(),
+		UserId:     session.UserId(),
+		BackendUrl: session.BackendUrl(),
+	}
+	if data := session.Data(); data != nil {
+		info.Sid = data.Sid
+		info.Created = data.Created.AsTime()
+	}
+	if backend := session.Backend(); backend != nil {
+		info.Backend = backend.Id()
+	}
+	if room := session.GetRoom(); room != nil {
+		info.RoomId = room.Id()
+	}
+
+	if s, ok := session.(*ClientSession); ok {
+		info.RoomSessionId = s.RoomSessionId()
+		info.InCall = s.GetInCall()
+		info.Features = s.GetFeatures()
+		for _, streamType := range []StreamType{StreamTypeVideo, StreamTypeScreen} {
+			if s.GetPublisher(streamType) != nil {
+				info.Publishers = append(info.Publishers, streamType)
+			}
+		}
+
+		if client := s.GetClient(); client != nil {
+			info.Connected = client.IsConnected()
+			info.RemoteAddr = client.RemoteAddr()
+			info.Country = client.Country()
+			info.UserAgent = client.UserAgent()
+		}
+	}
+	return info
+}
+
+func getStoredSessionInfo(session *HubStorageSession) *BackendServerSessionInfo {
+	return &BackendServerSessionInfo{
+		SessionId:  session.PublicId,
+		Created:    session.Created,
+		ClientType: session.ClientType,
+		UserId:     session.UserId,
+		Backend:    session.BackendId,
+		RoomId:     session.RoomId,
+		Node:       session.Node,
+	}
+}
+
+func (b *BackendServer) geoipLookupHandler(w http.ResponseWriter, r *http.Request) {
+	ip := net.ParseIP(r.URL.Query().Get("ip"))
+	if ip == nil {
+		http.Error(w, "Invalid ip parameter", http.StatusBadRequest)
+		return
+	}
+
+	lookup := b.hub.GetGeoIPLookup(ip)
+	lookupData, err := json.MarshalIndent(lookup, "", "  ")
+	if err != nil {
+		backendLog.Errorf("Could not serialize GeoIP lookup %+v: %s", lookup, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	w.Write(lookupData) // nolint
+}
+
+func (b *BackendServer) geoipOverridesHandler(w http.ResponseWriter, r *http.Request) {
+	overrides := BackendServerGeoIPOverrides{
+		Overrides: b.hub.geoipOverrides.GetOverrides(),
+	}
+	overridesData, err := json.MarshalIndent(overrides, "", "  ")
+	if err != nil {
+		backendLog.Errorf("Could not serialize GeoIP overrides: %s", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	w.Write(overridesData) // nolint
+}
+
+func (b *BackendServer) healthHandler(w http.ResponseWriter, ready bool) {
+	health := b.hub.GetHealth(ready)
+	healthData, err := json.MarshalIndent(health, "", "  ")
+	if err != nil {
+		backendLog.Errorf("Could not serialize health %+v: %s", health, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	status := http.StatusOK
+	if health.Status == HealthStatusError {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(status)
+	w.Write(healthData) // nolint
+}
+
+func (b *BackendServer) liveHandler(w http.ResponseWriter, r *http.Request) {
+	b.healthHandler(w, false)
+}
+
+func (b *BackendServer) readyHandler(w http.ResponseWriter, r *http.Request) {
+	b.healthHandler(w, true)
+}
+
 func (b *BackendServer) metricsHandler(w http.ResponseWriter, r *http.Request) {
-	promhttp.Handler().ServeHTTP(w, r)
+	metricsHandler().ServeHTTP(w, r)
 }
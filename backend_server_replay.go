@@ -0,0 +1,137 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"errors"
+	"maps"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// Replay protection requires backends that send timestamps, so it is
+	// disabled by default.
+	defaultBackendReplayWindowSeconds = 0
+)
+
+var (
+	ErrBackendRequestReplayed = errors.New("backend request replayed")
+	ErrBackendRequestExpired  = errors.New("backend request expired")
+	ErrInvalidTimestamp       = errors.New("invalid timestamp")
+	ErrMissingTimestamp       = errors.New("missing timestamp")
+)
+
+// parseBackendTimestamp parses the value of the timestamp header (unix
+// timestamp in seconds). It returns a zero time if no timestamp was sent.
+func parseBackendTimestamp(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	ts, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || ts <= 0 {
+		return time.Time{}, ErrInvalidTimestamp
+	}
+
+	return time.Unix(ts, 0), nil
+}
+
+// backendReplayCache remembers the random values of backend requests so a
+// captured request can't be sent again.
+type backendReplayCache struct {
+	mu          sync.Mutex
+	window      time.Duration
+	nonces      map[string]time.Time
+	nextCleanup time.Time
+}
+
+func newBackendReplayCache(window time.Duration) *backendReplayCache {
+	return &backendReplayCache{
+		window: window,
+		nonces: make(map[string]time.Time),
+	}
+}
+
+func (c *backendReplayCache) SetWindow(window time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.window = window
+	if window <= 0 {
+		clear(c.nonces)
+	}
+}
+
+func (c *backendReplayCache) Window() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.window
+}
+
+func (c *backendReplayCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.nonces)
+}
+
+func (c *backendReplayCache) cleanupLocked(now time.Time) {
+	if now.Before(c.nextCleanup) {
+		return
+	}
+
+	maps.DeleteFunc(c.nonces, func(nonce string, expires time.Time) bool {
+		return !now.Before(expires)
+	})
+	c.nextCleanup = now.Add(c.window / 2)
+}
+
+// Check returns an error if the nonce has been seen before or if the timestamp
+// is missing or outside of the acceptance window.
+func (c *backendReplayCache) Check(nonce string, timestamp time.Time, now time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.window <= 0 {
+		return nil
+	}
+
+	// Requests without timestamp could be replayed once their nonce expired.
+	if timestamp.IsZero() {
+		return ErrMissingTimestamp
+	} else if timestamp.Before(now.Add(-c.window)) || timestamp.After(now.Add(c.window)) {
+		return ErrBackendRequestExpired
+	}
+
+	c.cleanupLocked(now)
+	if expires, found := c.nonces[nonce]; found && now.Before(expires) {
+		return ErrBackendRequestReplayed
+	}
+
+	// The request will be rejected after the window anyway, only need to
+	// remember the nonce until then.
+	c.nonces[nonce] = timestamp.Add(c.window)
+	return nil
+}
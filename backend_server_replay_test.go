@@ -0,0 +1,75 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackendReplayCache(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	cache := newBackendReplayCache(time.Minute)
+
+	now := time.Now()
+	assert.ErrorIs(cache.Check("nonce1", time.Time{}, now), ErrMissingTimestamp)
+	assert.NoError(cache.Check("nonce1", now, now))
+	assert.ErrorIs(cache.Check("nonce1", now, now.Add(time.Second)), ErrBackendRequestReplayed)
+	assert.NoError(cache.Check("nonce2", now, now))
+	assert.Equal(2, cache.Len())
+
+	// Nonces may be reused with a newer timestamp after the window.
+	later := now.Add(time.Minute + time.Second)
+	assert.ErrorIs(cache.Check("nonce1", now, later), ErrBackendRequestExpired)
+	assert.NoError(cache.Check("nonce1", later, later))
+	// Expired entries have been removed.
+	assert.Equal(1, cache.Len())
+
+	assert.ErrorIs(cache.Check("nonce3", now.Add(-2*time.Minute), now), ErrBackendRequestExpired)
+	assert.ErrorIs(cache.Check("nonce3", now.Add(2*time.Minute), now), ErrBackendRequestExpired)
+	assert.NoError(cache.Check("nonce3", now, now))
+	assert.ErrorIs(cache.Check("nonce3", now, now), ErrBackendRequestReplayed)
+
+	cache.SetWindow(0)
+	assert.Equal(0, cache.Len())
+	assert.NoError(cache.Check("nonce1", time.Time{}, now))
+	assert.NoError(cache.Check("nonce1", time.Time{}, now))
+}
+
+func TestParseBackendTimestamp(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	if ts, err := parseBackendTimestamp(""); assert.NoError(err) {
+		assert.True(ts.IsZero())
+	}
+	if ts, err := parseBackendTimestamp("1234567890"); assert.NoError(err) {
+		assert.Equal(time.Unix(1234567890, 0), ts)
+	}
+	_, err := parseBackendTimestamp("foo")
+	assert.ErrorIs(err, ErrInvalidTimestamp)
+	_, err = parseBackendTimestamp("-1")
+	assert.ErrorIs(err, ErrInvalidTimestamp)
+}
@@ -30,11 +30,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"log/slog"
 	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/textproto"
 	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -276,6 +278,28 @@ func TestBackendServer_NoAuth(t *testing.T) {
 	assert.Equal(http.StatusForbidden, res.StatusCode, "Expected error response, got %s: %s", res.Status, string(body))
 }
 
+func TestBackendServer_Schema(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	_, _, _, _, _, server := CreateBackendServerForTest(t)
+
+	res, err := http.Get(server.URL + "/api/v1/schema")
+	require.NoError(err)
+
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, res.StatusCode, "Expected successful response, got %s: %s", res.Status, string(body))
+	assert.Equal("application/schema+json; charset=utf-8", res.Header.Get("Content-Type"))
+
+	var schema map[string]any
+	if assert.NoError(json.Unmarshal(body, &schema)) {
+		assert.Equal(jsonSchemaDialect, schema["$schema"])
+	}
+}
+
 func TestBackendServer_InvalidAuth(t *testing.T) {
 	t.Parallel()
 	CatchLogForTest(t)
@@ -300,6 +324,76 @@ func TestBackendServer_InvalidAuth(t *testing.T) {
 	assert.Equal(http.StatusForbidden, res.StatusCode, "Expected error response, got %s: %s", res.Status, string(body))
 }
 
+func TestBackendServer_ReplayProtection(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	config := goconf.NewConfigFile()
+	config.AddOption("backend", "replaywindow", "300")
+	_, _, _, _, _, server := CreateBackendServerForTestFromConfig(t, config)
+
+	roomId := "the-room-id"
+	msg := &BackendServerRoomRequest{
+		Type: "invite",
+		Invite: &BackendRoomInviteRequest{
+			UserIds: []string{
+				"the-user-id",
+			},
+			AllUserIds: []string{
+				"the-user-id",
+			},
+		},
+	}
+
+	data, err := json.Marshal(msg)
+	require.NoError(err)
+
+	sendRequestWithChecksum := func(rnd string, timestamp string, checksum string) int {
+		request, err := http.NewRequest("POST", server.URL+"/api/v1/room/"+roomId, bytes.NewReader(data))
+		require.NoError(err)
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("Spreed-Signaling-Random", rnd)
+		request.Header.Set("Spreed-Signaling-Checksum", checksum)
+		request.Header.Set("Spreed-Signaling-Backend", server.URL)
+		if timestamp != "" {
+			request.Header.Set("Spreed-Signaling-Timestamp", timestamp)
+		}
+		client := &http.Client{}
+		res, err := client.Do(request)
+		require.NoError(err)
+
+		defer res.Body.Close()
+		_, err = io.ReadAll(res.Body)
+		assert.NoError(err)
+		return res.StatusCode
+	}
+	sendRequest := func(rnd string, timestamp string) int {
+		checksum := CalculateBackendChecksum(GetBackendChecksumRandom(timestamp, rnd), data, testBackendSecret)
+		return sendRequestWithChecksum(rnd, timestamp, checksum)
+	}
+
+	// Requests without timestamp could be replayed later.
+	rnd := newRandomString(32)
+	assert.Equal(http.StatusForbidden, sendRequest(rnd, ""))
+
+	now := time.Now()
+	assert.Equal(http.StatusForbidden, sendRequest(rnd, strconv.FormatInt(now.Add(-time.Hour).Unix(), 10)))
+	assert.Equal(http.StatusForbidden, sendRequest(rnd, "invalid"))
+	assert.Equal(http.StatusOK, sendRequest(rnd, strconv.FormatInt(now.Unix(), 10)))
+	assert.Equal(http.StatusForbidden, sendRequest(rnd, strconv.FormatInt(now.Unix(), 10)))
+
+	// The timestamp can't be moved into the random header of a captured
+	// request, neither the other way round.
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	rnd = newRandomString(32)
+	checksum := CalculateBackendChecksum(GetBackendChecksumRandom(timestamp, rnd), data, testBackendSecret)
+	assert.Equal(http.StatusOK, sendRequestWithChecksum(rnd, timestamp, checksum))
+	assert.Equal(http.StatusForbidden, sendRequestWithChecksum(timestamp+rnd, "", checksum))
+	assert.Equal(http.StatusForbidden, sendRequestWithChecksum(timestamp+":"+rnd, "", checksum))
+	assert.Equal(http.StatusForbidden, sendRequestWithChecksum(rnd[1:], timestamp+rnd[:1], checksum))
+}
+
 func TestBackendServer_OldCompatAuth(t *testing.T) {
 	t.Parallel()
 	CatchLogForTest(t)
@@ -527,6 +621,61 @@ func RunTestBackendServer_RoomDisinvite(t *testing.T) {
 	client.RunUntilClosed(ctx)
 }
 
+func TestBackendServer_RoomDisinviteKicked(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	_, _, _, hub, _, server := CreateBackendServerForTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client, hello := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId)
+
+	roomId := "test-room"
+	MustSucceed2(t, client.JoinRoom, ctx, roomId)
+	require.True(client.RunUntilJoined(ctx, hello.Hello))
+
+	msg := &BackendServerRoomRequest{
+		Type: "disinvite",
+		Disinvite: &BackendRoomDisinviteRequest{
+			SessionIds: []RoomSessionId{
+				RoomSessionId(roomId + "-" + string(hello.Hello.SessionId)),
+			},
+			Reason: "invalid-reason",
+		},
+	}
+
+	data, err := json.Marshal(msg)
+	require.NoError(err)
+	res, err := performBackendRequest(server.URL+"/api/v1/room/"+roomId, data)
+	require.NoError(err)
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(err)
+	assert.Equal(http.StatusBadRequest, res.StatusCode, "Expected error, got %s", string(body))
+
+	msg.Disinvite.Reason = ByeReasonKicked
+	data, err = json.Marshal(msg)
+	require.NoError(err)
+	res, err = performBackendRequest(server.URL+"/api/v1/room/"+roomId, data)
+	require.NoError(err)
+	defer res.Body.Close()
+	body, err = io.ReadAll(res.Body)
+	assert.NoError(err)
+	assert.Equal(http.StatusOK, res.StatusCode, "Expected successful request, got %s", string(body))
+
+	if message, ok := client.RunUntilMessage(ctx); ok {
+		if checkMessageType(t, message, "bye") {
+			assert.Equal(ByeReasonKicked, message.Bye.Reason, "%+v", message.Bye)
+			assert.False(message.Bye.Reconnect, "%+v", message.Bye)
+		}
+	}
+
+	client.RunUntilClosed(ctx)
+}
+
 func TestBackendServer_RoomDisinviteDifferentRooms(t *testing.T) {
 	t.Parallel()
 	CatchLogForTest(t)
@@ -1324,7 +1473,7 @@ func TestBackendServer_StatsAllowedIps(t *testing.T) {
 			r1 := &http.Request{
 				RemoteAddr: addr,
 			}
-			assert.True(backend.allowStatsAccess(r1), "should allow %s", addr)
+			assert.NoError(backend.checkStatsAccess(r1, StatsPermissionAdmin), "should allow %s", addr)
 
 			if host, _, err := net.SplitHostPort(addr); err == nil {
 				addr = host
@@ -1336,7 +1485,7 @@ func TestBackendServer_StatsAllowedIps(t *testing.T) {
 					textproto.CanonicalMIMEHeaderKey("x-real-ip"): []string{addr},
 				},
 			}
-			assert.True(backend.allowStatsAccess(r2), "should allow %s", addr)
+			assert.NoError(backend.checkStatsAccess(r2, StatsPermissionAdmin), "should allow %s", addr)
 
 			r3 := &http.Request{
 				RemoteAddr: "1.2.3.4:12345",
@@ -1344,7 +1493,7 @@ func TestBackendServer_StatsAllowedIps(t *testing.T) {
 					textproto.CanonicalMIMEHeaderKey("x-forwarded-for"): []string{addr},
 				},
 			}
-			assert.True(backend.allowStatsAccess(r3), "should allow %s", addr)
+			assert.NoError(backend.checkStatsAccess(r3, StatsPermissionAdmin), "should allow %s", addr)
 
 			r4 := &http.Request{
 				RemoteAddr: "1.2.3.4:12345",
@@ -1352,7 +1501,7 @@ func TestBackendServer_StatsAllowedIps(t *testing.T) {
 					textproto.CanonicalMIMEHeaderKey("x-forwarded-for"): []string{addr + ", 1.2.3.4:23456"},
 				},
 			}
-			assert.True(backend.allowStatsAccess(r4), "should allow %s", addr)
+			assert.NoError(backend.checkStatsAccess(r4, StatsPermissionAdmin), "should allow %s", addr)
 		})
 	}
 
@@ -1362,11 +1511,308 @@ func TestBackendServer_StatsAllowedIps(t *testing.T) {
 			r := &http.Request{
 				RemoteAddr: addr,
 			}
-			assert.False(t, backend.allowStatsAccess(r), "should not allow %s", addr)
+			assert.ErrorIs(t, backend.checkStatsAccess(r, StatsPermissionAdmin), ErrStatsAddressNotAllowed, "should not allow %s", addr)
 		})
 	}
 }
 
+func TestBackendServer_StatsToken(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	config := goconf.NewConfigFile()
+	config.AddOption("stats", "token", "admin-token")
+	config.AddOption("stats", "metrics_token", "metrics-token")
+	_, _, _, _, _, server := CreateBackendServerForTestFromConfig(t, config)
+
+	request := func(path string, token string) int {
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		require.NoError(err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(err)
+		defer res.Body.Close()
+		io.Copy(io.Discard, res.Body) // nolint
+		return res.StatusCode
+	}
+
+	assert.Equal(http.StatusUnauthorized, request("/api/v1/stats", ""))
+	assert.Equal(http.StatusUnauthorized, request("/api/v1/stats", "metrics-token"))
+	assert.Equal(http.StatusOK, request("/api/v1/stats", "admin-token"))
+
+	assert.Equal(http.StatusUnauthorized, request("/metrics", ""))
+	assert.Equal(http.StatusOK, request("/metrics", "metrics-token"))
+	assert.Equal(http.StatusOK, request("/metrics", "admin-token"))
+}
+
+func TestBackendServer_DebugHandlers(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	config := goconf.NewConfigFile()
+	config.AddOption("stats", "token", "admin-token")
+	config.AddOption("stats", "metrics_token", "metrics-token")
+	_, backend, _, _, r, server := CreateBackendServerForTestFromConfig(t, config)
+	backend.InstallDebugHandlers(r)
+
+	request := func(path string, token string) int {
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		require.NoError(err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(err)
+		defer res.Body.Close()
+		io.Copy(io.Discard, res.Body) // nolint
+		return res.StatusCode
+	}
+
+	assert.Equal(http.StatusUnauthorized, request("/debug/pprof/", ""))
+	assert.Equal(http.StatusUnauthorized, request("/debug/pprof/goroutine", ""))
+	assert.Equal(http.StatusUnauthorized, request("/debug/pprof/", "metrics-token"))
+	assert.Equal(http.StatusOK, request("/debug/pprof/", "admin-token"))
+	assert.Equal(http.StatusOK, request("/debug/pprof/goroutine", "admin-token"))
+}
+
+func TestBackendServer_Config(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	config, _, _, hub, _, server := CreateBackendServerForTest(t)
+
+	res, err := http.Get(server.URL + "/api/v1/config")
+	require.NoError(err)
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(err)
+	require.Equal(http.StatusOK, res.StatusCode, "Expected successful request, got %s", string(body))
+	assert.NotContains(string(body), string(testInternalSecret))
+
+	var info BackendServerConfig
+	require.NoError(json.Unmarshal(body, &info))
+	assert.Equal("no-version", info.Version)
+	assert.False(info.Loaded.IsZero())
+	assert.Equal(redactedConfigValue, info.Config["backend1"]["secret"])
+	assert.Equal(redactedConfigValue, info.Config["sessions"]["hashkey"])
+	assert.Equal("none", info.Config["geoip"]["url"])
+	if assert.Len(info.Backends, 1) {
+		assert.Equal("backend1", info.Backends[0].Id)
+		assert.Equal([]string{server.URL + "/"}, info.Backends[0].Urls)
+		assert.True(info.Backends[0].AllowHttp)
+	}
+
+	// Values applied by a reload are returned.
+	config.AddOption("app", "trustedproxies", "1.2.3.4")
+	hub.Reload(config)
+
+	res2, err := http.Get(server.URL + "/api/v1/config")
+	require.NoError(err)
+	defer res2.Body.Close()
+	body, err = io.ReadAll(res2.Body)
+	assert.NoError(err)
+	require.Equal(http.StatusOK, res2.StatusCode, "Expected successful request, got %s", string(body))
+
+	var info2 BackendServerConfig
+	require.NoError(json.Unmarshal(body, &info2))
+	assert.Equal("1.2.3.4", info2.Config["app"]["trustedproxies"])
+	assert.False(info2.Loaded.Before(info.Loaded))
+}
+
+func TestBackendServer_GeoIPLookup(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	_, _, _, hub, _, server := CreateBackendServerForTest(t)
+
+	performLookup := func(ip string) (int, string) {
+		res, err := http.Get(server.URL + "/api/v1/geoip/lookup?ip=" + url.QueryEscape(ip))
+		require.NoError(err)
+		defer res.Body.Close()
+		data, err := io.ReadAll(res.Body)
+		require.NoError(err)
+		return res.StatusCode, string(data)
+	}
+
+	status, body := performLookup("invalid")
+	assert.Equal(http.StatusBadRequest, status, body)
+
+	require.NoError(hub.geoipOverrides.SetApiOverrides(map[string]string{
+		"10.0.0.0/8": "DE",
+	}))
+
+	status, body = performLookup("10.1.2.3")
+	require.Equal(http.StatusOK, status, body)
+	var lookup BackendServerGeoIPLookup
+	require.NoError(json.Unmarshal([]byte(body), &lookup))
+	assert.Equal("10.1.2.3", lookup.IP)
+	assert.Equal("DE", lookup.Country)
+	// No proxies are used for the builtin MCU.
+	assert.Empty(lookup.Proxies)
+
+	status, body = performLookup("127.0.0.1")
+	require.Equal(http.StatusOK, status, body)
+	require.NoError(json.Unmarshal([]byte(body), &lookup))
+	assert.Equal(loopback, lookup.Country)
+}
+
+func TestBackendServer_Load(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	_, _, _, hub, _, server := CreateBackendServerForTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client, _ := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId)
+	defer client.CloseWithBye()
+	hub.performHousekeeping(time.Now())
+
+	res, err := http.Get(server.URL + "/api/v1/load")
+	require.NoError(err)
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.StatusCode, string(body))
+
+	var load BackendServerLoad
+	require.NoError(json.Unmarshal(body, &load), string(body))
+	assert.Equal(1, load.Sessions)
+	assert.Equal(defaultLoadHeadroom, load.Headroom)
+	// No capacity is configured.
+	assert.EqualValues(0, load.Score)
+	assert.Nil(load.McuLoad)
+}
+
+func TestBackendServer_ReloadTurn(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	config, backend, _, _, _, _ := CreateBackendServerForTestWithTurn(t)
+
+	assert.Equal(turnServers, backend.turn.Load().servers)
+
+	config.AddOption("turn", "servers", "turn:5.6.7.8:9991")
+	require.NoError(backend.Reload(config))
+	assert.Equal([]string{"turn:5.6.7.8:9991"}, backend.turn.Load().servers)
+
+	// Invalid settings are not applied.
+	config.RemoveOption("turn", "secret")
+	err := backend.Reload(config)
+	var se *ConfigSectionError
+	if assert.ErrorAs(err, &se) {
+		assert.Equal("turn", se.Section)
+	}
+	assert.Equal([]string{"turn:5.6.7.8:9991"}, backend.turn.Load().servers)
+}
+
+func TestBackendServer_Health(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	_, _, _, hub, _, server := CreateBackendServerForTest(t)
+
+	getHealth := func(path string) (int, *BackendServerHealth) {
+		res, err := http.Get(server.URL + path)
+		require.NoError(err)
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		require.NoError(err)
+
+		var health BackendServerHealth
+		require.NoError(json.Unmarshal(body, &health), "invalid response %s", string(body))
+		return res.StatusCode, &health
+	}
+
+	status, health := getHealth("/live")
+	assert.Equal(http.StatusOK, status)
+	assert.Equal(HealthStatusOk, health.Status)
+	assert.Len(health.Components, 1)
+	assert.Equal(HealthStatusOk, health.Components["hub"].Status)
+
+	status, health = getHealth("/ready")
+	assert.Equal(http.StatusOK, status)
+	assert.Equal(HealthStatusOk, health.Status)
+	assert.Equal(HealthStatusOk, health.Components["hub"].Status)
+	assert.Equal(HealthStatusOk, health.Components["events"].Status)
+	assert.Equal(HealthStatusDisabled, health.Components["etcd"].Status)
+	assert.Equal(HealthStatusDisabled, health.Components["mcu"].Status)
+	assert.Equal(HealthStatusDisabled, health.Components["grpc"].Status)
+	assert.Equal(HealthStatusOk, health.Components["backends"].Status)
+
+	// A server that is shutting down is still alive but no longer ready.
+	hub.ScheduleShutdown()
+	status, health = getHealth("/live")
+	assert.Equal(http.StatusOK, status)
+	assert.Equal(HealthStatusOk, health.Status)
+
+	status, health = getHealth("/ready")
+	assert.Equal(http.StatusServiceUnavailable, status)
+	assert.Equal(HealthStatusError, health.Status)
+	assert.Equal(HealthStatusError, health.Components["hub"].Status)
+}
+
+func TestBackendServer_Events(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	_, _, _, _, _, server := CreateBackendServerForTest(t)
+
+	start := time.Now().Add(-time.Second)
+	backendLog.Eventf(RecentEventTypeBackend, slog.LevelInfo, "Test event %s", t.Name())
+
+	getEvents := func(query url.Values) []RecentEvent {
+		res, err := http.Get(server.URL + "/api/v1/events?" + query.Encode())
+		require.NoError(err)
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		require.NoError(err)
+		require.Equal(http.StatusOK, res.StatusCode, "Expected successful request, got %s", string(body))
+
+		var events BackendServerEvents
+		require.NoError(json.Unmarshal(body, &events))
+		var result []RecentEvent
+		for _, event := range events.Events {
+			if event.Message == "Test event "+t.Name() {
+				result = append(result, event)
+			}
+		}
+		return result
+	}
+
+	if events := getEvents(url.Values{
+		"since": []string{start.Format(time.RFC3339)},
+	}); assert.Len(events, 1) {
+		assert.Equal(RecentEventTypeBackend, events[0].Type)
+		assert.Equal(string(LogSubsystemBackend), events[0].Subsystem)
+	}
+	assert.Len(getEvents(url.Values{
+		"type": []string{"error, backend"},
+	}), 1)
+	assert.Empty(getEvents(url.Values{
+		"type": []string{"error"},
+	}))
+	assert.Empty(getEvents(url.Values{
+		"since": []string{time.Now().Add(time.Hour).Format(time.RFC3339)},
+	}))
+
+	res, err := http.Get(server.URL + "/api/v1/events?since=invalid")
+	require.NoError(err)
+	defer res.Body.Close()
+	assert.Equal(http.StatusBadRequest, res.StatusCode)
+}
+
 func Test_IsNumeric(t *testing.T) {
 	t.Parallel()
 	numeric := []string{
@@ -1436,6 +1882,112 @@ func TestBackendServer_DialoutNoSipBridge(t *testing.T) {
 	}
 }
 
+func TestBackendServer_DialoutNumberNotAllowed(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	_, _, _, hub, _, server := CreateBackendServerForTest(t)
+
+	u, err := url.Parse(server.URL)
+	require.NoError(err)
+	backend := hub.backend.GetBackend(u)
+	require.NotNil(backend)
+	backend.dialoutDeniedPatterns = []string{"+1900*"}
+
+	client := NewTestClient(t, server, hub)
+	defer client.CloseWithBye()
+	require.NoError(client.SendHelloInternalWithFeatures([]string{"start-dialout"}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	MustSucceed1(t, client.RunUntilHello, ctx)
+
+	roomId := "12345"
+	msg := &BackendServerRoomRequest{
+		Type: "dialout",
+		Dialout: &BackendRoomDialoutRequest{
+			Number: "001 (900) 123-4567",
+		},
+	}
+
+	data, err := json.Marshal(msg)
+	require.NoError(err)
+	res, err := performBackendRequest(server.URL+"/api/v1/room/"+roomId, data)
+	require.NoError(err)
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(err)
+	require.Equal(http.StatusForbidden, res.StatusCode, "Expected error, got %s", string(body))
+
+	var response BackendServerRoomResponse
+	if assert.NoError(json.Unmarshal(body, &response)) {
+		assert.Equal("dialout", response.Type)
+		if assert.NotNil(response.Dialout) &&
+			assert.NotNil(response.Dialout.Error) {
+			assert.Equal(NumberNotAllowed.Code, response.Dialout.Error.Code)
+		}
+	}
+
+	// The SIP bridge didn't receive a request.
+	ctx2, cancel2 := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel2()
+	client.RunUntilErrorIs(ctx2, ErrNoMessageReceived, context.DeadlineExceeded)
+}
+
+func TestBackendServer_DialoutBusy(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	_, _, _, hub, _, server := CreateBackendServerForTest(t)
+	hub.dialoutBalancer = newDialoutBalancerForTest(t, "", "1")
+
+	client := NewTestClient(t, server, hub)
+	defer client.CloseWithBye()
+	require.NoError(client.SendHelloInternalWithFeatures([]string{"start-dialout"}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	hello := MustSucceed1(t, client.RunUntilHello, ctx)
+	session := hub.GetSessionByPublicId(hello.Hello.SessionId).(*ClientSession)
+	require.NotNil(session)
+	hub.dialoutBalancer.AddCall(session, "call-123")
+
+	roomId := "12345"
+	msg := &BackendServerRoomRequest{
+		Type: "dialout",
+		Dialout: &BackendRoomDialoutRequest{
+			Number: "+1234567890",
+		},
+	}
+
+	data, err := json.Marshal(msg)
+	require.NoError(err)
+	res, err := performBackendRequest(server.URL+"/api/v1/room/"+roomId, data)
+	require.NoError(err)
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(err)
+	require.Equal(http.StatusServiceUnavailable, res.StatusCode, "Expected error, got %s", string(body))
+
+	var response BackendServerRoomResponse
+	if assert.NoError(json.Unmarshal(body, &response)) {
+		assert.Equal("dialout", response.Type)
+		if assert.NotNil(response.Dialout) &&
+			assert.NotNil(response.Dialout.Error) {
+			assert.Equal("dialout_busy", response.Dialout.Error.Code)
+		}
+	}
+
+	if info := hub.GetServerInfoDialout(); assert.Len(info, 1) {
+		assert.Equal(1, info[0].Calls)
+		assert.Equal(1, info[0].MaxCalls)
+	}
+}
+
 func TestBackendServer_DialoutAccepted(t *testing.T) {
 	t.Parallel()
 	CatchLogForTest(t)
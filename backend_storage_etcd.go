@@ -26,7 +26,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"net/url"
 	"slices"
 	"time"
@@ -120,9 +119,9 @@ func (s *backendStorageEtcd) EtcdClientCreated(client *EtcdClient) {
 				if errors.Is(err, context.Canceled) {
 					return
 				} else if errors.Is(err, context.DeadlineExceeded) {
-					log.Printf("Timeout getting initial list of backends, retry in %s", backoff.NextWait())
+					backendLog.Warnf("Timeout getting initial list of backends, retry in %s", backoff.NextWait())
 				} else {
-					log.Printf("Could not get initial list of backends, retry in %s: %s", backoff.NextWait(), err)
+					backendLog.Errorf("Could not get initial list of backends, retry in %s: %s", backoff.NextWait(), err)
 				}
 
 				backoff.Wait(s.closeCtx)
@@ -140,7 +139,7 @@ func (s *backendStorageEtcd) EtcdClientCreated(client *EtcdClient) {
 			for s.closeCtx.Err() == nil {
 				var err error
 				if nextRevision, err = client.Watch(s.closeCtx, s.keyPrefix, nextRevision, s, clientv3.WithPrefix()); err != nil {
-					log.Printf("Error processing watch for %s (%s), retry in %s", s.keyPrefix, err, backoff.NextWait())
+					backendLog.Errorf("Error processing watch for %s (%s), retry in %s", s.keyPrefix, err, backoff.NextWait())
 					backoff.Wait(s.closeCtx)
 					continue
 				}
@@ -149,7 +148,7 @@ func (s *backendStorageEtcd) EtcdClientCreated(client *EtcdClient) {
 					backoff.Reset()
 					prevRevision = nextRevision
 				} else {
-					log.Printf("Processing watch for %s interrupted, retry in %s", s.keyPrefix, backoff.NextWait())
+					backendLog.Infof("Processing watch for %s interrupted, retry in %s", s.keyPrefix, backoff.NextWait())
 					backoff.Wait(s.closeCtx)
 				}
 			}
@@ -171,11 +170,11 @@ func (s *backendStorageEtcd) getBackends(ctx context.Context, client *EtcdClient
 func (s *backendStorageEtcd) EtcdKeyUpdated(client *EtcdClient, key string, data []byte, prevValue []byte) {
 	var info BackendInformationEtcd
 	if err := json.Unmarshal(data, &info); err != nil {
-		log.Printf("Could not decode backend information %s: %s", string(data), err)
+		backendLog.Errorf("Could not decode backend information %s: %s", string(data), err)
 		return
 	}
 	if err := info.CheckValid(); err != nil {
-		log.Printf("Received invalid backend information %s: %s", string(data), err)
+		backendLog.Warnf("Received invalid backend information %s: %s", string(data), err)
 		return
 	}
 
@@ -193,6 +192,19 @@ func (s *backendStorageEtcd) EtcdKeyUpdated(client *EtcdClient, key string, data
 		maxStreamBitrate: info.MaxStreamBitrate,
 		maxScreenBitrate: info.MaxScreenBitrate,
 		sessionLimit:     info.SessionLimit,
+
+		requestPool: newBackendPool(key, BackendPoolRequests, info.ConcurrentRequests),
+		messagePool: newBackendPool(key, BackendPoolMessages, info.ConcurrentMessages),
+
+		messageRate: newRateLimiter(info.MessageRate, time.Second),
+		joinRate:    newRateLimiter(info.JoinRate, time.Minute),
+
+		disabledFeatures: parseDisabledBackendFeatures(key, info.DisabledFeatures),
+
+		dialoutAllowedPrefixes: parseDialoutAllowedPrefixes(key, info.DialoutAllowedPrefixes),
+		dialoutDeniedPatterns:  parseDialoutDeniedPatterns(key, info.DialoutDeniedPatterns),
+
+		oidcIssuer: info.OidcIssuer,
 	}
 
 	s.mu.Lock()
@@ -205,7 +217,7 @@ func (s *backendStorageEtcd) EtcdKeyUpdated(client *EtcdClient, key string, data
 		entries, found := s.backends[host]
 		if !found {
 			// Simple case, first backend for this host
-			log.Printf("Added backend %s (from %s)", info.Urls[idx], key)
+			backendLog.Infof("Added backend %s (from %s)", info.Urls[idx], key)
 			s.backends[host] = []*Backend{backend}
 			added = true
 			continue
@@ -215,7 +227,7 @@ func (s *backendStorageEtcd) EtcdKeyUpdated(client *EtcdClient, key string, data
 		replaced := false
 		for idx, entry := range entries {
 			if entry.id == key {
-				log.Printf("Updated backend %s (from %s)", info.Urls[idx], key)
+				backendLog.Infof("Updated backend %s (from %s)", info.Urls[idx], key)
 				entries[idx] = backend
 				replaced = true
 				break
@@ -224,7 +236,7 @@ func (s *backendStorageEtcd) EtcdKeyUpdated(client *EtcdClient, key string, data
 
 		if !replaced {
 			// New backend, add to list.
-			log.Printf("Added backend %s (from %s)", info.Urls[idx], key)
+			backendLog.Infof("Added backend %s (from %s)", info.Urls[idx], key)
 			s.backends[host] = append(entries, backend)
 			added = true
 		}
@@ -256,13 +268,13 @@ func (s *backendStorageEtcd) EtcdKeyDeleted(client *EtcdClient, key string, prev
 				if slices.ContainsFunc(d, func(b *Backend) bool {
 					return slices.Contains(b.urls, u.String())
 				}) {
-					log.Printf("Removing backend %s (from %s)", info.Urls[idx], key)
+					backendLog.Infof("Removing backend %s (from %s)", info.Urls[idx], key)
 				}
 			}
 			continue
 		}
 
-		log.Printf("Removing backend %s (from %s)", info.Urls[idx], key)
+		backendLog.Infof("Removing backend %s (from %s)", info.Urls[idx], key)
 		newEntries := make([]*Backend, 0, len(entries)-1)
 		for _, entry := range entries {
 			if entry.id == key {
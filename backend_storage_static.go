@@ -22,10 +22,10 @@
 package signaling
 
 import (
-	"log"
 	"net/url"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/dlintw/goconf"
 )
@@ -49,12 +49,16 @@ func NewBackendStorageStatic(config *goconf.ConfigFile) (BackendStorage, error)
 	if err != nil || sessionLimit < 0 {
 		sessionLimit = 0
 	}
+	concurrentRequests, _ := config.GetInt("backend", "concurrentrequests")
+	concurrentMessages, _ := config.GetInt("backend", "concurrentmessages")
+	messageRate, _ := config.GetInt("backend", "messagerate")
+	joinRate, _ := config.GetInt("backend", "joinrate")
 	backends := make(map[string][]*Backend)
 	backendsById := make(map[string]*Backend)
 	var compatBackend *Backend
 	numBackends := 0
 	if allowAll {
-		log.Println("WARNING: All backend hostnames are allowed, only use for development!")
+		backendLog.Warnf("All backend hostnames are allowed, only use for development!")
 		compatBackend = &Backend{
 			id:     "compat",
 			secret: []byte(commonSecret),
@@ -63,9 +67,15 @@ func NewBackendStorageStatic(config *goconf.ConfigFile) (BackendStorage, error)
 
 			sessionLimit: uint64(sessionLimit),
 			counted:      true,
+
+			requestPool: newBackendPool("compat", BackendPoolRequests, concurrentRequests),
+			messagePool: newBackendPool("compat", BackendPoolMessages, concurrentMessages),
+
+			messageRate: newRateLimiter(messageRate, time.Second),
+			joinRate:    newRateLimiter(joinRate, time.Minute),
 		}
 		if sessionLimit > 0 {
-			log.Printf("Allow a maximum of %d sessions", sessionLimit)
+			backendLog.Infof("Allow a maximum of %d sessions", sessionLimit)
 		}
 		updateBackendStats(compatBackend)
 		backendsById[compatBackend.id] = compatBackend
@@ -79,7 +89,7 @@ func NewBackendStorageStatic(config *goconf.ConfigFile) (BackendStorage, error)
 			}
 		}
 		for _, be := range added {
-			log.Printf("Backend %s added for %s", be.id, strings.Join(be.urls, ", "))
+			backendLog.Infof("Backend %s added for %s", be.id, strings.Join(be.urls, ", "))
 			backendsById[be.id] = be
 			updateBackendStats(be)
 			be.counted = true
@@ -90,7 +100,7 @@ func NewBackendStorageStatic(config *goconf.ConfigFile) (BackendStorage, error)
 		allowMap := make(map[string]bool)
 		for u := range SplitEntries(allowedUrls, ",") {
 			if idx := strings.IndexByte(u, '/'); idx != -1 {
-				log.Printf("WARNING: Removing path from allowed hostname \"%s\", check your configuration!", u)
+				backendLog.Warnf("Removing path from allowed hostname \"%s\", check your configuration!", u)
 				if u = u[:idx]; u == "" {
 					continue
 				}
@@ -100,7 +110,7 @@ func NewBackendStorageStatic(config *goconf.ConfigFile) (BackendStorage, error)
 		}
 
 		if len(allowMap) == 0 {
-			log.Println("WARNING: No backend hostnames are allowed, check your configuration!")
+			backendLog.Warnf("No backend hostnames are allowed, check your configuration!")
 		} else {
 			compatBackend = &Backend{
 				id:     "compat",
@@ -110,6 +120,12 @@ func NewBackendStorageStatic(config *goconf.ConfigFile) (BackendStorage, error)
 
 				sessionLimit: uint64(sessionLimit),
 				counted:      true,
+
+				requestPool: newBackendPool("compat", BackendPoolRequests, concurrentRequests),
+				messagePool: newBackendPool("compat", BackendPoolMessages, concurrentMessages),
+
+				messageRate: newRateLimiter(messageRate, time.Second),
+				joinRate:    newRateLimiter(joinRate, time.Minute),
 			}
 			hosts := make([]string, 0, len(allowMap))
 			for host := range allowMap {
@@ -117,11 +133,11 @@ func NewBackendStorageStatic(config *goconf.ConfigFile) (BackendStorage, error)
 				backends[host] = []*Backend{compatBackend}
 			}
 			if len(hosts) > 1 {
-				log.Println("WARNING: Using deprecated backend configuration. Please migrate the \"allowed\" setting to the new \"backends\" configuration.")
+				backendLog.Warnf("Using deprecated backend configuration. Please migrate the \"allowed\" setting to the new \"backends\" configuration.")
 			}
-			log.Printf("Allowed backend hostnames: %s", hosts)
+			backendLog.Infof("Allowed backend hostnames: %s", hosts)
 			if sessionLimit > 0 {
-				log.Printf("Allow a maximum of %d sessions", sessionLimit)
+				backendLog.Infof("Allow a maximum of %d sessions", sessionLimit)
 			}
 			updateBackendStats(compatBackend)
 			backendsById[compatBackend.id] = compatBackend
@@ -130,7 +146,7 @@ func NewBackendStorageStatic(config *goconf.ConfigFile) (BackendStorage, error)
 	}
 
 	if numBackends == 0 {
-		log.Printf("WARNING: No backends configured, client connections will not be possible.")
+		backendLog.Warnf("No backends configured, client connections will not be possible.")
 	}
 
 	statsBackendsCurrent.Add(float64(numBackends))
@@ -162,7 +178,7 @@ func (s *backendStorageStatic) RemoveBackendsForHost(host string, seen map[strin
 			urls := slices.DeleteFunc(backend.urls, func(s string) bool {
 				return !strings.Contains(s, "://"+host)
 			})
-			log.Printf("Backend %s removed for %s", backend.id, strings.Join(urls, ", "))
+			backendLog.Infof("Backend %s removed for %s", backend.id, strings.Join(urls, ", "))
 			if len(urls) == len(backend.urls) && backend.counted {
 				deleteBackendStats(backend)
 				delete(s.backendsById, backend.Id())
@@ -199,7 +215,7 @@ func (s *backendStorageStatic) UpsertHost(host string, backends []*Backend, seen
 				backends = slices.Delete(backends, index, index+1)
 				if seen[newBackend.id] != seenUpdated {
 					seen[newBackend.id] = seenUpdated
-					log.Printf("Backend %s updated for %s", newBackend.id, strings.Join(newBackend.urls, ", "))
+					backendLog.Infof("Backend %s updated for %s", newBackend.id, strings.Join(newBackend.urls, ", "))
 					updateBackendStats(newBackend)
 					newBackend.counted = existingBackend.counted
 					s.backendsById[newBackend.id] = newBackend
@@ -216,7 +232,7 @@ func (s *backendStorageStatic) UpsertHost(host string, backends []*Backend, seen
 				urls := slices.DeleteFunc(removed.urls, func(s string) bool {
 					return !strings.Contains(s, "://"+host)
 				})
-				log.Printf("Backend %s removed for %s", removed.id, strings.Join(urls, ", "))
+				backendLog.Infof("Backend %s removed for %s", removed.id, strings.Join(urls, ", "))
 				if len(urls) == len(removed.urls) && removed.counted {
 					deleteBackendStats(removed)
 					delete(s.backendsById, removed.Id())
@@ -242,7 +258,7 @@ func (s *backendStorageStatic) UpsertHost(host string, backends []*Backend, seen
 			s.backendsById[added.id] = added
 		}
 
-		log.Printf("Backend %s added for %s", added.id, strings.Join(added.urls, ", "))
+		backendLog.Infof("Backend %s added for %s", added.id, strings.Join(added.urls, ", "))
 		if !added.counted {
 			updateBackendStats(added)
 			addedBackends++
@@ -273,11 +289,11 @@ func getConfiguredHosts(backendIds string, config *goconf.ConfigFile, commonSecr
 	for _, id := range getConfiguredBackendIDs(backendIds) {
 		secret, _ := GetStringOptionWithEnv(config, id, "secret")
 		if secret == "" && commonSecret != "" {
-			log.Printf("Backend %s has no own shared secret set, using common shared secret", id)
+			backendLog.Infof("Backend %s has no own shared secret set, using common shared secret", id)
 			secret = commonSecret
 		}
 		if secret == "" {
-			log.Printf("Backend %s is missing or incomplete, skipping", id)
+			backendLog.Infof("Backend %s is missing or incomplete, skipping", id)
 			continue
 		}
 
@@ -286,7 +302,24 @@ func getConfiguredHosts(backendIds string, config *goconf.ConfigFile, commonSecr
 			sessionLimit = 0
 		}
 		if sessionLimit > 0 {
-			log.Printf("Backend %s allows a maximum of %d sessions", id, sessionLimit)
+			backendLog.Infof("Backend %s allows a maximum of %d sessions", id, sessionLimit)
+		}
+
+		concurrentRequests, _ := config.GetInt(id, "concurrentrequests")
+		if concurrentRequests > 0 {
+			backendLog.Infof("Backend %s allows a maximum of %d concurrent requests", id, concurrentRequests)
+		}
+		concurrentMessages, _ := config.GetInt(id, "concurrentmessages")
+		if concurrentMessages > 0 {
+			backendLog.Infof("Backend %s processes a maximum of %d concurrent messages", id, concurrentMessages)
+		}
+		messageRate, _ := config.GetInt(id, "messagerate")
+		if messageRate > 0 {
+			backendLog.Infof("Backend %s allows a maximum of %d messages per second", id, messageRate)
+		}
+		joinRate, _ := config.GetInt(id, "joinrate")
+		if joinRate > 0 {
+			backendLog.Infof("Backend %s allows a maximum of %d room joins per minute", id, joinRate)
 		}
 
 		maxStreamBitrate, err := config.GetInt(id, "maxstreambitrate")
@@ -298,6 +331,41 @@ func getConfiguredHosts(backendIds string, config *goconf.ConfigFile, commonSecr
 			maxScreenBitrate = 0
 		}
 
+		var disabledFeatures []string
+		if features, _ := config.GetString(id, "disabledfeatures"); features != "" {
+			disabledFeatures = parseDisabledBackendFeatures(id, slices.Collect(SplitEntries(features, ",")))
+			if len(disabledFeatures) > 0 {
+				backendLog.Infof("Backend %s has disabled features: %s", id, strings.Join(disabledFeatures, ", "))
+			}
+		}
+
+		var dialoutAllowedPrefixes []string
+		if prefixes, _ := config.GetString(id, "dialoutallowedprefixes"); prefixes != "" {
+			dialoutAllowedPrefixes = parseDialoutAllowedPrefixes(id, slices.Collect(SplitEntries(prefixes, ",")))
+			if len(dialoutAllowedPrefixes) > 0 {
+				backendLog.Infof("Backend %s allows dialout to numbers starting with: %s", id, strings.Join(dialoutAllowedPrefixes, ", "))
+			}
+		}
+		var dialoutDeniedPatterns []string
+		if patterns, _ := config.GetString(id, "dialoutdeniedpatterns"); patterns != "" {
+			dialoutDeniedPatterns = parseDialoutDeniedPatterns(id, slices.Collect(SplitEntries(patterns, ",")))
+			if len(dialoutDeniedPatterns) > 0 {
+				backendLog.Infof("Backend %s denies dialout to numbers matching: %s", id, strings.Join(dialoutDeniedPatterns, ", "))
+			}
+		}
+
+		var oidcIssuer string
+		if issuer, _ := config.GetString(id, "oidcissuer"); issuer != "" {
+			parsed, err := ParseOidcIssuer(issuer)
+			if err != nil {
+				backendLog.Errorf("Backend %s has an invalid OpenID Connect issuer, skipping: %s", id, err)
+				continue
+			}
+
+			oidcIssuer = parsed
+			backendLog.Infof("Backend %s validates tokens with keys of OpenID Connect issuer %s", id, oidcIssuer)
+		}
+
 		var urls []string
 		if u, _ := GetStringOptionWithEnv(config, id, "urls"); u != "" {
 			urls = slices.Sorted(SplitEntries(u, ","))
@@ -309,7 +377,7 @@ func getConfiguredHosts(backendIds string, config *goconf.ConfigFile, commonSecr
 		}
 
 		if len(urls) == 0 {
-			log.Printf("Backend %s is missing or incomplete, skipping", id)
+			backendLog.Infof("Backend %s is missing or incomplete, skipping", id)
 			continue
 		}
 
@@ -320,7 +388,20 @@ func getConfiguredHosts(backendIds string, config *goconf.ConfigFile, commonSecr
 			maxStreamBitrate: maxStreamBitrate,
 			maxScreenBitrate: maxScreenBitrate,
 
+			disabledFeatures: disabledFeatures,
+
+			dialoutAllowedPrefixes: dialoutAllowedPrefixes,
+			dialoutDeniedPatterns:  dialoutDeniedPatterns,
+
+			oidcIssuer: oidcIssuer,
+
 			sessionLimit: uint64(sessionLimit),
+
+			requestPool: newBackendPool(id, BackendPoolRequests, concurrentRequests),
+			messagePool: newBackendPool(id, BackendPoolMessages, concurrentMessages),
+
+			messageRate: newRateLimiter(messageRate, time.Second),
+			joinRate:    newRateLimiter(joinRate, time.Minute),
 		}
 
 		added := make(map[string]bool)
@@ -331,7 +412,7 @@ func getConfiguredHosts(backendIds string, config *goconf.ConfigFile, commonSecr
 
 			parsed, err := url.Parse(u)
 			if err != nil {
-				log.Printf("Backend %s has an invalid url %s configured (%s), skipping", id, u, err)
+				backendLog.Infof("Backend %s has an invalid url %s configured (%s), skipping", id, u, err)
 				continue
 			}
 
@@ -341,7 +422,7 @@ func getConfiguredHosts(backendIds string, config *goconf.ConfigFile, commonSecr
 			}
 
 			if prev, found := seenUrls[u]; found {
-				log.Printf("Url %s in backend %s was already used in backend %s, skipping", u, id, prev)
+				backendLog.Infof("Url %s in backend %s was already used in backend %s, skipping", u, id, prev)
 				continue
 			}
 
@@ -366,7 +447,7 @@ func (s *backendStorageStatic) Reload(config *goconf.ConfigFile) {
 	defer s.mu.Unlock()
 
 	if s.compatBackend != nil {
-		log.Println("Old-style configuration active, reload is not supported")
+		backendLog.Infof("Old-style configuration active, reload is not supported")
 		return
 	}
 
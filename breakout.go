@@ -0,0 +1,73 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"slices"
+	"sync"
+)
+
+// BreakoutManager keeps track of the breakout rooms that were started for
+// parent rooms.
+type BreakoutManager struct {
+	mu sync.RWMutex
+	// Ids of the breakout rooms by backend and parent room id.
+	rooms map[string][]string
+}
+
+func NewBreakoutManager() *BreakoutManager {
+	return &BreakoutManager{
+		rooms: make(map[string][]string),
+	}
+}
+
+// Start stores the breakout rooms of a parent room, replacing any breakout
+// rooms that were started before.
+func (m *BreakoutManager) Start(roomId string, backend *Backend, rooms []string) {
+	key := getRoomIdForBackend(roomId, backend)
+	rooms = slices.Clone(rooms)
+	slices.Sort(rooms)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.rooms[key] = rooms
+}
+
+// Stop removes and returns the breakout rooms of a parent room.
+func (m *BreakoutManager) Stop(roomId string, backend *Backend) []string {
+	key := getRoomIdForBackend(roomId, backend)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	rooms := m.rooms[key]
+	delete(m.rooms, key)
+	return rooms
+}
+
+// Get returns the breakout rooms of a parent room.
+func (m *BreakoutManager) Get(roomId string, backend *Backend) []string {
+	key := getRoomIdForBackend(roomId, backend)
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.rooms[key]
+}
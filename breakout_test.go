@@ -0,0 +1,185 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreakoutManager(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	manager := NewBreakoutManager()
+	backend1 := &Backend{id: "backend1"}
+	backend2 := &Backend{id: "backend2"}
+
+	assert.Empty(manager.Get("room", backend1))
+	manager.Start("room", backend1, []string{"room-b", "room-a"})
+	assert.Equal([]string{"room-a", "room-b"}, manager.Get("room", backend1))
+	assert.Empty(manager.Get("room", backend2))
+	assert.Empty(manager.Get("room-a", backend1))
+
+	manager.Start("room", backend1, []string{"room-c"})
+	assert.Equal([]string{"room-c"}, manager.Get("room", backend1))
+
+	assert.Equal([]string{"room-c"}, manager.Stop("room", backend1))
+	assert.Empty(manager.Get("room", backend1))
+	assert.Empty(manager.Stop("room", backend1))
+}
+
+func TestBackendRoomBreakoutRequest(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	valid := []*BackendRoomBreakoutRequest{
+		{Action: BreakoutActionStart, Rooms: map[string]BackendRoomSwitchToSessionsList{"room-a": {"session"}}},
+		{Action: BreakoutActionStart, Rooms: map[string]BackendRoomSwitchToSessionsList{"room-a": nil, "room-b": {"session"}}},
+		{Action: BreakoutActionStop},
+		{Action: BreakoutActionStop, Rooms: map[string]BackendRoomSwitchToSessionsList{"room-a": nil}},
+	}
+	for _, request := range valid {
+		assert.NoError(request.CheckValid("room"), "expected %+v to be valid", request)
+	}
+
+	invalid := []*BackendRoomBreakoutRequest{
+		{},
+		{Action: "unknown"},
+		{Action: BreakoutActionStart},
+		{Action: BreakoutActionStart, Rooms: map[string]BackendRoomSwitchToSessionsList{"": {"session"}}},
+		{Action: BreakoutActionStart, Rooms: map[string]BackendRoomSwitchToSessionsList{"room": {"session"}}},
+	}
+	for _, request := range invalid {
+		assert.Error(request.CheckValid("room"), "expected %+v to be invalid", request)
+	}
+}
+
+func performBreakoutRequest(t *testing.T, url string, request *BackendRoomBreakoutRequest) (int, string) {
+	t.Helper()
+	data, err := json.Marshal(&BackendServerRoomRequest{
+		Type:     "breakout",
+		Breakout: request,
+	})
+	require.NoError(t, err)
+	res, err := performBackendRequest(url, data)
+	require.NoError(t, err)
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	return res.StatusCode, string(body)
+}
+
+func TestBreakoutRooms(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	hub, _, _, server := CreateHubForTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client1, hello1 := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId+"1")
+	client2, hello2 := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId+"2")
+
+	roomId := "test-room"
+	roomSessionId1 := RoomSessionId("roomsession1")
+	roomMsg := MustSucceed3(t, client1.JoinRoomWithRoomSession, ctx, roomId, roomSessionId1)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+
+	roomSessionId2 := RoomSessionId("roomsession2")
+	roomMsg = MustSucceed3(t, client2.JoinRoomWithRoomSession, ctx, roomId, roomSessionId2)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+
+	WaitForUsersJoined(ctx, t, client1, hello1, client2, hello2)
+
+	// Messages can't be sent before breakout rooms were started.
+	data := StringMap{
+		"message": "Hello breakout rooms",
+	}
+	require.NoError(client1.SendMessage(MessageClientMessageRecipient{
+		Type: RecipientTypeBreakout,
+	}, data))
+	client1.RunUntilError(ctx, NoBreakoutRooms.Code) // nolint
+
+	url := server.URL + "/api/v1/room/" + roomId
+	status, body := performBreakoutRequest(t, url, &BackendRoomBreakoutRequest{
+		Action: "unknown",
+	})
+	assert.Equal(http.StatusBadRequest, status, "Expected error, got %s", body)
+
+	breakoutRoomId1 := "breakout-room-1"
+	breakoutRoomId2 := "breakout-room-2"
+	status, body = performBreakoutRequest(t, url, &BackendRoomBreakoutRequest{
+		Action: BreakoutActionStart,
+		Rooms: map[string]BackendRoomSwitchToSessionsList{
+			breakoutRoomId1: {roomSessionId2},
+			breakoutRoomId2: nil,
+		},
+	})
+	assert.Equal(http.StatusOK, status, "Expected successful request, got %s", body)
+	assert.Equal([]string{breakoutRoomId1, breakoutRoomId2}, hub.breakout.Get(roomId, hub.backend.GetCompatBackend()))
+
+	client2.RunUntilSwitchTo(ctx, breakoutRoomId1, nil)
+	roomMsg = MustSucceed3(t, client2.JoinRoomWithRoomSession, ctx, breakoutRoomId1, roomSessionId2+"-breakout")
+	require.Equal(breakoutRoomId1, roomMsg.Room.RoomId)
+	client2.RunUntilJoined(ctx, hello2.Hello)
+	client1.RunUntilLeft(ctx, hello2.Hello)
+
+	// The moderator of the parent room can send to all breakout rooms.
+	require.NoError(client1.SendMessage(MessageClientMessageRecipient{
+		Type: RecipientTypeBreakout,
+	}, data))
+	var payload StringMap
+	if checkReceiveClientMessage(ctx, t, client2, RecipientTypeBreakout, hello1.Hello, &payload) {
+		assert.Equal(data, payload)
+	}
+
+	// There are no breakout rooms for the breakout room itself.
+	require.NoError(client2.SendMessage(MessageClientMessageRecipient{
+		Type: RecipientTypeBreakout,
+	}, data))
+	client2.RunUntilError(ctx, NoBreakoutRooms.Code) // nolint
+
+	// Only moderators may send to the breakout rooms.
+	session1 := hub.GetSessionByPublicId(hello1.Hello.SessionId).(*ClientSession)
+	require.NotNil(session1)
+	session1.SetPermissions([]Permission{PERMISSION_MAY_PUBLISH_MEDIA})
+	require.NoError(client1.SendMessage(MessageClientMessageRecipient{
+		Type: RecipientTypeBreakout,
+	}, data))
+	client1.RunUntilError(ctx, "not_allowed") // nolint
+
+	// Stopping the breakout rooms moves everybody back to the parent room.
+	status, body = performBreakoutRequest(t, url, &BackendRoomBreakoutRequest{
+		Action: BreakoutActionStop,
+	})
+	assert.Equal(http.StatusOK, status, "Expected successful request, got %s", body)
+	client2.RunUntilSwitchTo(ctx, roomId, nil)
+	assert.Empty(hub.breakout.Get(roomId, hub.backend.GetCompatBackend()))
+}
@@ -26,6 +26,8 @@ import (
 	"encoding/json"
 	"io"
 	"sync"
+
+	"github.com/mailru/easyjson"
 )
 
 type BufferPool struct {
@@ -77,3 +79,20 @@ func (p *BufferPool) MarshalAsJSON(v any) (*bytes.Buffer, error) {
 
 	return b, nil
 }
+
+// MarshalMessage serializes a message into a buffer from the pool. The buffer
+// must be returned to the pool after it has been used.
+func (p *BufferPool) MarshalMessage(message any) (*bytes.Buffer, error) {
+	m, ok := message.(easyjson.Marshaler)
+	if !ok {
+		return p.MarshalAsJSON(message)
+	}
+
+	b := p.Get()
+	if _, err := easyjson.MarshalToWriter(m, b); err != nil {
+		p.Put(b)
+		return nil, err
+	}
+
+	return b, nil
+}
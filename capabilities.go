@@ -25,7 +25,6 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"log"
 	"net/http"
 	"net/url"
 	"strings"
@@ -45,6 +44,15 @@ const (
 	// Name of capability that is set if the server supports Federation V2.
 	FeatureFederationV2 = "federation-v2"
 
+	// Name of capability that is set if the server wants to be notified about
+	// status changes of dialout calls.
+	FeatureDialoutStatus = "dialout-status"
+
+	// Name of capability that is set if the server wants to be notified when
+	// virtual sessions are degraded because their internal client stopped
+	// sending heartbeats.
+	FeatureSessionDegraded = "session-degraded"
+
 	// minCapabilitiesCacheDuration specifies the minimum duration to cache
 	// capabilities.
 	// This could overwrite the "max-age" from a "Cache-Control" header.
@@ -125,18 +133,18 @@ func (e *capabilitiesEntry) update(ctx context.Context, u *url.URL, now time.Tim
 		capUrl.Path = capUrl.Path[:pos+11] + "/cloud/capabilities"
 	}
 
-	log.Printf("Capabilities expired for %s, updating", capUrl.String())
+	backendLog.Infof("Capabilities expired for %s, updating", capUrl.String())
 
 	client, pool, err := e.c.pool.Get(ctx, &capUrl)
 	if err != nil {
-		log.Printf("Could not get client for host %s: %s", capUrl.Host, err)
+		backendLog.Errorf("Could not get client for host %s: %s", capUrl.Host, err)
 		return false, err
 	}
 	defer pool.Put(client)
 
 	req, err := http.NewRequestWithContext(ctx, "GET", capUrl.String(), nil)
 	if err != nil {
-		log.Printf("Could not create request to %s: %s", &capUrl, err)
+		backendLog.Errorf("Could not create request to %s: %s", &capUrl, err)
 		return false, err
 	}
 	req.Header.Set("Accept", "application/json")
@@ -168,22 +176,22 @@ func (e *capabilitiesEntry) update(ctx context.Context, u *url.URL, now time.Tim
 	e.nextUpdate = now.Add(maxAge)
 
 	if response.StatusCode == http.StatusNotModified {
-		log.Printf("Capabilities %+v from %s have not changed", e.capabilities, url)
+		backendLog.Infof("Capabilities %+v from %s have not changed", e.capabilities, url)
 		return false, nil
 	} else if response.StatusCode != http.StatusOK {
-		log.Printf("Received unexpected HTTP status from %s: %s", url, response.Status)
+		backendLog.Infof("Received unexpected HTTP status from %s: %s", url, response.Status)
 		return e.errorIfMustRevalidate(ErrUnexpectedHttpStatus)
 	}
 
 	ct := response.Header.Get("Content-Type")
 	if !strings.HasPrefix(ct, "application/json") {
-		log.Printf("Received unsupported content-type from %s: %s (%s)", url, ct, response.Status)
+		backendLog.Warnf("Received unsupported content-type from %s: %s (%s)", url, ct, response.Status)
 		return e.errorIfMustRevalidate(ErrUnsupportedContentType)
 	}
 
 	body, err := e.c.buffers.ReadAll(response.Body)
 	if err != nil {
-		log.Printf("Could not read response body from %s: %s", url, err)
+		backendLog.Errorf("Could not read response body from %s: %s", url, err)
 		return e.errorIfMustRevalidate(err)
 	}
 
@@ -191,34 +199,34 @@ func (e *capabilitiesEntry) update(ctx context.Context, u *url.URL, now time.Tim
 
 	var ocs OcsResponse
 	if err := json.Unmarshal(body.Bytes(), &ocs); err != nil {
-		log.Printf("Could not decode OCS response %s from %s: %s", body.String(), url, err)
+		backendLog.Errorf("Could not decode OCS response %s from %s: %s", body.String(), url, err)
 		return e.errorIfMustRevalidate(err)
 	} else if ocs.Ocs == nil || len(ocs.Ocs.Data) == 0 {
-		log.Printf("Incomplete OCS response %s from %s", body.String(), url)
+		backendLog.Infof("Incomplete OCS response %s from %s", body.String(), url)
 		return e.errorIfMustRevalidate(ErrIncompleteResponse)
 	}
 
 	var capaResponse CapabilitiesResponse
 	if err := json.Unmarshal(ocs.Ocs.Data, &capaResponse); err != nil {
-		log.Printf("Could not decode OCS response body %s from %s: %s", string(ocs.Ocs.Data), url, err)
+		backendLog.Errorf("Could not decode OCS response body %s from %s: %s", string(ocs.Ocs.Data), url, err)
 		return e.errorIfMustRevalidate(err)
 	}
 
 	capaObj, found := capaResponse.Capabilities[AppNameSpreed]
 	if !found || len(capaObj) == 0 {
-		log.Printf("No capabilities received for app spreed from %s: %+v", url, capaResponse)
+		backendLog.Infof("No capabilities received for app spreed from %s: %+v", url, capaResponse)
 		e.capabilities = nil
 		return false, nil
 	}
 
 	var capa StringMap
 	if err := json.Unmarshal(capaObj, &capa); err != nil {
-		log.Printf("Unsupported capabilities received for app spreed from %s: %+v", url, capaResponse)
+		backendLog.Warnf("Unsupported capabilities received for app spreed from %s: %+v", url, capaResponse)
 		e.capabilities = nil
 		return false, nil
 	}
 
-	log.Printf("Received capabilities %+v from %s", capa, url)
+	backendLog.Infof("Received capabilities %+v from %s", capa, url)
 	e.capabilities = capa
 	return true, nil
 }
@@ -236,10 +244,11 @@ type Capabilities struct {
 	// Can be overwritten by tests.
 	getNow func() time.Time
 
-	version        string
-	pool           *HttpClientPool
-	entries        map[string]*capabilitiesEntry
-	nextInvalidate map[string]time.Time
+	version string
+	pool    *HttpClientPool
+	entries map[string]*capabilitiesEntry
+	// Keys that have been invalidated recently.
+	nextInvalidate *LruCache[string, struct{}]
 
 	buffers BufferPool
 }
@@ -251,7 +260,11 @@ func NewCapabilities(version string, pool *HttpClientPool) (*Capabilities, error
 		version:        version,
 		pool:           pool,
 		entries:        make(map[string]*capabilitiesEntry),
-		nextInvalidate: make(map[string]time.Time),
+		nextInvalidate: NewLruCache[string, struct{}](0),
+	}
+	// Only used while holding the lock, so tests can change "getNow".
+	result.nextInvalidate.now = func() time.Time {
+		return result.getNow()
 	}
 
 	return result, nil
@@ -292,8 +305,7 @@ func (c *Capabilities) invalidateCapabilities(key string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	now := c.getNow()
-	if entry, found := c.nextInvalidate[key]; found && entry.After(now) {
+	if _, found := c.nextInvalidate.Get(key); found {
 		return
 	}
 
@@ -301,7 +313,7 @@ func (c *Capabilities) invalidateCapabilities(key string) {
 		entry.invalidate()
 	}
 
-	c.nextInvalidate[key] = now.Add(maxInvalidateInterval)
+	c.nextInvalidate.SetTTL(key, struct{}{}, maxInvalidateInterval)
 }
 
 func (c *Capabilities) newCapabilitiesEntry(key string) *capabilitiesEntry {
@@ -340,7 +352,7 @@ func (c *Capabilities) loadCapabilities(ctx context.Context, u *url.URL) (String
 func (c *Capabilities) HasCapabilityFeature(ctx context.Context, u *url.URL, feature string) bool {
 	caps, _, err := c.loadCapabilities(ctx, u)
 	if err != nil {
-		log.Printf("Could not get capabilities for %s: %s", u, err)
+		backendLog.Errorf("Could not get capabilities for %s: %s", u, err)
 		return false
 	}
 
@@ -351,7 +363,7 @@ func (c *Capabilities) HasCapabilityFeature(ctx context.Context, u *url.URL, fea
 
 	features, ok := featuresInterface.([]any)
 	if !ok {
-		log.Printf("Invalid features list received for %s: %+v", u, featuresInterface)
+		backendLog.Warnf("Invalid features list received for %s: %+v", u, featuresInterface)
 		return false
 	}
 
@@ -366,7 +378,7 @@ func (c *Capabilities) HasCapabilityFeature(ctx context.Context, u *url.URL, fea
 func (c *Capabilities) getConfigGroup(ctx context.Context, u *url.URL, group string) (StringMap, bool, bool) {
 	caps, cached, err := c.loadCapabilities(ctx, u)
 	if err != nil {
-		log.Printf("Could not get capabilities for %s: %s", u, err)
+		backendLog.Errorf("Could not get capabilities for %s: %s", u, err)
 		return nil, cached, false
 	}
 
@@ -377,7 +389,7 @@ func (c *Capabilities) getConfigGroup(ctx context.Context, u *url.URL, group str
 
 	config, ok := ConvertStringMap(configInterface)
 	if !ok {
-		log.Printf("Invalid config mapping received from %s: %+v", u, configInterface)
+		backendLog.Warnf("Invalid config mapping received from %s: %+v", u, configInterface)
 		return nil, cached, false
 	}
 
@@ -388,7 +400,7 @@ func (c *Capabilities) getConfigGroup(ctx context.Context, u *url.URL, group str
 
 	groupConfig, ok := ConvertStringMap(groupInterface)
 	if !ok {
-		log.Printf("Invalid group mapping \"%s\" received from %s: %+v", group, u, groupInterface)
+		backendLog.Warnf("Invalid group mapping \"%s\" received from %s: %+v", group, u, groupInterface)
 		return nil, cached, false
 	}
 
@@ -414,7 +426,7 @@ func (c *Capabilities) GetIntegerConfig(ctx context.Context, u *url.URL, group,
 	case float64:
 		return int(value), cached, true
 	default:
-		log.Printf("Invalid config value for \"%s\" received from %s: %+v", key, u, value)
+		backendLog.Warnf("Invalid config value for \"%s\" received from %s: %+v", key, u, value)
 	}
 
 	return 0, cached, false
@@ -435,7 +447,7 @@ func (c *Capabilities) GetStringConfig(ctx context.Context, u *url.URL, group, k
 	case string:
 		return value, cached, true
 	default:
-		log.Printf("Invalid config value for \"%s\" received from %s: %+v", key, u, value)
+		backendLog.Warnf("Invalid config value for \"%s\" received from %s: %+v", key, u, value)
 	}
 
 	return "", cached, false
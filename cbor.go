@@ -0,0 +1,107 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+const (
+	// Websocket subprotocol to exchange messages encoded as CBOR instead of
+	// JSON, see RFC 8949.
+	WebsocketProtocolCbor = "nextcloud-spreed-signaling.cbor"
+)
+
+var (
+	cborEncMode = mustCreateCborEncMode()
+	cborDecMode = mustCreateCborDecMode()
+)
+
+func mustCreateCborEncMode() cbor.EncMode {
+	mode, err := cbor.EncOptions{
+		ShortestFloat: cbor.ShortestFloat16,
+	}.EncMode()
+	if err != nil {
+		panic(err)
+	}
+	return mode
+}
+
+func mustCreateCborDecMode() cbor.DecMode {
+	mode, err := cbor.DecOptions{
+		// Messages are converted to JSON which only supports string keys.
+		DefaultMapType: reflect.TypeOf(map[string]any(nil)),
+	}.DecMode()
+	if err != nil {
+		panic(err)
+	}
+	return mode
+}
+
+// convertJsonNumbers replaces numbers decoded from JSON with integers (if
+// possible) or floats, so they are encoded with the smallest CBOR type.
+func convertJsonNumbers(value any) any {
+	switch value := value.(type) {
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			return i
+		}
+		f, _ := value.Float64()
+		return f
+	case map[string]any:
+		for k, v := range value {
+			value[k] = convertJsonNumbers(v)
+		}
+	case []any:
+		for i, v := range value {
+			value[i] = convertJsonNumbers(v)
+		}
+	}
+	return value
+}
+
+// jsonToCbor converts a JSON encoded message to CBOR.
+func jsonToCbor(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("could not decode JSON: %w", err)
+	}
+
+	return cborEncMode.Marshal(convertJsonNumbers(value))
+}
+
+// cborToJson converts a CBOR encoded message to JSON.
+func cborToJson(data []byte) ([]byte, error) {
+	var value any
+	if err := cborDecMode.Unmarshal(data, &value); err != nil {
+		return nil, fmt.Errorf("could not decode CBOR: %w", err)
+	}
+
+	return json.Marshal(value)
+}
@@ -0,0 +1,173 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCborTranscoding(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	assert := assert.New(t)
+
+	message := []byte(`{"id":"123","type":"message","message":{"data":{"int":1,"large":12345678901,"float":1.5,"list":[true,false,null,"foo"]}}}`)
+	data, err := jsonToCbor(message)
+	require.NoError(err)
+	assert.Less(len(data), len(message))
+
+	var decoded map[string]any
+	require.NoError(cbor.Unmarshal(data, &decoded))
+	assert.Equal("123", decoded["id"])
+
+	result, err := cborToJson(data)
+	require.NoError(err)
+	assert.JSONEq(string(message), string(result))
+
+	_, err = jsonToCbor([]byte("invalid"))
+	assert.Error(err)
+	_, err = cborToJson([]byte{0xff})
+	assert.Error(err)
+
+	// Only string keys can be converted to JSON.
+	data, err = cbor.Marshal(map[int]string{1: "foo"})
+	require.NoError(err)
+	_, err = cborToJson(data)
+	assert.Error(err)
+}
+
+func readCborMessage(t *testing.T, conn *websocket.Conn) *ServerMessage {
+	t.Helper()
+	require := require.New(t)
+
+	messageType, data, err := conn.ReadMessage()
+	require.NoError(err)
+	require.Equal(websocket.BinaryMessage, messageType)
+
+	data, err = cborToJson(data)
+	require.NoError(err)
+	var message ServerMessage
+	require.NoError(json.Unmarshal(data, &message))
+	return &message
+}
+
+func TestClientCbor(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	_, _, _, server := CreateHubForTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	dialer := websocket.Dialer{
+		Subprotocols: []string{WebsocketProtocolCbor},
+	}
+	conn, _, err := dialer.DialContext(ctx, getWebsocketUrl(server.URL), nil)
+	require.NoError(err)
+	defer conn.Close()
+	assert.Equal(WebsocketProtocolCbor, conn.Subprotocol())
+
+	if welcome := readCborMessage(t, conn); assert.Equal("welcome", welcome.Type) && assert.NotNil(welcome.Welcome) {
+		assert.Contains(welcome.Welcome.Features, ServerFeatureCbor)
+	}
+
+	hello, err := cbor.Marshal(map[string]any{
+		"id":   "1234",
+		"type": "hello",
+		"hello": map[string]any{
+			"version": HelloVersionV1,
+			"auth": map[string]any{
+				"url": server.URL,
+				"params": map[string]any{
+					"userid": testDefaultUserId,
+				},
+			},
+		},
+	})
+	require.NoError(err)
+	require.NoError(conn.WriteMessage(websocket.BinaryMessage, hello))
+
+	if message := readCborMessage(t, conn); assert.Equal("hello", message.Type, "%+v", message) && assert.NotNil(message.Hello) {
+		assert.Equal("1234", message.Id)
+		assert.Equal(testDefaultUserId, message.Hello.UserId)
+		assert.NotEmpty(message.Hello.SessionId)
+	}
+
+	// Invalid messages are rejected.
+	require.NoError(conn.WriteMessage(websocket.BinaryMessage, []byte{0xff}))
+	if message := readCborMessage(t, conn); assert.Equal("error", message.Type) && assert.NotNil(message.Error) {
+		assert.Equal(InvalidFormat.Code, message.Error.Code)
+	}
+
+	bye, err := cbor.Marshal(map[string]any{
+		"id":   "9876",
+		"type": "bye",
+		"bye":  map[string]any{},
+	})
+	require.NoError(err)
+	require.NoError(conn.WriteMessage(websocket.BinaryMessage, bye))
+	if message := readCborMessage(t, conn); assert.Equal("bye", message.Type) {
+		assert.Equal("9876", message.Id)
+	}
+}
+
+func TestClientWithoutCbor(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	_, _, _, server := CreateHubForTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	conn, _, err := testClientDialer.DialContext(ctx, getWebsocketUrl(server.URL), nil)
+	require.NoError(err)
+	defer conn.Close()
+	assert.Empty(conn.Subprotocol())
+
+	messageType, data, err := conn.ReadMessage()
+	require.NoError(err)
+	assert.Equal(websocket.TextMessage, messageType)
+	var message ServerMessage
+	require.NoError(json.Unmarshal(data, &message))
+	assert.Equal("welcome", message.Type)
+
+	// Binary messages are not supported without CBOR.
+	require.NoError(conn.WriteMessage(websocket.BinaryMessage, []byte{0xa0}))
+	messageType, data, err = conn.ReadMessage()
+	require.NoError(err)
+	assert.Equal(websocket.TextMessage, messageType)
+	require.NoError(json.Unmarshal(data, &message))
+	if assert.Equal("error", message.Type) && assert.NotNil(message.Error) {
+		assert.Equal(InvalidFormat.Code, message.Error.Code)
+	}
+}
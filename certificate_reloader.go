@@ -22,83 +22,12 @@
 package signaling
 
 import (
-	"crypto/tls"
 	"crypto/x509"
 	"fmt"
-	"log"
 	"os"
 	"sync/atomic"
 )
 
-type CertificateReloader struct {
-	certFile    string
-	certWatcher *FileWatcher
-
-	keyFile    string
-	keyWatcher *FileWatcher
-
-	certificate atomic.Pointer[tls.Certificate]
-
-	reloadCounter atomic.Uint64
-}
-
-func NewCertificateReloader(certFile string, keyFile string) (*CertificateReloader, error) {
-	pair, err := tls.LoadX509KeyPair(certFile, keyFile)
-	if err != nil {
-		return nil, fmt.Errorf("could not load certificate / key: %w", err)
-	}
-
-	reloader := &CertificateReloader{
-		certFile: certFile,
-		keyFile:  keyFile,
-	}
-	reloader.certificate.Store(&pair)
-	reloader.certWatcher, err = NewFileWatcher(certFile, reloader.reload)
-	if err != nil {
-		return nil, err
-	}
-	reloader.keyWatcher, err = NewFileWatcher(keyFile, reloader.reload)
-	if err != nil {
-		reloader.certWatcher.Close() // nolint
-		return nil, err
-	}
-
-	return reloader, nil
-}
-
-func (r *CertificateReloader) Close() {
-	r.keyWatcher.Close()
-	r.certWatcher.Close()
-}
-
-func (r *CertificateReloader) reload(filename string) {
-	log.Printf("reloading certificate from %s with %s", r.certFile, r.keyFile)
-	pair, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
-	if err != nil {
-		log.Printf("could not load certificate / key: %s", err)
-		return
-	}
-
-	r.certificate.Store(&pair)
-	r.reloadCounter.Add(1)
-}
-
-func (r *CertificateReloader) getCertificate() (*tls.Certificate, error) {
-	return r.certificate.Load(), nil
-}
-
-func (r *CertificateReloader) GetCertificate(h *tls.ClientHelloInfo) (*tls.Certificate, error) {
-	return r.getCertificate()
-}
-
-func (r *CertificateReloader) GetClientCertificate(i *tls.CertificateRequestInfo) (*tls.Certificate, error) {
-	return r.getCertificate()
-}
-
-func (r *CertificateReloader) GetReloadCounter() uint64 {
-	return r.reloadCounter.Load()
-}
-
 type CertPoolReloader struct {
 	certFile    string
 	certWatcher *FileWatcher
@@ -145,10 +74,10 @@ func (r *CertPoolReloader) Close() {
 }
 
 func (r *CertPoolReloader) reload(filename string) {
-	log.Printf("reloading certificate pool from %s", r.certFile)
+	appLog.Infof("reloading certificate pool from %s", r.certFile)
 	pool, err := loadCertPool(r.certFile)
 	if err != nil {
-		log.Printf("could not load certificate pool: %s", err)
+		appLog.Errorf("could not load certificate pool: %s", err)
 		return
 	}
 
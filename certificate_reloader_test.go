@@ -39,16 +39,6 @@ func UpdateCertificateCheckIntervalForTest(t *testing.T, interval time.Duration)
 	deduplicateWatchEvents.Store(int64(interval))
 }
 
-func (r *CertificateReloader) WaitForReload(ctx context.Context, counter uint64) error {
-	for counter == r.GetReloadCounter() {
-		if err := ctx.Err(); err != nil {
-			return err
-		}
-		time.Sleep(time.Millisecond)
-	}
-	return nil
-}
-
 func (r *CertPoolReloader) WaitForReload(ctx context.Context, counter uint64) error {
 	for counter == r.GetReloadCounter() {
 		if err := ctx.Err(); err != nil {
@@ -0,0 +1,205 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2017 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"crypto/tls"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// Time to wait for the other file of a certificate / key pair to be updated
+	// before an inconsistent pair is reported as error.
+	certificateMismatchTimeout = 5 * time.Second
+)
+
+// CertificateWatcher provides a certificate / key pair that is reloaded if one
+// of the files changes. An updated pair is only used once the certificate
+// matches the private key, so the previous pair is still served while a
+// renewal has only replaced one of the files.
+type CertificateWatcher struct {
+	certificate atomic.Pointer[tls.Certificate]
+
+	reloadCounter atomic.Uint64
+
+	mu          sync.Mutex
+	certFile    string
+	certWatcher *FileWatcher
+	keyFile     string
+	keyWatcher  *FileWatcher
+	// Only set while the files on disk don't contain a consistent pair.
+	mismatchTimer *time.Timer
+}
+
+func NewCertificateWatcher(certFile string, keyFile string) (*CertificateWatcher, error) {
+	// This also checks that the private key matches the certificate.
+	pair, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not load certificate / key: %w", err)
+	}
+
+	watcher := &CertificateWatcher{
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+	watcher.certificate.Store(&pair)
+	watcher.certWatcher, err = NewFileWatcher(certFile, watcher.reload)
+	if err != nil {
+		return nil, err
+	}
+	watcher.keyWatcher, err = NewFileWatcher(keyFile, watcher.reload)
+	if err != nil {
+		watcher.certWatcher.Close() // nolint
+		return nil, err
+	}
+
+	return watcher, nil
+}
+
+func (w *CertificateWatcher) Close() {
+	w.mu.Lock()
+	keyWatcher := w.keyWatcher
+	certWatcher := w.certWatcher
+	w.mu.Unlock()
+
+	keyWatcher.Close()
+	certWatcher.Close()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.mismatchTimer != nil {
+		w.mismatchTimer.Stop()
+		w.mismatchTimer = nil
+	}
+}
+
+// Reload loads the certificate / key pair again, the files may be different
+// from the ones currently used. The previous pair is still served if the new
+// one could not be loaded.
+func (w *CertificateWatcher) Reload(certFile string, keyFile string) error {
+	pair, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return fmt.Errorf("could not load certificate / key: %w", err)
+	}
+
+	w.mu.Lock()
+	var oldWatchers []*FileWatcher
+	if certFile != w.certFile || keyFile != w.keyFile {
+		certWatcher, err := NewFileWatcher(certFile, w.reload)
+		if err != nil {
+			w.mu.Unlock()
+			return err
+		}
+		keyWatcher, err := NewFileWatcher(keyFile, w.reload)
+		if err != nil {
+			w.mu.Unlock()
+			certWatcher.Close() // nolint
+			return err
+		}
+
+		oldWatchers = append(oldWatchers, w.certWatcher, w.keyWatcher)
+		w.certFile = certFile
+		w.certWatcher = certWatcher
+		w.keyFile = keyFile
+		w.keyWatcher = keyWatcher
+	}
+
+	if w.mismatchTimer != nil {
+		w.mismatchTimer.Stop()
+		w.mismatchTimer = nil
+	}
+
+	w.storeCertificate(&pair)
+	w.mu.Unlock()
+
+	// Closing must happen without the lock as pending callbacks need it.
+	for _, watcher := range oldWatchers {
+		watcher.Close() // nolint
+	}
+	return nil
+}
+
+func (w *CertificateWatcher) reload(filename string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	pair, err := tls.LoadX509KeyPair(w.certFile, w.keyFile)
+	if err != nil {
+		// The other file of the pair might not have been updated yet.
+		if w.mismatchTimer == nil {
+			appLog.Infof("Certificate %s and key %s are not consistent, waiting for further updates: %s", w.certFile, w.keyFile, err)
+			w.mismatchTimer = time.AfterFunc(certificateMismatchTimeout, w.checkMismatch)
+		}
+		return
+	}
+
+	if w.mismatchTimer != nil {
+		w.mismatchTimer.Stop()
+		w.mismatchTimer = nil
+	}
+
+	w.storeCertificate(&pair)
+}
+
+func (w *CertificateWatcher) storeCertificate(pair *tls.Certificate) {
+	appLog.Infof("Reloaded certificate from %s with %s", w.certFile, w.keyFile)
+	w.certificate.Store(pair)
+	w.reloadCounter.Add(1)
+}
+
+func (w *CertificateWatcher) checkMismatch() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.mismatchTimer == nil {
+		// A consistent pair has been loaded in the meantime.
+		return
+	}
+
+	w.mismatchTimer = nil
+	pair, err := tls.LoadX509KeyPair(w.certFile, w.keyFile)
+	if err != nil {
+		appLog.Errorf("Could not load certificate / key, continue using previous certificate: %s", err)
+		return
+	}
+
+	w.storeCertificate(&pair)
+}
+
+func (w *CertificateWatcher) getCertificate() (*tls.Certificate, error) {
+	return w.certificate.Load(), nil
+}
+
+func (w *CertificateWatcher) GetCertificate(h *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return w.getCertificate()
+}
+
+func (w *CertificateWatcher) GetClientCertificate(i *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return w.getCertificate()
+}
+
+func (w *CertificateWatcher) GetReloadCounter() uint64 {
+	return w.reloadCounter.Load()
+}
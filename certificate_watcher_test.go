@@ -0,0 +1,179 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2017 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (w *CertificateWatcher) WaitForReload(ctx context.Context, counter uint64) error {
+	for counter == w.GetReloadCounter() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return nil
+}
+
+func (w *CertificateWatcher) hasMismatch() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.mismatchTimer != nil
+}
+
+func checkCertificateOrganization(t *testing.T, w *CertificateWatcher, organization string) {
+	t.Helper()
+	assert := assert.New(t)
+	if cert, err := w.GetCertificate(nil); assert.NoError(err) && assert.NotNil(cert.Leaf) {
+		if assert.NotEmpty(cert.Leaf.Subject.Organization) {
+			assert.Equal(organization, cert.Leaf.Subject.Organization[0])
+		}
+	}
+}
+
+func TestCertificateWatcher_Invalid(t *testing.T) {
+	CatchLogForTest(t)
+	require := require.New(t)
+	key1, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(err)
+	key2, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(err)
+
+	dir := t.TempDir()
+	certFile := path.Join(dir, "cert.pem")
+	keyFile := path.Join(dir, "privkey.pem")
+	require.NoError(os.WriteFile(certFile, GenerateSelfSignedCertificateForTesting(t, 1024, "Testing certificate", key1), 0644))
+	require.NoError(WritePrivateKey(key2, keyFile))
+
+	if w, err := NewCertificateWatcher(certFile, keyFile); !assert.Error(t, err) {
+		w.Close()
+	}
+}
+
+func TestCertificateWatcher_Reload(t *testing.T) {
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	key1, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(err)
+	key2, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(err)
+
+	dir := t.TempDir()
+	certFile := path.Join(dir, "cert.pem")
+	keyFile := path.Join(dir, "privkey.pem")
+	org1 := "Testing certificate"
+	require.NoError(os.WriteFile(certFile, GenerateSelfSignedCertificateForTesting(t, 1024, org1, key1), 0644))
+	require.NoError(WritePrivateKey(key1, keyFile))
+
+	UpdateCertificateCheckIntervalForTest(t, 0)
+	w, err := NewCertificateWatcher(certFile, keyFile)
+	require.NoError(err)
+	defer w.Close()
+
+	checkCertificateOrganization(t, w, org1)
+
+	// The certificate is updated before the key, the previous pair will be used
+	// until both files match.
+	org2 := "Updated certificate"
+	replaceFile(t, certFile, GenerateSelfSignedCertificateForTesting(t, 1024, org2, key2), 0644)
+	assert.Eventually(w.hasMismatch, time.Second, time.Millisecond)
+	assert.EqualValues(0, w.GetReloadCounter())
+	checkCertificateOrganization(t, w, org1)
+
+	// Still inconsistent after the timeout.
+	w.checkMismatch()
+	assert.EqualValues(0, w.GetReloadCounter())
+	checkCertificateOrganization(t, w, org1)
+
+	newKeyFile := path.Join(dir, "newkey.pem")
+	require.NoError(WritePrivateKey(key2, newKeyFile))
+	data, err := os.ReadFile(newKeyFile)
+	require.NoError(err)
+	replaceFile(t, keyFile, data, 0600)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	require.NoError(w.WaitForReload(ctx, 0))
+	assert.False(w.hasMismatch())
+	checkCertificateOrganization(t, w, org2)
+}
+
+func TestCertificateWatcher_ReloadFiles(t *testing.T) {
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	key1, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(err)
+	key2, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(err)
+
+	dir := t.TempDir()
+	certFile1 := path.Join(dir, "cert1.pem")
+	keyFile1 := path.Join(dir, "privkey1.pem")
+	org1 := "Testing certificate"
+	require.NoError(os.WriteFile(certFile1, GenerateSelfSignedCertificateForTesting(t, 1024, org1, key1), 0644))
+	require.NoError(WritePrivateKey(key1, keyFile1))
+
+	UpdateCertificateCheckIntervalForTest(t, 0)
+	w, err := NewCertificateWatcher(certFile1, keyFile1)
+	require.NoError(err)
+	defer w.Close()
+
+	checkCertificateOrganization(t, w, org1)
+
+	certFile2 := path.Join(dir, "cert2.pem")
+	keyFile2 := path.Join(dir, "privkey2.pem")
+	org2 := "Updated certificate"
+	require.NoError(os.WriteFile(certFile2, GenerateSelfSignedCertificateForTesting(t, 1024, org2, key2), 0644))
+
+	// The previous pair is used if the files don't match.
+	assert.Error(w.Reload(certFile2, keyFile1))
+	assert.Error(w.Reload(certFile2, keyFile2))
+	checkCertificateOrganization(t, w, org1)
+	assert.EqualValues(0, w.GetReloadCounter())
+
+	require.NoError(WritePrivateKey(key2, keyFile2))
+	require.NoError(w.Reload(certFile2, keyFile2))
+	checkCertificateOrganization(t, w, org2)
+	assert.EqualValues(1, w.GetReloadCounter())
+
+	// Changes of the new files are detected.
+	org3 := "Renewed certificate"
+	counter := w.GetReloadCounter()
+	replaceFile(t, certFile2, GenerateSelfSignedCertificateForTesting(t, 1024, org3, key2), 0644)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(w.WaitForReload(ctx, counter))
+	checkCertificateOrganization(t, w, org3)
+}
@@ -26,8 +26,9 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"log"
+	"io"
 	"net"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -36,6 +37,7 @@ import (
 
 	"github.com/gorilla/websocket"
 	"github.com/mailru/easyjson"
+	"github.com/mailru/easyjson/jwriter"
 )
 
 const (
@@ -48,6 +50,9 @@ const (
 	// Send pings to peer with this period. Must be less than pongWait.
 	pingPeriod = (pongWait * 9) / 10
 
+	// Maximum number of messages to combine into a single frame.
+	maxBatchMessages = 64
+
 	// Maximum message size allowed from peer.
 	maxMessageSize = 64 * 1024
 )
@@ -60,6 +65,86 @@ var (
 	unknownCountry = "unknown-country"
 )
 
+// updateHelloStats counts the version and features announced in the "hello"
+// of a new session. Unknown features are counted as "other" to keep the
+// number of labels bounded.
+func updateHelloStats(hello *HelloClientMessage, clientType ClientType) {
+	statsClientHelloVersionsTotal.WithLabelValues(hello.Version, string(clientType)).Inc()
+	seen := make(map[string]bool, len(hello.Features))
+	for _, feature := range hello.Features {
+		if !slices.Contains(knownClientFeatures, feature) {
+			feature = "other"
+		}
+		if seen[feature] {
+			continue
+		}
+
+		seen[feature] = true
+		statsClientHelloFeaturesTotal.WithLabelValues(feature, string(clientType)).Inc()
+	}
+}
+
+// getWebsocketErrorReason returns a short reason for a websocket read or
+// write error that can be used as label in metrics.
+func getWebsocketErrorReason(err error) string {
+	var ne net.Error
+	switch {
+	case errors.Is(err, websocket.ErrCloseSent):
+		return "close_sent"
+	case errors.Is(err, websocket.ErrReadLimit):
+		return "message_too_large"
+	case errors.As(err, &ne) && ne.Timeout():
+		return "timeout"
+	case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+		return "eof"
+	// Gorilla websocket hides the original net.Error, so also compare error messages
+	case errors.Is(err, net.ErrClosed) || strings.Contains(err.Error(), net.ErrClosed.Error()):
+		return "closed"
+	case strings.Contains(err.Error(), "connection reset by peer"):
+		return "reset"
+	case strings.Contains(err.Error(), "broken pipe"):
+		return "broken_pipe"
+	}
+
+	return "other"
+}
+
+func updateWebsocketReadStats(err error) {
+	if ce, ok := err.(*websocket.CloseError); ok {
+		statsClientWebsocketCloseCodes.WithLabelValues(strconv.Itoa(ce.Code)).Inc()
+	} else {
+		reason := getWebsocketErrorReason(err)
+		statsClientWebsocketReadErrors.WithLabelValues(reason).Inc()
+		if reason == "timeout" {
+			statsClientKeepaliveMissesTotal.Inc()
+		}
+	}
+}
+
+// getWebsocketHandshakeFailureReason returns a short reason for a failed
+// websocket handshake that can be used as label in metrics.
+func getWebsocketHandshakeFailureReason(err error) string {
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "origin not allowed"):
+		return "origin"
+	case strings.Contains(msg, "method is not GET"):
+		return "method"
+	case strings.Contains(msg, "unsupported version"):
+		return "version"
+	case strings.Contains(msg, "not using the websocket protocol") || strings.Contains(msg, "not a websocket handshake"):
+		return "bad_request"
+	case strings.Contains(msg, "Hijacker") || strings.Contains(msg, "hijack"):
+		return "hijack"
+	}
+
+	var he websocket.HandshakeError
+	if errors.As(err, &he) {
+		return "other"
+	}
+	return "io"
+}
+
 func init() {
 	RegisterClientStats()
 }
@@ -91,6 +176,28 @@ type WritableClientMessage interface {
 	CloseAfterSend(session Session) bool
 }
 
+// clientMessageBatch is sent as JSON array of the messages.
+type clientMessageBatch []WritableClientMessage
+
+func (b clientMessageBatch) MarshalJSON() ([]byte, error) {
+	return easyjson.Marshal(b)
+}
+
+func (b clientMessageBatch) MarshalEasyJSON(w *jwriter.Writer) {
+	w.RawByte('[')
+	for idx, message := range b {
+		if idx > 0 {
+			w.RawByte(',')
+		}
+		if m, ok := message.(easyjson.Marshaler); ok {
+			m.MarshalEasyJSON(w)
+		} else {
+			w.Raw(message.MarshalJSON())
+		}
+	}
+	w.RawByte(']')
+}
+
 type HandlerClient interface {
 	Context() context.Context
 	RemoteAddr() string
@@ -128,6 +235,15 @@ type Client struct {
 	closed  atomic.Int32
 	country *string
 	logRTT  bool
+	// Messages are encoded as CBOR instead of JSON.
+	cbor bool
+	// Outgoing messages are compressed if they have at least this size.
+	compress             bool
+	compressionThreshold int
+	// Queued messages are combined into a single frame.
+	batching atomic.Bool
+	batchMu  sync.Mutex
+	batch    []WritableClientMessage
 
 	handlerMu sync.RWMutex
 	handler   ClientHandler
@@ -136,9 +252,16 @@ type Client struct {
 	sessionId atomic.Pointer[PublicSessionId]
 
 	mu sync.Mutex
+	// Number of messages waiting to be written to the connection.
+	pendingWrites atomic.Int32
+
+	pingInterval     atomic.Int64
+	pongTimeout      atomic.Int64
+	keepaliveChanged chan struct{}
 
 	closer       *Closer
 	closeOnce    sync.Once
+	closeReason  atomic.Pointer[string]
 	messagesDone chan struct{}
 	messageChan  chan *bytes.Buffer
 }
@@ -164,11 +287,51 @@ func NewClient(ctx context.Context, conn *websocket.Conn, remoteAddress string,
 func (c *Client) SetConn(ctx context.Context, conn *websocket.Conn, remoteAddress string, handler ClientHandler) {
 	c.ctx = ctx
 	c.conn = conn
+	c.cbor = conn != nil && conn.Subprotocol() == WebsocketProtocolCbor
 	c.addr = remoteAddress
 	c.SetHandler(handler)
 	c.closer = NewCloser()
 	c.messageChan = make(chan *bytes.Buffer, 16)
 	c.messagesDone = make(chan struct{})
+	c.keepaliveChanged = make(chan struct{}, 1)
+}
+
+// SetKeepalive changes the interval in which pings are sent to the client and
+// the time to wait for a response before the connection is closed.
+func (c *Client) SetKeepalive(pingInterval time.Duration, pongTimeout time.Duration) {
+	c.pingInterval.Store(int64(pingInterval))
+	c.pongTimeout.Store(int64(pongTimeout))
+	c.mu.Lock()
+	if c.conn != nil {
+		// The read pump might already wait with the previous timeout.
+		c.conn.SetReadDeadline(time.Now().Add(pongTimeout)) // nolint
+	}
+	c.mu.Unlock()
+	select {
+	case c.keepaliveChanged <- struct{}{}:
+	default:
+	}
+}
+
+func (c *Client) getPingInterval() time.Duration {
+	if interval := c.pingInterval.Load(); interval > 0 {
+		return time.Duration(interval)
+	}
+	return pingPeriod
+}
+
+func (c *Client) getPongTimeout() time.Duration {
+	if timeout := c.pongTimeout.Load(); timeout > 0 {
+		return time.Duration(timeout)
+	}
+	return pongWait
+}
+
+// SetCompressionThreshold enables compression of outgoing messages that have
+// at least the given size. Must be called before the write pump is started.
+func (c *Client) SetCompressionThreshold(threshold int) {
+	c.compress = true
+	c.compressionThreshold = threshold
 }
 
 func (c *Client) SetHandler(handler ClientHandler) {
@@ -272,7 +435,11 @@ func (c *Client) doClose() {
 		c.mu.Lock()
 		defer c.mu.Unlock()
 		if c.conn != nil {
-			c.conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")) // nolint
+			closeData := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
+			if reason := c.closeReason.Load(); reason != nil {
+				closeData = websocket.FormatCloseMessage(websocket.CloseGoingAway, *reason)
+			}
+			c.conn.WriteMessage(websocket.CloseMessage, closeData) // nolint
 			c.conn.Close()
 			c.conn = nil
 		}
@@ -305,12 +472,7 @@ func (c *Client) SendByeResponseWithReason(message *ClientMessage, reason string
 	if message != nil {
 		response.Id = message.Id
 	}
-	if reason != "" {
-		if response.Bye == nil {
-			response.Bye = &ByeServerMessage{}
-		}
-		response.Bye.Reason = reason
-	}
+	response.Bye = NewByeServerMessage(reason)
 	return c.SendMessage(response)
 }
 
@@ -331,14 +493,14 @@ func (c *Client) ReadPump() {
 	conn := c.conn
 	c.mu.Unlock()
 	if conn == nil {
-		log.Printf("Connection from %s closed while starting readPump", addr)
+		hubLog.Infof("Connection from %s closed while starting readPump", addr)
 		return
 	}
 
 	conn.SetReadLimit(maxMessageSize)
 	conn.SetPongHandler(func(msg string) error {
 		now := time.Now()
-		conn.SetReadDeadline(now.Add(pongWait)) // nolint
+		conn.SetReadDeadline(now.Add(c.getPongTimeout())) // nolint
 		if msg == "" {
 			return nil
 		}
@@ -347,9 +509,9 @@ func (c *Client) ReadPump() {
 			if c.logRTT {
 				rtt_ms := rtt.Nanoseconds() / time.Millisecond.Nanoseconds()
 				if sessionId := c.GetSessionId(); sessionId != "" {
-					log.Printf("Client %s has RTT of %d ms (%s)", sessionId, rtt_ms, rtt)
+					hubLog.Infof("Client %s has RTT of %d ms (%s)", sessionId, rtt_ms, rtt)
 				} else {
-					log.Printf("Client from %s has RTT of %d ms (%s)", addr, rtt_ms, rtt)
+					hubLog.Infof("Client from %s has RTT of %d ms (%s)", addr, rtt_ms, rtt)
 				}
 			}
 			c.getHandler().OnRTTReceived(c, rtt)
@@ -358,30 +520,39 @@ func (c *Client) ReadPump() {
 	})
 
 	for {
-		conn.SetReadDeadline(time.Now().Add(pongWait)) // nolint
+		conn.SetReadDeadline(time.Now().Add(c.getPongTimeout())) // nolint
 		messageType, reader, err := conn.NextReader()
 		if err != nil {
 			// Gorilla websocket hides the original net.Error, so also compare error messages
 			if errors.Is(err, net.ErrClosed) || errors.Is(err, websocket.ErrCloseSent) || strings.Contains(err.Error(), net.ErrClosed.Error()) {
 				break
-			} else if _, ok := err.(*websocket.CloseError); !ok || websocket.IsUnexpectedCloseError(err,
+			}
+
+			updateWebsocketReadStats(err)
+			if ne, ok := err.(net.Error); ok && ne.Timeout() {
+				// No pong was received in time, tell the client why the
+				// connection is closed.
+				reason := ByeReasonIdleTimeout
+				c.closeReason.Store(&reason)
+			}
+			if _, ok := err.(*websocket.CloseError); !ok || websocket.IsUnexpectedCloseError(err,
 				websocket.CloseNormalClosure,
 				websocket.CloseGoingAway,
 				websocket.CloseNoStatusReceived) {
 				if sessionId := c.GetSessionId(); sessionId != "" {
-					log.Printf("Error reading from client %s: %v", sessionId, err)
+					hubLog.Errorf("Error reading from client %s: %v", sessionId, err)
 				} else {
-					log.Printf("Error reading from %s: %v", addr, err)
+					hubLog.Errorf("Error reading from %s: %v", addr, err)
 				}
 			}
 			break
 		}
 
-		if messageType != websocket.TextMessage {
+		if messageType != websocket.TextMessage && (!c.cbor || messageType != websocket.BinaryMessage) {
 			if sessionId := c.GetSessionId(); sessionId != "" {
-				log.Printf("Unsupported message type %v from client %s", messageType, sessionId)
+				hubLog.Warnf("Unsupported message type %v from client %s", messageType, sessionId)
 			} else {
-				log.Printf("Unsupported message type %v from %s", messageType, addr)
+				hubLog.Warnf("Unsupported message type %v from %s", messageType, addr)
 			}
 			c.SendError(InvalidFormat)
 			continue
@@ -389,14 +560,32 @@ func (c *Client) ReadPump() {
 
 		decodeBuffer, err := bufferPool.ReadAll(reader)
 		if err != nil {
+			updateWebsocketReadStats(err)
 			if sessionId := c.GetSessionId(); sessionId != "" {
-				log.Printf("Error reading message from client %s: %v", sessionId, err)
+				hubLog.Errorf("Error reading message from client %s: %v", sessionId, err)
 			} else {
-				log.Printf("Error reading message from %s: %v", addr, err)
+				hubLog.Errorf("Error reading message from %s: %v", addr, err)
 			}
 			break
 		}
 
+		if messageType == websocket.BinaryMessage {
+			data, err := cborToJson(decodeBuffer.Bytes())
+			if err != nil {
+				if sessionId := c.GetSessionId(); sessionId != "" {
+					hubLog.Warnf("Error decoding message from client %s: %v", sessionId, err)
+				} else {
+					hubLog.Warnf("Error decoding message from %s: %v", addr, err)
+				}
+				bufferPool.Put(decodeBuffer)
+				c.SendError(InvalidFormat)
+				continue
+			}
+
+			decodeBuffer.Reset()
+			decodeBuffer.Write(data)
+		}
+
 		// Stop processing if the client was closed.
 		if !c.IsConnected() {
 			bufferPool.Put(decodeBuffer)
@@ -408,6 +597,8 @@ func (c *Client) ReadPump() {
 }
 
 func (c *Client) processMessages() {
+	defer reportPanic(LogSubsystemHub, c.GetSession)
+
 	for {
 		buffer := <-c.messageChan
 		if buffer == nil {
@@ -425,17 +616,28 @@ func (c *Client) processMessages() {
 func (c *Client) writeInternal(message json.Marshaler) bool {
 	var closeData []byte
 
-	c.conn.SetWriteDeadline(time.Now().Add(writeWait)) // nolint
-	writer, err := c.conn.NextWriter(websocket.TextMessage)
-	if err == nil {
-		if m, ok := (any(message)).(easyjson.Marshaler); ok {
-			_, err = easyjson.MarshalToWriter(m, writer)
-		} else {
-			err = json.NewEncoder(writer).Encode(message)
+	var err error
+	if c.cbor || c.compress {
+		err = c.writeBuffered(message)
+	} else {
+		c.conn.SetWriteDeadline(time.Now().Add(writeWait)) // nolint
+		var writer io.WriteCloser
+		writer, err = c.conn.NextWriter(websocket.TextMessage)
+		if err == nil {
+			if prepared, ok := message.(*PreparedServerMessage); ok {
+				var data []byte
+				if data, err = prepared.Data(); err == nil {
+					_, err = writer.Write(data)
+				}
+			} else if m, ok := (any(message)).(easyjson.Marshaler); ok {
+				_, err = easyjson.MarshalToWriter(m, writer)
+			} else {
+				err = json.NewEncoder(writer).Encode(message)
+			}
+		}
+		if err == nil {
+			err = writer.Close()
 		}
-	}
-	if err == nil {
-		err = writer.Close()
 	}
 	if err != nil {
 		if err == websocket.ErrCloseSent {
@@ -443,10 +645,12 @@ func (c *Client) writeInternal(message json.Marshaler) bool {
 			return false
 		}
 
+		statsClientWebsocketWriteErrors.WithLabelValues(getWebsocketErrorReason(err)).Inc()
+
 		if sessionId := c.GetSessionId(); sessionId != "" {
-			log.Printf("Could not send message %+v to client %s: %v", message, sessionId, err)
+			hubLog.Errorf("Could not send message %+v to client %s: %v", message, sessionId, err)
 		} else {
-			log.Printf("Could not send message %+v to %s: %v", message, c.RemoteAddr(), err)
+			hubLog.Errorf("Could not send message %+v to %s: %v", message, c.RemoteAddr(), err)
 		}
 		closeData = websocket.FormatCloseMessage(websocket.CloseInternalServerErr, "")
 		goto close
@@ -457,14 +661,46 @@ close:
 	c.conn.SetWriteDeadline(time.Now().Add(writeWait)) // nolint
 	if err := c.conn.WriteMessage(websocket.CloseMessage, closeData); err != nil {
 		if sessionId := c.GetSessionId(); sessionId != "" {
-			log.Printf("Could not send close message to client %s: %v", sessionId, err)
+			hubLog.Errorf("Could not send close message to client %s: %v", sessionId, err)
 		} else {
-			log.Printf("Could not send close message to %s: %v", c.RemoteAddr(), err)
+			hubLog.Errorf("Could not send close message to %s: %v", c.RemoteAddr(), err)
 		}
 	}
 	return false
 }
 
+func (c *Client) writeBuffered(message json.Marshaler) error {
+	var data []byte
+	var err error
+	if prepared, ok := message.(*PreparedServerMessage); ok {
+		// Serialized only once for all recipients.
+		if data, err = prepared.Data(); err != nil {
+			return err
+		}
+	} else {
+		b, err := bufferPool.MarshalMessage(message)
+		if err != nil {
+			return err
+		}
+		defer bufferPool.Put(b)
+		data = b.Bytes()
+	}
+
+	messageType := websocket.TextMessage
+	if c.cbor {
+		if data, err = jsonToCbor(data); err != nil {
+			return err
+		}
+		messageType = websocket.BinaryMessage
+	}
+
+	if c.compress {
+		c.conn.EnableWriteCompression(len(data) >= c.compressionThreshold)
+	}
+	c.conn.SetWriteDeadline(time.Now().Add(writeWait)) // nolint
+	return c.conn.WriteMessage(messageType, data)
+}
+
 func (c *Client) writeError(e error) bool { // nolint
 	message := &ServerMessage{
 		Type:  "error",
@@ -484,15 +720,26 @@ func (c *Client) writeError(e error) bool { // nolint
 	c.conn.SetWriteDeadline(time.Now().Add(writeWait)) // nolint
 	if err := c.conn.WriteMessage(websocket.CloseMessage, closeData); err != nil {
 		if sessionId := c.GetSessionId(); sessionId != "" {
-			log.Printf("Could not send close message to client %s: %v", sessionId, err)
+			hubLog.Errorf("Could not send close message to client %s: %v", sessionId, err)
 		} else {
-			log.Printf("Could not send close message to %s: %v", c.RemoteAddr(), err)
+			hubLog.Errorf("Could not send close message to %s: %v", c.RemoteAddr(), err)
 		}
 	}
 	return false
 }
 
+func (c *Client) PendingWrites() int {
+	return int(c.pendingWrites.Load())
+}
+
 func (c *Client) writeMessage(message WritableClientMessage) bool {
+	c.pendingWrites.Add(1)
+	defer c.pendingWrites.Add(-1)
+
+	if c.batching.Load() {
+		return c.writeBatched(message)
+	}
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	if c.conn == nil {
@@ -509,12 +756,72 @@ func (c *Client) writeMessageLocked(message WritableClientMessage) bool {
 
 	session := c.GetSession()
 	if message.CloseAfterSend(session) {
-		c.conn.SetWriteDeadline(time.Now().Add(writeWait))    // nolint
-		c.conn.WriteMessage(websocket.CloseMessage, []byte{}) // nolint
-		if session != nil {
-			go session.Close()
+		c.closeAfterSendLocked(session)
+	}
+
+	return true
+}
+
+func (c *Client) closeAfterSendLocked(session Session) {
+	c.conn.SetWriteDeadline(time.Now().Add(writeWait))    // nolint
+	c.conn.WriteMessage(websocket.CloseMessage, []byte{}) // nolint
+	if session != nil {
+		go session.Close()
+	}
+	go c.Close()
+}
+
+// SetBatching enables or disables combining queued messages into a single
+// frame. Must only be enabled if the client negotiated the feature.
+func (c *Client) SetBatching(batching bool) {
+	c.batching.Store(batching)
+}
+
+// writeBatched queues the message and writes all messages that were queued
+// while another message was written in a single frame. Returns after the
+// message has been written, either by this or by a concurrent caller.
+func (c *Client) writeBatched(message WritableClientMessage) bool {
+	c.batchMu.Lock()
+	c.batch = append(c.batch, message)
+	c.batchMu.Unlock()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.batchMu.Lock()
+	messages := c.batch
+	c.batch = nil
+	c.batchMu.Unlock()
+
+	if c.conn == nil {
+		return false
+	}
+
+	for len(messages) > 0 {
+		count := min(len(messages), maxBatchMessages)
+		if !c.writeBatchLocked(messages[:count]) {
+			return false
 		}
-		go c.Close()
+		messages = messages[count:]
+	}
+	return true
+}
+
+func (c *Client) writeBatchLocked(messages []WritableClientMessage) bool {
+	if len(messages) == 1 {
+		return c.writeMessageLocked(messages[0])
+	}
+
+	if !c.writeInternal(clientMessageBatch(messages)) {
+		return false
+	}
+
+	statsClientBatchedMessagesTotal.Add(float64(len(messages)))
+	session := c.GetSession()
+	if slices.ContainsFunc(messages, func(message WritableClientMessage) bool {
+		return message.CloseAfterSend(session)
+	}) {
+		c.closeAfterSendLocked(session)
 	}
 
 	return true
@@ -531,10 +838,11 @@ func (c *Client) sendPing() bool {
 	msg := strconv.FormatInt(now, 10)
 	c.conn.SetWriteDeadline(time.Now().Add(writeWait)) // nolint
 	if err := c.conn.WriteMessage(websocket.PingMessage, []byte(msg)); err != nil {
+		statsClientWebsocketWriteErrors.WithLabelValues(getWebsocketErrorReason(err)).Inc()
 		if sessionId := c.GetSessionId(); sessionId != "" {
-			log.Printf("Could not send ping to client %s: %v", sessionId, err)
+			hubLog.Errorf("Could not send ping to client %s: %v", sessionId, err)
 		} else {
-			log.Printf("Could not send ping to %s: %v", c.RemoteAddr(), err)
+			hubLog.Errorf("Could not send ping to %s: %v", c.RemoteAddr(), err)
 		}
 		return false
 	}
@@ -543,7 +851,7 @@ func (c *Client) sendPing() bool {
 }
 
 func (c *Client) WritePump() {
-	ticker := time.NewTicker(pingPeriod)
+	ticker := time.NewTicker(c.getPingInterval())
 	defer func() {
 		ticker.Stop()
 	}()
@@ -556,6 +864,8 @@ func (c *Client) WritePump() {
 			if !c.sendPing() {
 				return
 			}
+		case <-c.keepaliveChanged:
+			ticker.Reset(c.getPingInterval())
 		case <-c.closer.C:
 			return
 		}
@@ -35,6 +35,7 @@ import (
 	"os"
 	"os/signal"
 	"runtime"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -48,12 +49,26 @@ import (
 )
 
 var (
-	addr = flag.String("addr", "localhost:28080", "http service address")
+	addr = flag.String("addr", "localhost:28080", "comma-separated list of signaling servers to connect to (\"host:port\" or websocket URLs)")
 
 	config = flag.String("config", "server.conf", "config file to use")
 
 	maxClients = flag.Int("maxClients", 100, "number of client connections")
 
+	numRooms = flag.Int("rooms", 0, "number of rooms the clients join, messages are only sent to sessions in the same room (0 to not join rooms)")
+
+	messageRate = flag.Float64("rate", 0, "number of messages per second each client sends (0 for as fast as possible)")
+
+	rampUp = flag.Duration("rampup", 0, "time to spread the creation of clients over")
+
+	inCallInterval = flag.Duration("incall", 0, "interval to toggle the in-call state of the rooms (0 to disable)")
+
+	runDuration = flag.Duration("duration", 0, "time to run before stopping (0 to run until interrupted)")
+
+	reportInterval = flag.Duration("report", 10*time.Second, "interval to report statistics")
+
+	listenAddr = flag.String("listen", "", "address the internal backend server listens on, must be reachable by the signaling server (defaults to a random port on the local IP)")
+
 	backendSecret []byte
 
 	// Report messages that took more than 1 second.
@@ -74,46 +89,13 @@ const (
 	maxMessageSize = 64 * 1024
 )
 
-type Stats struct {
-	numRecvMessages   atomic.Uint64
-	numSentMessages   atomic.Uint64
-	resetRecvMessages uint64
-	resetSentMessages uint64
-
-	start time.Time
-}
-
-func (s *Stats) reset(start time.Time) {
-	s.resetRecvMessages = s.numRecvMessages.Load()
-	s.resetSentMessages = s.numSentMessages.Load()
-	s.start = start
-}
-
-func (s *Stats) Log() {
-	now := time.Now()
-	duration := now.Sub(s.start)
-	perSec := uint64(duration / time.Second)
-	if perSec == 0 {
-		return
-	}
-
-	totalSentMessages := s.numSentMessages.Load()
-	sentMessages := totalSentMessages - s.resetSentMessages
-	totalRecvMessages := s.numRecvMessages.Load()
-	recvMessages := totalRecvMessages - s.resetRecvMessages
-	log.Printf("Stats: sent=%d (%d/sec), recv=%d (%d/sec), delta=%d",
-		totalSentMessages, sentMessages/perSec,
-		totalRecvMessages, recvMessages/perSec,
-		totalSentMessages-totalRecvMessages)
-	s.reset(now)
-}
-
 type MessagePayload struct {
 	Now time.Time `json:"now"`
 }
 
 type SignalingClient struct {
 	readyWg *sync.WaitGroup
+	ready   atomic.Bool
 	cookie  *signaling.SessionIdCodec
 
 	conn *websocket.Conn
@@ -123,10 +105,17 @@ type SignalingClient struct {
 
 	stopChan chan struct{}
 
+	// Room to join after the hello was processed (if not empty).
+	roomId        string
+	roomSessionId signaling.RoomSessionId
+
 	lock             sync.Mutex
 	privateSessionId signaling.PrivateSessionId
 	publicSessionId  signaling.PublicSessionId
 	userId           string
+	helloSent        time.Time
+	joinSent         time.Time
+	joined           bool
 }
 
 func NewSignalingClient(cookie *signaling.SessionIdCodec, url string, stats *Stats, readyWg *sync.WaitGroup, doneWg *sync.WaitGroup) (*SignalingClient, error) {
@@ -135,6 +124,7 @@ func NewSignalingClient(cookie *signaling.SessionIdCodec, url string, stats *Sta
 		return nil, err
 	}
 
+	readyWg.Add(1)
 	client := &SignalingClient{
 		readyWg: readyWg,
 		cookie:  cookie,
@@ -164,6 +154,8 @@ func (c *SignalingClient) Close() {
 
 	// Signal writepump to terminate
 	close(c.stopChan)
+	// Don't block waiting for clients that failed to connect.
+	c.markReady()
 
 	c.lock.Lock()
 	c.publicSessionId = ""
@@ -190,18 +182,57 @@ func (c *SignalingClient) Send(message *signaling.ClientMessage) {
 	c.lock.Unlock()
 }
 
+func (c *SignalingClient) markReady() {
+	if c.ready.CompareAndSwap(false, true) {
+		c.readyWg.Done()
+	}
+}
+
+func (c *SignalingClient) IsJoined() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.joined
+}
+
+func (c *SignalingClient) SendHello(backendUrl string, userId string) {
+	params, _ := json.Marshal(AuthParams{
+		UserId: userId,
+	})
+	request := &signaling.ClientMessage{
+		Type: "hello",
+		Hello: &signaling.HelloClientMessage{
+			Version: signaling.HelloVersionV1,
+			Auth: &signaling.HelloClientMessageAuth{
+				Url:    backendUrl + "/auth",
+				Params: params,
+			},
+		},
+	}
+
+	c.lock.Lock()
+	c.helloSent = time.Now()
+	c.lock.Unlock()
+	c.Send(request)
+}
+
 func (c *SignalingClient) processMessage(message *signaling.ServerMessage) {
 	c.stats.numRecvMessages.Add(1)
 	switch message.Type {
 	case "hello":
 		c.processHelloMessage(message)
+	case "room":
+		c.processRoomMessage(message)
 	case "message":
 		c.processMessageMessage(message)
+	case "welcome":
+	case "event":
+		// Participant updates, e.g. when the in-call state changed.
 	case "bye":
 		log.Printf("Received bye: %+v", message.Bye)
 		c.Close()
 	case "error":
 		log.Printf("Received error: %+v", message.Error)
+		c.stats.numErrors.Add(1)
 		c.Close()
 	default:
 		log.Printf("Unsupported message type: %+v", *message)
@@ -222,12 +253,44 @@ func (c *SignalingClient) privateToPublicSessionId(privateId signaling.PrivateSe
 
 func (c *SignalingClient) processHelloMessage(message *signaling.ServerMessage) {
 	c.lock.Lock()
-	defer c.lock.Unlock()
 	c.privateSessionId = message.Hello.ResumeId
-	c.publicSessionId = c.privateToPublicSessionId(c.privateSessionId)
+	c.publicSessionId = message.Hello.SessionId
+	if c.publicSessionId == "" {
+		c.publicSessionId = c.privateToPublicSessionId(c.privateSessionId)
+	}
 	c.userId = message.Hello.UserId
+	c.stats.hello.Add(time.Since(c.helloSent))
 	log.Printf("Registered as %s (userid %s)", c.privateSessionId, c.userId)
-	c.readyWg.Done()
+	if c.roomId == "" {
+		c.lock.Unlock()
+		c.markReady()
+		return
+	}
+
+	c.joinSent = time.Now()
+	c.lock.Unlock()
+
+	c.Send(&signaling.ClientMessage{
+		Type: "room",
+		Room: &signaling.RoomClientMessage{
+			RoomId:    c.roomId,
+			SessionId: c.roomSessionId,
+		},
+	})
+}
+
+func (c *SignalingClient) processRoomMessage(message *signaling.ServerMessage) {
+	if message.Room == nil || message.Room.RoomId != c.roomId {
+		return
+	}
+
+	c.lock.Lock()
+	if !c.joined {
+		c.joined = true
+		c.stats.join.Add(time.Since(c.joinSent))
+	}
+	c.lock.Unlock()
+	c.markReady()
 }
 
 func (c *SignalingClient) PublicSessionId() signaling.PublicSessionId {
@@ -245,6 +308,7 @@ func (c *SignalingClient) processMessageMessage(message *signaling.ServerMessage
 
 	now := time.Now()
 	duration := now.Sub(msg.Now)
+	c.stats.messages.Add(duration)
 	if duration > messageReportDuration {
 		log.Printf("Message took %s", duration)
 	}
@@ -373,7 +437,21 @@ func (c *SignalingClient) SendMessages(clients []*SignalingClient) {
 		sessionIds[c] = c.PublicSessionId()
 	}
 
+	var ticker *time.Ticker
+	if *messageRate > 0 {
+		ticker = time.NewTicker(time.Duration(float64(time.Second) / *messageRate))
+		defer ticker.Stop()
+	}
+
 	for !c.closed.Load() {
+		if ticker != nil {
+			select {
+			case <-ticker.C:
+			case <-c.stopChan:
+				return
+			}
+		}
+
 		now := time.Now()
 
 		sender := c
@@ -398,13 +476,137 @@ func (c *SignalingClient) SendMessages(clients []*SignalingClient) {
 			},
 		}
 		sender.Send(msg)
-		// Give some time to other clients.
-		time.Sleep(1 * time.Millisecond)
+		if ticker == nil {
+			// Give some time to other clients.
+			time.Sleep(1 * time.Millisecond)
+		}
 	}
 }
 
-func registerAuthHandler(router *mux.Router) {
-	router.HandleFunc("/auth", func(w http.ResponseWriter, r *http.Request) {
+// InCallToggler periodically changes the in-call state of all sessions in a
+// room through the backend API of the signaling server.
+type InCallToggler struct {
+	client     *http.Client
+	url        string
+	backendUrl string
+	stats      *Stats
+
+	roomId  string
+	clients []*SignalingClient
+	inCall  bool
+}
+
+func (t *InCallToggler) Toggle() error {
+	t.inCall = !t.inCall
+	flags := 0
+	if t.inCall {
+		flags = signaling.FlagInCall | signaling.FlagWithAudio
+	}
+
+	var changed []signaling.StringMap
+	for _, c := range t.clients {
+		changed = append(changed, signaling.StringMap{
+			"sessionId": c.PublicSessionId(),
+			"inCall":    flags,
+		})
+	}
+	inCall, _ := json.Marshal(flags)
+	msg := &signaling.BackendServerRoomRequest{
+		Type: "incall",
+		InCall: &signaling.BackendRoomInCallRequest{
+			InCall:  inCall,
+			Changed: changed,
+			Users:   changed,
+		},
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, t.url+"/api/v1/room/"+url.PathEscape(t.roomId), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(signaling.HeaderBackendServer, t.backendUrl+"/")
+	signaling.AddBackendChecksum(request, data, backendSecret)
+
+	start := time.Now()
+	response, err := t.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	body, _ := io.ReadAll(response.Body)
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d: %s", response.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	t.stats.incall.Add(time.Since(start))
+	return nil
+}
+
+// AuthParams are sent by the clients in the "hello" request and passed to the
+// internal backend server.
+type AuthParams struct {
+	UserId string `json:"userid"`
+}
+
+func getBackendResponse(request *signaling.BackendClientRequest) (*signaling.BackendClientResponse, error) {
+	switch request.Type {
+	case "auth":
+		var params AuthParams
+		if len(request.Auth.Params) > 0 {
+			if err := json.Unmarshal(request.Auth.Params, &params); err != nil {
+				return nil, err
+			}
+		}
+		if params.UserId == "" {
+			params.UserId = "sample-user"
+		}
+
+		return &signaling.BackendClientResponse{
+			Type: "auth",
+			Auth: &signaling.BackendClientAuthResponse{
+				Version: signaling.BackendVersion,
+				UserId:  params.UserId,
+			},
+		}, nil
+	case "room":
+		return &signaling.BackendClientResponse{
+			Type: "room",
+			Room: &signaling.BackendClientRoomResponse{
+				Version:    signaling.BackendVersion,
+				RoomId:     request.Room.RoomId,
+				Properties: json.RawMessage("{}"),
+			},
+		}, nil
+	case "ping":
+		return &signaling.BackendClientResponse{
+			Type: "ping",
+			Ping: &signaling.BackendClientRingResponse{
+				Version: signaling.BackendVersion,
+				RoomId:  request.Ping.RoomId,
+			},
+		}, nil
+	case "session":
+		return &signaling.BackendClientResponse{
+			Type: "session",
+			Session: &signaling.BackendClientSessionResponse{
+				Version: signaling.BackendVersion,
+				RoomId:  request.Session.RoomId,
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported request type %s", request.Type)
+	}
+}
+
+func registerBackendHandler(router *mux.Router) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
 		body, err := io.ReadAll(r.Body)
 		if err != nil {
 			log.Println("Error reading body:", err)
@@ -429,12 +631,10 @@ func registerAuthHandler(router *mux.Router) {
 			return
 		}
 
-		response := &signaling.BackendClientResponse{
-			Type: "auth",
-			Auth: &signaling.BackendClientAuthResponse{
-				Version: signaling.BackendVersion,
-				UserId:  "sample-user",
-			},
+		response, err := getBackendResponse(&request)
+		if err != nil {
+			log.Println(err)
+			return
 		}
 
 		data, err := response.MarshalJSON()
@@ -464,7 +664,49 @@ func registerAuthHandler(router *mux.Router) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		w.Write(jsonpayload) // nolint
-	})
+	}
+	// Auth requests are sent to the URL used in the "hello" request, other
+	// requests to the OCS endpoint below that URL.
+	router.HandleFunc("/auth", handler)
+	router.HandleFunc("/auth/ocs/v2.php/apps/spreed/api/{version}/signaling/backend", handler)
+}
+
+// getSignalingUrl returns the websocket URL for the given address, which can
+// either be a "host:port" or a full URL.
+func getSignalingUrl(addr string) (*url.URL, error) {
+	if !strings.Contains(addr, "://") {
+		return &url.URL{
+			Scheme: "ws",
+			Host:   addr,
+			Path:   "/spreed",
+		}, nil
+	}
+
+	u, err := url.Parse(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	switch u.Scheme {
+	case "ws":
+	case "wss":
+	default:
+		return nil, fmt.Errorf("unsupported scheme %s", u.Scheme)
+	}
+	if u.Path == "" {
+		u.Path = "/spreed"
+	}
+	return u, nil
+}
+
+// getBackendApiUrl returns the base URL of the backend API of the signaling
+// server with the given websocket URL.
+func getBackendApiUrl(u *url.URL) string {
+	scheme := "http"
+	if u.Scheme == "wss" {
+		scheme = "https"
+	}
+	return scheme + "://" + u.Host + strings.TrimSuffix(strings.TrimSuffix(u.Path, "/"), "/spreed")
 }
 
 func getLocalIP() string {
@@ -522,10 +764,13 @@ func main() {
 	signal.Notify(interrupt, os.Interrupt)
 
 	r := mux.NewRouter()
-	registerAuthHandler(r)
+	registerBackendHandler(r)
 
-	localIP := getLocalIP()
-	listener, err := net.Listen("tcp", localIP+":0")
+	listen := *listenAddr
+	if listen == "" {
+		listen = getLocalIP() + ":0"
+	}
+	listener, err := net.Listen("tcp", listen)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -539,17 +784,19 @@ func main() {
 	backendUrl := "http://" + listener.Addr().String()
 	log.Println("Backend server running on", backendUrl)
 
-	urls := make([]url.URL, 0)
+	urls := make([]*url.URL, 0)
 	urlstrings := make([]string, 0)
 	for host := range signaling.SplitEntries(*addr, ",") {
-		u := url.URL{
-			Scheme: "ws",
-			Host:   host,
-			Path:   "/spreed",
+		u, err := getSignalingUrl(host)
+		if err != nil {
+			log.Fatalf("Invalid address %s: %s", host, err)
 		}
 		urls = append(urls, u)
 		urlstrings = append(urlstrings, u.String())
 	}
+	if len(urls) == 0 {
+		log.Fatal("No address to connect to")
+	}
 	log.Printf("Connecting to %s", urlstrings)
 
 	clients := make([]*SignalingClient, 0)
@@ -558,57 +805,128 @@ func main() {
 	if *maxClients < 2 {
 		log.Fatalf("Need at least 2 clients, got %d", *maxClients)
 	}
+	if *numRooms < 0 || *numRooms > *maxClients/2 {
+		log.Fatalf("Need at least 2 clients per room, got %d clients for %d rooms", *maxClients, *numRooms)
+	}
+	if *inCallInterval > 0 && *numRooms == 0 {
+		log.Fatal("Toggling the in-call state needs rooms")
+	}
 
 	log.Printf("Starting %d clients", *maxClients)
 
 	var doneWg sync.WaitGroup
 	var readyWg sync.WaitGroup
 
+	var createDelay time.Duration
+	if *rampUp > 0 {
+		createDelay = *rampUp / time.Duration(*maxClients)
+	}
 	for i := 0; i < *maxClients; i++ {
+		select {
+		case <-interrupt:
+			log.Println("Interrupted")
+			return
+		default:
+		}
+
 		client, err := NewSignalingClient(cookie, urls[i%len(urls)].String(), stats, &readyWg, &doneWg)
 		if err != nil {
 			log.Fatal(err)
 		}
 		defer client.Close()
-		readyWg.Add(1)
-
-		request := &signaling.ClientMessage{
-			Type: "hello",
-			Hello: &signaling.HelloClientMessage{
-				Version: signaling.HelloVersionV1,
-				Auth: &signaling.HelloClientMessageAuth{
-					Url:    backendUrl + "/auth",
-					Params: json.RawMessage("{}"),
-				},
-			},
-		}
 
-		client.Send(request)
+		if *numRooms > 0 {
+			client.roomId = fmt.Sprintf("loadtest-room-%d", i%*numRooms)
+			client.roomSessionId = signaling.RoomSessionId(fmt.Sprintf("loadtest-session-%d", i))
+		}
+		client.SendHello(backendUrl, fmt.Sprintf("loadtest-user-%d", i))
 		clients = append(clients, client)
+		if createDelay > 0 {
+			time.Sleep(createDelay)
+		}
 	}
 
 	log.Println("Clients created")
 	readyWg.Wait()
 
-	log.Println("All connections established")
-
+	// Clients that failed to connect or join their room don't send messages.
+	active := make(map[string][]*SignalingClient)
 	for _, c := range clients {
-		doneWg.Add(1)
-		go func(c *SignalingClient) {
-			defer doneWg.Done()
-			c.SendMessages(clients)
-		}(c)
+		if c.closed.Load() || (c.roomId != "" && !c.IsJoined()) {
+			continue
+		}
+
+		active[c.roomId] = append(active[c.roomId], c)
+	}
+
+	log.Printf("All connections established, %d failed", len(clients)-countClients(active))
+
+	for _, roomClients := range active {
+		if len(roomClients) < 2 {
+			continue
+		}
+
+		for _, c := range roomClients {
+			doneWg.Add(1)
+			go func(c *SignalingClient) {
+				defer doneWg.Done()
+				c.SendMessages(roomClients)
+			}(c)
+		}
+	}
+
+	var inCall <-chan time.Time
+	var togglers []*InCallToggler
+	if *inCallInterval > 0 {
+		httpClient := &http.Client{
+			Timeout: *inCallInterval,
+		}
+		apiUrl := getBackendApiUrl(urls[0])
+		for roomId, roomClients := range active {
+			togglers = append(togglers, &InCallToggler{
+				client:     httpClient,
+				url:        apiUrl,
+				backendUrl: backendUrl,
+				stats:      stats,
+
+				roomId:  roomId,
+				clients: roomClients,
+			})
+		}
+
+		ticker := time.NewTicker(*inCallInterval)
+		defer ticker.Stop()
+		inCall = ticker.C
 	}
 
-	stats.start = time.Now()
-	reportInterval := 10 * time.Second
-	report := time.NewTicker(reportInterval)
+	var stop <-chan time.Time
+	if *runDuration > 0 {
+		timer := time.NewTimer(*runDuration)
+		defer timer.Stop()
+		stop = timer.C
+	}
+
+	stats.reset(time.Now())
+	report := time.NewTicker(*reportInterval)
+	defer report.Stop()
 loop:
 	for {
 		select {
 		case <-interrupt:
 			log.Println("Interrupted")
 			break loop
+		case <-stop:
+			log.Printf("Stopping after %s", *runDuration)
+			break loop
+		case <-inCall:
+			for _, t := range togglers {
+				go func(t *InCallToggler) {
+					if err := t.Toggle(); err != nil {
+						log.Printf("Could not change in-call state of room %s: %s", t.roomId, err)
+						stats.numErrors.Add(1)
+					}
+				}(t)
+			}
 		case <-report.C:
 			stats.Log()
 		}
@@ -619,4 +937,13 @@ loop:
 		c.Close()
 	}
 	doneWg.Wait()
+	stats.LogSummary()
+}
+
+func countClients(clients map[string][]*SignalingClient) int {
+	var result int
+	for _, c := range clients {
+		result += len(c)
+	}
+	return result
 }
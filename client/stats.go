@@ -0,0 +1,210 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package main
+
+import (
+	"fmt"
+	"log"
+	pseudorand "math/rand"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// Maximum number of latency samples to keep for calculating percentiles.
+	maxLatencySamples = 10000
+)
+
+type LatencySummary struct {
+	Count uint64
+	Min   time.Duration
+	Avg   time.Duration
+	Max   time.Duration
+	P50   time.Duration
+	P95   time.Duration
+	P99   time.Duration
+}
+
+func (s LatencySummary) String() string {
+	if s.Count == 0 {
+		return "count=0"
+	}
+
+	return fmt.Sprintf("count=%d min=%s avg=%s p50=%s p95=%s p99=%s max=%s",
+		s.Count, s.Min, s.Avg, s.P50, s.P95, s.P99, s.Max)
+}
+
+// LatencyStats collects latencies. Percentiles are calculated from a random
+// sample of the values to limit the memory usage.
+type LatencyStats struct {
+	mu      sync.Mutex
+	count   uint64
+	sum     time.Duration
+	min     time.Duration
+	max     time.Duration
+	samples []time.Duration
+}
+
+func (s *LatencyStats) Add(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.count++
+	s.sum += d
+	if s.count == 1 || d < s.min {
+		s.min = d
+	}
+	if d > s.max {
+		s.max = d
+	}
+
+	if len(s.samples) < maxLatencySamples {
+		s.samples = append(s.samples, d)
+	} else if idx := pseudorand.Int63n(int64(s.count)); idx < maxLatencySamples {
+		// Reservoir sampling, every value has the same probability to be kept.
+		s.samples[idx] = d
+	}
+}
+
+func percentile(sorted []time.Duration, p int) time.Duration {
+	idx := (len(sorted)*p+99)/100 - 1
+	return sorted[max(idx, 0)]
+}
+
+// Summary returns the summary of the collected latencies and optionally
+// resets the values.
+func (s *LatencyStats) Summary(reset bool) LatencySummary {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result LatencySummary
+	if s.count > 0 {
+		sorted := slices.Clone(s.samples)
+		slices.Sort(sorted)
+		result = LatencySummary{
+			Count: s.count,
+			Min:   s.min,
+			Avg:   s.sum / time.Duration(s.count),
+			Max:   s.max,
+			P50:   percentile(sorted, 50),
+			P95:   percentile(sorted, 95),
+			P99:   percentile(sorted, 99),
+		}
+	}
+
+	if reset {
+		s.count = 0
+		s.sum = 0
+		s.min = 0
+		s.max = 0
+		s.samples = s.samples[:0]
+	}
+	return result
+}
+
+// LatencyMetric collects latencies for the current report interval and the
+// whole runtime.
+type LatencyMetric struct {
+	interval LatencyStats
+	total    LatencyStats
+}
+
+func (m *LatencyMetric) Add(d time.Duration) {
+	m.interval.Add(d)
+	m.total.Add(d)
+}
+
+type Stats struct {
+	numRecvMessages   atomic.Uint64
+	numSentMessages   atomic.Uint64
+	numErrors         atomic.Uint64
+	resetRecvMessages uint64
+	resetSentMessages uint64
+
+	hello    LatencyMetric
+	join     LatencyMetric
+	messages LatencyMetric
+	incall   LatencyMetric
+
+	begin time.Time
+	start time.Time
+}
+
+func (s *Stats) reset(start time.Time) {
+	s.resetRecvMessages = s.numRecvMessages.Load()
+	s.resetSentMessages = s.numSentMessages.Load()
+	s.start = start
+	if s.begin.IsZero() {
+		s.begin = start
+	}
+}
+
+func (s *Stats) Log() {
+	now := time.Now()
+	duration := now.Sub(s.start)
+	perSec := uint64(duration / time.Second)
+	if perSec == 0 {
+		return
+	}
+
+	totalSentMessages := s.numSentMessages.Load()
+	sentMessages := totalSentMessages - s.resetSentMessages
+	totalRecvMessages := s.numRecvMessages.Load()
+	recvMessages := totalRecvMessages - s.resetRecvMessages
+	log.Printf("Stats: sent=%d (%d/sec), recv=%d (%d/sec), delta=%d, errors=%d",
+		totalSentMessages, sentMessages/perSec,
+		totalRecvMessages, recvMessages/perSec,
+		int64(totalSentMessages)-int64(totalRecvMessages),
+		s.numErrors.Load())
+	log.Printf("  Message latency: %s", s.messages.interval.Summary(true))
+	if summary := s.incall.interval.Summary(true); summary.Count > 0 {
+		log.Printf("  In-call latency: %s", summary)
+	}
+	s.reset(now)
+}
+
+// LogSummary logs the statistics of the whole runtime.
+func (s *Stats) LogSummary() {
+	duration := time.Since(s.begin)
+	perSec := uint64(duration / time.Second)
+	if perSec == 0 {
+		perSec = 1
+	}
+
+	totalSentMessages := s.numSentMessages.Load()
+	totalRecvMessages := s.numRecvMessages.Load()
+	log.Printf("Summary after %s:", duration.Truncate(time.Second))
+	log.Printf("  Messages: sent=%d (%d/sec), recv=%d (%d/sec), errors=%d",
+		totalSentMessages, totalSentMessages/perSec,
+		totalRecvMessages, totalRecvMessages/perSec,
+		s.numErrors.Load())
+	log.Printf("  Hello latency: %s", s.hello.total.Summary(false))
+	if summary := s.join.total.Summary(false); summary.Count > 0 {
+		log.Printf("  Join latency: %s", summary)
+	}
+	log.Printf("  Message latency: %s", s.messages.total.Summary(false))
+	if summary := s.incall.total.Summary(false); summary.Count > 0 {
+		log.Printf("  In-call latency: %s", summary)
+	}
+}
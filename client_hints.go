@@ -0,0 +1,96 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+const (
+	// Clients with a lower downlink bandwidth (in bits per second) will
+	// initially receive the lowest simulcast layer.
+	clientHintsLowDownlink = 500000
+	// Clients with a lower downlink bandwidth (in bits per second) will
+	// initially receive at most the medium simulcast layer.
+	clientHintsMediumDownlink = 1500000
+
+	simulcastSubstreamLow    = 0
+	simulcastSubstreamMedium = 1
+
+	// Temporal layer with a reduced framerate.
+	simulcastTemporalReduced = 1
+)
+
+// getClientHintsStreamSelection returns the maximum substream and temporal
+// layer a client with the given hints should receive initially. A value of
+// -1 means no restriction.
+func getClientHintsStreamSelection(hints *HelloClientMessageHints) (substream int, temporal int) {
+	substream = -1
+	temporal = -1
+	if hints == nil {
+		return
+	}
+
+	limitSubstream := func(value int) {
+		if substream == -1 || value < substream {
+			substream = value
+		}
+	}
+
+	if hints.MaxDownlink > 0 {
+		if hints.MaxDownlink < clientHintsLowDownlink {
+			limitSubstream(simulcastSubstreamLow)
+		} else if hints.MaxDownlink < clientHintsMediumDownlink {
+			limitSubstream(simulcastSubstreamMedium)
+		}
+	}
+	if hints.Mobile {
+		limitSubstream(simulcastSubstreamMedium)
+	}
+	if hints.BatterySaver {
+		limitSubstream(simulcastSubstreamLow)
+		temporal = simulcastTemporalReduced
+	}
+	return
+}
+
+// applyClientHints adds the initial stream selection for the given client
+// hints to the payload of a "requestoffer" message. Values explicitly
+// requested by the client are not changed.
+func applyClientHints(hints *HelloClientMessageHints, streamType StreamType, payload StringMap) StringMap {
+	if streamType != StreamTypeVideo {
+		// Screensharing should always be received in full quality.
+		return payload
+	}
+
+	substream, temporal := getClientHintsStreamSelection(hints)
+	if substream == -1 && temporal == -1 {
+		return payload
+	}
+
+	if payload == nil {
+		payload = make(StringMap)
+	}
+	if _, found := payload["substream"]; !found && substream != -1 {
+		payload["substream"] = substream
+	}
+	if _, found := payload["temporal"]; !found && temporal != -1 {
+		payload["temporal"] = temporal
+	}
+	return payload
+}
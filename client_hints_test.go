@@ -0,0 +1,85 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientHintsStreamSelection(t *testing.T) {
+	t.Parallel()
+	testcases := []struct {
+		hints     *HelloClientMessageHints
+		substream int
+		temporal  int
+	}{
+		{nil, -1, -1},
+		{&HelloClientMessageHints{}, -1, -1},
+		{&HelloClientMessageHints{MaxDownlink: 300000}, 0, -1},
+		{&HelloClientMessageHints{MaxDownlink: 1000000}, 1, -1},
+		{&HelloClientMessageHints{MaxDownlink: 5000000}, -1, -1},
+		{&HelloClientMessageHints{Mobile: true}, 1, -1},
+		{&HelloClientMessageHints{Mobile: true, MaxDownlink: 300000}, 0, -1},
+		{&HelloClientMessageHints{BatterySaver: true}, 0, 1},
+		{&HelloClientMessageHints{BatterySaver: true, MaxDownlink: 5000000}, 0, 1},
+	}
+
+	for idx, tc := range testcases {
+		substream, temporal := getClientHintsStreamSelection(tc.hints)
+		assert.Equal(t, tc.substream, substream, "failed for testcase %d: %+v", idx, tc.hints)
+		assert.Equal(t, tc.temporal, temporal, "failed for testcase %d: %+v", idx, tc.hints)
+	}
+}
+
+func TestApplyClientHints(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	hints := &HelloClientMessageHints{
+		BatterySaver: true,
+	}
+
+	assert.Nil(applyClientHints(nil, StreamTypeVideo, nil))
+	assert.Nil(applyClientHints(&HelloClientMessageHints{}, StreamTypeVideo, nil))
+	// Screensharing is not changed.
+	assert.Nil(applyClientHints(hints, StreamTypeScreen, nil))
+
+	payload := applyClientHints(hints, StreamTypeVideo, nil)
+	assert.Equal(StringMap{
+		"substream": 0,
+		"temporal":  1,
+	}, payload)
+	if stream, err := parseStreamSelection(payload); assert.NoError(err) {
+		assert.True(stream.HasValues())
+	}
+
+	// Explicitly requested values are not overwritten.
+	payload = applyClientHints(hints, StreamTypeVideo, StringMap{
+		"substream": 2,
+	})
+	assert.Equal(StringMap{
+		"substream": 2,
+		"temporal":  1,
+	}, payload)
+}
@@ -32,9 +32,101 @@ var (
 		Name:      "countries_total",
 		Help:      "The total number of connections by country",
 	}, []string{"country"})
+	statsClientSessionsCountry = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "signaling",
+		Subsystem: "client",
+		Name:      "sessions_country",
+		Help:      "The current number of sessions by country and continent",
+	}, []string{"country", "continent"})
+	statsClientWebsocketCloseCodes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "signaling",
+		Subsystem: "client",
+		Name:      "websocket_close_codes_total",
+		Help:      "The total number of websocket close codes received from clients",
+	}, []string{"code"})
+	statsClientWebsocketReadErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "signaling",
+		Subsystem: "client",
+		Name:      "websocket_read_errors_total",
+		Help:      "The total number of errors while reading from client websockets",
+	}, []string{"reason"})
+	statsClientWebsocketWriteErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "signaling",
+		Subsystem: "client",
+		Name:      "websocket_write_errors_total",
+		Help:      "The total number of errors while writing to client websockets",
+	}, []string{"reason"})
+	statsClientWebsocketHandshakeFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "signaling",
+		Subsystem: "client",
+		Name:      "websocket_handshake_failures_total",
+		Help:      "The total number of failed websocket handshakes",
+	}, []string{"reason"})
+	statsClientSlowConsumersCurrent = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "signaling",
+		Subsystem: "client",
+		Name:      "slow_consumers",
+		Help:      "The current number of clients that are slow consumers",
+	})
+	statsClientHelloVersionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "signaling",
+		Subsystem: "client",
+		Name:      "hello_versions_total",
+		Help:      "The total number of new sessions by hello version",
+	}, []string{"version", "clienttype"})
+	statsClientHelloFeaturesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "signaling",
+		Subsystem: "client",
+		Name:      "hello_features_total",
+		Help:      "The total number of new sessions by announced feature",
+	}, []string{"feature", "clienttype"})
+	statsClientWebsocketCompressedCurrent = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "signaling",
+		Subsystem: "client",
+		Name:      "websocket_compressed",
+		Help:      "The current number of clients that receive compressed messages",
+	})
+	statsClientKeepaliveMissesTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "signaling",
+		Subsystem: "client",
+		Name:      "keepalive_misses_total",
+		Help:      "The total number of clients that didn't respond to pings in time",
+	})
+	statsClientPingIntervalRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "signaling",
+		Subsystem: "client",
+		Name:      "ping_interval_requests_total",
+		Help:      "The total number of ping intervals requested by clients",
+	}, []string{"result"})
+	statsClientRttSeconds = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: "signaling",
+		Subsystem: "client",
+		Name:      "rtt_seconds",
+		Help:      "The round trip times reported by clients using echo messages",
+		Buckets:   prometheus.ExponentialBucketsRange(0.001, 30, 30),
+	})
+	statsClientBatchedMessagesTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "signaling",
+		Subsystem: "client",
+		Name:      "batched_messages_total",
+		Help:      "The total number of messages that were combined into batches",
+	})
 
 	clientStats = []prometheus.Collector{
 		statsClientCountries,
+		statsClientSessionsCountry,
+		statsClientWebsocketCloseCodes,
+		statsClientWebsocketReadErrors,
+		statsClientWebsocketWriteErrors,
+		statsClientWebsocketHandshakeFailures,
+		statsClientSlowConsumersCurrent,
+		statsClientHelloVersionsTotal,
+		statsClientHelloFeaturesTotal,
+		statsClientWebsocketCompressedCurrent,
+		statsClientKeepaliveMissesTotal,
+		statsClientPingIntervalRequestsTotal,
+		statsClientRttSeconds,
+		statsClientBatchedMessagesTotal,
 	}
 )
 
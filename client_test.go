@@ -0,0 +1,126 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientMessageBatch(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	assert := assert.New(t)
+
+	batch := clientMessageBatch{
+		&ServerMessage{
+			Id:   "1",
+			Type: "bye",
+		},
+		NewPreparedServerMessage(&ServerMessage{
+			Id:   "2",
+			Type: "bye",
+		}),
+	}
+	data, err := json.Marshal(batch)
+	require.NoError(err)
+
+	var messages []ServerMessage
+	require.NoError(json.Unmarshal(data, &messages))
+	if assert.Len(messages, 2) {
+		assert.Equal("1", messages[0].Id)
+		assert.Equal("2", messages[1].Id)
+	}
+}
+
+func TestClientBatching(t *testing.T) {
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	hub, _, _, server := CreateHubForTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client := NewTestClient(t, server, hub)
+	defer client.CloseWithBye()
+	require.NoError(client.SendHelloClientWithFeatures(testDefaultUserId, []string{ClientFeatureBatching}))
+	hello := MustSucceed1(t, client.RunUntilHello, ctx)
+
+	session, ok := hub.GetSessionByPublicId(hello.Hello.SessionId).(*ClientSession)
+	require.True(ok)
+	c, ok := session.GetClient().(*Client)
+	require.True(ok)
+	require.True(c.batching.Load())
+
+	initial := testutil.ToFloat64(statsClientBatchedMessagesTotal)
+
+	// Messages that are queued while another message is written are sent in a
+	// single frame.
+	c.mu.Lock()
+	count := 3
+	for i := range count {
+		go c.SendMessage(&ServerMessage{
+			Id:    strconv.Itoa(i),
+			Type:  "error",
+			Error: NewError("test", "Test message."),
+		})
+		assert.Eventually(func() bool {
+			c.batchMu.Lock()
+			defer c.batchMu.Unlock()
+			return len(c.batch) == i+1
+		}, testTimeout, time.Millisecond)
+	}
+	c.mu.Unlock()
+
+	for i := range count {
+		msg := MustSucceed1(t, client.RunUntilMessage, ctx)
+		assert.Equal(strconv.Itoa(i), msg.Id)
+	}
+	checkStatsValue(t, statsClientBatchedMessagesTotal, initial+float64(count))
+}
+
+func TestClientWithoutBatching(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	hub, _, _, server := CreateHubForTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client, hello := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId)
+	defer client.CloseWithBye()
+
+	session, ok := hub.GetSessionByPublicId(hello.Hello.SessionId).(*ClientSession)
+	require.True(ok)
+	c, ok := session.GetClient().(*Client)
+	require.True(ok)
+	require.False(c.batching.Load())
+}
@@ -24,8 +24,9 @@ package signaling
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"maps"
 	"net/url"
 	"slices"
@@ -34,6 +35,7 @@ import (
 	"time"
 
 	"github.com/pion/sdp/v3"
+	"golang.org/x/text/language"
 )
 
 var (
@@ -43,6 +45,8 @@ var (
 	// The "/api/v1/signaling/" URL will be changed to use "v3" as the "signaling-v3"
 	// feature is returned by the capabilities endpoint.
 	PathToOcsSignalingBackend = "ocs/v2.php/apps/spreed/api/v1/signaling/backend"
+
+	ErrScreensharingDisabled = errors.New("screensharing is disabled for the backend")
 )
 
 const (
@@ -60,11 +64,18 @@ type ClientSession struct {
 	data      *SessionIdData
 	ctx       context.Context
 	closeFunc context.CancelFunc
+	// Adds the ids of the session to all log messages.
+	log *Logger
 
 	clientType ClientType
 	features   []string
-	userId     string
-	userData   json.RawMessage
+	// The features from the "hello" request that are honored by the server.
+	negotiatedFeatures []string
+	// Language for error messages sent to the client.
+	language language.Tag
+	hints    *HelloClientMessageHints
+	userId   string
+	userData json.RawMessage
 
 	parseUserData func() (StringMap, error)
 
@@ -76,12 +87,25 @@ type ClientSession struct {
 	backendUrl       string
 	parsedBackendUrl *url.URL
 
+	// Country of the client that created the session.
+	country string
+	// Time when the session was created.
+	created time.Time
+	// How the current client connected to the session, either by a "hello"
+	// or by resuming the session. Protected by the lock of the hub.
+	connectType string
+
 	mu sync.Mutex
+	// Messages are sent through the gate so control-plane messages can pass
+	// bulk messages that wait to be sent.
+	sendGate PriorityGate
 
 	client       HandlerClient
 	room         atomic.Pointer[Room]
 	roomJoinTime atomic.Int64
 	federation   atomic.Pointer[FederationClient]
+	// Origin of the first signed message of a federated session.
+	federationOrigin atomic.Pointer[string]
 
 	roomSessionIdLock sync.RWMutex
 	roomSessionId     RoomSessionId
@@ -97,13 +121,30 @@ type ClientSession struct {
 
 	virtualSessions map[*VirtualSession]bool
 
+	secondaryRooms map[string]*secondaryRoom
+
 	seenJoinedLock   sync.Mutex
 	seenJoinedEvents map[PublicSessionId]bool
 
+	participants ParticipantsDelta
+
 	responseHandlersLock sync.Mutex
 	responseHandlers     map[string]ResponseHandlerFunc
 }
 
+func getSessionLogAttrs(publicId PublicSessionId, backend *Backend, userId string) []any {
+	attrs := []any{
+		slog.String("session", string(publicId)),
+	}
+	if backend != nil {
+		attrs = append(attrs, slog.String("backend", backend.Id()))
+	}
+	if userId != "" {
+		attrs = append(attrs, slog.String("user", userId))
+	}
+	return attrs
+}
+
 func NewClientSession(hub *Hub, privateId PrivateSessionId, publicId PublicSessionId, data *SessionIdData, backend *Backend, hello *HelloClientMessage, auth *BackendClientAuthResponse) (*ClientSession, error) {
 	ctx, closeFunc := context.WithCancel(context.Background())
 	s := &ClientSession{
@@ -117,16 +158,23 @@ func NewClientSession(hub *Hub, privateId PrivateSessionId, publicId PublicSessi
 
 		clientType:    hello.Auth.Type,
 		features:      hello.Features,
+		hints:         hello.Hints,
 		userId:        auth.UserId,
 		userData:      auth.User,
 		parseUserData: parseUserData(auth.User),
 
 		backend: backend,
+		created: time.Now(),
+	}
+	s.log = hubLog.With(getSessionLogAttrs(publicId, backend, auth.UserId)...)
+	s.negotiatedFeatures = NegotiateClientFeatures(hub.GetServerInfo(s), s.clientType, hello.Features)
+	if hello.Language != "" {
+		s.language = GetErrorLanguage(hello.Language)
 	}
 	if s.clientType == HelloClientTypeInternal {
 		s.backendUrl = hello.Auth.internalParams.Backend
 		s.parsedBackendUrl = hello.Auth.internalParams.parsedBackend
-		if !s.HasFeature(ClientFeatureInternalInCall) {
+		if !s.HasNegotiatedFeature(ClientFeatureInternalInCall) {
 			s.SetInCall(FlagInCall | FlagWithAudio)
 		}
 	} else {
@@ -187,6 +235,24 @@ func (s *ClientSession) HasFeature(feature string) bool {
 	return slices.Contains(s.features, feature)
 }
 
+// NegotiatedFeatures returns the optional features requested by the client
+// that are honored by the server.
+func (s *ClientSession) NegotiatedFeatures() []string {
+	return s.negotiatedFeatures
+}
+
+// HasNegotiatedFeature checks if the client requested the optional feature
+// and it is honored by the server.
+func (s *ClientSession) HasNegotiatedFeature(feature string) bool {
+	_, found := slices.BinarySearch(s.negotiatedFeatures, feature)
+	return found
+}
+
+// Hints returns the optional hints the client sent in its "hello" request.
+func (s *ClientSession) Hints() *HelloClientMessageHints {
+	return s.hints
+}
+
 // HasPermission checks if the session has the passed permissions.
 func (s *ClientSession) HasPermission(permission Permission) bool {
 	s.mu.Lock()
@@ -233,6 +299,12 @@ func (s *ClientSession) hasPermissionLocked(permission Permission) bool {
 }
 
 func (s *ClientSession) SetPermissions(permissions []Permission) {
+	s.updatePermissions(permissions)
+}
+
+// updatePermissions sets the permissions of the session and returns the
+// effective permissions that were added and removed.
+func (s *ClientSession) updatePermissions(permissions []Permission) (added []Permission, removed []Permission) {
 	var p map[Permission]bool
 	for _, permission := range permissions {
 		if p == nil {
@@ -244,12 +316,55 @@ func (s *ClientSession) SetPermissions(permissions []Permission) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	if s.supportsPermissions && maps.Equal(s.permissions, p) {
-		return
+		return nil, nil
+	}
+
+	candidates := slices.Clone(KnownPermissions)
+	candidates = slices.AppendSeq(candidates, maps.Keys(s.permissions))
+	candidates = slices.AppendSeq(candidates, maps.Keys(p))
+	slices.Sort(candidates)
+	for _, permission := range slices.Compact(candidates) {
+		if prev, next := s.hasPermissionLocked(permission), p[permission]; prev && !next {
+			removed = append(removed, permission)
+		} else if !prev && next {
+			added = append(added, permission)
+		}
 	}
 
 	s.permissions = p
 	s.supportsPermissions = true
-	log.Printf("Permissions of session %s changed: %s", s.PublicId(), permissions)
+	s.log.Infof("Permissions of session %s changed: %s", s.PublicId(), permissions)
+	return added, removed
+}
+
+// closeForbiddenPublishersLocked closes the publishers that the session is no
+// longer allowed to use and returns their stream types.
+func (s *ClientSession) closeForbiddenPublishersLocked() []StreamType {
+	var closed []StreamType
+	if !s.hasPermissionLocked(PERMISSION_MAY_PUBLISH_MEDIA) {
+		if publisher, found := s.publishers[StreamTypeVideo]; found {
+			if (publisher.HasMedia(MediaTypeAudio) && !s.hasPermissionLocked(PERMISSION_MAY_PUBLISH_AUDIO)) ||
+				(publisher.HasMedia(MediaTypeVideo) && !s.hasPermissionLocked(PERMISSION_MAY_PUBLISH_VIDEO)) {
+				delete(s.publishers, StreamTypeVideo)
+				s.log.Infof("Session %s is no longer allowed to publish media, closing publisher %s", s.PublicId(), publisher.Id())
+				go func() {
+					publisher.Close(context.Background())
+				}()
+				closed = append(closed, StreamTypeVideo)
+			}
+		}
+	}
+	if !s.hasPermissionLocked(PERMISSION_MAY_PUBLISH_SCREEN) {
+		if publisher, found := s.publishers[StreamTypeScreen]; found {
+			delete(s.publishers, StreamTypeScreen)
+			s.log.Infof("Session %s is no longer allowed to publish screen, closing publisher %s", s.PublicId(), publisher.Id())
+			go func() {
+				publisher.Close(context.Background())
+			}()
+			closed = append(closed, StreamTypeScreen)
+		}
+	}
+	return closed
 }
 
 func (s *ClientSession) Backend() *Backend {
@@ -304,6 +419,8 @@ func (s *ClientSession) onRoomSet(hasRoom bool) {
 		s.roomJoinTime.Store(0)
 	}
 
+	s.participants.Reset()
+
 	s.seenJoinedLock.Lock()
 	defer s.seenJoinedLock.Unlock()
 	s.seenJoinedEvents = nil
@@ -313,6 +430,16 @@ func (s *ClientSession) GetFederationClient() *FederationClient {
 	return s.federation.Load()
 }
 
+// checkFederationOrigin returns false if the origin of a signed message is
+// different from the origin of the previous signed messages of the session.
+func (s *ClientSession) checkFederationOrigin(origin string) bool {
+	if s.federationOrigin.CompareAndSwap(nil, &origin) {
+		return true
+	}
+
+	return *s.federationOrigin.Load() == origin
+}
+
 func (s *ClientSession) SetFederationClient(federation *FederationClient) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -377,6 +504,10 @@ func (s *ClientSession) closeAndWait(wait bool) {
 		s.events.UnregisterUserListener(s.userId, s.backend, s)
 	}
 	s.events.UnregisterSessionListener(s.publicId, s.backend, s)
+	for _, room := range s.secondaryRooms {
+		s.leaveSecondaryRoomLocked(room, true)
+	}
+	s.secondaryRooms = nil
 	go func(virtualSessions map[*VirtualSession]bool) {
 		for session := range virtualSessions {
 			session.Close()
@@ -415,19 +546,19 @@ func (s *ClientSession) UpdateRoomSessionId(roomSessionId RoomSessionId) error {
 
 	if roomSessionId != "" {
 		if room := s.GetRoom(); room != nil {
-			log.Printf("Session %s updated room session id to %s in room %s", s.PublicId(), roomSessionId, room.Id())
+			s.log.Infof("Session %s updated room session id to %s in room %s", s.PublicId(), roomSessionId, room.Id())
 		} else if client := s.GetFederationClient(); client != nil {
-			log.Printf("Session %s updated room session id to %s in federated room %s", s.PublicId(), roomSessionId, client.RemoteRoomId())
+			s.log.Infof("Session %s updated room session id to %s in federated room %s", s.PublicId(), roomSessionId, client.RemoteRoomId())
 		} else {
-			log.Printf("Session %s updated room session id to %s in unknown room", s.PublicId(), roomSessionId)
+			s.log.Infof("Session %s updated room session id to %s in unknown room", s.PublicId(), roomSessionId)
 		}
 	} else {
 		if room := s.GetRoom(); room != nil {
-			log.Printf("Session %s cleared room session id in room %s", s.PublicId(), room.Id())
+			s.log.Infof("Session %s cleared room session id in room %s", s.PublicId(), room.Id())
 		} else if client := s.GetFederationClient(); client != nil {
-			log.Printf("Session %s cleared room session id in federated room %s", s.PublicId(), client.RemoteRoomId())
+			s.log.Infof("Session %s cleared room session id in federated room %s", s.PublicId(), client.RemoteRoomId())
 		} else {
-			log.Printf("Session %s cleared room session id in unknown room", s.PublicId())
+			s.log.Infof("Session %s cleared room session id in unknown room", s.PublicId())
 		}
 	}
 
@@ -449,7 +580,7 @@ func (s *ClientSession) SubscribeRoomEvents(roomid string, roomSessionId RoomSes
 			return err
 		}
 	}
-	log.Printf("Session %s joined room %s with room session id %s", s.PublicId(), roomid, roomSessionId)
+	s.log.Infof("Session %s joined room %s with room session id %s", s.PublicId(), roomid, roomSessionId)
 	s.roomSessionId = roomSessionId
 	return nil
 }
@@ -463,7 +594,7 @@ func (s *ClientSession) LeaveCall() {
 		return
 	}
 
-	log.Printf("Session %s left call %s", s.PublicId(), room.Id())
+	s.log.Infof("Session %s left call %s", s.PublicId(), room.Id())
 	s.releaseMcuObjects()
 }
 
@@ -475,7 +606,7 @@ func (s *ClientSession) LeaveRoomWithMessage(notify bool, message *ClientMessage
 	if prev := s.federation.Swap(nil); prev != nil {
 		// Session was connected to a federation room.
 		if err := prev.Leave(message); err != nil {
-			log.Printf("Error leaving room for session %s on federation client %s: %s", s.PublicId(), prev.URL(), err)
+			s.log.Errorf("Error leaving room for session %s on federation client %s: %s", s.PublicId(), prev.URL(), err)
 			prev.Close()
 		}
 		return nil
@@ -517,23 +648,152 @@ func (s *ClientSession) doUnsubscribeRoomEvents(notify bool) {
 	s.roomSessionIdLock.Lock()
 	defer s.roomSessionIdLock.Unlock()
 	if notify && room != nil && s.roomSessionId != "" && !s.roomSessionId.IsFederated() {
-		// Notify
-		go func(sid RoomSessionId) {
-			ctx := context.Background()
-			request := NewBackendClientRoomRequest(room.Id(), s.userId, sid)
-			request.Room.UpdateFromSession(s)
-			request.Room.Action = "leave"
-			var response StringMap
-			if err := s.hub.backend.PerformJSONRequest(ctx, s.ParsedBackendOcsUrl(), request, &response); err != nil {
-				log.Printf("Could not notify about room session %s left room %s: %s", sid, room.Id(), err)
-			} else {
-				log.Printf("Removed room session %s: %+v", sid, response)
-			}
-		}(s.roomSessionId)
+		s.notifyRoomLeft(room.Id(), s.roomSessionId)
 	}
 	s.roomSessionId = ""
 }
 
+// notifyRoomLeft asynchronously notifies the backend that the room session
+// left the room.
+func (s *ClientSession) notifyRoomLeft(roomId string, sid RoomSessionId) {
+	go func() {
+		ctx := context.Background()
+		request := NewBackendClientRoomRequest(roomId, s.userId, sid)
+		request.Room.UpdateFromSession(s)
+		request.Room.Action = "leave"
+		var response StringMap
+		if err := s.hub.backend.PerformJSONRequest(ctx, s.ParsedBackendOcsUrl(), request, &response); err != nil {
+			s.log.Errorf("Could not notify about room session %s left room %s: %s", sid, roomId, err)
+		} else {
+			s.log.Infof("Removed room session %s: %+v", sid, response)
+		}
+	}()
+}
+
+// secondaryRoom receives the events of a room that a session joined in
+// addition to its primary room.
+type secondaryRoom struct {
+	session       *ClientSession
+	roomId        string
+	roomSessionId RoomSessionId
+}
+
+func (r *secondaryRoom) ProcessAsyncRoomMessage(message *AsyncMessage) {
+	r.session.processSecondaryRoomMessage(r.roomId, message)
+}
+
+// JoinSecondaryRoom subscribes the session to the events of a room without
+// changing its primary room.
+func (s *ClientSession) JoinSecondaryRoom(roomId string, roomSessionId RoomSessionId, maxRooms int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, found := s.secondaryRooms[roomId]; found {
+		return nil
+	} else if len(s.secondaryRooms) >= maxRooms {
+		return newTooManySecondaryRoomsError(maxRooms)
+	}
+
+	room := &secondaryRoom{
+		session:       s,
+		roomId:        roomId,
+		roomSessionId: roomSessionId,
+	}
+	if err := s.events.RegisterRoomListener(roomId, s.backend, room); err != nil {
+		return err
+	}
+
+	if s.secondaryRooms == nil {
+		s.secondaryRooms = make(map[string]*secondaryRoom)
+	}
+	s.secondaryRooms[roomId] = room
+	s.log.Infof("Session %s joined secondary room %s with room session id %s", s.PublicId(), roomId, roomSessionId)
+	return nil
+}
+
+// LeaveSecondaryRoom unsubscribes the session from the events of a secondary
+// room. Returns false if the room was not joined.
+func (s *ClientSession) LeaveSecondaryRoom(roomId string, notify bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	room, found := s.secondaryRooms[roomId]
+	if !found {
+		return false
+	}
+
+	delete(s.secondaryRooms, roomId)
+	s.leaveSecondaryRoomLocked(room, notify)
+	return true
+}
+
+func (s *ClientSession) leaveSecondaryRoomLocked(room *secondaryRoom, notify bool) {
+	s.events.UnregisterRoomListener(room.roomId, s.backend, room)
+	s.log.Infof("Session %s left secondary room %s", s.PublicId(), room.roomId)
+	if notify && room.roomSessionId != "" {
+		s.notifyRoomLeft(room.roomId, room.roomSessionId)
+	}
+}
+
+// HasSecondaryRoom returns true if the session joined the given room as
+// secondary room.
+func (s *ClientSession) HasSecondaryRoom(roomId string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, found := s.secondaryRooms[roomId]
+	return found
+}
+
+// SecondaryRoomsCount returns the number of secondary rooms of the session.
+func (s *ClientSession) SecondaryRoomsCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.secondaryRooms)
+}
+
+// SendSecondaryRoomMessage sends a message of a secondary room to the session.
+// Events are tagged with the id of the room, room updates are flagged as
+// secondary, all other messages are ignored.
+func (s *ClientSession) SendSecondaryRoomMessage(roomId string, message *ServerMessage) {
+	// The message might be shared with other listeners, so update a copy.
+	msg := *message
+	switch msg.Type {
+	case "event":
+		if msg.Event == nil {
+			return
+		}
+
+		event := *msg.Event
+		event.RoomId = roomId
+		msg.Event = &event
+	case "room":
+		if msg.Room == nil {
+			return
+		}
+
+		room := *msg.Room
+		room.Secondary = true
+		msg.Room = &room
+	default:
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sendMessageUnlocked(&msg)
+}
+
+func (s *ClientSession) processSecondaryRoomMessage(roomId string, message *AsyncMessage) {
+	if message.Type != "message" || message.Message == nil {
+		return
+	}
+
+	s.SendSecondaryRoomMessage(roomId, message.Message)
+}
+
 func (s *ClientSession) ClearClient(client HandlerClient) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -545,7 +805,7 @@ func (s *ClientSession) clearClientLocked(client HandlerClient) {
 	if s.client == nil {
 		return
 	} else if client != nil && s.client != client {
-		log.Printf("Trying to clear other client in session %s", s.PublicId())
+		s.log.Infof("Trying to clear other client in session %s", s.PublicId())
 		return
 	}
 
@@ -579,6 +839,9 @@ func (s *ClientSession) SetClient(client HandlerClient) HandlerClient {
 	}
 
 	client.SetSession(s)
+	if c, ok := client.(*Client); ok {
+		c.SetBatching(s.HasNegotiatedFeature(ClientFeatureBatching))
+	}
 	prev := s.client
 	if prev != nil {
 		s.clearClientLocked(prev)
@@ -598,7 +861,7 @@ func (s *ClientSession) sendOffer(client McuClient, sender PublicSessionId, stre
 	}
 	offer_data, err := json.Marshal(offer_message)
 	if err != nil {
-		log.Println("Could not serialize offer", offer_message, err)
+		s.log.Errorf("Could not serialize offer %v %v", offer_message, err)
 		return
 	}
 	response_message := &ServerMessage{
@@ -628,7 +891,7 @@ func (s *ClientSession) sendCandidate(client McuClient, sender PublicSessionId,
 	}
 	candidate_data, err := json.Marshal(candidate_message)
 	if err != nil {
-		log.Println("Could not serialize candidate", candidate_message, err)
+		s.log.Errorf("Could not serialize candidate %v %v", candidate_message, err)
 		return
 	}
 	response_message := &ServerMessage{
@@ -646,14 +909,57 @@ func (s *ClientSession) sendCandidate(client McuClient, sender PublicSessionId,
 }
 
 func (s *ClientSession) sendMessageUnlocked(message *ServerMessage) bool {
+	s.sendMessageWithStatusUnlocked(message)
+	return true
+}
+
+func (s *ClientSession) sendMessageWithStatusUnlocked(message *ServerMessage) string {
+	return s.sendWritableWithStatusUnlocked(message, message)
+}
+
+func (s *ClientSession) sendWritableWithStatusUnlocked(message *ServerMessage, writable WritableClientMessage) string {
 	if c := s.getClientUnlocked(); c != nil {
-		if c.SendMessage(message) {
-			return true
+		if c.SendMessage(writable) {
+			return ReceiptStatusDelivered
 		}
 	}
 
 	s.storePendingMessage(message)
-	return true
+	return ReceiptStatusQueued
+}
+
+// SendMessageWithReceipt sends a message to the session and notifies the sender
+// of the receipt about the delivery.
+func (s *ClientSession) SendMessageWithReceipt(message *ServerMessage, receipt *AsyncMessageReceipt) {
+	if message = s.filterMessage(message); message == nil {
+		return
+	}
+
+	s.sendGate.Lock(message.IsPriority())
+	s.mu.Lock()
+	status := s.sendMessageWithStatusUnlocked(message)
+	s.mu.Unlock()
+	s.sendGate.Unlock()
+
+	response := &AsyncMessage{
+		Type: "message",
+		Message: &ServerMessage{
+			Id:   receipt.MessageId,
+			Type: "event",
+			Event: &EventServerMessage{
+				Target: "session",
+				Type:   "receipt",
+				Receipt: &EventServerMessageReceipt{
+					Type:      receipt.Type,
+					Recipient: &receipt.Recipient,
+					Status:    status,
+				},
+			},
+		},
+	}
+	if err := s.events.PublishSessionMessage(receipt.SessionId, s.backend, response); err != nil {
+		s.log.Errorf("Error sending receipt for message %s to %s: %s", receipt.MessageId, receipt.SessionId, err)
+	}
 }
 
 func (s *ClientSession) SendError(e *Error) bool {
@@ -670,13 +976,40 @@ func (s *ClientSession) SendMessage(message *ServerMessage) bool {
 		return true
 	}
 
+	s.sendGate.Lock(message.IsPriority())
+	defer s.sendGate.Unlock()
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	return s.sendMessageUnlocked(message)
 }
 
+// sendPreparedMessage sends a message that is shared with other recipients.
+// The serialized data is only used if the message was not modified for this
+// session.
+func (s *ClientSession) sendPreparedMessage(prepared *PreparedServerMessage) bool {
+	message := s.filterMessage(prepared.Message())
+	if message == nil {
+		return true
+	}
+
+	var writable WritableClientMessage = message
+	if message == prepared.Message() {
+		writable = prepared
+	}
+
+	s.sendGate.Lock(message.IsPriority())
+	defer s.sendGate.Unlock()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sendWritableWithStatusUnlocked(message, writable)
+	return true
+}
+
 func (s *ClientSession) SendMessages(messages []*ServerMessage) bool {
+	s.sendGate.Lock(slices.ContainsFunc(messages, (*ServerMessage).IsPriority))
+	defer s.sendGate.Unlock()
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -716,7 +1049,7 @@ func (s *ClientSession) OnIceCandidate(client McuClient, candidate any) {
 		}
 	}
 
-	log.Printf("Session %s received candidate %+v for unknown client %s", s.PublicId(), candidate, client.Id())
+	s.log.Infof("Session %s received candidate %+v for unknown client %s", s.PublicId(), candidate, client.Id())
 }
 
 func (s *ClientSession) OnIceCompleted(client McuClient) {
@@ -799,6 +1132,9 @@ func (s *ClientSession) IsAllowedToSend(data *MessageClientMessageData) error {
 	defer s.mu.Unlock()
 
 	if data != nil && data.RoomType == "screen" {
+		if !s.backend.HasFeature(BackendFeatureScreensharing) {
+			return ErrScreensharingDisabled
+		}
 		if s.hasPermissionLocked(PERMISSION_MAY_PUBLISH_SCREEN) {
 			return nil
 		}
@@ -832,6 +1168,9 @@ func (s *ClientSession) CheckOfferType(streamType StreamType, data *MessageClien
 
 func (s *ClientSession) checkOfferTypeLocked(streamType StreamType, data *MessageClientMessageData) (MediaType, error) {
 	if streamType == StreamTypeScreen {
+		if !s.backend.HasFeature(BackendFeatureScreensharing) {
+			return 0, ErrScreensharingDisabled
+		}
 		if !s.hasPermissionLocked(PERMISSION_MAY_PUBLISH_SCREEN) {
 			return 0, &PermissionError{PERMISSION_MAY_PUBLISH_SCREEN}
 		}
@@ -904,7 +1243,7 @@ func (s *ClientSession) GetOrCreatePublisher(ctx context.Context, mcu Mcu, strea
 		} else {
 			s.publishers[streamType] = publisher
 		}
-		log.Printf("Publishing %s as %s for session %s", streamType, publisher.Id(), s.PublicId())
+		s.log.Infof("Publishing %s as %s for session %s", streamType, publisher.Id(), s.PublicId())
 		s.publisherWaiters.Wakeup()
 	} else {
 		publisher.SetMedia(mediaTypes)
@@ -982,7 +1321,7 @@ func (s *ClientSession) GetOrCreateSubscriber(ctx context.Context, mcu Mcu, id P
 		} else {
 			s.subscribers[getStreamId(id, streamType)] = subscriber
 		}
-		log.Printf("Subscribing %s from %s as %s in session %s", streamType, id, subscriber.Id(), s.PublicId())
+		s.log.Infof("Subscribing %s from %s as %s in session %s", streamType, id, subscriber.Id(), s.PublicId())
 	}
 
 	return subscriber, nil
@@ -1010,39 +1349,40 @@ func (s *ClientSession) ProcessAsyncSessionMessage(message *AsyncMessage) {
 func (s *ClientSession) processAsyncMessage(message *AsyncMessage) {
 	switch message.Type {
 	case "permissions":
-		s.SetPermissions(message.Permissions)
+		added, removed := s.updatePermissions(message.Permissions)
+		if len(added) == 0 && len(removed) == 0 {
+			return
+		}
+
 		go func() {
 			s.mu.Lock()
 			defer s.mu.Unlock()
 
-			if !s.hasPermissionLocked(PERMISSION_MAY_PUBLISH_MEDIA) {
-				if publisher, found := s.publishers[StreamTypeVideo]; found {
-					if (publisher.HasMedia(MediaTypeAudio) && !s.hasPermissionLocked(PERMISSION_MAY_PUBLISH_AUDIO)) ||
-						(publisher.HasMedia(MediaTypeVideo) && !s.hasPermissionLocked(PERMISSION_MAY_PUBLISH_VIDEO)) {
-						delete(s.publishers, StreamTypeVideo)
-						log.Printf("Session %s is no longer allowed to publish media, closing publisher %s", s.PublicId(), publisher.Id())
-						go func() {
-							publisher.Close(context.Background())
-						}()
-						return
-					}
-				}
-			}
-			if !s.hasPermissionLocked(PERMISSION_MAY_PUBLISH_SCREEN) {
-				if publisher, found := s.publishers[StreamTypeScreen]; found {
-					delete(s.publishers, StreamTypeScreen)
-					log.Printf("Session %s is no longer allowed to publish screen, closing publisher %s", s.PublicId(), publisher.Id())
-					go func() {
-						publisher.Close(context.Background())
-					}()
-					return
-				}
-			}
+			permissions := slices.Clone(message.Permissions)
+			slices.Sort(permissions)
+			s.sendMessageUnlocked(&ServerMessage{
+				Type: "event",
+				Event: &EventServerMessage{
+					Target: "session",
+					Type:   "permissions",
+					Permissions: &EventServerMessagePermissions{
+						Permissions: permissions,
+						Added:       added,
+						Removed:     removed,
+						Renegotiate: s.closeForbiddenPublishersLocked(),
+					},
+				},
+			})
 		}()
 		return
 	case "message":
-		if message.Message.Type == "bye" && message.Message.Bye.Reason == "room_session_reconnected" {
-			log.Printf("Closing session %s because same room session %s connected", s.PublicId(), s.RoomSessionId())
+		if message.Message.Type == "bye" && message.Message.Bye != nil {
+			switch message.Message.Bye.Reason {
+			case ByeReasonRoomSessionReconnected:
+				s.log.Infof("Closing session %s because same room session %s connected", s.PublicId(), s.RoomSessionId())
+			default:
+				s.log.Infof("Closing session %s (%s)", s.PublicId(), message.Message.Bye.Reason)
+			}
 			s.LeaveRoom(false)
 			defer s.closeAndWait(false)
 		}
@@ -1054,7 +1394,7 @@ func (s *ClientSession) processAsyncMessage(message *AsyncMessage) {
 
 			mc, err := s.GetOrCreateSubscriber(ctx, s.hub.mcu, message.SendOffer.SessionId, StreamType(message.SendOffer.Data.RoomType))
 			if err != nil {
-				log.Printf("Could not create MCU subscriber for session %s to process sendoffer in %s: %s", message.SendOffer.SessionId, s.PublicId(), err)
+				s.log.Errorf("Could not create MCU subscriber for session %s to process sendoffer in %s: %s", message.SendOffer.SessionId, s.PublicId(), err)
 				if err := s.events.PublishSessionMessage(message.SendOffer.SessionId, s.backend, &AsyncMessage{
 					Type: "message",
 					Message: &ServerMessage{
@@ -1063,11 +1403,11 @@ func (s *ClientSession) processAsyncMessage(message *AsyncMessage) {
 						Error: NewError("client_not_found", "No MCU client found to send message to."),
 					},
 				}); err != nil {
-					log.Printf("Error sending sendoffer error response to %s: %s", message.SendOffer.SessionId, err)
+					s.log.Errorf("Error sending sendoffer error response to %s: %s", message.SendOffer.SessionId, err)
 				}
 				return
 			} else if mc == nil {
-				log.Printf("No MCU subscriber found for session %s to process sendoffer in %s", message.SendOffer.SessionId, s.PublicId())
+				s.log.Warnf("No MCU subscriber found for session %s to process sendoffer in %s", message.SendOffer.SessionId, s.PublicId())
 				if err := s.events.PublishSessionMessage(message.SendOffer.SessionId, s.backend, &AsyncMessage{
 					Type: "message",
 					Message: &ServerMessage{
@@ -1076,14 +1416,14 @@ func (s *ClientSession) processAsyncMessage(message *AsyncMessage) {
 						Error: NewError("client_not_found", "No MCU client found to send message to."),
 					},
 				}); err != nil {
-					log.Printf("Error sending sendoffer error response to %s: %s", message.SendOffer.SessionId, err)
+					s.log.Errorf("Error sending sendoffer error response to %s: %s", message.SendOffer.SessionId, err)
 				}
 				return
 			}
 
 			mc.SendMessage(s.Context(), nil, message.SendOffer.Data, func(err error, response StringMap) {
 				if err != nil {
-					log.Printf("Could not send MCU message %+v for session %s to %s: %s", message.SendOffer.Data, message.SendOffer.SessionId, s.PublicId(), err)
+					s.log.Errorf("Could not send MCU message %+v for session %s to %s: %s", message.SendOffer.Data, message.SendOffer.SessionId, s.PublicId(), err)
 					if err := s.events.PublishSessionMessage(message.SendOffer.SessionId, s.backend, &AsyncMessage{
 						Type: "message",
 						Message: &ServerMessage{
@@ -1092,7 +1432,7 @@ func (s *ClientSession) processAsyncMessage(message *AsyncMessage) {
 							Error: NewError("processing_failed", "Processing of the message failed, please check server logs."),
 						},
 					}); err != nil {
-						log.Printf("Error sending sendoffer error response to %s: %s", message.SendOffer.SessionId, err)
+						s.log.Errorf("Error sending sendoffer error response to %s: %s", message.SendOffer.SessionId, err)
 					}
 					return
 				} else if response == nil {
@@ -1115,7 +1455,36 @@ func (s *ClientSession) processAsyncMessage(message *AsyncMessage) {
 		return
 	}
 
-	s.SendMessage(serverMessage)
+	if message.Receipt != nil {
+		s.SendMessageWithReceipt(serverMessage, message.Receipt)
+	} else if message.prepared != nil && message.prepared.Message() == serverMessage {
+		s.sendPreparedMessage(message.prepared)
+	} else {
+		s.SendMessage(serverMessage)
+	}
+	switch serverMessage.Type {
+	case "message":
+		fallthrough
+	case "control":
+		observeMessageLatency(s.Backend(), serverMessage.Type, message, time.Now())
+	}
+}
+
+// observeMessageLatency records the time since the async message was sent by
+// the hub that received it from a client. Messages from servers that don't
+// include their id are ignored.
+func observeMessageLatency(backend *Backend, messageType string, message *AsyncMessage, now time.Time) {
+	if message.SendTime.IsZero() || message.SendServerId == "" {
+		return
+	}
+
+	delivery := "local"
+	if message.SendServerId != GrpcServerId {
+		// The clocks of different servers might not be in sync.
+		delivery = "remote"
+	}
+	latency := max(now.Sub(message.SendTime), 0)
+	statsHubMessageLatencySeconds.WithLabelValues(statsBackendLabel(backend), messageType, delivery).Observe(latency.Seconds())
 }
 
 func (s *ClientSession) storePendingMessage(message *ServerMessage) {
@@ -1132,7 +1501,7 @@ func (s *ClientSession) storePendingMessage(message *ServerMessage) {
 	}
 	s.pendingClientMessages = append(s.pendingClientMessages, message)
 	if len(s.pendingClientMessages) >= warnPendingMessagesCount {
-		log.Printf("Session %s has %d pending messages", s.PublicId(), len(s.pendingClientMessages))
+		s.log.Infof("Session %s has %d pending messages", s.PublicId(), len(s.pendingClientMessages))
 	}
 }
 
@@ -1187,7 +1556,7 @@ func (s *ClientSession) filterDuplicateJoin(entries []*EventServerMessageSession
 	result := make([]*EventServerMessageSessionEntry, 0, len(entries))
 	for _, e := range entries {
 		if s.seenJoinedEvents[e.SessionId] {
-			log.Printf("Session %s got duplicate joined event for %s, ignoring", s.publicId, e.SessionId)
+			s.log.Infof("Session %s got duplicate joined event for %s, ignoring", s.publicId, e.SessionId)
 			continue
 		}
 
@@ -1207,6 +1576,7 @@ func (s *ClientSession) filterMessage(message *ServerMessage) *ServerMessage {
 		case "participants":
 			if message.Event.Type == "update" {
 				m := message.Event.Update
+				partial := len(m.Users) == 0
 				users := make(map[any]bool)
 				for _, entry := range m.Users {
 					users[entry["sessionId"]] = true
@@ -1220,6 +1590,29 @@ func (s *ClientSession) filterMessage(message *ServerMessage) *ServerMessage {
 				// TODO(jojo): Only send all users if current session id has
 				// changed its "inCall" flag to true.
 				m.Changed = nil
+
+				if s.HasNegotiatedFeature(ClientFeatureParticipantsDelta) {
+					if m.All {
+						// The flags of all participants changed, send full list with next update.
+						s.participants.Reset()
+						break
+					}
+
+					update := s.participants.Update(m.RoomId, m.Users, partial)
+					if update == nil {
+						return nil
+					}
+
+					message = &ServerMessage{
+						Id:   message.Id,
+						Type: message.Type,
+						Event: &EventServerMessage{
+							Type:   message.Event.Type,
+							Target: message.Event.Target,
+							Update: update,
+						},
+					}
+				}
 			}
 		case "room":
 			switch message.Event.Type {
@@ -1297,6 +1690,32 @@ func (s *ClientSession) filterMessage(message *ServerMessage) *ServerMessage {
 				}
 			}
 		}
+	case "transient":
+		if message.TransientData == nil || s.ClientType() == HelloClientTypeInternal {
+			return message
+		}
+
+		switch message.TransientData.Type {
+		case "initial":
+			data := filterTransientData(message.TransientData.Data, s.PublicId())
+			if len(data) == 0 {
+				return nil
+			} else if len(data) != len(message.TransientData.Data) {
+				// Create unique copy of message for only this client.
+				message = &ServerMessage{
+					Id:   message.Id,
+					Type: message.Type,
+					TransientData: &TransientDataServerMessage{
+						Type: message.TransientData.Type,
+						Data: data,
+					},
+				}
+			}
+		default:
+			if !IsTransientKeyVisible(message.TransientData.Key, s.PublicId()) {
+				return nil
+			}
+		}
 	case "message":
 		if message.Message != nil && len(message.Message.Data) > 0 && s.HasPermission(PERMISSION_HIDE_DISPLAYNAMES) {
 			var data MessageServerMessageData
@@ -1308,6 +1727,15 @@ func (s *ClientSession) filterMessage(message *ServerMessage) *ServerMessage {
 				return nil
 			}
 		}
+	case "error":
+		if message.Error != nil && s.language != language.Und {
+			if localized := message.Error.Localize(s.language); localized != message.Error {
+				// Create copy of message to not modify shared errors.
+				m := *message
+				m.Error = localized
+				message = &m
+			}
+		}
 	}
 
 	return message
@@ -1317,7 +1745,7 @@ func (s *ClientSession) filterAsyncMessage(msg *AsyncMessage) *ServerMessage {
 	switch msg.Type {
 	case "message":
 		if msg.Message == nil {
-			log.Printf("Received asynchronous message without payload: %+v", msg)
+			s.log.Infof("Received asynchronous message without payload: %+v", msg)
 			return nil
 		}
 
@@ -1357,7 +1785,7 @@ func (s *ClientSession) filterAsyncMessage(msg *AsyncMessage) *ServerMessage {
 				// Can happen mostly during tests where an older room async message
 				// could be received by a subscriber that joined after it was sent.
 				if joined := s.getRoomJoinTime(); joined.IsZero() || msg.SendTime.Before(joined) {
-					log.Printf("Message %+v was sent on %s before room was joined on %s, ignoring", msg.Message, msg.SendTime, joined)
+					s.log.Infof("Message %+v was sent on %s before room was joined on %s, ignoring", msg.Message, msg.SendTime, joined)
 					return nil
 				}
 			}
@@ -1365,7 +1793,7 @@ func (s *ClientSession) filterAsyncMessage(msg *AsyncMessage) *ServerMessage {
 
 		return msg.Message
 	default:
-		log.Printf("Received async message with unsupported type %s: %+v", msg.Type, msg)
+		s.log.Infof("Received async message with unsupported type %s: %+v", msg.Type, msg)
 		return nil
 	}
 }
@@ -1387,7 +1815,7 @@ func (s *ClientSession) NotifySessionResumed(client HandlerClient) {
 	s.hasPendingParticipantsUpdate = false
 	s.mu.Unlock()
 
-	log.Printf("Send %d pending messages to session %s", len(messages), s.PublicId())
+	s.log.Infof("Send %d pending messages to session %s", len(messages), s.PublicId())
 	// Send through session to handle connection interruptions.
 	s.SendMessages(messages)
 
@@ -1399,6 +1827,30 @@ func (s *ClientSession) NotifySessionResumed(client HandlerClient) {
 	}
 }
 
+// SendParticipantsDiff sends the changes of the participants list since the
+// given version (or the full list if no diff is available) to the client.
+func (s *ClientSession) SendParticipantsDiff(messageId string, roomId string, version uint64) bool {
+	update := s.participants.Diff(roomId, version)
+	if update == nil {
+		return false
+	}
+
+	message := &ServerMessage{
+		Id:   messageId,
+		Type: "event",
+		Event: &EventServerMessage{
+			Target: "participants",
+			Type:   "update",
+			Update: update,
+		},
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sendMessageUnlocked(message)
+}
+
 func (s *ClientSession) AddVirtualSession(session *VirtualSession) {
 	s.mu.Lock()
 	if s.virtualSessions == nil {
@@ -25,7 +25,9 @@ import (
 	"context"
 	"net/url"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -159,3 +161,29 @@ func TestBandwidth_Backend(t *testing.T) {
 		})
 	}
 }
+
+func TestObserveMessageLatency(t *testing.T) {
+	// Make sure test is not executed with "t.Parallel()"
+	t.Setenv("PARALLEL_CHECK", "1")
+	assert := assert.New(t)
+	statsHubMessageLatencySeconds.Reset()
+	t.Cleanup(statsHubMessageLatencySeconds.Reset)
+
+	now := time.Now()
+	observeMessageLatency(nil, "message", &AsyncMessage{
+		SendTime:     now.Add(-time.Millisecond),
+		SendServerId: GrpcServerId,
+	}, now)
+	// Clocks of remote servers could be ahead.
+	observeMessageLatency(nil, "control", &AsyncMessage{
+		SendTime:     now.Add(time.Millisecond),
+		SendServerId: "other-server",
+	}, now)
+	// Messages without server id are ignored.
+	observeMessageLatency(nil, "message", &AsyncMessage{
+		SendTime: now.Add(-time.Millisecond),
+	}, now)
+
+	assert.Equal(2, testutil.CollectAndCount(statsHubMessageLatencySeconds))
+	collectAndLint(t, statsHubMessageLatencySeconds)
+}
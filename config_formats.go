@@ -0,0 +1,176 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/dlintw/goconf"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFormat reads a configuration file in a specific format. All formats
+// use the same sections and options as the ini format.
+type ConfigFormat interface {
+	Read(filename string) (*goconf.ConfigFile, error)
+}
+
+type iniConfigFormat struct{}
+
+func (f iniConfigFormat) Read(filename string) (*goconf.ConfigFile, error) {
+	return goconf.ReadConfigFile(filename)
+}
+
+type yamlConfigFormat struct{}
+
+func (f yamlConfigFormat) Read(filename string) (*goconf.ConfigFile, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+
+	config := goconf.NewConfigFile()
+	if len(root.Content) == 0 {
+		// Empty file.
+		return config, nil
+	}
+
+	doc := root.Content[0]
+	if doc.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: expected a mapping of sections", doc.Line)
+	}
+
+	sections := make(map[string]bool)
+	for i := 0; i+1 < len(doc.Content); i += 2 {
+		key := doc.Content[i]
+		section := doc.Content[i+1]
+		if sections[strings.ToLower(key.Value)] {
+			return nil, fmt.Errorf("line %d: duplicate section \"%s\"", key.Line, key.Value)
+		}
+		sections[strings.ToLower(key.Value)] = true
+
+		if section.Kind == yaml.ScalarNode && section.Tag == "!!null" {
+			config.AddSection(key.Value)
+			continue
+		} else if section.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("line %d: section \"%s\" must be a mapping of options", section.Line, key.Value)
+		}
+
+		config.AddSection(key.Value)
+		options := make(map[string]bool)
+		for j := 0; j+1 < len(section.Content); j += 2 {
+			option := section.Content[j]
+			value := section.Content[j+1]
+			if options[strings.ToLower(option.Value)] {
+				return nil, fmt.Errorf("line %d: duplicate option \"%s\" in section \"%s\"", option.Line, option.Value, key.Value)
+			}
+			options[strings.ToLower(option.Value)] = true
+
+			if value.Kind == yaml.AliasNode {
+				value = value.Alias
+			}
+			if value.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("line %d: option \"%s\" in section \"%s\" must be a string, number or boolean", option.Line, option.Value, key.Value)
+			}
+
+			if value.Tag == "!!null" {
+				config.AddOption(key.Value, option.Value, "")
+			} else {
+				config.AddOption(key.Value, option.Value, value.Value)
+			}
+		}
+	}
+	return config, nil
+}
+
+type tomlConfigFormat struct{}
+
+func (f tomlConfigFormat) Read(filename string) (*goconf.ConfigFile, error) {
+	var data map[string]any
+	if _, err := toml.DecodeFile(filename, &data); err != nil {
+		var pe toml.ParseError
+		if errors.As(err, &pe) {
+			return nil, fmt.Errorf("line %d: %s", pe.Position.Line, pe.Message)
+		}
+		return nil, err
+	}
+
+	config := goconf.NewConfigFile()
+	for name, value := range data {
+		section, ok := value.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("option \"%s\" must be in a section", name)
+		}
+
+		config.AddSection(name)
+		for option, value := range section {
+			var s string
+			switch value := value.(type) {
+			case string:
+				s = value
+			case int64:
+				s = strconv.FormatInt(value, 10)
+			case float64:
+				s = strconv.FormatFloat(value, 'f', -1, 64)
+			case bool:
+				s = strconv.FormatBool(value)
+			case time.Time:
+				s = value.Format(time.RFC3339)
+			default:
+				return nil, fmt.Errorf("option \"%s\" in section \"%s\" must be a string, number or boolean", option, name)
+			}
+
+			config.AddOption(name, option, s)
+		}
+	}
+	return config, nil
+}
+
+var (
+	configFormats = map[string]ConfigFormat{
+		".yaml": yamlConfigFormat{},
+		".yml":  yamlConfigFormat{},
+		".toml": tomlConfigFormat{},
+	}
+)
+
+// getConfigFormat returns the format of a configuration file based on its
+// extension. Files with unknown extensions use the ini format.
+func getConfigFormat(filename string) ConfigFormat {
+	if format, found := configFormats[strings.ToLower(filepath.Ext(filename))]; found {
+		return format
+	}
+
+	return iniConfigFormat{}
+}
@@ -0,0 +1,155 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig_Yaml(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "server.yaml")
+	writeConfigForTest(t, filename, `app:
+  debug: true
+http:
+  listen: "127.0.0.1:8080"
+  readtimeout: 15
+backend:
+  backends: one, two
+  secret:
+one: &backend
+  url: https://one.domain.invalid
+empty:
+include:
+  files: turn.toml
+`)
+	writeConfigForTest(t, filepath.Join(dir, "turn.toml"), `[turn]
+servers = "turn:1.2.3.4:9991"
+
+[http]
+readtimeout = 30
+`)
+
+	config, patterns, err := LoadConfig(filename)
+	require.NoError(err)
+	assert.Equal([]string{filepath.Join(dir, "turn.toml")}, patterns)
+
+	debug, _ := config.GetBool("app", "debug")
+	assert.True(debug)
+	listen, _ := config.GetString("http", "listen")
+	assert.Equal("127.0.0.1:8080", listen)
+	timeout, _ := config.GetInt("http", "readtimeout")
+	assert.Equal(30, timeout)
+	backends, _ := config.GetString("backend", "backends")
+	assert.Equal("one, two", backends)
+	secret, err := config.GetString("backend", "secret")
+	assert.NoError(err)
+	assert.Empty(secret)
+	url, _ := config.GetString("one", "url")
+	assert.Equal("https://one.domain.invalid", url)
+	servers, _ := config.GetString("turn", "servers")
+	assert.Equal("turn:1.2.3.4:9991", servers)
+}
+
+func TestLoadConfig_Toml(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	assert := assert.New(t)
+
+	filename := filepath.Join(t.TempDir(), "proxy.toml")
+	writeConfigForTest(t, filename, `[app]
+debug = true
+country = "DE"
+
+[sessions]
+maxage = 3600
+ratio = 0.5
+
+[continent-overrides]
+AF = "EU"
+`)
+
+	config, _, err := LoadConfig(filename)
+	require.NoError(err)
+	debug, _ := config.GetBool("app", "debug")
+	assert.True(debug)
+	country, _ := config.GetString("app", "country")
+	assert.Equal("DE", country)
+	maxage, _ := config.GetInt("sessions", "maxage")
+	assert.Equal(3600, maxage)
+	ratio, _ := config.GetString("sessions", "ratio")
+	assert.Equal("0.5", ratio)
+	continent, _ := config.GetString("continent-overrides", "AF")
+	assert.Equal("EU", continent)
+}
+
+func TestLoadConfig_FormatErrors(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	testcases := map[string]string{
+		"list.yaml":      "app:\n  debug: true\nbackend:\n  backends:\n    - one\n    - two\n",
+		"section.yaml":   "app: true\n",
+		"root.yaml":      "- app\n",
+		"syntax.yaml":    "app:\n  debug: [true\n",
+		"list.toml":      "[backend]\nbackends = [\"one\", \"two\"]\n",
+		"nested.toml":    "[backend.one]\nurl = \"https://one.domain.invalid\"\n",
+		"toplevel.toml":  "debug = true\n",
+		"syntax.toml":    "[app]\ndebug = \n",
+		"included.yml":   "include:\n  files: missing.toml\n",
+		"duplicate.yaml": "app:\n  debug: true\n  debug: false\n",
+	}
+	expected := map[string]string{
+		"list.yaml":      "line 4: option \"backends\" in section \"backend\" must be a string, number or boolean",
+		"section.yaml":   "line 1: section \"app\" must be a mapping of options",
+		"root.yaml":      "line 1: expected a mapping of sections",
+		"syntax.yaml":    "did not find expected ',' or ']'",
+		"list.toml":      "option \"backends\" in section \"backend\" must be a string, number or boolean",
+		"nested.toml":    "option \"one\" in section \"backend\" must be a string, number or boolean",
+		"toplevel.toml":  "option \"debug\" must be in a section",
+		"syntax.toml":    "line 2",
+		"included.yml":   "missing.toml",
+		"duplicate.yaml": "line 3: duplicate option \"debug\" in section \"app\"",
+	}
+	for name, data := range testcases {
+		filename := filepath.Join(dir, name)
+		writeConfigForTest(t, filename, data)
+		_, _, err := LoadConfig(filename)
+		if msg, found := expected[name]; found {
+			if assert.Error(err, "expected error for %s", name) {
+				assert.ErrorContains(err, "could not read "+dir)
+				assert.ErrorContains(err, msg, "unexpected error for %s", name)
+			}
+		} else {
+			assert.Error(err, "expected error for %s", name)
+		}
+	}
+}
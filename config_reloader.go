@@ -0,0 +1,178 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/dlintw/goconf"
+)
+
+// ConfigSectionError is returned if the options of a section could not be
+// applied while reloading the configuration.
+type ConfigSectionError struct {
+	Section string
+	Err     error
+}
+
+func NewConfigSectionError(section string, err error) error {
+	return &ConfigSectionError{
+		Section: section,
+		Err:     err,
+	}
+}
+
+func (e *ConfigSectionError) Error() string {
+	return fmt.Sprintf("section %s: %s", e.Section, e.Err)
+}
+
+func (e *ConfigSectionError) Unwrap() error {
+	return e.Err
+}
+
+// ConfigReloadFunc applies the reloaded configuration. Errors of specific
+// sections should be returned as ConfigSectionError (multiple errors can be
+// combined with errors.Join).
+type ConfigReloadFunc func(config *goconf.ConfigFile) error
+
+// ConfigReloadResults contains the result of the last reload per section, a
+// nil error means that the section was applied successfully.
+type ConfigReloadResults map[string]error
+
+type configReloadHandler struct {
+	sections []string
+	reload   ConfigReloadFunc
+}
+
+// ConfigReloader runs the reload functions of the different components for
+// the changed sections of a configuration and keeps track of the results.
+type ConfigReloader struct {
+	mu       sync.Mutex
+	handlers []configReloadHandler
+	results  ConfigReloadResults
+}
+
+func NewConfigReloader() *ConfigReloader {
+	return &ConfigReloader{}
+}
+
+// Register adds a function that is called if any of the given sections was
+// changed. Functions without sections are called for changes of all sections
+// except "logging".
+func (r *ConfigReloader) Register(reload ConfigReloadFunc, sections ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.handlers = append(r.handlers, configReloadHandler{
+		sections: sections,
+		reload:   reload,
+	})
+}
+
+func flattenConfigReloadErrors(err error) []error {
+	if e, ok := err.(interface{ Unwrap() []error }); ok {
+		var result []error
+		for _, err := range e.Unwrap() {
+			result = append(result, flattenConfigReloadErrors(err)...)
+		}
+		return result
+	}
+
+	return []error{err}
+}
+
+// Reload applies the configuration for the changed sections and returns the
+// result per section. It can be used as ConfigWatcherCallback.
+func (r *ConfigReloader) Reload(config *goconf.ConfigFile, changed []string) ConfigReloadResults {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	results := make(ConfigReloadResults, len(changed))
+	for _, section := range changed {
+		results[section] = nil
+	}
+
+	for _, handler := range r.handlers {
+		var affected []string
+		if len(handler.sections) == 0 {
+			affected = slices.DeleteFunc(slices.Clone(changed), func(section string) bool {
+				return section == "logging"
+			})
+		} else {
+			affected = slices.DeleteFunc(slices.Clone(changed), func(section string) bool {
+				return !slices.Contains(handler.sections, section)
+			})
+		}
+		if len(affected) == 0 {
+			continue
+		}
+
+		err := handler.reload(config)
+		if err == nil {
+			continue
+		}
+
+		for _, err := range flattenConfigReloadErrors(err) {
+			var se *ConfigSectionError
+			if errors.As(err, &se) {
+				results[se.Section] = errors.Join(results[se.Section], se.Err)
+				continue
+			}
+
+			for _, section := range affected {
+				results[section] = errors.Join(results[section], err)
+			}
+		}
+	}
+
+	var failed []string
+	for _, section := range slices.Sorted(maps.Keys(results)) {
+		if err := results[section]; err != nil {
+			appLog.Errorf("Could not reload configuration section %s: %s", section, err)
+			failed = append(failed, section)
+		}
+	}
+	if len(failed) == 0 {
+		appLog.Infof("Reloaded configuration sections %s", strings.Join(changed, ", "))
+	}
+
+	r.results = results
+	return results
+}
+
+// Callback can be passed to NewConfigWatcher to reload all registered
+// components.
+func (r *ConfigReloader) Callback(config *goconf.ConfigFile, changed []string) {
+	r.Reload(config, changed)
+}
+
+// Results returns the results of the last reload.
+func (r *ConfigReloader) Results() ConfigReloadResults {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return maps.Clone(r.results)
+}
@@ -0,0 +1,98 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dlintw/goconf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigReloader(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	assert := assert.New(t)
+
+	var called []string
+	reloader := NewConfigReloader()
+	reloader.Register(func(config *goconf.ConfigFile) error {
+		called = append(called, "logging")
+		return errors.New("invalid level")
+	}, "logging")
+	reloader.Register(func(config *goconf.ConfigFile) error {
+		called = append(called, "https")
+		return nil
+	}, "https")
+	reloader.Register(func(config *goconf.ConfigFile) error {
+		called = append(called, "all")
+		return errors.Join(
+			NewConfigSectionError("mcu", errors.New("invalid candidates")),
+			NewConfigSectionError("turn", errors.New("missing secret")),
+		)
+	})
+
+	config := goconf.NewConfigFile()
+	results := reloader.Reload(config, []string{"app", "logging", "mcu"})
+	assert.Equal([]string{"logging", "all"}, called)
+	if assert.Len(results, 4) {
+		assert.NoError(results["app"])
+		assert.ErrorContains(results["logging"], "invalid level")
+		assert.ErrorContains(results["mcu"], "invalid candidates")
+		// Errors of sections that were not changed are also reported.
+		assert.ErrorContains(results["turn"], "missing secret")
+	}
+	assert.Equal(results, reloader.Results())
+
+	// Handlers without sections are not called for logging changes.
+	called = nil
+	results = reloader.Reload(config, []string{"logging"})
+	assert.Equal([]string{"logging"}, called)
+	if assert.Len(results, 1) {
+		assert.Error(results["logging"])
+	}
+
+	called = nil
+	results = reloader.Reload(config, []string{"https"})
+	assert.Equal([]string{"https", "all"}, called)
+	assert.NoError(results["https"])
+}
+
+func TestConfigReloader_GenericError(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	assert := assert.New(t)
+
+	reloader := NewConfigReloader()
+	reloader.Register(func(config *goconf.ConfigFile) error {
+		return errors.New("failed")
+	})
+
+	// Errors without section are reported for all changed sections.
+	results := reloader.Reload(goconf.NewConfigFile(), []string{"backend", "logging", "sessions"})
+	if assert.Len(results, 3) {
+		assert.ErrorContains(results["backend"], "failed")
+		assert.NoError(results["logging"])
+		assert.ErrorContains(results["sessions"], "failed")
+	}
+}
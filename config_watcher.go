@@ -0,0 +1,158 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2017 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/dlintw/goconf"
+)
+
+// ConfigWatcherCallback is called with the reloaded configuration and the
+// sorted names of all sections that were changed.
+type ConfigWatcherCallback func(config *goconf.ConfigFile, changed []string)
+
+// ConfigWatcher reloads a configuration file including all files it includes
+// and notifies about the sections that were changed.
+type ConfigWatcher struct {
+	filename string
+	callback ConfigWatcherCallback
+	watch    bool
+
+	mu       sync.Mutex
+	config   *goconf.ConfigFile
+	watchers map[string]*FileWatcher
+	closed   bool
+}
+
+// NewConfigWatcher creates a watcher for a configuration that was loaded with
+// LoadConfig. If "watch" is true, the configuration is reloaded automatically
+// if the file or any of the included files change.
+func NewConfigWatcher(filename string, config *goconf.ConfigFile, patterns []string, watch bool, callback ConfigWatcherCallback) (*ConfigWatcher, error) {
+	w := &ConfigWatcher{
+		filename: filename,
+		callback: callback,
+		watch:    watch,
+
+		config:   config,
+		watchers: make(map[string]*FileWatcher),
+	}
+
+	if watch {
+		watcher, err := NewFileWatcher(filename, w.fileChanged)
+		if err != nil {
+			return nil, err
+		}
+
+		w.watchers[filename] = watcher
+		w.updateWatchers(patterns)
+	}
+
+	return w, nil
+}
+
+func (w *ConfigWatcher) Close() {
+	w.mu.Lock()
+	watchers := w.watchers
+	w.watchers = nil
+	w.closed = true
+	w.mu.Unlock()
+
+	for _, watcher := range watchers {
+		watcher.Close()
+	}
+}
+
+func (w *ConfigWatcher) updateWatchers(patterns []string) {
+	for pattern, watcher := range w.watchers {
+		if pattern != w.filename && !slices.Contains(patterns, pattern) {
+			watcher.Close()
+			delete(w.watchers, pattern)
+		}
+	}
+
+	for _, pattern := range patterns {
+		if _, found := w.watchers[pattern]; found {
+			continue
+		}
+
+		var watcher *FileWatcher
+		var err error
+		if isConfigPattern(pattern) {
+			watcher, err = NewDirectoryWatcher(pattern, w.fileChanged)
+		} else {
+			watcher, err = NewFileWatcher(pattern, w.fileChanged)
+		}
+		if err != nil {
+			appLog.Errorf("Could not watch included configuration %s: %s", pattern, err)
+			continue
+		}
+
+		w.watchers[pattern] = watcher
+	}
+}
+
+func (w *ConfigWatcher) fileChanged(filename string) {
+	appLog.Infof("Configuration file %s changed, reloading", filename)
+	w.Reload(false) // nolint
+}
+
+// Reload reads the configuration again and runs the callback if any section
+// was changed. If "force" is true, the callback is run with all sections.
+// Returns an error if the configuration could not be read.
+func (w *ConfigWatcher) Reload(force bool) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return nil
+	}
+
+	config, patterns, err := LoadConfig(w.filename)
+	if err != nil {
+		appLog.Errorf("Could not read configuration from %s: %s", w.filename, err)
+		return err
+	}
+
+	if w.watch {
+		w.updateWatchers(patterns)
+	}
+
+	var changed []string
+	if force {
+		changed = append(w.config.GetSections(), config.GetSections()...)
+		slices.Sort(changed)
+		changed = slices.Compact(changed)
+	} else {
+		changed = GetChangedConfigSections(w.config, config)
+	}
+	w.config = config
+	if len(changed) == 0 {
+		appLog.Debugf("No configuration sections changed in %s", w.filename)
+		return nil
+	}
+
+	appLog.Infof("Reloading configuration sections %s", strings.Join(changed, ", "))
+	w.callback(config, changed)
+	return nil
+}
@@ -0,0 +1,153 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2017 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dlintw/goconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigWatcher(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	require.NoError(os.Mkdir(filepath.Join(dir, "conf.d"), 0755))
+	filename := filepath.Join(dir, "server.conf")
+	writeConfigForTest(t, filename, `[app]
+debug = true
+
+[include]
+files = turn.conf conf.d/*.conf
+`)
+	writeConfigForTest(t, filepath.Join(dir, "turn.conf"), `[turn]
+servers = turn:1.2.3.4:9991
+`)
+
+	config, patterns, err := LoadConfig(filename)
+	require.NoError(err)
+
+	type reloadEvent struct {
+		config  *goconf.ConfigFile
+		changed []string
+	}
+	events := make(chan reloadEvent, 10)
+	w, err := NewConfigWatcher(filename, config, patterns, true, func(config *goconf.ConfigFile, changed []string) {
+		events <- reloadEvent{config, changed}
+	})
+	require.NoError(err)
+	defer w.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	writeConfigForTest(t, filepath.Join(dir, "turn.conf"), `[turn]
+servers = turn:5.6.7.8:9991
+`)
+	select {
+	case event := <-events:
+		assert.Equal([]string{"turn"}, event.changed)
+		servers, _ := event.config.GetString("turn", "servers")
+		assert.Equal("turn:5.6.7.8:9991", servers)
+	case <-ctx.Done():
+		require.NoError(ctx.Err())
+	}
+
+	// Files matching an include pattern can be added later.
+	writeConfigForTest(t, filepath.Join(dir, "conf.d", "backends.conf"), `[backend]
+backends = one
+`)
+	select {
+	case event := <-events:
+		assert.Equal([]string{"backend"}, event.changed)
+	case <-ctx.Done():
+		require.NoError(ctx.Err())
+	}
+
+	// Changes that don't modify any options are ignored.
+	writeConfigForTest(t, filename, `[app]
+# Enable debugging.
+debug = true
+
+[include]
+files = turn.conf conf.d/*.conf
+`)
+	ctxTimeout, cancel2 := context.WithTimeout(context.Background(), testWatcherNoEventTimeout)
+	defer cancel2()
+	select {
+	case event := <-events:
+		assert.Fail("should not have received event", "changed %+v", event.changed)
+	case <-ctxTimeout.Done():
+	}
+
+	// A forced reload returns all sections.
+	w.Reload(true)
+	select {
+	case event := <-events:
+		assert.Equal([]string{"app", "backend", "default", "turn"}, event.changed)
+	case <-ctx.Done():
+		require.NoError(ctx.Err())
+	}
+}
+
+func TestConfigWatcher_NoWatch(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "server.conf")
+	writeConfigForTest(t, filename, `[app]
+debug = true
+`)
+
+	config, patterns, err := LoadConfig(filename)
+	require.NoError(err)
+
+	var changed []string
+	w, err := NewConfigWatcher(filename, config, patterns, false, func(config *goconf.ConfigFile, c []string) {
+		changed = c
+	})
+	require.NoError(err)
+	defer w.Close()
+
+	writeConfigForTest(t, filename, `[app]
+debug = false
+`)
+	w.Reload(false)
+	assert.Equal([]string{"app"}, changed)
+
+	// Invalid configurations are not applied.
+	changed = nil
+	writeConfigForTest(t, filename, `debug`)
+	w.Reload(false)
+	assert.Nil(changed)
+}
@@ -0,0 +1,437 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"connectrpc.com/connect"
+	"github.com/gorilla/mux"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	// Number of messages that can be queued for an RpcSignaling client.
+	connectClientMessageQueue = 256
+
+	// Length of the random connection id to send messages.
+	connectClientIdLength = 32
+
+	// Maximum time in seconds browsers may cache the result of preflight
+	// requests.
+	rpcSignalingCorsMaxAge = "7200"
+)
+
+var (
+	errConnectClientClosed = errors.New("client closed")
+
+	// Field names of the generated messages are the keys of the JSON messages.
+	rpcSignalingMarshalOptions = protojson.MarshalOptions{
+		UseProtoNames: true,
+	}
+	rpcSignalingUnmarshalOptions = protojson.UnmarshalOptions{
+		DiscardUnknown: true,
+	}
+
+	rpcSignalingCorsAllowedHeaders = []string{
+		"Content-Type",
+		"Connect-Protocol-Version",
+		"Connect-Timeout-Ms",
+		"Grpc-Timeout",
+		"X-Grpc-Web",
+		"X-User-Agent",
+	}
+	rpcSignalingCorsExposedHeaders = []string{
+		"Grpc-Status",
+		"Grpc-Message",
+		"Grpc-Status-Details-Bin",
+		"Server",
+		"X-Spreed-Signaling-Features",
+	}
+)
+
+// connectClient is a client that uses the signaling protocol through the
+// "RpcSignaling" service. Messages from the server are received through a
+// stream, messages from the client are sent with separate requests, so the
+// service can also be used with gRPC-Web from browsers.
+type connectClient struct {
+	hub   *Hub
+	ctx   context.Context
+	id    string
+	addr  string
+	agent string
+
+	countryOnce sync.Once
+	country     string
+
+	session atomic.Pointer[Session]
+
+	// Messages must be processed in the order they were received.
+	processMu sync.Mutex
+
+	closeCtx  context.Context
+	closeFunc context.CancelFunc
+
+	messages chan WritableClientMessage
+}
+
+func newConnectClient(ctx context.Context, hub *Hub, addr string, agent string) *connectClient {
+	addr = strings.TrimSpace(addr)
+	if addr == "" {
+		addr = "unknown remote address"
+	}
+	agent = strings.TrimSpace(agent)
+	if agent == "" {
+		agent = "unknown user agent"
+	}
+
+	closeCtx, closeFunc := context.WithCancel(context.Background())
+	return &connectClient{
+		hub:   hub,
+		ctx:   ctx,
+		id:    newRandomString(connectClientIdLength),
+		addr:  addr,
+		agent: agent,
+
+		closeCtx:  closeCtx,
+		closeFunc: closeFunc,
+
+		messages: make(chan WritableClientMessage, connectClientMessageQueue),
+	}
+}
+
+func (c *connectClient) Context() context.Context {
+	return c.ctx
+}
+
+func (c *connectClient) RemoteAddr() string {
+	return c.addr
+}
+
+func (c *connectClient) UserAgent() string {
+	return c.agent
+}
+
+func (c *connectClient) Country() string {
+	c.countryOnce.Do(func() {
+		c.country = c.hub.OnLookupCountry(c)
+	})
+	return c.country
+}
+
+func (c *connectClient) IsConnected() bool {
+	return c.closeCtx.Err() == nil
+}
+
+func (c *connectClient) IsAuthenticated() bool {
+	return c.GetSession() != nil
+}
+
+func (c *connectClient) GetSession() Session {
+	session := c.session.Load()
+	if session == nil {
+		return nil
+	}
+
+	return *session
+}
+
+func (c *connectClient) SetSession(session Session) {
+	if session == nil {
+		c.session.Store(nil)
+	} else {
+		c.session.Store(&session)
+	}
+}
+
+func (c *connectClient) SendError(e *Error) bool {
+	message := &ServerMessage{
+		Type:  "error",
+		Error: e,
+	}
+	return c.SendMessage(message)
+}
+
+func (c *connectClient) SendByeResponse(message *ClientMessage) bool {
+	return c.SendByeResponseWithReason(message, "")
+}
+
+func (c *connectClient) SendByeResponseWithReason(message *ClientMessage, reason string) bool {
+	response := &ServerMessage{
+		Type: "bye",
+	}
+	if message != nil {
+		response.Id = message.Id
+	}
+	response.Bye = NewByeServerMessage(reason)
+	return c.SendMessage(response)
+}
+
+func (c *connectClient) SendMessage(message WritableClientMessage) bool {
+	if !c.IsConnected() {
+		return false
+	}
+
+	select {
+	case c.messages <- message:
+		return true
+	default:
+		hubLog.Warnf("Message queue for RpcSignaling client from %s is full, not sending %+v", c.addr, message)
+		return false
+	}
+}
+
+func (c *connectClient) Close() {
+	c.closeFunc()
+}
+
+func (c *connectClient) processMessage(data []byte) error {
+	c.processMu.Lock()
+	defer c.processMu.Unlock()
+
+	if !c.IsConnected() {
+		return errConnectClientClosed
+	}
+
+	c.hub.OnMessageReceived(c, data)
+	return nil
+}
+
+// run sends queued messages to the client until it is closed.
+func (c *connectClient) run(stream *connect.ServerStream[SignalingReceiveResponse]) error {
+	for {
+		select {
+		case <-c.ctx.Done():
+			return nil
+		case <-c.closeCtx.Done():
+			return nil
+		case message := <-c.messages:
+			msg, err := decodeRpcSignalingServerMessage(message)
+			if err != nil {
+				hubLog.Errorf("Could not convert message %+v for RpcSignaling client from %s: %s", message, c.addr, err)
+				continue
+			}
+
+			if msg != nil {
+				if err := stream.Send(&SignalingReceiveResponse{
+					Response: &SignalingReceiveResponse_Message{
+						Message: msg,
+					},
+				}); err != nil {
+					return err
+				}
+			}
+
+			if session := c.GetSession(); message.CloseAfterSend(session) {
+				if session != nil {
+					go session.Close()
+				}
+				return nil
+			}
+		}
+	}
+}
+
+// encodeRpcSignalingClientMessage returns the JSON encoded message for the
+// signaling protocol. The type of the message is the name of the payload field
+// that is set.
+func encodeRpcSignalingClientMessage(message *SignalingClientMessage) ([]byte, error) {
+	m := message.ProtoReflect()
+	payload := m.WhichOneof(m.Descriptor().Oneofs().ByName("payload"))
+	if payload == nil {
+		return nil, errors.New("message payload missing")
+	}
+
+	data, err := rpcSignalingMarshalOptions.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	fields["type"], err = json.Marshal(string(payload.Name()))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// decodeRpcSignalingServerMessage converts a message of the signaling protocol
+// to the generated message. Messages with types that are not supported by the
+// service are skipped and nil is returned.
+func decodeRpcSignalingServerMessage(message WritableClientMessage) (*SignalingServerMessage, error) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+
+	var msg SignalingServerMessage
+	if err := rpcSignalingUnmarshalOptions.Unmarshal(data, &msg); err != nil {
+		return nil, err
+	} else if msg.Payload != nil {
+		return &msg, nil
+	}
+
+	// Some messages (e.g. "bye") don't need to contain their payload.
+	var header struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, err
+	}
+
+	m := msg.ProtoReflect()
+	field := m.Descriptor().Oneofs().ByName("payload").Fields().ByName(protoreflect.Name(header.Type))
+	if field == nil {
+		return nil, nil
+	}
+
+	m.Set(field, m.NewField(field))
+	return &msg, nil
+}
+
+// rpcSignalingService implements the "RpcSignaling" service for the hub.
+type rpcSignalingService struct {
+	hub *Hub
+}
+
+// rpcSignalingRequest returns a request that can be used to get the real ip
+// address of a client.
+func rpcSignalingRequest(request connect.AnyRequest) *http.Request {
+	return &http.Request{
+		RemoteAddr: request.Peer().Addr,
+		Header:     request.Header(),
+	}
+}
+
+func (s *rpcSignalingService) Receive(ctx context.Context, request *connect.Request[SignalingReceiveRequest], stream *connect.ServerStream[SignalingReceiveResponse]) error {
+	h := s.hub
+	addr := h.getRealUserIP(rpcSignalingRequest(request))
+	client := newConnectClient(ctx, h, addr, request.Header().Get("User-Agent"))
+	h.mu.Lock()
+	h.connectClients[client.id] = client
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		delete(h.connectClients, client.id)
+		h.mu.Unlock()
+	}()
+
+	stream.ResponseHeader().Set("Server", "nextcloud-spreed-signaling/"+h.version)
+	stream.ResponseHeader().Set("X-Spreed-Signaling-Features", strings.Join(h.info.Features, ", "))
+
+	// The client needs the id of the connection to send messages.
+	if err := stream.Send(&SignalingReceiveResponse{
+		Response: &SignalingReceiveResponse_Connectionid{
+			Connectionid: client.id,
+		},
+	}); err != nil {
+		hubLog.Errorf("Could not start RpcSignaling stream for %s: %s", addr, err)
+		return err
+	}
+
+	h.processNewClient(client)
+	defer reportPanic(LogSubsystemHub, client.GetSession)
+	err := client.run(stream)
+	if err != nil {
+		if session := client.GetSession(); session != nil {
+			hubLog.Errorf("Error sending to RpcSignaling client %s: %s", session.PublicId(), err)
+		} else {
+			hubLog.Errorf("Error sending to RpcSignaling client from %s: %s", addr, err)
+		}
+	}
+
+	// Make sure no more messages are processed before unregistering.
+	client.Close()
+	client.processMu.Lock()
+	defer client.processMu.Unlock()
+	h.OnClosed(client)
+	return err
+}
+
+func (s *rpcSignalingService) Send(ctx context.Context, request *connect.Request[SignalingSendRequest]) (*connect.Response[SignalingSendResponse], error) {
+	h := s.hub
+	h.mu.RLock()
+	client := h.connectClients[request.Msg.Connectionid]
+	h.mu.RUnlock()
+	if client == nil {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("unknown connection"))
+	}
+
+	if request.Msg.Message == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("message missing"))
+	}
+
+	data, err := encodeRpcSignalingClientMessage(request.Msg.Message)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	if err := client.processMessage(data); err != nil {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+	}
+
+	return connect.NewResponse(&SignalingSendResponse{}), nil
+}
+
+func (h *Hub) registerRpcSignaling(r *mux.Router) {
+	path, handler := NewRpcSignalingHandler(&rpcSignalingService{
+		hub: h,
+	}, connect.WithReadMaxBytes(maxMessageSize))
+	r.PathPrefix(path).Handler(h.serveRpcSignaling(handler))
+}
+
+// serveRpcSignaling checks the origin of requests to the "RpcSignaling" service
+// and adds the headers that browsers need for cross-origin requests.
+func (h *Hub) serveRpcSignaling(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !h.checkOrigin(r) {
+			http.Error(w, "Origin not allowed", http.StatusForbidden)
+			return
+		}
+
+		if origin := r.Header.Get("Origin"); origin != "" {
+			w.Header().Add("Vary", "Origin")
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Expose-Headers", strings.Join(rpcSignalingCorsExposedHeaders, ", "))
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				w.Header().Set("Access-Control-Allow-Methods", "GET, POST")
+				w.Header().Set("Access-Control-Allow-Headers", strings.Join(rpcSignalingCorsAllowedHeaders, ", "))
+				w.Header().Set("Access-Control-Max-Age", rpcSignalingCorsMaxAge)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+
+		handler.ServeHTTP(w, r)
+	})
+}
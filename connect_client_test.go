@@ -0,0 +1,282 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"connectrpc.com/connect"
+	"github.com/dlintw/goconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func getTestConfigWithRpcSignaling(server *httptest.Server) (*goconf.ConfigFile, error) {
+	config, err := getTestConfig(server)
+	if err != nil {
+		return nil, err
+	}
+
+	config.AddOption("app", "rpcsignaling", "true")
+	return config, nil
+}
+
+func TestConnectClient(t *testing.T) {
+	t.Parallel()
+	for name, opts := range map[string][]connect.ClientOption{
+		"connect": nil,
+		"grpcweb": {connect.WithGRPCWeb()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			CatchLogForTest(t)
+			require := require.New(t)
+			assert := assert.New(t)
+
+			hub, _, _, server := CreateHubForTestWithConfig(t, getTestConfigWithRpcSignaling)
+
+			ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+			defer cancel()
+
+			client := NewRpcSignalingClient(server.Client(), server.URL, opts...)
+			stream, err := client.Receive(ctx, connect.NewRequest(&SignalingReceiveRequest{}))
+			require.NoError(err)
+			defer stream.Close()
+
+			receive := func() *SignalingServerMessage {
+				require.True(stream.Receive(), "stream closed: %s", stream.Err())
+				message := stream.Msg().GetMessage()
+				require.NotNil(message, "expected message, got %+v", stream.Msg())
+				return message
+			}
+
+			require.True(stream.Receive(), "stream closed: %s", stream.Err())
+			connectionId := stream.Msg().GetConnectionid()
+			require.NotEmpty(connectionId)
+
+			if welcome := receive().GetWelcome(); assert.NotNil(welcome) {
+				assert.NotEmpty(welcome.Features)
+			}
+
+			params, err := structpb.NewStruct(map[string]any{
+				"userid": testDefaultUserId,
+			})
+			require.NoError(err)
+			_, err = client.Send(ctx, connect.NewRequest(&SignalingSendRequest{
+				Connectionid: connectionId,
+				Message: &SignalingClientMessage{
+					Id: "1234",
+					Payload: &SignalingClientMessage_Hello_{
+						Hello: &SignalingClientMessage_Hello{
+							Version: HelloVersionV1,
+							Auth: &SignalingClientMessage_Hello_Auth{
+								Url:    server.URL,
+								Params: params,
+							},
+						},
+					},
+				},
+			}))
+			require.NoError(err)
+
+			message := receive()
+			assert.Equal("1234", message.Id)
+			if hello := message.GetHello(); assert.NotNil(hello, "expected hello, got %+v", message) {
+				assert.Equal(testDefaultUserId, hello.Userid)
+				assert.NotNil(hub.GetSessionByPublicId(PublicSessionId(hello.Sessionid)))
+			}
+
+			// Messages without payload are rejected.
+			_, err = client.Send(ctx, connect.NewRequest(&SignalingSendRequest{
+				Connectionid: connectionId,
+				Message: &SignalingClientMessage{
+					Id: "4567",
+				},
+			}))
+			assert.Equal(connect.CodeInvalidArgument, connect.CodeOf(err))
+
+			_, err = client.Send(ctx, connect.NewRequest(&SignalingSendRequest{
+				Connectionid: connectionId,
+				Message: &SignalingClientMessage{
+					Id: "9876",
+					Payload: &SignalingClientMessage_Bye_{
+						Bye: &SignalingClientMessage_Bye{},
+					},
+				},
+			}))
+			require.NoError(err)
+
+			message = receive()
+			assert.Equal("9876", message.Id)
+			assert.NotNil(message.GetBye())
+
+			// The server closed the stream.
+			assert.False(stream.Receive())
+			assert.NoError(stream.Err())
+		})
+	}
+}
+
+func TestConnectClientUnknownConnection(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+
+	_, _, _, server := CreateHubForTestWithConfig(t, getTestConfigWithRpcSignaling)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client := NewRpcSignalingClient(server.Client(), server.URL)
+	_, err := client.Send(ctx, connect.NewRequest(&SignalingSendRequest{
+		Connectionid: "unknown",
+		Message: &SignalingClientMessage{
+			Payload: &SignalingClientMessage_Bye_{
+				Bye: &SignalingClientMessage_Bye{},
+			},
+		},
+	}))
+	assert.Equal(t, connect.CodeNotFound, connect.CodeOf(err))
+}
+
+func TestConnectClientOrigin(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+
+	_, _, _, server := CreateHubForTestWithConfig(t, func(server *httptest.Server) (*goconf.ConfigFile, error) {
+		config, err := getTestConfigWithRpcSignaling(server)
+		if err != nil {
+			return nil, err
+		}
+
+		config.AddOption("app", "allowedorigins", "https://cloud.domain.invalid")
+		return config, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodOptions, server.URL+RpcSignalingSendProcedure, nil)
+	require.NoError(err)
+	request.Header.Set("Origin", "https://cloud.domain.invalid")
+	request.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	response, err := server.Client().Do(request)
+	require.NoError(err)
+	defer response.Body.Close()
+	assert.Equal(http.StatusNoContent, response.StatusCode)
+	assert.Equal("https://cloud.domain.invalid", response.Header.Get("Access-Control-Allow-Origin"))
+	assert.Contains(response.Header.Get("Access-Control-Allow-Headers"), "X-Grpc-Web")
+
+	request.Header.Set("Origin", "https://other.domain.invalid")
+	response2, err := server.Client().Do(request)
+	require.NoError(err)
+	defer response2.Body.Close()
+	assert.Equal(http.StatusForbidden, response2.StatusCode)
+}
+
+func TestConnectClientDisabled(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+
+	_, _, _, server := CreateHubForTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client := NewRpcSignalingClient(server.Client(), server.URL)
+	stream, err := client.Receive(ctx, connect.NewRequest(&SignalingReceiveRequest{}))
+	if err == nil {
+		defer stream.Close()
+		assert.False(t, stream.Receive())
+		err = stream.Err()
+	}
+	assert.Error(t, err)
+}
+
+func TestConnectClientMessageConversion(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	assert := assert.New(t)
+
+	data, err := encodeRpcSignalingClientMessage(&SignalingClientMessage{
+		Id: "1234",
+		Payload: &SignalingClientMessage_Message_{
+			Message: &SignalingClientMessage_Message{
+				Recipient: &SignalingRecipient{
+					Type:      "session",
+					Sessionid: "the-session",
+				},
+				Data: structpb.NewStringValue("hello"),
+			},
+		},
+	})
+	require.NoError(err)
+	var message ClientMessage
+	require.NoError(json.Unmarshal(data, &message))
+	require.NoError(message.CheckValid())
+	assert.Equal("1234", message.Id)
+	assert.Equal("message", message.Type)
+	if assert.NotNil(message.Message) {
+		assert.Equal(PublicSessionId("the-session"), message.Message.Recipient.SessionId)
+		assert.JSONEq(`"hello"`, string(message.Message.Data))
+	}
+
+	msg, err := decodeRpcSignalingServerMessage(&ServerMessage{
+		Id:   "9876",
+		Type: "event",
+		Event: &EventServerMessage{
+			Target: "room",
+			Type:   "join",
+			Join: []*EventServerMessageSessionEntry{
+				{
+					SessionId: "the-session",
+					UserId:    "the-user",
+					User:      json.RawMessage(`{"displayname":"Test"}`),
+				},
+			},
+		},
+	})
+	require.NoError(err)
+	require.NotNil(msg)
+	assert.Equal("9876", msg.Id)
+	if event := msg.GetEvent(); assert.NotNil(event) && assert.Len(event.Join, 1) {
+		assert.Equal("join", event.Type)
+		assert.Equal("the-session", event.Join[0].Sessionid)
+		assert.Equal("Test", event.Join[0].User.GetStructValue().GetFields()["displayname"].GetStringValue())
+	}
+
+	// Messages of types that are not supported by the service are skipped.
+	msg, err = decodeRpcSignalingServerMessage(&ServerMessage{
+		Type: "internal",
+		Internal: &InternalServerMessage{
+			Type: "dialout",
+		},
+	})
+	require.NoError(err)
+	assert.Nil(msg)
+}
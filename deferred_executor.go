@@ -22,7 +22,6 @@
 package signaling
 
 import (
-	"log"
 	"reflect"
 	"runtime"
 	"runtime/debug"
@@ -69,8 +68,8 @@ func getFunctionName(i any) string {
 func (e *DeferredExecutor) Execute(f func()) {
 	defer func() {
 		if e := recover(); e != nil {
-			log.Printf("Could not defer function %v: %+v", getFunctionName(f), e)
-			log.Printf("Called from %s", string(debug.Stack()))
+			appLog.Errorf("Could not defer function %v: %+v", getFunctionName(f), e)
+			appLog.Infof("Called from %s", string(debug.Stack()))
 		}
 	}()
 
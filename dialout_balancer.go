@@ -0,0 +1,203 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/dlintw/goconf"
+)
+
+type DialoutBalancing string
+
+const (
+	// Use the internal clients in turns.
+	DialoutBalancingRoundRobin DialoutBalancing = "roundrobin"
+	// Use the internal client with the least number of active calls.
+	DialoutBalancingLeastCalls DialoutBalancing = "leastcalls"
+
+	defaultDialoutBalancing = DialoutBalancingLeastCalls
+)
+
+// DialoutBalancer selects the internal clients (e.g. SIP bridges) to use for
+// dialout requests and keeps track of their active calls.
+type DialoutBalancer struct {
+	mu sync.Mutex
+
+	balancing DialoutBalancing
+	maxCalls  int
+	next      int
+
+	calls map[*ClientSession]map[string]bool
+}
+
+func NewDialoutBalancer(config *goconf.ConfigFile) (*DialoutBalancer, error) {
+	result := &DialoutBalancer{
+		calls: make(map[*ClientSession]map[string]bool),
+	}
+	if err := result.load(config); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (b *DialoutBalancer) load(config *goconf.ConfigFile) error {
+	balancing := defaultDialoutBalancing
+	if value, _ := config.GetString("dialout", "balancing"); value != "" {
+		balancing = DialoutBalancing(strings.ToLower(value))
+		switch balancing {
+		case DialoutBalancingRoundRobin:
+		case DialoutBalancingLeastCalls:
+		default:
+			return fmt.Errorf("unsupported dialout balancing: %s", value)
+		}
+	}
+	maxCalls, _ := config.GetInt("dialout", "maxcalls")
+	if maxCalls < 0 {
+		maxCalls = 0
+	}
+
+	if maxCalls > 0 {
+		hubLog.Infof("Using %s balancing for dialout with at most %d calls per client", balancing, maxCalls)
+	} else {
+		hubLog.Infof("Using %s balancing for dialout", balancing)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.balancing = balancing
+	b.maxCalls = maxCalls
+	return nil
+}
+
+func (b *DialoutBalancer) Reload(config *goconf.ConfigFile) {
+	if err := b.load(config); err != nil {
+		hubLog.Errorf("Error reloading dialout balancing: %s", err)
+	}
+}
+
+// MaxCalls returns the maximum number of concurrent calls per client or 0 if
+// the calls are not limited.
+func (b *DialoutBalancer) MaxCalls() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.maxCalls
+}
+
+// Select returns the sessions in the order they should be tried for a new
+// dialout request. Sessions that reached the maximum number of calls are
+// omitted.
+func (b *DialoutBalancer) Select(sessions []*ClientSession) []*ClientSession {
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	result := make([]*ClientSession, 0, len(sessions))
+	for _, session := range sessions {
+		if b.maxCalls > 0 && len(b.calls[session]) >= b.maxCalls {
+			continue
+		}
+
+		result = append(result, session)
+	}
+	if len(result) == 0 {
+		return nil
+	}
+
+	// Start with a stable order that is rotated for every request, so clients
+	// with the same number of calls are also used in turns.
+	slices.SortFunc(result, func(a, c *ClientSession) int {
+		return strings.Compare(string(a.PublicId()), string(c.PublicId()))
+	})
+	start := b.next % len(result)
+	b.next++
+	result = append(result[start:], result[:start]...)
+
+	if b.balancing == DialoutBalancingLeastCalls {
+		slices.SortStableFunc(result, func(a, c *ClientSession) int {
+			return len(b.calls[a]) - len(b.calls[c])
+		})
+	}
+	return result
+}
+
+// AddCall marks the call as active for the session.
+func (b *DialoutBalancer) AddCall(session *ClientSession, callId string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	calls, found := b.calls[session]
+	if !found {
+		calls = make(map[string]bool)
+		b.calls[session] = calls
+	} else if calls[callId] {
+		return
+	}
+
+	calls[callId] = true
+	statsHubDialoutCallsCurrent.WithLabelValues(statsBackendLabel(session.Backend())).Inc()
+}
+
+// RemoveCall marks the call of the session as ended.
+func (b *DialoutBalancer) RemoveCall(session *ClientSession, callId string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	calls := b.calls[session]
+	if !calls[callId] {
+		return
+	}
+
+	delete(calls, callId)
+	if len(calls) == 0 {
+		delete(b.calls, session)
+	}
+	statsHubDialoutCallsCurrent.WithLabelValues(statsBackendLabel(session.Backend())).Dec()
+}
+
+// RemoveSession removes all active calls of the session.
+func (b *DialoutBalancer) RemoveSession(session *ClientSession) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	calls, found := b.calls[session]
+	if !found {
+		return
+	}
+
+	delete(b.calls, session)
+	statsHubDialoutCallsCurrent.WithLabelValues(statsBackendLabel(session.Backend())).Sub(float64(len(calls)))
+}
+
+// ActiveCalls returns the number of active calls of the session.
+func (b *DialoutBalancer) ActiveCalls(session *ClientSession) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return len(b.calls[session])
+}
@@ -0,0 +1,141 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"testing"
+
+	"github.com/dlintw/goconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDialoutBalancerForTest(t *testing.T, balancing string, maxCalls string) *DialoutBalancer {
+	config := goconf.NewConfigFile()
+	if balancing != "" {
+		config.AddOption("dialout", "balancing", balancing)
+	}
+	if maxCalls != "" {
+		config.AddOption("dialout", "maxcalls", maxCalls)
+	}
+	balancer, err := NewDialoutBalancer(config)
+	require.NoError(t, err)
+	return balancer
+}
+
+func TestDialoutBalancer_Invalid(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	config := goconf.NewConfigFile()
+	config.AddOption("dialout", "balancing", "random")
+	_, err := NewDialoutBalancer(config)
+	assert.Error(t, err)
+}
+
+func TestDialoutBalancer_RoundRobin(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	balancer := newDialoutBalancerForTest(t, "roundrobin", "")
+
+	assert.Empty(balancer.Select(nil))
+
+	session1 := &ClientSession{
+		publicId: "session1",
+	}
+	session2 := &ClientSession{
+		publicId: "session2",
+	}
+	sessions := []*ClientSession{session2, session1}
+
+	assert.Equal([]*ClientSession{session1, session2}, balancer.Select(sessions))
+	assert.Equal([]*ClientSession{session2, session1}, balancer.Select(sessions))
+	// Active calls are ignored for round-robin balancing.
+	balancer.AddCall(session1, "call1")
+	balancer.AddCall(session1, "call2")
+	assert.Equal([]*ClientSession{session1, session2}, balancer.Select(sessions))
+	assert.Equal([]*ClientSession{session2, session1}, balancer.Select(sessions))
+}
+
+func TestDialoutBalancer_LeastCalls(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	balancer := newDialoutBalancerForTest(t, "", "")
+
+	session1 := &ClientSession{
+		publicId: "session1",
+	}
+	session2 := &ClientSession{
+		publicId: "session2",
+	}
+	sessions := []*ClientSession{session1, session2}
+
+	// Clients with the same number of calls are used in turns.
+	assert.Equal([]*ClientSession{session1, session2}, balancer.Select(sessions))
+	assert.Equal([]*ClientSession{session2, session1}, balancer.Select(sessions))
+
+	balancer.AddCall(session1, "call1")
+	assert.Equal(1, balancer.ActiveCalls(session1))
+	assert.Equal([]*ClientSession{session2, session1}, balancer.Select(sessions))
+	assert.Equal([]*ClientSession{session2, session1}, balancer.Select(sessions))
+
+	balancer.AddCall(session2, "call2")
+	balancer.AddCall(session2, "call3")
+	// Adding the same call again is ignored.
+	balancer.AddCall(session2, "call3")
+	assert.Equal(2, balancer.ActiveCalls(session2))
+	assert.Equal([]*ClientSession{session1, session2}, balancer.Select(sessions))
+
+	balancer.RemoveCall(session2, "call2")
+	balancer.RemoveCall(session2, "call3")
+	assert.Equal(0, balancer.ActiveCalls(session2))
+	assert.Equal([]*ClientSession{session2, session1}, balancer.Select(sessions))
+
+	balancer.RemoveSession(session1)
+	assert.Equal(0, balancer.ActiveCalls(session1))
+}
+
+func TestDialoutBalancer_MaxCalls(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	balancer := newDialoutBalancerForTest(t, "leastcalls", "1")
+	assert.Equal(1, balancer.MaxCalls())
+
+	session1 := &ClientSession{
+		publicId: "session1",
+	}
+	session2 := &ClientSession{
+		publicId: "session2",
+	}
+	sessions := []*ClientSession{session1, session2}
+
+	balancer.AddCall(session1, "call1")
+	assert.Equal([]*ClientSession{session2}, balancer.Select(sessions))
+
+	balancer.AddCall(session2, "call2")
+	assert.Empty(balancer.Select(sessions))
+
+	balancer.RemoveCall(session1, "call1")
+	assert.Equal([]*ClientSession{session1}, balancer.Select(sessions))
+}
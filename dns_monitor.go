@@ -23,7 +23,6 @@ package signaling
 
 import (
 	"context"
-	"log"
 	"net"
 	"net/url"
 	"slices"
@@ -338,7 +337,7 @@ func (m *DnsMonitor) checkHostname(entry *dnsMonitorEntry) {
 
 	ips, err := lookupDnsMonitorIP(entry.hostname)
 	if err != nil {
-		log.Printf("Could not lookup %s: %s", entry.hostname, err)
+		appLog.Errorf("Could not lookup %s: %s", entry.hostname, err)
 		return
 	}
 
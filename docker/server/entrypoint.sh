@@ -77,6 +77,10 @@ if [ ! -f "$CONFIG" ]; then
   else
     sed -i "s|#url = nats://localhost:4222|url = nats://loopback|" "$CONFIG"
   fi
+  if [ -n "$REDIS_URL" ]; then
+    sed -i "s|#type = nats|type = redis|" "$CONFIG"
+    sed -i "s|#url = redis://localhost:6379|url = $REDIS_URL|" "$CONFIG"
+  fi
   if [ -n "$FEDERATION_TIMEOUT" ]; then
     sed -i "/federation/,/federation/ s|#timeout =.*|timeout = $FEDERATION_TIMEOUT|" "$CONFIG"
   fi
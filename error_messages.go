@@ -0,0 +1,117 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+var (
+	// Translations of error messages, the key is the English message.
+	errorTranslations = map[language.Tag]map[string]string{
+		language.German: {
+			"Expected Hello request.":                                         "Hello-Anfrage erwartet.",
+			"The hello version is not supported.":                             "Die Hello-Version wird nicht unterstützt.",
+			"The user could not be authenticated.":                            "Der Benutzer konnte nicht authentifiziert werden.",
+			"Could not join the room.":                                        "Der Raum konnte nicht betreten werden.",
+			"The client type is not supported.":                               "Der Client-Typ wird nicht unterstützt.",
+			"The backend URL is not supported.":                               "Die Backend-URL wird nicht unterstützt.",
+			"The passed token is invalid.":                                    "Das übergebene Token ist ungültig.",
+			"The session to resume does not exist.":                           "Die fortzusetzende Sitzung existiert nicht.",
+			"The token is not valid yet.":                                     "Das Token ist noch nicht gültig.",
+			"The token is expired.":                                           "Das Token ist abgelaufen.",
+			"Too many requests.":                                              "Zu viele Anfragen.",
+			"Too many secondary rooms joined ({max}).":                        "Zu viele zusätzliche Räume betreten ({max}).",
+			"The secondary room was not joined.":                              "Der zusätzliche Raum wurde nicht betreten.",
+			"Too many requests for this backend, please try again later.":     "Zu viele Anfragen für dieses Backend, bitte später erneut versuchen.",
+			"Too many sessions connected for this backend ({count}/{limit}).": "Zu viele Sitzungen für dieses Backend verbunden ({count}/{limit}).",
+			"The feature is disabled for this backend.":                       "Die Funktion ist für dieses Backend deaktiviert.",
+			"No room joined yet.":                                             "Noch kein Raum betreten.",
+			"Already joined this room.":                                       "Der Raum wurde bereits betreten.",
+			"The server is scheduled to shutdown.":                            "Der Server wird in Kürze heruntergefahren.",
+			"No breakout rooms have been started for the room.":               "Für den Raum wurden keine Breakout-Räume gestartet.",
+		},
+	}
+
+	errorLanguageMatcher = language.NewMatcher(getErrorLanguages())
+)
+
+func getErrorLanguages() []language.Tag {
+	// The first language is used if no other language matches.
+	result := []language.Tag{language.English}
+	for tag := range errorTranslations {
+		result = append(result, tag)
+	}
+	return result
+}
+
+// GetErrorLanguage returns the language to use for error messages from a list
+// of preferred languages in the format of the "Accept-Language" HTTP header.
+// Returns "language.Und" if no translations are available.
+func GetErrorLanguage(accept string) language.Tag {
+	tags, _, err := language.ParseAcceptLanguage(accept)
+	if err != nil || len(tags) == 0 {
+		return language.Und
+	}
+
+	tag, idx, confidence := errorLanguageMatcher.Match(tags...)
+	if idx == 0 || confidence == language.No {
+		return language.Und
+	}
+
+	base, _ := tag.Base()
+	return language.Make(base.String())
+}
+
+// formatErrorMessage replaces placeholders like "{name}" in the message with
+// the value of the corresponding parameter.
+func formatErrorMessage(message string, params StringMap) string {
+	if len(params) == 0 {
+		return message
+	}
+
+	replacements := make([]string, 0, len(params)*2)
+	for key, value := range params {
+		replacements = append(replacements, "{"+key+"}", fmt.Sprint(value))
+	}
+	return strings.NewReplacer(replacements...).Replace(message)
+}
+
+// Localize returns a copy of the error with the localized message for the
+// given language. The error is returned unmodified if no translation exists.
+func (e *Error) Localize(tag language.Tag) *Error {
+	if e == nil || tag == language.Und {
+		return e
+	}
+
+	translated, found := errorTranslations[tag][e.template]
+	if !found {
+		return e
+	}
+
+	result := *e
+	result.Localized = formatErrorMessage(translated, e.Params)
+	return &result
+}
@@ -0,0 +1,98 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+func TestGetErrorLanguage(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	assert.Equal(language.German, GetErrorLanguage("de"))
+	assert.Equal(language.German, GetErrorLanguage("de-AT"))
+	assert.Equal(language.German, GetErrorLanguage("fr-FR, de;q=0.9, en;q=0.8"))
+	assert.Equal(language.Und, GetErrorLanguage("en-US, de;q=0.5"))
+	assert.Equal(language.Und, GetErrorLanguage("fr"))
+	assert.Equal(language.Und, GetErrorLanguage("invalid language;q=foo"))
+	assert.Equal(language.Und, GetErrorLanguage(""))
+}
+
+func TestErrorWithParams(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+
+	e := NewSessionLimitExceeded(50, 50)
+	assert.Equal("session_limit_exceeded", e.Code)
+	assert.Equal("Too many sessions connected for this backend (50/50).", e.Message)
+	assert.Empty(e.Localized)
+
+	localized := e.Localize(language.German)
+	assert.NotSame(e, localized)
+	assert.Empty(e.Localized)
+	assert.Equal(e.Message, localized.Message)
+	assert.Equal("Zu viele Sitzungen für dieses Backend verbunden (50/50).", localized.Localized)
+
+	data, err := json.Marshal(localized)
+	require.NoError(err)
+	var decoded Error
+	require.NoError(json.Unmarshal(data, &decoded))
+	assert.Equal(localized.Code, decoded.Code)
+	assert.Equal(localized.Message, decoded.Message)
+	assert.Equal(localized.Localized, decoded.Localized)
+	assert.Equal(StringMap{
+		"count": float64(50),
+		"limit": float64(50),
+	}, decoded.Params)
+
+	// Errors without translation are not modified.
+	e = NewError("unknown_error", "Some unknown error.")
+	assert.Same(e, e.Localize(language.German))
+	assert.Same(TooManyRequests, TooManyRequests.Localize(language.Und))
+}
+
+func TestErrorLocalizedHello(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	message := &ClientMessage{
+		Id:   "1234",
+		Type: "hello",
+		Hello: &HelloClientMessage{
+			Version:  HelloVersionV1,
+			Language: "de-DE, en;q=0.5",
+		},
+	}
+	response := message.NewErrorServerMessage(InvalidToken)
+	if assert.NotNil(response.Error) {
+		assert.Equal(InvalidToken.Message, response.Error.Message)
+		assert.Equal("Das übergebene Token ist ungültig.", response.Error.Localized)
+	}
+	assert.Empty(InvalidToken.Localized)
+}
@@ -0,0 +1,176 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/dlintw/goconf"
+	"github.com/getsentry/sentry-go"
+)
+
+const (
+	// Time to wait for pending reports to be sent before the process exits
+	// after a panic or fatal error.
+	errorReportingFlushTimeout = 2 * time.Second
+)
+
+var (
+	errorReporting atomic.Pointer[ErrorReporting]
+)
+
+// ErrorReporting sends errors and panics to a Sentry compatible service.
+type ErrorReporting struct {
+	hub *sentry.Hub
+}
+
+// NewErrorReporting creates the error reporting from the "[sentry]" section
+// of the configuration. Returns nil if no DSN is configured.
+func NewErrorReporting(config *goconf.ConfigFile, version string) (*ErrorReporting, error) {
+	dsn, _ := GetStringOptionWithEnv(config, "sentry", "dsn")
+	if dsn == "" {
+		appLog.Infof("Error reporting is disabled")
+		return nil, nil
+	}
+
+	environment, _ := config.GetString("sentry", "environment")
+	sampleRate := 1.0
+	if value, _ := config.GetString("sentry", "samplerate"); value != "" {
+		var err error
+		if sampleRate, err = strconv.ParseFloat(value, 64); err != nil {
+			return nil, fmt.Errorf("invalid sample rate %s: %w", value, err)
+		} else if sampleRate < 0 || sampleRate > 1 {
+			return nil, fmt.Errorf("sample rate must be between 0 and 1, got %f", sampleRate)
+		}
+	}
+
+	result, err := newErrorReporting(sentry.ClientOptions{
+		Dsn:         dsn,
+		Environment: environment,
+		Release:     "nextcloud-spreed-signaling@" + version,
+		SampleRate:  sampleRate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if environment != "" {
+		appLog.Infof("Error reporting is enabled (environment %s)", environment)
+	} else {
+		appLog.Infof("Error reporting is enabled")
+	}
+	return result, nil
+}
+
+func newErrorReporting(options sentry.ClientOptions) (*ErrorReporting, error) {
+	client, err := sentry.NewClient(options)
+	if err != nil {
+		return nil, fmt.Errorf("could not create error reporting client: %w", err)
+	}
+
+	result := &ErrorReporting{
+		hub: sentry.NewHub(client, sentry.NewScope()),
+	}
+	errorReporting.Store(result)
+	return result, nil
+}
+
+// Close sends any pending reports and disables the error reporting.
+func (e *ErrorReporting) Close(timeout time.Duration) {
+	if e == nil {
+		return
+	}
+
+	errorReporting.CompareAndSwap(e, nil)
+	e.hub.Flush(timeout)
+}
+
+// getSessionIdHash returns a hash of the session id that can be used to
+// correlate reports without sending the actual session id.
+func getSessionIdHash(sessionId PublicSessionId) string {
+	hash := sha256.Sum256([]byte(sessionId))
+	return hex.EncodeToString(hash[:8])
+}
+
+func configureErrorReportingScope(scope *sentry.Scope, subsystem LogSubsystem, session Session) {
+	scope.SetTag("subsystem", string(subsystem))
+	if session == nil {
+		return
+	}
+
+	scope.SetTag("session", getSessionIdHash(session.PublicId()))
+	scope.SetTag("clienttype", string(session.ClientType()))
+	if backend := session.Backend(); backend != nil {
+		scope.SetTag("backend", backend.Id())
+	}
+}
+
+// reportError sends an error message that was logged by the given subsystem.
+func reportError(subsystem LogSubsystem, message string) {
+	e := errorReporting.Load()
+	if e == nil {
+		return
+	}
+
+	hub := e.hub.Clone()
+	hub.ConfigureScope(func(scope *sentry.Scope) {
+		configureErrorReportingScope(scope, subsystem, nil)
+		scope.SetLevel(sentry.LevelError)
+	})
+	hub.CaptureMessage(message)
+}
+
+// flushErrorReporting waits for pending reports to be sent.
+func flushErrorReporting() {
+	if e := errorReporting.Load(); e != nil {
+		e.hub.Flush(errorReportingFlushTimeout)
+	}
+}
+
+// reportPanic must be deferred by the caller. It sends a recovered panic with
+// the context of the session (if any) and continues panicking afterwards.
+func reportPanic(subsystem LogSubsystem, getSession func() Session) {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+
+	if e := errorReporting.Load(); e != nil {
+		var session Session
+		if getSession != nil {
+			session = getSession()
+		}
+
+		hub := e.hub.Clone()
+		hub.ConfigureScope(func(scope *sentry.Scope) {
+			configureErrorReportingScope(scope, subsystem, session)
+		})
+		hub.Recover(recovered)
+		hub.Flush(errorReportingFlushTimeout)
+	}
+	panic(recovered)
+}
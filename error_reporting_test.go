@@ -0,0 +1,130 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dlintw/goconf"
+	"github.com/getsentry/sentry-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testErrorReportingTransport struct {
+	mu     sync.Mutex
+	events []*sentry.Event
+}
+
+func (t *testErrorReportingTransport) Configure(options sentry.ClientOptions) {
+}
+
+func (t *testErrorReportingTransport) SendEvent(event *sentry.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events = append(t.events, event)
+}
+
+func (t *testErrorReportingTransport) Flush(timeout time.Duration) bool {
+	return true
+}
+
+func (t *testErrorReportingTransport) FlushWithContext(ctx context.Context) bool {
+	return true
+}
+
+func (t *testErrorReportingTransport) Close() {
+}
+
+func (t *testErrorReportingTransport) Events() []*sentry.Event {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.events
+}
+
+func newErrorReportingForTest(t *testing.T) *testErrorReportingTransport {
+	transport := &testErrorReportingTransport{}
+	reporting, err := newErrorReporting(sentry.ClientOptions{
+		Transport: transport,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		reporting.Close(time.Second)
+	})
+	return transport
+}
+
+func TestErrorReporting_Disabled(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	reporting, err := NewErrorReporting(goconf.NewConfigFile(), "1.0.0")
+	assert.NoError(t, err)
+	assert.Nil(t, reporting)
+	// Closing the disabled error reporting is allowed.
+	reporting.Close(time.Second)
+
+	config := goconf.NewConfigFile()
+	config.AddOption("sentry", "dsn", "https://public@sentry.domain.invalid/1")
+	config.AddOption("sentry", "samplerate", "2")
+	_, err = NewErrorReporting(config, "1.0.0")
+	assert.Error(t, err)
+}
+
+func TestErrorReporting_Errors(t *testing.T) {
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	transport := newErrorReportingForTest(t)
+
+	mcuLog.Warnf("Not reported")
+	mcuLog.Errorf("Something failed: %s", "the-error")
+	if events := transport.Events(); assert.Len(events, 1) {
+		assert.Equal("Something failed: the-error", events[0].Message)
+		assert.Equal(sentry.LevelError, events[0].Level)
+		assert.Equal("mcu", events[0].Tags["subsystem"])
+	}
+}
+
+func TestErrorReporting_Panic(t *testing.T) {
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	transport := newErrorReportingForTest(t)
+
+	session := &DummySession{
+		publicId: "the-session-id",
+	}
+	assert.PanicsWithValue("the-panic", func() {
+		defer reportPanic(LogSubsystemHub, func() Session {
+			return session
+		})
+
+		panic("the-panic")
+	})
+	if events := transport.Events(); assert.Len(events, 1) {
+		assert.Equal(sentry.LevelFatal, events[0].Level)
+		assert.Equal("hub", events[0].Tags["subsystem"])
+		assert.Equal(getSessionIdHash(session.publicId), events[0].Tags["session"])
+		assert.NotContains(events[0].Tags, "backend")
+	}
+}
@@ -25,7 +25,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
 	"slices"
 	"sync"
 	"sync/atomic"
@@ -93,7 +92,7 @@ func (c *EtcdClient) getConfigStringWithFallback(config *goconf.ConfigFile, opti
 	if value == "" && c.compatSection != "" {
 		value, _ = config.GetString(c.compatSection, option)
 		if value != "" {
-			log.Printf("WARNING: Configuring etcd option \"%s\" in section \"%s\" is deprecated, use section \"etcd\" instead", option, c.compatSection)
+			appLog.Warnf("Configuring etcd option \"%s\" in section \"%s\" is deprecated, use section \"etcd\" instead", option, c.compatSection)
 		}
 	}
 
@@ -121,7 +120,7 @@ func (c *EtcdClient) load(config *goconf.ConfigFile, ignoreErrors bool) error {
 			return nil
 		}
 
-		log.Printf("No etcd endpoints configured, not changing client")
+		appLog.Infof("No etcd endpoints configured, not changing client")
 	} else {
 		cfg := clientv3.Config{
 			Endpoints: endpoints,
@@ -156,7 +155,7 @@ func (c *EtcdClient) load(config *goconf.ConfigFile, ignoreErrors bool) error {
 					return fmt.Errorf("could not setup etcd TLS configuration: %w", err)
 				}
 
-				log.Printf("Could not setup TLS configuration, will be disabled (%s)", err)
+				appLog.Errorf("Could not setup TLS configuration, will be disabled (%s)", err)
 			} else {
 				cfg.TLS = tlsConfig
 			}
@@ -168,14 +167,14 @@ func (c *EtcdClient) load(config *goconf.ConfigFile, ignoreErrors bool) error {
 				return err
 			}
 
-			log.Printf("Could not create new client from etd endpoints %+v: %s", endpoints, err)
+			appLog.Errorf("Could not create new client from etd endpoints %+v: %s", endpoints, err)
 		} else {
 			prev := c.getEtcdClient()
 			if prev != nil {
 				prev.Close()
 			}
 			c.client.Store(client)
-			log.Printf("Using etcd endpoints %+v", endpoints)
+			appLog.Infof("Using etcd endpoints %+v", endpoints)
 			c.notifyListeners()
 		}
 	}
@@ -256,16 +255,16 @@ func (c *EtcdClient) WaitForConnection(ctx context.Context) error {
 			if errors.Is(err, context.Canceled) {
 				return err
 			} else if errors.Is(err, context.DeadlineExceeded) {
-				log.Printf("Timeout waiting for etcd client to connect to the cluster, retry in %s", backoff.NextWait())
+				appLog.Warnf("Timeout waiting for etcd client to connect to the cluster, retry in %s", backoff.NextWait())
 			} else {
-				log.Printf("Could not sync etcd client with the cluster, retry in %s: %s", backoff.NextWait(), err)
+				appLog.Errorf("Could not sync etcd client with the cluster, retry in %s: %s", backoff.NextWait(), err)
 			}
 
 			backoff.Wait(ctx)
 			continue
 		}
 
-		log.Printf("Client synced, using endpoints %+v", c.getEtcdClient().Endpoints())
+		appLog.Infof("Client synced, using endpoints %+v", c.getEtcdClient().Endpoints())
 		return nil
 	}
 }
@@ -274,11 +273,31 @@ func (c *EtcdClient) Get(ctx context.Context, key string, opts ...clientv3.OpOpt
 	return c.getEtcdClient().Get(ctx, key, opts...)
 }
 
+func (c *EtcdClient) Put(ctx context.Context, key string, value string, opts ...clientv3.OpOption) (*clientv3.PutResponse, error) {
+	return c.getEtcdClient().Put(ctx, key, value, opts...)
+}
+
+func (c *EtcdClient) Delete(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.DeleteResponse, error) {
+	return c.getEtcdClient().Delete(ctx, key, opts...)
+}
+
+func (c *EtcdClient) Grant(ctx context.Context, ttl int64) (*clientv3.LeaseGrantResponse, error) {
+	return c.getEtcdClient().Grant(ctx, ttl)
+}
+
+func (c *EtcdClient) KeepAlive(ctx context.Context, id clientv3.LeaseID) (<-chan *clientv3.LeaseKeepAliveResponse, error) {
+	return c.getEtcdClient().KeepAlive(ctx, id)
+}
+
+func (c *EtcdClient) Revoke(ctx context.Context, id clientv3.LeaseID) (*clientv3.LeaseRevokeResponse, error) {
+	return c.getEtcdClient().Revoke(ctx, id)
+}
+
 func (c *EtcdClient) Watch(ctx context.Context, key string, nextRevision int64, watcher EtcdClientWatcher, opts ...clientv3.OpOption) (int64, error) {
-	log.Printf("Wait for leader and start watching on %s (rev=%d)", key, nextRevision)
+	appLog.Infof("Wait for leader and start watching on %s (rev=%d)", key, nextRevision)
 	opts = append(opts, clientv3.WithRev(nextRevision), clientv3.WithPrevKV())
 	ch := c.getEtcdClient().Watch(clientv3.WithRequireLeader(ctx), key, opts...)
-	log.Printf("Watch created for %s", key)
+	appLog.Infof("Watch created for %s", key)
 	watcher.EtcdWatchCreated(c, key)
 	for response := range ch {
 		if err := response.Err(); err != nil {
@@ -301,7 +320,7 @@ func (c *EtcdClient) Watch(ctx context.Context, key string, nextRevision int64,
 				}
 				watcher.EtcdKeyDeleted(c, string(ev.Kv.Key), prevValue)
 			default:
-				log.Printf("Unsupported watch event %s %q -> %q", ev.Type, ev.Kv.Key, ev.Kv.Value)
+				appLog.Warnf("Unsupported watch event %s %q -> %q", ev.Type, ev.Kv.Key, ev.Kv.Value)
 			}
 		}
 	}
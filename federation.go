@@ -27,7 +27,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"net"
 	"strconv"
 	"strings"
@@ -178,7 +177,7 @@ func (c *FederationClient) CanReuse(federation *RoomFederationMessage) bool {
 }
 
 func (c *FederationClient) connect(ctx context.Context) error {
-	log.Printf("Creating federation connection to %s for %s", c.URL(), c.session.PublicId())
+	hubLog.Infof("Creating federation connection to %s for %s", c.URL(), c.session.PublicId())
 	conn, response, err := c.dialer.DialContext(ctx, c.url, nil)
 	if err != nil {
 		return err
@@ -195,13 +194,13 @@ func (c *FederationClient) connect(ctx context.Context) error {
 	}
 	if !supportsFederation {
 		if err := conn.Close(); err != nil {
-			log.Printf("Error closing federation connection to %s: %s", c.URL(), err)
+			hubLog.Errorf("Error closing federation connection to %s: %s", c.URL(), err)
 		}
 
 		return ErrFederationNotSupported
 	}
 
-	log.Printf("Federation connection established to %s for %s", c.URL(), c.session.PublicId())
+	hubLog.Infof("Federation connection established to %s for %s", c.URL(), c.session.PublicId())
 
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -273,18 +272,18 @@ func (c *FederationClient) closeConnection(withBye bool) {
 		if err := c.sendMessageLocked(&ClientMessage{
 			Type: "bye",
 		}); err != nil && !isClosedError(err) {
-			log.Printf("Error sending bye on federation connection to %s: %s", c.URL(), err)
+			hubLog.Errorf("Error sending bye on federation connection to %s: %s", c.URL(), err)
 		}
 	}
 
 	closeMessage := websocket.FormatCloseMessage(websocket.CloseNormalClosure, "")
 	deadline := time.Now().Add(writeWait)
 	if err := c.conn.WriteControl(websocket.CloseMessage, closeMessage, deadline); err != nil && !isClosedError(err) {
-		log.Printf("Error sending close message on federation connection to %s: %s", c.URL(), err)
+		hubLog.Errorf("Error sending close message on federation connection to %s: %s", c.URL(), err)
 	}
 
 	if err := c.conn.Close(); err != nil && !isClosedError(err) {
-		log.Printf("Error closing federation connection to %s: %s", c.URL(), err)
+		hubLog.Errorf("Error closing federation connection to %s: %s", c.URL(), err)
 	}
 
 	c.conn = nil
@@ -335,7 +334,7 @@ func (c *FederationClient) reconnect() {
 	defer cancel()
 
 	if err := c.connect(ctx); err != nil {
-		log.Printf("Error connecting to federation server %s for %s: %s", c.URL(), c.session.PublicId(), err)
+		hubLog.Errorf("Error connecting to federation server %s for %s: %s", c.URL(), c.session.PublicId(), err)
 		c.scheduleReconnect()
 		return
 	}
@@ -359,7 +358,7 @@ func (c *FederationClient) readPump(conn *websocket.Conn) {
 			}
 
 			if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseNoStatusReceived) {
-				log.Printf("Error reading from %s for %s: %s", c.URL(), c.session.PublicId(), err)
+				hubLog.Errorf("Error reading from %s for %s: %s", c.URL(), c.session.PublicId(), err)
 			}
 
 			c.scheduleReconnect()
@@ -372,7 +371,7 @@ func (c *FederationClient) readPump(conn *websocket.Conn) {
 
 		var msg ServerMessage
 		if err := json.Unmarshal(data, &msg); err != nil {
-			log.Printf("Error unmarshalling %s from %s: %s", string(data), c.URL(), err)
+			hubLog.Errorf("Error unmarshalling %s from %s: %s", string(data), c.URL(), err)
 			continue
 		}
 
@@ -401,7 +400,7 @@ func (c *FederationClient) sendPing() {
 	msg := strconv.FormatInt(now, 10)
 	c.conn.SetWriteDeadline(time.Now().Add(writeWait)) // nolint
 	if err := c.conn.WriteMessage(websocket.PingMessage, []byte(msg)); err != nil {
-		log.Printf("Could not send ping to federated client %s for %s: %v", c.URL(), c.session.PublicId(), err)
+		hubLog.Errorf("Could not send ping to federated client %s for %s: %v", c.URL(), c.session.PublicId(), err)
 		c.scheduleReconnectLocked()
 	}
 }
@@ -485,7 +484,7 @@ func (c *FederationClient) processWelcome(msg *ServerMessage) {
 		Token: c.federation.Load().Token,
 	}
 	if err := c.sendHello(federationParams); err != nil {
-		log.Printf("Error sending hello message to %s for %s: %s", c.URL(), c.session.PublicId(), err)
+		hubLog.Errorf("Error sending hello message to %s for %s: %s", c.URL(), c.session.PublicId(), err)
 		c.closeWithError(err)
 	}
 }
@@ -497,7 +496,7 @@ func (c *FederationClient) processHello(msg *ServerMessage) {
 	defer c.helloMu.Unlock()
 
 	if msg.Id != c.helloMsgId {
-		log.Printf("Received hello response %+v for unknown request, expected %s", msg, c.helloMsgId)
+		hubLog.Infof("Received hello response %+v for unknown request, expected %s", msg, c.helloMsgId)
 		if err := c.sendHelloLocked(c.helloAuth); err != nil {
 			c.closeWithError(err)
 		}
@@ -516,12 +515,12 @@ func (c *FederationClient) processHello(msg *ServerMessage) {
 				c.closeWithError(err)
 			}
 		default:
-			log.Printf("Received hello error from federated client for %s to %s: %+v", c.session.PublicId(), c.URL(), msg)
+			hubLog.Infof("Received hello error from federated client for %s to %s: %+v", c.session.PublicId(), c.URL(), msg)
 			c.closeWithError(msg.Error)
 		}
 		return
 	} else if msg.Type != "hello" {
-		log.Printf("Received unknown hello response from federated client for %s to %s: %+v", c.session.PublicId(), c.URL(), msg)
+		hubLog.Infof("Received unknown hello response from federated client for %s to %s: %+v", c.session.PublicId(), c.URL(), msg)
 		if err := c.sendHelloLocked(c.helloAuth); err != nil {
 			c.closeWithError(err)
 		}
@@ -562,7 +561,7 @@ func (c *FederationClient) processHello(msg *ServerMessage) {
 			messages := c.pendingMessages
 			c.pendingMessages = nil
 
-			log.Printf("Sending %d pending messages to %s for %s", count, c.URL(), c.session.PublicId())
+			hubLog.Infof("Sending %d pending messages to %s for %s", count, c.URL(), c.session.PublicId())
 
 			c.helloMu.Unlock()
 			defer c.helloMu.Lock()
@@ -571,7 +570,7 @@ func (c *FederationClient) processHello(msg *ServerMessage) {
 			defer c.mu.Unlock()
 			for _, msg := range messages {
 				if err := c.sendMessageLocked(msg); err != nil {
-					log.Printf("Error sending pending message %+v on federation connection to %s: %s", msg, c.URL(), err)
+					hubLog.Errorf("Error sending pending message %+v on federation connection to %s: %s", msg, c.URL(), err)
 					break
 				}
 			}
@@ -748,7 +747,11 @@ func (c *FederationClient) processMessage(msg *ServerMessage) {
 			}
 		}
 	case "error":
-		if c.changeRoomId.Load() && msg.Error.Code == "already_joined" {
+		if msg.Error.Code == InvalidSignature.Code {
+			// The remote server doesn't accept our messages, no need to continue.
+			hubLog.Infof("Remote server %s rejected signature of message for %s", c.URL(), c.session.PublicId())
+			doClose = true
+		} else if c.changeRoomId.Load() && msg.Error.Code == "already_joined" {
 			if len(msg.Error.Details) > 0 {
 				var details RoomErrorDetails
 				if err := json.Unmarshal(msg.Error.Details, &details); err == nil && details.Room != nil {
@@ -846,12 +849,16 @@ func (c *FederationClient) deferMessage(message *ClientMessage) {
 
 	c.pendingMessages = append(c.pendingMessages, message)
 	if len(c.pendingMessages) >= warnPendingMessagesCount {
-		log.Printf("Session %s has %d pending federated messages", c.session.PublicId(), len(c.pendingMessages))
+		hubLog.Infof("Session %s has %d pending federated messages", c.session.PublicId(), len(c.pendingMessages))
 	}
 }
 
 func (c *FederationClient) sendMessageLocked(message *ClientMessage) error {
-	if c.conn == nil {
+	// Signatures are bound to the remote session, so signed messages can only
+	// be sent once the hello response has been received.
+	sign := c.hub.federationSigner != nil && message.Type != "hello" && message.Type != "bye"
+	hello := c.hello.Load()
+	if c.conn == nil || (sign && hello == nil) {
 		if message.Type != "room" {
 			// Join requests will be automatically sent after the hello response has
 			// been received.
@@ -860,6 +867,21 @@ func (c *FederationClient) sendMessageLocked(message *ClientMessage) error {
 		return nil
 	}
 
+	// Never forward signatures received from the local client.
+	if sign {
+		signed, err := c.hub.federationSigner.Sign(message, hello.SessionId, c.session.BackendUrl())
+		if err != nil {
+			hubLog.Errorf("Could not sign message %+v for %s to federated client %s: %s", message, c.session.PublicId(), c.URL(), err)
+			return err
+		}
+
+		message = signed
+	} else if message.Signature != "" {
+		msg := *message
+		msg.Signature = ""
+		message = &msg
+	}
+
 	c.conn.SetWriteDeadline(time.Now().Add(writeWait)) // nolint
 	writer, err := c.conn.NextWriter(websocket.TextMessage)
 	if err == nil {
@@ -878,7 +900,7 @@ func (c *FederationClient) sendMessageLocked(message *ClientMessage) error {
 			return err
 		}
 
-		log.Printf("Could not send message %+v for %s to federated client %s: %v", message, c.session.PublicId(), c.URL(), err)
+		hubLog.Errorf("Could not send message %+v for %s to federated client %s: %v", message, c.session.PublicId(), c.URL(), err)
 		c.deferMessage(message)
 		c.scheduleReconnectLocked()
 	}
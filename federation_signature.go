@@ -0,0 +1,388 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/dlintw/goconf"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	// InvalidSignature is returned if a message from a federated session has a missing or invalid signature.
+	InvalidSignature = NewError("invalid_signature", "The message signature is invalid.")
+
+	ErrSignatureMissing      = errors.New("signature missing")
+	ErrSignatureInvalid      = errors.New("signature invalid")
+	ErrSignatureUnknownKey   = errors.New("signature key unknown")
+	ErrSignatureExpired      = errors.New("signature expired")
+	ErrSignatureReplayed     = errors.New("signature replayed")
+	ErrSignatureWrongSession = errors.New("signature for different session")
+	ErrSignatureWrongOrigin  = errors.New("signature from different origin")
+	ErrUnsupportedSigningKey = errors.New("unsupported signing key")
+)
+
+type federationSignatureHeader struct {
+	Alg      string `json:"alg"`
+	KeyId    string `json:"kid"`
+	IssuedAt int64  `json:"iat"`
+	Nonce    string `json:"jti"`
+	// Id of the federated session on the remote server.
+	SessionId PublicSessionId `json:"sid"`
+	// Nextcloud server of the session that sent the message.
+	Origin string `json:"origin"`
+}
+
+// federationKey is a public key that may sign messages of federated sessions
+// from the given origins.
+type federationKey struct {
+	key     crypto.PublicKey
+	origins []string
+}
+
+func normalizeFederationOrigin(origin string) string {
+	return strings.TrimSuffix(origin, "/")
+}
+
+func parseFederationPrivateKey(data []byte) (jwt.SigningMethod, crypto.PrivateKey, error) {
+	if key, err := jwt.ParseRSAPrivateKeyFromPEM(data); err == nil {
+		return jwt.SigningMethodRS256, key, nil
+	}
+	if key, err := jwt.ParseECPrivateKeyFromPEM(data); err == nil {
+		switch key.Curve.Params().BitSize {
+		case 256:
+			return jwt.SigningMethodES256, key, nil
+		case 384:
+			return jwt.SigningMethodES384, key, nil
+		case 521:
+			return jwt.SigningMethodES512, key, nil
+		default:
+			return nil, nil, ErrUnsupportedSigningKey
+		}
+	}
+	if key, err := jwt.ParseEdPrivateKeyFromPEM(data); err == nil {
+		return jwt.SigningMethodEdDSA, key, nil
+	}
+
+	return nil, nil, ErrUnsupportedSigningKey
+}
+
+func parseFederationPublicKey(data []byte) (crypto.PublicKey, error) {
+	if key, err := jwt.ParseRSAPublicKeyFromPEM(data); err == nil {
+		return key, nil
+	}
+	if key, err := jwt.ParseECPublicKeyFromPEM(data); err == nil {
+		return key, nil
+	}
+	if key, err := jwt.ParseEdPublicKeyFromPEM(data); err == nil {
+		return key, nil
+	}
+
+	return nil, ErrUnsupportedSigningKey
+}
+
+func isCompatibleSigningMethod(method jwt.SigningMethod, key crypto.PublicKey) bool {
+	switch key.(type) {
+	case *rsa.PublicKey:
+		_, ok := method.(*jwt.SigningMethodRSA)
+		return ok
+	case *ecdsa.PublicKey:
+		_, ok := method.(*jwt.SigningMethodECDSA)
+		return ok
+	case ed25519.PublicKey:
+		_, ok := method.(*jwt.SigningMethodEd25519)
+		return ok
+	default:
+		return false
+	}
+}
+
+// FederationSigner creates detached signatures for messages that are sent to
+// remote signaling servers on behalf of federated sessions.
+type FederationSigner struct {
+	id     string
+	method jwt.SigningMethod
+	key    crypto.PrivateKey
+}
+
+// NewFederationSigner loads the signing key from the configuration. It
+// returns nil if no key is configured.
+func NewFederationSigner(config *goconf.ConfigFile) (*FederationSigner, error) {
+	filename, _ := config.GetString("federation", "signingkey")
+	if filename == "" {
+		return nil, nil
+	}
+
+	id, _ := config.GetString("federation", "signingkeyid")
+	if id == "" {
+		return nil, fmt.Errorf("no signingkeyid configured for federation signing key")
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("could not read federation signing key from %s: %w", filename, err)
+	}
+
+	method, key, err := parseFederationPrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse federation signing key from %s: %w", filename, err)
+	}
+
+	return &FederationSigner{
+		id:     id,
+		method: method,
+		key:    key,
+	}, nil
+}
+
+func (s *FederationSigner) Id() string {
+	return s.id
+}
+
+// Sign returns a message that contains the given message as signed payload.
+// The signature is bound to the id of the federated session on the remote
+// server and the Nextcloud server of the local session.
+func (s *FederationSigner) Sign(message *ClientMessage, sessionId PublicSessionId, origin string) (*ClientMessage, error) {
+	msg := *message
+	msg.Signature = ""
+	payload, err := msg.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := json.Marshal(federationSignatureHeader{
+		Alg:       s.method.Alg(),
+		KeyId:     s.id,
+		IssuedAt:  time.Now().Unix(),
+		Nonce:     newRandomString(32),
+		SessionId: sessionId,
+		Origin:    normalizeFederationOrigin(origin),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	signingString := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	sig, err := s.method.Sign(signingString, s.key)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the fields required to process the message before its signature is
+	// checked are sent outside of the signed payload.
+	return &ClientMessage{
+		Id:        message.Id,
+		Type:      message.Type,
+		Signature: signingString + "." + base64.RawURLEncoding.EncodeToString(sig),
+	}, nil
+}
+
+// FederationVerifier checks the signatures of messages received from
+// federated sessions against a list of trusted public keys.
+type FederationVerifier struct {
+	required atomic.Bool
+	keys     atomic.Pointer[map[string]*federationKey]
+	// Nonces of signatures are remembered while they are valid.
+	nonces *backendReplayCache
+}
+
+func NewFederationVerifier(config *goconf.ConfigFile) (*FederationVerifier, error) {
+	result := &FederationVerifier{
+		nonces: newBackendReplayCache(tokenLeeway),
+	}
+	if err := result.load(config, false); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (v *FederationVerifier) load(config *goconf.ConfigFile, ignoreErrors bool) error {
+	options, err := GetStringOptions(config, "federation-keys", ignoreErrors)
+	if err != nil {
+		return err
+	}
+
+	keys := make(map[string]*federationKey)
+	for id, value := range options {
+		filename, origins, _ := strings.Cut(strings.TrimSpace(value), " ")
+		if filename == "" {
+			if !ignoreErrors {
+				return fmt.Errorf("no filename given for federation key %s", id)
+			}
+
+			hubLog.Infof("No filename given for federation key %s, ignoring", id)
+			continue
+		}
+
+		var allowed []string
+		for origin := range SplitEntries(origins, " ") {
+			allowed = append(allowed, normalizeFederationOrigin(origin))
+		}
+		if len(allowed) == 0 {
+			if !ignoreErrors {
+				return fmt.Errorf("no origins given for federation key %s", id)
+			}
+
+			hubLog.Errorf("No origins given for federation key %s, ignoring", id)
+			continue
+		}
+
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			if !ignoreErrors {
+				return fmt.Errorf("could not read federation key from %s: %s", filename, err)
+			}
+
+			hubLog.Errorf("Could not read federation key from %s, ignoring: %s", filename, err)
+			continue
+		}
+
+		key, err := parseFederationPublicKey(data)
+		if err != nil {
+			if !ignoreErrors {
+				return fmt.Errorf("could not parse federation key from %s: %s", filename, err)
+			}
+
+			hubLog.Errorf("Could not parse federation key from %s, ignoring: %s", filename, err)
+			continue
+		}
+
+		keys[id] = &federationKey{
+			key:     key,
+			origins: allowed,
+		}
+	}
+
+	required, _ := config.GetBool("federation", "requiresignature")
+	if required && len(keys) == 0 {
+		hubLog.Warnf("Signatures are required for federated sessions but no keys are configured")
+	}
+
+	if len(keys) > 0 {
+		keyIds := slices.Sorted(maps.Keys(keys))
+		hubLog.Infof("Enabled federation keys: %v", keyIds)
+	}
+	v.keys.Store(&keys)
+	v.required.Store(required)
+	return nil
+}
+
+func (v *FederationVerifier) Reload(config *goconf.ConfigFile) {
+	if err := v.load(config, true); err != nil {
+		hubLog.Errorf("Error reloading federation keys: %s", err)
+	}
+}
+
+// Verify checks the signature of a message received from the given federated
+// session and replaces the message with the signed payload. Messages without
+// signature are only accepted if signatures are not required.
+func (v *FederationVerifier) Verify(session *ClientSession, message *ClientMessage) error {
+	if message.Signature == "" {
+		if v.required.Load() {
+			return ErrSignatureMissing
+		}
+		return nil
+	}
+
+	parts := strings.Split(message.Signature, ".")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return ErrSignatureInvalid
+	}
+
+	headerData, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return ErrSignatureInvalid
+	}
+
+	var header federationSignatureHeader
+	if err := json.Unmarshal(headerData, &header); err != nil || header.Nonce == "" {
+		return ErrSignatureInvalid
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ErrSignatureInvalid
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return ErrSignatureInvalid
+	}
+
+	var key *federationKey
+	if keys := v.keys.Load(); keys != nil {
+		key = (*keys)[header.KeyId]
+	}
+	if key == nil {
+		return ErrSignatureUnknownKey
+	}
+
+	method := jwt.GetSigningMethod(header.Alg)
+	if method == nil || !isCompatibleSigningMethod(method, key.key) {
+		return ErrSignatureInvalid
+	}
+
+	if err := method.Verify(parts[0]+"."+parts[1], sig, key.key); err != nil {
+		return ErrSignatureInvalid
+	}
+
+	if header.SessionId != session.PublicId() {
+		return ErrSignatureWrongSession
+	} else if !slices.Contains(key.origins, header.Origin) {
+		return ErrSignatureWrongOrigin
+	}
+
+	var signed ClientMessage
+	if err := signed.UnmarshalJSON(payload); err != nil || signed.Type != message.Type || signed.Signature != "" {
+		return ErrSignatureInvalid
+	}
+
+	switch err := v.nonces.Check(header.KeyId+"|"+header.Nonce, time.Unix(header.IssuedAt, 0), time.Now()); err {
+	case nil:
+	case ErrBackendRequestReplayed:
+		return ErrSignatureReplayed
+	default:
+		return ErrSignatureExpired
+	}
+
+	if !session.checkFederationOrigin(header.Origin) {
+		return ErrSignatureWrongOrigin
+	}
+
+	*message = signed
+	return nil
+}
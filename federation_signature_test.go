@@ -0,0 +1,341 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dlintw/goconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFederationKeysForTest(t *testing.T, name string, algo string) (string, string) {
+	require := require.New(t)
+
+	var private crypto.Signer
+	var err error
+	switch algo {
+	case "ECDSA":
+		private, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case "Ed25519":
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		private, err = rsa.GenerateKey(rand.Reader, 2048)
+	}
+	require.NoError(err)
+
+	privateData, err := x509.MarshalPKCS8PrivateKey(private)
+	require.NoError(err)
+	publicData, err := x509.MarshalPKIXPublicKey(private.Public())
+	require.NoError(err)
+
+	dir := t.TempDir()
+	privateFilename := path.Join(dir, name+".key")
+	require.NoError(os.WriteFile(privateFilename, pem.EncodeToMemory(&pem.Block{
+		Type:  "PRIVATE KEY",
+		Bytes: privateData,
+	}), 0600))
+	publicFilename := path.Join(dir, name+".pub")
+	require.NoError(os.WriteFile(publicFilename, pem.EncodeToMemory(&pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: publicData,
+	}), 0644))
+	return privateFilename, publicFilename
+}
+
+const (
+	testFederationOrigin = "https://nextcloud.remote.example.com"
+)
+
+func TestFederationSignature(t *testing.T) {
+	t.Parallel()
+	for _, algo := range []string{"RSA", "ECDSA", "Ed25519"} {
+		t.Run(algo, func(t *testing.T) {
+			t.Parallel()
+			assert := assert.New(t)
+			require := require.New(t)
+
+			privateFilename, publicFilename := writeFederationKeysForTest(t, "server1", algo)
+			config := goconf.NewConfigFile()
+			config.AddOption("federation", "signingkey", privateFilename)
+			config.AddOption("federation", "signingkeyid", "server1")
+			config.AddOption("federation", "requiresignature", "true")
+			config.AddOption("federation-keys", "server1", publicFilename+" "+testFederationOrigin+"/")
+
+			signer, err := NewFederationSigner(config)
+			require.NoError(err)
+			require.NotNil(signer)
+			verifier, err := NewFederationVerifier(config)
+			require.NoError(err)
+
+			session := &ClientSession{
+				publicId: "the-federated-session",
+			}
+			msg := &ClientMessage{
+				Id:   "1234",
+				Type: "message",
+				Message: &MessageClientMessage{
+					Recipient: MessageClientMessageRecipient{
+						Type:      RecipientTypeSession,
+						SessionId: "the-session-id",
+					},
+					Data: json.RawMessage(`{"foo": "bar"}`),
+				},
+			}
+			assert.ErrorIs(verifier.Verify(session, msg), ErrSignatureMissing)
+
+			signed, err := signer.Sign(msg, session.PublicId(), testFederationOrigin)
+			require.NoError(err)
+			assert.Equal(msg.Id, signed.Id)
+			assert.Equal(msg.Type, signed.Type)
+			assert.Nil(signed.Message)
+
+			// Signature must survive a roundtrip through the wire format.
+			data, err := signed.MarshalJSON()
+			require.NoError(err)
+			var received ClientMessage
+			require.NoError(received.UnmarshalJSON(data))
+			if assert.NoError(verifier.Verify(session, &received)) {
+				assert.Empty(received.Signature)
+				if assert.NotNil(received.Message) {
+					assert.Equal(msg.Message.Recipient, received.Message.Recipient)
+					assert.JSONEq(string(msg.Message.Data), string(received.Message.Data))
+				}
+			}
+
+			// Signatures may only be used once.
+			require.NoError(received.UnmarshalJSON(data))
+			assert.ErrorIs(verifier.Verify(session, &received), ErrSignatureReplayed)
+
+			signed, err = signer.Sign(msg, session.PublicId(), testFederationOrigin)
+			require.NoError(err)
+			parts := strings.Split(signed.Signature, ".")
+			require.Len(parts, 3)
+
+			// Payload can't be modified.
+			modified := *msg
+			modified.Message = &MessageClientMessage{
+				Recipient: msg.Message.Recipient,
+				Data:      json.RawMessage(`{"foo": "baz"}`),
+			}
+			payload, err := modified.MarshalJSON()
+			require.NoError(err)
+			received = *signed
+			received.Signature = parts[0] + "." + base64.RawURLEncoding.EncodeToString(payload) + "." + parts[2]
+			assert.ErrorIs(verifier.Verify(session, &received), ErrSignatureInvalid)
+
+			// Payload must be of the same type as the outer message.
+			received = *signed
+			received.Type = "room"
+			assert.ErrorIs(verifier.Verify(session, &received), ErrSignatureInvalid)
+
+			received = *signed
+			received.Signature = parts[0] + "." + parts[1] + "." + parts[2][:len(parts[2])-4] + "AAAA"
+			assert.ErrorIs(verifier.Verify(session, &received), ErrSignatureInvalid)
+
+			received.Signature = "invalid-signature"
+			assert.ErrorIs(verifier.Verify(session, &received), ErrSignatureInvalid)
+
+			received = *signed
+			assert.NoError(verifier.Verify(session, &received))
+		})
+	}
+}
+
+func TestFederationSignature_SessionAndOrigin(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+
+	otherOrigin := "https://nextcloud.other.example.com"
+	privateFilename, publicFilename := writeFederationKeysForTest(t, "server1", "Ed25519")
+	config := goconf.NewConfigFile()
+	config.AddOption("federation", "signingkey", privateFilename)
+	config.AddOption("federation", "signingkeyid", "server1")
+	config.AddOption("federation-keys", "server1", publicFilename+" "+testFederationOrigin+" "+otherOrigin)
+
+	signer, err := NewFederationSigner(config)
+	require.NoError(err)
+	verifier, err := NewFederationVerifier(config)
+	require.NoError(err)
+
+	session1 := &ClientSession{
+		publicId: "the-federated-session-1",
+	}
+	session2 := &ClientSession{
+		publicId: "the-federated-session-2",
+	}
+	msg := &ClientMessage{
+		Type: "room",
+		Room: &RoomClientMessage{
+			RoomId: "the-room-id",
+		},
+	}
+
+	// Signature for one session can't be used for a different session.
+	signed, err := signer.Sign(msg, session1.PublicId(), testFederationOrigin)
+	require.NoError(err)
+	received := *signed
+	assert.ErrorIs(verifier.Verify(session2, &received), ErrSignatureWrongSession)
+	received = *signed
+	assert.NoError(verifier.Verify(session1, &received))
+
+	// Origin of a session is fixed by the first signed message.
+	signed, err = signer.Sign(msg, session1.PublicId(), otherOrigin)
+	require.NoError(err)
+	assert.ErrorIs(verifier.Verify(session1, signed), ErrSignatureWrongOrigin)
+
+	// Key may only sign messages from the configured origins.
+	signed, err = signer.Sign(msg, session2.PublicId(), "https://nextcloud.invalid.example.com")
+	require.NoError(err)
+	assert.ErrorIs(verifier.Verify(session2, signed), ErrSignatureWrongOrigin)
+
+	signed, err = signer.Sign(msg, session2.PublicId(), otherOrigin)
+	require.NoError(err)
+	assert.NoError(verifier.Verify(session2, signed))
+}
+
+func TestFederationSignature_UnknownKey(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+
+	privateFilename, _ := writeFederationKeysForTest(t, "server1", "Ed25519")
+	_, otherPublicFilename := writeFederationKeysForTest(t, "server2", "Ed25519")
+	config := goconf.NewConfigFile()
+	config.AddOption("federation", "signingkey", privateFilename)
+	config.AddOption("federation", "signingkeyid", "server1")
+	config.AddOption("federation-keys", "server2", otherPublicFilename+" "+testFederationOrigin)
+
+	signer, err := NewFederationSigner(config)
+	require.NoError(err)
+	verifier, err := NewFederationVerifier(config)
+	require.NoError(err)
+
+	session := &ClientSession{
+		publicId: "the-federated-session",
+	}
+	msg := &ClientMessage{
+		Type: "room",
+		Room: &RoomClientMessage{
+			RoomId: "the-room-id",
+		},
+	}
+	// Signatures are not required.
+	assert.NoError(verifier.Verify(session, msg))
+
+	signed, err := signer.Sign(msg, session.PublicId(), testFederationOrigin)
+	require.NoError(err)
+	received := *signed
+	assert.ErrorIs(verifier.Verify(session, &received), ErrSignatureUnknownKey)
+
+	// Key is signed with different key than configured for the key id.
+	config.RemoveOption("federation-keys", "server2")
+	config.AddOption("federation-keys", "server1", otherPublicFilename+" "+testFederationOrigin)
+	verifier.Reload(config)
+	received = *signed
+	assert.ErrorIs(verifier.Verify(session, &received), ErrSignatureInvalid)
+}
+
+func TestFederationSignature_Expired(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+
+	privateFilename, publicFilename := writeFederationKeysForTest(t, "server1", "Ed25519")
+	config := goconf.NewConfigFile()
+	config.AddOption("federation", "signingkey", privateFilename)
+	config.AddOption("federation", "signingkeyid", "server1")
+	config.AddOption("federation-keys", "server1", publicFilename+" "+testFederationOrigin)
+
+	signer, err := NewFederationSigner(config)
+	require.NoError(err)
+	verifier, err := NewFederationVerifier(config)
+	require.NoError(err)
+
+	session := &ClientSession{
+		publicId: "the-federated-session",
+	}
+	msg := &ClientMessage{
+		Type: "room",
+		Room: &RoomClientMessage{
+			RoomId: "the-room-id",
+		},
+	}
+	payload, err := msg.MarshalJSON()
+	require.NoError(err)
+	header, err := json.Marshal(federationSignatureHeader{
+		Alg:       signer.method.Alg(),
+		KeyId:     signer.id,
+		IssuedAt:  time.Now().Add(-2 * tokenLeeway).Unix(),
+		Nonce:     newRandomString(32),
+		SessionId: session.PublicId(),
+		Origin:    testFederationOrigin,
+	})
+	require.NoError(err)
+	signingString := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	sig, err := signer.method.Sign(signingString, signer.key)
+	require.NoError(err)
+	msg.Signature = signingString + "." + base64.RawURLEncoding.EncodeToString(sig)
+	assert.ErrorIs(verifier.Verify(session, msg), ErrSignatureExpired)
+}
+
+func TestFederationVerifier_MissingOrigins(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	_, publicFilename := writeFederationKeysForTest(t, "server1", "Ed25519")
+	config := goconf.NewConfigFile()
+	config.AddOption("federation-keys", "server1", publicFilename)
+
+	_, err := NewFederationVerifier(config)
+	assert.ErrorContains(err, "no origins")
+}
+
+func TestFederationSigner_NotConfigured(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	config := goconf.NewConfigFile()
+	signer, err := NewFederationSigner(config)
+	assert.NoError(err)
+	assert.Nil(signer)
+
+	config.AddOption("federation", "signingkey", "/path/to/key.pem")
+	_, err = NewFederationSigner(config)
+	assert.ErrorContains(err, "signingkeyid")
+}
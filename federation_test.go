@@ -25,10 +25,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/dlintw/goconf"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -72,6 +74,122 @@ func Test_FederationInvalidToken(t *testing.T) {
 	}
 }
 
+func Test_FederationSignatures(t *testing.T) {
+	CatchLogForTest(t)
+
+	privateFilename, publicFilename := writeFederationKeysForTest(t, "server", "Ed25519")
+	for _, name := range []string{"signed", "unsigned"} {
+		signed := name == "signed"
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			hub1, hub2, _, _, server1, server2 := CreateClusteredHubsForTestWithConfig(t, func(server *httptest.Server) (*goconf.ConfigFile, error) {
+				config, err := getTestConfig(server)
+				if err != nil {
+					return nil, err
+				}
+
+				if signed {
+					config.AddOption("federation", "signingkey", privateFilename)
+					config.AddOption("federation", "signingkeyid", "server")
+				}
+				config.AddOption("federation", "requiresignature", "true")
+				config.AddOption("federation-keys", "server", publicFilename+" "+server.URL)
+				return config, nil
+			})
+
+			// The key may sign messages from the Nextcloud servers of both hubs.
+			for _, hub := range []*Hub{hub1, hub2} {
+				config := goconf.NewConfigFile()
+				config.AddOption("federation", "requiresignature", "true")
+				config.AddOption("federation-keys", "server", publicFilename+" "+server1.URL+" "+server2.URL)
+				hub.federationVerifier.Reload(config)
+			}
+
+			client1 := NewTestClient(t, server1, hub1)
+			defer client1.CloseWithBye()
+			require.NoError(client1.SendHelloV2(testDefaultUserId + "1"))
+
+			client2 := NewTestClient(t, server2, hub2)
+			defer client2.CloseWithBye()
+			require.NoError(client2.SendHelloV2(testDefaultUserId + "2"))
+
+			ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+			defer cancel()
+
+			hello1 := MustSucceed1(t, client1.RunUntilHello, ctx)
+			hello2 := MustSucceed1(t, client2.RunUntilHello, ctx)
+
+			roomId := "test-room"
+			federatedRoomId := roomId + "@federated"
+			MustSucceed2(t, client1.JoinRoom, ctx, roomId)
+			client1.RunUntilJoined(ctx, hello1.Hello)
+
+			now := time.Now()
+			userdata := StringMap{
+				"displayname": "Federated user",
+				"actorType":   "federated_users",
+				"actorId":     "the-federated-user-id",
+			}
+			token, err := client1.CreateHelloV2TokenWithUserdata(testDefaultUserId+"2", now, now.Add(time.Minute), userdata)
+			require.NoError(err)
+
+			msg := &ClientMessage{
+				Id:   "join-room-fed",
+				Type: "room",
+				Room: &RoomClientMessage{
+					RoomId:    federatedRoomId,
+					SessionId: RoomSessionId(fmt.Sprintf("%s-%s", federatedRoomId, hello2.Hello.SessionId)),
+					Federation: &RoomFederationMessage{
+						SignalingUrl: server1.URL,
+						NextcloudUrl: server1.URL,
+						RoomId:       roomId,
+						Token:        token,
+					},
+				},
+			}
+			require.NoError(client2.WriteJSON(msg))
+
+			message, ok := client2.RunUntilMessage(ctx)
+			require.True(ok)
+			assert.Equal(msg.Id, message.Id)
+			if !signed {
+				if checkMessageType(t, message, "error") {
+					assert.Equal(InvalidSignature.Code, message.Error.Code)
+				}
+				return
+			}
+
+			require.Equal("room", message.Type)
+			require.Equal(federatedRoomId, message.Room.RoomId)
+
+			var remoteSessionId PublicSessionId
+			if message, ok := client1.RunUntilMessage(ctx); ok {
+				client1.checkSingleMessageJoined(message)
+				remoteSessionId = message.Event.Join[0].SessionId
+			}
+			client2.RunUntilJoined(ctx, hello1.Hello, hello2.Hello)
+
+			// Messages are also forwarded with signatures.
+			recipient1 := MessageClientMessageRecipient{
+				Type:      "session",
+				SessionId: hello1.Hello.SessionId,
+			}
+			data := "from-2-to-1"
+			require.NoError(client2.SendMessage(recipient1, data))
+
+			var payload string
+			if checkReceiveClientMessage(ctx, t, client1, "session", &HelloServerMessage{
+				SessionId: remoteSessionId,
+				UserId:    hello2.Hello.UserId,
+			}, &payload) {
+				assert.Equal(data, payload)
+			}
+		})
+	}
+}
+
 func Test_Federation(t *testing.T) {
 	CatchLogForTest(t)
 
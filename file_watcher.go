@@ -24,7 +24,7 @@ package signaling
 import (
 	"context"
 	"errors"
-	"log"
+	"fmt"
 	"os"
 	"path"
 	"path/filepath"
@@ -55,12 +55,24 @@ type FileWatcher struct {
 	target   string
 	callback FileWatcherCallback
 
+	// Only set for watchers created with NewDirectoryWatcher.
+	dir     string
+	pattern string
+	targets map[string]string
+
+	// Only set for watchers that poll for changes.
+	states map[string]*fileWatcherState
+
 	watcher   *fsnotify.Watcher
 	closeCtx  context.Context
 	closeFunc context.CancelFunc
 }
 
 func NewFileWatcher(filename string, callback FileWatcherCallback) (*FileWatcher, error) {
+	if interval, polling := usePolling(path.Dir(filename)); polling {
+		return newPollingWatcher(filename, "", "", callback, interval)
+	}
+
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
 		return nil, err
@@ -90,6 +102,115 @@ func NewFileWatcher(filename string, callback FileWatcherCallback) (*FileWatcher
 	return w, nil
 }
 
+// NewDirectoryWatcher watches all files in a directory that match a glob
+// pattern, e.g. "/etc/signaling/keys/*.pem". If the pattern is a directory,
+// all files in it are watched. Hidden files (starting with a ".") are ignored.
+// The callback is called with the name of each file that was created, changed
+// or removed, including files added after the watcher was created.
+func NewDirectoryWatcher(pattern string, callback FileWatcherCallback) (*FileWatcher, error) {
+	dir, match := filepath.Clean(pattern), "*"
+	if stat, err := os.Stat(dir); err != nil || !stat.IsDir() {
+		dir, match = filepath.Split(dir)
+		dir = filepath.Clean(dir)
+	}
+
+	if _, err := filepath.Match(match, ""); err != nil {
+		return nil, err
+	} else if strings.ContainsAny(dir, "*?[") {
+		return nil, fmt.Errorf("only the filename may contain a pattern in %s", pattern)
+	}
+
+	if stat, err := os.Stat(dir); err != nil {
+		return nil, err
+	} else if !stat.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	if interval, polling := usePolling(dir); polling {
+		return newPollingWatcher(pattern, dir, match, callback, interval)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := watcher.Add(dir); err != nil {
+		watcher.Close() // nolint
+		return nil, err
+	}
+
+	closeCtx, closeFunc := context.WithCancel(context.Background())
+
+	w := &FileWatcher{
+		filename: pattern,
+		callback: callback,
+		watcher:  watcher,
+
+		dir:     dir,
+		pattern: match,
+
+		closeCtx:  closeCtx,
+		closeFunc: closeFunc,
+	}
+	w.targets = w.getSymlinkTargets()
+
+	go w.run()
+	return w, nil
+}
+
+func (f *FileWatcher) matchesPattern(filename string) bool {
+	name := filepath.Base(filename)
+	if strings.HasPrefix(name, ".") {
+		return false
+	}
+
+	matched, _ := filepath.Match(f.pattern, name)
+	return matched
+}
+
+// getSymlinkTargets returns the targets of all matching files in the watched
+// directory that are symlinks.
+func (f *FileWatcher) getSymlinkTargets() map[string]string {
+	entries, err := os.ReadDir(f.dir)
+	if err != nil {
+		appLog.Errorf("Could not read directory %s: %s", f.dir, err)
+		return nil
+	}
+
+	result := make(map[string]string)
+	for _, entry := range entries {
+		if entry.Type()&os.ModeSymlink == 0 || !f.matchesPattern(entry.Name()) {
+			continue
+		}
+
+		filename := filepath.Join(f.dir, entry.Name())
+		if target, err := filepath.EvalSymlinks(filename); err == nil {
+			result[filename] = target
+		}
+	}
+	return result
+}
+
+func (f *FileWatcher) processDirectoryEvent(event fsnotify.Event, triggerEvent func(filename string)) {
+	filename := filepath.Clean(event.Name)
+	targets := f.getSymlinkTargets()
+	if f.matchesPattern(filename) {
+		if stat, err := os.Stat(filename); err != nil || !stat.IsDir() {
+			triggerEvent(filename)
+		}
+	}
+
+	// Changing other entries (e.g. the "..data" symlink in Kubernetes volumes)
+	// could change the targets of symlinked files.
+	for name, target := range targets {
+		if name != filename && f.targets[name] != target {
+			triggerEvent(name)
+		}
+	}
+	f.targets = targets
+}
+
 func (f *FileWatcher) updateWatcher() error {
 	realFilename, err := filepath.EvalSymlinks(f.filename)
 	if err != nil {
@@ -106,6 +227,10 @@ func (f *FileWatcher) updateWatcher() error {
 
 func (f *FileWatcher) Close() error {
 	f.closeFunc()
+	if f.watcher == nil {
+		return nil
+	}
+
 	return f.watcher.Close()
 }
 
@@ -113,22 +238,26 @@ func (f *FileWatcher) run() {
 	var mu sync.Mutex
 	timers := make(map[string]*time.Timer)
 
-	triggerEvent := func(event fsnotify.Event) {
+	triggerFile := func(filename string) {
+		// Watchers of single files always report the configured filename.
+		name := f.filename
+		if f.pattern != "" {
+			name = filename
+		}
+
 		deduplicate := time.Duration(deduplicateWatchEvents.Load())
 		if deduplicate <= 0 {
-			f.callback(f.filename)
+			f.callback(name)
 			return
 		}
 
-		filename := path.Clean(event.Name)
-
 		// Use timer to deduplicate multiple events for the same file.
 		mu.Lock()
 		t, found := timers[filename]
 		mu.Unlock()
 		if !found {
 			t = time.AfterFunc(deduplicate, func() {
-				f.callback(f.filename)
+				f.callback(name)
 
 				mu.Lock()
 				delete(timers, filename)
@@ -141,6 +270,9 @@ func (f *FileWatcher) run() {
 			t.Reset(deduplicate)
 		}
 	}
+	triggerEvent := func(event fsnotify.Event) {
+		triggerFile(path.Clean(event.Name))
+	}
 
 	for {
 		select {
@@ -149,6 +281,11 @@ func (f *FileWatcher) run() {
 				continue
 			}
 
+			if f.pattern != "" {
+				f.processDirectoryEvent(event, triggerFile)
+				continue
+			}
+
 			if event.Has(fsnotify.Remove) {
 				// Watched target has been deleted, assume it was symlinked and try to watch new target.
 				if event.Name != f.target {
@@ -157,14 +294,14 @@ func (f *FileWatcher) run() {
 
 				triggerEvent(event)
 				if err := f.updateWatcher(); err != nil {
-					log.Printf("Error updating watcher after %s is deleted: %s", event.Name, err)
+					appLog.Errorf("Error updating watcher after %s is deleted: %s", event.Name, err)
 				}
 				continue
 			}
 
 			if stat, err := os.Lstat(event.Name); err != nil {
 				if !errors.Is(err, os.ErrNotExist) {
-					log.Printf("Could not lstat %s: %s", event.Name, err)
+					appLog.Errorf("Could not lstat %s: %s", event.Name, err)
 				}
 			} else if stat.Mode()&os.ModeSymlink != 0 {
 				target, err := filepath.EvalSymlinks(event.Name)
@@ -183,7 +320,7 @@ func (f *FileWatcher) run() {
 				return
 			}
 
-			log.Printf("Error watching %s: %s", f.filename, err)
+			appLog.Errorf("Error watching %s: %s", f.filename, err)
 		case <-f.closeCtx.Done():
 			return
 		}
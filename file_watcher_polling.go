@@ -0,0 +1,247 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2017 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/dlintw/goconf"
+)
+
+type FileWatcherPolling string
+
+const (
+	// FileWatcherPollingAuto polls for changes if the watched files are on a
+	// network filesystem that doesn't support file notifications.
+	FileWatcherPollingAuto   FileWatcherPolling = "auto"
+	FileWatcherPollingAlways FileWatcherPolling = "always"
+	FileWatcherPollingNever  FileWatcherPolling = "never"
+
+	defaultFileWatcherPollInterval = 10 * time.Second
+)
+
+var (
+	fileWatcherPolling      atomic.Value
+	fileWatcherPollInterval atomic.Int64
+
+	errIsDirectory = errors.New("is a directory")
+)
+
+func init() {
+	fileWatcherPolling.Store(FileWatcherPollingAuto)
+	fileWatcherPollInterval.Store(int64(defaultFileWatcherPollInterval))
+}
+
+// ConfigureFileWatcher sets the polling mode for file watchers created
+// afterwards.
+func ConfigureFileWatcher(config *goconf.ConfigFile) error {
+	polling := FileWatcherPollingAuto
+	if value, _ := config.GetString("app", "filewatcherpolling"); value != "" {
+		switch p := FileWatcherPolling(value); p {
+		case FileWatcherPollingAuto, FileWatcherPollingAlways, FileWatcherPollingNever:
+			polling = p
+		default:
+			return fmt.Errorf("unsupported file watcher polling mode: %s", value)
+		}
+	}
+
+	interval := defaultFileWatcherPollInterval
+	if value, _ := config.GetInt("app", "filewatcherpollinterval"); value > 0 {
+		interval = time.Duration(value) * time.Second
+	}
+
+	fileWatcherPolling.Store(polling)
+	fileWatcherPollInterval.Store(int64(interval))
+	return nil
+}
+
+// usePolling returns the poll interval and true if changes of files in the
+// given directory should be detected by polling.
+func usePolling(dir string) (time.Duration, bool) {
+	interval := time.Duration(fileWatcherPollInterval.Load())
+	switch fileWatcherPolling.Load().(FileWatcherPolling) {
+	case FileWatcherPollingAlways:
+		return interval, true
+	case FileWatcherPollingNever:
+		return 0, false
+	default:
+		if !isNetworkFilesystem(dir) {
+			return 0, false
+		}
+
+		appLog.Infof("%s is on a network filesystem, polling for changes every %s", dir, interval)
+		return interval, true
+	}
+}
+
+type fileWatcherState struct {
+	target  string
+	modTime time.Time
+	size    int64
+	hash    [sha256.Size]byte
+}
+
+// getFileWatcherState returns the state of a file. The contents are only
+// hashed again if the previous state doesn't match.
+func getFileWatcherState(filename string, prev *fileWatcherState) (*fileWatcherState, error) {
+	target, err := filepath.EvalSymlinks(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	stat, err := os.Stat(target)
+	if err != nil {
+		return nil, err
+	} else if stat.IsDir() {
+		return nil, errIsDirectory
+	}
+
+	if prev != nil && prev.target == target && prev.modTime.Equal(stat.ModTime()) && prev.size == stat.Size() {
+		return prev, nil
+	}
+
+	data, err := os.ReadFile(target)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fileWatcherState{
+		target:  target,
+		modTime: stat.ModTime(),
+		size:    stat.Size(),
+		hash:    sha256.Sum256(data),
+	}, nil
+}
+
+func newPollingWatcher(filename string, dir string, pattern string, callback FileWatcherCallback, interval time.Duration) (*FileWatcher, error) {
+	closeCtx, closeFunc := context.WithCancel(context.Background())
+
+	w := &FileWatcher{
+		filename: filename,
+		callback: callback,
+
+		dir:     dir,
+		pattern: pattern,
+
+		closeCtx:  closeCtx,
+		closeFunc: closeFunc,
+	}
+
+	if pattern == "" {
+		// Same as for watchers using file notifications, the file must exist.
+		state, err := getFileWatcherState(filename, nil)
+		if err != nil {
+			closeFunc()
+			return nil, err
+		}
+
+		w.states = map[string]*fileWatcherState{
+			filename: state,
+		}
+	} else {
+		w.states = w.getPollingStates()
+	}
+
+	go w.runPolling(interval)
+	return w, nil
+}
+
+func (f *FileWatcher) getPollingFiles() []string {
+	if f.pattern == "" {
+		return []string{f.filename}
+	}
+
+	entries, err := os.ReadDir(f.dir)
+	if err != nil {
+		appLog.Errorf("Could not read directory %s: %s", f.dir, err)
+		return nil
+	}
+
+	var result []string
+	for _, entry := range entries {
+		if f.matchesPattern(entry.Name()) {
+			result = append(result, filepath.Join(f.dir, entry.Name()))
+		}
+	}
+	return result
+}
+
+func (f *FileWatcher) getPollingStates() map[string]*fileWatcherState {
+	result := make(map[string]*fileWatcherState)
+	for _, filename := range f.getPollingFiles() {
+		state, err := getFileWatcherState(filename, f.states[filename])
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) && !errors.Is(err, errIsDirectory) {
+				appLog.Errorf("Could not check %s for changes: %s", filename, err)
+			}
+			continue
+		}
+
+		result[filename] = state
+	}
+	return result
+}
+
+func (f *FileWatcher) runPolling(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			f.poll()
+		case <-f.closeCtx.Done():
+			return
+		}
+	}
+}
+
+func (f *FileWatcher) poll() {
+	// Watchers of single files always report the configured filename.
+	trigger := func(filename string) {
+		if f.pattern == "" {
+			f.callback(f.filename)
+		} else {
+			f.callback(filename)
+		}
+	}
+
+	states := f.getPollingStates()
+	for filename, state := range states {
+		if prev, found := f.states[filename]; !found || prev.hash != state.hash {
+			trigger(filename)
+		}
+	}
+	for filename := range f.states {
+		if _, found := states[filename]; !found {
+			trigger(filename)
+		}
+	}
+	f.states = states
+}
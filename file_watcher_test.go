@@ -26,7 +26,9 @@ import (
 	"os"
 	"path"
 	"testing"
+	"time"
 
+	"github.com/dlintw/goconf"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -392,3 +394,230 @@ func TestFileWatcher_UpdateSymlinkFolder(t *testing.T) {
 	case <-ctxTimeout.Done():
 	}
 }
+
+func waitForWatchedFile(t *testing.T, modified <-chan string, expected string) {
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	select {
+	case filename := <-modified:
+		assert.Equal(t, expected, filename)
+	case <-ctx.Done():
+		assert.Fail(t, "no event received", "expected event for %s", expected)
+	}
+}
+
+func checkNoWatchedFile(t *testing.T, modified <-chan string) {
+	ctxTimeout, cancel := context.WithTimeout(context.Background(), testWatcherNoEventTimeout)
+	defer cancel()
+
+	select {
+	case filename := <-modified:
+		assert.Fail(t, "should not have received another event", "received event for %s", filename)
+	case <-ctxTimeout.Done():
+	}
+}
+
+func TestDirectoryWatcher_Invalid(t *testing.T) {
+	assert := assert.New(t)
+	tmpdir := t.TempDir()
+	if w, err := NewDirectoryWatcher(path.Join(tmpdir, "missing", "*.pem"), func(filename string) {}); !assert.ErrorIs(err, os.ErrNotExist) {
+		if w != nil {
+			assert.NoError(w.Close())
+		}
+	}
+	if w, err := NewDirectoryWatcher(path.Join(tmpdir, "*", "key.pem"), func(filename string) {}); !assert.Error(err) {
+		if w != nil {
+			assert.NoError(w.Close())
+		}
+	}
+	if w, err := NewDirectoryWatcher(path.Join(tmpdir, "[.pem"), func(filename string) {}); !assert.Error(err) {
+		if w != nil {
+			assert.NoError(w.Close())
+		}
+	}
+}
+
+func TestDirectoryWatcher_Pattern(t *testing.T) {
+	ensureNoGoroutinesLeak(t, func(t *testing.T) {
+		require := require.New(t)
+		tmpdir := t.TempDir()
+		filename1 := path.Join(tmpdir, "key1.pem")
+		require.NoError(os.WriteFile(filename1, []byte("Hello world!"), 0644))
+
+		modified := make(chan string, 1)
+		w, err := NewDirectoryWatcher(path.Join(tmpdir, "*.pem"), func(filename string) {
+			modified <- filename
+		})
+		require.NoError(err)
+		defer w.Close()
+
+		require.NoError(os.WriteFile(filename1, []byte("Updated"), 0644))
+		waitForWatchedFile(t, modified, filename1)
+		checkNoWatchedFile(t, modified)
+
+		// Files added later are also watched.
+		filename2 := path.Join(tmpdir, "key2.pem")
+		require.NoError(os.WriteFile(filename2, []byte("Hello world!"), 0644))
+		waitForWatchedFile(t, modified, filename2)
+		checkNoWatchedFile(t, modified)
+
+		// Files not matching the pattern are ignored.
+		require.NoError(os.WriteFile(path.Join(tmpdir, "test.txt"), []byte("Hello world!"), 0644))
+		checkNoWatchedFile(t, modified)
+
+		require.NoError(os.Remove(filename1))
+		waitForWatchedFile(t, modified, filename1)
+		checkNoWatchedFile(t, modified)
+	})
+}
+
+func TestDirectoryWatcher_Directory(t *testing.T) {
+	require := require.New(t)
+	tmpdir := t.TempDir()
+
+	modified := make(chan string, 1)
+	w, err := NewDirectoryWatcher(tmpdir, func(filename string) {
+		modified <- filename
+	})
+	require.NoError(err)
+	defer w.Close()
+
+	filename := path.Join(tmpdir, "test.txt")
+	require.NoError(os.WriteFile(filename, []byte("Hello world!"), 0644))
+	waitForWatchedFile(t, modified, filename)
+	checkNoWatchedFile(t, modified)
+
+	// Hidden files and subdirectories are ignored.
+	require.NoError(os.WriteFile(path.Join(tmpdir, ".hidden"), []byte("Hello world!"), 0644))
+	require.NoError(os.Mkdir(path.Join(tmpdir, "subdir"), 0755))
+	checkNoWatchedFile(t, modified)
+}
+
+func TestDirectoryWatcher_UpdateSymlinkFolder(t *testing.T) {
+	// This mimics what k8s is doing with configmaps / secrets.
+	require := require.New(t)
+	tmpdir := t.TempDir()
+
+	version1Path := path.Join(tmpdir, "..version1")
+	require.NoError(os.Mkdir(version1Path, 0755))
+	require.NoError(os.WriteFile(path.Join(version1Path, "key.pem"), []byte("Hello world!"), 0644))
+
+	dataPath := path.Join(tmpdir, "..data")
+	require.NoError(os.Symlink("..version1", dataPath))
+
+	filename := path.Join(tmpdir, "key.pem")
+	require.NoError(os.Symlink("..data/key.pem", filename))
+
+	modified := make(chan string, 1)
+	w, err := NewDirectoryWatcher(tmpdir, func(filename string) {
+		modified <- filename
+	})
+	require.NoError(err)
+	defer w.Close()
+
+	version2Path := path.Join(tmpdir, "..version2")
+	require.NoError(os.Mkdir(version2Path, 0755))
+	require.NoError(os.WriteFile(path.Join(version2Path, "key.pem"), []byte("Updated"), 0644))
+	checkNoWatchedFile(t, modified)
+
+	require.NoError(os.Symlink("..version2", dataPath+".tmp"))
+	require.NoError(os.Rename(dataPath+".tmp", dataPath))
+	require.NoError(os.RemoveAll(version1Path))
+
+	waitForWatchedFile(t, modified, filename)
+	checkNoWatchedFile(t, modified)
+}
+
+func TestConfigureFileWatcher(t *testing.T) {
+	assert := assert.New(t)
+	config := goconf.NewConfigFile()
+	config.AddOption("app", "filewatcherpolling", "invalid")
+	assert.Error(ConfigureFileWatcher(config))
+	assert.Equal(FileWatcherPollingAuto, fileWatcherPolling.Load())
+
+	config.AddOption("app", "filewatcherpolling", "never")
+	config.AddOption("app", "filewatcherpollinterval", "2")
+	assert.NoError(ConfigureFileWatcher(config))
+	t.Cleanup(func() {
+		assert.NoError(ConfigureFileWatcher(goconf.NewConfigFile()))
+	})
+	if interval, polling := usePolling(t.TempDir()); assert.False(polling) {
+		assert.EqualValues(0, interval)
+	}
+	assert.False(isNetworkFilesystem(t.TempDir()))
+}
+
+func TestFileWatcher_Polling(t *testing.T) {
+	ensureNoGoroutinesLeak(t, func(t *testing.T) {
+		require := require.New(t)
+		tmpdir := t.TempDir()
+		filename := path.Join(tmpdir, "test.txt")
+		require.NoError(os.WriteFile(filename, []byte("Hello world!"), 0644))
+
+		modified := make(chan string, 1)
+		w, err := newPollingWatcher(filename, "", "", func(filename string) {
+			modified <- filename
+		}, time.Millisecond)
+		require.NoError(err)
+		defer w.Close()
+
+		require.NoError(os.WriteFile(filename, []byte("Updated"), 0644))
+		waitForWatchedFile(t, modified, filename)
+		checkNoWatchedFile(t, modified)
+
+		// Writing the same contents doesn't trigger the callback.
+		require.NoError(os.WriteFile(filename, []byte("Updated"), 0644))
+		checkNoWatchedFile(t, modified)
+
+		// Changing the target of a symlink triggers the callback.
+		target := path.Join(tmpdir, "target.txt")
+		require.NoError(os.WriteFile(target, []byte("Hello world!"), 0644))
+		require.NoError(os.Symlink(target, filename+".tmp"))
+		require.NoError(os.Rename(filename+".tmp", filename))
+		waitForWatchedFile(t, modified, filename)
+		checkNoWatchedFile(t, modified)
+	})
+}
+
+func TestFileWatcher_PollingNotExist(t *testing.T) {
+	assert := assert.New(t)
+	tmpdir := t.TempDir()
+	if w, err := newPollingWatcher(path.Join(tmpdir, "test.txt"), "", "", func(filename string) {}, time.Millisecond); !assert.ErrorIs(err, os.ErrNotExist) {
+		if w != nil {
+			assert.NoError(w.Close())
+		}
+	}
+}
+
+func TestDirectoryWatcher_Polling(t *testing.T) {
+	ensureNoGoroutinesLeak(t, func(t *testing.T) {
+		require := require.New(t)
+		tmpdir := t.TempDir()
+		filename1 := path.Join(tmpdir, "key1.pem")
+		require.NoError(os.WriteFile(filename1, []byte("Hello world!"), 0644))
+
+		modified := make(chan string, 1)
+		w, err := newPollingWatcher(path.Join(tmpdir, "*.pem"), tmpdir, "*.pem", func(filename string) {
+			modified <- filename
+		}, time.Millisecond)
+		require.NoError(err)
+		defer w.Close()
+
+		require.NoError(os.WriteFile(filename1, []byte("Updated"), 0644))
+		waitForWatchedFile(t, modified, filename1)
+		checkNoWatchedFile(t, modified)
+
+		filename2 := path.Join(tmpdir, "key2.pem")
+		require.NoError(os.WriteFile(filename2, []byte("Hello world!"), 0644))
+		waitForWatchedFile(t, modified, filename2)
+		checkNoWatchedFile(t, modified)
+
+		require.NoError(os.WriteFile(path.Join(tmpdir, "test.txt"), []byte("Hello world!"), 0644))
+		checkNoWatchedFile(t, modified)
+
+		require.NoError(os.Remove(filename1))
+		waitForWatchedFile(t, modified, filename1)
+		checkNoWatchedFile(t, modified)
+	})
+}
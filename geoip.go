@@ -24,26 +24,41 @@ package signaling
 import (
 	"archive/tar"
 	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
-	"log"
 	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
-	"github.com/dlintw/goconf"
 	"github.com/oschwald/maxminddb-golang"
 )
 
+const (
+	defaultGeoIpUpdateInterval = 24 * time.Hour
+
+	// Maximum size of a checksum file, they only contain the hash and filename.
+	maxGeoIpChecksumSize = 1024
+)
+
 var (
 	ErrDatabaseNotInitialized = fmt.Errorf("GeoIP database not initialized yet")
+
+	// Build time of the currently used GeoIP database (in seconds since the epoch).
+	geoipDatabaseBuildEpoch atomic.Int64
 )
 
-func GetGeoIpDownloadUrl(license string) string {
+func init() {
+	RegisterGeoIPStats()
+}
+
+func getGeoIpDownloadUrl(license string, suffix string) string {
 	if license == "" {
 		return ""
 	}
@@ -51,25 +66,41 @@ func GetGeoIpDownloadUrl(license string) string {
 	result := "https://download.maxmind.com/app/geoip_download"
 	result += "?edition_id=GeoLite2-Country"
 	result += "&license_key=" + url.QueryEscape(license)
-	result += "&suffix=tar.gz"
+	result += "&suffix=" + suffix
 	return result
 }
 
+func GetGeoIpDownloadUrl(license string) string {
+	return getGeoIpDownloadUrl(license, "tar.gz")
+}
+
+// GetGeoIpChecksumUrl returns the url of the SHA256 checksum for the database
+// returned by GetGeoIpDownloadUrl.
+func GetGeoIpChecksumUrl(license string) string {
+	return getGeoIpDownloadUrl(license, "tar.gz.sha256")
+}
+
 type GeoLookup struct {
-	url    string
-	isFile bool
-	client http.Client
-	mu     sync.Mutex
+	url         string
+	checksumUrl string
+	isFile      bool
+	client      http.Client
+	mu          sync.Mutex
 
 	lastModifiedHeader string
 	lastModifiedTime   time.Time
+	lastChecksum       string
 
 	reader *maxminddb.Reader
 }
 
-func NewGeoLookupFromUrl(url string) (*GeoLookup, error) {
+// NewGeoLookupFromUrl creates a lookup that downloads the database from the
+// given url. If a checksum url is given, the download will be verified against
+// the SHA256 checksum from it and is skipped if the checksum didn't change.
+func NewGeoLookupFromUrl(url string, checksumUrl string) (*GeoLookup, error) {
 	geoip := &GeoLookup{
-		url: url,
+		url:         url,
+		checksumUrl: checksumUrl,
 	}
 	return geoip, nil
 }
@@ -95,6 +126,13 @@ func (g *GeoLookup) Close() {
 	g.mu.Unlock()
 }
 
+// IsLoaded returns true if a database is available for lookups.
+func (g *GeoLookup) IsLoaded() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.reader != nil
+}
+
 func (g *GeoLookup) Update() error {
 	if g.isFile {
 		return g.updateFile()
@@ -122,20 +160,69 @@ func (g *GeoLookup) updateFile() error {
 		return err
 	}
 
+	g.setReader(reader)
+	g.lastModifiedTime = info.ModTime()
+	return nil
+}
+
+// setReader replaces the current database with a new one that was verified.
+func (g *GeoLookup) setReader(reader *maxminddb.Reader) {
 	metadata := reader.Metadata
-	log.Printf("Using %s GeoIP database from %s (built on %s)", metadata.DatabaseType, g.url, time.Unix(int64(metadata.BuildEpoch), 0).UTC())
+	appLog.Infof("Using %s GeoIP database from %s (built on %s)", metadata.DatabaseType, g.url, time.Unix(int64(metadata.BuildEpoch), 0).UTC())
 
 	g.mu.Lock()
 	if g.reader != nil {
 		g.reader.Close()
 	}
 	g.reader = reader
-	g.lastModifiedTime = info.ModTime()
 	g.mu.Unlock()
-	return nil
+	geoipDatabaseBuildEpoch.Store(int64(metadata.BuildEpoch))
+}
+
+func (g *GeoLookup) downloadChecksum() (string, error) {
+	response, err := g.client.Get(g.checksumUrl)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode/100 != 2 {
+		return "", fmt.Errorf("downloading checksum from %s returned an error: %s", g.checksumUrl, response.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(response.Body, maxGeoIpChecksumSize))
+	if err != nil {
+		return "", err
+	}
+
+	// The file contains the checksum and optionally the filename.
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("no checksum found in %s", g.checksumUrl)
+	}
+
+	checksum := strings.ToLower(fields[0])
+	if decoded, err := hex.DecodeString(checksum); err != nil || len(decoded) != sha256.Size {
+		return "", fmt.Errorf("invalid checksum %s in %s", fields[0], g.checksumUrl)
+	}
+
+	return checksum, nil
 }
 
 func (g *GeoLookup) updateUrl() error {
+	var checksum string
+	if g.checksumUrl != "" {
+		var err error
+		if checksum, err = g.downloadChecksum(); err != nil {
+			return err
+		}
+
+		if checksum == g.lastChecksum {
+			appLog.Infof("GeoIP database at %s has not changed", g.url)
+			return nil
+		}
+	}
+
 	request, err := http.NewRequest("GET", g.url, nil)
 	if err != nil {
 		return err
@@ -150,13 +237,15 @@ func (g *GeoLookup) updateUrl() error {
 	defer response.Body.Close()
 
 	if response.StatusCode == http.StatusNotModified {
-		log.Printf("GeoIP database at %s has not changed", g.url)
+		appLog.Infof("GeoIP database at %s has not changed", g.url)
 		return nil
 	} else if response.StatusCode/100 != 2 {
 		return fmt.Errorf("downloading %s returned an error: %s", g.url, response.Status)
 	}
 
-	body := response.Body
+	hash := sha256.New()
+	download := io.TeeReader(response.Body, hash)
+	body := download
 	url := g.url
 	if strings.HasSuffix(url, ".gz") {
 		body, err = gzip.NewReader(body)
@@ -194,6 +283,17 @@ func (g *GeoLookup) updateUrl() error {
 		}
 	}
 
+	if checksum != "" {
+		// The checksum is calculated over the complete download.
+		if _, err := io.Copy(io.Discard, download); err != nil {
+			return err
+		}
+
+		if actual := hex.EncodeToString(hash.Sum(nil)); actual != checksum {
+			return fmt.Errorf("checksum mismatch for GeoIP database from %s: expected %s, got %s", g.url, checksum, actual)
+		}
+	}
+
 	if len(geoipdata) == 0 {
 		return fmt.Errorf("did not find GeoIP database in download from %s", g.url)
 	}
@@ -207,16 +307,9 @@ func (g *GeoLookup) updateUrl() error {
 		return err
 	}
 
-	metadata := reader.Metadata
-	log.Printf("Using %s GeoIP database from %s (built on %s)", metadata.DatabaseType, g.url, time.Unix(int64(metadata.BuildEpoch), 0).UTC())
-
-	g.mu.Lock()
-	if g.reader != nil {
-		g.reader.Close()
-	}
-	g.reader = reader
+	g.setReader(reader)
 	g.lastModifiedHeader = response.Header.Get("Last-Modified")
-	g.mu.Unlock()
+	g.lastChecksum = checksum
 	return nil
 }
 
@@ -250,6 +343,24 @@ func LookupContinents(country string) []string {
 	return continents
 }
 
+const (
+	statsUnknownLabel = "unknown"
+)
+
+// getStatsCountryLabels returns the country and continent labels to use in
+// metrics. Countries on multiple continents are reported for the first one.
+func getStatsCountryLabels(country string) (string, string) {
+	if !IsValidCountry(country) {
+		return statsUnknownLabel, statsUnknownLabel
+	}
+
+	continent := statsUnknownLabel
+	if continents := LookupContinents(country); len(continents) > 0 {
+		continent = continents[0]
+	}
+	return country, continent
+}
+
 func IsValidContinent(continent string) bool {
 	switch continent {
 	case "AF":
@@ -277,63 +388,3 @@ func IsValidContinent(continent string) bool {
 		return false
 	}
 }
-
-func LoadGeoIPOverrides(config *goconf.ConfigFile, ignoreErrors bool) (map[*net.IPNet]string, error) {
-	options, _ := GetStringOptions(config, "geoip-overrides", true)
-	if len(options) == 0 {
-		return nil, nil
-	}
-
-	var err error
-	geoipOverrides := make(map[*net.IPNet]string, len(options))
-	for option, value := range options {
-		var ip net.IP
-		var ipNet *net.IPNet
-		if strings.Contains(option, "/") {
-			_, ipNet, err = net.ParseCIDR(option)
-			if err != nil {
-				if ignoreErrors {
-					log.Printf("could not parse CIDR %s (%s), skipping", option, err)
-					continue
-				}
-
-				return nil, fmt.Errorf("could not parse CIDR %s: %s", option, err)
-			}
-		} else {
-			ip = net.ParseIP(option)
-			if ip == nil {
-				if ignoreErrors {
-					log.Printf("could not parse IP %s, skipping", option)
-					continue
-				}
-
-				return nil, fmt.Errorf("could not parse IP %s", option)
-			}
-
-			var mask net.IPMask
-			if ipv4 := ip.To4(); ipv4 != nil {
-				mask = net.CIDRMask(32, 32)
-			} else {
-				mask = net.CIDRMask(128, 128)
-			}
-			ipNet = &net.IPNet{
-				IP:   ip,
-				Mask: mask,
-			}
-		}
-
-		value = strings.ToUpper(strings.TrimSpace(value))
-		if value == "" {
-			log.Printf("IP %s doesn't have a country assigned, skipping", option)
-			continue
-		} else if !IsValidCountry(value) {
-			log.Printf("Country %s for IP %s is invalid, skipping", value, option)
-			continue
-		}
-
-		log.Printf("Using country %s for %s", value, ipNet)
-		geoipOverrides[ipNet] = value
-	}
-
-	return geoipOverrides, nil
-}
@@ -0,0 +1,350 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2017 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"bufio"
+	"fmt"
+	"maps"
+	"net"
+	"net/netip"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode"
+
+	"github.com/dlintw/goconf"
+)
+
+// GeoIPOverrides maps IP ranges to countries. Lookups return the country of
+// the most specific range that contains an address, so large ranges can be
+// refined by smaller ones.
+type GeoIPOverrides struct {
+	// Countries by prefix length and masked prefix.
+	prefixes map[int]map[netip.Prefix]string
+	// Prefix lengths in use, longest first.
+	bits []int
+	size int
+}
+
+func NewGeoIPOverrides(overrides map[netip.Prefix]string) *GeoIPOverrides {
+	result := &GeoIPOverrides{
+		prefixes: make(map[int]map[netip.Prefix]string),
+	}
+	for prefix, country := range overrides {
+		bits := prefix.Bits()
+		entries, found := result.prefixes[bits]
+		if !found {
+			entries = make(map[netip.Prefix]string)
+			result.prefixes[bits] = entries
+			result.bits = append(result.bits, bits)
+		}
+		entries[prefix.Masked()] = country
+		result.size++
+	}
+	slices.Sort(result.bits)
+	slices.Reverse(result.bits)
+	return result
+}
+
+func (o *GeoIPOverrides) Len() int {
+	if o == nil {
+		return 0
+	}
+
+	return o.size
+}
+
+// Lookup returns the country of the most specific range containing the IP.
+func (o *GeoIPOverrides) Lookup(ip net.IP) (string, bool) {
+	if o.Len() == 0 {
+		return "", false
+	}
+
+	addr, ok := netip.AddrFromSlice(ip)
+	if !ok {
+		return "", false
+	}
+
+	addr = addr.Unmap()
+	for _, bits := range o.bits {
+		prefix, err := addr.Prefix(bits)
+		if err != nil {
+			// Prefix is longer than the address (e.g. IPv6 range for IPv4 address).
+			continue
+		}
+
+		if country, found := o.prefixes[bits][prefix]; found {
+			return country, true
+		}
+	}
+	return "", false
+}
+
+// parseGeoIPOverride parses an IP address or CIDR range and the country that
+// should be used for it. The country is empty if it should be skipped.
+func parseGeoIPOverride(ip string, country string) (netip.Prefix, string, error) {
+	var prefix netip.Prefix
+	if strings.Contains(ip, "/") {
+		p, err := netip.ParsePrefix(ip)
+		if err != nil {
+			return prefix, "", fmt.Errorf("could not parse CIDR %s: %w", ip, err)
+		}
+		prefix = p.Masked()
+	} else {
+		addr, err := netip.ParseAddr(ip)
+		if err != nil {
+			return prefix, "", fmt.Errorf("could not parse IP %s", ip)
+		}
+		addr = addr.Unmap()
+		prefix = netip.PrefixFrom(addr, addr.BitLen())
+	}
+
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if country == "" {
+		appLog.Infof("IP %s doesn't have a country assigned, skipping", ip)
+		return prefix, "", nil
+	} else if !IsValidCountry(country) {
+		appLog.Infof("Country %s for IP %s is invalid, skipping", country, ip)
+		return prefix, "", nil
+	}
+
+	return prefix, country, nil
+}
+
+func parseGeoIPOverrides(overrides map[string]string, ignoreErrors bool) (map[netip.Prefix]string, error) {
+	result := make(map[netip.Prefix]string, len(overrides))
+	for ip, country := range overrides {
+		prefix, country, err := parseGeoIPOverride(ip, country)
+		if err != nil {
+			if ignoreErrors {
+				appLog.Errorf("%s, skipping", err)
+				continue
+			}
+
+			return nil, err
+		} else if country == "" {
+			continue
+		}
+
+		result[prefix] = country
+	}
+	return result, nil
+}
+
+func LoadGeoIPOverrides(config *goconf.ConfigFile, ignoreErrors bool) (map[netip.Prefix]string, error) {
+	options, _ := GetStringOptions(config, "geoip-overrides", true)
+	if len(options) == 0 {
+		return nil, nil
+	}
+
+	return parseGeoIPOverrides(options, ignoreErrors)
+}
+
+// LoadGeoIPOverridesFile reads overrides from a file that contains an IP
+// address or CIDR range and the country on each line, separated by
+// whitespace, "=" or ",". Empty lines and lines starting with "#" are ignored.
+func LoadGeoIPOverridesFile(filename string, ignoreErrors bool) (map[netip.Prefix]string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	overrides := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for lineno := 1; scanner.Scan(); lineno++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		fields := strings.FieldsFunc(line, func(r rune) bool {
+			return unicode.IsSpace(r) || r == '=' || r == ','
+		})
+		if len(fields) != 2 {
+			if ignoreErrors {
+				appLog.Errorf("Invalid GeoIP override in line %d of %s, skipping", lineno, filename)
+				continue
+			}
+
+			return nil, fmt.Errorf("invalid GeoIP override in line %d of %s", lineno, filename)
+		}
+
+		overrides[fields[0]] = fields[1]
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return parseGeoIPOverrides(overrides, ignoreErrors)
+}
+
+// GeoIPOverridesManager combines the GeoIP overrides from the configuration,
+// an optional file that is reloaded when it changes and overrides set through
+// the admin API. Later sources take precedence for identical ranges.
+type GeoIPOverridesManager struct {
+	mu       sync.Mutex
+	filename string
+	watcher  *FileWatcher
+	config   map[netip.Prefix]string
+	file     map[netip.Prefix]string
+	api      map[netip.Prefix]string
+
+	overrides atomic.Pointer[GeoIPOverrides]
+}
+
+func NewGeoIPOverridesManager(config *goconf.ConfigFile) (*GeoIPOverridesManager, error) {
+	overrides, err := LoadGeoIPOverrides(config, false)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &GeoIPOverridesManager{
+		config: overrides,
+	}
+	if filename, _ := config.GetString("geoip", "overridesfile"); filename != "" {
+		if m.file, err = LoadGeoIPOverridesFile(filename, false); err != nil {
+			return nil, err
+		}
+
+		if err := m.watchFile(filename); err != nil {
+			return nil, err
+		}
+	}
+	m.updateOverrides()
+	return m, nil
+}
+
+func (m *GeoIPOverridesManager) Close() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.watcher != nil {
+		m.watcher.Close()
+		m.watcher = nil
+	}
+}
+
+func (m *GeoIPOverridesManager) watchFile(filename string) error {
+	watcher, err := NewFileWatcher(filename, m.reloadFile)
+	if err != nil {
+		return err
+	}
+
+	if m.watcher != nil {
+		m.watcher.Close()
+	}
+	m.filename = filename
+	m.watcher = watcher
+	return nil
+}
+
+func (m *GeoIPOverridesManager) reloadFile(filename string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.filename == "" {
+		return
+	}
+
+	overrides, err := LoadGeoIPOverridesFile(m.filename, true)
+	if err != nil {
+		appLog.Errorf("Could not reload GeoIP overrides from %s: %s", m.filename, err)
+		return
+	}
+
+	appLog.Infof("Reloaded %d GeoIP overrides from %s", len(overrides), m.filename)
+	m.file = overrides
+	m.updateOverrides()
+}
+
+func (m *GeoIPOverridesManager) Reload(config *goconf.ConfigFile) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.config, _ = LoadGeoIPOverrides(config, true)
+	filename, _ := config.GetString("geoip", "overridesfile")
+	if filename == "" {
+		if m.watcher != nil {
+			m.watcher.Close()
+			m.watcher = nil
+		}
+		m.filename = ""
+		m.file = nil
+	} else if overrides, err := LoadGeoIPOverridesFile(filename, true); err != nil {
+		appLog.Errorf("Could not load GeoIP overrides from %s: %s", filename, err)
+	} else {
+		m.file = overrides
+		if filename != m.filename {
+			if err := m.watchFile(filename); err != nil {
+				appLog.Errorf("Could not watch GeoIP overrides in %s: %s", filename, err)
+			}
+		}
+	}
+	m.updateOverrides()
+}
+
+func (m *GeoIPOverridesManager) updateOverrides() {
+	overrides := make(map[netip.Prefix]string, len(m.config)+len(m.file)+len(m.api))
+	maps.Copy(overrides, m.config)
+	maps.Copy(overrides, m.file)
+	maps.Copy(overrides, m.api)
+	if len(overrides) > 0 {
+		appLog.Infof("Using %d GeoIP overrides", len(overrides))
+	}
+	m.overrides.Store(NewGeoIPOverrides(overrides))
+}
+
+func (m *GeoIPOverridesManager) Lookup(ip net.IP) (string, bool) {
+	return m.overrides.Load().Lookup(ip)
+}
+
+// SetApiOverrides replaces the overrides that were set through the admin API.
+func (m *GeoIPOverridesManager) SetApiOverrides(overrides map[string]string) error {
+	parsed, err := parseGeoIPOverrides(overrides, false)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.api = parsed
+	m.updateOverrides()
+	return nil
+}
+
+// GetOverrides returns the combined overrides from all sources.
+func (m *GeoIPOverridesManager) GetOverrides() map[string]string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make(map[string]string, len(m.config)+len(m.file)+len(m.api))
+	for _, overrides := range []map[netip.Prefix]string{m.config, m.file, m.api} {
+		for prefix, country := range overrides {
+			result[prefix.String()] = country
+		}
+	}
+	return result
+}
@@ -0,0 +1,161 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2017 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dlintw/goconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeoIPOverrides(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+
+	parsed, err := parseGeoIPOverrides(map[string]string{
+		"10.0.0.0/8":      "DE",
+		"10.1.0.0/16":     "IT",
+		"10.1.2.3":        "site1",
+		"2001:db8::/32":   "FR",
+		"2001:db8:1::/48": "ES",
+		"192.168.0.1":     "",
+	}, false)
+	require.NoError(err)
+	overrides := NewGeoIPOverrides(parsed)
+	assert.Equal(5, overrides.Len())
+
+	testcases := map[string]string{
+		"10.3.4.5":        "DE",
+		"10.1.4.5":        "IT",
+		"10.1.2.3":        "SITE1",
+		"::ffff:10.1.2.3": "SITE1",
+		"2001:db8:2::1":   "FR",
+		"2001:db8:1::1":   "ES",
+		"192.168.0.1":     "",
+		"8.8.8.8":         "",
+	}
+	for ip, expected := range testcases {
+		country, found := overrides.Lookup(net.ParseIP(ip))
+		assert.Equal(expected != "", found, "failed for %s", ip)
+		assert.Equal(expected, country, "failed for %s", ip)
+	}
+
+	var empty *GeoIPOverrides
+	_, found := empty.Lookup(net.ParseIP("10.1.2.3"))
+	assert.False(found)
+
+	_, err = parseGeoIPOverrides(map[string]string{
+		"10.0.0.0/33": "DE",
+	}, false)
+	assert.ErrorContains(err, "could not parse CIDR")
+	_, err = parseGeoIPOverrides(map[string]string{
+		"invalid": "DE",
+	}, false)
+	assert.ErrorContains(err, "could not parse IP")
+}
+
+func TestLoadGeoIPOverridesFile(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+
+	filename := filepath.Join(t.TempDir(), "overrides.txt")
+	require.NoError(os.WriteFile(filename, []byte(`# Corporate VPN
+10.0.0.0/8 DE
+10.1.0.0/16 = IT
+192.168.0.0/24,FR
+
+invalid-line
+`), 0644))
+
+	_, err := LoadGeoIPOverridesFile(filename, false)
+	assert.ErrorContains(err, "line 6")
+
+	overrides, err := LoadGeoIPOverridesFile(filename, true)
+	require.NoError(err)
+	assert.Len(overrides, 3)
+	country, found := NewGeoIPOverrides(overrides).Lookup(net.ParseIP("192.168.0.10"))
+	assert.True(found)
+	assert.Equal("FR", country)
+}
+
+func TestGeoIPOverridesManager(t *testing.T) {
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+
+	filename := filepath.Join(t.TempDir(), "overrides.txt")
+	require.NoError(os.WriteFile(filename, []byte("10.0.0.0/8 DE\n"), 0644))
+
+	config := goconf.NewConfigFile()
+	config.AddOption("geoip", "overridesfile", filename)
+	config.AddOption("geoip-overrides", "10.0.0.0/8", "IT")
+	config.AddOption("geoip-overrides", "192.168.0.1", "FR")
+
+	UpdateCertificateCheckIntervalForTest(t, 0)
+	m, err := NewGeoIPOverridesManager(config)
+	require.NoError(err)
+	defer m.Close()
+
+	lookup := func(ip string) string {
+		country, _ := m.Lookup(net.ParseIP(ip))
+		return country
+	}
+
+	// Entries from the file take precedence over the configuration.
+	assert.Equal("DE", lookup("10.1.2.3"))
+	assert.Equal("FR", lookup("192.168.0.1"))
+
+	require.NoError(os.WriteFile(filename, []byte("10.0.0.0/8 ES\n10.1.0.0/16 PT\n"), 0644))
+	assert.Eventually(func() bool {
+		return lookup("10.1.2.3") == "PT"
+	}, time.Second, time.Millisecond)
+	assert.Equal("ES", lookup("10.2.3.4"))
+
+	// Entries from the API take precedence over the file.
+	assert.Error(m.SetApiOverrides(map[string]string{
+		"invalid": "DE",
+	}))
+	require.NoError(m.SetApiOverrides(map[string]string{
+		"10.1.0.0/16": "GB",
+	}))
+	assert.Equal("GB", lookup("10.1.2.3"))
+	assert.Equal(map[string]string{
+		"10.0.0.0/8":     "ES",
+		"10.1.0.0/16":    "GB",
+		"192.168.0.1/32": "FR",
+	}, m.GetOverrides())
+
+	config.RemoveOption("geoip", "overridesfile")
+	m.Reload(config)
+	assert.Equal("IT", lookup("10.2.3.4"))
+	assert.Equal("GB", lookup("10.1.2.3"))
+}
@@ -0,0 +1,54 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2017 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	statsGeoIPDatabaseAgeSeconds = prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: "signaling",
+		Subsystem: "geoip",
+		Name:      "database_age_seconds",
+		Help:      "The time in seconds since the used GeoIP database was built",
+	}, getGeoIPDatabaseAge)
+
+	geoipStats = []prometheus.Collector{
+		statsGeoIPDatabaseAgeSeconds,
+	}
+)
+
+func getGeoIPDatabaseAge() float64 {
+	epoch := geoipDatabaseBuildEpoch.Load()
+	if epoch == 0 {
+		return 0
+	}
+
+	return time.Since(time.Unix(epoch, 0)).Seconds()
+}
+
+func RegisterGeoIPStats() {
+	registerAll(geoipStats...)
+}
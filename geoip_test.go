@@ -24,12 +24,16 @@ package signaling
 import (
 	"archive/tar"
 	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -78,7 +82,7 @@ func GetGeoIpUrlForTest(t *testing.T) string {
 func TestGeoLookup(t *testing.T) {
 	CatchLogForTest(t)
 	require := require.New(t)
-	reader, err := NewGeoLookupFromUrl(GetGeoIpUrlForTest(t))
+	reader, err := NewGeoLookupFromUrl(GetGeoIpUrlForTest(t), "")
 	require.NoError(err)
 	defer reader.Close()
 
@@ -90,7 +94,7 @@ func TestGeoLookup(t *testing.T) {
 func TestGeoLookupCaching(t *testing.T) {
 	CatchLogForTest(t)
 	require := require.New(t)
-	reader, err := NewGeoLookupFromUrl(GetGeoIpUrlForTest(t))
+	reader, err := NewGeoLookupFromUrl(GetGeoIpUrlForTest(t), "")
 	require.NoError(err)
 	defer reader.Close()
 
@@ -127,11 +131,73 @@ func TestGeoLookupContinent(t *testing.T) {
 
 func TestGeoLookupCloseEmpty(t *testing.T) {
 	CatchLogForTest(t)
-	reader, err := NewGeoLookupFromUrl("ignore-url")
+	reader, err := NewGeoLookupFromUrl("ignore-url", "")
 	require.NoError(t, err)
 	reader.Close()
 }
 
+func TestGeoLookupChecksum(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	assert := assert.New(t)
+
+	data := []byte("the-database")
+	dataChecksum := sha256.Sum256(data)
+	checksum := hex.EncodeToString(dataChecksum[:])
+	var downloads atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/GeoLite2-Country.mmdb", func(w http.ResponseWriter, r *http.Request) {
+		downloads.Add(1)
+		w.Write(data) // nolint
+	})
+	mux.HandleFunc("/GeoLite2-Country.mmdb.sha256", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s  GeoLite2-Country.mmdb\n", checksum)
+	})
+	mux.HandleFunc("/mismatch.sha256", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%s  GeoLite2-Country.mmdb\n", strings.Repeat("0", sha256.Size*2))
+	})
+	mux.HandleFunc("/invalid.sha256", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "invalid-checksum")
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	reader, err := NewGeoLookupFromUrl(server.URL+"/GeoLite2-Country.mmdb", server.URL+"/mismatch.sha256")
+	require.NoError(t, err)
+	defer reader.Close()
+	assert.ErrorContains(reader.Update(), "checksum mismatch")
+	assert.False(reader.IsLoaded())
+
+	reader.checksumUrl = server.URL + "/invalid.sha256"
+	assert.ErrorContains(reader.Update(), "invalid checksum")
+
+	// The checksum matches but the data is not a valid database.
+	reader.checksumUrl = server.URL + "/GeoLite2-Country.mmdb.sha256"
+	if err := reader.Update(); assert.Error(err) {
+		assert.NotContains(err.Error(), "checksum")
+	}
+	assert.EqualValues(2, downloads.Load())
+
+	// The database is not downloaded again if the checksum didn't change.
+	reader.lastChecksum = checksum
+	assert.NoError(reader.Update())
+	assert.EqualValues(2, downloads.Load())
+}
+
+func TestGeoIPDatabaseAge(t *testing.T) {
+	assert := assert.New(t)
+	old := geoipDatabaseBuildEpoch.Load()
+	t.Cleanup(func() {
+		geoipDatabaseBuildEpoch.Store(old)
+	})
+
+	geoipDatabaseBuildEpoch.Store(0)
+	assert.Zero(getGeoIPDatabaseAge())
+
+	geoipDatabaseBuildEpoch.Store(time.Now().Add(-time.Hour).Unix())
+	assert.InDelta(time.Hour.Seconds(), getGeoIPDatabaseAge(), 5)
+}
+
 func TestGeoLookupFromFile(t *testing.T) {
 	CatchLogForTest(t)
 	require := require.New(t)
@@ -202,3 +268,27 @@ func TestIsValidContinent(t *testing.T) {
 		}
 	}
 }
+
+func TestStatsCountryLabels(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	testcases := []struct {
+		country   string
+		expected  string
+		continent string
+	}{
+		{"DE", "DE", "EU"},
+		{"US", "US", "NA"},
+		// Countries on multiple continents use the first one.
+		{"RU", "RU", ContinentMap["RU"][0]},
+		{"", statsUnknownLabel, statsUnknownLabel},
+		{loopback, statsUnknownLabel, statsUnknownLabel},
+		{unknownCountry, statsUnknownLabel, statsUnknownLabel},
+		{"SITE1", "SITE1", statsUnknownLabel},
+	}
+	for _, tc := range testcases {
+		country, continent := getStatsCountryLabels(tc.country)
+		assert.Equal(tc.expected, country, "failed for %s", tc.country)
+		assert.Equal(tc.continent, continent, "failed for %s", tc.country)
+	}
+}
@@ -27,7 +27,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net"
 	"slices"
 	"sync"
@@ -36,6 +35,7 @@ import (
 
 	"github.com/dlintw/goconf"
 	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
@@ -48,6 +48,8 @@ import (
 const (
 	GrpcTargetTypeStatic = "static"
 	GrpcTargetTypeEtcd   = "etcd"
+	GrpcTargetTypeDnsSrv = "dnssrv"
+	GrpcTargetTypeConsul = "consul"
 
 	DefaultGrpcTargetType = GrpcTargetTypeStatic
 )
@@ -200,7 +202,7 @@ func (c *GrpcClient) GetServerId(ctx context.Context) (string, string, error) {
 func (c *GrpcClient) LookupResumeId(ctx context.Context, resumeId PrivateSessionId) (*LookupResumeIdReply, error) {
 	statsGrpcClientCalls.WithLabelValues("LookupResumeId").Inc()
 	// TODO: Remove debug logging
-	log.Printf("Lookup resume id %s on %s", resumeId, c.Target())
+	grpcLog.Infof("Lookup resume id %s on %s", resumeId, c.Target())
 	response, err := c.impl.LookupResumeId(ctx, &LookupResumeIdRequest{
 		ResumeId: string(resumeId),
 	}, grpc.WaitForReady(true))
@@ -220,7 +222,7 @@ func (c *GrpcClient) LookupResumeId(ctx context.Context, resumeId PrivateSession
 func (c *GrpcClient) LookupSessionId(ctx context.Context, roomSessionId RoomSessionId, disconnectReason string) (PublicSessionId, error) {
 	statsGrpcClientCalls.WithLabelValues("LookupSessionId").Inc()
 	// TODO: Remove debug logging
-	log.Printf("Lookup room session %s on %s", roomSessionId, c.Target())
+	grpcLog.Infof("Lookup room session %s on %s", roomSessionId, c.Target())
 	response, err := c.impl.LookupSessionId(ctx, &LookupSessionIdRequest{
 		RoomSessionId:    string(roomSessionId),
 		DisconnectReason: disconnectReason,
@@ -242,7 +244,7 @@ func (c *GrpcClient) LookupSessionId(ctx context.Context, roomSessionId RoomSess
 func (c *GrpcClient) IsSessionInCall(ctx context.Context, sessionId PublicSessionId, room *Room, backendUrl string) (bool, error) {
 	statsGrpcClientCalls.WithLabelValues("IsSessionInCall").Inc()
 	// TODO: Remove debug logging
-	log.Printf("Check if session %s is in call %s on %s", sessionId, room.Id(), c.Target())
+	grpcLog.Infof("Check if session %s is in call %s on %s", sessionId, room.Id(), c.Target())
 	response, err := c.impl.IsSessionInCall(ctx, &IsSessionInCallRequest{
 		SessionId:  string(sessionId),
 		RoomId:     room.Id(),
@@ -260,7 +262,7 @@ func (c *GrpcClient) IsSessionInCall(ctx context.Context, sessionId PublicSessio
 func (c *GrpcClient) GetInternalSessions(ctx context.Context, roomId string, backendUrls []string) (internal map[PublicSessionId]*InternalSessionData, virtual map[PublicSessionId]*VirtualSessionData, err error) {
 	statsGrpcClientCalls.WithLabelValues("GetInternalSessions").Inc()
 	// TODO: Remove debug logging
-	log.Printf("Get internal sessions for %s on %s", roomId, c.Target())
+	grpcLog.Infof("Get internal sessions for %s on %s", roomId, c.Target())
 	var backendUrl string
 	if len(backendUrls) > 0 {
 		backendUrl = backendUrls[0]
@@ -292,10 +294,46 @@ func (c *GrpcClient) GetInternalSessions(ctx context.Context, roomId string, bac
 	return
 }
 
+func (c *GrpcClient) GetTransientData(ctx context.Context, roomId string, backendUrls []string) (map[string]*TransientEntry, error) {
+	statsGrpcClientCalls.WithLabelValues("GetTransientData").Inc()
+	// TODO: Remove debug logging
+	grpcLog.Infof("Get transient data for %s on %s", roomId, c.Target())
+	response, err := c.impl.GetTransientData(ctx, &GetTransientDataRequest{
+		RoomId:      roomId,
+		BackendUrls: backendUrls,
+	}, grpc.WaitForReady(true))
+	if s, ok := status.FromError(err); ok && s.Code() == codes.NotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if len(response.Entries) == 0 {
+		return nil, nil
+	}
+
+	result := make(map[string]*TransientEntry, len(response.Entries))
+	for key, entry := range response.Entries {
+		var value any
+		if err := json.Unmarshal(entry.GetValue(), &value); err != nil {
+			return nil, fmt.Errorf("invalid transient data %s: %w", key, err)
+		}
+
+		e := &TransientEntry{
+			Value: value,
+		}
+		if expires := entry.GetExpires(); expires > 0 {
+			e.Expires = time.UnixMilli(expires)
+		}
+		result[key] = e
+	}
+	return result, nil
+}
+
 func (c *GrpcClient) GetPublisherId(ctx context.Context, sessionId PublicSessionId, streamType StreamType) (PublicSessionId, string, net.IP, string, string, error) {
 	statsGrpcClientCalls.WithLabelValues("GetPublisherId").Inc()
 	// TODO: Remove debug logging
-	log.Printf("Get %s publisher id %s on %s", streamType, sessionId, c.Target())
+	grpcLog.Infof("Get %s publisher id %s on %s", streamType, sessionId, c.Target())
 	response, err := c.impl.GetPublisherId(ctx, &GetPublisherIdRequest{
 		SessionId:  string(sessionId),
 		StreamType: string(streamType),
@@ -312,7 +350,7 @@ func (c *GrpcClient) GetPublisherId(ctx context.Context, sessionId PublicSession
 func (c *GrpcClient) GetSessionCount(ctx context.Context, url string) (uint32, error) {
 	statsGrpcClientCalls.WithLabelValues("GetSessionCount").Inc()
 	// TODO: Remove debug logging
-	log.Printf("Get session count for %s on %s", url, c.Target())
+	grpcLog.Infof("Get session count for %s on %s", url, c.Target())
 	response, err := c.impl.GetSessionCount(ctx, &GetSessionCountRequest{
 		Url: url,
 	}, grpc.WaitForReady(true))
@@ -347,7 +385,7 @@ func (p *SessionProxy) recvPump() {
 	defer func() {
 		p.receiver.OnProxyClose(closeError)
 		if err := p.Close(); err != nil {
-			log.Printf("Error closing proxy for session %s: %s", p.sessionId, err)
+			grpcLog.Errorf("Error closing proxy for session %s: %s", p.sessionId, err)
 		}
 	}()
 
@@ -358,13 +396,13 @@ func (p *SessionProxy) recvPump() {
 				break
 			}
 
-			log.Printf("Error receiving message from proxy for session %s: %s", p.sessionId, err)
+			grpcLog.Errorf("Error receiving message from proxy for session %s: %s", p.sessionId, err)
 			closeError = err
 			break
 		}
 
 		if err := p.receiver.OnProxyMessage(msg); err != nil {
-			log.Printf("Error processing message %+v from proxy for session %s: %s", msg, p.sessionId, err)
+			grpcLog.Errorf("Error processing message %+v from proxy for session %s: %s", msg, p.sessionId, err)
 		}
 	}
 }
@@ -420,6 +458,9 @@ type GrpcClients struct {
 	dnsMonitor   *DnsMonitor
 	dnsDiscovery bool
 
+	discovery       grpcTargetDiscovery
+	discoveryCancel context.CancelFunc
+
 	etcdClient        *EtcdClient
 	targetPrefix      string
 	targetInformation map[string]*GrpcTargetInformationEtcd
@@ -492,7 +533,10 @@ func (c *GrpcClients) load(config *goconf.ConfigFile, fromReload bool) error {
 	}
 	c.creds = creds
 
-	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	}
 	c.dialOptions.Store(opts)
 
 	targetType, _ := config.GetString("grpc", "targettype")
@@ -505,6 +549,10 @@ func (c *GrpcClients) load(config *goconf.ConfigFile, fromReload bool) error {
 		err = c.loadTargetsStatic(config, fromReload, opts...)
 	case GrpcTargetTypeEtcd:
 		err = c.loadTargetsEtcd(config, fromReload, opts...)
+	case GrpcTargetTypeDnsSrv:
+		fallthrough
+	case GrpcTargetTypeConsul:
+		err = c.loadTargetsDiscovery(config, targetType)
 	default:
 		err = fmt.Errorf("unknown GRPC target type: %s", targetType)
 	}
@@ -518,7 +566,7 @@ func (c *GrpcClients) closeClient(client *GrpcClient) {
 	}
 
 	if err := client.Close(); err != nil {
-		log.Printf("Error closing client to %s: %s", client.Target(), err)
+		grpcLog.Errorf("Error closing client to %s: %s", client.Target(), err)
 	}
 }
 
@@ -564,7 +612,7 @@ loop:
 				}
 
 				if status.Code(err) != codes.Canceled {
-					log.Printf("Error checking GRPC server id of %s, retrying in %s: %s", client.Target(), backoff.NextWait(), err)
+					grpcLog.Errorf("Error checking GRPC server id of %s, retrying in %s: %s", client.Target(), backoff.NextWait(), err)
 				}
 				backoff.Wait(ctx)
 				continue
@@ -572,13 +620,13 @@ loop:
 
 			client.version.Store(version)
 			if id == GrpcServerId {
-				log.Printf("GRPC target %s is this server, removing", client.Target())
+				grpcLog.Infof("GRPC target %s is this server, removing", client.Target())
 				c.closeClient(client)
 				client.SetSelf(true)
 			} else if version != c.version {
-				log.Printf("WARNING: Node %s is runing different version %s than local node (%s)", client.Target(), version, c.version)
+				grpcLog.Warnf("Node %s is runing different version %s than local node (%s)", client.Target(), version, c.version)
 			} else {
-				log.Printf("Checked GRPC server id of %s running version %s", client.Target(), version)
+				grpcLog.Infof("Checked GRPC server id of %s running version %s", client.Target(), version)
 			}
 			break loop
 		}
@@ -589,6 +637,8 @@ func (c *GrpcClients) loadTargetsStatic(config *goconf.ConfigFile, fromReload bo
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	c.stopDiscoveryLocked()
+
 	dnsDiscovery, _ := config.GetBool("grpc", "dnsdiscovery")
 	if dnsDiscovery != c.dnsDiscovery {
 		if !dnsDiscovery {
@@ -662,7 +712,7 @@ func (c *GrpcClients) loadTargetsStatic(config *goconf.ConfigFile, fromReload bo
 		c.selfCheckWaitGroup.Add(1)
 		go c.checkIsSelf(c.closeCtx, target, client)
 
-		log.Printf("Adding %s as GRPC target", client.Target())
+		grpcLog.Infof("Adding %s as GRPC target", client.Target())
 		entry, found := clientsMap[target]
 		if !found {
 			entry = &grpcClientsList{}
@@ -675,7 +725,7 @@ func (c *GrpcClients) loadTargetsStatic(config *goconf.ConfigFile, fromReload bo
 	for target := range removeTargets {
 		if entry, found := clientsMap[target]; found {
 			for _, client := range entry.clients {
-				log.Printf("Deleting GRPC target %s", client.Target())
+				grpcLog.Infof("Deleting GRPC target %s", client.Target())
 				c.closeClient(client)
 			}
 
@@ -712,7 +762,7 @@ func (c *GrpcClients) onLookup(entry *DnsMonitorEntry, all []net.IP, added []net
 		for _, client := range e.clients {
 			if ip.Equal(client.ip) {
 				mapModified = true
-				log.Printf("Removing connection to %s", client.Target())
+				grpcLog.Infof("Removing connection to %s", client.Target())
 				c.closeClient(client)
 				c.wakeupForTesting()
 			}
@@ -730,14 +780,14 @@ func (c *GrpcClients) onLookup(entry *DnsMonitorEntry, all []net.IP, added []net
 	for _, ip := range added {
 		client, err := NewGrpcClient(target, ip, opts...)
 		if err != nil {
-			log.Printf("Error creating client to %s with IP %s: %s", target, ip.String(), err)
+			grpcLog.Errorf("Error creating client to %s with IP %s: %s", target, ip.String(), err)
 			continue
 		}
 
 		c.selfCheckWaitGroup.Add(1)
 		go c.checkIsSelf(c.closeCtx, target, client)
 
-		log.Printf("Adding %s as GRPC target", client.Target())
+		grpcLog.Infof("Adding %s as GRPC target", client.Target())
 		newClients = append(newClients, client)
 		mapModified = true
 		c.wakeupForTesting()
@@ -790,9 +840,9 @@ func (c *GrpcClients) EtcdClientCreated(client *EtcdClient) {
 				if errors.Is(err, context.Canceled) {
 					return
 				} else if errors.Is(err, context.DeadlineExceeded) {
-					log.Printf("Timeout getting initial list of GRPC targets, retry in %s", backoff.NextWait())
+					grpcLog.Warnf("Timeout getting initial list of GRPC targets, retry in %s", backoff.NextWait())
 				} else {
-					log.Printf("Could not get initial list of GRPC targets, retry in %s: %s", backoff.NextWait(), err)
+					grpcLog.Errorf("Could not get initial list of GRPC targets, retry in %s: %s", backoff.NextWait(), err)
 				}
 
 				backoff.Wait(c.closeCtx)
@@ -812,7 +862,7 @@ func (c *GrpcClients) EtcdClientCreated(client *EtcdClient) {
 		for c.closeCtx.Err() == nil {
 			var err error
 			if nextRevision, err = client.Watch(c.closeCtx, c.targetPrefix, nextRevision, c, clientv3.WithPrefix()); err != nil {
-				log.Printf("Error processing watch for %s (%s), retry in %s", c.targetPrefix, err, backoff.NextWait())
+				grpcLog.Errorf("Error processing watch for %s (%s), retry in %s", c.targetPrefix, err, backoff.NextWait())
 				backoff.Wait(c.closeCtx)
 				continue
 			}
@@ -821,7 +871,7 @@ func (c *GrpcClients) EtcdClientCreated(client *EtcdClient) {
 				backoff.Reset()
 				prevRevision = nextRevision
 			} else {
-				log.Printf("Processing watch for %s interrupted, retry in %s", c.targetPrefix, backoff.NextWait())
+				grpcLog.Infof("Processing watch for %s interrupted, retry in %s", c.targetPrefix, backoff.NextWait())
 				backoff.Wait(c.closeCtx)
 			}
 		}
@@ -841,11 +891,11 @@ func (c *GrpcClients) getGrpcTargets(ctx context.Context, client *EtcdClient, ta
 func (c *GrpcClients) EtcdKeyUpdated(client *EtcdClient, key string, data []byte, prevValue []byte) {
 	var info GrpcTargetInformationEtcd
 	if err := json.Unmarshal(data, &info); err != nil {
-		log.Printf("Could not decode GRPC target %s=%s: %s", key, string(data), err)
+		grpcLog.Errorf("Could not decode GRPC target %s=%s: %s", key, string(data), err)
 		return
 	}
 	if err := info.CheckValid(); err != nil {
-		log.Printf("Received invalid GRPC target %s=%s: %s", key, string(data), err)
+		grpcLog.Warnf("Received invalid GRPC target %s=%s: %s", key, string(data), err)
 		return
 	}
 
@@ -859,21 +909,21 @@ func (c *GrpcClients) EtcdKeyUpdated(client *EtcdClient, key string, data []byte
 	}
 
 	if _, found := c.clientsMap[info.Address]; found {
-		log.Printf("GRPC target %s already exists, ignoring %s", info.Address, key)
+		grpcLog.Infof("GRPC target %s already exists, ignoring %s", info.Address, key)
 		return
 	}
 
 	opts := c.dialOptions.Load().([]grpc.DialOption)
 	cl, err := NewGrpcClient(info.Address, nil, opts...)
 	if err != nil {
-		log.Printf("Could not create GRPC client for target %s: %s", info.Address, err)
+		grpcLog.Errorf("Could not create GRPC client for target %s: %s", info.Address, err)
 		return
 	}
 
 	c.selfCheckWaitGroup.Add(1)
 	go c.checkIsSelf(c.closeCtx, info.Address, cl)
 
-	log.Printf("Adding %s as GRPC target", cl.Target())
+	grpcLog.Infof("Adding %s as GRPC target", cl.Target())
 
 	if c.clientsMap == nil {
 		c.clientsMap = make(map[string]*grpcClientsList)
@@ -897,7 +947,7 @@ func (c *GrpcClients) EtcdKeyDeleted(client *EtcdClient, key string, prevValue [
 func (c *GrpcClients) removeEtcdClientLocked(key string) {
 	info, found := c.targetInformation[key]
 	if !found {
-		log.Printf("No connection found for %s, ignoring", key)
+		grpcLog.Infof("No connection found for %s, ignoring", key)
 		c.wakeupForTesting()
 		return
 	}
@@ -909,7 +959,7 @@ func (c *GrpcClients) removeEtcdClientLocked(key string) {
 	}
 
 	for _, client := range entry.clients {
-		log.Printf("Removing connection to %s (from %s)", client.Target(), key)
+		grpcLog.Infof("Removing connection to %s (from %s)", client.Target(), key)
 		c.closeClient(client)
 	}
 	delete(c.clientsMap, info.Address)
@@ -943,7 +993,7 @@ func (c *GrpcClients) wakeupForTesting() {
 
 func (c *GrpcClients) Reload(config *goconf.ConfigFile) {
 	if err := c.load(config, true); err != nil {
-		log.Printf("Could not reload RPC clients: %s", err)
+		grpcLog.Errorf("Could not reload RPC clients: %s", err)
 	}
 }
 
@@ -954,7 +1004,7 @@ func (c *GrpcClients) Close() {
 	for _, entry := range c.clientsMap {
 		for _, client := range entry.clients {
 			if err := client.Close(); err != nil {
-				log.Printf("Error closing client to %s: %s", client.Target(), err)
+				grpcLog.Errorf("Error closing client to %s: %s", client.Target(), err)
 			}
 		}
 
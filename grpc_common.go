@@ -25,7 +25,6 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
-	"log"
 	"net"
 
 	"github.com/dlintw/goconf"
@@ -36,7 +35,7 @@ import (
 type reloadableCredentials struct {
 	config *tls.Config
 
-	loader *CertificateReloader
+	loader *CertificateWatcher
 	pool   *CertPoolReloader
 }
 
@@ -150,10 +149,10 @@ func NewReloadableCredentials(config *goconf.ConfigFile, server bool) (credentia
 	cfg := &tls.Config{
 		NextProtos: []string{"h2"},
 	}
-	var loader *CertificateReloader
+	var loader *CertificateWatcher
 	var err error
 	if certificateFile != "" && keyFile != "" {
-		loader, err = NewCertificateReloader(certificateFile, keyFile)
+		loader, err = NewCertificateWatcher(certificateFile, keyFile)
 		if err != nil {
 			return nil, fmt.Errorf("invalid GRPC %s certificate / key in %s / %s: %w", prefix, certificateFile, keyFile, err)
 		}
@@ -173,9 +172,9 @@ func NewReloadableCredentials(config *goconf.ConfigFile, server bool) (credentia
 
 	if loader == nil && pool == nil {
 		if server {
-			log.Printf("WARNING: No GRPC server certificate and/or key configured, running unencrypted")
+			grpcLog.Warnf("No GRPC server certificate and/or key configured, running unencrypted")
 		} else {
-			log.Printf("WARNING: No GRPC CA configured, expecting unencrypted connections")
+			grpcLog.Warnf("No GRPC CA configured, expecting unencrypted connections")
 		}
 		return insecure.NewCredentials(), nil
 	}
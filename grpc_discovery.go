@@ -0,0 +1,349 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dlintw/goconf"
+	"google.golang.org/grpc"
+)
+
+const (
+	// Default interval to re-resolve discovered GRPC targets.
+	defaultGrpcDiscoveryInterval = 30 * time.Second
+
+	// Timeout for a single lookup of GRPC targets.
+	grpcDiscoveryTimeout = 10 * time.Second
+
+	defaultGrpcConsulUrl = "http://127.0.0.1:8500"
+
+	// Maximum size of a response from the Consul catalog.
+	maxGrpcConsulResponseSize = 4 * 1024 * 1024
+	// Maximum number of bytes of a response that are included in errors.
+	maxGrpcConsulErrorBodySize = 512
+)
+
+var (
+	lookupGrpcSrv = net.DefaultResolver.LookupSRV
+
+	// Connections to targets that are no longer discovered are closed after
+	// this delay so pending requests can finish.
+	grpcDiscoveryCloseDelay = 10 * time.Second
+)
+
+type grpcTargetDiscovery interface {
+	fmt.Stringer
+
+	// Lookup returns the addresses of the currently available GRPC targets.
+	Lookup(ctx context.Context) ([]string, error)
+}
+
+func newGrpcTargetDiscovery(config *goconf.ConfigFile, targetType string) (grpcTargetDiscovery, error) {
+	switch targetType {
+	case GrpcTargetTypeDnsSrv:
+		name, _ := config.GetString("grpc", "srvrecord")
+		if name == "" {
+			return nil, fmt.Errorf("no GRPC SRV record configured")
+		}
+
+		return &grpcDnsSrvDiscovery{
+			name: name,
+		}, nil
+	case GrpcTargetTypeConsul:
+		service, _ := config.GetString("grpc", "consulservice")
+		if service == "" {
+			return nil, fmt.Errorf("no GRPC Consul service configured")
+		}
+
+		consulUrl, _ := config.GetString("grpc", "consulurl")
+		if consulUrl == "" {
+			consulUrl = defaultGrpcConsulUrl
+		}
+		if _, err := url.Parse(consulUrl); err != nil {
+			return nil, fmt.Errorf("invalid Consul url %s: %w", consulUrl, err)
+		}
+
+		token, _ := GetStringOptionWithEnv(config, "grpc", "consultoken")
+		tag, _ := config.GetString("grpc", "consultag")
+		return &grpcConsulDiscovery{
+			url:     strings.TrimSuffix(consulUrl, "/"),
+			service: service,
+			tag:     tag,
+			token:   token,
+			client:  &http.Client{},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown GRPC target type: %s", targetType)
+	}
+}
+
+// grpcDnsSrvDiscovery discovers GRPC targets from the entries of a DNS SRV
+// record, e.g. of a headless service in Kubernetes.
+type grpcDnsSrvDiscovery struct {
+	name string
+}
+
+func (d *grpcDnsSrvDiscovery) String() string {
+	return "SRV record " + d.name
+}
+
+func (d *grpcDnsSrvDiscovery) Lookup(ctx context.Context) ([]string, error) {
+	_, records, err := lookupGrpcSrv(ctx, "", "", d.name)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make([]string, 0, len(records))
+	for _, record := range records {
+		host := strings.TrimSuffix(record.Target, ".")
+		targets = append(targets, net.JoinHostPort(host, strconv.Itoa(int(record.Port))))
+	}
+	return targets, nil
+}
+
+type grpcConsulCatalogService struct {
+	Address        string `json:"Address"`
+	ServiceAddress string `json:"ServiceAddress"`
+	ServicePort    int    `json:"ServicePort"`
+}
+
+// grpcConsulDiscovery discovers GRPC targets from the instances of a service
+// in the Consul catalog.
+type grpcConsulDiscovery struct {
+	url     string
+	service string
+	tag     string
+	token   string
+
+	client *http.Client
+}
+
+func (d *grpcConsulDiscovery) String() string {
+	return fmt.Sprintf("Consul service %s at %s", d.service, d.url)
+}
+
+func (d *grpcConsulDiscovery) Lookup(ctx context.Context) ([]string, error) {
+	u := d.url + "/v1/catalog/service/" + url.PathEscape(d.service)
+	if d.tag != "" {
+		u += "?tag=" + url.QueryEscape(d.tag)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if d.token != "" {
+		req.Header.Set("X-Consul-Token", d.token)
+	}
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxGrpcConsulErrorBodySize))
+		return nil, fmt.Errorf("unexpected response status %d: %s", resp.StatusCode, string(body))
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxGrpcConsulResponseSize+1))
+	if err != nil {
+		return nil, err
+	} else if len(body) > maxGrpcConsulResponseSize {
+		return nil, fmt.Errorf("response exceeds maximum size of %d bytes", maxGrpcConsulResponseSize)
+	}
+
+	var services []grpcConsulCatalogService
+	if err := json.Unmarshal(body, &services); err != nil {
+		return nil, fmt.Errorf("could not decode response %s: %w", string(body[:min(len(body), maxGrpcConsulErrorBodySize)]), err)
+	}
+
+	targets := make([]string, 0, len(services))
+	for _, service := range services {
+		host := service.ServiceAddress
+		if host == "" {
+			host = service.Address
+		}
+		if host == "" || service.ServicePort <= 0 {
+			continue
+		}
+
+		targets = append(targets, net.JoinHostPort(host, strconv.Itoa(service.ServicePort)))
+	}
+	return targets, nil
+}
+
+func (c *GrpcClients) loadTargetsDiscovery(config *goconf.ConfigFile, targetType string) error {
+	discovery, err := newGrpcTargetDiscovery(config, targetType)
+	if err != nil {
+		return err
+	}
+
+	interval := defaultGrpcDiscoveryInterval
+	if value, _ := config.GetInt("grpc", "discoveryinterval"); value > 0 {
+		interval = time.Duration(value) * time.Second
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stopDiscoveryLocked()
+
+	ctx, cancel := context.WithCancel(c.closeCtx)
+	c.discovery = discovery
+	c.discoveryCancel = cancel
+	grpcLog.Infof("Discovering GRPC targets from %s every %s", discovery, interval)
+	go c.runDiscovery(ctx, discovery, interval)
+	return nil
+}
+
+func (c *GrpcClients) stopDiscoveryLocked() {
+	if c.discoveryCancel != nil {
+		c.discoveryCancel()
+		c.discoveryCancel = nil
+		c.discovery = nil
+	}
+}
+
+func (c *GrpcClients) runDiscovery(ctx context.Context, discovery grpcTargetDiscovery, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		c.discoverTargets(ctx, discovery)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *GrpcClients) discoverTargets(ctx context.Context, discovery grpcTargetDiscovery) {
+	lookupCtx, cancel := context.WithTimeout(ctx, grpcDiscoveryTimeout)
+	defer cancel()
+
+	targets, err := discovery.Lookup(lookupCtx)
+	if err != nil {
+		if ctx.Err() == nil {
+			// Keep the current targets, the lookup will be retried.
+			grpcLog.Errorf("Could not discover GRPC targets from %s: %s", discovery, err)
+		}
+		return
+	}
+
+	c.setDiscoveredTargets(targets)
+	c.initializedFunc()
+}
+
+func (c *GrpcClients) setDiscoveredTargets(targets []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closeCtx.Err() != nil {
+		// Already closed.
+		return
+	}
+
+	opts := c.dialOptions.Load().([]grpc.DialOption)
+
+	modified := false
+	clientsMap := make(map[string]*grpcClientsList, len(targets))
+	for _, target := range targets {
+		if _, found := clientsMap[target]; found {
+			continue
+		}
+
+		if entry, found := c.clientsMap[target]; found {
+			clientsMap[target] = entry
+			continue
+		}
+
+		client, err := NewGrpcClient(target, nil, opts...)
+		if err != nil {
+			grpcLog.Errorf("Could not create GRPC client for target %s: %s", target, err)
+			continue
+		}
+
+		c.selfCheckWaitGroup.Add(1)
+		go c.checkIsSelf(c.closeCtx, target, client)
+
+		grpcLog.Infof("Adding %s as GRPC target", client.Target())
+		clientsMap[target] = &grpcClientsList{
+			clients: []*GrpcClient{client},
+		}
+		modified = true
+	}
+
+	for target, entry := range c.clientsMap {
+		if _, found := clientsMap[target]; found {
+			continue
+		}
+
+		for _, client := range entry.clients {
+			grpcLog.Infof("Removing GRPC target %s", client.Target())
+			c.closeClientDelayed(client)
+		}
+		modified = true
+	}
+
+	if !modified {
+		return
+	}
+
+	c.clientsMap = clientsMap
+	c.clients = make([]*GrpcClient, 0, len(clientsMap))
+	for _, target := range slices.Sorted(maps.Keys(clientsMap)) {
+		c.clients = append(c.clients, clientsMap[target].clients...)
+	}
+	statsGrpcClients.Set(float64(len(c.clients)))
+	c.wakeupForTesting()
+}
+
+// closeClientDelayed closes a client that is no longer used for new requests
+// after a delay to let pending requests finish.
+func (c *GrpcClients) closeClientDelayed(client *GrpcClient) {
+	go func() {
+		timer := time.NewTimer(grpcDiscoveryCloseDelay)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-c.closeCtx.Done():
+		}
+		c.closeClient(client)
+	}()
+}
@@ -0,0 +1,271 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dlintw/goconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockGrpcSrvLookup struct {
+	mu      sync.Mutex
+	records map[string][]*net.SRV
+}
+
+func newMockGrpcSrvLookupForTest(t *testing.T) *mockGrpcSrvLookup {
+	mock := &mockGrpcSrvLookup{
+		records: make(map[string][]*net.SRV),
+	}
+	prev := lookupGrpcSrv
+	t.Cleanup(func() {
+		lookupGrpcSrv = prev
+	})
+	lookupGrpcSrv = mock.lookup
+	return mock
+}
+
+func (m *mockGrpcSrvLookup) Set(name string, records []*net.SRV) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.records[name] = records
+}
+
+func (m *mockGrpcSrvLookup) Remove(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.records, name)
+}
+
+func (m *mockGrpcSrvLookup) lookup(ctx context.Context, service string, proto string, name string) (string, []*net.SRV, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	records, found := m.records[name]
+	if !found {
+		return "", nil, &net.DNSError{
+			Err:        "could not resolve " + name,
+			Name:       name,
+			IsNotFound: true,
+		}
+	}
+
+	return name, records, nil
+}
+
+func getGrpcClientTargets(client *GrpcClients) []string {
+	var targets []string
+	for _, cl := range client.GetClients() {
+		targets = append(targets, cl.Target())
+	}
+	return targets
+}
+
+func Test_GrpcClients_DnsSrvDiscovery(t *testing.T) {
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	require := require.New(t)
+	lookup := newMockGrpcSrvLookupForTest(t)
+	name := "_grpc._tcp.signaling.local"
+	lookup.Set(name, []*net.SRV{
+		{Target: "signaling1.local.", Port: 9090},
+		{Target: "signaling2.local.", Port: 9091},
+	})
+
+	config := goconf.NewConfigFile()
+	config.AddOption("grpc", "targettype", GrpcTargetTypeDnsSrv)
+	config.AddOption("grpc", "srvrecord", name)
+	config.AddOption("grpc", "discoveryinterval", "3600")
+	client, _ := NewGrpcClientsForTestWithConfig(t, config, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	require.NoError(client.WaitForInitialized(ctx))
+	assert.Equal([]string{"signaling1.local:9090", "signaling2.local:9091"}, getGrpcClientTargets(client))
+	clients := client.GetClients()
+
+	lookup.Set(name, []*net.SRV{
+		{Target: "signaling2.local.", Port: 9091},
+		{Target: "signaling3.local.", Port: 9090},
+	})
+	client.discoverTargets(ctx, client.discovery)
+	if assert.Equal([]string{"signaling2.local:9091", "signaling3.local:9090"}, getGrpcClientTargets(client)) {
+		// Existing connections are kept.
+		assert.Same(clients[1], client.GetClients()[0])
+	}
+
+	// Targets are kept if the lookup fails.
+	lookup.Remove(name)
+	client.discoverTargets(ctx, client.discovery)
+	assert.Equal([]string{"signaling2.local:9091", "signaling3.local:9090"}, getGrpcClientTargets(client))
+}
+
+func Test_GrpcClients_ConsulDiscovery(t *testing.T) {
+	t.Setenv("CONSUL_TOKEN", "the-token")
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var mu sync.Mutex
+	services := []grpcConsulCatalogService{
+		{Address: "192.168.0.1", ServicePort: 9090},
+		{Address: "192.168.0.2", ServiceAddress: "10.0.0.2", ServicePort: 9090},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/catalog/service/signaling" || r.URL.Query().Get("tag") != "grpc" {
+			http.NotFound(w, r)
+			return
+		} else if r.Header.Get("X-Consul-Token") != "the-token" {
+			http.Error(w, "Permission denied", http.StatusForbidden)
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		assert.NoError(json.NewEncoder(w).Encode(services))
+	}))
+	t.Cleanup(server.Close)
+
+	config := goconf.NewConfigFile()
+	config.AddOption("grpc", "targettype", GrpcTargetTypeConsul)
+	config.AddOption("grpc", "consulurl", server.URL+"/")
+	config.AddOption("grpc", "consulservice", "signaling")
+	config.AddOption("grpc", "consultag", "grpc")
+	config.AddOption("grpc", "consultoken", "$(CONSUL_TOKEN)")
+	config.AddOption("grpc", "discoveryinterval", "3600")
+	client, _ := NewGrpcClientsForTestWithConfig(t, config, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	require.NoError(client.WaitForInitialized(ctx))
+	assert.Equal([]string{"10.0.0.2:9090", "192.168.0.1:9090"}, getGrpcClientTargets(client))
+
+	mu.Lock()
+	services = services[:1]
+	mu.Unlock()
+	client.discoverTargets(ctx, client.discovery)
+	assert.Equal([]string{"192.168.0.1:9090"}, getGrpcClientTargets(client))
+
+	targets, err := (&grpcConsulDiscovery{
+		url:     server.URL,
+		service: "signaling",
+		tag:     "grpc",
+		client:  &http.Client{},
+	}).Lookup(ctx)
+	assert.ErrorContains(err, "403")
+	assert.Empty(targets)
+}
+
+func Test_GrpcClients_ConsulDiscoveryResponseSize(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte("[" + strings.Repeat(" ", maxGrpcConsulResponseSize) + "]")) // nolint
+	}))
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	targets, err := (&grpcConsulDiscovery{
+		url:     server.URL,
+		service: "signaling",
+		client:  &http.Client{},
+	}).Lookup(ctx)
+	assert.ErrorContains(err, "maximum size")
+	assert.Empty(targets)
+}
+
+func Test_GrpcClients_ConsulDiscoveryErrorBody(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	body := strings.Repeat("a", maxGrpcConsulErrorBodySize) + strings.Repeat("b", 1024)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(body)) // nolint
+	}))
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	targets, err := (&grpcConsulDiscovery{
+		url:     server.URL,
+		service: "signaling",
+		client:  &http.Client{},
+	}).Lookup(ctx)
+	if assert.ErrorContains(err, "500") {
+		// Only the start of the body is included in the error.
+		assert.Contains(err.Error(), body[:maxGrpcConsulErrorBodySize])
+		assert.NotContains(err.Error(), "b")
+	}
+	assert.Empty(targets)
+}
+
+func Test_GrpcClients_DiscoveryConfig(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	for _, options := range []map[string]string{
+		{"targettype": GrpcTargetTypeDnsSrv},
+		{"targettype": GrpcTargetTypeConsul},
+		{"targettype": GrpcTargetTypeConsul, "consulservice": "signaling", "consulurl": "http://invalid host"},
+	} {
+		config := goconf.NewConfigFile()
+		for k, v := range options {
+			config.AddOption("grpc", k, v)
+		}
+		_, err := NewGrpcClients(config, nil, nil, "0.0.0")
+		assert.Error(err, "expected error for %+v", options)
+	}
+}
+
+func Test_GrpcDnsSrvDiscovery_Error(t *testing.T) {
+	CatchLogForTest(t)
+	newMockGrpcSrvLookupForTest(t)
+
+	discovery := &grpcDnsSrvDiscovery{
+		name: "_grpc._tcp.unknown.local",
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := discovery.Lookup(ctx)
+	var dnsErr *net.DNSError
+	assert.True(t, errors.As(err, &dnsErr), "expected DNS error, got %+v", err)
+}
@@ -27,7 +27,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"sync/atomic"
 
 	"google.golang.org/grpc/codes"
@@ -105,7 +104,7 @@ func (c *remoteGrpcClient) readPump() {
 			}
 
 			if status.Code(err) != codes.Canceled {
-				log.Printf("Error reading from remote client for session %s: %s", c.sessionId, err)
+				grpcLog.Errorf("Error reading from remote client for session %s: %s", c.sessionId, err)
 				closeError = err
 			}
 			break
@@ -175,12 +174,7 @@ func (c *remoteGrpcClient) SendByeResponseWithReason(message *ClientMessage, rea
 	if message != nil {
 		response.Id = message.Id
 	}
-	if reason != "" {
-		if response.Bye == nil {
-			response.Bye = &ByeServerMessage{}
-		}
-		response.Bye.Reason = reason
-	}
+	response.Bye = NewByeServerMessage(reason)
 	return c.SendMessage(response)
 }
 
@@ -193,7 +187,7 @@ func (c *remoteGrpcClient) SendMessage(message WritableClientMessage) bool {
 	case c.messages <- message:
 		return true
 	default:
-		log.Printf("Message queue for remote client of session %s is full, not sending %+v", c.sessionId, message)
+		grpcLog.Infof("Message queue for remote client of session %s is full, not sending %+v", c.sessionId, message)
 		return false
 	}
 }
@@ -215,7 +209,7 @@ func (c *remoteGrpcClient) run() error {
 		case msg := <-c.messages:
 			data, err := json.Marshal(msg)
 			if err != nil {
-				log.Printf("Error marshalling %+v for remote client for session %s: %s", msg, c.sessionId, err)
+				grpcLog.Errorf("Error marshalling %+v for remote client for session %s: %s", msg, c.sessionId, err)
 				continue
 			}
 
@@ -25,14 +25,15 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"net"
 	"net/url"
 	"os"
 
 	"github.com/dlintw/goconf"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
@@ -86,7 +87,7 @@ func NewGrpcServer(config *goconf.ConfigFile, version string) (*GrpcServer, erro
 	var listener net.Listener
 	if addr, _ := GetStringOptionWithEnv(config, "grpc", "listen"); addr != "" {
 		var err error
-		listener, err = net.Listen("tcp", addr)
+		listener, err = CreateListener(addr, false)
 		if err != nil {
 			return nil, fmt.Errorf("could not create GRPC listener %s: %w", addr, err)
 		}
@@ -97,7 +98,10 @@ func NewGrpcServer(config *goconf.ConfigFile, version string) (*GrpcServer, erro
 		return nil, err
 	}
 
-	conn := grpc.NewServer(grpc.Creds(creds))
+	conn := grpc.NewServer(
+		grpc.Creds(creds),
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+	)
 	result := &GrpcServer{
 		version:  version,
 		creds:    creds,
@@ -130,7 +134,7 @@ func (s *GrpcServer) Close() {
 func (s *GrpcServer) LookupResumeId(ctx context.Context, request *LookupResumeIdRequest) (*LookupResumeIdReply, error) {
 	statsGrpcServerCalls.WithLabelValues("LookupResumeId").Inc()
 	// TODO: Remove debug logging
-	log.Printf("Lookup session for resume id %s", request.ResumeId)
+	grpcLog.Infof("Lookup session for resume id %s", request.ResumeId)
 	session := s.hub.GetSessionByResumeId(PrivateSessionId(request.ResumeId))
 	if session == nil {
 		return nil, status.Error(codes.NotFound, "no such room session id")
@@ -144,7 +148,7 @@ func (s *GrpcServer) LookupResumeId(ctx context.Context, request *LookupResumeId
 func (s *GrpcServer) LookupSessionId(ctx context.Context, request *LookupSessionIdRequest) (*LookupSessionIdReply, error) {
 	statsGrpcServerCalls.WithLabelValues("LookupSessionId").Inc()
 	// TODO: Remove debug logging
-	log.Printf("Lookup session id for room session id %s", request.RoomSessionId)
+	grpcLog.Infof("Lookup session id for room session id %s", request.RoomSessionId)
 	sid, err := s.hub.GetSessionIdByRoomSessionId(RoomSessionId(request.RoomSessionId))
 	if errors.Is(err, ErrNoSuchRoomSession) {
 		return nil, status.Error(codes.NotFound, "no such room session id")
@@ -154,12 +158,12 @@ func (s *GrpcServer) LookupSessionId(ctx context.Context, request *LookupSession
 
 	if sid != "" && request.DisconnectReason != "" {
 		if session := s.hub.GetSessionByPublicId(PublicSessionId(sid)); session != nil {
-			log.Printf("Closing session %s because same room session %s connected", session.PublicId(), request.RoomSessionId)
+			grpcLog.Infof("Closing session %s because same room session %s connected", session.PublicId(), request.RoomSessionId)
 			session.LeaveRoom(false)
 			switch sess := session.(type) {
 			case *ClientSession:
 				if client := sess.GetClient(); client != nil {
-					client.SendByeResponseWithReason(nil, "room_session_reconnected")
+					client.SendByeResponseWithReason(nil, ByeReasonRoomSessionReconnected)
 				}
 			}
 			session.Close()
@@ -173,7 +177,7 @@ func (s *GrpcServer) LookupSessionId(ctx context.Context, request *LookupSession
 func (s *GrpcServer) IsSessionInCall(ctx context.Context, request *IsSessionInCallRequest) (*IsSessionInCallReply, error) {
 	statsGrpcServerCalls.WithLabelValues("IsSessionInCall").Inc()
 	// TODO: Remove debug logging
-	log.Printf("Check if session %s is in call %s on %s", request.SessionId, request.RoomId, request.BackendUrl)
+	grpcLog.Infof("Check if session %s is in call %s on %s", request.SessionId, request.RoomId, request.BackendUrl)
 	session := s.hub.GetSessionByPublicId(PublicSessionId(request.SessionId))
 	if session == nil {
 		return nil, status.Error(codes.NotFound, "no such session id")
@@ -194,7 +198,7 @@ func (s *GrpcServer) IsSessionInCall(ctx context.Context, request *IsSessionInCa
 func (s *GrpcServer) GetInternalSessions(ctx context.Context, request *GetInternalSessionsRequest) (*GetInternalSessionsReply, error) {
 	statsGrpcServerCalls.WithLabelValues("GetInternalSessions").Inc()
 	// TODO: Remove debug logging
-	log.Printf("Get internal sessions from %s on %v (fallback %s)", request.RoomId, request.BackendUrls, request.BackendUrl)
+	grpcLog.Infof("Get internal sessions from %s on %v (fallback %s)", request.RoomId, request.BackendUrls, request.BackendUrl)
 
 	var backendUrls []string
 	if len(request.BackendUrls) > 0 {
@@ -256,10 +260,63 @@ func (s *GrpcServer) GetInternalSessions(ctx context.Context, request *GetIntern
 	return result, nil
 }
 
+func (s *GrpcServer) GetTransientData(ctx context.Context, request *GetTransientDataRequest) (*GetTransientDataReply, error) {
+	statsGrpcServerCalls.WithLabelValues("GetTransientData").Inc()
+	// TODO: Remove debug logging
+	grpcLog.Infof("Get transient data from %s on %v", request.RoomId, request.BackendUrls)
+
+	backendUrls := request.BackendUrls
+	if len(backendUrls) == 0 {
+		// Only compat backend.
+		backendUrls = []string{""}
+	}
+
+	var parsed *url.URL
+	if bu := backendUrls[0]; bu != "" {
+		var err error
+		parsed, err = url.Parse(bu)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "invalid url")
+		}
+	}
+
+	backend := s.hub.GetBackend(parsed)
+	if backend == nil {
+		return nil, status.Error(codes.NotFound, "no such backend")
+	}
+
+	room := s.hub.GetRoomForBackend(request.RoomId, backend)
+	if room == nil {
+		return nil, status.Error(codes.NotFound, "no such room")
+	}
+
+	result := &GetTransientDataReply{}
+	for key, entry := range room.GetTransientEntries() {
+		value, err := json.Marshal(entry.Value)
+		if err != nil {
+			grpcLog.Errorf("Could not serialize transient data %s in room %s: %s", key, request.RoomId, err)
+			continue
+		}
+
+		if result.Entries == nil {
+			result.Entries = make(map[string]*TransientDataEntry)
+		}
+		data := &TransientDataEntry{
+			Value: value,
+		}
+		if !entry.Expires.IsZero() {
+			data.Expires = entry.Expires.UnixMilli()
+		}
+		result.Entries[key] = data
+	}
+
+	return result, nil
+}
+
 func (s *GrpcServer) GetPublisherId(ctx context.Context, request *GetPublisherIdRequest) (*GetPublisherIdReply, error) {
 	statsGrpcServerCalls.WithLabelValues("GetPublisherId").Inc()
 	// TODO: Remove debug logging
-	log.Printf("Get %s publisher id for session %s", request.StreamType, request.SessionId)
+	grpcLog.Infof("Get %s publisher id for session %s", request.StreamType, request.SessionId)
 	session := s.hub.GetSessionByPublicId(PublicSessionId(request.SessionId))
 	if session == nil {
 		return nil, status.Error(codes.NotFound, "no such session")
@@ -281,11 +338,11 @@ func (s *GrpcServer) GetPublisherId(ctx context.Context, request *GetPublisherId
 		}
 		var err error
 		if reply.ConnectToken, err = s.hub.CreateProxyToken(""); err != nil && !errors.Is(err, ErrNoProxyMcu) {
-			log.Printf("Error creating proxy token for connection: %s", err)
+			grpcLog.Errorf("Error creating proxy token for connection: %s", err)
 			return nil, status.Error(codes.Internal, "error creating proxy connect token")
 		}
 		if reply.PublisherToken, err = s.hub.CreateProxyToken(publisher.Id()); err != nil && !errors.Is(err, ErrNoProxyMcu) {
-			log.Printf("Error creating proxy token for publisher %s: %s", publisher.Id(), err)
+			grpcLog.Errorf("Error creating proxy token for publisher %s: %s", publisher.Id(), err)
 			return nil, status.Error(codes.Internal, "error creating proxy publisher token")
 		}
 		return reply, nil
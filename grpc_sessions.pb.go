@@ -554,6 +554,156 @@ func (x *GetInternalSessionsReply) GetVirtualSessions() []*VirtualSessionData {
 	return nil
 }
 
+type GetTransientDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=roomId,proto3" json:"roomId,omitempty"`
+	BackendUrls   []string               `protobuf:"bytes,2,rep,name=backendUrls,proto3" json:"backendUrls,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransientDataRequest) Reset() {
+	*x = GetTransientDataRequest{}
+	mi := &file_grpc_sessions_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransientDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransientDataRequest) ProtoMessage() {}
+
+func (x *GetTransientDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_sessions_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransientDataRequest.ProtoReflect.Descriptor instead.
+func (*GetTransientDataRequest) Descriptor() ([]byte, []int) {
+	return file_grpc_sessions_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetTransientDataRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *GetTransientDataRequest) GetBackendUrls() []string {
+	if x != nil {
+		return x.BackendUrls
+	}
+	return nil
+}
+
+type TransientDataEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// JSON encoded value.
+	Value []byte `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// Expiration time in unix milliseconds, 0 if the entry doesn't expire.
+	Expires       int64 `protobuf:"varint,2,opt,name=expires,proto3" json:"expires,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransientDataEntry) Reset() {
+	*x = TransientDataEntry{}
+	mi := &file_grpc_sessions_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransientDataEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransientDataEntry) ProtoMessage() {}
+
+func (x *TransientDataEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_sessions_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransientDataEntry.ProtoReflect.Descriptor instead.
+func (*TransientDataEntry) Descriptor() ([]byte, []int) {
+	return file_grpc_sessions_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TransientDataEntry) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *TransientDataEntry) GetExpires() int64 {
+	if x != nil {
+		return x.Expires
+	}
+	return 0
+}
+
+type GetTransientDataReply struct {
+	state         protoimpl.MessageState         `protogen:"open.v1"`
+	Entries       map[string]*TransientDataEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransientDataReply) Reset() {
+	*x = GetTransientDataReply{}
+	mi := &file_grpc_sessions_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransientDataReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransientDataReply) ProtoMessage() {}
+
+func (x *GetTransientDataReply) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_sessions_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransientDataReply.ProtoReflect.Descriptor instead.
+func (*GetTransientDataReply) Descriptor() ([]byte, []int) {
+	return file_grpc_sessions_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetTransientDataReply) GetEntries() map[string]*TransientDataEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
 type ClientSessionMessage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       []byte                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
@@ -563,7 +713,7 @@ type ClientSessionMessage struct {
 
 func (x *ClientSessionMessage) Reset() {
 	*x = ClientSessionMessage{}
-	mi := &file_grpc_sessions_proto_msgTypes[10]
+	mi := &file_grpc_sessions_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -575,7 +725,7 @@ func (x *ClientSessionMessage) String() string {
 func (*ClientSessionMessage) ProtoMessage() {}
 
 func (x *ClientSessionMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_grpc_sessions_proto_msgTypes[10]
+	mi := &file_grpc_sessions_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -588,7 +738,7 @@ func (x *ClientSessionMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ClientSessionMessage.ProtoReflect.Descriptor instead.
 func (*ClientSessionMessage) Descriptor() ([]byte, []int) {
-	return file_grpc_sessions_proto_rawDescGZIP(), []int{10}
+	return file_grpc_sessions_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ClientSessionMessage) GetMessage() []byte {
@@ -607,7 +757,7 @@ type ServerSessionMessage struct {
 
 func (x *ServerSessionMessage) Reset() {
 	*x = ServerSessionMessage{}
-	mi := &file_grpc_sessions_proto_msgTypes[11]
+	mi := &file_grpc_sessions_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -619,7 +769,7 @@ func (x *ServerSessionMessage) String() string {
 func (*ServerSessionMessage) ProtoMessage() {}
 
 func (x *ServerSessionMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_grpc_sessions_proto_msgTypes[11]
+	mi := &file_grpc_sessions_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -632,7 +782,7 @@ func (x *ServerSessionMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServerSessionMessage.ProtoReflect.Descriptor instead.
 func (*ServerSessionMessage) Descriptor() ([]byte, []int) {
-	return file_grpc_sessions_proto_rawDescGZIP(), []int{11}
+	return file_grpc_sessions_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ServerSessionMessage) GetMessage() []byte {
@@ -679,16 +829,28 @@ const file_grpc_sessions_proto_rawDesc = "" +
 	"\x06inCall\x18\x02 \x01(\rR\x06inCall\"\xaf\x01\n" +
 	"\x18GetInternalSessionsReply\x12J\n" +
 	"\x10internalSessions\x18\x01 \x03(\v2\x1e.signaling.InternalSessionDataR\x10internalSessions\x12G\n" +
-	"\x0fvirtualSessions\x18\x02 \x03(\v2\x1d.signaling.VirtualSessionDataR\x0fvirtualSessions\"0\n" +
+	"\x0fvirtualSessions\x18\x02 \x03(\v2\x1d.signaling.VirtualSessionDataR\x0fvirtualSessions\"S\n" +
+	"\x17GetTransientDataRequest\x12\x16\n" +
+	"\x06roomId\x18\x01 \x01(\tR\x06roomId\x12 \n" +
+	"\vbackendUrls\x18\x02 \x03(\tR\vbackendUrls\"D\n" +
+	"\x12TransientDataEntry\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\fR\x05value\x12\x18\n" +
+	"\aexpires\x18\x02 \x01(\x03R\aexpires\"\xbb\x01\n" +
+	"\x15GetTransientDataReply\x12G\n" +
+	"\aentries\x18\x01 \x03(\v2-.signaling.GetTransientDataReply.EntriesEntryR\aentries\x1aY\n" +
+	"\fEntriesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x123\n" +
+	"\x05value\x18\x02 \x01(\v2\x1d.signaling.TransientDataEntryR\x05value:\x028\x01\"0\n" +
 	"\x14ClientSessionMessage\x12\x18\n" +
 	"\amessage\x18\x01 \x01(\fR\amessage\"0\n" +
 	"\x14ServerSessionMessage\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\fR\amessage2\xd2\x03\n" +
+	"\amessage\x18\x01 \x01(\fR\amessage2\xae\x04\n" +
 	"\vRpcSessions\x12T\n" +
 	"\x0eLookupResumeId\x12 .signaling.LookupResumeIdRequest\x1a\x1e.signaling.LookupResumeIdReply\"\x00\x12W\n" +
 	"\x0fLookupSessionId\x12!.signaling.LookupSessionIdRequest\x1a\x1f.signaling.LookupSessionIdReply\"\x00\x12W\n" +
 	"\x0fIsSessionInCall\x12!.signaling.IsSessionInCallRequest\x1a\x1f.signaling.IsSessionInCallReply\"\x00\x12c\n" +
-	"\x13GetInternalSessions\x12%.signaling.GetInternalSessionsRequest\x1a#.signaling.GetInternalSessionsReply\"\x00\x12V\n" +
+	"\x13GetInternalSessions\x12%.signaling.GetInternalSessionsRequest\x1a#.signaling.GetInternalSessionsReply\"\x00\x12Z\n" +
+	"\x10GetTransientData\x12\".signaling.GetTransientDataRequest\x1a .signaling.GetTransientDataReply\"\x00\x12V\n" +
 	"\fProxySession\x12\x1f.signaling.ClientSessionMessage\x1a\x1f.signaling.ServerSessionMessage\"\x00(\x010\x01B<Z:github.com/strukturag/nextcloud-spreed-signaling;signalingb\x06proto3"
 
 var (
@@ -703,7 +865,7 @@ func file_grpc_sessions_proto_rawDescGZIP() []byte {
 	return file_grpc_sessions_proto_rawDescData
 }
 
-var file_grpc_sessions_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_grpc_sessions_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
 var file_grpc_sessions_proto_goTypes = []any{
 	(*LookupResumeIdRequest)(nil),      // 0: signaling.LookupResumeIdRequest
 	(*LookupResumeIdReply)(nil),        // 1: signaling.LookupResumeIdReply
@@ -715,27 +877,35 @@ var file_grpc_sessions_proto_goTypes = []any{
 	(*InternalSessionData)(nil),        // 7: signaling.InternalSessionData
 	(*VirtualSessionData)(nil),         // 8: signaling.VirtualSessionData
 	(*GetInternalSessionsReply)(nil),   // 9: signaling.GetInternalSessionsReply
-	(*ClientSessionMessage)(nil),       // 10: signaling.ClientSessionMessage
-	(*ServerSessionMessage)(nil),       // 11: signaling.ServerSessionMessage
+	(*GetTransientDataRequest)(nil),    // 10: signaling.GetTransientDataRequest
+	(*TransientDataEntry)(nil),         // 11: signaling.TransientDataEntry
+	(*GetTransientDataReply)(nil),      // 12: signaling.GetTransientDataReply
+	(*ClientSessionMessage)(nil),       // 13: signaling.ClientSessionMessage
+	(*ServerSessionMessage)(nil),       // 14: signaling.ServerSessionMessage
+	nil,                                // 15: signaling.GetTransientDataReply.EntriesEntry
 }
 var file_grpc_sessions_proto_depIdxs = []int32{
 	7,  // 0: signaling.GetInternalSessionsReply.internalSessions:type_name -> signaling.InternalSessionData
 	8,  // 1: signaling.GetInternalSessionsReply.virtualSessions:type_name -> signaling.VirtualSessionData
-	0,  // 2: signaling.RpcSessions.LookupResumeId:input_type -> signaling.LookupResumeIdRequest
-	2,  // 3: signaling.RpcSessions.LookupSessionId:input_type -> signaling.LookupSessionIdRequest
-	4,  // 4: signaling.RpcSessions.IsSessionInCall:input_type -> signaling.IsSessionInCallRequest
-	6,  // 5: signaling.RpcSessions.GetInternalSessions:input_type -> signaling.GetInternalSessionsRequest
-	10, // 6: signaling.RpcSessions.ProxySession:input_type -> signaling.ClientSessionMessage
-	1,  // 7: signaling.RpcSessions.LookupResumeId:output_type -> signaling.LookupResumeIdReply
-	3,  // 8: signaling.RpcSessions.LookupSessionId:output_type -> signaling.LookupSessionIdReply
-	5,  // 9: signaling.RpcSessions.IsSessionInCall:output_type -> signaling.IsSessionInCallReply
-	9,  // 10: signaling.RpcSessions.GetInternalSessions:output_type -> signaling.GetInternalSessionsReply
-	11, // 11: signaling.RpcSessions.ProxySession:output_type -> signaling.ServerSessionMessage
-	7,  // [7:12] is the sub-list for method output_type
-	2,  // [2:7] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	15, // 2: signaling.GetTransientDataReply.entries:type_name -> signaling.GetTransientDataReply.EntriesEntry
+	11, // 3: signaling.GetTransientDataReply.EntriesEntry.value:type_name -> signaling.TransientDataEntry
+	0,  // 4: signaling.RpcSessions.LookupResumeId:input_type -> signaling.LookupResumeIdRequest
+	2,  // 5: signaling.RpcSessions.LookupSessionId:input_type -> signaling.LookupSessionIdRequest
+	4,  // 6: signaling.RpcSessions.IsSessionInCall:input_type -> signaling.IsSessionInCallRequest
+	6,  // 7: signaling.RpcSessions.GetInternalSessions:input_type -> signaling.GetInternalSessionsRequest
+	10, // 8: signaling.RpcSessions.GetTransientData:input_type -> signaling.GetTransientDataRequest
+	13, // 9: signaling.RpcSessions.ProxySession:input_type -> signaling.ClientSessionMessage
+	1,  // 10: signaling.RpcSessions.LookupResumeId:output_type -> signaling.LookupResumeIdReply
+	3,  // 11: signaling.RpcSessions.LookupSessionId:output_type -> signaling.LookupSessionIdReply
+	5,  // 12: signaling.RpcSessions.IsSessionInCall:output_type -> signaling.IsSessionInCallReply
+	9,  // 13: signaling.RpcSessions.GetInternalSessions:output_type -> signaling.GetInternalSessionsReply
+	12, // 14: signaling.RpcSessions.GetTransientData:output_type -> signaling.GetTransientDataReply
+	14, // 15: signaling.RpcSessions.ProxySession:output_type -> signaling.ServerSessionMessage
+	10, // [10:16] is the sub-list for method output_type
+	4,  // [4:10] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_grpc_sessions_proto_init() }
@@ -749,7 +919,7 @@ func file_grpc_sessions_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_grpc_sessions_proto_rawDesc), len(file_grpc_sessions_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   12,
+			NumMessages:   16,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
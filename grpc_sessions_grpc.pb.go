@@ -41,6 +41,7 @@ const (
 	RpcSessions_LookupSessionId_FullMethodName     = "/signaling.RpcSessions/LookupSessionId"
 	RpcSessions_IsSessionInCall_FullMethodName     = "/signaling.RpcSessions/IsSessionInCall"
 	RpcSessions_GetInternalSessions_FullMethodName = "/signaling.RpcSessions/GetInternalSessions"
+	RpcSessions_GetTransientData_FullMethodName    = "/signaling.RpcSessions/GetTransientData"
 	RpcSessions_ProxySession_FullMethodName        = "/signaling.RpcSessions/ProxySession"
 )
 
@@ -52,6 +53,7 @@ type RpcSessionsClient interface {
 	LookupSessionId(ctx context.Context, in *LookupSessionIdRequest, opts ...grpc.CallOption) (*LookupSessionIdReply, error)
 	IsSessionInCall(ctx context.Context, in *IsSessionInCallRequest, opts ...grpc.CallOption) (*IsSessionInCallReply, error)
 	GetInternalSessions(ctx context.Context, in *GetInternalSessionsRequest, opts ...grpc.CallOption) (*GetInternalSessionsReply, error)
+	GetTransientData(ctx context.Context, in *GetTransientDataRequest, opts ...grpc.CallOption) (*GetTransientDataReply, error)
 	ProxySession(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ClientSessionMessage, ServerSessionMessage], error)
 }
 
@@ -103,6 +105,16 @@ func (c *rpcSessionsClient) GetInternalSessions(ctx context.Context, in *GetInte
 	return out, nil
 }
 
+func (c *rpcSessionsClient) GetTransientData(ctx context.Context, in *GetTransientDataRequest, opts ...grpc.CallOption) (*GetTransientDataReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTransientDataReply)
+	err := c.cc.Invoke(ctx, RpcSessions_GetTransientData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *rpcSessionsClient) ProxySession(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ClientSessionMessage, ServerSessionMessage], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	stream, err := c.cc.NewStream(ctx, &RpcSessions_ServiceDesc.Streams[0], RpcSessions_ProxySession_FullMethodName, cOpts...)
@@ -124,6 +136,7 @@ type RpcSessionsServer interface {
 	LookupSessionId(context.Context, *LookupSessionIdRequest) (*LookupSessionIdReply, error)
 	IsSessionInCall(context.Context, *IsSessionInCallRequest) (*IsSessionInCallReply, error)
 	GetInternalSessions(context.Context, *GetInternalSessionsRequest) (*GetInternalSessionsReply, error)
+	GetTransientData(context.Context, *GetTransientDataRequest) (*GetTransientDataReply, error)
 	ProxySession(grpc.BidiStreamingServer[ClientSessionMessage, ServerSessionMessage]) error
 	mustEmbedUnimplementedRpcSessionsServer()
 }
@@ -147,6 +160,9 @@ func (UnimplementedRpcSessionsServer) IsSessionInCall(context.Context, *IsSessio
 func (UnimplementedRpcSessionsServer) GetInternalSessions(context.Context, *GetInternalSessionsRequest) (*GetInternalSessionsReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetInternalSessions not implemented")
 }
+func (UnimplementedRpcSessionsServer) GetTransientData(context.Context, *GetTransientDataRequest) (*GetTransientDataReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransientData not implemented")
+}
 func (UnimplementedRpcSessionsServer) ProxySession(grpc.BidiStreamingServer[ClientSessionMessage, ServerSessionMessage]) error {
 	return status.Errorf(codes.Unimplemented, "method ProxySession not implemented")
 }
@@ -243,6 +259,24 @@ func _RpcSessions_GetInternalSessions_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RpcSessions_GetTransientData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransientDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RpcSessionsServer).GetTransientData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RpcSessions_GetTransientData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RpcSessionsServer).GetTransientData(ctx, req.(*GetTransientDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RpcSessions_ProxySession_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(RpcSessionsServer).ProxySession(&grpc.GenericServerStream[ClientSessionMessage, ServerSessionMessage]{ServerStream: stream})
 }
@@ -273,6 +307,10 @@ var RpcSessions_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetInternalSessions",
 			Handler:    _RpcSessions_GetInternalSessions_Handler,
 		},
+		{
+			MethodName: "GetTransientData",
+			Handler:    _RpcSessions_GetTransientData_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
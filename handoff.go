@@ -0,0 +1,644 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/text/language"
+	"google.golang.org/protobuf/proto"
+)
+
+// A running server can hand off its listening sockets and the resumable state
+// of its sessions to a new process through a local unix socket. The clients
+// are disconnected afterwards and resume their sessions on the new process.
+//
+// Protocol (JSON messages, separated by newlines):
+// - new process: {"type":"request","version":1}
+// - old process: {"type":"listeners","addresses":[...]} with the file
+//   descriptors of the listeners attached, followed by the HandoffState.
+// - new process: {"type":"ready"} once it is serving the restored sessions or
+//   {"type":"error","error":"..."} if the state could not be restored.
+
+const (
+	handoffProtocolVersion = 1
+
+	// Maximum time a handoff may take.
+	handoffTimeout = 30 * time.Second
+
+	// Maximum size of the message with the listeners.
+	handoffMaxListenersMessageSize = 64 * 1024
+)
+
+var (
+	ErrHandoffNotSupported = errors.New("handoff is not supported on this platform")
+
+	ErrHandoffSessionIdMismatch = errors.New("session ids don't match the session")
+	ErrHandoffPeerNotAllowed    = errors.New("handoff peer is not allowed")
+)
+
+type handoffMessage struct {
+	Type      string   `json:"type"`
+	Version   int      `json:"version,omitempty"`
+	Addresses []string `json:"addresses,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// HandoffSession contains the state of a client session that is required to
+// resume it on a different process.
+type HandoffSession struct {
+	PrivateId PrivateSessionId `json:"privateid"`
+	PublicId  PublicSessionId  `json:"publicid"`
+	Sid       uint64           `json:"sid"`
+	Created   time.Time        `json:"created"`
+	BackendId string           `json:"backendid"`
+
+	ClientType ClientType               `json:"clienttype"`
+	BackendUrl string                   `json:"backendurl"`
+	Features   []string                 `json:"features,omitempty"`
+	Hints      *HelloClientMessageHints `json:"hints,omitempty"`
+	Language   string                   `json:"language,omitempty"`
+	UserId     string                   `json:"userid,omitempty"`
+	User       json.RawMessage          `json:"user,omitempty"`
+	Country    string                   `json:"country,omitempty"`
+
+	// Only set if the backend sent permissions for the session.
+	Permissions *[]Permission `json:"permissions,omitempty"`
+
+	RoomId        string        `json:"roomid,omitempty"`
+	RoomSessionId RoomSessionId `json:"roomsessionid,omitempty"`
+	InCall        bool          `json:"incall,omitempty"`
+}
+
+// HandoffRoom contains the state of a room with sessions that are handed off.
+type HandoffRoom struct {
+	Id            string                     `json:"id"`
+	BackendId     string                     `json:"backendid"`
+	Properties    json.RawMessage            `json:"properties,omitempty"`
+	TransientData map[string]*TransientEntry `json:"transientdata,omitempty"`
+}
+
+// HandoffState is handed off to a new process and also used for snapshots of
+// the state that can be imported into a different server.
+type HandoffState struct {
+	Sessions []*HandoffSession `json:"sessions,omitempty"`
+	Rooms    []*HandoffRoom    `json:"rooms,omitempty"`
+}
+
+func (s *ClientSession) getHandoffSession() *HandoffSession {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result := &HandoffSession{
+		PrivateId: s.privateId,
+		PublicId:  s.publicId,
+		Sid:       s.data.Sid,
+		Created:   s.data.Created.AsTime(),
+		BackendId: s.data.BackendId,
+
+		ClientType: s.clientType,
+		BackendUrl: s.backendUrl,
+		Features:   s.features,
+		Hints:      s.hints,
+		UserId:     s.userId,
+		User:       s.userData,
+		Country:    s.country,
+	}
+	if s.language != (language.Tag{}) {
+		result.Language = s.language.String()
+	}
+	if s.supportsPermissions {
+		permissions := make([]Permission, 0, len(s.permissions))
+		for permission, allowed := range s.permissions {
+			if allowed {
+				permissions = append(permissions, permission)
+			}
+		}
+		result.Permissions = &permissions
+	}
+	if room := s.GetRoom(); room != nil {
+		result.RoomId = room.Id()
+		result.RoomSessionId = s.RoomSessionId()
+		result.InCall = room.IsSessionInCall(s)
+	}
+	return result
+}
+
+// GetHandoffState returns the state of the sessions that can be resumed on a
+// different process. Only sessions of regular clients are included, internal
+// and federated sessions must connect again.
+func (h *Hub) GetHandoffState() *HandoffState {
+	h.mu.RLock()
+	sessions := make([]*ClientSession, 0, len(h.sessions))
+	for _, session := range h.sessions {
+		if s, ok := session.(*ClientSession); ok && s.ClientType() == HelloClientTypeClient && s.GetFederationClient() == nil {
+			sessions = append(sessions, s)
+		}
+	}
+	h.mu.RUnlock()
+
+	result := &HandoffState{}
+	rooms := make(map[*Room]bool)
+	for _, session := range sessions {
+		result.Sessions = append(result.Sessions, session.getHandoffSession())
+		if room := session.GetRoom(); room != nil && !rooms[room] {
+			rooms[room] = true
+			result.Rooms = append(result.Rooms, &HandoffRoom{
+				Id:            room.Id(),
+				BackendId:     room.Backend().Id(),
+				Properties:    room.Properties(),
+				TransientData: room.GetTransientEntries(),
+			})
+		}
+	}
+	return result
+}
+
+func (h *Hub) restoreHandoffSession(s *HandoffSession, trusted bool) (*ClientSession, error) {
+	if !trusted && s.ClientType != HelloClientTypeClient {
+		return nil, fmt.Errorf("sessions of type %s can only be restored from a handoff", s.ClientType)
+	}
+
+	// The ids must have been issued by a server using the same session keys.
+	data := h.decodePrivateSessionId(s.PrivateId)
+	if data == nil || data.Sid != s.Sid || data.BackendId != s.BackendId || !data.Created.AsTime().Equal(s.Created) ||
+		!proto.Equal(data, h.decodePublicSessionId(s.PublicId)) {
+		return nil, ErrHandoffSessionIdMismatch
+	}
+
+	parsedUrl, err := url.Parse(s.BackendUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid backend url %s: %w", s.BackendUrl, err)
+	}
+
+	backend := h.backend.GetBackend(parsedUrl)
+	if backend == nil || backend.Id() != s.BackendId {
+		return nil, fmt.Errorf("unknown backend %s for %s", s.BackendId, s.BackendUrl)
+	}
+
+	hello := &HelloClientMessage{
+		Features: s.Features,
+		Hints:    s.Hints,
+		Language: s.Language,
+		Auth: &HelloClientMessageAuth{
+			Type:      s.ClientType,
+			Url:       s.BackendUrl,
+			parsedUrl: parsedUrl,
+		},
+	}
+	auth := &BackendClientAuthResponse{
+		UserId: s.UserId,
+		User:   s.User,
+	}
+	session, err := NewClientSession(h, s.PrivateId, s.PublicId, data, backend, hello, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	session.country = s.Country
+	session.created = s.Created
+	if s.Permissions != nil {
+		session.SetPermissions(*s.Permissions)
+	}
+	if err := backend.AddSession(session); err != nil {
+		session.Close()
+		return nil, err
+	}
+	return session, nil
+}
+
+// RestoreHandoffState creates the sessions and rooms that were handed off by a
+// different process. The sessions expire if they are not resumed by their
+// clients. Errors of individual sessions are logged and the sessions skipped.
+// Sessions of internal and federated clients are only restored if the state is
+// trusted, i.e. it was handed off by a local process or loaded from the session
+// store. Returns the number of restored sessions and rooms.
+func (h *Hub) RestoreHandoffState(state *HandoffState, trusted bool) (int, int) {
+	rooms := make(map[string]*HandoffRoom, len(state.Rooms))
+	for _, room := range state.Rooms {
+		rooms[room.Id+"|"+room.BackendId] = room
+	}
+
+	roomSessions := make(map[*Room][]Session)
+	inCall := make(map[*Room][]Session)
+	now := time.Now()
+	restored := 0
+	for _, s := range state.Sessions {
+		h.mu.RLock()
+		_, found := h.sessions[s.Sid]
+		h.mu.RUnlock()
+		if found {
+			hubLog.Errorf("Could not restore session %s: a session with id %d already exists", s.PublicId, s.Sid)
+			continue
+		}
+
+		session, err := h.restoreHandoffSession(s, trusted)
+		if err != nil {
+			hubLog.Errorf("Could not restore session %s: %s", s.PublicId, err)
+			continue
+		}
+
+		// New sessions must not reuse the ids of restored sessions.
+		for {
+			sid := h.sid.Load()
+			if sid >= s.Sid || h.sid.CompareAndSwap(sid, s.Sid) {
+				break
+			}
+		}
+
+		h.mu.Lock()
+		h.sessions[s.Sid] = session
+		h.expiredSessions[session] = now.Add(sessionExpireDuration)
+		h.mu.Unlock()
+		h.storeSession(session, nil)
+		statsHubSessionsCurrent.WithLabelValues(statsBackendLabel(session.Backend()), string(session.ClientType())).Inc()
+		restored++
+
+		if s.RoomId == "" {
+			continue
+		}
+
+		room, err := h.getOrCreateHandoffRoom(s.RoomId, session.Backend(), rooms[s.RoomId+"|"+s.BackendId], now)
+		if err == nil {
+			err = session.SubscribeRoomEvents(s.RoomId, s.RoomSessionId)
+		}
+		if err != nil {
+			hubLog.Errorf("Could not restore room %s of session %s: %s", s.RoomId, s.PublicId, err)
+			continue
+		}
+
+		session.SetRoom(room)
+		roomSessions[room] = append(roomSessions[room], session)
+		if s.InCall {
+			inCall[room] = append(inCall[room], session)
+		}
+	}
+
+	for room, sessions := range roomSessions {
+		room.restoreSessions(sessions, inCall[room])
+	}
+	hubLog.Infof("Restored %d sessions in %d rooms", restored, len(roomSessions))
+	return restored, len(roomSessions)
+}
+
+func (h *Hub) getOrCreateHandoffRoom(roomId string, backend *Backend, state *HandoffRoom, now time.Time) (*Room, error) {
+	h.ru.Lock()
+	defer h.ru.Unlock()
+
+	room, found := h.rooms[getRoomIdForBackend(roomId, backend)]
+	if !found {
+		var properties json.RawMessage
+		if state != nil {
+			properties = state.Properties
+		}
+
+		var err error
+		if room, err = h.createRoom(roomId, properties, backend); err != nil {
+			return nil, err
+		}
+	}
+
+	if state != nil && len(state.TransientData) > 0 {
+		// Entries that were changed on this server take precedence.
+		room.transientData.MergeEntries(state.TransientData, now)
+	}
+	return room, nil
+}
+
+// CloseClientsForHandoff closes the connections of all clients without
+// notifying them, so they resume their sessions on the new process.
+func (h *Hub) CloseClientsForHandoff() {
+	h.mu.RLock()
+	clients := make([]HandlerClient, 0, len(h.clients))
+	for _, client := range h.clients {
+		clients = append(clients, client)
+	}
+	h.mu.RUnlock()
+
+	// The sessions are stored by the new process from now on.
+	h.sessionStoreDisabled.Store(true)
+	hubLog.Infof("Closing %d clients after handoff", len(clients))
+	for _, client := range clients {
+		client.Close()
+	}
+}
+
+// HandoffServer waits for a new process to request the listeners and the state
+// of the sessions.
+type HandoffServer struct {
+	hub      *Hub
+	path     string
+	listener *net.UnixListener
+
+	// Returns the listeners that should be handed off by their address.
+	getListeners func() map[string]net.Listener
+	// Stops the listeners of this process after the handoff.
+	stopListeners func()
+
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+func NewHandoffServer(path string, hub *Hub, getListeners func() map[string]net.Listener, stopListeners func()) (*HandoffServer, error) {
+	// Only processes of the same user may request a handoff. The socket is
+	// created in a directory that is only accessible by the owner and moved to
+	// its final path once the permissions have been restricted, so it can't be
+	// connected to in the meantime.
+	dir, err := os.MkdirTemp(filepath.Dir(path), ".handoff-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir) // nolint
+
+	tmpPath := filepath.Join(dir, filepath.Base(path))
+	listener, err := net.ListenUnix("unix", &net.UnixAddr{
+		Name: tmpPath,
+		Net:  "unix",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The socket is removed in "Close" unless it was handed off.
+	listener.SetUnlinkOnClose(false)
+	if err := os.Chmod(tmpPath, 0600); err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	result := &HandoffServer{
+		hub:      hub,
+		path:     path,
+		listener: listener,
+
+		getListeners:  getListeners,
+		stopListeners: stopListeners,
+
+		done: make(chan struct{}),
+	}
+	go result.run()
+	return result, nil
+}
+
+// Done is closed after the server handed off to a new process.
+func (s *HandoffServer) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *HandoffServer) Close() {
+	s.listener.Close()
+	select {
+	case <-s.done:
+		// The new process owns the socket path now.
+	default:
+		os.Remove(s.path) // nolint
+	}
+}
+
+func (s *HandoffServer) run() {
+	for {
+		conn, err := s.listener.AcceptUnix()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				appLog.Errorf("Error accepting handoff connection: %s", err)
+			}
+			return
+		}
+
+		if err := checkHandoffPeer(conn); err != nil {
+			appLog.Warnf("Rejecting handoff connection: %s", err)
+			conn.Close()
+			continue
+		}
+
+		err = s.handle(conn)
+		conn.Close()
+		if err != nil {
+			appLog.Errorf("Handoff to new process failed: %s", err)
+			continue
+		}
+
+		// The new process owns the socket path now, "Close" won't remove it.
+		s.doneOnce.Do(func() {
+			close(s.done)
+		})
+		s.listener.Close()
+		return
+	}
+}
+
+func (s *HandoffServer) handle(conn *net.UnixConn) error {
+	if err := conn.SetDeadline(time.Now().Add(handoffTimeout)); err != nil {
+		return err
+	}
+
+	reader := bufio.NewReader(conn)
+	var request handoffMessage
+	if err := readHandoffMessage(reader, &request); err != nil {
+		return err
+	} else if request.Type != "request" || request.Version != handoffProtocolVersion {
+		return fmt.Errorf("unsupported request %+v", request)
+	}
+
+	appLog.Infof("Handoff requested by new process")
+	listeners := s.getListeners()
+	addresses := make([]string, 0, len(listeners))
+	files := make([]*os.File, 0, len(listeners))
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+	for address, listener := range listeners {
+		f, err := getListenerFile(listener)
+		if err != nil {
+			appLog.Warnf("Could not hand off listener %s: %s", address, err)
+			continue
+		}
+
+		addresses = append(addresses, address)
+		files = append(files, f)
+	}
+
+	if err := writeHandoffListeners(conn, addresses, files); err != nil {
+		return err
+	}
+
+	state := s.hub.GetHandoffState()
+	if err := json.NewEncoder(conn).Encode(state); err != nil {
+		return err
+	}
+
+	var response handoffMessage
+	if err := readHandoffMessage(reader, &response); err != nil {
+		return err
+	}
+	switch response.Type {
+	case "ready":
+	case "error":
+		return fmt.Errorf("new process reported error: %s", response.Error)
+	default:
+		return fmt.Errorf("unsupported response %+v", response)
+	}
+
+	appLog.Infof("Handed off %d listeners and %d sessions to new process", len(addresses), len(state.Sessions))
+	for _, listener := range listeners {
+		if l, ok := listener.(*net.UnixListener); ok {
+			// The socket is used by the new process.
+			l.SetUnlinkOnClose(false)
+		}
+	}
+	s.stopListeners()
+	s.hub.CloseClientsForHandoff()
+	return nil
+}
+
+func getListenerFile(listener net.Listener) (*os.File, error) {
+	switch l := listener.(type) {
+	case *net.TCPListener:
+		return l.File()
+	case *net.UnixListener:
+		return l.File()
+	default:
+		return nil, fmt.Errorf("unsupported listener %T", listener)
+	}
+}
+
+func readHandoffMessage(reader *bufio.Reader, message *handoffMessage) error {
+	line, err := reader.ReadBytes('\n')
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(line, message)
+}
+
+func writeHandoffMessage(w io.Writer, message *handoffMessage) error {
+	return json.NewEncoder(w).Encode(message)
+}
+
+// HandoffClient requests the listeners and sessions from a running process.
+type HandoffClient struct {
+	conn *net.UnixConn
+
+	// Listeners of the previous process by their address.
+	Listeners map[string]net.Listener
+	State     *HandoffState
+}
+
+// RequestHandoff connects to the handoff socket of a running process and
+// receives its listeners and the state of the sessions.
+func RequestHandoff(ctx context.Context, path string) (*HandoffClient, error) {
+	var dialer net.Dialer
+	c, err := dialer.DialContext(ctx, "unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := c.(*net.UnixConn)
+	if err := conn.SetDeadline(time.Now().Add(handoffTimeout)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if err := writeHandoffMessage(conn, &handoffMessage{
+		Type:    "request",
+		Version: handoffProtocolVersion,
+	}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	addresses, files, rest, err := readHandoffListeners(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	result := &HandoffClient{
+		conn:      conn,
+		Listeners: make(map[string]net.Listener, len(addresses)),
+	}
+	for idx, address := range addresses {
+		listener, err := net.FileListener(files[idx])
+		files[idx].Close()
+		if err != nil {
+			appLog.Warnf("Could not create listener %s from handoff: %s", address, err)
+			continue
+		}
+
+		result.Listeners[address] = listener
+	}
+
+	var state HandoffState
+	if err := json.NewDecoder(io.MultiReader(bytes.NewReader(rest), conn)).Decode(&state); err != nil {
+		result.Abort(err)
+		return nil, err
+	}
+
+	result.State = &state
+	return result, nil
+}
+
+// Complete notifies the previous process that the sessions were restored and
+// the listeners are used by this process.
+func (c *HandoffClient) Complete() error {
+	defer c.conn.Close()
+	return writeHandoffMessage(c.conn, &handoffMessage{
+		Type: "ready",
+	})
+}
+
+// Abort notifies the previous process that the handoff failed, so it continues
+// to serve its clients.
+func (c *HandoffClient) Abort(err error) {
+	defer c.conn.Close()
+	for _, listener := range c.Listeners {
+		if l, ok := listener.(*net.UnixListener); ok {
+			l.SetUnlinkOnClose(false)
+		}
+		listener.Close()
+	}
+	if err := writeHandoffMessage(c.conn, &handoffMessage{
+		Type:  "error",
+		Error: err.Error(),
+	}); err != nil {
+		appLog.Warnf("Could not send handoff error: %s", err)
+	}
+}
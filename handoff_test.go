@@ -0,0 +1,227 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"net"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dlintw/goconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandoff(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+
+	hub1, _, _, server1 := CreateHubForTest(t)
+	// The new hub uses the same configuration (backend and session keys).
+	hub2, _, _, server2 := CreateHubForTestWithConfig(t, func(*httptest.Server) (*goconf.ConfigFile, error) {
+		return getTestConfig(server1)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client1, hello1 := NewTestClientWithHello(ctx, t, server1, hub1, testDefaultUserId+"1")
+	client2, hello2 := NewTestClientWithHello(ctx, t, server1, hub1, testDefaultUserId+"2")
+	roomId := "test-room"
+	roomMsg := MustSucceed2(t, client1.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+	client1.RunUntilJoined(ctx, hello1.Hello)
+	roomMsg = MustSucceed2(t, client2.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+	client1.RunUntilJoined(ctx, hello2.Hello)
+	client2.RunUntilJoined(ctx, hello1.Hello, hello2.Hello)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer listener.Close()
+
+	var stopped atomic.Bool
+	path := filepath.Join(t.TempDir(), "handoff.sock")
+	handoffServer, err := NewHandoffServer(path, hub1, func() map[string]net.Listener {
+		return map[string]net.Listener{
+			"the-address": listener,
+		}
+	}, func() {
+		stopped.Store(true)
+	})
+	require.NoError(err)
+	defer handoffServer.Close()
+
+	handoff, err := RequestHandoff(ctx, path)
+	require.NoError(err)
+
+	// The listener is shared with the new process.
+	if assert.Len(handoff.Listeners, 1) && assert.Contains(handoff.Listeners, "the-address") {
+		inherited := handoff.Listeners["the-address"]
+		defer inherited.Close()
+		assert.Equal(listener.Addr().String(), inherited.Addr().String())
+		conn, err := net.Dial("tcp", inherited.Addr().String())
+		require.NoError(err)
+		defer conn.Close()
+		accepted, err := inherited.Accept()
+		require.NoError(err)
+		accepted.Close()
+	}
+
+	if assert.Len(handoff.State.Sessions, 2) && assert.Len(handoff.State.Rooms, 1) {
+		assert.Equal(roomId, handoff.State.Rooms[0].Id)
+	}
+	hub2.RestoreHandoffState(handoff.State, true)
+	assert.False(stopped.Load())
+	require.NoError(handoff.Complete())
+
+	select {
+	case <-handoffServer.Done():
+	case <-ctx.Done():
+		require.NoError(ctx.Err())
+	}
+	assert.True(stopped.Load())
+
+	// The clients of the previous process are disconnected without "bye".
+	client1.RunUntilClosed(ctx)
+	client2.RunUntilClosed(ctx)
+
+	// The sessions are resumed on the new process and are still in the room.
+	session1 := hub2.GetSessionByPublicId(hello1.Hello.SessionId)
+	require.NotNil(session1)
+	room := hub2.GetRoomForBackend(roomId, session1.Backend())
+	require.NotNil(room)
+	for _, hello := range []*ServerMessage{hello1, hello2} {
+		session := hub2.GetSessionByPublicId(hello.Hello.SessionId)
+		if assert.NotNil(session, "session %s not restored", hello.Hello.SessionId) {
+			assert.True(room.HasSession(session))
+			assert.Same(room, session.(*ClientSession).GetRoom())
+		}
+	}
+
+	// The previous process would exit, expire the sessions to cleanup the hub.
+	hub1.performHousekeeping(time.Now().Add(sessionExpireDuration + time.Second))
+
+	for _, hello := range []*ServerMessage{hello1, hello2} {
+		client := NewTestClient(t, server2, hub2)
+		defer client.CloseWithBye()
+		require.NoError(client.SendHelloResume(hello.Hello.ResumeId))
+		if resumed, ok := client.RunUntilHello(ctx); ok {
+			assert.Equal(hello.Hello.SessionId, resumed.Hello.SessionId)
+			assert.Equal(hello.Hello.UserId, resumed.Hello.UserId)
+		}
+	}
+
+	// New sessions don't reuse ids of restored sessions.
+	client3 := NewTestClient(t, server2, hub2)
+	defer client3.CloseWithBye()
+	require.NoError(client3.SendHelloParams(server1.URL, HelloVersionV1, "", nil, TestBackendClientAuthParams{
+		UserId: testDefaultUserId + "3",
+	}))
+	hello3 := MustSucceed1(t, client3.RunUntilHello, ctx)
+	assert.NotEqual(hello1.Hello.SessionId, hello3.Hello.SessionId)
+	assert.NotEqual(hello2.Hello.SessionId, hello3.Hello.SessionId)
+}
+
+func TestHandoffServerSocket(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+
+	hub, _, _, _ := CreateHubForTest(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "handoff.sock")
+	handoffServer, err := NewHandoffServer(path, hub, func() map[string]net.Listener {
+		return nil
+	}, func() {
+		assert.Fail("listeners should not be stopped")
+	})
+	require.NoError(err)
+
+	fi, err := os.Stat(path)
+	require.NoError(err)
+	assert.Equal(os.ModeSocket, fi.Mode().Type())
+	assert.Equal(os.FileMode(0600), fi.Mode().Perm())
+
+	// The temporary directory of the socket has been removed.
+	entries, err := os.ReadDir(dir)
+	require.NoError(err)
+	if assert.Len(entries, 1) {
+		assert.Equal("handoff.sock", entries[0].Name())
+	}
+
+	conn, err := net.DialUnix("unix", nil, &net.UnixAddr{
+		Name: path,
+		Net:  "unix",
+	})
+	require.NoError(err)
+	defer conn.Close()
+	assert.NoError(checkHandoffPeer(conn))
+
+	handoffServer.Close()
+	_, err = os.Stat(path)
+	assert.ErrorIs(err, os.ErrNotExist)
+}
+
+func TestHandoffUnsupportedRequest(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+
+	hub, _, _, _ := CreateHubForTest(t)
+	path := filepath.Join(t.TempDir(), "handoff.sock")
+	handoffServer, err := NewHandoffServer(path, hub, func() map[string]net.Listener {
+		return nil
+	}, func() {
+		assert.Fail("listeners should not be stopped")
+	})
+	require.NoError(err)
+	defer handoffServer.Close()
+
+	conn, err := net.Dial("unix", path)
+	require.NoError(err)
+	defer conn.Close()
+
+	require.NoError(writeHandoffMessage(conn, &handoffMessage{
+		Type:    "request",
+		Version: handoffProtocolVersion + 1,
+	}))
+	// The connection is closed without response.
+	n, err := conn.Read(make([]byte, 1))
+	assert.Equal(0, n)
+	assert.Error(err)
+
+	select {
+	case <-handoffServer.Done():
+		assert.Fail("handoff should not be done")
+	default:
+	}
+}
@@ -0,0 +1,109 @@
+//go:build unix
+
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"syscall"
+)
+
+func writeHandoffListeners(conn *net.UnixConn, addresses []string, files []*os.File) error {
+	data, err := json.Marshal(&handoffMessage{
+		Type:      "listeners",
+		Addresses: addresses,
+	})
+	if err != nil {
+		return err
+	}
+
+	fds := make([]int, 0, len(files))
+	for _, f := range files {
+		fds = append(fds, int(f.Fd()))
+	}
+
+	var oob []byte
+	if len(fds) > 0 {
+		oob = syscall.UnixRights(fds...)
+	}
+	_, _, err = conn.WriteMsgUnix(append(data, '\n'), oob, nil)
+	return err
+}
+
+// readHandoffListeners returns the addresses and files of the listeners and
+// any data that was received after the message.
+func readHandoffListeners(conn *net.UnixConn) ([]string, []*os.File, []byte, error) {
+	buf := make([]byte, handoffMaxListenersMessageSize)
+	oob := make([]byte, syscall.CmsgSpace(64*4))
+	n, oobn, _, _, err := conn.ReadMsgUnix(buf, oob)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	var files []*os.File
+	if oobn > 0 {
+		messages, err := syscall.ParseSocketControlMessage(oob[:oobn])
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		for _, msg := range messages {
+			fds, err := syscall.ParseUnixRights(&msg)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+
+			for _, fd := range fds {
+				files = append(files, os.NewFile(uintptr(fd), "handoff-listener"))
+			}
+		}
+	}
+
+	closeFiles := func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}
+
+	line, rest, found := bytes.Cut(buf[:n], []byte("\n"))
+	if !found {
+		closeFiles()
+		return nil, nil, nil, errors.New("incomplete listeners message")
+	}
+
+	var message handoffMessage
+	if err := json.Unmarshal(line, &message); err != nil {
+		closeFiles()
+		return nil, nil, nil, err
+	} else if message.Type != "listeners" || len(message.Addresses) != len(files) {
+		closeFiles()
+		return nil, nil, nil, fmt.Errorf("unsupported listeners message %+v with %d files", message, len(files))
+	}
+
+	return message.Addresses, files, bytes.Clone(rest), nil
+}
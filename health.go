@@ -0,0 +1,220 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"fmt"
+)
+
+func healthOk(format string, args ...any) BackendServerHealthComponent {
+	return BackendServerHealthComponent{
+		Status:  HealthStatusOk,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+func healthDegraded(format string, args ...any) BackendServerHealthComponent {
+	return BackendServerHealthComponent{
+		Status:  HealthStatusDegraded,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+func healthError(format string, args ...any) BackendServerHealthComponent {
+	return BackendServerHealthComponent{
+		Status:  HealthStatusError,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+var (
+	healthDisabled = BackendServerHealthComponent{
+		Status: HealthStatusDisabled,
+	}
+)
+
+func (h *Hub) getHealthHub(ready bool) BackendServerHealthComponent {
+	if h.closer.IsClosed() {
+		return healthError("stopped")
+	} else if ready && h.draining.Load() {
+		// Clients are migrating to other servers of the cluster.
+		return healthError("draining")
+	} else if ready && h.shutdownScheduled.Load() {
+		// Don't route new clients to a server that is shutting down.
+		return healthError("shutdown scheduled")
+	}
+
+	return healthOk("running")
+}
+
+func getHealthEvents(events AsyncEvents) BackendServerHealthComponent {
+	var connected bool
+	switch e := events.(type) {
+	case *asyncEventsNats:
+		info := e.GetServerInfoNats()
+		connected = info != nil && info.Connected
+	case *asyncEventsRedis:
+		connected = e.client.IsConnected()
+	default:
+		return healthOk("")
+	}
+
+	if !connected {
+		return healthError("not connected")
+	}
+
+	return healthOk("connected")
+}
+
+func (h *Hub) getHealthEtcd() BackendServerHealthComponent {
+	if h.etcdClient == nil {
+		return healthDisabled
+	}
+
+	info := h.etcdClient.GetServerInfoEtcd()
+	if info == nil || info.Connected == nil || !*info.Connected {
+		return healthError("not connected")
+	}
+
+	return healthOk("connected")
+}
+
+func (h *Hub) getHealthGeoIP() BackendServerHealthComponent {
+	geoip := h.geoip.Load()
+	if geoip == nil {
+		return healthDisabled
+	}
+
+	if !geoip.IsLoaded() {
+		// Lookups are optional, clients can still be served.
+		return healthDegraded("database not loaded")
+	}
+
+	return healthOk("database loaded")
+}
+
+func (h *Hub) getHealthMcu() BackendServerHealthComponent {
+	if h.mcu == nil {
+		return healthDisabled
+	}
+
+	info := h.mcu.GetServerInfoSfu()
+	if info == nil {
+		return healthOk("")
+	}
+
+	switch info.Mode {
+	case SfuModeJanus:
+		if info.Janus == nil || !info.Janus.Connected {
+			return healthError("not connected")
+		}
+		return healthOk("connected")
+	case SfuModeProxy:
+		var connected int
+		for _, proxy := range info.Proxies {
+			if proxy.Connected && (proxy.Shutdown == nil || !*proxy.Shutdown) {
+				connected++
+			}
+		}
+		if connected == 0 {
+			return healthError("no proxies available")
+		} else if connected < len(info.Proxies) {
+			return healthDegraded("%d of %d proxies available", connected, len(info.Proxies))
+		}
+		return healthOk("%d proxies available", connected)
+	default:
+		return healthOk("")
+	}
+}
+
+func (h *Hub) getHealthGrpc() BackendServerHealthComponent {
+	if h.rpcClients == nil {
+		return healthDisabled
+	}
+
+	clients := h.rpcClients.GetServerInfoGrpc()
+	var connected int
+	for _, client := range clients {
+		if client.Connected {
+			connected++
+		}
+	}
+	if connected < len(clients) {
+		// Local sessions can still be served if other servers are unavailable.
+		return healthDegraded("%d of %d peers connected", connected, len(clients))
+	}
+
+	return healthOk("%d peers connected", connected)
+}
+
+func (h *Hub) getHealthBackends() BackendServerHealthComponent {
+	backends := h.backend.backends
+	switch s := backends.storage.(type) {
+	case *backendStorageStatic:
+		if s.allowAll {
+			return healthOk("all backends allowed")
+		}
+	case *backendStorageEtcd:
+		if s.initializedCtx.Err() == nil {
+			return healthError("not loaded from etcd")
+		}
+	}
+
+	count := len(backends.GetBackends())
+	if count == 0 {
+		return healthError("no backends configured")
+	}
+
+	return healthOk("%d backends configured", count)
+}
+
+// GetHealth returns the state of the hub and the components it depends on. If
+// "ready" is false, only the components that are required to keep the process
+// running are checked.
+func (h *Hub) GetHealth(ready bool) *BackendServerHealth {
+	result := &BackendServerHealth{
+		Components: map[string]BackendServerHealthComponent{
+			"hub": h.getHealthHub(ready),
+		},
+	}
+	if ready {
+		result.Components["events"] = getHealthEvents(h.events)
+		result.Components["etcd"] = h.getHealthEtcd()
+		result.Components["geoip"] = h.getHealthGeoIP()
+		result.Components["mcu"] = h.getHealthMcu()
+		result.Components["grpc"] = h.getHealthGrpc()
+		result.Components["backends"] = h.getHealthBackends()
+	}
+
+	result.Status = HealthStatusOk
+	for _, component := range result.Components {
+		switch component.Status {
+		case HealthStatusError:
+			result.Status = HealthStatusError
+		case HealthStatusDegraded:
+			if result.Status == HealthStatusOk {
+				result.Status = HealthStatusDegraded
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,143 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type healthTestMCU struct {
+	*TestMCU
+
+	info *BackendServerInfoSfu
+}
+
+func (m *healthTestMCU) GetServerInfoSfu() *BackendServerInfoSfu {
+	return m.info
+}
+
+func TestHealth_Mcu(t *testing.T) {
+	t.Parallel()
+	mcu, err := NewTestMCU()
+	require.NoError(t, err)
+
+	testcases := []struct {
+		info     *BackendServerInfoSfu
+		expected HealthStatus
+	}{
+		{
+			&BackendServerInfoSfu{
+				Mode:  SfuModeJanus,
+				Janus: &BackendServerInfoSfuJanus{},
+			},
+			HealthStatusError,
+		},
+		{
+			&BackendServerInfoSfu{
+				Mode: SfuModeJanus,
+				Janus: &BackendServerInfoSfuJanus{
+					Connected: true,
+				},
+			},
+			HealthStatusOk,
+		},
+		{
+			&BackendServerInfoSfu{
+				Mode: SfuModeProxy,
+			},
+			HealthStatusError,
+		},
+		{
+			&BackendServerInfoSfu{
+				Mode: SfuModeProxy,
+				Proxies: []BackendServerInfoSfuProxy{
+					{Connected: true},
+					{Connected: false},
+				},
+			},
+			HealthStatusDegraded,
+		},
+		{
+			&BackendServerInfoSfu{
+				Mode: SfuModeProxy,
+				Proxies: []BackendServerInfoSfuProxy{
+					{Connected: true},
+					{Connected: true, Shutdown: makePtr(true)},
+				},
+			},
+			HealthStatusDegraded,
+		},
+		{
+			&BackendServerInfoSfu{
+				Mode: SfuModeProxy,
+				Proxies: []BackendServerInfoSfuProxy{
+					{Connected: true, Shutdown: makePtr(true)},
+				},
+			},
+			HealthStatusError,
+		},
+		{
+			&BackendServerInfoSfu{
+				Mode: SfuModeProxy,
+				Proxies: []BackendServerInfoSfuProxy{
+					{Connected: true},
+					{Connected: true, Shutdown: makePtr(false)},
+				},
+			},
+			HealthStatusOk,
+		},
+	}
+
+	for idx, tc := range testcases {
+		hub := &Hub{
+			mcu: &healthTestMCU{
+				TestMCU: mcu,
+				info:    tc.info,
+			},
+		}
+		assert.Equal(t, tc.expected, hub.getHealthMcu().Status, "failed for testcase %d", idx)
+	}
+
+	hub := &Hub{}
+	assert.Equal(t, HealthStatusDisabled, hub.getHealthMcu().Status)
+}
+
+func TestHealth_Status(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	hub := &Hub{
+		closer: NewCloser(),
+	}
+	hub.geoip.Store(&GeoLookup{})
+
+	// The geoip database is not loaded but the hub is still alive.
+	health := hub.GetHealth(false)
+	assert.Equal(HealthStatusOk, health.Status)
+	assert.Equal(HealthStatusDegraded, hub.getHealthGeoIP().Status)
+
+	hub.closer.Close()
+	health = hub.GetHealth(false)
+	assert.Equal(HealthStatusError, health.Status)
+}
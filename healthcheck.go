@@ -0,0 +1,196 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dlintw/goconf"
+)
+
+const (
+	// Timeout of the request performed by the healthcheck.
+	healthcheckTimeout = 5 * time.Second
+)
+
+var (
+	ErrNoHealthcheckListener = errors.New("no listener configured that can be checked")
+	ErrNoAdminListener       = errors.New("no admin listener configured that can be reached")
+)
+
+type healthcheckTarget struct {
+	url     string
+	network string
+	address string
+}
+
+func getHealthcheckTarget(scheme string, addr string, path string) (*healthcheckTarget, bool) {
+	if strings.HasPrefix(addr, systemdListenerPrefix) {
+		// The address of sockets passed by systemd is unknown.
+		return nil, false
+	}
+
+	if addr != "" && addr[0] == '/' {
+		return &healthcheckTarget{
+			url:     scheme + "://localhost" + path,
+			network: "unix",
+			address: addr,
+		}, true
+	}
+
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, false
+	}
+
+	switch host {
+	case "", "0.0.0.0":
+		host = "127.0.0.1"
+	case "::":
+		host = "::1"
+	}
+
+	address := net.JoinHostPort(host, port)
+	return &healthcheckTarget{
+		url:     scheme + "://" + address + path,
+		network: "tcp",
+		address: address,
+	}, true
+}
+
+// getHealthcheckTargetFromConfig returns the local endpoint to check for the
+// given configuration. The HTTP listener is preferred over the HTTPS listener.
+func getHealthcheckTargetFromConfig(config *goconf.ConfigFile, path string) (*healthcheckTarget, error) {
+	for _, scheme := range []string{"http", "https"} {
+		listen, _ := GetStringOptionWithEnv(config, scheme, "listen")
+		for addr := range SplitEntries(listen, " ") {
+			if target, ok := getHealthcheckTarget(scheme, addr, path); ok {
+				return target, nil
+			}
+		}
+	}
+
+	return nil, ErrNoHealthcheckListener
+}
+
+// getAdminTargetFromConfig returns the local endpoint of the admin API for the
+// given configuration.
+func getAdminTargetFromConfig(config *goconf.ConfigFile, path string) (*healthcheckTarget, error) {
+	listen, _ := GetStringOptionWithEnv(config, "admin", "listen")
+	for addr := range SplitEntries(listen, " ") {
+		if target, ok := getHealthcheckTarget("http", addr, path); ok {
+			return target, nil
+		}
+	}
+
+	return nil, ErrNoAdminListener
+}
+
+// PerformLocalRequest performs a GET request to the endpoint with the given
+// path of the server running with the configuration. The token from the
+// "[stats]" section is sent if configured. The caller must close the body of
+// the returned response.
+func PerformLocalRequest(ctx context.Context, config *goconf.ConfigFile, path string) (*http.Response, error) {
+	target, err := getHealthcheckTargetFromConfig(config, path)
+	if err != nil {
+		return nil, err
+	}
+
+	token, _ := GetStringOptionWithEnv(config, "stats", "token")
+	return performLocalRequest(ctx, target, token, http.MethodGet, nil, healthcheckTimeout)
+}
+
+// PerformLocalAdminRequest performs a request to the admin API of the server
+// running with the configuration. The token from the "[admin]" section is sent.
+// The request is not limited by a timeout, this must be handled through the
+// context. The caller must close the body of the returned response.
+func PerformLocalAdminRequest(ctx context.Context, config *goconf.ConfigFile, method string, path string, body io.Reader) (*http.Response, error) {
+	target, err := getAdminTargetFromConfig(config, path)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := getAdminToken(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return performLocalRequest(ctx, target, token, method, body, 0)
+}
+
+func performLocalRequest(ctx context.Context, target *healthcheckTarget, token string, method string, body io.Reader, timeout time.Duration) (*http.Response, error) {
+	var dialer net.Dialer
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				return dialer.DialContext(ctx, target.network, target.address)
+			},
+			TLSClientConfig: &tls.Config{
+				// The certificate is issued for the public name, not the local
+				// address.
+				InsecureSkipVerify: true, // nolint
+			},
+			DisableKeepAlives: true,
+		},
+		Timeout: timeout,
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, target.url, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if token != "" {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return client.Do(request)
+}
+
+// RunHealthcheck performs a request to the endpoint with the given path of the
+// server running with the configuration. An error is returned if the server
+// could not be reached or didn't respond with a status code of 200.
+func RunHealthcheck(ctx context.Context, config *goconf.ConfigFile, path string) error {
+	response, err := PerformLocalRequest(ctx, config, path)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return fmt.Errorf("%s returned status %d: %s", response.Request.URL, response.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/dlintw/goconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealthcheckTarget(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	testcases := []struct {
+		http     string
+		https    string
+		url      string
+		network  string
+		expected string
+	}{
+		{"127.0.0.1:8080", "", "http://127.0.0.1:8080/ready", "tcp", "127.0.0.1:8080"},
+		{"0.0.0.0:8080", "", "http://127.0.0.1:8080/ready", "tcp", "127.0.0.1:8080"},
+		{":8080", "", "http://127.0.0.1:8080/ready", "tcp", "127.0.0.1:8080"},
+		{"[::]:8080", "", "http://[::1]:8080/ready", "tcp", "[::1]:8080"},
+		{"", "0.0.0.0:8443", "https://127.0.0.1:8443/ready", "tcp", "127.0.0.1:8443"},
+		{"/run/signaling.sock", "", "http://localhost/ready", "unix", "/run/signaling.sock"},
+		{"systemd:http 10.1.2.3:8080", "", "http://10.1.2.3:8080/ready", "tcp", "10.1.2.3:8080"},
+		{"systemd:http", "127.0.0.1:8443", "https://127.0.0.1:8443/ready", "tcp", "127.0.0.1:8443"},
+	}
+
+	for _, tc := range testcases {
+		config := goconf.NewConfigFile()
+		if tc.http != "" {
+			config.AddOption("http", "listen", tc.http)
+		}
+		if tc.https != "" {
+			config.AddOption("https", "listen", tc.https)
+		}
+
+		if target, err := getHealthcheckTargetFromConfig(config, "/ready"); assert.NoError(err, "failed for %+v", tc) {
+			assert.Equal(tc.url, target.url)
+			assert.Equal(tc.network, target.network)
+			assert.Equal(tc.expected, target.address)
+		}
+	}
+
+	config := goconf.NewConfigFile()
+	_, err := getHealthcheckTargetFromConfig(config, "/ready")
+	assert.ErrorIs(err, ErrNoHealthcheckListener)
+
+	config.AddOption("http", "listen", "systemd:http")
+	_, err = getHealthcheckTargetFromConfig(config, "/ready")
+	assert.ErrorIs(err, ErrNoHealthcheckListener)
+}
+
+func TestRunHealthcheck(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	assert := assert.New(t)
+
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ready" {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	config := goconf.NewConfigFile()
+	config.AddOption("http", "listen", server.Listener.Addr().String())
+
+	ctx := context.Background()
+	assert.NoError(RunHealthcheck(ctx, config, "/ready"))
+	assert.Error(RunHealthcheck(ctx, config, "/live"))
+
+	status = http.StatusServiceUnavailable
+	assert.ErrorContains(RunHealthcheck(ctx, config, "/ready"), "503")
+
+	// Use a server that is listening on a unix socket.
+	socket := filepath.Join(t.TempDir(), "signaling.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(err)
+	unixServer := httptest.NewUnstartedServer(server.Config.Handler)
+	unixServer.Listener = listener
+	unixServer.Start()
+	defer unixServer.Close()
+
+	status = http.StatusOK
+	config = goconf.NewConfigFile()
+	config.AddOption("http", "listen", socket)
+	assert.NoError(RunHealthcheck(ctx, config, "/ready"))
+
+	unixServer.Close()
+	assert.Error(RunHealthcheck(ctx, config, "/ready"))
+}
+
+func TestPerformLocalRequestToken(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer the-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := goconf.NewConfigFile()
+	config.AddOption("http", "listen", server.Listener.Addr().String())
+
+	response, err := PerformLocalRequest(context.Background(), config, "/api/v1/stats")
+	require.NoError(err)
+	response.Body.Close()
+	assert.Equal(http.StatusUnauthorized, response.StatusCode)
+
+	config.AddOption("stats", "token", "the-token")
+	response, err = PerformLocalRequest(context.Background(), config, "/api/v1/stats")
+	require.NoError(err)
+	response.Body.Close()
+	assert.Equal(http.StatusOK, response.StatusCode)
+}
+
+func TestPerformLocalAdminRequest(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	assert := assert.New(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer the-admin-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := goconf.NewConfigFile()
+	config.AddOption("http", "listen", server.Listener.Addr().String())
+	config.AddOption("stats", "token", "the-token")
+	_, err := PerformLocalAdminRequest(context.Background(), config, http.MethodPost, "/api/v1/state", nil)
+	assert.ErrorIs(err, ErrNoAdminListener)
+
+	config.AddOption("admin", "listen", server.Listener.Addr().String())
+	_, err = PerformLocalAdminRequest(context.Background(), config, http.MethodPost, "/api/v1/state", nil)
+	assert.ErrorIs(err, ErrAdminTokenMissing)
+
+	config.AddOption("admin", "token", "the-admin-token")
+	response, err := PerformLocalAdminRequest(context.Background(), config, http.MethodPost, "/api/v1/state", nil)
+	require.NoError(err)
+	response.Body.Close()
+	assert.Equal(http.StatusOK, response.StatusCode)
+}
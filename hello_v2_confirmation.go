@@ -0,0 +1,266 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrConfirmationProofMissing   = errors.New("confirmation proof missing")
+	ErrConfirmationProofInvalid   = errors.New("confirmation proof invalid")
+	ErrConfirmationProofReplayed  = errors.New("confirmation proof replayed")
+	ErrConfirmationKeyMismatch    = errors.New("confirmation key mismatch")
+	ErrConfirmationUnsupported    = errors.New("unsupported confirmation method")
+	ErrUnsupportedConfirmationKey = errors.New("unsupported confirmation key")
+)
+
+// HelloV2ProofClaims are the claims of the proof-of-possession token that
+// must be sent by the client together with a bound Hello v2 token.
+type HelloV2ProofClaims struct {
+	jwt.RegisteredClaims
+
+	// Base64url-encoded SHA-256 hash of the Hello v2 token.
+	TokenHash string `json:"ath"`
+}
+
+type helloV2ProofJwk struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+}
+
+func decodeJwkInt(s string) (*big.Int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	} else if len(data) == 0 {
+		return nil, ErrUnsupportedConfirmationKey
+	}
+
+	return new(big.Int).SetBytes(data), nil
+}
+
+func (k *helloV2ProofJwk) PublicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, ErrUnsupportedConfirmationKey
+		}
+
+		x, err := decodeJwkInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeJwkInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, ErrUnsupportedConfirmationKey
+		}
+
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     x,
+			Y:     y,
+		}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, ErrUnsupportedConfirmationKey
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		} else if len(x) != ed25519.PublicKeySize {
+			return nil, ErrUnsupportedConfirmationKey
+		}
+
+		return ed25519.PublicKey(x), nil
+	case "RSA":
+		n, err := decodeJwkInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeJwkInt(k.E)
+		if err != nil {
+			return nil, err
+		} else if !e.IsInt64() || e.Int64() > 1<<31-1 {
+			return nil, ErrUnsupportedConfirmationKey
+		}
+
+		return &rsa.PublicKey{
+			N: n,
+			E: int(e.Int64()),
+		}, nil
+	default:
+		return nil, ErrUnsupportedConfirmationKey
+	}
+}
+
+// Thumbprint returns the base64url-encoded SHA-256 thumbprint of the key as
+// defined in RFC 7638.
+func (k *helloV2ProofJwk) Thumbprint() (string, error) {
+	// The required members must be ordered lexicographically.
+	var members any
+	switch k.Kty {
+	case "EC":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		}{k.Crv, k.Kty, k.X, k.Y}
+	case "OKP":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{k.Crv, k.Kty, k.X}
+	case "RSA":
+		members = struct {
+			E   string `json:"e"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+		}{k.E, k.Kty, k.N}
+	default:
+		return "", ErrUnsupportedConfirmationKey
+	}
+
+	data, err := json.Marshal(members)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(hash[:]), nil
+}
+
+func getHelloV2TokenHash(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+// VerifyHelloV2Confirmation checks that the client that sent the Hello v2
+// token has possession of the key the token is bound to. The ids of accepted
+// proofs are remembered in "proofs" until they expire, so each proof can only
+// be used for one connection.
+func VerifyHelloV2Confirmation(cnf *HelloV2TokenConfirmation, token string, proof string, proofs *backendReplayCache) error {
+	if cnf.JwkThumbprint == "" {
+		return ErrConfirmationUnsupported
+	} else if proof == "" {
+		return ErrConfirmationProofMissing
+	}
+
+	claims := &HelloV2ProofClaims{}
+	parsed, err := jwt.ParseWithClaims(proof, claims, func(token *jwt.Token) (any, error) {
+		value, found := token.Header["jwk"]
+		if !found {
+			return nil, ErrConfirmationProofInvalid
+		}
+
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+
+		var jwk helloV2ProofJwk
+		if err := json.Unmarshal(data, &jwk); err != nil {
+			return nil, err
+		}
+
+		thumbprint, err := jwk.Thumbprint()
+		if err != nil {
+			return nil, err
+		}
+
+		if subtle.ConstantTimeCompare([]byte(thumbprint), []byte(cnf.JwkThumbprint)) != 1 {
+			return nil, ErrConfirmationKeyMismatch
+		}
+
+		return jwk.PublicKey()
+	}, jwt.WithValidMethods([]string{
+		jwt.SigningMethodRS256.Alg(),
+		jwt.SigningMethodRS384.Alg(),
+		jwt.SigningMethodRS512.Alg(),
+		jwt.SigningMethodES256.Alg(),
+		jwt.SigningMethodES384.Alg(),
+		jwt.SigningMethodES512.Alg(),
+		jwt.SigningMethodEdDSA.Alg(),
+	}), jwt.WithIssuedAt(), jwt.WithLeeway(tokenLeeway))
+	if err != nil {
+		if errors.Is(err, ErrConfirmationKeyMismatch) {
+			return ErrConfirmationKeyMismatch
+		}
+		return fmt.Errorf("%w: %s", ErrConfirmationProofInvalid, err)
+	} else if !parsed.Valid {
+		return ErrConfirmationProofInvalid
+	}
+
+	// Proofs must be created freshly for each connection attempt.
+	if claims.IssuedAt == nil || claims.IssuedAt.Before(time.Now().Add(-tokenLeeway)) {
+		return fmt.Errorf("%w: issued at missing or too old", ErrConfirmationProofInvalid)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(claims.TokenHash), []byte(getHelloV2TokenHash(token))) != 1 {
+		return fmt.Errorf("%w: token hash mismatch", ErrConfirmationProofInvalid)
+	}
+
+	if claims.ID == "" {
+		return fmt.Errorf("%w: id missing", ErrConfirmationProofInvalid)
+	}
+
+	switch err := proofs.Check(cnf.JwkThumbprint+"|"+claims.ID, claims.IssuedAt.Time, time.Now()); err {
+	case nil:
+		return nil
+	case ErrBackendRequestReplayed:
+		return ErrConfirmationProofReplayed
+	default:
+		return fmt.Errorf("%w: %s", ErrConfirmationProofInvalid, err)
+	}
+}
@@ -0,0 +1,173 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type helloV2ProofKeyForTest struct {
+	method jwt.SigningMethod
+	key    crypto.PrivateKey
+	jwk    helloV2ProofJwk
+}
+
+func newHelloV2ProofKeyForTest(t *testing.T, algo string) *helloV2ProofKeyForTest {
+	t.Helper()
+	require := require.New(t)
+	switch algo {
+	case "RSA":
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(err)
+		return &helloV2ProofKeyForTest{
+			method: jwt.SigningMethodRS256,
+			key:    key,
+			jwk: helloV2ProofJwk{
+				Kty: "RSA",
+				N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			},
+		}
+	case "ECDSA":
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(err)
+		return &helloV2ProofKeyForTest{
+			method: jwt.SigningMethodES256,
+			key:    key,
+			jwk: helloV2ProofJwk{
+				Kty: "EC",
+				Crv: "P-256",
+				X:   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+				Y:   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+			},
+		}
+	case "Ed25519":
+		public, key, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(err)
+		return &helloV2ProofKeyForTest{
+			method: jwt.SigningMethodEdDSA,
+			key:    key,
+			jwk: helloV2ProofJwk{
+				Kty: "OKP",
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(public),
+			},
+		}
+	default:
+		require.Fail("unsupported algorithm", algo)
+		return nil
+	}
+}
+
+func (k *helloV2ProofKeyForTest) Confirmation(t *testing.T) *HelloV2TokenConfirmation {
+	t.Helper()
+	thumbprint, err := k.jwk.Thumbprint()
+	require.NoError(t, err)
+	return &HelloV2TokenConfirmation{
+		JwkThumbprint: thumbprint,
+	}
+}
+
+func (k *helloV2ProofKeyForTest) CreateProof(t *testing.T, token string, issuedAt time.Time) string {
+	t.Helper()
+	return k.CreateProofWithId(t, token, newRandomString(16), issuedAt)
+}
+
+func (k *helloV2ProofKeyForTest) CreateProofWithId(t *testing.T, token string, id string, issuedAt time.Time) string {
+	t.Helper()
+	proof := jwt.NewWithClaims(k.method, &HelloV2ProofClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:       id,
+			IssuedAt: jwt.NewNumericDate(issuedAt),
+		},
+		TokenHash: getHelloV2TokenHash(token),
+	})
+	proof.Header["jwk"] = k.jwk
+	result, err := proof.SignedString(k.key)
+	require.NoError(t, err)
+	return result
+}
+
+func TestHelloV2ProofJwk_Thumbprint(t *testing.T) {
+	t.Parallel()
+	// Example from RFC 7638, section 3.1
+	jwk := helloV2ProofJwk{
+		Kty: "RSA",
+		N:   "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+		E:   "AQAB",
+	}
+	thumbprint, err := jwk.Thumbprint()
+	require.NoError(t, err)
+	assert.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", thumbprint)
+
+	jwk.Kty = "unknown"
+	_, err = jwk.Thumbprint()
+	assert.ErrorIs(t, err, ErrUnsupportedConfirmationKey)
+}
+
+func TestVerifyHelloV2Confirmation(t *testing.T) {
+	t.Parallel()
+	token := "the-hello-v2-token"
+	for _, algo := range []string{"RSA", "ECDSA", "Ed25519"} {
+		t.Run(algo, func(t *testing.T) {
+			t.Parallel()
+			assert := assert.New(t)
+			key := newHelloV2ProofKeyForTest(t, algo)
+			cnf := key.Confirmation(t)
+			now := time.Now()
+			proofs := newBackendReplayCache(tokenLeeway)
+
+			proof := key.CreateProof(t, token, now)
+			assert.NoError(VerifyHelloV2Confirmation(cnf, token, proof, proofs))
+			// Proofs can only be used once.
+			assert.ErrorIs(VerifyHelloV2Confirmation(cnf, token, proof, proofs), ErrConfirmationProofReplayed)
+			assert.ErrorIs(VerifyHelloV2Confirmation(cnf, token, key.CreateProofWithId(t, token, "", now), proofs), ErrConfirmationProofInvalid)
+
+			assert.ErrorIs(VerifyHelloV2Confirmation(cnf, token, "", proofs), ErrConfirmationProofMissing)
+			assert.ErrorIs(VerifyHelloV2Confirmation(cnf, "other-token", key.CreateProof(t, token, now), proofs), ErrConfirmationProofInvalid)
+			assert.ErrorIs(VerifyHelloV2Confirmation(cnf, token, key.CreateProof(t, token, now.Add(-time.Hour)), proofs), ErrConfirmationProofInvalid)
+			assert.ErrorIs(VerifyHelloV2Confirmation(cnf, token, key.CreateProof(t, token, now.Add(time.Hour)), proofs), ErrConfirmationProofInvalid)
+
+			other := newHelloV2ProofKeyForTest(t, algo)
+			assert.ErrorIs(VerifyHelloV2Confirmation(cnf, token, other.CreateProof(t, token, now), proofs), ErrConfirmationKeyMismatch)
+
+			// The embedded key must match the key that signed the proof.
+			other.jwk = key.jwk
+			assert.ErrorIs(VerifyHelloV2Confirmation(cnf, token, other.CreateProof(t, token, now), proofs), ErrConfirmationProofInvalid)
+
+			assert.ErrorIs(VerifyHelloV2Confirmation(&HelloV2TokenConfirmation{}, token, key.CreateProof(t, token, now), proofs), ErrConfirmationUnsupported)
+		})
+	}
+}
@@ -0,0 +1,180 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dlintw/goconf"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var (
+	ErrTokenIssuerMismatch   = errors.New("token issuer doesn't match backend")
+	ErrTokenLifetimeExceeded = errors.New("token lifetime exceeds maximum")
+	ErrTokenLifetimeMissing  = errors.New("token lifetime can't be checked without iat and exp")
+)
+
+// HelloV2TokenValidation contains optional additional checks that are
+// performed on Hello v2 tokens.
+type HelloV2TokenValidation struct {
+	audiences    []string
+	strictIssuer bool
+	maxLifetime  time.Duration
+}
+
+func NewHelloV2TokenValidation(config *goconf.ConfigFile) *HelloV2TokenValidation {
+	result := &HelloV2TokenValidation{}
+	if value, _ := config.GetString("backend", "tokenaudiences"); value != "" {
+		result.audiences = slices.Collect(SplitEntries(value, ","))
+	}
+
+	result.strictIssuer, _ = config.GetBool("backend", "tokenstrictissuer")
+
+	if maxLifetimeSeconds, _ := config.GetInt("backend", "tokenmaxlifetime"); maxLifetimeSeconds > 0 {
+		result.maxLifetime = time.Duration(maxLifetimeSeconds) * time.Second
+	}
+
+	return result
+}
+
+func (v *HelloV2TokenValidation) String() string {
+	var parts []string
+	if len(v.audiences) > 0 {
+		parts = append(parts, fmt.Sprintf("audiences=%s", strings.Join(v.audiences, ",")))
+	}
+	if v.strictIssuer {
+		parts = append(parts, "strictissuer")
+	}
+	if v.maxLifetime > 0 {
+		parts = append(parts, fmt.Sprintf("maxlifetime=%s", v.maxLifetime))
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ParserOptions returns additional options to pass to the JWT parser.
+func (v *HelloV2TokenValidation) ParserOptions() []jwt.ParserOption {
+	var result []jwt.ParserOption
+	if len(v.audiences) > 0 {
+		result = append(result, jwt.WithAudience(v.audiences...))
+	}
+	return result
+}
+
+// normalizeIssuerUrl returns a normalized version of the given url that can be
+// used to compare issuers: scheme and host are lowercased, standard ports get
+// removed, query and fragment are ignored and the path always ends with a "/".
+func normalizeIssuerUrl(s string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(s))
+	if err != nil {
+		return "", err
+	} else if u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("not an absolute url: %s", s)
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	if strings.Contains(u.Host, ":") && hasStandardPort(u) {
+		u.Host = u.Hostname()
+	}
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	u.RawPath = ""
+	return u.String(), nil
+}
+
+func (v *HelloV2TokenValidation) checkIssuer(claims jwt.Claims, helloUrl *url.URL, backend *Backend) error {
+	issuer, err := claims.GetIssuer()
+	if err != nil {
+		return err
+	}
+
+	iss, err := normalizeIssuerUrl(issuer)
+	if err != nil {
+		return ErrTokenIssuerMismatch
+	}
+
+	if backend == nil {
+		return ErrTokenIssuerMismatch
+	}
+
+	urls := backend.Urls()
+	if backend.IsCompat() {
+		// Old-style backends only configure hosts, so the issuer must match the
+		// url of the Nextcloud instance the client is connecting for.
+		if helloUrl == nil {
+			return ErrTokenIssuerMismatch
+		}
+		urls = []string{helloUrl.String()}
+	}
+
+	for _, u := range urls {
+		if expected, err := normalizeIssuerUrl(u); err == nil && iss == expected {
+			return nil
+		}
+	}
+
+	return ErrTokenIssuerMismatch
+}
+
+// Validate performs the configured checks on the (already verified) claims of
+// a token that was received for the given hello url and backend.
+func (v *HelloV2TokenValidation) Validate(claims jwt.Claims, helloUrl *url.URL, backend *Backend) error {
+	// The issuer of tokens signed by an OpenID Connect provider was already
+	// checked while parsing.
+	if v.strictIssuer && backend.OidcIssuer() == "" {
+		if err := v.checkIssuer(claims, helloUrl, backend); err != nil {
+			return err
+		}
+	}
+
+	if v.maxLifetime > 0 {
+		issuedAt, err := claims.GetIssuedAt()
+		if err != nil {
+			return err
+		}
+		expiresAt, err := claims.GetExpirationTime()
+		if err != nil {
+			return err
+		}
+
+		if issuedAt == nil || expiresAt == nil {
+			return ErrTokenLifetimeMissing
+		} else if expiresAt.Sub(issuedAt.Time) > v.maxLifetime {
+			return ErrTokenLifetimeExceeded
+		}
+	}
+
+	return nil
+}
@@ -36,7 +36,8 @@ import (
 	"errors"
 	"fmt"
 	"hash/fnv"
-	"log"
+	"maps"
+	"math/rand/v2"
 	"net"
 	"net/http"
 	"net/url"
@@ -50,6 +51,8 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -79,6 +82,14 @@ var (
 	TokenExpired = NewError("token_expired", "The token is expired.")
 	// TooManyRequests is returned if brute force detection reports too many failed "hello" requests.
 	TooManyRequests = NewError("too_many_requests", "Too many requests.")
+	// TooManySecondaryRooms is returned if a session tries to join more secondary rooms than configured.
+	TooManySecondaryRooms = NewError("too_many_rooms", "Too many secondary rooms joined.")
+	// NotInSecondaryRoom is returned if a session tries to leave a secondary room it didn't join.
+	NotInSecondaryRoom = NewError("not_in_room", "The secondary room was not joined.")
+	// Throttled is returned if the sessions of a backend exceed its configured message or join rate.
+	Throttled = NewError("throttled", "Too many requests for this backend, please try again later.")
+	// NoBreakoutRooms is returned if a message is sent to the breakout rooms of a room that has none.
+	NoBreakoutRooms = NewError("no_breakout_rooms", "No breakout rooms have been started for the room.")
 
 	// Maximum number of concurrent requests to a backend.
 	defaultMaxConcurrentRequestsPerHost = 8
@@ -92,6 +103,13 @@ var (
 	// Federation requests will be cancelled if they take too long.
 	defaultFederationTimeoutSeconds = 10
 
+	// Clients of a draining server are asked to reconnect within 10 seconds.
+	defaultDrainSpread = 10 * time.Second
+
+	// A draining server shuts down after 60 seconds even if not all sessions
+	// have migrated to other servers.
+	defaultDrainTimeout = 60 * time.Second
+
 	// New connections have to send a "Hello" request after 2 seconds.
 	initialHelloTimeout = 2 * time.Second
 
@@ -123,6 +141,9 @@ var (
 	// Delay after which a screen publisher should be cleaned up.
 	cleanupScreenPublisherDelay = time.Second
 
+	// Default maximum number of sessions in a single "join" event.
+	defaultJoinEventSize = 500
+
 	// Delay after which a "cleared" / "rejected" dialout status should be removed.
 	removeCallStatusTTL = 5 * time.Second
 
@@ -144,6 +165,8 @@ type Hub struct {
 	info         *WelcomeServerMessage
 	infoInternal *WelcomeServerMessage
 	welcome      atomic.Value // *ServerMessage
+	// Time when the hub was created, used as base for the monotonic time.
+	started time.Time
 
 	closer          *Closer
 	readPumpActive  atomic.Int32
@@ -151,6 +174,9 @@ type Hub struct {
 
 	shutdown          *Closer
 	shutdownScheduled atomic.Bool
+	draining          atomic.Bool
+	drainSpread       atomic.Int64
+	drainTimeout      atomic.Int64
 
 	roomUpdated      chan *BackendServerRoomRequest
 	roomDeleted      chan *BackendServerRoomRequest
@@ -165,14 +191,25 @@ type Hub struct {
 	sessions map[uint64]Session
 	rooms    map[string]*Room
 
+	sseClients     map[string]*sseClient
+	connectClients map[string]*connectClient
+
 	roomSessions    RoomSessions
+	storage         HubStorage
 	roomPing        *RoomPing
 	virtualSessions map[PublicSessionId]uint64
 
-	decodeCaches []*LruCache
+	sessionStore         SessionStore
+	sessionStoreInterval time.Duration
+	// Set if the sessions are stored by a different process after a handoff.
+	sessionStoreDisabled atomic.Bool
+	savingSessions       atomic.Bool
+
+	decodeCaches []*LruCache[string, *SessionIdData]
 
 	mcu                   Mcu
 	mcuTimeout            time.Duration
+	mcuDisconnected       atomic.Bool
 	internalClientsSecret []byte
 
 	allowSubscribeAnyStream bool
@@ -187,50 +224,87 @@ type Hub struct {
 	backendTimeout time.Duration
 	backend        *BackendClient
 
+	helloV2Validation atomic.Pointer[HelloV2TokenValidation]
+
 	trustedProxies atomic.Pointer[AllowedIps]
-	geoip          *GeoLookup
-	geoipOverrides atomic.Pointer[map[*net.IPNet]string]
+	allowedOrigins atomic.Pointer[AllowedOrigins]
+	geoip          atomic.Pointer[GeoLookup]
+	geoipOverrides *GeoIPOverridesManager
 	geoipUpdating  atomic.Bool
+	// Interval to check for updates of the GeoIP database.
+	geoipUpdateInterval atomic.Int64
+	geoipMu             sync.Mutex
+	// Url and checksum url of the current GeoIP database.
+	geoipUrls [2]string
 
 	etcdClient *EtcdClient
 	rpcServer  *GrpcServer
 	rpcClients *GrpcClients
 
 	throttler Throttler
+	// Ids of proofs for bound Hello v2 tokens that have been used.
+	helloV2Proofs *backendReplayCache
 
 	skipFederationVerify bool
 	federationTimeout    time.Duration
+	federationSigner     *FederationSigner
+	federationVerifier   *FederationVerifier
+
+	anomalies     *AnomalyDetector
+	slowConsumers *SlowConsumerDetector
+	compression   *WebsocketCompression
+	keepalive     *KeepaliveSettings
+
+	// Maximum number of sessions in a single "join" event.
+	joinEventSize atomic.Int32
+	// Maximum number of secondary rooms a session may join.
+	maxSecondaryRooms int
+
+	dialoutBalancer    *DialoutBalancer
+	recording          *RecordingManager
+	transcriptions     *TranscriptionManager
+	breakout           *BreakoutManager
+	internalHeartbeats *InternalHeartbeatMonitor
+	load               *LoadReporter
 
 	allowedCandidates atomic.Pointer[AllowedIps]
 	blockedCandidates atomic.Pointer[AllowedIps]
+
+	config atomic.Pointer[hubConfig]
 }
 
-func NewHub(config *goconf.ConfigFile, events AsyncEvents, rpcServer *GrpcServer, rpcClients *GrpcClients, etcdClient *EtcdClient, r *mux.Router, version string) (*Hub, error) {
-	hashKey, _ := GetStringOptionWithEnv(config, "sessions", "hashkey")
-	switch len(hashKey) {
-	case 32:
-	case 64:
-	default:
-		log.Printf("WARNING: The sessions hash key should be 32 or 64 bytes but is %d bytes", len(hashKey))
-	}
+// hubConfig is the configuration that was last loaded by the hub.
+type hubConfig struct {
+	config *goconf.ConfigFile
+	loaded time.Time
+}
 
-	blockKey, _ := GetStringOptionWithEnv(config, "sessions", "blockkey")
-	blockBytes := []byte(blockKey)
-	switch len(blockKey) {
-	case 0:
-		blockBytes = nil
-	case 16:
-	case 24:
-	case 32:
-	default:
-		return nil, fmt.Errorf("the sessions block key must be 16, 24 or 32 bytes but is %d bytes", len(blockKey))
+func NewHub(config *goconf.ConfigFile, events AsyncEvents, rpcServer *GrpcServer, rpcClients *GrpcClients, etcdClient *EtcdClient, r *mux.Router, version string) (*Hub, error) {
+	cookie, err := NewSessionIdCodecFromConfig(config)
+	if err != nil {
+		return nil, err
 	}
 
 	internalClientsSecret, _ := GetStringOptionWithEnv(config, "clients", "internalsecret")
 	if internalClientsSecret == "" {
-		log.Println("WARNING: No shared secret has been set for internal clients.")
+		hubLog.Warnf("No shared secret has been set for internal clients.")
+	}
+
+	maxSecondaryRooms, _ := config.GetInt("clients", "maxsecondaryrooms")
+	if maxSecondaryRooms > 0 {
+		hubLog.Infof("Sessions may join up to %d secondary rooms", maxSecondaryRooms)
+	} else {
+		maxSecondaryRooms = 0
+	}
+
+	dialoutBalancer, err := NewDialoutBalancer(config)
+	if err != nil {
+		return nil, err
 	}
 
+	statsBackendLabels.load(config)
+	recentEvents.load(config)
+
 	maxConcurrentRequestsPerHost, _ := config.GetInt("backend", "connectionsperhost")
 	if maxConcurrentRequestsPerHost <= 0 {
 		maxConcurrentRequestsPerHost = defaultMaxConcurrentRequestsPerHost
@@ -240,14 +314,14 @@ func NewHub(config *goconf.ConfigFile, events AsyncEvents, rpcServer *GrpcServer
 	if err != nil {
 		return nil, err
 	}
-	log.Printf("Using a maximum of %d concurrent backend connections per host", maxConcurrentRequestsPerHost)
+	hubLog.Infof("Using a maximum of %d concurrent backend connections per host", maxConcurrentRequestsPerHost)
 
 	backendTimeoutSeconds, _ := config.GetInt("backend", "timeout")
 	if backendTimeoutSeconds <= 0 {
 		backendTimeoutSeconds = defaultBackendTimeoutSeconds
 	}
 	backendTimeout := time.Duration(backendTimeoutSeconds) * time.Second
-	log.Printf("Using a timeout of %s for backend connections", backendTimeout)
+	hubLog.Infof("Using a timeout of %s for backend connections", backendTimeout)
 
 	mcuTimeoutSeconds, _ := config.GetInt("mcu", "timeout")
 	if mcuTimeoutSeconds <= 0 {
@@ -257,7 +331,7 @@ func NewHub(config *goconf.ConfigFile, events AsyncEvents, rpcServer *GrpcServer
 
 	allowSubscribeAnyStream, _ := config.GetBool("app", "allowsubscribeany")
 	if allowSubscribeAnyStream {
-		log.Printf("WARNING: Allow subscribing any streams, this is insecure and should only be enabled for testing")
+		hubLog.Warnf("Allow subscribing any streams, this is insecure and should only be enabled for testing")
 	}
 
 	trustedProxies, _ := config.GetString("app", "trustedproxies")
@@ -266,26 +340,42 @@ func NewHub(config *goconf.ConfigFile, events AsyncEvents, rpcServer *GrpcServer
 		return nil, err
 	}
 
+	allowedOrigins, err := LoadAllowedOrigins(config)
+	if err != nil {
+		return nil, err
+	}
+
 	skipFederationVerify, _ := config.GetBool("federation", "skipverify")
 	if skipFederationVerify {
-		log.Println("WARNING: Federation target verification is disabled!")
+		hubLog.Warnf("Federation target verification is disabled!")
 	}
 	federationTimeoutSeconds, _ := config.GetInt("federation", "timeout")
 	if federationTimeoutSeconds <= 0 {
 		federationTimeoutSeconds = defaultFederationTimeoutSeconds
 	}
 	federationTimeout := time.Duration(federationTimeoutSeconds) * time.Second
+	federationSigner, err := NewFederationSigner(config)
+	if err != nil {
+		return nil, err
+	}
+	if federationSigner != nil {
+		hubLog.Infof("Signing federated messages with key %s", federationSigner.Id())
+	}
+	federationVerifier, err := NewFederationVerifier(config)
+	if err != nil {
+		return nil, err
+	}
 
 	if !trustedProxiesIps.Empty() {
-		log.Printf("Trusted proxies: %s", trustedProxiesIps)
+		hubLog.Infof("Trusted proxies: %s", trustedProxiesIps)
 	} else {
 		trustedProxiesIps = DefaultTrustedProxies
-		log.Printf("No trusted proxies configured, only allowing for %s", trustedProxiesIps)
+		hubLog.Infof("No trusted proxies configured, only allowing for %s", trustedProxiesIps)
 	}
 
-	decodeCaches := make([]*LruCache, 0, numDecodeCaches)
+	decodeCaches := make([]*LruCache[string, *SessionIdData], 0, numDecodeCaches)
 	for range numDecodeCaches {
-		decodeCaches = append(decodeCaches, NewLruCache(decodeCacheSize))
+		decodeCaches = append(decodeCaches, NewNamedLruCache[string, *SessionIdData]("session_ids", decodeCacheSize))
 	}
 
 	roomSessions, err := NewBuiltinRoomSessions(rpcClients)
@@ -293,43 +383,43 @@ func NewHub(config *goconf.ConfigFile, events AsyncEvents, rpcServer *GrpcServer
 		return nil, err
 	}
 
+	storage, err := NewHubStorage(config, etcdClient, GrpcServerId)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionStore, err := NewSessionStore(config, etcdClient)
+	if err != nil {
+		return nil, err
+	}
+
 	roomPing, err := NewRoomPing(backend, backend.capabilities)
 	if err != nil {
 		return nil, err
 	}
 
-	geoipUrl, _ := config.GetString("geoip", "url")
-	if geoipUrl == "default" || geoipUrl == "none" {
-		geoipUrl = ""
+	geoipUrl, geoipChecksumUrl, geoipUpdateInterval := getGeoIPConfig(config)
+	geoip, err := newGeoLookupFromConfig(geoipUrl, geoipChecksumUrl)
+	if err != nil {
+		return nil, err
 	}
-	if geoipUrl == "" {
-		if geoipLicense, _ := config.GetString("geoip", "license"); geoipLicense != "" {
-			geoipUrl = GetGeoIpDownloadUrl(geoipLicense)
-		}
+
+	geoipOverrides, err := NewGeoIPOverridesManager(config)
+	if err != nil {
+		return nil, err
 	}
 
-	var geoip *GeoLookup
-	if geoipUrl != "" {
-		if geoipUrl, found := strings.CutPrefix(geoipUrl, "file://"); found {
-			log.Printf("Using GeoIP database from %s", geoipUrl)
-			geoip, err = NewGeoLookupFromFile(geoipUrl)
-		} else {
-			log.Printf("Downloading GeoIP database from %s", geoipUrl)
-			geoip, err = NewGeoLookupFromUrl(geoipUrl)
-		}
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		log.Printf("Not using GeoIP database")
+	throttler, err := NewMemoryThrottler(config)
+	if err != nil {
+		return nil, err
 	}
 
-	geoipOverrides, err := LoadGeoIPOverrides(config, false)
+	compression, err := NewWebsocketCompression(config)
 	if err != nil {
 		return nil, err
 	}
 
-	throttler, err := NewMemoryThrottler()
+	keepalive, err := NewKeepaliveSettings(config)
 	if err != nil {
 		return nil, err
 	}
@@ -341,11 +431,13 @@ func NewHub(config *goconf.ConfigFile, events AsyncEvents, rpcServer *GrpcServer
 			ReadBufferSize:  websocketReadBufferSize,
 			WriteBufferSize: websocketWriteBufferSize,
 			WriteBufferPool: websocketWriteBufferPool,
+			Subprotocols:    []string{WebsocketProtocolCbor},
 		},
-		cookie:       NewSessionIdCodec([]byte(hashKey), blockBytes),
+		cookie:       cookie,
 		info:         NewWelcomeServerMessage(version, DefaultFeatures...),
 		infoInternal: NewWelcomeServerMessage(version, DefaultFeaturesInternal...),
 
+		started:  time.Now(),
 		closer:   NewCloser(),
 		shutdown: NewCloser(),
 
@@ -358,9 +450,16 @@ func NewHub(config *goconf.ConfigFile, events AsyncEvents, rpcServer *GrpcServer
 		sessions: make(map[uint64]Session),
 		rooms:    make(map[string]*Room),
 
-		roomSessions:    roomSessions,
-		roomPing:        roomPing,
-		virtualSessions: make(map[PublicSessionId]uint64),
+		sseClients:     make(map[string]*sseClient),
+		connectClients: make(map[string]*connectClient),
+
+		roomSessions: roomSessions,
+		storage:      storage,
+
+		sessionStore:         sessionStore,
+		sessionStoreInterval: getSessionStoreInterval(config),
+		roomPing:             roomPing,
+		virtualSessions:      make(map[PublicSessionId]uint64),
 
 		decodeCaches: decodeCaches,
 
@@ -379,27 +478,46 @@ func NewHub(config *goconf.ConfigFile, events AsyncEvents, rpcServer *GrpcServer
 		backendTimeout: backendTimeout,
 		backend:        backend,
 
-		geoip: geoip,
+		geoipOverrides: geoipOverrides,
+		geoipUrls:      [2]string{geoipUrl, geoipChecksumUrl},
 
 		etcdClient: etcdClient,
 		rpcServer:  rpcServer,
 		rpcClients: rpcClients,
 
-		throttler: throttler,
+		throttler:     throttler,
+		helloV2Proofs: newBackendReplayCache(tokenLeeway),
 
 		skipFederationVerify: skipFederationVerify,
 		federationTimeout:    federationTimeout,
-	}
+		federationSigner:     federationSigner,
+		federationVerifier:   federationVerifier,
+
+		anomalies:     NewAnomalyDetector(config),
+		slowConsumers: NewSlowConsumerDetector(config),
+		compression:   compression,
+		keepalive:     keepalive,
+
+		maxSecondaryRooms:  maxSecondaryRooms,
+		dialoutBalancer:    dialoutBalancer,
+		transcriptions:     NewTranscriptionManager(config),
+		breakout:           NewBreakoutManager(),
+		internalHeartbeats: NewInternalHeartbeatMonitor(config),
+		load:               NewLoadReporter(config),
+	}
+	hub.recording = NewRecordingManager(hub, config)
+	hub.geoip.Store(geoip)
+	hub.geoipUpdateInterval.Store(int64(geoipUpdateInterval))
 	if value, _ := config.GetString("mcu", "allowedcandidates"); value != "" {
 		allowed, err := ParseAllowedIps(value)
 		if err != nil {
 			return nil, fmt.Errorf("invalid allowedcandidates: %w", err)
 		}
 
-		log.Printf("Candidates allowlist: %s", allowed)
+		hubLog.Infof("Candidates allowlist: %s", allowed)
 		hub.allowedCandidates.Store(allowed)
 	} else {
-		log.Printf("No candidates allowlist")
+		hubLog.Infof("No candidates allowlist")
 	}
 	if value, _ := config.GetString("mcu", "blockedcandidates"); value != "" {
 		blocked, err := ParseAllowedIps(value)
@@ -407,28 +525,49 @@ func NewHub(config *goconf.ConfigFile, events AsyncEvents, rpcServer *GrpcServer
 			return nil, fmt.Errorf("invalid blockedcandidates: %w", err)
 		}
 
-		log.Printf("Candidates blocklist: %s", blocked)
+		hubLog.Infof("Candidates blocklist: %s", blocked)
 		hub.blockedCandidates.Store(blocked)
 	} else {
-		log.Printf("No candidates blocklist")
+		hubLog.Infof("No candidates blocklist")
 	}
 
+	helloV2Validation := NewHelloV2TokenValidation(config)
+	hubLog.Infof("Additional Hello v2 token validation: %s", helloV2Validation)
+	hub.helloV2Validation.Store(helloV2Validation)
+
 	hub.trustedProxies.Store(trustedProxiesIps)
-	if len(geoipOverrides) > 0 {
-		hub.geoipOverrides.Store(&geoipOverrides)
-	}
-	hub.setWelcomeMessage(&ServerMessage{
+	hub.drainSpread.Store(int64(getDrainSpread(config)))
+	hub.drainTimeout.Store(int64(getDrainTimeout(config)))
+	hubLog.Infof("Allowed origins: %s", allowedOrigins)
+	hub.allowedOrigins.Store(allowedOrigins)
+	welcome := &ServerMessage{
 		Type:    "welcome",
 		Welcome: NewWelcomeServerMessage(version, DefaultWelcomeFeatures...),
-	})
+	}
+	if maxSecondaryRooms > 0 {
+		hub.info.AddFeature(ServerFeatureSecondaryRooms)
+		hub.infoInternal.AddFeature(ServerFeatureSecondaryRooms)
+		welcome.Welcome.AddFeature(ServerFeatureSecondaryRooms)
+	}
+	hub.setWelcomeMessage(welcome)
+	hub.setConfig(config)
+	hub.loadJoinEventSize(config)
+	events.SetOnReconnected(hub.onAsyncEventsReconnected)
 	backend.hub = hub
 	if rpcServer != nil {
 		rpcServer.hub = hub
 	}
 	hub.upgrader.CheckOrigin = hub.checkOrigin
+	compression.configureUpgrader(&hub.upgrader)
 	r.HandleFunc("/spreed", func(w http.ResponseWriter, r *http.Request) {
 		hub.serveWs(w, r)
 	})
+	r.HandleFunc("/spreed/sse", hub.serveSse).Methods("GET")
+	r.HandleFunc("/spreed/sse/{id}", hub.serveSseMessage).Methods("POST")
+	if enabled, _ := config.GetBool("app", "rpcsignaling"); enabled {
+		hubLog.Infof("Clients may use the signaling protocol through the RpcSignaling service")
+		hub.registerRpcSignaling(r)
+	}
 
 	return hub, nil
 }
@@ -445,36 +584,178 @@ func (h *Hub) SetMcu(mcu Mcu) {
 	h.mcu = mcu
 	// Create copy of message so it can be updated concurrently.
 	welcome := *h.getWelcomeMessage()
+	if mcuSupportsRecording(mcu) {
+		h.info.AddFeature(ServerFeatureRecordingControl)
+		h.infoInternal.AddFeature(ServerFeatureRecordingControl)
+		welcome.Welcome.AddFeature(ServerFeatureRecordingControl)
+	} else {
+		h.info.RemoveFeature(ServerFeatureRecordingControl)
+		h.infoInternal.RemoveFeature(ServerFeatureRecordingControl)
+		welcome.Welcome.RemoveFeature(ServerFeatureRecordingControl)
+	}
 	if mcu == nil {
 		h.info.RemoveFeature(ServerFeatureMcu, ServerFeatureSimulcast, ServerFeatureUpdateSdp)
 		h.infoInternal.RemoveFeature(ServerFeatureMcu, ServerFeatureSimulcast, ServerFeatureUpdateSdp)
 
 		welcome.Welcome.RemoveFeature(ServerFeatureMcu, ServerFeatureSimulcast, ServerFeatureUpdateSdp)
 	} else {
-		log.Printf("Using a timeout of %s for MCU requests", h.mcuTimeout)
+		hubLog.Infof("Using a timeout of %s for MCU requests", h.mcuTimeout)
 		h.info.AddFeature(ServerFeatureMcu, ServerFeatureSimulcast, ServerFeatureUpdateSdp)
 		h.infoInternal.AddFeature(ServerFeatureMcu, ServerFeatureSimulcast, ServerFeatureUpdateSdp)
 
 		welcome.Welcome.AddFeature(ServerFeatureMcu, ServerFeatureSimulcast, ServerFeatureUpdateSdp)
+
+		mcu.SetOnConnected(h.onMcuConnected)
+		mcu.SetOnDisconnected(h.onMcuDisconnected)
 	}
 	h.setWelcomeMessage(&welcome)
 }
 
+// enableWebTransport announces to clients that WebTransport connections are
+// supported.
+func (h *Hub) enableWebTransport() {
+	// Create copy of message so it can be updated concurrently.
+	welcome := *h.getWelcomeMessage()
+	h.info.AddFeature(ServerFeatureWebTransport)
+	h.infoInternal.AddFeature(ServerFeatureWebTransport)
+	welcome.Welcome.AddFeature(ServerFeatureWebTransport)
+	h.setWelcomeMessage(&welcome)
+}
+
+func (h *Hub) onMcuConnected() {
+	if !h.mcuDisconnected.CompareAndSwap(true, false) {
+		// Initial connection, no state to resync.
+		return
+	}
+
+	h.sendResync(ResyncReasonMcuReconnected, ResyncStatePublishers)
+}
+
+func (h *Hub) onMcuDisconnected() {
+	h.mcuDisconnected.Store(true)
+}
+
+func (h *Hub) onAsyncEventsReconnected() {
+	h.sendResync(ResyncReasonEventsReconnected, ResyncStateParticipants, ResyncStateInCall)
+}
+
+// sendResync notifies sessions in rooms that some of their state might be stale
+// and should be refreshed. Only sessions that support the "resync" feature will
+// be notified.
+func (h *Hub) sendResync(reason string, stale ...string) {
+	var sessions []*ClientSession
+	h.mu.RLock()
+	for _, session := range h.sessions {
+		if s, ok := session.(*ClientSession); ok && s.HasNegotiatedFeature(ClientFeatureResync) && s.GetRoom() != nil {
+			sessions = append(sessions, s)
+		}
+	}
+	h.mu.RUnlock()
+
+	if len(sessions) == 0 {
+		return
+	}
+
+	hubLog.Infof("Sending resync for %s (%s) to %d sessions", strings.Join(stale, ", "), reason, len(sessions))
+	for _, session := range sessions {
+		session.SendMessage(&ServerMessage{
+			Type: "event",
+			Event: &EventServerMessage{
+				Target: "session",
+				Type:   "resync",
+				Resync: &EventServerMessageResync{
+					Reason: reason,
+					Stale:  stale,
+				},
+			},
+		})
+	}
+}
+
 func (h *Hub) checkOrigin(r *http.Request) bool {
-	// We allow any Origin to connect to the service.
+	origin := r.Header.Get("Origin")
+	if !h.allowedOrigins.Load().IsAllowed(origin, r.Header.Get("User-Agent")) {
+		hubLog.Warnf("Rejecting connection from %s with origin \"%s\"", h.getRealUserIP(r), origin)
+		return false
+	}
+
 	return true
 }
 
 func (h *Hub) GetServerInfo(session Session) *WelcomeServerMessage {
 	if session.ClientType() == HelloClientTypeInternal {
-		return h.infoInternal
+		return session.Backend().FilterServerInfo(h.infoInternal)
+	}
+
+	return session.Backend().FilterServerInfo(h.info)
+}
+
+func getGeoIPConfig(config *goconf.ConfigFile) (string, string, time.Duration) {
+	geoipUrl, _ := config.GetString("geoip", "url")
+	if geoipUrl == "default" || geoipUrl == "none" {
+		geoipUrl = ""
+	}
+	geoipChecksumUrl, _ := config.GetString("geoip", "checksumurl")
+	if geoipUrl == "" {
+		if geoipLicense, _ := config.GetString("geoip", "license"); geoipLicense != "" {
+			geoipUrl = GetGeoIpDownloadUrl(geoipLicense)
+			if geoipChecksumUrl == "" {
+				geoipChecksumUrl = GetGeoIpChecksumUrl(geoipLicense)
+			}
+		}
+	}
+	geoipUpdateInterval := defaultGeoIpUpdateInterval
+	if hours, _ := config.GetInt("geoip", "updateinterval"); hours > 0 {
+		geoipUpdateInterval = time.Duration(hours) * time.Hour
+	}
+	return geoipUrl, geoipChecksumUrl, geoipUpdateInterval
+}
+
+func newGeoLookupFromConfig(geoipUrl string, geoipChecksumUrl string) (*GeoLookup, error) {
+	if geoipUrl == "" {
+		hubLog.Infof("Not using GeoIP database")
+		return nil, nil
+	}
+
+	if geoipUrl, found := strings.CutPrefix(geoipUrl, "file://"); found {
+		hubLog.Infof("Using GeoIP database from %s", geoipUrl)
+		return NewGeoLookupFromFile(geoipUrl)
+	}
+
+	hubLog.Infof("Downloading GeoIP database from %s", geoipUrl)
+	return NewGeoLookupFromUrl(geoipUrl, geoipChecksumUrl)
+}
+
+// reloadGeoIP replaces the GeoIP database if its url changed. A changed update
+// interval is used after the next scheduled update.
+func (h *Hub) reloadGeoIP(config *goconf.ConfigFile) error {
+	geoipUrl, geoipChecksumUrl, geoipUpdateInterval := getGeoIPConfig(config)
+	h.geoipUpdateInterval.Store(int64(geoipUpdateInterval))
+
+	h.geoipMu.Lock()
+	defer h.geoipMu.Unlock()
+	urls := [2]string{geoipUrl, geoipChecksumUrl}
+	if urls == h.geoipUrls {
+		return nil
+	}
+
+	geoip, err := newGeoLookupFromConfig(geoipUrl, geoipChecksumUrl)
+	if err != nil {
+		return NewConfigSectionError("geoip", err)
 	}
 
-	return h.info
+	h.geoipUrls = urls
+	if old := h.geoip.Swap(geoip); old != nil {
+		old.Close()
+	}
+	if geoip != nil {
+		go h.updateGeoDatabase()
+	}
+	return nil
 }
 
 func (h *Hub) updateGeoDatabase() {
-	if h.geoip == nil {
+	if h.geoip.Load() == nil {
 		return
 	}
 
@@ -486,17 +767,22 @@ func (h *Hub) updateGeoDatabase() {
 	defer h.geoipUpdating.Store(false)
 	backoff, err := NewExponentialBackoff(time.Second, 5*time.Minute)
 	if err != nil {
-		log.Printf("Could not create exponential backoff: %s", err)
+		hubLog.Errorf("Could not create exponential backoff: %s", err)
 		return
 	}
 
 	for !h.closer.IsClosed() {
-		err := h.geoip.Update()
+		geoip := h.geoip.Load()
+		if geoip == nil {
+			break
+		}
+
+		err := geoip.Update()
 		if err == nil {
 			break
 		}
 
-		log.Printf("Could not update GeoIP database, will retry in %s (%s)", backoff.NextWait(), err)
+		hubLog.Errorf("Could not update GeoIP database, will retry in %s (%s)", backoff.NextWait(), err)
 		backoff.Wait(context.Background())
 	}
 }
@@ -509,7 +795,13 @@ func (h *Hub) Run() {
 
 	housekeeping := time.NewTicker(housekeepingInterval)
 	federationPing := time.NewTicker(updateActiveSessionsInterval)
-	geoipUpdater := time.NewTicker(24 * time.Hour)
+	geoipUpdater := time.NewTicker(time.Duration(h.geoipUpdateInterval.Load()))
+	var saveSessions <-chan time.Time
+	if h.sessionStore != nil {
+		ticker := time.NewTicker(h.sessionStoreInterval)
+		defer ticker.Stop()
+		saveSessions = ticker.C
+	}
 
 loop:
 	for {
@@ -527,75 +819,183 @@ loop:
 		case now := <-housekeeping.C:
 			h.performHousekeeping(now)
 		case <-geoipUpdater.C:
+			geoipUpdater.Reset(time.Duration(h.geoipUpdateInterval.Load()))
 			go h.updateGeoDatabase()
 		case <-federationPing.C:
 			go h.publishFederatedSessions()
+		case <-saveSessions:
+			go h.saveSessionsPeriodically()
 		case <-h.closer.C:
 			break loop
 		}
 	}
-	if h.geoip != nil {
-		h.geoip.Close()
+	if geoip := h.geoip.Load(); geoip != nil {
+		geoip.Close()
 	}
+	h.geoipOverrides.Close()
 }
 
 func (h *Hub) Stop() {
 	h.closer.Close()
 	h.throttler.Close()
+	h.storage.Close()
+	if h.sessionStore != nil {
+		h.sessionStore.Close()
+	}
 }
 
-func (h *Hub) Reload(config *goconf.ConfigFile) {
+// Reload applies the changed configuration to the hub and its components.
+// Errors are returned as ConfigSectionError for the affected sections.
+func (h *Hub) Reload(config *goconf.ConfigFile) error {
+	var errs []error
 	trustedProxies, _ := config.GetString("app", "trustedproxies")
 	if trustedProxiesIps, err := ParseAllowedIps(trustedProxies); err == nil {
 		if !trustedProxiesIps.Empty() {
-			log.Printf("Trusted proxies: %s", trustedProxiesIps)
+			hubLog.Infof("Trusted proxies: %s", trustedProxiesIps)
 		} else {
 			trustedProxiesIps = DefaultTrustedProxies
-			log.Printf("No trusted proxies configured, only allowing for %s", trustedProxiesIps)
+			hubLog.Infof("No trusted proxies configured, only allowing for %s", trustedProxiesIps)
 		}
 		h.trustedProxies.Store(trustedProxiesIps)
 	} else {
-		log.Printf("Error parsing trusted proxies from \"%s\": %s", trustedProxies, err)
+		hubLog.Errorf("Error parsing trusted proxies from \"%s\": %s", trustedProxies, err)
+		errs = append(errs, NewConfigSectionError("app", fmt.Errorf("invalid trustedproxies: %w", err)))
 	}
 
-	geoipOverrides, _ := LoadGeoIPOverrides(config, true)
-	if len(geoipOverrides) > 0 {
-		h.geoipOverrides.Store(&geoipOverrides)
+	if allowedOrigins, err := LoadAllowedOrigins(config); err == nil {
+		hubLog.Infof("Allowed origins: %s", allowedOrigins)
+		h.allowedOrigins.Store(allowedOrigins)
 	} else {
-		h.geoipOverrides.Store(nil)
+		hubLog.Errorf("Error parsing allowed origins: %s", err)
+		errs = append(errs, NewConfigSectionError("app", fmt.Errorf("invalid allowed origins: %w", err)))
+	}
+
+	helloV2Validation := NewHelloV2TokenValidation(config)
+	hubLog.Infof("Additional Hello v2 token validation: %s", helloV2Validation)
+	h.helloV2Validation.Store(helloV2Validation)
+
+	h.drainSpread.Store(int64(getDrainSpread(config)))
+	h.drainTimeout.Store(int64(getDrainTimeout(config)))
+
+	h.geoipOverrides.Reload(config)
+	if err := h.reloadGeoIP(config); err != nil {
+		hubLog.Errorf("Could not reload GeoIP database: %s", err)
+		errs = append(errs, err)
 	}
+	h.throttler.Reload(config)
 
 	if value, _ := config.GetString("mcu", "allowedcandidates"); value != "" {
 		if allowed, err := ParseAllowedIps(value); err != nil {
-			log.Printf("invalid allowedcandidates: %s", err)
+			hubLog.Warnf("invalid allowedcandidates: %s", err)
+			errs = append(errs, NewConfigSectionError("mcu", fmt.Errorf("invalid allowedcandidates: %w", err)))
 		} else {
-			log.Printf("Candidates allowlist: %s", allowed)
+			hubLog.Infof("Candidates allowlist: %s", allowed)
 			h.allowedCandidates.Store(allowed)
 		}
 	} else {
-		log.Printf("No candidates allowlist")
+		hubLog.Infof("No candidates allowlist")
 		h.allowedCandidates.Store(nil)
 	}
 	if value, _ := config.GetString("mcu", "blockedcandidates"); value != "" {
 		if blocked, err := ParseAllowedIps(value); err != nil {
-			log.Printf("invalid blockedcandidates: %s", err)
+			hubLog.Warnf("invalid blockedcandidates: %s", err)
+			errs = append(errs, NewConfigSectionError("mcu", fmt.Errorf("invalid blockedcandidates: %w", err)))
 		} else {
-			log.Printf("Candidates blocklist: %s", blocked)
+			hubLog.Infof("Candidates blocklist: %s", blocked)
 			h.blockedCandidates.Store(blocked)
 		}
 	} else {
-		log.Printf("No candidates blocklist")
+		hubLog.Infof("No candidates blocklist")
 		h.blockedCandidates.Store(nil)
 	}
 
+	h.federationVerifier.Reload(config)
+	h.anomalies.Reload(config)
+	h.slowConsumers.Reload(config)
+	h.dialoutBalancer.Reload(config)
+	h.recording.Reload(config)
+	h.transcriptions.Reload(config)
+	h.internalHeartbeats.Reload(config)
+	h.load.Reload(config)
+	h.loadJoinEventSize(config)
+	statsBackendLabels.load(config)
+	recentEvents.load(config)
+
 	if h.mcu != nil {
-		h.mcu.Reload(config)
+		if err := h.mcu.Reload(config); err != nil {
+			errs = append(errs, err)
+		}
 	}
 	h.backend.Reload(config)
-	h.rpcClients.Reload(config)
+	h.closeSessionsWithoutBackend()
+	if h.rpcClients != nil {
+		h.rpcClients.Reload(config)
+	}
+	h.setConfig(config)
+	return errors.Join(errs...)
+}
+
+// closeSessionsWithoutBackend closes all client sessions whose backend is no
+// longer configured.
+func (h *Hub) closeSessionsWithoutBackend() {
+	var sessions []*ClientSession
+	h.mu.RLock()
+	for _, session := range h.sessions {
+		if s, ok := session.(*ClientSession); ok {
+			if u := s.ParsedBackendUrl(); u != nil && h.backend.GetBackend(u) == nil {
+				sessions = append(sessions, s)
+			}
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, session := range sessions {
+		hubLog.Infof("Closing session %s because backend %s was removed", session.PublicId(), session.BackendUrl())
+		if client := session.GetClient(); client != nil {
+			client.SendByeResponseWithReason(nil, ByeReasonBackendGone)
+		}
+		session.Close()
+	}
+}
+
+func (h *Hub) loadJoinEventSize(config *goconf.ConfigFile) {
+	size, err := config.GetInt("clients", "joineventsize")
+	if err != nil {
+		size = defaultJoinEventSize
+	} else if size < 0 {
+		size = 0
+	}
+
+	if size > 0 {
+		hubLog.Infof("Sending at most %d sessions per join event", size)
+	} else {
+		hubLog.Infof("Sending all sessions in a single join event")
+	}
+	h.joinEventSize.Store(int32(size))
+}
+
+func (h *Hub) setConfig(config *goconf.ConfigFile) {
+	h.config.Store(&hubConfig{
+		config: config,
+		loaded: time.Now(),
+	})
+}
+
+// GetServerConfig returns the currently active configuration with secrets
+// redacted and the backends that were loaded from the configuration or etcd.
+func (h *Hub) GetServerConfig() *BackendServerConfig {
+	result := &BackendServerConfig{
+		Version:  h.version,
+		Backends: h.backend.backends.GetServerConfigBackends(),
+	}
+	if current := h.config.Load(); current != nil {
+		result.Loaded = current.loaded
+		result.Config = GetRedactedConfig(current.config)
+	}
+	return result
 }
 
-func (h *Hub) getDecodeCache(cache_key string) *LruCache {
+func (h *Hub) getDecodeCache(cache_key string) *LruCache[string, *SessionIdData] {
 	hash := fnv.New32a()
 	hash.Write([]byte(cache_key)) // nolint
 	idx := hash.Sum32() % uint32(len(h.decodeCaches))
@@ -645,16 +1045,13 @@ func (h *Hub) decodePrivateSessionId(id PrivateSessionId) *SessionIdData {
 
 	cache_key := fmt.Sprintf("%s|%s", id, privateSessionName)
 	cache := h.getDecodeCache(cache_key)
-	if result := cache.Get(cache_key); result != nil {
-		return result.(*SessionIdData)
-	}
-
-	data, err := h.cookie.DecodePrivate(id)
+	data, err := cache.GetOrCompute(cache_key, 0, func() (*SessionIdData, error) {
+		return h.cookie.DecodePrivate(id)
+	})
 	if err != nil {
 		return nil
 	}
 
-	cache.Set(cache_key, data)
 	return data
 }
 
@@ -665,16 +1062,13 @@ func (h *Hub) decodePublicSessionId(id PublicSessionId) *SessionIdData {
 
 	cache_key := fmt.Sprintf("%s|%s", id, publicSessionName)
 	cache := h.getDecodeCache(cache_key)
-	if result := cache.Get(cache_key); result != nil {
-		return result.(*SessionIdData)
-	}
-
-	data, err := h.cookie.DecodePublic(id)
+	data, err := cache.GetOrCompute(cache_key, 0, func() (*SessionIdData, error) {
+		return h.cookie.DecodePublic(id)
+	})
 	if err != nil {
 		return nil
 	}
 
-	cache.Set(cache_key, data)
 	return data
 }
 
@@ -750,7 +1144,7 @@ func (h *Hub) checkExpiredSessions(now time.Time) {
 	for session, expires := range h.expiredSessions {
 		if now.After(expires) {
 			h.mu.Unlock()
-			log.Printf("Closing expired session %s (private=%s)", session.PublicId(), session.PrivateId())
+			hubLog.Infof("Closing expired session %s (private=%s)", session.PublicId(), session.PrivateId())
 			session.Close()
 			h.mu.Lock()
 			// Should already be deleted by the close code, but better be sure.
@@ -765,7 +1159,7 @@ func (h *Hub) checkAnonymousSessions(now time.Time) {
 			// This will close the client connection.
 			h.mu.Unlock()
 			if client := session.GetClient(); client != nil {
-				client.SendByeResponseWithReason(nil, "room_join_timeout")
+				client.SendByeResponseWithReason(nil, ByeReasonRoomJoinTimeout)
 			}
 			session.Close()
 			h.mu.Lock()
@@ -778,7 +1172,7 @@ func (h *Hub) checkInitialHello(now time.Time) {
 		if now.After(timeout) {
 			// This will close the client connection.
 			h.mu.Unlock()
-			client.SendByeResponseWithReason(nil, "hello_timeout")
+			client.SendByeResponseWithReason(nil, ByeReasonHelloTimeout)
 			h.mu.Lock()
 		}
 	}
@@ -789,7 +1183,51 @@ func (h *Hub) performHousekeeping(now time.Time) {
 	h.checkExpiredSessions(now)
 	h.checkAnonymousSessions(now)
 	h.checkInitialHello(now)
+	clients := make([]*Client, 0, len(h.clients))
+	for _, client := range h.clients {
+		if c, ok := client.(*Client); ok {
+			clients = append(clients, c)
+		}
+	}
+	sessions := 0
+	for _, s := range h.sessions {
+		if s.ClientType() != HelloClientTypeInternal {
+			sessions++
+		}
+	}
 	h.mu.Unlock()
+
+	h.slowConsumers.Check(now, clients)
+	h.updateLoad(now, sessions, clients)
+	h.recording.CheckHeartbeats(now)
+	if failed := h.internalHeartbeats.Check(now); len(failed) > 0 {
+		cleanup := h.internalHeartbeats.Cleanup()
+		for _, session := range failed {
+			h.processInternalHeartbeatFailure(session, cleanup)
+		}
+	}
+}
+
+func (h *Hub) updateLoad(now time.Time, sessions int, clients []*Client) {
+	stats := LoadStats{
+		Sessions: sessions,
+	}
+	h.ru.RLock()
+	stats.Rooms = len(h.rooms)
+	h.ru.RUnlock()
+	for _, client := range clients {
+		stats.PendingWrites += client.PendingWrites()
+	}
+	if proxy, ok := h.mcu.(*mcuProxy); ok {
+		if load, found := proxy.GetAverageLoad(); found {
+			stats.McuLoad = &load
+		}
+	}
+	h.load.Update(now, stats)
+}
+
+func (h *Hub) GetLoad() *BackendServerLoad {
+	return h.load.GetLoad()
 }
 
 func (h *Hub) removeSession(session Session) (removed bool) {
@@ -802,14 +1240,24 @@ func (h *Hub) removeSession(session Session) (removed bool) {
 		delete(h.clients, data.Sid)
 		if _, found := h.sessions[data.Sid]; found {
 			delete(h.sessions, data.Sid)
-			statsHubSessionsCurrent.WithLabelValues(session.Backend().Id(), string(session.ClientType())).Dec()
+			statsHubSessionsCurrent.WithLabelValues(statsBackendLabel(session.Backend()), string(session.ClientType())).Dec()
+			if s, ok := session.(*ClientSession); ok {
+				statsClientSessionsCountry.WithLabelValues(getStatsCountryLabels(s.country)).Dec()
+				statsHubSessionDurationSeconds.WithLabelValues(statsBackendLabel(session.Backend()), string(session.ClientType())).Observe(time.Since(s.created).Seconds())
+			}
 			removed = true
 		}
 	}
 	delete(h.expiredSessions, session)
+	h.storage.RemoveSession(session.PublicId())
+	h.anomalies.RemoveSession(session)
 	if session, ok := session.(*ClientSession); ok {
 		delete(h.anonymousSessions, session)
 		delete(h.dialoutSessions, session)
+		h.dialoutBalancer.RemoveSession(session)
+		h.recording.RemoveSession(session)
+		h.transcriptions.RemoveSession(session)
+		h.internalHeartbeats.RemoveSession(session)
 	}
 	if h.IsShutdownScheduled() && !h.hasSessionsLocked(false) {
 		go h.shutdown.Close()
@@ -818,6 +1266,12 @@ func (h *Hub) removeSession(session Session) (removed bool) {
 	return
 }
 
+// storeSession updates the information on the session and the room it joined
+// in the hub storage.
+func (h *Hub) storeSession(session Session, room *Room) {
+	h.storage.SetSession(newHubStorageSession(session, GrpcServerId, room))
+}
+
 func (h *Hub) hasSessionsLocked(withInternal bool) bool {
 	if withInternal {
 		return len(h.sessions) > 0
@@ -872,8 +1326,22 @@ func (h *Hub) processNewClient(client HandlerClient) {
 	h.sendWelcome(client)
 }
 
+// getServerTime returns the current time of the server.
+func (h *Hub) getServerTime() *ServerTimeMessage {
+	now := time.Now()
+	return &ServerTimeMessage{
+		Now:       now.UnixMilli(),
+		Monotonic: now.Sub(h.started).Milliseconds(),
+	}
+}
+
 func (h *Hub) sendWelcome(client HandlerClient) {
-	client.SendMessage(h.getWelcomeMessage())
+	// Create copy of message so the time can be set for this client.
+	welcome := *h.getWelcomeMessage()
+	info := *welcome.Welcome
+	info.Time = h.getServerTime()
+	welcome.Welcome = &info
+	client.SendMessage(&welcome)
 }
 
 func (h *Hub) registerClient(client HandlerClient) uint64 {
@@ -927,12 +1395,14 @@ func (h *Hub) processRegister(c HandlerClient, message *ClientMessage, backend *
 		return
 	}
 
-	client, ok := c.(*Client)
-	if !ok {
-		log.Printf("Can't register non-client %T", c)
-		client.SendMessage(message.NewWrappedErrorServerMessage(errors.New("can't register non-client")))
+	switch c.(type) {
+	case *Client, *sseClient, *webtransportClient, *connectClient:
+	default:
+		hubLog.Errorf("Can't register non-client %T", c)
+		c.SendMessage(message.NewWrappedErrorServerMessage(errors.New("can't register non-client")))
 		return
 	}
+	client := c
 
 	sessionIdData := h.newSessionIdData(backend)
 	privateSessionId, err := h.cookie.EncodePrivate(sessionIdData)
@@ -948,11 +1418,11 @@ func (h *Hub) processRegister(c HandlerClient, message *ClientMessage, backend *
 
 	userId := auth.Auth.UserId
 	if userId != "" {
-		log.Printf("Register user %s@%s from %s in %s (%s) %s (private=%s)", userId, backend.Id(), client.RemoteAddr(), client.Country(), client.UserAgent(), publicSessionId, privateSessionId)
+		hubLog.Infof("Register user %s@%s from %s in %s (%s) %s (private=%s)", userId, backend.Id(), client.RemoteAddr(), client.Country(), client.UserAgent(), publicSessionId, privateSessionId)
 	} else if message.Hello.Auth.Type != HelloClientTypeClient {
-		log.Printf("Register %s@%s from %s in %s (%s) %s (private=%s)", message.Hello.Auth.Type, backend.Id(), client.RemoteAddr(), client.Country(), client.UserAgent(), publicSessionId, privateSessionId)
+		hubLog.Infof("Register %s@%s from %s in %s (%s) %s (private=%s)", message.Hello.Auth.Type, backend.Id(), client.RemoteAddr(), client.Country(), client.UserAgent(), publicSessionId, privateSessionId)
 	} else {
-		log.Printf("Register anonymous@%s from %s in %s (%s) %s (private=%s)", backend.Id(), client.RemoteAddr(), client.Country(), client.UserAgent(), publicSessionId, privateSessionId)
+		hubLog.Infof("Register anonymous@%s from %s in %s (%s) %s (private=%s)", backend.Id(), client.RemoteAddr(), client.Country(), client.UserAgent(), publicSessionId, privateSessionId)
 	}
 
 	session, err := NewClientSession(h, privateSessionId, publicSessionId, sessionIdData, backend, message.Hello, auth.Auth)
@@ -960,9 +1430,10 @@ func (h *Hub) processRegister(c HandlerClient, message *ClientMessage, backend *
 		client.SendMessage(message.NewWrappedErrorServerMessage(err))
 		return
 	}
+	session.country = client.Country()
 
 	if err := backend.AddSession(session); err != nil {
-		log.Printf("Error adding session %s to backend %s: %s", session.PublicId(), backend.Id(), err)
+		hubLog.Errorf("Error adding session %s to backend %s: %s", session.PublicId(), backend.Id(), err)
 		session.Close()
 		client.SendMessage(message.NewWrappedErrorServerMessage(err))
 		return
@@ -981,22 +1452,23 @@ func (h *Hub) processRegister(c HandlerClient, message *ClientMessage, backend *
 
 				count, err := c.GetSessionCount(ctx, session.BackendUrl())
 				if err != nil {
-					log.Printf("Received error while getting session count for %s from %s: %s", session.BackendUrl(), c.Target(), err)
+					hubLog.Infof("Received error while getting session count for %s from %s: %s", session.BackendUrl(), c.Target(), err)
 					return
 				}
 
 				if count > 0 {
-					log.Printf("%d sessions connected for %s on %s", count, session.BackendUrl(), c.Target())
+					hubLog.Infof("%d sessions connected for %s on %s", count, session.BackendUrl(), c.Target())
 					totalCount.Add(count)
 				}
 			}(client)
 		}
 		wg.Wait()
-		if totalCount.Load() > limit {
+		if count := totalCount.Load(); count > limit {
+			err := NewSessionLimitExceeded(uint64(count-1), uint64(limit))
 			backend.RemoveSession(session)
-			log.Printf("Error adding session %s to backend %s: %s", session.PublicId(), backend.Id(), SessionLimitExceeded)
+			hubLog.Errorf("Error adding session %s to backend %s: %s", session.PublicId(), backend.Id(), err)
 			session.Close()
-			client.SendMessage(message.NewWrappedErrorServerMessage(SessionLimitExceeded))
+			client.SendMessage(message.NewWrappedErrorServerMessage(err))
 			return
 		}
 	}
@@ -1011,23 +1483,31 @@ func (h *Hub) processRegister(c HandlerClient, message *ClientMessage, backend *
 	}
 
 	session.SetClient(client)
+	session.connectType = statsConnectTypeHello
 	h.sessions[sessionIdData.Sid] = session
 	h.clients[sessionIdData.Sid] = client
+	h.storeSession(session, nil)
 	delete(h.expectHelloClients, client)
 	if userId == "" && session.ClientType() != HelloClientTypeInternal {
 		h.startWaitAnonymousSessionRoomLocked(session)
-	} else if session.ClientType() == HelloClientTypeInternal && session.HasFeature(ClientFeatureStartDialout) {
+	} else if session.ClientType() == HelloClientTypeInternal && session.HasNegotiatedFeature(ClientFeatureStartDialout) {
 		// TODO: There is a small race condition for sessions that take some time
 		// between connecting and joining a room.
 		h.dialoutSessions[session] = true
 	}
+	if session.ClientType() == HelloClientTypeInternal && session.HasNegotiatedFeature(ClientFeatureRecording) {
+		h.recording.AddSession(session)
+	}
 	h.mu.Unlock()
 
 	if country := client.Country(); IsValidCountry(country) {
 		statsClientCountries.WithLabelValues(country).Inc()
 	}
-	statsHubSessionsCurrent.WithLabelValues(backend.Id(), string(session.ClientType())).Inc()
-	statsHubSessionsTotal.WithLabelValues(backend.Id(), string(session.ClientType())).Inc()
+	statsClientSessionsCountry.WithLabelValues(getStatsCountryLabels(session.country)).Inc()
+	statsHubSessionsCurrent.WithLabelValues(statsBackendLabel(backend), string(session.ClientType())).Inc()
+	statsHubSessionsTotal.WithLabelValues(statsBackendLabel(backend), string(session.ClientType())).Inc()
+	statsHubClientConnectsTotal.WithLabelValues(statsBackendLabel(backend), statsConnectTypeHello).Inc()
+	updateHelloStats(message.Hello, session.ClientType())
 
 	h.setDecodedPrivateSessionId(privateSessionId, sessionIdData)
 	h.setDecodedPublicSessionId(publicSessionId, sessionIdData)
@@ -1043,13 +1523,17 @@ func (h *Hub) processUnregister(client HandlerClient) Session {
 		delete(h.clients, session.Data().Sid)
 		now := time.Now()
 		h.expiredSessions[session] = now.Add(sessionExpireDuration)
+		if cs, ok := session.(*ClientSession); ok && cs.connectType != "" {
+			statsHubClientDisconnectsTotal.WithLabelValues(statsBackendLabel(session.Backend()), cs.connectType).Inc()
+		}
 	}
 	h.mu.Unlock()
 	if session != nil {
-		log.Printf("Unregister %s (private=%s)", session.PublicId(), session.PrivateId())
-		if c, ok := client.(*Client); ok {
-			if cs, ok := session.(*ClientSession); ok {
-				cs.ClearClient(c)
+		hubLog.Infof("Unregister %s (private=%s)", session.PublicId(), session.PrivateId())
+		if cs, ok := session.(*ClientSession); ok {
+			switch client.(type) {
+			case *Client, *sseClient, *webtransportClient, *connectClient:
+				cs.ClearClient(client)
 			}
 		}
 	}
@@ -1059,28 +1543,38 @@ func (h *Hub) processUnregister(client HandlerClient) Session {
 }
 
 func (h *Hub) processMessage(client HandlerClient, data []byte) {
+	received := time.Now()
 	var message ClientMessage
 	if err := message.UnmarshalJSON(data); err != nil {
 		if session := client.GetSession(); session != nil {
-			log.Printf("Error decoding message from client %s: %v", session.PublicId(), err)
+			hubLog.Errorf("Error decoding message from client %s: %v", session.PublicId(), err)
 			session.SendError(InvalidFormat)
 		} else {
-			log.Printf("Error decoding message from %s: %v", client.RemoteAddr(), err)
+			hubLog.Errorf("Error decoding message from %s: %v", client.RemoteAddr(), err)
 			client.SendError(InvalidFormat)
 		}
 		return
 	}
 
+	if session, ok := client.GetSession().(*ClientSession); ok && session.ClientType() == HelloClientTypeFederation && message.Type != "bye" {
+		// The signed payload replaces the received message and is validated below.
+		if err := h.federationVerifier.Verify(session, &message); err != nil {
+			hubLog.Warnf("Rejecting message %+v from federated session %s: %s", message, session.PublicId(), err)
+			session.SendMessage(message.NewErrorServerMessage(InvalidSignature))
+			return
+		}
+	}
+
 	if err := message.CheckValid(); err != nil {
 		if session := client.GetSession(); session != nil {
-			log.Printf("Invalid message %+v from client %s: %v", message, session.PublicId(), err)
+			hubLog.Warnf("Invalid message %+v from client %s: %v", message, session.PublicId(), err)
 			if err, ok := err.(*Error); ok {
 				session.SendMessage(message.NewErrorServerMessage(err))
 			} else {
 				session.SendMessage(message.NewErrorServerMessage(InvalidFormat))
 			}
 		} else {
-			log.Printf("Invalid message %+v from %s: %v", message, client.RemoteAddr(), err)
+			hubLog.Warnf("Invalid message %+v from %s: %v", message, client.RemoteAddr(), err)
 			if err, ok := err.(*Error); ok {
 				client.SendMessage(message.NewErrorServerMessage(err))
 			} else {
@@ -1090,22 +1584,64 @@ func (h *Hub) processMessage(client HandlerClient, data []byte) {
 		return
 	}
 
-	statsMessagesTotal.WithLabelValues(message.Type).Inc()
-
 	session := client.GetSession()
+	ctx := client.Context()
+	var backend *Backend
+	if session != nil {
+		ctx = session.Context()
+		backend = session.Backend()
+	}
+	statsMessagesTotal.WithLabelValues(statsBackendLabel(backend), message.Type).Inc()
+
+	ctx, span := tracer.Start(ctx, "hub.message", trace.WithAttributes(
+		attribute.String("signaling.message.type", message.Type),
+	))
+	defer span.End()
+
+	if message.Type == "echo" {
+		// Answered directly without a session, so clients can also measure the
+		// round trip time before sending the "hello" request.
+		h.processEcho(client, &message, received)
+		return
+	}
+
 	if session == nil {
 		if message.Type != "hello" {
 			client.SendMessage(message.NewErrorServerMessage(HelloExpected))
 			return
 		}
 
-		h.processHello(client, &message)
+		h.processHello(ctx, client, &message)
+		return
+	}
+
+	span.SetAttributes(attribute.String("signaling.session", string(session.PublicId())))
+
+	if message.Type != "bye" && h.anomalies.IsThrottled(session) {
+		statsAnomaliesThrottledTotal.WithLabelValues(statsBackendLabel(session.Backend())).Inc()
+		session.SendMessage(message.NewErrorServerMessage(TooManyRequests))
+		return
+	}
+
+	if message.Type != "bye" && session.ClientType() != HelloClientTypeInternal && !backend.AllowMessage(received) {
+		session.SendMessage(message.NewErrorServerMessage(Throttled))
 		return
 	}
 
+	if message.Type != "bye" {
+		// Messages of a backend are processed in its own pool, so a backend with
+		// many active sessions can't starve others.
+		release, err := backend.AcquireMessage(ctx)
+		if err != nil {
+			return
+		}
+		defer release()
+	}
+
 	isLocalMessage := message.Type == "room" ||
 		message.Type == "hello" ||
-		message.Type == "bye"
+		message.Type == "bye" ||
+		message.Type == "participants"
 	if cs, ok := session.(*ClientSession); ok && !isLocalMessage {
 		if federated := cs.GetFederationClient(); federated != nil {
 			if err := federated.ProxyMessage(&message); err != nil {
@@ -1117,21 +1653,39 @@ func (h *Hub) processMessage(client HandlerClient, data []byte) {
 
 	switch message.Type {
 	case "room":
-		h.processRoom(session, &message)
+		h.processRoom(ctx, session, &message)
 	case "message":
-		h.processMessageMsg(session, &message)
+		if message.Message.Recipient.Type == RecipientTypeSessions {
+			for _, m := range expandMultipleRecipients(&message, &message.Message.Recipient) {
+				h.processMessageMsg(ctx, session, m)
+			}
+		} else {
+			h.processMessageMsg(ctx, session, &message)
+		}
 	case "control":
-		h.processControlMsg(session, &message)
+		if message.Control.Recipient.Type == RecipientTypeSessions {
+			for _, m := range expandMultipleRecipients(&message, &message.Control.Recipient) {
+				h.processControlMsg(session, m)
+			}
+		} else {
+			h.processControlMsg(session, &message)
+		}
 	case "internal":
-		h.processInternalMsg(session, &message)
+		h.processInternalMsg(ctx, session, &message)
 	case "transient":
 		h.processTransientMsg(session, &message)
+	case "participants":
+		h.processParticipantsMsg(session, &message)
+	case "dtmf":
+		h.processDtmfMsg(session, &message)
+	case "recording":
+		h.processRecordingMsg(session, &message)
 	case "bye":
 		h.processByeMsg(client, &message)
 	case "hello":
-		log.Printf("Ignore hello %+v for already authenticated connection %s", message.Hello, session.PublicId())
+		hubLog.Warnf("Ignore hello %+v for already authenticated connection %s", message.Hello, session.PublicId())
 	default:
-		log.Printf("Ignore unknown message %+v from %s", message, session.PublicId())
+		hubLog.Warnf("Ignore unknown message %+v from %s", message, session.PublicId())
 	}
 }
 
@@ -1144,9 +1698,24 @@ func (h *Hub) sendHelloResponse(session *ClientSession, message *ClientMessage)
 			SessionId: session.PublicId(),
 			ResumeId:  session.PrivateId(),
 			UserId:    session.UserId(),
+			Time:      h.getServerTime(),
+			Features:  session.NegotiatedFeatures(),
 			Server:    h.GetServerInfo(session),
 		},
 	}
+	if requested := message.Hello.PingInterval; requested > 0 {
+		if client, ok := session.GetClient().(*Client); ok {
+			interval, timeout := h.keepalive.getForClient(requested)
+			client.SetKeepalive(interval, timeout)
+			response.Hello.PingInterval = int(interval / time.Second)
+		}
+	}
+	if session.ClientType() == HelloClientTypeInternal {
+		if interval := h.internalHeartbeats.Interval(); interval > 0 {
+			h.internalHeartbeats.AddSession(session, time.Now())
+			response.Hello.Heartbeat = max(int(interval/time.Second), 1)
+		}
+	}
 	return session.SendMessage(response)
 }
 
@@ -1188,7 +1757,7 @@ func (h *Hub) tryProxyResume(c HandlerClient, resumeId PrivateSessionId, message
 
 			response, err := client.LookupResumeId(ctx, resumeId)
 			if err != nil {
-				log.Printf("Could not lookup resume id %s on %s: %s", resumeId, client.Target(), err)
+				hubLog.Errorf("Could not lookup resume id %s on %s: %s", resumeId, client.Target(), err)
 				return
 			}
 
@@ -1213,17 +1782,17 @@ func (h *Hub) tryProxyResume(c HandlerClient, resumeId PrivateSessionId, message
 
 	rs, err := NewRemoteSession(h, client, info.client, PublicSessionId(info.response.SessionId))
 	if err != nil {
-		log.Printf("Could not create remote session %s on %s: %s", info.response.SessionId, info.client.Target(), err)
+		hubLog.Errorf("Could not create remote session %s on %s: %s", info.response.SessionId, info.client.Target(), err)
 		return false
 	}
 
 	if err := rs.Start(message); err != nil {
 		rs.Close()
-		log.Printf("Could not start remote session %s on %s: %s", info.response.SessionId, info.client.Target(), err)
+		hubLog.Errorf("Could not start remote session %s on %s: %s", info.response.SessionId, info.client.Target(), err)
 		return false
 	}
 
-	log.Printf("Proxy session %s to %s", info.response.SessionId, info.client.Target())
+	hubLog.Infof("Proxy session %s to %s", info.response.SessionId, info.client.Target())
 	h.mu.Lock()
 	defer h.mu.Unlock()
 	h.remoteSessions[rs] = true
@@ -1231,16 +1800,20 @@ func (h *Hub) tryProxyResume(c HandlerClient, resumeId PrivateSessionId, message
 	return true
 }
 
-func (h *Hub) processHello(client HandlerClient, message *ClientMessage) {
-	ctx := context.TODO()
-	resumeId := message.Hello.ResumeId
-	if resumeId != "" {
+func (h *Hub) processHello(ctx context.Context, client HandlerClient, message *ClientMessage) {
+	if h.IsDraining() {
+		client.SendMessage(message.NewErrorServerMessage(h.newDrainingError()))
+		return
+	}
+
+	resumeId := message.Hello.ResumeId
+	if resumeId != "" {
 		throttle, err := h.throttler.CheckBruteforce(ctx, client.RemoteAddr(), "HelloResume")
 		if err == ErrBruteforceDetected {
 			client.SendMessage(message.NewErrorServerMessage(TooManyRequests))
 			return
 		} else if err != nil {
-			log.Printf("Error checking for bruteforce: %s", err)
+			hubLog.Errorf("Error checking for bruteforce: %s", err)
 			client.SendMessage(message.NewWrappedErrorServerMessage(err))
 			return
 		}
@@ -1275,7 +1848,7 @@ func (h *Hub) processHello(client HandlerClient, message *ClientMessage) {
 		if !ok {
 			// Should never happen as clients only can resume their own sessions.
 			h.mu.Unlock()
-			log.Printf("Client resumed non-client session %s (private=%s)", session.PublicId(), session.PrivateId())
+			hubLog.Infof("Client resumed non-client session %s (private=%s)", session.PublicId(), session.PrivateId())
 			statsHubSessionResumeFailed.Inc()
 			client.SendMessage(message.NewErrorServerMessage(NoSuchSession))
 			return
@@ -1288,18 +1861,20 @@ func (h *Hub) processHello(client HandlerClient, message *ClientMessage) {
 		}
 
 		if prev := clientSession.SetClient(client); prev != nil {
-			log.Printf("Closing previous client from %s for session %s", prev.RemoteAddr(), session.PublicId())
-			prev.SendByeResponseWithReason(nil, "session_resumed")
+			hubLog.Infof("Closing previous client from %s for session %s", prev.RemoteAddr(), session.PublicId())
+			prev.SendByeResponseWithReason(nil, ByeReasonSessionResumed)
 		}
 
 		delete(h.expiredSessions, clientSession)
+		clientSession.connectType = statsConnectTypeResume
 		h.clients[data.Sid] = client
 		delete(h.expectHelloClients, client)
 		h.mu.Unlock()
 
-		log.Printf("Resume session from %s in %s (%s) %s (private=%s)", client.RemoteAddr(), client.Country(), client.UserAgent(), session.PublicId(), session.PrivateId())
+		hubLog.Infof("Resume session from %s in %s (%s) %s (private=%s)", client.RemoteAddr(), client.Country(), client.UserAgent(), session.PublicId(), session.PrivateId())
 
-		statsHubSessionsResumedTotal.WithLabelValues(clientSession.Backend().Id(), string(clientSession.ClientType())).Inc()
+		statsHubSessionsResumedTotal.WithLabelValues(statsBackendLabel(clientSession.Backend()), string(clientSession.ClientType())).Inc()
+		statsHubClientConnectsTotal.WithLabelValues(statsBackendLabel(clientSession.Backend()), statsConnectTypeResume).Inc()
 		h.sendHelloResponse(clientSession, message)
 		clientSession.NotifySessionResumed(client)
 		return
@@ -1314,7 +1889,7 @@ func (h *Hub) processHello(client HandlerClient, message *ClientMessage) {
 	case HelloClientTypeClient:
 		fallthrough
 	case HelloClientTypeFederation:
-		h.processHelloClient(client, message)
+		h.processHelloClient(ctx, client, message)
 	case HelloClientTypeInternal:
 		h.processHelloInternal(client, message)
 	default:
@@ -1361,6 +1936,9 @@ func (h *Hub) processHelloV2(ctx context.Context, client HandlerClient, message
 		tokenString = message.Hello.Auth.helloV2Params.Token
 		tokenClaims = &HelloV2TokenClaims{}
 	case HelloClientTypeFederation:
+		if !backend.HasFeature(BackendFeatureFederation) {
+			return nil, nil, FeatureDisabled
+		}
 		if !h.backend.capabilities.HasCapabilityFeature(ctx, url, FeatureFederationV2) {
 			return nil, nil, ErrFederationNotSupported
 		}
@@ -1370,6 +1948,29 @@ func (h *Hub) processHelloV2(ctx context.Context, client HandlerClient, message
 	default:
 		return nil, nil, InvalidClientType
 	}
+	validation := h.helloV2Validation.Load()
+	var oidcIssuer string
+	if message.Hello.Auth.Type == HelloClientTypeClient {
+		// Tokens of clients can be signed by an OpenID Connect provider instead
+		// of the Nextcloud instance.
+		oidcIssuer = backend.OidcIssuer()
+	}
+	parserOptions := append([]jwt.ParserOption{
+		jwt.WithValidMethods([]string{
+			jwt.SigningMethodRS256.Alg(),
+			jwt.SigningMethodRS384.Alg(),
+			jwt.SigningMethodRS512.Alg(),
+			jwt.SigningMethodES256.Alg(),
+			jwt.SigningMethodES384.Alg(),
+			jwt.SigningMethodES512.Alg(),
+			jwt.SigningMethodEdDSA.Alg(),
+		}),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(tokenLeeway),
+	}, validation.ParserOptions()...)
+	if oidcIssuer != "" {
+		parserOptions = append(parserOptions, jwt.WithIssuer(oidcIssuer))
+	}
 	token, err := jwt.ParseWithClaims(tokenString, tokenClaims, func(token *jwt.Token) (any, error) {
 		// Only public-private-key algorithms are supported.
 		var loadKeyFunc func([]byte) (any, error)
@@ -1405,7 +2006,7 @@ func (h *Hub) processHelloV2(ctx context.Context, client HandlerClient, message
 				return jwt.ParseEdPublicKeyFromPEM(data)
 			}
 		default:
-			log.Printf("Unexpected signing method: %v", token.Header["alg"])
+			hubLog.Warnf("Unexpected signing method: %v", token.Header["alg"])
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
 
@@ -1413,6 +2014,11 @@ func (h *Hub) processHelloV2(ctx context.Context, client HandlerClient, message
 		backendCtx, cancel := context.WithTimeout(ctx, h.backendTimeout)
 		defer cancel()
 
+		if oidcIssuer != "" {
+			kid, _ := token.Header["kid"].(string)
+			return h.backend.oidcKeys.GetKey(backendCtx, oidcIssuer, kid)
+		}
+
 		keyData, cached, found := h.backend.capabilities.GetStringConfig(backendCtx, url, ConfigGroupSignaling, ConfigKeyHelloV2TokenKey)
 		if !found {
 			if cached {
@@ -1432,15 +2038,7 @@ func (h *Hub) processHelloV2(ctx context.Context, client HandlerClient, message
 		}
 
 		return key, nil
-	}, jwt.WithValidMethods([]string{
-		jwt.SigningMethodRS256.Alg(),
-		jwt.SigningMethodRS384.Alg(),
-		jwt.SigningMethodRS512.Alg(),
-		jwt.SigningMethodES256.Alg(),
-		jwt.SigningMethodES384.Alg(),
-		jwt.SigningMethodES512.Alg(),
-		jwt.SigningMethodEdDSA.Alg(),
-	}), jwt.WithIssuedAt(), jwt.WithLeeway(tokenLeeway))
+	}, parserOptions...)
 	if err != nil {
 		if errors.Is(err, jwt.ErrTokenNotValidYet) || errors.Is(err, jwt.ErrTokenUsedBeforeIssued) {
 			return nil, nil, TokenNotValidYet
@@ -1484,6 +2082,18 @@ func (h *Hub) processHelloV2(ctx context.Context, client HandlerClient, message
 		return nil, nil, TokenExpired
 	}
 
+	if err := validation.Validate(authTokenClaims, url, backend); err != nil {
+		hubLog.Warnf("Rejecting Hello v2 token from %s: %s", client.RemoteAddr(), err)
+		return nil, nil, InvalidToken
+	}
+
+	if claims, ok := authTokenClaims.(*HelloV2TokenClaims); ok && claims.Confirmation != nil {
+		if err := VerifyHelloV2Confirmation(claims.Confirmation, tokenString, message.Hello.Auth.helloV2Params.Proof, h.helloV2Proofs); err != nil {
+			hubLog.Warnf("Rejecting bound Hello v2 token from %s: %s", client.RemoteAddr(), err)
+			return nil, nil, InvalidToken
+		}
+	}
+
 	subject, err := authTokenClaims.GetSubject()
 	if err != nil {
 		return nil, nil, InvalidToken
@@ -1500,7 +2110,7 @@ func (h *Hub) processHelloV2(ctx context.Context, client HandlerClient, message
 	return backend, auth, nil
 }
 
-func (h *Hub) processHelloClient(client HandlerClient, message *ClientMessage) {
+func (h *Hub) processHelloClient(ctx context.Context, client HandlerClient, message *ClientMessage) {
 	// Make sure the client must send another "hello" in case of errors.
 	defer h.startExpectHello(client)
 
@@ -1518,7 +2128,7 @@ func (h *Hub) processHelloClient(client HandlerClient, message *ClientMessage) {
 		return
 	}
 
-	backend, auth, err := authFunc(client.Context(), client, message)
+	backend, auth, err := authFunc(ctx, client, message)
 	if err != nil {
 		if e, ok := err.(*Error); ok {
 			client.SendMessage(message.NewErrorServerMessage(e))
@@ -1544,7 +2154,7 @@ func (h *Hub) processHelloInternal(client HandlerClient, message *ClientMessage)
 		client.SendMessage(message.NewErrorServerMessage(TooManyRequests))
 		return
 	} else if err != nil {
-		log.Printf("Error checking for bruteforce: %s", err)
+		hubLog.Errorf("Error checking for bruteforce: %s", err)
 		client.SendMessage(message.NewWrappedErrorServerMessage(err))
 		return
 	}
@@ -1575,11 +2185,11 @@ func (h *Hub) processHelloInternal(client HandlerClient, message *ClientMessage)
 }
 
 func (h *Hub) disconnectByRoomSessionId(ctx context.Context, roomSessionId RoomSessionId, backend *Backend) {
-	sessionId, err := h.roomSessions.LookupSessionId(ctx, roomSessionId, "room_session_reconnected")
+	sessionId, err := h.roomSessions.LookupSessionId(ctx, roomSessionId, ByeReasonRoomSessionReconnected)
 	if err == ErrNoSuchRoomSession {
 		return
 	} else if err != nil {
-		log.Printf("Could not get session id for room session %s: %s", roomSessionId, err)
+		hubLog.Errorf("Could not get session id for room session %s: %s", roomSessionId, err)
 		return
 	}
 
@@ -1591,23 +2201,22 @@ func (h *Hub) disconnectByRoomSessionId(ctx context.Context, roomSessionId RoomS
 			Type: "message",
 			Message: &ServerMessage{
 				Type: "bye",
-				Bye: &ByeServerMessage{
-					Reason: "room_session_reconnected",
-				},
+				Bye:  NewByeServerMessage(ByeReasonRoomSessionReconnected),
 			},
 		}
+		injectAsyncTraceContext(ctx, msg)
 		if err := h.events.PublishSessionMessage(sessionId, backend, msg); err != nil {
-			log.Printf("Could not send reconnect bye to session %s: %s", sessionId, err)
+			hubLog.Errorf("Could not send reconnect bye to session %s: %s", sessionId, err)
 		}
 		return
 	}
 
-	log.Printf("Closing session %s because same room session %s connected", session.PublicId(), roomSessionId)
+	hubLog.Infof("Closing session %s because same room session %s connected", session.PublicId(), roomSessionId)
 	session.LeaveRoom(false)
 	switch sess := session.(type) {
 	case *ClientSession:
 		if client := sess.GetClient(); client != nil {
-			client.SendByeResponseWithReason(nil, "room_session_reconnected")
+			client.SendByeResponseWithReason(nil, ByeReasonRoomSessionReconnected)
 		}
 	}
 	session.Close()
@@ -1633,7 +2242,7 @@ func (h *Hub) sendRoom(session *ClientSession, message *ClientMessage, room *Roo
 	return session.SendMessage(response)
 }
 
-func (h *Hub) processRoom(sess Session, message *ClientMessage) {
+func (h *Hub) processRoom(ctx context.Context, sess Session, message *ClientMessage) {
 	session, ok := sess.(*ClientSession)
 	if !ok {
 		return
@@ -1653,13 +2262,30 @@ func (h *Hub) processRoom(sess Session, message *ClientMessage) {
 		return
 	}
 
+	if session.ClientType() != HelloClientTypeInternal && !session.Backend().AllowJoin(time.Now()) {
+		session.SendMessage(message.NewErrorServerMessage(Throttled))
+		return
+	}
+
+	if message.Room.Secondary {
+		h.processSecondaryRoom(ctx, session, message)
+		return
+	}
+
+	h.anomalies.Record(session, AnomalyRoomCycling, roomId)
+
 	if federation := message.Room.Federation; federation != nil {
+		if !session.Backend().HasFeature(BackendFeatureFederation) {
+			session.SendMessage(message.NewErrorServerMessage(FeatureDisabled))
+			return
+		}
+
 		h.mu.Lock()
 		// The session will join a room, make sure it doesn't expire while connecting.
 		delete(h.anonymousSessions, session)
 		h.mu.Unlock()
 
-		ctx, cancel := context.WithTimeout(session.Context(), h.federationTimeout)
+		ctx, cancel := context.WithTimeout(ctx, h.federationTimeout)
 		defer cancel()
 
 		client := session.GetFederationClient()
@@ -1718,7 +2344,7 @@ func (h *Hub) processRoom(sess Session, message *ClientMessage) {
 				}
 			}
 
-			log.Printf("Error creating federation client to %s for %s to join room %s: %s", federation.SignalingUrl, session.PublicId(), roomId, err)
+			hubLog.Errorf("Error creating federation client to %s for %s to join room %s: %s", federation.SignalingUrl, session.PublicId(), roomId, err)
 			session.SendMessage(message.NewErrorServerMessage(
 				NewErrorDetail("federation_error", "Failed to create federation client.", details),
 			))
@@ -1730,14 +2356,14 @@ func (h *Hub) processRoom(sess Session, message *ClientMessage) {
 		roomSessionId := message.Room.SessionId
 		if roomSessionId == "" {
 			// TODO(jojo): Better make the session id required in the request.
-			log.Printf("User did not send a room session id, assuming session %s", session.PublicId())
+			hubLog.Infof("User did not send a room session id, assuming session %s", session.PublicId())
 			roomSessionId = RoomSessionId(session.PublicId())
 		}
 
 		// Prefix room session id to allow using the same signaling server for two Nextcloud instances during development.
 		// Otherwise the same room session id will be detected and the other session will be kicked.
 		if err := session.UpdateRoomSessionId(FederatedRoomSessionIdPrefix + roomSessionId); err != nil {
-			log.Printf("Error updating room session id for session %s: %s", session.PublicId(), err)
+			hubLog.Errorf("Error updating room session id for session %s: %s", session.PublicId(), err)
 		}
 
 		h.mu.Lock()
@@ -1751,12 +2377,12 @@ func (h *Hub) processRoom(sess Session, message *ClientMessage) {
 		roomSessionId := message.Room.SessionId
 		if roomSessionId == "" {
 			// TODO(jojo): Better make the session id required in the request.
-			log.Printf("User did not send a room session id, assuming session %s", session.PublicId())
+			hubLog.Infof("User did not send a room session id, assuming session %s", session.PublicId())
 			roomSessionId = RoomSessionId(session.PublicId())
 		}
 
 		if err := session.UpdateRoomSessionId(roomSessionId); err != nil {
-			log.Printf("Error updating room session id for session %s: %s", session.PublicId(), err)
+			hubLog.Errorf("Error updating room session id for session %s: %s", session.PublicId(), err)
 		}
 		session.SendMessage(message.NewErrorServerMessage(
 			NewErrorDetail("already_joined", "Already joined this room.", &RoomErrorDetails{
@@ -1780,13 +2406,13 @@ func (h *Hub) processRoom(sess Session, message *ClientMessage) {
 		}
 	} else {
 		// Run in timeout context to prevent blocking too long.
-		ctx, cancel := context.WithTimeout(session.Context(), h.backendTimeout)
+		ctx, cancel := context.WithTimeout(ctx, h.backendTimeout)
 		defer cancel()
 
 		sessionId := message.Room.SessionId
 		if sessionId == "" {
 			// TODO(jojo): Better make the session id required in the request.
-			log.Printf("User did not send a room session id, assuming session %s", session.PublicId())
+			hubLog.Infof("User did not send a room session id, assuming session %s", session.PublicId())
 			sessionId = RoomSessionId(session.PublicId())
 		}
 		request := NewBackendClientRoomRequest(roomId, session.UserId(), sessionId)
@@ -1801,7 +2427,7 @@ func (h *Hub) processRoom(sess Session, message *ClientMessage) {
 		if message.Room.SessionId != "" {
 			// There can only be one connection per Nextcloud Talk session,
 			// disconnect any other connections without sending a "leave" event.
-			ctx, cancel := context.WithTimeout(session.Context(), time.Second)
+			ctx, cancel := context.WithTimeout(ctx, time.Second)
 			defer cancel()
 
 			h.disconnectByRoomSessionId(ctx, message.Room.SessionId, session.Backend())
@@ -1878,7 +2504,7 @@ func (h *Hub) publishFederatedSessions() (int, *sync.WaitGroup) {
 				defer cancel()
 
 				if err := h.roomPing.SendPings(ctx, roomId, url, entries); err != nil {
-					log.Printf("Error pinging room %s for active entries %+v: %s", roomId, entries, err)
+					hubLog.Errorf("Error pinging room %s for active entries %+v: %s", roomId, entries, err)
 				}
 			}(roomId, urls[u], e)
 		}
@@ -1899,7 +2525,7 @@ func (h *Hub) removeRoom(room *Room) {
 	h.ru.Lock()
 	if _, found := h.rooms[internalRoomId]; found {
 		delete(h.rooms, internalRoomId)
-		statsHubRoomsCurrent.WithLabelValues(room.Backend().Id()).Dec()
+		statsHubRoomsCurrent.WithLabelValues(statsBackendLabel(room.Backend())).Dec()
 	}
 	h.ru.Unlock()
 	h.roomPing.DeleteRoom(room.Id())
@@ -1914,10 +2540,109 @@ func (h *Hub) createRoom(id string, properties json.RawMessage, backend *Backend
 
 	internalRoomId := getRoomIdForBackend(id, backend)
 	h.rooms[internalRoomId] = room
-	statsHubRoomsCurrent.WithLabelValues(backend.Id()).Inc()
+	statsHubRoomsCurrent.WithLabelValues(statsBackendLabel(backend)).Inc()
+	if h.rpcClients != nil {
+		go room.fetchClusteredTransientData()
+	}
 	return room, nil
 }
 
+func newTooManySecondaryRoomsError(maxRooms int) *Error {
+	return NewErrorWithParams(TooManySecondaryRooms.Code, "Too many secondary rooms joined ({max}).", StringMap{
+		"max": maxRooms,
+	})
+}
+
+func (h *Hub) sendSecondaryRoom(session *ClientSession, message *ClientMessage, roomId string) bool {
+	return session.SendMessage(&ServerMessage{
+		Id:   message.Id,
+		Type: "room",
+		Room: &RoomServerMessage{
+			RoomId:    roomId,
+			Secondary: true,
+		},
+	})
+}
+
+func (h *Hub) processSecondaryRoom(ctx context.Context, session *ClientSession, message *ClientMessage) {
+	if h.maxSecondaryRooms <= 0 {
+		session.SendMessage(message.NewErrorServerMessage(FeatureDisabled))
+		return
+	}
+
+	roomId := message.Room.RoomId
+	if message.Room.Leave {
+		if !session.LeaveSecondaryRoom(roomId, true) {
+			session.SendMessage(message.NewErrorServerMessage(NotInSecondaryRoom))
+			return
+		}
+
+		h.sendSecondaryRoom(session, message, "")
+		return
+	}
+
+	if room := session.GetRoom(); (room != nil && room.Id() == roomId) || session.HasSecondaryRoom(roomId) {
+		session.SendMessage(message.NewErrorServerMessage(
+			NewErrorDetail("already_joined", "Already joined this room.", &RoomErrorDetails{
+				Room: &RoomServerMessage{
+					RoomId:    roomId,
+					Secondary: true,
+				},
+			}),
+		))
+		return
+	} else if session.SecondaryRoomsCount() >= h.maxSecondaryRooms {
+		session.SendMessage(message.NewErrorServerMessage(newTooManySecondaryRoomsError(h.maxSecondaryRooms)))
+		return
+	}
+
+	h.anomalies.Record(session, AnomalyRoomCycling, roomId)
+
+	roomSessionId := message.Room.SessionId
+	if session.ClientType() != HelloClientTypeInternal {
+		// Run in timeout context to prevent blocking too long.
+		ctx, cancel := context.WithTimeout(ctx, h.backendTimeout)
+		defer cancel()
+
+		if roomSessionId == "" {
+			roomSessionId = RoomSessionId(session.PublicId())
+		}
+		request := NewBackendClientRoomRequest(roomId, session.UserId(), roomSessionId)
+		request.Room.UpdateFromSession(session)
+		var response BackendClientResponse
+		if err := h.backend.PerformJSONRequest(ctx, session.ParsedBackendOcsUrl(), request, &response); err != nil {
+			session.SendMessage(message.NewWrappedErrorServerMessage(err))
+			return
+		}
+
+		if response.Type == "error" {
+			session.SendMessage(message.NewErrorServerMessage(response.Error))
+			return
+		} else if response.Type != "room" || response.Room == nil {
+			session.SendMessage(message.NewErrorServerMessage(RoomJoinFailed))
+			return
+		}
+	}
+
+	if err := session.JoinSecondaryRoom(roomId, roomSessionId, h.maxSecondaryRooms); err != nil {
+		if roomSessionId != "" {
+			session.notifyRoomLeft(roomId, roomSessionId)
+		}
+		session.SendMessage(message.NewWrappedErrorServerMessage(err))
+		return
+	}
+
+	if !h.sendSecondaryRoom(session, message, roomId) {
+		return
+	}
+
+	if room := h.GetRoomForBackend(roomId, session.Backend()); room != nil {
+		for _, msg := range room.GetJoinEvents() {
+			session.SendSecondaryRoomMessage(roomId, msg)
+		}
+	}
+}
+
 func (h *Hub) processJoinRoom(session *ClientSession, message *ClientMessage, room *BackendClientResponse) {
 	if room.Type == "error" {
 		session.SendMessage(message.NewErrorServerMessage(room.Error))
@@ -1933,6 +2658,9 @@ func (h *Hub) processJoinRoom(session *ClientSession, message *ClientMessage, ro
 	h.mu.Unlock()
 
 	roomId := room.Room.RoomId
+	// The room is now the primary room, the backend was already notified by the
+	// join request.
+	session.LeaveSecondaryRoom(roomId, false)
 	internalRoomId := getRoomIdForBackend(roomId, session.Backend())
 	if err := session.SubscribeRoomEvents(roomId, message.Room.SessionId); err != nil {
 		session.SendMessage(message.NewWrappedErrorServerMessage(err))
@@ -1959,7 +2687,7 @@ func (h *Hub) processJoinRoom(session *ClientSession, message *ClientMessage, ro
 	h.mu.Lock()
 	// The session now joined a room, don't expire if it is anonymous.
 	delete(h.anonymousSessions, session)
-	if session.ClientType() == HelloClientTypeInternal && session.HasFeature(ClientFeatureStartDialout) {
+	if session.ClientType() == HelloClientTypeInternal && session.HasNegotiatedFeature(ClientFeatureStartDialout) {
 		// An internal session in a room can not be used for dialout.
 		delete(h.dialoutSessions, session)
 	}
@@ -1970,9 +2698,13 @@ func (h *Hub) processJoinRoom(session *ClientSession, message *ClientMessage, ro
 	}
 	h.sendRoom(session, message, r)
 	r.AddSession(session, room.Room.Session)
+	if status := h.recording.GetStatus(r.Id(), r.Backend()); status != "" {
+		// Sessions joining later must also know the room is being recorded.
+		session.SendMessage(newRecordingEventMessage(r.Id(), status))
+	}
 }
 
-func (h *Hub) processMessageMsg(sess Session, message *ClientMessage) {
+func (h *Hub) processMessageMsg(ctx context.Context, sess Session, message *ClientMessage) {
 	session, ok := sess.(*ClientSession)
 	if !ok {
 		// Client is not connected yet.
@@ -1980,6 +2712,18 @@ func (h *Hub) processMessageMsg(sess Session, message *ClientMessage) {
 	}
 
 	msg := message.Message
+	switch msg.Recipient.Type {
+	case RecipientTypeSession:
+		h.anomalies.Record(session, AnomalyMessageFlood, "session:"+string(msg.Recipient.SessionId))
+	case RecipientTypeUser:
+		h.anomalies.Record(session, AnomalyMessageFlood, "user:"+msg.Recipient.UserId)
+	}
+
+	if msg.Recipient.Type == RecipientTypeBreakout {
+		h.processBreakoutMessage(ctx, session, message)
+		return
+	}
+
 	var recipient *ClientSession
 	var subject string
 	var clientData *MessageClientMessageData
@@ -1993,7 +2737,7 @@ func (h *Hub) processMessageMsg(sess Session, message *ClientMessage) {
 			var data MessageClientMessageData
 			if err := json.Unmarshal(msg.Data, &data); err == nil {
 				if err := data.CheckValid(); err != nil {
-					log.Printf("Invalid message %+v from client %s: %v", message, session.PublicId(), err)
+					hubLog.Warnf("Invalid message %+v from client %s: %v", message, session.PublicId(), err)
 					if err, ok := err.(*Error); ok {
 						session.SendMessage(message.NewErrorServerMessage(err))
 					} else {
@@ -2008,7 +2752,7 @@ func (h *Hub) processMessageMsg(sess Session, message *ClientMessage) {
 				case "requestoffer":
 					// Process asynchronously to avoid blocking regular
 					// message processing for this client.
-					go h.processMcuMessage(session, message, msg, clientData)
+					go h.processMcuMessage(ctx, session, message, msg, clientData)
 					return
 				case "offer":
 					fallthrough
@@ -2019,7 +2763,7 @@ func (h *Hub) processMessageMsg(sess Session, message *ClientMessage) {
 				case "selectStream":
 					fallthrough
 				case "candidate":
-					h.processMcuMessage(session, message, msg, clientData)
+					h.processMcuMessage(ctx, session, message, msg, clientData)
 					return
 				case "unshareScreen":
 					if msg.Recipient.SessionId == session.PublicId() {
@@ -2041,7 +2785,7 @@ func (h *Hub) processMessageMsg(sess Session, message *ClientMessage) {
 								return
 							}
 
-							log.Printf("Closing screen publisher for %s", session.PublicId())
+							hubLog.Infof("Closing screen publisher for %s", session.PublicId())
 							ctx, cancel := context.WithTimeout(context.Background(), h.mcuTimeout)
 							defer cancel()
 							publisher.Close(ctx)
@@ -2073,6 +2817,11 @@ func (h *Hub) processMessageMsg(sess Session, message *ClientMessage) {
 			// Send to client connection for virtual sessions.
 			if sess.ClientType() == HelloClientTypeVirtual {
 				virtualSession := sess.(*VirtualSession)
+				if !virtualSession.HasPermission(PERMISSION_MAY_CHAT) {
+					hubLog.Debugf("Virtual session %s may not receive messages, ignoring %+v from %s", virtualSession.PublicId(), msg, session.PublicId())
+					return
+				}
+
 				clientSession := virtualSession.Session()
 				subject = GetSubjectForSessionId(clientSession.PublicId(), sess.Backend())
 				recipientSessionId = clientSession.PublicId()
@@ -2084,6 +2833,10 @@ func (h *Hub) processMessageMsg(sess Session, message *ClientMessage) {
 				}
 			}
 		} else {
+			if h.decodePublicSessionId(msg.Recipient.SessionId) == nil {
+				h.anomalies.Record(session, AnomalyInvalidSessionIds, string(msg.Recipient.SessionId))
+			}
+
 			subject = GetSubjectForSessionId(msg.Recipient.SessionId, nil)
 			recipientSessionId = msg.Recipient.SessionId
 			serverRecipient = &msg.Recipient
@@ -2110,7 +2863,7 @@ func (h *Hub) processMessageMsg(sess Session, message *ClientMessage) {
 					var data MessageClientMessageData
 					if err := json.Unmarshal(msg.Data, &data); err == nil {
 						if err := data.CheckValid(); err != nil {
-							log.Printf("Invalid message %+v from client %s: %v", message, session.PublicId(), err)
+							hubLog.Warnf("Invalid message %+v from client %s: %v", message, session.PublicId(), err)
 							if err, ok := err.(*Error); ok {
 								session.SendMessage(message.NewErrorServerMessage(err))
 							} else {
@@ -2126,7 +2879,7 @@ func (h *Hub) processMessageMsg(sess Session, message *ClientMessage) {
 		}
 	}
 	if subject == "" {
-		log.Printf("Unknown recipient in message %+v from %s", msg, session.PublicId())
+		hubLog.Warnf("Unknown recipient in message %+v from %s", msg, session.PublicId())
 		return
 	}
 
@@ -2146,7 +2899,7 @@ func (h *Hub) processMessageMsg(sess Session, message *ClientMessage) {
 		// The recipient is connected to this instance, no need to go through asynchronous events.
 		if clientData != nil && clientData.Type == "sendoffer" {
 			if err := session.IsAllowedToSend(clientData); err != nil {
-				log.Printf("Session %s is not allowed to send offer for %s, ignoring (%s)", session.PublicId(), clientData.RoomType, err)
+				hubLog.Infof("Session %s is not allowed to send offer for %s, ignoring (%s)", session.PublicId(), clientData.RoomType, err)
 				sendNotAllowed(session, message, "Not allowed to send offer")
 				return
 			}
@@ -2155,23 +2908,23 @@ func (h *Hub) processMessageMsg(sess Session, message *ClientMessage) {
 			// client) to start his stream, so we must not block the active
 			// goroutine.
 			go func() {
-				ctx, cancel := context.WithTimeout(session.Context(), h.mcuTimeout)
+				ctx, cancel := context.WithTimeout(ctx, h.mcuTimeout)
 				defer cancel()
 
 				mc, err := recipient.GetOrCreateSubscriber(ctx, h.mcu, session.PublicId(), StreamType(clientData.RoomType))
 				if err != nil {
-					log.Printf("Could not create MCU subscriber for session %s to send %+v to %s: %s", session.PublicId(), clientData, recipient.PublicId(), err)
+					hubLog.Errorf("Could not create MCU subscriber for session %s to send %+v to %s: %s", session.PublicId(), clientData, recipient.PublicId(), err)
 					sendMcuClientNotFound(session, message)
 					return
 				} else if mc == nil {
-					log.Printf("No MCU subscriber found for session %s to send %+v to %s", session.PublicId(), clientData, recipient.PublicId())
+					hubLog.Warnf("No MCU subscriber found for session %s to send %+v to %s", session.PublicId(), clientData, recipient.PublicId())
 					sendMcuClientNotFound(session, message)
 					return
 				}
 
 				mc.SendMessage(session.Context(), msg, clientData, func(err error, response StringMap) {
 					if err != nil {
-						log.Printf("Could not send MCU message %+v for session %s to %s: %s", clientData, session.PublicId(), recipient.PublicId(), err)
+						hubLog.Errorf("Could not send MCU message %+v for session %s to %s: %s", clientData, session.PublicId(), recipient.PublicId(), err)
 						sendMcuProcessingFailed(session, message)
 						return
 					} else if response == nil {
@@ -2188,11 +2941,15 @@ func (h *Hub) processMessageMsg(sess Session, message *ClientMessage) {
 			return
 		}
 
-		recipient.SendMessage(response)
+		if receipt := getMessageReceipt(session, message, &msg.Recipient); receipt != nil {
+			recipient.SendMessageWithReceipt(response, receipt)
+		} else {
+			recipient.SendMessage(response)
+		}
 	} else {
 		if clientData != nil && clientData.Type == "sendoffer" {
 			if err := session.IsAllowedToSend(clientData); err != nil {
-				log.Printf("Session %s is not allowed to send offer for %s, ignoring (%s)", session.PublicId(), clientData.RoomType, err)
+				hubLog.Infof("Session %s is not allowed to send offer for %s, ignoring (%s)", session.PublicId(), clientData.RoomType, err)
 				sendNotAllowed(session, message, "Not allowed to send offer")
 				return
 			}
@@ -2205,8 +2962,9 @@ func (h *Hub) processMessageMsg(sess Session, message *ClientMessage) {
 					Data:      clientData,
 				},
 			}
+			injectAsyncTraceContext(ctx, async)
 			if err := h.events.PublishSessionMessage(recipientSessionId, session.Backend(), async); err != nil {
-				log.Printf("Error publishing message to remote session: %s", err)
+				hubLog.Errorf("Error publishing message to remote session: %s", err)
 			}
 			return
 		}
@@ -2214,7 +2972,9 @@ func (h *Hub) processMessageMsg(sess Session, message *ClientMessage) {
 		async := &AsyncMessage{
 			Type:    "message",
 			Message: response,
+			Receipt: getMessageReceipt(session, message, &msg.Recipient),
 		}
+		injectAsyncTraceContext(ctx, async)
 		var err error
 		switch msg.Recipient.Type {
 		case RecipientTypeSession:
@@ -2230,7 +2990,51 @@ func (h *Hub) processMessageMsg(sess Session, message *ClientMessage) {
 		}
 
 		if err != nil {
-			log.Printf("Error publishing message to remote session: %s", err)
+			hubLog.Errorf("Error publishing message to remote session: %s", err)
+		}
+	}
+}
+
+// processBreakoutMessage sends a message of a moderator in a parent room to
+// all sessions in its breakout rooms.
+func (h *Hub) processBreakoutMessage(ctx context.Context, session *ClientSession, message *ClientMessage) {
+	room := session.GetRoom()
+	if room == nil {
+		hubLog.Warnf("Ignore breakout message %+v from %s without room", message.Message, session.PublicId())
+		return
+	}
+
+	if !isAllowedToControl(session) {
+		hubLog.Infof("Session %s is not allowed to send messages to the breakout rooms of %s, ignoring", session.PublicId(), room.Id())
+		sendNotAllowed(session, message, "Not allowed to send messages to breakout rooms.")
+		return
+	}
+
+	roomIds := h.breakout.Get(room.Id(), room.Backend())
+	if len(roomIds) == 0 {
+		session.SendMessage(message.NewErrorServerMessage(NoBreakoutRooms))
+		return
+	}
+
+	response := &ServerMessage{
+		Type: "message",
+		Message: &MessageServerMessage{
+			Sender: &MessageServerMessageSender{
+				Type:      RecipientTypeBreakout,
+				SessionId: session.PublicId(),
+				UserId:    session.UserId(),
+			},
+			Data: message.Message.Data,
+		},
+	}
+	for _, roomId := range roomIds {
+		async := &AsyncMessage{
+			Type:    "message",
+			Message: response,
+		}
+		injectAsyncTraceContext(ctx, async)
+		if err := h.events.PublishRoomMessage(roomId, room.Backend(), async); err != nil {
+			hubLog.Errorf("Error publishing message to breakout room %s of %s: %s", roomId, room.Id(), err)
 		}
 	}
 }
@@ -2249,10 +3053,62 @@ func isAllowedToControl(session Session) bool {
 	return false
 }
 
+// expandMultipleRecipients returns copies of a "message" or "control" request
+// to multiple sessions, each with one of the sessions as recipient. Duplicate
+// sessions are only returned once.
+func expandMultipleRecipients(message *ClientMessage, recipient *MessageClientMessageRecipient) []*ClientMessage {
+	seen := make(map[PublicSessionId]bool, len(recipient.SessionIds))
+	result := make([]*ClientMessage, 0, len(recipient.SessionIds))
+	for _, sessionId := range recipient.SessionIds {
+		if seen[sessionId] {
+			continue
+		}
+		seen[sessionId] = true
+
+		single := MessageClientMessageRecipient{
+			Type:      RecipientTypeSession,
+			SessionId: sessionId,
+		}
+		m := *message
+		switch {
+		case message.Message != nil:
+			msg := *message.Message
+			msg.Recipient = single
+			m.Message = &msg
+		case message.Control != nil:
+			control := *message.Control
+			control.Recipient = single
+			m.Control = &control
+		}
+		result = append(result, &m)
+	}
+	return result
+}
+
+// getMessageReceipt returns the receipt to request for a message that was sent
+// by the given session or nil if the sender is not interested in receipts.
+// Receipts are only supported for messages to a single session.
+func getMessageReceipt(session Session, message *ClientMessage, recipient *MessageClientMessageRecipient) *AsyncMessageReceipt {
+	if message.Id == "" || recipient.Type != RecipientTypeSession {
+		return nil
+	}
+
+	if s, ok := session.(*ClientSession); !ok || !s.HasNegotiatedFeature(ClientFeatureReceipts) {
+		return nil
+	}
+
+	return &AsyncMessageReceipt{
+		MessageId: message.Id,
+		Type:      message.Type,
+		SessionId: session.PublicId(),
+		Recipient: *recipient,
+	}
+}
+
 func (h *Hub) processControlMsg(session Session, message *ClientMessage) {
 	msg := message.Control
 	if !isAllowedToControl(session) {
-		log.Printf("Ignore control message %+v from %s", msg, session.PublicId())
+		hubLog.Warnf("Ignore control message %+v from %s", msg, session.PublicId())
 		return
 	}
 
@@ -2297,6 +3153,7 @@ func (h *Hub) processControlMsg(session Session, message *ClientMessage) {
 			}
 			h.mu.RUnlock()
 		} else {
+			h.anomalies.Record(session, AnomalyInvalidSessionIds, string(msg.Recipient.SessionId))
 			serverRecipient = &msg.Recipient
 		}
 	case RecipientTypeUser:
@@ -2320,7 +3177,7 @@ func (h *Hub) processControlMsg(session Session, message *ClientMessage) {
 		}
 	}
 	if subject == "" {
-		log.Printf("Unknown recipient in message %+v from %s", msg, session.PublicId())
+		hubLog.Warnf("Unknown recipient in message %+v from %s", msg, session.PublicId())
 		return
 	}
 
@@ -2337,11 +3194,16 @@ func (h *Hub) processControlMsg(session Session, message *ClientMessage) {
 		},
 	}
 	if recipient != nil {
-		recipient.SendMessage(response)
+		if receipt := getMessageReceipt(session, message, &msg.Recipient); receipt != nil {
+			recipient.SendMessageWithReceipt(response, receipt)
+		} else {
+			recipient.SendMessage(response)
+		}
 	} else {
 		async := &AsyncMessage{
 			Type:    "message",
 			Message: response,
+			Receipt: getMessageReceipt(session, message, &msg.Recipient),
 		}
 		var err error
 		switch msg.Recipient.Type {
@@ -2357,19 +3219,19 @@ func (h *Hub) processControlMsg(session Session, message *ClientMessage) {
 			err = fmt.Errorf("unsupported recipient type: %s", msg.Recipient.Type)
 		}
 		if err != nil {
-			log.Printf("Error publishing message to remote session: %s", err)
+			hubLog.Errorf("Error publishing message to remote session: %s", err)
 		}
 	}
 }
 
-func (h *Hub) processInternalMsg(sess Session, message *ClientMessage) {
+func (h *Hub) processInternalMsg(ctx context.Context, sess Session, message *ClientMessage) {
 	msg := message.Internal
 	session, ok := sess.(*ClientSession)
 	if !ok {
 		// Client is not connected yet.
 		return
 	} else if session.ClientType() != HelloClientTypeInternal {
-		log.Printf("Ignore internal message %+v from %s", msg, session.PublicId())
+		hubLog.Warnf("Ignore internal message %+v from %s", msg, session.PublicId())
 		return
 	}
 
@@ -2380,86 +3242,81 @@ func (h *Hub) processInternalMsg(sess Session, message *ClientMessage) {
 	switch msg.Type {
 	case "addsession":
 		msg := msg.AddSession
+		if !session.Backend().HasFeature(BackendFeatureVirtualSessions) {
+			hubLog.Warnf("Ignore add session message %+v from %s, virtual sessions are disabled for backend %s", *msg, session.PublicId(), session.Backend().Id())
+			session.SendMessage(message.NewErrorServerMessage(FeatureDisabled))
+			return
+		}
+
 		room := h.GetRoomForBackend(msg.RoomId, session.Backend())
 		if room == nil {
-			log.Printf("Ignore add session message %+v for invalid room %s from %s", *msg, msg.RoomId, session.PublicId())
+			hubLog.Warnf("Ignore add session message %+v for invalid room %s from %s", *msg, msg.RoomId, session.PublicId())
 			return
 		}
 
-		sessionIdData := h.newSessionIdData(session.Backend())
-		privateSessionId, err := h.cookie.EncodePrivate(sessionIdData)
+		ctx, cancel := context.WithTimeout(ctx, h.backendTimeout)
+		defer cancel()
+
+		sess, err := h.newVirtualSession(ctx, session, room, msg)
 		if err != nil {
-			log.Printf("Could not encode private virtual session id: %s", err)
+			session.SendMessage(message.NewErrorServerMessage(err))
 			return
 		}
-		publicSessionId, err := h.cookie.EncodePublic(sessionIdData)
-		if err != nil {
-			log.Printf("Could not encode public virtual session id: %s", err)
+
+		h.registerVirtualSession(session, sess, room)
+		room.AddSession(sess, nil)
+	case "addsessions":
+		msg := msg.AddSessions
+		if !session.Backend().HasFeature(BackendFeatureVirtualSessions) {
+			hubLog.Warnf("Ignore add sessions message from %s, virtual sessions are disabled for backend %s", session.PublicId(), session.Backend().Id())
+			session.SendMessage(message.NewErrorServerMessage(FeatureDisabled))
 			return
 		}
 
-		ctx, cancel := context.WithTimeout(session.Context(), h.backendTimeout)
-		defer cancel()
-
-		virtualSessionId := GetVirtualSessionId(session, msg.SessionId)
-
-		sess, err := NewVirtualSession(session, privateSessionId, publicSessionId, sessionIdData, msg)
-		if err != nil {
-			log.Printf("Could not create virtual session %s: %s", virtualSessionId, err)
-			reply := message.NewErrorServerMessage(NewError("add_failed", "Could not create virtual session."))
-			session.SendMessage(reply)
+		room := h.GetRoomForBackend(msg.RoomId, session.Backend())
+		if room == nil {
+			hubLog.Warnf("Ignore add sessions message for invalid room %s from %s", msg.RoomId, session.PublicId())
 			return
 		}
 
-		if options := msg.Options; options != nil && options.ActorId != "" && options.ActorType != "" {
-			request := NewBackendClientRoomRequest(room.Id(), msg.UserId, RoomSessionId(publicSessionId))
-			request.Room.ActorId = options.ActorId
-			request.Room.ActorType = options.ActorType
-			request.Room.InCall = sess.GetInCall()
+		ctx, cancel := context.WithTimeout(ctx, h.backendTimeout)
+		defer cancel()
 
-			var response BackendClientResponse
-			if err := h.backend.PerformJSONRequest(ctx, session.ParsedBackendOcsUrl(), request, &response); err != nil {
-				sess.Close()
-				log.Printf("Could not join virtual session %s at backend %s: %s", virtualSessionId, session.BackendUrl(), err)
-				reply := message.NewErrorServerMessage(NewError("add_failed", "Could not join virtual session."))
-				session.SendMessage(reply)
-				return
-			}
+		sessions := make([]*VirtualSession, len(msg.Sessions))
+		errs := make([]*Error, len(msg.Sessions))
+		var wg sync.WaitGroup
+		for idx, m := range msg.Sessions {
+			wg.Add(1)
+			go func(idx int, m *AddSessionInternalClientMessage) {
+				defer wg.Done()
+				sessions[idx], errs[idx] = h.newVirtualSession(ctx, session, room, m)
+			}(idx, m)
+		}
+		wg.Wait()
 
-			if response.Type == "error" {
-				sess.Close()
-				log.Printf("Could not join virtual session %s at backend %s: %+v", virtualSessionId, session.BackendUrl(), response.Error)
-				reply := message.NewErrorServerMessage(NewError("add_failed", response.Error.Error()))
-				session.SendMessage(reply)
-				return
-			}
-		} else {
-			request := NewBackendClientSessionRequest(room.Id(), "add", publicSessionId, msg)
-			var response BackendClientSessionResponse
-			if err := h.backend.PerformJSONRequest(ctx, session.ParsedBackendOcsUrl(), request, &response); err != nil {
-				sess.Close()
-				log.Printf("Could not add virtual session %s at backend %s: %s", virtualSessionId, session.BackendUrl(), err)
-				reply := message.NewErrorServerMessage(NewError("add_failed", "Could not add virtual session."))
-				session.SendMessage(reply)
-				return
+		// The sessions are only added if all of them could be created.
+		if idx := slices.IndexFunc(errs, func(err *Error) bool { return err != nil }); idx != -1 {
+			for _, sess := range sessions {
+				if sess != nil {
+					sess.Close()
+					go sess.notifyBackendRemoved(room, nil, nil)
+				}
 			}
+			session.SendMessage(message.NewErrorServerMessage(errs[idx]))
+			return
 		}
 
-		h.mu.Lock()
-		h.sessions[sessionIdData.Sid] = sess
-		h.virtualSessions[virtualSessionId] = sessionIdData.Sid
-		h.mu.Unlock()
-		statsHubSessionsCurrent.WithLabelValues(session.Backend().Id(), string(sess.ClientType())).Inc()
-		statsHubSessionsTotal.WithLabelValues(session.Backend().Id(), string(sess.ClientType())).Inc()
-		log.Printf("Session %s added virtual session %s with initial flags %d", session.PublicId(), sess.PublicId(), sess.Flags())
-		session.AddVirtualSession(sess)
-		sess.SetRoom(room)
-		room.AddSession(sess, nil)
+		added := make([]Session, 0, len(sessions))
+		for _, sess := range sessions {
+			h.registerVirtualSession(session, sess, room)
+			added = append(added, sess)
+		}
+		room.AddSessions(added)
 	case "updatesession":
 		msg := msg.UpdateSession
 		room := h.GetRoomForBackend(msg.RoomId, session.Backend())
 		if room == nil {
-			log.Printf("Ignore remove session message %+v for invalid room %s from %s", *msg, msg.RoomId, session.PublicId())
+			hubLog.Warnf("Ignore remove session message %+v for invalid room %s from %s", *msg, msg.RoomId, session.PublicId())
 			return
 		}
 
@@ -2476,6 +3333,9 @@ func (h *Hub) processInternalMsg(sess Session, message *ClientMessage) {
 		if sess != nil {
 			var changed SessionChangeFlag
 			if virtualSession, ok := sess.(*VirtualSession); ok {
+				if msg.Permissions != nil {
+					changed |= virtualSession.SetPermissions(*msg.Permissions)
+				}
 				if msg.Flags != nil {
 					if virtualSession.SetFlags(*msg.Flags) {
 						changed |= SessionChangeFlags
@@ -2487,17 +3347,56 @@ func (h *Hub) processInternalMsg(sess Session, message *ClientMessage) {
 					}
 				}
 			} else {
-				log.Printf("Ignore update request for non-virtual session %s", sess.PublicId())
+				hubLog.Warnf("Ignore update request for non-virtual session %s", sess.PublicId())
 			}
 			if changed != 0 {
 				room.NotifySessionChanged(sess, changed)
 			}
 		}
+	case "removesessions":
+		msg := msg.RemoveSessions
+		room := h.GetRoomForBackend(msg.RoomId, session.Backend())
+		if room == nil {
+			hubLog.Warnf("Ignore remove sessions message for invalid room %s from %s", msg.RoomId, session.PublicId())
+			return
+		}
+
+		var sessions []Session
+		h.mu.Lock()
+		for _, sessionId := range msg.SessionIds {
+			virtualSessionId := GetVirtualSessionId(session, sessionId)
+			sid, found := h.virtualSessions[virtualSessionId]
+			if !found {
+				continue
+			}
+
+			delete(h.virtualSessions, virtualSessionId)
+			if sess := h.sessions[sid]; sess != nil {
+				sessions = append(sessions, sess)
+			}
+		}
+		h.mu.Unlock()
+		if len(sessions) == 0 {
+			return
+		}
+
+		hubLog.Infof("Session %s removes %d virtual sessions", session.PublicId(), len(sessions))
+		// Notify other sessions with a single event, the sessions will not send
+		// individual events when they are closed below.
+		room.RemoveSessions(sessions)
+		for _, sess := range sessions {
+			if vsess, ok := sess.(*VirtualSession); ok {
+				// We should always have a VirtualSession here.
+				vsess.CloseWithFeedback(session, message)
+			} else {
+				sess.Close()
+			}
+		}
 	case "removesession":
 		msg := msg.RemoveSession
 		room := h.GetRoomForBackend(msg.RoomId, session.Backend())
 		if room == nil {
-			log.Printf("Ignore remove session message %+v for invalid room %s from %s", *msg, msg.RoomId, session.PublicId())
+			hubLog.Warnf("Ignore remove session message %+v for invalid room %s from %s", *msg, msg.RoomId, session.PublicId())
 			return
 		}
 
@@ -2513,7 +3412,7 @@ func (h *Hub) processInternalMsg(sess Session, message *ClientMessage) {
 		sess := h.sessions[sid]
 		h.mu.Unlock()
 		if sess != nil {
-			log.Printf("Session %s removed virtual session %s", session.PublicId(), sess.PublicId())
+			hubLog.Infof("Session %s removed virtual session %s", session.PublicId(), sess.PublicId())
 			if vsess, ok := sess.(*VirtualSession); ok {
 				// We should always have a VirtualSession here.
 				vsess.CloseWithFeedback(session, message)
@@ -2543,12 +3442,17 @@ func (h *Hub) processInternalMsg(sess Session, message *ClientMessage) {
 					},
 				},
 			}
-			if msg.Dialout.Status.Status == DialoutStatusCleared || msg.Dialout.Status.Status == DialoutStatusRejected {
+			if msg.Dialout.Status.Status == DialoutStatusAccepted {
+				h.dialoutBalancer.AddCall(session, msg.Dialout.Status.CallId)
+			} else if msg.Dialout.Status.Status.IsFinal() {
 				asyncMessage.Room.Transient.TTL = removeCallStatusTTL
+				h.dialoutBalancer.RemoveCall(session, msg.Dialout.Status.CallId)
 			}
 			if err := h.events.PublishBackendRoomMessage(roomId, session.Backend(), asyncMessage); err != nil {
-				log.Printf("Error publishing dialout message %+v to room %s", msg.Dialout, roomId)
+				hubLog.Errorf("Error publishing dialout message %+v to room %s", msg.Dialout, roomId)
 			}
+
+			h.notifyDialoutStatus(ctx, session, roomId, msg.Dialout.Status)
 		} else {
 			if err := h.events.PublishRoomMessage(roomId, session.Backend(), &AsyncMessage{
 				Type: "message",
@@ -2557,62 +3461,540 @@ func (h *Hub) processInternalMsg(sess Session, message *ClientMessage) {
 					Dialout: msg.Dialout,
 				},
 			}); err != nil {
-				log.Printf("Error publishing dialout message %+v to room %s", msg.Dialout, roomId)
+				hubLog.Errorf("Error publishing dialout message %+v to room %s", msg.Dialout, roomId)
 			}
 		}
+	case "dtmf":
+		msg := msg.Dtmf
+		room := h.GetRoomForBackend(msg.RoomId, session.Backend())
+		if room == nil {
+			hubLog.Warnf("Ignore dtmf message %+v for invalid room %s from %s", *msg, msg.RoomId, session.PublicId())
+			return
+		}
+
+		virtualSessionId := GetVirtualSessionId(session, msg.SessionId)
+		h.mu.Lock()
+		sid, found := h.virtualSessions[virtualSessionId]
+		var sess Session
+		if found {
+			sess = h.sessions[sid]
+		}
+		h.mu.Unlock()
+		if sess == nil {
+			hubLog.Warnf("Ignore dtmf message %+v for unknown session from %s", *msg, session.PublicId())
+			return
+		}
+
+		if err := h.events.PublishRoomMessage(room.Id(), room.Backend(), &AsyncMessage{
+			Type: "message",
+			Message: &ServerMessage{
+				Type: "event",
+				Event: &EventServerMessage{
+					Target: "room",
+					Type:   "dtmf",
+					Dtmf: &EventServerMessageDtmf{
+						RoomId:    room.Id(),
+						SessionId: sess.PublicId(),
+						Digits:    msg.Digits,
+					},
+				},
+			},
+		}); err != nil {
+			hubLog.Errorf("Error publishing dtmf message %+v to room %s: %s", *msg, room.Id(), err)
+		}
+	case "recording":
+		if !session.HasNegotiatedFeature(ClientFeatureRecording) {
+			hubLog.Warnf("Ignore recording message %+v from %s without recording support", *msg.Recording, session.PublicId())
+			return
+		}
+
+		h.recording.ProcessMessage(session, msg.Recording)
+	case "transcription":
+		if !session.HasNegotiatedFeature(ClientFeatureTranscription) {
+			hubLog.Warnf("Ignore transcription message %+v from %s without transcription support", *msg.Transcription, session.PublicId())
+			return
+		}
+
+		h.processTranscriptionMsg(ctx, session, message)
+	case "heartbeat":
+		if h.internalHeartbeats.Heartbeat(session, time.Now()) {
+			hubLog.Infof("Internal client %s is sending heartbeats again", session.PublicId())
+			h.setVirtualSessionsDegraded(session, false)
+		}
 	default:
-		log.Printf("Ignore unsupported internal message %+v from %s", msg, session.PublicId())
+		hubLog.Warnf("Ignore unsupported internal message %+v from %s", msg, session.PublicId())
 		return
 	}
 }
 
-func isAllowedToUpdateTransientData(session Session) bool {
-	if session.ClientType() == HelloClientTypeInternal {
-		// Internal clients are always allowed.
-		return true
+// newVirtualSession creates a virtual session of an internal client and
+// registers it at the backend.
+func (h *Hub) newVirtualSession(ctx context.Context, session *ClientSession, room *Room, msg *AddSessionInternalClientMessage) (*VirtualSession, *Error) {
+	sessionIdData := h.newSessionIdData(session.Backend())
+	privateSessionId, err := h.cookie.EncodePrivate(sessionIdData)
+	if err != nil {
+		hubLog.Errorf("Could not encode private virtual session id: %s", err)
+		return nil, NewError("add_failed", "Could not create virtual session.")
+	}
+	publicSessionId, err := h.cookie.EncodePublic(sessionIdData)
+	if err != nil {
+		hubLog.Errorf("Could not encode public virtual session id: %s", err)
+		return nil, NewError("add_failed", "Could not create virtual session.")
 	}
 
-	if session.HasPermission(PERMISSION_TRANSIENT_DATA) {
-		return true
+	virtualSessionId := GetVirtualSessionId(session, msg.SessionId)
+
+	sess, err := NewVirtualSession(session, privateSessionId, publicSessionId, sessionIdData, msg)
+	if err != nil {
+		hubLog.Errorf("Could not create virtual session %s: %s", virtualSessionId, err)
+		return nil, NewError("add_failed", "Could not create virtual session.")
 	}
 
-	return false
-}
+	if options := msg.Options; options != nil && options.ActorId != "" && options.ActorType != "" {
+		request := NewBackendClientRoomRequest(room.Id(), msg.UserId, RoomSessionId(publicSessionId))
+		request.Room.ActorId = options.ActorId
+		request.Room.ActorType = options.ActorType
+		request.Room.InCall = sess.GetInCall()
 
-func (h *Hub) processTransientMsg(session Session, message *ClientMessage) {
-	room := session.GetRoom()
-	if room == nil {
-		response := message.NewErrorServerMessage(NewError("not_in_room", "No room joined yet."))
+		var response BackendClientResponse
+		if err := h.backend.PerformJSONRequest(ctx, session.ParsedBackendOcsUrl(), request, &response); err != nil {
+			sess.Close()
+			hubLog.Errorf("Could not join virtual session %s at backend %s: %s", virtualSessionId, session.BackendUrl(), err)
+			return nil, NewError("add_failed", "Could not join virtual session.")
+		}
+
+		if response.Type == "error" {
+			sess.Close()
+			hubLog.Errorf("Could not join virtual session %s at backend %s: %+v", virtualSessionId, session.BackendUrl(), response.Error)
+			return nil, NewError("add_failed", response.Error.Error())
+		}
+	} else {
+		request := NewBackendClientSessionRequest(room.Id(), "add", publicSessionId, msg)
+		var response BackendClientSessionResponse
+		if err := h.backend.PerformJSONRequest(ctx, session.ParsedBackendOcsUrl(), request, &response); err != nil {
+			sess.Close()
+			hubLog.Errorf("Could not add virtual session %s at backend %s: %s", virtualSessionId, session.BackendUrl(), err)
+			return nil, NewError("add_failed", "Could not add virtual session.")
+		}
+	}
+
+	return sess, nil
+}
+
+func (h *Hub) processTranscriptionMsg(ctx context.Context, session *ClientSession, message *ClientMessage) {
+	msg := message.Internal.Transcription
+	room := h.GetRoomForBackend(msg.RoomId, session.Backend())
+	if room == nil {
+		hubLog.Warnf("Ignore transcription message %+v for invalid room %s from %s", *msg, msg.RoomId, session.PublicId())
+		session.SendMessage(message.NewErrorServerMessage(NewError("no_such_room", "The room does not exist.")))
+		return
+	}
+
+	speaker := h.GetSessionByPublicId(msg.SessionId)
+	if speaker == nil || speaker.GetRoom() != room {
+		session.SendMessage(message.NewErrorServerMessage(NewError("no_such_session", "The session is not in the room.")))
+		return
+	}
+
+	switch msg.Type {
+	case "forward":
+		fallthrough
+	case "stopforward":
+		var publisher McuPublisher
+		if clientSession, ok := speaker.(*ClientSession); ok {
+			publisher = clientSession.GetPublisher(StreamTypeVideo)
+		}
+		if publisher == nil || !publisher.HasMedia(MediaTypeAudio) {
+			session.SendMessage(message.NewErrorServerMessage(NewError("no_audio", "The session is not publishing audio.")))
+			return
+		}
+
+		remotePublisher, ok := publisher.(McuRemoteAwarePublisher)
+		if !ok {
+			session.SendMessage(message.NewErrorServerMessage(NewError("not_supported", "Forwarding audio is not supported.")))
+			return
+		}
+
+		var err error
+		if msg.Type == "forward" {
+			err = h.transcriptions.Forward(ctx, session, remotePublisher, msg.Hostname, msg.Port, msg.RtcpPort)
+		} else {
+			err = h.transcriptions.StopForward(ctx, session, remotePublisher, msg.Hostname, msg.Port, msg.RtcpPort)
+		}
+		if err != nil {
+			hubLog.Errorf("Could not %s audio of %s to %s: %s", msg.Type, speaker.PublicId(), session.PublicId(), err)
+			session.SendMessage(message.NewErrorServerMessage(NewError("forward_failed", "Could not change forwarding of audio.")))
+		}
+	case "caption":
+		if !h.transcriptions.AllowCaption(session, speaker.PublicId(), msg.Caption.Final, time.Now()) {
+			statsHubCaptionsThrottledTotal.WithLabelValues(statsBackendLabel(room.Backend())).Inc()
+			return
+		}
+
+		if err := h.events.PublishRoomMessage(room.Id(), room.Backend(), &AsyncMessage{
+			Type: "message",
+			Message: &ServerMessage{
+				Type: "event",
+				Event: &EventServerMessage{
+					Target: "room",
+					Type:   "caption",
+					Caption: &EventServerMessageCaption{
+						RoomId:               room.Id(),
+						SessionId:            speaker.PublicId(),
+						UserId:               speaker.UserId(),
+						TranscriptionCaption: *msg.Caption,
+					},
+				},
+			},
+		}); err != nil {
+			hubLog.Errorf("Error publishing caption of %s to room %s: %s", speaker.PublicId(), room.Id(), err)
+		}
+	}
+}
+
+// processInternalHeartbeatFailure marks the virtual sessions of an internal
+// client that stopped sending heartbeats as degraded and runs the configured
+// cleanup.
+func (h *Hub) processInternalHeartbeatFailure(session *ClientSession, cleanup InternalHeartbeatCleanup) {
+	hubLog.Warnf("No heartbeat received from internal client %s, cleanup is %s", session.PublicId(), cleanup)
+	statsHubInternalHeartbeatFailuresTotal.WithLabelValues(statsBackendLabel(session.Backend()), string(cleanup)).Inc()
+	h.setVirtualSessionsDegraded(session, true)
+
+	switch cleanup {
+	case InternalHeartbeatCleanupRemove:
+		for _, sess := range session.GetVirtualSessions() {
+			sess.Close()
+		}
+	case InternalHeartbeatCleanupClose:
+		if client := session.GetClient(); client != nil {
+			client.SendByeResponseWithReason(nil, ByeReasonHeartbeatTimeout)
+		}
+		session.Close()
+	}
+}
+
+// setVirtualSessionsDegraded changes the degraded state of the virtual sessions
+// of an internal client and notifies the rooms and backends about the change.
+func (h *Hub) setVirtualSessionsDegraded(session *ClientSession, degraded bool) {
+	rooms := make(map[*Room][]*VirtualSession)
+	for _, sess := range session.GetVirtualSessions() {
+		room := sess.GetRoom()
+		if room == nil || !sess.SetDegraded(degraded) {
+			continue
+		}
+
+		rooms[room] = append(rooms[room], sess)
+	}
+
+	for room, sessions := range rooms {
+		sessionIds := make([]PublicSessionId, 0, len(sessions))
+		for _, sess := range sessions {
+			sessionIds = append(sessionIds, sess.PublicId())
+		}
+		slices.Sort(sessionIds)
+
+		if err := h.events.PublishRoomMessage(room.Id(), room.Backend(), &AsyncMessage{
+			Type: "message",
+			Message: &ServerMessage{
+				Type: "event",
+				Event: &EventServerMessage{
+					Target: "room",
+					Type:   "degraded",
+					Degraded: &EventServerMessageDegraded{
+						RoomId:     room.Id(),
+						SessionIds: sessionIds,
+						Degraded:   degraded,
+					},
+				},
+			},
+		}); err != nil {
+			hubLog.Errorf("Error publishing degraded state of virtual sessions %v to room %s: %s", sessionIds, room.Id(), err)
+		}
+
+		go h.notifyVirtualSessionsDegraded(session, room.Id(), sessions, degraded)
+	}
+}
+
+// notifyVirtualSessionsDegraded sends the degraded state of virtual sessions
+// to the backend of the room if it supports receiving it.
+func (h *Hub) notifyVirtualSessionsDegraded(session *ClientSession, roomId string, sessions []*VirtualSession, degraded bool) {
+	ctx, cancel := context.WithTimeout(context.Background(), h.backendTimeout)
+	defer cancel()
+
+	url := session.ParsedBackendOcsUrl()
+	if !h.backend.capabilities.HasCapabilityFeature(ctx, url, FeatureSessionDegraded) {
+		return
+	}
+
+	action := "degraded"
+	if !degraded {
+		action = "recovered"
+	}
+	for _, sess := range sessions {
+		request := NewBackendClientSessionRequest(roomId, action, sess.PublicId(), &AddSessionInternalClientMessage{
+			UserId: sess.UserId(),
+			User:   sess.UserData(),
+		})
+		var response BackendClientSessionResponse
+		if err := h.backend.PerformJSONRequest(ctx, url, request, &response); err != nil {
+			hubLog.Errorf("Could not send %s state of virtual session %s to backend %s: %s", action, sess.PublicId(), session.BackendUrl(), err)
+		}
+	}
+}
+
+// registerVirtualSession makes a virtual session created by newVirtualSession
+// available in the hub. The caller must add it to the room afterwards.
+func (h *Hub) registerVirtualSession(session *ClientSession, sess *VirtualSession, room *Room) {
+	h.mu.Lock()
+	h.sessions[sess.Data().Sid] = sess
+	h.virtualSessions[GetVirtualSessionId(session, sess.SessionId())] = sess.Data().Sid
+	h.mu.Unlock()
+	h.storeSession(sess, nil)
+	statsHubSessionsCurrent.WithLabelValues(statsBackendLabel(session.Backend()), string(sess.ClientType())).Inc()
+	statsHubSessionsTotal.WithLabelValues(statsBackendLabel(session.Backend()), string(sess.ClientType())).Inc()
+	hubLog.Infof("Session %s added virtual session %s with initial flags %d", session.PublicId(), sess.PublicId(), sess.Flags())
+	session.AddVirtualSession(sess)
+	sess.SetRoom(room)
+}
+
+// notifyDialoutStatus sends the status of a dialout call to the backend of the
+// room if it supports receiving them.
+func (h *Hub) notifyDialoutStatus(ctx context.Context, session *ClientSession, roomId string, status *DialoutStatusInternalClientMessage) {
+	ctx, cancel := context.WithTimeout(ctx, h.backendTimeout)
+	defer cancel()
+
+	url := session.ParsedBackendOcsUrl()
+	if !h.backend.capabilities.HasCapabilityFeature(ctx, url, FeatureDialoutStatus) {
+		return
+	}
+
+	request := NewBackendClientDialoutRequest(roomId, status)
+	var response BackendClientResponse
+	if err := h.backend.PerformJSONRequest(ctx, url, request, &response); err != nil {
+		hubLog.Errorf("Could not send status %s of dialout call %s in room %s to backend %s: %s", status.Status, status.CallId, roomId, session.BackendUrl(), err)
+	} else if response.Type == "error" {
+		hubLog.Errorf("Backend %s returned error for status %s of dialout call %s in room %s: %+v", session.BackendUrl(), status.Status, status.CallId, roomId, response.Error)
+	}
+}
+
+func isAllowedToUpdateTransientData(session Session, key string) bool {
+	if session.ClientType() == HelloClientTypeInternal {
+		// Internal clients are always allowed.
+		return true
+	}
+
+	if !session.HasPermission(PERMISSION_TRANSIENT_DATA) {
+		return false
+	}
+
+	if strings.HasPrefix(key, TransientNamespaceModerator) {
+		return session.HasPermission(PERMISSION_MAY_CONTROL)
+	}
+
+	if owner, found := getTransientKeyOwner(key); found {
+		return owner == session.PublicId()
+	}
+
+	return true
+}
+
+// updateTransientData sets (or removes if the value is nil) transient data of
+// a room. In clustered setups, the change is distributed to all servers with
+// sessions in the room, so the data is still available if the room moves to
+// another server.
+func (h *Hub) updateTransientData(room *Room, key string, value any, ttl time.Duration) {
+	if h.rpcClients != nil {
+		request := &BackendRoomTransientRequest{
+			Action: TransientActionDelete,
+			Key:    key,
+		}
+		if value != nil {
+			request.Action = TransientActionSet
+			request.Value = value
+			request.TTL = ttl
+		}
+		err := h.events.PublishBackendRoomMessage(room.Id(), room.Backend(), &AsyncMessage{
+			Type: "room",
+			Room: &BackendServerRoomRequest{
+				Type:      "transient",
+				Transient: request,
+			},
+		})
+		if err == nil {
+			return
+		}
+
+		hubLog.Errorf("Error publishing transient data %s to room %s, updating locally: %s", key, room.Id(), err)
+	}
+
+	room.SetTransientDataTTL(key, value, ttl)
+}
+
+func (h *Hub) processTransientMsg(session Session, message *ClientMessage) {
+	room := session.GetRoom()
+	if room == nil {
+		response := message.NewErrorServerMessage(NewError("not_in_room", "No room joined yet."))
 		session.SendMessage(response)
 		return
 	}
 
+	if !session.Backend().HasFeature(BackendFeatureTransientData) {
+		session.SendMessage(message.NewErrorServerMessage(FeatureDisabled))
+		return
+	}
+
 	msg := message.TransientData
 	switch msg.Type {
 	case "set":
-		if !isAllowedToUpdateTransientData(session) {
+		if !isAllowedToUpdateTransientData(session, msg.Key) {
 			sendNotAllowed(session, message, "Not allowed to update transient data.")
 			return
 		}
 
-		if msg.Value == nil {
-			room.SetTransientDataTTL(msg.Key, nil, msg.TTL)
-		} else {
-			room.SetTransientDataTTL(msg.Key, msg.Value, msg.TTL)
-		}
+		h.updateTransientData(room, msg.Key, msg.Value, msg.TTL)
 	case "remove":
-		if !isAllowedToUpdateTransientData(session) {
+		if !isAllowedToUpdateTransientData(session, msg.Key) {
 			sendNotAllowed(session, message, "Not allowed to update transient data.")
 			return
 		}
 
-		room.RemoveTransientData(msg.Key)
+		h.updateTransientData(room, msg.Key, nil, 0)
 	default:
 		response := message.NewErrorServerMessage(NewError("ignored", "Unsupported message type."))
 		session.SendMessage(response)
 	}
 }
 
+func (h *Hub) processEcho(client HandlerClient, message *ClientMessage, received time.Time) {
+	if rtt := message.Echo.Rtt; rtt > 0 {
+		statsClientRttSeconds.Observe((time.Duration(rtt) * time.Millisecond).Seconds())
+	}
+
+	response := &ServerMessage{
+		Id:   message.Id,
+		Type: "echo",
+		Echo: &EchoServerMessage{
+			Timestamp: message.Echo.Timestamp,
+			Received:  received.UnixMilli(),
+			Sent:      time.Now().UnixMilli(),
+		},
+	}
+	client.SendMessage(response)
+}
+
+func (h *Hub) processParticipantsMsg(session Session, message *ClientMessage) {
+	clientSession, ok := session.(*ClientSession)
+	if !ok || !clientSession.HasNegotiatedFeature(ClientFeatureParticipantsDelta) {
+		response := message.NewErrorServerMessage(NewError("ignored", "Feature \"participants-delta\" not requested."))
+		session.SendMessage(response)
+		return
+	}
+
+	room := clientSession.GetRoom()
+	if room == nil {
+		response := message.NewErrorServerMessage(NewError("not_in_room", "No room joined yet."))
+		session.SendMessage(response)
+		return
+	}
+
+	if !clientSession.SendParticipantsDiff(message.Id, room.Id(), message.Participants.Version) {
+		response := message.NewErrorServerMessage(NewError("no_participants", "No participants list received yet."))
+		session.SendMessage(response)
+	}
+}
+
+func (h *Hub) processDtmfMsg(session Session, message *ClientMessage) {
+	msg := message.Dtmf
+	if !isAllowedToControl(session) {
+		sendNotAllowed(session, message, "Not allowed to send DTMF digits.")
+		return
+	}
+
+	room := session.GetRoom()
+	if room == nil {
+		response := message.NewErrorServerMessage(NewError("not_in_room", "No room joined yet."))
+		session.SendMessage(response)
+		return
+	}
+
+	if h.decodePublicSessionId(msg.SessionId) == nil {
+		h.anomalies.Record(session, AnomalyInvalidSessionIds, string(msg.SessionId))
+		response := message.NewErrorServerMessage(NewError("invalid_session", "The session id is invalid."))
+		session.SendMessage(response)
+		return
+	}
+
+	// The virtual session of the dial-in participant will only forward the
+	// digits to the SIP bridge if it is in the same room as the sender.
+	if err := h.events.PublishSessionMessage(msg.SessionId, session.Backend(), &AsyncMessage{
+		Type: "message",
+		Message: &ServerMessage{
+			Type: "event",
+			Event: &EventServerMessage{
+				Target: "session",
+				Type:   "dtmf",
+				Dtmf: &EventServerMessageDtmf{
+					RoomId:    room.Id(),
+					SessionId: msg.SessionId,
+					Digits:    msg.Digits,
+					Sender:    session.PublicId(),
+				},
+			},
+		},
+	}); err != nil {
+		hubLog.Errorf("Error publishing dtmf message %+v to session %s: %s", *msg, msg.SessionId, err)
+	}
+}
+
+func (h *Hub) processRecordingMsg(session Session, message *ClientMessage) {
+	msg := message.Recording
+	if !isAllowedToControl(session) {
+		sendNotAllowed(session, message, "Not allowed to control the recording.")
+		return
+	}
+
+	room := session.GetRoom()
+	if room == nil {
+		response := message.NewErrorServerMessage(NewError("not_in_room", "No room joined yet."))
+		session.SendMessage(response)
+		return
+	}
+
+	if !mcuSupportsRecording(h.mcu) {
+		session.SendMessage(message.NewErrorServerMessage(ErrRecordingNotAvailable))
+		return
+	}
+
+	// Requests to the recording clients may take a while, don't block other
+	// messages of the session. The participants are notified about the new
+	// status through a "recording" event.
+	go func() {
+		ctx := session.Context()
+		var err error
+		switch msg.Action {
+		case RecordingActionStart:
+			err = h.recording.Start(ctx, room.Id(), room.Backend(), getInternalBackendUrl(room.Backend(), session.BackendUrl()), msg.Options)
+		case RecordingActionStop:
+			err = h.recording.Stop(ctx, room.Id(), room.Backend())
+		case RecordingActionPause:
+			err = h.recording.Pause(ctx, room.Id(), room.Backend())
+		case RecordingActionResume:
+			err = h.recording.Resume(ctx, room.Id(), room.Backend())
+		}
+		if err == nil {
+			return
+		}
+
+		hubLog.Warnf("Could not %s recording of room %s for session %s: %s", msg.Action, room.Id(), session.PublicId(), err)
+		var e *Error
+		if errors.As(err, &e) {
+			session.SendMessage(message.NewErrorServerMessage(e))
+		} else {
+			session.SendMessage(message.NewWrappedErrorServerMessage(err))
+		}
+	}()
+}
+
 func sendNotAllowed(session Session, message *ClientMessage, reason string) {
 	response := message.NewErrorServerMessage(NewError("not_allowed", reason))
 	session.SendMessage(response)
@@ -2647,7 +4029,7 @@ func (h *Hub) isInSameCallRemote(ctx context.Context, senderSession *ClientSessi
 			if errors.Is(err, context.Canceled) {
 				return
 			} else if err != nil {
-				log.Printf("Error checking session %s in call on %s: %s", recipientSessionId, client.Target(), err)
+				hubLog.Errorf("Error checking session %s in call on %s: %s", recipientSessionId, client.Target(), err)
 				return
 			} else if !inCall {
 				return
@@ -2690,8 +4072,8 @@ func (h *Hub) isInSameCall(ctx context.Context, senderSession *ClientSession, re
 	return true
 }
 
-func (h *Hub) processMcuMessage(session *ClientSession, client_message *ClientMessage, message *MessageClientMessage, data *MessageClientMessageData) {
-	ctx, cancel := context.WithTimeout(session.Context(), h.mcuTimeout)
+func (h *Hub) processMcuMessage(ctx context.Context, session *ClientSession, client_message *ClientMessage, message *MessageClientMessage, data *MessageClientMessageData) {
+	ctx, cancel := context.WithTimeout(ctx, h.mcuTimeout)
 	defer cancel()
 
 	var mc McuClient
@@ -2700,19 +4082,20 @@ func (h *Hub) processMcuMessage(session *ClientSession, client_message *ClientMe
 	switch data.Type {
 	case "requestoffer":
 		if session.PublicId() == message.Recipient.SessionId {
-			log.Printf("Not requesting offer from itself for session %s", session.PublicId())
+			hubLog.Infof("Not requesting offer from itself for session %s", session.PublicId())
 			return
 		}
 
 		// A user is only allowed to subscribe a stream if she is in the same room
 		// as the other user and both have their "inCall" flag set.
 		if !h.allowSubscribeAnyStream && !h.isInSameCall(ctx, session, message.Recipient.SessionId) {
-			log.Printf("Session %s is not in the same call as session %s, not requesting offer", session.PublicId(), message.Recipient.SessionId)
+			hubLog.Infof("Session %s is not in the same call as session %s, not requesting offer", session.PublicId(), message.Recipient.SessionId)
 			sendNotAllowed(session, client_message, "Not allowed to request offer.")
 			return
 		}
 
 		clientType = "subscriber"
+		data.Payload = applyClientHints(session.Hints(), StreamType(data.RoomType), data.Payload)
 		mc, err = session.GetOrCreateSubscriber(ctx, h.mcu, message.Recipient.SessionId, StreamType(data.RoomType))
 	case "sendoffer":
 		// Will be sent directly.
@@ -2721,13 +4104,13 @@ func (h *Hub) processMcuMessage(session *ClientSession, client_message *ClientMe
 		clientType = "publisher"
 		mc, err = session.GetOrCreatePublisher(ctx, h.mcu, StreamType(data.RoomType), data)
 		if err, ok := err.(*PermissionError); ok {
-			log.Printf("Session %s is not allowed to offer %s, ignoring (%s)", session.PublicId(), data.RoomType, err)
+			hubLog.Infof("Session %s is not allowed to offer %s, ignoring (%s)", session.PublicId(), data.RoomType, err)
 			sendNotAllowed(session, client_message, "Not allowed to publish.")
 			return
 		}
 	case "selectStream":
 		if session.PublicId() == message.Recipient.SessionId {
-			log.Printf("Not selecting substream for own %s stream in session %s", data.RoomType, session.PublicId())
+			hubLog.Infof("Not selecting substream for own %s stream in session %s", data.RoomType, session.PublicId())
 			return
 		}
 
@@ -2741,7 +4124,7 @@ func (h *Hub) processMcuMessage(session *ClientSession, client_message *ClientMe
 
 		if session.PublicId() == message.Recipient.SessionId {
 			if err := session.IsAllowedToSend(data); err != nil {
-				log.Printf("Session %s is not allowed to send candidate for %s, ignoring (%s)", session.PublicId(), data.RoomType, err)
+				hubLog.Infof("Session %s is not allowed to send candidate for %s, ignoring (%s)", session.PublicId(), data.RoomType, err)
 				sendNotAllowed(session, client_message, "Not allowed to send candidate.")
 				return
 			}
@@ -2754,19 +4137,27 @@ func (h *Hub) processMcuMessage(session *ClientSession, client_message *ClientMe
 		}
 	}
 	if err != nil {
-		log.Printf("Could not create MCU %s for session %s to send %+v to %s: %s", clientType, session.PublicId(), data, message.Recipient.SessionId, err)
+		hubLog.Errorf("Could not create MCU %s for session %s to send %+v to %s: %s", clientType, session.PublicId(), data, message.Recipient.SessionId, err)
 		sendMcuClientNotFound(session, client_message)
 		return
 	} else if mc == nil {
-		log.Printf("No MCU %s found for session %s to send %+v to %s", clientType, session.PublicId(), data, message.Recipient.SessionId)
+		hubLog.Warnf("No MCU %s found for session %s to send %+v to %s", clientType, session.PublicId(), data, message.Recipient.SessionId)
 		sendMcuClientNotFound(session, client_message)
 		return
 	}
 
-	mc.SendMessage(session.Context(), message, data, func(err error, response StringMap) {
+	// The message is processed in the context of the session but should still
+	// be part of the trace of the received message.
+	sendCtx, done := startMcuOperation(trace.ContextWithSpan(session.Context(), trace.SpanFromContext(ctx)), "SendMessage", session.PublicId(), StreamType(data.RoomType))
+	mc.SendMessage(sendCtx, message, data, func(err error, response StringMap) {
+		if errors.Is(err, ErrCandidateFiltered) {
+			done(nil)
+		} else {
+			done(err)
+		}
 		if err != nil {
 			if !errors.Is(err, ErrCandidateFiltered) {
-				log.Printf("Could not send MCU message %+v for session %s to %s: %s", data, session.PublicId(), message.Recipient.SessionId, err)
+				hubLog.Errorf("Could not send MCU message %+v for session %s to %s: %s", data, session.PublicId(), message.Recipient.SessionId, err)
 				sendMcuProcessingFailed(session, client_message)
 			}
 			return
@@ -2793,7 +4184,7 @@ func (h *Hub) sendMcuMessageResponse(session *ClientSession, mcuClient McuClient
 		}
 		answer_data, err := json.Marshal(answer_message)
 		if err != nil {
-			log.Printf("Could not serialize answer %+v to %s: %s", answer_message, session.PublicId(), err)
+			hubLog.Errorf("Could not serialize answer %+v to %s: %s", answer_message, session.PublicId(), err)
 			return
 		}
 		response_message = &ServerMessage{
@@ -2818,7 +4209,7 @@ func (h *Hub) sendMcuMessageResponse(session *ClientSession, mcuClient McuClient
 		}
 		offer_data, err := json.Marshal(offer_message)
 		if err != nil {
-			log.Printf("Could not serialize offer %+v to %s: %s", offer_message, session.PublicId(), err)
+			hubLog.Errorf("Could not serialize offer %+v to %s: %s", offer_message, session.PublicId(), err)
 			return
 		}
 		response_message = &ServerMessage{
@@ -2833,7 +4224,7 @@ func (h *Hub) sendMcuMessageResponse(session *ClientSession, mcuClient McuClient
 			},
 		}
 	default:
-		log.Printf("Unsupported response %+v received to send to %s", response, session.PublicId())
+		hubLog.Warnf("Unsupported response %+v received to send to %s", response, session.PublicId())
 		return
 	}
 
@@ -2874,7 +4265,7 @@ func (h *Hub) processRoomInCallChanged(message *BackendServerRoomRequest) {
 		if err := json.Unmarshal(message.InCall.InCall, &flags); err != nil {
 			var incall bool
 			if err := json.Unmarshal(message.InCall.InCall, &incall); err != nil {
-				log.Printf("Unsupported InCall flags type: %+v, ignoring", string(message.InCall.InCall))
+				hubLog.Warnf("Unsupported InCall flags type: %+v, ignoring", string(message.InCall.InCall))
 				return
 			}
 
@@ -2910,7 +4301,34 @@ func (h *Hub) GetStats() StringMap {
 	return result
 }
 
+// GetSessions returns the sessions that are connected to this server.
+func (h *Hub) GetSessions() []Session {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return slices.Collect(maps.Values(h.sessions))
+}
+
+// GetRooms returns the rooms that have sessions on this server.
+func (h *Hub) GetRooms() []*Room {
+	h.ru.RLock()
+	defer h.ru.RUnlock()
+	return slices.Collect(maps.Values(h.rooms))
+}
+
+// DisconnectSession closes the session and sends a "bye" with the given
+// reason to its client.
+func (h *Hub) DisconnectSession(session Session, reason string) {
+	if s, ok := session.(*ClientSession); ok {
+		if client := s.GetClient(); client != nil {
+			client.SendByeResponseWithReason(nil, reason)
+		}
+	}
+	session.Close()
+}
+
 func (h *Hub) GetServerInfoDialout() (result []BackendServerInfoDialout) {
+	maxCalls := h.dialoutBalancer.MaxCalls()
+
 	h.mu.RLock()
 	defer h.mu.RUnlock()
 
@@ -2934,6 +4352,8 @@ func (h *Hub) GetServerInfoDialout() (result []BackendServerInfoDialout) {
 			}
 			dialout.Features = session.GetFeatures()
 		}
+		dialout.Calls = h.dialoutBalancer.ActiveCalls(session)
+		dialout.MaxCalls = maxCalls
 		result = append(result, dialout)
 	}
 
@@ -2943,62 +4363,74 @@ func (h *Hub) GetServerInfoDialout() (result []BackendServerInfoDialout) {
 	return
 }
 
-func GetRealUserIP(r *http.Request, trusted *AllowedIps) string {
-	addr := r.RemoteAddr
-	if host, _, err := net.SplitHostPort(addr); err == nil {
-		addr = host
+// parseUserIP parses an IP address that may contain a port, be enclosed in
+// brackets (IPv6) or have a zone.
+func parseUserIP(s string) net.IP {
+	s = strings.TrimSpace(s)
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		s = host
+	} else if len(s) > 1 && s[0] == '[' && s[len(s)-1] == ']' {
+		s = s[1 : len(s)-1]
+	}
+	if pos := strings.IndexByte(s, '%'); pos != -1 {
+		s = s[:pos]
 	}
 
-	ip := net.ParseIP(addr)
+	return net.ParseIP(s)
+}
+
+// GetRealUserIP returns the IP address of the client that sent the request.
+// The headers "X-Real-IP" and "X-Forwarded-For" are only evaluated if the
+// request was received from a trusted proxy. IP addresses are returned in
+// their canonical form, i.e. IPv4-mapped IPv6 addresses are returned as IPv4.
+func GetRealUserIP(r *http.Request, trusted *AllowedIps) string {
+	ip := parseUserIP(r.RemoteAddr)
 	if len(ip) == 0 {
-		return addr
+		return r.RemoteAddr
 	}
 
 	// Don't check any headers if the server can be reached by untrusted clients directly.
 	if trusted == nil || !trusted.Allowed(ip) {
-		return addr
+		return ip.String()
 	}
 
-	if realIP := r.Header.Get("X-Real-IP"); realIP != "" {
-		if ip := net.ParseIP(realIP); len(ip) > 0 {
-			return realIP
-		}
+	if realIP := parseUserIP(r.Header.Get("X-Real-IP")); len(realIP) > 0 {
+		return realIP.String()
 	}
 
 	// See https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/X-Forwarded-For#selecting_an_ip_address
 	forwarded := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
 	if len(forwarded) > 0 {
 		slices.Reverse(forwarded)
-		var lastTrusted string
+		var lastTrusted net.IP
 		for _, hop := range forwarded {
-			hop = strings.TrimSpace(hop)
-			// Make sure to remove any port.
-			if host, _, err := net.SplitHostPort(hop); err == nil {
-				hop = host
-			}
-
-			ip := net.ParseIP(hop)
+			ip := parseUserIP(hop)
 			if len(ip) == 0 {
 				continue
 			}
 
 			if trusted.Allowed(ip) {
-				lastTrusted = hop
+				lastTrusted = ip
 				continue
 			}
 
-			return hop
+			return ip.String()
 		}
 
 		// If all entries in the "X-Forwarded-For" list are trusted, the left-most
 		// will be the client IP. This can happen if a subnet is trusted and the
 		// client also has an IP from this subnet.
-		if lastTrusted != "" {
-			return lastTrusted
+		if len(lastTrusted) > 0 {
+			return lastTrusted.String()
 		}
 	}
 
-	return addr
+	return ip.String()
+}
+
+// GetTrustedProxies returns the proxies that may pass the address of clients.
+func (h *Hub) GetTrustedProxies() *AllowedIps {
+	return h.trustedProxies.Load()
 }
 
 func (h *Hub) getRealUserIP(r *http.Request) string {
@@ -3015,15 +4447,23 @@ func (h *Hub) serveWs(w http.ResponseWriter, r *http.Request) {
 
 	conn, err := h.upgrader.Upgrade(w, r, header)
 	if err != nil {
-		log.Printf("Could not upgrade request from %s: %s", addr, err)
+		statsClientWebsocketHandshakeFailures.WithLabelValues(getWebsocketHandshakeFailureReason(err)).Inc()
+		hubLog.Errorf("Could not upgrade request from %s: %s", addr, err)
 		return
 	}
 
+	compress := h.compression.Acquire(r)
+	if compress {
+		defer h.compression.Release()
+	}
+
 	client, err := NewClient(r.Context(), conn, addr, agent, h)
 	if err != nil {
-		log.Printf("Could not create client for %s: %s", addr, err)
+		hubLog.Errorf("Could not create client for %s: %s", addr, err)
 		return
 	}
+	h.compression.Configure(client, conn, compress)
+	client.SetKeepalive(h.keepalive.pingInterval, h.keepalive.pongTimeout)
 
 	h.processNewClient(client)
 	go func(h *Hub) {
@@ -3034,6 +4474,7 @@ func (h *Hub) serveWs(w http.ResponseWriter, r *http.Request) {
 
 	h.readPumpActive.Add(1)
 	defer h.readPumpActive.Add(-1)
+	defer reportPanic(LogSubsystemHub, client.GetSession)
 	client.ReadPump()
 }
 
@@ -3043,12 +4484,26 @@ func (h *Hub) OnLookupCountry(client HandlerClient) string {
 		return noCountry
 	}
 
-	if overrides := h.geoipOverrides.Load(); overrides != nil {
-		for overrideNet, country := range *overrides {
-			if overrideNet.Contains(ip) {
-				return country
-			}
-		}
+	return h.lookupCountry(ip)
+}
+
+// GetGeoIPLookup returns the country of an IP address and the proxies that
+// would be used for publishers from it.
+func (h *Hub) GetGeoIPLookup(ip net.IP) *BackendServerGeoIPLookup {
+	result := &BackendServerGeoIPLookup{
+		IP:      ip.String(),
+		Country: h.lookupCountry(ip),
+	}
+	if proxy, ok := h.mcu.(*mcuProxy); ok {
+		result.Continents = proxy.GetContinentsForCountry(result.Country)
+		result.Proxies = proxy.GetProxiesForCountry(result.Country)
+	}
+	return result
+}
+
+func (h *Hub) lookupCountry(ip net.IP) string {
+	if country, found := h.geoipOverrides.Lookup(ip); found {
+		return country
 	}
 
 	if ip.IsLoopback() {
@@ -3056,11 +4511,11 @@ func (h *Hub) OnLookupCountry(client HandlerClient) string {
 	}
 
 	country := unknownCountry
-	if h.geoip != nil {
+	if geoip := h.geoip.Load(); geoip != nil {
 		var err error
-		country, err = h.geoip.LookupCountry(ip)
+		country, err = geoip.LookupCountry(ip)
 		if err != nil {
-			log.Printf("Could not lookup country for %s: %s", ip, err)
+			hubLog.Errorf("Could not lookup country for %s: %s", ip, err)
 			return unknownCountry
 		}
 
@@ -3076,6 +4531,7 @@ func (h *Hub) OnClosed(client HandlerClient) {
 }
 
 func (h *Hub) OnMessageReceived(client HandlerClient, data []byte) {
+	h.load.MessageReceived()
 	h.processMessage(client, data)
 }
 
@@ -3091,6 +4547,114 @@ func (h *Hub) IsShutdownScheduled() bool {
 	return h.shutdownScheduled.Load()
 }
 
+func getDrainSpread(config *goconf.ConfigFile) time.Duration {
+	spread := defaultDrainSpread
+	if value, err := config.GetInt("app", "drainspread"); err == nil && value >= 0 {
+		spread = time.Duration(value) * time.Second
+	}
+	return spread
+}
+
+func getDrainTimeout(config *goconf.ConfigFile) time.Duration {
+	timeout := defaultDrainTimeout
+	if value, err := config.GetInt("app", "draintimeout"); err == nil && value > 0 {
+		timeout = time.Duration(value) * time.Second
+	}
+	return timeout
+}
+
+// getDrainRetryAfter returns a random number of seconds a client should wait
+// before reconnecting, so the clients of a draining server don't all connect
+// to the remaining servers at the same time.
+func (h *Hub) getDrainRetryAfter() int {
+	seconds := int(time.Duration(h.drainSpread.Load()) / time.Second)
+	if seconds <= 0 {
+		return 0
+	}
+
+	return rand.IntN(seconds + 1)
+}
+
+func (h *Hub) newDrainingError() *Error {
+	return NewErrorDetail("server_draining", "The server is draining, please connect to a different server.", &DrainingErrorDetails{
+		RetryAfter: h.getDrainRetryAfter(),
+	})
+}
+
+// DrainClients notifies all connected clients that the server is going away
+// and closes their sessions, so they can reconnect to a different server.
+func (h *Hub) DrainClients() {
+	h.mu.RLock()
+	clients := make([]HandlerClient, 0, len(h.clients))
+	for _, client := range h.clients {
+		clients = append(clients, client)
+	}
+	h.mu.RUnlock()
+
+	if len(clients) > 0 {
+		hubLog.Infof("Draining %d clients", len(clients))
+	}
+	for _, client := range clients {
+		bye := NewByeServerMessage(ByeReasonDraining)
+		bye.RetryAfter = h.getDrainRetryAfter()
+		client.SendMessage(&ServerMessage{
+			Type: "bye",
+			Bye:  bye,
+		})
+	}
+}
+
+// Drain stops accepting new sessions and asks all connected clients to
+// reconnect to a different server of the cluster. The shutdown channel is
+// closed once all sessions have left the server. Returns false if the server
+// is already draining.
+func (h *Hub) Drain() bool {
+	if !h.draining.CompareAndSwap(false, true) {
+		return false
+	}
+
+	timeout := time.Duration(h.drainTimeout.Load())
+	hubLog.Infof("Draining server, no longer accepting new sessions")
+	h.ScheduleShutdown()
+	time.AfterFunc(timeout, func() {
+		if !h.shutdown.IsClosed() {
+			hubLog.Warnf("Not all sessions left the server after %s, shutting down", timeout)
+			h.shutdown.Close()
+		}
+	})
+	h.DrainClients()
+
+	// Sessions without a connection can't be resumed while draining, so there
+	// is no need to wait for them to expire.
+	h.mu.RLock()
+	expired := slices.Collect(maps.Keys(h.expiredSessions))
+	h.mu.RUnlock()
+	for _, session := range expired {
+		hubLog.Infof("Closing disconnected session %s while draining", session.PublicId())
+		session.Close()
+	}
+	return true
+}
+
+func (h *Hub) IsDraining() bool {
+	return h.draining.Load()
+}
+
+func (h *Hub) GetDrainStatus() *BackendServerDrainStatus {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	result := &BackendServerDrainStatus{
+		Draining: h.IsDraining(),
+	}
+	for _, s := range h.sessions {
+		if s.ClientType() != HelloClientTypeInternal {
+			result.Sessions++
+		}
+	}
+	return result
+}
+
 func (h *Hub) ScheduleShutdown() {
 	if !h.shutdownScheduled.CompareAndSwap(false, true) {
 		return
@@ -25,6 +25,13 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+const (
+	// The client sent a "hello" to create a new session.
+	statsConnectTypeHello = "hello"
+	// The client resumed an existing session.
+	statsConnectTypeResume = "resume"
+)
+
 var (
 	statsHubRoomsCurrent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: "signaling",
@@ -56,12 +63,92 @@ var (
 		Name:      "sessions_resume_failed_total",
 		Help:      "The total number of failed session resume requests",
 	})
+	statsHubSessionDurationSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "signaling",
+		Subsystem: "hub",
+		Name:      "session_duration_seconds",
+		Help:      "The lifetime of client sessions in seconds",
+		Buckets:   prometheus.ExponentialBucketsRange(1, 24*60*60, 16),
+	}, []string{"backend", "clienttype"})
+	statsHubClientConnectsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "signaling",
+		Subsystem: "hub",
+		Name:      "client_connects_total",
+		Help:      "The total number of clients that connected to a session",
+	}, []string{"backend", "type"})
+	statsHubClientDisconnectsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "signaling",
+		Subsystem: "hub",
+		Name:      "client_disconnects_total",
+		Help:      "The total number of clients that disconnected from a session",
+	}, []string{"backend", "type"})
+	statsHubMessageLatencySeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "signaling",
+		Subsystem: "hub",
+		Name:      "message_latency_seconds",
+		Help:      "The time between receiving and delivering messages of clients",
+		Buckets:   prometheus.ExponentialBucketsRange(0.0001, 10, 20),
+	}, []string{"backend", "type", "delivery"})
+	statsHubDialoutCallsCurrent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "signaling",
+		Subsystem: "hub",
+		Name:      "dialout_calls",
+		Help:      "The current number of active dialout calls per backend",
+	}, []string{"backend"})
+	statsHubDialoutRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "signaling",
+		Subsystem: "hub",
+		Name:      "dialout_requests_total",
+		Help:      "The total number of dialout requests per backend and result",
+	}, []string{"backend", "result"})
+	statsHubRecordingsCurrent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "signaling",
+		Subsystem: "hub",
+		Name:      "recordings",
+		Help:      "The current number of active recordings per backend",
+	}, []string{"backend"})
+	statsHubRecordingFailoversTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "signaling",
+		Subsystem: "hub",
+		Name:      "recording_failovers_total",
+		Help:      "The total number of recordings moved to a different client per backend and result",
+	}, []string{"backend", "result"})
+	statsHubCaptionsThrottledTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "signaling",
+		Subsystem: "hub",
+		Name:      "captions_throttled_total",
+		Help:      "The total number of interim captions dropped because they were sent too fast per backend",
+	}, []string{"backend"})
+	statsHubInternalHeartbeatFailuresTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "signaling",
+		Subsystem: "hub",
+		Name:      "internal_heartbeat_failures_total",
+		Help:      "The total number of internal clients that stopped sending heartbeats per backend and cleanup",
+	}, []string{"backend", "cleanup"})
+	statsHubLoadScore = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "signaling",
+		Subsystem: "hub",
+		Name:      "load_score",
+		Help:      "The load of the server in percent of the configured capacity minus the headroom",
+	})
 
 	hubStats = []prometheus.Collector{
 		statsHubRoomsCurrent,
 		statsHubSessionsCurrent,
 		statsHubSessionsTotal,
+		statsHubSessionsResumedTotal,
 		statsHubSessionResumeFailed,
+		statsHubMessageLatencySeconds,
+		statsHubSessionDurationSeconds,
+		statsHubClientConnectsTotal,
+		statsHubClientDisconnectsTotal,
+		statsHubDialoutCallsCurrent,
+		statsHubDialoutRequestsTotal,
+		statsHubRecordingsCurrent,
+		statsHubRecordingFailoversTotal,
+		statsHubCaptionsThrottledTotal,
+		statsHubInternalHeartbeatFailuresTotal,
+		statsHubLoadScore,
 	}
 )
 
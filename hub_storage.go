@@ -0,0 +1,107 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2019 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dlintw/goconf"
+)
+
+const (
+	HubStorageTypeMemory = "memory"
+	HubStorageTypeEtcd   = "etcd"
+
+	DefaultHubStorageType = HubStorageTypeMemory
+)
+
+var (
+	ErrNoSuchStoredSession = errors.New("unknown session")
+)
+
+// HubStorageSession is the information on a session and the room it joined
+// that is kept in the hub storage.
+type HubStorageSession struct {
+	PublicId   PublicSessionId `json:"publicid"`
+	Node       string          `json:"node"`
+	Created    time.Time       `json:"created"`
+	BackendId  string          `json:"backendid"`
+	ClientType ClientType      `json:"clienttype"`
+	UserId     string          `json:"userid,omitempty"`
+
+	RoomId string `json:"roomid,omitempty"`
+}
+
+// HubStorage keeps track of the sessions and their rooms. The default storage
+// only knows about sessions of the local server, other implementations share
+// the information between all servers of a cluster.
+type HubStorage interface {
+	Close()
+
+	// SetSession adds or updates a session of the local server. Changes are
+	// applied asynchronously.
+	SetSession(session *HubStorageSession)
+	// RemoveSession removes a session of the local server. Changes are applied
+	// asynchronously.
+	RemoveSession(sessionId PublicSessionId)
+
+	GetSession(ctx context.Context, sessionId PublicSessionId) (*HubStorageSession, error)
+	GetRoomSessions(ctx context.Context, backendId string, roomId string) ([]*HubStorageSession, error)
+}
+
+func NewHubStorage(config *goconf.ConfigFile, etcdClient *EtcdClient, node string) (HubStorage, error) {
+	storageType, _ := config.GetString("sessions", "storage")
+	if storageType == "" {
+		storageType = DefaultHubStorageType
+	}
+
+	switch storageType {
+	case HubStorageTypeMemory:
+		return NewHubStorageMemory()
+	case HubStorageTypeEtcd:
+		return NewHubStorageEtcd(config, etcdClient, node)
+	default:
+		return nil, fmt.Errorf("unknown session storage type: %s", storageType)
+	}
+}
+
+func newHubStorageSession(session Session, node string, room *Room) *HubStorageSession {
+	result := &HubStorageSession{
+		PublicId:   session.PublicId(),
+		Node:       node,
+		ClientType: session.ClientType(),
+		UserId:     session.UserId(),
+	}
+	if backend := session.Backend(); backend != nil {
+		result.BackendId = backend.Id()
+	}
+	if data := session.Data(); data != nil && data.Created != nil {
+		result.Created = data.Created.AsTime()
+	}
+	if room != nil {
+		result.RoomId = room.Id()
+	}
+	return result
+}
@@ -0,0 +1,355 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2019 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dlintw/goconf"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const (
+	DefaultHubStoragePrefix = "/signaling/hub"
+
+	// Sessions of a server are removed from etcd if it doesn't refresh its
+	// lease within this time.
+	hubStorageEtcdLeaseTTL = 30 // seconds
+
+	hubStorageEtcdRequestTimeout = time.Second
+)
+
+// hubStorageEtcd stores the sessions and their rooms in etcd so they can be
+// looked up by other servers. The entries of a server are attached to a lease,
+// so they are removed automatically if the server stops.
+type hubStorageEtcd struct {
+	etcdClient *EtcdClient
+	keyPrefix  string
+	node       string
+
+	closeCtx  context.Context
+	closeFunc context.CancelFunc
+	wg        sync.WaitGroup
+
+	wakeupCh chan struct{}
+
+	mu sync.Mutex
+	// Sessions of the local server.
+	sessions map[PublicSessionId]*HubStorageSession
+	// Sessions that need to be updated in etcd.
+	dirty map[PublicSessionId]bool
+
+	// Only accessed from the update goroutine.
+	storedRoomKeys map[PublicSessionId]string
+}
+
+func NewHubStorageEtcd(config *goconf.ConfigFile, etcdClient *EtcdClient, node string) (HubStorage, error) {
+	if etcdClient == nil || !etcdClient.IsConfigured() {
+		return nil, fmt.Errorf("no etcd endpoints configured")
+	}
+
+	keyPrefix, _ := config.GetString("sessions", "storageprefix")
+	if keyPrefix == "" {
+		keyPrefix = DefaultHubStoragePrefix
+	}
+	keyPrefix = strings.TrimSuffix(keyPrefix, "/")
+	if node == "" {
+		return nil, fmt.Errorf("no node id configured")
+	}
+
+	closeCtx, closeFunc := context.WithCancel(context.Background())
+	result := &hubStorageEtcd{
+		etcdClient: etcdClient,
+		keyPrefix:  keyPrefix,
+		node:       node,
+
+		closeCtx:  closeCtx,
+		closeFunc: closeFunc,
+
+		wakeupCh: make(chan struct{}, 1),
+
+		sessions:       make(map[PublicSessionId]*HubStorageSession),
+		dirty:          make(map[PublicSessionId]bool),
+		storedRoomKeys: make(map[PublicSessionId]string),
+	}
+
+	etcdClient.AddListener(result)
+	return result, nil
+}
+
+func (s *hubStorageEtcd) Close() {
+	s.etcdClient.RemoveListener(s)
+	s.closeFunc()
+	s.wg.Wait()
+}
+
+func (s *hubStorageEtcd) getSessionKey(sessionId PublicSessionId) string {
+	return s.keyPrefix + "/sessions/" + string(sessionId)
+}
+
+func (s *hubStorageEtcd) getRoomPrefix(backendId string, roomId string) string {
+	return s.keyPrefix + "/rooms/" + url.PathEscape(backendId) + "/" + url.PathEscape(roomId) + "/"
+}
+
+func (s *hubStorageEtcd) wakeup() {
+	select {
+	case s.wakeupCh <- struct{}{}:
+	default:
+	}
+}
+
+func (s *hubStorageEtcd) SetSession(session *HubStorageSession) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions[session.PublicId] = session
+	s.dirty[session.PublicId] = true
+	s.wakeup()
+}
+
+func (s *hubStorageEtcd) RemoveSession(sessionId PublicSessionId) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, found := s.sessions[sessionId]; !found {
+		return
+	}
+
+	delete(s.sessions, sessionId)
+	s.dirty[sessionId] = true
+	s.wakeup()
+}
+
+func (s *hubStorageEtcd) GetSession(ctx context.Context, sessionId PublicSessionId) (*HubStorageSession, error) {
+	response, err := s.etcdClient.Get(ctx, s.getSessionKey(sessionId))
+	if err != nil {
+		return nil, err
+	} else if len(response.Kvs) == 0 {
+		return nil, ErrNoSuchStoredSession
+	}
+
+	var session HubStorageSession
+	if err := json.Unmarshal(response.Kvs[0].Value, &session); err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (s *hubStorageEtcd) GetRoomSessions(ctx context.Context, backendId string, roomId string) ([]*HubStorageSession, error) {
+	response, err := s.etcdClient.Get(ctx, s.getRoomPrefix(backendId, roomId), clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*HubStorageSession, 0, len(response.Kvs))
+	for _, kv := range response.Kvs {
+		var session HubStorageSession
+		if err := json.Unmarshal(kv.Value, &session); err != nil {
+			hubLog.Warnf("Could not decode stored session %s: %s", string(kv.Key), err)
+			continue
+		}
+
+		result = append(result, &session)
+	}
+	return result, nil
+}
+
+func (s *hubStorageEtcd) EtcdClientCreated(client *EtcdClient) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		if err := client.WaitForConnection(s.closeCtx); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+
+			panic(err)
+		}
+
+		backoff, err := NewExponentialBackoff(initialWaitDelay, maxWaitDelay)
+		if err != nil {
+			panic(err)
+		}
+		for s.closeCtx.Err() == nil {
+			lease, keepalive, err := s.grantLease(client)
+			if err != nil {
+				if errors.Is(err, context.Canceled) {
+					return
+				}
+
+				hubLog.Errorf("Could not create lease for session storage, retry in %s: %s", backoff.NextWait(), err)
+				backoff.Wait(s.closeCtx)
+				continue
+			}
+
+			backoff.Reset()
+			s.processUpdates(client, lease, keepalive)
+		}
+	}()
+}
+
+func (s *hubStorageEtcd) grantLease(client *EtcdClient) (clientv3.LeaseID, <-chan *clientv3.LeaseKeepAliveResponse, error) {
+	ctx, cancel := context.WithTimeout(s.closeCtx, hubStorageEtcdRequestTimeout)
+	defer cancel()
+
+	response, err := client.Grant(ctx, hubStorageEtcdLeaseTTL)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	keepalive, err := client.KeepAlive(s.closeCtx, response.ID)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return response.ID, keepalive, nil
+}
+
+// processUpdates writes changed sessions to etcd until the lease is lost or
+// the storage is closed.
+func (s *hubStorageEtcd) processUpdates(client *EtcdClient, lease clientv3.LeaseID, keepalive <-chan *clientv3.LeaseKeepAliveResponse) {
+	// All entries of a previous lease are gone, write all sessions again.
+	clear(s.storedRoomKeys)
+	s.mu.Lock()
+	for sessionId := range s.sessions {
+		s.dirty[sessionId] = true
+	}
+	s.mu.Unlock()
+
+	retry := time.NewTimer(0)
+	defer retry.Stop()
+	for {
+		select {
+		case <-s.closeCtx.Done():
+			ctx, cancel := context.WithTimeout(context.Background(), hubStorageEtcdRequestTimeout)
+			defer cancel()
+			if _, err := client.Revoke(ctx, lease); err != nil {
+				hubLog.Warnf("Could not revoke lease of session storage: %s", err)
+			}
+			return
+		case _, ok := <-keepalive:
+			if !ok {
+				hubLog.Warn("Lease of session storage expired, creating new")
+				return
+			}
+		case <-s.wakeupCh:
+			if !s.flush(client, lease) {
+				retry.Reset(time.Second)
+			}
+		case <-retry.C:
+			if !s.flush(client, lease) {
+				retry.Reset(time.Second)
+			}
+		}
+	}
+}
+
+func (s *hubStorageEtcd) flush(client *EtcdClient, lease clientv3.LeaseID) bool {
+	s.mu.Lock()
+	dirty := s.dirty
+	s.dirty = make(map[PublicSessionId]bool)
+	sessions := make(map[PublicSessionId]*HubStorageSession, len(dirty))
+	for sessionId := range dirty {
+		sessions[sessionId] = s.sessions[sessionId]
+	}
+	s.mu.Unlock()
+
+	var failed []PublicSessionId
+	for sessionId, session := range sessions {
+		if err := s.update(client, lease, sessionId, session); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return true
+			}
+
+			hubLog.Errorf("Could not update session %s in storage: %s", sessionId, err)
+			failed = append(failed, sessionId)
+		}
+	}
+
+	if len(failed) == 0 {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sessionId := range failed {
+		s.dirty[sessionId] = true
+	}
+	return false
+}
+
+func (s *hubStorageEtcd) update(client *EtcdClient, lease clientv3.LeaseID, sessionId PublicSessionId, session *HubStorageSession) error {
+	ctx, cancel := context.WithTimeout(s.closeCtx, hubStorageEtcdRequestTimeout)
+	defer cancel()
+
+	// The session key is written last, so the room entries are up to date once
+	// it has been updated.
+	prevRoomKey := s.storedRoomKeys[sessionId]
+	if session == nil {
+		if prevRoomKey != "" {
+			if _, err := client.Delete(ctx, prevRoomKey); err != nil {
+				return err
+			}
+			delete(s.storedRoomKeys, sessionId)
+		}
+		if _, err := client.Delete(ctx, s.getSessionKey(sessionId)); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	var roomKey string
+	if session.RoomId != "" {
+		roomKey = s.getRoomPrefix(session.BackendId, session.RoomId) + string(sessionId)
+	}
+	if prevRoomKey != "" && prevRoomKey != roomKey {
+		if _, err := client.Delete(ctx, prevRoomKey); err != nil {
+			return err
+		}
+		delete(s.storedRoomKeys, sessionId)
+	}
+	if roomKey != "" {
+		if _, err := client.Put(ctx, roomKey, string(data), clientv3.WithLease(lease)); err != nil {
+			return err
+		}
+		s.storedRoomKeys[sessionId] = roomKey
+	}
+
+	if _, err := client.Put(ctx, s.getSessionKey(sessionId), string(data), clientv3.WithLease(lease)); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2019 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dlintw/goconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func (s *hubStorageEtcd) waitForUpdates(t *testing.T) {
+	assert.Eventually(t, func() bool {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		response, err := s.etcdClient.Get(ctx, s.keyPrefix+"/sessions/", clientv3.WithPrefix())
+		if err != nil {
+			return false
+		}
+
+		// The stored sessions must match the local sessions.
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if len(response.Kvs) != len(s.sessions) {
+			return false
+		}
+		for _, kv := range response.Kvs {
+			var stored HubStorageSession
+			if err := json.Unmarshal(kv.Value, &stored); err != nil {
+				return false
+			}
+			if session, found := s.sessions[stored.PublicId]; !found || !reflect.DeepEqual(session, &stored) {
+				return false
+			}
+		}
+		return true
+	}, testTimeout, 10*time.Millisecond)
+}
+
+func TestHubStorageEtcd(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	_, client := NewEtcdClientForTest(t)
+
+	config := goconf.NewConfigFile()
+	config.AddOption("sessions", "storage", HubStorageTypeEtcd)
+	config.AddOption("sessions", "storageprefix", "/test/hub/")
+
+	s, err := NewHubStorage(config, client, "node1")
+	require.NoError(err)
+	storage := s.(*hubStorageEtcd)
+	assert.Equal("/test/hub", storage.keyPrefix)
+
+	testHubStorage(t, storage, func() {
+		storage.waitForUpdates(t)
+	})
+
+	// Sessions of a server are visible to others and removed when it stops.
+	s2, err := NewHubStorage(config, client, "node2")
+	require.NoError(err)
+	defer s2.Close()
+
+	session := &HubStorageSession{
+		PublicId:   "session1",
+		Node:       "node1",
+		Created:    time.Now().Truncate(time.Second).UTC(),
+		BackendId:  "backend1",
+		ClientType: HelloClientTypeClient,
+		RoomId:     "room1",
+	}
+	storage.SetSession(session)
+	storage.waitForUpdates(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	if stored, err := s2.GetSession(ctx, "session1"); assert.NoError(err) {
+		assert.Equal(session, stored)
+	}
+	if sessions, err := s2.GetRoomSessions(ctx, "backend1", "room1"); assert.NoError(err) {
+		assert.Equal([]*HubStorageSession{session}, sessions)
+	}
+
+	storage.Close()
+	_, err = s2.GetSession(ctx, "session1")
+	assert.ErrorIs(err, ErrNoSuchStoredSession)
+	if sessions, err := s2.GetRoomSessions(ctx, "backend1", "room1"); assert.NoError(err) {
+		assert.Empty(sessions)
+	}
+}
+
+func TestHubStorageEtcdNotConfigured(t *testing.T) {
+	t.Parallel()
+	config := goconf.NewConfigFile()
+	config.AddOption("sessions", "storage", HubStorageTypeEtcd)
+	_, err := NewHubStorage(config, nil, "node1")
+	assert.Error(t, err)
+
+	config.AddOption("sessions", "storage", "unknown")
+	_, err = NewHubStorage(config, nil, "node1")
+	assert.Error(t, err)
+}
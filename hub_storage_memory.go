@@ -0,0 +1,135 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2019 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"sync"
+)
+
+type hubStorageRoomKey struct {
+	backendId string
+	roomId    string
+}
+
+type hubStorageMemory struct {
+	mu       sync.RWMutex
+	sessions map[PublicSessionId]*HubStorageSession
+	rooms    map[hubStorageRoomKey]map[PublicSessionId]*HubStorageSession
+}
+
+func NewHubStorageMemory() (HubStorage, error) {
+	return newHubStorageMemory(), nil
+}
+
+func newHubStorageMemory() *hubStorageMemory {
+	return &hubStorageMemory{
+		sessions: make(map[PublicSessionId]*HubStorageSession),
+		rooms:    make(map[hubStorageRoomKey]map[PublicSessionId]*HubStorageSession),
+	}
+}
+
+func (s *hubStorageMemory) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clear(s.sessions)
+	clear(s.rooms)
+}
+
+func (s *hubStorageMemory) removeFromRoomLocked(session *HubStorageSession) {
+	if session.RoomId == "" {
+		return
+	}
+
+	key := hubStorageRoomKey{
+		backendId: session.BackendId,
+		roomId:    session.RoomId,
+	}
+	if sessions, found := s.rooms[key]; found {
+		delete(sessions, session.PublicId)
+		if len(sessions) == 0 {
+			delete(s.rooms, key)
+		}
+	}
+}
+
+func (s *hubStorageMemory) SetSession(session *HubStorageSession) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if prev, found := s.sessions[session.PublicId]; found {
+		s.removeFromRoomLocked(prev)
+	}
+
+	s.sessions[session.PublicId] = session
+	if session.RoomId != "" {
+		key := hubStorageRoomKey{
+			backendId: session.BackendId,
+			roomId:    session.RoomId,
+		}
+		sessions, found := s.rooms[key]
+		if !found {
+			sessions = make(map[PublicSessionId]*HubStorageSession)
+			s.rooms[key] = sessions
+		}
+		sessions[session.PublicId] = session
+	}
+}
+
+func (s *hubStorageMemory) RemoveSession(sessionId PublicSessionId) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if prev, found := s.sessions[sessionId]; found {
+		delete(s.sessions, sessionId)
+		s.removeFromRoomLocked(prev)
+	}
+}
+
+func (s *hubStorageMemory) GetSession(ctx context.Context, sessionId PublicSessionId) (*HubStorageSession, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	session, found := s.sessions[sessionId]
+	if !found {
+		return nil, ErrNoSuchStoredSession
+	}
+
+	return session, nil
+}
+
+func (s *hubStorageMemory) GetRoomSessions(ctx context.Context, backendId string, roomId string) ([]*HubStorageSession, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key := hubStorageRoomKey{
+		backendId: backendId,
+		roomId:    roomId,
+	}
+	sessions := s.rooms[key]
+	result := make([]*HubStorageSession, 0, len(sessions))
+	for _, session := range sessions {
+		result = append(result, session)
+	}
+	return result, nil
+}
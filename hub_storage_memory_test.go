@@ -0,0 +1,115 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2019 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testHubStorage(t *testing.T, storage HubStorage, waitForUpdate func()) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	_, err := storage.GetSession(ctx, "session1")
+	assert.ErrorIs(err, ErrNoSuchStoredSession)
+
+	session1 := &HubStorageSession{
+		PublicId:   "session1",
+		Node:       "node1",
+		Created:    time.Now().Truncate(time.Second).UTC(),
+		BackendId:  "backend1",
+		ClientType: HelloClientTypeClient,
+		UserId:     "user1",
+	}
+	storage.SetSession(session1)
+	waitForUpdate()
+
+	if stored, err := storage.GetSession(ctx, "session1"); assert.NoError(err) {
+		assert.Equal(session1, stored)
+	}
+	if sessions, err := storage.GetRoomSessions(ctx, "backend1", "room1"); assert.NoError(err) {
+		assert.Empty(sessions)
+	}
+
+	joined1 := *session1
+	joined1.RoomId = "room1"
+	storage.SetSession(&joined1)
+	session2 := &HubStorageSession{
+		PublicId:   "session2",
+		Node:       "node1",
+		Created:    time.Now().Truncate(time.Second).UTC(),
+		BackendId:  "backend1",
+		ClientType: HelloClientTypeClient,
+		RoomId:     "room1",
+	}
+	storage.SetSession(session2)
+	waitForUpdate()
+
+	sessions, err := storage.GetRoomSessions(ctx, "backend1", "room1")
+	require.NoError(err)
+	assert.ElementsMatch([]*HubStorageSession{&joined1, session2}, sessions)
+	// Rooms are separate per backend.
+	if sessions, err := storage.GetRoomSessions(ctx, "backend2", "room1"); assert.NoError(err) {
+		assert.Empty(sessions)
+	}
+
+	joined2 := *session1
+	joined2.RoomId = "room2"
+	storage.SetSession(&joined2)
+	waitForUpdate()
+
+	if sessions, err := storage.GetRoomSessions(ctx, "backend1", "room1"); assert.NoError(err) {
+		assert.Equal([]*HubStorageSession{session2}, sessions)
+	}
+	if sessions, err := storage.GetRoomSessions(ctx, "backend1", "room2"); assert.NoError(err) {
+		assert.Equal([]*HubStorageSession{&joined2}, sessions)
+	}
+
+	storage.RemoveSession("session1")
+	storage.RemoveSession("session2")
+	waitForUpdate()
+
+	_, err = storage.GetSession(ctx, "session1")
+	assert.ErrorIs(err, ErrNoSuchStoredSession)
+	for _, roomId := range []string{"room1", "room2"} {
+		if sessions, err := storage.GetRoomSessions(ctx, "backend1", roomId); assert.NoError(err) {
+			assert.Empty(sessions)
+		}
+	}
+}
+
+func TestHubStorageMemory(t *testing.T) {
+	t.Parallel()
+	storage, err := NewHubStorageMemory()
+	require.NoError(t, err)
+	defer storage.Close()
+
+	testHubStorage(t, storage, func() {})
+}
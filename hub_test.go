@@ -35,10 +35,13 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
@@ -49,6 +52,8 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
 	"github.com/nats-io/nats-server/v2/server"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -132,6 +137,16 @@ func getTestConfigWithMultipleBackends(server *httptest.Server) (*goconf.ConfigF
 	return config, nil
 }
 
+func getTestConfigWithDisabledFeatures(server *httptest.Server) (*goconf.ConfigFile, error) {
+	config, err := getTestConfigWithMultipleBackends(server)
+	if err != nil {
+		return nil, err
+	}
+
+	config.AddOption("backend2", "disabledfeatures", "transient-data, virtual-sessions, federation")
+	return config, nil
+}
+
 func getTestConfigWithMultipleUrls(server *httptest.Server) (*goconf.ConfigFile, error) {
 	config, err := getTestConfig(server)
 	if err != nil {
@@ -539,6 +554,35 @@ func storePingRequest(t *testing.T, request *BackendClientRequest) {
 	}
 }
 
+var dialoutRequests sync.Map // *testing.T -> chan *BackendClientDialoutRequest
+
+func getDialoutRequests(t *testing.T) chan *BackendClientDialoutRequest {
+	ch, loaded := dialoutRequests.LoadOrStore(t, make(chan *BackendClientDialoutRequest, 16))
+	if !loaded {
+		t.Cleanup(func() {
+			dialoutRequests.Delete(t)
+		})
+	}
+	return ch.(chan *BackendClientDialoutRequest)
+}
+
+func processDialoutRequest(t *testing.T, w http.ResponseWriter, r *http.Request, request *BackendClientRequest) *BackendClientResponse {
+	if request.Type != "dialout" || request.Dialout == nil {
+		require.Fail(t, "Expected a dialout backend request", "received %+v", request)
+	}
+
+	getDialoutRequests(t) <- request.Dialout
+
+	response := &BackendClientResponse{
+		Type: "dialout",
+		Dialout: &BackendClientDialoutResponse{
+			Version: BackendVersion,
+			RoomId:  request.Dialout.RoomId,
+		},
+	}
+	return response
+}
+
 func processPingRequest(t *testing.T, w http.ResponseWriter, r *http.Request, request *BackendClientRequest) *BackendClientResponse {
 	if request.Type != "ping" || request.Ping == nil {
 		require.Fail(t, "Expected an ping backend request", "received %+v", request)
@@ -677,6 +721,8 @@ func registerBackendHandlerUrl(t *testing.T, router *mux.Router, url string) {
 			return processSessionRequest(t, w, r, request)
 		case "ping":
 			return processPingRequest(t, w, r, request)
+		case "dialout":
+			return processDialoutRequest(t, w, r, request)
 		default:
 			require.Fail(t, "Unsupported request", "received: %+v", request)
 			return nil
@@ -699,6 +745,9 @@ func registerBackendHandlerUrl(t *testing.T, router *mux.Router, url string) {
 		if strings.Contains(t.Name(), "Federation") {
 			features = append(features, "federation-v2")
 		}
+		if strings.Contains(t.Name(), "DialoutStatus") {
+			features = append(features, "dialout-status")
+		}
 		signaling := StringMap{
 			"foo": "bar",
 			"baz": 42,
@@ -834,8 +883,128 @@ func TestInitialWelcome(t *testing.T) {
 		if assert.NotNil(msg.Welcome, "%+v", msg) {
 			assert.NotEmpty(msg.Welcome.Version, "%+v", msg)
 			assert.NotEmpty(msg.Welcome.Features, "%+v", msg)
+			if assert.NotNil(msg.Welcome.Time, "%+v", msg) {
+				assert.InDelta(time.Now().UnixMilli(), msg.Welcome.Time.Now, float64(time.Minute.Milliseconds()))
+				assert.GreaterOrEqual(msg.Welcome.Time.Monotonic, int64(0))
+			}
+		}
+	}
+	// The shared welcome message is not modified.
+	assert.Nil(hub.getWelcomeMessage().Welcome.Time)
+}
+
+func TestDrainClients(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	hub, _, _, server := CreateHubForTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client, _ := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId)
+
+	hub.DrainClients()
+
+	if message, ok := client.RunUntilMessage(ctx); ok {
+		if checkMessageType(t, message, "bye") {
+			assert.Equal(ByeReasonDraining, message.Bye.Reason, "%+v", message.Bye)
+			assert.True(message.Bye.Reconnect, "%+v", message.Bye)
 		}
 	}
+
+	client.RunUntilClosed(ctx)
+}
+
+func TestDrain(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	hub, _, _, server := CreateHubForTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client1, _ := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId+"1")
+	client2, hello2 := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId+"2")
+
+	// The session of a disconnected client is closed when draining.
+	client2.Close()
+	assert.Eventually(func() bool {
+		hub.mu.RLock()
+		defer hub.mu.RUnlock()
+		return len(hub.expiredSessions) == 1
+	}, testTimeout, time.Millisecond)
+
+	require.True(hub.Drain())
+	assert.False(hub.Drain())
+	assert.True(hub.IsDraining())
+
+	if message, ok := client1.RunUntilMessage(ctx); ok {
+		if checkMessageType(t, message, "bye") {
+			assert.Equal(ByeReasonDraining, message.Bye.Reason, "%+v", message.Bye)
+			assert.True(message.Bye.Reconnect, "%+v", message.Bye)
+			assert.GreaterOrEqual(message.Bye.RetryAfter, 0)
+			assert.LessOrEqual(message.Bye.RetryAfter, int(defaultDrainSpread/time.Second))
+		}
+	}
+	client1.RunUntilClosed(ctx)
+
+	select {
+	case <-hub.ShutdownChannel():
+	case <-ctx.Done():
+		assert.Fail("should have shutdown")
+	}
+	assert.Nil(hub.GetSessionByPublicId(hello2.Hello.SessionId))
+
+	// New sessions are rejected while draining.
+	client3 := NewTestClient(t, server, hub)
+	defer client3.CloseWithBye()
+	require.NoError(client3.SendHello(testDefaultUserId + "3"))
+	if message, ok := client3.RunUntilMessage(ctx); ok && checkMessageError(t, message, "server_draining") {
+		var details DrainingErrorDetails
+		if assert.NoError(json.Unmarshal(message.Error.Details, &details)) {
+			assert.LessOrEqual(details.RetryAfter, int(defaultDrainSpread/time.Second))
+		}
+	}
+}
+
+func TestDrainTimeout(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	hub, _, _, _ := CreateHubForTest(t)
+
+	// Sessions that don't leave the server don't prevent the shutdown.
+	session := &ClientSession{
+		hub:        hub,
+		publicId:   "public-session",
+		privateId:  "private-session",
+		clientType: HelloClientTypeClient,
+	}
+	hub.mu.Lock()
+	hub.sessions[1] = session
+	hub.mu.Unlock()
+	defer func() {
+		hub.mu.Lock()
+		delete(hub.sessions, 1)
+		hub.mu.Unlock()
+	}()
+
+	hub.drainTimeout.Store(int64(100 * time.Millisecond))
+	assert.True(hub.Drain())
+	select {
+	case <-hub.ShutdownChannel():
+		assert.Fail("should not have shutdown")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	select {
+	case <-hub.ShutdownChannel():
+	case <-time.After(testTimeout):
+		assert.Fail("should have shutdown")
+	}
 }
 
 func TestExpectClientHello(t *testing.T) {
@@ -859,12 +1028,62 @@ func TestExpectClientHello(t *testing.T) {
 	if message, ok := client.RunUntilMessage(ctx); ok {
 		if checkMessageType(t, message, "bye") {
 			assert.Equal("hello_timeout", message.Bye.Reason, "%+v", message.Bye)
+			assert.True(message.Bye.Reconnect, "%+v", message.Bye)
 		}
 	}
 
 	client.RunUntilClosed(ctx)
 }
 
+func TestClientEcho(t *testing.T) {
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	hub, _, _, server := CreateHubForTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client := NewTestClient(t, server, hub)
+	defer client.CloseWithBye()
+
+	checkEcho := func(timestamp int64, rtt int64) {
+		t.Helper()
+		before := time.Now().UnixMilli()
+		require.NoError(client.WriteJSON(&ClientMessage{
+			Id:   "1234",
+			Type: "echo",
+			Echo: &EchoClientMessage{
+				Timestamp: timestamp,
+				Rtt:       rtt,
+			},
+		}))
+
+		if message, ok := client.RunUntilMessage(ctx); ok && checkMessageType(t, message, "echo") {
+			assert.Equal("1234", message.Id)
+			assert.Equal(timestamp, message.Echo.Timestamp)
+			assert.GreaterOrEqual(message.Echo.Received, before)
+			assert.GreaterOrEqual(message.Echo.Sent, message.Echo.Received)
+			assert.LessOrEqual(message.Echo.Sent, time.Now().UnixMilli())
+		}
+	}
+
+	// Echo messages are answered before the "hello" request.
+	checkEcho(1234, 0)
+
+	require.NoError(client.SendHello(testDefaultUserId))
+	MustSucceed1(t, client.RunUntilHello, ctx)
+
+	// Reported round trip times are collected.
+	var before dto.Metric
+	require.NoError(statsClientRttSeconds.Write(&before))
+	checkEcho(5678, 25)
+	var after dto.Metric
+	require.NoError(statsClientRttSeconds.Write(&after))
+	assert.Equal(before.GetHistogram().GetSampleCount()+1, after.GetHistogram().GetSampleCount())
+	assert.InDelta(before.GetHistogram().GetSampleSum()+0.025, after.GetHistogram().GetSampleSum(), 0.0001)
+}
+
 func TestExpectClientHelloUnsupportedVersion(t *testing.T) {
 	t.Parallel()
 	CatchLogForTest(t)
@@ -903,6 +1122,37 @@ func TestClientHelloV1(t *testing.T) {
 
 	assert.Equal(testDefaultUserId, hello.Hello.UserId, "%+v", hello)
 	assert.NotEmpty(hello.Hello.SessionId, "%+v", hello)
+	if assert.NotNil(hello.Hello.Time, "%+v", hello) {
+		assert.InDelta(time.Now().UnixMilli(), hello.Hello.Time.Now, float64(time.Minute.Milliseconds()))
+	}
+}
+
+func TestClientHelloNegotiatedFeatures(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	hub, _, _, server := CreateHubForTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client := NewTestClient(t, server, hub)
+	defer client.CloseWithBye()
+
+	require.NoError(client.SendHelloClientWithFeatures(testDefaultUserId, []string{
+		ClientFeatureReceipts,
+		ClientFeatureStartDialout,
+		"unknown-feature",
+	}))
+	hello := MustSucceed1(t, client.RunUntilHello, ctx)
+	assert.Equal([]string{ClientFeatureReceipts}, hello.Hello.Features)
+
+	session := hub.GetSessionByPublicId(hello.Hello.SessionId).(*ClientSession)
+	require.NotNil(session, "Session %s does not exist", hello.Hello.SessionId)
+	assert.True(session.HasFeature(ClientFeatureStartDialout))
+	assert.True(session.HasNegotiatedFeature(ClientFeatureReceipts))
+	assert.False(session.HasNegotiatedFeature(ClientFeatureStartDialout))
 }
 
 func TestClientHelloV2(t *testing.T) {
@@ -1094,6 +1344,136 @@ func TestClientHelloV2_CachedCapabilities(t *testing.T) {
 	}
 }
 
+func TestClientHelloV2_StrictValidation(t *testing.T) {
+	CatchLogForTest(t)
+	for _, algo := range testHelloV2Algorithms {
+		t.Run(algo, func(t *testing.T) {
+			hub, _, _, server := CreateHubForTestWithConfig(t, func(server *httptest.Server) (*goconf.ConfigFile, error) {
+				config, err := getTestConfig(server)
+				if err != nil {
+					return nil, err
+				}
+
+				config.AddOption("backend", "tokenaudiences", "signaling, other")
+				config.AddOption("backend", "tokenstrictissuer", "true")
+				config.AddOption("backend", "tokenmaxlifetime", "120")
+				return config, nil
+			})
+
+			ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+			defer cancel()
+
+			now := time.Now()
+			validClaims := func(userid string) *HelloV2TokenClaims {
+				return &HelloV2TokenClaims{
+					RegisteredClaims: jwt.RegisteredClaims{
+						Issuer:    server.URL,
+						Audience:  jwt.ClaimStrings{"signaling"},
+						Subject:   userid,
+						IssuedAt:  jwt.NewNumericDate(now),
+						ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+					},
+				}
+			}
+
+			client1 := NewTestClient(t, server, hub)
+			defer client1.CloseWithBye()
+
+			token, err := client1.CreateHelloV2TokenWithClaims(validClaims(testDefaultUserId + "1"))
+			require.NoError(t, err)
+			require.NoError(t, client1.SendHelloV2WithToken(token))
+			if hello, ok := client1.RunUntilHello(ctx); ok {
+				assert.Equal(t, testDefaultUserId+"1", hello.Hello.UserId, "%+v", hello.Hello)
+			}
+
+			modifiers := map[string]func(claims *HelloV2TokenClaims){
+				"missing audience": func(claims *HelloV2TokenClaims) {
+					claims.Audience = nil
+				},
+				"wrong audience": func(claims *HelloV2TokenClaims) {
+					claims.Audience = jwt.ClaimStrings{"foobar"}
+				},
+				"wrong issuer": func(claims *HelloV2TokenClaims) {
+					claims.Issuer = "https://other.invalid"
+				},
+				"lifetime exceeded": func(claims *HelloV2TokenClaims) {
+					claims.ExpiresAt = jwt.NewNumericDate(now.Add(time.Hour))
+				},
+			}
+			for name, modify := range modifiers {
+				t.Run(name, func(t *testing.T) {
+					client := NewTestClient(t, server, hub)
+					defer client.CloseWithBye()
+
+					claims := validClaims(testDefaultUserId + "2")
+					modify(claims)
+					token, err := client.CreateHelloV2TokenWithClaims(claims)
+					require.NoError(t, err)
+					require.NoError(t, client.SendHelloV2WithToken(token))
+					client.RunUntilError(ctx, InvalidToken.Code) // nolint
+				})
+			}
+		})
+	}
+}
+
+func TestClientHelloV2_Confirmation(t *testing.T) {
+	CatchLogForTest(t)
+	for _, algo := range testHelloV2Algorithms {
+		t.Run(algo, func(t *testing.T) {
+			hub, _, _, server := CreateHubForTest(t)
+
+			ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+			defer cancel()
+
+			key := newHelloV2ProofKeyForTest(t, "ECDSA")
+			now := time.Now()
+			claims := &HelloV2TokenClaims{
+				RegisteredClaims: jwt.RegisteredClaims{
+					Issuer:    server.URL,
+					Subject:   testDefaultUserId,
+					IssuedAt:  jwt.NewNumericDate(now),
+					ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+				},
+				Confirmation: key.Confirmation(t),
+			}
+
+			client1 := NewTestClient(t, server, hub)
+			defer client1.CloseWithBye()
+
+			token, err := client1.CreateHelloV2TokenWithClaims(claims)
+			require.NoError(t, err)
+			proof := key.CreateProof(t, token, now)
+			require.NoError(t, client1.SendHelloV2WithTokenAndProof(token, proof))
+			if hello, ok := client1.RunUntilHello(ctx); ok {
+				assert.Equal(t, testDefaultUserId, hello.Hello.UserId, "%+v", hello.Hello)
+			}
+
+			// Proofs can't be used for multiple connections.
+			client4 := NewTestClient(t, server, hub)
+			defer client4.CloseWithBye()
+
+			require.NoError(t, client4.SendHelloV2WithTokenAndProof(token, proof))
+			client4.RunUntilError(ctx, InvalidToken.Code) // nolint
+
+			// Bound tokens can't be used without proof.
+			client2 := NewTestClient(t, server, hub)
+			defer client2.CloseWithBye()
+
+			require.NoError(t, client2.SendHelloV2WithToken(token))
+			client2.RunUntilError(ctx, InvalidToken.Code) // nolint
+
+			// Bound tokens can't be used with a proof from a different key.
+			client3 := NewTestClient(t, server, hub)
+			defer client3.CloseWithBye()
+
+			other := newHelloV2ProofKeyForTest(t, "ECDSA")
+			require.NoError(t, client3.SendHelloV2WithTokenAndProof(token, other.CreateProof(t, token, now)))
+			client3.RunUntilError(ctx, InvalidToken.Code) // nolint
+		})
+	}
+}
+
 func TestClientHelloWithSpaces(t *testing.T) {
 	t.Parallel()
 	CatchLogForTest(t)
@@ -1232,7 +1612,12 @@ func TestClientHelloSessionLimit(t *testing.T) {
 			}
 			require.NoError(client2.SendHelloParams(server1.URL+"/one", HelloVersionV1, "client", nil, params2))
 
-			client2.RunUntilError(ctx, "session_limit_exceeded") //nolint
+			if err, ok := client2.RunUntilError(ctx, "session_limit_exceeded"); ok {
+				assert.Equal(StringMap{
+					"count": float64(1),
+					"limit": float64(1),
+				}, err.Params)
+			}
 
 			// The client can connect to a different backend.
 			require.NoError(client2.SendHelloParams(server1.URL+"/two", HelloVersionV1, "client", nil, params2))
@@ -1358,6 +1743,79 @@ func TestClientHelloResume(t *testing.T) {
 	}
 }
 
+func TestClientConnectStats(t *testing.T) {
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	hub, _, _, server := CreateHubForTest(t)
+
+	statsHubSessionDurationSeconds.Reset()
+	t.Cleanup(statsHubSessionDurationSeconds.Reset)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client, hello := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId)
+	session := hub.GetSessionByPublicId(hello.Hello.SessionId)
+	require.NotNil(session)
+	backend := statsBackendLabel(session.Backend())
+
+	connectsHello := statsHubClientConnectsTotal.WithLabelValues(backend, statsConnectTypeHello)
+	connectsResume := statsHubClientConnectsTotal.WithLabelValues(backend, statsConnectTypeResume)
+	disconnectsHello := statsHubClientDisconnectsTotal.WithLabelValues(backend, statsConnectTypeHello)
+	disconnectsResume := statsHubClientDisconnectsTotal.WithLabelValues(backend, statsConnectTypeResume)
+	initialConnectsHello := testutil.ToFloat64(connectsHello)
+	initialConnectsResume := testutil.ToFloat64(connectsResume)
+	initialDisconnectsHello := testutil.ToFloat64(disconnectsHello)
+	initialDisconnectsResume := testutil.ToFloat64(disconnectsResume)
+
+	client.Close()
+	assert.NoError(client.WaitForClientRemoved(ctx))
+	checkStatsValue(t, disconnectsHello, initialDisconnectsHello+1)
+	assert.Equal(0, testutil.CollectAndCount(statsHubSessionDurationSeconds))
+
+	client = NewTestClient(t, server, hub)
+	require.NoError(client.SendHelloResume(hello.Hello.ResumeId))
+	MustSucceed1(t, client.RunUntilHello, ctx)
+	checkStatsValue(t, connectsHello, initialConnectsHello)
+	checkStatsValue(t, connectsResume, initialConnectsResume+1)
+
+	client.CloseWithBye()
+	assert.NoError(client.WaitForSessionRemoved(ctx, hello.Hello.SessionId))
+	checkStatsValue(t, disconnectsResume, initialDisconnectsResume+1)
+	assert.Equal(1, testutil.CollectAndCount(statsHubSessionDurationSeconds))
+}
+
+func TestClientHelloStats(t *testing.T) {
+	CatchLogForTest(t)
+	hub, _, _, server := CreateHubForTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	versions := statsClientHelloVersionsTotal.WithLabelValues(HelloVersionV1, string(HelloClientTypeClient))
+	initialVersions := testutil.ToFloat64(versions)
+	client, _ := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId)
+	defer client.CloseWithBye()
+	checkStatsValue(t, versions, initialVersions+1)
+
+	dialout := statsClientHelloFeaturesTotal.WithLabelValues(ClientFeatureStartDialout, string(HelloClientTypeInternal))
+	other := statsClientHelloFeaturesTotal.WithLabelValues("other", string(HelloClientTypeInternal))
+	initialDialout := testutil.ToFloat64(dialout)
+	initialOther := testutil.ToFloat64(other)
+	updateHelloStats(&HelloClientMessage{
+		Version: HelloVersionV2,
+		Features: []string{
+			ClientFeatureStartDialout,
+			"foo",
+			"bar",
+			ClientFeatureStartDialout,
+		},
+	}, HelloClientTypeInternal)
+	checkStatsValue(t, dialout, initialDialout+1)
+	checkStatsValue(t, other, initialOther+1)
+}
+
 func TestClientHelloResumeThrottle(t *testing.T) {
 	t.Parallel()
 	CatchLogForTest(t)
@@ -1948,6 +2406,159 @@ func TestClientMessageToSessionId(t *testing.T) {
 	}
 }
 
+func checkReceiveReceipt(ctx context.Context, t *testing.T, client *TestClient, messageType string, recipient MessageClientMessageRecipient) {
+	t.Helper()
+	assert := assert.New(t)
+	if message, ok := client.RunUntilMessage(ctx); ok && checkMessageType(t, message, "event") {
+		assert.Equal("abcd", message.Id)
+		assert.Equal("session", message.Event.Target)
+		assert.Equal("receipt", message.Event.Type)
+		if assert.NotNil(message.Event.Receipt, "no receipt in %+v", message) {
+			assert.Equal(messageType, message.Event.Receipt.Type)
+			assert.Equal(&recipient, message.Event.Receipt.Recipient)
+			assert.Equal(ReceiptStatusDelivered, message.Event.Receipt.Status)
+		}
+	}
+}
+
+func TestClientMessageReceipts(t *testing.T) {
+	CatchLogForTest(t)
+	for _, subtest := range clusteredTests {
+		t.Run(subtest, func(t *testing.T) {
+			t.Parallel()
+			require := require.New(t)
+			assert := assert.New(t)
+			var hub1 *Hub
+			var hub2 *Hub
+			var server1 *httptest.Server
+			var server2 *httptest.Server
+
+			if isLocalTest(t) {
+				hub1, _, _, server1 = CreateHubForTest(t)
+
+				hub2 = hub1
+				server2 = server1
+			} else {
+				hub1, hub2, server1, server2 = CreateClusteredHubsForTest(t)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+			defer cancel()
+
+			client1 := NewTestClient(t, server1, hub1)
+			defer client1.CloseWithBye()
+			require.NoError(client1.SendHelloParams(server1.URL, HelloVersionV1, "", []string{ClientFeatureReceipts}, TestBackendClientAuthParams{
+				UserId: testDefaultUserId + "1",
+			}))
+			hello1 := MustSucceed1(t, client1.RunUntilHello, ctx)
+			client2, hello2 := NewTestClientWithHello(ctx, t, server2, hub2, testDefaultUserId+"2")
+			defer client2.CloseWithBye()
+
+			recipient1 := MessageClientMessageRecipient{
+				Type:      "session",
+				SessionId: hello1.Hello.SessionId,
+			}
+			recipient2 := MessageClientMessageRecipient{
+				Type:      "session",
+				SessionId: hello2.Hello.SessionId,
+			}
+
+			data1 := "from-1-to-2"
+			require.NoError(client1.SendMessage(recipient2, data1))
+			var payload string
+			if checkReceiveClientMessage(ctx, t, client2, "session", hello1.Hello, &payload) {
+				assert.Equal(data1, payload)
+			}
+			checkReceiveReceipt(ctx, t, client1, "message", recipient2)
+
+			require.NoError(client1.SendControl(recipient2, data1))
+			if checkReceiveClientControl(ctx, t, client2, "session", hello1.Hello, &payload) {
+				assert.Equal(data1, payload)
+			}
+			checkReceiveReceipt(ctx, t, client1, "control", recipient2)
+
+			// Receipts are only sent to clients that support them.
+			data2 := "from-2-to-1"
+			require.NoError(client2.SendMessage(recipient1, data2))
+			if checkReceiveClientMessage(ctx, t, client1, "session", hello2.Hello, &payload) {
+				assert.Equal(data2, payload)
+			}
+
+			ctx2, cancel2 := context.WithTimeout(ctx, 200*time.Millisecond)
+			defer cancel2()
+			client2.RunUntilErrorIs(ctx2, ErrNoMessageReceived, context.DeadlineExceeded)
+		})
+	}
+}
+
+func TestClientMessageToMultipleSessions(t *testing.T) {
+	CatchLogForTest(t)
+	for _, subtest := range clusteredTests {
+		t.Run(subtest, func(t *testing.T) {
+			t.Parallel()
+			require := require.New(t)
+			assert := assert.New(t)
+			var hub1 *Hub
+			var hub2 *Hub
+			var server1 *httptest.Server
+			var server2 *httptest.Server
+
+			if isLocalTest(t) {
+				hub1, _, _, server1 = CreateHubForTest(t)
+
+				hub2 = hub1
+				server2 = server1
+			} else {
+				hub1, hub2, server1, server2 = CreateClusteredHubsForTest(t)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+			defer cancel()
+
+			client1, hello1 := NewTestClientWithHello(ctx, t, server1, hub1, testDefaultUserId+"1")
+			defer client1.CloseWithBye()
+			client2, hello2 := NewTestClientWithHello(ctx, t, server1, hub1, testDefaultUserId+"2")
+			defer client2.CloseWithBye()
+			client3, hello3 := NewTestClientWithHello(ctx, t, server2, hub2, testDefaultUserId+"3")
+			defer client3.CloseWithBye()
+
+			recipient := MessageClientMessageRecipient{
+				Type: "sessions",
+				SessionIds: []PublicSessionId{
+					hello2.Hello.SessionId,
+					hello3.Hello.SessionId,
+					hello2.Hello.SessionId,
+				},
+			}
+
+			data := "from-1-to-many"
+			require.NoError(client1.SendMessage(recipient, data))
+			var payload string
+			var received *MessageClientMessageRecipient
+			if checkReceiveClientMessageWithSenderAndRecipient(ctx, t, client2, "session", hello1.Hello, &payload, nil, &received) {
+				assert.Equal(data, payload)
+				assert.Nil(received)
+			}
+			if checkReceiveClientMessage(ctx, t, client3, "session", hello1.Hello, &payload) {
+				assert.Equal(data, payload)
+			}
+
+			require.NoError(client1.SendControl(recipient, data))
+			if checkReceiveClientControl(ctx, t, client2, "session", hello1.Hello, &payload) {
+				assert.Equal(data, payload)
+			}
+			if checkReceiveClientControl(ctx, t, client3, "session", hello1.Hello, &payload) {
+				assert.Equal(data, payload)
+			}
+
+			// Duplicate recipients only receive the messages once.
+			ctx2, cancel2 := context.WithTimeout(ctx, 200*time.Millisecond)
+			defer cancel2()
+			client2.RunUntilErrorIs(ctx2, ErrNoMessageReceived, context.DeadlineExceeded)
+		})
+	}
+}
+
 func TestClientControlToSessionId(t *testing.T) {
 	CatchLogForTest(t)
 	for _, subtest := range clusteredTests {
@@ -2535,6 +3146,65 @@ func TestJoinRoom(t *testing.T) {
 	require.Equal("", roomMsg.Room.RoomId)
 }
 
+func TestResyncEvents(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	hub, _, _, server := CreateHubForTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client1 := NewTestClient(t, server, hub)
+	defer client1.CloseWithBye()
+	require.NoError(client1.SendHelloParams(server.URL, HelloVersionV1, "", []string{ClientFeatureResync}, TestBackendClientAuthParams{
+		UserId: testDefaultUserId + "1",
+	}))
+	hello1 := MustSucceed1(t, client1.RunUntilHello, ctx)
+	client2, hello2 := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId+"2")
+	defer client2.CloseWithBye()
+
+	// Sessions that are not in a room will not be notified.
+	hub.onAsyncEventsReconnected()
+
+	roomId := "test-room"
+	roomMsg := MustSucceed2(t, client1.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+	client1.RunUntilJoined(ctx, hello1.Hello)
+	roomMsg = MustSucceed2(t, client2.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+	client1.RunUntilJoined(ctx, hello2.Hello)
+	client2.RunUntilJoined(ctx, hello1.Hello, hello2.Hello)
+
+	// The initial connection to the MCU doesn't trigger a resync.
+	hub.onMcuConnected()
+	hub.onMcuDisconnected()
+	hub.onMcuConnected()
+	if msg, ok := client1.RunUntilMessage(ctx); ok && checkMessageType(t, msg, "event") {
+		assert.Equal("session", msg.Event.Target)
+		assert.Equal("resync", msg.Event.Type)
+		if assert.NotNil(msg.Event.Resync) {
+			assert.Equal(ResyncReasonMcuReconnected, msg.Event.Resync.Reason)
+			assert.Equal([]string{ResyncStatePublishers}, msg.Event.Resync.Stale)
+		}
+	}
+
+	hub.onAsyncEventsReconnected()
+	if msg, ok := client1.RunUntilMessage(ctx); ok && checkMessageType(t, msg, "event") {
+		assert.Equal("resync", msg.Event.Type)
+		if assert.NotNil(msg.Event.Resync) {
+			assert.Equal(ResyncReasonEventsReconnected, msg.Event.Resync.Reason)
+			assert.Equal([]string{ResyncStateParticipants, ResyncStateInCall}, msg.Event.Resync.Stale)
+		}
+	}
+
+	// The second client doesn't support resync events.
+	ctx2, cancel2 := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer cancel2()
+	client2.RunUntilErrorIs(ctx2, ErrNoMessageReceived, context.DeadlineExceeded)
+}
+
 func TestJoinInvalidRoom(t *testing.T) {
 	t.Parallel()
 	CatchLogForTest(t)
@@ -2758,15 +3428,229 @@ func TestJoinMultiple(t *testing.T) {
 	// The first client will also receive a "joined" event from the second client.
 	client1.RunUntilJoined(ctx, hello2.Hello)
 
-	// Leave room.
-	roomMsg = MustSucceed2(t, client1.JoinRoom, ctx, "")
-	require.Equal("", roomMsg.Room.RoomId)
+	// Leave room.
+	roomMsg = MustSucceed2(t, client1.JoinRoom, ctx, "")
+	require.Equal("", roomMsg.Room.RoomId)
+
+	// The second client will now receive a "left" event
+	client2.RunUntilLeft(ctx, hello1.Hello)
+
+	roomMsg = MustSucceed2(t, client2.JoinRoom, ctx, "")
+	require.Equal("", roomMsg.Room.RoomId)
+}
+
+func TestJoinSecondaryRoom(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	hub, _, _, server := CreateHubForTestWithConfig(t, func(server *httptest.Server) (*goconf.ConfigFile, error) {
+		config, err := getTestConfig(server)
+		if err != nil {
+			return nil, err
+		}
+
+		config.AddOption("clients", "maxsecondaryrooms", "1")
+		return config, nil
+	})
+	assert.True(hub.getWelcomeMessage().Welcome.HasFeature(ServerFeatureSecondaryRooms))
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client1, hello1 := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId+"1")
+	client2, hello2 := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId+"2")
+
+	roomId := "test-room"
+	roomMsg := MustSucceed2(t, client1.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+	client1.RunUntilJoined(ctx, hello1.Hello)
+
+	secondary := &ClientMessage{
+		Id:   "ABCD",
+		Type: "room",
+		Room: &RoomClientMessage{
+			RoomId:    roomId,
+			SessionId: RoomSessionId(roomId + "-" + string(hello2.Hello.SessionId)),
+			Secondary: true,
+		},
+	}
+	require.NoError(client2.WriteJSON(secondary))
+	if message, ok := client2.RunUntilMessage(ctx); ok && checkMessageRoomId(t, message, roomId) {
+		assert.True(message.Room.Secondary)
+	}
+
+	// The current sessions of the secondary room are sent tagged with the room.
+	if message, ok := client2.RunUntilMessage(ctx); ok && client2.checkMessageJoined(message, hello1.Hello) {
+		assert.Equal(roomId, message.Event.RoomId)
+	}
+
+	// Joining the same room again fails.
+	require.NoError(client2.WriteJSON(secondary))
+	client2.RunUntilError(ctx, "already_joined") // nolint
+
+	// Only one secondary room may be joined.
+	secondary.Room.RoomId = roomId + "-2"
+	require.NoError(client2.WriteJSON(secondary))
+	client2.RunUntilError(ctx, TooManySecondaryRooms.Code) // nolint
+
+	// Events of the secondary room are tagged.
+	roomMsg = MustSucceed2(t, client1.JoinRoom, ctx, "")
+	require.Equal("", roomMsg.Room.RoomId)
+	if message, ok := client2.RunUntilMessage(ctx); ok && client2.checkMessageRoomLeave(message, hello1.Hello) {
+		assert.Equal(roomId, message.Event.RoomId)
+	}
+
+	secondary.Room.RoomId = roomId
+	secondary.Room.Leave = true
+	require.NoError(client2.WriteJSON(secondary))
+	if message, ok := client2.RunUntilMessage(ctx); ok && checkMessageRoomId(t, message, "") {
+		assert.True(message.Room.Secondary)
+	}
+
+	require.NoError(client2.WriteJSON(secondary))
+	client2.RunUntilError(ctx, NotInSecondaryRoom.Code) // nolint
+
+	// The session only received events of the room while it was joined.
+	MustSucceed2(t, client1.JoinRoom, ctx, roomId)
+	client1.RunUntilJoined(ctx, hello1.Hello)
+
+	ctx2, cancel2 := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel2()
+	client2.RunUntilErrorIs(ctx2, ErrNoMessageReceived, context.DeadlineExceeded)
+}
+
+func TestClientLocalizedErrors(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	hub, _, _, server := CreateHubForTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client := NewTestClient(t, server, hub)
+	defer client.CloseWithBye()
+
+	params, err := json.Marshal(TestBackendClientAuthParams{
+		UserId: testDefaultUserId,
+	})
+	require.NoError(err)
+	require.NoError(client.WriteJSON(&ClientMessage{
+		Id:   "1234",
+		Type: "hello",
+		Hello: &HelloClientMessage{
+			Version:  HelloVersionV1,
+			Language: "de-DE, en;q=0.5",
+			Auth: &HelloClientMessageAuth{
+				Url:    server.URL,
+				Params: params,
+			},
+		},
+	}))
+	MustSucceed1(t, client.RunUntilHello, ctx)
+
+	require.NoError(client.WriteJSON(&ClientMessage{
+		Id:   "ABCD",
+		Type: "room",
+		Room: &RoomClientMessage{
+			RoomId:    "test-room",
+			Secondary: true,
+		},
+	}))
+	if err, ok := client.RunUntilError(ctx, FeatureDisabled.Code); ok {
+		assert.Equal(FeatureDisabled.Message, err.Message)
+		assert.Equal("Die Funktion ist für dieses Backend deaktiviert.", err.Localized)
+	}
+}
+
+func TestJoinSecondaryRoomDisabled(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	hub, _, _, server := CreateHubForTest(t)
+	require.False(hub.getWelcomeMessage().Welcome.HasFeature(ServerFeatureSecondaryRooms))
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client, _ := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId)
+	require.NoError(client.WriteJSON(&ClientMessage{
+		Id:   "ABCD",
+		Type: "room",
+		Room: &RoomClientMessage{
+			RoomId:    "test-room",
+			Secondary: true,
+		},
+	}))
+	client.RunUntilError(ctx, FeatureDisabled.Code) // nolint
+}
+
+func TestJoinRoomChunkedEvents(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	hub, _, _, server := CreateHubForTestWithConfig(t, func(server *httptest.Server) (*goconf.ConfigFile, error) {
+		config, err := getTestConfig(server)
+		if err != nil {
+			return nil, err
+		}
+
+		config.AddOption("clients", "joineventsize", "1")
+		return config, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client1, hello1 := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId+"1")
+	client2, hello2 := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId+"2")
+	client3, hello3 := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId+"3")
+
+	roomId := "test-room"
+	MustSucceed2(t, client1.JoinRoom, ctx, roomId)
+	client1.RunUntilJoined(ctx, hello1.Hello)
+
+	MustSucceed2(t, client2.JoinRoom, ctx, roomId)
+	client2.RunUntilJoined(ctx, hello1.Hello, hello2.Hello)
+	client1.RunUntilJoined(ctx, hello2.Hello)
+
+	MustSucceed2(t, client3.JoinRoom, ctx, roomId)
+	client1.RunUntilJoined(ctx, hello3.Hello)
+	client2.RunUntilJoined(ctx, hello3.Hello)
+
+	// The third client receives the existing sessions in two chunks and its
+	// own "join" event separately.
+	var joined []PublicSessionId
+	var chunks []*EventServerMessageChunk
+	for range 3 {
+		var event *EventServerMessage
+		if !checkReceiveClientEvent(ctx, t, client3, "join", &event) {
+			break
+		}
 
-	// The second client will now receive a "left" event
-	client2.RunUntilLeft(ctx, hello1.Hello)
+		if event.Chunk == nil {
+			if assert.Len(event.Join, 1) {
+				assert.Equal(hello3.Hello.SessionId, event.Join[0].SessionId)
+			}
+			continue
+		}
 
-	roomMsg = MustSucceed2(t, client2.JoinRoom, ctx, "")
-	require.Equal("", roomMsg.Room.RoomId)
+		chunks = append(chunks, event.Chunk)
+		if assert.Len(event.Join, 1) {
+			joined = append(joined, event.Join[0].SessionId)
+		}
+	}
+	require.Equal([]*EventServerMessageChunk{
+		{Sequence: 1, More: true, Total: 2},
+		{Sequence: 2, More: false, Total: 2},
+	}, chunks)
+	assert.ElementsMatch([]PublicSessionId{
+		hello1.Hello.SessionId,
+		hello2.Hello.SessionId,
+	}, joined)
 }
 
 func TestJoinDisplaynamesPermission(t *testing.T) {
@@ -3107,6 +3991,54 @@ func TestGetRealUserIP(t *testing.T) {
 			"192.168.0.0/16",
 			"192.168.1.2:23456",
 		},
+		// Addresses are returned in canonical form.
+		{
+			"192.168.1.2",
+			nil,
+			"192.168.0.0/16",
+			"[::ffff:192.168.1.2]:23456",
+		},
+		{
+			"11.12.13.14",
+			http.Header{
+				http.CanonicalHeaderKey("x-real-ip"): []string{"::ffff:11.12.13.14"},
+			},
+			"192.168.0.0/16",
+			"[::ffff:192.168.1.2]:23456",
+		},
+		{
+			"2002:db8::1",
+			http.Header{
+				http.CanonicalHeaderKey("x-real-ip"): []string{"2002:DB8:0::1"},
+			},
+			"::1",
+			"[::1]:23456",
+		},
+		// Ports, brackets and zones are removed.
+		{
+			"2002:db8::1",
+			http.Header{
+				http.CanonicalHeaderKey("x-real-ip"): []string{"[2002:db8::1]"},
+			},
+			"2001:db8::/48",
+			"[2001:db8::1]:23456",
+		},
+		{
+			"2002:db8::1",
+			http.Header{
+				http.CanonicalHeaderKey("x-forwarded-for"): []string{"[2002:db8::1]:12345, [2001:db8::2]:23456"},
+			},
+			"2001:db8::/48",
+			"[2001:db8::1]:23456",
+		},
+		{
+			"11.12.13.14",
+			http.Header{
+				http.CanonicalHeaderKey("x-forwarded-for"): []string{"11.12.13.14:12345, fe80::1%eth0"},
+			},
+			"fe80::/10",
+			"[fe80::2%eth0]:23456",
+		},
 	}
 
 	for _, tc := range testcases {
@@ -3590,6 +4522,25 @@ loop:
 		// Give some time to async processing.
 		time.Sleep(time.Millisecond)
 	}
+
+	// The client is notified about the changed permissions and the stopped publisher.
+	for {
+		message, ok := client.RunUntilMessage(ctx)
+		if !ok {
+			break
+		} else if message.Type != "event" || message.Event.Target != "session" {
+			continue
+		}
+
+		if assert.Equal("permissions", message.Event.Type, "invalid event type in %+v", message) &&
+			assert.NotNil(message.Event.Permissions, "permissions missing in %+v", message) {
+			assert.Equal([]Permission{PERMISSION_MAY_PUBLISH_AUDIO}, message.Event.Permissions.Permissions)
+			assert.Empty(message.Event.Permissions.Added)
+			assert.Equal([]Permission{PERMISSION_MAY_PUBLISH_VIDEO}, message.Event.Permissions.Removed)
+			assert.Equal([]StreamType{StreamTypeVideo}, message.Event.Permissions.Renegotiate)
+		}
+		break
+	}
 }
 
 func TestClientSendOfferPermissionsAudioVideoMedia(t *testing.T) {
@@ -4988,6 +5939,69 @@ func TestGeoipOverrides(t *testing.T) {
 	assert.Equal(strings.ToUpper(country3), hub.OnLookupCountry(&Client{addr: "192.168.10.20"}))
 }
 
+func TestClientSessionsCountryStats(t *testing.T) {
+	CatchLogForTest(t)
+	hub, _, _, server := CreateHubForTestWithConfig(t, func(server *httptest.Server) (*goconf.ConfigFile, error) {
+		conf, err := getTestConfig(server)
+		if err != nil {
+			return nil, err
+		}
+
+		conf.AddOption("geoip-overrides", "127.0.0.1", "DE")
+		return conf, err
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	sessions := statsClientSessionsCountry.WithLabelValues("DE", "EU")
+	initial := testutil.ToFloat64(sessions)
+	client, _ := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId)
+	checkStatsValue(t, sessions, initial+1)
+
+	client.CloseWithBye()
+	WaitForHub(ctx, t, hub)
+	checkStatsValue(t, sessions, initial)
+}
+
+func TestClientWebsocketStats(t *testing.T) {
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	hub, _, _, server := CreateHubForTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	closeCodes := statsClientWebsocketCloseCodes.WithLabelValues(strconv.Itoa(websocket.CloseNoStatusReceived))
+	initialCloseCodes := testutil.ToFloat64(closeCodes)
+	client, _ := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId)
+	client.CloseWithBye()
+	WaitForHub(ctx, t, hub)
+	checkStatsValue(t, closeCodes, initialCloseCodes+1)
+
+	handshakeFailures := statsClientWebsocketHandshakeFailures.WithLabelValues("bad_request")
+	initialHandshakeFailures := testutil.ToFloat64(handshakeFailures)
+	response, err := http.Get(server.URL + "/spreed")
+	require.NoError(err)
+	defer response.Body.Close()
+	assert.Equal(http.StatusBadRequest, response.StatusCode)
+	checkStatsValue(t, handshakeFailures, initialHandshakeFailures+1)
+}
+
+func TestWebsocketErrorReason(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	assert.Equal("close_sent", getWebsocketErrorReason(websocket.ErrCloseSent))
+	assert.Equal("message_too_large", getWebsocketErrorReason(websocket.ErrReadLimit))
+	assert.Equal("timeout", getWebsocketErrorReason(os.ErrDeadlineExceeded))
+	assert.Equal("eof", getWebsocketErrorReason(io.ErrUnexpectedEOF))
+	assert.Equal("closed", getWebsocketErrorReason(net.ErrClosed))
+	assert.Equal("reset", getWebsocketErrorReason(errors.New("read tcp: connection reset by peer")))
+	assert.Equal("other", getWebsocketErrorReason(errors.New("foo")))
+}
+
 func TestDialoutStatus(t *testing.T) {
 	t.Parallel()
 	CatchLogForTest(t)
@@ -5005,6 +6019,7 @@ func TestDialoutStatus(t *testing.T) {
 	MustSucceed1(t, internalClient.RunUntilHello, ctx)
 
 	roomId := "12345"
+	backendRequests := getDialoutRequests(t)
 	client, hello := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId)
 
 	MustSucceed2(t, client.JoinRoom, ctx, roomId)
@@ -5077,6 +6092,20 @@ func TestDialoutStatus(t *testing.T) {
 		}
 	}
 
+	checkBackendRequest := func(status DialoutStatus) *BackendClientDialoutRequest {
+		t.Helper()
+		select {
+		case request := <-backendRequests:
+			assert.Equal(roomId, request.RoomId)
+			assert.Equal(callId, request.CallId)
+			assert.Equal(status, request.Status)
+			return request
+		case <-ctx.Done():
+			assert.Fail("no backend request received", "expected status %s", status)
+			return nil
+		}
+	}
+
 	key := "callstatus_" + callId
 	if msg, ok := client.RunUntilMessage(ctx); ok {
 		checkMessageTransientSet(t, msg, key, StringMap{
@@ -5084,6 +6113,7 @@ func TestDialoutStatus(t *testing.T) {
 			"status": "accepted",
 		}, nil)
 	}
+	checkBackendRequest(DialoutStatusAccepted)
 
 	require.NoError(internalClient.SendInternalDialout(&DialoutInternalClientMessage{
 		RoomId: roomId,
@@ -5103,6 +6133,27 @@ func TestDialoutStatus(t *testing.T) {
 			"status": "accepted",
 		})
 	}
+	checkBackendRequest(DialoutStatusRinging)
+
+	require.NoError(internalClient.SendInternalDialout(&DialoutInternalClientMessage{
+		RoomId: roomId,
+		Type:   "status",
+		Status: &DialoutStatusInternalClientMessage{
+			CallId: callId,
+			Status: "earlymedia",
+		},
+	}))
+
+	if msg, ok := client.RunUntilMessage(ctx); ok {
+		checkMessageTransientSet(t, msg, key, StringMap{
+			"callid": callId,
+			"status": "earlymedia",
+		}, StringMap{
+			"callid": callId,
+			"status": "ringing",
+		})
+	}
+	checkBackendRequest(DialoutStatusEarlyMedia)
 
 	old := removeCallStatusTTL
 	defer func() {
@@ -5118,6 +6169,7 @@ func TestDialoutStatus(t *testing.T) {
 			CallId: callId,
 			Status: "cleared",
 			Cause:  clearedCause,
+			Reason: "remote",
 		},
 	}))
 
@@ -5126,11 +6178,16 @@ func TestDialoutStatus(t *testing.T) {
 			"callid": callId,
 			"status": "cleared",
 			"cause":  clearedCause,
+			"reason": "remote",
 		}, StringMap{
 			"callid": callId,
-			"status": "ringing",
+			"status": "earlymedia",
 		})
 	}
+	if request := checkBackendRequest(DialoutStatusCleared); request != nil {
+		assert.Equal(clearedCause, request.Cause)
+		assert.Equal("remote", request.Reason)
+	}
 
 	ctx2, cancel := context.WithTimeout(ctx, removeCallStatusTTL*2)
 	defer cancel()
@@ -5140,6 +6197,7 @@ func TestDialoutStatus(t *testing.T) {
 			"callid": callId,
 			"status": "cleared",
 			"cause":  clearedCause,
+			"reason": "remote",
 		})
 	}
 }
@@ -5213,3 +6271,208 @@ func TestGracefulShutdownOnExpiration(t *testing.T) {
 		assert.Fail("should have shutdown")
 	}
 }
+
+func TestClientDisabledBackendFeatures(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	hub, _, r, server := CreateHubForTestWithConfig(t, getTestConfigWithDisabledFeatures)
+	registerBackendHandlerUrl(t, r, "/one")
+	registerBackendHandlerUrl(t, r, "/two")
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client1 := NewTestClient(t, server, hub)
+	defer client1.CloseWithBye()
+
+	params1 := TestBackendClientAuthParams{
+		UserId: "user1",
+	}
+	require.NoError(client1.SendHelloParams(server.URL+"/one", HelloVersionV1, "client", nil, params1))
+	hello1 := MustSucceed1(t, client1.RunUntilHello, ctx)
+	if assert.NotNil(hello1.Hello.Server) {
+		assert.True(hello1.Hello.Server.HasFeature(ServerFeatureTransientData))
+		assert.True(hello1.Hello.Server.HasFeature(ServerFeatureFederation))
+	}
+
+	client2 := NewTestClient(t, server, hub)
+	defer client2.CloseWithBye()
+
+	params2 := TestBackendClientAuthParams{
+		UserId: "user2",
+	}
+	require.NoError(client2.SendHelloParams(server.URL+"/two", HelloVersionV1, "client", nil, params2))
+	hello2 := MustSucceed1(t, client2.RunUntilHello, ctx)
+	if assert.NotNil(hello2.Hello.Server) {
+		assert.False(hello2.Hello.Server.HasFeature(ServerFeatureTransientData))
+		assert.False(hello2.Hello.Server.HasFeature(ServerFeatureFederation))
+	}
+
+	// Both clients join different rooms as they are on different backends.
+	roomId := "test-room"
+	roomMsg := MustSucceed2(t, client1.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+	if msg1, ok := client1.RunUntilMessage(ctx); ok {
+		client1.checkMessageJoined(msg1, hello1.Hello)
+	}
+
+	roomMsg = MustSucceed2(t, client2.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+	if msg2, ok := client2.RunUntilMessage(ctx); ok {
+		client2.checkMessageJoined(msg2, hello2.Hello)
+	}
+
+	session1 := hub.GetSessionByPublicId(hello1.Hello.SessionId).(*ClientSession)
+	require.NotNil(session1, "Session %s does not exist", hello1.Hello.SessionId)
+	session1.SetPermissions([]Permission{PERMISSION_TRANSIENT_DATA})
+	session2 := hub.GetSessionByPublicId(hello2.Hello.SessionId).(*ClientSession)
+	require.NotNil(session2, "Session %s does not exist", hello2.Hello.SessionId)
+	session2.SetPermissions([]Permission{PERMISSION_TRANSIENT_DATA})
+
+	require.NoError(client2.SetTransientData("foo", "bar", 0))
+	client2.RunUntilError(ctx, FeatureDisabled.Code) // nolint
+
+	require.NoError(client1.SetTransientData("foo", "bar", 0))
+	if msg, ok := client1.RunUntilMessage(ctx); ok {
+		checkMessageTransientSet(t, msg, "foo", "bar", nil)
+	}
+}
+
+func TestHubBackendRateLimits(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	hub, _, r, server := CreateHubForTestWithConfig(t, func(server *httptest.Server) (*goconf.ConfigFile, error) {
+		config, err := getTestConfigWithMultipleBackends(server)
+		if err != nil {
+			return nil, err
+		}
+
+		config.AddOption("backend2", "messagerate", "5")
+		config.AddOption("backend2", "joinrate", "1")
+		return config, nil
+	})
+	registerBackendHandlerUrl(t, r, "/one")
+	registerBackendHandlerUrl(t, r, "/two")
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client1 := NewTestClient(t, server, hub)
+	defer client1.CloseWithBye()
+	require.NoError(client1.SendHelloParams(server.URL+"/one", HelloVersionV1, "client", nil, TestBackendClientAuthParams{
+		UserId: "user1",
+	}))
+	hello1 := MustSucceed1(t, client1.RunUntilHello, ctx)
+
+	client2 := NewTestClient(t, server, hub)
+	defer client2.CloseWithBye()
+	require.NoError(client2.SendHelloParams(server.URL+"/two", HelloVersionV1, "client", nil, TestBackendClientAuthParams{
+		UserId: "user2",
+	}))
+	hello2 := MustSucceed1(t, client2.RunUntilHello, ctx)
+
+	roomId := "test-room"
+	roomMsg := MustSucceed2(t, client2.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+	client2.RunUntilJoined(ctx, hello2.Hello)
+
+	// Only one room may be joined per minute.
+	require.NoError(client2.WriteJSON(&ClientMessage{
+		Id:   "join-again",
+		Type: "room",
+		Room: &RoomClientMessage{
+			RoomId:    roomId + "-other",
+			SessionId: RoomSessionId(roomId + "-other-" + string(hello2.Hello.SessionId)),
+		},
+	}))
+	if msg, ok := client2.RunUntilMessage(ctx); ok && checkMessageError(t, msg, Throttled.Code) {
+		assert.Equal("join-again", msg.Id)
+	}
+
+	// The other backend is not limited.
+	roomMsg = MustSucceed2(t, client1.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+	client1.RunUntilJoined(ctx, hello1.Hello)
+
+	// Messages exceeding the rate of the backend are rejected.
+	recipient := MessageClientMessageRecipient{
+		Type:      "session",
+		SessionId: hello1.Hello.SessionId,
+	}
+	for range 10 {
+		require.NoError(client2.SendMessage(recipient, "hello"))
+	}
+	client2.RunUntilError(ctx, Throttled.Code) // nolint
+}
+
+func TestHubAllowedOrigins(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	_, _, _, server := CreateHubForTestWithConfig(t, func(server *httptest.Server) (*goconf.ConfigFile, error) {
+		config, err := getTestConfig(server)
+		if err != nil {
+			return nil, err
+		}
+
+		config.AddOption("app", "allowedorigins", "https://cloud.domain.invalid")
+		return config, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	dial := func(origin string) (*websocket.Conn, *http.Response, error) {
+		header := http.Header{}
+		if origin != "" {
+			header.Set("Origin", origin)
+		}
+		return testClientDialer.DialContext(ctx, getWebsocketUrl(server.URL), header)
+	}
+
+	if conn, _, err := dial("https://cloud.domain.invalid"); assert.NoError(t, err) {
+		assert.NoError(t, conn.Close())
+	}
+
+	_, response, err := dial("https://evil.invalid")
+	if assert.ErrorIs(t, err, websocket.ErrBadHandshake) {
+		assert.Equal(t, http.StatusForbidden, response.StatusCode)
+	}
+
+	_, response, err = dial("")
+	if assert.ErrorIs(t, err, websocket.ErrBadHandshake) {
+		assert.Equal(t, http.StatusForbidden, response.StatusCode)
+	}
+}
+
+func TestHubReloadGeoIP(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	hub, _, _, _ := CreateHubForTest(t)
+
+	assert.Nil(hub.geoip.Load())
+
+	config := goconf.NewConfigFile()
+	config.AddOption("geoip", "url", "file://"+filepath.Join(t.TempDir(), "missing.mmdb"))
+	config.AddOption("geoip", "updateinterval", "2")
+	err := hub.reloadGeoIP(config)
+	var se *ConfigSectionError
+	if assert.ErrorAs(err, &se) {
+		assert.Equal("geoip", se.Section)
+	}
+	assert.Nil(hub.geoip.Load())
+	assert.EqualValues(2*time.Hour, hub.geoipUpdateInterval.Load())
+
+	// The reload is retried if the configuration didn't change.
+	assert.Error(hub.reloadGeoIP(config))
+
+	config.AddOption("geoip", "url", "none")
+	config.RemoveOption("geoip", "updateinterval")
+	assert.NoError(hub.reloadGeoIP(config))
+	assert.Nil(hub.geoip.Load())
+	assert.EqualValues(defaultGeoIpUpdateInterval, hub.geoipUpdateInterval.Load())
+}
@@ -0,0 +1,180 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dlintw/goconf"
+)
+
+type InternalHeartbeatCleanup string
+
+const (
+	// Only mark the virtual sessions of the internal client as degraded.
+	InternalHeartbeatCleanupNone InternalHeartbeatCleanup = "none"
+	// Remove the virtual sessions of the internal client.
+	InternalHeartbeatCleanupRemove InternalHeartbeatCleanup = "remove"
+	// Close the session of the internal client.
+	InternalHeartbeatCleanupClose InternalHeartbeatCleanup = "close"
+
+	defaultInternalHeartbeatCleanup = InternalHeartbeatCleanupRemove
+)
+
+type internalHeartbeatState struct {
+	last   time.Time
+	failed bool
+}
+
+// InternalHeartbeatMonitor detects internal clients that stopped sending
+// heartbeats, e.g. because the connection is stuck, without waiting for the
+// TCP connection to time out.
+type InternalHeartbeatMonitor struct {
+	mu sync.Mutex
+
+	timeout time.Duration
+	cleanup InternalHeartbeatCleanup
+
+	sessions map[*ClientSession]*internalHeartbeatState
+}
+
+func parseInternalHeartbeatCleanup(value string) (InternalHeartbeatCleanup, error) {
+	switch cleanup := InternalHeartbeatCleanup(value); cleanup {
+	case "":
+		return defaultInternalHeartbeatCleanup, nil
+	case InternalHeartbeatCleanupNone:
+		fallthrough
+	case InternalHeartbeatCleanupRemove:
+		fallthrough
+	case InternalHeartbeatCleanupClose:
+		return cleanup, nil
+	default:
+		return "", fmt.Errorf("unsupported cleanup %s", value)
+	}
+}
+
+func NewInternalHeartbeatMonitor(config *goconf.ConfigFile) *InternalHeartbeatMonitor {
+	result := &InternalHeartbeatMonitor{
+		sessions: make(map[*ClientSession]*internalHeartbeatState),
+	}
+	result.load(config)
+	return result
+}
+
+func (m *InternalHeartbeatMonitor) load(config *goconf.ConfigFile) {
+	var timeout time.Duration
+	if timeoutSeconds, _ := config.GetInt("clients", "internalheartbeattimeout"); timeoutSeconds > 0 {
+		timeout = time.Duration(timeoutSeconds) * time.Second
+	}
+	value, _ := config.GetString("clients", "internalheartbeatcleanup")
+	cleanup, err := parseInternalHeartbeatCleanup(value)
+	if err != nil {
+		hubLog.Warnf("Invalid internal heartbeat cleanup: %s, using %s", err, defaultInternalHeartbeatCleanup)
+		cleanup = defaultInternalHeartbeatCleanup
+	}
+
+	if timeout > 0 {
+		hubLog.Infof("Internal clients must send heartbeats every %s, cleanup is %s", timeout/2, cleanup)
+	} else {
+		hubLog.Infof("Heartbeats of internal clients are disabled")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.timeout = timeout
+	m.cleanup = cleanup
+}
+
+func (m *InternalHeartbeatMonitor) Reload(config *goconf.ConfigFile) {
+	m.load(config)
+}
+
+// Interval returns the interval in which internal clients must send
+// heartbeats, or 0 if heartbeats are disabled.
+func (m *InternalHeartbeatMonitor) Interval() time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.timeout / 2
+}
+
+// Cleanup returns what should be done with internal clients that failed to
+// send heartbeats.
+func (m *InternalHeartbeatMonitor) Cleanup() InternalHeartbeatCleanup {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.cleanup
+}
+
+func (m *InternalHeartbeatMonitor) AddSession(session *ClientSession, now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions[session] = &internalHeartbeatState{
+		last: now,
+	}
+}
+
+func (m *InternalHeartbeatMonitor) RemoveSession(session *ClientSession) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.sessions, session)
+}
+
+// Heartbeat records a heartbeat of the internal client and returns true if it
+// was marked as failed before.
+func (m *InternalHeartbeatMonitor) Heartbeat(session *ClientSession, now time.Time) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state, found := m.sessions[session]
+	if !found {
+		return false
+	}
+
+	state.last = now
+	recovered := state.failed
+	state.failed = false
+	return recovered
+}
+
+// Check returns the internal clients that didn't send a heartbeat within the
+// timeout. Each client is only returned once until it sends a heartbeat again.
+func (m *InternalHeartbeatMonitor) Check(now time.Time) []*ClientSession {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.timeout <= 0 {
+		return nil
+	}
+
+	var failed []*ClientSession
+	for session, state := range m.sessions {
+		if state.failed || now.Sub(state.last) <= m.timeout {
+			continue
+		}
+
+		state.failed = true
+		failed = append(failed, session)
+	}
+	return failed
+}
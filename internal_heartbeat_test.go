@@ -0,0 +1,201 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/dlintw/goconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInternalHeartbeatMonitor(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	config := goconf.NewConfigFile()
+	monitor := NewInternalHeartbeatMonitor(config)
+	assert.EqualValues(0, monitor.Interval())
+	assert.Equal(InternalHeartbeatCleanupRemove, monitor.Cleanup())
+
+	session1 := &ClientSession{}
+	session2 := &ClientSession{}
+	now := time.Now()
+	monitor.AddSession(session1, now)
+	assert.Empty(monitor.Check(now.Add(time.Hour)))
+
+	config.AddOption("clients", "internalheartbeattimeout", "10")
+	config.AddOption("clients", "internalheartbeatcleanup", "none")
+	monitor.Reload(config)
+	assert.Equal(5*time.Second, monitor.Interval())
+	assert.Equal(InternalHeartbeatCleanupNone, monitor.Cleanup())
+
+	monitor.AddSession(session2, now)
+	assert.Empty(monitor.Check(now.Add(10 * time.Second)))
+	assert.False(monitor.Heartbeat(session2, now.Add(5*time.Second)))
+	assert.Equal([]*ClientSession{session1}, monitor.Check(now.Add(11*time.Second)))
+	// Failed sessions are only returned once.
+	assert.Empty(monitor.Check(now.Add(12 * time.Second)))
+
+	assert.True(monitor.Heartbeat(session1, now.Add(13*time.Second)))
+	assert.False(monitor.Heartbeat(session1, now.Add(14*time.Second)))
+	assert.Equal([]*ClientSession{session2}, monitor.Check(now.Add(16*time.Second)))
+
+	monitor.RemoveSession(session2)
+	assert.False(monitor.Heartbeat(session2, now.Add(17*time.Second)))
+	assert.Empty(monitor.Check(now.Add(20 * time.Second)))
+
+	config.AddOption("clients", "internalheartbeatcleanup", "invalid")
+	monitor.Reload(config)
+	assert.Equal(InternalHeartbeatCleanupRemove, monitor.Cleanup())
+}
+
+func setupInternalHeartbeatTest(ctx context.Context, t *testing.T, cleanup InternalHeartbeatCleanup) (*Hub, *TestClient, *TestClient, *VirtualSession) {
+	require := require.New(t)
+	hub, _, _, server := CreateHubForTest(t)
+
+	config := goconf.NewConfigFile()
+	config.AddOption("clients", "internalheartbeattimeout", "30")
+	config.AddOption("clients", "internalheartbeatcleanup", string(cleanup))
+	hub.internalHeartbeats.Reload(config)
+
+	roomId := "the-room-id"
+	emptyProperties := json.RawMessage("{}")
+	backend := &Backend{
+		id: "compat",
+	}
+	room, err := hub.createRoom(roomId, emptyProperties, backend)
+	require.NoError(err)
+	t.Cleanup(func() {
+		room.Close()
+	})
+
+	clientInternal := NewTestClient(t, server, hub)
+	t.Cleanup(clientInternal.CloseWithBye)
+	require.NoError(clientInternal.SendHelloInternal())
+	helloInternal := MustSucceed1(t, clientInternal.RunUntilHello, ctx)
+	require.Equal(15, helloInternal.Hello.Heartbeat)
+
+	client, hello := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId)
+	t.Cleanup(client.CloseWithBye)
+	roomMsg := MustSucceed2(t, client.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+	client.RunUntilJoined(ctx, hello.Hello)
+
+	require.NoError(clientInternal.WriteJSON(&ClientMessage{
+		Type: "internal",
+		Internal: &InternalClientMessage{
+			Type: "addsession",
+			AddSession: &AddSessionInternalClientMessage{
+				CommonSessionInternalClientMessage: CommonSessionInternalClientMessage{
+					SessionId: "session1",
+					RoomId:    roomId,
+				},
+				UserId: "user1",
+			},
+		},
+	}))
+
+	msg := MustSucceed1(t, client.RunUntilMessage, ctx)
+	require.True(client.checkMessageJoinedSession(msg, "", "user1"))
+	sessionId := msg.Event.Join[0].SessionId
+	session, ok := hub.GetSessionByPublicId(sessionId).(*VirtualSession)
+	require.True(ok, "expected virtual session for %s", sessionId)
+
+	msg = MustSucceed1(t, client.RunUntilMessage, ctx)
+	if updateMsg, ok := checkMessageParticipantsInCall(t, msg); ok {
+		checkHasEntryWithInCall(t, updateMsg, sessionId, "virtual", FlagInCall|FlagWithPhone)
+	}
+	return hub, clientInternal, client, session
+}
+
+func checkMessageDegraded(t *testing.T, msg *ServerMessage, sessionId PublicSessionId, degraded bool) {
+	assert := assert.New(t)
+	if checkMessageType(t, msg, "event") &&
+		assert.Equal("room", msg.Event.Target) &&
+		assert.Equal("degraded", msg.Event.Type) &&
+		assert.NotNil(msg.Event.Degraded) {
+		assert.Equal("the-room-id", msg.Event.Degraded.RoomId)
+		assert.Equal([]PublicSessionId{sessionId}, msg.Event.Degraded.SessionIds)
+		assert.Equal(degraded, msg.Event.Degraded.Degraded)
+	}
+}
+
+func TestInternalHeartbeat_Degraded(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	hub, clientInternal, client, session := setupInternalHeartbeatTest(ctx, t, InternalHeartbeatCleanupNone)
+	internalSession := session.Session()
+
+	failed := hub.internalHeartbeats.Check(time.Now().Add(time.Minute))
+	require.Equal([]*ClientSession{internalSession}, failed)
+	hub.processInternalHeartbeatFailure(internalSession, hub.internalHeartbeats.Cleanup())
+	assert.True(session.IsDegraded())
+	checkMessageDegraded(t, MustSucceed1(t, client.RunUntilMessage, ctx), session.PublicId(), true)
+
+	require.NoError(clientInternal.WriteJSON(&ClientMessage{
+		Type: "internal",
+		Internal: &InternalClientMessage{
+			Type: "heartbeat",
+		},
+	}))
+	checkMessageDegraded(t, MustSucceed1(t, client.RunUntilMessage, ctx), session.PublicId(), false)
+	assert.False(session.IsDegraded())
+	assert.Same(session, hub.GetSessionByPublicId(session.PublicId()))
+}
+
+func TestInternalHeartbeat_Close(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	hub, clientInternal, client, session := setupInternalHeartbeatTest(ctx, t, InternalHeartbeatCleanupClose)
+	internalSession := session.Session()
+
+	failed := hub.internalHeartbeats.Check(time.Now().Add(time.Minute))
+	require.Equal([]*ClientSession{internalSession}, failed)
+	hub.processInternalHeartbeatFailure(internalSession, hub.internalHeartbeats.Cleanup())
+
+	if msg, ok := clientInternal.RunUntilMessage(ctx); ok && checkMessageType(t, msg, "bye") {
+		assert.Equal(ByeReasonHeartbeatTimeout, msg.Bye.Reason)
+		assert.True(msg.Bye.Reconnect)
+	}
+
+	checkMessageDegraded(t, MustSucceed1(t, client.RunUntilMessage, ctx), session.PublicId(), true)
+	msg := MustSucceed1(t, client.RunUntilMessage, ctx)
+	assert.True(client.checkMessageRoomLeaveSession(msg, session.PublicId()))
+	assert.Nil(hub.GetSessionByPublicId(internalSession.PublicId()))
+}
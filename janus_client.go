@@ -33,7 +33,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
 	"strconv"
 	"sync"
@@ -388,7 +387,7 @@ loop:
 			err := gateway.conn.WriteControl(websocket.PingMessage, []byte{}, time.Now().Add(20*time.Second))
 			gateway.writeMu.Unlock()
 			if err != nil {
-				log.Println("Error sending ping to MCU:", err)
+				mcuLog.Errorf("Error sending ping to MCU: %s", err)
 			}
 		case <-gateway.closer.C:
 			break loop
@@ -413,7 +412,7 @@ func (gateway *JanusGateway) recv() {
 
 		_, reader, err := conn.NextReader()
 		if err != nil {
-			log.Printf("conn.NextReader: %s", err)
+			mcuLog.Infof("conn.NextReader: %s", err)
 			gateway.writeMu.Lock()
 			gateway.conn = nil
 			gateway.writeMu.Unlock()
@@ -424,7 +423,7 @@ func (gateway *JanusGateway) recv() {
 
 		decodeBuffer.Reset()
 		if _, err := decodeBuffer.ReadFrom(reader); err != nil {
-			log.Printf("decodeBuffer.ReadFrom: %s", err)
+			mcuLog.Infof("decodeBuffer.ReadFrom: %s", err)
 			gateway.writeMu.Lock()
 			gateway.conn = nil
 			gateway.writeMu.Unlock()
@@ -437,13 +436,13 @@ func (gateway *JanusGateway) recv() {
 		decoder := json.NewDecoder(data)
 		decoder.UseNumber()
 		if err := decoder.Decode(&base); err != nil {
-			log.Printf("json.Unmarshal of %s: %s", decodeBuffer.String(), err)
+			mcuLog.Infof("json.Unmarshal of %s: %s", decodeBuffer.String(), err)
 			continue
 		}
 
 		typeFunc, ok := msgtypes[base.Type]
 		if !ok {
-			log.Printf("Unknown message type received: %s", decodeBuffer.String())
+			mcuLog.Warnf("Unknown message type received: %s", decodeBuffer.String())
 			continue
 		}
 
@@ -452,7 +451,7 @@ func (gateway *JanusGateway) recv() {
 		decoder = json.NewDecoder(data)
 		decoder.UseNumber()
 		if err := decoder.Decode(&msg); err != nil {
-			log.Printf("json.Unmarshal of %s: %s", decodeBuffer.String(), err)
+			mcuLog.Infof("json.Unmarshal of %s: %s", decodeBuffer.String(), err)
 			continue // Decode error
 		}
 
@@ -462,14 +461,14 @@ func (gateway *JanusGateway) recv() {
 			if base.Handle == 0 {
 				// Nope. No idea what's going on...
 				// Error()
-				log.Printf("Received event without handle, ignoring: %s", decodeBuffer.String())
+				mcuLog.Infof("Received event without handle, ignoring: %s", decodeBuffer.String())
 			} else {
 				// Lookup Session
 				gateway.Lock()
 				session := gateway.Sessions[base.Session]
 				gateway.Unlock()
 				if session == nil {
-					log.Printf("Unable to deliver message %s. Session %d gone?", decodeBuffer.String(), base.Session)
+					mcuLog.Errorf("Unable to deliver message %s. Session %d gone?", decodeBuffer.String(), base.Session)
 					continue
 				}
 
@@ -478,7 +477,7 @@ func (gateway *JanusGateway) recv() {
 				handle := session.Handles[base.Handle]
 				session.Unlock()
 				if handle == nil {
-					log.Printf("Unable to deliver message %s. Handle %d gone?", decodeBuffer.String(), base.Handle)
+					mcuLog.Errorf("Unable to deliver message %s. Handle %d gone?", decodeBuffer.String(), base.Handle)
 					continue
 				}
 
@@ -488,7 +487,7 @@ func (gateway *JanusGateway) recv() {
 		} else {
 			id, err := strconv.ParseUint(base.ID, 10, 64)
 			if err != nil {
-				log.Printf("Could not decode transaction id %s: %s", base.ID, err)
+				mcuLog.Errorf("Could not decode transaction id %s: %s", base.ID, err)
 				continue
 			}
 
@@ -498,7 +497,7 @@ func (gateway *JanusGateway) recv() {
 			gateway.Unlock()
 			if transaction == nil {
 				// Error()
-				log.Printf("Received event for unknown transaction, ignoring: %s", decodeBuffer.String())
+				mcuLog.Infof("Received event for unknown transaction, ignoring: %s", decodeBuffer.String())
 				continue
 			}
 
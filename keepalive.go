@@ -0,0 +1,93 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dlintw/goconf"
+)
+
+const (
+	defaultMaxPingInterval = 5 * time.Minute
+)
+
+// KeepaliveSettings contain the intervals to check if client connections are
+// still alive.
+type KeepaliveSettings struct {
+	pingInterval    time.Duration
+	pongTimeout     time.Duration
+	maxPingInterval time.Duration
+}
+
+func NewKeepaliveSettings(config *goconf.ConfigFile) (*KeepaliveSettings, error) {
+	pingInterval := pingPeriod
+	if value, _ := config.GetInt("clients", "pinginterval"); value > 0 {
+		pingInterval = time.Duration(value) * time.Second
+	}
+	pongTimeout := pongWait
+	if value, _ := config.GetInt("clients", "pongtimeout"); value > 0 {
+		pongTimeout = time.Duration(value) * time.Second
+	} else if pingInterval >= pongTimeout {
+		pongTimeout = (pingInterval * 10) / 9
+	}
+	if pongTimeout <= pingInterval {
+		return nil, fmt.Errorf("pong timeout %s must be larger than the ping interval %s", pongTimeout, pingInterval)
+	}
+
+	maxPingInterval := defaultMaxPingInterval
+	if value, err := config.GetInt("clients", "maxpinginterval"); err == nil {
+		maxPingInterval = time.Duration(max(value, 0)) * time.Second
+	}
+
+	if maxPingInterval > pingInterval {
+		hubLog.Infof("Sending pings every %s with a timeout of %s, clients may request up to %s", pingInterval, pongTimeout, maxPingInterval)
+	} else {
+		hubLog.Infof("Sending pings every %s with a timeout of %s", pingInterval, pongTimeout)
+	}
+	return &KeepaliveSettings{
+		pingInterval:    pingInterval,
+		pongTimeout:     pongTimeout,
+		maxPingInterval: maxPingInterval,
+	}, nil
+}
+
+// getForClient returns the ping interval and pong timeout for a client that
+// requested the given interval in seconds. Requests for intervals shorter than
+// the default are ignored, longer intervals are capped at the configured
+// maximum.
+func (s *KeepaliveSettings) getForClient(requested int) (time.Duration, time.Duration) {
+	interval := time.Duration(requested) * time.Second
+	switch {
+	case interval <= s.pingInterval || s.maxPingInterval <= s.pingInterval:
+		statsClientPingIntervalRequestsTotal.WithLabelValues("ignored").Inc()
+		return s.pingInterval, s.pongTimeout
+	case interval > s.maxPingInterval:
+		statsClientPingIntervalRequestsTotal.WithLabelValues("capped").Inc()
+		interval = s.maxPingInterval
+	default:
+		statsClientPingIntervalRequestsTotal.WithLabelValues("accepted").Inc()
+	}
+
+	return interval, interval + (s.pongTimeout - s.pingInterval)
+}
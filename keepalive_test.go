@@ -0,0 +1,146 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dlintw/goconf"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeepaliveSettings(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+
+	config := goconf.NewConfigFile()
+	settings, err := NewKeepaliveSettings(config)
+	require.NoError(err)
+	assert.Equal(pingPeriod, settings.pingInterval)
+	assert.Equal(pongWait, settings.pongTimeout)
+	assert.Equal(defaultMaxPingInterval, settings.maxPingInterval)
+
+	config.AddOption("clients", "pinginterval", "90")
+	settings, err = NewKeepaliveSettings(config)
+	require.NoError(err)
+	assert.Equal(90*time.Second, settings.pingInterval)
+	assert.Equal(100*time.Second, settings.pongTimeout)
+
+	config.AddOption("clients", "pongtimeout", "90")
+	_, err = NewKeepaliveSettings(config)
+	assert.Error(err)
+}
+
+func TestKeepaliveSettingsForClient(t *testing.T) {
+	CatchLogForTest(t)
+	assert := assert.New(t)
+
+	config := goconf.NewConfigFile()
+	config.AddOption("clients", "pinginterval", "30")
+	config.AddOption("clients", "pongtimeout", "40")
+	config.AddOption("clients", "maxpinginterval", "120")
+	settings, err := NewKeepaliveSettings(config)
+	require.NoError(t, err)
+
+	accepted := statsClientPingIntervalRequestsTotal.WithLabelValues("accepted")
+	capped := statsClientPingIntervalRequestsTotal.WithLabelValues("capped")
+	ignored := statsClientPingIntervalRequestsTotal.WithLabelValues("ignored")
+	initialAccepted := testutil.ToFloat64(accepted)
+	initialCapped := testutil.ToFloat64(capped)
+	initialIgnored := testutil.ToFloat64(ignored)
+
+	interval, timeout := settings.getForClient(10)
+	assert.Equal(30*time.Second, interval)
+	assert.Equal(40*time.Second, timeout)
+	checkStatsValue(t, ignored, initialIgnored+1)
+
+	interval, timeout = settings.getForClient(60)
+	assert.Equal(60*time.Second, interval)
+	assert.Equal(70*time.Second, timeout)
+	checkStatsValue(t, accepted, initialAccepted+1)
+
+	interval, timeout = settings.getForClient(600)
+	assert.Equal(120*time.Second, interval)
+	assert.Equal(130*time.Second, timeout)
+	checkStatsValue(t, capped, initialCapped+1)
+
+	// Clients may not change the interval if no maximum is configured.
+	config.AddOption("clients", "maxpinginterval", "0")
+	settings, err = NewKeepaliveSettings(config)
+	require.NoError(t, err)
+	interval, timeout = settings.getForClient(60)
+	assert.Equal(30*time.Second, interval)
+	assert.Equal(40*time.Second, timeout)
+	checkStatsValue(t, ignored, initialIgnored+2)
+}
+
+func TestClientPingInterval(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	hub, _, _, server := CreateHubForTestWithConfig(t, func(server *httptest.Server) (*goconf.ConfigFile, error) {
+		config, err := getTestConfig(server)
+		if err != nil {
+			return nil, err
+		}
+
+		config.AddOption("clients", "maxpinginterval", "120")
+		return config, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client := NewTestClient(t, server, hub)
+	defer client.CloseWithBye()
+
+	hello := &ClientMessage{
+		Id:   "1234",
+		Type: "hello",
+		Hello: &HelloClientMessage{
+			Version:      HelloVersionV1,
+			PingInterval: 90,
+			Auth: &HelloClientMessageAuth{
+				Url:    server.URL,
+				Params: []byte("{\"userid\":\"" + testDefaultUserId + "\"}"),
+			},
+		},
+	}
+	require.NoError(client.WriteJSON(hello))
+	message := MustSucceed1(t, client.RunUntilHello, ctx)
+	assert.Equal(90, message.Hello.PingInterval)
+
+	session := hub.GetSessionByPublicId(message.Hello.SessionId)
+	require.NotNil(session)
+	if c, ok := session.(*ClientSession).GetClient().(*Client); assert.True(ok) {
+		assert.Equal(90*time.Second, c.getPingInterval())
+		assert.Equal(90*time.Second+(pongWait-pingPeriod), c.getPongTimeout())
+	}
+}
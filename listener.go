@@ -0,0 +1,160 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/coreos/go-systemd/v22/activation"
+)
+
+const (
+	// Prefix of listen addresses that use sockets passed by systemd.
+	systemdListenerPrefix = "systemd:"
+)
+
+var (
+	ErrReusePortNotSupported = errors.New("SO_REUSEPORT is not supported on this platform")
+
+	systemdListenersOnce sync.Once
+	systemdListenersMu   sync.Mutex
+	systemdListeners     map[string][]net.Listener
+
+	// Listeners that were created (or inherited) by their address, can be
+	// handed off to a new process.
+	handoffListenersMu sync.Mutex
+	createdListeners   map[string]net.Listener
+	inheritedListeners map[string]net.Listener
+)
+
+func loadSystemdListeners() {
+	listeners, err := activation.ListenersWithNames()
+	if err != nil {
+		appLog.Errorf("Could not get listeners from systemd: %s", err)
+		return
+	}
+
+	systemdListeners = listeners
+}
+
+// getSystemdListener returns the next listener passed by systemd through
+// socket activation with the given name ("FileDescriptorName" of the socket
+// unit, defaults to the name of the unit).
+func getSystemdListener(name string) (net.Listener, error) {
+	systemdListenersOnce.Do(loadSystemdListeners)
+
+	systemdListenersMu.Lock()
+	defer systemdListenersMu.Unlock()
+
+	for len(systemdListeners[name]) > 0 {
+		listener := systemdListeners[name][0]
+		systemdListeners[name] = systemdListeners[name][1:]
+		// Entries are nil for sockets that are not stream sockets.
+		if listener != nil {
+			return listener, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no listener %s received from systemd", name)
+}
+
+// CreateListener creates a listener for the given address. Addresses starting
+// with "/" are unix sockets and "systemd:<name>" uses a socket passed by
+// systemd. All other addresses are TCP addresses. If "reusePort" is set, the
+// socket is bound with SO_REUSEPORT so multiple processes can share a port.
+func CreateListener(addr string, reusePort bool) (net.Listener, error) {
+	handoffListenersMu.Lock()
+	defer handoffListenersMu.Unlock()
+
+	listener, found := inheritedListeners[addr]
+	if found {
+		delete(inheritedListeners, addr)
+	} else {
+		var err error
+		if listener, err = createListener(addr, reusePort); err != nil {
+			return nil, err
+		}
+	}
+
+	if createdListeners == nil {
+		createdListeners = make(map[string]net.Listener)
+	}
+	createdListeners[addr] = listener
+	return listener, nil
+}
+
+func createListener(addr string, reusePort bool) (net.Listener, error) {
+	if name, found := strings.CutPrefix(addr, systemdListenerPrefix); found {
+		return getSystemdListener(name)
+	}
+
+	if addr != "" && addr[0] == '/' {
+		os.Remove(addr)
+		return net.Listen("unix", addr)
+	}
+
+	if !reusePort {
+		return net.Listen("tcp", addr)
+	}
+
+	config := net.ListenConfig{
+		Control: setReusePort,
+	}
+	return config.Listen(context.Background(), "tcp", addr)
+}
+
+// GetCreatedListeners returns the listeners that were created by their address.
+func GetCreatedListeners() map[string]net.Listener {
+	handoffListenersMu.Lock()
+	defer handoffListenersMu.Unlock()
+
+	return maps.Clone(createdListeners)
+}
+
+// SetInheritedListeners sets listeners received from a previous process. They
+// will be returned by CreateListener for the same addresses.
+func SetInheritedListeners(listeners map[string]net.Listener) {
+	handoffListenersMu.Lock()
+	defer handoffListenersMu.Unlock()
+
+	inheritedListeners = maps.Clone(listeners)
+}
+
+// CloseInheritedListeners closes listeners received from a previous process
+// that were not used, e.g. because the configuration was changed.
+func CloseInheritedListeners() {
+	handoffListenersMu.Lock()
+	defer handoffListenersMu.Unlock()
+
+	for addr, listener := range inheritedListeners {
+		appLog.Infof("Closing unused listener %s from previous process", addr)
+		listener.Close()
+	}
+	inheritedListeners = nil
+}
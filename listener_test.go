@@ -0,0 +1,80 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"net"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateListener_Unix(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	assert := assert.New(t)
+
+	addr := filepath.Join(t.TempDir(), "test.sock")
+	listener, err := CreateListener(addr, false)
+	require.NoError(err)
+	defer listener.Close()
+
+	assert.Equal("unix", listener.Addr().Network())
+	conn, err := net.Dial("unix", addr)
+	require.NoError(err)
+	assert.NoError(conn.Close())
+}
+
+func TestCreateListener_ReusePort(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	assert := assert.New(t)
+
+	listener1, err := CreateListener("127.0.0.1:0", true)
+	if runtime.GOOS != "linux" {
+		assert.ErrorIs(err, ErrReusePortNotSupported)
+		return
+	}
+	require.NoError(err)
+	defer listener1.Close()
+
+	addr := listener1.Addr().String()
+	listener2, err := CreateListener(addr, true)
+	require.NoError(err)
+	defer listener2.Close()
+
+	// Without SO_REUSEPORT, the port can't be shared.
+	if listener3, err := CreateListener(addr, false); assert.Error(err) {
+		assert.Nil(listener3)
+	} else {
+		listener3.Close()
+	}
+}
+
+func TestCreateListener_SystemdMissing(t *testing.T) {
+	t.Parallel()
+	_, err := CreateListener("systemd:unknown", false)
+	assert.ErrorContains(t, err, "no listener unknown received from systemd")
+}
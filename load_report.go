@@ -0,0 +1,166 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dlintw/goconf"
+)
+
+const (
+	// Percentage of the capacity that should be kept free on each node.
+	defaultLoadHeadroom = 20
+
+	// Time window of the moving average of the received message rate.
+	loadMessageRateWindow = time.Minute
+)
+
+// LoadStats contains the values the load score is calculated from.
+type LoadStats struct {
+	Sessions      int
+	Rooms         int
+	PendingWrites int
+	// Average load of the connected proxies, only set if the proxy MCU is used.
+	McuLoad *int64
+}
+
+// LoadReporter calculates a load score for the node that can be used by
+// external autoscalers. A score of 100 means that the configured capacity
+// minus the headroom has been reached.
+type LoadReporter struct {
+	messages atomic.Uint64
+
+	mu               sync.Mutex
+	maxSessions      int
+	maxRooms         int
+	maxMessageRate   float64
+	maxPendingWrites int
+	maxMcuLoad       int64
+	headroom         int
+
+	stats        LoadStats
+	lastUpdate   time.Time
+	lastMessages uint64
+	// Exponential moving average of the received messages per second.
+	messageRate float64
+	current     *BackendServerLoad
+}
+
+func NewLoadReporter(config *goconf.ConfigFile) *LoadReporter {
+	result := &LoadReporter{}
+	result.Reload(config)
+	return result
+}
+
+func (r *LoadReporter) Reload(config *goconf.ConfigFile) {
+	maxSessions, _ := config.GetInt("load", "maxsessions")
+	maxRooms, _ := config.GetInt("load", "maxrooms")
+	maxMessageRate, _ := config.GetInt("load", "maxmessagerate")
+	maxPendingWrites, _ := config.GetInt("load", "maxpendingwrites")
+	maxMcuLoad, _ := config.GetInt("load", "maxmcuload")
+	headroom, err := config.GetInt("load", "headroom")
+	if err != nil {
+		headroom = defaultLoadHeadroom
+	} else if headroom < 0 || headroom >= 100 {
+		hubLog.Warnf("Invalid load headroom %d, using default of %d", headroom, defaultLoadHeadroom)
+		headroom = defaultLoadHeadroom
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.maxSessions = max(maxSessions, 0)
+	r.maxRooms = max(maxRooms, 0)
+	r.maxMessageRate = float64(max(maxMessageRate, 0))
+	r.maxPendingWrites = max(maxPendingWrites, 0)
+	r.maxMcuLoad = int64(max(maxMcuLoad, 0))
+	r.headroom = headroom
+	r.updateCurrentLocked()
+}
+
+// MessageReceived must be called for every message received from a client.
+func (r *LoadReporter) MessageReceived() {
+	r.messages.Add(1)
+}
+
+// Update recalculates the load score. It is called periodically by the hub.
+func (r *LoadReporter) Update(now time.Time, stats LoadStats) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	messages := r.messages.Load()
+	if !r.lastUpdate.IsZero() {
+		if elapsed := now.Sub(r.lastUpdate); elapsed > 0 {
+			rate := float64(messages-r.lastMessages) / elapsed.Seconds()
+			alpha := 1 - math.Exp(-float64(elapsed)/float64(loadMessageRateWindow))
+			r.messageRate += alpha * (rate - r.messageRate)
+		}
+	}
+	r.lastUpdate = now
+	r.lastMessages = messages
+	r.stats = stats
+	r.updateCurrentLocked()
+}
+
+func roundLoadValue(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+func (r *LoadReporter) updateCurrentLocked() {
+	var utilization float64
+	check := func(value float64, limit float64) {
+		if limit > 0 {
+			utilization = max(utilization, value/limit)
+		}
+	}
+
+	check(float64(r.stats.Sessions), float64(r.maxSessions))
+	check(float64(r.stats.Rooms), float64(r.maxRooms))
+	check(r.messageRate, r.maxMessageRate)
+	check(float64(r.stats.PendingWrites), float64(r.maxPendingWrites))
+	if r.stats.McuLoad != nil {
+		check(float64(*r.stats.McuLoad), float64(r.maxMcuLoad))
+	}
+
+	target := 1 - float64(r.headroom)/100
+	score := roundLoadValue(utilization / target * 100)
+	r.current = &BackendServerLoad{
+		Score:         score,
+		Headroom:      r.headroom,
+		Sessions:      r.stats.Sessions,
+		Rooms:         r.stats.Rooms,
+		MessageRate:   roundLoadValue(r.messageRate),
+		PendingWrites: r.stats.PendingWrites,
+		McuLoad:       r.stats.McuLoad,
+	}
+	statsHubLoadScore.Set(score)
+}
+
+// GetLoad returns the load of the last update.
+func (r *LoadReporter) GetLoad() *BackendServerLoad {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.current
+}
@@ -0,0 +1,111 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dlintw/goconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadReporter(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	assert := assert.New(t)
+	config := goconf.NewConfigFile()
+	config.AddOption("load", "maxsessions", "100")
+	config.AddOption("load", "maxrooms", "10")
+	reporter := NewLoadReporter(config)
+
+	load := reporter.GetLoad()
+	require.NotNil(load)
+	assert.EqualValues(0, load.Score)
+	assert.Equal(defaultLoadHeadroom, load.Headroom)
+
+	now := time.Now()
+	reporter.Update(now, LoadStats{
+		Sessions: 40,
+		Rooms:    2,
+	})
+	load = reporter.GetLoad()
+	// 40% of the sessions are used, the target is 80%.
+	assert.InDelta(50, load.Score, 0.01)
+	assert.Equal(40, load.Sessions)
+	assert.Equal(2, load.Rooms)
+	assert.Nil(load.McuLoad)
+
+	mcuLoad := int64(90)
+	reporter.Update(now.Add(time.Second), LoadStats{
+		Sessions: 40,
+		Rooms:    8,
+		McuLoad:  &mcuLoad,
+	})
+	load = reporter.GetLoad()
+	// The MCU load is ignored without a configured capacity.
+	assert.InDelta(100, load.Score, 0.01)
+	if assert.NotNil(load.McuLoad) {
+		assert.EqualValues(90, *load.McuLoad)
+	}
+
+	config.AddOption("load", "maxmcuload", "100")
+	config.AddOption("load", "headroom", "10")
+	reporter.Reload(config)
+	load = reporter.GetLoad()
+	assert.Equal(10, load.Headroom)
+	assert.InDelta(100, load.Score, 0.01)
+
+	// Invalid values use the default headroom.
+	config.AddOption("load", "headroom", "100")
+	reporter.Reload(config)
+	assert.Equal(defaultLoadHeadroom, reporter.GetLoad().Headroom)
+}
+
+func TestLoadReporter_MessageRate(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	config := goconf.NewConfigFile()
+	config.AddOption("load", "maxmessagerate", "100")
+	reporter := NewLoadReporter(config)
+
+	now := time.Now()
+	reporter.Update(now, LoadStats{})
+	for range 10 * 60 {
+		for range 40 {
+			reporter.MessageReceived()
+		}
+		now = now.Add(time.Second)
+		reporter.Update(now, LoadStats{})
+	}
+
+	load := reporter.GetLoad()
+	assert.InDelta(40, load.MessageRate, 0.1)
+	assert.InDelta(50, load.Score, 0.1)
+
+	// The rate decreases if no messages are received.
+	now = now.Add(time.Minute)
+	reporter.Update(now, LoadStats{})
+	load = reporter.GetLoad()
+	assert.Less(load.MessageRate, float64(20))
+}
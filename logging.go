@@ -0,0 +1,419 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dlintw/goconf"
+)
+
+type LogSubsystem string
+
+const (
+	LogSubsystemApp     LogSubsystem = "app"
+	LogSubsystemHub     LogSubsystem = "hub"
+	LogSubsystemBackend LogSubsystem = "backend"
+	LogSubsystemMcu     LogSubsystem = "mcu"
+	LogSubsystemProxy   LogSubsystem = "proxy"
+	LogSubsystemGrpc    LogSubsystem = "grpc"
+
+	LogFormatText = "text"
+	LogFormatJson = "json"
+
+	defaultLogLevel = slog.LevelInfo
+
+	defaultLogRateLimitInterval = 10 * time.Second
+)
+
+func init() {
+	RegisterLoggingStats()
+}
+
+var (
+	logSubsystems = []LogSubsystem{
+		LogSubsystemApp,
+		LogSubsystemHub,
+		LogSubsystemBackend,
+		LogSubsystemMcu,
+		LogSubsystemProxy,
+		LogSubsystemGrpc,
+	}
+
+	// The text format is written through the "log" package, so its flags and
+	// output (e.g. in tests) are respected.
+	textLogHandler = slog.Default().Handler()
+
+	logHandler atomic.Pointer[slog.Handler]
+
+	loggersLock sync.Mutex
+	loggers     = make(map[LogSubsystem]*Logger)
+	// Levels as configured, used to restore the levels after debug logging
+	// was toggled.
+	configuredLogLevels = make(map[LogSubsystem]slog.Level)
+	debugLogging        bool
+
+	logRateLimit = newLogRateLimiter()
+
+	appLog     = NewLogger(LogSubsystemApp)
+	hubLog     = NewLogger(LogSubsystemHub)
+	backendLog = NewLogger(LogSubsystemBackend)
+	mcuLog     = NewLogger(LogSubsystemMcu)
+	grpcLog    = NewLogger(LogSubsystemGrpc)
+)
+
+// Logger writes structured log messages of a subsystem. The level of each
+// subsystem can be configured separately.
+type Logger struct {
+	*slog.Logger
+
+	subsystem LogSubsystem
+	level     *slog.LevelVar
+}
+
+// NewLogger returns the logger for the given subsystem. Loggers are shared, so
+// all callers of a subsystem will use the same level.
+func NewLogger(subsystem LogSubsystem) *Logger {
+	loggersLock.Lock()
+	defer loggersLock.Unlock()
+
+	if logger, found := loggers[subsystem]; found {
+		return logger
+	}
+
+	level := &slog.LevelVar{}
+	level.Set(defaultLogLevel)
+	handler := &subsystemLogHandler{
+		level: level,
+	}
+	logger := &Logger{
+		Logger:    slog.New(handler).With(slog.String("subsystem", string(subsystem))),
+		subsystem: subsystem,
+		level:     level,
+	}
+	loggers[subsystem] = logger
+	return logger
+}
+
+// With returns a logger of the same subsystem that adds the given attributes
+// to all messages, e.g. the ids of a session or room so the messages can be
+// correlated.
+func (l *Logger) With(args ...any) *Logger {
+	return &Logger{
+		Logger:    l.Logger.With(args...),
+		subsystem: l.subsystem,
+		level:     l.level,
+	}
+}
+
+func (l *Logger) Level() slog.Level {
+	return l.level.Level()
+}
+
+func (l *Logger) SetLevel(level slog.Level) {
+	l.level.Set(level)
+}
+
+func (l *Logger) logf(level slog.Level, eventType RecentEventType, ratelimit bool, format string, args ...any) {
+	ctx := context.Background()
+	enabled := l.Enabled(ctx, level)
+	if !enabled && eventType == "" {
+		return
+	}
+
+	message := fmt.Sprintf(format, args...)
+	if eventType != "" {
+		recordEvent(eventType, l.subsystem, message)
+	}
+	if !enabled {
+		return
+	}
+
+	var pcs [1]uintptr
+	// Skip runtime.Callers, logf and the exported wrapper.
+	runtime.Callers(3, pcs[:])
+	now := time.Now()
+	r := slog.NewRecord(now, level, message, pcs[0])
+	if !ratelimit {
+		_ = l.Handler().Handle(ctx, r)
+		if level >= slog.LevelError {
+			reportError(l.subsystem, message)
+		}
+		return
+	}
+
+	allowed, suppressed := logRateLimit.allow(pcs[0], now)
+	if !allowed {
+		statsLoggingSuppressedTotal.WithLabelValues(string(l.subsystem), level.String()).Inc()
+		return
+	}
+	if suppressed > 0 {
+		r.AddAttrs(slog.Int("suppressed", suppressed))
+	}
+	_ = l.Handler().Handle(ctx, r)
+	if level >= slog.LevelError {
+		reportError(l.subsystem, message)
+	}
+}
+
+func (l *Logger) Debugf(format string, args ...any) {
+	l.logf(slog.LevelDebug, "", true, format, args...)
+}
+
+func (l *Logger) Infof(format string, args ...any) {
+	l.logf(slog.LevelInfo, "", true, format, args...)
+}
+
+func (l *Logger) Warnf(format string, args ...any) {
+	l.logf(slog.LevelWarn, "", true, format, args...)
+}
+
+// Errorf logs an error, which is also kept in the recent events and sent to
+// the error reporting (if enabled).
+func (l *Logger) Errorf(format string, args ...any) {
+	l.logf(slog.LevelError, RecentEventTypeError, true, format, args...)
+}
+
+// Eventf logs a message and keeps it in the recent events with the given type,
+// even if the level is not enabled for logging.
+func (l *Logger) Eventf(eventType RecentEventType, level slog.Level, format string, args ...any) {
+	l.logf(level, eventType, true, format, args...)
+}
+
+// Fatalf logs an error and terminates the process.
+func (l *Logger) Fatalf(format string, args ...any) {
+	l.logf(slog.LevelError, "", false, format, args...)
+	flushErrorReporting()
+	os.Exit(1)
+}
+
+type logRateLimitState struct {
+	start      time.Time
+	count      int
+	suppressed int
+}
+
+// logRateLimiter limits the number of messages that are logged from the same
+// call site in an interval, so repetitive messages (e.g. caused by a single
+// misbehaving client) can't flood the logs.
+type logRateLimiter struct {
+	mu sync.Mutex
+
+	limit    int
+	interval time.Duration
+	sites    map[uintptr]*logRateLimitState
+}
+
+func newLogRateLimiter() *logRateLimiter {
+	return &logRateLimiter{
+		interval: defaultLogRateLimitInterval,
+		sites:    make(map[uintptr]*logRateLimitState),
+	}
+}
+
+func (l *logRateLimiter) setLimit(limit int, interval time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.limit = limit
+	l.interval = interval
+	clear(l.sites)
+}
+
+// allow returns true if a message from the given call site may be logged and
+// the number of messages that were suppressed since the last logged message.
+func (l *logRateLimiter) allow(pc uintptr, now time.Time) (bool, int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.limit <= 0 {
+		return true, 0
+	}
+
+	state, found := l.sites[pc]
+	if !found {
+		state = &logRateLimitState{
+			start: now,
+		}
+		l.sites[pc] = state
+	} else if now.Sub(state.start) >= l.interval {
+		state.start = now
+		state.count = 0
+	}
+
+	if state.count >= l.limit {
+		state.suppressed++
+		return false, 0
+	}
+
+	state.count++
+	suppressed := state.suppressed
+	state.suppressed = 0
+	return true, suppressed
+}
+
+// subsystemLogHandler filters records by the level of the subsystem and
+// forwards them to the currently configured handler.
+type subsystemLogHandler struct {
+	level *slog.LevelVar
+	wrap  []func(slog.Handler) slog.Handler
+}
+
+func (h *subsystemLogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *subsystemLogHandler) Handle(ctx context.Context, r slog.Record) error {
+	handler := textLogHandler
+	if current := logHandler.Load(); current != nil {
+		handler = *current
+	}
+	for _, f := range h.wrap {
+		handler = f(handler)
+	}
+	return handler.Handle(ctx, r)
+}
+
+func (h *subsystemLogHandler) withWrap(f func(slog.Handler) slog.Handler) slog.Handler {
+	return &subsystemLogHandler{
+		level: h.level,
+		wrap:  append(slices.Clip(h.wrap), f),
+	}
+}
+
+func (h *subsystemLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.withWrap(func(handler slog.Handler) slog.Handler {
+		return handler.WithAttrs(attrs)
+	})
+}
+
+func (h *subsystemLogHandler) WithGroup(name string) slog.Handler {
+	return h.withWrap(func(handler slog.Handler) slog.Handler {
+		return handler.WithGroup(name)
+	})
+}
+
+// ParseLogLevel returns the level for names like "debug" or "warn".
+func ParseLogLevel(value string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(value))); err != nil {
+		return defaultLogLevel, fmt.Errorf("invalid log level %s", value)
+	}
+	return level, nil
+}
+
+func newLogHandler(format string, w io.Writer) (slog.Handler, error) {
+	// Levels are filtered by the subsystem loggers.
+	options := &slog.HandlerOptions{
+		AddSource: true,
+		Level:     slog.Level(-100),
+	}
+	switch format {
+	case "":
+		fallthrough
+	case LogFormatText:
+		return textLogHandler, nil
+	case LogFormatJson:
+		return slog.NewJSONHandler(w, options), nil
+	default:
+		return nil, fmt.Errorf("unsupported log format %s", format)
+	}
+}
+
+// ConfigureLogging sets the output format and the levels of the subsystems
+// from the "[logging]" section of the configuration. It can be called again
+// to reload the configuration.
+func ConfigureLogging(config *goconf.ConfigFile) error {
+	format, _ := config.GetString("logging", "format")
+	handler, err := newLogHandler(format, os.Stderr)
+	if err != nil {
+		return err
+	}
+
+	defaultLevel := defaultLogLevel
+	if value, _ := config.GetString("logging", "level"); value != "" {
+		if defaultLevel, err = ParseLogLevel(value); err != nil {
+			return err
+		}
+	}
+
+	levels := make(map[LogSubsystem]slog.Level)
+	for _, subsystem := range logSubsystems {
+		levels[subsystem] = defaultLevel
+		if value, _ := config.GetString("logging", string(subsystem)); value != "" {
+			level, err := ParseLogLevel(value)
+			if err != nil {
+				return fmt.Errorf("invalid log level for %s: %w", subsystem, err)
+			}
+			levels[subsystem] = level
+		}
+	}
+
+	limit, _ := config.GetInt("logging", "ratelimit")
+	interval := defaultLogRateLimitInterval
+	if value, _ := config.GetInt("logging", "ratelimitinterval"); value > 0 {
+		interval = time.Duration(value) * time.Second
+	}
+
+	logHandler.Store(&handler)
+	logRateLimit.setLimit(limit, interval)
+
+	loggersLock.Lock()
+	defer loggersLock.Unlock()
+	configuredLogLevels = levels
+	applyLogLevelsLocked()
+	return nil
+}
+
+func applyLogLevelsLocked() {
+	for subsystem, logger := range loggers {
+		level, found := configuredLogLevels[subsystem]
+		if !found {
+			level = defaultLogLevel
+		}
+		if debugLogging {
+			level = min(level, slog.LevelDebug)
+		}
+		logger.SetLevel(level)
+	}
+}
+
+// ToggleDebugLogging switches all subsystems to debug logging or back to the
+// configured levels. Returns true if debug logging is now enabled.
+func ToggleDebugLogging() bool {
+	loggersLock.Lock()
+	defer loggersLock.Unlock()
+
+	debugLogging = !debugLogging
+	applyLogLevelsLocked()
+	return debugLogging
+}
@@ -0,0 +1,207 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dlintw/goconf"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLogLevel(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	testcases := map[string]slog.Level{
+		"debug": slog.LevelDebug,
+		"INFO":  slog.LevelInfo,
+		"warn":  slog.LevelWarn,
+		"error": slog.LevelError,
+	}
+	for value, expected := range testcases {
+		level, err := ParseLogLevel(value)
+		if assert.NoError(err, "failed for %s", value) {
+			assert.Equal(expected, level, "failed for %s", value)
+		}
+	}
+
+	_, err := ParseLogLevel("foo")
+	assert.Error(err)
+}
+
+func TestConfigureLogging(t *testing.T) {
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	require := require.New(t)
+	t.Cleanup(func() {
+		require.NoError(ConfigureLogging(goconf.NewConfigFile()))
+	})
+
+	config := goconf.NewConfigFile()
+	config.AddOption("logging", "level", "warn")
+	config.AddOption("logging", "mcu", "debug")
+	require.NoError(ConfigureLogging(config))
+	assert.Equal(slog.LevelWarn, hubLog.Level())
+	assert.Equal(slog.LevelWarn, backendLog.Level())
+	assert.Equal(slog.LevelDebug, mcuLog.Level())
+
+	assert.True(ToggleDebugLogging())
+	assert.Equal(slog.LevelDebug, hubLog.Level())
+	assert.Equal(slog.LevelDebug, mcuLog.Level())
+	assert.False(ToggleDebugLogging())
+	assert.Equal(slog.LevelWarn, hubLog.Level())
+	assert.Equal(slog.LevelDebug, mcuLog.Level())
+
+	config = goconf.NewConfigFile()
+	config.AddOption("logging", "format", "xml")
+	assert.Error(ConfigureLogging(config))
+	config = goconf.NewConfigFile()
+	config.AddOption("logging", "hub", "verbose")
+	assert.Error(ConfigureLogging(config))
+	// Invalid configurations don't change the levels.
+	assert.Equal(slog.LevelWarn, hubLog.Level())
+}
+
+func TestLoggerJson(t *testing.T) {
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	var buf bytes.Buffer
+	handler, err := newLogHandler(LogFormatJson, &buf)
+	require.NoError(t, err)
+	prev := logHandler.Swap(&handler)
+	t.Cleanup(func() {
+		logHandler.Store(prev)
+	})
+
+	logger := NewLogger(LogSubsystem("test-json"))
+	logger.SetLevel(slog.LevelInfo)
+	logger.Debugf("Not logged %d", 1)
+	logger.Warnf("Hello %s", "world")
+	logger.Info("structured", "key", "value")
+	// Derived loggers add their attributes and share the level.
+	derived := logger.With(slog.String("session", "the-session"))
+	derived.Infof("Session %s", "message")
+	logger.SetLevel(slog.LevelWarn)
+	derived.Infof("Not logged %d", 2)
+
+	var records []map[string]any
+	for line := range strings.SplitSeq(buf.String(), "\n") {
+		var record map[string]any
+		if line == "" || json.Unmarshal([]byte(line), &record) != nil || record["subsystem"] != "test-json" {
+			continue
+		}
+		records = append(records, record)
+	}
+	if assert.Len(records, 3) {
+		assert.Equal("WARN", records[0]["level"])
+		assert.Equal("Hello world", records[0]["msg"])
+		if source, ok := records[0]["source"].(map[string]any); assert.True(ok) {
+			assert.Contains(source["file"], "logging_test.go")
+		}
+		assert.Equal("INFO", records[1]["level"])
+		assert.Equal("structured", records[1]["msg"])
+		assert.Equal("value", records[1]["key"])
+		assert.Equal("Session message", records[2]["msg"])
+		assert.Equal("the-session", records[2]["session"])
+		if source, ok := records[2]["source"].(map[string]any); assert.True(ok) {
+			assert.Contains(source["file"], "logging_test.go")
+		}
+	}
+}
+
+func TestLogRateLimiter(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	limiter := newLogRateLimiter()
+
+	now := time.Now()
+	for range 10 {
+		allowed, suppressed := limiter.allow(1, now)
+		assert.True(allowed)
+		assert.Equal(0, suppressed)
+	}
+
+	limiter.setLimit(2, time.Second)
+	for range 2 {
+		allowed, suppressed := limiter.allow(1, now)
+		assert.True(allowed)
+		assert.Equal(0, suppressed)
+	}
+	for range 3 {
+		allowed, _ := limiter.allow(1, now)
+		assert.False(allowed)
+	}
+	// Other call sites are limited separately.
+	allowed, _ := limiter.allow(2, now)
+	assert.True(allowed)
+
+	now = now.Add(time.Second)
+	allowed, suppressed := limiter.allow(1, now)
+	assert.True(allowed)
+	assert.Equal(3, suppressed)
+	allowed, suppressed = limiter.allow(1, now)
+	assert.True(allowed)
+	assert.Equal(0, suppressed)
+}
+
+func TestLoggerRateLimit(t *testing.T) {
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	var buf bytes.Buffer
+	handler, err := newLogHandler(LogFormatJson, &buf)
+	require.NoError(t, err)
+	prev := logHandler.Swap(&handler)
+	logRateLimit.setLimit(2, time.Hour)
+	t.Cleanup(func() {
+		logHandler.Store(prev)
+		logRateLimit.setLimit(0, defaultLogRateLimitInterval)
+	})
+
+	logger := NewLogger(LogSubsystem("test-ratelimit"))
+	logger.SetLevel(slog.LevelInfo)
+	suppressed := statsLoggingSuppressedTotal.WithLabelValues("test-ratelimit", "WARN")
+	initial := testutil.ToFloat64(suppressed)
+	for i := range 5 {
+		logger.Warnf("Message %d", i)
+	}
+	checkStatsValue(t, suppressed, initial+3)
+
+	var records []map[string]any
+	for line := range strings.SplitSeq(buf.String(), "\n") {
+		var record map[string]any
+		if line == "" || json.Unmarshal([]byte(line), &record) != nil || record["subsystem"] != "test-ratelimit" {
+			continue
+		}
+		records = append(records, record)
+	}
+	if assert.Len(records, 2) {
+		assert.Equal("Message 0", records[0]["msg"])
+		assert.Equal("Message 1", records[1]["msg"])
+	}
+}
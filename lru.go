@@ -24,62 +24,194 @@ package signaling
 import (
 	"container/list"
 	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
 )
 
-type cacheEntry struct {
-	key   string
-	value any
+func init() {
+	RegisterLruCacheStats()
+}
+
+type cacheEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
 }
 
-type LruCache struct {
+func (e *cacheEntry[K, V]) isExpired(now time.Time) bool {
+	return !e.expires.IsZero() && !e.expires.After(now)
+}
+
+// LruCache is a cache with an optional maximum size that removes the least
+// recently used entries if the size is exceeded. Entries can optionally
+// expire after a given time.
+type LruCache[K comparable, V any] struct {
 	size    int
 	mu      sync.Mutex
 	entries *list.List
-	data    map[string]*list.Element
+	data    map[K]*list.Element
+	onEvict func(key K, value V)
+
+	// Only set for named caches.
+	statsHits      prometheus.Counter
+	statsMisses    prometheus.Counter
+	statsEvictions prometheus.Counter
+	statsEntries   prometheus.Gauge
+
+	// Can be overwritten by tests.
+	now func() time.Time
 }
 
-func NewLruCache(size int) *LruCache {
-	return &LruCache{
+// NewLruCache creates a new cache with the given maximum size. A size of 0
+// creates an unbound cache.
+func NewLruCache[K comparable, V any](size int) *LruCache[K, V] {
+	return &LruCache[K, V]{
 		size:    size,
 		entries: list.New(),
-		data:    make(map[string]*list.Element),
+		data:    make(map[K]*list.Element),
+		now:     time.Now,
+	}
+}
+
+// NewNamedLruCache creates a new cache like NewLruCache that reports hits,
+// misses, evictions and the number of entries as metrics with the given name.
+// Caches with the same name share their metrics.
+func NewNamedLruCache[K comparable, V any](name string, size int) *LruCache[K, V] {
+	result := NewLruCache[K, V](size)
+	labels := prometheus.Labels{"cache": name}
+	result.statsHits = statsCacheHitsTotal.With(labels)
+	result.statsMisses = statsCacheMissesTotal.With(labels)
+	result.statsEvictions = statsCacheEvictionsTotal.With(labels)
+	result.statsEntries = statsCacheEntries.With(labels)
+	return result
+}
+
+func (c *LruCache[K, V]) countHit() {
+	if c.statsHits != nil {
+		c.statsHits.Inc()
+	}
+}
+
+func (c *LruCache[K, V]) countMiss() {
+	if c.statsMisses != nil {
+		c.statsMisses.Inc()
 	}
 }
 
-func (c *LruCache) Set(key string, value any) {
+func (c *LruCache[K, V]) countEvictions(count int) {
+	if c.statsEvictions != nil && count > 0 {
+		c.statsEvictions.Add(float64(count))
+	}
+}
+
+// OnEvict sets a callback that is called for entries that are removed because
+// the cache is full or because they expired. The callback is called without
+// holding the lock of the cache.
+func (c *LruCache[K, V]) OnEvict(f func(key K, value V)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onEvict = f
+}
+
+// Set stores a value that doesn't expire.
+func (c *LruCache[K, V]) Set(key K, value V) {
+	c.SetTTL(key, value, 0)
+}
+
+// SetTTL stores a value that expires after the given duration. A duration of
+// 0 or less stores a value that doesn't expire.
+func (c *LruCache[K, V]) SetTTL(key K, value V, ttl time.Duration) {
+	var expires time.Time
+	if ttl > 0 {
+		expires = c.now().Add(ttl)
+	}
+
 	c.mu.Lock()
 	if v, found := c.data[key]; found {
 		c.entries.MoveToFront(v)
-		v.Value.(*cacheEntry).value = value
+		entry := v.Value.(*cacheEntry[K, V])
+		entry.value = value
+		entry.expires = expires
 		c.mu.Unlock()
 		return
 	}
 
-	v := c.entries.PushFront(&cacheEntry{
-		key:   key,
-		value: value,
+	v := c.entries.PushFront(&cacheEntry[K, V]{
+		key:     key,
+		value:   value,
+		expires: expires,
 	})
 	c.data[key] = v
+	if c.statsEntries != nil {
+		c.statsEntries.Inc()
+	}
+	var evicted *cacheEntry[K, V]
 	if c.size > 0 && c.entries.Len() > c.size {
-		c.removeOldestLocked()
+		evicted = c.removeOldestLocked()
+		c.countEvictions(1)
 	}
+	onEvict := c.onEvict
 	c.mu.Unlock()
+
+	if evicted != nil && onEvict != nil {
+		onEvict(evicted.key, evicted.value)
+	}
 }
 
-func (c *LruCache) Get(key string) any {
+// Get returns the value for the given key and true if the key exists and has
+// not expired yet.
+func (c *LruCache[K, V]) Get(key K) (V, bool) {
 	c.mu.Lock()
-	if v, found := c.data[key]; found {
-		c.entries.MoveToFront(v)
-		value := v.Value.(*cacheEntry).value
+	v, found := c.data[key]
+	if !found {
+		c.countMiss()
 		c.mu.Unlock()
-		return value
+		var defaultValue V
+		return defaultValue, false
 	}
 
+	entry := v.Value.(*cacheEntry[K, V])
+	if entry.isExpired(c.now()) {
+		c.removeElement(v)
+		c.countMiss()
+		c.countEvictions(1)
+		onEvict := c.onEvict
+		c.mu.Unlock()
+		if onEvict != nil {
+			onEvict(entry.key, entry.value)
+		}
+		var defaultValue V
+		return defaultValue, false
+	}
+
+	c.entries.MoveToFront(v)
+	c.countHit()
 	c.mu.Unlock()
-	return nil
+	return entry.value, true
 }
 
-func (c *LruCache) Remove(key string) {
+// GetOrCompute returns the value for the given key. If the key doesn't exist
+// or has expired, the compute function is called and a successful result is
+// stored with the given time-to-live. Errors are not cached.
+//
+// The compute function is called without holding the lock of the cache, so it
+// may be called concurrently for the same key.
+func (c *LruCache[K, V]) GetOrCompute(key K, ttl time.Duration, compute func() (V, error)) (V, error) {
+	if value, found := c.Get(key); found {
+		return value, nil
+	}
+
+	value, err := compute()
+	if err != nil {
+		return value, err
+	}
+
+	c.SetTTL(key, value, ttl)
+	return value, nil
+}
+
+func (c *LruCache[K, V]) Remove(key K) {
 	c.mu.Lock()
 	if v, found := c.data[key]; found {
 		c.removeElement(v)
@@ -87,26 +219,59 @@ func (c *LruCache) Remove(key string) {
 	c.mu.Unlock()
 }
 
-func (c *LruCache) removeOldestLocked() {
+func (c *LruCache[K, V]) removeOldestLocked() *cacheEntry[K, V] {
 	v := c.entries.Back()
-	if v != nil {
-		c.removeElement(v)
+	if v == nil {
+		return nil
 	}
+
+	return c.removeElement(v)
 }
 
-func (c *LruCache) RemoveOldest() {
+func (c *LruCache[K, V]) RemoveOldest() {
 	c.mu.Lock()
 	c.removeOldestLocked()
 	c.mu.Unlock()
 }
 
-func (c *LruCache) removeElement(e *list.Element) {
+// RemoveExpired removes all expired entries and returns the number of removed
+// entries.
+func (c *LruCache[K, V]) RemoveExpired() int {
+	now := c.now()
+	var evicted []*cacheEntry[K, V]
+	c.mu.Lock()
+	for v := c.entries.Front(); v != nil; {
+		next := v.Next()
+		if entry := v.Value.(*cacheEntry[K, V]); entry.isExpired(now) {
+			evicted = append(evicted, c.removeElement(v))
+		}
+		v = next
+	}
+	c.countEvictions(len(evicted))
+	onEvict := c.onEvict
+	c.mu.Unlock()
+
+	if onEvict != nil {
+		for _, entry := range evicted {
+			onEvict(entry.key, entry.value)
+		}
+	}
+	return len(evicted)
+}
+
+func (c *LruCache[K, V]) removeElement(e *list.Element) *cacheEntry[K, V] {
 	c.entries.Remove(e)
-	entry := e.Value.(*cacheEntry)
+	entry := e.Value.(*cacheEntry[K, V])
 	delete(c.data, entry.key)
+	if c.statsEntries != nil {
+		c.statsEntries.Dec()
+	}
+	return entry
 }
 
-func (c *LruCache) Len() int {
+// Len returns the number of entries in the cache, including expired entries
+// that have not been removed yet.
+func (c *LruCache[K, V]) Len() int {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	return c.entries.Len()
@@ -0,0 +1,64 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2017 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	statsCacheHitsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "signaling",
+		Subsystem: "cache",
+		Name:      "hits_total",
+		Help:      "The total number of cache lookups that found an entry",
+	}, []string{"cache"})
+	statsCacheMissesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "signaling",
+		Subsystem: "cache",
+		Name:      "misses_total",
+		Help:      "The total number of cache lookups that didn't find an entry",
+	}, []string{"cache"})
+	statsCacheEvictionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "signaling",
+		Subsystem: "cache",
+		Name:      "evictions_total",
+		Help:      "The total number of cache entries removed because the cache was full or they expired",
+	}, []string{"cache"})
+	statsCacheEntries = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "signaling",
+		Subsystem: "cache",
+		Name:      "entries",
+		Help:      "The current number of cache entries",
+	}, []string{"cache"})
+
+	lruCacheStats = []prometheus.Collector{
+		statsCacheHitsTotal,
+		statsCacheMissesTotal,
+		statsCacheEvictionsTotal,
+		statsCacheEntries,
+	}
+)
+
+func RegisterLruCacheStats() {
+	registerAll(lruCacheStats...)
+}
@@ -22,15 +22,18 @@
 package signaling
 
 import (
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestLruUnbound(t *testing.T) {
 	assert := assert.New(t)
-	lru := NewLruCache(0)
+	lru := NewLruCache[string, int](0)
 	count := 10
 	for i := range count {
 		key := fmt.Sprintf("%d", i)
@@ -39,7 +42,7 @@ func TestLruUnbound(t *testing.T) {
 	assert.Equal(count, lru.Len())
 	for i := range count {
 		key := fmt.Sprintf("%d", i)
-		if value := lru.Get(key); assert.NotNil(value, "No value found for %s", key) {
+		if value, found := lru.Get(key); assert.True(found, "No value found for %s", key) {
 			assert.EqualValues(i, value)
 		}
 	}
@@ -48,11 +51,11 @@ func TestLruUnbound(t *testing.T) {
 	assert.Equal(count-1, lru.Len())
 	for i := range count {
 		key := fmt.Sprintf("%d", i)
-		value := lru.Get(key)
+		value, found := lru.Get(key)
 		if i == 0 {
-			assert.Nil(value, "The value for key %s should have been removed", key)
+			assert.False(found, "The value for key %s should have been removed", key)
 			continue
-		} else if assert.NotNil(value, "No value found for %s", key) {
+		} else if assert.True(found, "No value found for %s", key) {
 			assert.EqualValues(i, value)
 		}
 	}
@@ -68,7 +71,7 @@ func TestLruUnbound(t *testing.T) {
 	// NOTE: The same ordering as the Set calls above.
 	for i := count - 1; i >= 1; i-- {
 		key := fmt.Sprintf("%d", i)
-		if value := lru.Get(key); assert.NotNil(value, "No value found for %s", key) {
+		if value, found := lru.Get(key); assert.True(found, "No value found for %s", key) {
 			assert.EqualValues(i, value)
 		}
 	}
@@ -78,11 +81,11 @@ func TestLruUnbound(t *testing.T) {
 	assert.Equal(count-2, lru.Len())
 	for i := range count {
 		key := fmt.Sprintf("%d", i)
-		value := lru.Get(key)
+		value, found := lru.Get(key)
 		if i == 0 || i == count-1 {
-			assert.Nil(value, "The value for key %s should have been removed", key)
+			assert.False(found, "The value for key %s should have been removed", key)
 			continue
-		} else if assert.NotNil(value, "No value found for %s", key) {
+		} else if assert.True(found, "No value found for %s", key) {
 			assert.EqualValues(i, value)
 		}
 	}
@@ -93,11 +96,11 @@ func TestLruUnbound(t *testing.T) {
 	assert.Equal(count-3, lru.Len())
 	for i := range count {
 		key := fmt.Sprintf("%d", i)
-		value := lru.Get(key)
+		value, found := lru.Get(key)
 		if i == 0 || i == count-1 || i == count/2 {
-			assert.Nil(value, "The value for key %s should have been removed", key)
+			assert.False(found, "The value for key %s should have been removed", key)
 			continue
-		} else if assert.NotNil(value, "No value found for %s", key) {
+		} else if assert.True(found, "No value found for %s", key) {
 			assert.EqualValues(i, value)
 		}
 	}
@@ -106,7 +109,7 @@ func TestLruUnbound(t *testing.T) {
 func TestLruBound(t *testing.T) {
 	assert := assert.New(t)
 	size := 2
-	lru := NewLruCache(size)
+	lru := NewLruCache[string, int](size)
 	count := 10
 	for i := range count {
 		key := fmt.Sprintf("%d", i)
@@ -116,12 +119,144 @@ func TestLruBound(t *testing.T) {
 	// Only the last "size" entries have been stored.
 	for i := range count {
 		key := fmt.Sprintf("%d", i)
-		value := lru.Get(key)
+		value, found := lru.Get(key)
 		if i < count-size {
-			assert.Nil(value, "The value for key %s should have been removed", key)
+			assert.False(found, "The value for key %s should have been removed", key)
 			continue
-		} else if assert.NotNil(value, "No value found for %s", key) {
+		} else if assert.True(found, "No value found for %s", key) {
 			assert.EqualValues(i, value)
 		}
 	}
 }
+
+func TestLruExpiration(t *testing.T) {
+	assert := assert.New(t)
+	lru := NewLruCache[string, int](0)
+	now := time.Now()
+	lru.now = func() time.Time {
+		return now
+	}
+
+	var evicted []string
+	lru.OnEvict(func(key string, value int) {
+		evicted = append(evicted, fmt.Sprintf("%s=%d", key, value))
+	})
+
+	lru.SetTTL("a", 1, time.Second)
+	lru.SetTTL("b", 2, 2*time.Second)
+	lru.Set("c", 3)
+	if value, found := lru.Get("a"); assert.True(found) {
+		assert.Equal(1, value)
+	}
+
+	now = now.Add(time.Second)
+	_, found := lru.Get("a")
+	assert.False(found)
+	assert.Equal([]string{"a=1"}, evicted)
+	assert.Equal(2, lru.Len())
+
+	// Updating a value also updates its expiration.
+	lru.Set("b", 4)
+	now = now.Add(time.Hour)
+	assert.Equal(0, lru.RemoveExpired())
+	if value, found := lru.Get("b"); assert.True(found) {
+		assert.Equal(4, value)
+	}
+
+	lru.SetTTL("d", 5, time.Second)
+	now = now.Add(time.Second)
+	assert.Equal(1, lru.RemoveExpired())
+	assert.Equal([]string{"a=1", "d=5"}, evicted)
+
+	// Explicitly removed entries are not evicted.
+	lru.Remove("c")
+	assert.Equal([]string{"a=1", "d=5"}, evicted)
+	assert.Equal(1, lru.Len())
+}
+
+func TestLruEvict(t *testing.T) {
+	assert := assert.New(t)
+	lru := NewLruCache[string, int](2)
+	var evicted []string
+	lru.OnEvict(func(key string, value int) {
+		evicted = append(evicted, key)
+	})
+
+	lru.Set("a", 1)
+	lru.Set("b", 2)
+	lru.Get("a")
+	lru.Set("c", 3)
+	assert.Equal([]string{"b"}, evicted)
+	assert.Equal(2, lru.Len())
+}
+
+func TestLruGetOrCompute(t *testing.T) {
+	assert := assert.New(t)
+	lru := NewLruCache[string, int](0)
+	now := time.Now()
+	lru.now = func() time.Time {
+		return now
+	}
+
+	calls := 0
+	compute := func() (int, error) {
+		calls++
+		return calls, nil
+	}
+	if value, err := lru.GetOrCompute("a", time.Second, compute); assert.NoError(err) {
+		assert.Equal(1, value)
+	}
+	if value, err := lru.GetOrCompute("a", time.Second, compute); assert.NoError(err) {
+		assert.Equal(1, value)
+	}
+
+	now = now.Add(time.Second)
+	if value, err := lru.GetOrCompute("a", time.Second, compute); assert.NoError(err) {
+		assert.Equal(2, value)
+	}
+
+	// Errors are not cached.
+	testErr := errors.New("test error")
+	_, err := lru.GetOrCompute("b", 0, func() (int, error) {
+		return 0, testErr
+	})
+	assert.ErrorIs(err, testErr)
+	_, found := lru.Get("b")
+	assert.False(found)
+	assert.Equal(2, calls)
+}
+
+func TestLruStats(t *testing.T) {
+	lru := NewNamedLruCache[string, int]("test_stats", 2)
+	labels := prometheus.Labels{"cache": "test_stats"}
+	now := time.Now()
+	lru.now = func() time.Time {
+		return now
+	}
+
+	lru.Set("a", 1)
+	lru.SetTTL("b", 2, time.Second)
+	checkStatsValue(t, statsCacheEntries.With(labels), 2)
+	lru.Get("a")
+	lru.Get("c")
+	checkStatsValue(t, statsCacheHitsTotal.With(labels), 1)
+	checkStatsValue(t, statsCacheMissesTotal.With(labels), 1)
+
+	// The oldest entry "b" is evicted.
+	lru.Set("c", 3)
+	checkStatsValue(t, statsCacheEvictionsTotal.With(labels), 1)
+	checkStatsValue(t, statsCacheEntries.With(labels), 2)
+
+	lru.SetTTL("d", 4, time.Second)
+	checkStatsValue(t, statsCacheEvictionsTotal.With(labels), 2)
+	now = now.Add(time.Second)
+	lru.Get("d")
+	checkStatsValue(t, statsCacheMissesTotal.With(labels), 2)
+	checkStatsValue(t, statsCacheEvictionsTotal.With(labels), 3)
+	checkStatsValue(t, statsCacheEntries.With(labels), 1)
+
+	lru.Remove("c")
+	checkStatsValue(t, statsCacheEntries.With(labels), 0)
+	checkStatsValue(t, statsCacheEvictionsTotal.With(labels), 3)
+	collectAndLint(t, lruCacheStats...)
+}
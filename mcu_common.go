@@ -24,7 +24,6 @@ package signaling
 import (
 	"context"
 	"fmt"
-	"log"
 	"sync/atomic"
 	"time"
 
@@ -32,8 +31,9 @@ import (
 )
 
 const (
-	McuTypeJanus = "janus"
-	McuTypeProxy = "proxy"
+	McuTypeJanus   = "janus"
+	McuTypeProxy   = "proxy"
+	McuTypeLivekit = "livekit"
 
 	McuTypeDefault = McuTypeJanus
 
@@ -71,6 +71,14 @@ type McuInitiator interface {
 	Country() string
 }
 
+func getInitiatorCountry(initiator McuInitiator) string {
+	if initiator == nil {
+		return ""
+	}
+
+	return initiator.Country()
+}
+
 type McuSettings interface {
 	MaxStreamBitrate() int32
 	MaxScreenBitrate() int32
@@ -107,14 +115,14 @@ func (s *mcuCommonSettings) load(config *goconf.ConfigFile) error {
 	if maxStreamBitrate <= 0 {
 		maxStreamBitrate = defaultMaxStreamBitrate
 	}
-	log.Printf("Maximum bandwidth %d bits/sec per publishing stream", maxStreamBitrate)
+	mcuLog.Infof("Maximum bandwidth %d bits/sec per publishing stream", maxStreamBitrate)
 	s.maxStreamBitrate.Store(int32(maxStreamBitrate))
 
 	maxScreenBitrate, _ := config.GetInt("mcu", "maxscreenbitrate")
 	if maxScreenBitrate <= 0 {
 		maxScreenBitrate = defaultMaxScreenBitrate
 	}
-	log.Printf("Maximum bandwidth %d bits/sec per screensharing stream", maxScreenBitrate)
+	mcuLog.Infof("Maximum bandwidth %d bits/sec per screensharing stream", maxScreenBitrate)
 	s.maxScreenBitrate.Store(int32(maxScreenBitrate))
 	return nil
 }
@@ -122,7 +130,7 @@ func (s *mcuCommonSettings) load(config *goconf.ConfigFile) error {
 type Mcu interface {
 	Start(ctx context.Context) error
 	Stop()
-	Reload(config *goconf.ConfigFile)
+	Reload(config *goconf.ConfigFile) error
 
 	SetOnConnected(func())
 	SetOnDisconnected(func())
@@ -26,7 +26,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -111,7 +111,7 @@ func getPluginIntValue(data janus.PluginData, pluginName string, key string) uin
 
 	result, err := convertIntValue(val)
 	if err != nil {
-		log.Printf("Invalid value %+v for %s: %s", val, key, err)
+		mcuLog.Warnf("Invalid value %+v for %s: %s", val, key, err)
 		result = 0
 	}
 	return result
@@ -163,7 +163,7 @@ func (s *mcuJanusSettings) load(config *goconf.ConfigFile) error {
 		mcuTimeoutSeconds = defaultMcuTimeoutSeconds
 	}
 	mcuTimeout := time.Duration(mcuTimeoutSeconds) * time.Second
-	log.Printf("Using a timeout of %s for MCU requests", mcuTimeout)
+	mcuLog.Infof("Using a timeout of %s for MCU requests", mcuTimeout)
 	s.setTimeout(mcuTimeout)
 
 	if value, _ := config.GetString("mcu", "allowedcandidates"); value != "" {
@@ -172,10 +172,10 @@ func (s *mcuJanusSettings) load(config *goconf.ConfigFile) error {
 			return fmt.Errorf("invalid allowedcandidates: %w", err)
 		}
 
-		log.Printf("Candidates allowlist: %s", allowed)
+		mcuLog.Infof("Candidates allowlist: %s", allowed)
 		s.allowedCandidates.Store(allowed)
 	} else {
-		log.Printf("No candidates allowlist")
+		mcuLog.Infof("No candidates allowlist")
 		s.allowedCandidates.Store(nil)
 	}
 	if value, _ := config.GetString("mcu", "blockedcandidates"); value != "" {
@@ -184,10 +184,10 @@ func (s *mcuJanusSettings) load(config *goconf.ConfigFile) error {
 			return fmt.Errorf("invalid blockedcandidates: %w", err)
 		}
 
-		log.Printf("Candidates blocklist: %s", blocked)
+		mcuLog.Infof("Candidates blocklist: %s", blocked)
 		s.blockedCandidates.Store(blocked)
 	} else {
-		log.Printf("No candidates blocklist")
+		mcuLog.Infof("No candidates blocklist")
 		s.blockedCandidates.Store(nil)
 	}
 
@@ -196,7 +196,7 @@ func (s *mcuJanusSettings) load(config *goconf.ConfigFile) error {
 
 func (s *mcuJanusSettings) Reload(config *goconf.ConfigFile) {
 	if err := s.load(config); err != nil {
-		log.Printf("Error reloading MCU settings: %s", err)
+		mcuLog.Errorf("Error reloading MCU settings: %s", err)
 	}
 }
 
@@ -277,18 +277,18 @@ func (m *mcuJanus) disconnect() {
 		m.handle = nil
 		m.closeChan <- struct{}{}
 		if _, err := handle.Detach(context.TODO()); err != nil {
-			log.Printf("Error detaching handle %d: %s", handle.Id, err)
+			mcuLog.Errorf("Error detaching handle %d: %s", handle.Id, err)
 		}
 	}
 	if m.session != nil {
 		if _, err := m.session.Destroy(context.TODO()); err != nil {
-			log.Printf("Error destroying session %d: %s", m.session.Id, err)
+			mcuLog.Errorf("Error destroying session %d: %s", m.session.Id, err)
 		}
 		m.session = nil
 	}
 	if m.gw != nil {
 		if err := m.gw.Close(); err != nil {
-			log.Println("Error while closing connection to MCU", err)
+			mcuLog.Errorf("Error while closing connection to MCU: %s", err)
 		}
 		m.gw = nil
 	}
@@ -316,7 +316,7 @@ func (m *mcuJanus) doReconnect(ctx context.Context) {
 		return
 	}
 
-	log.Println("Reconnection to Janus gateway successful")
+	mcuLog.Eventf(RecentEventTypeConnection, slog.LevelInfo, "Reconnection to Janus gateway successful")
 	m.mu.Lock()
 	clear(m.publishers)
 	m.publisherCreated.Reset()
@@ -336,9 +336,9 @@ func (m *mcuJanus) scheduleReconnect(err error) {
 	defer m.mu.Unlock()
 	m.reconnectTimer.Reset(m.reconnectInterval)
 	if err == nil {
-		log.Printf("Connection to Janus gateway was interrupted, reconnecting in %s", m.reconnectInterval)
+		mcuLog.Eventf(RecentEventTypeConnection, slog.LevelInfo, "Connection to Janus gateway was interrupted, reconnecting in %s", m.reconnectInterval)
 	} else {
-		log.Printf("Reconnect to Janus gateway failed (%s), reconnecting in %s", err, m.reconnectInterval)
+		mcuLog.Eventf(RecentEventTypeConnection, slog.LevelInfo, "Reconnect to Janus gateway failed (%s), reconnecting in %s", err, m.reconnectInterval)
 	}
 
 	m.reconnectInterval = min(m.reconnectInterval*2, maxReconnectInterval)
@@ -368,7 +368,7 @@ func (m *mcuJanus) Start(ctx context.Context) error {
 		return err
 	}
 
-	log.Printf("Connected to %s %s by %s", info.Name, info.VersionString, info.Author)
+	mcuLog.Infof("Connected to %s %s by %s", info.Name, info.VersionString, info.Author)
 	plugin, found := info.Plugins[pluginVideoRoom]
 	if !found {
 		return fmt.Errorf("plugin %s is not supported", pluginVideoRoom)
@@ -376,30 +376,30 @@ func (m *mcuJanus) Start(ctx context.Context) error {
 
 	m.version = info.Version
 
-	log.Printf("Found %s %s by %s", plugin.Name, plugin.VersionString, plugin.Author)
+	mcuLog.Infof("Found %s %s by %s", plugin.Name, plugin.VersionString, plugin.Author)
 	if !info.DataChannels {
 		return fmt.Errorf("data channels are not supported")
 	}
 
-	log.Println("Data channels are supported")
+	mcuLog.Infof("Data channels are supported")
 	if !info.FullTrickle {
-		log.Println("WARNING: Full-Trickle is NOT enabled in Janus!")
+		mcuLog.Warnf("Full-Trickle is NOT enabled in Janus!")
 	} else {
-		log.Println("Full-Trickle is enabled")
+		mcuLog.Infof("Full-Trickle is enabled")
 	}
 
 	if m.session, err = m.gw.Create(ctx); err != nil {
 		m.disconnect()
 		return err
 	}
-	log.Println("Created Janus session", m.session.Id)
+	mcuLog.Infof("Created Janus session %v", m.session.Id)
 	m.connectedSince = time.Now()
 
 	if m.handle, err = m.session.Attach(ctx, pluginVideoRoom); err != nil {
 		m.disconnect()
 		return err
 	}
-	log.Println("Created Janus handle", m.handle.Id)
+	mcuLog.Infof("Created Janus handle %v", m.handle.Id)
 
 	m.info.Store(info)
 
@@ -481,8 +481,9 @@ func (m *mcuJanus) GetServerInfoSfu() *BackendServerInfoSfu {
 	return sfu
 }
 
-func (m *mcuJanus) Reload(config *goconf.ConfigFile) {
+func (m *mcuJanus) Reload(config *goconf.ConfigFile) error {
 	m.settings.Reload(config)
+	return nil
 }
 
 func (m *mcuJanus) SetOnConnected(f func()) {
@@ -538,7 +539,7 @@ func (m *mcuJanus) GetStats() any {
 
 func (m *mcuJanus) sendKeepalive(ctx context.Context) {
 	if _, err := m.session.KeepAlive(ctx); err != nil {
-		log.Println("Could not send keepalive request", err)
+		mcuLog.Errorf("Could not send keepalive request: %s", err)
 		if e, ok := err.(*janus.ErrorMsg); ok {
 			switch e.Err.Code {
 			case JANUS_ERROR_SESSION_NOT_FOUND:
@@ -604,7 +605,7 @@ func (m *mcuJanus) createPublisherRoom(ctx context.Context, handle *JanusHandle,
 	create_response, err := handle.Request(ctx, create_msg)
 	if err != nil {
 		if _, err2 := handle.Detach(ctx); err2 != nil {
-			log.Printf("Error detaching handle %d: %s", handle.Id, err2)
+			mcuLog.Errorf("Error detaching handle %d: %s", handle.Id, err2)
 		}
 		return 0, 0, err
 	}
@@ -612,12 +613,12 @@ func (m *mcuJanus) createPublisherRoom(ctx context.Context, handle *JanusHandle,
 	roomId := getPluginIntValue(create_response.PluginData, pluginVideoRoom, "room")
 	if roomId == 0 {
 		if _, err := handle.Detach(ctx); err != nil {
-			log.Printf("Error detaching handle %d: %s", handle.Id, err)
+			mcuLog.Errorf("Error detaching handle %d: %s", handle.Id, err)
 		}
 		return 0, 0, fmt.Errorf("no room id received: %+v", create_response)
 	}
 
-	log.Println("Created room", roomId, create_response.PluginData)
+	mcuLog.Infof("Created room %v %v", roomId, create_response.PluginData)
 	return roomId, bitrate, nil
 }
 
@@ -631,12 +632,12 @@ func (m *mcuJanus) getOrCreatePublisherHandle(ctx context.Context, id PublicSess
 		return nil, 0, 0, 0, err
 	}
 
-	log.Printf("Attached %s as publisher %d to plugin %s in session %d", streamType, handle.Id, pluginVideoRoom, session.Id)
+	mcuLog.Infof("Attached %s as publisher %d to plugin %s in session %d", streamType, handle.Id, pluginVideoRoom, session.Id)
 
 	roomId, bitrate, err := m.createPublisherRoom(ctx, handle, id, streamType, settings)
 	if err != nil {
 		if _, err2 := handle.Detach(ctx); err2 != nil {
-			log.Printf("Error detaching handle %d: %s", handle.Id, err2)
+			mcuLog.Errorf("Error detaching handle %d: %s", handle.Id, err2)
 		}
 		return nil, 0, 0, 0, err
 	}
@@ -651,7 +652,7 @@ func (m *mcuJanus) getOrCreatePublisherHandle(ctx context.Context, id PublicSess
 	response, err := handle.Message(ctx, msg, nil)
 	if err != nil {
 		if _, err2 := handle.Detach(ctx); err2 != nil {
-			log.Printf("Error detaching handle %d: %s", handle.Id, err2)
+			mcuLog.Errorf("Error detaching handle %d: %s", handle.Id, err2)
 		}
 		return nil, 0, 0, 0, err
 	}
@@ -660,6 +661,13 @@ func (m *mcuJanus) getOrCreatePublisherHandle(ctx context.Context, id PublicSess
 }
 
 func (m *mcuJanus) NewPublisher(ctx context.Context, listener McuListener, id PublicSessionId, sid string, streamType StreamType, settings NewPublisherSettings, initiator McuInitiator) (McuPublisher, error) {
+	ctx, done := startMcuOperation(ctx, "NewPublisher", id, streamType)
+	result, err := m.newPublisher(ctx, listener, id, sid, streamType, settings, initiator)
+	done(err)
+	return result, err
+}
+
+func (m *mcuJanus) newPublisher(ctx context.Context, listener McuListener, id PublicSessionId, sid string, streamType StreamType, settings NewPublisherSettings, initiator McuInitiator) (McuPublisher, error) {
 	if _, found := streamTypeUserIds[streamType]; !found {
 		return nil, fmt.Errorf("unsupported stream type %s", streamType)
 	}
@@ -689,6 +697,7 @@ func (m *mcuJanus) NewPublisher(ctx context.Context, listener McuListener, id Pu
 		sdpReady: NewCloser(),
 		id:       id,
 		settings: settings,
+		country:  getInitiatorCountry(initiator),
 	}
 	client.mcuJanusClient.handleEvent = client.handleEvent
 	client.mcuJanusClient.handleHangup = client.handleHangup
@@ -698,7 +707,7 @@ func (m *mcuJanus) NewPublisher(ctx context.Context, listener McuListener, id Pu
 	client.mcuJanusClient.handleMedia = client.handleMedia
 
 	m.registerClient(client)
-	log.Printf("Publisher %s is using handle %d", client.id, client.handleId)
+	mcuLog.Infof("Publisher %s is using handle %d", client.id, client.handleId)
 	go client.run(handle, client.closeChan)
 	m.mu.Lock()
 	m.publishers[getStreamId(id, streamType)] = client
@@ -706,6 +715,8 @@ func (m *mcuJanus) NewPublisher(ctx context.Context, listener McuListener, id Pu
 	m.mu.Unlock()
 	statsPublishersCurrent.WithLabelValues(string(streamType)).Inc()
 	statsPublishersTotal.WithLabelValues(string(streamType)).Inc()
+	country, continent := getStatsCountryLabels(client.country)
+	statsMcuPublishersCountry.WithLabelValues(string(streamType), country, continent).Inc()
 	return client, nil
 }
 
@@ -753,11 +764,18 @@ func (m *mcuJanus) getOrCreateSubscriberHandle(ctx context.Context, publisher Pu
 		return nil, nil, err
 	}
 
-	log.Printf("Attached subscriber to room %d of publisher %s in plugin %s in session %d as %d", pub.roomId, publisher, pluginVideoRoom, session.Id, handle.Id)
+	mcuLog.Infof("Attached subscriber to room %d of publisher %s in plugin %s in session %d as %d", pub.roomId, publisher, pluginVideoRoom, session.Id, handle.Id)
 	return handle, pub, nil
 }
 
 func (m *mcuJanus) NewSubscriber(ctx context.Context, listener McuListener, publisher PublicSessionId, streamType StreamType, initiator McuInitiator) (McuSubscriber, error) {
+	ctx, done := startMcuOperation(ctx, "NewSubscriber", publisher, streamType)
+	result, err := m.newSubscriber(ctx, listener, publisher, streamType, initiator)
+	done(err)
+	return result, err
+}
+
+func (m *mcuJanus) newSubscriber(ctx context.Context, listener McuListener, publisher PublicSessionId, streamType StreamType, initiator McuInitiator) (McuSubscriber, error) {
 	if _, found := streamTypeUserIds[streamType]; !found {
 		return nil, fmt.Errorf("unsupported stream type %s", streamType)
 	}
@@ -828,7 +846,7 @@ func (m *mcuJanus) getOrCreateRemotePublisher(ctx context.Context, controller Re
 	roomId, maxBitrate, err := m.createPublisherRoom(ctx, handle, controller.PublisherId(), streamType, settings)
 	if err != nil {
 		if _, err2 := handle.Detach(ctx); err2 != nil {
-			log.Printf("Error detaching handle %d: %s", handle.Id, err2)
+			mcuLog.Errorf("Error detaching handle %d: %s", handle.Id, err2)
 		}
 		return nil, err
 	}
@@ -841,7 +859,7 @@ func (m *mcuJanus) getOrCreateRemotePublisher(ctx context.Context, controller Re
 	})
 	if err != nil {
 		if _, err2 := handle.Detach(ctx); err2 != nil {
-			log.Printf("Error detaching handle %d: %s", handle.Id, err2)
+			mcuLog.Errorf("Error detaching handle %d: %s", handle.Id, err2)
 		}
 		return nil, err
 	}
@@ -929,7 +947,7 @@ func (m *mcuJanus) NewRemoteSubscriber(ctx context.Context, listener McuListener
 		return nil, err
 	}
 
-	log.Printf("Attached subscriber to room %d of publisher %s in plugin %s in session %d as %d", pub.roomId, pub.id, pluginVideoRoom, session.Id, handle.Id)
+	mcuLog.Infof("Attached subscriber to room %d of publisher %s in plugin %s in session %d as %d", pub.roomId, pub.id, pluginVideoRoom, session.Id, handle.Id)
 
 	client := &mcuJanusRemoteSubscriber{
 		mcuJanusSubscriber: mcuJanusSubscriber{
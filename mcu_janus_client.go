@@ -23,7 +23,6 @@ package signaling
 
 import (
 	"context"
-	"log"
 	"reflect"
 	"strconv"
 	"sync"
@@ -84,7 +83,7 @@ func (c *mcuJanusClient) closeClient(ctx context.Context) bool {
 		close(c.closeChan)
 		if _, err := handle.Detach(ctx); err != nil {
 			if e, ok := err.(*janus.ErrorMsg); !ok || e.Err.Code != JANUS_ERROR_HANDLE_NOT_FOUND {
-				log.Println("Could not detach client", handle.Id, err)
+				mcuLog.Errorf("Could not detach client %v %v", handle.Id, err)
 			}
 		}
 		return true
@@ -114,7 +113,7 @@ loop:
 			case *TrickleMsg:
 				c.handleTrickle(t)
 			default:
-				log.Println("Received unsupported event type", msg, reflect.TypeOf(msg))
+				mcuLog.Warnf("Received unsupported event type %v %v", msg, reflect.TypeOf(msg))
 			}
 		case f := <-c.deferred:
 			f()
@@ -162,7 +161,7 @@ func (c *mcuJanusClient) sendAnswer(ctx context.Context, answer StringMap, callb
 		callback(err, nil)
 		return
 	}
-	log.Println("Started listener", start_response)
+	mcuLog.Infof("Started listener %v", start_response)
 	callback(nil, nil)
 }
 
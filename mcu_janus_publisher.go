@@ -25,7 +25,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"log"
 	"strconv"
 	"strings"
 	"sync/atomic"
@@ -49,6 +48,7 @@ type mcuJanusPublisher struct {
 
 	id        PublicSessionId
 	settings  NewPublisherSettings
+	country   string
 	stats     publisherStatsCounter
 	sdpFlags  Flags
 	sdpReady  *Closer
@@ -65,38 +65,38 @@ func (p *mcuJanusPublisher) handleEvent(event *janus.EventMsg) {
 		ctx := context.TODO()
 		switch videoroom {
 		case "destroyed":
-			log.Printf("Publisher %d: associated room has been destroyed, closing", p.handleId)
+			mcuLog.Infof("Publisher %d: associated room has been destroyed, closing", p.handleId)
 			go p.Close(ctx)
 		case "slow_link":
 			// Ignore, processed through "handleSlowLink" in the general events.
 		default:
-			log.Printf("Unsupported videoroom publisher event in %d: %+v", p.handleId, event)
+			mcuLog.Warnf("Unsupported videoroom publisher event in %d: %+v", p.handleId, event)
 		}
 	} else {
-		log.Printf("Unsupported publisher event in %d: %+v", p.handleId, event)
+		mcuLog.Warnf("Unsupported publisher event in %d: %+v", p.handleId, event)
 	}
 }
 
 func (p *mcuJanusPublisher) handleHangup(event *janus.HangupMsg) {
-	log.Printf("Publisher %d received hangup (%s), closing", p.handleId, event.Reason)
+	mcuLog.Infof("Publisher %d received hangup (%s), closing", p.handleId, event.Reason)
 	go p.Close(context.Background())
 }
 
 func (p *mcuJanusPublisher) handleDetached(event *janus.DetachedMsg) {
-	log.Printf("Publisher %d received detached, closing", p.handleId)
+	mcuLog.Infof("Publisher %d received detached, closing", p.handleId)
 	go p.Close(context.Background())
 }
 
 func (p *mcuJanusPublisher) handleConnected(event *janus.WebRTCUpMsg) {
-	log.Printf("Publisher %d received connected", p.handleId)
+	mcuLog.Infof("Publisher %d received connected", p.handleId)
 	p.mcu.publisherConnected.Notify(string(getStreamId(p.id, p.streamType)))
 }
 
 func (p *mcuJanusPublisher) handleSlowLink(event *janus.SlowLinkMsg) {
 	if event.Uplink {
-		log.Printf("Publisher %s (%d) is reporting %d lost packets on the uplink (Janus -> client)", p.listener.PublicId(), p.handleId, event.Lost)
+		mcuLog.Infof("Publisher %s (%d) is reporting %d lost packets on the uplink (Janus -> client)", p.listener.PublicId(), p.handleId, event.Lost)
 	} else {
-		log.Printf("Publisher %s (%d) is reporting %d lost packets on the downlink (client -> Janus)", p.listener.PublicId(), p.handleId, event.Lost)
+		mcuLog.Infof("Publisher %s (%d) is reporting %d lost packets on the downlink (client -> Janus)", p.listener.PublicId(), p.handleId, event.Lost)
 	}
 }
 
@@ -122,7 +122,7 @@ func (p *mcuJanusPublisher) NotifyReconnected() {
 	ctx := context.TODO()
 	handle, session, roomId, _, err := p.mcu.getOrCreatePublisherHandle(ctx, p.id, p.streamType, p.settings)
 	if err != nil {
-		log.Printf("Could not reconnect publisher %s: %s", p.id, err)
+		mcuLog.Errorf("Could not reconnect publisher %s: %s", p.id, err)
 		// TODO(jojo): Retry
 		return
 	}
@@ -132,7 +132,7 @@ func (p *mcuJanusPublisher) NotifyReconnected() {
 	p.session = session
 	p.roomId = roomId
 
-	log.Printf("Publisher %s reconnected on handle %d", p.id, p.handleId)
+	mcuLog.Infof("Publisher %s reconnected on handle %d", p.id, p.handleId)
 }
 
 func (p *mcuJanusPublisher) Close(ctx context.Context) {
@@ -144,9 +144,9 @@ func (p *mcuJanusPublisher) Close(ctx context.Context) {
 			"room":    p.roomId,
 		}
 		if _, err := handle.Request(ctx, destroy_msg); err != nil {
-			log.Printf("Error destroying room %d: %s", p.roomId, err)
+			mcuLog.Errorf("Error destroying room %d: %s", p.roomId, err)
 		} else {
-			log.Printf("Room %d destroyed", p.roomId)
+			mcuLog.Infof("Room %d destroyed", p.roomId)
 		}
 		p.mcu.mu.Lock()
 		delete(p.mcu.publishers, getStreamId(p.id, p.streamType))
@@ -161,6 +161,8 @@ func (p *mcuJanusPublisher) Close(ctx context.Context) {
 
 	if notify {
 		statsPublishersCurrent.WithLabelValues(string(p.streamType)).Dec()
+		country, continent := getStatsCountryLabels(p.country)
+		statsMcuPublishersCountry.WithLabelValues(string(p.streamType), country, continent).Dec()
 		p.mcu.unregisterClient(p)
 		p.listener.PublisherClosed(p)
 	}
@@ -209,9 +211,9 @@ func (p *mcuJanusPublisher) SendMessage(ctx context.Context, message *MessageCli
 
 				sdpString, found := GetStringMapEntry[string](jsep, "sdp")
 				if !found {
-					log.Printf("No/invalid sdp found in answer %+v", jsep)
+					mcuLog.Infof("No/invalid sdp found in answer %+v", jsep)
 				} else if answerSdp, err := parseSDP(sdpString); err != nil {
-					log.Printf("Error parsing answer sdp %+v: %s", sdpString, err)
+					mcuLog.Errorf("Error parsing answer sdp %+v: %s", sdpString, err)
 					p.answerSdp.Store(nil)
 					p.sdpFlags.Remove(sdpHasAnswer)
 				} else {
@@ -349,7 +351,7 @@ func (p *mcuJanusPublisher) GetStreams(ctx context.Context) ([]PublisherStream,
 				switch a.Key {
 				case sdp.AttrKeyExtMap:
 					if err := extmap.Unmarshal(extmap.Name() + ":" + a.Value); err != nil {
-						log.Printf("Error parsing extmap %s: %s", a.Value, err)
+						mcuLog.Errorf("Error parsing extmap %s: %s", a.Value, err)
 						continue
 					}
 
@@ -382,7 +384,7 @@ func (p *mcuJanusPublisher) GetStreams(ctx context.Context) ([]PublisherStream,
 		} else if strings.EqualFold(s.Type, "data") { // nolint
 			// Already handled above.
 		} else {
-			log.Printf("Skip type %s", s.Type)
+			mcuLog.Infof("Skip type %s", s.Type)
 			continue
 		}
 
@@ -429,7 +431,7 @@ func (p *mcuJanusPublisher) PublishRemote(ctx context.Context, remoteId PublicSe
 		}
 	}
 
-	log.Printf("Publishing %s to %s (port=%d, rtcpPort=%d) for %s", p.id, hostname, port, rtcpPort, remoteId)
+	mcuLog.Infof("Publishing %s to %s (port=%d, rtcpPort=%d) for %s", p.id, hostname, port, rtcpPort, remoteId)
 	return nil
 }
 
@@ -463,6 +465,6 @@ func (p *mcuJanusPublisher) UnpublishRemote(ctx context.Context, remoteId Public
 		}
 	}
 
-	log.Printf("Unpublished remote %s for %s", p.id, remoteId)
+	mcuLog.Infof("Unpublished remote %s for %s", p.id, remoteId)
 	return nil
 }
@@ -23,7 +23,6 @@ package signaling
 
 import (
 	"context"
-	"log"
 	"sync/atomic"
 
 	"github.com/notedit/janus-go"
@@ -61,38 +60,38 @@ func (p *mcuJanusRemotePublisher) handleEvent(event *janus.EventMsg) {
 		ctx := context.TODO()
 		switch videoroom {
 		case "destroyed":
-			log.Printf("Remote publisher %d: associated room has been destroyed, closing", p.handleId)
+			mcuLog.Infof("Remote publisher %d: associated room has been destroyed, closing", p.handleId)
 			go p.Close(ctx)
 		case "slow_link":
 			// Ignore, processed through "handleSlowLink" in the general events.
 		default:
-			log.Printf("Unsupported videoroom remote publisher event in %d: %+v", p.handleId, event)
+			mcuLog.Warnf("Unsupported videoroom remote publisher event in %d: %+v", p.handleId, event)
 		}
 	} else {
-		log.Printf("Unsupported remote publisher event in %d: %+v", p.handleId, event)
+		mcuLog.Warnf("Unsupported remote publisher event in %d: %+v", p.handleId, event)
 	}
 }
 
 func (p *mcuJanusRemotePublisher) handleHangup(event *janus.HangupMsg) {
-	log.Printf("Remote publisher %d received hangup (%s), closing", p.handleId, event.Reason)
+	mcuLog.Infof("Remote publisher %d received hangup (%s), closing", p.handleId, event.Reason)
 	go p.Close(context.Background())
 }
 
 func (p *mcuJanusRemotePublisher) handleDetached(event *janus.DetachedMsg) {
-	log.Printf("Remote publisher %d received detached, closing", p.handleId)
+	mcuLog.Infof("Remote publisher %d received detached, closing", p.handleId)
 	go p.Close(context.Background())
 }
 
 func (p *mcuJanusRemotePublisher) handleConnected(event *janus.WebRTCUpMsg) {
-	log.Printf("Remote publisher %d received connected", p.handleId)
+	mcuLog.Infof("Remote publisher %d received connected", p.handleId)
 	p.mcu.publisherConnected.Notify(string(getStreamId(p.id, p.streamType)))
 }
 
 func (p *mcuJanusRemotePublisher) handleSlowLink(event *janus.SlowLinkMsg) {
 	if event.Uplink {
-		log.Printf("Remote publisher %s (%d) is reporting %d lost packets on the uplink (Janus -> client)", p.listener.PublicId(), p.handleId, event.Lost)
+		mcuLog.Infof("Remote publisher %s (%d) is reporting %d lost packets on the uplink (Janus -> client)", p.listener.PublicId(), p.handleId, event.Lost)
 	} else {
-		log.Printf("Remote publisher %s (%d) is reporting %d lost packets on the downlink (client -> Janus)", p.listener.PublicId(), p.handleId, event.Lost)
+		mcuLog.Infof("Remote publisher %s (%d) is reporting %d lost packets on the downlink (client -> Janus)", p.listener.PublicId(), p.handleId, event.Lost)
 	}
 }
 
@@ -100,7 +99,7 @@ func (p *mcuJanusRemotePublisher) NotifyReconnected() {
 	ctx := context.TODO()
 	handle, session, roomId, _, err := p.mcu.getOrCreatePublisherHandle(ctx, p.id, p.streamType, p.settings)
 	if err != nil {
-		log.Printf("Could not reconnect remote publisher %s: %s", p.id, err)
+		mcuLog.Errorf("Could not reconnect remote publisher %s: %s", p.id, err)
 		// TODO(jojo): Retry
 		return
 	}
@@ -110,7 +109,7 @@ func (p *mcuJanusRemotePublisher) NotifyReconnected() {
 	p.session = session
 	p.roomId = roomId
 
-	log.Printf("Remote publisher %s reconnected on handle %d", p.id, p.handleId)
+	mcuLog.Infof("Remote publisher %s reconnected on handle %d", p.id, p.handleId)
 }
 
 func (p *mcuJanusRemotePublisher) Close(ctx context.Context) {
@@ -119,7 +118,7 @@ func (p *mcuJanusRemotePublisher) Close(ctx context.Context) {
 	}
 
 	if err := p.controller.StopPublishing(ctx, p); err != nil {
-		log.Printf("Error stopping remote publisher %s in room %d: %s", p.id, p.roomId, err)
+		mcuLog.Errorf("Error stopping remote publisher %s in room %d: %s", p.id, p.roomId, err)
 	}
 
 	p.mu.Lock()
@@ -130,9 +129,9 @@ func (p *mcuJanusRemotePublisher) Close(ctx context.Context) {
 			"id":      streamTypeUserIds[p.streamType],
 		})
 		if err != nil {
-			log.Printf("Error removing remote publisher %s in room %d: %s", p.id, p.roomId, err)
+			mcuLog.Errorf("Error removing remote publisher %s in room %d: %s", p.id, p.roomId, err)
 		} else {
-			log.Printf("Removed remote publisher: %+v", response)
+			mcuLog.Infof("Removed remote publisher: %+v", response)
 		}
 		if p.roomId != 0 {
 			destroy_msg := StringMap{
@@ -140,9 +139,9 @@ func (p *mcuJanusRemotePublisher) Close(ctx context.Context) {
 				"room":    p.roomId,
 			}
 			if _, err := handle.Request(ctx, destroy_msg); err != nil {
-				log.Printf("Error destroying room %d: %s", p.roomId, err)
+				mcuLog.Errorf("Error destroying room %d: %s", p.roomId, err)
 			} else {
-				log.Printf("Room %d destroyed", p.roomId)
+				mcuLog.Infof("Room %d destroyed", p.roomId)
 			}
 			p.mcu.mu.Lock()
 			delete(p.mcu.remotePublishers, getStreamId(p.id, p.streamType))
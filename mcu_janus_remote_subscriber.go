@@ -23,7 +23,6 @@ package signaling
 
 import (
 	"context"
-	"log"
 	"strconv"
 	"sync/atomic"
 
@@ -41,7 +40,7 @@ func (p *mcuJanusRemoteSubscriber) handleEvent(event *janus.EventMsg) {
 		ctx := context.TODO()
 		switch videoroom {
 		case "destroyed":
-			log.Printf("Remote subscriber %d: associated room has been destroyed, closing", p.handleId)
+			mcuLog.Infof("Remote subscriber %d: associated room has been destroyed, closing", p.handleId)
 			go p.Close(ctx)
 		case "event":
 			// Handle renegotiations, but ignore other events like selected
@@ -53,33 +52,33 @@ func (p *mcuJanusRemoteSubscriber) handleEvent(event *janus.EventMsg) {
 		case "slow_link":
 			// Ignore, processed through "handleSlowLink" in the general events.
 		default:
-			log.Printf("Unsupported videoroom event %s for remote subscriber %d: %+v", videoroom, p.handleId, event)
+			mcuLog.Warnf("Unsupported videoroom event %s for remote subscriber %d: %+v", videoroom, p.handleId, event)
 		}
 	} else {
-		log.Printf("Unsupported event for remote subscriber %d: %+v", p.handleId, event)
+		mcuLog.Warnf("Unsupported event for remote subscriber %d: %+v", p.handleId, event)
 	}
 }
 
 func (p *mcuJanusRemoteSubscriber) handleHangup(event *janus.HangupMsg) {
-	log.Printf("Remote subscriber %d received hangup (%s), closing", p.handleId, event.Reason)
+	mcuLog.Infof("Remote subscriber %d received hangup (%s), closing", p.handleId, event.Reason)
 	go p.Close(context.Background())
 }
 
 func (p *mcuJanusRemoteSubscriber) handleDetached(event *janus.DetachedMsg) {
-	log.Printf("Remote subscriber %d received detached, closing", p.handleId)
+	mcuLog.Infof("Remote subscriber %d received detached, closing", p.handleId)
 	go p.Close(context.Background())
 }
 
 func (p *mcuJanusRemoteSubscriber) handleConnected(event *janus.WebRTCUpMsg) {
-	log.Printf("Remote subscriber %d received connected", p.handleId)
+	mcuLog.Infof("Remote subscriber %d received connected", p.handleId)
 	p.mcu.SubscriberConnected(p.Id(), p.publisher, p.streamType)
 }
 
 func (p *mcuJanusRemoteSubscriber) handleSlowLink(event *janus.SlowLinkMsg) {
 	if event.Uplink {
-		log.Printf("Remote subscriber %s (%d) is reporting %d lost packets on the uplink (Janus -> client)", p.listener.PublicId(), p.handleId, event.Lost)
+		mcuLog.Infof("Remote subscriber %s (%d) is reporting %d lost packets on the uplink (Janus -> client)", p.listener.PublicId(), p.handleId, event.Lost)
 	} else {
-		log.Printf("Remote subscriber %s (%d) is reporting %d lost packets on the downlink (client -> Janus)", p.listener.PublicId(), p.handleId, event.Lost)
+		mcuLog.Infof("Remote subscriber %s (%d) is reporting %d lost packets on the downlink (client -> Janus)", p.listener.PublicId(), p.handleId, event.Lost)
 	}
 }
 
@@ -93,7 +92,7 @@ func (p *mcuJanusRemoteSubscriber) NotifyReconnected() {
 	handle, pub, err := p.mcu.getOrCreateSubscriberHandle(ctx, p.publisher, p.streamType)
 	if err != nil {
 		// TODO(jojo): Retry?
-		log.Printf("Could not reconnect remote subscriber for publisher %s: %s", p.publisher, err)
+		mcuLog.Errorf("Could not reconnect remote subscriber for publisher %s: %s", p.publisher, err)
 		p.Close(context.Background())
 		return
 	}
@@ -103,7 +102,7 @@ func (p *mcuJanusRemoteSubscriber) NotifyReconnected() {
 	p.roomId = pub.roomId
 	p.sid = strconv.FormatUint(handle.Id, 10)
 	p.listener.SubscriberSidUpdated(p)
-	log.Printf("Subscriber %d for publisher %s reconnected on handle %d", p.id, p.publisher, p.handleId)
+	mcuLog.Infof("Subscriber %d for publisher %s reconnected on handle %d", p.id, p.publisher, p.handleId)
 }
 
 func (p *mcuJanusRemoteSubscriber) Close(ctx context.Context) {
@@ -24,7 +24,6 @@ package signaling
 import (
 	"context"
 	"fmt"
-	"log"
 	"strconv"
 
 	"github.com/notedit/janus-go"
@@ -45,7 +44,7 @@ func (p *mcuJanusSubscriber) handleEvent(event *janus.EventMsg) {
 		ctx := context.TODO()
 		switch videoroom {
 		case "destroyed":
-			log.Printf("Subscriber %d: associated room has been destroyed, closing", p.handleId)
+			mcuLog.Infof("Subscriber %d: associated room has been destroyed, closing", p.handleId)
 			go p.Close(ctx)
 		case "updated":
 			streams, ok := getPluginValue(event.Plugindata, pluginVideoRoom, "streams").([]any)
@@ -62,7 +61,7 @@ func (p *mcuJanusSubscriber) handleEvent(event *janus.EventMsg) {
 				}
 			}
 
-			log.Printf("Subscriber %d: received updated event with no active media streams, closing", p.handleId)
+			mcuLog.Infof("Subscriber %d: received updated event with no active media streams, closing", p.handleId)
 			go p.Close(ctx)
 		case "event":
 			// Handle renegotiations, but ignore other events like selected
@@ -74,33 +73,33 @@ func (p *mcuJanusSubscriber) handleEvent(event *janus.EventMsg) {
 		case "slow_link":
 			// Ignore, processed through "handleSlowLink" in the general events.
 		default:
-			log.Printf("Unsupported videoroom event %s for subscriber %d: %+v", videoroom, p.handleId, event)
+			mcuLog.Warnf("Unsupported videoroom event %s for subscriber %d: %+v", videoroom, p.handleId, event)
 		}
 	} else {
-		log.Printf("Unsupported event for subscriber %d: %+v", p.handleId, event)
+		mcuLog.Warnf("Unsupported event for subscriber %d: %+v", p.handleId, event)
 	}
 }
 
 func (p *mcuJanusSubscriber) handleHangup(event *janus.HangupMsg) {
-	log.Printf("Subscriber %d received hangup (%s), closing", p.handleId, event.Reason)
+	mcuLog.Infof("Subscriber %d received hangup (%s), closing", p.handleId, event.Reason)
 	go p.Close(context.Background())
 }
 
 func (p *mcuJanusSubscriber) handleDetached(event *janus.DetachedMsg) {
-	log.Printf("Subscriber %d received detached, closing", p.handleId)
+	mcuLog.Infof("Subscriber %d received detached, closing", p.handleId)
 	go p.Close(context.Background())
 }
 
 func (p *mcuJanusSubscriber) handleConnected(event *janus.WebRTCUpMsg) {
-	log.Printf("Subscriber %d received connected", p.handleId)
+	mcuLog.Infof("Subscriber %d received connected", p.handleId)
 	p.mcu.SubscriberConnected(p.Id(), p.publisher, p.streamType)
 }
 
 func (p *mcuJanusSubscriber) handleSlowLink(event *janus.SlowLinkMsg) {
 	if event.Uplink {
-		log.Printf("Subscriber %s (%d) is reporting %d lost packets on the uplink (Janus -> client)", p.listener.PublicId(), p.handleId, event.Lost)
+		mcuLog.Infof("Subscriber %s (%d) is reporting %d lost packets on the uplink (Janus -> client)", p.listener.PublicId(), p.handleId, event.Lost)
 	} else {
-		log.Printf("Subscriber %s (%d) is reporting %d lost packets on the downlink (client -> Janus)", p.listener.PublicId(), p.handleId, event.Lost)
+		mcuLog.Infof("Subscriber %s (%d) is reporting %d lost packets on the downlink (client -> Janus)", p.listener.PublicId(), p.handleId, event.Lost)
 	}
 }
 
@@ -114,7 +113,7 @@ func (p *mcuJanusSubscriber) NotifyReconnected() {
 	handle, pub, err := p.mcu.getOrCreateSubscriberHandle(ctx, p.publisher, p.streamType)
 	if err != nil {
 		// TODO(jojo): Retry?
-		log.Printf("Could not reconnect subscriber for publisher %s: %s", p.publisher, err)
+		mcuLog.Errorf("Could not reconnect subscriber for publisher %s: %s", p.publisher, err)
 		p.Close(context.Background())
 		return
 	}
@@ -124,7 +123,7 @@ func (p *mcuJanusSubscriber) NotifyReconnected() {
 	p.roomId = pub.roomId
 	p.sid = strconv.FormatUint(handle.Id, 10)
 	p.listener.SubscriberSidUpdated(p)
-	log.Printf("Subscriber %d for publisher %s reconnected on handle %d", p.id, p.publisher, p.handleId)
+	mcuLog.Infof("Subscriber %d for publisher %s reconnected on handle %d", p.id, p.publisher, p.handleId)
 }
 
 func (p *mcuJanusSubscriber) closeClient(ctx context.Context) bool {
@@ -216,19 +215,19 @@ retry:
 			p.closeChan = make(chan struct{}, 1)
 			statsSubscribersCurrent.WithLabelValues(string(p.streamType)).Inc()
 			go p.run(p.handle, p.closeChan)
-			log.Printf("Already connected subscriber %d for %s, leaving and re-joining on handle %d", p.id, p.streamType, p.handleId)
+			mcuLog.Infof("Already connected subscriber %d for %s, leaving and re-joining on handle %d", p.id, p.streamType, p.handleId)
 			goto retry
 		case JANUS_VIDEOROOM_ERROR_NO_SUCH_ROOM:
 			fallthrough
 		case JANUS_VIDEOROOM_ERROR_NO_SUCH_FEED:
 			switch error_code {
 			case JANUS_VIDEOROOM_ERROR_NO_SUCH_ROOM:
-				log.Printf("Publisher %s not created yet for %s, not joining room %d as subscriber", p.publisher, p.streamType, p.roomId)
+				mcuLog.Infof("Publisher %s not created yet for %s, not joining room %d as subscriber", p.publisher, p.streamType, p.roomId)
 				go p.Close(context.Background())
 				callback(fmt.Errorf("Publisher %s not created yet for %s", p.publisher, p.streamType), nil)
 				return
 			case JANUS_VIDEOROOM_ERROR_NO_SUCH_FEED:
-				log.Printf("Publisher %s not sending yet for %s, wait and retry to join room %d as subscriber", p.publisher, p.streamType, p.roomId)
+				mcuLog.Infof("Publisher %s not sending yet for %s, wait and retry to join room %d as subscriber", p.publisher, p.streamType, p.roomId)
 			}
 
 			if !loggedNotPublishingYet {
@@ -241,7 +240,7 @@ retry:
 				callback(err, nil)
 				return
 			}
-			log.Printf("Retry subscribing %s from %s", p.streamType, p.publisher)
+			mcuLog.Infof("Retry subscribing %s from %s", p.streamType, p.publisher)
 			goto retry
 		default:
 			// TODO(jojo): Should we handle other errors, too?
@@ -249,7 +248,7 @@ retry:
 			return
 		}
 	}
-	//log.Println("Joined as listener", join_response)
+	//mcuLog.Infof("Joined as listener %v", join_response)
 
 	p.session = join_response.Session
 	callback(nil, join_response.Jsep)
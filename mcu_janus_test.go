@@ -33,6 +33,7 @@ import (
 
 	"github.com/dlintw/goconf"
 	"github.com/notedit/janus-go"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -1030,6 +1031,34 @@ func Test_JanusPublisherSubscriber(t *testing.T) {
 	defer sub.Close(context.Background())
 }
 
+func Test_JanusPublisherCountryStats(t *testing.T) {
+	CatchLogForTest(t)
+	require := require.New(t)
+
+	mcu, gateway := newMcuJanusForTesting(t)
+	gateway.registerHandlers(map[string]TestJanusHandler{})
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	publishers := statsMcuPublishersCountry.WithLabelValues(string(StreamTypeVideo), "IT", "EU")
+	initial := testutil.ToFloat64(publishers)
+
+	pubId := PublicSessionId("publisher-id")
+	listener := &TestMcuListener{
+		id: pubId,
+	}
+	initiator := &TestMcuInitiator{
+		country: "IT",
+	}
+	pub, err := mcu.NewPublisher(ctx, listener, pubId, "sid", StreamTypeVideo, NewPublisherSettings{}, initiator)
+	require.NoError(err)
+	checkStatsValue(t, publishers, initial+1)
+
+	pub.Close(context.Background())
+	checkStatsValue(t, publishers, initial)
+}
+
 func Test_JanusSubscriberPublisher(t *testing.T) {
 	CatchLogForTest(t)
 	t.Parallel()
@@ -0,0 +1,652 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dlintw/goconf"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// Validity of the access tokens used for requests to the LiveKit server API.
+	livekitTokenValidity = 10 * time.Minute
+
+	// Interval in which the connection to the LiveKit server is checked.
+	livekitCheckInterval = 10 * time.Second
+
+	livekitWhipInput = "WHIP_INPUT"
+)
+
+var (
+	ErrLivekitSubscriberNotSupported = errors.New("subscribers are not supported by LiveKit")
+)
+
+type livekitVideoGrant struct {
+	RoomCreate   bool   `json:"roomCreate,omitempty"`
+	RoomList     bool   `json:"roomList,omitempty"`
+	RoomAdmin    bool   `json:"roomAdmin,omitempty"`
+	IngressAdmin bool   `json:"ingressAdmin,omitempty"`
+	Room         string `json:"room,omitempty"`
+}
+
+type livekitClaims struct {
+	jwt.RegisteredClaims
+
+	Video *livekitVideoGrant `json:"video,omitempty"`
+}
+
+// livekitApiError is returned by the Twirp endpoints of the LiveKit server.
+type livekitApiError struct {
+	Code    string `json:"code"`
+	Message string `json:"msg"`
+}
+
+func (e *livekitApiError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+type livekitListRoomsRequest struct {
+	Names []string `json:"names,omitempty"`
+}
+
+type livekitRoom struct {
+	Sid             string `json:"sid"`
+	Name            string `json:"name"`
+	NumParticipants int    `json:"num_participants,omitempty"`
+	NumPublishers   int    `json:"num_publishers,omitempty"`
+}
+
+type livekitListRoomsResponse struct {
+	Rooms []livekitRoom `json:"rooms"`
+}
+
+type livekitDeleteRoomRequest struct {
+	Room string `json:"room"`
+}
+
+type livekitCreateIngressRequest struct {
+	InputType           string `json:"input_type"`
+	Name                string `json:"name"`
+	RoomName            string `json:"room_name"`
+	ParticipantIdentity string `json:"participant_identity"`
+	ParticipantName     string `json:"participant_name,omitempty"`
+	BypassTranscoding   bool   `json:"bypass_transcoding,omitempty"`
+}
+
+type livekitIngressInfo struct {
+	IngressId           string `json:"ingress_id"`
+	Name                string `json:"name,omitempty"`
+	StreamKey           string `json:"stream_key"`
+	Url                 string `json:"url"`
+	RoomName            string `json:"room_name,omitempty"`
+	ParticipantIdentity string `json:"participant_identity,omitempty"`
+}
+
+type livekitDeleteIngressRequest struct {
+	IngressId string `json:"ingress_id"`
+}
+
+// livekitApiClient performs requests against the Twirp based server API of
+// LiveKit and the WHIP endpoints of its ingress service.
+type livekitApiClient struct {
+	url       string
+	apiKey    string
+	apiSecret string
+
+	client *http.Client
+}
+
+func newLivekitApiClient(url string, apiKey string, apiSecret string, skipVerify bool) *livekitApiClient {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if skipVerify {
+		transport.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: true,
+		}
+	}
+
+	// LiveKit servers are usually configured with a "ws(s)" url for clients.
+	url = strings.TrimSuffix(url, "/")
+	if rest, found := strings.CutPrefix(url, "ws://"); found {
+		url = "http://" + rest
+	} else if rest, found := strings.CutPrefix(url, "wss://"); found {
+		url = "https://" + rest
+	}
+
+	return &livekitApiClient{
+		url:       url,
+		apiKey:    apiKey,
+		apiSecret: apiSecret,
+
+		client: &http.Client{
+			Transport: transport,
+		},
+	}
+}
+
+func (c *livekitApiClient) newToken(grant *livekitVideoGrant) (string, error) {
+	now := time.Now()
+	claims := &livekitClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    c.apiKey,
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(livekitTokenValidity)),
+		},
+		Video: grant,
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(c.apiSecret))
+}
+
+func (c *livekitApiClient) call(ctx context.Context, service string, method string, grant *livekitVideoGrant, request any, response any) error {
+	token, err := c.newToken(grant)
+	if err != nil {
+		return fmt.Errorf("could not create token: %w", err)
+	}
+
+	data, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/twirp/livekit.%s/%s", c.url, service, method)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr livekitApiError
+		if err := json.Unmarshal(body, &apiErr); err != nil || apiErr.Code == "" {
+			return fmt.Errorf("%s.%s failed with status %d: %s", service, method, resp.StatusCode, string(body))
+		}
+
+		return &apiErr
+	}
+
+	if response == nil {
+		return nil
+	}
+
+	return json.Unmarshal(body, response)
+}
+
+func (c *livekitApiClient) ListRooms(ctx context.Context) ([]livekitRoom, error) {
+	var response livekitListRoomsResponse
+	if err := c.call(ctx, "RoomService", "ListRooms", &livekitVideoGrant{
+		RoomList: true,
+	}, &livekitListRoomsRequest{}, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Rooms, nil
+}
+
+func (c *livekitApiClient) DeleteRoom(ctx context.Context, room string) error {
+	return c.call(ctx, "RoomService", "DeleteRoom", &livekitVideoGrant{
+		RoomCreate: true,
+	}, &livekitDeleteRoomRequest{
+		Room: room,
+	}, nil)
+}
+
+func (c *livekitApiClient) CreateIngress(ctx context.Context, request *livekitCreateIngressRequest) (*livekitIngressInfo, error) {
+	var response livekitIngressInfo
+	if err := c.call(ctx, "Ingress", "CreateIngress", &livekitVideoGrant{
+		IngressAdmin: true,
+	}, request, &response); err != nil {
+		return nil, err
+	}
+
+	return &response, nil
+}
+
+func (c *livekitApiClient) DeleteIngress(ctx context.Context, ingressId string) error {
+	return c.call(ctx, "Ingress", "DeleteIngress", &livekitVideoGrant{
+		IngressAdmin: true,
+	}, &livekitDeleteIngressRequest{
+		IngressId: ingressId,
+	}, nil)
+}
+
+// PublishWhip sends the offer to the WHIP endpoint of an ingress and returns
+// the answer and the url of the created WHIP resource.
+func (c *livekitApiClient) PublishWhip(ctx context.Context, ingress *livekitIngressInfo, offer string) (string, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ingress.Url, strings.NewReader(offer))
+	if err != nil {
+		return "", "", err
+	}
+
+	req.Header.Set("Content-Type", "application/sdp")
+	req.Header.Set("Authorization", "Bearer "+ingress.StreamKey)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", "", err
+	}
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("WHIP request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var resource string
+	if location, err := resp.Location(); err == nil {
+		resource = location.String()
+	}
+	return string(body), resource, nil
+}
+
+// DeleteWhip stops publishing to a WHIP resource.
+func (c *livekitApiClient) DeleteWhip(ctx context.Context, ingress *livekitIngressInfo, resource string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, resource, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+ingress.StreamKey)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("WHIP delete failed with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+type mcuLivekitPublisher struct {
+	mcu      *mcuLivekit
+	listener McuListener
+
+	id         PublicSessionId
+	sid        string
+	streamType StreamType
+	maxBitrate int
+	settings   NewPublisherSettings
+	room       string
+	ingress    *livekitIngressInfo
+
+	mu       sync.Mutex
+	resource string
+	closed   bool
+}
+
+func (p *mcuLivekitPublisher) Id() string {
+	return p.ingress.IngressId
+}
+
+func (p *mcuLivekitPublisher) Sid() string {
+	return p.sid
+}
+
+func (p *mcuLivekitPublisher) StreamType() StreamType {
+	return p.streamType
+}
+
+func (p *mcuLivekitPublisher) MaxBitrate() int {
+	return p.maxBitrate
+}
+
+func (p *mcuLivekitPublisher) PublisherId() PublicSessionId {
+	return p.id
+}
+
+func (p *mcuLivekitPublisher) HasMedia(mt MediaType) bool {
+	return (p.settings.MediaTypes & mt) == mt
+}
+
+func (p *mcuLivekitPublisher) SetMedia(mt MediaType) {
+	p.settings.MediaTypes = mt
+}
+
+func (p *mcuLivekitPublisher) Close(ctx context.Context) {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	resource := p.resource
+	p.resource = ""
+	p.mu.Unlock()
+
+	if resource != "" {
+		if err := p.mcu.api.DeleteWhip(ctx, p.ingress, resource); err != nil {
+			mcuLog.Warnf("Could not delete WHIP resource of publisher %s: %s", p.id, err)
+		}
+	}
+	if err := p.mcu.api.DeleteIngress(ctx, p.ingress.IngressId); err != nil {
+		mcuLog.Warnf("Could not delete ingress %s of publisher %s: %s", p.ingress.IngressId, p.id, err)
+	}
+	if err := p.mcu.api.DeleteRoom(ctx, p.room); err != nil {
+		mcuLog.Warnf("Could not delete room %s of publisher %s: %s", p.room, p.id, err)
+	}
+
+	p.mcu.unregisterPublisher(p)
+	p.listener.PublisherClosed(p)
+}
+
+func (p *mcuLivekitPublisher) SendMessage(ctx context.Context, message *MessageClientMessage, data *MessageClientMessageData, callback func(error, StringMap)) {
+	switch data.Type {
+	case "offer":
+		sdp, found := GetStringMapEntry[string](data.Payload, "sdp")
+		if !found {
+			go callback(errors.New("no sdp found in offer"), nil)
+			return
+		}
+
+		go func() {
+			msgctx, cancel := context.WithTimeout(context.Background(), p.mcu.settings.Timeout())
+			defer cancel()
+
+			answer, resource, err := p.mcu.api.PublishWhip(msgctx, p.ingress, sdp)
+			if err != nil {
+				callback(err, nil)
+				return
+			}
+
+			p.mu.Lock()
+			p.resource = resource
+			p.mu.Unlock()
+			callback(nil, StringMap{
+				"type": "answer",
+				"sdp":  answer,
+			})
+		}()
+	case "candidate":
+		// LiveKit includes its candidates in the answer and learns the
+		// candidates of the client from the connectivity checks.
+		go callback(nil, nil)
+	case "endOfCandidates":
+		go callback(nil, nil)
+	default:
+		go callback(fmt.Errorf("unsupported message type: %s", data.Type), nil)
+	}
+}
+
+type mcuLivekitStats struct {
+	Url        string `json:"url"`
+	Connected  bool   `json:"connected"`
+	Publishers int64  `json:"publishers"`
+}
+
+type mcuLivekit struct {
+	url      string
+	api      *livekitApiClient
+	settings *mcuCommonSettings
+
+	connected atomic.Bool
+	closeChan chan struct{}
+	closeOnce sync.Once
+
+	mu         sync.Mutex
+	publishers map[StreamId]*mcuLivekitPublisher
+
+	onConnected    atomic.Value
+	onDisconnected atomic.Value
+}
+
+func NewMcuLivekit(url string, config *goconf.ConfigFile) (Mcu, error) {
+	if url == "" {
+		return nil, errors.New("no LiveKit url configured")
+	}
+
+	apiKey, _ := GetStringOptionWithEnv(config, "mcu", "apikey")
+	apiSecret, _ := GetStringOptionWithEnv(config, "mcu", "apisecret")
+	if apiKey == "" || apiSecret == "" {
+		return nil, errors.New("no LiveKit api key / secret configured")
+	}
+
+	settings := &mcuCommonSettings{}
+	if err := settings.load(config); err != nil {
+		return nil, err
+	}
+	mcuTimeoutSeconds, _ := config.GetInt("mcu", "timeout")
+	if mcuTimeoutSeconds <= 0 {
+		mcuTimeoutSeconds = defaultMcuTimeoutSeconds
+	}
+	settings.setTimeout(time.Duration(mcuTimeoutSeconds) * time.Second)
+
+	skipVerify, _ := config.GetBool("mcu", "skipverify")
+	if skipVerify {
+		mcuLog.Warnf("MCU verification is disabled for %s", url)
+	}
+
+	mcu := &mcuLivekit{
+		url:       url,
+		api:       newLivekitApiClient(url, apiKey, apiSecret, skipVerify),
+		settings:  settings,
+		closeChan: make(chan struct{}),
+
+		publishers: make(map[StreamId]*mcuLivekitPublisher),
+	}
+	mcu.onConnected.Store(emptyOnConnected)
+	mcu.onDisconnected.Store(emptyOnDisconnected)
+	return mcu, nil
+}
+
+func (m *mcuLivekit) Start(ctx context.Context) error {
+	rooms, err := m.api.ListRooms(ctx)
+	if err != nil {
+		return err
+	}
+
+	mcuLog.Infof("Connected to LiveKit server at %s with %d active rooms", m.url, len(rooms))
+	m.connected.Store(true)
+	go m.run()
+	return nil
+}
+
+func (m *mcuLivekit) run() {
+	ticker := time.NewTicker(livekitCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			m.checkConnection()
+		case <-m.closeChan:
+			return
+		}
+	}
+}
+
+func (m *mcuLivekit) checkConnection() {
+	ctx, cancel := context.WithTimeout(context.Background(), m.settings.Timeout())
+	defer cancel()
+
+	_, err := m.api.ListRooms(ctx)
+	if err != nil {
+		if m.connected.CompareAndSwap(true, false) {
+			mcuLog.Errorf("Connection to LiveKit server at %s lost: %s", m.url, err)
+			m.onDisconnected.Load().(func())()
+		}
+		return
+	}
+
+	if m.connected.CompareAndSwap(false, true) {
+		mcuLog.Infof("Connection to LiveKit server at %s restored", m.url)
+		m.onConnected.Load().(func())()
+	}
+}
+
+func (m *mcuLivekit) Stop() {
+	m.closeOnce.Do(func() {
+		close(m.closeChan)
+	})
+
+	m.mu.Lock()
+	publishers := make([]*mcuLivekitPublisher, 0, len(m.publishers))
+	for _, publisher := range m.publishers {
+		publishers = append(publishers, publisher)
+	}
+	m.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.settings.Timeout())
+	defer cancel()
+	for _, publisher := range publishers {
+		publisher.Close(ctx)
+	}
+}
+
+func (m *mcuLivekit) Reload(config *goconf.ConfigFile) error {
+	return m.settings.load(config)
+}
+
+func (m *mcuLivekit) SetOnConnected(f func()) {
+	if f == nil {
+		f = emptyOnConnected
+	}
+
+	m.onConnected.Store(f)
+}
+
+func (m *mcuLivekit) SetOnDisconnected(f func()) {
+	if f == nil {
+		f = emptyOnDisconnected
+	}
+
+	m.onDisconnected.Store(f)
+}
+
+func (m *mcuLivekit) GetStats() any {
+	result := mcuLivekitStats{
+		Url:       m.url,
+		Connected: m.connected.Load(),
+	}
+	m.mu.Lock()
+	result.Publishers = int64(len(m.publishers))
+	m.mu.Unlock()
+	return result
+}
+
+func (m *mcuLivekit) GetServerInfoSfu() *BackendServerInfoSfu {
+	return &BackendServerInfoSfu{
+		Mode: SfuModeLivekit,
+		Livekit: &BackendServerInfoSfuLivekit{
+			Url:       m.url,
+			Connected: m.connected.Load(),
+		},
+	}
+}
+
+func (m *mcuLivekit) unregisterPublisher(publisher *mcuLivekitPublisher) {
+	key := getStreamId(publisher.id, publisher.streamType)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.publishers[key] == publisher {
+		delete(m.publishers, key)
+	}
+}
+
+func (m *mcuLivekit) NewPublisher(ctx context.Context, listener McuListener, id PublicSessionId, sid string, streamType StreamType, settings NewPublisherSettings, initiator McuInitiator) (McuPublisher, error) {
+	ctx, done := startMcuOperation(ctx, "NewPublisher", id, streamType)
+	result, err := m.newPublisher(ctx, listener, id, sid, streamType, settings)
+	done(err)
+	return result, err
+}
+
+func (m *mcuLivekit) newPublisher(ctx context.Context, listener McuListener, id PublicSessionId, sid string, streamType StreamType, settings NewPublisherSettings) (McuPublisher, error) {
+	if _, found := streamTypeUserIds[streamType]; !found {
+		return nil, fmt.Errorf("unsupported stream type %s", streamType)
+	}
+
+	var maxBitrate int
+	if streamType == StreamTypeScreen {
+		maxBitrate = int(m.settings.MaxScreenBitrate())
+	} else {
+		maxBitrate = int(m.settings.MaxStreamBitrate())
+	}
+	if settings.Bitrate <= 0 || settings.Bitrate > maxBitrate {
+		settings.Bitrate = maxBitrate
+	}
+
+	// Each stream is published to a separate LiveKit room.
+	room := string(getStreamId(id, streamType))
+	ingress, err := m.api.CreateIngress(ctx, &livekitCreateIngressRequest{
+		InputType:           livekitWhipInput,
+		Name:                room,
+		RoomName:            room,
+		ParticipantIdentity: string(id),
+		BypassTranscoding:   true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not create ingress: %w", err)
+	}
+
+	publisher := &mcuLivekitPublisher{
+		mcu:      m,
+		listener: listener,
+
+		id:         id,
+		sid:        sid,
+		streamType: streamType,
+		maxBitrate: settings.Bitrate,
+		settings:   settings,
+		room:       room,
+		ingress:    ingress,
+	}
+
+	mcuLog.Infof("Publisher %s is using LiveKit ingress %s", id, ingress.IngressId)
+	m.mu.Lock()
+	m.publishers[getStreamId(id, streamType)] = publisher
+	m.mu.Unlock()
+	return publisher, nil
+}
+
+func (m *mcuLivekit) NewSubscriber(ctx context.Context, listener McuListener, publisher PublicSessionId, streamType StreamType, initiator McuInitiator) (McuSubscriber, error) {
+	// The LiveKit server API doesn't provide a way to subscribe to streams
+	// through the signaling server, clients must connect to LiveKit directly.
+	return nil, ErrLivekitSubscriberNotSupported
+}
@@ -0,0 +1,277 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dlintw/goconf"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testLivekitApiKey    = "the-key"
+	testLivekitApiSecret = "the-secret"
+	testLivekitStreamKey = "the-stream-key"
+	testLivekitAnswer    = "v=0\r\no=- 1 1 IN IP4 127.0.0.1\r\n"
+)
+
+type testLivekitServer struct {
+	t      *testing.T
+	server *httptest.Server
+
+	mu       sync.Mutex
+	ingress  map[string]*livekitCreateIngressRequest
+	rooms    map[string]bool
+	resource map[string]bool
+}
+
+func newTestLivekitServer(t *testing.T) *testLivekitServer {
+	s := &testLivekitServer{
+		t:        t,
+		ingress:  make(map[string]*livekitCreateIngressRequest),
+		rooms:    make(map[string]bool),
+		resource: make(map[string]bool),
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.server.Close)
+	return s
+}
+
+func (s *testLivekitServer) checkToken(r *http.Request) *livekitVideoGrant {
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !assert.True(s.t, found) {
+		return nil
+	}
+
+	var claims livekitClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(token *jwt.Token) (any, error) {
+		return []byte(testLivekitApiSecret), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if !assert.NoError(s.t, err) || !assert.Equal(s.t, testLivekitApiKey, claims.Issuer) {
+		return nil
+	}
+
+	return claims.Video
+}
+
+func (s *testLivekitServer) handle(w http.ResponseWriter, r *http.Request) {
+	assert := assert.New(s.t)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case r.URL.Path == "/whip":
+		assert.Equal("Bearer "+testLivekitStreamKey, r.Header.Get("Authorization"))
+		assert.Equal(http.MethodPost, r.Method)
+		assert.Equal("application/sdp", r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(MockSdpOfferAudioOnly, string(body))
+		s.resource["/whip/resource"] = true
+		w.Header().Set("Location", "/whip/resource")
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, testLivekitAnswer) // nolint
+		return
+	case r.URL.Path == "/whip/resource":
+		assert.Equal(http.MethodDelete, r.Method)
+		delete(s.resource, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	grant := s.checkToken(r)
+	if grant == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var response any
+	switch r.URL.Path {
+	case "/twirp/livekit.RoomService/ListRooms":
+		assert.True(grant.RoomList)
+		response = &livekitListRoomsResponse{}
+	case "/twirp/livekit.RoomService/DeleteRoom":
+		assert.True(grant.RoomCreate)
+		var request livekitDeleteRoomRequest
+		assert.NoError(json.NewDecoder(r.Body).Decode(&request))
+		delete(s.rooms, request.Room)
+		response = StringMap{}
+	case "/twirp/livekit.Ingress/CreateIngress":
+		assert.True(grant.IngressAdmin)
+		var request livekitCreateIngressRequest
+		assert.NoError(json.NewDecoder(r.Body).Decode(&request))
+		s.ingress["ingress-id"] = &request
+		s.rooms[request.RoomName] = true
+		response = &livekitIngressInfo{
+			IngressId: "ingress-id",
+			StreamKey: testLivekitStreamKey,
+			Url:       s.server.URL + "/whip",
+			RoomName:  request.RoomName,
+		}
+	case "/twirp/livekit.Ingress/DeleteIngress":
+		assert.True(grant.IngressAdmin)
+		var request livekitDeleteIngressRequest
+		assert.NoError(json.NewDecoder(r.Body).Decode(&request))
+		if _, found := s.ingress[request.IngressId]; !found {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(&livekitApiError{Code: "not_found", Message: "ingress not found"}) // nolint
+			return
+		}
+		delete(s.ingress, request.IngressId)
+		response = StringMap{}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	assert.NoError(json.NewEncoder(w).Encode(response))
+}
+
+type testLivekitListener struct {
+	TestMcuListener
+
+	closed chan McuPublisher
+}
+
+func (l *testLivekitListener) PublisherClosed(publisher McuPublisher) {
+	l.closed <- publisher
+}
+
+func TestMcuLivekit_Config(t *testing.T) {
+	CatchLogForTest(t)
+	assert := assert.New(t)
+
+	config := goconf.NewConfigFile()
+	_, err := NewMcuLivekit("", config)
+	assert.Error(err)
+	_, err = NewMcuLivekit("https://livekit.domain.invalid", config)
+	assert.Error(err)
+
+	config.AddOption("mcu", "apikey", testLivekitApiKey)
+	config.AddOption("mcu", "apisecret", testLivekitApiSecret)
+	mcu, err := NewMcuLivekit("wss://livekit.domain.invalid/", config)
+	require.NoError(t, err)
+	assert.Equal("https://livekit.domain.invalid", mcu.(*mcuLivekit).api.url)
+}
+
+func TestMcuLivekit(t *testing.T) {
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	require := require.New(t)
+	server := newTestLivekitServer(t)
+
+	config := goconf.NewConfigFile()
+	config.AddOption("mcu", "apikey", testLivekitApiKey)
+	config.AddOption("mcu", "apisecret", testLivekitApiSecret)
+	mcu, err := NewMcuLivekit(server.server.URL, config)
+	require.NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	require.NoError(mcu.Start(ctx))
+	defer mcu.Stop()
+
+	if info := mcu.GetServerInfoSfu(); assert.NotNil(info) && assert.NotNil(info.Livekit) {
+		assert.Equal(SfuModeLivekit, info.Mode)
+		assert.Equal(server.server.URL, info.Livekit.Url)
+		assert.True(info.Livekit.Connected)
+	}
+
+	listener := &testLivekitListener{
+		TestMcuListener: TestMcuListener{
+			id: "publisher-id",
+		},
+		closed: make(chan McuPublisher, 1),
+	}
+	pub, err := mcu.NewPublisher(ctx, listener, listener.id, "sid", StreamTypeVideo, NewPublisherSettings{
+		MediaTypes: MediaTypeAudio,
+	}, nil)
+	require.NoError(err)
+	assert.Equal(listener.id, pub.PublisherId())
+	assert.Equal("ingress-id", pub.Id())
+	assert.Equal(defaultMaxStreamBitrate, pub.MaxBitrate())
+	assert.True(pub.HasMedia(MediaTypeAudio))
+	assert.False(pub.HasMedia(MediaTypeVideo))
+
+	server.mu.Lock()
+	if request, found := server.ingress["ingress-id"]; assert.True(found) {
+		assert.Equal(livekitWhipInput, request.InputType)
+		assert.Equal(string(listener.id), request.ParticipantIdentity)
+		assert.Equal(string(getStreamId(listener.id, StreamTypeVideo)), request.RoomName)
+	}
+	server.mu.Unlock()
+
+	answer := make(chan StringMap, 1)
+	pub.SendMessage(ctx, &MessageClientMessage{}, &MessageClientMessageData{
+		Type: "offer",
+		Payload: StringMap{
+			"sdp": MockSdpOfferAudioOnly,
+		},
+	}, func(err error, response StringMap) {
+		assert.NoError(err)
+		answer <- response
+	})
+	select {
+	case response := <-answer:
+		assert.Equal(StringMap{
+			"type": "answer",
+			"sdp":  testLivekitAnswer,
+		}, response)
+	case <-ctx.Done():
+		assert.Fail("no answer received")
+	}
+
+	_, err = mcu.NewSubscriber(ctx, listener, listener.id, StreamTypeVideo, nil)
+	assert.ErrorIs(err, ErrLivekitSubscriberNotSupported)
+
+	stats := mcu.GetStats().(mcuLivekitStats)
+	assert.EqualValues(1, stats.Publishers)
+
+	pub.Close(ctx)
+	assert.Equal(pub, <-listener.closed)
+	server.mu.Lock()
+	assert.Empty(server.ingress)
+	assert.Empty(server.rooms)
+	assert.Empty(server.resource)
+	server.mu.Unlock()
+
+	stats = mcu.GetStats().(mcuLivekitStats)
+	assert.EqualValues(0, stats.Publishers)
+
+	// Errors from the server API are returned.
+	err = mcu.(*mcuLivekit).api.DeleteIngress(ctx, "unknown")
+	var apiErr *livekitApiError
+	if assert.ErrorAs(err, &apiErr) {
+		assert.Equal("not_found", apiErr.Code)
+	}
+}
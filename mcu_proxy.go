@@ -29,7 +29,7 @@ import (
 	"errors"
 	"fmt"
 	"iter"
-	"log"
+	"log/slog"
 	"math/rand/v2"
 	"net"
 	"net/http"
@@ -109,7 +109,7 @@ func (c *mcuProxyPubSubCommon) doSendMessage(ctx context.Context, msg *ProxyClie
 		}
 
 		if proxyDebugMessages {
-			log.Printf("Response from %s: %+v", c.conn, response)
+			mcuLog.Infof("Response from %s: %+v", c.conn, response)
 		}
 		if response.Type == "error" {
 			callback(response.Error, nil)
@@ -126,7 +126,7 @@ func (c *mcuProxyPubSubCommon) doProcessPayload(client McuClient, msg *PayloadPr
 	case "offer":
 		offer, ok := ConvertStringMap(msg.Payload["offer"])
 		if !ok {
-			log.Printf("Unsupported payload from %s: %+v", c.conn, msg)
+			mcuLog.Warnf("Unsupported payload from %s: %+v", c.conn, msg)
 			return
 		}
 
@@ -134,7 +134,7 @@ func (c *mcuProxyPubSubCommon) doProcessPayload(client McuClient, msg *PayloadPr
 	case "candidate":
 		c.listener.OnIceCandidate(client, msg.Payload["candidate"])
 	default:
-		log.Printf("Unsupported payload from %s: %+v", c.conn, msg)
+		mcuLog.Warnf("Unsupported payload from %s: %+v", c.conn, msg)
 	}
 }
 
@@ -143,9 +143,10 @@ type mcuProxyPublisher struct {
 
 	id       PublicSessionId
 	settings NewPublisherSettings
+	country  string
 }
 
-func newMcuProxyPublisher(id PublicSessionId, sid string, streamType StreamType, maxBitrate int, settings NewPublisherSettings, proxyId string, conn *mcuProxyConnection, listener McuListener) *mcuProxyPublisher {
+func newMcuProxyPublisher(id PublicSessionId, sid string, streamType StreamType, maxBitrate int, settings NewPublisherSettings, country string, proxyId string, conn *mcuProxyConnection, listener McuListener) *mcuProxyPublisher {
 	return &mcuProxyPublisher{
 		mcuProxyPubSubCommon: mcuProxyPubSubCommon{
 			sid:        sid,
@@ -157,6 +158,7 @@ func newMcuProxyPublisher(id PublicSessionId, sid string, streamType StreamType,
 		},
 		id:       id,
 		settings: settings,
+		country:  country,
 	}
 }
 
@@ -174,7 +176,7 @@ func (p *mcuProxyPublisher) SetMedia(mt MediaType) {
 }
 
 func (p *mcuProxyPublisher) NotifyClosed() {
-	log.Printf("Publisher %s at %s was closed", p.proxyId, p.conn)
+	mcuLog.Infof("Publisher %s at %s was closed", p.proxyId, p.conn)
 	p.listener.PublisherClosed(p)
 	p.conn.removePublisher(p)
 }
@@ -191,14 +193,14 @@ func (p *mcuProxyPublisher) Close(ctx context.Context) {
 	}
 
 	if response, _, err := p.conn.performSyncRequest(ctx, msg); err != nil {
-		log.Printf("Could not delete publisher %s at %s: %s", p.proxyId, p.conn, err)
+		mcuLog.Errorf("Could not delete publisher %s at %s: %s", p.proxyId, p.conn, err)
 		return
 	} else if response.Type == "error" {
-		log.Printf("Could not delete publisher %s at %s: %s", p.proxyId, p.conn, response.Error)
+		mcuLog.Errorf("Could not delete publisher %s at %s: %s", p.proxyId, p.conn, response.Error)
 		return
 	}
 
-	log.Printf("Deleted publisher %s at %s", p.proxyId, p.conn)
+	mcuLog.Infof("Deleted publisher %s at %s", p.proxyId, p.conn)
 }
 
 func (p *mcuProxyPublisher) SendMessage(ctx context.Context, message *MessageClientMessage, data *MessageClientMessageData, callback func(error, StringMap)) {
@@ -226,7 +228,7 @@ func (p *mcuProxyPublisher) ProcessEvent(msg *EventProxyServerMessage) {
 	case "publisher-closed":
 		p.NotifyClosed()
 	default:
-		log.Printf("Unsupported event from %s: %+v", p.conn, msg)
+		mcuLog.Warnf("Unsupported event from %s: %+v", p.conn, msg)
 	}
 }
 
@@ -259,9 +261,9 @@ func (s *mcuProxySubscriber) Publisher() PublicSessionId {
 
 func (s *mcuProxySubscriber) NotifyClosed() {
 	if s.publisherConn != nil {
-		log.Printf("Remote subscriber %s at %s (forwarded to %s) was closed", s.proxyId, s.conn, s.publisherConn)
+		mcuLog.Infof("Remote subscriber %s at %s (forwarded to %s) was closed", s.proxyId, s.conn, s.publisherConn)
 	} else {
-		log.Printf("Subscriber %s at %s was closed", s.proxyId, s.conn)
+		mcuLog.Infof("Subscriber %s at %s was closed", s.proxyId, s.conn)
 	}
 	s.listener.SubscriberClosed(s)
 	s.conn.removeSubscriber(s)
@@ -280,24 +282,24 @@ func (s *mcuProxySubscriber) Close(ctx context.Context) {
 
 	if response, _, err := s.conn.performSyncRequest(ctx, msg); err != nil {
 		if s.publisherConn != nil {
-			log.Printf("Could not delete remote subscriber %s at %s (forwarded to %s): %s", s.proxyId, s.conn, s.publisherConn, err)
+			mcuLog.Errorf("Could not delete remote subscriber %s at %s (forwarded to %s): %s", s.proxyId, s.conn, s.publisherConn, err)
 		} else {
-			log.Printf("Could not delete subscriber %s at %s: %s", s.proxyId, s.conn, err)
+			mcuLog.Errorf("Could not delete subscriber %s at %s: %s", s.proxyId, s.conn, err)
 		}
 		return
 	} else if response.Type == "error" {
 		if s.publisherConn != nil {
-			log.Printf("Could not delete remote subscriber %s at %s (forwarded to %s): %s", s.proxyId, s.conn, s.publisherConn, response.Error)
+			mcuLog.Errorf("Could not delete remote subscriber %s at %s (forwarded to %s): %s", s.proxyId, s.conn, s.publisherConn, response.Error)
 		} else {
-			log.Printf("Could not delete subscriber %s at %s: %s", s.proxyId, s.conn, response.Error)
+			mcuLog.Errorf("Could not delete subscriber %s at %s: %s", s.proxyId, s.conn, response.Error)
 		}
 		return
 	}
 
 	if s.publisherConn != nil {
-		log.Printf("Deleted remote subscriber %s at %s (forwarded to %s)", s.proxyId, s.conn, s.publisherConn)
+		mcuLog.Infof("Deleted remote subscriber %s at %s (forwarded to %s)", s.proxyId, s.conn, s.publisherConn)
 	} else {
-		log.Printf("Deleted subscriber %s at %s", s.proxyId, s.conn)
+		mcuLog.Infof("Deleted subscriber %s at %s", s.proxyId, s.conn)
 	}
 }
 
@@ -329,7 +331,7 @@ func (s *mcuProxySubscriber) ProcessEvent(msg *EventProxyServerMessage) {
 	case "subscriber-closed":
 		s.NotifyClosed()
 	default:
-		log.Printf("Unsupported event from %s: %+v", s.conn, msg)
+		mcuLog.Warnf("Unsupported event from %s: %+v", s.conn, msg)
 	}
 }
 
@@ -448,17 +450,7 @@ func (c *mcuProxyConnection) IsSameContinent(initiator McuInitiator) bool {
 		return true
 	}
 
-	initiatorContinents, found := ContinentMap[initiatorCountry]
-	if found {
-		m := c.proxy.getContinentsMap()
-		// Map continents to other continents (e.g. use Europe for Africa).
-		for _, continent := range initiatorContinents {
-			if toAdd, found := m[continent]; found {
-				initiatorContinents = append(initiatorContinents, toAdd...)
-			}
-		}
-
-	}
+	initiatorContinents := getMappedContinents(initiatorCountry, c.proxy.getContinentsMap())
 	connContinents := ContinentMap[connCountry]
 	return ContinentsOverlap(initiatorContinents, connContinents)
 }
@@ -511,6 +503,12 @@ func (c *mcuProxyConnection) Bandwidth() *EventProxyServerBandwidth {
 }
 
 func (c *mcuProxyConnection) Country() string {
+	if c.proxy != nil {
+		if country, found := c.proxy.getCountryOverride(c.rawUrl); found {
+			return country
+		}
+	}
+
 	return c.country.Load().(string)
 }
 
@@ -581,7 +579,7 @@ func (c *mcuProxyConnection) readPump() {
 			rtt := now.Sub(time.Unix(0, ts))
 			if rtt >= rttLogDuration {
 				rtt_ms := rtt.Nanoseconds() / time.Millisecond.Nanoseconds()
-				log.Printf("Proxy at %s has RTT of %d ms (%s)", c, rtt_ms, rtt)
+				mcuLog.Infof("Proxy at %s has RTT of %d ms (%s)", c, rtt_ms, rtt)
 			}
 		}
 		return nil
@@ -597,14 +595,14 @@ func (c *mcuProxyConnection) readPump() {
 				websocket.CloseNormalClosure,
 				websocket.CloseGoingAway,
 				websocket.CloseNoStatusReceived) {
-				log.Printf("Error reading from %s: %v", c, err)
+				mcuLog.Errorf("Error reading from %s: %v", c, err)
 			}
 			break
 		}
 
 		var msg ProxyServerMessage
 		if err := json.Unmarshal(message, &msg); err != nil {
-			log.Printf("Error unmarshaling %s from %s: %s", string(message), c, err)
+			mcuLog.Errorf("Error unmarshaling %s from %s: %s", string(message), c, err)
 			continue
 		}
 
@@ -623,7 +621,7 @@ func (c *mcuProxyConnection) sendPing() bool {
 	msg := strconv.FormatInt(now.UnixNano(), 10)
 	c.conn.SetWriteDeadline(now.Add(writeWait)) // nolint
 	if err := c.conn.WriteMessage(websocket.PingMessage, []byte(msg)); err != nil {
-		log.Printf("Could not send ping to proxy at %s: %v", c, err)
+		mcuLog.Errorf("Could not send ping to proxy at %s: %v", c, err)
 		go c.scheduleReconnect()
 		return false
 	}
@@ -675,7 +673,7 @@ func (c *mcuProxyConnection) stop(ctx context.Context) {
 	c.closer.Close()
 	if err := c.sendClose(); err != nil {
 		if err != ErrNotConnected {
-			log.Printf("Could not send close message to %s: %s", c, err)
+			mcuLog.Errorf("Could not send close message to %s: %s", c, err)
 		}
 		c.close()
 		return
@@ -685,7 +683,7 @@ func (c *mcuProxyConnection) stop(ctx context.Context) {
 	case <-c.closedDone.C:
 	case <-ctx.Done():
 		if err := ctx.Err(); err != nil {
-			log.Printf("Error waiting for connection to %s get closed: %s", c, err)
+			mcuLog.Errorf("Error waiting for connection to %s get closed: %s", c, err)
 			c.close()
 		}
 	}
@@ -724,7 +722,7 @@ func (c *mcuProxyConnection) closeIfEmpty() bool {
 	c.subscribersLock.RUnlock()
 	if total > 0 {
 		// Connection will be closed once all clients have disconnected.
-		log.Printf("Connection to %s is still used by %d clients, defer closing", c, total)
+		mcuLog.Infof("Connection to %s is still used by %d clients, defer closing", c, total)
 		return false
 	}
 
@@ -732,7 +730,7 @@ func (c *mcuProxyConnection) closeIfEmpty() bool {
 		ctx, cancel := context.WithTimeout(context.Background(), closeTimeout)
 		defer cancel()
 
-		log.Printf("All clients disconnected, closing connection to %s", c)
+		mcuLog.Infof("All clients disconnected, closing connection to %s", c)
 		c.stop(ctx)
 
 		c.proxy.removeConnection(c)
@@ -742,7 +740,7 @@ func (c *mcuProxyConnection) closeIfEmpty() bool {
 
 func (c *mcuProxyConnection) scheduleReconnect() {
 	if err := c.sendClose(); err != nil && err != ErrNotConnected {
-		log.Printf("Could not send close message to %s: %s", c, err)
+		mcuLog.Errorf("Could not send close message to %s: %s", c, err)
 	}
 	c.close()
 
@@ -764,7 +762,7 @@ func (c *mcuProxyConnection) scheduleReconnect() {
 func (c *mcuProxyConnection) reconnect() {
 	u, err := c.url.Parse("proxy")
 	if err != nil {
-		log.Printf("Could not resolve url to proxy at %s: %s", c, err)
+		mcuLog.Errorf("Could not resolve url to proxy at %s: %s", c, err)
 		c.scheduleReconnect()
 		return
 	}
@@ -794,7 +792,7 @@ func (c *mcuProxyConnection) reconnect() {
 	}
 	conn, _, err := dialer.Dial(u.String(), nil)
 	if err != nil {
-		log.Printf("Could not connect to %s: %s", c, err)
+		mcuLog.Errorf("Could not connect to %s: %s", c, err)
 		c.scheduleReconnect()
 		return
 	}
@@ -804,7 +802,7 @@ func (c *mcuProxyConnection) reconnect() {
 		return
 	}
 
-	log.Printf("Connected to %s", c)
+	mcuLog.Eventf(RecentEventTypeConnection, slog.LevelInfo, "Connected to %s", c)
 	c.closed.Store(false)
 	c.helloProcessed.Store(false)
 
@@ -816,7 +814,7 @@ func (c *mcuProxyConnection) reconnect() {
 	c.reconnectInterval.Store(int64(initialReconnectInterval))
 	c.shutdownScheduled.Store(false)
 	if err := c.sendHello(); err != nil {
-		log.Printf("Could not send hello request to %s: %s", c, err)
+		mcuLog.Errorf("Could not send hello request to %s: %s", c, err)
 		c.scheduleReconnect()
 		return
 	}
@@ -853,6 +851,8 @@ func (c *mcuProxyConnection) removePublisher(publisher *mcuProxyPublisher) {
 	if _, found := c.publishers[publisher.proxyId]; found {
 		delete(c.publishers, publisher.proxyId)
 		statsPublishersCurrent.WithLabelValues(string(publisher.StreamType())).Dec()
+		country, continent := getStatsCountryLabels(publisher.country)
+		statsMcuPublishersCountry.WithLabelValues(string(publisher.StreamType()), country, continent).Dec()
 	}
 	delete(c.publisherIds, getStreamId(publisher.id, publisher.StreamType()))
 
@@ -962,19 +962,19 @@ func (c *mcuProxyConnection) processMessage(msg *ProxyServerMessage) {
 		switch msg.Type {
 		case "error":
 			if msg.Error.Code == "no_such_session" {
-				log.Printf("Session %s could not be resumed on %s, registering new", c.SessionId(), c)
+				mcuLog.Infof("Session %s could not be resumed on %s, registering new", c.SessionId(), c)
 				c.clearPublishers()
 				c.clearSubscribers()
 				c.clearCallbacks()
 				c.sessionId.Store(PublicSessionId(""))
 				if err := c.sendHello(); err != nil {
-					log.Printf("Could not send hello request to %s: %s", c, err)
+					mcuLog.Errorf("Could not send hello request to %s: %s", c, err)
 					c.scheduleReconnect()
 				}
 				return
 			}
 
-			log.Printf("Hello connection to %s failed with %+v, reconnecting", c, msg.Error)
+			mcuLog.Eventf(RecentEventTypeConnection, slog.LevelInfo, "Hello connection to %s failed with %+v, reconnecting", c, msg.Error)
 			c.scheduleReconnect()
 		case "hello":
 			resumed := c.SessionId() == msg.Hello.SessionId
@@ -982,7 +982,7 @@ func (c *mcuProxyConnection) processMessage(msg *ProxyServerMessage) {
 			country := ""
 			if server := msg.Hello.Server; server != nil {
 				if country = server.Country; country != "" && !IsValidCountry(country) {
-					log.Printf("Proxy %s sent invalid country %s in hello response", c, country)
+					mcuLog.Infof("Proxy %s sent invalid country %s in hello response", c, country)
 					country = ""
 				}
 				c.version.Store(server.Version)
@@ -996,11 +996,11 @@ func (c *mcuProxyConnection) processMessage(msg *ProxyServerMessage) {
 			}
 			c.country.Store(country)
 			if resumed {
-				log.Printf("Resumed session %s on %s", c.SessionId(), c)
+				mcuLog.Infof("Resumed session %s on %s", c.SessionId(), c)
 			} else if country != "" {
-				log.Printf("Received session %s from %s (in %s)", c.SessionId(), c, country)
+				mcuLog.Infof("Received session %s from %s (in %s)", c.SessionId(), c, country)
 			} else {
-				log.Printf("Received session %s from %s", c.SessionId(), c)
+				mcuLog.Infof("Received session %s from %s", c.SessionId(), c)
 			}
 			if c.trackClose.CompareAndSwap(false, true) {
 				statsConnectedProxyBackendsCurrent.WithLabelValues(c.Country()).Inc()
@@ -1009,14 +1009,14 @@ func (c *mcuProxyConnection) processMessage(msg *ProxyServerMessage) {
 			c.helloProcessed.Store(true)
 			c.connectedNotifier.Notify()
 		default:
-			log.Printf("Received unsupported hello response %+v from %s, reconnecting", msg, c)
+			mcuLog.Warnf("Received unsupported hello response %+v from %s, reconnecting", msg, c)
 			c.scheduleReconnect()
 		}
 		return
 	}
 
 	if proxyDebugMessages {
-		log.Printf("Received from %s: %+v", c, msg)
+		mcuLog.Infof("Received from %s: %+v", c, msg)
 	}
 	if callback := c.getCallback(msg.Id); callback != nil {
 		callback(msg)
@@ -1034,7 +1034,7 @@ func (c *mcuProxyConnection) processMessage(msg *ProxyServerMessage) {
 	case "bye":
 		c.processBye(msg)
 	default:
-		log.Printf("Unsupported message received from %s: %+v", c, msg)
+		mcuLog.Warnf("Unsupported message received from %s: %+v", c, msg)
 	}
 }
 
@@ -1056,38 +1056,38 @@ func (c *mcuProxyConnection) processPayload(msg *ProxyServerMessage) {
 		return
 	}
 
-	log.Printf("Received payload for unknown client %+v from %s", payload, c)
+	mcuLog.Infof("Received payload for unknown client %+v from %s", payload, c)
 }
 
 func (c *mcuProxyConnection) processEvent(msg *ProxyServerMessage) {
 	event := msg.Event
 	switch event.Type {
 	case "backend-disconnected":
-		log.Printf("Upstream backend at %s got disconnected, reset MCU objects", c)
+		mcuLog.Eventf(RecentEventTypeConnection, slog.LevelInfo, "Upstream backend at %s got disconnected, reset MCU objects", c)
 		c.clearPublishers()
 		c.clearSubscribers()
 		c.clearCallbacks()
 		// TODO: Should we also reconnect?
 		return
 	case "backend-connected":
-		log.Printf("Upstream backend at %s is connected", c)
+		mcuLog.Eventf(RecentEventTypeConnection, slog.LevelInfo, "Upstream backend at %s is connected", c)
 		return
 	case "update-load":
 		if proxyDebugMessages {
-			log.Printf("Load of %s now at %d (%s)", c, event.Load, event.Bandwidth)
+			mcuLog.Infof("Load of %s now at %d (%s)", c, event.Load, event.Bandwidth)
 		}
 		c.load.Store(event.Load)
 		c.bandwidth.Store(event.Bandwidth)
 		statsProxyBackendLoadCurrent.WithLabelValues(c.url.String()).Set(float64(event.Load))
 		return
 	case "shutdown-scheduled":
-		log.Printf("Proxy %s is scheduled to shutdown", c)
+		mcuLog.Infof("Proxy %s is scheduled to shutdown", c)
 		c.shutdownScheduled.Store(true)
 		return
 	}
 
 	if proxyDebugMessages {
-		log.Printf("Process event from %s: %+v", c, event)
+		mcuLog.Infof("Process event from %s: %+v", c, event)
 	}
 	c.publishersLock.RLock()
 	publisher, found := c.publishers[event.ClientId]
@@ -1105,20 +1105,20 @@ func (c *mcuProxyConnection) processEvent(msg *ProxyServerMessage) {
 		return
 	}
 
-	log.Printf("Received event for unknown client %+v from %s", event, c)
+	mcuLog.Infof("Received event for unknown client %+v from %s", event, c)
 }
 
 func (c *mcuProxyConnection) processBye(msg *ProxyServerMessage) {
 	bye := msg.Bye
 	switch bye.Reason {
 	case "session_resumed":
-		log.Printf("Session %s on %s was resumed by other client, resetting", c.SessionId(), c)
+		mcuLog.Infof("Session %s on %s was resumed by other client, resetting", c.SessionId(), c)
 	case "session_expired":
-		log.Printf("Session %s expired on %s, resetting", c.SessionId(), c)
+		mcuLog.Infof("Session %s expired on %s, resetting", c.SessionId(), c)
 	case "session_closed":
-		log.Printf("Session %s was closed on %s, resetting", c.SessionId(), c)
+		mcuLog.Infof("Session %s was closed on %s, resetting", c.SessionId(), c)
 	default:
-		log.Printf("Received bye with unsupported reason from %s %+v", c, bye)
+		mcuLog.Infof("Received bye with unsupported reason from %s %+v", c, bye)
 	}
 	c.sessionId.Store(PublicSessionId(""))
 }
@@ -1156,7 +1156,7 @@ func (c *mcuProxyConnection) sendMessage(msg *ProxyClientMessage) error {
 
 func (c *mcuProxyConnection) sendMessageLocked(msg *ProxyClientMessage) error {
 	if proxyDebugMessages {
-		log.Printf("Send message to %s: %+v", c, msg)
+		mcuLog.Infof("Send message to %s: %+v", c, msg)
 	}
 	if c.conn == nil {
 		return ErrNotConnected
@@ -1217,12 +1217,12 @@ func (c *mcuProxyConnection) performSyncRequest(ctx context.Context, msg *ProxyC
 
 func (c *mcuProxyConnection) deferredDeletePublisher(id PublicSessionId, streamType StreamType, response *ProxyServerMessage) {
 	if response.Type == "error" {
-		log.Printf("Publisher for %s was not created at %s: %s", id, c, response.Error)
+		mcuLog.Infof("Publisher for %s was not created at %s: %s", id, c, response.Error)
 		return
 	}
 
 	proxyId := response.Command.Id
-	log.Printf("Created unused %s publisher %s on %s for %s", streamType, proxyId, c, id)
+	mcuLog.Infof("Created unused %s publisher %s on %s for %s", streamType, proxyId, c, id)
 	msg := &ProxyClientMessage{
 		Type: "command",
 		Command: &CommandProxyClientMessage{
@@ -1235,17 +1235,17 @@ func (c *mcuProxyConnection) deferredDeletePublisher(id PublicSessionId, streamT
 	defer cancel()
 
 	if response, _, err := c.performSyncRequest(ctx, msg); err != nil {
-		log.Printf("Could not delete publisher %s at %s: %s", proxyId, c, err)
+		mcuLog.Errorf("Could not delete publisher %s at %s: %s", proxyId, c, err)
 		return
 	} else if response.Type == "error" {
-		log.Printf("Could not delete publisher %s at %s: %s", proxyId, c, response.Error)
+		mcuLog.Errorf("Could not delete publisher %s at %s: %s", proxyId, c, response.Error)
 		return
 	}
 
-	log.Printf("Deleted publisher %s at %s", proxyId, c)
+	mcuLog.Infof("Deleted publisher %s at %s", proxyId, c)
 }
 
-func (c *mcuProxyConnection) newPublisher(ctx context.Context, listener McuListener, id PublicSessionId, sid string, streamType StreamType, settings NewPublisherSettings) (McuPublisher, error) {
+func (c *mcuProxyConnection) newPublisher(ctx context.Context, listener McuListener, id PublicSessionId, sid string, streamType StreamType, settings NewPublisherSettings, country string) (McuPublisher, error) {
 	msg := &ProxyClientMessage{
 		Type: "command",
 		Command: &CommandProxyClientMessage{
@@ -1272,25 +1272,27 @@ func (c *mcuProxyConnection) newPublisher(ctx context.Context, listener McuListe
 	}
 
 	proxyId := response.Command.Id
-	log.Printf("Created %s publisher %s on %s for %s", streamType, proxyId, c, id)
-	publisher := newMcuProxyPublisher(id, sid, streamType, response.Command.Bitrate, settings, proxyId, c, listener)
+	mcuLog.Infof("Created %s publisher %s on %s for %s", streamType, proxyId, c, id)
+	publisher := newMcuProxyPublisher(id, sid, streamType, response.Command.Bitrate, settings, country, proxyId, c, listener)
 	c.publishersLock.Lock()
 	c.publishers[proxyId] = publisher
 	c.publisherIds[getStreamId(id, streamType)] = PublicSessionId(proxyId)
 	c.publishersLock.Unlock()
 	statsPublishersCurrent.WithLabelValues(string(streamType)).Inc()
 	statsPublishersTotal.WithLabelValues(string(streamType)).Inc()
+	countryLabel, continentLabel := getStatsCountryLabels(country)
+	statsMcuPublishersCountry.WithLabelValues(string(streamType), countryLabel, continentLabel).Inc()
 	return publisher, nil
 }
 
 func (c *mcuProxyConnection) deferredDeleteSubscriber(publisherSessionId PublicSessionId, streamType StreamType, publisherConn *mcuProxyConnection, response *ProxyServerMessage) {
 	if response.Type == "error" {
-		log.Printf("Subscriber for %s was not created at %s: %s", publisherSessionId, c, response.Error)
+		mcuLog.Infof("Subscriber for %s was not created at %s: %s", publisherSessionId, c, response.Error)
 		return
 	}
 
 	proxyId := response.Command.Id
-	log.Printf("Created unused %s subscriber %s on %s for %s", streamType, proxyId, c, publisherSessionId)
+	mcuLog.Infof("Created unused %s subscriber %s on %s for %s", streamType, proxyId, c, publisherSessionId)
 
 	msg := &ProxyClientMessage{
 		Type: "command",
@@ -1305,24 +1307,24 @@ func (c *mcuProxyConnection) deferredDeleteSubscriber(publisherSessionId PublicS
 
 	if response, _, err := c.performSyncRequest(ctx, msg); err != nil {
 		if publisherConn != nil {
-			log.Printf("Could not delete remote subscriber %s at %s (forwarded to %s): %s", proxyId, c, publisherConn, err)
+			mcuLog.Errorf("Could not delete remote subscriber %s at %s (forwarded to %s): %s", proxyId, c, publisherConn, err)
 		} else {
-			log.Printf("Could not delete subscriber %s at %s: %s", proxyId, c, err)
+			mcuLog.Errorf("Could not delete subscriber %s at %s: %s", proxyId, c, err)
 		}
 		return
 	} else if response.Type == "error" {
 		if publisherConn != nil {
-			log.Printf("Could not delete remote subscriber %s at %s (forwarded to %s): %s", proxyId, c, publisherConn, response.Error)
+			mcuLog.Errorf("Could not delete remote subscriber %s at %s (forwarded to %s): %s", proxyId, c, publisherConn, response.Error)
 		} else {
-			log.Printf("Could not delete subscriber %s at %s: %s", proxyId, c, response.Error)
+			mcuLog.Errorf("Could not delete subscriber %s at %s: %s", proxyId, c, response.Error)
 		}
 		return
 	}
 
 	if publisherConn != nil {
-		log.Printf("Deleted remote subscriber %s at %s (forwarded to %s)", proxyId, c, publisherConn)
+		mcuLog.Infof("Deleted remote subscriber %s at %s (forwarded to %s)", proxyId, c, publisherConn)
 	} else {
-		log.Printf("Deleted subscriber %s at %s", proxyId, c)
+		mcuLog.Infof("Deleted subscriber %s at %s", proxyId, c)
 	}
 }
 
@@ -1349,7 +1351,7 @@ func (c *mcuProxyConnection) newSubscriber(ctx context.Context, listener McuList
 	}
 
 	proxyId := response.Command.Id
-	log.Printf("Created %s subscriber %s on %s for %s", streamType, proxyId, c, publisherSessionId)
+	mcuLog.Infof("Created %s subscriber %s on %s for %s", streamType, proxyId, c, publisherSessionId)
 	subscriber := newMcuProxySubscriber(publisherSessionId, response.Command.Sid, streamType, response.Command.Bitrate, proxyId, c, listener, nil)
 	c.subscribersLock.Lock()
 	c.subscribers[proxyId] = subscriber
@@ -1396,7 +1398,7 @@ func (c *mcuProxyConnection) newRemoteSubscriber(ctx context.Context, listener M
 	}
 
 	proxyId := response.Command.Id
-	log.Printf("Created remote %s subscriber %s on %s for %s (forwarded to %s)", streamType, proxyId, c, publisherSessionId, publisherConn)
+	mcuLog.Infof("Created remote %s subscriber %s on %s for %s (forwarded to %s)", streamType, proxyId, c, publisherSessionId, publisherConn)
 	subscriber := newMcuProxySubscriber(publisherSessionId, response.Command.Sid, streamType, response.Command.Bitrate, proxyId, c, listener, publisherConn)
 	c.subscribersLock.Lock()
 	c.subscribers[proxyId] = subscriber
@@ -1429,14 +1431,14 @@ func (s *mcuProxySettings) load(config *goconf.ConfigFile) error {
 		proxyTimeoutSeconds = defaultProxyTimeoutSeconds
 	}
 	proxyTimeout := time.Duration(proxyTimeoutSeconds) * time.Second
-	log.Printf("Using a timeout of %s for proxy requests", proxyTimeout)
+	mcuLog.Infof("Using a timeout of %s for proxy requests", proxyTimeout)
 	s.setTimeout(proxyTimeout)
 	return nil
 }
 
 func (s *mcuProxySettings) Reload(config *goconf.ConfigFile) {
 	if err := s.load(config); err != nil {
-		log.Printf("Error reloading proxy settings: %s", err)
+		mcuLog.Errorf("Error reloading proxy settings: %s", err)
 	}
 }
 
@@ -1461,6 +1463,8 @@ type mcuProxy struct {
 	publisherWaiters ChannelWaiters
 
 	continentsMap atomic.Value
+	// Countries to use for proxies instead of the ones they report.
+	countryOverrides atomic.Pointer[map[string]string]
 
 	rpcClients *GrpcClients
 }
@@ -1510,13 +1514,14 @@ func NewMcuProxy(config *goconf.ConfigFile, etcdClient *EtcdClient, rpcClients *
 		rpcClients: rpcClients,
 	}
 
+	mcu.loadCountryOverrides(config)
 	if err := mcu.loadContinentsMap(config); err != nil {
 		return nil, err
 	}
 
 	skipverify, _ := config.GetBool("mcu", "skipverify")
 	if skipverify {
-		log.Println("WARNING: MCU verification is disabled!")
+		mcuLog.Warnf("MCU verification is disabled!")
 		mcu.dialer.TLSClientConfig = &tls.Config{
 			InsecureSkipVerify: skipverify,
 		}
@@ -1552,7 +1557,7 @@ func (m *mcuProxy) loadContinentsMap(config *goconf.ConfigFile) error {
 	for option, value := range options {
 		option = strings.ToUpper(strings.TrimSpace(option))
 		if !IsValidContinent(option) {
-			log.Printf("Ignore unknown continent %s", option)
+			mcuLog.Warnf("Ignore unknown continent %s", option)
 			continue
 		}
 
@@ -1560,24 +1565,50 @@ func (m *mcuProxy) loadContinentsMap(config *goconf.ConfigFile) error {
 		for v := range SplitEntries(value, ",") {
 			v = strings.ToUpper(v)
 			if !IsValidContinent(v) {
-				log.Printf("Ignore unknown continent %s for override %s", v, option)
+				mcuLog.Warnf("Ignore unknown continent %s for override %s", v, option)
 				continue
 			}
 			values = append(values, v)
 		}
 		if len(values) == 0 {
-			log.Printf("No valid values found for continent override %s, ignoring", option)
+			mcuLog.Infof("No valid values found for continent override %s, ignoring", option)
 			continue
 		}
 
 		continentsMap[option] = values
-		log.Printf("Mapping users on continent %s to %s", option, values)
+		mcuLog.Infof("Mapping users on continent %s to %s", option, values)
 	}
 
 	m.setContinentsMap(continentsMap)
 	return nil
 }
 
+func (m *mcuProxy) loadCountryOverrides(config *goconf.ConfigFile) {
+	options, _ := GetStringOptions(config, "proxy-countries", true)
+	overrides := make(map[string]string, len(options))
+	for proxyUrl, country := range options {
+		country = strings.ToUpper(strings.TrimSpace(country))
+		if !IsValidCountry(country) {
+			mcuLog.Warnf("Ignore invalid country %s for proxy %s", country, proxyUrl)
+			continue
+		}
+
+		overrides[proxyUrl] = country
+		mcuLog.Infof("Using country %s for proxy %s", country, proxyUrl)
+	}
+	m.countryOverrides.Store(&overrides)
+}
+
+func (m *mcuProxy) getCountryOverride(proxyUrl string) (string, bool) {
+	overrides := m.countryOverrides.Load()
+	if overrides == nil {
+		return "", false
+	}
+
+	country, found := (*overrides)[proxyUrl]
+	return country, found
+}
+
 func (m *mcuProxy) Start(ctx context.Context) error {
 	return m.config.Start()
 }
@@ -1658,7 +1689,7 @@ func (m *mcuProxy) AddConnection(ignoreErrors bool, url string, ips ...net.IP) e
 		conn, err := newMcuProxyConnection(m, url, nil, "")
 		if err != nil {
 			if ignoreErrors {
-				log.Printf("Could not create proxy connection to %s: %s", url, err)
+				mcuLog.Errorf("Could not create proxy connection to %s: %s", url, err)
 				return nil
 			}
 
@@ -1671,7 +1702,7 @@ func (m *mcuProxy) AddConnection(ignoreErrors bool, url string, ips ...net.IP) e
 			conn, err := newMcuProxyConnection(m, url, ip, "")
 			if err != nil {
 				if ignoreErrors {
-					log.Printf("Could not create proxy connection to %s (%s): %s", url, ip, err)
+					mcuLog.Errorf("Could not create proxy connection to %s (%s): %s", url, ip, err)
 					continue
 				}
 
@@ -1683,7 +1714,7 @@ func (m *mcuProxy) AddConnection(ignoreErrors bool, url string, ips ...net.IP) e
 	}
 
 	for _, conn := range conns {
-		log.Printf("Adding new connection to %s", conn)
+		mcuLog.Infof("Adding new connection to %s", conn)
 		conn.start()
 
 		m.connections = append(m.connections, conn)
@@ -1730,7 +1761,7 @@ func (m *mcuProxy) iterateConnections(url string, ips []net.IP) iter.Seq[*mcuPro
 
 func (m *mcuProxy) RemoveConnection(url string, ips ...net.IP) {
 	for conn := range m.iterateConnections(url, ips) {
-		log.Printf("Removing connection to %s", conn)
+		mcuLog.Infof("Removing connection to %s", conn)
 		conn.closeIfEmpty()
 	}
 }
@@ -1742,20 +1773,25 @@ func (m *mcuProxy) KeepConnection(url string, ips ...net.IP) {
 	}
 }
 
-func (m *mcuProxy) Reload(config *goconf.ConfigFile) {
+func (m *mcuProxy) Reload(config *goconf.ConfigFile) error {
 	m.settings.Reload(config)
 
 	if m.settings.Timeout() != m.dialer.HandshakeTimeout {
 		m.dialer.HandshakeTimeout = m.settings.Timeout()
 	}
 
+	var errs []error
+	m.loadCountryOverrides(config)
 	if err := m.loadContinentsMap(config); err != nil {
-		log.Printf("Error loading continents map: %s", err)
+		mcuLog.Errorf("Error loading continents map: %s", err)
+		errs = append(errs, NewConfigSectionError("continent-overrides", err))
 	}
 
 	if err := m.config.Reload(config); err != nil {
-		log.Printf("could not reload proxy configuration: %s", err)
+		mcuLog.Errorf("could not reload proxy configuration: %s", err)
+		errs = append(errs, NewConfigSectionError("mcu", err))
 	}
+	return errors.Join(errs...)
 }
 
 func (m *mcuProxy) removeConnection(c *mcuProxyConnection) {
@@ -1813,6 +1849,28 @@ func (m *mcuProxy) GetStats() any {
 	return result
 }
 
+func (c *mcuProxyConnection) getServerInfo() BackendServerInfoSfuProxy {
+	proxy := BackendServerInfoSfuProxy{
+		Url: c.rawUrl,
+
+		Temporary: c.IsTemporary(),
+	}
+	if len(c.ip) > 0 {
+		proxy.IP = c.ip.String()
+	}
+	if c.IsConnected() {
+		proxy.Connected = true
+		proxy.Shutdown = makePtr(c.IsShutdownScheduled())
+		proxy.Uptime = &c.connectedSince
+		proxy.Version = c.Version()
+		proxy.Features = c.Features()
+		proxy.Country = c.Country()
+		proxy.Load = makePtr(c.Load())
+		proxy.Bandwidth = c.Bandwidth()
+	}
+	return proxy
+}
+
 func (m *mcuProxy) GetServerInfoSfu() *BackendServerInfoSfu {
 	m.connectionsMu.RLock()
 	defer m.connectionsMu.RUnlock()
@@ -1821,25 +1879,7 @@ func (m *mcuProxy) GetServerInfoSfu() *BackendServerInfoSfu {
 		Mode: SfuModeProxy,
 	}
 	for _, c := range m.connections {
-		proxy := BackendServerInfoSfuProxy{
-			Url: c.rawUrl,
-
-			Temporary: c.IsTemporary(),
-		}
-		if len(c.ip) > 0 {
-			proxy.IP = c.ip.String()
-		}
-		if c.IsConnected() {
-			proxy.Connected = true
-			proxy.Shutdown = makePtr(c.IsShutdownScheduled())
-			proxy.Uptime = &c.connectedSince
-			proxy.Version = c.Version()
-			proxy.Features = c.Features()
-			proxy.Country = c.Country()
-			proxy.Load = makePtr(c.Load())
-			proxy.Bandwidth = c.Bandwidth()
-		}
-		sfu.Proxies = append(sfu.Proxies, proxy)
+		sfu.Proxies = append(sfu.Proxies, c.getServerInfo())
 	}
 	slices.SortFunc(sfu.Proxies, func(a, b BackendServerInfoSfuProxy) int {
 		c := strings.Compare(a.Url, b.Url)
@@ -1851,6 +1891,25 @@ func (m *mcuProxy) GetServerInfoSfu() *BackendServerInfoSfu {
 	return sfu
 }
 
+// GetAverageLoad returns the average load of the connected proxies.
+func (m *mcuProxy) GetAverageLoad() (int64, bool) {
+	m.connectionsMu.RLock()
+	defer m.connectionsMu.RUnlock()
+
+	var load int64
+	var count int64
+	for _, c := range m.connections {
+		if c.IsConnected() {
+			load += c.Load()
+			count++
+		}
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return load / count, true
+}
+
 func (m *mcuProxy) getContinentsMap() map[string][]string {
 	continentsMap := m.continentsMap.Load()
 	if continentsMap == nil {
@@ -1881,6 +1940,21 @@ func ContinentsOverlap(a, b []string) bool {
 	return false
 }
 
+// getMappedContinents returns the continents of a country including the
+// continents they are mapped to (e.g. use Europe for Africa).
+func getMappedContinents(country string, continentMap map[string][]string) []string {
+	continents, found := ContinentMap[country]
+	if !found {
+		return nil
+	}
+
+	result := slices.Clone(continents)
+	for _, continent := range continents {
+		result = append(result, continentMap[continent]...)
+	}
+	return result
+}
+
 func sortConnectionsForCountry(connections []*mcuProxyConnection, country string, continentMap map[string][]string) []*mcuProxyConnection {
 	// Move connections in the same country to the start of the list.
 	sorted := make(mcuProxyConnectionsList, 0, len(connections))
@@ -1892,14 +1966,8 @@ func sortConnectionsForCountry(connections []*mcuProxyConnection, country string
 			unprocessed = append(unprocessed, conn)
 		}
 	}
-	if continents, found := ContinentMap[country]; found && len(unprocessed) > 1 {
+	if continents := getMappedContinents(country, continentMap); len(continents) > 0 && len(unprocessed) > 1 {
 		remaining := make(mcuProxyConnectionsList, 0, len(unprocessed))
-		// Map continents to other continents (e.g. use Europe for Africa).
-		for _, continent := range continents {
-			if toAdd, found := continentMap[continent]; found {
-				continents = append(continents, toAdd...)
-			}
-		}
 
 		// Next up are connections on the same or mapped continent.
 		for _, conn := range unprocessed {
@@ -1955,6 +2023,33 @@ func (m *mcuProxy) getSortedConnections(initiator McuInitiator) []*mcuProxyConne
 	return connections
 }
 
+// GetContinentsForCountry returns the continents that are used to select
+// proxies for publishers in the given country.
+func (m *mcuProxy) GetContinentsForCountry(country string) []string {
+	return getMappedContinents(country, m.getContinentsMap())
+}
+
+// GetProxiesForCountry returns the proxies in the order they would be used for
+// publishers in the given country.
+func (m *mcuProxy) GetProxiesForCountry(country string) []BackendServerInfoSfuProxy {
+	m.connectionsMu.RLock()
+	connections := slices.Clone(m.connections)
+	m.connectionsMu.RUnlock()
+
+	slices.SortStableFunc(connections, func(a, b *mcuProxyConnection) int {
+		return int(a.Load() - b.Load())
+	})
+	if IsValidCountry(country) {
+		connections = sortConnectionsForCountry(connections, country, m.getContinentsMap())
+	}
+
+	result := make([]BackendServerInfoSfuProxy, 0, len(connections))
+	for _, c := range connections {
+		result = append(result, c.getServerInfo())
+	}
+	return result
+}
+
 func (m *mcuProxy) removePublisher(publisher *mcuProxyPublisher) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -1985,9 +2080,9 @@ func (m *mcuProxy) createPublisher(ctx context.Context, listener McuListener, id
 		subctx, cancel := context.WithTimeout(ctx, m.settings.Timeout())
 		defer cancel()
 
-		publisher, err := conn.newPublisher(subctx, listener, id, sid, streamType, publisherSettings)
+		publisher, err := conn.newPublisher(subctx, listener, id, sid, streamType, publisherSettings, getInitiatorCountry(initiator))
 		if err != nil {
-			log.Printf("Could not create %s publisher for %s on %s: %s", streamType, id, conn, err)
+			mcuLog.Errorf("Could not create %s publisher for %s on %s: %s", streamType, id, conn, err)
 			continue
 		}
 
@@ -2002,6 +2097,13 @@ func (m *mcuProxy) createPublisher(ctx context.Context, listener McuListener, id
 }
 
 func (m *mcuProxy) NewPublisher(ctx context.Context, listener McuListener, id PublicSessionId, sid string, streamType StreamType, settings NewPublisherSettings, initiator McuInitiator) (McuPublisher, error) {
+	ctx, done := startMcuOperation(ctx, "NewPublisher", id, streamType)
+	result, err := m.newPublisher(ctx, listener, id, sid, streamType, settings, initiator)
+	done(err)
+	return result, err
+}
+
+func (m *mcuProxy) newPublisher(ctx context.Context, listener McuListener, id PublicSessionId, sid string, streamType StreamType, settings NewPublisherSettings, initiator McuInitiator) (McuPublisher, error) {
 	connections := m.getSortedConnections(initiator)
 	publisher := m.createPublisher(ctx, listener, id, sid, streamType, settings, initiator, connections, func(c *mcuProxyConnection) bool {
 		bw := c.Bandwidth()
@@ -2114,7 +2216,7 @@ func (m *mcuProxy) createSubscriber(ctx context.Context, listener McuListener, i
 			subscriber, err = conn.newRemoteSubscriber(subctx, listener, info.id, publisher, streamType, info.conn, info.token)
 		}
 		if err != nil {
-			log.Printf("Could not create subscriber for %s publisher %s on %s: %s", streamType, publisher, conn, err)
+			mcuLog.Errorf("Could not create subscriber for %s publisher %s on %s: %s", streamType, publisher, conn, err)
 			continue
 		}
 
@@ -2125,6 +2227,13 @@ func (m *mcuProxy) createSubscriber(ctx context.Context, listener McuListener, i
 }
 
 func (m *mcuProxy) NewSubscriber(ctx context.Context, listener McuListener, publisher PublicSessionId, streamType StreamType, initiator McuInitiator) (McuSubscriber, error) {
+	ctx, done := startMcuOperation(ctx, "NewSubscriber", publisher, streamType)
+	result, err := m.newSubscriber(ctx, listener, publisher, streamType, initiator)
+	done(err)
+	return result, err
+}
+
+func (m *mcuProxy) newSubscriber(ctx context.Context, listener McuListener, publisher PublicSessionId, streamType StreamType, initiator McuInitiator) (McuSubscriber, error) {
 	var publisherInfo *proxyPublisherInfo
 	if conn := m.getPublisherConnection(publisher, streamType); conn != nil {
 		// Fast common path: publisher is available locally.
@@ -2140,7 +2249,7 @@ func (m *mcuProxy) NewSubscriber(ctx context.Context, listener McuListener, publ
 			conn: conn,
 		}
 	} else {
-		log.Printf("No %s publisher %s found yet, deferring", streamType, publisher)
+		mcuLog.Infof("No %s publisher %s found yet, deferring", streamType, publisher)
 		ch := make(chan *proxyPublisherInfo, 1)
 		getctx, cancel := context.WithCancel(ctx)
 		defer cancel()
@@ -2181,7 +2290,7 @@ func (m *mcuProxy) NewSubscriber(ctx context.Context, listener McuListener, publ
 					if errors.Is(err, context.Canceled) {
 						return
 					} else if err != nil {
-						log.Printf("Error getting %s publisher id %s from %s: %s", streamType, publisher, client.Target(), err)
+						mcuLog.Errorf("Error getting %s publisher id %s from %s: %s", streamType, publisher, client.Target(), err)
 						return
 					} else if id == "" {
 						// Publisher not found on other server
@@ -2189,7 +2298,7 @@ func (m *mcuProxy) NewSubscriber(ctx context.Context, listener McuListener, publ
 					}
 
 					cancel() // Cancel pending RPC calls.
-					log.Printf("Found publisher id %s through %s on proxy %s", id, client.Target(), url)
+					mcuLog.Infof("Found publisher id %s through %s on proxy %s", id, client.Target(), url)
 
 					m.connectionsMu.RLock()
 					connections := m.connections
@@ -2208,14 +2317,14 @@ func (m *mcuProxy) NewSubscriber(ctx context.Context, listener McuListener, publ
 					if publisherConn == nil {
 						publisherConn, err = newMcuProxyConnection(m, url, ip, connectToken)
 						if err != nil {
-							log.Printf("Could not create temporary connection to %s for %s publisher %s: %s", url, streamType, publisher, err)
+							mcuLog.Errorf("Could not create temporary connection to %s for %s publisher %s: %s", url, streamType, publisher, err)
 							return
 						}
 
 						publisherConn.setTemporary()
 						publisherConn.start()
 						if err := publisherConn.waitUntilConnected(ctx); err != nil {
-							log.Printf("Could not establish new connection to %s: %s", publisherConn, err)
+							mcuLog.Errorf("Could not establish new connection to %s: %s", publisherConn, err)
 							publisherConn.closeIfEmpty()
 							return
 						}
@@ -2320,7 +2429,7 @@ func (m *mcuProxy) NewSubscriber(ctx context.Context, listener McuListener, publ
 		if publisherInfo.conn.IsTemporary() {
 			publisherInfo.conn.closeIfEmpty()
 		}
-		log.Printf("Could not create subscriber for %s publisher %s on %s: %s", streamType, publisher, publisherInfo.conn, err)
+		mcuLog.Errorf("Could not create subscriber for %s publisher %s on %s: %s", streamType, publisher, publisherInfo.conn, err)
 		return nil, err
 	}
 
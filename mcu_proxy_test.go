@@ -187,6 +187,20 @@ func Test_sortConnectionsForCountryWithOverride(t *testing.T) {
 	}
 }
 
+func Test_getMappedContinents(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	continentMap := map[string][]string{
+		"AF": {"EU"},
+	}
+
+	assert.Equal([]string{"EU"}, getMappedContinents("DE", continentMap))
+	assert.Equal([]string{"AF", "EU"}, getMappedContinents("ZA", continentMap))
+	assert.Empty(getMappedContinents("XX", continentMap))
+	// The global continent map must not be modified.
+	assert.Equal([]string{"AF"}, ContinentMap["ZA"])
+}
+
 type proxyServerClientHandler func(msg *ProxyClientMessage) (*ProxyServerMessage, error)
 
 type testProxyServerPublisher struct {
@@ -1478,6 +1492,57 @@ func Test_ProxyPublisherCountry(t *testing.T) {
 	assert.Equal(t, serverUS.URL, pubUS.(*mcuProxyPublisher).conn.rawUrl)
 }
 
+func Test_ProxyPublisherCountryOverride(t *testing.T) {
+	CatchLogForTest(t)
+	t.Parallel()
+	serverDE := NewProxyServerForTest(t, "DE")
+	serverUS := NewProxyServerForTest(t, "US")
+	mcu, config := newMcuProxyForTestWithOptions(t, proxyTestOptions{
+		servers: []*TestProxyServerHandler{
+			serverDE,
+			serverUS,
+		},
+	}, 0)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	if proxies := mcu.GetProxiesForCountry("DE"); assert.Len(t, proxies, 2) {
+		assert.Equal(t, serverDE.URL, proxies[0].Url)
+		assert.Equal(t, "DE", proxies[0].Country)
+		assert.Equal(t, serverUS.URL, proxies[1].Url)
+	}
+
+	// The countries reported by the proxies can be overridden on reload.
+	config.AddOption("proxy-countries", serverDE.URL, "US")
+	config.AddOption("proxy-countries", serverUS.URL, "de")
+	mcu.Reload(config)
+
+	if proxies := mcu.GetProxiesForCountry("DE"); assert.Len(t, proxies, 2) {
+		assert.Equal(t, serverUS.URL, proxies[0].Url)
+		assert.Equal(t, "DE", proxies[0].Country)
+		assert.Equal(t, serverDE.URL, proxies[1].Url)
+		assert.Equal(t, "US", proxies[1].Country)
+	}
+
+	pubId := PublicSessionId("the-publisher")
+	pubSid := "1234567890"
+	pubListener := &MockMcuListener{
+		publicId: pubId + "-public",
+	}
+	pubInitiator := &MockMcuInitiator{
+		country: "DE",
+	}
+	pub, err := mcu.NewPublisher(ctx, pubListener, pubId, pubSid, StreamTypeVideo, NewPublisherSettings{
+		MediaTypes: MediaTypeVideo | MediaTypeAudio,
+	}, pubInitiator)
+	require.NoError(t, err)
+
+	defer pub.Close(context.Background())
+
+	assert.Equal(t, serverUS.URL, pub.(*mcuProxyPublisher).conn.rawUrl)
+}
+
 func Test_ProxyPublisherContinent(t *testing.T) {
 	CatchLogForTest(t)
 	t.Parallel()
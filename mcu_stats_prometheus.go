@@ -74,6 +74,19 @@ var (
 		Name:      "publisher_streams",
 		Help:      "The current number of published media streams",
 	}, []string{"type"})
+	statsMcuPublishersCountry = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "signaling",
+		Subsystem: "mcu",
+		Name:      "publishers_country",
+		Help:      "The current number of publishers by country and continent of the client",
+	}, []string{"type", "country", "continent"})
+	statsMcuOperationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "signaling",
+		Subsystem: "mcu",
+		Name:      "operations_duration",
+		Help:      "The duration of MCU operations in seconds",
+		Buckets:   prometheus.ExponentialBucketsRange(0.01, 30, 30),
+	}, []string{"operation", "type"})
 
 	commonMcuStats = []prometheus.Collector{
 		statsPublishersCurrent,
@@ -84,6 +97,8 @@ var (
 		statsMcuMessagesTotal,
 		statsMcuSubscriberStreamTypesCurrent,
 		statsMcuPublisherStreamTypesCurrent,
+		statsMcuPublishersCountry,
+		statsMcuOperationDuration,
 	}
 
 	statsConnectedProxyBackendsCurrent = prometheus.NewGaugeVec(prometheus.GaugeOpts{
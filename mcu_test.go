@@ -27,6 +27,7 @@ import (
 	"fmt"
 	"log"
 	"maps"
+	"slices"
 	"sync"
 	"sync/atomic"
 
@@ -58,7 +59,8 @@ func (m *TestMCU) Start(ctx context.Context) error {
 func (m *TestMCU) Stop() {
 }
 
-func (m *TestMCU) Reload(config *goconf.ConfigFile) {
+func (m *TestMCU) Reload(config *goconf.ConfigFile) error {
+	return nil
 }
 
 func (m *TestMCU) SetOnConnected(f func()) {
@@ -180,6 +182,9 @@ type TestMCUPublisher struct {
 	settings NewPublisherSettings
 
 	sdp string
+
+	remotesMu sync.Mutex
+	remotes   map[string]bool
 }
 
 func (p *TestMCUPublisher) PublisherId() PublicSessionId {
@@ -233,11 +238,34 @@ func (p *TestMCUPublisher) GetStreams(ctx context.Context) ([]PublisherStream, e
 }
 
 func (p *TestMCUPublisher) PublishRemote(ctx context.Context, remoteId PublicSessionId, hostname string, port int, rtcpPort int) error {
-	return errors.New("remote publishing not supported")
+	p.remotesMu.Lock()
+	defer p.remotesMu.Unlock()
+
+	if p.remotes == nil {
+		p.remotes = make(map[string]bool)
+	}
+	p.remotes[getPublisherRemoteId(p.PublisherId(), remoteId, hostname, port, rtcpPort)] = true
+	return nil
 }
 
 func (p *TestMCUPublisher) UnpublishRemote(ctx context.Context, remoteId PublicSessionId, hostname string, port int, rtcpPort int) error {
-	return errors.New("remote publishing not supported")
+	p.remotesMu.Lock()
+	defer p.remotesMu.Unlock()
+
+	id := getPublisherRemoteId(p.PublisherId(), remoteId, hostname, port, rtcpPort)
+	if !p.remotes[id] {
+		return fmt.Errorf("not published to %s", id)
+	}
+
+	delete(p.remotes, id)
+	return nil
+}
+
+func (p *TestMCUPublisher) getRemotes() []string {
+	p.remotesMu.Lock()
+	defer p.remotesMu.Unlock()
+
+	return slices.Sorted(maps.Keys(p.remotes))
 }
 
 type TestMCUSubscriber struct {
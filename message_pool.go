@@ -0,0 +1,101 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"sync"
+)
+
+// Messages that are only used to publish events are taken from pools to reduce
+// the number of allocations in the fan-out path.
+//
+// Ownership rules:
+//   - A message may only be returned to its pool by the code that got it.
+//   - A message may only be returned if it is no longer referenced, i.e. it
+//     was only passed to the "Publish*" methods of AsyncEvents (which serialize
+//     the message before returning) and not sent to sessions or clients.
+//   - Nested messages are not returned automatically and must be returned to
+//     their own pools.
+var (
+	asyncMessagePool       sync.Pool
+	serverMessagePool      sync.Pool
+	eventServerMessagePool sync.Pool
+)
+
+func getPooledAsyncMessage() *AsyncMessage {
+	if m, ok := asyncMessagePool.Get().(*AsyncMessage); ok {
+		return m
+	}
+
+	return &AsyncMessage{}
+}
+
+func putPooledAsyncMessage(m *AsyncMessage) {
+	*m = AsyncMessage{}
+	asyncMessagePool.Put(m)
+}
+
+func getPooledServerMessage() *ServerMessage {
+	if m, ok := serverMessagePool.Get().(*ServerMessage); ok {
+		return m
+	}
+
+	return &ServerMessage{}
+}
+
+func putPooledServerMessage(m *ServerMessage) {
+	*m = ServerMessage{}
+	serverMessagePool.Put(m)
+}
+
+func getPooledEventServerMessage() *EventServerMessage {
+	if m, ok := eventServerMessagePool.Get().(*EventServerMessage); ok {
+		return m
+	}
+
+	return &EventServerMessage{}
+}
+
+func putPooledEventServerMessage(m *EventServerMessage) {
+	*m = EventServerMessage{}
+	eventServerMessagePool.Put(m)
+}
+
+// getPooledEvent returns a pooled server message for an event. It must be
+// returned with putPooledEvent after it has been published.
+func getPooledEvent(target string, eventType string) *ServerMessage {
+	event := getPooledEventServerMessage()
+	event.Target = target
+	event.Type = eventType
+
+	message := getPooledServerMessage()
+	message.Type = "event"
+	message.Event = event
+	return message
+}
+
+func putPooledEvent(message *ServerMessage) {
+	if message.Event != nil {
+		putPooledEventServerMessage(message.Event)
+	}
+	putPooledServerMessage(message)
+}
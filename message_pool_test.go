@@ -0,0 +1,79 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessagePool(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	for range 10 {
+		message := getPooledEvent("room", "leave")
+		if assert.NotNil(message.Event) {
+			assert.Equal("event", message.Type)
+			assert.Equal("room", message.Event.Target)
+			assert.Equal("leave", message.Event.Type)
+			assert.Empty(message.Event.Leave)
+			assert.Nil(message.Event.Join)
+		}
+		assert.Empty(message.Id)
+		message.Id = "the-id"
+		message.Event.Leave = []PublicSessionId{"the-session"}
+
+		async := getPooledAsyncMessage()
+		assert.Empty(async.Type)
+		assert.Nil(async.Message)
+		assert.Nil(async.prepared)
+		async.Type = "message"
+		async.Message = message
+		async.prepare()
+
+		putPooledAsyncMessage(async)
+		putPooledEvent(message)
+	}
+}
+
+func BenchmarkMessagePool_PublishEvent(b *testing.B) {
+	var pool BufferPool
+
+	b.ReportAllocs()
+	for b.Loop() {
+		message := getPooledEvent("room", "leave")
+		message.Event.Leave = []PublicSessionId{"the-session"}
+		async := getPooledAsyncMessage()
+		async.Type = "message"
+		async.Message = message
+
+		data, err := pool.MarshalMessage(async)
+		if err != nil {
+			b.Fatal(err)
+		}
+		pool.Put(data)
+		putPooledAsyncMessage(async)
+		putPooledEvent(message)
+	}
+}
@@ -25,15 +25,18 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/url"
 	"os"
 	"os/signal"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
 )
 
 const (
@@ -41,8 +44,26 @@ const (
 	maxConnectInterval     = 8 * time.Second
 
 	NatsLoopbackUrl = "nats://loopback"
+
+	DefaultNatsJetStreamName   = "signaling-sessions"
+	DefaultNatsJetStreamMaxAge = time.Minute
+
+	natsJetStreamTimeout = 10 * time.Second
+)
+
+var (
+	ErrNatsJetStreamNotEnabled = errors.New("JetStream is not enabled")
 )
 
+// NatsJetStreamConfig contains the settings of the JetStream stream that is
+// used to buffer messages sent to sessions.
+type NatsJetStreamConfig struct {
+	Name      string
+	Subjects  []string
+	Retention jetstream.RetentionPolicy
+	MaxAge    time.Duration
+}
+
 type NatsSubscription interface {
 	Unsubscribe() error
 }
@@ -54,6 +75,21 @@ type NatsClient interface {
 	Publish(subject string, message any) error
 
 	Decode(msg *nats.Msg, v any) error
+
+	// SetOnReconnected sets a function that will be called after the
+	// connection was restored. Messages might have been lost in between.
+	SetOnReconnected(f func())
+}
+
+// NatsDurableClient is implemented by NATS clients that can store messages
+// in a JetStream stream, so subscribers will receive messages that were
+// published while they were disconnected from the NATS server.
+type NatsDurableClient interface {
+	NatsClient
+
+	IsDurable() bool
+	SubscribeDurable(subject string, ch chan *nats.Msg) (NatsSubscription, error)
+	PublishDurable(subject string, message any) error
 }
 
 // The NATS client doesn't work if a subject contains spaces. As the room id
@@ -65,15 +101,20 @@ func GetEncodedSubject(prefix string, suffix string) string {
 
 type natsClient struct {
 	conn *nats.Conn
+
+	js     jetstream.JetStream
+	stream string
+
+	onReconnectedFunc atomic.Pointer[func()]
 }
 
 func NewNatsClient(url string, options ...nats.Option) (NatsClient, error) {
 	if url == ":loopback:" {
-		log.Printf("WARNING: events url %s is deprecated, please use %s instead", url, NatsLoopbackUrl)
+		appLog.Warnf("events url %s is deprecated, please use %s instead", url, NatsLoopbackUrl)
 		url = NatsLoopbackUrl
 	}
 	if url == NatsLoopbackUrl {
-		log.Println("Using internal NATS loopback client")
+		appLog.Infof("Using internal NATS loopback client")
 		return NewLoopbackNatsClient()
 	}
 
@@ -97,7 +138,7 @@ func NewNatsClient(url string, options ...nats.Option) (NatsClient, error) {
 
 	// The initial connect must succeed, so we retry in the case of an error.
 	for err != nil {
-		log.Printf("Could not create connection (%s), will retry in %s", err, backoff.NextWait())
+		appLog.Errorf("Could not create connection (%s), will retry in %s", err, backoff.NextWait())
 		backoff.Wait(ctx)
 		if ctx.Err() != nil {
 			return nil, fmt.Errorf("interrupted")
@@ -105,7 +146,7 @@ func NewNatsClient(url string, options ...nats.Option) (NatsClient, error) {
 
 		client.conn, err = nats.Connect(url)
 	}
-	log.Printf("Connection established to %s (%s)", removeURLCredentials(client.conn.ConnectedUrl()), client.conn.ConnectedServerId())
+	appLog.Infof("Connection established to %s (%s)", removeURLCredentials(client.conn.ConnectedUrl()), client.conn.ConnectedServerId())
 	return client, nil
 }
 
@@ -114,15 +155,22 @@ func (c *natsClient) Close() {
 }
 
 func (c *natsClient) onClosed(conn *nats.Conn) {
-	log.Println("NATS client closed", conn.LastError())
+	appLog.Eventf(RecentEventTypeConnection, slog.LevelInfo, "NATS client closed %v", conn.LastError())
 }
 
 func (c *natsClient) onDisconnected(conn *nats.Conn) {
-	log.Println("NATS client disconnected")
+	appLog.Eventf(RecentEventTypeConnection, slog.LevelInfo, "NATS client disconnected")
 }
 
 func (c *natsClient) onReconnected(conn *nats.Conn) {
-	log.Printf("NATS client reconnected to %s (%s)", conn.ConnectedUrl(), conn.ConnectedServerId())
+	appLog.Eventf(RecentEventTypeConnection, slog.LevelInfo, "NATS client reconnected to %s (%s)", conn.ConnectedUrl(), conn.ConnectedServerId())
+	if f := c.onReconnectedFunc.Load(); f != nil {
+		(*f)()
+	}
+}
+
+func (c *natsClient) SetOnReconnected(f func()) {
+	c.onReconnectedFunc.Store(&f)
 }
 
 func (c *natsClient) Subscribe(subject string, ch chan *nats.Msg) (NatsSubscription, error) {
@@ -130,12 +178,115 @@ func (c *natsClient) Subscribe(subject string, ch chan *nats.Msg) (NatsSubscript
 }
 
 func (c *natsClient) Publish(subject string, message any) error {
-	data, err := json.Marshal(message)
+	data, err := bufferPool.MarshalMessage(message)
+	if err != nil {
+		return err
+	}
+	// The data is copied to the outgoing buffer of the connection.
+	defer bufferPool.Put(data)
+
+	return c.conn.Publish(subject, data.Bytes())
+}
+
+// EnableJetStream creates or updates the stream with the given configuration
+// that will be used for durable subscriptions and publishing.
+func (c *natsClient) EnableJetStream(config *NatsJetStreamConfig) error {
+	js, err := jetstream.New(c.conn)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), natsJetStreamTimeout)
+	defer cancel()
+
+	if _, err := js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:      config.Name,
+		Subjects:  config.Subjects,
+		Retention: config.Retention,
+		MaxAge:    config.MaxAge,
+	}); err != nil {
+		return fmt.Errorf("could not create stream %s: %w", config.Name, err)
+	}
+
+	appLog.Infof("Using JetStream stream %s for %s (retention %s, max age %s)", config.Name, strings.Join(config.Subjects, ", "), config.Retention, config.MaxAge)
+	c.js = js
+	c.stream = config.Name
+	return nil
+}
+
+func (c *natsClient) IsDurable() bool {
+	return c.js != nil
+}
+
+type natsDurableSubscription struct {
+	closeChan chan struct{}
+	consume   jetstream.ConsumeContext
+}
+
+func (s *natsDurableSubscription) Unsubscribe() error {
+	close(s.closeChan)
+	s.consume.Stop()
+	return nil
+}
+
+// SubscribeDurable subscribes to messages of the subject that are stored in
+// the JetStream stream. Messages published after the subscription was created
+// will be delivered in order, also if the connection was interrupted in
+// between.
+func (c *natsClient) SubscribeDurable(subject string, ch chan *nats.Msg) (NatsSubscription, error) {
+	if c.js == nil {
+		return nil, ErrNatsJetStreamNotEnabled
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), natsJetStreamTimeout)
+	defer cancel()
+
+	consumer, err := c.js.OrderedConsumer(ctx, c.stream, jetstream.OrderedConsumerConfig{
+		FilterSubjects: []string{subject},
+		DeliverPolicy:  jetstream.DeliverNewPolicy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sub := &natsDurableSubscription{
+		closeChan: make(chan struct{}),
+	}
+	sub.consume, err = consumer.Consume(func(msg jetstream.Msg) {
+		select {
+		case ch <- &nats.Msg{
+			Subject: msg.Subject(),
+			Header:  msg.Headers(),
+			Data:    msg.Data(),
+		}:
+		case <-sub.closeChan:
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+// PublishDurable publishes a message to the JetStream stream and waits until
+// it has been stored.
+func (c *natsClient) PublishDurable(subject string, message any) error {
+	if c.js == nil {
+		return ErrNatsJetStreamNotEnabled
+	}
+
+	data, err := bufferPool.MarshalMessage(message)
 	if err != nil {
 		return err
 	}
+	defer bufferPool.Put(data)
+
+	ctx, cancel := context.WithTimeout(context.Background(), natsJetStreamTimeout)
+	defer cancel()
 
-	return c.conn.Publish(subject, data)
+	_, err = c.js.Publish(ctx, subject, data.Bytes())
+	return err
 }
 
 func (c *natsClient) Decode(msg *nats.Msg, vPtr any) (err error) {
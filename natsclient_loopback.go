@@ -24,7 +24,6 @@ package signaling
 import (
 	"container/list"
 	"encoding/json"
-	"log"
 	"strings"
 	"sync"
 
@@ -81,7 +80,7 @@ func (c *LoopbackNatsClient) processMessage(msg *nats.Msg) {
 		select {
 		case ch <- msg:
 		default:
-			log.Printf("Slow consumer %s, dropping message", msg.Subject)
+			appLog.Infof("Slow consumer %s, dropping message", msg.Subject)
 		}
 	}
 }
@@ -95,6 +94,10 @@ func (c *LoopbackNatsClient) Close() {
 	c.wakeup.Signal()
 }
 
+func (c *LoopbackNatsClient) SetOnReconnected(f func()) {
+	// The loopback client is always connected.
+}
+
 type loopbackNatsSubscription struct {
 	subject string
 	client  *LoopbackNatsClient
@@ -22,6 +22,7 @@
 package signaling
 
 import (
+	"context"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -52,6 +53,21 @@ func startLocalNatsServerPort(t *testing.T, port int) (*server.Server, int) {
 	return srv, opts.Port
 }
 
+func startLocalNatsServerJetStream(t *testing.T) *server.Server {
+	t.Helper()
+	opts := natsserver.DefaultTestOptions
+	opts.Port = server.RANDOM_PORT
+	opts.Cluster.Name = "testing"
+	opts.JetStream = true
+	opts.StoreDir = t.TempDir()
+	srv := natsserver.RunServer(&opts)
+	t.Cleanup(func() {
+		srv.Shutdown()
+		srv.WaitForShutdown()
+	})
+	return srv
+}
+
 func CreateLocalNatsClientForTest(t *testing.T, options ...nats.Option) (*server.Server, int, NatsClient) {
 	t.Helper()
 	server, port := startLocalNatsServer(t)
@@ -204,3 +220,76 @@ func TestNatsClient_MaxReconnects(t *testing.T) {
 		assert.Equal(server.ID(), c.conn.ConnectedServerId())
 	})
 }
+
+func TestNatsClient_JetStream(t *testing.T) {
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	require := require.New(t)
+	server := startLocalNatsServerJetStream(t)
+
+	config := &NatsJetStreamConfig{
+		Name:     DefaultNatsJetStreamName,
+		Subjects: []string{"session.>"},
+		MaxAge:   DefaultNatsJetStreamMaxAge,
+	}
+
+	newClient := func() *natsClient {
+		client, err := NewNatsClient(server.ClientURL(),
+			nats.ReconnectWait(10*time.Millisecond),
+			nats.ReconnectJitter(0, 0),
+		)
+		require.NoError(err)
+		t.Cleanup(client.Close)
+		c, ok := client.(*natsClient)
+		require.True(ok, "wrong class: %T", client)
+		return c
+	}
+
+	subscriber := newClient()
+	publisher := newClient()
+	assert.False(subscriber.IsDurable())
+	_, err := subscriber.SubscribeDurable("session.foo", make(chan *nats.Msg))
+	assert.ErrorIs(err, ErrNatsJetStreamNotEnabled)
+	assert.ErrorIs(publisher.PublishDurable("session.foo", "bar"), ErrNatsJetStreamNotEnabled)
+
+	require.NoError(subscriber.EnableJetStream(config))
+	require.NoError(publisher.EnableJetStream(config))
+	assert.True(subscriber.IsDurable())
+
+	ch := make(chan *nats.Msg, 10)
+	sub, err := subscriber.SubscribeDurable("session.foo", ch)
+	require.NoError(err)
+	defer func() {
+		assert.NoError(sub.Unsubscribe())
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	receive := func() string {
+		select {
+		case msg := <-ch:
+			var value StringMap
+			assert.NoError(subscriber.Decode(msg, &value))
+			return value["value"].(string)
+		case <-ctx.Done():
+			assert.Fail("no message received")
+			return ""
+		}
+	}
+
+	require.NoError(publisher.PublishDurable("session.foo", StringMap{"value": "first"}))
+	require.NoError(publisher.PublishDurable("session.bar", StringMap{"value": "other"}))
+	assert.Equal("first", receive())
+
+	// Messages published while the subscriber is disconnected are replayed
+	// after it reconnected.
+	cid, err := subscriber.conn.GetClientID()
+	require.NoError(err)
+	require.NoError(server.DisconnectClientByID(cid))
+	require.NoError(publisher.PublishDurable("session.foo", StringMap{"value": "second"}))
+	require.NoError(publisher.PublishDurable("session.foo", StringMap{"value": "third"}))
+
+	assert.Equal("second", receive())
+	assert.Equal("third", receive())
+}
@@ -0,0 +1,296 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pquerna/cachecontrol/cacheobject"
+)
+
+const (
+	// Path of the OpenID Connect discovery document below the issuer url.
+	oidcDiscoveryPath = ".well-known/openid-configuration"
+
+	// defaultOidcKeysCacheDuration specifies how long the keys of an issuer are
+	// cached if the response doesn't contain a "Cache-Control" header.
+	defaultOidcKeysCacheDuration = time.Hour
+
+	// minOidcKeysCacheDuration specifies the minimum duration to cache the keys
+	// of an issuer. This could overwrite the "max-age" from a "Cache-Control"
+	// header.
+	minOidcKeysCacheDuration = time.Minute
+
+	// Don't fetch the keys of an issuer more than once per minute if a token
+	// references an unknown key.
+	minOidcKeysRefreshInterval = time.Minute
+)
+
+var (
+	ErrOidcIssuerMismatch = errors.New("issuer in discovery document doesn't match")
+	ErrOidcKeyNotFound    = errors.New("no matching key found for issuer")
+)
+
+// ParseOidcIssuer checks that the given value is a valid issuer url. The issuer
+// is returned unmodified as it must exactly match the "iss" claim of tokens.
+func ParseOidcIssuer(issuer string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(issuer))
+	if err != nil {
+		return "", fmt.Errorf("invalid issuer %s: %w", issuer, err)
+	} else if u.Scheme != "https" && u.Scheme != "http" {
+		return "", fmt.Errorf("unsupported issuer scheme in %s", issuer)
+	} else if u.Host == "" || u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("invalid issuer %s", issuer)
+	}
+
+	return u.String(), nil
+}
+
+type oidcDiscoveryDocument struct {
+	Issuer  string `json:"issuer"`
+	JwksUri string `json:"jwks_uri"`
+}
+
+type oidcJwk struct {
+	helloV2ProofJwk
+
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+}
+
+type oidcJwks struct {
+	Keys []oidcJwk `json:"keys"`
+}
+
+type oidcIssuerEntry struct {
+	mu         sync.Mutex
+	keys       map[string]crypto.PublicKey
+	nextUpdate time.Time
+	lastFetch  time.Time
+}
+
+// OidcKeys discovers and caches the signing keys of OpenID Connect issuers.
+type OidcKeys struct {
+	// Can be overwritten by tests.
+	getNow func() time.Time
+
+	version string
+	pool    *HttpClientPool
+
+	mu      sync.Mutex
+	entries map[string]*oidcIssuerEntry
+
+	buffers BufferPool
+}
+
+func NewOidcKeys(version string, pool *HttpClientPool) *OidcKeys {
+	return &OidcKeys{
+		getNow: time.Now,
+
+		version: version,
+		pool:    pool,
+		entries: make(map[string]*oidcIssuerEntry),
+	}
+}
+
+func (k *OidcKeys) getEntry(issuer string) *oidcIssuerEntry {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	entry, found := k.entries[issuer]
+	if !found {
+		entry = &oidcIssuerEntry{}
+		k.entries[issuer] = entry
+	}
+	return entry
+}
+
+func (k *OidcKeys) fetchJSON(ctx context.Context, u *url.URL, result any) (time.Duration, error) {
+	client, pool, err := k.pool.Get(ctx, u)
+	if err != nil {
+		return 0, err
+	}
+	defer pool.Put(client)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "nextcloud-spreed-signaling/"+k.version)
+
+	response, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		backendLog.Infof("Received unexpected HTTP status from %s: %s", u, response.Status)
+		return 0, ErrUnexpectedHttpStatus
+	}
+
+	ct := response.Header.Get("Content-Type")
+	if !strings.HasPrefix(ct, "application/json") && !strings.HasPrefix(ct, "application/jwk-set+json") {
+		backendLog.Warnf("Received unsupported content-type from %s: %s (%s)", u, ct, response.Status)
+		return 0, ErrUnsupportedContentType
+	}
+
+	body, err := k.buffers.ReadAll(response.Body)
+	if err != nil {
+		return 0, err
+	}
+	defer k.buffers.Put(body)
+
+	if err := json.Unmarshal(body.Bytes(), result); err != nil {
+		return 0, err
+	}
+
+	var maxAge time.Duration
+	if cacheControl := response.Header.Get("Cache-Control"); cacheControl != "" {
+		if cc, err := cacheobject.ParseResponseCacheControl(cacheControl); err == nil && !cc.NoCachePresent && cc.MaxAge > 0 {
+			maxAge = time.Duration(cc.MaxAge) * time.Second
+		}
+	}
+	return maxAge, nil
+}
+
+func (k *OidcKeys) loadKeys(ctx context.Context, issuer string) (map[string]crypto.PublicKey, time.Duration, error) {
+	discoveryUrl, err := url.Parse(strings.TrimSuffix(issuer, "/") + "/" + oidcDiscoveryPath)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var discovery oidcDiscoveryDocument
+	if _, err := k.fetchJSON(ctx, discoveryUrl, &discovery); err != nil {
+		return nil, 0, fmt.Errorf("could not get discovery document from %s: %w", discoveryUrl, err)
+	}
+
+	// See OpenID Connect Discovery 1.0, section 4.3.
+	if strings.TrimSuffix(discovery.Issuer, "/") != strings.TrimSuffix(issuer, "/") {
+		return nil, 0, ErrOidcIssuerMismatch
+	}
+
+	jwksUrl, err := url.Parse(discovery.JwksUri)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid jwks_uri %s: %w", discovery.JwksUri, err)
+	} else if jwksUrl.Scheme != "https" && jwksUrl.Scheme != "http" {
+		return nil, 0, fmt.Errorf("unsupported jwks_uri %s", discovery.JwksUri)
+	}
+
+	var jwks oidcJwks
+	maxAge, err := k.fetchJSON(ctx, jwksUrl, &jwks)
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not get keys from %s: %w", jwksUrl, err)
+	}
+
+	keys := make(map[string]crypto.PublicKey)
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.PublicKey()
+		if err != nil {
+			backendLog.Warnf("Ignoring unsupported key %s of type %s from %s: %s", jwk.Kid, jwk.Kty, jwksUrl, err)
+			continue
+		}
+
+		keys[jwk.Kid] = key
+	}
+
+	backendLog.Infof("Received %d keys for issuer %s", len(keys), issuer)
+	return keys, maxAge, nil
+}
+
+// GetKey returns the public key with the given id from the keys published by
+// the issuer. The keys are fetched again if they expired or if an unknown key
+// id is requested, so rotated keys are picked up.
+func (k *OidcKeys) GetKey(ctx context.Context, issuer string, kid string) (crypto.PublicKey, error) {
+	entry := k.getEntry(issuer)
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	now := k.getNow()
+	if entry.keys != nil && entry.nextUpdate.After(now) {
+		if key := entry.findKey(kid); key != nil {
+			return key, nil
+		} else if now.Sub(entry.lastFetch) < minOidcKeysRefreshInterval {
+			return nil, ErrOidcKeyNotFound
+		}
+	}
+
+	keys, maxAge, err := k.loadKeys(ctx, issuer)
+	if err != nil {
+		if entry.keys != nil {
+			// Continue using the previous keys if the issuer is not available.
+			backendLog.Warnf("Could not update keys of issuer %s: %s", issuer, err)
+			entry.lastFetch = now
+			entry.nextUpdate = now.Add(minOidcKeysRefreshInterval)
+			if key := entry.findKey(kid); key != nil {
+				return key, nil
+			}
+		}
+		return nil, err
+	}
+
+	if maxAge == 0 {
+		maxAge = defaultOidcKeysCacheDuration
+	} else if maxAge < minOidcKeysCacheDuration {
+		maxAge = minOidcKeysCacheDuration
+	}
+	entry.keys = keys
+	entry.lastFetch = now
+	entry.nextUpdate = now.Add(maxAge)
+
+	if key := entry.findKey(kid); key != nil {
+		return key, nil
+	}
+
+	return nil, ErrOidcKeyNotFound
+}
+
+func (e *oidcIssuerEntry) findKey(kid string) crypto.PublicKey {
+	if key, found := e.keys[kid]; found {
+		return key
+	}
+
+	if kid == "" && len(e.keys) == 1 {
+		// Tokens without a key id can only be verified if the issuer
+		// published a single key.
+		for _, key := range e.keys {
+			return key
+		}
+	}
+
+	return nil
+}
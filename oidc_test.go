@@ -0,0 +1,306 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dlintw/goconf"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testOidcProvider struct {
+	t      *testing.T
+	server *httptest.Server
+	issuer string
+
+	jwksRequests atomic.Int32
+
+	mu   sync.Mutex
+	keys map[string]*ecdsa.PrivateKey
+}
+
+func newTestOidcProvider(t *testing.T) *testOidcProvider {
+	provider := &testOidcProvider{
+		t:    t,
+		keys: make(map[string]*ecdsa.PrivateKey),
+	}
+
+	mux := http.NewServeMux()
+	// The discovery document of "/other" returns a different issuer.
+	for _, path := range []string{"/realm/", "/other/"} {
+		mux.HandleFunc(path+oidcDiscoveryPath, func(w http.ResponseWriter, r *http.Request) {
+			provider.writeJSON(w, &oidcDiscoveryDocument{
+				Issuer:  provider.issuer,
+				JwksUri: provider.server.URL + "/jwks",
+			})
+		})
+	}
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		provider.jwksRequests.Add(1)
+		provider.mu.Lock()
+		defer provider.mu.Unlock()
+
+		var jwks oidcJwks
+		for kid, key := range provider.keys {
+			jwks.Keys = append(jwks.Keys, oidcJwk{
+				helloV2ProofJwk: helloV2ProofJwk{
+					Kty: "EC",
+					Crv: "P-256",
+					X:   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+					Y:   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+				},
+				Kid: kid,
+				Use: "sig",
+			})
+		}
+		// Keys for encryption must be ignored.
+		jwks.Keys = append(jwks.Keys, oidcJwk{
+			helloV2ProofJwk: helloV2ProofJwk{
+				Kty: "RSA",
+			},
+			Kid: "encryption",
+			Use: "enc",
+		})
+		provider.writeJSON(w, &jwks)
+	})
+	provider.server = httptest.NewServer(mux)
+	t.Cleanup(provider.server.Close)
+	provider.issuer = provider.server.URL + "/realm"
+	return provider
+}
+
+func (p *testOidcProvider) writeJSON(w http.ResponseWriter, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	assert.NoError(p.t, json.NewEncoder(w).Encode(data))
+}
+
+func (p *testOidcProvider) AddKey(kid string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(p.t, err)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keys[kid] = key
+}
+
+func (p *testOidcProvider) RemoveKey(kid string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.keys, kid)
+}
+
+func (p *testOidcProvider) CreateToken(kid string, claims jwt.Claims) string {
+	p.mu.Lock()
+	key := p.keys[kid]
+	p.mu.Unlock()
+	require.NotNil(p.t, key, "key %s not found", kid)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header["kid"] = kid
+	result, err := token.SignedString(key)
+	require.NoError(p.t, err)
+	return result
+}
+
+func newOidcKeysForTest(t *testing.T) *OidcKeys {
+	pool, err := NewHttpClientPool(1, false)
+	require.NoError(t, err)
+	return NewOidcKeys("0.0", pool)
+}
+
+func TestParseOidcIssuer(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	valid := map[string]string{
+		"https://idp.domain.invalid":            "https://idp.domain.invalid",
+		" https://idp.domain.invalid/realms/a ": "https://idp.domain.invalid/realms/a",
+		"https://idp.domain.invalid/":           "https://idp.domain.invalid/",
+		"http://localhost:8080/realm":           "http://localhost:8080/realm",
+	}
+	for issuer, expected := range valid {
+		if parsed, err := ParseOidcIssuer(issuer); assert.NoError(err, "expected %s to be valid", issuer) {
+			assert.Equal(expected, parsed)
+		}
+	}
+
+	invalid := []string{
+		"idp.domain.invalid",
+		"ftp://idp.domain.invalid",
+		"https://",
+		"https://idp.domain.invalid/?foo=bar",
+		"https://idp.domain.invalid/#foo",
+	}
+	for _, issuer := range invalid {
+		_, err := ParseOidcIssuer(issuer)
+		assert.Error(err, "expected %s to be invalid", issuer)
+	}
+}
+
+func TestOidcKeys(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	require := require.New(t)
+
+	provider := newTestOidcProvider(t)
+	provider.AddKey("key1")
+
+	keys := newOidcKeysForTest(t)
+	now := time.Now()
+	keys.getNow = func() time.Time {
+		return now
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	key1, err := keys.GetKey(ctx, provider.issuer, "key1")
+	require.NoError(err)
+	assert.IsType(&ecdsa.PublicKey{}, key1)
+	assert.EqualValues(1, provider.jwksRequests.Load())
+
+	// Tokens without key id can be verified if only one key exists.
+	if key, err := keys.GetKey(ctx, provider.issuer, ""); assert.NoError(err) {
+		assert.Equal(key1, key)
+	}
+	assert.EqualValues(1, provider.jwksRequests.Load())
+
+	_, err = keys.GetKey(ctx, provider.issuer, "encryption")
+	assert.ErrorIs(err, ErrOidcKeyNotFound)
+
+	// Keys are not fetched again if unknown keys are requested too often.
+	provider.AddKey("key2")
+	_, err = keys.GetKey(ctx, provider.issuer, "key2")
+	assert.ErrorIs(err, ErrOidcKeyNotFound)
+	assert.EqualValues(1, provider.jwksRequests.Load())
+
+	// Rotated keys are picked up.
+	now = now.Add(minOidcKeysRefreshInterval)
+	if key, err := keys.GetKey(ctx, provider.issuer, "key2"); assert.NoError(err) {
+		assert.NotEqual(key1, key)
+	}
+	assert.EqualValues(2, provider.jwksRequests.Load())
+
+	// The keys expire after the cache duration.
+	provider.RemoveKey("key1")
+	if key, err := keys.GetKey(ctx, provider.issuer, "key1"); assert.NoError(err) {
+		assert.Equal(key1, key)
+	}
+	assert.EqualValues(2, provider.jwksRequests.Load())
+	now = now.Add(defaultOidcKeysCacheDuration)
+	_, err = keys.GetKey(ctx, provider.issuer, "key1")
+	assert.ErrorIs(err, ErrOidcKeyNotFound)
+	assert.EqualValues(3, provider.jwksRequests.Load())
+}
+
+func TestOidcKeys_IssuerMismatch(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+
+	provider := newTestOidcProvider(t)
+	provider.AddKey("key1")
+
+	keys := newOidcKeysForTest(t)
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	_, err := keys.GetKey(ctx, provider.server.URL+"/other", "key1")
+	assert.ErrorIs(t, err, ErrOidcIssuerMismatch)
+	assert.EqualValues(t, 0, provider.jwksRequests.Load())
+}
+
+func TestClientHelloV2_Oidc(t *testing.T) {
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+
+	provider := newTestOidcProvider(t)
+	provider.AddKey("key1")
+
+	hub, _, _, server := CreateHubForTestWithConfig(t, func(server *httptest.Server) (*goconf.ConfigFile, error) {
+		config, err := getTestConfig(server)
+		if err != nil {
+			return nil, err
+		}
+
+		config.RemoveOption("backend", "allowed")
+		config.RemoveOption("backend", "secret")
+		config.AddOption("backend", "backends", "backend1")
+		config.AddOption("backend", "tokenstrictissuer", "true")
+
+		config.AddOption("backend1", "url", server.URL)
+		config.AddOption("backend1", "secret", string(testBackendSecret))
+		config.AddOption("backend1", "oidcissuer", provider.issuer)
+		return config, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	now := time.Now()
+	claims := func(issuer string) *HelloV2TokenClaims {
+		return &HelloV2TokenClaims{
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    issuer,
+				Subject:   testDefaultUserId,
+				IssuedAt:  jwt.NewNumericDate(now),
+				ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+			},
+		}
+	}
+
+	client1 := NewTestClient(t, server, hub)
+	defer client1.CloseWithBye()
+	require.NoError(client1.SendHelloV2WithToken(provider.CreateToken("key1", claims(provider.issuer))))
+	if hello, ok := client1.RunUntilHello(ctx); ok {
+		assert.Equal(testDefaultUserId, hello.Hello.UserId, "%+v", hello.Hello)
+	}
+
+	// The issuer must match the configured issuer.
+	client2 := NewTestClient(t, server, hub)
+	defer client2.CloseWithBye()
+	require.NoError(client2.SendHelloV2WithToken(provider.CreateToken("key1", claims(server.URL))))
+	client2.RunUntilError(ctx, InvalidToken.Code) // nolint
+
+	// Tokens signed with the key from the capabilities are no longer accepted.
+	client3 := NewTestClient(t, server, hub)
+	defer client3.CloseWithBye()
+	token, err := client3.CreateHelloV2TokenWithClaims(claims(provider.issuer))
+	require.NoError(err)
+	require.NoError(client3.SendHelloV2WithToken(token))
+	client3.RunUntilError(ctx, InvalidToken.Code) // nolint
+}
@@ -0,0 +1,247 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"sync"
+)
+
+const (
+	// Number of versions for which removed participants are remembered, so
+	// clients can request diffs for them.
+	maxParticipantsDeltaVersions = 100
+)
+
+type participantsDeltaEntry struct {
+	data    StringMap
+	encoded string
+	version uint64
+}
+
+// ParticipantsDelta keeps track of the participants list that was sent to a
+// session, so only the changes need to be sent with later updates.
+type ParticipantsDelta struct {
+	mu      sync.Mutex
+	roomId  string
+	version uint64
+	// Oldest version for which diffs can be generated.
+	base    uint64
+	entries map[PublicSessionId]*participantsDeltaEntry
+	removed map[PublicSessionId]uint64
+}
+
+func getParticipantsDeltaId(entry StringMap) (PublicSessionId, bool) {
+	sessionId, found := GetStringMapString[PublicSessionId](entry, "sessionId")
+	if !found {
+		sessionId, found = GetStringMapString[PublicSessionId](entry, "sessionid")
+	}
+	return sessionId, found && sessionId != ""
+}
+
+// Reset forgets the participants list, the next update will contain the full
+// list again.
+func (d *ParticipantsDelta) Reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.resetLocked("")
+}
+
+func (d *ParticipantsDelta) resetLocked(roomId string) {
+	d.roomId = roomId
+	d.entries = nil
+	d.removed = nil
+}
+
+func (d *ParticipantsDelta) fullLocked(roomId string, users []StringMap) *RoomEventServerMessage {
+	d.resetLocked(roomId)
+	d.version++
+	d.base = d.version
+	d.entries = make(map[PublicSessionId]*participantsDeltaEntry, len(users))
+	for _, user := range users {
+		sessionId, found := getParticipantsDeltaId(user)
+		if !found {
+			// Can't track participants without a session id.
+			d.resetLocked("")
+			break
+		}
+
+		encoded, _ := json.Marshal(user)
+		d.entries[sessionId] = &participantsDeltaEntry{
+			data:    user,
+			encoded: string(encoded),
+			version: d.version,
+		}
+	}
+
+	return &RoomEventServerMessage{
+		RoomId:  roomId,
+		Users:   users,
+		Version: d.version,
+	}
+}
+
+// Update returns the event to send for the given list of participants. If
+// "partial" is set, the list only contains changed participants, otherwise
+// participants not included in the list were removed.
+// Returns nil if the participants didn't change since the last update.
+func (d *ParticipantsDelta) Update(roomId string, users []StringMap, partial bool) *RoomEventServerMessage {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.entries == nil || d.roomId != roomId {
+		if partial {
+			// Clients need the full list first.
+			return &RoomEventServerMessage{
+				RoomId: roomId,
+				Users:  users,
+			}
+		}
+
+		return d.fullLocked(roomId, users)
+	}
+
+	type change struct {
+		sessionId PublicSessionId
+		entry     *participantsDeltaEntry
+	}
+
+	seen := make(map[PublicSessionId]bool, len(users))
+	var changed []change
+	for _, user := range users {
+		sessionId, found := getParticipantsDeltaId(user)
+		if !found {
+			if partial {
+				d.resetLocked("")
+				return &RoomEventServerMessage{
+					RoomId: roomId,
+					Users:  users,
+				}
+			}
+
+			return d.fullLocked(roomId, users)
+		}
+
+		seen[sessionId] = true
+		encoded, _ := json.Marshal(user)
+		if prev, found := d.entries[sessionId]; found && prev.encoded == string(encoded) {
+			continue
+		}
+
+		changed = append(changed, change{
+			sessionId: sessionId,
+			entry: &participantsDeltaEntry{
+				data:    user,
+				encoded: string(encoded),
+			},
+		})
+	}
+
+	var removed []PublicSessionId
+	if !partial {
+		for sessionId := range d.entries {
+			if !seen[sessionId] {
+				removed = append(removed, sessionId)
+			}
+		}
+	}
+
+	if len(changed) == 0 && len(removed) == 0 {
+		return nil
+	}
+
+	d.version++
+	result := &RoomEventServerMessage{
+		RoomId:  roomId,
+		Version: d.version,
+		Delta:   true,
+	}
+	for _, c := range changed {
+		c.entry.version = d.version
+		d.entries[c.sessionId] = c.entry
+		result.Users = append(result.Users, c.entry.data)
+	}
+	if len(removed) > 0 {
+		if d.removed == nil {
+			d.removed = make(map[PublicSessionId]uint64)
+		}
+		slices.Sort(removed)
+		for _, sessionId := range removed {
+			delete(d.entries, sessionId)
+			d.removed[sessionId] = d.version
+		}
+		result.Removed = removed
+	}
+	d.pruneLocked()
+	return result
+}
+
+func (d *ParticipantsDelta) pruneLocked() {
+	if d.version <= maxParticipantsDeltaVersions {
+		return
+	}
+
+	oldest := d.version - maxParticipantsDeltaVersions
+	for sessionId, version := range d.removed {
+		if version <= oldest {
+			delete(d.removed, sessionId)
+		}
+	}
+	d.base = max(d.base, oldest)
+}
+
+// Diff returns the changes since the given version. The full list of
+// participants is returned if no diff can be generated for the version.
+// Returns nil if no participants list was sent for the room yet.
+func (d *ParticipantsDelta) Diff(roomId string, version uint64) *RoomEventServerMessage {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.entries == nil || d.roomId != roomId {
+		return nil
+	}
+
+	result := &RoomEventServerMessage{
+		RoomId:  roomId,
+		Version: d.version,
+	}
+	// Diffs are only available for versions that are still tracked.
+	delta := version >= d.base && version <= d.version
+	for _, sessionId := range slices.Sorted(maps.Keys(d.entries)) {
+		entry := d.entries[sessionId]
+		if !delta || entry.version > version {
+			result.Users = append(result.Users, entry.data)
+		}
+	}
+	if delta {
+		result.Delta = true
+		for _, sessionId := range slices.Sorted(maps.Keys(d.removed)) {
+			if d.removed[sessionId] > version {
+				result.Removed = append(result.Removed, sessionId)
+			}
+		}
+	}
+	return result
+}
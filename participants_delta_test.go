@@ -0,0 +1,165 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParticipantsDelta(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var delta ParticipantsDelta
+	user1 := StringMap{"sessionId": "session1", "inCall": 0}
+	user2 := StringMap{"sessionId": "session2", "inCall": 0}
+	user3 := StringMap{"sessionId": "session3", "inCall": 0}
+
+	assert.Nil(delta.Diff("room1", 0))
+
+	// First update contains the full list.
+	update := delta.Update("room1", []StringMap{user1, user2}, false)
+	require.NotNil(update)
+	assert.Equal("room1", update.RoomId)
+	assert.EqualValues(1, update.Version)
+	assert.False(update.Delta)
+	assert.Equal([]StringMap{user1, user2}, update.Users)
+	assert.Empty(update.Removed)
+
+	// Nothing changed.
+	assert.Nil(delta.Update("room1", []StringMap{
+		{"sessionId": "session1", "inCall": 0},
+		{"sessionId": "session2", "inCall": 0},
+	}, false))
+
+	// Changed and removed participants.
+	user1Changed := StringMap{"sessionId": "session1", "inCall": 7}
+	update = delta.Update("room1", []StringMap{user1Changed}, false)
+	require.NotNil(update)
+	assert.EqualValues(2, update.Version)
+	assert.True(update.Delta)
+	assert.Equal([]StringMap{user1Changed}, update.Users)
+	assert.Equal([]PublicSessionId{"session2"}, update.Removed)
+
+	// Partial updates don't remove participants.
+	update = delta.Update("room1", []StringMap{user3}, true)
+	require.NotNil(update)
+	assert.EqualValues(3, update.Version)
+	assert.True(update.Delta)
+	assert.Equal([]StringMap{user3}, update.Users)
+	assert.Empty(update.Removed)
+
+	if diff := delta.Diff("room1", 1); assert.NotNil(diff) {
+		assert.EqualValues(3, diff.Version)
+		assert.True(diff.Delta)
+		assert.Equal([]StringMap{user1Changed, user3}, diff.Users)
+		assert.Equal([]PublicSessionId{"session2"}, diff.Removed)
+	}
+	if diff := delta.Diff("room1", 2); assert.NotNil(diff) {
+		assert.True(diff.Delta)
+		assert.Equal([]StringMap{user3}, diff.Users)
+		assert.Empty(diff.Removed)
+	}
+	if diff := delta.Diff("room1", 3); assert.NotNil(diff) {
+		assert.True(diff.Delta)
+		assert.Empty(diff.Users)
+		assert.Empty(diff.Removed)
+	}
+	// Unknown versions return the full list.
+	for _, version := range []uint64{0, 4} {
+		if diff := delta.Diff("room1", version); assert.NotNil(diff) {
+			assert.EqualValues(3, diff.Version)
+			assert.False(diff.Delta)
+			assert.Equal([]StringMap{user1Changed, user3}, diff.Users)
+			assert.Empty(diff.Removed)
+		}
+	}
+	assert.Nil(delta.Diff("room2", 1))
+
+	// A different room starts with the full list again.
+	update = delta.Update("room2", []StringMap{user2}, false)
+	require.NotNil(update)
+	assert.EqualValues(4, update.Version)
+	assert.False(update.Delta)
+	assert.Equal([]StringMap{user2}, update.Users)
+
+	delta.Reset()
+	assert.Nil(delta.Diff("room2", 4))
+	update = delta.Update("room2", []StringMap{user2}, false)
+	require.NotNil(update)
+	assert.EqualValues(5, update.Version)
+	assert.False(update.Delta)
+}
+
+func TestParticipantsDelta_MissingSessionId(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	var delta ParticipantsDelta
+	users := []StringMap{
+		{"sessionId": "session1"},
+		{"userId": "user2"},
+	}
+	// Lists without session ids can't be tracked and are always sent in full.
+	for range 2 {
+		if update := delta.Update("room1", users, false); assert.NotNil(update) {
+			assert.False(update.Delta)
+			assert.Equal(users, update.Users)
+		}
+	}
+	assert.Nil(delta.Diff("room1", 0))
+}
+
+func TestParticipantsDelta_Prune(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	var delta ParticipantsDelta
+	delta.Update("room1", []StringMap{
+		{"sessionId": "session1", "inCall": 0},
+		{"sessionId": "session2"},
+	}, false)
+	delta.Update("room1", []StringMap{
+		{"sessionId": "session1", "inCall": 0},
+	}, false)
+	for i := range maxParticipantsDeltaVersions {
+		delta.Update("room1", []StringMap{
+			{"sessionId": "session1", "inCall": i + 1},
+		}, false)
+	}
+
+	// The removed session is no longer tracked, so old versions get the full list.
+	if diff := delta.Diff("room1", 1); assert.NotNil(diff) {
+		assert.False(diff.Delta)
+		assert.Len(diff.Users, 1)
+		assert.Empty(diff.Removed)
+	}
+	if diff := delta.Diff("room1", 2); assert.NotNil(diff) {
+		assert.True(diff.Delta)
+		assert.Len(diff.Users, 1)
+		assert.Empty(diff.Removed)
+	}
+}
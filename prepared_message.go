@@ -0,0 +1,75 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"sync"
+
+	"github.com/mailru/easyjson"
+	"github.com/mailru/easyjson/jwriter"
+)
+
+// PreparedServerMessage is a server message that is serialized only once,
+// even if it is sent to multiple recipients.
+type PreparedServerMessage struct {
+	message *ServerMessage
+
+	once sync.Once
+	data []byte
+	err  error
+}
+
+func NewPreparedServerMessage(message *ServerMessage) *PreparedServerMessage {
+	return &PreparedServerMessage{
+		message: message,
+	}
+}
+
+func (m *PreparedServerMessage) Message() *ServerMessage {
+	return m.message
+}
+
+// Data returns the serialized message. The returned data is shared between
+// all recipients and must not be modified.
+func (m *PreparedServerMessage) Data() ([]byte, error) {
+	m.once.Do(func() {
+		m.data, m.err = easyjson.Marshal(m.message)
+	})
+	return m.data, m.err
+}
+
+func (m *PreparedServerMessage) MarshalJSON() ([]byte, error) {
+	return m.Data()
+}
+
+func (m *PreparedServerMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	data, err := m.Data()
+	w.Raw(data, err)
+}
+
+func (m *PreparedServerMessage) CloseAfterSend(session Session) bool {
+	return m.message.CloseAfterSend(session)
+}
+
+func (m *PreparedServerMessage) String() string {
+	return m.message.String()
+}
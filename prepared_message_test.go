@@ -0,0 +1,132 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mailru/easyjson"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBroadcastMessageForTest() *ServerMessage {
+	return &ServerMessage{
+		Type: "message",
+		Message: &MessageServerMessage{
+			Sender: &MessageServerMessageSender{
+				Type:      "room",
+				SessionId: "the-sender",
+				UserId:    "the-user",
+			},
+			Data: json.RawMessage(`{"type":"chat","chat":{"refresh":true},"text":"Hello world!"}`),
+		},
+	}
+}
+
+func TestPreparedServerMessage(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	assert := assert.New(t)
+
+	message := newBroadcastMessageForTest()
+	expected, err := easyjson.Marshal(message)
+	require.NoError(err)
+
+	prepared := NewPreparedServerMessage(message)
+	assert.Same(message, prepared.Message())
+	if data, err := prepared.Data(); assert.NoError(err) {
+		assert.Equal(expected, data)
+	}
+	if data, err := json.Marshal(prepared); assert.NoError(err) {
+		assert.JSONEq(string(expected), string(data))
+	}
+	if data, err := easyjson.Marshal(prepared); assert.NoError(err) {
+		assert.Equal(expected, data)
+	}
+
+	// The message is only serialized once.
+	message.Message.Sender.UserId = "other-user"
+	if data, err := prepared.Data(); assert.NoError(err) {
+		assert.Equal(expected, data)
+	}
+}
+
+func TestBufferPool_MarshalMessage(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+	assert := assert.New(t)
+
+	var pool BufferPool
+	message := newBroadcastMessageForTest()
+	expected, err := easyjson.Marshal(message)
+	require.NoError(err)
+
+	b, err := pool.MarshalMessage(message)
+	require.NoError(err)
+	assert.Equal(expected, b.Bytes())
+	pool.Put(b)
+}
+
+func BenchmarkServerMessage_Broadcast(b *testing.B) {
+	message := newBroadcastMessageForTest()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		// Serialized for every recipient.
+		for range 100 {
+			if _, err := easyjson.Marshal(message); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkPreparedServerMessage_Broadcast(b *testing.B) {
+	message := newBroadcastMessageForTest()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		// Serialized once for all recipients.
+		prepared := NewPreparedServerMessage(message)
+		for range 100 {
+			if _, err := prepared.Data(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkBufferPool_MarshalMessage(b *testing.B) {
+	var pool BufferPool
+	message := newBroadcastMessageForTest()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		buffer, err := pool.MarshalMessage(message)
+		if err != nil {
+			b.Fatal(err)
+		}
+		pool.Put(buffer)
+	}
+}
@@ -0,0 +1,85 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"sync"
+)
+
+// PriorityGate serializes callers similar to a mutex, but lets waiting callers
+// with high priority pass before waiting callers with normal priority. Callers
+// with the same priority pass in the order they arrived.
+type PriorityGate struct {
+	mu     sync.Mutex
+	locked bool
+	high   []chan struct{}
+	normal []chan struct{}
+}
+
+// Lock waits until the gate can be passed.
+func (g *PriorityGate) Lock(priority bool) {
+	g.mu.Lock()
+	if !g.locked {
+		g.locked = true
+		g.mu.Unlock()
+		return
+	}
+
+	ch := make(chan struct{})
+	if priority {
+		g.high = append(g.high, ch)
+	} else {
+		g.normal = append(g.normal, ch)
+	}
+	g.mu.Unlock()
+
+	// The gate is handed over by "Unlock".
+	<-ch
+}
+
+// Unlock lets the next waiting caller pass the gate.
+func (g *PriorityGate) Unlock() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var next chan struct{}
+	if len(g.high) > 0 {
+		next = g.high[0]
+		g.high = g.high[1:]
+	} else if len(g.normal) > 0 {
+		next = g.normal[0]
+		g.normal = g.normal[1:]
+	} else {
+		g.locked = false
+		return
+	}
+
+	close(next)
+}
+
+// Waiting returns the number of callers with high and normal priority that
+// wait to pass the gate.
+func (g *PriorityGate) Waiting() (int, int) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.high), len(g.normal)
+}
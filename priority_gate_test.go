@@ -0,0 +1,78 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriorityGate(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	var gate PriorityGate
+	gate.Lock(false)
+
+	var mu sync.Mutex
+	var order []string
+	var wg sync.WaitGroup
+	enter := func(name string, priority bool, high int, normal int) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			gate.Lock(priority)
+			defer gate.Unlock()
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, name)
+		}()
+		// Wait until the caller is queued to get a defined order.
+		assert.Eventually(func() bool {
+			h, n := gate.Waiting()
+			return h == high && n == normal
+		}, time.Second, time.Millisecond)
+	}
+
+	enter("normal1", false, 0, 1)
+	enter("normal2", false, 0, 2)
+	enter("high1", true, 1, 2)
+	enter("normal3", false, 1, 3)
+	enter("high2", true, 2, 3)
+
+	gate.Unlock()
+	wg.Wait()
+	assert.Equal([]string{"high1", "high2", "normal1", "normal2", "normal3"}, order)
+
+	h, n := gate.Waiting()
+	assert.Equal(0, h)
+	assert.Equal(0, n)
+
+	// The gate can be passed directly if nobody is waiting.
+	gate.Lock(false)
+	gate.Unlock()
+	gate.Lock(true)
+	gate.Unlock()
+}
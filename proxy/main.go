@@ -22,10 +22,11 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
 	"flag"
 	"fmt"
 	"log"
-	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -45,6 +46,12 @@ var (
 	configFlag = flag.String("config", "proxy.conf", "config file to use")
 
 	showVersion = flag.Bool("version", false, "show version and quit")
+
+	checkConfig = flag.Bool("check-config", false, "check the configuration and quit")
+
+	healthcheck = flag.Bool("healthcheck", false, "check if the running server is ready and quit")
+
+	proxyLog = signaling.NewLogger(signaling.LogSubsystemProxy)
 )
 
 const (
@@ -63,32 +70,76 @@ func main() {
 		os.Exit(0)
 	}
 
+	if *checkConfig {
+		if _, _, err := signaling.LoadConfig(*configFlag); err != nil {
+			fmt.Printf("Configuration %s is invalid: %s\n", *configFlag, err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Configuration %s is valid\n", *configFlag)
+		os.Exit(0)
+	}
+
+	if *healthcheck {
+		config, _, err := signaling.LoadConfig(*configFlag)
+		if err != nil {
+			fmt.Printf("Could not read configuration %s: %s\n", *configFlag, err)
+			os.Exit(1)
+		}
+
+		if err := signaling.RunHealthcheck(context.Background(), config, "/ready"); err != nil {
+			fmt.Printf("Healthcheck failed: %s\n", err)
+			os.Exit(1)
+		}
+
+		os.Exit(0)
+	}
+
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt)
 	signal.Notify(sigChan, syscall.SIGHUP)
 	signal.Notify(sigChan, syscall.SIGUSR1)
+	signal.Notify(sigChan, syscall.SIGUSR2)
 
-	log.Printf("Starting up version %s/%s as pid %d", version, runtime.Version(), os.Getpid())
+	proxyLog.Infof("Starting up version %s/%s as pid %d", version, runtime.Version(), os.Getpid())
 
-	config, err := goconf.ReadConfigFile(*configFlag)
+	config, includes, err := signaling.LoadConfig(*configFlag)
 	if err != nil {
-		log.Fatal("Could not read configuration: ", err)
+		proxyLog.Fatalf("Could not read configuration: %s", err)
+	}
+
+	if err := signaling.ConfigureLogging(config); err != nil {
+		proxyLog.Fatalf("Could not configure logging: %s", err)
+	}
+
+	if err := signaling.ConfigureFileWatcher(config); err != nil {
+		proxyLog.Fatalf("Could not configure file watcher: %s", err)
 	}
 
-	log.Printf("Using a maximum of %d CPUs", runtime.GOMAXPROCS(0))
+	proxyLog.Infof("Using a maximum of %d CPUs", runtime.GOMAXPROCS(0))
+
+	signaling.RegisterBuildInfo(version)
+
+	statsPusher, err := signaling.NewStatsPusher(config, "nextcloud-spreed-signaling-proxy")
+	if err != nil {
+		proxyLog.Fatalf("Could not create metrics pusher: %s", err)
+	}
+	statsPusher.Start()
+	defer statsPusher.Close()
 
 	r := mux.NewRouter()
 
 	proxy, err := NewProxyServer(r, version, config)
 	if err != nil {
-		log.Fatal(err)
+		proxyLog.Fatalf("%s", err)
 	}
 
 	if err := proxy.Start(config); err != nil {
-		log.Fatal(err)
+		proxyLog.Fatalf("%s", err)
 	}
 	defer proxy.Stop()
 
+	reloader := signaling.NewConfigReloader()
 	if addr, _ := signaling.GetStringOptionWithEnv(config, "http", "listen"); addr != "" {
 		readTimeout, _ := config.GetInt("http", "readtimeout")
 		if readTimeout <= 0 {
@@ -98,13 +149,21 @@ func main() {
 		if writeTimeout <= 0 {
 			writeTimeout = defaultWriteTimeout
 		}
+		reusePort, _ := config.GetBool("http", "reuseport")
+		proxyProtocol, _ := config.GetBool("http", "proxyprotocol")
+		if proxyProtocol {
+			proxyLog.Infof("Using PROXY protocol for HTTP connections from trusted proxies")
+		}
 
 		for address := range signaling.SplitEntries(addr, " ") {
 			go func(address string) {
-				log.Println("Listening on", address)
-				listener, err := net.Listen("tcp", address)
+				proxyLog.Infof("Listening on %v", address)
+				listener, err := signaling.CreateListener(address, reusePort)
 				if err != nil {
-					log.Fatal("Could not start listening: ", err)
+					proxyLog.Fatalf("Could not start listening: %s", err)
+				}
+				if proxyProtocol {
+					listener = signaling.NewProxyProtocolListener(listener, proxy.GetTrustedProxies)
 				}
 				srv := &http.Server{
 					Handler: r,
@@ -114,33 +173,109 @@ func main() {
 					WriteTimeout: time.Duration(writeTimeout) * time.Second,
 				}
 				if err := srv.Serve(listener); err != nil {
-					log.Fatal("Could not start server: ", err)
+					proxyLog.Fatalf("Could not start server: %s", err)
 				}
 			}(address)
 		}
 	}
 
+	if addr, _ := signaling.GetStringOptionWithEnv(config, "https", "listen"); addr != "" {
+		cert, _ := config.GetString("https", "certificate")
+		key, _ := config.GetString("https", "key")
+		if cert == "" || key == "" {
+			proxyLog.Fatalf("Need a certificate and key for the HTTPS listener")
+		}
+
+		certificates, err := signaling.NewCertificateWatcher(cert, key)
+		if err != nil {
+			proxyLog.Fatalf("Could not load certificate for the HTTPS listener: %s", err)
+		}
+		defer certificates.Close()
+		reloader.Register(func(config *goconf.ConfigFile) error {
+			cert, _ := config.GetString("https", "certificate")
+			key, _ := config.GetString("https", "key")
+			return certificates.Reload(cert, key)
+		}, "https")
+
+		readTimeout, _ := config.GetInt("https", "readtimeout")
+		if readTimeout <= 0 {
+			readTimeout = defaultReadTimeout
+		}
+		writeTimeout, _ := config.GetInt("https", "writetimeout")
+		if writeTimeout <= 0 {
+			writeTimeout = defaultWriteTimeout
+		}
+
+		tlsConfig := &tls.Config{
+			GetCertificate: certificates.GetCertificate,
+		}
+		reusePort, _ := config.GetBool("https", "reuseport")
+		proxyProtocol, _ := config.GetBool("https", "proxyprotocol")
+		if proxyProtocol {
+			proxyLog.Infof("Using PROXY protocol for HTTPS connections from trusted proxies")
+		}
+		for address := range signaling.SplitEntries(addr, " ") {
+			go func(address string) {
+				proxyLog.Infof("Listening on %v", address)
+				listener, err := signaling.CreateListener(address, reusePort)
+				if err != nil {
+					proxyLog.Fatalf("Could not start listening: %s", err)
+				}
+				if proxyProtocol {
+					listener = signaling.NewProxyProtocolListener(listener, proxy.GetTrustedProxies)
+				}
+				listener = tls.NewListener(listener, tlsConfig)
+				srv := &http.Server{
+					Handler: r,
+					Addr:    addr,
+
+					ReadTimeout:  time.Duration(readTimeout) * time.Second,
+					WriteTimeout: time.Duration(writeTimeout) * time.Second,
+				}
+				if err := srv.Serve(listener); err != nil {
+					proxyLog.Fatalf("Could not start server: %s", err)
+				}
+			}(address)
+		}
+	}
+
+	watchConfig, _ := config.GetBool("app", "watchconfig")
+	reloader.Register(signaling.ConfigureLogging, "logging")
+	reloader.Register(signaling.ConfigureFileWatcher, "app")
+	// The remaining components use options from most of the other sections.
+	reloader.Register(func(config *goconf.ConfigFile) error {
+		proxy.Reload(config)
+		return nil
+	})
+	configWatcher, err := signaling.NewConfigWatcher(*configFlag, config, includes, watchConfig, reloader.Callback)
+	if err != nil {
+		proxyLog.Fatalf("Could not watch configuration: %s", err)
+	}
+	defer configWatcher.Close()
+
 loop:
 	for {
 		select {
 		case sig := <-sigChan:
 			switch sig {
 			case os.Interrupt:
-				log.Println("Interrupted")
+				proxyLog.Infof("Interrupted")
 				break loop
 			case syscall.SIGHUP:
-				log.Printf("Received SIGHUP, reloading %s", *configFlag)
-				if config, err := goconf.ReadConfigFile(*configFlag); err != nil {
-					log.Printf("Could not read configuration from %s: %s", *configFlag, err)
-				} else {
-					proxy.Reload(config)
-				}
+				proxyLog.Infof("Received SIGHUP, reloading %s", *configFlag)
+				configWatcher.Reload(true) // nolint
 			case syscall.SIGUSR1:
-				log.Printf("Received SIGUSR1, scheduling server to shutdown")
+				proxyLog.Infof("Received SIGUSR1, scheduling server to shutdown")
 				proxy.ScheduleShutdown()
+			case syscall.SIGUSR2:
+				if signaling.ToggleDebugLogging() {
+					proxyLog.Infof("Received SIGUSR2, enabled debug logging")
+				} else {
+					proxyLog.Infof("Received SIGUSR2, restored configured log levels")
+				}
 			}
 		case <-proxy.ShutdownChannel():
-			log.Printf("All clients disconnected, shutting down")
+			proxyLog.Infof("All clients disconnected, shutting down")
 			break loop
 		}
 	}
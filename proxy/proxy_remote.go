@@ -27,7 +27,6 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"errors"
-	"log"
 	"math/rand/v2"
 	"net"
 	"net/http"
@@ -118,7 +117,7 @@ func (c *RemoteConnection) String() string {
 func (c *RemoteConnection) reconnect() {
 	u, err := c.url.Parse("proxy")
 	if err != nil {
-		log.Printf("Could not resolve url to proxy at %s: %s", c, err)
+		proxyLog.Errorf("Could not resolve url to proxy at %s: %s", c, err)
 		c.scheduleReconnect()
 		return
 	}
@@ -136,12 +135,12 @@ func (c *RemoteConnection) reconnect() {
 
 	conn, _, err := dialer.DialContext(context.TODO(), u.String(), nil)
 	if err != nil {
-		log.Printf("Error connecting to proxy at %s: %s", c, err)
+		proxyLog.Errorf("Error connecting to proxy at %s: %s", c, err)
 		c.scheduleReconnect()
 		return
 	}
 
-	log.Printf("Connected to %s", c)
+	proxyLog.Infof("Connected to %s", c)
 	c.closed.Store(false)
 
 	c.mu.Lock()
@@ -152,7 +151,7 @@ func (c *RemoteConnection) reconnect() {
 	c.reconnectInterval.Store(int64(initialReconnectInterval))
 
 	if err := c.sendHello(); err != nil {
-		log.Printf("Error sending hello request to proxy at %s: %s", c, err)
+		proxyLog.Errorf("Error sending hello request to proxy at %s: %s", c, err)
 		c.scheduleReconnect()
 		return
 	}
@@ -166,7 +165,7 @@ func (c *RemoteConnection) reconnect() {
 
 func (c *RemoteConnection) scheduleReconnect() {
 	if err := c.sendClose(); err != nil && err != ErrNotConnected {
-		log.Printf("Could not send close message to %s: %s", c, err)
+		proxyLog.Errorf("Could not send close message to %s: %s", c, err)
 	}
 	c.close()
 
@@ -329,20 +328,20 @@ func (c *RemoteConnection) readPump(conn *websocket.Conn) {
 				websocket.CloseGoingAway,
 				websocket.CloseNoStatusReceived) {
 				if !errors.Is(err, net.ErrClosed) || !c.closed.Load() {
-					log.Printf("Error reading from %s: %v", c, err)
+					proxyLog.Errorf("Error reading from %s: %v", c, err)
 				}
 			}
 			break
 		}
 
 		if msgType != websocket.TextMessage {
-			log.Printf("unexpected message type %q (%s)", msgType, string(msg))
+			proxyLog.Warnf("unexpected message type %q (%s)", msgType, string(msg))
 			continue
 		}
 
 		var message signaling.ProxyServerMessage
 		if err := json.Unmarshal(msg, &message); err != nil {
-			log.Printf("could not decode message %s: %s", string(msg), err)
+			proxyLog.Errorf("could not decode message %s: %s", string(msg), err)
 			continue
 		}
 
@@ -369,7 +368,7 @@ func (c *RemoteConnection) sendPing() bool {
 	msg := strconv.FormatInt(now.UnixNano(), 10)
 	c.conn.SetWriteDeadline(now.Add(writeWait)) // nolint
 	if err := c.conn.WriteMessage(websocket.PingMessage, []byte(msg)); err != nil {
-		log.Printf("Could not send ping to proxy at %s: %v", c, err)
+		proxyLog.Errorf("Could not send ping to proxy at %s: %v", c, err)
 		go c.scheduleReconnect()
 		return false
 	}
@@ -401,16 +400,16 @@ func (c *RemoteConnection) processHello(msg *signaling.ProxyServerMessage) {
 	switch msg.Type {
 	case "error":
 		if msg.Error.Code == "no_such_session" {
-			log.Printf("Session %s could not be resumed on %s, registering new", c.sessionId, c)
+			proxyLog.Infof("Session %s could not be resumed on %s, registering new", c.sessionId, c)
 			c.sessionId = ""
 			if err := c.sendHello(); err != nil {
-				log.Printf("Could not send hello request to %s: %s", c, err)
+				proxyLog.Errorf("Could not send hello request to %s: %s", c, err)
 				c.scheduleReconnect()
 			}
 			return
 		}
 
-		log.Printf("Hello connection to %s failed with %+v, reconnecting", c, msg.Error)
+		proxyLog.Infof("Hello connection to %s failed with %+v, reconnecting", c, msg.Error)
 		c.scheduleReconnect()
 	case "hello":
 		resumed := c.sessionId == msg.Hello.SessionId
@@ -418,16 +417,16 @@ func (c *RemoteConnection) processHello(msg *signaling.ProxyServerMessage) {
 		country := ""
 		if msg.Hello.Server != nil {
 			if country = msg.Hello.Server.Country; country != "" && !signaling.IsValidCountry(country) {
-				log.Printf("Proxy %s sent invalid country %s in hello response", c, country)
+				proxyLog.Infof("Proxy %s sent invalid country %s in hello response", c, country)
 				country = ""
 			}
 		}
 		if resumed {
-			log.Printf("Resumed session %s on %s", c.sessionId, c)
+			proxyLog.Infof("Resumed session %s on %s", c.sessionId, c)
 		} else if country != "" {
-			log.Printf("Received session %s from %s (in %s)", c.sessionId, c, country)
+			proxyLog.Infof("Received session %s from %s (in %s)", c.sessionId, c, country)
 		} else {
-			log.Printf("Received session %s from %s", c.sessionId, c)
+			proxyLog.Infof("Received session %s from %s", c.sessionId, c)
 		}
 
 		pending := c.pendingMessages
@@ -438,11 +437,11 @@ func (c *RemoteConnection) processHello(msg *signaling.ProxyServerMessage) {
 			}
 
 			if err := c.sendMessageLocked(context.Background(), m); err != nil {
-				log.Printf("Could not send pending message %+v to %s: %s", m, c, err)
+				proxyLog.Errorf("Could not send pending message %+v to %s: %s", m, c, err)
 			}
 		}
 	default:
-		log.Printf("Received unsupported hello response %+v from %s, reconnecting", msg, c)
+		proxyLog.Warnf("Received unsupported hello response %+v from %s, reconnecting", msg, c)
 		c.scheduleReconnect()
 	}
 }
@@ -464,14 +463,14 @@ func (c *RemoteConnection) processMessage(msg *signaling.ProxyServerMessage) {
 	case "event":
 		c.processEvent(msg)
 	case "bye":
-		log.Printf("Connection to %s was closed: %s", c, msg.Bye.Reason)
+		proxyLog.Infof("Connection to %s was closed: %s", c, msg.Bye.Reason)
 		if msg.Bye.Reason == "session_expired" {
 			// Don't try to resume expired session.
 			c.sessionId = ""
 		}
 		c.scheduleReconnect()
 	default:
-		log.Printf("Received unsupported message %+v from %s", msg, c)
+		proxyLog.Warnf("Received unsupported message %+v from %s", msg, c)
 	}
 }
 
@@ -480,10 +479,10 @@ func (c *RemoteConnection) processEvent(msg *signaling.ProxyServerMessage) {
 	case "update-load":
 		// Ignore
 	case "publisher-closed":
-		log.Printf("Remote publisher %s was closed on %s", msg.Event.ClientId, c)
+		proxyLog.Infof("Remote publisher %s was closed on %s", msg.Event.ClientId, c)
 		c.p.RemotePublisherDeleted(signaling.PublicSessionId(msg.Event.ClientId))
 	default:
-		log.Printf("Received unsupported event %+v from %s", msg, c)
+		proxyLog.Warnf("Received unsupported event %+v from %s", msg, c)
 	}
 }
 
@@ -30,7 +30,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net"
 	"net/http"
 	"net/http/pprof"
@@ -126,9 +125,9 @@ type ProxyServer struct {
 
 	upgrader websocket.Upgrader
 
-	tokens          ProxyTokens
-	statsAllowedIps atomic.Pointer[signaling.AllowedIps]
-	trustedProxies  atomic.Pointer[signaling.AllowedIps]
+	tokens         ProxyTokens
+	statsAccess    atomic.Pointer[signaling.StatsAccess]
+	trustedProxies atomic.Pointer[signaling.AllowedIps]
 
 	sid          atomic.Uint64
 	cookie       *signaling.SessionIdCodec
@@ -189,18 +188,18 @@ func getTargetBandwidths(config *goconf.ConfigFile) (int, int) {
 		maxIncoming = 0
 	}
 	if maxIncoming > 0 {
-		log.Printf("Target bandwidth for incoming streams: %d MBit/s", maxIncoming)
+		proxyLog.Infof("Target bandwidth for incoming streams: %d MBit/s", maxIncoming)
 	} else {
-		log.Printf("Target bandwidth for incoming streams: unlimited")
+		proxyLog.Infof("Target bandwidth for incoming streams: unlimited")
 	}
 	maxOutgoing, _ := config.GetInt("bandwidth", "outgoing")
 	if maxOutgoing < 0 {
 		maxOutgoing = 0
 	}
 	if maxIncoming > 0 {
-		log.Printf("Target bandwidth for outgoing streams: %d MBit/s", maxOutgoing)
+		proxyLog.Infof("Target bandwidth for outgoing streams: %d MBit/s", maxOutgoing)
 	} else {
-		log.Printf("Target bandwidth for outgoing streams: unlimited")
+		proxyLog.Infof("Target bandwidth for outgoing streams: unlimited")
 	}
 
 	return maxIncoming, maxOutgoing
@@ -236,19 +235,11 @@ func NewProxyServer(r *mux.Router, version string, config *goconf.ConfigFile) (*
 		return nil, err
 	}
 
-	statsAllowed, _ := config.GetString("stats", "allowed_ips")
-	statsAllowedIps, err := signaling.ParseAllowedIps(statsAllowed)
+	statsAccess, err := signaling.NewStatsAccessFromConfig(config, proxyLog)
 	if err != nil {
 		return nil, err
 	}
 
-	if !statsAllowedIps.Empty() {
-		log.Printf("Only allowing access to the stats endpoint from %s", statsAllowed)
-	} else {
-		log.Printf("No IPs configured for the stats endpoint, only allowing access from 127.0.0.1")
-		statsAllowedIps = signaling.DefaultAllowedIps()
-	}
-
 	trustedProxies, _ := config.GetString("app", "trustedproxies")
 	trustedProxiesIps, err := signaling.ParseAllowedIps(trustedProxies)
 	if err != nil {
@@ -256,20 +247,20 @@ func NewProxyServer(r *mux.Router, version string, config *goconf.ConfigFile) (*
 	}
 
 	if !trustedProxiesIps.Empty() {
-		log.Printf("Trusted proxies: %s", trustedProxiesIps)
+		proxyLog.Infof("Trusted proxies: %s", trustedProxiesIps)
 	} else {
 		trustedProxiesIps = signaling.DefaultTrustedProxies
-		log.Printf("No trusted proxies configured, only allowing for %s", trustedProxiesIps)
+		proxyLog.Infof("No trusted proxies configured, only allowing for %s", trustedProxiesIps)
 	}
 
 	country, _ := config.GetString("app", "country")
 	country = strings.ToUpper(country)
 	if signaling.IsValidCountry(country) {
-		log.Printf("Sending %s as country information", country)
+		proxyLog.Infof("Sending %s as country information", country)
 	} else if country != "" {
 		return nil, fmt.Errorf("invalid country: %s", country)
 	} else {
-		log.Printf("Not sending country information")
+		proxyLog.Infof("Not sending country information")
 	}
 
 	welcome := map[string]string{
@@ -299,7 +290,7 @@ func NewProxyServer(r *mux.Router, version string, config *goconf.ConfigFile) (*
 		if err != nil {
 			return nil, fmt.Errorf("could not parse private key from %s: %s", tokenKeyFilename, err)
 		}
-		log.Printf("Using \"%s\" as token id for remote streams", tokenId)
+		proxyLog.Infof("Using \"%s\" as token id for remote streams", tokenId)
 
 		remoteHostname, _ = config.GetString("app", "hostname")
 		if remoteHostname == "" {
@@ -309,20 +300,20 @@ func NewProxyServer(r *mux.Router, version string, config *goconf.ConfigFile) (*
 			}
 		}
 		if remoteHostname == "" {
-			log.Printf("WARNING: Could not determine hostname for remote streams, will be disabled. Please configure manually.")
+			proxyLog.Warnf("Could not determine hostname for remote streams, will be disabled. Please configure manually.")
 		} else {
-			log.Printf("Using \"%s\" as hostname for remote streams", remoteHostname)
+			proxyLog.Infof("Using \"%s\" as hostname for remote streams", remoteHostname)
 		}
 
 		skipverify, _ := config.GetBool("backend", "skipverify")
 		if skipverify {
-			log.Println("WARNING: Remote stream requests verification is disabled!")
+			proxyLog.Warnf("Remote stream requests verification is disabled!")
 			remoteTlsConfig = &tls.Config{
 				InsecureSkipVerify: skipverify,
 			}
 		}
 	} else {
-		log.Printf("No token id configured, remote streams will be disabled")
+		proxyLog.Infof("No token id configured, remote streams will be disabled")
 	}
 
 	maxIncoming, maxOutgoing := getTargetBandwidths(config)
@@ -370,27 +361,34 @@ func NewProxyServer(r *mux.Router, version string, config *goconf.ConfigFile) (*
 
 	result.maxIncoming.Store(int64(maxIncoming) * 1024 * 1024)
 	result.maxOutgoing.Store(int64(maxOutgoing) * 1024 * 1024)
-	result.statsAllowedIps.Store(statsAllowedIps)
+	result.statsAccess.Store(statsAccess)
 	result.trustedProxies.Store(trustedProxiesIps)
 	result.upgrader.CheckOrigin = result.checkOrigin
 
 	if debug, _ := config.GetBool("app", "debug"); debug {
-		log.Println("Installing debug handlers in \"/debug/pprof\"")
-		r.Handle("/debug/pprof/", http.HandlerFunc(pprof.Index))
-		r.Handle("/debug/pprof/cmdline", http.HandlerFunc(pprof.Cmdline))
-		r.Handle("/debug/pprof/profile", http.HandlerFunc(pprof.Profile))
-		r.Handle("/debug/pprof/symbol", http.HandlerFunc(pprof.Symbol))
-		r.Handle("/debug/pprof/trace", http.HandlerFunc(pprof.Trace))
+		proxyLog.Infof("Installing debug handlers in \"/debug/pprof\"")
+		debug := func(f func(http.ResponseWriter, *http.Request)) func(http.ResponseWriter, *http.Request) {
+			return result.validateStatsRequest(signaling.StatsPermissionAdmin, f)
+		}
+		r.HandleFunc("/debug/pprof/", debug(pprof.Index))
+		r.HandleFunc("/debug/pprof/cmdline", debug(pprof.Cmdline))
+		r.HandleFunc("/debug/pprof/profile", debug(pprof.Profile))
+		r.HandleFunc("/debug/pprof/symbol", debug(pprof.Symbol))
+		r.HandleFunc("/debug/pprof/trace", debug(pprof.Trace))
 		for _, profile := range runtimepprof.Profiles() {
 			name := profile.Name()
-			r.Handle("/debug/pprof/"+name, pprof.Handler(name))
+			r.HandleFunc("/debug/pprof/"+name, debug(pprof.Handler(name).ServeHTTP))
 		}
 	}
 
 	r.HandleFunc("/welcome", result.setCommonHeaders(result.welcomeHandler)).Methods("GET")
 	r.HandleFunc("/proxy", result.setCommonHeaders(result.proxyHandler)).Methods("GET")
-	r.HandleFunc("/stats", result.setCommonHeaders(result.validateStatsRequest(result.statsHandler))).Methods("GET")
-	r.HandleFunc("/metrics", result.setCommonHeaders(result.validateStatsRequest(result.metricsHandler))).Methods("GET")
+	r.HandleFunc("/stats", result.setCommonHeaders(result.validateStatsRequest(signaling.StatsPermissionAdmin, result.statsHandler))).Methods("GET")
+	r.HandleFunc("/metrics", result.setCommonHeaders(result.validateStatsRequest(signaling.StatsPermissionMetrics, result.metricsHandler))).Methods("GET")
+
+	// Endpoints for liveness / readiness probes (e.g. in Kubernetes).
+	r.HandleFunc("/live", result.setCommonHeaders(result.liveHandler)).Methods("GET")
+	r.HandleFunc("/ready", result.setCommonHeaders(result.readyHandler)).Methods("GET")
 	return result, nil
 }
 
@@ -434,14 +432,14 @@ func (s *ProxyServer) Start(config *goconf.ConfigFile) error {
 			mcu.SetOnDisconnected(s.onMcuDisconnected)
 			err = mcu.Start(ctx)
 			if err != nil {
-				log.Printf("Could not create %s MCU at %s: %s", mcuType, s.url, err)
+				proxyLog.Errorf("Could not create %s MCU at %s: %s", mcuType, s.url, err)
 			}
 		}
 		if err == nil {
 			break
 		}
 
-		log.Printf("Could not initialize %s MCU at %s (%s) will retry in %s", mcuType, s.url, err, backoff.NextWait())
+		proxyLog.Errorf("Could not initialize %s MCU at %s (%s) will retry in %s", mcuType, s.url, err, backoff.NextWait())
 		backoff.Wait(ctx)
 		if ctx.Err() != nil {
 			return fmt.Errorf("cancelled")
@@ -547,7 +545,7 @@ func (s *ProxyServer) expireSessions() {
 			continue
 		}
 
-		log.Printf("Delete expired session %s", session.PublicId())
+		proxyLog.Infof("Delete expired session %s", session.PublicId())
 		s.deleteSessionLocked(session.Sid())
 	}
 }
@@ -588,30 +586,23 @@ func (s *ProxyServer) ScheduleShutdown() {
 }
 
 func (s *ProxyServer) Reload(config *goconf.ConfigFile) {
-	statsAllowed, _ := config.GetString("stats", "allowed_ips")
-	if statsAllowedIps, err := signaling.ParseAllowedIps(statsAllowed); err == nil {
-		if !statsAllowedIps.Empty() {
-			log.Printf("Only allowing access to the stats endpoint from %s", statsAllowed)
-		} else {
-			log.Printf("No IPs configured for the stats endpoint, only allowing access from 127.0.0.1")
-			statsAllowedIps = signaling.DefaultAllowedIps()
-		}
-		s.statsAllowedIps.Store(statsAllowedIps)
+	if statsAccess, err := signaling.NewStatsAccessFromConfig(config, proxyLog); err == nil {
+		s.statsAccess.Store(statsAccess)
 	} else {
-		log.Printf("Error parsing allowed stats ips from \"%s\": %s", statsAllowedIps, err)
+		proxyLog.Errorf("Error parsing allowed stats ips: %s", err)
 	}
 
 	trustedProxies, _ := config.GetString("app", "trustedproxies")
 	if trustedProxiesIps, err := signaling.ParseAllowedIps(trustedProxies); err == nil {
 		if !trustedProxiesIps.Empty() {
-			log.Printf("Trusted proxies: %s", trustedProxiesIps)
+			proxyLog.Infof("Trusted proxies: %s", trustedProxiesIps)
 		} else {
 			trustedProxiesIps = signaling.DefaultTrustedProxies
-			log.Printf("No trusted proxies configured, only allowing for %s", trustedProxiesIps)
+			proxyLog.Infof("No trusted proxies configured, only allowing for %s", trustedProxiesIps)
 		}
 		s.trustedProxies.Store(trustedProxiesIps)
 	} else {
-		log.Printf("Error parsing trusted proxies from \"%s\": %s", trustedProxies, err)
+		proxyLog.Errorf("Error parsing trusted proxies from \"%s\": %s", trustedProxies, err)
 	}
 
 	maxIncoming, maxOutgoing := getTargetBandwidths(config)
@@ -640,6 +631,11 @@ func (s *ProxyServer) welcomeHandler(w http.ResponseWriter, r *http.Request) {
 	io.WriteString(w, s.welcomeMessage) // nolint
 }
 
+// GetTrustedProxies returns the proxies that may pass the address of clients.
+func (s *ProxyServer) GetTrustedProxies() *signaling.AllowedIps {
+	return s.trustedProxies.Load()
+}
+
 func (s *ProxyServer) proxyHandler(w http.ResponseWriter, r *http.Request) {
 	addr := signaling.GetRealUserIP(r, s.trustedProxies.Load())
 	header := http.Header{}
@@ -647,13 +643,13 @@ func (s *ProxyServer) proxyHandler(w http.ResponseWriter, r *http.Request) {
 	header.Set("X-Spreed-Signaling-Features", strings.Join(s.welcomeMsg.Features, ", "))
 	conn, err := s.upgrader.Upgrade(w, r, header)
 	if err != nil {
-		log.Printf("Could not upgrade request from %s: %s", addr, err)
+		proxyLog.Errorf("Could not upgrade request from %s: %s", addr, err)
 		return
 	}
 
 	client, err := NewProxyClient(r.Context(), s, conn, addr)
 	if err != nil {
-		log.Printf("Could not create client for %s: %s", addr, err)
+		proxyLog.Errorf("Could not create client for %s: %s", addr, err)
 		return
 	}
 
@@ -662,11 +658,11 @@ func (s *ProxyServer) proxyHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *ProxyServer) clientClosed(client *signaling.Client) {
-	log.Printf("Connection from %s closed", client.RemoteAddr())
+	proxyLog.Infof("Connection from %s closed", client.RemoteAddr())
 }
 
 func (s *ProxyServer) onMcuConnected() {
-	log.Printf("Connection to %s established", s.url)
+	proxyLog.Infof("Connection to %s established", s.url)
 	msg := &signaling.ProxyServerMessage{
 		Type: "event",
 		Event: &signaling.EventProxyServerMessage{
@@ -685,7 +681,7 @@ func (s *ProxyServer) onMcuDisconnected() {
 		return
 	}
 
-	log.Printf("Connection to %s lost", s.url)
+	proxyLog.Infof("Connection to %s lost", s.url)
 	msg := &signaling.ProxyServerMessage{
 		Type: "event",
 		Event: &signaling.EventProxyServerMessage{
@@ -716,14 +712,14 @@ func (s *ProxyServer) sendShutdownScheduled(session *ProxySession) {
 
 func (s *ProxyServer) processMessage(client *ProxyClient, data []byte) {
 	if proxyDebugMessages {
-		log.Printf("Message: %s", string(data))
+		proxyLog.Infof("Message: %s", string(data))
 	}
 	var message signaling.ProxyClientMessage
 	if err := message.UnmarshalJSON(data); err != nil {
 		if session := client.GetSession(); session != nil {
-			log.Printf("Error decoding message from client %s: %v", session.PublicId(), err)
+			proxyLog.Errorf("Error decoding message from client %s: %v", session.PublicId(), err)
 		} else {
-			log.Printf("Error decoding message from %s: %v", client.RemoteAddr(), err)
+			proxyLog.Errorf("Error decoding message from %s: %v", client.RemoteAddr(), err)
 		}
 		client.SendError(signaling.InvalidFormat)
 		return
@@ -731,9 +727,9 @@ func (s *ProxyServer) processMessage(client *ProxyClient, data []byte) {
 
 	if err := message.CheckValid(); err != nil {
 		if session := client.GetSession(); session != nil {
-			log.Printf("Invalid message %+v from client %s: %v", message, session.PublicId(), err)
+			proxyLog.Warnf("Invalid message %+v from client %s: %v", message, session.PublicId(), err)
 		} else {
-			log.Printf("Invalid message %+v from %s: %v", message, client.RemoteAddr(), err)
+			proxyLog.Warnf("Invalid message %+v from %s: %v", message, client.RemoteAddr(), err)
 		}
 		client.SendMessage(message.NewErrorServerMessage(signaling.InvalidFormat))
 		return
@@ -757,7 +753,7 @@ func (s *ProxyServer) processMessage(client *ProxyClient, data []byte) {
 				return
 			}
 
-			log.Printf("Resumed session %s", session.PublicId())
+			proxyLog.Infof("Resumed session %s", session.PublicId())
 			session.MarkUsed()
 			if s.shutdownScheduled.Load() {
 				s.sendShutdownScheduled(session)
@@ -914,14 +910,14 @@ func (s *ProxyServer) addRemotePublisher(publisher *proxyRemotePublisher) {
 	}
 
 	publishers[publisher] = true
-	log.Printf("Add remote publisher to %s", publisher.remoteUrl)
+	proxyLog.Infof("Add remote publisher to %s", publisher.remoteUrl)
 }
 
 func (s *ProxyServer) removeRemotePublisher(publisher *proxyRemotePublisher) {
 	s.remoteConnectionsLock.Lock()
 	defer s.remoteConnectionsLock.Unlock()
 
-	log.Printf("Removing remote publisher to %s", publisher.remoteUrl)
+	proxyLog.Infof("Removing remote publisher to %s", publisher.remoteUrl)
 	publishers, found := s.remotePublishers[publisher.remoteUrl]
 	if !found {
 		return
@@ -936,9 +932,9 @@ func (s *ProxyServer) removeRemotePublisher(publisher *proxyRemotePublisher) {
 	if conn, found := s.remoteConnections[publisher.remoteUrl]; found {
 		delete(s.remoteConnections, publisher.remoteUrl)
 		if err := conn.Close(); err != nil {
-			log.Printf("Error closing remote connection to %s: %s", publisher.remoteUrl, err)
+			proxyLog.Errorf("Error closing remote connection to %s: %s", publisher.remoteUrl, err)
 		} else {
-			log.Printf("Remote connection to %s closed", publisher.remoteUrl)
+			proxyLog.Infof("Remote connection to %s closed", publisher.remoteUrl)
 		}
 	}
 }
@@ -968,16 +964,16 @@ func (s *ProxyServer) processCommand(ctx context.Context, client *ProxyClient, s
 		}
 		publisher, err := s.mcu.NewPublisher(ctx2, session, signaling.PublicSessionId(id), cmd.Sid, cmd.StreamType, *settings, &emptyInitiator{})
 		if err == context.DeadlineExceeded {
-			log.Printf("Timeout while creating %s publisher %s for %s", cmd.StreamType, id, session.PublicId())
+			proxyLog.Warnf("Timeout while creating %s publisher %s for %s", cmd.StreamType, id, session.PublicId())
 			session.sendMessage(message.NewErrorServerMessage(TimeoutCreatingPublisher))
 			return
 		} else if err != nil {
-			log.Printf("Error while creating %s publisher %s for %s: %s", cmd.StreamType, id, session.PublicId(), err)
+			proxyLog.Errorf("Error while creating %s publisher %s for %s: %s", cmd.StreamType, id, session.PublicId(), err)
 			session.sendMessage(message.NewWrappedErrorServerMessage(err))
 			return
 		}
 
-		log.Printf("Created %s publisher %s as %s for %s", cmd.StreamType, publisher.Id(), id, session.PublicId())
+		proxyLog.Infof("Created %s publisher %s as %s for %s", cmd.StreamType, publisher.Id(), id, session.PublicId())
 		session.StorePublisher(ctx, id, publisher)
 		s.StoreClient(id, publisher)
 
@@ -1000,7 +996,7 @@ func (s *ProxyServer) processCommand(ctx context.Context, client *ProxyClient, s
 
 		handleCreateError := func(err error) {
 			if err == context.DeadlineExceeded {
-				log.Printf("Timeout while creating %s subscriber on %s for %s", cmd.StreamType, publisherId, session.PublicId())
+				proxyLog.Warnf("Timeout while creating %s subscriber on %s for %s", cmd.StreamType, publisherId, session.PublicId())
 				session.sendMessage(message.NewErrorServerMessage(TimeoutCreatingSubscriber))
 				return
 			} else if errors.Is(err, signaling.ErrRemoteStreamsNotSupported) {
@@ -1008,7 +1004,7 @@ func (s *ProxyServer) processCommand(ctx context.Context, client *ProxyClient, s
 				return
 			}
 
-			log.Printf("Error while creating %s subscriber on %s for %s: %s", cmd.StreamType, publisherId, session.PublicId(), err)
+			proxyLog.Errorf("Error while creating %s subscriber on %s for %s: %s", cmd.StreamType, publisherId, session.PublicId(), err)
 			session.sendMessage(message.NewWrappedErrorServerMessage(err))
 		}
 
@@ -1042,7 +1038,7 @@ func (s *ProxyServer) processCommand(ctx context.Context, client *ProxyClient, s
 			subCtx, cancel := context.WithTimeout(ctx, remotePublisherTimeout)
 			defer cancel()
 
-			log.Printf("Creating remote subscriber for %s on %s", publisherId, cmd.RemoteUrl)
+			proxyLog.Infof("Creating remote subscriber for %s on %s", publisherId, cmd.RemoteUrl)
 
 			controller := &proxyRemotePublisher{
 				proxy:       s,
@@ -1069,7 +1065,7 @@ func (s *ProxyServer) processCommand(ctx context.Context, client *ProxyClient, s
 				return
 			}
 
-			log.Printf("Created remote %s subscriber %s as %s for %s on %s", cmd.StreamType, subscriber.Id(), id, session.PublicId(), cmd.RemoteUrl)
+			proxyLog.Infof("Created remote %s subscriber %s as %s for %s on %s", cmd.StreamType, subscriber.Id(), id, session.PublicId(), cmd.RemoteUrl)
 		} else {
 			ctx2, cancel := context.WithTimeout(ctx, s.mcuTimeout)
 			defer cancel()
@@ -1080,7 +1076,7 @@ func (s *ProxyServer) processCommand(ctx context.Context, client *ProxyClient, s
 				return
 			}
 
-			log.Printf("Created %s subscriber %s as %s for %s", cmd.StreamType, subscriber.Id(), id, session.PublicId())
+			proxyLog.Infof("Created %s subscriber %s as %s for %s", cmd.StreamType, subscriber.Id(), id, session.PublicId())
 		}
 
 		session.StoreSubscriber(ctx, id, subscriber)
@@ -1120,7 +1116,7 @@ func (s *ProxyServer) processCommand(ctx context.Context, client *ProxyClient, s
 		}
 
 		go func() {
-			log.Printf("Closing %s publisher %s as %s", client.StreamType(), client.Id(), cmd.ClientId)
+			proxyLog.Infof("Closing %s publisher %s as %s", client.StreamType(), client.Id(), cmd.ClientId)
 			client.Close(context.Background())
 		}()
 
@@ -1155,7 +1151,7 @@ func (s *ProxyServer) processCommand(ctx context.Context, client *ProxyClient, s
 		}
 
 		go func() {
-			log.Printf("Closing %s subscriber %s as %s", client.StreamType(), client.Id(), cmd.ClientId)
+			proxyLog.Infof("Closing %s subscriber %s as %s", client.StreamType(), client.Id(), cmd.ClientId)
 			client.Close(context.Background())
 		}()
 
@@ -1186,7 +1182,7 @@ func (s *ProxyServer) processCommand(ctx context.Context, client *ProxyClient, s
 		if err := publisher.PublishRemote(ctx2, session.PublicId(), cmd.Hostname, cmd.Port, cmd.RtcpPort); err != nil {
 			var je *janus.ErrorMsg
 			if !errors.As(err, &je) || je.Err.Code != signaling.JANUS_VIDEOROOM_ERROR_ID_EXISTS {
-				log.Printf("Error publishing %s %s to remote %s (port=%d, rtcpPort=%d): %s", publisher.StreamType(), cmd.ClientId, cmd.Hostname, cmd.Port, cmd.RtcpPort, err)
+				proxyLog.Errorf("Error publishing %s %s to remote %s (port=%d, rtcpPort=%d): %s", publisher.StreamType(), cmd.ClientId, cmd.Hostname, cmd.Port, cmd.RtcpPort, err)
 				session.sendMessage(message.NewWrappedErrorServerMessage(err))
 				return
 			}
@@ -1195,7 +1191,7 @@ func (s *ProxyServer) processCommand(ctx context.Context, client *ProxyClient, s
 			defer cancel()
 
 			if err := publisher.UnpublishRemote(ctx2, session.PublicId(), cmd.Hostname, cmd.Port, cmd.RtcpPort); err != nil {
-				log.Printf("Error unpublishing old %s %s to remote %s (port=%d, rtcpPort=%d): %s", publisher.StreamType(), cmd.ClientId, cmd.Hostname, cmd.Port, cmd.RtcpPort, err)
+				proxyLog.Errorf("Error unpublishing old %s %s to remote %s (port=%d, rtcpPort=%d): %s", publisher.StreamType(), cmd.ClientId, cmd.Hostname, cmd.Port, cmd.RtcpPort, err)
 				session.sendMessage(message.NewWrappedErrorServerMessage(err))
 				return
 			}
@@ -1204,7 +1200,7 @@ func (s *ProxyServer) processCommand(ctx context.Context, client *ProxyClient, s
 			defer cancel()
 
 			if err := publisher.PublishRemote(ctx2, session.PublicId(), cmd.Hostname, cmd.Port, cmd.RtcpPort); err != nil {
-				log.Printf("Error publishing %s %s to remote %s (port=%d, rtcpPort=%d): %s", publisher.StreamType(), cmd.ClientId, cmd.Hostname, cmd.Port, cmd.RtcpPort, err)
+				proxyLog.Errorf("Error publishing %s %s to remote %s (port=%d, rtcpPort=%d): %s", publisher.StreamType(), cmd.ClientId, cmd.Hostname, cmd.Port, cmd.RtcpPort, err)
 				session.sendMessage(message.NewWrappedErrorServerMessage(err))
 				return
 			}
@@ -1236,7 +1232,7 @@ func (s *ProxyServer) processCommand(ctx context.Context, client *ProxyClient, s
 		defer cancel()
 
 		if err := publisher.UnpublishRemote(ctx2, session.PublicId(), cmd.Hostname, cmd.Port, cmd.RtcpPort); err != nil {
-			log.Printf("Error unpublishing %s %s from remote %s: %s", publisher.StreamType(), cmd.ClientId, cmd.Hostname, err)
+			proxyLog.Errorf("Error unpublishing %s %s from remote %s: %s", publisher.StreamType(), cmd.ClientId, cmd.Hostname, err)
 			session.sendMessage(message.NewWrappedErrorServerMessage(err))
 			return
 		}
@@ -1266,7 +1262,7 @@ func (s *ProxyServer) processCommand(ctx context.Context, client *ProxyClient, s
 
 		streams, err := publisher.GetStreams(ctx)
 		if err != nil {
-			log.Printf("Could not get streams of publisher %s: %s", publisher.Id(), err)
+			proxyLog.Errorf("Could not get streams of publisher %s: %s", publisher.Id(), err)
 			session.sendMessage(message.NewWrappedErrorServerMessage(err))
 			return
 		}
@@ -1281,7 +1277,7 @@ func (s *ProxyServer) processCommand(ctx context.Context, client *ProxyClient, s
 		}
 		session.sendMessage(response)
 	default:
-		log.Printf("Unsupported command %+v", message.Command)
+		proxyLog.Warnf("Unsupported command %+v", message.Command)
 		session.sendMessage(message.NewErrorServerMessage(UnsupportedCommand))
 	}
 }
@@ -1336,7 +1332,7 @@ func (s *ProxyServer) processPayload(ctx context.Context, client *ProxyClient, s
 	}
 
 	if err := mcuData.CheckValid(); err != nil {
-		log.Printf("Received invalid payload %+v for %s client %s: %s", mcuData, mcuClient.StreamType(), payload.ClientId, err)
+		proxyLog.Warnf("Received invalid payload %+v for %s client %s: %s", mcuData, mcuClient.StreamType(), payload.ClientId, err)
 		session.sendMessage(message.NewErrorServerMessage(UnsupportedPayload))
 		return
 	}
@@ -1352,7 +1348,7 @@ func (s *ProxyServer) processPayload(ctx context.Context, client *ProxyClient, s
 		}
 
 		if err != nil {
-			log.Printf("Error sending %+v to %s client %s: %s", mcuData, mcuClient.StreamType(), payload.ClientId, err)
+			proxyLog.Errorf("Error sending %+v to %s client %s: %s", mcuData, mcuClient.StreamType(), payload.ClientId, err)
 			responseMsg = message.NewWrappedErrorServerMessage(err)
 		} else {
 			responseMsg = &signaling.ProxyServerMessage{
@@ -1371,7 +1367,7 @@ func (s *ProxyServer) processPayload(ctx context.Context, client *ProxyClient, s
 }
 
 func (s *ProxyServer) processBye(ctx context.Context, client *ProxyClient, session *ProxySession, message *signaling.ProxyClientMessage) {
-	log.Printf("Closing session %s", session.PublicId())
+	proxyLog.Infof("Closing session %s", session.PublicId())
 	s.DeleteSession(session.Sid())
 }
 
@@ -1380,27 +1376,27 @@ func (s *ProxyServer) parseToken(tokenValue string) (*signaling.TokenClaims, str
 	token, err := jwt.ParseWithClaims(tokenValue, &signaling.TokenClaims{}, func(token *jwt.Token) (any, error) {
 		// Don't forget to validate the alg is what you expect:
 		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
-			log.Printf("Unexpected signing method: %v", token.Header["alg"])
+			proxyLog.Warnf("Unexpected signing method: %v", token.Header["alg"])
 			reason = "unsupported-signing-method"
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
 
 		claims, ok := token.Claims.(*signaling.TokenClaims)
 		if !ok {
-			log.Printf("Unsupported claims type: %+v", token.Claims)
+			proxyLog.Warnf("Unsupported claims type: %+v", token.Claims)
 			reason = "unsupported-claims"
 			return nil, fmt.Errorf("unsupported claims type")
 		}
 
 		tokenKey, err := s.tokens.Get(claims.Issuer)
 		if err != nil {
-			log.Printf("Could not get token for %s: %s", claims.Issuer, err)
+			proxyLog.Errorf("Could not get token for %s: %s", claims.Issuer, err)
 			reason = "missing-issuer"
 			return nil, err
 		}
 
 		if tokenKey == nil || tokenKey.key == nil {
-			log.Printf("Issuer %s is not supported", claims.Issuer)
+			proxyLog.Infof("Issuer %s is not supported", claims.Issuer)
 			reason = "unsupported-issuer"
 			return nil, fmt.Errorf("no key found for issuer")
 		}
@@ -1437,7 +1433,7 @@ func (s *ProxyServer) parseToken(tokenValue string) (*signaling.TokenClaims, str
 
 func (s *ProxyServer) NewSession(hello *signaling.HelloProxyClientMessage) (*ProxySession, error) {
 	if proxyDebugMessages {
-		log.Printf("Hello: %+v", hello)
+		proxyLog.Infof("Hello: %+v", hello)
 	}
 
 	claims, reason, err := s.parseToken(hello.Token)
@@ -1461,7 +1457,7 @@ func (s *ProxyServer) NewSession(hello *signaling.HelloProxyClientMessage) (*Pro
 		return nil, err
 	}
 
-	log.Printf("Created session %s for %+v", encoded, claims)
+	proxyLog.Infof("Created session %s for %+v", encoded, claims)
 	session := NewProxySession(s, sid, encoded)
 	s.StoreSession(sid, session)
 	statsSessionsCurrent.Inc()
@@ -1595,21 +1591,21 @@ func (s *ProxyServer) getStats() signaling.StringMap {
 	return result
 }
 
-func (s *ProxyServer) allowStatsAccess(r *http.Request) bool {
+func (s *ProxyServer) checkStatsAccess(r *http.Request, permission signaling.StatsPermission) error {
 	addr := signaling.GetRealUserIP(r, s.trustedProxies.Load())
 	ip := net.ParseIP(addr)
-	if len(ip) == 0 {
-		return false
+	access := s.statsAccess.Load()
+	if access == nil {
+		return signaling.ErrStatsAddressNotAllowed
 	}
 
-	allowed := s.statsAllowedIps.Load()
-	return allowed != nil && allowed.Allowed(ip)
+	return access.Check(r, ip, permission)
 }
 
-func (s *ProxyServer) validateStatsRequest(f func(http.ResponseWriter, *http.Request)) func(http.ResponseWriter, *http.Request) {
+func (s *ProxyServer) validateStatsRequest(permission signaling.StatsPermission, f func(http.ResponseWriter, *http.Request)) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if !s.allowStatsAccess(r) {
-			http.Error(w, "Authentication check failed", http.StatusForbidden)
+		if err := s.checkStatsAccess(r, permission); err != nil {
+			signaling.WriteStatsAccessError(w, err)
 			return
 		}
 
@@ -1617,11 +1613,55 @@ func (s *ProxyServer) validateStatsRequest(f func(http.ResponseWriter, *http.Req
 	}
 }
 
+func (s *ProxyServer) healthHandler(w http.ResponseWriter, ready bool) {
+	component := signaling.BackendServerHealthComponent{
+		Status:  signaling.HealthStatusOk,
+		Message: "running",
+	}
+	if ready && s.shutdownScheduled.Load() {
+		// Don't route new sessions to a proxy that is shutting down.
+		component.Status = signaling.HealthStatusError
+		component.Message = "shutdown scheduled"
+	}
+
+	health := signaling.BackendServerHealth{
+		Status: component.Status,
+		Components: map[string]signaling.BackendServerHealthComponent{
+			"proxy": component,
+		},
+	}
+	healthData, err := json.MarshalIndent(health, "", "  ")
+	if err != nil {
+		proxyLog.Errorf("Could not serialize health %+v: %s", health, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	status := http.StatusOK
+	if health.Status == signaling.HealthStatusError {
+		status = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(status)
+	w.Write(healthData) // nolint
+}
+
+func (s *ProxyServer) liveHandler(w http.ResponseWriter, r *http.Request) {
+	s.healthHandler(w, false)
+}
+
+func (s *ProxyServer) readyHandler(w http.ResponseWriter, r *http.Request) {
+	s.healthHandler(w, true)
+}
+
 func (s *ProxyServer) statsHandler(w http.ResponseWriter, r *http.Request) {
 	stats := s.getStats()
 	statsData, err := json.MarshalIndent(stats, "", "  ")
 	if err != nil {
-		log.Printf("Could not serialize stats %+v: %s", stats, err)
+		proxyLog.Errorf("Could not serialize stats %+v: %s", stats, err)
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
@@ -28,7 +28,9 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"errors"
+	"io"
 	"net"
+	"net/http"
 	"net/http/httptest"
 	"os"
 	"strings"
@@ -98,6 +100,10 @@ func WaitForProxyServer(ctx context.Context, t *testing.T, proxy *ProxyServer) {
 }
 
 func newProxyServerForTest(t *testing.T) (*ProxyServer, *rsa.PrivateKey, *httptest.Server) {
+	return newProxyServerForTestWithConfig(t, nil)
+}
+
+func newProxyServerForTestWithConfig(t *testing.T, config *goconf.ConfigFile) (*ProxyServer, *rsa.PrivateKey, *httptest.Server) {
 	require := require.New(t)
 	tempdir := t.TempDir()
 	var proxy *ProxyServer
@@ -133,7 +139,9 @@ func newProxyServerForTest(t *testing.T) (*ProxyServer, *rsa.PrivateKey, *httpte
 	require.NoError(pem.Encode(pubkey, pub))
 	require.NoError(pubkey.Close())
 
-	config := goconf.NewConfigFile()
+	if config == nil {
+		config = goconf.NewConfigFile()
+	}
 	config.AddOption("tokens", TokenIdForTest, pubkey.Name())
 
 	proxy, err = NewProxyServer(r, "0.0", config)
@@ -147,6 +155,34 @@ func newProxyServerForTest(t *testing.T) (*ProxyServer, *rsa.PrivateKey, *httpte
 	return proxy, key, server
 }
 
+func TestDebugHandlers(t *testing.T) {
+	signaling.CatchLogForTest(t)
+	assert := assert.New(t)
+	require := require.New(t)
+	config := goconf.NewConfigFile()
+	config.AddOption("app", "debug", "true")
+	config.AddOption("stats", "token", "admin-token")
+	_, _, server := newProxyServerForTestWithConfig(t, config)
+
+	request := func(path string, token string) int {
+		req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+		require.NoError(err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(err)
+		defer res.Body.Close()
+		io.Copy(io.Discard, res.Body) // nolint
+		return res.StatusCode
+	}
+
+	assert.Equal(http.StatusUnauthorized, request("/debug/pprof/", ""))
+	assert.Equal(http.StatusUnauthorized, request("/debug/pprof/heap", ""))
+	assert.Equal(http.StatusOK, request("/debug/pprof/", "admin-token"))
+	assert.Equal(http.StatusOK, request("/debug/pprof/heap", "admin-token"))
+}
+
 func TestTokenValid(t *testing.T) {
 	signaling.CatchLogForTest(t)
 	proxy, key, _ := newProxyServerForTest(t)
@@ -363,7 +399,8 @@ func (m *TestMCU) Start(ctx context.Context) error {
 func (m *TestMCU) Stop() {
 }
 
-func (m *TestMCU) Reload(config *goconf.ConfigFile) {
+func (m *TestMCU) Reload(config *goconf.ConfigFile) error {
+	return nil
 }
 
 func (m *TestMCU) SetOnConnected(f func()) {
@@ -1350,3 +1387,27 @@ func TestProxyUnpublishRemoteOnSessionClose(t *testing.T) {
 		assert.Nil(publisher.getRemoteData())
 	}
 }
+
+func TestProxyServerReady(t *testing.T) {
+	signaling.CatchLogForTest(t)
+	assert := assert.New(t)
+	require := require.New(t)
+	proxy, _, server := newProxyServerForTest(t)
+
+	getStatus := func(path string) int {
+		res, err := http.Get(server.URL + path)
+		require.NoError(err)
+		defer res.Body.Close()
+		io.Copy(io.Discard, res.Body) // nolint
+		return res.StatusCode
+	}
+
+	assert.Equal(http.StatusOK, getStatus("/live"))
+	assert.Equal(http.StatusOK, getStatus("/ready"))
+
+	// Proxies that are shutting down are still alive but no longer ready.
+	proxy.shutdownScheduled.Store(true)
+	defer proxy.shutdownScheduled.Store(false)
+	assert.Equal(http.StatusOK, getStatus("/live"))
+	assert.Equal(http.StatusServiceUnavailable, getStatus("/ready"))
+}
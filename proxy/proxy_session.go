@@ -24,7 +24,7 @@ package main
 import (
 	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -51,6 +51,8 @@ type ProxySession struct {
 	lastUsed  atomic.Int64
 	ctx       context.Context
 	closeFunc context.CancelFunc
+	// Adds the id of the session to all log messages.
+	log *signaling.Logger
 
 	clientLock      sync.Mutex
 	client          *ProxyClient
@@ -76,6 +78,7 @@ func NewProxySession(proxy *ProxyServer, sid uint64, id signaling.PublicSessionI
 		sid:       sid,
 		ctx:       ctx,
 		closeFunc: closeFunc,
+		log:       proxyLog.With(slog.String("session", string(id))),
 
 		publishers:   make(map[string]signaling.McuPublisher),
 		publisherIds: make(map[signaling.McuPublisher]string),
@@ -161,7 +164,7 @@ func (s *ProxySession) SetClient(client *ProxyClient) *ProxyClient {
 func (s *ProxySession) OnUpdateOffer(client signaling.McuClient, offer signaling.StringMap) {
 	id := s.proxy.GetClientId(client)
 	if id == "" {
-		log.Printf("Received offer %+v from unknown %s client %s (%+v)", offer, client.StreamType(), client.Id(), client)
+		s.log.Infof("Received offer %+v from unknown %s client %s (%+v)", offer, client.StreamType(), client.Id(), client)
 		return
 	}
 
@@ -181,7 +184,7 @@ func (s *ProxySession) OnUpdateOffer(client signaling.McuClient, offer signaling
 func (s *ProxySession) OnIceCandidate(client signaling.McuClient, candidate any) {
 	id := s.proxy.GetClientId(client)
 	if id == "" {
-		log.Printf("Received candidate %+v from unknown %s client %s (%+v)", candidate, client.StreamType(), client.Id(), client)
+		s.log.Infof("Received candidate %+v from unknown %s client %s (%+v)", candidate, client.StreamType(), client.Id(), client)
 		return
 	}
 
@@ -214,7 +217,7 @@ func (s *ProxySession) sendMessage(message *signaling.ProxyServerMessage) {
 func (s *ProxySession) OnIceCompleted(client signaling.McuClient) {
 	id := s.proxy.GetClientId(client)
 	if id == "" {
-		log.Printf("Received ice completed event from unknown %s client %s (%+v)", client.StreamType(), client.Id(), client)
+		s.log.Infof("Received ice completed event from unknown %s client %s (%+v)", client.StreamType(), client.Id(), client)
 		return
 	}
 
@@ -231,7 +234,7 @@ func (s *ProxySession) OnIceCompleted(client signaling.McuClient) {
 func (s *ProxySession) SubscriberSidUpdated(subscriber signaling.McuSubscriber) {
 	id := s.proxy.GetClientId(subscriber)
 	if id == "" {
-		log.Printf("Received subscriber sid updated event from unknown %s subscriber %s (%+v)", subscriber.StreamType(), subscriber.Id(), subscriber)
+		s.log.Infof("Received subscriber sid updated event from unknown %s subscriber %s (%+v)", subscriber.StreamType(), subscriber.Id(), subscriber)
 		return
 	}
 
@@ -353,7 +356,7 @@ func (s *ProxySession) clearRemotePublishers() {
 		for publisher, entries := range remotePublishers {
 			for _, data := range entries {
 				if err := publisher.UnpublishRemote(context.Background(), s.PublicId(), data.hostname, data.port, data.rtcpPort); err != nil {
-					log.Printf("Error unpublishing %s %s from remote %s: %s", publisher.StreamType(), publisher.Id(), data.hostname, err)
+					s.log.Errorf("Error unpublishing %s %s from remote %s: %s", publisher.StreamType(), publisher.Id(), data.hostname, err)
 				}
 			}
 		}
@@ -466,7 +469,7 @@ func (s *ProxySession) OnRemotePublisherDeleted(publisherId signaling.PublicSess
 			delete(s.subscribers, id)
 			delete(s.subscriberIds, sub)
 
-			log.Printf("Remote subscriber %s was closed, closing %s subscriber %s", publisherId, sub.StreamType(), sub.Id())
+			s.log.Infof("Remote subscriber %s was closed, closing %s subscriber %s", publisherId, sub.StreamType(), sub.Id())
 			go sub.Close(context.Background())
 		}
 	}
@@ -25,7 +25,6 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"log"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -49,7 +48,7 @@ type tokensEtcd struct {
 	client *signaling.EtcdClient
 
 	tokenFormats atomic.Value
-	tokenCache   *signaling.LruCache
+	tokenCache   *signaling.LruCache[string, *tokenCacheEntry]
 }
 
 func NewProxyTokensEtcd(config *goconf.ConfigFile) (ProxyTokens, error) {
@@ -64,7 +63,7 @@ func NewProxyTokensEtcd(config *goconf.ConfigFile) (ProxyTokens, error) {
 
 	result := &tokensEtcd{
 		client:     client,
-		tokenCache: signaling.NewLruCache(tokenCacheSize),
+		tokenCache: signaling.NewNamedLruCache[string, *tokenCacheEntry]("proxy_tokens", tokenCacheSize),
 	}
 	if err := result.load(config, false); err != nil {
 		return nil, err
@@ -94,11 +93,11 @@ func (t *tokensEtcd) getByKey(id string, key string) (*ProxyToken, error) {
 	if len(resp.Kvs) == 0 {
 		return nil, nil
 	} else if len(resp.Kvs) > 1 {
-		log.Printf("Received multiple keys for %s, using last", key)
+		proxyLog.Infof("Received multiple keys for %s, using last", key)
 	}
 
 	keyValue := resp.Kvs[len(resp.Kvs)-1].Value
-	cached, _ := t.tokenCache.Get(key).(*tokenCacheEntry)
+	cached, _ := t.tokenCache.Get(key)
 	if cached == nil || !bytes.Equal(cached.keyValue, keyValue) {
 		// Parsed public keys are cached to avoid the parse overhead.
 		publicKey, err := jwt.ParseRSAPublicKeyFromPEM(keyValue)
@@ -123,7 +122,7 @@ func (t *tokensEtcd) Get(id string) (*ProxyToken, error) {
 	for _, k := range t.getKeys(id) {
 		token, err := t.getByKey(id, k)
 		if err != nil {
-			log.Printf("Could not get public key from %s for %s: %s", k, id, err)
+			proxyLog.Errorf("Could not get public key from %s for %s: %s", k, id, err)
 			continue
 		} else if token == nil {
 			continue
@@ -151,18 +150,18 @@ func (t *tokensEtcd) load(config *goconf.ConfigFile, ignoreErrors bool) error {
 	}
 
 	t.tokenFormats.Store(tokenFormats)
-	log.Printf("Using %v as token formats", tokenFormats)
+	proxyLog.Infof("Using %v as token formats", tokenFormats)
 	return nil
 }
 
 func (t *tokensEtcd) Reload(config *goconf.ConfigFile) {
 	if err := t.load(config, true); err != nil {
-		log.Printf("Error reloading etcd tokens: %s", err)
+		proxyLog.Errorf("Error reloading etcd tokens: %s", err)
 	}
 }
 
 func (t *tokensEtcd) Close() {
 	if err := t.client.Close(); err != nil {
-		log.Printf("Error while closing etcd client: %s", err)
+		proxyLog.Errorf("Error while closing etcd client: %s", err)
 	}
 }
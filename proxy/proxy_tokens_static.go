@@ -23,7 +23,6 @@ package main
 
 import (
 	"fmt"
-	"log"
 	"os"
 	"slices"
 	"sync/atomic"
@@ -74,7 +73,7 @@ func (t *tokensStatic) load(config *goconf.ConfigFile, ignoreErrors bool) error
 				return fmt.Errorf("no filename given for token %s", id)
 			}
 
-			log.Printf("No filename given for token %s, ignoring", id)
+			proxyLog.Infof("No filename given for token %s, ignoring", id)
 			continue
 		}
 
@@ -84,7 +83,7 @@ func (t *tokensStatic) load(config *goconf.ConfigFile, ignoreErrors bool) error
 				return fmt.Errorf("could not read public key from %s: %s", filename, err)
 			}
 
-			log.Printf("Could not read public key from %s, ignoring: %s", filename, err)
+			proxyLog.Errorf("Could not read public key from %s, ignoring: %s", filename, err)
 			continue
 		}
 		key, err := jwt.ParseRSAPublicKeyFromPEM(keyData)
@@ -93,7 +92,7 @@ func (t *tokensStatic) load(config *goconf.ConfigFile, ignoreErrors bool) error
 				return fmt.Errorf("could not parse public key from %s: %s", filename, err)
 			}
 
-			log.Printf("Could not parse public key from %s, ignoring: %s", filename, err)
+			proxyLog.Errorf("Could not parse public key from %s, ignoring: %s", filename, err)
 			continue
 		}
 
@@ -104,14 +103,14 @@ func (t *tokensStatic) load(config *goconf.ConfigFile, ignoreErrors bool) error
 	}
 
 	if len(tokenKeys) == 0 {
-		log.Printf("No token keys loaded")
+		proxyLog.Infof("No token keys loaded")
 	} else {
 		var keyIds []string
 		for k := range tokenKeys {
 			keyIds = append(keyIds, k)
 		}
 		slices.Sort(keyIds)
-		log.Printf("Enabled token keys: %v", keyIds)
+		proxyLog.Infof("Enabled token keys: %v", keyIds)
 	}
 	t.setTokenKeys(tokenKeys)
 	return nil
@@ -119,7 +118,7 @@ func (t *tokensStatic) load(config *goconf.ConfigFile, ignoreErrors bool) error
 
 func (t *tokensStatic) Reload(config *goconf.ConfigFile) {
 	if err := t.load(config, true); err != nil {
-		log.Printf("Error reloading static tokens: %s", err)
+		proxyLog.Errorf("Error reloading static tokens: %s", err)
 	}
 }
 
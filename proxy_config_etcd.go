@@ -25,7 +25,6 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"log"
 	"sync"
 	"time"
 
@@ -116,9 +115,9 @@ func (p *proxyConfigEtcd) EtcdClientCreated(client *EtcdClient) {
 				if errors.Is(err, context.Canceled) {
 					return
 				} else if errors.Is(err, context.DeadlineExceeded) {
-					log.Printf("Timeout getting initial list of proxy URLs, retry in %s", backoff.NextWait())
+					mcuLog.Warnf("Timeout getting initial list of proxy URLs, retry in %s", backoff.NextWait())
 				} else {
-					log.Printf("Could not get initial list of proxy URLs, retry in %s: %s", backoff.NextWait(), err)
+					mcuLog.Errorf("Could not get initial list of proxy URLs, retry in %s: %s", backoff.NextWait(), err)
 				}
 
 				backoff.Wait(p.closeCtx)
@@ -137,7 +136,7 @@ func (p *proxyConfigEtcd) EtcdClientCreated(client *EtcdClient) {
 		for p.closeCtx.Err() == nil {
 			var err error
 			if nextRevision, err = client.Watch(p.closeCtx, p.keyPrefix, nextRevision, p, clientv3.WithPrefix()); err != nil {
-				log.Printf("Error processing watch for %s (%s), retry in %s", p.keyPrefix, err, backoff.NextWait())
+				mcuLog.Errorf("Error processing watch for %s (%s), retry in %s", p.keyPrefix, err, backoff.NextWait())
 				backoff.Wait(p.closeCtx)
 				continue
 			}
@@ -146,7 +145,7 @@ func (p *proxyConfigEtcd) EtcdClientCreated(client *EtcdClient) {
 				backoff.Reset()
 				prevRevision = nextRevision
 			} else {
-				log.Printf("Processing watch for %s interrupted, retry in %s", p.keyPrefix, backoff.NextWait())
+				mcuLog.Infof("Processing watch for %s interrupted, retry in %s", p.keyPrefix, backoff.NextWait())
 				backoff.Wait(p.closeCtx)
 			}
 		}
@@ -166,11 +165,11 @@ func (p *proxyConfigEtcd) getProxyUrls(ctx context.Context, client *EtcdClient,
 func (p *proxyConfigEtcd) EtcdKeyUpdated(client *EtcdClient, key string, data []byte, prevValue []byte) {
 	var info ProxyInformationEtcd
 	if err := json.Unmarshal(data, &info); err != nil {
-		log.Printf("Could not decode proxy information %s: %s", string(data), err)
+		mcuLog.Errorf("Could not decode proxy information %s: %s", string(data), err)
 		return
 	}
 	if err := info.CheckValid(); err != nil {
-		log.Printf("Received invalid proxy information %s: %s", string(data), err)
+		mcuLog.Warnf("Received invalid proxy information %s: %s", string(data), err)
 		return
 	}
 
@@ -185,7 +184,7 @@ func (p *proxyConfigEtcd) EtcdKeyUpdated(client *EtcdClient, key string, data []
 	}
 
 	if otherKey, otherFound := p.urlToKey[info.Address]; otherFound && otherKey != key {
-		log.Printf("Address %s is already registered for key %s, ignoring %s", info.Address, otherKey, key)
+		mcuLog.Infof("Address %s is already registered for key %s, ignoring %s", info.Address, otherKey, key)
 		return
 	}
 
@@ -194,11 +193,11 @@ func (p *proxyConfigEtcd) EtcdKeyUpdated(client *EtcdClient, key string, data []
 		p.proxy.KeepConnection(info.Address)
 	} else {
 		if err := p.proxy.AddConnection(false, info.Address); err != nil {
-			log.Printf("Could not create proxy connection to %s: %s", info.Address, err)
+			mcuLog.Errorf("Could not create proxy connection to %s: %s", info.Address, err)
 			return
 		}
 
-		log.Printf("Added new connection to %s (from %s)", info.Address, key)
+		mcuLog.Infof("Added new connection to %s (from %s)", info.Address, key)
 		p.keyInfos[key] = &info
 		p.urlToKey[info.Address] = key
 	}
@@ -220,6 +219,6 @@ func (p *proxyConfigEtcd) removeEtcdProxyLocked(key string) {
 	delete(p.keyInfos, key)
 	delete(p.urlToKey, info.Address)
 
-	log.Printf("Removing connection to %s (from %s)", info.Address, key)
+	mcuLog.Infof("Removing connection to %s (from %s)", info.Address, key)
 	p.proxy.RemoveConnection(info.Address)
 }
@@ -23,7 +23,6 @@ package signaling
 
 import (
 	"errors"
-	"log"
 	"maps"
 	"net"
 	"net/url"
@@ -98,7 +97,7 @@ func (p *proxyConfigStatic) configure(config *goconf.ConfigFile, fromReload bool
 				return err
 			}
 
-			log.Printf("Could not parse URL %s: %s", u, err)
+			mcuLog.Errorf("Could not parse URL %s: %s", u, err)
 			continue
 		}
 
@@ -119,7 +118,7 @@ func (p *proxyConfigStatic) configure(config *goconf.ConfigFile, fromReload bool
 					return err
 				}
 
-				log.Printf("Could not create proxy connection to %s: %s", u, err)
+				mcuLog.Errorf("Could not create proxy connection to %s: %s", u, err)
 				continue
 			}
 		}
@@ -196,7 +195,7 @@ func (p *proxyConfigStatic) onLookup(entry *DnsMonitorEntry, all []net.IP, added
 
 	if len(added) > 0 {
 		if err := p.proxy.AddConnection(true, u, added...); err != nil {
-			log.Printf("Could not add proxy connection to %s with %+v: %s", u, added, err)
+			mcuLog.Errorf("Could not add proxy connection to %s with %+v: %s", u, added, err)
 		}
 	}
 
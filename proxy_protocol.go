@@ -0,0 +1,263 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Support for the PROXY protocol of HAProxy, see
+// https://www.haproxy.org/download/3.0/doc/proxy-protocol.txt
+
+const (
+	// Maximum time to wait for the PROXY protocol header of a connection.
+	proxyProtocolHeaderTimeout = 5 * time.Second
+
+	// Maximum length of a v1 (text) header including the trailing CRLF.
+	proxyProtocolV1MaxLength = 107
+)
+
+var (
+	ErrProxyProtocolHeaderMissing = errors.New("missing PROXY protocol header")
+	ErrProxyProtocolHeaderInvalid = errors.New("invalid PROXY protocol header")
+
+	proxyProtocolV1Prefix    = []byte("PROXY ")
+	proxyProtocolV2Signature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+)
+
+type proxyProtocolListener struct {
+	net.Listener
+
+	trusted func() *AllowedIps
+}
+
+// NewProxyProtocolListener returns a listener that reads the client address
+// from the PROXY protocol header (v1 or v2) of incoming connections. The header
+// is required for connections from trusted proxies and must not be sent by
+// other clients. Connections that are not using TCP (e.g. unix sockets) are
+// always trusted.
+func NewProxyProtocolListener(listener net.Listener, trusted func() *AllowedIps) net.Listener {
+	return &proxyProtocolListener{
+		Listener: listener,
+		trusted:  trusted,
+	}
+}
+
+func (l *proxyProtocolListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	return &proxyProtocolConn{
+		Conn:    conn,
+		trusted: l.trusted,
+	}, nil
+}
+
+// proxyProtocolConn reads the PROXY protocol header lazily on the first call
+// to Read or RemoteAddr so the accepting goroutine is not blocked.
+type proxyProtocolConn struct {
+	net.Conn
+
+	trusted func() *AllowedIps
+
+	once       sync.Once
+	reader     io.Reader
+	remoteAddr net.Addr
+	err        error
+}
+
+func (c *proxyProtocolConn) init() {
+	c.once.Do(func() {
+		c.reader = c.Conn
+		c.remoteAddr = c.Conn.RemoteAddr()
+		if addr, ok := c.remoteAddr.(*net.TCPAddr); ok {
+			if trusted := c.trusted(); trusted == nil || !trusted.Allowed(addr.IP) {
+				return
+			}
+		}
+
+		if err := c.Conn.SetReadDeadline(time.Now().Add(proxyProtocolHeaderTimeout)); err != nil {
+			c.err = err
+			return
+		}
+
+		reader := bufio.NewReader(c.Conn)
+		addr, err := readProxyProtocolHeader(reader)
+		if err == nil {
+			err = c.Conn.SetReadDeadline(time.Time{})
+		}
+		if err != nil {
+			appLog.Warnf("Could not read PROXY protocol header from %s: %s", c.remoteAddr, err)
+			c.err = err
+			c.Conn.Close() // nolint
+			return
+		}
+
+		if addr != nil {
+			c.remoteAddr = addr
+		}
+		c.reader = reader
+	})
+}
+
+func (c *proxyProtocolConn) Read(b []byte) (int, error) {
+	c.init()
+	if c.err != nil {
+		return 0, c.err
+	}
+
+	return c.reader.Read(b)
+}
+
+func (c *proxyProtocolConn) RemoteAddr() net.Addr {
+	c.init()
+	return c.remoteAddr
+}
+
+// readProxyProtocolHeader reads a PROXY protocol header and returns the source
+// address from it. The returned address is nil if the header doesn't contain
+// an address (e.g. for health checks of the proxy).
+func readProxyProtocolHeader(r *bufio.Reader) (net.Addr, error) {
+	prefix, err := r.Peek(len(proxyProtocolV1Prefix))
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.Equal(prefix, proxyProtocolV1Prefix) {
+		return readProxyProtocolV1Header(r)
+	}
+
+	prefix, err = r.Peek(len(proxyProtocolV2Signature))
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.Equal(prefix, proxyProtocolV2Signature) {
+		return readProxyProtocolV2Header(r)
+	}
+
+	return nil, ErrProxyProtocolHeaderMissing
+}
+
+func readProxyProtocolV1Header(r *bufio.Reader) (net.Addr, error) {
+	var line []byte
+	for !bytes.HasSuffix(line, []byte("\r\n")) {
+		if len(line) >= proxyProtocolV1MaxLength {
+			return nil, fmt.Errorf("%w: header too long", ErrProxyProtocolHeaderInvalid)
+		}
+
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		line = append(line, b)
+	}
+
+	// PROXY TCP4|TCP6|UNKNOWN <source> <destination> <source port> <destination port>
+	parts := strings.Split(string(line[:len(line)-2]), " ")
+	if len(parts) >= 2 && parts[1] == "UNKNOWN" {
+		return nil, nil
+	}
+
+	if len(parts) != 6 {
+		return nil, fmt.Errorf("%w: %q", ErrProxyProtocolHeaderInvalid, line)
+	}
+
+	if parts[1] != "TCP4" && parts[1] != "TCP6" {
+		return nil, fmt.Errorf("%w: unsupported protocol %q", ErrProxyProtocolHeaderInvalid, parts[1])
+	}
+
+	ip := net.ParseIP(parts[2])
+	if ip == nil || (parts[1] == "TCP4" && ip.To4() == nil) {
+		return nil, fmt.Errorf("%w: invalid source address %q", ErrProxyProtocolHeaderInvalid, parts[2])
+	}
+
+	port, err := strconv.ParseUint(parts[4], 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid source port %q", ErrProxyProtocolHeaderInvalid, parts[4])
+	}
+
+	return &net.TCPAddr{
+		IP:   ip,
+		Port: int(port),
+	}, nil
+}
+
+func readProxyProtocolV2Header(r *bufio.Reader) (net.Addr, error) {
+	var header [16]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+
+	if version := header[12] >> 4; version != 2 {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrProxyProtocolHeaderInvalid, version)
+	}
+
+	payload := make([]byte, binary.BigEndian.Uint16(header[14:16]))
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, err
+	}
+
+	switch command := header[12] & 0x0f; command {
+	case 0x00:
+		// LOCAL: connection was established by the proxy itself.
+		return nil, nil
+	case 0x01:
+		// PROXY
+	default:
+		return nil, fmt.Errorf("%w: unsupported command %d", ErrProxyProtocolHeaderInvalid, command)
+	}
+
+	var ipLen int
+	switch family := header[13]; family {
+	case 0x11:
+		// TCP over IPv4.
+		ipLen = net.IPv4len
+	case 0x21:
+		// TCP over IPv6.
+		ipLen = net.IPv6len
+	default:
+		// Other address families are not supported, use the address of the proxy.
+		return nil, nil
+	}
+
+	if len(payload) < 2*ipLen+4 {
+		return nil, fmt.Errorf("%w: address too short", ErrProxyProtocolHeaderInvalid)
+	}
+
+	return &net.TCPAddr{
+		IP:   net.IP(bytes.Clone(payload[:ipLen])),
+		Port: int(binary.BigEndian.Uint16(payload[2*ipLen:])),
+	}, nil
+}
@@ -0,0 +1,184 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadProxyProtocolHeader(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	v2Header := func(command byte, family byte, payload []byte) string {
+		header := append([]byte{}, proxyProtocolV2Signature...)
+		header = append(header, 0x20|command, family)
+		header = binary.BigEndian.AppendUint16(header, uint16(len(payload)))
+		return string(append(header, payload...))
+	}
+	v4Payload := []byte{1, 2, 3, 4, 5, 6, 7, 8, 0x30, 0x39, 0x01, 0xbb}
+	v6Payload := make([]byte, 36)
+	copy(v6Payload, net.ParseIP("2001:db8::1"))
+	copy(v6Payload[16:], net.ParseIP("2001:db8::2"))
+	binary.BigEndian.PutUint16(v6Payload[32:], 12345)
+	binary.BigEndian.PutUint16(v6Payload[34:], 443)
+
+	valid := []struct {
+		header   string
+		expected string
+	}{
+		{"PROXY TCP4 1.2.3.4 5.6.7.8 12345 443\r\n", "1.2.3.4:12345"},
+		{"PROXY TCP6 2001:db8::1 2001:db8::2 12345 443\r\n", "[2001:db8::1]:12345"},
+		{"PROXY UNKNOWN\r\n", ""},
+		{"PROXY UNKNOWN ffff::1 ffff::2 1 2\r\n", ""},
+		{v2Header(0x01, 0x11, v4Payload), "1.2.3.4:12345"},
+		{v2Header(0x01, 0x21, v6Payload), "[2001:db8::1]:12345"},
+		// TLVs after the addresses are ignored.
+		{v2Header(0x01, 0x11, append(v4Payload, 0x04, 0x00, 0x01, 0x00)), "1.2.3.4:12345"},
+		// LOCAL connections and unsupported families use the address of the proxy.
+		{v2Header(0x00, 0x00, nil), ""},
+		{v2Header(0x01, 0x31, make([]byte, 216)), ""},
+	}
+	for _, tc := range valid {
+		reader := bufio.NewReader(strings.NewReader(tc.header + "GET / HTTP/1.1\r\n"))
+		addr, err := readProxyProtocolHeader(reader)
+		if assert.NoError(err, "failed for %q", tc.header) {
+			if tc.expected == "" {
+				assert.Nil(addr, "failed for %q", tc.header)
+			} else if assert.NotNil(addr, "failed for %q", tc.header) {
+				assert.Equal(tc.expected, addr.String())
+			}
+
+			// The remaining data is not consumed.
+			if rest, err := io.ReadAll(reader); assert.NoError(err) {
+				assert.Equal("GET / HTTP/1.1\r\n", string(rest))
+			}
+		}
+	}
+
+	invalid := []string{
+		"GET / HTTP/1.1\r\n",
+		"PROXY TCP4 1.2.3.4 5.6.7.8 12345\r\n",
+		"PROXY TCP4 2001:db8::1 5.6.7.8 12345 443\r\n",
+		"PROXY TCP4 1.2.3.4 5.6.7.8 123456 443\r\n",
+		"PROXY UDP4 1.2.3.4 5.6.7.8 12345 443\r\n",
+		"PROXY TCP4 " + strings.Repeat("1", 200) + "\r\n",
+		v2Header(0x02, 0x11, v4Payload),
+		v2Header(0x01, 0x11, v4Payload[:8]),
+		// Truncated payload.
+		v2Header(0x01, 0x11, v4Payload)[:20],
+	}
+	for _, header := range invalid {
+		_, err := readProxyProtocolHeader(bufio.NewReader(strings.NewReader(header)))
+		assert.Error(err, "expected error for %q", header)
+	}
+}
+
+func TestProxyProtocolListener(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+
+	for _, tc := range []struct {
+		name     string
+		trusted  string
+		header   string
+		expected string
+	}{
+		{"trusted", "127.0.0.1", "PROXY TCP4 1.2.3.4 127.0.0.1 12345 80\r\n", "1.2.3.4:12345"},
+		{"untrusted", "192.168.0.0/16", "", "127.0.0.1"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			require := require.New(t)
+			assert := assert.New(t)
+
+			trusted, err := ParseAllowedIps(tc.trusted)
+			require.NoError(err)
+
+			listener, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(err)
+			listener = NewProxyProtocolListener(listener, func() *AllowedIps {
+				return trusted
+			})
+			defer listener.Close()
+
+			client, err := net.Dial("tcp", listener.Addr().String())
+			require.NoError(err)
+			defer client.Close()
+
+			_, err = io.WriteString(client, tc.header+"Hello")
+			require.NoError(err)
+
+			conn, err := listener.Accept()
+			require.NoError(err)
+			defer conn.Close()
+
+			if tc.header == "" {
+				host, _, err := net.SplitHostPort(conn.RemoteAddr().String())
+				require.NoError(err)
+				assert.Equal(tc.expected, host)
+			} else {
+				assert.Equal(tc.expected, conn.RemoteAddr().String())
+			}
+			buf := make([]byte, 5)
+			if _, err := io.ReadFull(conn, buf); assert.NoError(err) {
+				assert.Equal("Hello", string(buf))
+			}
+		})
+	}
+}
+
+func TestProxyProtocolListenerMissingHeader(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	listener = NewProxyProtocolListener(listener, func() *AllowedIps {
+		return DefaultTrustedProxies
+	})
+	defer listener.Close()
+
+	client, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(err)
+	defer client.Close()
+
+	_, err = io.WriteString(client, "GET / HTTP/1.1\r\n")
+	require.NoError(err)
+
+	conn, err := listener.Accept()
+	require.NoError(err)
+	defer conn.Close()
+
+	_, err = conn.Read(make([]byte, 10))
+	assert.ErrorIs(err, ErrProxyProtocolHeaderMissing)
+}
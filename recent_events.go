@@ -0,0 +1,145 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/dlintw/goconf"
+)
+
+const (
+	defaultMaxRecentEvents = 1000
+)
+
+var (
+	recentEvents = NewRecentEvents(defaultMaxRecentEvents)
+)
+
+// RecentEvents keeps the most recent significant events (errors, connection
+// changes, bans, ...) in a ring buffer, so they can be queried by
+// administrators without having to check the logs.
+type RecentEvents struct {
+	mu     sync.Mutex
+	events []RecentEvent
+	// Index of the oldest event if the buffer is full.
+	next int
+	full bool
+}
+
+func NewRecentEvents(size int) *RecentEvents {
+	return &RecentEvents{
+		events: make([]RecentEvent, 0, max(size, 0)),
+	}
+}
+
+func (e *RecentEvents) load(config *goconf.ConfigFile) {
+	size, _ := config.GetInt("stats", "maxrecentevents")
+	if size <= 0 {
+		size = defaultMaxRecentEvents
+	}
+
+	e.SetSize(size)
+}
+
+func (e *RecentEvents) getLocked() []RecentEvent {
+	if !e.full {
+		result := make([]RecentEvent, len(e.events))
+		copy(result, e.events)
+		return result
+	}
+
+	result := make([]RecentEvent, 0, len(e.events))
+	result = append(result, e.events[e.next:]...)
+	result = append(result, e.events[:e.next]...)
+	return result
+}
+
+// SetSize changes the maximum number of events, keeping the newest events.
+func (e *RecentEvents) SetSize(size int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if size == cap(e.events) {
+		return
+	}
+
+	events := e.getLocked()
+	if len(events) > size {
+		events = events[len(events)-size:]
+	}
+	e.events = append(make([]RecentEvent, 0, size), events...)
+	e.next = 0
+	e.full = size > 0 && len(e.events) == size
+}
+
+func (e *RecentEvents) Add(event RecentEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	size := cap(e.events)
+	if size == 0 {
+		return
+	}
+
+	if !e.full {
+		e.events = append(e.events, event)
+		e.full = len(e.events) == size
+		return
+	}
+
+	e.events[e.next] = event
+	e.next = (e.next + 1) % size
+}
+
+// Get returns the events that happened after "since", oldest first. If "types"
+// are given, only events of these types are returned.
+func (e *RecentEvents) Get(since time.Time, types ...RecentEventType) []RecentEvent {
+	e.mu.Lock()
+	events := e.getLocked()
+	e.mu.Unlock()
+
+	result := events[:0]
+	for _, event := range events {
+		if !event.Time.After(since) {
+			continue
+		}
+
+		if len(types) > 0 && !slices.Contains(types, event.Type) {
+			continue
+		}
+
+		result = append(result, event)
+	}
+	return result
+}
+
+func recordEvent(eventType RecentEventType, subsystem LogSubsystem, message string) {
+	recentEvents.Add(RecentEvent{
+		Time:      time.Now(),
+		Type:      eventType,
+		Subsystem: string(subsystem),
+		Message:   message,
+	})
+}
@@ -0,0 +1,108 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dlintw/goconf"
+	"github.com/stretchr/testify/assert"
+)
+
+func getRecentEventMessages(events []RecentEvent) []string {
+	var result []string
+	for _, event := range events {
+		result = append(result, event.Message)
+	}
+	return result
+}
+
+func TestRecentEvents(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	events := NewRecentEvents(3)
+	start := time.Now()
+	assert.Empty(events.Get(time.Time{}))
+
+	for i := range 5 {
+		events.Add(RecentEvent{
+			Time:    start.Add(time.Duration(i) * time.Second),
+			Type:    RecentEventTypeError,
+			Message: fmt.Sprintf("event %d", i),
+		})
+	}
+
+	// Only the newest events are kept.
+	assert.Equal([]string{"event 2", "event 3", "event 4"}, getRecentEventMessages(events.Get(time.Time{})))
+	assert.Equal([]string{"event 4"}, getRecentEventMessages(events.Get(start.Add(3*time.Second))))
+
+	events.Add(RecentEvent{
+		Time:    start.Add(5 * time.Second),
+		Type:    RecentEventTypeBan,
+		Message: "event 5",
+	})
+	assert.Equal([]string{"event 5"}, getRecentEventMessages(events.Get(time.Time{}, RecentEventTypeBan)))
+	assert.Equal([]string{"event 3", "event 4", "event 5"}, getRecentEventMessages(events.Get(time.Time{}, RecentEventTypeBan, RecentEventTypeError)))
+
+	events.SetSize(2)
+	assert.Equal([]string{"event 4", "event 5"}, getRecentEventMessages(events.Get(time.Time{})))
+
+	events.SetSize(4)
+	events.Add(RecentEvent{
+		Time:    start.Add(6 * time.Second),
+		Type:    RecentEventTypeError,
+		Message: "event 6",
+	})
+	assert.Equal([]string{"event 4", "event 5", "event 6"}, getRecentEventMessages(events.Get(time.Time{})))
+
+	config := goconf.NewConfigFile()
+	config.AddOption("stats", "maxrecentevents", "1")
+	events.load(config)
+	assert.Equal([]string{"event 6"}, getRecentEventMessages(events.Get(time.Time{})))
+}
+
+func TestRecentEvents_Logger(t *testing.T) {
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	logger := NewLogger(LogSubsystemBackend)
+	start := time.Now()
+
+	logger.Errorf("Test error %s", t.Name())
+	// Events are recorded even if the level is disabled.
+	logger.Eventf(RecentEventTypeConnection, slog.LevelDebug, "Test connection %s", t.Name())
+	logger.Infof("Test info %s", t.Name())
+
+	var found []RecentEvent
+	for _, event := range recentEvents.Get(start.Add(-time.Nanosecond)) {
+		if event.Subsystem == string(LogSubsystemBackend) && strings.HasSuffix(event.Message, t.Name()) {
+			found = append(found, event)
+		}
+	}
+	if assert.Len(found, 2) {
+		assert.Equal(RecentEventTypeError, found[0].Type)
+		assert.Equal(RecentEventTypeConnection, found[1].Type)
+	}
+}
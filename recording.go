@@ -0,0 +1,567 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dlintw/goconf"
+)
+
+const (
+	// Time to wait for a recording client to respond to a request.
+	recordingRequestTimeout = 30 * time.Second
+
+	// Recordings are moved to a different client if no heartbeat was received
+	// in this interval.
+	defaultRecordingHeartbeatTimeout = 30 * time.Second
+)
+
+var (
+	ErrRecordingActive       = NewError("recording_active", "The room is already being recorded.")
+	ErrRecordingNotActive    = NewError("recording_not_active", "The room is not being recorded.")
+	ErrNoRecordingClient     = NewError("no_client_available", "No available client found to start recording.")
+	ErrRecordingPaused       = NewError("recording_paused", "The recording is already paused.")
+	ErrRecordingNotPaused    = NewError("recording_not_paused", "The recording is not paused.")
+	ErrRecordingNotAvailable = NewError("recording_not_available", "Recording is not available on this server.")
+)
+
+type recordingState struct {
+	roomId     string
+	backend    *Backend
+	backendUrl string
+	options    json.RawMessage
+
+	// Will be nil while the recording is being started or moved to a
+	// different client.
+	session       *ClientSession
+	lastHeartbeat time.Time
+
+	paused atomic.Bool
+}
+
+func (r *recordingState) status() RecordingStatus {
+	if r.paused.Load() {
+		return RecordingStatusPaused
+	}
+	return RecordingStatusStarted
+}
+
+// RecordingManager keeps track of the internal clients that support recording
+// rooms and of the rooms that are currently being recorded.
+type RecordingManager struct {
+	hub *Hub
+
+	heartbeatTimeout atomic.Int64
+
+	mu sync.Mutex
+	// Internal clients that support recording.
+	sessions map[*ClientSession]bool
+	// Active recordings by backend and room id.
+	recordings map[string]*recordingState
+}
+
+func NewRecordingManager(hub *Hub, config *goconf.ConfigFile) *RecordingManager {
+	result := &RecordingManager{
+		hub:        hub,
+		sessions:   make(map[*ClientSession]bool),
+		recordings: make(map[string]*recordingState),
+	}
+	result.Reload(config)
+	return result
+}
+
+func (m *RecordingManager) Reload(config *goconf.ConfigFile) {
+	timeout := defaultRecordingHeartbeatTimeout
+	if value, _ := config.GetInt("recording", "heartbeattimeout"); value > 0 {
+		timeout = time.Duration(value) * time.Second
+	}
+	if previous := time.Duration(m.heartbeatTimeout.Swap(int64(timeout))); previous != 0 && previous != timeout {
+		hubLog.Infof("Changed recording heartbeat timeout from %s to %s", previous, timeout)
+	}
+}
+
+func (m *RecordingManager) getHeartbeatTimeout() time.Duration {
+	return time.Duration(m.heartbeatTimeout.Load())
+}
+
+// mcuSupportsRecording returns true if recording clients can subscribe to the
+// streams published to the MCU. The LiveKit MCU only supports publishing.
+func mcuSupportsRecording(mcu Mcu) bool {
+	if mcu == nil {
+		return false
+	}
+
+	_, isLivekit := mcu.(*mcuLivekit)
+	return !isLivekit
+}
+
+func getRecordingKey(roomId string, backend *Backend) string {
+	return backend.Id() + "|" + roomId
+}
+
+// AddSession registers an internal client that can record rooms.
+func (m *RecordingManager) AddSession(session *ClientSession) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sessions[session] = true
+}
+
+// RemoveSession unregisters a recording client. Rooms that were recorded by
+// the client will be moved to a different client.
+func (m *RecordingManager) RemoveSession(session *ClientSession) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.sessions[session] {
+		return
+	}
+
+	delete(m.sessions, session)
+	for _, r := range m.recordings {
+		if r.session == session {
+			hubLog.Warnf("Recording client %s disconnected while recording room %s", session.PublicId(), r.roomId)
+			r.session = nil
+			go m.failover(r, session)
+		}
+	}
+}
+
+// GetStatus returns the status of the recording of the given room, or an empty
+// status if the room is not being recorded.
+func (m *RecordingManager) GetStatus(roomId string, backend *Backend) RecordingStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	r, found := m.recordings[getRecordingKey(roomId, backend)]
+	if !found {
+		return ""
+	}
+	return r.status()
+}
+
+// getCandidates returns the recording clients of the backend, ordered by the
+// number of rooms they are currently recording.
+func (m *RecordingManager) getCandidates(backend *Backend, exclude *ClientSession) []*ClientSession {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	active := make(map[*ClientSession]int)
+	for _, r := range m.recordings {
+		if r.session != nil {
+			active[r.session]++
+		}
+	}
+
+	var result []*ClientSession
+	for session := range m.sessions {
+		if session == exclude || !backend.HasUrl(session.BackendUrl()) {
+			continue
+		}
+
+		if session.GetClient() != nil {
+			result = append(result, session)
+		}
+	}
+	slices.SortStableFunc(result, func(a, b *ClientSession) int {
+		return active[a] - active[b]
+	})
+	return result
+}
+
+func (m *RecordingManager) sendRequest(ctx context.Context, session *ClientSession, request *InternalServerRecordingRequest) error {
+	id := newRandomString(32)
+	msg := &ServerMessage{
+		Id:   id,
+		Type: "internal",
+		Internal: &InternalServerMessage{
+			Type:      "recording",
+			Recording: request,
+		},
+	}
+
+	subCtx, cancel := context.WithTimeout(ctx, recordingRequestTimeout)
+	defer cancel()
+
+	var response atomic.Pointer[RecordingInternalClientMessage]
+
+	session.HandleResponse(id, func(message *ClientMessage) bool {
+		if message.Internal == nil || message.Internal.Recording == nil {
+			return false
+		}
+
+		response.Store(message.Internal.Recording)
+		cancel()
+		return true
+	})
+	defer session.ClearResponseHandler(id)
+
+	if !session.SendMessage(msg) {
+		return NewError("error_notify", "Could not send recording request.")
+	}
+
+	<-subCtx.Done()
+	if err := subCtx.Err(); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return NewError("timeout", "Timeout while waiting for recording response.")
+		} else if errors.Is(err, context.Canceled) && errors.Is(ctx.Err(), context.Canceled) {
+			// Upstream request was cancelled.
+			return err
+		}
+	}
+
+	recording := response.Load()
+	if recording == nil {
+		return NewError("error_notify", "No recording response received.")
+	}
+
+	switch recording.Type {
+	case "error":
+		return recording.Error
+	case "status":
+		expected := RecordingStatusStarted
+		switch request.Action {
+		case RecordingActionStart:
+			if request.Paused {
+				expected = RecordingStatusPaused
+			}
+		case RecordingActionStop:
+			expected = RecordingStatusStopped
+		case RecordingActionPause:
+			expected = RecordingStatusPaused
+		}
+		if recording.Status != expected {
+			return NewError("unsupported_status", fmt.Sprintf("Unsupported recording status received: %+v", recording))
+		}
+		return nil
+	default:
+		return NewError("unsupported_type", fmt.Sprintf("Unsupported recording type received: %+v", recording))
+	}
+}
+
+func (m *RecordingManager) newRequest(r *recordingState, action string) *InternalServerRecordingRequest {
+	return &InternalServerRecordingRequest{
+		Action:    action,
+		RoomId:    r.roomId,
+		Backend:   r.backendUrl,
+		Heartbeat: int(m.getHeartbeatTimeout() / time.Second / 2),
+		Paused:    action == RecordingActionStart && r.paused.Load(),
+		Options:   r.options,
+	}
+}
+
+// startInSessions tries to start the recording in the passed sessions and
+// returns the session that accepted the recording.
+func (m *RecordingManager) startInSessions(ctx context.Context, r *recordingState, sessions []*ClientSession) (*ClientSession, error) {
+	var sessionError error
+	for _, session := range sessions {
+		if ctx.Err() != nil {
+			// Upstream request was cancelled.
+			return nil, ctx.Err()
+		}
+
+		if err := m.sendRequest(ctx, session, m.newRequest(r, RecordingActionStart)); err != nil {
+			hubLog.Errorf("Error starting recording of room %s in session %s: %s", r.roomId, session.PublicId(), err)
+			if sessionError == nil {
+				sessionError = err
+			}
+			continue
+		}
+
+		return session, nil
+	}
+
+	if sessionError == nil {
+		sessionError = ErrNoRecordingClient
+	}
+	return nil, sessionError
+}
+
+// Start records the room using one of the available recording clients.
+func (m *RecordingManager) Start(ctx context.Context, roomId string, backend *Backend, backendUrl string, options json.RawMessage) error {
+	key := getRecordingKey(roomId, backend)
+	r := &recordingState{
+		roomId:     roomId,
+		backend:    backend,
+		backendUrl: backendUrl,
+		options:    options,
+	}
+
+	m.mu.Lock()
+	if _, found := m.recordings[key]; found {
+		m.mu.Unlock()
+		return ErrRecordingActive
+	}
+	m.recordings[key] = r
+	m.mu.Unlock()
+
+	session, err := m.startInSessions(ctx, r, m.getCandidates(backend, nil))
+
+	m.mu.Lock()
+	if err != nil {
+		delete(m.recordings, key)
+		m.mu.Unlock()
+		return err
+	}
+
+	r.lastHeartbeat = time.Now()
+	if !m.sessions[session] {
+		// The client disconnected while the recording was started.
+		go m.failover(r, session)
+	} else {
+		r.session = session
+	}
+	m.mu.Unlock()
+
+	hubLog.Infof("Session %s started recording room %s", session.PublicId(), roomId)
+	statsHubRecordingsCurrent.WithLabelValues(statsBackendLabel(backend)).Inc()
+	m.notifyRoom(r, RecordingStatusStarted)
+	return nil
+}
+
+// Stop stops recording the room.
+func (m *RecordingManager) Stop(ctx context.Context, roomId string, backend *Backend) error {
+	key := getRecordingKey(roomId, backend)
+
+	m.mu.Lock()
+	r, found := m.recordings[key]
+	if !found || r.lastHeartbeat.IsZero() {
+		// Not recording or recording is still being started.
+		m.mu.Unlock()
+		return ErrRecordingNotActive
+	}
+	delete(m.recordings, key)
+	session := r.session
+	m.mu.Unlock()
+
+	statsHubRecordingsCurrent.WithLabelValues(statsBackendLabel(backend)).Dec()
+	if session != nil {
+		if err := m.sendRequest(ctx, session, m.newRequest(r, RecordingActionStop)); err != nil {
+			hubLog.Errorf("Error stopping recording of room %s in session %s: %s", roomId, session.PublicId(), err)
+		}
+	}
+
+	hubLog.Infof("Stopped recording room %s", roomId)
+	m.notifyRoom(r, RecordingStatusStopped)
+	return nil
+}
+
+// Pause pauses recording the room.
+func (m *RecordingManager) Pause(ctx context.Context, roomId string, backend *Backend) error {
+	return m.setPaused(ctx, roomId, backend, true)
+}
+
+// Resume continues recording a paused room.
+func (m *RecordingManager) Resume(ctx context.Context, roomId string, backend *Backend) error {
+	return m.setPaused(ctx, roomId, backend, false)
+}
+
+func (m *RecordingManager) setPaused(ctx context.Context, roomId string, backend *Backend, paused bool) error {
+	key := getRecordingKey(roomId, backend)
+
+	m.mu.Lock()
+	r, found := m.recordings[key]
+	if !found || r.session == nil {
+		// Not recording or recording is being started or moved.
+		m.mu.Unlock()
+		return ErrRecordingNotActive
+	} else if r.paused.Load() == paused {
+		m.mu.Unlock()
+		if paused {
+			return ErrRecordingPaused
+		}
+		return ErrRecordingNotPaused
+	}
+	session := r.session
+	m.mu.Unlock()
+
+	action := RecordingActionResume
+	status := RecordingStatusStarted
+	if paused {
+		action = RecordingActionPause
+		status = RecordingStatusPaused
+	}
+	if err := m.sendRequest(ctx, session, m.newRequest(r, action)); err != nil {
+		hubLog.Errorf("Error changing recording of room %s in session %s to %s: %s", roomId, session.PublicId(), status, err)
+		return err
+	}
+
+	m.mu.Lock()
+	if m.recordings[key] != r {
+		// Recording was stopped in the meantime.
+		m.mu.Unlock()
+		return ErrRecordingNotActive
+	}
+	changed := r.paused.Swap(paused) != paused
+	m.mu.Unlock()
+
+	if changed {
+		hubLog.Infof("Recording of room %s %s", roomId, status)
+		m.notifyRoom(r, status)
+	}
+	return nil
+}
+
+// failover moves a recording to a different client after the previous client
+// disconnected or stopped sending heartbeats.
+func (m *RecordingManager) failover(r *recordingState, failed *ClientSession) {
+	backendLabel := statsBackendLabel(r.backend)
+	ctx, cancel := context.WithTimeout(context.Background(), recordingRequestTimeout)
+	defer cancel()
+
+	session, err := m.startInSessions(ctx, r, m.getCandidates(r.backend, failed))
+
+	m.mu.Lock()
+	key := getRecordingKey(r.roomId, r.backend)
+	if m.recordings[key] != r {
+		// Recording was stopped in the meantime.
+		m.mu.Unlock()
+		if session != nil {
+			go func() {
+				ctx, cancel := context.WithTimeout(context.Background(), recordingRequestTimeout)
+				defer cancel()
+
+				if err := m.sendRequest(ctx, session, m.newRequest(r, RecordingActionStop)); err != nil {
+					hubLog.Errorf("Error stopping recording of room %s in session %s: %s", r.roomId, session.PublicId(), err)
+				}
+			}()
+		}
+		return
+	}
+
+	if err != nil {
+		delete(m.recordings, key)
+		m.mu.Unlock()
+
+		hubLog.Errorf("Could not move recording of room %s to a different client: %s", r.roomId, err)
+		statsHubRecordingFailoversTotal.WithLabelValues(backendLabel, "failed").Inc()
+		statsHubRecordingsCurrent.WithLabelValues(backendLabel).Dec()
+		m.notifyRoom(r, RecordingStatusFailed)
+		return
+	}
+
+	r.session = session
+	r.lastHeartbeat = time.Now()
+	m.mu.Unlock()
+
+	hubLog.Infof("Moved recording of room %s from session %s to %s", r.roomId, failed.PublicId(), session.PublicId())
+	statsHubRecordingFailoversTotal.WithLabelValues(backendLabel, "success").Inc()
+}
+
+// ProcessMessage handles status and heartbeat messages of recording clients.
+func (m *RecordingManager) ProcessMessage(session *ClientSession, msg *RecordingInternalClientMessage) {
+	key := getRecordingKey(msg.RoomId, session.Backend())
+
+	m.mu.Lock()
+	r, found := m.recordings[key]
+	if !found || r.session != session {
+		m.mu.Unlock()
+		hubLog.Debugf("Ignore recording message %+v from %s for room that is not recorded by the session", msg, session.PublicId())
+		return
+	}
+
+	switch msg.Type {
+	case "heartbeat":
+		r.lastHeartbeat = time.Now()
+		m.mu.Unlock()
+	case "status":
+		if msg.Status == RecordingStatusStarted || msg.Status == RecordingStatusPaused {
+			// The client paused or resumed the recording itself.
+			paused := msg.Status == RecordingStatusPaused
+			changed := r.paused.Swap(paused) != paused
+			m.mu.Unlock()
+			if changed {
+				hubLog.Infof("Recording of room %s %s in session %s", r.roomId, msg.Status, session.PublicId())
+				m.notifyRoom(r, msg.Status)
+			}
+			return
+		}
+
+		delete(m.recordings, key)
+		m.mu.Unlock()
+
+		hubLog.Infof("Recording of room %s %s in session %s", r.roomId, msg.Status, session.PublicId())
+		statsHubRecordingsCurrent.WithLabelValues(statsBackendLabel(r.backend)).Dec()
+		m.notifyRoom(r, msg.Status)
+	default:
+		m.mu.Unlock()
+	}
+}
+
+// CheckHeartbeats moves recordings to a different client if no heartbeat was
+// received by the current client in time.
+func (m *RecordingManager) CheckHeartbeats(now time.Time) {
+	timeout := m.getHeartbeatTimeout()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, r := range m.recordings {
+		if r.session == nil || now.Sub(r.lastHeartbeat) <= timeout {
+			continue
+		}
+
+		failed := r.session
+		hubLog.Warnf("No heartbeat received from recording client %s for room %s in %s", failed.PublicId(), r.roomId, timeout)
+		r.session = nil
+		// Make sure the client no longer records the room if it's still alive.
+		failed.SendMessage(&ServerMessage{
+			Type: "internal",
+			Internal: &InternalServerMessage{
+				Type:      "recording",
+				Recording: m.newRequest(r, RecordingActionStop),
+			},
+		})
+		go m.failover(r, failed)
+	}
+}
+
+func newRecordingEventMessage(roomId string, status RecordingStatus) *ServerMessage {
+	return &ServerMessage{
+		Type: "event",
+		Event: &EventServerMessage{
+			Target: "room",
+			Type:   "recording",
+			Recording: &EventServerMessageRecording{
+				RoomId: roomId,
+				Status: status,
+			},
+		},
+	}
+}
+
+func (m *RecordingManager) notifyRoom(r *recordingState, status RecordingStatus) {
+	if err := m.hub.events.PublishRoomMessage(r.roomId, r.backend, &AsyncMessage{
+		Type:    "message",
+		Message: newRecordingEventMessage(r.roomId, status),
+	}); err != nil {
+		hubLog.Errorf("Error publishing recording status %s to room %s: %s", status, r.roomId, err)
+	}
+}
@@ -0,0 +1,492 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordingInternalClientMessage(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	valid := []*RecordingInternalClientMessage{
+		{Type: "error", Error: NewError("failed", "Recording failed.")},
+		{Type: "status", RoomId: "the-room", Status: RecordingStatusStarted},
+		{Type: "status", RoomId: "the-room", Status: RecordingStatusPaused},
+		{Type: "status", RoomId: "the-room", Status: RecordingStatusStopped},
+		{Type: "status", RoomId: "the-room", Status: RecordingStatusFailed},
+		{Type: "heartbeat", RoomId: "the-room"},
+	}
+	for _, msg := range valid {
+		assert.NoError(msg.CheckValid(), "expected %+v to be valid", msg)
+	}
+
+	invalid := []*RecordingInternalClientMessage{
+		{},
+		{Type: "error"},
+		{Type: "status", RoomId: "the-room"},
+		{Type: "status", RoomId: "the-room", Status: "unknown"},
+		{Type: "status", Status: RecordingStatusStarted},
+		{Type: "heartbeat"},
+	}
+	for _, msg := range invalid {
+		assert.Error(msg.CheckValid(), "expected %+v to be invalid", msg)
+	}
+}
+
+func TestRecordingClientMessage(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	for _, action := range []string{RecordingActionStart, RecordingActionStop, RecordingActionPause, RecordingActionResume} {
+		msg := &RecordingClientMessage{Action: action}
+		assert.NoError(msg.CheckValid(), "expected %+v to be valid", msg)
+	}
+
+	invalid := []*RecordingClientMessage{
+		{},
+		{Action: "unknown"},
+	}
+	for _, msg := range invalid {
+		assert.Error(msg.CheckValid(), "expected %+v to be invalid", msg)
+	}
+}
+
+// respondRecordingRequest waits for a recording request and responds with the
+// given status.
+func respondRecordingRequest(ctx context.Context, t *testing.T, client *TestClient, action string, status RecordingStatus) *InternalServerRecordingRequest {
+	assert := assert.New(t)
+	msg, ok := client.RunUntilMessage(ctx)
+	if !ok {
+		return nil
+	}
+
+	if !assert.Equal("internal", msg.Type) ||
+		!assert.NotNil(msg.Internal) ||
+		!assert.Equal("recording", msg.Internal.Type) ||
+		!assert.NotNil(msg.Internal.Recording) {
+		return nil
+	}
+
+	request := msg.Internal.Recording
+	assert.Equal(action, request.Action)
+	response := &ClientMessage{
+		Id:   msg.Id,
+		Type: "internal",
+		Internal: &InternalClientMessage{
+			Type: "recording",
+			Recording: &RecordingInternalClientMessage{
+				Type:   "status",
+				RoomId: request.RoomId,
+				Status: status,
+			},
+		},
+	}
+	assert.NoError(client.WriteJSON(response))
+	return request
+}
+
+func performRecordingRequest(t *testing.T, url string, action string) (int, *BackendServerRoomResponse) {
+	require := require.New(t)
+	msg := &BackendServerRoomRequest{
+		Type: "recording",
+		Recording: &BackendRoomRecordingRequest{
+			Action: action,
+		},
+	}
+
+	data, err := json.Marshal(msg)
+	require.NoError(err)
+	res, err := performBackendRequest(url, data)
+	require.NoError(err)
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	require.NoError(err)
+
+	var response BackendServerRoomResponse
+	require.NoError(json.Unmarshal(body, &response), "invalid response %s", string(body))
+	return res.StatusCode, &response
+}
+
+func checkMessageRecording(t *testing.T, msg *ServerMessage, roomId string, status RecordingStatus) {
+	assert := assert.New(t)
+	if checkMessageType(t, msg, "event") &&
+		assert.Equal("room", msg.Event.Target) &&
+		assert.Equal("recording", msg.Event.Type) &&
+		assert.NotNil(msg.Event.Recording) {
+		assert.Equal(roomId, msg.Event.Recording.RoomId)
+		assert.Equal(status, msg.Event.Recording.Status)
+	}
+}
+
+func TestBackendServer_RecordingNoClient(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	_, _, _, _, _, server := CreateBackendServerForTest(t)
+
+	status, response := performRecordingRequest(t, server.URL+"/api/v1/room/12345", "start")
+	assert.Equal(http.StatusNotFound, status)
+	if assert.NotNil(response.Recording) && assert.NotNil(response.Recording.Error) {
+		assert.Equal("no_client_available", response.Recording.Error.Code)
+	}
+
+	status, response = performRecordingRequest(t, server.URL+"/api/v1/room/12345", "stop")
+	assert.Equal(http.StatusNotFound, status)
+	if assert.NotNil(response.Recording) && assert.NotNil(response.Recording.Error) {
+		assert.Equal("recording_not_active", response.Recording.Error.Code)
+	}
+}
+
+func TestBackendServer_Recording(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	_, _, _, hub, _, server := CreateBackendServerForTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	recorder := NewTestClient(t, server, hub)
+	defer recorder.CloseWithBye()
+	require.NoError(recorder.SendHelloInternalWithFeatures([]string{ClientFeatureRecording}))
+	MustSucceed1(t, recorder.RunUntilHello, ctx)
+
+	roomId := "12345"
+	client, hello := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId)
+	roomMsg := MustSucceed2(t, client.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+	client.RunUntilJoined(ctx, hello.Hello)
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		if request := respondRecordingRequest(ctx, t, recorder, "start", RecordingStatusStarted); request != nil {
+			assert.Equal(roomId, request.RoomId)
+			assert.Equal(server.URL+"/", request.Backend)
+			assert.Equal(int(defaultRecordingHeartbeatTimeout/time.Second/2), request.Heartbeat)
+		}
+	}()
+
+	status, response := performRecordingRequest(t, server.URL+"/api/v1/room/"+roomId, "start")
+	<-stopped
+	require.Equal(http.StatusOK, status)
+	if assert.NotNil(response.Recording) {
+		assert.Nil(response.Recording.Error)
+		assert.Equal(RecordingStatusStarted, response.Recording.Status)
+	}
+
+	checkMessageRecording(t, MustSucceed1(t, client.RunUntilMessage, ctx), roomId, RecordingStatusStarted)
+
+	// Sessions joining later will be notified about the active recording.
+	client2, hello2 := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId+"2")
+	roomMsg = MustSucceed2(t, client2.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+	if _, additional, ok := client2.RunUntilJoinedAndReturn(ctx, hello.Hello, hello2.Hello); ok {
+		if assert.Len(additional, 1) {
+			checkMessageRecording(t, additional[0], roomId, RecordingStatusStarted)
+		}
+	}
+	client.RunUntilJoined(ctx, hello2.Hello)
+
+	status, response = performRecordingRequest(t, server.URL+"/api/v1/room/"+roomId, "start")
+	assert.Equal(http.StatusConflict, status)
+	if assert.NotNil(response.Recording) && assert.NotNil(response.Recording.Error) {
+		assert.Equal("recording_active", response.Recording.Error.Code)
+	}
+
+	// Heartbeats keep the recording on the client.
+	require.NoError(recorder.WriteJSON(&ClientMessage{
+		Type: "internal",
+		Internal: &InternalClientMessage{
+			Type: "recording",
+			Recording: &RecordingInternalClientMessage{
+				Type:   "heartbeat",
+				RoomId: roomId,
+			},
+		},
+	}))
+
+	stopped = make(chan struct{})
+	go func() {
+		defer close(stopped)
+		respondRecordingRequest(ctx, t, recorder, "stop", RecordingStatusStopped)
+	}()
+
+	status, response = performRecordingRequest(t, server.URL+"/api/v1/room/"+roomId, "stop")
+	<-stopped
+	require.Equal(http.StatusOK, status)
+	if assert.NotNil(response.Recording) {
+		assert.Equal(RecordingStatusStopped, response.Recording.Status)
+	}
+
+	checkMessageRecording(t, MustSucceed1(t, client.RunUntilMessage, ctx), roomId, RecordingStatusStopped)
+	checkMessageRecording(t, MustSucceed1(t, client2.RunUntilMessage, ctx), roomId, RecordingStatusStopped)
+}
+
+func TestBackendServer_RecordingFailover(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	_, _, _, hub, _, server := CreateBackendServerForTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	recorder1 := NewTestClient(t, server, hub)
+	defer recorder1.CloseWithBye()
+	require.NoError(recorder1.SendHelloInternalWithFeatures([]string{ClientFeatureRecording}))
+	MustSucceed1(t, recorder1.RunUntilHello, ctx)
+
+	roomId := "12345"
+	client, hello := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId)
+	roomMsg := MustSucceed2(t, client.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+	client.RunUntilJoined(ctx, hello.Hello)
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		respondRecordingRequest(ctx, t, recorder1, "start", RecordingStatusStarted)
+	}()
+
+	status, _ := performRecordingRequest(t, server.URL+"/api/v1/room/"+roomId, "start")
+	<-stopped
+	require.Equal(http.StatusOK, status)
+	checkMessageRecording(t, MustSucceed1(t, client.RunUntilMessage, ctx), roomId, RecordingStatusStarted)
+
+	recorder2 := NewTestClient(t, server, hub)
+	defer recorder2.CloseWithBye()
+	require.NoError(recorder2.SendHelloInternalWithFeatures([]string{ClientFeatureRecording}))
+	MustSucceed1(t, recorder2.RunUntilHello, ctx)
+
+	// The recording is moved to the second client if the first one disconnects.
+	recorder1.CloseWithBye()
+	if request := respondRecordingRequest(ctx, t, recorder2, "start", RecordingStatusStarted); request != nil {
+		assert.Equal(roomId, request.RoomId)
+	}
+
+	// Missing heartbeats also trigger a failover. No other client is available,
+	// so the participants are notified that the recording failed.
+	assert.Eventually(func() bool {
+		hub.recording.mu.Lock()
+		defer hub.recording.mu.Unlock()
+		for _, r := range hub.recording.recordings {
+			return r.session != nil
+		}
+		return false
+	}, testTimeout, time.Millisecond)
+	hub.recording.CheckHeartbeats(time.Now().Add(2 * defaultRecordingHeartbeatTimeout))
+
+	if msg, ok := recorder2.RunUntilMessage(ctx); ok && checkMessageType(t, msg, "internal") {
+		if assert.NotNil(msg.Internal.Recording) {
+			assert.Equal("stop", msg.Internal.Recording.Action)
+		}
+	}
+
+	checkMessageRecording(t, MustSucceed1(t, client.RunUntilMessage, ctx), roomId, RecordingStatusFailed)
+	hub.recording.mu.Lock()
+	defer hub.recording.mu.Unlock()
+	assert.Empty(hub.recording.recordings)
+}
+
+func TestBackendServer_RecordingPause(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	_, _, _, hub, _, server := CreateBackendServerForTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	recorder := NewTestClient(t, server, hub)
+	defer recorder.CloseWithBye()
+	require.NoError(recorder.SendHelloInternalWithFeatures([]string{ClientFeatureRecording}))
+	MustSucceed1(t, recorder.RunUntilHello, ctx)
+
+	roomId := "12345"
+	client, hello := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId)
+	roomMsg := MustSucceed2(t, client.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+	client.RunUntilJoined(ctx, hello.Hello)
+
+	status, _ := performRecordingRequest(t, server.URL+"/api/v1/room/"+roomId, "pause")
+	assert.Equal(http.StatusNotFound, status)
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		respondRecordingRequest(ctx, t, recorder, "start", RecordingStatusStarted)
+	}()
+	status, _ = performRecordingRequest(t, server.URL+"/api/v1/room/"+roomId, "start")
+	<-stopped
+	require.Equal(http.StatusOK, status)
+	checkMessageRecording(t, MustSucceed1(t, client.RunUntilMessage, ctx), roomId, RecordingStatusStarted)
+
+	status, response := performRecordingRequest(t, server.URL+"/api/v1/room/"+roomId, "resume")
+	assert.Equal(http.StatusConflict, status)
+	if assert.NotNil(response.Recording) && assert.NotNil(response.Recording.Error) {
+		assert.Equal("recording_not_paused", response.Recording.Error.Code)
+	}
+
+	stopped = make(chan struct{})
+	go func() {
+		defer close(stopped)
+		respondRecordingRequest(ctx, t, recorder, "pause", RecordingStatusPaused)
+	}()
+	status, response = performRecordingRequest(t, server.URL+"/api/v1/room/"+roomId, "pause")
+	<-stopped
+	require.Equal(http.StatusOK, status)
+	if assert.NotNil(response.Recording) {
+		assert.Equal(RecordingStatusPaused, response.Recording.Status)
+	}
+	checkMessageRecording(t, MustSucceed1(t, client.RunUntilMessage, ctx), roomId, RecordingStatusPaused)
+
+	status, response = performRecordingRequest(t, server.URL+"/api/v1/room/"+roomId, "pause")
+	assert.Equal(http.StatusConflict, status)
+	if assert.NotNil(response.Recording) && assert.NotNil(response.Recording.Error) {
+		assert.Equal("recording_paused", response.Recording.Error.Code)
+	}
+
+	// Sessions joining later will be notified about the paused recording.
+	client2, hello2 := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId+"2")
+	roomMsg = MustSucceed2(t, client2.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+	if _, additional, ok := client2.RunUntilJoinedAndReturn(ctx, hello.Hello, hello2.Hello); ok {
+		if assert.Len(additional, 1) {
+			checkMessageRecording(t, additional[0], roomId, RecordingStatusPaused)
+		}
+	}
+	client.RunUntilJoined(ctx, hello2.Hello)
+
+	// The recording client can also resume the recording itself.
+	require.NoError(recorder.WriteJSON(&ClientMessage{
+		Type: "internal",
+		Internal: &InternalClientMessage{
+			Type: "recording",
+			Recording: &RecordingInternalClientMessage{
+				Type:   "status",
+				RoomId: roomId,
+				Status: RecordingStatusStarted,
+			},
+		},
+	}))
+	checkMessageRecording(t, MustSucceed1(t, client.RunUntilMessage, ctx), roomId, RecordingStatusStarted)
+	checkMessageRecording(t, MustSucceed1(t, client2.RunUntilMessage, ctx), roomId, RecordingStatusStarted)
+	assert.Equal(RecordingStatusStarted, hub.recording.GetStatus(roomId, hub.backend.GetBackend(mustParse(server.URL))))
+}
+
+func TestRecordingClientControl(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	_, _, _, hub, _, server := CreateBackendServerForTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	recorder := NewTestClient(t, server, hub)
+	defer recorder.CloseWithBye()
+	require.NoError(recorder.SendHelloInternalWithFeatures([]string{ClientFeatureRecording}))
+	MustSucceed1(t, recorder.RunUntilHello, ctx)
+
+	roomId := "12345"
+	client, hello := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId)
+	roomMsg := MustSucceed2(t, client.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+	client.RunUntilJoined(ctx, hello.Hello)
+
+	sendRecording := func(action string) {
+		require.NoError(client.WriteJSON(&ClientMessage{
+			Id:   action,
+			Type: "recording",
+			Recording: &RecordingClientMessage{
+				Action: action,
+			},
+		}))
+	}
+
+	// Recording is only available if an MCU is configured.
+	sendRecording(RecordingActionStart)
+	if err, ok := client.RunUntilError(ctx, ErrRecordingNotAvailable.Code); ok {
+		assert.NotNil(err)
+	}
+
+	mcu, err := NewTestMCU()
+	require.NoError(err)
+	require.NoError(mcu.Start(ctx))
+	defer mcu.Stop()
+	hub.SetMcu(mcu)
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		if request := respondRecordingRequest(ctx, t, recorder, "start", RecordingStatusStarted); request != nil {
+			assert.Equal(roomId, request.RoomId)
+			assert.Equal(server.URL+"/", request.Backend)
+		}
+	}()
+	sendRecording(RecordingActionStart)
+	<-stopped
+	checkMessageRecording(t, MustSucceed1(t, client.RunUntilMessage, ctx), roomId, RecordingStatusStarted)
+
+	sendRecording(RecordingActionStart)
+	client.RunUntilError(ctx, ErrRecordingActive.Code) // nolint
+
+	stopped = make(chan struct{})
+	go func() {
+		defer close(stopped)
+		respondRecordingRequest(ctx, t, recorder, "pause", RecordingStatusPaused)
+	}()
+	sendRecording(RecordingActionPause)
+	<-stopped
+	checkMessageRecording(t, MustSucceed1(t, client.RunUntilMessage, ctx), roomId, RecordingStatusPaused)
+
+	stopped = make(chan struct{})
+	go func() {
+		defer close(stopped)
+		respondRecordingRequest(ctx, t, recorder, "stop", RecordingStatusStopped)
+	}()
+	sendRecording(RecordingActionStop)
+	<-stopped
+	checkMessageRecording(t, MustSucceed1(t, client.RunUntilMessage, ctx), roomId, RecordingStatusStopped)
+
+	// Only sessions that may control the room can change the recording.
+	session := hub.GetSessionByPublicId(hello.Hello.SessionId).(*ClientSession)
+	require.NotNil(session)
+	session.SetPermissions([]Permission{PERMISSION_MAY_PUBLISH_MEDIA})
+	sendRecording(RecordingActionStart)
+	client.RunUntilError(ctx, "not_allowed") // nolint
+}
@@ -0,0 +1,595 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2017 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/url"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DefaultRedisUrl = "redis://localhost:6379"
+
+	redisDialTimeout      = 5 * time.Second
+	redisCommandTimeout   = 5 * time.Second
+	redisSubscribeTimeout = 5 * time.Second
+)
+
+var (
+	ErrRedisClosed = errors.New("redis client closed")
+)
+
+func init() {
+	RegisterRedisStats()
+}
+
+type redisError string
+
+func (e redisError) Error() string {
+	return string(e)
+}
+
+// redisConn is a connection to a Redis server using the RESP2 protocol.
+type redisConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+
+	writeMu sync.Mutex
+}
+
+func (c *redisConn) Close() error {
+	return c.conn.Close()
+}
+
+func (c *redisConn) writeCommand(args ...string) error {
+	size := 16
+	for _, arg := range args {
+		size += len(arg) + 16
+	}
+	buf := make([]byte, 0, size)
+	buf = append(buf, '*')
+	buf = strconv.AppendInt(buf, int64(len(args)), 10)
+	buf = append(buf, "\r\n"...)
+	for _, arg := range args {
+		buf = append(buf, '$')
+		buf = strconv.AppendInt(buf, int64(len(arg)), 10)
+		buf = append(buf, "\r\n"...)
+		buf = append(buf, arg...)
+		buf = append(buf, "\r\n"...)
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	if err := c.conn.SetWriteDeadline(time.Now().Add(redisCommandTimeout)); err != nil {
+		return err
+	}
+
+	_, err := c.conn.Write(buf)
+	return err
+}
+
+func (c *redisConn) readLine() (string, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return "", fmt.Errorf("invalid line received: %q", line)
+	}
+	return line[:len(line)-2], nil
+}
+
+// readReply returns the next reply from the server. Bulk strings are returned
+// as []byte, arrays as []any and errors as redisError.
+func (c *redisConn) readReply() (any, error) {
+	line, err := c.readLine()
+	if err != nil {
+		return nil, err
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return redisError(line[1:]), nil
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		} else if size < 0 {
+			return nil, nil
+		}
+
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(c.r, data); err != nil {
+			return nil, err
+		}
+		return data[:size], nil
+	case '*':
+		count, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		} else if count < 0 {
+			return nil, nil
+		}
+
+		result := make([]any, count)
+		for i := range count {
+			if result[i], err = c.readReply(); err != nil {
+				return nil, err
+			}
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("unsupported reply received: %q", line)
+	}
+}
+
+// do sends a command and waits for the reply. Must not be used for
+// connections in subscribe mode.
+func (c *redisConn) do(args ...string) (any, error) {
+	if err := c.conn.SetReadDeadline(time.Now().Add(redisCommandTimeout)); err != nil {
+		return nil, err
+	}
+	defer c.conn.SetReadDeadline(time.Time{}) // nolint
+
+	if err := c.writeCommand(args...); err != nil {
+		return nil, err
+	}
+
+	reply, err := c.readReply()
+	if err != nil {
+		return nil, err
+	} else if err, ok := reply.(redisError); ok {
+		return nil, err
+	}
+
+	return reply, nil
+}
+
+type RedisSubscription interface {
+	Unsubscribe() error
+}
+
+type redisSubscription struct {
+	client  *RedisClient
+	channel string
+	ch      chan []byte
+	closed  chan struct{}
+}
+
+func (s *redisSubscription) Unsubscribe() error {
+	return s.client.unsubscribe(s)
+}
+
+// RedisClient publishes messages and receives messages of subscribed channels
+// through the pub/sub functionality of a Redis server.
+type RedisClient struct {
+	url       *url.URL
+	closeCtx  context.Context
+	closeFunc context.CancelFunc
+	wg        sync.WaitGroup
+
+	commandMu   sync.Mutex
+	commandConn *redisConn
+
+	mu            sync.Mutex
+	subscribeConn *redisConn
+	subscriptions map[string]map[*redisSubscription]bool
+	pending       map[string][]chan struct{}
+
+	connected         atomic.Bool
+	onReconnectedFunc atomic.Pointer[func()]
+}
+
+func NewRedisClient(redisUrl string) (*RedisClient, error) {
+	u, err := url.Parse(redisUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	switch u.Scheme {
+	case "redis", "rediss":
+		if u.Port() == "" {
+			u.Host = net.JoinHostPort(u.Hostname(), "6379")
+		}
+	case "unix":
+	default:
+		return nil, fmt.Errorf("unsupported redis url: %s", removeURLCredentials(redisUrl))
+	}
+
+	closeCtx, closeFunc := context.WithCancel(context.Background())
+	client := &RedisClient{
+		url:       u,
+		closeCtx:  closeCtx,
+		closeFunc: closeFunc,
+
+		subscriptions: make(map[string]map[*redisSubscription]bool),
+		pending:       make(map[string][]chan struct{}),
+	}
+
+	backoff, err := NewExponentialBackoff(initialConnectInterval, maxConnectInterval)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// The initial connect must succeed, so we retry in the case of an error.
+	conn, err := client.connect(ctx)
+	for err != nil {
+		appLog.Errorf("Could not create connection to %s (%s), will retry in %s", client, err, backoff.NextWait())
+		backoff.Wait(ctx)
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("interrupted")
+		}
+
+		conn, err = client.connect(ctx)
+	}
+	appLog.Infof("Connection established to %s", client)
+
+	client.subscribeConn = conn
+	client.connected.Store(true)
+	client.wg.Add(1)
+	go client.run(conn)
+	return client, nil
+}
+
+func (c *RedisClient) String() string {
+	return removeURLCredentials(c.url.String())
+}
+
+func (c *RedisClient) IsConnected() bool {
+	return c.connected.Load()
+}
+
+func (c *RedisClient) connect(ctx context.Context) (*redisConn, error) {
+	ctx, cancel := context.WithTimeout(ctx, redisDialTimeout)
+	defer cancel()
+
+	var conn net.Conn
+	var err error
+	switch c.url.Scheme {
+	case "unix":
+		var dialer net.Dialer
+		conn, err = dialer.DialContext(ctx, "unix", c.url.Path)
+	case "rediss":
+		dialer := tls.Dialer{
+			Config: &tls.Config{
+				ServerName: c.url.Hostname(),
+			},
+		}
+		conn, err = dialer.DialContext(ctx, "tcp", c.url.Host)
+	default:
+		var dialer net.Dialer
+		conn, err = dialer.DialContext(ctx, "tcp", c.url.Host)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &redisConn{
+		conn: conn,
+		r:    bufio.NewReader(conn),
+	}
+	if user := c.url.User; user != nil {
+		var args []string
+		if password, found := user.Password(); found {
+			if username := user.Username(); username != "" {
+				args = []string{"AUTH", username, password}
+			} else {
+				args = []string{"AUTH", password}
+			}
+		} else {
+			args = []string{"AUTH", user.Username()}
+		}
+		if _, err := result.do(args...); err != nil {
+			result.Close()
+			return nil, fmt.Errorf("authentication failed: %w", err)
+		}
+	}
+	return result, nil
+}
+
+func (c *RedisClient) Close() {
+	c.closeFunc()
+
+	c.mu.Lock()
+	if c.subscribeConn != nil {
+		c.subscribeConn.Close()
+	}
+	c.mu.Unlock()
+
+	c.commandMu.Lock()
+	if c.commandConn != nil {
+		c.commandConn.Close()
+		c.commandConn = nil
+	}
+	c.commandMu.Unlock()
+
+	c.wg.Wait()
+}
+
+func (c *RedisClient) SetOnReconnected(f func()) {
+	c.onReconnectedFunc.Store(&f)
+}
+
+func (c *RedisClient) run(conn *redisConn) {
+	defer c.wg.Done()
+
+	backoff, err := NewExponentialBackoff(initialConnectInterval, maxConnectInterval)
+	if err != nil {
+		panic(err)
+	}
+
+	for {
+		c.processMessages(conn)
+
+		c.mu.Lock()
+		c.subscribeConn = nil
+		// Pending subscriptions will be sent again after reconnecting.
+		for _, waiters := range c.pending {
+			for _, ch := range waiters {
+				close(ch)
+			}
+		}
+		clear(c.pending)
+		c.mu.Unlock()
+		conn.Close()
+		c.connected.Store(false)
+		if c.closeCtx.Err() != nil {
+			return
+		}
+
+		appLog.Eventf(RecentEventTypeConnection, slog.LevelInfo, "Redis client disconnected from %s", c)
+		for {
+			conn, err = c.connect(c.closeCtx)
+			if err == nil {
+				break
+			} else if c.closeCtx.Err() != nil {
+				return
+			}
+
+			appLog.Errorf("Could not reconnect to %s (%s), will retry in %s", c, err, backoff.NextWait())
+			backoff.Wait(c.closeCtx)
+		}
+		backoff.Reset()
+
+		c.mu.Lock()
+		c.subscribeConn = conn
+		if len(c.subscriptions) > 0 {
+			args := make([]string, 0, len(c.subscriptions)+1)
+			args = append(args, "SUBSCRIBE")
+			for channel := range c.subscriptions {
+				args = append(args, channel)
+			}
+			if err := conn.writeCommand(args...); err != nil {
+				appLog.Errorf("Could not restore subscriptions on %s: %s", c, err)
+			}
+		}
+		c.mu.Unlock()
+		c.connected.Store(true)
+
+		appLog.Eventf(RecentEventTypeConnection, slog.LevelInfo, "Redis client reconnected to %s", c)
+		if f := c.onReconnectedFunc.Load(); f != nil {
+			(*f)()
+		}
+	}
+}
+
+func (c *RedisClient) processMessages(conn *redisConn) {
+	for {
+		reply, err := conn.readReply()
+		if err != nil {
+			if c.closeCtx.Err() == nil {
+				appLog.Errorf("Error reading from %s: %s", c, err)
+			}
+			return
+		}
+
+		values, ok := reply.([]any)
+		if !ok || len(values) < 3 {
+			appLog.Warnf("Received unsupported reply from %s: %+v", c, reply)
+			continue
+		}
+
+		kind, _ := values[0].([]byte)
+		channel, _ := values[1].([]byte)
+		switch string(kind) {
+		case "message":
+			data, _ := values[2].([]byte)
+			c.dispatch(string(channel), data)
+		case "subscribe":
+			c.mu.Lock()
+			waiters := c.pending[string(channel)]
+			delete(c.pending, string(channel))
+			c.mu.Unlock()
+			for _, ch := range waiters {
+				close(ch)
+			}
+		}
+	}
+}
+
+func (c *RedisClient) dispatch(channel string, data []byte) {
+	c.mu.Lock()
+	subscriptions := make([]*redisSubscription, 0, len(c.subscriptions[channel]))
+	for sub := range c.subscriptions[channel] {
+		subscriptions = append(subscriptions, sub)
+	}
+	c.mu.Unlock()
+
+	// Never block the reader, a stalled subscriber would delay the messages of
+	// all other channels.
+	for _, sub := range subscriptions {
+		select {
+		case sub.ch <- data:
+		case <-sub.closed:
+		default:
+			statsRedisDroppedMessagesTotal.Inc()
+			appLog.Warnf("Slow consumer for channel %s on %s, dropping message", channel, c)
+		}
+	}
+}
+
+// Subscribe sends messages of the given channel to ch until the subscription
+// is unsubscribed. It waits until the server confirmed the subscription.
+func (c *RedisClient) Subscribe(channel string, ch chan []byte) (RedisSubscription, error) {
+	sub := &redisSubscription{
+		client:  c,
+		channel: channel,
+		ch:      ch,
+		closed:  make(chan struct{}),
+	}
+
+	c.mu.Lock()
+	if c.closeCtx.Err() != nil {
+		c.mu.Unlock()
+		return nil, ErrRedisClosed
+	}
+
+	subscriptions, found := c.subscriptions[channel]
+	if !found {
+		subscriptions = make(map[*redisSubscription]bool)
+		c.subscriptions[channel] = subscriptions
+	}
+	subscriptions[sub] = true
+
+	var waiter chan struct{}
+	if conn := c.subscribeConn; conn != nil && !found {
+		waiter = make(chan struct{})
+		c.pending[channel] = append(c.pending[channel], waiter)
+		if err := conn.writeCommand("SUBSCRIBE", channel); err != nil {
+			// The subscription will be sent again after reconnecting.
+			appLog.Errorf("Could not subscribe to %s on %s: %s", channel, c, err)
+		}
+	}
+	c.mu.Unlock()
+
+	if waiter != nil {
+		select {
+		case <-waiter:
+		case <-time.After(redisSubscribeTimeout):
+			appLog.Warnf("Subscription to %s on %s was not confirmed", channel, c)
+		case <-c.closeCtx.Done():
+		}
+	}
+	return sub, nil
+}
+
+func (c *RedisClient) unsubscribe(sub *redisSubscription) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	subscriptions, found := c.subscriptions[sub.channel]
+	if !found || !subscriptions[sub] {
+		return nil
+	}
+
+	close(sub.closed)
+	delete(subscriptions, sub)
+	if len(subscriptions) > 0 {
+		return nil
+	}
+
+	delete(c.subscriptions, sub.channel)
+	if conn := c.subscribeConn; conn != nil {
+		return conn.writeCommand("UNSUBSCRIBE", sub.channel)
+	}
+	return nil
+}
+
+// do sends a command on the connection that is used for all commands except
+// the subscriptions.
+func (c *RedisClient) do(args ...string) (any, error) {
+	c.commandMu.Lock()
+	defer c.commandMu.Unlock()
+
+	if c.closeCtx.Err() != nil {
+		return nil, ErrRedisClosed
+	}
+
+	if c.commandConn == nil {
+		conn, err := c.connect(c.closeCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		c.commandConn = conn
+	}
+
+	reply, err := c.commandConn.do(args...)
+	if err != nil {
+		var redisErr redisError
+		if !errors.As(err, &redisErr) {
+			// Reconnect for the next command.
+			c.commandConn.Close()
+			c.commandConn = nil
+		}
+		return nil, err
+	}
+
+	return reply, nil
+}
+
+func (c *RedisClient) Publish(channel string, data []byte) error {
+	_, err := c.do("PUBLISH", channel, string(data))
+	return err
+}
+
+// Get returns the value of the key or nil if it doesn't exist.
+func (c *RedisClient) Get(key string) ([]byte, error) {
+	reply, err := c.do("GET", key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, _ := reply.([]byte)
+	return data, nil
+}
+
+func (c *RedisClient) Set(key string, value []byte) error {
+	_, err := c.do("SET", key, string(value))
+	return err
+}
+
+func (c *RedisClient) Delete(key string) error {
+	_, err := c.do("DEL", key)
+	return err
+}
@@ -0,0 +1,468 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testRedisServer implements the pub/sub commands of a Redis server.
+type testRedisServer struct {
+	t        *testing.T
+	listener net.Listener
+	password string
+
+	mu          sync.Mutex
+	conns       map[*testRedisConn]bool
+	subscribers map[string]map[*testRedisConn]bool
+	values      map[string]string
+}
+
+type testRedisConn struct {
+	*redisConn
+
+	channels map[string]bool
+}
+
+func (c *testRedisConn) writeReply(values ...any) error {
+	var buf []byte
+	if len(values) > 1 {
+		buf = append(buf, '*')
+		buf = strconv.AppendInt(buf, int64(len(values)), 10)
+		buf = append(buf, "\r\n"...)
+	}
+	for _, value := range values {
+		switch v := value.(type) {
+		case string:
+			buf = append(buf, '$')
+			buf = strconv.AppendInt(buf, int64(len(v)), 10)
+			buf = append(buf, "\r\n"...)
+			buf = append(buf, v...)
+			buf = append(buf, "\r\n"...)
+		case int:
+			buf = append(buf, ':')
+			buf = strconv.AppendInt(buf, int64(v), 10)
+			buf = append(buf, "\r\n"...)
+		case nil:
+			buf = append(buf, "$-1\r\n"...)
+		case redisError:
+			buf = append(buf, '-')
+			buf = append(buf, v...)
+			buf = append(buf, "\r\n"...)
+		default:
+			return fmt.Errorf("unsupported value %+v", value)
+		}
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+	_, err := c.conn.Write(buf)
+	return err
+}
+
+func startLocalRedisServer(t *testing.T) *testRedisServer {
+	t.Helper()
+	return startLocalRedisServerWithPassword(t, "")
+}
+
+func startLocalRedisServerWithPassword(t *testing.T, password string) *testRedisServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := &testRedisServer{
+		t:           t,
+		listener:    listener,
+		password:    password,
+		conns:       make(map[*testRedisConn]bool),
+		subscribers: make(map[string]map[*testRedisConn]bool),
+		values:      make(map[string]string),
+	}
+	go server.run()
+	t.Cleanup(func() {
+		listener.Close()
+		server.closeConnections()
+	})
+	return server
+}
+
+func (s *testRedisServer) URL() string {
+	if s.password != "" {
+		return "redis://:" + s.password + "@" + s.listener.Addr().String()
+	}
+
+	return "redis://" + s.listener.Addr().String()
+}
+
+func (s *testRedisServer) run() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		c := &testRedisConn{
+			redisConn: &redisConn{
+				conn: conn,
+				r:    bufio.NewReader(conn),
+			},
+			channels: make(map[string]bool),
+		}
+		s.mu.Lock()
+		s.conns[c] = true
+		s.mu.Unlock()
+		go s.processConnection(c)
+	}
+}
+
+func (s *testRedisServer) closeConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.conns {
+		c.Close()
+	}
+}
+
+func (s *testRedisServer) subscriberCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.subscribers)
+}
+
+func (s *testRedisServer) processConnection(c *testRedisConn) {
+	defer func() {
+		c.Close()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.conns, c)
+		for channel := range c.channels {
+			s.removeSubscriber(channel, c)
+		}
+	}()
+
+	authenticated := s.password == ""
+	for {
+		request, err := c.readReply()
+		if err != nil {
+			return
+		}
+
+		values, ok := request.([]any)
+		if !ok || len(values) == 0 {
+			c.writeReply(redisError("ERR invalid request")) // nolint
+			continue
+		}
+
+		args := make([]string, 0, len(values))
+		for _, v := range values {
+			arg, _ := v.([]byte)
+			args = append(args, string(arg))
+		}
+
+		if args[0] == "AUTH" {
+			if args[len(args)-1] == s.password {
+				authenticated = true
+				c.writeReply("OK") // nolint
+			} else {
+				c.writeReply(redisError("WRONGPASS invalid password")) // nolint
+			}
+			continue
+		} else if !authenticated {
+			c.writeReply(redisError("NOAUTH Authentication required.")) // nolint
+			continue
+		}
+
+		switch args[0] {
+		case "PUBLISH":
+			s.publish(args[1], args[2])
+			c.writeReply(1) // nolint
+		case "GET":
+			s.mu.Lock()
+			value, found := s.values[args[1]]
+			s.mu.Unlock()
+			if found {
+				c.writeReply(value) // nolint
+			} else {
+				c.writeReply(nil) // nolint
+			}
+		case "SET":
+			s.mu.Lock()
+			s.values[args[1]] = args[2]
+			s.mu.Unlock()
+			c.writeReply("OK") // nolint
+		case "DEL":
+			s.mu.Lock()
+			_, found := s.values[args[1]]
+			delete(s.values, args[1])
+			s.mu.Unlock()
+			if found {
+				c.writeReply(1) // nolint
+			} else {
+				c.writeReply(0) // nolint
+			}
+		case "SUBSCRIBE":
+			for _, channel := range args[1:] {
+				s.mu.Lock()
+				c.channels[channel] = true
+				subscribers, found := s.subscribers[channel]
+				if !found {
+					subscribers = make(map[*testRedisConn]bool)
+					s.subscribers[channel] = subscribers
+				}
+				subscribers[c] = true
+				s.mu.Unlock()
+				c.writeReply("subscribe", channel, len(c.channels)) // nolint
+			}
+		case "UNSUBSCRIBE":
+			for _, channel := range args[1:] {
+				s.mu.Lock()
+				delete(c.channels, channel)
+				s.removeSubscriber(channel, c)
+				s.mu.Unlock()
+				c.writeReply("unsubscribe", channel, len(c.channels)) // nolint
+			}
+		default:
+			c.writeReply(redisError("ERR unknown command '" + args[0] + "'")) // nolint
+		}
+	}
+}
+
+func (s *testRedisServer) removeSubscriber(channel string, c *testRedisConn) {
+	if subscribers, found := s.subscribers[channel]; found {
+		delete(subscribers, c)
+		if len(subscribers) == 0 {
+			delete(s.subscribers, channel)
+		}
+	}
+}
+
+func (s *testRedisServer) publish(channel string, data string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.subscribers[channel] {
+		c.writeReply("message", channel, data) // nolint
+	}
+}
+
+func CreateLocalRedisClientForTest(t *testing.T) (*testRedisServer, *RedisClient) {
+	t.Helper()
+	server := startLocalRedisServer(t)
+	client, err := NewRedisClient(server.URL())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		client.Close()
+	})
+	return server, client
+}
+
+func TestRedisClient_Subscribe(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	server, client := CreateLocalRedisClientForTest(t)
+
+	ch1 := make(chan []byte, 1)
+	sub1, err := client.Subscribe("foo", ch1)
+	require.NoError(err)
+	ch2 := make(chan []byte, 1)
+	sub2, err := client.Subscribe("foo", ch2)
+	require.NoError(err)
+	ch3 := make(chan []byte, 1)
+	sub3, err := client.Subscribe("bar", ch3)
+	require.NoError(err)
+	assert.Equal(2, server.subscriberCount())
+
+	require.NoError(client.Publish("foo", []byte("hello")))
+	assert.Equal("hello", string(<-ch1))
+	assert.Equal("hello", string(<-ch2))
+
+	require.NoError(sub1.Unsubscribe())
+	require.NoError(client.Publish("foo", []byte("world")))
+	assert.Equal("world", string(<-ch2))
+	assert.Empty(ch1)
+
+	require.NoError(client.Publish("bar", []byte("baz")))
+	assert.Equal("baz", string(<-ch3))
+
+	require.NoError(sub2.Unsubscribe())
+	require.NoError(sub3.Unsubscribe())
+	assert.Eventually(func() bool {
+		return server.subscriberCount() == 0
+	}, testTimeout, time.Millisecond)
+}
+
+func TestRedisClient_StalledSubscriber(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	_, client := CreateLocalRedisClientForTest(t)
+
+	// Nobody reads from the channel of the first subscriber.
+	stalled := make(chan []byte, 1)
+	_, err := client.Subscribe("foo", stalled)
+	require.NoError(err)
+	ch1 := make(chan []byte, 1)
+	_, err = client.Subscribe("foo", ch1)
+	require.NoError(err)
+	ch2 := make(chan []byte, 1)
+	_, err = client.Subscribe("bar", ch2)
+	require.NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	for i := range 10 {
+		require.NoError(client.Publish("foo", []byte(fmt.Sprintf("foo-%d", i))))
+		select {
+		case msg := <-ch1:
+			assert.Equal(fmt.Sprintf("foo-%d", i), string(msg))
+		case <-ctx.Done():
+			require.NoError(ctx.Err())
+		}
+
+		require.NoError(client.Publish("bar", []byte(fmt.Sprintf("bar-%d", i))))
+		select {
+		case msg := <-ch2:
+			assert.Equal(fmt.Sprintf("bar-%d", i), string(msg))
+		case <-ctx.Done():
+			require.NoError(ctx.Err())
+		}
+	}
+
+	// Only the first message could be delivered to the stalled subscriber.
+	if assert.Len(stalled, 1) {
+		assert.Equal("foo-0", string(<-stalled))
+	}
+}
+
+func TestRedisClient_Reconnect(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	server, client := CreateLocalRedisClientForTest(t)
+
+	var reconnected atomic.Int32
+	client.SetOnReconnected(func() {
+		reconnected.Add(1)
+	})
+
+	ch := make(chan []byte, 1)
+	_, err := client.Subscribe("foo", ch)
+	require.NoError(err)
+
+	server.closeConnections()
+	assert.Eventually(func() bool {
+		return reconnected.Load() == 1 && server.subscriberCount() == 1
+	}, testTimeout, time.Millisecond)
+	assert.True(client.IsConnected())
+
+	// The publishing connection is recreated if it was closed.
+	if err := client.Publish("foo", []byte("hello")); err != nil {
+		require.NoError(client.Publish("foo", []byte("hello")))
+	}
+	assert.Equal("hello", string(<-ch))
+}
+
+func TestRedisClient_Auth(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	server := startLocalRedisServerWithPassword(t, "secret")
+
+	client, err := NewRedisClient(server.URL())
+	require.NoError(err)
+	defer client.Close()
+	assert.NotContains(client.String(), "secret")
+
+	ch := make(chan []byte, 1)
+	_, err = client.Subscribe("foo", ch)
+	require.NoError(err)
+	require.NoError(client.Publish("foo", []byte("hello")))
+	assert.Equal("hello", string(<-ch))
+
+	// The initial connection is retried until it succeeds, so check the
+	// connection directly.
+	u, err := url.Parse("redis://:invalid@" + server.listener.Addr().String())
+	require.NoError(err)
+	client2 := &RedisClient{
+		url: u,
+	}
+	_, err = client2.connect(t.Context())
+	var redisErr redisError
+	assert.ErrorAs(err, &redisErr)
+}
+
+func TestRedisClient_InvalidUrl(t *testing.T) {
+	t.Parallel()
+	_, err := NewRedisClient("http://localhost")
+	assert.Error(t, err)
+}
+
+func TestRedisClient_Closed(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	_, client := CreateLocalRedisClientForTest(t)
+
+	client.Close()
+	assert.ErrorIs(client.Publish("foo", []byte("hello")), ErrRedisClosed)
+	_, err := client.Subscribe("foo", make(chan []byte))
+	assert.ErrorIs(err, ErrRedisClosed)
+}
+
+func TestRedisClient_Values(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	_, client := CreateLocalRedisClientForTest(t)
+
+	value, err := client.Get("foo")
+	require.NoError(err)
+	assert.Nil(value)
+
+	require.NoError(client.Set("foo", []byte("bar")))
+	value, err = client.Get("foo")
+	require.NoError(err)
+	assert.Equal("bar", string(value))
+
+	require.NoError(client.Delete("foo"))
+	value, err = client.Get("foo")
+	require.NoError(err)
+	assert.Nil(value)
+}
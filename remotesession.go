@@ -26,7 +26,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"sync/atomic"
 	"time"
 )
@@ -97,7 +96,7 @@ func (s *RemoteSession) OnProxyMessage(msg *ServerSessionMessage) error {
 
 func (s *RemoteSession) OnProxyClose(err error) {
 	if err != nil {
-		log.Printf("Proxy connection for session %s to %s was closed with error: %s", s.sessionId, s.remoteClient.Target(), err)
+		hubLog.Infof("Proxy connection for session %s to %s was closed with error: %s", s.sessionId, s.remoteClient.Target(), err)
 	}
 	s.Close()
 }
@@ -145,7 +144,7 @@ func (s *RemoteSession) OnClosed(client HandlerClient) {
 
 func (s *RemoteSession) OnMessageReceived(client HandlerClient, message []byte) {
 	if err := s.sendProxyMessage(message); err != nil {
-		log.Printf("Error sending %s to the proxy for session %s: %s", string(message), s.sessionId, err)
+		hubLog.Errorf("Error sending %s to the proxy for session %s: %s", string(message), s.sessionId, err)
 		s.Close()
 	}
 }
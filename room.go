@@ -26,9 +26,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"maps"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -69,6 +70,9 @@ type Room struct {
 
 	properties json.RawMessage
 
+	// Adds the ids of the room to all log messages.
+	log *Logger
+
 	closer   *Closer
 	mu       *sync.RWMutex
 	sessions map[PublicSessionId]Session
@@ -106,6 +110,11 @@ func NewRoom(roomId string, properties json.RawMessage, hub *Hub, events AsyncEv
 
 		properties: properties,
 
+		log: hubLog.With(
+			slog.String("room", roomId),
+			slog.String("backend", backend.Id()),
+		),
+
 		closer:   NewCloser(),
 		mu:       &sync.RWMutex{},
 		sessions: make(map[PublicSessionId]Session),
@@ -116,7 +125,7 @@ func NewRoom(roomId string, properties json.RawMessage, hub *Hub, events AsyncEv
 		roomSessionData:  make(map[PublicSessionId]*RoomSessionData),
 
 		statsRoomSessionsCurrent: statsRoomSessionsCurrent.MustCurryWith(prometheus.Labels{
-			"backend": backend.Id(),
+			"backend": statsBackendLabel(backend),
 			"room":    roomId,
 		}),
 
@@ -215,7 +224,7 @@ func (r *Room) ProcessBackendRoomRequest(message *AsyncMessage) {
 	case "asyncroom":
 		r.processBackendRoomRequestAsyncRoom(message.AsyncRoom)
 	default:
-		log.Printf("Unsupported backend room request with type %s in %s: %+v", message.Type, r.id, message)
+		r.log.Warnf("Unsupported backend room request with type %s in %s: %+v", message.Type, r.id, message)
 	}
 }
 
@@ -223,9 +232,9 @@ func (r *Room) processBackendRoomRequestRoom(message *BackendServerRoomRequest)
 	received := message.ReceivedTime
 	if last, found := r.lastRoomRequests[message.Type]; found && last > received {
 		if msg, err := json.Marshal(message); err == nil {
-			log.Printf("Ignore old backend room request for %s: %s", r.Id(), string(msg))
+			r.log.Warnf("Ignore old backend room request for %s: %s", r.Id(), string(msg))
 		} else {
-			log.Printf("Ignore old backend room request for %s: %+v", r.Id(), message)
+			r.log.Warnf("Ignore old backend room request for %s: %+v", r.Id(), message)
 		}
 		return
 	}
@@ -246,6 +255,8 @@ func (r *Room) processBackendRoomRequestRoom(message *BackendServerRoomRequest)
 		r.publishRoomMessage(message.Message)
 	case "switchto":
 		r.publishSwitchTo(message.SwitchTo)
+	case "breakout":
+		r.processBreakout(message.Breakout)
 	case "transient":
 		switch message.Transient.Action {
 		case TransientActionSet:
@@ -253,10 +264,10 @@ func (r *Room) processBackendRoomRequestRoom(message *BackendServerRoomRequest)
 		case TransientActionDelete:
 			r.RemoveTransientData(message.Transient.Key)
 		default:
-			log.Printf("Unsupported transient action in room %s: %+v", r.Id(), message.Transient)
+			r.log.Warnf("Unsupported transient action in room %s: %+v", r.Id(), message.Transient)
 		}
 	default:
-		log.Printf("Unsupported backend room request with type %s in %s: %+v", message.Type, r.Id(), message)
+		r.log.Warnf("Unsupported backend room request with type %s in %s: %+v", message.Type, r.Id(), message)
 	}
 }
 
@@ -268,7 +279,7 @@ func (r *Room) processBackendRoomRequestAsyncRoom(message *AsyncRoomMessage) {
 			r.publishUsersChangedWithInternal()
 		}
 	default:
-		log.Printf("Unsupported async room request with type %s in %s: %+v", message.Type, r.Id(), message)
+		r.log.Warnf("Unsupported async room request with type %s in %s: %+v", message.Type, r.Id(), message)
 	}
 }
 
@@ -277,57 +288,135 @@ func (r *Room) AddSession(session Session, sessionData json.RawMessage) {
 	if len(sessionData) > 0 {
 		roomSessionData = &RoomSessionData{}
 		if err := json.Unmarshal(sessionData, roomSessionData); err != nil {
-			log.Printf("Error decoding room session data \"%s\": %s", string(sessionData), err)
+			r.log.Errorf("Error decoding room session data \"%s\": %s", string(sessionData), err)
 			roomSessionData = nil
 		}
 	}
 
-	sid := session.PublicId()
+	checkRoomSessionType(session)
+
 	r.mu.Lock()
-	_, found := r.sessions[sid]
-	r.sessions[sid] = session
+	found := !r.addSessionLocked(session)
+	if roomSessionData != nil {
+		r.roomSessionData[session.PublicId()] = roomSessionData
+		r.log.Infof("Session %s sent room session data %+v", session.PublicId(), roomSessionData)
+	}
+	r.mu.Unlock()
 	if !found {
-		r.statsRoomSessionsCurrent.With(prometheus.Labels{"clienttype": string(session.ClientType())}).Inc()
+		r.PublishSessionJoined(session, roomSessionData)
+		r.notifySessionsAdded([]Session{session})
 	}
-	var publishUsersChanged bool
+
+	r.publishSessionJoinedAsync(session)
+}
+
+// AddSessions adds multiple sessions to the room and notifies the other
+// sessions with a single "join" event.
+func (r *Room) AddSessions(sessions []Session) {
+	for _, session := range sessions {
+		checkRoomSessionType(session)
+	}
+
+	added := make([]Session, 0, len(sessions))
+	r.mu.Lock()
+	for _, session := range sessions {
+		if r.addSessionLocked(session) {
+			added = append(added, session)
+		}
+	}
+	r.mu.Unlock()
+	if len(added) > 0 {
+		entries := make([]*EventServerMessageSessionEntry, 0, len(added))
+		for _, session := range added {
+			if entry := getSessionJoinEntry(session, nil); entry != nil {
+				entries = append(entries, entry)
+			}
+		}
+		r.publishSessionsJoined(entries)
+		r.notifySessionsAdded(added)
+	}
+
+	for _, session := range sessions {
+		r.publishSessionJoinedAsync(session)
+	}
+}
+
+// restoreSessions adds sessions that were handed off by a different process.
+// The other sessions already know about them, so no "join" events are sent.
+func (r *Room) restoreSessions(sessions []Session, inCall []Session) {
+	added := make([]Session, 0, len(sessions))
+	r.mu.Lock()
+	for _, session := range sessions {
+		checkRoomSessionType(session)
+		if r.addSessionLocked(session) {
+			added = append(added, session)
+		}
+	}
+	for _, session := range inCall {
+		r.inCallSessions[session] = true
+	}
+	r.mu.Unlock()
+	r.notifySessionsAdded(added)
+}
+
+func checkRoomSessionType(session Session) {
 	switch session.ClientType() {
 	case HelloClientTypeInternal:
-		clientSession, ok := session.(*ClientSession)
-		if !ok {
-			delete(r.sessions, sid)
-			r.mu.Unlock()
+		if _, ok := session.(*ClientSession); !ok {
 			panic(fmt.Sprintf("Expected a client session, got %v (%T)", session, session))
 		}
-		r.internalSessions[clientSession] = true
 	case HelloClientTypeVirtual:
-		virtualSession, ok := session.(*VirtualSession)
-		if !ok {
-			delete(r.sessions, sid)
-			r.mu.Unlock()
+		if _, ok := session.(*VirtualSession); !ok {
 			panic(fmt.Sprintf("Expected a virtual session, got %v (%T)", session, session))
 		}
-		r.virtualSessions[virtualSession] = true
-		publishUsersChanged = true
 	}
-	if roomSessionData != nil {
-		r.roomSessionData[sid] = roomSessionData
-		log.Printf("Session %s sent room session data %+v", session.PublicId(), roomSessionData)
-	}
-	r.mu.Unlock()
+}
+
+// addSessionLocked returns true if the session was not in the room before.
+func (r *Room) addSessionLocked(session Session) bool {
+	sid := session.PublicId()
+	_, found := r.sessions[sid]
+	r.sessions[sid] = session
 	if !found {
-		r.PublishSessionJoined(session, roomSessionData)
-		if publishUsersChanged {
-			r.publishUsersChangedWithInternal()
-			if session, ok := session.(*VirtualSession); ok && session.Flags() != 0 {
-				r.publishSessionFlagsChanged(session)
-			}
+		r.statsRoomSessionsCurrent.With(prometheus.Labels{"clienttype": string(session.ClientType())}).Inc()
+	}
+	switch session.ClientType() {
+	case HelloClientTypeInternal:
+		r.internalSessions[session.(*ClientSession)] = true
+	case HelloClientTypeVirtual:
+		r.virtualSessions[session.(*VirtualSession)] = true
+	}
+	return !found
+}
+
+// notifySessionsAdded publishes the events for sessions that were added to the
+// room after the "join" event was sent.
+func (r *Room) notifySessionsAdded(sessions []Session) {
+	var publishUsersChanged bool
+	for _, session := range sessions {
+		r.hub.storeSession(session, r)
+		if session.ClientType() == HelloClientTypeVirtual {
+			publishUsersChanged = true
 		}
 		if clientSession, ok := session.(*ClientSession); ok {
 			r.transientData.AddListener(clientSession)
 		}
 	}
+	if !publishUsersChanged {
+		return
+	}
 
+	r.publishUsersChangedWithInternal()
+	for _, session := range sessions {
+		if session, ok := session.(*VirtualSession); ok && session.Flags() != 0 {
+			r.publishSessionFlagsChanged(session)
+		}
+	}
+}
+
+func (r *Room) publishSessionJoinedAsync(session Session) {
 	// Trigger notifications that the session joined.
+	sid := session.PublicId()
 	if err := r.events.PublishBackendRoomMessage(r.id, r.backend, &AsyncMessage{
 		Type: "asyncroom",
 		AsyncRoom: &AsyncRoomMessage{
@@ -336,7 +425,7 @@ func (r *Room) AddSession(session Session, sessionData json.RawMessage) {
 			ClientType: session.ClientType(),
 		},
 	}); err != nil {
-		log.Printf("Error publishing joined event for session %s: %s", sid, err)
+		r.log.Errorf("Error publishing joined event for session %s: %s", sid, err)
 	}
 }
 
@@ -356,17 +445,10 @@ func (r *Room) getOtherSessions(ignoreSessionId PublicSessionId) (Session, []Ses
 	return r.sessions[ignoreSessionId], sessions
 }
 
-func (r *Room) notifySessionJoined(sessionId PublicSessionId) {
-	session, sessions := r.getOtherSessions(sessionId)
-	if len(sessions) == 0 {
-		return
-	}
-
-	if session != nil && session.ClientType() != HelloClientTypeClient {
-		session = nil
-	}
-
-	events := make([]*EventServerMessageSessionEntry, 0, len(sessions))
+// getJoinEvents returns the "join" events for the given sessions, split into
+// chunks of the configured size.
+func (r *Room) getJoinEvents(sessions []Session) []*ServerMessage {
+	entries := make([]*EventServerMessageSessionEntry, 0, len(sessions))
 	for _, s := range sessions {
 		entry := &EventServerMessageSessionEntry{
 			SessionId: s.PublicId(),
@@ -378,23 +460,68 @@ func (r *Room) notifySessionJoined(sessionId PublicSessionId) {
 			entry.RoomSessionId = s.RoomSessionId()
 			entry.Federated = s.ClientType() == HelloClientTypeFederation
 		}
-		events = append(events, entry)
+		entries = append(entries, entry)
 	}
 
-	msg := &ServerMessage{
-		Type: "event",
-		Event: &EventServerMessage{
-			Target: "room",
-			Type:   "join",
-			Join:   events,
-		},
+	size := len(entries)
+	if r.hub != nil {
+		if maxSize := int(r.hub.joinEventSize.Load()); maxSize > 0 {
+			size = maxSize
+		}
+	}
+	chunks := slices.Collect(slices.Chunk(entries, size))
+	result := make([]*ServerMessage, 0, len(chunks))
+	for idx, chunk := range chunks {
+		msg := &ServerMessage{
+			Type: "event",
+			Event: &EventServerMessage{
+				Target: "room",
+				Type:   "join",
+				Join:   chunk,
+			},
+		}
+		if len(chunks) > 1 {
+			msg.Event.Chunk = &EventServerMessageChunk{
+				Sequence: idx + 1,
+				More:     idx < len(chunks)-1,
+				Total:    len(entries),
+			}
+		}
+		result = append(result, msg)
 	}
+	return result
+}
 
-	if err := r.events.PublishSessionMessage(sessionId, r.backend, &AsyncMessage{
-		Type:    "message",
-		Message: msg,
-	}); err != nil {
-		log.Printf("Error publishing joined events to session %s: %s", sessionId, err)
+// GetJoinEvents returns the "join" events for all sessions in the room.
+func (r *Room) GetJoinEvents() []*ServerMessage {
+	_, sessions := r.getOtherSessions("")
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	return r.getJoinEvents(sessions)
+}
+
+func (r *Room) notifySessionJoined(sessionId PublicSessionId) {
+	session, sessions := r.getOtherSessions(sessionId)
+	if len(sessions) == 0 {
+		return
+	}
+
+	if session != nil && session.ClientType() != HelloClientTypeClient {
+		session = nil
+	}
+
+	async := getPooledAsyncMessage()
+	defer putPooledAsyncMessage(async)
+
+	for _, msg := range r.getJoinEvents(sessions) {
+		async.Type = "message"
+		async.Message = msg
+		if err := r.events.PublishSessionMessage(sessionId, r.backend, async); err != nil {
+			r.log.Errorf("Error publishing joined events to session %s: %s", sessionId, err)
+			break
+		}
 	}
 
 	// Notify about initial flags of virtual sessions.
@@ -409,25 +536,19 @@ func (r *Room) notifySessionJoined(sessionId PublicSessionId) {
 			continue
 		}
 
-		msg := &ServerMessage{
-			Type: "event",
-			Event: &EventServerMessage{
-				Target: "participants",
-				Type:   "flags",
-				Flags: &RoomFlagsServerMessage{
-					RoomId:    r.id,
-					SessionId: vsess.PublicId(),
-					Flags:     vsess.Flags(),
-				},
-			},
+		msg := getPooledEvent("participants", "flags")
+		msg.Event.Flags = &RoomFlagsServerMessage{
+			RoomId:    r.id,
+			SessionId: vsess.PublicId(),
+			Flags:     vsess.Flags(),
 		}
 
-		if err := r.events.PublishSessionMessage(sessionId, r.backend, &AsyncMessage{
-			Type:    "message",
-			Message: msg,
-		}); err != nil {
-			log.Printf("Error publishing initial flags to session %s: %s", sessionId, err)
+		async.Type = "message"
+		async.Message = msg
+		if err := r.events.PublishSessionMessage(sessionId, r.backend, async); err != nil {
+			r.log.Errorf("Error publishing initial flags to session %s: %s", sessionId, err)
 		}
+		putPooledEvent(msg)
 	}
 }
 
@@ -445,14 +566,63 @@ func (r *Room) IsSessionInCall(session Session) bool {
 	return result
 }
 
+// GetSessionCounts returns the number of sessions in the room and how many of
+// them joined the call.
+func (r *Room) GetSessionCounts() (sessions int, inCall int) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.sessions), len(r.inCallSessions)
+}
+
 // Returns "true" if there are still clients in the room.
 func (r *Room) RemoveSession(session Session) bool {
+	return r.RemoveSessions([]Session{session})
+}
+
+// RemoveSessions removes multiple sessions from the room and notifies the other
+// sessions with a single "leave" event. Returns false if the room was closed
+// because no sessions are left.
+func (r *Room) RemoveSessions(sessions []Session) bool {
+	removed := make([]Session, 0, len(sessions))
 	r.mu.Lock()
-	if _, found := r.sessions[session.PublicId()]; !found {
+	for _, session := range sessions {
+		if r.removeSessionLocked(session) {
+			removed = append(removed, session)
+		}
+	}
+	if len(removed) == 0 {
+		r.mu.Unlock()
+		return true
+	}
+
+	for _, session := range removed {
+		r.hub.storeSession(session, nil)
+	}
+
+	if len(r.sessions) > 0 {
 		r.mu.Unlock()
+		r.publishSessionsLeft(removed)
 		return true
 	}
 
+	r.hub.removeRoom(r)
+	r.statsRoomSessionsCurrent.Delete(prometheus.Labels{"clienttype": string(HelloClientTypeClient)})
+	r.statsRoomSessionsCurrent.Delete(prometheus.Labels{"clienttype": string(HelloClientTypeInternal)})
+	r.statsRoomSessionsCurrent.Delete(prometheus.Labels{"clienttype": string(HelloClientTypeVirtual)})
+	r.unsubscribeBackend()
+	r.doClose()
+	r.mu.Unlock()
+	// Still need to publish an event so sessions on other servers get notified.
+	r.publishSessionsLeft(removed)
+	return false
+}
+
+// removeSessionLocked returns true if the session was in the room before.
+func (r *Room) removeSessionLocked(session Session) bool {
+	if _, found := r.sessions[session.PublicId()]; !found {
+		return false
+	}
+
 	sid := session.PublicId()
 	r.statsRoomSessionsCurrent.With(prometheus.Labels{"clienttype": string(session.ClientType())}).Dec()
 	delete(r.sessions, sid)
@@ -475,29 +645,16 @@ func (r *Room) RemoveSession(session Session) bool {
 	}
 	delete(r.inCallSessions, session)
 	delete(r.roomSessionData, sid)
-	if len(r.sessions) > 0 {
-		r.mu.Unlock()
-		r.PublishSessionLeft(session)
-		return true
-	}
-
-	r.hub.removeRoom(r)
-	r.statsRoomSessionsCurrent.Delete(prometheus.Labels{"clienttype": string(HelloClientTypeClient)})
-	r.statsRoomSessionsCurrent.Delete(prometheus.Labels{"clienttype": string(HelloClientTypeInternal)})
-	r.statsRoomSessionsCurrent.Delete(prometheus.Labels{"clienttype": string(HelloClientTypeVirtual)})
-	r.unsubscribeBackend()
-	r.doClose()
-	r.mu.Unlock()
-	// Still need to publish an event so sessions on other servers get notified.
-	r.PublishSessionLeft(session)
-	return false
+	return true
 }
 
 func (r *Room) publish(message *ServerMessage) error {
-	return r.events.PublishRoomMessage(r.id, r.backend, &AsyncMessage{
-		Type:    "message",
-		Message: message,
-	})
+	async := getPooledAsyncMessage()
+	defer putPooledAsyncMessage(async)
+
+	async.Type = "message"
+	async.Message = message
+	return r.events.PublishRoomMessage(r.id, r.backend, async)
 }
 
 func (r *Room) UpdateProperties(properties json.RawMessage) {
@@ -518,7 +675,7 @@ func (r *Room) UpdateProperties(properties json.RawMessage) {
 		},
 	}
 	if err := r.publish(message); err != nil {
-		log.Printf("Could not publish update properties message in room %s: %s", r.Id(), err)
+		r.log.Errorf("Could not publish update properties message in room %s: %s", r.Id(), err)
 	}
 }
 
@@ -528,10 +685,10 @@ func (r *Room) GetRoomSessionData(session Session) *RoomSessionData {
 	return r.roomSessionData[session.PublicId()]
 }
 
-func (r *Room) PublishSessionJoined(session Session, sessionData *RoomSessionData) {
+func getSessionJoinEntry(session Session, sessionData *RoomSessionData) *EventServerMessageSessionEntry {
 	sessionId := session.PublicId()
 	if sessionId == "" {
-		return
+		return nil
 	}
 
 	userid := session.UserId()
@@ -539,51 +696,66 @@ func (r *Room) PublishSessionJoined(session Session, sessionData *RoomSessionDat
 		userid = sessionData.UserId
 	}
 
-	message := &ServerMessage{
-		Type: "event",
-		Event: &EventServerMessage{
-			Target: "room",
-			Type:   "join",
-			Join: []*EventServerMessageSessionEntry{
-				{
-					SessionId: sessionId,
-					UserId:    userid,
-					User:      session.UserData(),
-				},
-			},
-		},
+	entry := &EventServerMessageSessionEntry{
+		SessionId: sessionId,
+		UserId:    userid,
+		User:      session.UserData(),
 	}
 	if session, ok := session.(*ClientSession); ok {
-		message.Event.Join[0].Features = session.GetFeatures()
-		message.Event.Join[0].RoomSessionId = session.RoomSessionId()
-		message.Event.Join[0].Federated = session.ClientType() == HelloClientTypeFederation
+		entry.Features = session.GetFeatures()
+		entry.RoomSessionId = session.RoomSessionId()
+		entry.Federated = session.ClientType() == HelloClientTypeFederation
+	}
+	return entry
+}
+
+func (r *Room) PublishSessionJoined(session Session, sessionData *RoomSessionData) {
+	if entry := getSessionJoinEntry(session, sessionData); entry != nil {
+		r.publishSessionsJoined([]*EventServerMessageSessionEntry{entry})
+	}
+}
+
+func (r *Room) publishSessionsJoined(entries []*EventServerMessageSessionEntry) {
+	if len(entries) == 0 {
+		return
 	}
+
+	message := getPooledEvent("room", "join")
+	defer putPooledEvent(message)
+
+	message.Event.Join = entries
 	if err := r.publish(message); err != nil {
-		log.Printf("Could not publish session joined message in room %s: %s", r.Id(), err)
+		r.log.Errorf("Could not publish session joined message in room %s: %s", r.Id(), err)
 	}
 }
 
 func (r *Room) PublishSessionLeft(session Session) {
-	sessionId := session.PublicId()
-	if sessionId == "" {
+	r.publishSessionsLeft([]Session{session})
+}
+
+func (r *Room) publishSessionsLeft(sessions []Session) {
+	leave := make([]PublicSessionId, 0, len(sessions))
+	var publishUsersChanged bool
+	for _, session := range sessions {
+		if sessionId := session.PublicId(); sessionId != "" {
+			leave = append(leave, sessionId)
+		}
+		if session.ClientType() == HelloClientTypeInternal {
+			publishUsersChanged = true
+		}
+	}
+	if len(leave) == 0 {
 		return
 	}
 
-	message := &ServerMessage{
-		Type: "event",
-		Event: &EventServerMessage{
-			Target: "room",
-			Type:   "leave",
-			Leave: []PublicSessionId{
-				sessionId,
-			},
-		},
-	}
+	message := getPooledEvent("room", "leave")
+	message.Event.Leave = leave
 	if err := r.publish(message); err != nil {
-		log.Printf("Could not publish session left message in room %s: %s", r.Id(), err)
+		r.log.Errorf("Could not publish session left message in room %s: %s", r.Id(), err)
 	}
+	putPooledEvent(message)
 
-	if session.ClientType() == HelloClientTypeInternal {
+	if publishUsersChanged {
 		r.publishUsersChangedWithInternal()
 	}
 }
@@ -607,7 +779,7 @@ func (r *Room) getClusteredInternalSessionsRLocked() (internal map[PublicSession
 
 			clientInternal, clientVirtual, err := c.GetInternalSessions(ctx, r.Id(), r.Backend().Urls())
 			if err != nil {
-				log.Printf("Received error while getting internal sessions for %s@%s from %s: %s", r.Id(), r.Backend().Id(), c.Target(), err)
+				r.log.Infof("Received error while getting internal sessions for %s@%s from %s: %s", r.Id(), r.Backend().Id(), c.Target(), err)
 				return
 			}
 
@@ -777,7 +949,7 @@ func (r *Room) PublishUsersInCallChanged(changed []StringMap, users []StringMap)
 			r.mu.Lock()
 			if !r.inCallSessions[session] {
 				r.inCallSessions[session] = true
-				log.Printf("Session %s joined call %s", session.PublicId(), r.id)
+				r.log.Infof("Session %s joined call %s", session.PublicId(), r.id)
 			}
 			r.mu.Unlock()
 		} else {
@@ -806,7 +978,7 @@ func (r *Room) PublishUsersInCallChanged(changed []StringMap, users []StringMap)
 		},
 	}
 	if err := r.publish(message); err != nil {
-		log.Printf("Could not publish incall message in room %s: %s", r.Id(), err)
+		r.log.Errorf("Could not publish incall message in room %s: %s", r.Id(), err)
 	}
 }
 
@@ -840,7 +1012,7 @@ func (r *Room) PublishUsersInCallChangedAll(inCall int) {
 			return
 		}
 
-		log.Printf("Sessions %v joined call %s", joined, r.id)
+		r.log.Infof("Sessions %v joined call %s", joined, r.id)
 	} else if len(r.inCallSessions) > 0 {
 		// Perform actual leaving asynchronously.
 		ch := make(chan *ClientSession, 1)
@@ -893,7 +1065,7 @@ func (r *Room) PublishUsersInCallChangedAll(inCall int) {
 
 	for _, session := range notify {
 		if !session.SendMessage(message) {
-			log.Printf("Could not send incall message from room %s to %s", r.Id(), session.PublicId())
+			r.log.Errorf("Could not send incall message from room %s to %s", r.Id(), session.PublicId())
 		}
 	}
 }
@@ -915,7 +1087,7 @@ func (r *Room) PublishUsersChanged(changed []StringMap, users []StringMap) {
 		},
 	}
 	if err := r.publish(message); err != nil {
-		log.Printf("Could not publish users changed message in room %s: %s", r.Id(), err)
+		r.log.Errorf("Could not publish users changed message in room %s: %s", r.Id(), err)
 	}
 }
 
@@ -975,7 +1147,7 @@ func (r *Room) NotifySessionChanged(session Session, flags SessionChangeFlag) {
 				r.mu.Lock()
 				if !r.inCallSessions[session] {
 					r.inCallSessions[session] = true
-					log.Printf("Session %s joined call %s", session.PublicId(), r.id)
+					r.log.Infof("Session %s joined call %s", session.PublicId(), r.id)
 				}
 				r.mu.Unlock()
 			case 2:
@@ -1000,7 +1172,7 @@ func (r *Room) publishUsersChangedWithInternal() {
 	}
 
 	if err := r.publish(message); err != nil {
-		log.Printf("Could not publish users changed message in room %s: %s", r.Id(), err)
+		r.log.Errorf("Could not publish users changed message in room %s: %s", r.Id(), err)
 	}
 }
 
@@ -1018,7 +1190,7 @@ func (r *Room) publishSessionFlagsChanged(session *VirtualSession) {
 		},
 	}
 	if err := r.publish(message); err != nil {
-		log.Printf("Could not publish flags changed message in room %s: %s", r.Id(), err)
+		r.log.Errorf("Could not publish flags changed message in room %s: %s", r.Id(), err)
 	}
 }
 
@@ -1037,7 +1209,7 @@ func (r *Room) publishActiveSessions() (int, *sync.WaitGroup) {
 		u += PathToOcsSignalingBackend
 		parsed, err := url.Parse(u)
 		if err != nil {
-			log.Printf("Could not parse backend url %s: %s", u, err)
+			r.log.Errorf("Could not parse backend url %s: %s", u, err)
 			continue
 		}
 
@@ -1093,7 +1265,7 @@ func (r *Room) publishActiveSessions() (int, *sync.WaitGroup) {
 			defer cancel()
 
 			if err := r.hub.roomPing.SendPings(ctx, r.id, url, entries); err != nil {
-				log.Printf("Error pinging room %s for active entries %+v: %s", r.id, entries, err)
+				r.log.Errorf("Error pinging room %s for active entries %+v: %s", r.id, entries, err)
 			}
 		}(urls[u], e)
 	}
@@ -1117,7 +1289,7 @@ func (r *Room) publishRoomMessage(message *BackendRoomMessageRequest) {
 		},
 	}
 	if err := r.publish(msg); err != nil {
-		log.Printf("Could not publish room message in room %s: %s", r.Id(), err)
+		r.log.Errorf("Could not publish room message in room %s: %s", r.Id(), err)
 	}
 }
 
@@ -1144,7 +1316,7 @@ func (r *Room) publishSwitchTo(message *BackendRoomSwitchToMessageRequest) {
 					Type:    "message",
 					Message: msg,
 				}); err != nil {
-					log.Printf("Error publishing switchto event to session %s: %s", sessionId, err)
+					r.log.Errorf("Error publishing switchto event to session %s: %s", sessionId, err)
 				}
 			}(sessionId)
 		}
@@ -1172,7 +1344,7 @@ func (r *Room) publishSwitchTo(message *BackendRoomSwitchToMessageRequest) {
 					Type:    "message",
 					Message: msg,
 				}); err != nil {
-					log.Printf("Error publishing switchto event to session %s: %s", sessionId, err)
+					r.log.Errorf("Error publishing switchto event to session %s: %s", sessionId, err)
 				}
 			}(sessionId, details)
 		}
@@ -1180,6 +1352,65 @@ func (r *Room) publishSwitchTo(message *BackendRoomSwitchToMessageRequest) {
 	wg.Wait()
 }
 
+func (r *Room) processBreakout(message *BackendRoomBreakoutRequest) {
+	if message.ParentRoomId != "" {
+		// This is a breakout room that has been stopped, all sessions must
+		// switch back to the parent room.
+		if message.Action == BreakoutActionStop {
+			r.publishSwitchToAll(message.ParentRoomId)
+		}
+		return
+	}
+
+	switch message.Action {
+	case BreakoutActionStart:
+		r.hub.breakout.Start(r.id, r.backend, message.RoomIds)
+		for _, roomId := range message.RoomIds {
+			if sessions := message.SessionsMap[roomId]; len(sessions) > 0 {
+				r.publishSwitchTo(&BackendRoomSwitchToMessageRequest{
+					RoomId:       roomId,
+					SessionsList: sessions,
+				})
+			}
+		}
+	case BreakoutActionStop:
+		r.hub.breakout.Stop(r.id, r.backend)
+	default:
+		r.log.Warnf("Unsupported breakout action in room %s: %+v", r.Id(), message)
+	}
+}
+
+// publishSwitchToAll notifies all local client sessions of the room that they
+// should switch to a different room.
+func (r *Room) publishSwitchToAll(roomId string) {
+	msg := &ServerMessage{
+		Type: "event",
+		Event: &EventServerMessage{
+			Target: "room",
+			Type:   "switchto",
+			SwitchTo: &EventServerMessageSwitchTo{
+				RoomId: roomId,
+			},
+		},
+	}
+
+	r.mu.RLock()
+	var notify []*ClientSession
+	for _, session := range r.sessions {
+		clientSession, ok := session.(*ClientSession)
+		if !ok || session.ClientType() != HelloClientTypeClient {
+			continue
+		}
+
+		notify = append(notify, clientSession)
+	}
+	r.mu.RUnlock()
+
+	for _, session := range notify {
+		session.SendMessage(msg)
+	}
+}
+
 func (r *Room) notifyInternalRoomDeleted() {
 	msg := &ServerMessage{
 		Type: "event",
@@ -1207,3 +1438,38 @@ func (r *Room) SetTransientDataTTL(key string, value any, ttl time.Duration) {
 func (r *Room) RemoveTransientData(key string) {
 	r.transientData.Remove(key)
 }
+
+func (r *Room) GetTransientEntries() map[string]*TransientEntry {
+	return r.transientData.GetEntries()
+}
+
+// fetchClusteredTransientData merges the transient data of the room from other
+// servers in the cluster, e.g. if the sessions of the room moved from a server
+// that was restarted.
+func (r *Room) fetchClusteredTransientData() {
+	if r.hub.rpcClients == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, client := range r.hub.rpcClients.GetClients() {
+		wg.Add(1)
+		go func(c *GrpcClient) {
+			defer wg.Done()
+
+			entries, err := c.GetTransientData(ctx, r.Id(), r.Backend().Urls())
+			if err != nil {
+				r.log.Infof("Received error while getting transient data for %s@%s from %s: %s", r.Id(), r.Backend().Id(), c.Target(), err)
+				return
+			}
+
+			if count := r.transientData.MergeEntries(entries, time.Now()); count > 0 {
+				r.log.Infof("Received %d transient data entries for %s@%s from %s", count, r.Id(), r.Backend().Id(), c.Target())
+			}
+		}(client)
+	}
+	wg.Wait()
+}
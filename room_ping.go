@@ -23,7 +23,6 @@ package signaling
 
 import (
 	"context"
-	"log"
 	"net/url"
 	"slices"
 	"sync"
@@ -126,7 +125,7 @@ func (p *RoomPing) publishEntries(entries *pingEntries, timeout time.Duration) {
 			defer cancel2()
 
 			if err := p.sendPingsDirect(ctx2, roomId, entries.url, e); err != nil {
-				log.Printf("Error pinging room %s for active entries %+v: %s", roomId, e, err)
+				hubLog.Errorf("Error pinging room %s for active entries %+v: %s", roomId, e, err)
 			}
 		}
 		return
@@ -173,7 +172,7 @@ func (p *RoomPing) sendPingsCombined(url *url.URL, entries []BackendPingEntry, l
 		request := NewBackendClientPingRequest("", tosend)
 		var response BackendClientResponse
 		if err := p.backend.PerformJSONRequest(ctx, url, request, &response); err != nil {
-			log.Printf("Error sending combined ping session entries %+v to %s: %s", tosend, url, err)
+			hubLog.Errorf("Error sending combined ping session entries %+v to %s: %s", tosend, url, err)
 		}
 	}
 }
@@ -29,9 +29,12 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/dlintw/goconf"
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -430,3 +433,282 @@ func TestRoom_InCallAll(t *testing.T) {
 		checkMessageInCallAll(t, msg, roomId, 0)
 	}
 }
+
+func TestRoom_ParticipantsDelta(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	hub, _, _, server := CreateHubForTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client1 := NewTestClient(t, server, hub)
+	defer client1.CloseWithBye()
+	require.NoError(client1.SendHelloParams(server.URL, HelloVersionV1, "", []string{ClientFeatureParticipantsDelta}, TestBackendClientAuthParams{
+		UserId: testDefaultUserId + "1",
+	}))
+	hello1 := MustSucceed1(t, client1.RunUntilHello, ctx)
+	client2, hello2 := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId+"2")
+	defer client2.CloseWithBye()
+
+	// Requesting the participants requires the feature and a room.
+	require.NoError(client2.WriteJSON(&ClientMessage{
+		Id:           "1234",
+		Type:         "participants",
+		Participants: &ParticipantsClientMessage{},
+	}))
+	if msg, ok := client2.RunUntilMessage(ctx); ok {
+		assert.Equal("1234", msg.Id)
+		assert.True(checkMessageError(t, msg, "ignored"))
+	}
+	require.NoError(client1.WriteJSON(&ClientMessage{
+		Id:           "1234",
+		Type:         "participants",
+		Participants: &ParticipantsClientMessage{},
+	}))
+	if msg, ok := client1.RunUntilMessage(ctx); ok {
+		assert.Equal("1234", msg.Id)
+		assert.True(checkMessageError(t, msg, "not_in_room"))
+	}
+
+	roomId := "test-room"
+	MustSucceed2(t, client1.JoinRoom, ctx, roomId)
+	client1.RunUntilJoined(ctx, hello1.Hello)
+	MustSucceed2(t, client2.JoinRoom, ctx, roomId)
+	client1.RunUntilJoined(ctx, hello2.Hello)
+	client2.RunUntilJoined(ctx, hello1.Hello, hello2.Hello)
+
+	room := hub.getRoom(roomId)
+	require.NotNil(room, "Room %s not found", roomId)
+
+	user1 := StringMap{"sessionId": string(hello1.Hello.SessionId), "inCall": 0}
+	user2 := StringMap{"sessionId": string(hello2.Hello.SessionId), "inCall": 0}
+	user2Changed := StringMap{"sessionId": string(hello2.Hello.SessionId), "inCall": 3}
+
+	checkUpdate := func(client *TestClient) *RoomEventServerMessage {
+		msg, ok := client.RunUntilMessage(ctx)
+		if !ok || !checkMessageType(t, msg, "event") {
+			return nil
+		}
+
+		assert.Equal("participants", msg.Event.Target)
+		assert.Equal("update", msg.Event.Type)
+		if assert.NotNil(msg.Event.Update) {
+			assert.Equal(roomId, msg.Event.Update.RoomId)
+		}
+		return msg.Event.Update
+	}
+
+	// The first update contains the full list.
+	room.PublishUsersChanged(nil, []StringMap{user1, user2})
+	if update := checkUpdate(client1); assert.NotNil(update) {
+		assert.EqualValues(1, update.Version)
+		assert.False(update.Delta)
+		assert.Len(update.Users, 2)
+	}
+	if update := checkUpdate(client2); assert.NotNil(update) {
+		assert.Zero(update.Version)
+		assert.False(update.Delta)
+		assert.Len(update.Users, 2)
+	}
+
+	// Only changes are sent to clients supporting deltas.
+	room.PublishUsersChanged([]StringMap{user2Changed}, []StringMap{user1, user2Changed})
+	if update := checkUpdate(client1); assert.NotNil(update) {
+		assert.EqualValues(2, update.Version)
+		assert.True(update.Delta)
+		if assert.Len(update.Users, 1) {
+			assert.EqualValues(hello2.Hello.SessionId, update.Users[0]["sessionId"])
+			assert.EqualValues(3, update.Users[0]["inCall"])
+		}
+		assert.Empty(update.Removed)
+	}
+	if update := checkUpdate(client2); assert.NotNil(update) {
+		assert.Len(update.Users, 2)
+	}
+
+	room.PublishUsersChanged(nil, []StringMap{user1})
+	if update := checkUpdate(client1); assert.NotNil(update) {
+		assert.EqualValues(3, update.Version)
+		assert.True(update.Delta)
+		assert.Empty(update.Users)
+		assert.Equal([]PublicSessionId{hello2.Hello.SessionId}, update.Removed)
+	}
+	if update := checkUpdate(client2); assert.NotNil(update) {
+		assert.Len(update.Users, 1)
+	}
+
+	// Clients can request the changes since a version.
+	require.NoError(client1.WriteJSON(&ClientMessage{
+		Id:   "2345",
+		Type: "participants",
+		Participants: &ParticipantsClientMessage{
+			Version: 1,
+		},
+	}))
+	if update := checkUpdate(client1); assert.NotNil(update) {
+		assert.EqualValues(3, update.Version)
+		assert.True(update.Delta)
+		assert.Empty(update.Users)
+		assert.Equal([]PublicSessionId{hello2.Hello.SessionId}, update.Removed)
+	}
+
+	// Or the full list.
+	require.NoError(client1.WriteJSON(&ClientMessage{
+		Id:           "3456",
+		Type:         "participants",
+		Participants: &ParticipantsClientMessage{},
+	}))
+	if update := checkUpdate(client1); assert.NotNil(update) {
+		assert.EqualValues(3, update.Version)
+		assert.False(update.Delta)
+		if assert.Len(update.Users, 1) {
+			assert.EqualValues(hello1.Hello.SessionId, update.Users[0]["sessionId"])
+		}
+		assert.Empty(update.Removed)
+	}
+}
+
+// benchmarkRoomClient is a client without network connection that serializes
+// messages as they would be sent to the websocket.
+type benchmarkRoomClient struct {
+	session  Session
+	received *sync.WaitGroup
+}
+
+func (c *benchmarkRoomClient) Context() context.Context { return context.Background() }
+func (c *benchmarkRoomClient) RemoteAddr() string       { return "127.0.0.1" }
+func (c *benchmarkRoomClient) Country() string          { return "" }
+func (c *benchmarkRoomClient) UserAgent() string        { return "benchmark" }
+func (c *benchmarkRoomClient) IsConnected() bool        { return true }
+func (c *benchmarkRoomClient) IsAuthenticated() bool    { return true }
+func (c *benchmarkRoomClient) GetSession() Session      { return c.session }
+func (c *benchmarkRoomClient) SetSession(session Session) {
+	c.session = session
+}
+func (c *benchmarkRoomClient) SendError(e *Error) bool                     { return true }
+func (c *benchmarkRoomClient) SendByeResponse(message *ClientMessage) bool { return true }
+func (c *benchmarkRoomClient) SendByeResponseWithReason(message *ClientMessage, reason string) bool {
+	return true
+}
+func (c *benchmarkRoomClient) Close() {}
+
+func (c *benchmarkRoomClient) SendMessage(message WritableClientMessage) bool {
+	if _, err := message.MarshalJSON(); err != nil {
+		panic(err)
+	}
+
+	var msg *ServerMessage
+	switch m := message.(type) {
+	case *PreparedServerMessage:
+		msg = m.Message()
+	case *ServerMessage:
+		msg = m
+	}
+	// Only the room messages sent by the benchmark are counted, join events
+	// and other notifications are ignored.
+	if msg != nil && msg.Type == "event" && msg.Event.Type == "message" {
+		c.received.Done()
+	}
+	return true
+}
+
+func newRoomForBenchmark(b *testing.B, sessions int, received *sync.WaitGroup) *Room {
+	config := goconf.NewConfigFile()
+	config.AddOption("backend", "allowall", "true")
+	config.AddOption("backend", "secret", string(testBackendSecret))
+	config.AddOption("sessions", "hashkey", "12345678901234567890123456789012")
+	config.AddOption("sessions", "blockkey", "09876543210987654321098765432109")
+	config.AddOption("geoip", "url", "none")
+
+	events, err := NewAsyncEvents(NatsLoopbackUrl)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() {
+		events.Close()
+	})
+
+	hub, err := NewHub(config, events, nil, nil, nil, mux.NewRouter(), "no-version")
+	if err != nil {
+		b.Fatal(err)
+	}
+	go hub.Run()
+	b.Cleanup(hub.Stop)
+
+	backend := hub.GetBackend(nil)
+	hub.ru.Lock()
+	room, err := hub.createRoom("benchmark-room", nil, backend)
+	hub.ru.Unlock()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	added := make([]Session, 0, sessions)
+	for i := range sessions {
+		data := hub.newSessionIdData(backend)
+		privateId, err := hub.cookie.EncodePrivate(data)
+		if err != nil {
+			b.Fatal(err)
+		}
+		publicId, err := hub.cookie.EncodePublic(data)
+		if err != nil {
+			b.Fatal(err)
+		}
+		hello := &HelloClientMessage{
+			Version: HelloVersionV1,
+			Auth: &HelloClientMessageAuth{
+				Type: HelloClientTypeClient,
+				Url:  "https://domain.invalid",
+			},
+		}
+		auth := &BackendClientAuthResponse{
+			UserId: fmt.Sprintf("user-%d", i),
+		}
+		session, err := NewClientSession(hub, privateId, publicId, data, backend, hello, auth)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Cleanup(session.Close)
+
+		session.SetClient(&benchmarkRoomClient{
+			received: received,
+		})
+		if err := session.SubscribeRoomEvents(room.Id(), ""); err != nil {
+			b.Fatal(err)
+		}
+		session.SetRoom(room)
+		added = append(added, session)
+	}
+	room.AddSessions(added)
+	return room
+}
+
+// BenchmarkRoom_PublishMessage measures the fan-out of room messages to all
+// sessions in the room. The messages are delivered through the loopback NATS
+// client, so the benchmark runs without any network connections.
+func BenchmarkRoom_PublishMessage(b *testing.B) {
+	for _, sessions := range []int{10, 100, 1000} {
+		b.Run(strconv.Itoa(sessions), func(b *testing.B) {
+			DiscardLogForTest(b)
+			var received sync.WaitGroup
+			room := newRoomForBenchmark(b, sessions, &received)
+			message := &BackendRoomMessageRequest{
+				Data: json.RawMessage(`{"type":"chat","chat":{"refresh":true}}`),
+			}
+
+			b.ReportAllocs()
+			start := time.Now()
+			for b.Loop() {
+				received.Add(sessions)
+				room.publishRoomMessage(message)
+				received.Wait()
+			}
+			if elapsed := time.Since(start); elapsed > 0 {
+				b.ReportMetric(float64(b.N*sessions)/elapsed.Seconds(), "msgs/s")
+			}
+		})
+	}
+}
@@ -24,7 +24,6 @@ package signaling
 import (
 	"context"
 	"errors"
-	"log"
 	"sync"
 	"sync/atomic"
 )
@@ -124,10 +123,10 @@ func (r *BuiltinRoomSessions) LookupSessionId(ctx context.Context, roomSessionId
 			if errors.Is(err, context.Canceled) {
 				return
 			} else if err != nil {
-				log.Printf("Received error while checking for room session id %s on %s: %s", roomSessionId, client.Target(), err)
+				hubLog.Infof("Received error while checking for room session id %s on %s: %s", roomSessionId, client.Target(), err)
 				return
 			} else if sid == "" {
-				log.Printf("Received empty session id for room session id %s from %s", roomSessionId, client.Target())
+				hubLog.Infof("Received empty session id for room session id %s from %s", roomSessionId, client.Target())
 				return
 			}
 
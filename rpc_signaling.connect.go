@@ -0,0 +1,164 @@
+//*
+// Standalone signaling server for the Nextcloud Spreed app.
+// Copyright (C) 2025 struktur AG
+//
+// @author Joachim Bauch <bauch@struktur.de>
+//
+// @license GNU AGPL version 3 or any later version
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: rpc_signaling.proto
+
+package signaling
+
+import (
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// RpcSignalingName is the fully-qualified name of the RpcSignaling service.
+	RpcSignalingName = "signaling.RpcSignaling"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// RpcSignalingReceiveProcedure is the fully-qualified name of the RpcSignaling's Receive RPC.
+	RpcSignalingReceiveProcedure = "/signaling.RpcSignaling/Receive"
+	// RpcSignalingSendProcedure is the fully-qualified name of the RpcSignaling's Send RPC.
+	RpcSignalingSendProcedure = "/signaling.RpcSignaling/Send"
+)
+
+// RpcSignalingClient is a client for the signaling.RpcSignaling service.
+type RpcSignalingClient interface {
+	// Open a connection and receive the messages from the server. The first
+	// response contains the id of the connection, followed by the messages that
+	// would be sent through a WebSocket connection (starting with "welcome").
+	Receive(context.Context, *connect.Request[SignalingReceiveRequest]) (*connect.ServerStreamForClient[SignalingReceiveResponse], error)
+	// Send a message to the server through an open connection.
+	Send(context.Context, *connect.Request[SignalingSendRequest]) (*connect.Response[SignalingSendResponse], error)
+}
+
+// NewRpcSignalingClient constructs a client for the signaling.RpcSignaling service. By default, it
+// uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and sends
+// uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC() or
+// connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewRpcSignalingClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) RpcSignalingClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	rpcSignalingMethods := File_rpc_signaling_proto.Services().ByName("RpcSignaling").Methods()
+	return &rpcSignalingClient{
+		receive: connect.NewClient[SignalingReceiveRequest, SignalingReceiveResponse](
+			httpClient,
+			baseURL+RpcSignalingReceiveProcedure,
+			connect.WithSchema(rpcSignalingMethods.ByName("Receive")),
+			connect.WithClientOptions(opts...),
+		),
+		send: connect.NewClient[SignalingSendRequest, SignalingSendResponse](
+			httpClient,
+			baseURL+RpcSignalingSendProcedure,
+			connect.WithSchema(rpcSignalingMethods.ByName("Send")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// rpcSignalingClient implements RpcSignalingClient.
+type rpcSignalingClient struct {
+	receive *connect.Client[SignalingReceiveRequest, SignalingReceiveResponse]
+	send    *connect.Client[SignalingSendRequest, SignalingSendResponse]
+}
+
+// Receive calls signaling.RpcSignaling.Receive.
+func (c *rpcSignalingClient) Receive(ctx context.Context, req *connect.Request[SignalingReceiveRequest]) (*connect.ServerStreamForClient[SignalingReceiveResponse], error) {
+	return c.receive.CallServerStream(ctx, req)
+}
+
+// Send calls signaling.RpcSignaling.Send.
+func (c *rpcSignalingClient) Send(ctx context.Context, req *connect.Request[SignalingSendRequest]) (*connect.Response[SignalingSendResponse], error) {
+	return c.send.CallUnary(ctx, req)
+}
+
+// RpcSignalingHandler is an implementation of the signaling.RpcSignaling service.
+type RpcSignalingHandler interface {
+	// Open a connection and receive the messages from the server. The first
+	// response contains the id of the connection, followed by the messages that
+	// would be sent through a WebSocket connection (starting with "welcome").
+	Receive(context.Context, *connect.Request[SignalingReceiveRequest], *connect.ServerStream[SignalingReceiveResponse]) error
+	// Send a message to the server through an open connection.
+	Send(context.Context, *connect.Request[SignalingSendRequest]) (*connect.Response[SignalingSendResponse], error)
+}
+
+// NewRpcSignalingHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewRpcSignalingHandler(svc RpcSignalingHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	rpcSignalingMethods := File_rpc_signaling_proto.Services().ByName("RpcSignaling").Methods()
+	rpcSignalingReceiveHandler := connect.NewServerStreamHandler(
+		RpcSignalingReceiveProcedure,
+		svc.Receive,
+		connect.WithSchema(rpcSignalingMethods.ByName("Receive")),
+		connect.WithHandlerOptions(opts...),
+	)
+	rpcSignalingSendHandler := connect.NewUnaryHandler(
+		RpcSignalingSendProcedure,
+		svc.Send,
+		connect.WithSchema(rpcSignalingMethods.ByName("Send")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/signaling.RpcSignaling/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case RpcSignalingReceiveProcedure:
+			rpcSignalingReceiveHandler.ServeHTTP(w, r)
+		case RpcSignalingSendProcedure:
+			rpcSignalingSendHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedRpcSignalingHandler returns CodeUnimplemented from all methods.
+type UnimplementedRpcSignalingHandler struct{}
+
+func (UnimplementedRpcSignalingHandler) Receive(context.Context, *connect.Request[SignalingReceiveRequest], *connect.ServerStream[SignalingReceiveResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("signaling.RpcSignaling.Receive is not implemented"))
+}
+
+func (UnimplementedRpcSignalingHandler) Send(context.Context, *connect.Request[SignalingSendRequest]) (*connect.Response[SignalingSendResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("signaling.RpcSignaling.Send is not implemented"))
+}
@@ -0,0 +1,3730 @@
+//*
+// Standalone signaling server for the Nextcloud Spreed app.
+// Copyright (C) 2025 struktur AG
+//
+// @author Joachim Bauch <bauch@struktur.de>
+//
+// @license GNU AGPL version 3 or any later version
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: rpc_signaling.proto
+
+package signaling
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SignalingReceiveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingReceiveRequest) Reset() {
+	*x = SignalingReceiveRequest{}
+	mi := &file_rpc_signaling_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingReceiveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingReceiveRequest) ProtoMessage() {}
+
+func (x *SignalingReceiveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingReceiveRequest.ProtoReflect.Descriptor instead.
+func (*SignalingReceiveRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{0}
+}
+
+type SignalingReceiveResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*SignalingReceiveResponse_Connectionid
+	//	*SignalingReceiveResponse_Message
+	Response      isSignalingReceiveResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingReceiveResponse) Reset() {
+	*x = SignalingReceiveResponse{}
+	mi := &file_rpc_signaling_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingReceiveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingReceiveResponse) ProtoMessage() {}
+
+func (x *SignalingReceiveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingReceiveResponse.ProtoReflect.Descriptor instead.
+func (*SignalingReceiveResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SignalingReceiveResponse) GetResponse() isSignalingReceiveResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *SignalingReceiveResponse) GetConnectionid() string {
+	if x != nil {
+		if x, ok := x.Response.(*SignalingReceiveResponse_Connectionid); ok {
+			return x.Connectionid
+		}
+	}
+	return ""
+}
+
+func (x *SignalingReceiveResponse) GetMessage() *SignalingServerMessage {
+	if x != nil {
+		if x, ok := x.Response.(*SignalingReceiveResponse_Message); ok {
+			return x.Message
+		}
+	}
+	return nil
+}
+
+type isSignalingReceiveResponse_Response interface {
+	isSignalingReceiveResponse_Response()
+}
+
+type SignalingReceiveResponse_Connectionid struct {
+	Connectionid string `protobuf:"bytes,1,opt,name=connectionid,proto3,oneof"`
+}
+
+type SignalingReceiveResponse_Message struct {
+	Message *SignalingServerMessage `protobuf:"bytes,2,opt,name=message,proto3,oneof"`
+}
+
+func (*SignalingReceiveResponse_Connectionid) isSignalingReceiveResponse_Response() {}
+
+func (*SignalingReceiveResponse_Message) isSignalingReceiveResponse_Response() {}
+
+type SignalingSendRequest struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Connectionid  string                  `protobuf:"bytes,1,opt,name=connectionid,proto3" json:"connectionid,omitempty"`
+	Message       *SignalingClientMessage `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingSendRequest) Reset() {
+	*x = SignalingSendRequest{}
+	mi := &file_rpc_signaling_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingSendRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingSendRequest) ProtoMessage() {}
+
+func (x *SignalingSendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingSendRequest.ProtoReflect.Descriptor instead.
+func (*SignalingSendRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SignalingSendRequest) GetConnectionid() string {
+	if x != nil {
+		return x.Connectionid
+	}
+	return ""
+}
+
+func (x *SignalingSendRequest) GetMessage() *SignalingClientMessage {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+type SignalingSendResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingSendResponse) Reset() {
+	*x = SignalingSendResponse{}
+	mi := &file_rpc_signaling_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingSendResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingSendResponse) ProtoMessage() {}
+
+func (x *SignalingSendResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingSendResponse.ProtoReflect.Descriptor instead.
+func (*SignalingSendResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{3}
+}
+
+type SignalingRecipient struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Sessionid     string                 `protobuf:"bytes,2,opt,name=sessionid,proto3" json:"sessionid,omitempty"`
+	Sessionids    []string               `protobuf:"bytes,3,rep,name=sessionids,proto3" json:"sessionids,omitempty"`
+	Userid        string                 `protobuf:"bytes,4,opt,name=userid,proto3" json:"userid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingRecipient) Reset() {
+	*x = SignalingRecipient{}
+	mi := &file_rpc_signaling_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingRecipient) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingRecipient) ProtoMessage() {}
+
+func (x *SignalingRecipient) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingRecipient.ProtoReflect.Descriptor instead.
+func (*SignalingRecipient) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SignalingRecipient) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SignalingRecipient) GetSessionid() string {
+	if x != nil {
+		return x.Sessionid
+	}
+	return ""
+}
+
+func (x *SignalingRecipient) GetSessionids() []string {
+	if x != nil {
+		return x.Sessionids
+	}
+	return nil
+}
+
+func (x *SignalingRecipient) GetUserid() string {
+	if x != nil {
+		return x.Userid
+	}
+	return ""
+}
+
+type SignalingSender struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Sessionid     string                 `protobuf:"bytes,2,opt,name=sessionid,proto3" json:"sessionid,omitempty"`
+	Userid        string                 `protobuf:"bytes,3,opt,name=userid,proto3" json:"userid,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingSender) Reset() {
+	*x = SignalingSender{}
+	mi := &file_rpc_signaling_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingSender) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingSender) ProtoMessage() {}
+
+func (x *SignalingSender) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingSender.ProtoReflect.Descriptor instead.
+func (*SignalingSender) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SignalingSender) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SignalingSender) GetSessionid() string {
+	if x != nil {
+		return x.Sessionid
+	}
+	return ""
+}
+
+func (x *SignalingSender) GetUserid() string {
+	if x != nil {
+		return x.Userid
+	}
+	return ""
+}
+
+type SignalingClientMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The type of the message is the name of the field that is set.
+	//
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*SignalingClientMessage_Hello_
+	//	*SignalingClientMessage_Bye_
+	//	*SignalingClientMessage_Room_
+	//	*SignalingClientMessage_Message_
+	//	*SignalingClientMessage_Control
+	//	*SignalingClientMessage_Transient
+	//	*SignalingClientMessage_Participants_
+	//	*SignalingClientMessage_Echo_
+	//	*SignalingClientMessage_Dtmf_
+	//	*SignalingClientMessage_Recording_
+	Payload       isSignalingClientMessage_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingClientMessage) Reset() {
+	*x = SignalingClientMessage{}
+	mi := &file_rpc_signaling_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingClientMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingClientMessage) ProtoMessage() {}
+
+func (x *SignalingClientMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingClientMessage.ProtoReflect.Descriptor instead.
+func (*SignalingClientMessage) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SignalingClientMessage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SignalingClientMessage) GetPayload() isSignalingClientMessage_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *SignalingClientMessage) GetHello() *SignalingClientMessage_Hello {
+	if x != nil {
+		if x, ok := x.Payload.(*SignalingClientMessage_Hello_); ok {
+			return x.Hello
+		}
+	}
+	return nil
+}
+
+func (x *SignalingClientMessage) GetBye() *SignalingClientMessage_Bye {
+	if x != nil {
+		if x, ok := x.Payload.(*SignalingClientMessage_Bye_); ok {
+			return x.Bye
+		}
+	}
+	return nil
+}
+
+func (x *SignalingClientMessage) GetRoom() *SignalingClientMessage_Room {
+	if x != nil {
+		if x, ok := x.Payload.(*SignalingClientMessage_Room_); ok {
+			return x.Room
+		}
+	}
+	return nil
+}
+
+func (x *SignalingClientMessage) GetMessage() *SignalingClientMessage_Message {
+	if x != nil {
+		if x, ok := x.Payload.(*SignalingClientMessage_Message_); ok {
+			return x.Message
+		}
+	}
+	return nil
+}
+
+func (x *SignalingClientMessage) GetControl() *SignalingClientMessage_Message {
+	if x != nil {
+		if x, ok := x.Payload.(*SignalingClientMessage_Control); ok {
+			return x.Control
+		}
+	}
+	return nil
+}
+
+func (x *SignalingClientMessage) GetTransient() *SignalingClientMessage_TransientData {
+	if x != nil {
+		if x, ok := x.Payload.(*SignalingClientMessage_Transient); ok {
+			return x.Transient
+		}
+	}
+	return nil
+}
+
+func (x *SignalingClientMessage) GetParticipants() *SignalingClientMessage_Participants {
+	if x != nil {
+		if x, ok := x.Payload.(*SignalingClientMessage_Participants_); ok {
+			return x.Participants
+		}
+	}
+	return nil
+}
+
+func (x *SignalingClientMessage) GetEcho() *SignalingClientMessage_Echo {
+	if x != nil {
+		if x, ok := x.Payload.(*SignalingClientMessage_Echo_); ok {
+			return x.Echo
+		}
+	}
+	return nil
+}
+
+func (x *SignalingClientMessage) GetDtmf() *SignalingClientMessage_Dtmf {
+	if x != nil {
+		if x, ok := x.Payload.(*SignalingClientMessage_Dtmf_); ok {
+			return x.Dtmf
+		}
+	}
+	return nil
+}
+
+func (x *SignalingClientMessage) GetRecording() *SignalingClientMessage_Recording {
+	if x != nil {
+		if x, ok := x.Payload.(*SignalingClientMessage_Recording_); ok {
+			return x.Recording
+		}
+	}
+	return nil
+}
+
+type isSignalingClientMessage_Payload interface {
+	isSignalingClientMessage_Payload()
+}
+
+type SignalingClientMessage_Hello_ struct {
+	Hello *SignalingClientMessage_Hello `protobuf:"bytes,2,opt,name=hello,proto3,oneof"`
+}
+
+type SignalingClientMessage_Bye_ struct {
+	Bye *SignalingClientMessage_Bye `protobuf:"bytes,3,opt,name=bye,proto3,oneof"`
+}
+
+type SignalingClientMessage_Room_ struct {
+	Room *SignalingClientMessage_Room `protobuf:"bytes,4,opt,name=room,proto3,oneof"`
+}
+
+type SignalingClientMessage_Message_ struct {
+	Message *SignalingClientMessage_Message `protobuf:"bytes,5,opt,name=message,proto3,oneof"`
+}
+
+type SignalingClientMessage_Control struct {
+	Control *SignalingClientMessage_Message `protobuf:"bytes,6,opt,name=control,proto3,oneof"`
+}
+
+type SignalingClientMessage_Transient struct {
+	Transient *SignalingClientMessage_TransientData `protobuf:"bytes,7,opt,name=transient,proto3,oneof"`
+}
+
+type SignalingClientMessage_Participants_ struct {
+	Participants *SignalingClientMessage_Participants `protobuf:"bytes,8,opt,name=participants,proto3,oneof"`
+}
+
+type SignalingClientMessage_Echo_ struct {
+	Echo *SignalingClientMessage_Echo `protobuf:"bytes,9,opt,name=echo,proto3,oneof"`
+}
+
+type SignalingClientMessage_Dtmf_ struct {
+	Dtmf *SignalingClientMessage_Dtmf `protobuf:"bytes,10,opt,name=dtmf,proto3,oneof"`
+}
+
+type SignalingClientMessage_Recording_ struct {
+	Recording *SignalingClientMessage_Recording `protobuf:"bytes,11,opt,name=recording,proto3,oneof"`
+}
+
+func (*SignalingClientMessage_Hello_) isSignalingClientMessage_Payload() {}
+
+func (*SignalingClientMessage_Bye_) isSignalingClientMessage_Payload() {}
+
+func (*SignalingClientMessage_Room_) isSignalingClientMessage_Payload() {}
+
+func (*SignalingClientMessage_Message_) isSignalingClientMessage_Payload() {}
+
+func (*SignalingClientMessage_Control) isSignalingClientMessage_Payload() {}
+
+func (*SignalingClientMessage_Transient) isSignalingClientMessage_Payload() {}
+
+func (*SignalingClientMessage_Participants_) isSignalingClientMessage_Payload() {}
+
+func (*SignalingClientMessage_Echo_) isSignalingClientMessage_Payload() {}
+
+func (*SignalingClientMessage_Dtmf_) isSignalingClientMessage_Payload() {}
+
+func (*SignalingClientMessage_Recording_) isSignalingClientMessage_Payload() {}
+
+type SignalingServerTime struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Now           int64                  `protobuf:"varint,1,opt,name=now,proto3" json:"now,omitempty"`
+	Monotonic     int64                  `protobuf:"varint,2,opt,name=monotonic,proto3" json:"monotonic,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingServerTime) Reset() {
+	*x = SignalingServerTime{}
+	mi := &file_rpc_signaling_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingServerTime) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingServerTime) ProtoMessage() {}
+
+func (x *SignalingServerTime) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingServerTime.ProtoReflect.Descriptor instead.
+func (*SignalingServerTime) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SignalingServerTime) GetNow() int64 {
+	if x != nil {
+		return x.Now
+	}
+	return 0
+}
+
+func (x *SignalingServerTime) GetMonotonic() int64 {
+	if x != nil {
+		return x.Monotonic
+	}
+	return 0
+}
+
+type SignalingSessionEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessionid     string                 `protobuf:"bytes,1,opt,name=sessionid,proto3" json:"sessionid,omitempty"`
+	Userid        string                 `protobuf:"bytes,2,opt,name=userid,proto3" json:"userid,omitempty"`
+	Features      []string               `protobuf:"bytes,3,rep,name=features,proto3" json:"features,omitempty"`
+	User          *structpb.Value        `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
+	Roomsessionid string                 `protobuf:"bytes,5,opt,name=roomsessionid,proto3" json:"roomsessionid,omitempty"`
+	Federated     bool                   `protobuf:"varint,6,opt,name=federated,proto3" json:"federated,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingSessionEntry) Reset() {
+	*x = SignalingSessionEntry{}
+	mi := &file_rpc_signaling_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingSessionEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingSessionEntry) ProtoMessage() {}
+
+func (x *SignalingSessionEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingSessionEntry.ProtoReflect.Descriptor instead.
+func (*SignalingSessionEntry) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SignalingSessionEntry) GetSessionid() string {
+	if x != nil {
+		return x.Sessionid
+	}
+	return ""
+}
+
+func (x *SignalingSessionEntry) GetUserid() string {
+	if x != nil {
+		return x.Userid
+	}
+	return ""
+}
+
+func (x *SignalingSessionEntry) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *SignalingSessionEntry) GetUser() *structpb.Value {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *SignalingSessionEntry) GetRoomsessionid() string {
+	if x != nil {
+		return x.Roomsessionid
+	}
+	return ""
+}
+
+func (x *SignalingSessionEntry) GetFederated() bool {
+	if x != nil {
+		return x.Federated
+	}
+	return false
+}
+
+type SignalingRoomEvent struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Roomid     string                 `protobuf:"bytes,1,opt,name=roomid,proto3" json:"roomid,omitempty"`
+	Properties *structpb.Value        `protobuf:"bytes,2,opt,name=properties,proto3" json:"properties,omitempty"`
+	Incall     *structpb.Value        `protobuf:"bytes,3,opt,name=incall,proto3" json:"incall,omitempty"`
+	Changed    []*structpb.Struct     `protobuf:"bytes,4,rep,name=changed,proto3" json:"changed,omitempty"`
+	Users      []*structpb.Struct     `protobuf:"bytes,5,rep,name=users,proto3" json:"users,omitempty"`
+	All        bool                   `protobuf:"varint,6,opt,name=all,proto3" json:"all,omitempty"`
+	Version    uint64                 `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
+	Delta      bool                   `protobuf:"varint,8,opt,name=delta,proto3" json:"delta,omitempty"`
+	Removed    []string               `protobuf:"bytes,9,rep,name=removed,proto3" json:"removed,omitempty"`
+	// Only set for "disinvite" events.
+	Reason        string `protobuf:"bytes,10,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingRoomEvent) Reset() {
+	*x = SignalingRoomEvent{}
+	mi := &file_rpc_signaling_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingRoomEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingRoomEvent) ProtoMessage() {}
+
+func (x *SignalingRoomEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingRoomEvent.ProtoReflect.Descriptor instead.
+func (*SignalingRoomEvent) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SignalingRoomEvent) GetRoomid() string {
+	if x != nil {
+		return x.Roomid
+	}
+	return ""
+}
+
+func (x *SignalingRoomEvent) GetProperties() *structpb.Value {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *SignalingRoomEvent) GetIncall() *structpb.Value {
+	if x != nil {
+		return x.Incall
+	}
+	return nil
+}
+
+func (x *SignalingRoomEvent) GetChanged() []*structpb.Struct {
+	if x != nil {
+		return x.Changed
+	}
+	return nil
+}
+
+func (x *SignalingRoomEvent) GetUsers() []*structpb.Struct {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *SignalingRoomEvent) GetAll() bool {
+	if x != nil {
+		return x.All
+	}
+	return false
+}
+
+func (x *SignalingRoomEvent) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *SignalingRoomEvent) GetDelta() bool {
+	if x != nil {
+		return x.Delta
+	}
+	return false
+}
+
+func (x *SignalingRoomEvent) GetRemoved() []string {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+func (x *SignalingRoomEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type SignalingServerMessage struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// The type of the message is the name of the field that is set.
+	//
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*SignalingServerMessage_Error_
+	//	*SignalingServerMessage_Welcome_
+	//	*SignalingServerMessage_Hello_
+	//	*SignalingServerMessage_Bye_
+	//	*SignalingServerMessage_Room_
+	//	*SignalingServerMessage_Message_
+	//	*SignalingServerMessage_Control
+	//	*SignalingServerMessage_Event_
+	//	*SignalingServerMessage_Transient
+	//	*SignalingServerMessage_Echo_
+	Payload       isSignalingServerMessage_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingServerMessage) Reset() {
+	*x = SignalingServerMessage{}
+	mi := &file_rpc_signaling_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingServerMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingServerMessage) ProtoMessage() {}
+
+func (x *SignalingServerMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingServerMessage.ProtoReflect.Descriptor instead.
+func (*SignalingServerMessage) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SignalingServerMessage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage) GetPayload() isSignalingServerMessage_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage) GetError() *SignalingServerMessage_Error {
+	if x != nil {
+		if x, ok := x.Payload.(*SignalingServerMessage_Error_); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage) GetWelcome() *SignalingServerMessage_Welcome {
+	if x != nil {
+		if x, ok := x.Payload.(*SignalingServerMessage_Welcome_); ok {
+			return x.Welcome
+		}
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage) GetHello() *SignalingServerMessage_Hello {
+	if x != nil {
+		if x, ok := x.Payload.(*SignalingServerMessage_Hello_); ok {
+			return x.Hello
+		}
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage) GetBye() *SignalingServerMessage_Bye {
+	if x != nil {
+		if x, ok := x.Payload.(*SignalingServerMessage_Bye_); ok {
+			return x.Bye
+		}
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage) GetRoom() *SignalingServerMessage_Room {
+	if x != nil {
+		if x, ok := x.Payload.(*SignalingServerMessage_Room_); ok {
+			return x.Room
+		}
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage) GetMessage() *SignalingServerMessage_Message {
+	if x != nil {
+		if x, ok := x.Payload.(*SignalingServerMessage_Message_); ok {
+			return x.Message
+		}
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage) GetControl() *SignalingServerMessage_Message {
+	if x != nil {
+		if x, ok := x.Payload.(*SignalingServerMessage_Control); ok {
+			return x.Control
+		}
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage) GetEvent() *SignalingServerMessage_Event {
+	if x != nil {
+		if x, ok := x.Payload.(*SignalingServerMessage_Event_); ok {
+			return x.Event
+		}
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage) GetTransient() *SignalingServerMessage_TransientData {
+	if x != nil {
+		if x, ok := x.Payload.(*SignalingServerMessage_Transient); ok {
+			return x.Transient
+		}
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage) GetEcho() *SignalingServerMessage_Echo {
+	if x != nil {
+		if x, ok := x.Payload.(*SignalingServerMessage_Echo_); ok {
+			return x.Echo
+		}
+	}
+	return nil
+}
+
+type isSignalingServerMessage_Payload interface {
+	isSignalingServerMessage_Payload()
+}
+
+type SignalingServerMessage_Error_ struct {
+	Error *SignalingServerMessage_Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+type SignalingServerMessage_Welcome_ struct {
+	Welcome *SignalingServerMessage_Welcome `protobuf:"bytes,3,opt,name=welcome,proto3,oneof"`
+}
+
+type SignalingServerMessage_Hello_ struct {
+	Hello *SignalingServerMessage_Hello `protobuf:"bytes,4,opt,name=hello,proto3,oneof"`
+}
+
+type SignalingServerMessage_Bye_ struct {
+	Bye *SignalingServerMessage_Bye `protobuf:"bytes,5,opt,name=bye,proto3,oneof"`
+}
+
+type SignalingServerMessage_Room_ struct {
+	Room *SignalingServerMessage_Room `protobuf:"bytes,6,opt,name=room,proto3,oneof"`
+}
+
+type SignalingServerMessage_Message_ struct {
+	Message *SignalingServerMessage_Message `protobuf:"bytes,7,opt,name=message,proto3,oneof"`
+}
+
+type SignalingServerMessage_Control struct {
+	Control *SignalingServerMessage_Message `protobuf:"bytes,8,opt,name=control,proto3,oneof"`
+}
+
+type SignalingServerMessage_Event_ struct {
+	Event *SignalingServerMessage_Event `protobuf:"bytes,9,opt,name=event,proto3,oneof"`
+}
+
+type SignalingServerMessage_Transient struct {
+	Transient *SignalingServerMessage_TransientData `protobuf:"bytes,10,opt,name=transient,proto3,oneof"`
+}
+
+type SignalingServerMessage_Echo_ struct {
+	Echo *SignalingServerMessage_Echo `protobuf:"bytes,11,opt,name=echo,proto3,oneof"`
+}
+
+func (*SignalingServerMessage_Error_) isSignalingServerMessage_Payload() {}
+
+func (*SignalingServerMessage_Welcome_) isSignalingServerMessage_Payload() {}
+
+func (*SignalingServerMessage_Hello_) isSignalingServerMessage_Payload() {}
+
+func (*SignalingServerMessage_Bye_) isSignalingServerMessage_Payload() {}
+
+func (*SignalingServerMessage_Room_) isSignalingServerMessage_Payload() {}
+
+func (*SignalingServerMessage_Message_) isSignalingServerMessage_Payload() {}
+
+func (*SignalingServerMessage_Control) isSignalingServerMessage_Payload() {}
+
+func (*SignalingServerMessage_Event_) isSignalingServerMessage_Payload() {}
+
+func (*SignalingServerMessage_Transient) isSignalingServerMessage_Payload() {}
+
+func (*SignalingServerMessage_Echo_) isSignalingServerMessage_Payload() {}
+
+type SignalingClientMessage_Hello struct {
+	state         protoimpl.MessageState              `protogen:"open.v1"`
+	Version       string                              `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Resumeid      string                              `protobuf:"bytes,2,opt,name=resumeid,proto3" json:"resumeid,omitempty"`
+	Features      []string                            `protobuf:"bytes,3,rep,name=features,proto3" json:"features,omitempty"`
+	Pinginterval  int32                               `protobuf:"varint,4,opt,name=pinginterval,proto3" json:"pinginterval,omitempty"`
+	Hints         *SignalingClientMessage_Hello_Hints `protobuf:"bytes,5,opt,name=hints,proto3" json:"hints,omitempty"`
+	Language      string                              `protobuf:"bytes,6,opt,name=language,proto3" json:"language,omitempty"`
+	Auth          *SignalingClientMessage_Hello_Auth  `protobuf:"bytes,7,opt,name=auth,proto3" json:"auth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingClientMessage_Hello) Reset() {
+	*x = SignalingClientMessage_Hello{}
+	mi := &file_rpc_signaling_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingClientMessage_Hello) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingClientMessage_Hello) ProtoMessage() {}
+
+func (x *SignalingClientMessage_Hello) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingClientMessage_Hello.ProtoReflect.Descriptor instead.
+func (*SignalingClientMessage_Hello) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{6, 0}
+}
+
+func (x *SignalingClientMessage_Hello) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *SignalingClientMessage_Hello) GetResumeid() string {
+	if x != nil {
+		return x.Resumeid
+	}
+	return ""
+}
+
+func (x *SignalingClientMessage_Hello) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *SignalingClientMessage_Hello) GetPinginterval() int32 {
+	if x != nil {
+		return x.Pinginterval
+	}
+	return 0
+}
+
+func (x *SignalingClientMessage_Hello) GetHints() *SignalingClientMessage_Hello_Hints {
+	if x != nil {
+		return x.Hints
+	}
+	return nil
+}
+
+func (x *SignalingClientMessage_Hello) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *SignalingClientMessage_Hello) GetAuth() *SignalingClientMessage_Hello_Auth {
+	if x != nil {
+		return x.Auth
+	}
+	return nil
+}
+
+type SignalingClientMessage_Bye struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingClientMessage_Bye) Reset() {
+	*x = SignalingClientMessage_Bye{}
+	mi := &file_rpc_signaling_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingClientMessage_Bye) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingClientMessage_Bye) ProtoMessage() {}
+
+func (x *SignalingClientMessage_Bye) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingClientMessage_Bye.ProtoReflect.Descriptor instead.
+func (*SignalingClientMessage_Bye) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{6, 1}
+}
+
+type SignalingClientMessage_Room struct {
+	state         protoimpl.MessageState                  `protogen:"open.v1"`
+	Roomid        string                                  `protobuf:"bytes,1,opt,name=roomid,proto3" json:"roomid,omitempty"`
+	Sessionid     string                                  `protobuf:"bytes,2,opt,name=sessionid,proto3" json:"sessionid,omitempty"`
+	Federation    *SignalingClientMessage_Room_Federation `protobuf:"bytes,3,opt,name=federation,proto3" json:"federation,omitempty"`
+	Secondary     bool                                    `protobuf:"varint,4,opt,name=secondary,proto3" json:"secondary,omitempty"`
+	Leave         bool                                    `protobuf:"varint,5,opt,name=leave,proto3" json:"leave,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingClientMessage_Room) Reset() {
+	*x = SignalingClientMessage_Room{}
+	mi := &file_rpc_signaling_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingClientMessage_Room) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingClientMessage_Room) ProtoMessage() {}
+
+func (x *SignalingClientMessage_Room) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingClientMessage_Room.ProtoReflect.Descriptor instead.
+func (*SignalingClientMessage_Room) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{6, 2}
+}
+
+func (x *SignalingClientMessage_Room) GetRoomid() string {
+	if x != nil {
+		return x.Roomid
+	}
+	return ""
+}
+
+func (x *SignalingClientMessage_Room) GetSessionid() string {
+	if x != nil {
+		return x.Sessionid
+	}
+	return ""
+}
+
+func (x *SignalingClientMessage_Room) GetFederation() *SignalingClientMessage_Room_Federation {
+	if x != nil {
+		return x.Federation
+	}
+	return nil
+}
+
+func (x *SignalingClientMessage_Room) GetSecondary() bool {
+	if x != nil {
+		return x.Secondary
+	}
+	return false
+}
+
+func (x *SignalingClientMessage_Room) GetLeave() bool {
+	if x != nil {
+		return x.Leave
+	}
+	return false
+}
+
+type SignalingClientMessage_Message struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recipient     *SignalingRecipient    `protobuf:"bytes,1,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Data          *structpb.Value        `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingClientMessage_Message) Reset() {
+	*x = SignalingClientMessage_Message{}
+	mi := &file_rpc_signaling_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingClientMessage_Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingClientMessage_Message) ProtoMessage() {}
+
+func (x *SignalingClientMessage_Message) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingClientMessage_Message.ProtoReflect.Descriptor instead.
+func (*SignalingClientMessage_Message) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{6, 3}
+}
+
+func (x *SignalingClientMessage_Message) GetRecipient() *SignalingRecipient {
+	if x != nil {
+		return x.Recipient
+	}
+	return nil
+}
+
+func (x *SignalingClientMessage_Message) GetData() *structpb.Value {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type SignalingClientMessage_TransientData struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Key   string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value *structpb.Value        `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	// Time to live in nanoseconds.
+	Ttl           int64 `protobuf:"varint,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingClientMessage_TransientData) Reset() {
+	*x = SignalingClientMessage_TransientData{}
+	mi := &file_rpc_signaling_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingClientMessage_TransientData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingClientMessage_TransientData) ProtoMessage() {}
+
+func (x *SignalingClientMessage_TransientData) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingClientMessage_TransientData.ProtoReflect.Descriptor instead.
+func (*SignalingClientMessage_TransientData) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{6, 4}
+}
+
+func (x *SignalingClientMessage_TransientData) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SignalingClientMessage_TransientData) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SignalingClientMessage_TransientData) GetValue() *structpb.Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *SignalingClientMessage_TransientData) GetTtl() int64 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
+type SignalingClientMessage_Participants struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       uint64                 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingClientMessage_Participants) Reset() {
+	*x = SignalingClientMessage_Participants{}
+	mi := &file_rpc_signaling_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingClientMessage_Participants) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingClientMessage_Participants) ProtoMessage() {}
+
+func (x *SignalingClientMessage_Participants) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingClientMessage_Participants.ProtoReflect.Descriptor instead.
+func (*SignalingClientMessage_Participants) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{6, 5}
+}
+
+func (x *SignalingClientMessage_Participants) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type SignalingClientMessage_Echo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp     int64                  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Rtt           int64                  `protobuf:"varint,2,opt,name=rtt,proto3" json:"rtt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingClientMessage_Echo) Reset() {
+	*x = SignalingClientMessage_Echo{}
+	mi := &file_rpc_signaling_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingClientMessage_Echo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingClientMessage_Echo) ProtoMessage() {}
+
+func (x *SignalingClientMessage_Echo) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingClientMessage_Echo.ProtoReflect.Descriptor instead.
+func (*SignalingClientMessage_Echo) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{6, 6}
+}
+
+func (x *SignalingClientMessage_Echo) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *SignalingClientMessage_Echo) GetRtt() int64 {
+	if x != nil {
+		return x.Rtt
+	}
+	return 0
+}
+
+type SignalingClientMessage_Dtmf struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessionid     string                 `protobuf:"bytes,1,opt,name=sessionid,proto3" json:"sessionid,omitempty"`
+	Digits        string                 `protobuf:"bytes,2,opt,name=digits,proto3" json:"digits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingClientMessage_Dtmf) Reset() {
+	*x = SignalingClientMessage_Dtmf{}
+	mi := &file_rpc_signaling_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingClientMessage_Dtmf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingClientMessage_Dtmf) ProtoMessage() {}
+
+func (x *SignalingClientMessage_Dtmf) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingClientMessage_Dtmf.ProtoReflect.Descriptor instead.
+func (*SignalingClientMessage_Dtmf) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{6, 7}
+}
+
+func (x *SignalingClientMessage_Dtmf) GetSessionid() string {
+	if x != nil {
+		return x.Sessionid
+	}
+	return ""
+}
+
+func (x *SignalingClientMessage_Dtmf) GetDigits() string {
+	if x != nil {
+		return x.Digits
+	}
+	return ""
+}
+
+type SignalingClientMessage_Recording struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        string                 `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	Options       *structpb.Struct       `protobuf:"bytes,2,opt,name=options,proto3" json:"options,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingClientMessage_Recording) Reset() {
+	*x = SignalingClientMessage_Recording{}
+	mi := &file_rpc_signaling_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingClientMessage_Recording) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingClientMessage_Recording) ProtoMessage() {}
+
+func (x *SignalingClientMessage_Recording) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingClientMessage_Recording.ProtoReflect.Descriptor instead.
+func (*SignalingClientMessage_Recording) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{6, 8}
+}
+
+func (x *SignalingClientMessage_Recording) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *SignalingClientMessage_Recording) GetOptions() *structpb.Struct {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type SignalingClientMessage_Hello_Hints struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Maxdownlink   int32                  `protobuf:"varint,1,opt,name=maxdownlink,proto3" json:"maxdownlink,omitempty"`
+	Batterysaver  bool                   `protobuf:"varint,2,opt,name=batterysaver,proto3" json:"batterysaver,omitempty"`
+	Mobile        bool                   `protobuf:"varint,3,opt,name=mobile,proto3" json:"mobile,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingClientMessage_Hello_Hints) Reset() {
+	*x = SignalingClientMessage_Hello_Hints{}
+	mi := &file_rpc_signaling_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingClientMessage_Hello_Hints) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingClientMessage_Hello_Hints) ProtoMessage() {}
+
+func (x *SignalingClientMessage_Hello_Hints) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingClientMessage_Hello_Hints.ProtoReflect.Descriptor instead.
+func (*SignalingClientMessage_Hello_Hints) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{6, 0, 0}
+}
+
+func (x *SignalingClientMessage_Hello_Hints) GetMaxdownlink() int32 {
+	if x != nil {
+		return x.Maxdownlink
+	}
+	return 0
+}
+
+func (x *SignalingClientMessage_Hello_Hints) GetBatterysaver() bool {
+	if x != nil {
+		return x.Batterysaver
+	}
+	return false
+}
+
+func (x *SignalingClientMessage_Hello_Hints) GetMobile() bool {
+	if x != nil {
+		return x.Mobile
+	}
+	return false
+}
+
+type SignalingClientMessage_Hello_Auth struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Params        *structpb.Struct       `protobuf:"bytes,2,opt,name=params,proto3" json:"params,omitempty"`
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingClientMessage_Hello_Auth) Reset() {
+	*x = SignalingClientMessage_Hello_Auth{}
+	mi := &file_rpc_signaling_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingClientMessage_Hello_Auth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingClientMessage_Hello_Auth) ProtoMessage() {}
+
+func (x *SignalingClientMessage_Hello_Auth) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingClientMessage_Hello_Auth.ProtoReflect.Descriptor instead.
+func (*SignalingClientMessage_Hello_Auth) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{6, 0, 1}
+}
+
+func (x *SignalingClientMessage_Hello_Auth) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SignalingClientMessage_Hello_Auth) GetParams() *structpb.Struct {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *SignalingClientMessage_Hello_Auth) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type SignalingClientMessage_Room_Federation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Signaling     string                 `protobuf:"bytes,1,opt,name=signaling,proto3" json:"signaling,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Roomid        string                 `protobuf:"bytes,3,opt,name=roomid,proto3" json:"roomid,omitempty"`
+	Token         string                 `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingClientMessage_Room_Federation) Reset() {
+	*x = SignalingClientMessage_Room_Federation{}
+	mi := &file_rpc_signaling_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingClientMessage_Room_Federation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingClientMessage_Room_Federation) ProtoMessage() {}
+
+func (x *SignalingClientMessage_Room_Federation) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingClientMessage_Room_Federation.ProtoReflect.Descriptor instead.
+func (*SignalingClientMessage_Room_Federation) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{6, 2, 0}
+}
+
+func (x *SignalingClientMessage_Room_Federation) GetSignaling() string {
+	if x != nil {
+		return x.Signaling
+	}
+	return ""
+}
+
+func (x *SignalingClientMessage_Room_Federation) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *SignalingClientMessage_Room_Federation) GetRoomid() string {
+	if x != nil {
+		return x.Roomid
+	}
+	return ""
+}
+
+func (x *SignalingClientMessage_Room_Federation) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type SignalingServerMessage_Error struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Params        *structpb.Struct       `protobuf:"bytes,3,opt,name=params,proto3" json:"params,omitempty"`
+	Localized     string                 `protobuf:"bytes,4,opt,name=localized,proto3" json:"localized,omitempty"`
+	Details       *structpb.Value        `protobuf:"bytes,5,opt,name=details,proto3" json:"details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingServerMessage_Error) Reset() {
+	*x = SignalingServerMessage_Error{}
+	mi := &file_rpc_signaling_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingServerMessage_Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingServerMessage_Error) ProtoMessage() {}
+
+func (x *SignalingServerMessage_Error) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingServerMessage_Error.ProtoReflect.Descriptor instead.
+func (*SignalingServerMessage_Error) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{10, 0}
+}
+
+func (x *SignalingServerMessage_Error) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Error) GetParams() *structpb.Struct {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_Error) GetLocalized() string {
+	if x != nil {
+		return x.Localized
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Error) GetDetails() *structpb.Value {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+type SignalingServerMessage_Welcome struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Features      []string               `protobuf:"bytes,2,rep,name=features,proto3" json:"features,omitempty"`
+	Country       string                 `protobuf:"bytes,3,opt,name=country,proto3" json:"country,omitempty"`
+	Time          *SignalingServerTime   `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingServerMessage_Welcome) Reset() {
+	*x = SignalingServerMessage_Welcome{}
+	mi := &file_rpc_signaling_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingServerMessage_Welcome) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingServerMessage_Welcome) ProtoMessage() {}
+
+func (x *SignalingServerMessage_Welcome) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingServerMessage_Welcome.ProtoReflect.Descriptor instead.
+func (*SignalingServerMessage_Welcome) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{10, 1}
+}
+
+func (x *SignalingServerMessage_Welcome) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Welcome) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_Welcome) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Welcome) GetTime() *SignalingServerTime {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+type SignalingServerMessage_Hello struct {
+	state         protoimpl.MessageState          `protogen:"open.v1"`
+	Version       string                          `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Sessionid     string                          `protobuf:"bytes,2,opt,name=sessionid,proto3" json:"sessionid,omitempty"`
+	Resumeid      string                          `protobuf:"bytes,3,opt,name=resumeid,proto3" json:"resumeid,omitempty"`
+	Userid        string                          `protobuf:"bytes,4,opt,name=userid,proto3" json:"userid,omitempty"`
+	Pinginterval  int32                           `protobuf:"varint,5,opt,name=pinginterval,proto3" json:"pinginterval,omitempty"`
+	Heartbeat     int32                           `protobuf:"varint,6,opt,name=heartbeat,proto3" json:"heartbeat,omitempty"`
+	Time          *SignalingServerTime            `protobuf:"bytes,7,opt,name=time,proto3" json:"time,omitempty"`
+	Features      []string                        `protobuf:"bytes,8,rep,name=features,proto3" json:"features,omitempty"`
+	Server        *SignalingServerMessage_Welcome `protobuf:"bytes,9,opt,name=server,proto3" json:"server,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingServerMessage_Hello) Reset() {
+	*x = SignalingServerMessage_Hello{}
+	mi := &file_rpc_signaling_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingServerMessage_Hello) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingServerMessage_Hello) ProtoMessage() {}
+
+func (x *SignalingServerMessage_Hello) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingServerMessage_Hello.ProtoReflect.Descriptor instead.
+func (*SignalingServerMessage_Hello) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{10, 2}
+}
+
+func (x *SignalingServerMessage_Hello) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Hello) GetSessionid() string {
+	if x != nil {
+		return x.Sessionid
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Hello) GetResumeid() string {
+	if x != nil {
+		return x.Resumeid
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Hello) GetUserid() string {
+	if x != nil {
+		return x.Userid
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Hello) GetPinginterval() int32 {
+	if x != nil {
+		return x.Pinginterval
+	}
+	return 0
+}
+
+func (x *SignalingServerMessage_Hello) GetHeartbeat() int32 {
+	if x != nil {
+		return x.Heartbeat
+	}
+	return 0
+}
+
+func (x *SignalingServerMessage_Hello) GetTime() *SignalingServerTime {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_Hello) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_Hello) GetServer() *SignalingServerMessage_Welcome {
+	if x != nil {
+		return x.Server
+	}
+	return nil
+}
+
+type SignalingServerMessage_Bye struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reason        string                 `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	Reconnect     bool                   `protobuf:"varint,2,opt,name=reconnect,proto3" json:"reconnect,omitempty"`
+	Retryafter    int32                  `protobuf:"varint,3,opt,name=retryafter,proto3" json:"retryafter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingServerMessage_Bye) Reset() {
+	*x = SignalingServerMessage_Bye{}
+	mi := &file_rpc_signaling_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingServerMessage_Bye) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingServerMessage_Bye) ProtoMessage() {}
+
+func (x *SignalingServerMessage_Bye) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingServerMessage_Bye.ProtoReflect.Descriptor instead.
+func (*SignalingServerMessage_Bye) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{10, 3}
+}
+
+func (x *SignalingServerMessage_Bye) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Bye) GetReconnect() bool {
+	if x != nil {
+		return x.Reconnect
+	}
+	return false
+}
+
+func (x *SignalingServerMessage_Bye) GetRetryafter() int32 {
+	if x != nil {
+		return x.Retryafter
+	}
+	return 0
+}
+
+type SignalingServerMessage_Room struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roomid        string                 `protobuf:"bytes,1,opt,name=roomid,proto3" json:"roomid,omitempty"`
+	Properties    *structpb.Value        `protobuf:"bytes,2,opt,name=properties,proto3" json:"properties,omitempty"`
+	Secondary     bool                   `protobuf:"varint,3,opt,name=secondary,proto3" json:"secondary,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingServerMessage_Room) Reset() {
+	*x = SignalingServerMessage_Room{}
+	mi := &file_rpc_signaling_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingServerMessage_Room) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingServerMessage_Room) ProtoMessage() {}
+
+func (x *SignalingServerMessage_Room) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingServerMessage_Room.ProtoReflect.Descriptor instead.
+func (*SignalingServerMessage_Room) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{10, 4}
+}
+
+func (x *SignalingServerMessage_Room) GetRoomid() string {
+	if x != nil {
+		return x.Roomid
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Room) GetProperties() *structpb.Value {
+	if x != nil {
+		return x.Properties
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_Room) GetSecondary() bool {
+	if x != nil {
+		return x.Secondary
+	}
+	return false
+}
+
+type SignalingServerMessage_Message struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sender        *SignalingSender       `protobuf:"bytes,1,opt,name=sender,proto3" json:"sender,omitempty"`
+	Recipient     *SignalingRecipient    `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Data          *structpb.Value        `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingServerMessage_Message) Reset() {
+	*x = SignalingServerMessage_Message{}
+	mi := &file_rpc_signaling_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingServerMessage_Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingServerMessage_Message) ProtoMessage() {}
+
+func (x *SignalingServerMessage_Message) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingServerMessage_Message.ProtoReflect.Descriptor instead.
+func (*SignalingServerMessage_Message) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{10, 5}
+}
+
+func (x *SignalingServerMessage_Message) GetSender() *SignalingSender {
+	if x != nil {
+		return x.Sender
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_Message) GetRecipient() *SignalingRecipient {
+	if x != nil {
+		return x.Recipient
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_Message) GetData() *structpb.Value {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type SignalingServerMessage_Event struct {
+	state         protoimpl.MessageState                    `protogen:"open.v1"`
+	Target        string                                    `protobuf:"bytes,1,opt,name=target,proto3" json:"target,omitempty"`
+	Type          string                                    `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Roomid        string                                    `protobuf:"bytes,3,opt,name=roomid,proto3" json:"roomid,omitempty"`
+	Join          []*SignalingSessionEntry                  `protobuf:"bytes,4,rep,name=join,proto3" json:"join,omitempty"`
+	Leave         []string                                  `protobuf:"bytes,5,rep,name=leave,proto3" json:"leave,omitempty"`
+	Change        []*SignalingSessionEntry                  `protobuf:"bytes,6,rep,name=change,proto3" json:"change,omitempty"`
+	Switchto      *SignalingServerMessage_Event_SwitchTo    `protobuf:"bytes,7,opt,name=switchto,proto3" json:"switchto,omitempty"`
+	Dtmf          *SignalingServerMessage_Event_Dtmf        `protobuf:"bytes,8,opt,name=dtmf,proto3" json:"dtmf,omitempty"`
+	Recording     *SignalingServerMessage_Event_Recording   `protobuf:"bytes,9,opt,name=recording,proto3" json:"recording,omitempty"`
+	Caption       *SignalingServerMessage_Event_Caption     `protobuf:"bytes,10,opt,name=caption,proto3" json:"caption,omitempty"`
+	Degraded      *SignalingServerMessage_Event_Degraded    `protobuf:"bytes,11,opt,name=degraded,proto3" json:"degraded,omitempty"`
+	Resumed       *bool                                     `protobuf:"varint,12,opt,name=resumed,proto3,oneof" json:"resumed,omitempty"`
+	Chunk         *SignalingServerMessage_Event_Chunk       `protobuf:"bytes,13,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	Invite        *SignalingRoomEvent                       `protobuf:"bytes,14,opt,name=invite,proto3" json:"invite,omitempty"`
+	Disinvite     *SignalingRoomEvent                       `protobuf:"bytes,15,opt,name=disinvite,proto3" json:"disinvite,omitempty"`
+	Update        *SignalingRoomEvent                       `protobuf:"bytes,16,opt,name=update,proto3" json:"update,omitempty"`
+	Flags         *SignalingServerMessage_Event_Flags       `protobuf:"bytes,17,opt,name=flags,proto3" json:"flags,omitempty"`
+	Message       *SignalingServerMessage_Event_RoomMessage `protobuf:"bytes,18,opt,name=message,proto3" json:"message,omitempty"`
+	Lagging       *SignalingServerMessage_Event_Lagging     `protobuf:"bytes,19,opt,name=lagging,proto3" json:"lagging,omitempty"`
+	Permissions   *SignalingServerMessage_Event_Permissions `protobuf:"bytes,20,opt,name=permissions,proto3" json:"permissions,omitempty"`
+	Resync        *SignalingServerMessage_Event_Resync      `protobuf:"bytes,21,opt,name=resync,proto3" json:"resync,omitempty"`
+	Receipt       *SignalingServerMessage_Event_Receipt     `protobuf:"bytes,22,opt,name=receipt,proto3" json:"receipt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingServerMessage_Event) Reset() {
+	*x = SignalingServerMessage_Event{}
+	mi := &file_rpc_signaling_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingServerMessage_Event) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingServerMessage_Event) ProtoMessage() {}
+
+func (x *SignalingServerMessage_Event) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingServerMessage_Event.ProtoReflect.Descriptor instead.
+func (*SignalingServerMessage_Event) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{10, 6}
+}
+
+func (x *SignalingServerMessage_Event) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Event) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Event) GetRoomid() string {
+	if x != nil {
+		return x.Roomid
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Event) GetJoin() []*SignalingSessionEntry {
+	if x != nil {
+		return x.Join
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_Event) GetLeave() []string {
+	if x != nil {
+		return x.Leave
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_Event) GetChange() []*SignalingSessionEntry {
+	if x != nil {
+		return x.Change
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_Event) GetSwitchto() *SignalingServerMessage_Event_SwitchTo {
+	if x != nil {
+		return x.Switchto
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_Event) GetDtmf() *SignalingServerMessage_Event_Dtmf {
+	if x != nil {
+		return x.Dtmf
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_Event) GetRecording() *SignalingServerMessage_Event_Recording {
+	if x != nil {
+		return x.Recording
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_Event) GetCaption() *SignalingServerMessage_Event_Caption {
+	if x != nil {
+		return x.Caption
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_Event) GetDegraded() *SignalingServerMessage_Event_Degraded {
+	if x != nil {
+		return x.Degraded
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_Event) GetResumed() bool {
+	if x != nil && x.Resumed != nil {
+		return *x.Resumed
+	}
+	return false
+}
+
+func (x *SignalingServerMessage_Event) GetChunk() *SignalingServerMessage_Event_Chunk {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_Event) GetInvite() *SignalingRoomEvent {
+	if x != nil {
+		return x.Invite
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_Event) GetDisinvite() *SignalingRoomEvent {
+	if x != nil {
+		return x.Disinvite
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_Event) GetUpdate() *SignalingRoomEvent {
+	if x != nil {
+		return x.Update
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_Event) GetFlags() *SignalingServerMessage_Event_Flags {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_Event) GetMessage() *SignalingServerMessage_Event_RoomMessage {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_Event) GetLagging() *SignalingServerMessage_Event_Lagging {
+	if x != nil {
+		return x.Lagging
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_Event) GetPermissions() *SignalingServerMessage_Event_Permissions {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_Event) GetResync() *SignalingServerMessage_Event_Resync {
+	if x != nil {
+		return x.Resync
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_Event) GetReceipt() *SignalingServerMessage_Event_Receipt {
+	if x != nil {
+		return x.Receipt
+	}
+	return nil
+}
+
+type SignalingServerMessage_TransientData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Oldvalue      *structpb.Value        `protobuf:"bytes,3,opt,name=oldvalue,proto3" json:"oldvalue,omitempty"`
+	Value         *structpb.Value        `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	Data          *structpb.Struct       `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingServerMessage_TransientData) Reset() {
+	*x = SignalingServerMessage_TransientData{}
+	mi := &file_rpc_signaling_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingServerMessage_TransientData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingServerMessage_TransientData) ProtoMessage() {}
+
+func (x *SignalingServerMessage_TransientData) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingServerMessage_TransientData.ProtoReflect.Descriptor instead.
+func (*SignalingServerMessage_TransientData) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{10, 7}
+}
+
+func (x *SignalingServerMessage_TransientData) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_TransientData) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_TransientData) GetOldvalue() *structpb.Value {
+	if x != nil {
+		return x.Oldvalue
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_TransientData) GetValue() *structpb.Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_TransientData) GetData() *structpb.Struct {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type SignalingServerMessage_Echo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp     int64                  `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Received      int64                  `protobuf:"varint,2,opt,name=received,proto3" json:"received,omitempty"`
+	Sent          int64                  `protobuf:"varint,3,opt,name=sent,proto3" json:"sent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingServerMessage_Echo) Reset() {
+	*x = SignalingServerMessage_Echo{}
+	mi := &file_rpc_signaling_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingServerMessage_Echo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingServerMessage_Echo) ProtoMessage() {}
+
+func (x *SignalingServerMessage_Echo) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingServerMessage_Echo.ProtoReflect.Descriptor instead.
+func (*SignalingServerMessage_Echo) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{10, 8}
+}
+
+func (x *SignalingServerMessage_Echo) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *SignalingServerMessage_Echo) GetReceived() int64 {
+	if x != nil {
+		return x.Received
+	}
+	return 0
+}
+
+func (x *SignalingServerMessage_Echo) GetSent() int64 {
+	if x != nil {
+		return x.Sent
+	}
+	return 0
+}
+
+type SignalingServerMessage_Event_SwitchTo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roomid        string                 `protobuf:"bytes,1,opt,name=roomid,proto3" json:"roomid,omitempty"`
+	Details       *structpb.Value        `protobuf:"bytes,2,opt,name=details,proto3" json:"details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingServerMessage_Event_SwitchTo) Reset() {
+	*x = SignalingServerMessage_Event_SwitchTo{}
+	mi := &file_rpc_signaling_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingServerMessage_Event_SwitchTo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingServerMessage_Event_SwitchTo) ProtoMessage() {}
+
+func (x *SignalingServerMessage_Event_SwitchTo) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingServerMessage_Event_SwitchTo.ProtoReflect.Descriptor instead.
+func (*SignalingServerMessage_Event_SwitchTo) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{10, 6, 0}
+}
+
+func (x *SignalingServerMessage_Event_SwitchTo) GetRoomid() string {
+	if x != nil {
+		return x.Roomid
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Event_SwitchTo) GetDetails() *structpb.Value {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+type SignalingServerMessage_Event_Dtmf struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roomid        string                 `protobuf:"bytes,1,opt,name=roomid,proto3" json:"roomid,omitempty"`
+	Sessionid     string                 `protobuf:"bytes,2,opt,name=sessionid,proto3" json:"sessionid,omitempty"`
+	Digits        string                 `protobuf:"bytes,3,opt,name=digits,proto3" json:"digits,omitempty"`
+	Sender        string                 `protobuf:"bytes,4,opt,name=sender,proto3" json:"sender,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingServerMessage_Event_Dtmf) Reset() {
+	*x = SignalingServerMessage_Event_Dtmf{}
+	mi := &file_rpc_signaling_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingServerMessage_Event_Dtmf) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingServerMessage_Event_Dtmf) ProtoMessage() {}
+
+func (x *SignalingServerMessage_Event_Dtmf) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingServerMessage_Event_Dtmf.ProtoReflect.Descriptor instead.
+func (*SignalingServerMessage_Event_Dtmf) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{10, 6, 1}
+}
+
+func (x *SignalingServerMessage_Event_Dtmf) GetRoomid() string {
+	if x != nil {
+		return x.Roomid
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Event_Dtmf) GetSessionid() string {
+	if x != nil {
+		return x.Sessionid
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Event_Dtmf) GetDigits() string {
+	if x != nil {
+		return x.Digits
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Event_Dtmf) GetSender() string {
+	if x != nil {
+		return x.Sender
+	}
+	return ""
+}
+
+type SignalingServerMessage_Event_Recording struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roomid        string                 `protobuf:"bytes,1,opt,name=roomid,proto3" json:"roomid,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingServerMessage_Event_Recording) Reset() {
+	*x = SignalingServerMessage_Event_Recording{}
+	mi := &file_rpc_signaling_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingServerMessage_Event_Recording) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingServerMessage_Event_Recording) ProtoMessage() {}
+
+func (x *SignalingServerMessage_Event_Recording) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingServerMessage_Event_Recording.ProtoReflect.Descriptor instead.
+func (*SignalingServerMessage_Event_Recording) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{10, 6, 2}
+}
+
+func (x *SignalingServerMessage_Event_Recording) GetRoomid() string {
+	if x != nil {
+		return x.Roomid
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Event_Recording) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type SignalingServerMessage_Event_Caption struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roomid        string                 `protobuf:"bytes,1,opt,name=roomid,proto3" json:"roomid,omitempty"`
+	Sessionid     string                 `protobuf:"bytes,2,opt,name=sessionid,proto3" json:"sessionid,omitempty"`
+	Userid        string                 `protobuf:"bytes,3,opt,name=userid,proto3" json:"userid,omitempty"`
+	Text          string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`
+	Language      string                 `protobuf:"bytes,5,opt,name=language,proto3" json:"language,omitempty"`
+	Final         bool                   `protobuf:"varint,6,opt,name=final,proto3" json:"final,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingServerMessage_Event_Caption) Reset() {
+	*x = SignalingServerMessage_Event_Caption{}
+	mi := &file_rpc_signaling_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingServerMessage_Event_Caption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingServerMessage_Event_Caption) ProtoMessage() {}
+
+func (x *SignalingServerMessage_Event_Caption) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingServerMessage_Event_Caption.ProtoReflect.Descriptor instead.
+func (*SignalingServerMessage_Event_Caption) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{10, 6, 3}
+}
+
+func (x *SignalingServerMessage_Event_Caption) GetRoomid() string {
+	if x != nil {
+		return x.Roomid
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Event_Caption) GetSessionid() string {
+	if x != nil {
+		return x.Sessionid
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Event_Caption) GetUserid() string {
+	if x != nil {
+		return x.Userid
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Event_Caption) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Event_Caption) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Event_Caption) GetFinal() bool {
+	if x != nil {
+		return x.Final
+	}
+	return false
+}
+
+type SignalingServerMessage_Event_Degraded struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roomid        string                 `protobuf:"bytes,1,opt,name=roomid,proto3" json:"roomid,omitempty"`
+	Sessionids    []string               `protobuf:"bytes,2,rep,name=sessionids,proto3" json:"sessionids,omitempty"`
+	Degraded      bool                   `protobuf:"varint,3,opt,name=degraded,proto3" json:"degraded,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingServerMessage_Event_Degraded) Reset() {
+	*x = SignalingServerMessage_Event_Degraded{}
+	mi := &file_rpc_signaling_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingServerMessage_Event_Degraded) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingServerMessage_Event_Degraded) ProtoMessage() {}
+
+func (x *SignalingServerMessage_Event_Degraded) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingServerMessage_Event_Degraded.ProtoReflect.Descriptor instead.
+func (*SignalingServerMessage_Event_Degraded) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{10, 6, 4}
+}
+
+func (x *SignalingServerMessage_Event_Degraded) GetRoomid() string {
+	if x != nil {
+		return x.Roomid
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Event_Degraded) GetSessionids() []string {
+	if x != nil {
+		return x.Sessionids
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_Event_Degraded) GetDegraded() bool {
+	if x != nil {
+		return x.Degraded
+	}
+	return false
+}
+
+type SignalingServerMessage_Event_Chunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sequence      int32                  `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	More          bool                   `protobuf:"varint,2,opt,name=more,proto3" json:"more,omitempty"`
+	Total         int32                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingServerMessage_Event_Chunk) Reset() {
+	*x = SignalingServerMessage_Event_Chunk{}
+	mi := &file_rpc_signaling_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingServerMessage_Event_Chunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingServerMessage_Event_Chunk) ProtoMessage() {}
+
+func (x *SignalingServerMessage_Event_Chunk) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingServerMessage_Event_Chunk.ProtoReflect.Descriptor instead.
+func (*SignalingServerMessage_Event_Chunk) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{10, 6, 5}
+}
+
+func (x *SignalingServerMessage_Event_Chunk) GetSequence() int32 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *SignalingServerMessage_Event_Chunk) GetMore() bool {
+	if x != nil {
+		return x.More
+	}
+	return false
+}
+
+func (x *SignalingServerMessage_Event_Chunk) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type SignalingServerMessage_Event_Flags struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roomid        string                 `protobuf:"bytes,1,opt,name=roomid,proto3" json:"roomid,omitempty"`
+	Sessionid     string                 `protobuf:"bytes,2,opt,name=sessionid,proto3" json:"sessionid,omitempty"`
+	Flags         uint32                 `protobuf:"varint,3,opt,name=flags,proto3" json:"flags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingServerMessage_Event_Flags) Reset() {
+	*x = SignalingServerMessage_Event_Flags{}
+	mi := &file_rpc_signaling_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingServerMessage_Event_Flags) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingServerMessage_Event_Flags) ProtoMessage() {}
+
+func (x *SignalingServerMessage_Event_Flags) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingServerMessage_Event_Flags.ProtoReflect.Descriptor instead.
+func (*SignalingServerMessage_Event_Flags) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{10, 6, 6}
+}
+
+func (x *SignalingServerMessage_Event_Flags) GetRoomid() string {
+	if x != nil {
+		return x.Roomid
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Event_Flags) GetSessionid() string {
+	if x != nil {
+		return x.Sessionid
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Event_Flags) GetFlags() uint32 {
+	if x != nil {
+		return x.Flags
+	}
+	return 0
+}
+
+type SignalingServerMessage_Event_RoomMessage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Roomid        string                 `protobuf:"bytes,1,opt,name=roomid,proto3" json:"roomid,omitempty"`
+	Data          *structpb.Value        `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingServerMessage_Event_RoomMessage) Reset() {
+	*x = SignalingServerMessage_Event_RoomMessage{}
+	mi := &file_rpc_signaling_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingServerMessage_Event_RoomMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingServerMessage_Event_RoomMessage) ProtoMessage() {}
+
+func (x *SignalingServerMessage_Event_RoomMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingServerMessage_Event_RoomMessage.ProtoReflect.Descriptor instead.
+func (*SignalingServerMessage_Event_RoomMessage) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{10, 6, 7}
+}
+
+func (x *SignalingServerMessage_Event_RoomMessage) GetRoomid() string {
+	if x != nil {
+		return x.Roomid
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Event_RoomMessage) GetData() *structpb.Value {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type SignalingServerMessage_Event_Lagging struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Pending int32                  `protobuf:"varint,1,opt,name=pending,proto3" json:"pending,omitempty"`
+	// Time in RFC 3339 format.
+	Since         string `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingServerMessage_Event_Lagging) Reset() {
+	*x = SignalingServerMessage_Event_Lagging{}
+	mi := &file_rpc_signaling_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingServerMessage_Event_Lagging) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingServerMessage_Event_Lagging) ProtoMessage() {}
+
+func (x *SignalingServerMessage_Event_Lagging) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingServerMessage_Event_Lagging.ProtoReflect.Descriptor instead.
+func (*SignalingServerMessage_Event_Lagging) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{10, 6, 8}
+}
+
+func (x *SignalingServerMessage_Event_Lagging) GetPending() int32 {
+	if x != nil {
+		return x.Pending
+	}
+	return 0
+}
+
+func (x *SignalingServerMessage_Event_Lagging) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+type SignalingServerMessage_Event_Permissions struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Permissions   []string               `protobuf:"bytes,1,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	Added         []string               `protobuf:"bytes,2,rep,name=added,proto3" json:"added,omitempty"`
+	Removed       []string               `protobuf:"bytes,3,rep,name=removed,proto3" json:"removed,omitempty"`
+	Renegotiate   []string               `protobuf:"bytes,4,rep,name=renegotiate,proto3" json:"renegotiate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingServerMessage_Event_Permissions) Reset() {
+	*x = SignalingServerMessage_Event_Permissions{}
+	mi := &file_rpc_signaling_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingServerMessage_Event_Permissions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingServerMessage_Event_Permissions) ProtoMessage() {}
+
+func (x *SignalingServerMessage_Event_Permissions) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingServerMessage_Event_Permissions.ProtoReflect.Descriptor instead.
+func (*SignalingServerMessage_Event_Permissions) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{10, 6, 9}
+}
+
+func (x *SignalingServerMessage_Event_Permissions) GetPermissions() []string {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_Event_Permissions) GetAdded() []string {
+	if x != nil {
+		return x.Added
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_Event_Permissions) GetRemoved() []string {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_Event_Permissions) GetRenegotiate() []string {
+	if x != nil {
+		return x.Renegotiate
+	}
+	return nil
+}
+
+type SignalingServerMessage_Event_Resync struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reason        string                 `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	Stale         []string               `protobuf:"bytes,2,rep,name=stale,proto3" json:"stale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingServerMessage_Event_Resync) Reset() {
+	*x = SignalingServerMessage_Event_Resync{}
+	mi := &file_rpc_signaling_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingServerMessage_Event_Resync) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingServerMessage_Event_Resync) ProtoMessage() {}
+
+func (x *SignalingServerMessage_Event_Resync) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingServerMessage_Event_Resync.ProtoReflect.Descriptor instead.
+func (*SignalingServerMessage_Event_Resync) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{10, 6, 10}
+}
+
+func (x *SignalingServerMessage_Event_Resync) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Event_Resync) GetStale() []string {
+	if x != nil {
+		return x.Stale
+	}
+	return nil
+}
+
+type SignalingServerMessage_Event_Receipt struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Recipient     *SignalingRecipient    `protobuf:"bytes,2,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignalingServerMessage_Event_Receipt) Reset() {
+	*x = SignalingServerMessage_Event_Receipt{}
+	mi := &file_rpc_signaling_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignalingServerMessage_Event_Receipt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignalingServerMessage_Event_Receipt) ProtoMessage() {}
+
+func (x *SignalingServerMessage_Event_Receipt) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_signaling_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignalingServerMessage_Event_Receipt.ProtoReflect.Descriptor instead.
+func (*SignalingServerMessage_Event_Receipt) Descriptor() ([]byte, []int) {
+	return file_rpc_signaling_proto_rawDescGZIP(), []int{10, 6, 11}
+}
+
+func (x *SignalingServerMessage_Event_Receipt) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SignalingServerMessage_Event_Receipt) GetRecipient() *SignalingRecipient {
+	if x != nil {
+		return x.Recipient
+	}
+	return nil
+}
+
+func (x *SignalingServerMessage_Event_Receipt) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+var File_rpc_signaling_proto protoreflect.FileDescriptor
+
+const file_rpc_signaling_proto_rawDesc = "" +
+	"\n" +
+	"\x13rpc_signaling.proto\x12\tsignaling\x1a\x1cgoogle/protobuf/struct.proto\"\x19\n" +
+	"\x17SignalingReceiveRequest\"\x8b\x01\n" +
+	"\x18SignalingReceiveResponse\x12$\n" +
+	"\fconnectionid\x18\x01 \x01(\tH\x00R\fconnectionid\x12=\n" +
+	"\amessage\x18\x02 \x01(\v2!.signaling.SignalingServerMessageH\x00R\amessageB\n" +
+	"\n" +
+	"\bresponse\"w\n" +
+	"\x14SignalingSendRequest\x12\"\n" +
+	"\fconnectionid\x18\x01 \x01(\tR\fconnectionid\x12;\n" +
+	"\amessage\x18\x02 \x01(\v2!.signaling.SignalingClientMessageR\amessage\"\x17\n" +
+	"\x15SignalingSendResponse\"~\n" +
+	"\x12SignalingRecipient\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1c\n" +
+	"\tsessionid\x18\x02 \x01(\tR\tsessionid\x12\x1e\n" +
+	"\n" +
+	"sessionids\x18\x03 \x03(\tR\n" +
+	"sessionids\x12\x16\n" +
+	"\x06userid\x18\x04 \x01(\tR\x06userid\"[\n" +
+	"\x0fSignalingSender\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1c\n" +
+	"\tsessionid\x18\x02 \x01(\tR\tsessionid\x12\x16\n" +
+	"\x06userid\x18\x03 \x01(\tR\x06userid\"\xf0\x0f\n" +
+	"\x16SignalingClientMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12?\n" +
+	"\x05hello\x18\x02 \x01(\v2'.signaling.SignalingClientMessage.HelloH\x00R\x05hello\x129\n" +
+	"\x03bye\x18\x03 \x01(\v2%.signaling.SignalingClientMessage.ByeH\x00R\x03bye\x12<\n" +
+	"\x04room\x18\x04 \x01(\v2&.signaling.SignalingClientMessage.RoomH\x00R\x04room\x12E\n" +
+	"\amessage\x18\x05 \x01(\v2).signaling.SignalingClientMessage.MessageH\x00R\amessage\x12E\n" +
+	"\acontrol\x18\x06 \x01(\v2).signaling.SignalingClientMessage.MessageH\x00R\acontrol\x12O\n" +
+	"\ttransient\x18\a \x01(\v2/.signaling.SignalingClientMessage.TransientDataH\x00R\ttransient\x12T\n" +
+	"\fparticipants\x18\b \x01(\v2..signaling.SignalingClientMessage.ParticipantsH\x00R\fparticipants\x12<\n" +
+	"\x04echo\x18\t \x01(\v2&.signaling.SignalingClientMessage.EchoH\x00R\x04echo\x12<\n" +
+	"\x04dtmf\x18\n" +
+	" \x01(\v2&.signaling.SignalingClientMessage.DtmfH\x00R\x04dtmf\x12K\n" +
+	"\trecording\x18\v \x01(\v2+.signaling.SignalingClientMessage.RecordingH\x00R\trecording\x1a\xe6\x03\n" +
+	"\x05Hello\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x1a\n" +
+	"\bresumeid\x18\x02 \x01(\tR\bresumeid\x12\x1a\n" +
+	"\bfeatures\x18\x03 \x03(\tR\bfeatures\x12\"\n" +
+	"\fpinginterval\x18\x04 \x01(\x05R\fpinginterval\x12C\n" +
+	"\x05hints\x18\x05 \x01(\v2-.signaling.SignalingClientMessage.Hello.HintsR\x05hints\x12\x1a\n" +
+	"\blanguage\x18\x06 \x01(\tR\blanguage\x12@\n" +
+	"\x04auth\x18\a \x01(\v2,.signaling.SignalingClientMessage.Hello.AuthR\x04auth\x1ae\n" +
+	"\x05Hints\x12 \n" +
+	"\vmaxdownlink\x18\x01 \x01(\x05R\vmaxdownlink\x12\"\n" +
+	"\fbatterysaver\x18\x02 \x01(\bR\fbatterysaver\x12\x16\n" +
+	"\x06mobile\x18\x03 \x01(\bR\x06mobile\x1a]\n" +
+	"\x04Auth\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12/\n" +
+	"\x06params\x18\x02 \x01(\v2\x17.google.protobuf.StructR\x06params\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\x1a\x05\n" +
+	"\x03Bye\x1a\xaf\x02\n" +
+	"\x04Room\x12\x16\n" +
+	"\x06roomid\x18\x01 \x01(\tR\x06roomid\x12\x1c\n" +
+	"\tsessionid\x18\x02 \x01(\tR\tsessionid\x12Q\n" +
+	"\n" +
+	"federation\x18\x03 \x01(\v21.signaling.SignalingClientMessage.Room.FederationR\n" +
+	"federation\x12\x1c\n" +
+	"\tsecondary\x18\x04 \x01(\bR\tsecondary\x12\x14\n" +
+	"\x05leave\x18\x05 \x01(\bR\x05leave\x1aj\n" +
+	"\n" +
+	"Federation\x12\x1c\n" +
+	"\tsignaling\x18\x01 \x01(\tR\tsignaling\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x16\n" +
+	"\x06roomid\x18\x03 \x01(\tR\x06roomid\x12\x14\n" +
+	"\x05token\x18\x04 \x01(\tR\x05token\x1ar\n" +
+	"\aMessage\x12;\n" +
+	"\trecipient\x18\x01 \x01(\v2\x1d.signaling.SignalingRecipientR\trecipient\x12*\n" +
+	"\x04data\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\x04data\x1au\n" +
+	"\rTransientData\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12,\n" +
+	"\x05value\x18\x03 \x01(\v2\x16.google.protobuf.ValueR\x05value\x12\x10\n" +
+	"\x03ttl\x18\x04 \x01(\x03R\x03ttl\x1a(\n" +
+	"\fParticipants\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x04R\aversion\x1a6\n" +
+	"\x04Echo\x12\x1c\n" +
+	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\x12\x10\n" +
+	"\x03rtt\x18\x02 \x01(\x03R\x03rtt\x1a<\n" +
+	"\x04Dtmf\x12\x1c\n" +
+	"\tsessionid\x18\x01 \x01(\tR\tsessionid\x12\x16\n" +
+	"\x06digits\x18\x02 \x01(\tR\x06digits\x1aV\n" +
+	"\tRecording\x12\x16\n" +
+	"\x06action\x18\x01 \x01(\tR\x06action\x121\n" +
+	"\aoptions\x18\x02 \x01(\v2\x17.google.protobuf.StructR\aoptionsB\t\n" +
+	"\apayload\"E\n" +
+	"\x13SignalingServerTime\x12\x10\n" +
+	"\x03now\x18\x01 \x01(\x03R\x03now\x12\x1c\n" +
+	"\tmonotonic\x18\x02 \x01(\x03R\tmonotonic\"\xd9\x01\n" +
+	"\x15SignalingSessionEntry\x12\x1c\n" +
+	"\tsessionid\x18\x01 \x01(\tR\tsessionid\x12\x16\n" +
+	"\x06userid\x18\x02 \x01(\tR\x06userid\x12\x1a\n" +
+	"\bfeatures\x18\x03 \x03(\tR\bfeatures\x12*\n" +
+	"\x04user\x18\x04 \x01(\v2\x16.google.protobuf.ValueR\x04user\x12$\n" +
+	"\rroomsessionid\x18\x05 \x01(\tR\rroomsessionid\x12\x1c\n" +
+	"\tfederated\x18\x06 \x01(\bR\tfederated\"\xea\x02\n" +
+	"\x12SignalingRoomEvent\x12\x16\n" +
+	"\x06roomid\x18\x01 \x01(\tR\x06roomid\x126\n" +
+	"\n" +
+	"properties\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\n" +
+	"properties\x12.\n" +
+	"\x06incall\x18\x03 \x01(\v2\x16.google.protobuf.ValueR\x06incall\x121\n" +
+	"\achanged\x18\x04 \x03(\v2\x17.google.protobuf.StructR\achanged\x12-\n" +
+	"\x05users\x18\x05 \x03(\v2\x17.google.protobuf.StructR\x05users\x12\x10\n" +
+	"\x03all\x18\x06 \x01(\bR\x03all\x12\x18\n" +
+	"\aversion\x18\a \x01(\x04R\aversion\x12\x14\n" +
+	"\x05delta\x18\b \x01(\bR\x05delta\x12\x18\n" +
+	"\aremoved\x18\t \x03(\tR\aremoved\x12\x16\n" +
+	"\x06reason\x18\n" +
+	" \x01(\tR\x06reason\"\x95#\n" +
+	"\x16SignalingServerMessage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12?\n" +
+	"\x05error\x18\x02 \x01(\v2'.signaling.SignalingServerMessage.ErrorH\x00R\x05error\x12E\n" +
+	"\awelcome\x18\x03 \x01(\v2).signaling.SignalingServerMessage.WelcomeH\x00R\awelcome\x12?\n" +
+	"\x05hello\x18\x04 \x01(\v2'.signaling.SignalingServerMessage.HelloH\x00R\x05hello\x129\n" +
+	"\x03bye\x18\x05 \x01(\v2%.signaling.SignalingServerMessage.ByeH\x00R\x03bye\x12<\n" +
+	"\x04room\x18\x06 \x01(\v2&.signaling.SignalingServerMessage.RoomH\x00R\x04room\x12E\n" +
+	"\amessage\x18\a \x01(\v2).signaling.SignalingServerMessage.MessageH\x00R\amessage\x12E\n" +
+	"\acontrol\x18\b \x01(\v2).signaling.SignalingServerMessage.MessageH\x00R\acontrol\x12?\n" +
+	"\x05event\x18\t \x01(\v2'.signaling.SignalingServerMessage.EventH\x00R\x05event\x12O\n" +
+	"\ttransient\x18\n" +
+	" \x01(\v2/.signaling.SignalingServerMessage.TransientDataH\x00R\ttransient\x12<\n" +
+	"\x04echo\x18\v \x01(\v2&.signaling.SignalingServerMessage.EchoH\x00R\x04echo\x1a\xb6\x01\n" +
+	"\x05Error\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12/\n" +
+	"\x06params\x18\x03 \x01(\v2\x17.google.protobuf.StructR\x06params\x12\x1c\n" +
+	"\tlocalized\x18\x04 \x01(\tR\tlocalized\x120\n" +
+	"\adetails\x18\x05 \x01(\v2\x16.google.protobuf.ValueR\adetails\x1a\x8d\x01\n" +
+	"\aWelcome\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x1a\n" +
+	"\bfeatures\x18\x02 \x03(\tR\bfeatures\x12\x18\n" +
+	"\acountry\x18\x03 \x01(\tR\acountry\x122\n" +
+	"\x04time\x18\x04 \x01(\v2\x1e.signaling.SignalingServerTimeR\x04time\x1a\xc8\x02\n" +
+	"\x05Hello\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x1c\n" +
+	"\tsessionid\x18\x02 \x01(\tR\tsessionid\x12\x1a\n" +
+	"\bresumeid\x18\x03 \x01(\tR\bresumeid\x12\x16\n" +
+	"\x06userid\x18\x04 \x01(\tR\x06userid\x12\"\n" +
+	"\fpinginterval\x18\x05 \x01(\x05R\fpinginterval\x12\x1c\n" +
+	"\theartbeat\x18\x06 \x01(\x05R\theartbeat\x122\n" +
+	"\x04time\x18\a \x01(\v2\x1e.signaling.SignalingServerTimeR\x04time\x12\x1a\n" +
+	"\bfeatures\x18\b \x03(\tR\bfeatures\x12A\n" +
+	"\x06server\x18\t \x01(\v2).signaling.SignalingServerMessage.WelcomeR\x06server\x1a[\n" +
+	"\x03Bye\x12\x16\n" +
+	"\x06reason\x18\x01 \x01(\tR\x06reason\x12\x1c\n" +
+	"\treconnect\x18\x02 \x01(\bR\treconnect\x12\x1e\n" +
+	"\n" +
+	"retryafter\x18\x03 \x01(\x05R\n" +
+	"retryafter\x1at\n" +
+	"\x04Room\x12\x16\n" +
+	"\x06roomid\x18\x01 \x01(\tR\x06roomid\x126\n" +
+	"\n" +
+	"properties\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\n" +
+	"properties\x12\x1c\n" +
+	"\tsecondary\x18\x03 \x01(\bR\tsecondary\x1a\xa6\x01\n" +
+	"\aMessage\x122\n" +
+	"\x06sender\x18\x01 \x01(\v2\x1a.signaling.SignalingSenderR\x06sender\x12;\n" +
+	"\trecipient\x18\x02 \x01(\v2\x1d.signaling.SignalingRecipientR\trecipient\x12*\n" +
+	"\x04data\x18\x03 \x01(\v2\x16.google.protobuf.ValueR\x04data\x1a\x92\x13\n" +
+	"\x05Event\x12\x16\n" +
+	"\x06target\x18\x01 \x01(\tR\x06target\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x16\n" +
+	"\x06roomid\x18\x03 \x01(\tR\x06roomid\x124\n" +
+	"\x04join\x18\x04 \x03(\v2 .signaling.SignalingSessionEntryR\x04join\x12\x14\n" +
+	"\x05leave\x18\x05 \x03(\tR\x05leave\x128\n" +
+	"\x06change\x18\x06 \x03(\v2 .signaling.SignalingSessionEntryR\x06change\x12L\n" +
+	"\bswitchto\x18\a \x01(\v20.signaling.SignalingServerMessage.Event.SwitchToR\bswitchto\x12@\n" +
+	"\x04dtmf\x18\b \x01(\v2,.signaling.SignalingServerMessage.Event.DtmfR\x04dtmf\x12O\n" +
+	"\trecording\x18\t \x01(\v21.signaling.SignalingServerMessage.Event.RecordingR\trecording\x12I\n" +
+	"\acaption\x18\n" +
+	" \x01(\v2/.signaling.SignalingServerMessage.Event.CaptionR\acaption\x12L\n" +
+	"\bdegraded\x18\v \x01(\v20.signaling.SignalingServerMessage.Event.DegradedR\bdegraded\x12\x1d\n" +
+	"\aresumed\x18\f \x01(\bH\x00R\aresumed\x88\x01\x01\x12C\n" +
+	"\x05chunk\x18\r \x01(\v2-.signaling.SignalingServerMessage.Event.ChunkR\x05chunk\x125\n" +
+	"\x06invite\x18\x0e \x01(\v2\x1d.signaling.SignalingRoomEventR\x06invite\x12;\n" +
+	"\tdisinvite\x18\x0f \x01(\v2\x1d.signaling.SignalingRoomEventR\tdisinvite\x125\n" +
+	"\x06update\x18\x10 \x01(\v2\x1d.signaling.SignalingRoomEventR\x06update\x12C\n" +
+	"\x05flags\x18\x11 \x01(\v2-.signaling.SignalingServerMessage.Event.FlagsR\x05flags\x12M\n" +
+	"\amessage\x18\x12 \x01(\v23.signaling.SignalingServerMessage.Event.RoomMessageR\amessage\x12I\n" +
+	"\alagging\x18\x13 \x01(\v2/.signaling.SignalingServerMessage.Event.LaggingR\alagging\x12U\n" +
+	"\vpermissions\x18\x14 \x01(\v23.signaling.SignalingServerMessage.Event.PermissionsR\vpermissions\x12F\n" +
+	"\x06resync\x18\x15 \x01(\v2..signaling.SignalingServerMessage.Event.ResyncR\x06resync\x12I\n" +
+	"\areceipt\x18\x16 \x01(\v2/.signaling.SignalingServerMessage.Event.ReceiptR\areceipt\x1aT\n" +
+	"\bSwitchTo\x12\x16\n" +
+	"\x06roomid\x18\x01 \x01(\tR\x06roomid\x120\n" +
+	"\adetails\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\adetails\x1al\n" +
+	"\x04Dtmf\x12\x16\n" +
+	"\x06roomid\x18\x01 \x01(\tR\x06roomid\x12\x1c\n" +
+	"\tsessionid\x18\x02 \x01(\tR\tsessionid\x12\x16\n" +
+	"\x06digits\x18\x03 \x01(\tR\x06digits\x12\x16\n" +
+	"\x06sender\x18\x04 \x01(\tR\x06sender\x1a;\n" +
+	"\tRecording\x12\x16\n" +
+	"\x06roomid\x18\x01 \x01(\tR\x06roomid\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x1a\x9d\x01\n" +
+	"\aCaption\x12\x16\n" +
+	"\x06roomid\x18\x01 \x01(\tR\x06roomid\x12\x1c\n" +
+	"\tsessionid\x18\x02 \x01(\tR\tsessionid\x12\x16\n" +
+	"\x06userid\x18\x03 \x01(\tR\x06userid\x12\x12\n" +
+	"\x04text\x18\x04 \x01(\tR\x04text\x12\x1a\n" +
+	"\blanguage\x18\x05 \x01(\tR\blanguage\x12\x14\n" +
+	"\x05final\x18\x06 \x01(\bR\x05final\x1a^\n" +
+	"\bDegraded\x12\x16\n" +
+	"\x06roomid\x18\x01 \x01(\tR\x06roomid\x12\x1e\n" +
+	"\n" +
+	"sessionids\x18\x02 \x03(\tR\n" +
+	"sessionids\x12\x1a\n" +
+	"\bdegraded\x18\x03 \x01(\bR\bdegraded\x1aM\n" +
+	"\x05Chunk\x12\x1a\n" +
+	"\bsequence\x18\x01 \x01(\x05R\bsequence\x12\x12\n" +
+	"\x04more\x18\x02 \x01(\bR\x04more\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x05R\x05total\x1aS\n" +
+	"\x05Flags\x12\x16\n" +
+	"\x06roomid\x18\x01 \x01(\tR\x06roomid\x12\x1c\n" +
+	"\tsessionid\x18\x02 \x01(\tR\tsessionid\x12\x14\n" +
+	"\x05flags\x18\x03 \x01(\rR\x05flags\x1aQ\n" +
+	"\vRoomMessage\x12\x16\n" +
+	"\x06roomid\x18\x01 \x01(\tR\x06roomid\x12*\n" +
+	"\x04data\x18\x02 \x01(\v2\x16.google.protobuf.ValueR\x04data\x1a9\n" +
+	"\aLagging\x12\x18\n" +
+	"\apending\x18\x01 \x01(\x05R\apending\x12\x14\n" +
+	"\x05since\x18\x02 \x01(\tR\x05since\x1a\x81\x01\n" +
+	"\vPermissions\x12 \n" +
+	"\vpermissions\x18\x01 \x03(\tR\vpermissions\x12\x14\n" +
+	"\x05added\x18\x02 \x03(\tR\x05added\x12\x18\n" +
+	"\aremoved\x18\x03 \x03(\tR\aremoved\x12 \n" +
+	"\vrenegotiate\x18\x04 \x03(\tR\vrenegotiate\x1a6\n" +
+	"\x06Resync\x12\x16\n" +
+	"\x06reason\x18\x01 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05stale\x18\x02 \x03(\tR\x05stale\x1ar\n" +
+	"\aReceipt\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12;\n" +
+	"\trecipient\x18\x02 \x01(\v2\x1d.signaling.SignalingRecipientR\trecipient\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06statusB\n" +
+	"\n" +
+	"\b_resumed\x1a\xc4\x01\n" +
+	"\rTransientData\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x122\n" +
+	"\boldvalue\x18\x03 \x01(\v2\x16.google.protobuf.ValueR\boldvalue\x12,\n" +
+	"\x05value\x18\x04 \x01(\v2\x16.google.protobuf.ValueR\x05value\x12+\n" +
+	"\x04data\x18\x05 \x01(\v2\x17.google.protobuf.StructR\x04data\x1aT\n" +
+	"\x04Echo\x12\x1c\n" +
+	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\x12\x1a\n" +
+	"\breceived\x18\x02 \x01(\x03R\breceived\x12\x12\n" +
+	"\x04sent\x18\x03 \x01(\x03R\x04sentB\t\n" +
+	"\apayload2\xb3\x01\n" +
+	"\fRpcSignaling\x12V\n" +
+	"\aReceive\x12\".signaling.SignalingReceiveRequest\x1a#.signaling.SignalingReceiveResponse\"\x000\x01\x12K\n" +
+	"\x04Send\x12\x1f.signaling.SignalingSendRequest\x1a .signaling.SignalingSendResponse\"\x00B<Z:github.com/strukturag/nextcloud-spreed-signaling;signalingb\x06proto3"
+
+var (
+	file_rpc_signaling_proto_rawDescOnce sync.Once
+	file_rpc_signaling_proto_rawDescData []byte
+)
+
+func file_rpc_signaling_proto_rawDescGZIP() []byte {
+	file_rpc_signaling_proto_rawDescOnce.Do(func() {
+		file_rpc_signaling_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rpc_signaling_proto_rawDesc), len(file_rpc_signaling_proto_rawDesc)))
+	})
+	return file_rpc_signaling_proto_rawDescData
+}
+
+var file_rpc_signaling_proto_msgTypes = make([]protoimpl.MessageInfo, 44)
+var file_rpc_signaling_proto_goTypes = []any{
+	(*SignalingReceiveRequest)(nil),                  // 0: signaling.SignalingReceiveRequest
+	(*SignalingReceiveResponse)(nil),                 // 1: signaling.SignalingReceiveResponse
+	(*SignalingSendRequest)(nil),                     // 2: signaling.SignalingSendRequest
+	(*SignalingSendResponse)(nil),                    // 3: signaling.SignalingSendResponse
+	(*SignalingRecipient)(nil),                       // 4: signaling.SignalingRecipient
+	(*SignalingSender)(nil),                          // 5: signaling.SignalingSender
+	(*SignalingClientMessage)(nil),                   // 6: signaling.SignalingClientMessage
+	(*SignalingServerTime)(nil),                      // 7: signaling.SignalingServerTime
+	(*SignalingSessionEntry)(nil),                    // 8: signaling.SignalingSessionEntry
+	(*SignalingRoomEvent)(nil),                       // 9: signaling.SignalingRoomEvent
+	(*SignalingServerMessage)(nil),                   // 10: signaling.SignalingServerMessage
+	(*SignalingClientMessage_Hello)(nil),             // 11: signaling.SignalingClientMessage.Hello
+	(*SignalingClientMessage_Bye)(nil),               // 12: signaling.SignalingClientMessage.Bye
+	(*SignalingClientMessage_Room)(nil),              // 13: signaling.SignalingClientMessage.Room
+	(*SignalingClientMessage_Message)(nil),           // 14: signaling.SignalingClientMessage.Message
+	(*SignalingClientMessage_TransientData)(nil),     // 15: signaling.SignalingClientMessage.TransientData
+	(*SignalingClientMessage_Participants)(nil),      // 16: signaling.SignalingClientMessage.Participants
+	(*SignalingClientMessage_Echo)(nil),              // 17: signaling.SignalingClientMessage.Echo
+	(*SignalingClientMessage_Dtmf)(nil),              // 18: signaling.SignalingClientMessage.Dtmf
+	(*SignalingClientMessage_Recording)(nil),         // 19: signaling.SignalingClientMessage.Recording
+	(*SignalingClientMessage_Hello_Hints)(nil),       // 20: signaling.SignalingClientMessage.Hello.Hints
+	(*SignalingClientMessage_Hello_Auth)(nil),        // 21: signaling.SignalingClientMessage.Hello.Auth
+	(*SignalingClientMessage_Room_Federation)(nil),   // 22: signaling.SignalingClientMessage.Room.Federation
+	(*SignalingServerMessage_Error)(nil),             // 23: signaling.SignalingServerMessage.Error
+	(*SignalingServerMessage_Welcome)(nil),           // 24: signaling.SignalingServerMessage.Welcome
+	(*SignalingServerMessage_Hello)(nil),             // 25: signaling.SignalingServerMessage.Hello
+	(*SignalingServerMessage_Bye)(nil),               // 26: signaling.SignalingServerMessage.Bye
+	(*SignalingServerMessage_Room)(nil),              // 27: signaling.SignalingServerMessage.Room
+	(*SignalingServerMessage_Message)(nil),           // 28: signaling.SignalingServerMessage.Message
+	(*SignalingServerMessage_Event)(nil),             // 29: signaling.SignalingServerMessage.Event
+	(*SignalingServerMessage_TransientData)(nil),     // 30: signaling.SignalingServerMessage.TransientData
+	(*SignalingServerMessage_Echo)(nil),              // 31: signaling.SignalingServerMessage.Echo
+	(*SignalingServerMessage_Event_SwitchTo)(nil),    // 32: signaling.SignalingServerMessage.Event.SwitchTo
+	(*SignalingServerMessage_Event_Dtmf)(nil),        // 33: signaling.SignalingServerMessage.Event.Dtmf
+	(*SignalingServerMessage_Event_Recording)(nil),   // 34: signaling.SignalingServerMessage.Event.Recording
+	(*SignalingServerMessage_Event_Caption)(nil),     // 35: signaling.SignalingServerMessage.Event.Caption
+	(*SignalingServerMessage_Event_Degraded)(nil),    // 36: signaling.SignalingServerMessage.Event.Degraded
+	(*SignalingServerMessage_Event_Chunk)(nil),       // 37: signaling.SignalingServerMessage.Event.Chunk
+	(*SignalingServerMessage_Event_Flags)(nil),       // 38: signaling.SignalingServerMessage.Event.Flags
+	(*SignalingServerMessage_Event_RoomMessage)(nil), // 39: signaling.SignalingServerMessage.Event.RoomMessage
+	(*SignalingServerMessage_Event_Lagging)(nil),     // 40: signaling.SignalingServerMessage.Event.Lagging
+	(*SignalingServerMessage_Event_Permissions)(nil), // 41: signaling.SignalingServerMessage.Event.Permissions
+	(*SignalingServerMessage_Event_Resync)(nil),      // 42: signaling.SignalingServerMessage.Event.Resync
+	(*SignalingServerMessage_Event_Receipt)(nil),     // 43: signaling.SignalingServerMessage.Event.Receipt
+	(*structpb.Value)(nil),                           // 44: google.protobuf.Value
+	(*structpb.Struct)(nil),                          // 45: google.protobuf.Struct
+}
+var file_rpc_signaling_proto_depIdxs = []int32{
+	10, // 0: signaling.SignalingReceiveResponse.message:type_name -> signaling.SignalingServerMessage
+	6,  // 1: signaling.SignalingSendRequest.message:type_name -> signaling.SignalingClientMessage
+	11, // 2: signaling.SignalingClientMessage.hello:type_name -> signaling.SignalingClientMessage.Hello
+	12, // 3: signaling.SignalingClientMessage.bye:type_name -> signaling.SignalingClientMessage.Bye
+	13, // 4: signaling.SignalingClientMessage.room:type_name -> signaling.SignalingClientMessage.Room
+	14, // 5: signaling.SignalingClientMessage.message:type_name -> signaling.SignalingClientMessage.Message
+	14, // 6: signaling.SignalingClientMessage.control:type_name -> signaling.SignalingClientMessage.Message
+	15, // 7: signaling.SignalingClientMessage.transient:type_name -> signaling.SignalingClientMessage.TransientData
+	16, // 8: signaling.SignalingClientMessage.participants:type_name -> signaling.SignalingClientMessage.Participants
+	17, // 9: signaling.SignalingClientMessage.echo:type_name -> signaling.SignalingClientMessage.Echo
+	18, // 10: signaling.SignalingClientMessage.dtmf:type_name -> signaling.SignalingClientMessage.Dtmf
+	19, // 11: signaling.SignalingClientMessage.recording:type_name -> signaling.SignalingClientMessage.Recording
+	44, // 12: signaling.SignalingSessionEntry.user:type_name -> google.protobuf.Value
+	44, // 13: signaling.SignalingRoomEvent.properties:type_name -> google.protobuf.Value
+	44, // 14: signaling.SignalingRoomEvent.incall:type_name -> google.protobuf.Value
+	45, // 15: signaling.SignalingRoomEvent.changed:type_name -> google.protobuf.Struct
+	45, // 16: signaling.SignalingRoomEvent.users:type_name -> google.protobuf.Struct
+	23, // 17: signaling.SignalingServerMessage.error:type_name -> signaling.SignalingServerMessage.Error
+	24, // 18: signaling.SignalingServerMessage.welcome:type_name -> signaling.SignalingServerMessage.Welcome
+	25, // 19: signaling.SignalingServerMessage.hello:type_name -> signaling.SignalingServerMessage.Hello
+	26, // 20: signaling.SignalingServerMessage.bye:type_name -> signaling.SignalingServerMessage.Bye
+	27, // 21: signaling.SignalingServerMessage.room:type_name -> signaling.SignalingServerMessage.Room
+	28, // 22: signaling.SignalingServerMessage.message:type_name -> signaling.SignalingServerMessage.Message
+	28, // 23: signaling.SignalingServerMessage.control:type_name -> signaling.SignalingServerMessage.Message
+	29, // 24: signaling.SignalingServerMessage.event:type_name -> signaling.SignalingServerMessage.Event
+	30, // 25: signaling.SignalingServerMessage.transient:type_name -> signaling.SignalingServerMessage.TransientData
+	31, // 26: signaling.SignalingServerMessage.echo:type_name -> signaling.SignalingServerMessage.Echo
+	20, // 27: signaling.SignalingClientMessage.Hello.hints:type_name -> signaling.SignalingClientMessage.Hello.Hints
+	21, // 28: signaling.SignalingClientMessage.Hello.auth:type_name -> signaling.SignalingClientMessage.Hello.Auth
+	22, // 29: signaling.SignalingClientMessage.Room.federation:type_name -> signaling.SignalingClientMessage.Room.Federation
+	4,  // 30: signaling.SignalingClientMessage.Message.recipient:type_name -> signaling.SignalingRecipient
+	44, // 31: signaling.SignalingClientMessage.Message.data:type_name -> google.protobuf.Value
+	44, // 32: signaling.SignalingClientMessage.TransientData.value:type_name -> google.protobuf.Value
+	45, // 33: signaling.SignalingClientMessage.Recording.options:type_name -> google.protobuf.Struct
+	45, // 34: signaling.SignalingClientMessage.Hello.Auth.params:type_name -> google.protobuf.Struct
+	45, // 35: signaling.SignalingServerMessage.Error.params:type_name -> google.protobuf.Struct
+	44, // 36: signaling.SignalingServerMessage.Error.details:type_name -> google.protobuf.Value
+	7,  // 37: signaling.SignalingServerMessage.Welcome.time:type_name -> signaling.SignalingServerTime
+	7,  // 38: signaling.SignalingServerMessage.Hello.time:type_name -> signaling.SignalingServerTime
+	24, // 39: signaling.SignalingServerMessage.Hello.server:type_name -> signaling.SignalingServerMessage.Welcome
+	44, // 40: signaling.SignalingServerMessage.Room.properties:type_name -> google.protobuf.Value
+	5,  // 41: signaling.SignalingServerMessage.Message.sender:type_name -> signaling.SignalingSender
+	4,  // 42: signaling.SignalingServerMessage.Message.recipient:type_name -> signaling.SignalingRecipient
+	44, // 43: signaling.SignalingServerMessage.Message.data:type_name -> google.protobuf.Value
+	8,  // 44: signaling.SignalingServerMessage.Event.join:type_name -> signaling.SignalingSessionEntry
+	8,  // 45: signaling.SignalingServerMessage.Event.change:type_name -> signaling.SignalingSessionEntry
+	32, // 46: signaling.SignalingServerMessage.Event.switchto:type_name -> signaling.SignalingServerMessage.Event.SwitchTo
+	33, // 47: signaling.SignalingServerMessage.Event.dtmf:type_name -> signaling.SignalingServerMessage.Event.Dtmf
+	34, // 48: signaling.SignalingServerMessage.Event.recording:type_name -> signaling.SignalingServerMessage.Event.Recording
+	35, // 49: signaling.SignalingServerMessage.Event.caption:type_name -> signaling.SignalingServerMessage.Event.Caption
+	36, // 50: signaling.SignalingServerMessage.Event.degraded:type_name -> signaling.SignalingServerMessage.Event.Degraded
+	37, // 51: signaling.SignalingServerMessage.Event.chunk:type_name -> signaling.SignalingServerMessage.Event.Chunk
+	9,  // 52: signaling.SignalingServerMessage.Event.invite:type_name -> signaling.SignalingRoomEvent
+	9,  // 53: signaling.SignalingServerMessage.Event.disinvite:type_name -> signaling.SignalingRoomEvent
+	9,  // 54: signaling.SignalingServerMessage.Event.update:type_name -> signaling.SignalingRoomEvent
+	38, // 55: signaling.SignalingServerMessage.Event.flags:type_name -> signaling.SignalingServerMessage.Event.Flags
+	39, // 56: signaling.SignalingServerMessage.Event.message:type_name -> signaling.SignalingServerMessage.Event.RoomMessage
+	40, // 57: signaling.SignalingServerMessage.Event.lagging:type_name -> signaling.SignalingServerMessage.Event.Lagging
+	41, // 58: signaling.SignalingServerMessage.Event.permissions:type_name -> signaling.SignalingServerMessage.Event.Permissions
+	42, // 59: signaling.SignalingServerMessage.Event.resync:type_name -> signaling.SignalingServerMessage.Event.Resync
+	43, // 60: signaling.SignalingServerMessage.Event.receipt:type_name -> signaling.SignalingServerMessage.Event.Receipt
+	44, // 61: signaling.SignalingServerMessage.TransientData.oldvalue:type_name -> google.protobuf.Value
+	44, // 62: signaling.SignalingServerMessage.TransientData.value:type_name -> google.protobuf.Value
+	45, // 63: signaling.SignalingServerMessage.TransientData.data:type_name -> google.protobuf.Struct
+	44, // 64: signaling.SignalingServerMessage.Event.SwitchTo.details:type_name -> google.protobuf.Value
+	44, // 65: signaling.SignalingServerMessage.Event.RoomMessage.data:type_name -> google.protobuf.Value
+	4,  // 66: signaling.SignalingServerMessage.Event.Receipt.recipient:type_name -> signaling.SignalingRecipient
+	0,  // 67: signaling.RpcSignaling.Receive:input_type -> signaling.SignalingReceiveRequest
+	2,  // 68: signaling.RpcSignaling.Send:input_type -> signaling.SignalingSendRequest
+	1,  // 69: signaling.RpcSignaling.Receive:output_type -> signaling.SignalingReceiveResponse
+	3,  // 70: signaling.RpcSignaling.Send:output_type -> signaling.SignalingSendResponse
+	69, // [69:71] is the sub-list for method output_type
+	67, // [67:69] is the sub-list for method input_type
+	67, // [67:67] is the sub-list for extension type_name
+	67, // [67:67] is the sub-list for extension extendee
+	0,  // [0:67] is the sub-list for field type_name
+}
+
+func init() { file_rpc_signaling_proto_init() }
+func file_rpc_signaling_proto_init() {
+	if File_rpc_signaling_proto != nil {
+		return
+	}
+	file_rpc_signaling_proto_msgTypes[1].OneofWrappers = []any{
+		(*SignalingReceiveResponse_Connectionid)(nil),
+		(*SignalingReceiveResponse_Message)(nil),
+	}
+	file_rpc_signaling_proto_msgTypes[6].OneofWrappers = []any{
+		(*SignalingClientMessage_Hello_)(nil),
+		(*SignalingClientMessage_Bye_)(nil),
+		(*SignalingClientMessage_Room_)(nil),
+		(*SignalingClientMessage_Message_)(nil),
+		(*SignalingClientMessage_Control)(nil),
+		(*SignalingClientMessage_Transient)(nil),
+		(*SignalingClientMessage_Participants_)(nil),
+		(*SignalingClientMessage_Echo_)(nil),
+		(*SignalingClientMessage_Dtmf_)(nil),
+		(*SignalingClientMessage_Recording_)(nil),
+	}
+	file_rpc_signaling_proto_msgTypes[10].OneofWrappers = []any{
+		(*SignalingServerMessage_Error_)(nil),
+		(*SignalingServerMessage_Welcome_)(nil),
+		(*SignalingServerMessage_Hello_)(nil),
+		(*SignalingServerMessage_Bye_)(nil),
+		(*SignalingServerMessage_Room_)(nil),
+		(*SignalingServerMessage_Message_)(nil),
+		(*SignalingServerMessage_Control)(nil),
+		(*SignalingServerMessage_Event_)(nil),
+		(*SignalingServerMessage_Transient)(nil),
+		(*SignalingServerMessage_Echo_)(nil),
+	}
+	file_rpc_signaling_proto_msgTypes[29].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rpc_signaling_proto_rawDesc), len(file_rpc_signaling_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   44,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rpc_signaling_proto_goTypes,
+		DependencyIndexes: file_rpc_signaling_proto_depIdxs,
+		MessageInfos:      file_rpc_signaling_proto_msgTypes,
+	}.Build()
+	File_rpc_signaling_proto = out.File
+	file_rpc_signaling_proto_goTypes = nil
+	file_rpc_signaling_proto_depIdxs = nil
+}
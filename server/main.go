@@ -24,24 +24,27 @@ package main
 import (
 	"context"
 	"crypto/tls"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"net"
 	"net/http"
-	"net/http/pprof"
+	"net/url"
 	"os"
 	"os/signal"
 	"runtime"
 	runtimepprof "runtime/pprof"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/dlintw/goconf"
 	"github.com/gorilla/mux"
-	"github.com/nats-io/nats.go"
 
 	signaling "github.com/strukturag/nextcloud-spreed-signaling"
 )
@@ -56,6 +59,22 @@ var (
 	memprofile = flag.String("memprofile", "", "write memory profile to file")
 
 	showVersion = flag.Bool("version", false, "show version and quit")
+
+	checkConfig = flag.Bool("check-config", false, "check the configuration and quit")
+
+	healthcheck = flag.Bool("healthcheck", false, "check if the running server is ready and quit")
+
+	decodeSessionId = flag.String("decode-session-id", "", "decode the given public or private session id and quit")
+
+	querySession = flag.Bool("query-session", false, "query the running server for the state of the session given in -decode-session-id")
+
+	dumpState = flag.String("dump-state", "", "write a snapshot of the sessions and rooms of the running server to the given file (\"-\" for stdout) and quit")
+
+	restoreState = flag.String("restore-state", "", "import a snapshot of sessions and rooms from the given file (\"-\" for stdin) into the running server and quit")
+
+	takeover = flag.Bool("takeover", false, "take over the listeners and sessions of the running server through the socket configured in \"handoffsocket\"")
+
+	appLog = signaling.NewLogger(signaling.LogSubsystemApp)
 )
 
 const (
@@ -66,31 +85,39 @@ const (
 	maxMcuRetry     = time.Second * 16
 
 	dnsMonitorInterval = time.Second
+
+	sessionRequestTimeout = 5 * time.Second
+
+	// Snapshots of the state may contain many sessions.
+	stateRequestTimeout = time.Minute
+
+	sessionStoreTimeout = 30 * time.Second
 )
 
-func createListener(addr string) (net.Listener, error) {
-	if addr[0] == '/' {
-		os.Remove(addr)
-		return net.Listen("unix", addr)
+// createListener creates a listener for the given address. If "trusted" is
+// set, the addresses of clients are read from the PROXY protocol header.
+func createListener(addr string, reusePort bool, trusted func() *signaling.AllowedIps) (net.Listener, error) {
+	listener, err := signaling.CreateListener(addr, reusePort)
+	if err != nil {
+		return nil, err
 	}
 
-	return net.Listen("tcp", addr)
+	if trusted != nil {
+		listener = signaling.NewProxyProtocolListener(listener, trusted)
+	}
+	return listener, nil
 }
 
-func createTLSListener(addr string, certFile, keyFile string) (net.Listener, error) {
-	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+func createTLSListener(addr string, reusePort bool, trusted func() *signaling.AllowedIps, certificates *signaling.CertificateWatcher) (net.Listener, error) {
+	listener, err := createListener(addr, reusePort, trusted)
 	if err != nil {
 		return nil, err
 	}
+
 	config := tls.Config{
-		Certificates: []tls.Certificate{cert},
+		GetCertificate: certificates.GetCertificate,
 	}
-	if addr[0] == '/' {
-		os.Remove(addr)
-		return tls.Listen("unix", addr, &config)
-	}
-
-	return tls.Listen("tcp", addr, &config)
+	return tls.NewListener(listener, &config), nil
 }
 
 type Listeners struct {
@@ -111,9 +138,153 @@ func (l *Listeners) Close() {
 
 	for _, listener := range l.listeners {
 		if err := listener.Close(); err != nil {
-			log.Printf("Error closing listener %s: %s", listener.Addr(), err)
+			appLog.Errorf("Error closing listener %s: %s", listener.Addr(), err)
+		}
+	}
+}
+
+func inspectSessionId(sessionId string, query bool) error {
+	config, _, err := signaling.LoadConfig(*configFlag)
+	if err != nil {
+		return fmt.Errorf("could not read configuration %s: %w", *configFlag, err)
+	}
+
+	codec, err := signaling.NewSessionIdCodecFromConfig(config)
+	if err != nil {
+		return err
+	}
+
+	idType, data, err := codec.Decode(sessionId)
+	if err != nil {
+		return fmt.Errorf("could not decode session id: %w", err)
+	}
+
+	fmt.Printf("Type:    %s\n", idType)
+	fmt.Printf("Sid:     %d\n", data.Sid)
+	fmt.Printf("Backend: %s\n", data.BackendId)
+	fmt.Printf("Created: %s\n", data.Created.AsTime().Local().Format(time.RFC3339))
+	if !query {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sessionRequestTimeout)
+	defer cancel()
+
+	response, err := signaling.PerformLocalAdminRequest(ctx, config, http.MethodGet, "/api/v1/sessions/"+url.PathEscape(sessionId), nil)
+	if err != nil {
+		return fmt.Errorf("could not query session: %w", err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("could not read session: %w", err)
+	}
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		fmt.Printf("State:\n%s\n", strings.TrimSpace(string(body)))
+	case http.StatusNotFound:
+		fmt.Println("State:   not connected to this server")
+	default:
+		return fmt.Errorf("could not query session, got status %d: %s", response.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// saveSessions writes the sessions to the session store before shutting down.
+// Returns false if no session store is configured or saving failed.
+func saveSessions(hub *signaling.Hub) bool {
+	if !hub.HasSessionStore() {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sessionStoreTimeout)
+	defer cancel()
+	if err := hub.SaveSessions(ctx); err != nil {
+		appLog.Errorf("Could not save sessions: %s", err)
+		return false
+	}
+
+	appLog.Infof("Saved sessions to session store")
+	return true
+}
+
+// dumpServerState writes a snapshot of the state of the running server to the
+// given file.
+func dumpServerState(filename string) error {
+	config, _, err := signaling.LoadConfig(*configFlag)
+	if err != nil {
+		return fmt.Errorf("could not read configuration %s: %w", *configFlag, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), stateRequestTimeout)
+	defer cancel()
+
+	response, err := signaling.PerformLocalAdminRequest(ctx, config, http.MethodGet, "/api/v1/state", nil)
+	if err != nil {
+		return fmt.Errorf("could not get state: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+		return fmt.Errorf("could not get state, got status %d: %s", response.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	out := os.Stdout
+	if filename != "-" {
+		if out, err = os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600); err != nil {
+			return err
 		}
+		defer out.Close()
 	}
+
+	if _, err := io.Copy(out, response.Body); err != nil {
+		return fmt.Errorf("could not write state: %w", err)
+	}
+	return out.Sync()
+}
+
+// restoreServerState imports a snapshot of the state from the given file into
+// the running server.
+func restoreServerState(filename string) error {
+	config, _, err := signaling.LoadConfig(*configFlag)
+	if err != nil {
+		return fmt.Errorf("could not read configuration %s: %w", *configFlag, err)
+	}
+
+	in := os.Stdin
+	if filename != "-" {
+		if in, err = os.Open(filename); err != nil {
+			return err
+		}
+		defer in.Close()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), stateRequestTimeout)
+	defer cancel()
+
+	response, err := signaling.PerformLocalAdminRequest(ctx, config, http.MethodPost, "/api/v1/state", in)
+	if err != nil {
+		return fmt.Errorf("could not restore state: %w", err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("could not read response: %w", err)
+	} else if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("could not restore state, got status %d: %s", response.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var restored signaling.BackendServerStateRestored
+	if err := json.Unmarshal(body, &restored); err != nil {
+		return fmt.Errorf("could not decode response %s: %w", string(body), err)
+	}
+
+	fmt.Printf("Restored %d sessions in %d rooms\n", restored.Sessions, restored.Rooms)
+	return nil
 }
 
 func main() {
@@ -125,110 +296,208 @@ func main() {
 		os.Exit(0)
 	}
 
+	if *checkConfig {
+		if _, _, err := signaling.LoadConfig(*configFlag); err != nil {
+			fmt.Printf("Configuration %s is invalid: %s\n", *configFlag, err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Configuration %s is valid\n", *configFlag)
+		os.Exit(0)
+	}
+
+	if *healthcheck {
+		config, _, err := signaling.LoadConfig(*configFlag)
+		if err != nil {
+			fmt.Printf("Could not read configuration %s: %s\n", *configFlag, err)
+			os.Exit(1)
+		}
+
+		if err := signaling.RunHealthcheck(context.Background(), config, "/ready"); err != nil {
+			fmt.Printf("Healthcheck failed: %s\n", err)
+			os.Exit(1)
+		}
+
+		os.Exit(0)
+	}
+
+	if *dumpState != "" {
+		if err := dumpServerState(*dumpState); err != nil {
+			fmt.Printf("Could not dump state: %s\n", err)
+			os.Exit(1)
+		}
+
+		os.Exit(0)
+	}
+
+	if *restoreState != "" {
+		if err := restoreServerState(*restoreState); err != nil {
+			fmt.Printf("Could not restore state: %s\n", err)
+			os.Exit(1)
+		}
+
+		os.Exit(0)
+	}
+
+	if *decodeSessionId != "" {
+		if err := inspectSessionId(*decodeSessionId, *querySession); err != nil {
+			fmt.Printf("%s\n", err)
+			os.Exit(1)
+		}
+
+		os.Exit(0)
+	}
+
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, os.Interrupt)
 	signal.Notify(sigChan, syscall.SIGHUP)
 	signal.Notify(sigChan, syscall.SIGUSR1)
+	signal.Notify(sigChan, syscall.SIGUSR2)
+	signal.Notify(sigChan, syscall.SIGTERM)
 
 	if *cpuprofile != "" {
 		f, err := os.Create(*cpuprofile)
 		if err != nil {
-			log.Fatal(err)
+			appLog.Fatalf("%s", err)
 		}
 
 		if err := runtimepprof.StartCPUProfile(f); err != nil {
-			log.Fatalf("Error writing CPU profile to %s: %s", *cpuprofile, err)
+			appLog.Fatalf("Error writing CPU profile to %s: %s", *cpuprofile, err)
 		}
-		log.Printf("Writing CPU profile to %s ...", *cpuprofile)
+		appLog.Infof("Writing CPU profile to %s ...", *cpuprofile)
 		defer runtimepprof.StopCPUProfile()
 	}
 
 	if *memprofile != "" {
 		f, err := os.Create(*memprofile)
 		if err != nil {
-			log.Fatal(err)
+			appLog.Fatalf("%s", err)
 		}
 
 		defer func() {
-			log.Printf("Writing Memory profile to %s ...", *memprofile)
+			appLog.Infof("Writing Memory profile to %s ...", *memprofile)
 			runtime.GC()
 			if err := runtimepprof.WriteHeapProfile(f); err != nil {
-				log.Printf("Error writing Memory profile to %s: %s", *memprofile, err)
+				appLog.Errorf("Error writing Memory profile to %s: %s", *memprofile, err)
 			}
 		}()
 	}
 
-	log.Printf("Starting up version %s/%s as pid %d", version, runtime.Version(), os.Getpid())
+	appLog.Infof("Starting up version %s/%s as pid %d", version, runtime.Version(), os.Getpid())
 
-	config, err := goconf.ReadConfigFile(*configFlag)
+	config, includes, err := signaling.LoadConfig(*configFlag)
 	if err != nil {
-		log.Fatal("Could not read configuration: ", err)
+		appLog.Fatalf("Could not read configuration: %s", err)
+	}
+
+	if err := signaling.ConfigureLogging(config); err != nil {
+		appLog.Fatalf("Could not configure logging: %s", err)
+	}
+
+	if err := signaling.ConfigureFileWatcher(config); err != nil {
+		appLog.Fatalf("Could not configure file watcher: %s", err)
 	}
 
-	log.Printf("Using a maximum of %d CPUs", runtime.GOMAXPROCS(0))
+	appLog.Infof("Using a maximum of %d CPUs", runtime.GOMAXPROCS(0))
 
 	signaling.RegisterStats()
+	signaling.RegisterBuildInfo(version)
 
-	natsUrl, _ := signaling.GetStringOptionWithEnv(config, "nats", "url")
-	if natsUrl == "" {
-		natsUrl = nats.DefaultURL
+	statsPusher, err := signaling.NewStatsPusher(config, "nextcloud-spreed-signaling")
+	if err != nil {
+		appLog.Fatalf("Could not create metrics pusher: %s", err)
 	}
+	statsPusher.Start()
+	defer statsPusher.Close()
 
-	events, err := signaling.NewAsyncEvents(natsUrl)
+	errorReporting, err := signaling.NewErrorReporting(config, version)
 	if err != nil {
-		log.Fatal("Could not create async events client: ", err)
+		appLog.Fatalf("Could not initialize error reporting: %s", err)
+	}
+	defer errorReporting.Close(5 * time.Second)
+
+	tracing, err := signaling.NewTracing(context.Background(), config, "nextcloud-spreed-signaling", version)
+	if err != nil {
+		appLog.Fatalf("Could not initialize tracing: %s", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		tracing.Close(ctx)
+	}()
+
+	events, err := signaling.NewAsyncEventsFromConfig(config)
+	if err != nil {
+		appLog.Fatalf("Could not create async events client: %s", err)
 	}
 	defer events.Close()
 
 	dnsMonitor, err := signaling.NewDnsMonitor(dnsMonitorInterval)
 	if err != nil {
-		log.Fatal("Could not create DNS monitor: ", err)
+		appLog.Fatalf("Could not create DNS monitor: %s", err)
 	}
 	if err := dnsMonitor.Start(); err != nil {
-		log.Fatal("Could not start DNS monitor: ", err)
+		appLog.Fatalf("Could not start DNS monitor: %s", err)
 	}
 	defer dnsMonitor.Stop()
 
 	etcdClient, err := signaling.NewEtcdClient(config, "mcu")
 	if err != nil {
-		log.Fatalf("Could not create etcd client: %s", err)
+		appLog.Fatalf("Could not create etcd client: %s", err)
 	}
 	defer func() {
 		if err := etcdClient.Close(); err != nil {
-			log.Printf("Error while closing etcd client: %s", err)
+			appLog.Errorf("Error while closing etcd client: %s", err)
 		}
 	}()
 
+	handoffSocket, _ := config.GetString("app", "handoffsocket")
+	var handoff *signaling.HandoffClient
+	if *takeover {
+		if handoffSocket == "" {
+			appLog.Fatalf("No handoff socket configured to take over the running server")
+		}
+
+		appLog.Infof("Taking over running server through %s", handoffSocket)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		handoff, err = signaling.RequestHandoff(ctx, handoffSocket)
+		cancel()
+		if err != nil {
+			appLog.Fatalf("Could not take over running server: %s", err)
+		}
+		signaling.SetInheritedListeners(handoff.Listeners)
+	}
+
 	rpcServer, err := signaling.NewGrpcServer(config, version)
 	if err != nil {
-		log.Fatalf("Could not create RPC server: %s", err)
+		appLog.Fatalf("Could not create RPC server: %s", err)
 	}
 	go func() {
 		if err := rpcServer.Run(); err != nil {
-			log.Fatalf("Could not start RPC server: %s", err)
+			appLog.Fatalf("Could not start RPC server: %s", err)
 		}
 	}()
 	defer rpcServer.Close()
 
 	rpcClients, err := signaling.NewGrpcClients(config, etcdClient, dnsMonitor, version)
 	if err != nil {
-		log.Fatalf("Could not create RPC clients: %s", err)
+		appLog.Fatalf("Could not create RPC clients: %s", err)
 	}
 	defer rpcClients.Close()
 
 	r := mux.NewRouter()
 	hub, err := signaling.NewHub(config, events, rpcServer, rpcClients, etcdClient, r, version)
 	if err != nil {
-		log.Fatal("Could not create hub: ", err)
+		appLog.Fatalf("Could not create hub: %s", err)
 	}
 
 	mcuUrl, _ := signaling.GetStringOptionWithEnv(config, "mcu", "url")
 	mcuType, _ := config.GetString("mcu", "type")
 	if mcuType == "" && mcuUrl != "" {
-		log.Printf("WARNING: Old-style MCU configuration detected with url but no type, defaulting to type %s", signaling.McuTypeJanus)
+		appLog.Warnf("Old-style MCU configuration detected with url but no type, defaulting to type %s", signaling.McuTypeJanus)
 		mcuType = signaling.McuTypeJanus
 	} else if mcuType == signaling.McuTypeJanus && mcuUrl == "" {
-		log.Printf("WARNING: Old-style MCU configuration detected with type but no url, disabling")
+		appLog.Warnf("Old-style MCU configuration detected with type but no url, disabling")
 		mcuType = ""
 	}
 
@@ -249,38 +518,42 @@ func main() {
 				mcu, err = signaling.NewMcuProxy(config, etcdClient, rpcClients, dnsMonitor)
 				signaling.UnregisterJanusMcuStats()
 				signaling.RegisterProxyMcuStats()
+			case signaling.McuTypeLivekit:
+				mcu, err = signaling.NewMcuLivekit(mcuUrl, config)
+				signaling.UnregisterJanusMcuStats()
+				signaling.UnregisterProxyMcuStats()
 			default:
-				log.Fatal("Unsupported MCU type: ", mcuType)
+				appLog.Fatalf("Unsupported MCU type: %s", mcuType)
 			}
 			if err == nil {
 				err = mcu.Start(ctx)
 				if err != nil {
-					log.Printf("Could not create %s MCU: %s", mcuType, err)
+					appLog.Errorf("Could not create %s MCU: %s", mcuType, err)
 				}
 			}
 			if err == nil {
 				break
 			}
 
-			log.Printf("Could not initialize %s MCU (%s) will retry in %s", mcuType, err, mcuRetry)
+			appLog.Errorf("Could not initialize %s MCU (%s) will retry in %s", mcuType, err, mcuRetry)
 			mcuRetryTimer.Reset(mcuRetry)
 			select {
 			case sig := <-sigChan:
 				switch sig {
-				case os.Interrupt:
-					log.Fatalf("Cancelled")
+				case os.Interrupt, syscall.SIGTERM:
+					appLog.Fatalf("Cancelled")
 				case syscall.SIGHUP:
-					log.Printf("Received SIGHUP, reloading %s", *configFlag)
-					if config, err = goconf.ReadConfigFile(*configFlag); err != nil {
-						log.Printf("Could not read configuration from %s: %s", *configFlag, err)
+					appLog.Infof("Received SIGHUP, reloading %s", *configFlag)
+					if config, includes, err = signaling.LoadConfig(*configFlag); err != nil {
+						appLog.Errorf("Could not read configuration from %s: %s", *configFlag, err)
 					} else {
 						mcuUrl, _ = signaling.GetStringOptionWithEnv(config, "mcu", "url")
 						mcuType, _ = config.GetString("mcu", "type")
 						if mcuType == "" && mcuUrl != "" {
-							log.Printf("WARNING: Old-style MCU configuration detected with url but no type, defaulting to type %s", signaling.McuTypeJanus)
+							appLog.Warnf("Old-style MCU configuration detected with url but no type, defaulting to type %s", signaling.McuTypeJanus)
 							mcuType = signaling.McuTypeJanus
 						} else if mcuType == signaling.McuTypeJanus && mcuUrl == "" {
-							log.Printf("WARNING: Old-style MCU configuration detected with type but no url, disabling")
+							appLog.Warnf("Old-style MCU configuration detected with type but no url, disabling")
 							mcuType = ""
 							break mcuTypeLoop
 						}
@@ -294,7 +567,7 @@ func main() {
 		if mcu != nil {
 			defer mcu.Stop()
 
-			log.Printf("Using %s MCU", mcuType)
+			appLog.Infof("Using %s MCU", mcuType)
 			hub.SetMcu(mcu)
 		}
 	}
@@ -302,36 +575,54 @@ func main() {
 	go hub.Run()
 	defer hub.Stop()
 
+	if handoff != nil {
+		hub.RestoreHandoffState(handoff.State, true)
+	} else if hub.HasSessionStore() {
+		ctx, cancel := context.WithTimeout(context.Background(), sessionStoreTimeout)
+		if sessions, rooms, err := hub.RestoreSessions(ctx); err != nil {
+			appLog.Errorf("Could not restore stored sessions: %s", err)
+		} else if sessions > 0 {
+			appLog.Infof("Restored %d stored sessions in %d rooms", sessions, rooms)
+		}
+		cancel()
+	}
+
 	server, err := signaling.NewBackendServer(config, hub, version)
 	if err != nil {
-		log.Fatal("Could not create backend server: ", err)
+		appLog.Fatalf("Could not create backend server: %s", err)
 	}
 	if err := server.Start(r); err != nil {
-		log.Fatal("Could not start backend server: ", err)
+		appLog.Fatalf("Could not start backend server: %s", err)
 	}
 
 	if debug, _ := config.GetBool("app", "debug"); debug {
-		log.Println("Installing debug handlers in \"/debug/pprof\"")
-		r.Handle("/debug/pprof/", http.HandlerFunc(pprof.Index))
-		r.Handle("/debug/pprof/cmdline", http.HandlerFunc(pprof.Cmdline))
-		r.Handle("/debug/pprof/profile", http.HandlerFunc(pprof.Profile))
-		r.Handle("/debug/pprof/symbol", http.HandlerFunc(pprof.Symbol))
-		r.Handle("/debug/pprof/trace", http.HandlerFunc(pprof.Trace))
-		for _, profile := range runtimepprof.Profiles() {
-			name := profile.Name()
-			r.Handle("/debug/pprof/"+name, pprof.Handler(name))
-		}
+		appLog.Infof("Installing debug handlers in \"/debug/pprof\"")
+		server.InstallDebugHandlers(r)
 	}
 
 	var listeners Listeners
+	// Set if the listeners were closed after handing off to a new process.
+	var handedOff atomic.Bool
+	reloader := signaling.NewConfigReloader()
 
 	if saddr, _ := signaling.GetStringOptionWithEnv(config, "https", "listen"); saddr != "" {
 		cert, _ := config.GetString("https", "certificate")
 		key, _ := config.GetString("https", "key")
 		if cert == "" || key == "" {
-			log.Fatal("Need a certificate and key for the HTTPS listener")
+			appLog.Fatalf("Need a certificate and key for the HTTPS listener")
 		}
 
+		certificates, err := signaling.NewCertificateWatcher(cert, key)
+		if err != nil {
+			appLog.Fatalf("Could not load certificate for the HTTPS listener: %s", err)
+		}
+		defer certificates.Close()
+		reloader.Register(func(config *goconf.ConfigFile) error {
+			cert, _ := config.GetString("https", "certificate")
+			key, _ := config.GetString("https", "key")
+			return certificates.Reload(cert, key)
+		}, "https")
+
 		readTimeout, _ := config.GetInt("https", "readtimeout")
 		if readTimeout <= 0 {
 			readTimeout = defaultReadTimeout
@@ -340,12 +631,18 @@ func main() {
 		if writeTimeout <= 0 {
 			writeTimeout = defaultWriteTimeout
 		}
+		reusePort, _ := config.GetBool("https", "reuseport")
+		var trusted func() *signaling.AllowedIps
+		if proxyProtocol, _ := config.GetBool("https", "proxyprotocol"); proxyProtocol {
+			appLog.Infof("Using PROXY protocol for HTTPS connections from trusted proxies")
+			trusted = hub.GetTrustedProxies
+		}
 		for address := range signaling.SplitEntries(saddr, " ") {
 			go func(address string) {
-				log.Println("Listening on", address)
-				listener, err := createTLSListener(address, cert, key)
+				appLog.Infof("Listening on %v", address)
+				listener, err := createTLSListener(address, reusePort, trusted, certificates)
 				if err != nil {
-					log.Fatal("Could not start listening: ", err)
+					appLog.Fatalf("Could not start listening: %s", err)
 				}
 				srv := &http.Server{
 					Handler: r,
@@ -355,12 +652,34 @@ func main() {
 				}
 				listeners.Add(listener)
 				if err := srv.Serve(listener); err != nil {
-					if !hub.IsShutdownScheduled() || !errors.Is(err, net.ErrClosed) {
-						log.Fatal("Could not start server: ", err)
+					if !errors.Is(err, net.ErrClosed) || (!hub.IsShutdownScheduled() && !handedOff.Load()) {
+						appLog.Fatalf("Could not start server: %s", err)
 					}
 				}
 			}(address)
 		}
+
+		if webTransport, _ := config.GetBool("https", "webtransport"); webTransport {
+			wt := signaling.NewWebTransportServer(hub, certificates.GetCertificate)
+			defer wt.Close() // nolint
+			for address := range signaling.SplitEntries(saddr, " ") {
+				if strings.HasPrefix(address, "systemd:") {
+					appLog.Warnf("WebTransport is not supported for systemd socket %s", address)
+					continue
+				}
+
+				conn, err := net.ListenPacket("udp", address)
+				if err != nil {
+					appLog.Fatalf("Could not start listening for WebTransport: %s", err)
+				}
+				go func(address string) {
+					appLog.Infof("Listening for WebTransport on %v", address)
+					if err := wt.Serve(conn); err != nil && !errors.Is(err, context.Canceled) && !errors.Is(err, net.ErrClosed) {
+						appLog.Errorf("Could not serve WebTransport: %s", err)
+					}
+				}(address)
+			}
+		}
 	}
 
 	if addr, _ := signaling.GetStringOptionWithEnv(config, "http", "listen"); addr != "" {
@@ -372,13 +691,19 @@ func main() {
 		if writeTimeout <= 0 {
 			writeTimeout = defaultWriteTimeout
 		}
+		reusePort, _ := config.GetBool("http", "reuseport")
+		var trusted func() *signaling.AllowedIps
+		if proxyProtocol, _ := config.GetBool("http", "proxyprotocol"); proxyProtocol {
+			appLog.Infof("Using PROXY protocol for HTTP connections from trusted proxies")
+			trusted = hub.GetTrustedProxies
+		}
 
 		for address := range signaling.SplitEntries(addr, " ") {
 			go func(address string) {
-				log.Println("Listening on", address)
-				listener, err := createListener(address)
+				appLog.Infof("Listening on %v", address)
+				listener, err := createListener(address, reusePort, trusted)
 				if err != nil {
-					log.Fatal("Could not start listening: ", err)
+					appLog.Fatalf("Could not start listening: %s", err)
 				}
 				srv := &http.Server{
 					Handler: r,
@@ -389,37 +714,126 @@ func main() {
 				}
 				listeners.Add(listener)
 				if err := srv.Serve(listener); err != nil {
-					if !hub.IsShutdownScheduled() || !errors.Is(err, net.ErrClosed) {
-						log.Fatal("Could not start server: ", err)
+					if !errors.Is(err, net.ErrClosed) || (!hub.IsShutdownScheduled() && !handedOff.Load()) {
+						appLog.Fatalf("Could not start server: %s", err)
 					}
 				}
 			}(address)
 		}
 	}
 
+	var admin *signaling.AdminServer
+	if addr, _ := signaling.GetStringOptionWithEnv(config, "admin", "listen"); addr != "" {
+		admin, err = signaling.NewAdminServer(config, hub)
+		if err != nil {
+			appLog.Fatalf("Could not create admin server: %s", err)
+		}
+		reloader.Register(admin.Reload, "admin")
+
+		ar := mux.NewRouter()
+		admin.Start(ar)
+		for address := range signaling.SplitEntries(addr, " ") {
+			go func(address string) {
+				appLog.Infof("Listening for admin requests on %v", address)
+				listener, err := createListener(address, false, nil)
+				if err != nil {
+					appLog.Fatalf("Could not start listening: %s", err)
+				}
+				srv := &http.Server{
+					Handler: ar,
+
+					ReadTimeout:  time.Duration(defaultReadTimeout) * time.Second,
+					WriteTimeout: time.Duration(defaultWriteTimeout) * time.Second,
+				}
+				listeners.Add(listener)
+				if err := srv.Serve(listener); err != nil {
+					if !errors.Is(err, net.ErrClosed) || (!hub.IsShutdownScheduled() && !handedOff.Load()) {
+						appLog.Fatalf("Could not start admin server: %s", err)
+					}
+				}
+			}(address)
+		}
+	}
+
+	if handoff != nil {
+		if err := handoff.Complete(); err != nil {
+			appLog.Fatalf("Could not complete takeover of running server: %s", err)
+		}
+		signaling.CloseInheritedListeners()
+		appLog.Infof("Took over running server")
+	}
+
+	var handoffDone <-chan struct{}
+	if handoffSocket != "" {
+		handoffServer, err := signaling.NewHandoffServer(handoffSocket, hub, signaling.GetCreatedListeners, func() {
+			handedOff.Store(true)
+			listeners.Close()
+		})
+		if err != nil {
+			appLog.Fatalf("Could not create handoff socket %s: %s", handoffSocket, err)
+		}
+		defer handoffServer.Close()
+		handoffDone = handoffServer.Done()
+	}
+
+	watchConfig, _ := config.GetBool("app", "watchconfig")
+	reloader.Register(signaling.ConfigureLogging, "logging")
+	reloader.Register(signaling.ConfigureFileWatcher, "app")
+	// The remaining components use options from most of the other sections.
+	reloader.Register(hub.Reload)
+	reloader.Register(server.Reload)
+	configWatcher, err := signaling.NewConfigWatcher(*configFlag, config, includes, watchConfig, reloader.Callback)
+	if err != nil {
+		appLog.Fatalf("Could not watch configuration: %s", err)
+	}
+	defer configWatcher.Close()
+	if admin != nil {
+		admin.SetConfigReloadHandler(func() (signaling.ConfigReloadResults, error) {
+			appLog.Infof("Reload requested through admin API, reloading %s", *configFlag)
+			if err := configWatcher.Reload(true); err != nil {
+				return nil, err
+			}
+
+			return reloader.Results(), nil
+		})
+	}
+
 loop:
 	for {
 		select {
 		case sig := <-sigChan:
 			switch sig {
 			case os.Interrupt:
-				log.Println("Interrupted")
-				break loop
-			case syscall.SIGHUP:
-				log.Printf("Received SIGHUP, reloading %s", *configFlag)
-				if config, err := goconf.ReadConfigFile(*configFlag); err != nil {
-					log.Printf("Could not read configuration from %s: %s", *configFlag, err)
+				appLog.Infof("Interrupted")
+				if saveSessions(hub) {
+					// Clients will resume their sessions after the restart.
+					hub.CloseClientsForHandoff()
 				} else {
-					hub.Reload(config)
-					server.Reload(config)
+					hub.DrainClients()
 				}
+				break loop
+			case syscall.SIGHUP:
+				appLog.Infof("Received SIGHUP, reloading %s", *configFlag)
+				configWatcher.Reload(true) // nolint
 			case syscall.SIGUSR1:
-				log.Printf("Received SIGUSR1, scheduling server to shutdown")
+				appLog.Infof("Received SIGUSR1, scheduling server to shutdown")
 				hub.ScheduleShutdown()
 				listeners.Close()
+			case syscall.SIGTERM:
+				appLog.Infof("Received SIGTERM, draining server")
+				hub.Drain()
+			case syscall.SIGUSR2:
+				if signaling.ToggleDebugLogging() {
+					appLog.Infof("Received SIGUSR2, enabled debug logging")
+				} else {
+					appLog.Infof("Received SIGUSR2, restored configured log levels")
+				}
 			}
 		case <-hub.ShutdownChannel():
-			log.Printf("All clients disconnected, shutting down")
+			appLog.Infof("All clients disconnected, shutting down")
+			break loop
+		case <-handoffDone:
+			appLog.Infof("Handed off to new process, shutting down")
 			break loop
 		}
 	}
@@ -39,6 +39,22 @@ var (
 	PERMISSION_TRANSIENT_DATA     Permission = "transient-data"
 	PERMISSION_HIDE_DISPLAYNAMES  Permission = "hide-displaynames"
 
+	// PERMISSION_MAY_CHAT is only evaluated for virtual sessions which will
+	// not receive messages from other sessions without it.
+	PERMISSION_MAY_CHAT Permission = "chat"
+
+	// KnownPermissions contains all permissions that are evaluated by the
+	// signaling server.
+	KnownPermissions = []Permission{
+		PERMISSION_MAY_PUBLISH_MEDIA,
+		PERMISSION_MAY_PUBLISH_AUDIO,
+		PERMISSION_MAY_PUBLISH_VIDEO,
+		PERMISSION_MAY_PUBLISH_SCREEN,
+		PERMISSION_MAY_CONTROL,
+		PERMISSION_TRANSIENT_DATA,
+		PERMISSION_HIDE_DISPLAYNAMES,
+	}
+
 	// DefaultPermissionOverrides contains permission overrides for users where
 	// no permissions have been set by the server. If a permission is not set in
 	// this map, it's assumed the user has that permission.
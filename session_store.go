@@ -0,0 +1,240 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dlintw/goconf"
+)
+
+const (
+	SessionStoreTypeEtcd  = "etcd"
+	SessionStoreTypeRedis = "redis"
+
+	defaultSessionStoreInterval = 10 * time.Second
+)
+
+// SessionStore persists the state of the sessions, so clients can resume
+// them after the server was restarted.
+type SessionStore interface {
+	Close()
+
+	Save(ctx context.Context, data []byte) error
+	// Load returns nil if no state has been saved.
+	Load(ctx context.Context) ([]byte, error)
+}
+
+// storedSessionState is the state that is saved in the session store.
+type storedSessionState struct {
+	Saved time.Time `json:"saved"`
+
+	HandoffState
+}
+
+func getDefaultSessionStoreKey() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "localhost"
+	}
+
+	return "/signaling/sessions/" + hostname
+}
+
+// NewSessionStore creates the session store configured in section "sessions".
+// Returns nil if no session store is configured.
+func NewSessionStore(config *goconf.ConfigFile, etcdClient *EtcdClient) (SessionStore, error) {
+	storeType, _ := config.GetString("sessions", "store")
+	if storeType == "" {
+		return nil, nil
+	}
+
+	key, _ := config.GetString("sessions", "storekey")
+	if key = strings.TrimSpace(key); key == "" {
+		key = getDefaultSessionStoreKey()
+	}
+
+	switch storeType {
+	case SessionStoreTypeEtcd:
+		return NewSessionStoreEtcd(etcdClient, key)
+	case SessionStoreTypeRedis:
+		redisUrl, _ := GetStringOptionWithEnv(config, "redis", "url")
+		if redisUrl == "" {
+			redisUrl = DefaultRedisUrl
+		}
+
+		return NewSessionStoreRedis(redisUrl, key)
+	default:
+		return nil, fmt.Errorf("unknown session store type: %s", storeType)
+	}
+}
+
+func getSessionStoreInterval(config *goconf.ConfigFile) time.Duration {
+	interval := defaultSessionStoreInterval
+	if value, err := config.GetInt("sessions", "storeinterval"); err == nil && value > 0 {
+		interval = time.Duration(value) * time.Second
+	}
+	return interval
+}
+
+type sessionStoreEtcd struct {
+	client *EtcdClient
+	key    string
+}
+
+func NewSessionStoreEtcd(client *EtcdClient, key string) (SessionStore, error) {
+	if client == nil || !client.IsConfigured() {
+		return nil, fmt.Errorf("no etcd endpoints configured")
+	}
+
+	return &sessionStoreEtcd{
+		client: client,
+		key:    key,
+	}, nil
+}
+
+func (s *sessionStoreEtcd) Close() {
+}
+
+func (s *sessionStoreEtcd) Save(ctx context.Context, data []byte) error {
+	_, err := s.client.Put(ctx, s.key, string(data))
+	return err
+}
+
+func (s *sessionStoreEtcd) Load(ctx context.Context) ([]byte, error) {
+	if err := s.client.WaitForConnection(ctx); err != nil {
+		return nil, err
+	}
+
+	response, err := s.client.Get(ctx, s.key)
+	if err != nil {
+		return nil, err
+	} else if len(response.Kvs) == 0 {
+		return nil, nil
+	}
+
+	return response.Kvs[0].Value, nil
+}
+
+type sessionStoreRedis struct {
+	client *RedisClient
+	key    string
+}
+
+func NewSessionStoreRedis(url string, key string) (SessionStore, error) {
+	client, err := NewRedisClient(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sessionStoreRedis{
+		client: client,
+		key:    key,
+	}, nil
+}
+
+func (s *sessionStoreRedis) Close() {
+	s.client.Close()
+}
+
+func (s *sessionStoreRedis) Save(ctx context.Context, data []byte) error {
+	return s.client.Set(s.key, data)
+}
+
+func (s *sessionStoreRedis) Load(ctx context.Context) ([]byte, error) {
+	return s.client.Get(s.key)
+}
+
+// HasSessionStore returns true if a session store is configured.
+func (h *Hub) HasSessionStore() bool {
+	return h.sessionStore != nil
+}
+
+// SaveSessions writes the state of the sessions to the session store.
+func (h *Hub) SaveSessions(ctx context.Context) error {
+	if h.sessionStore == nil || h.sessionStoreDisabled.Load() {
+		return nil
+	}
+
+	state := &storedSessionState{
+		Saved:        time.Now(),
+		HandoffState: *h.GetHandoffState(),
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	return h.sessionStore.Save(ctx, data)
+}
+
+func (h *Hub) saveSessionsPeriodically() {
+	if !h.savingSessions.CompareAndSwap(false, true) {
+		// Previous state is still being saved.
+		return
+	}
+	defer h.savingSessions.Store(false)
+
+	ctx, cancel := context.WithTimeout(context.Background(), h.sessionStoreInterval)
+	defer cancel()
+	if err := h.SaveSessions(ctx); err != nil {
+		hubLog.Errorf("Could not save sessions: %s", err)
+	}
+}
+
+// RestoreSessions restores the sessions from the session store so they can
+// be resumed by their clients. States that are older than the session expiry
+// are ignored. Returns the number of restored sessions and rooms.
+func (h *Hub) RestoreSessions(ctx context.Context) (int, int, error) {
+	if h.sessionStore == nil {
+		return 0, 0, nil
+	}
+
+	data, err := h.sessionStore.Load(ctx)
+	if err != nil {
+		return 0, 0, err
+	} else if len(data) == 0 {
+		return 0, 0, nil
+	}
+
+	var state storedSessionState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return 0, 0, fmt.Errorf("could not decode stored sessions: %w", err)
+	}
+
+	if age := time.Since(state.Saved); age > sessionExpireDuration {
+		hubLog.Infof("Ignoring stored sessions from %s, they expired already", state.Saved)
+		return 0, 0, nil
+	}
+
+	if err := h.backend.backends.WaitForInitialized(ctx); err != nil {
+		return 0, 0, err
+	}
+
+	sessions, rooms := h.RestoreHandoffState(&state.HandoffState, true)
+	return sessions, rooms, nil
+}
@@ -0,0 +1,196 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dlintw/goconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionStoreConfig(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	config := goconf.NewConfigFile()
+	store, err := NewSessionStore(config, nil)
+	assert.NoError(err)
+	assert.Nil(store)
+	assert.Equal(defaultSessionStoreInterval, getSessionStoreInterval(config))
+
+	config.AddOption("sessions", "store", "unknown")
+	_, err = NewSessionStore(config, nil)
+	assert.Error(err)
+
+	config.AddOption("sessions", "store", SessionStoreTypeEtcd)
+	_, err = NewSessionStore(config, nil)
+	assert.Error(err)
+
+	config.AddOption("sessions", "storeinterval", "30")
+	assert.Equal(30*time.Second, getSessionStoreInterval(config))
+}
+
+func TestSessionStoreEtcd(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	_, client := NewEtcdClientForTest(t)
+
+	config := goconf.NewConfigFile()
+	config.AddOption("sessions", "store", SessionStoreTypeEtcd)
+	config.AddOption("sessions", "storekey", "/test/sessions")
+	store, err := NewSessionStore(config, client)
+	require.NoError(err)
+	defer store.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	data, err := store.Load(ctx)
+	require.NoError(err)
+	assert.Nil(data)
+
+	require.NoError(store.Save(ctx, []byte("the-data")))
+	data, err = store.Load(ctx)
+	require.NoError(err)
+	assert.Equal("the-data", string(data))
+}
+
+func TestSessionStoreRestore(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+
+	redis := startLocalRedisServer(t)
+	var backendServer *httptest.Server
+	getConfig := func(server *httptest.Server) (*goconf.ConfigFile, error) {
+		if backendServer == nil {
+			backendServer = server
+		}
+		// The restarted hub uses the same configuration (backend and session keys).
+		config, err := getTestConfig(backendServer)
+		if err != nil {
+			return nil, err
+		}
+
+		config.AddOption("sessions", "store", SessionStoreTypeRedis)
+		config.AddOption("sessions", "storekey", "the-sessions")
+		config.AddOption("redis", "url", redis.URL())
+		return config, nil
+	}
+	hub1, _, _, server1 := CreateHubForTestWithConfig(t, getConfig)
+	hub2, _, _, server2 := CreateHubForTestWithConfig(t, getConfig)
+	require.True(hub1.HasSessionStore())
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	// Nothing has been stored yet.
+	sessions, rooms, err := hub2.RestoreSessions(ctx)
+	require.NoError(err)
+	assert.Equal(0, sessions)
+	assert.Equal(0, rooms)
+
+	client1, hello1 := NewTestClientWithHello(ctx, t, server1, hub1, testDefaultUserId)
+	roomId := "test-room"
+	roomMsg := MustSucceed2(t, client1.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+	client1.RunUntilJoined(ctx, hello1.Hello)
+
+	require.NoError(hub1.SaveSessions(ctx))
+	// The clients are disconnected without "bye" when the server stops.
+	hub1.CloseClientsForHandoff()
+	client1.RunUntilClosed(ctx)
+	// No more changes are stored after the clients were closed.
+	hub1.performHousekeeping(time.Now().Add(sessionExpireDuration + time.Second))
+	require.NoError(hub1.SaveSessions(ctx))
+
+	sessions, rooms, err = hub2.RestoreSessions(ctx)
+	require.NoError(err)
+	assert.Equal(1, sessions)
+	assert.Equal(1, rooms)
+
+	client := NewTestClient(t, server2, hub2)
+	defer client.CloseWithBye()
+	require.NoError(client.SendHelloResume(hello1.Hello.ResumeId))
+	if resumed, ok := client.RunUntilHello(ctx); ok {
+		assert.Equal(hello1.Hello.SessionId, resumed.Hello.SessionId)
+		assert.Equal(hello1.Hello.UserId, resumed.Hello.UserId)
+	}
+
+	session := hub2.GetSessionByPublicId(hello1.Hello.SessionId)
+	require.NotNil(session)
+	if room := session.(*ClientSession).GetRoom(); assert.NotNil(room) {
+		assert.Equal(roomId, room.Id())
+	}
+}
+
+func TestSessionStoreExpired(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+
+	redis := startLocalRedisServer(t)
+	hub, _, _, _ := CreateHubForTestWithConfig(t, func(server *httptest.Server) (*goconf.ConfigFile, error) {
+		config, err := getTestConfig(server)
+		if err != nil {
+			return nil, err
+		}
+
+		config.AddOption("sessions", "store", SessionStoreTypeRedis)
+		config.AddOption("redis", "url", redis.URL())
+		return config, nil
+	})
+
+	state := &storedSessionState{
+		Saved: time.Now().Add(-sessionExpireDuration - time.Second),
+		HandoffState: HandoffState{
+			Sessions: []*HandoffSession{
+				{
+					PrivateId: "the-private-id",
+					PublicId:  "the-public-id",
+					Sid:       1,
+				},
+			},
+		},
+	}
+	data, err := json.Marshal(state)
+	require.NoError(err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	require.NoError(hub.sessionStore.Save(ctx, data))
+
+	sessions, rooms, err := hub.RestoreSessions(ctx)
+	require.NoError(err)
+	assert.Equal(0, sessions)
+	assert.Equal(0, rooms)
+}
@@ -25,6 +25,7 @@ import (
 	"encoding/base64"
 	"fmt"
 
+	"github.com/dlintw/goconf"
 	"github.com/gorilla/securecookie"
 	"google.golang.org/protobuf/proto"
 )
@@ -53,6 +54,13 @@ const (
 	publicSessionName  = "public-session"
 )
 
+type SessionIdType string
+
+const (
+	SessionIdTypePublic  SessionIdType = "public"
+	SessionIdTypePrivate SessionIdType = "private"
+)
+
 type SessionIdCodec struct {
 	cookie *securecookie.SecureCookie
 }
@@ -66,6 +74,32 @@ func NewSessionIdCodec(hashKey []byte, blockKey []byte) *SessionIdCodec {
 	}
 }
 
+// NewSessionIdCodecFromConfig creates a codec with the keys from the
+// "[sessions]" section.
+func NewSessionIdCodecFromConfig(config *goconf.ConfigFile) (*SessionIdCodec, error) {
+	hashKey, _ := GetStringOptionWithEnv(config, "sessions", "hashkey")
+	switch len(hashKey) {
+	case 32:
+	case 64:
+	default:
+		hubLog.Warnf("The sessions hash key should be 32 or 64 bytes but is %d bytes", len(hashKey))
+	}
+
+	blockKey, _ := GetStringOptionWithEnv(config, "sessions", "blockkey")
+	blockBytes := []byte(blockKey)
+	switch len(blockKey) {
+	case 0:
+		blockBytes = nil
+	case 16:
+	case 24:
+	case 32:
+	default:
+		return nil, fmt.Errorf("the sessions block key must be 16, 24 or 32 bytes but is %d bytes", len(blockKey))
+	}
+
+	return NewSessionIdCodec([]byte(hashKey), blockBytes), nil
+}
+
 func (c *SessionIdCodec) EncodePrivate(sessionData *SessionIdData) (PrivateSessionId, error) {
 	id, err := c.cookie.Encode(privateSessionName, sessionData)
 	if err != nil {
@@ -129,3 +163,17 @@ func (c *SessionIdCodec) DecodePublic(encodedData PublicSessionId) (*SessionIdDa
 
 	return &data, nil
 }
+
+// Decode decodes either a public or a private session id.
+func (c *SessionIdCodec) Decode(encodedData string) (SessionIdType, *SessionIdData, error) {
+	if data, err := c.DecodePublic(PublicSessionId(encodedData)); err == nil {
+		return SessionIdTypePublic, data, nil
+	}
+
+	data, err := c.DecodePrivate(PrivateSessionId(encodedData))
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid session id: %w", err)
+	}
+
+	return SessionIdTypePrivate, data, nil
+}
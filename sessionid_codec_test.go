@@ -25,6 +25,7 @@ import (
 	"encoding/base64"
 	"testing"
 
+	"github.com/dlintw/goconf"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -77,3 +78,51 @@ func TestPublicPrivate(t *testing.T) {
 		assert.Fail("should have failed", "received %+v", data)
 	}
 }
+
+func TestSessionIdCodecDecode(t *testing.T) {
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	require := require.New(t)
+	sd := &SessionIdData{
+		Sid:       1,
+		Created:   timestamppb.Now(),
+		BackendId: "foo",
+	}
+
+	config := goconf.NewConfigFile()
+	config.AddOption("sessions", "hashkey", "0123456789012345")
+	config.AddOption("sessions", "blockkey", "0123456789012345")
+	codec, err := NewSessionIdCodecFromConfig(config)
+	require.NoError(err)
+
+	private, err := codec.EncodePrivate(sd)
+	require.NoError(err)
+	public, err := codec.EncodePublic(sd)
+	require.NoError(err)
+
+	if idType, data, err := codec.Decode(string(public)); assert.NoError(err) {
+		assert.Equal(SessionIdTypePublic, idType)
+		assert.Equal(sd.Sid, data.Sid)
+		assert.Equal(sd.BackendId, data.BackendId)
+		assert.True(sd.Created.AsTime().Equal(data.Created.AsTime()))
+	}
+	if idType, data, err := codec.Decode(string(private)); assert.NoError(err) {
+		assert.Equal(SessionIdTypePrivate, idType)
+		assert.Equal(sd.Sid, data.Sid)
+	}
+	if _, data, err := codec.Decode("invalid"); !assert.Error(err) {
+		assert.Fail("should have failed", "received %+v", data)
+	}
+
+	// Ids can't be decoded with different keys.
+	config.AddOption("sessions", "blockkey", "5432109876543210")
+	codec2, err := NewSessionIdCodecFromConfig(config)
+	require.NoError(err)
+	if _, data, err := codec2.Decode(string(public)); !assert.Error(err) {
+		assert.Fail("should have failed", "received %+v", data)
+	}
+
+	config.AddOption("sessions", "blockkey", "012345")
+	_, err = NewSessionIdCodecFromConfig(config)
+	assert.Error(err)
+}
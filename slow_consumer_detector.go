@@ -0,0 +1,165 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/dlintw/goconf"
+)
+
+const (
+	defaultSlowConsumerQueue   = 16
+	defaultSlowConsumerTimeout = 10 * time.Second
+)
+
+type slowConsumerState struct {
+	since time.Time
+	slow  bool
+}
+
+// SlowConsumerDetector flags clients whose queue of outgoing messages stays
+// above a threshold for longer than the configured timeout.
+type SlowConsumerDetector struct {
+	mu sync.Mutex
+
+	threshold int
+	timeout   time.Duration
+	notify    bool
+
+	clients map[*Client]*slowConsumerState
+}
+
+func NewSlowConsumerDetector(config *goconf.ConfigFile) *SlowConsumerDetector {
+	result := &SlowConsumerDetector{
+		clients: make(map[*Client]*slowConsumerState),
+	}
+	result.load(config)
+	return result
+}
+
+func (d *SlowConsumerDetector) load(config *goconf.ConfigFile) {
+	threshold, err := config.GetInt("clients", "slowconsumerqueue")
+	if err != nil {
+		threshold = defaultSlowConsumerQueue
+	}
+	timeout := defaultSlowConsumerTimeout
+	if timeoutSeconds, _ := config.GetInt("clients", "slowconsumertimeout"); timeoutSeconds > 0 {
+		timeout = time.Duration(timeoutSeconds) * time.Second
+	}
+	notify, _ := config.GetBool("clients", "slowconsumernotify")
+
+	if threshold > 0 {
+		hubLog.Infof("Detecting slow consumers with %d queued messages for %s", threshold, timeout)
+		if notify {
+			hubLog.Infof("Notifying slow consumers")
+		}
+	} else {
+		hubLog.Infof("Slow consumer detection is disabled")
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.threshold = threshold
+	d.timeout = timeout
+	d.notify = notify
+}
+
+func (d *SlowConsumerDetector) Reload(config *goconf.ConfigFile) {
+	d.load(config)
+}
+
+// Check updates the state of the given clients. Clients that are no longer
+// passed will be removed.
+func (d *SlowConsumerDetector) Check(now time.Time, clients []*Client) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	seen := make(map[*Client]bool, len(clients))
+	for _, client := range clients {
+		seen[client] = true
+		pending := client.PendingWrites()
+		state, found := d.clients[client]
+		if d.threshold <= 0 || pending < d.threshold {
+			if found {
+				d.removeLocked(client, state)
+			}
+			continue
+		}
+
+		if !found {
+			d.clients[client] = &slowConsumerState{
+				since: now,
+			}
+			continue
+		}
+
+		if state.slow || now.Sub(state.since) < d.timeout {
+			continue
+		}
+
+		state.slow = true
+		statsClientSlowConsumersCurrent.Inc()
+		if sessionId := client.GetSessionId(); sessionId != "" {
+			hubLog.Eventf(RecentEventTypeConnection, slog.LevelWarn, "Client %s is a slow consumer with %d queued messages since %s", sessionId, pending, state.since)
+		} else {
+			hubLog.Eventf(RecentEventTypeConnection, slog.LevelWarn, "Client from %s is a slow consumer with %d queued messages since %s", client.RemoteAddr(), pending, state.since)
+		}
+		if d.notify {
+			go client.SendMessage(&ServerMessage{
+				Type: "event",
+				Event: &EventServerMessage{
+					Target: "session",
+					Type:   "lagging",
+					Lagging: &EventServerMessageLagging{
+						Pending: pending,
+						Since:   state.since,
+					},
+				},
+			})
+		}
+	}
+
+	for client, state := range d.clients {
+		if !seen[client] {
+			d.removeLocked(client, state)
+		}
+	}
+}
+
+func (d *SlowConsumerDetector) removeLocked(client *Client, state *slowConsumerState) {
+	delete(d.clients, client)
+	if state.slow {
+		statsClientSlowConsumersCurrent.Dec()
+	}
+}
+
+// IsSlow returns true if the client has been detected as slow consumer.
+func (d *SlowConsumerDetector) IsSlow(client *Client) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	state, found := d.clients[client]
+	return found && state.slow
+}
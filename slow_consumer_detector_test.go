@@ -0,0 +1,101 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dlintw/goconf"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlowConsumerDetector_Disabled(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	config := goconf.NewConfigFile()
+	config.AddOption("clients", "slowconsumerqueue", "0")
+	detector := NewSlowConsumerDetector(config)
+
+	client := &Client{}
+	client.pendingWrites.Store(100)
+	now := time.Now()
+	detector.Check(now, []*Client{client})
+	detector.Check(now.Add(time.Hour), []*Client{client})
+	assert.False(detector.IsSlow(client))
+}
+
+func TestSlowConsumerDetector(t *testing.T) {
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	config := goconf.NewConfigFile()
+	config.AddOption("clients", "slowconsumerqueue", "4")
+	config.AddOption("clients", "slowconsumertimeout", "5")
+	detector := NewSlowConsumerDetector(config)
+
+	initial := testutil.ToFloat64(statsClientSlowConsumersCurrent)
+	client1 := &Client{}
+	client2 := &Client{}
+	clients := []*Client{client1, client2}
+
+	now := time.Now()
+	client1.pendingWrites.Store(4)
+	client2.pendingWrites.Store(3)
+	detector.Check(now, clients)
+	assert.False(detector.IsSlow(client1))
+	assert.False(detector.IsSlow(client2))
+
+	now = now.Add(4 * time.Second)
+	detector.Check(now, clients)
+	assert.False(detector.IsSlow(client1))
+
+	now = now.Add(time.Second)
+	detector.Check(now, clients)
+	assert.True(detector.IsSlow(client1))
+	assert.False(detector.IsSlow(client2))
+	checkStatsValue(t, statsClientSlowConsumersCurrent, initial+1)
+
+	// Queue went down, client is no longer slow.
+	client1.pendingWrites.Store(1)
+	detector.Check(now, clients)
+	assert.False(detector.IsSlow(client1))
+	checkStatsValue(t, statsClientSlowConsumersCurrent, initial)
+
+	// The timeout starts again if the queue fills up.
+	client1.pendingWrites.Store(10)
+	now = now.Add(time.Second)
+	detector.Check(now, clients)
+	now = now.Add(3 * time.Second)
+	detector.Check(now, clients)
+	assert.False(detector.IsSlow(client1))
+	now = now.Add(2 * time.Second)
+	detector.Check(now, clients)
+	assert.True(detector.IsSlow(client1))
+	checkStatsValue(t, statsClientSlowConsumersCurrent, initial+1)
+
+	// Removed clients are no longer tracked.
+	detector.Check(now, []*Client{client2})
+	assert.False(detector.IsSlow(client1))
+	checkStatsValue(t, statsClientSlowConsumersCurrent, initial)
+}
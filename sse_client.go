@@ -0,0 +1,341 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	// Number of messages that can be queued for a server-sent events client.
+	sseClientMessageQueue = 256
+
+	// Length of the random connection id to send messages.
+	sseClientIdLength = 32
+)
+
+var (
+	errSseClientClosed = errors.New("client closed")
+)
+
+// sseClient is a client that receives messages as server-sent events and
+// sends messages using HTTP POST requests. It can be used as fallback in
+// networks where websocket connections are blocked.
+type sseClient struct {
+	hub   *Hub
+	ctx   context.Context
+	id    string
+	addr  string
+	agent string
+
+	countryOnce sync.Once
+	country     string
+
+	session atomic.Pointer[Session]
+
+	// Messages must be processed in the order they were received.
+	processMu sync.Mutex
+
+	closeCtx  context.Context
+	closeFunc context.CancelFunc
+
+	messages chan WritableClientMessage
+}
+
+func newSseClient(ctx context.Context, hub *Hub, addr string, agent string) *sseClient {
+	addr = strings.TrimSpace(addr)
+	if addr == "" {
+		addr = "unknown remote address"
+	}
+	agent = strings.TrimSpace(agent)
+	if agent == "" {
+		agent = "unknown user agent"
+	}
+
+	closeCtx, closeFunc := context.WithCancel(context.Background())
+	return &sseClient{
+		hub:   hub,
+		ctx:   ctx,
+		id:    newRandomString(sseClientIdLength),
+		addr:  addr,
+		agent: agent,
+
+		closeCtx:  closeCtx,
+		closeFunc: closeFunc,
+
+		messages: make(chan WritableClientMessage, sseClientMessageQueue),
+	}
+}
+
+func (c *sseClient) Context() context.Context {
+	return c.ctx
+}
+
+func (c *sseClient) RemoteAddr() string {
+	return c.addr
+}
+
+func (c *sseClient) UserAgent() string {
+	return c.agent
+}
+
+func (c *sseClient) Country() string {
+	c.countryOnce.Do(func() {
+		c.country = c.hub.OnLookupCountry(c)
+	})
+	return c.country
+}
+
+func (c *sseClient) IsConnected() bool {
+	return c.closeCtx.Err() == nil
+}
+
+func (c *sseClient) IsAuthenticated() bool {
+	return c.GetSession() != nil
+}
+
+func (c *sseClient) GetSession() Session {
+	session := c.session.Load()
+	if session == nil {
+		return nil
+	}
+
+	return *session
+}
+
+func (c *sseClient) SetSession(session Session) {
+	if session == nil {
+		c.session.Store(nil)
+	} else {
+		c.session.Store(&session)
+	}
+}
+
+func (c *sseClient) SendError(e *Error) bool {
+	message := &ServerMessage{
+		Type:  "error",
+		Error: e,
+	}
+	return c.SendMessage(message)
+}
+
+func (c *sseClient) SendByeResponse(message *ClientMessage) bool {
+	return c.SendByeResponseWithReason(message, "")
+}
+
+func (c *sseClient) SendByeResponseWithReason(message *ClientMessage, reason string) bool {
+	response := &ServerMessage{
+		Type: "bye",
+	}
+	if message != nil {
+		response.Id = message.Id
+	}
+	response.Bye = NewByeServerMessage(reason)
+	return c.SendMessage(response)
+}
+
+func (c *sseClient) SendMessage(message WritableClientMessage) bool {
+	if !c.IsConnected() {
+		return false
+	}
+
+	select {
+	case c.messages <- message:
+		return true
+	default:
+		hubLog.Warnf("Message queue for server-sent events client from %s is full, not sending %+v", c.addr, message)
+		return false
+	}
+}
+
+func (c *sseClient) Close() {
+	c.closeFunc()
+}
+
+func (c *sseClient) processMessage(data []byte) error {
+	c.processMu.Lock()
+	defer c.processMu.Unlock()
+
+	if !c.IsConnected() {
+		return errSseClientClosed
+	}
+
+	c.hub.OnMessageReceived(c, data)
+	return nil
+}
+
+func (c *sseClient) writeEvent(w io.Writer, rc *http.ResponseController, event string, data []byte) error {
+	var buf bytes.Buffer
+	if event != "" {
+		buf.WriteString("event: " + event + "\n")
+	}
+	for line := range bytes.SplitSeq(data, []byte{'\n'}) {
+		buf.WriteString("data: ")
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('\n')
+	return c.write(w, rc, buf.Bytes())
+}
+
+func (c *sseClient) write(w io.Writer, rc *http.ResponseController, data []byte) error {
+	rc.SetWriteDeadline(time.Now().Add(writeWait)) // nolint
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+
+	return rc.Flush()
+}
+
+// run writes queued messages to the client until it is closed.
+func (c *sseClient) run(w io.Writer, rc *http.ResponseController, pingInterval time.Duration) error {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return nil
+		case <-c.closeCtx.Done():
+			return nil
+		case <-ticker.C:
+			// Comments are ignored by clients but keep proxies from closing
+			// idle connections.
+			if err := c.write(w, rc, []byte(": ping\n\n")); err != nil {
+				return err
+			}
+		case message := <-c.messages:
+			data, err := json.Marshal(message)
+			if err != nil {
+				hubLog.Errorf("Could not marshal message %+v for server-sent events client from %s: %s", message, c.addr, err)
+				continue
+			}
+
+			if err := c.writeEvent(w, rc, "", data); err != nil {
+				return err
+			}
+
+			if session := c.GetSession(); message.CloseAfterSend(session) {
+				if session != nil {
+					go session.Close()
+				}
+				return nil
+			}
+		}
+	}
+}
+
+func (h *Hub) getSseClient(id string) *sseClient {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.sseClients[id]
+}
+
+func (h *Hub) serveSse(w http.ResponseWriter, r *http.Request) {
+	addr := h.getRealUserIP(r)
+	if !h.checkOrigin(r) {
+		http.Error(w, "Origin not allowed", http.StatusForbidden)
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	client := newSseClient(r.Context(), h, addr, r.Header.Get("User-Agent"))
+	h.mu.Lock()
+	h.sseClients[client.id] = client
+	h.mu.Unlock()
+	defer func() {
+		h.mu.Lock()
+		delete(h.sseClients, client.id)
+		h.mu.Unlock()
+	}()
+
+	w.Header().Set("Server", "nextcloud-spreed-signaling/"+h.version)
+	w.Header().Set("X-Spreed-Signaling-Features", strings.Join(h.info.Features, ", "))
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// Disable response buffering in nginx.
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	// The client needs the id of the connection to send messages.
+	if err := client.writeEvent(w, rc, "connection", fmt.Appendf(nil, "{\"id\":%q}", client.id)); err != nil {
+		hubLog.Errorf("Could not start server-sent events for %s: %s", addr, err)
+		return
+	}
+
+	h.processNewClient(client)
+	defer reportPanic(LogSubsystemHub, client.GetSession)
+	if err := client.run(w, rc, h.keepalive.pingInterval); err != nil {
+		if session := client.GetSession(); session != nil {
+			hubLog.Errorf("Error writing to server-sent events client %s: %s", session.PublicId(), err)
+		} else {
+			hubLog.Errorf("Error writing to server-sent events client from %s: %s", addr, err)
+		}
+	}
+
+	// Make sure no more messages are processed before unregistering.
+	client.Close()
+	client.processMu.Lock()
+	defer client.processMu.Unlock()
+	h.OnClosed(client)
+}
+
+func (h *Hub) serveSseMessage(w http.ResponseWriter, r *http.Request) {
+	client := h.getSseClient(mux.Vars(r)["id"])
+	if client == nil {
+		http.Error(w, "Unknown connection", http.StatusNotFound)
+		return
+	}
+
+	if !h.checkOrigin(r) {
+		http.Error(w, "Origin not allowed", http.StatusForbidden)
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxMessageSize+1))
+	if err != nil {
+		http.Error(w, "Could not read body", http.StatusBadRequest)
+		return
+	} else if len(data) > maxMessageSize {
+		http.Error(w, "Message too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	if err := client.processMessage(data); err != nil {
+		http.Error(w, "Connection closed", http.StatusGone)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
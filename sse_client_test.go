@@ -0,0 +1,234 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSseEvent struct {
+	Event string
+	Data  string
+}
+
+type testSseClient struct {
+	t      *testing.T
+	server *httptest.Server
+	cancel context.CancelFunc
+	res    *http.Response
+	reader *bufio.Reader
+	id     string
+}
+
+func newTestSseClient(ctx context.Context, t *testing.T, server *httptest.Server) *testSseClient {
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(ctx)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/spreed/sse", nil)
+	require.NoError(err)
+	request.Header.Set("Accept", "text/event-stream")
+	res, err := http.DefaultClient.Do(request)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.StatusCode)
+	require.Equal("text/event-stream", res.Header.Get("Content-Type"))
+
+	client := &testSseClient{
+		t:      t,
+		server: server,
+		cancel: cancel,
+		res:    res,
+		reader: bufio.NewReader(res.Body),
+	}
+	t.Cleanup(client.Close)
+
+	event, err := client.ReadEvent()
+	require.NoError(err)
+	require.Equal("connection", event.Event)
+	var connection struct {
+		Id string `json:"id"`
+	}
+	require.NoError(json.Unmarshal([]byte(event.Data), &connection))
+	require.NotEmpty(connection.Id)
+	client.id = connection.Id
+	return client
+}
+
+func (c *testSseClient) Close() {
+	c.cancel()
+	c.res.Body.Close()
+}
+
+func (c *testSseClient) ReadEvent() (*testSseEvent, error) {
+	var event testSseEvent
+	var data []string
+	for {
+		line, err := c.reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "":
+			if len(data) == 0 && event.Event == "" {
+				continue
+			}
+			event.Data = strings.Join(data, "\n")
+			return &event, nil
+		case strings.HasPrefix(line, ":"):
+			// Comment.
+		case strings.HasPrefix(line, "event: "):
+			event.Event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = append(data, strings.TrimPrefix(line, "data: "))
+		}
+	}
+}
+
+func (c *testSseClient) ReadMessage() *ServerMessage {
+	event, err := c.ReadEvent()
+	require.NoError(c.t, err)
+	assert.Empty(c.t, event.Event)
+	var message ServerMessage
+	require.NoError(c.t, json.Unmarshal([]byte(event.Data), &message))
+	return &message
+}
+
+func (c *testSseClient) Send(message *ClientMessage) int {
+	data, err := json.Marshal(message)
+	require.NoError(c.t, err)
+	res, err := http.Post(c.server.URL+"/spreed/sse/"+c.id, "application/json", bytes.NewReader(data))
+	require.NoError(c.t, err)
+	defer res.Body.Close()
+	return res.StatusCode
+}
+
+func (c *testSseClient) SendHello(userId string) *HelloServerMessage {
+	params, err := json.Marshal(TestBackendClientAuthParams{
+		UserId: userId,
+	})
+	require.NoError(c.t, err)
+	assert.Equal(c.t, http.StatusNoContent, c.Send(&ClientMessage{
+		Id:   "1234",
+		Type: "hello",
+		Hello: &HelloClientMessage{
+			Version: HelloVersionV1,
+			Auth: &HelloClientMessageAuth{
+				Url:    c.server.URL,
+				Params: params,
+			},
+		},
+	}))
+
+	message := c.ReadMessage()
+	require.True(c.t, checkMessageType(c.t, message, "hello"))
+	return message.Hello
+}
+
+func TestSseClient(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	require := require.New(t)
+	hub, _, _, server := CreateHubForTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client := newTestSseClient(ctx, t, server)
+	if message := client.ReadMessage(); checkMessageType(t, message, "welcome") {
+		assert.NotEmpty(message.Welcome.Version)
+	}
+
+	hello := client.SendHello(testDefaultUserId)
+	assert.Equal(testDefaultUserId, hello.UserId)
+	assert.NotNil(hub.GetSessionByPublicId(hello.SessionId))
+
+	assert.Equal(http.StatusNoContent, client.Send(&ClientMessage{
+		Id:   "9876",
+		Type: "bye",
+		Bye:  &ByeClientMessage{},
+	}))
+	if message := client.ReadMessage(); checkMessageType(t, message, "bye") {
+		assert.Equal("9876", message.Id)
+	}
+
+	// The server closed the stream.
+	_, err := client.ReadEvent()
+	require.Error(err)
+
+	// Messages can no longer be sent to the connection.
+	assert.Equal(http.StatusNotFound, client.Send(&ClientMessage{
+		Type: "bye",
+		Bye:  &ByeClientMessage{},
+	}))
+}
+
+func TestSseClientResume(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	require := require.New(t)
+	hub, _, _, server := CreateHubForTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	sseClient := newTestSseClient(ctx, t, server)
+	checkMessageType(t, sseClient.ReadMessage(), "welcome")
+	hello := sseClient.SendHello(testDefaultUserId)
+	require.NotEmpty(hello.ResumeId)
+
+	sseClient.Close()
+
+	// The session can be resumed from a websocket connection.
+	client := NewTestClient(t, server, hub)
+	defer client.CloseWithBye()
+
+	require.NoError(client.SendHelloResume(hello.ResumeId))
+	if hello2, ok := client.RunUntilHello(ctx); ok {
+		assert.Equal(hello.SessionId, hello2.Hello.SessionId, "%+v", hello2.Hello)
+		assert.Equal(hello.ResumeId, hello2.Hello.ResumeId, "%+v", hello2.Hello)
+	}
+}
+
+func TestSseClientUnknownConnection(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	require := require.New(t)
+	_, _, _, server := CreateHubForTest(t)
+
+	res, err := http.Post(server.URL+"/spreed/sse/unknown", "application/json", strings.NewReader("{}"))
+	require.NoError(err)
+	defer res.Body.Close()
+	assert.Equal(http.StatusNotFound, res.StatusCode)
+}
@@ -0,0 +1,164 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/dlintw/goconf"
+)
+
+type StatsPermission int
+
+const (
+	// StatsPermissionMetrics allows access to the Prometheus metrics.
+	StatsPermissionMetrics StatsPermission = iota
+	// StatsPermissionAdmin allows access to the stats, admin and debug
+	// endpoints (and the metrics).
+	StatsPermissionAdmin
+)
+
+var (
+	ErrStatsAddressNotAllowed = errors.New("address not allowed")
+	ErrStatsTokenInvalid      = errors.New("invalid or missing token")
+)
+
+// StatsAccess contains the addresses and tokens that are allowed to access
+// the stats endpoints.
+type StatsAccess struct {
+	allowedIps        *AllowedIps
+	metricsAllowedIps *AllowedIps
+
+	token        string
+	metricsToken string
+}
+
+// NewStatsAccessFromConfig loads the access settings from the "[stats]"
+// section.
+func NewStatsAccessFromConfig(config *goconf.ConfigFile, log *Logger) (*StatsAccess, error) {
+	statsAllowed, _ := config.GetString("stats", "allowed_ips")
+	allowedIps, err := ParseAllowedIps(statsAllowed)
+	if err != nil {
+		return nil, err
+	}
+
+	if !allowedIps.Empty() {
+		log.Infof("Only allowing access to the stats endpoint from %s", statsAllowed)
+	} else {
+		log.Infof("No IPs configured for the stats endpoint, only allowing access from 127.0.0.1")
+		allowedIps = DefaultAllowedIps()
+	}
+
+	metricsAllowed, _ := config.GetString("stats", "metrics_allowed_ips")
+	metricsAllowedIps, err := ParseAllowedIps(metricsAllowed)
+	if err != nil {
+		return nil, err
+	}
+
+	if !metricsAllowedIps.Empty() {
+		log.Infof("Also allowing access to the metrics endpoint from %s", metricsAllowed)
+	}
+
+	token, _ := GetStringOptionWithEnv(config, "stats", "token")
+	metricsToken, _ := GetStringOptionWithEnv(config, "stats", "metrics_token")
+	if token != "" {
+		log.Infof("Require token to access the stats endpoint")
+	}
+	if metricsToken != "" {
+		log.Infof("Require token to access the metrics endpoint")
+	}
+
+	result := &StatsAccess{
+		allowedIps:        allowedIps,
+		metricsAllowedIps: metricsAllowedIps,
+
+		token:        token,
+		metricsToken: metricsToken,
+	}
+	return result, nil
+}
+
+func getBearerToken(r *http.Request) string {
+	auth := r.Header.Get("Authorization")
+	if scheme, token, found := strings.Cut(auth, " "); found && strings.EqualFold(scheme, "bearer") {
+		return strings.TrimSpace(token)
+	}
+
+	return ""
+}
+
+func checkStatsToken(expected string, token string) bool {
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(token)) == 1
+}
+
+// Check returns an error if a request from the given address is not allowed
+// to access endpoints that need the passed permission.
+func (a *StatsAccess) Check(r *http.Request, ip net.IP, permission StatsPermission) error {
+	if len(ip) == 0 {
+		return ErrStatsAddressNotAllowed
+	}
+
+	adminAllowed := a.allowedIps.Allowed(ip)
+	switch permission {
+	case StatsPermissionMetrics:
+		if !adminAllowed && !a.metricsAllowedIps.Allowed(ip) {
+			return ErrStatsAddressNotAllowed
+		}
+
+		token := getBearerToken(r)
+		if a.metricsToken != "" {
+			// The admin token also grants access to the metrics.
+			if !checkStatsToken(a.metricsToken, token) &&
+				(a.token == "" || !checkStatsToken(a.token, token)) {
+				return ErrStatsTokenInvalid
+			}
+		} else if a.token != "" && !checkStatsToken(a.token, token) {
+			return ErrStatsTokenInvalid
+		}
+	default:
+		if !adminAllowed {
+			return ErrStatsAddressNotAllowed
+		}
+
+		if a.token != "" && !checkStatsToken(a.token, getBearerToken(r)) {
+			return ErrStatsTokenInvalid
+		}
+	}
+
+	return nil
+}
+
+// WriteStatsAccessError writes the response for a request that failed the
+// access check.
+func WriteStatsAccessError(w http.ResponseWriter, err error) {
+	if errors.Is(err, ErrStatsTokenInvalid) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "Authentication check failed", http.StatusUnauthorized)
+		return
+	}
+
+	http.Error(w, "Authentication check failed", http.StatusForbidden)
+}
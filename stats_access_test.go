@@ -0,0 +1,141 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dlintw/goconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStatsRequest(token string) *http.Request {
+	r := &http.Request{
+		Header: make(http.Header),
+	}
+	if token != "" {
+		r.Header.Set("Authorization", "Bearer "+token)
+	}
+	return r
+}
+
+func TestStatsAccess_Default(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	require := require.New(t)
+
+	config := goconf.NewConfigFile()
+	access, err := NewStatsAccessFromConfig(config, backendLog)
+	require.NoError(err)
+
+	r := newStatsRequest("")
+	for _, permission := range []StatsPermission{StatsPermissionMetrics, StatsPermissionAdmin} {
+		assert.NoError(access.Check(r, net.ParseIP("127.0.0.1"), permission))
+		assert.ErrorIs(access.Check(r, net.ParseIP("127.0.0.2"), permission), ErrStatsAddressNotAllowed)
+		assert.ErrorIs(access.Check(r, nil, permission), ErrStatsAddressNotAllowed)
+	}
+}
+
+func TestStatsAccess_Subnets(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	require := require.New(t)
+
+	config := goconf.NewConfigFile()
+	config.AddOption("stats", "allowed_ips", "10.0.0.0/8, 2001:db8::/32")
+	config.AddOption("stats", "metrics_allowed_ips", "192.168.0.0/16")
+	access, err := NewStatsAccessFromConfig(config, backendLog)
+	require.NoError(err)
+
+	r := newStatsRequest("")
+	for _, ip := range []string{"10.1.2.3", "2001:db8::1"} {
+		assert.NoError(access.Check(r, net.ParseIP(ip), StatsPermissionAdmin), "should allow %s", ip)
+		assert.NoError(access.Check(r, net.ParseIP(ip), StatsPermissionMetrics), "should allow %s", ip)
+	}
+
+	// Metrics-only addresses can't access the admin endpoints.
+	assert.NoError(access.Check(r, net.ParseIP("192.168.1.2"), StatsPermissionMetrics))
+	assert.ErrorIs(access.Check(r, net.ParseIP("192.168.1.2"), StatsPermissionAdmin), ErrStatsAddressNotAllowed)
+
+	assert.ErrorIs(access.Check(r, net.ParseIP("127.0.0.1"), StatsPermissionMetrics), ErrStatsAddressNotAllowed)
+	assert.ErrorIs(access.Check(r, net.ParseIP("2001:db9::1"), StatsPermissionAdmin), ErrStatsAddressNotAllowed)
+
+	config.AddOption("stats", "metrics_allowed_ips", "192.168.0.0/33")
+	_, err = NewStatsAccessFromConfig(config, backendLog)
+	assert.Error(err)
+}
+
+func TestStatsAccess_Token(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	require := require.New(t)
+
+	ip := net.ParseIP("127.0.0.1")
+	config := goconf.NewConfigFile()
+	config.AddOption("stats", "token", "admin-token")
+	access, err := NewStatsAccessFromConfig(config, backendLog)
+	require.NoError(err)
+
+	for _, permission := range []StatsPermission{StatsPermissionMetrics, StatsPermissionAdmin} {
+		assert.ErrorIs(access.Check(newStatsRequest(""), ip, permission), ErrStatsTokenInvalid)
+		assert.ErrorIs(access.Check(newStatsRequest("invalid"), ip, permission), ErrStatsTokenInvalid)
+		assert.NoError(access.Check(newStatsRequest("admin-token"), ip, permission))
+		// The token doesn't grant access from other addresses.
+		assert.ErrorIs(access.Check(newStatsRequest("admin-token"), net.ParseIP("10.1.2.3"), permission), ErrStatsAddressNotAllowed)
+	}
+
+	r := newStatsRequest("")
+	r.Header.Set("Authorization", "Basic admin-token")
+	assert.ErrorIs(access.Check(r, ip, StatsPermissionAdmin), ErrStatsTokenInvalid)
+
+	config.AddOption("stats", "metrics_token", "metrics-token")
+	access, err = NewStatsAccessFromConfig(config, backendLog)
+	require.NoError(err)
+
+	assert.NoError(access.Check(newStatsRequest("metrics-token"), ip, StatsPermissionMetrics))
+	assert.NoError(access.Check(newStatsRequest("admin-token"), ip, StatsPermissionMetrics))
+	assert.ErrorIs(access.Check(newStatsRequest(""), ip, StatsPermissionMetrics), ErrStatsTokenInvalid)
+	assert.ErrorIs(access.Check(newStatsRequest("metrics-token"), ip, StatsPermissionAdmin), ErrStatsTokenInvalid)
+	assert.NoError(access.Check(newStatsRequest("admin-token"), ip, StatsPermissionAdmin))
+}
+
+func TestStatsAccess_WriteError(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	w := httptest.NewRecorder()
+	WriteStatsAccessError(w, ErrStatsAddressNotAllowed)
+	assert.Equal(http.StatusForbidden, w.Code)
+	assert.Empty(w.Header().Get("WWW-Authenticate"))
+
+	w = httptest.NewRecorder()
+	WriteStatsAccessError(w, ErrStatsTokenInvalid)
+	assert.Equal(http.StatusUnauthorized, w.Code)
+	assert.Equal("Bearer", w.Header().Get("WWW-Authenticate"))
+}
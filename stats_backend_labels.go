@@ -0,0 +1,96 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"sync"
+
+	"github.com/dlintw/goconf"
+)
+
+const (
+	// Label of backends that exceed the maximum number of backend labels.
+	StatsBackendLabelOther = "other"
+)
+
+var (
+	statsBackendLabels = newBackendLabels()
+)
+
+// backendLabels maps backend ids to the values used for the "backend" label of
+// metrics. Once the maximum number of labels is reached, further backends will
+// be reported as "other" to limit the cardinality of the metrics.
+type backendLabels struct {
+	mu        sync.Mutex
+	maxLabels int
+	labels    map[string]bool
+}
+
+func newBackendLabels() *backendLabels {
+	return &backendLabels{
+		labels: make(map[string]bool),
+	}
+}
+
+func (l *backendLabels) load(config *goconf.ConfigFile) {
+	maxLabels, _ := config.GetInt("stats", "maxbackendlabels")
+	if maxLabels < 0 {
+		maxLabels = 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if maxLabels != l.maxLabels {
+		if maxLabels > 0 {
+			appLog.Infof("Using at most %d backend labels in metrics", maxLabels)
+		} else {
+			appLog.Infof("Not limiting the number of backend labels in metrics")
+		}
+	}
+	l.maxLabels = maxLabels
+}
+
+// Get returns the label for the given backend. Backends that already have a
+// label will keep it, even if the maximum number of labels was reduced.
+func (l *backendLabels) Get(backend *Backend) string {
+	if backend == nil {
+		return ""
+	}
+
+	id := backend.Id()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.labels[id] {
+		return id
+	}
+
+	if l.maxLabels > 0 && len(l.labels) >= l.maxLabels {
+		return StatsBackendLabelOther
+	}
+
+	l.labels[id] = true
+	return id
+}
+
+func statsBackendLabel(backend *Backend) string {
+	return statsBackendLabels.Get(backend)
+}
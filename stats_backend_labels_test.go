@@ -0,0 +1,59 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"testing"
+
+	"github.com/dlintw/goconf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackendLabels(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	labels := newBackendLabels()
+	config := goconf.NewConfigFile()
+	config.AddOption("stats", "maxbackendlabels", "2")
+	labels.load(config)
+
+	backend1 := &Backend{id: "backend1"}
+	backend2 := &Backend{id: "backend2"}
+	backend3 := &Backend{id: "backend3"}
+	assert.Empty(labels.Get(nil))
+	assert.Equal("backend1", labels.Get(backend1))
+	assert.Equal("backend2", labels.Get(backend2))
+	assert.Equal(StatsBackendLabelOther, labels.Get(backend3))
+	assert.Equal("backend1", labels.Get(backend1))
+
+	// Existing labels are kept when reducing the limit.
+	config = goconf.NewConfigFile()
+	config.AddOption("stats", "maxbackendlabels", "1")
+	labels.load(config)
+	assert.Equal("backend2", labels.Get(backend2))
+	assert.Equal(StatsBackendLabelOther, labels.Get(backend3))
+
+	// No limit by default.
+	labels.load(goconf.NewConfigFile())
+	assert.Equal("backend3", labels.Get(backend3))
+}
@@ -22,7 +22,13 @@
 package signaling
 
 import (
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"sync"
+
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 var (
@@ -31,7 +37,13 @@ var (
 		Subsystem: "server",
 		Name:      "messages_total",
 		Help:      "The total number of signaling messages",
-	}, []string{"type"})
+	}, []string{"backend", "type"})
+
+	statsBuildInfo = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "signaling",
+		Name:      "build_info",
+		Help:      "Information about the build, always has the value 1",
+	}, []string{"version", "commit", "goversion"})
 
 	signalingStats = []prometheus.Collector{
 		statsMessagesTotal,
@@ -57,3 +69,35 @@ func unregisterAll(cs ...prometheus.Collector) {
 func RegisterStats() {
 	registerAll(signalingStats...)
 }
+
+// getBuildCommit returns the VCS revision the binary was built from.
+func getBuildCommit() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" && setting.Value != "" {
+				return setting.Value
+			}
+		}
+	}
+	return "unknown"
+}
+
+// RegisterBuildInfo exports the version and build information of the server
+// or proxy. The runtime metrics of Go (e.g. "go_goroutines") are exported by
+// default.
+func RegisterBuildInfo(version string) {
+	registerAll(statsBuildInfo)
+	statsBuildInfo.Reset()
+	statsBuildInfo.WithLabelValues(version, getBuildCommit(), runtime.Version()).Set(1)
+}
+
+// metricsHandler serves the metrics in the OpenMetrics format if requested by
+// the client, which is required to export exemplars.
+var metricsHandler = sync.OnceValue(func() http.Handler {
+	return promhttp.InstrumentMetricHandler(
+		prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{
+			EnableOpenMetrics: true,
+		}),
+	)
+})
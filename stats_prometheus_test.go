@@ -89,3 +89,19 @@ func collectAndLint(t *testing.T, collectors ...prometheus.Collector) {
 		}
 	}
 }
+
+func TestBuildInfo(t *testing.T) {
+	assert := assert.New(t)
+	RegisterBuildInfo("1.2.3")
+	t.Cleanup(statsBuildInfo.Reset)
+
+	checkStatsValue(t, statsBuildInfo.WithLabelValues("1.2.3", getBuildCommit(), runtime.Version()), 1)
+	assert.Equal(1, testutil.CollectAndCount(statsBuildInfo))
+	collectAndLint(t, statsBuildInfo)
+
+	// Runtime metrics are exported by default.
+	count, err := testutil.GatherAndCount(prometheus.DefaultGatherer, "go_goroutines", "go_gc_duration_seconds", "go_memstats_heap_alloc_bytes")
+	if assert.NoError(err) {
+		assert.Equal(3, count)
+	}
+}
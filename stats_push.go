@@ -0,0 +1,151 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/dlintw/goconf"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+const (
+	defaultStatsPushInterval = 15 * time.Second
+)
+
+// StatsPusher periodically pushes the metrics to a Prometheus Pushgateway for
+// deployments where the stats endpoint can't be scraped.
+type StatsPusher struct {
+	pusher   *push.Pusher
+	target   string
+	interval time.Duration
+
+	closer *Closer
+	wg     sync.WaitGroup
+}
+
+// NewStatsPusher creates the pusher from the "pushgateway" options in the
+// "[stats]" section of the configuration. Returns nil if no Pushgateway is
+// configured.
+func NewStatsPusher(config *goconf.ConfigFile, job string) (*StatsPusher, error) {
+	target, _ := GetStringOptionWithEnv(config, "stats", "pushgateway")
+	if target == "" {
+		return nil, nil
+	}
+
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pushgateway url %s: %w", target, err)
+	} else if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported pushgateway url %s", target)
+	}
+
+	interval := defaultStatsPushInterval
+	if value, _ := config.GetInt("stats", "pushinterval"); value > 0 {
+		interval = time.Duration(value) * time.Second
+	}
+
+	if value, _ := config.GetString("stats", "pushjob"); value != "" {
+		job = value
+	}
+	instance, _ := config.GetString("stats", "pushinstance")
+	if instance == "" {
+		if instance, err = os.Hostname(); err != nil {
+			return nil, fmt.Errorf("could not get hostname for pushgateway instance: %w", err)
+		}
+	}
+
+	user := u.User
+	u.User = nil
+	pusher := push.New(u.String(), job).
+		Gatherer(prometheus.DefaultGatherer).
+		Grouping("instance", instance)
+	if user != nil {
+		password, _ := user.Password()
+		pusher = pusher.BasicAuth(user.Username(), password)
+	}
+
+	return &StatsPusher{
+		pusher:   pusher,
+		target:   u.String(),
+		interval: interval,
+		closer:   NewCloser(),
+	}, nil
+}
+
+func (p *StatsPusher) push(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, p.interval)
+	defer cancel()
+
+	return p.pusher.PushContext(ctx)
+}
+
+// Start pushes the metrics in the configured interval until the pusher is
+// closed.
+func (p *StatsPusher) Start() {
+	if p == nil {
+		return
+	}
+
+	appLog.Infof("Pushing metrics to %s every %s", p.target, p.interval)
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		failed := false
+		for {
+			select {
+			case <-ticker.C:
+				if err := p.push(context.Background()); err != nil {
+					if !failed {
+						appLog.Warnf("Could not push metrics to %s: %s", p.target, err)
+						failed = true
+					}
+				} else if failed {
+					appLog.Infof("Pushing metrics to %s succeeded again", p.target)
+					failed = false
+				}
+			case <-p.closer.C:
+				return
+			}
+		}
+	}()
+}
+
+// Close stops pushing the metrics.
+func (p *StatsPusher) Close() {
+	if p == nil {
+		return
+	}
+
+	p.closer.Close()
+	p.wg.Wait()
+}
@@ -0,0 +1,98 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dlintw/goconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsPusher_Disabled(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	pusher, err := NewStatsPusher(goconf.NewConfigFile(), "test-job")
+	assert.NoError(err)
+	assert.Nil(pusher)
+	// Starting and closing a disabled pusher is allowed.
+	pusher.Start()
+	pusher.Close()
+
+	config := goconf.NewConfigFile()
+	config.AddOption("stats", "pushgateway", "ftp://pushgateway.domain.invalid")
+	_, err = NewStatsPusher(config, "test-job")
+	assert.Error(err)
+}
+
+func TestStatsPusher(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+
+	var mu sync.Mutex
+	var paths []string
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "user" || password != "secret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(err)
+
+		mu.Lock()
+		defer mu.Unlock()
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		bodies = append(bodies, string(body))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := goconf.NewConfigFile()
+	config.AddOption("stats", "pushgateway", strings.Replace(server.URL, "http://", "http://user:secret@", 1))
+	config.AddOption("stats", "pushinstance", "the-instance")
+	pusher, err := NewStatsPusher(config, "test-job")
+	require.NoError(err)
+	require.NotNil(pusher)
+	assert.Equal(server.URL, pusher.target)
+
+	require.NoError(pusher.push(context.Background()))
+
+	mu.Lock()
+	defer mu.Unlock()
+	if assert.Len(paths, 1) {
+		assert.Equal("PUT /metrics/job/test-job/instance/the-instance", paths[0])
+		assert.NotEmpty(bodies[0])
+	}
+}
@@ -56,3 +56,13 @@ func CatchLogForTest(t testing.TB) {
 	log.SetOutput(&testLogWriter{t})
 	log.SetFlags(prevFlags | log.Lshortfile)
 }
+
+// DiscardLogForTest drops all log output, e.g. for benchmarks where the
+// output of the testing framework would be printed in any case.
+func DiscardLogForTest(t testing.TB) {
+	t.Cleanup(func() {
+		log.SetOutput(prevWriter)
+	})
+
+	log.SetOutput(io.Discard)
+}
@@ -109,6 +109,10 @@ func checkMessageType(t *testing.T, message *ServerMessage, expectedType string)
 		if !assert.NotNil(message.TransientData, "transient data missing in %+v", message) {
 			failed = true
 		}
+	case "echo":
+		if !assert.NotNil(message.Echo, "echo missing in %+v", message) {
+			failed = true
+		}
 	}
 	return !failed
 }
@@ -228,6 +232,17 @@ func NewTestClientContext(ctx context.Context, t *testing.T, server *httptest.Se
 				return
 			}
 
+			if len(data) > 0 && data[0] == '[' {
+				// Batch of messages if the client negotiated the "batching" feature.
+				var messages []json.RawMessage
+				if assert.NoError(t, json.Unmarshal(data, &messages)) {
+					for _, message := range messages {
+						messageChan <- message
+					}
+				}
+				continue
+			}
+
 			messageChan <- data
 		}
 	}()
@@ -416,6 +431,10 @@ func (c *TestClient) CreateHelloV2TokenWithUserdata(userid string, issuedAt time
 		claims.ExpiresAt = jwt.NewNumericDate(expiresAt)
 	}
 
+	return c.CreateHelloV2TokenWithClaims(claims)
+}
+
+func (c *TestClient) CreateHelloV2TokenWithClaims(claims *HelloV2TokenClaims) (string, error) {
 	var token *jwt.Token
 	if strings.Contains(c.t.Name(), "ECDSA") {
 		token = jwt.NewWithClaims(jwt.SigningMethodES256, claims)
@@ -450,6 +469,21 @@ func (c *TestClient) SendHelloV2WithTimesAndFeatures(userid string, issuedAt tim
 	return c.SendHelloParams(c.server.URL, HelloVersionV2, "", features, params)
 }
 
+func (c *TestClient) SendHelloV2WithToken(tokenString string) error {
+	params := HelloV2AuthParams{
+		Token: tokenString,
+	}
+	return c.SendHelloParams(c.server.URL, HelloVersionV2, "", nil, params)
+}
+
+func (c *TestClient) SendHelloV2WithTokenAndProof(tokenString string, proof string) error {
+	params := HelloV2AuthParams{
+		Token: tokenString,
+		Proof: proof,
+	}
+	return c.SendHelloParams(c.server.URL, HelloVersionV2, "", nil, params)
+}
+
 func (c *TestClient) SendHelloResume(resumeId PrivateSessionId) error {
 	hello := &ClientMessage{
 		Id:   "1234",
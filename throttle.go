@@ -24,11 +24,14 @@ package signaling
 import (
 	"context"
 	"errors"
-	"log"
+	"fmt"
+	"log/slog"
 	"net"
 	"strconv"
 	"sync"
 	"time"
+
+	"github.com/dlintw/goconf"
 )
 
 const (
@@ -50,8 +53,42 @@ const (
 
 	// maxThrottleDelay specifies the maxium time to sleep for failed requests.
 	maxThrottleDelay = 25 * time.Second
+
+	// gcraEmissionInterval specifies the interval at which failed requests are
+	// "forgotten" when using the GCRA algorithm.
+	gcraEmissionInterval = maxBruteforceDurationThreshold / maxBruteforceAttempts
+
+	// gcraBurstTolerance specifies how far the theoretical arrival time may be
+	// in the future until a "bruteforce" attempt is detected.
+	gcraBurstTolerance = (maxBruteforceAttempts - 1) * gcraEmissionInterval
+)
+
+type ThrottleAlgorithm string
+
+const (
+	// ThrottleAlgorithmWindow blocks clients if too many failed requests
+	// happened in a sliding window.
+	ThrottleAlgorithmWindow ThrottleAlgorithm = "window"
+	// ThrottleAlgorithmGCRA uses the generic cell rate algorithm to block
+	// clients that fail requests faster than allowed.
+	ThrottleAlgorithmGCRA ThrottleAlgorithm = "gcra"
+
+	defaultThrottleAlgorithm = ThrottleAlgorithmWindow
 )
 
+func ParseThrottleAlgorithm(s string) (ThrottleAlgorithm, error) {
+	switch s {
+	case "":
+		return defaultThrottleAlgorithm, nil
+	case string(ThrottleAlgorithmWindow):
+		return ThrottleAlgorithmWindow, nil
+	case string(ThrottleAlgorithmGCRA):
+		return ThrottleAlgorithmGCRA, nil
+	default:
+		return "", fmt.Errorf("unsupported throttle algorithm: %s", s)
+	}
+}
+
 var (
 	ErrBruteforceDetected = errors.New("bruteforce detected")
 
@@ -67,6 +104,7 @@ type ThrottleFunc func(ctx context.Context)
 
 type Throttler interface {
 	Close()
+	Reload(config *goconf.ConfigFile)
 
 	CheckBruteforce(ctx context.Context, client string, action string) (ThrottleFunc, error)
 }
@@ -79,7 +117,8 @@ func getThrottleIp(ipString string) string {
 	}
 
 	if i := ip.To4(); len(i) == net.IPv4len {
-		return ipString
+		// IPv4-mapped IPv6 address.
+		return i.String()
 	}
 
 	// Throttle /64 subnet of IPv6 addresses.
@@ -94,17 +133,29 @@ type memoryThrottler struct {
 	getNow  func() time.Time
 	doDelay func(context.Context, time.Duration)
 
-	mu      sync.RWMutex
+	mu        sync.RWMutex
+	algorithm ThrottleAlgorithm
+	// Failed requests if the "window" algorithm is used.
 	clients map[string]map[string][]throttleEntry
+	// Theoretical arrival times if the "gcra" algorithm is used.
+	arrivals map[string]map[string]time.Time
 
 	closer *Closer
 }
 
-func NewMemoryThrottler() (Throttler, error) {
+func NewMemoryThrottler(config *goconf.ConfigFile) (Throttler, error) {
+	value, _ := config.GetString("app", "throttlealgorithm")
+	algorithm, err := ParseThrottleAlgorithm(value)
+	if err != nil {
+		return nil, err
+	}
+
 	result := &memoryThrottler{
 		getNow: time.Now,
 
-		clients: make(map[string]map[string][]throttleEntry),
+		algorithm: algorithm,
+		clients:   make(map[string]map[string][]throttleEntry),
+		arrivals:  make(map[string]map[string]time.Time),
 
 		closer: NewCloser(),
 	}
@@ -113,6 +164,113 @@ func NewMemoryThrottler() (Throttler, error) {
 	return result, nil
 }
 
+func (t *memoryThrottler) Reload(config *goconf.ConfigFile) {
+	value, _ := config.GetString("app", "throttlealgorithm")
+	algorithm, err := ParseThrottleAlgorithm(value)
+	if err != nil {
+		hubLog.Errorf("Error parsing throttle algorithm, keeping current: %s", err)
+		return
+	}
+
+	t.setAlgorithm(algorithm, t.getNow())
+}
+
+func (t *memoryThrottler) getAlgorithm() ThrottleAlgorithm {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.algorithm
+}
+
+// setAlgorithm switches the throttle algorithm and converts the failed
+// requests that are currently known, so clients are not unblocked.
+func (t *memoryThrottler) setAlgorithm(algorithm ThrottleAlgorithm, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.algorithm == algorithm {
+		return
+	}
+
+	hubLog.Infof("Switching throttle algorithm from %s to %s", t.algorithm, algorithm)
+	t.algorithm = algorithm
+	switch algorithm {
+	case ThrottleAlgorithmGCRA:
+		for client, actions := range t.clients {
+			for action, entries := range actions {
+				if tat := getArrivalFromEntries(entries); tat.After(now) {
+					clientArrivals, found := t.arrivals[client]
+					if !found {
+						clientArrivals = make(map[string]time.Time)
+						t.arrivals[client] = clientArrivals
+					}
+					clientArrivals[action] = tat
+				}
+			}
+		}
+		clear(t.clients)
+	case ThrottleAlgorithmWindow:
+		for client, actions := range t.arrivals {
+			for action, tat := range actions {
+				if entries := getEntriesFromArrival(tat, now); len(entries) > 0 {
+					clientEntries, found := t.clients[client]
+					if !found {
+						clientEntries = make(map[string][]throttleEntry)
+						t.clients[client] = clientEntries
+					}
+					clientEntries[action] = entries
+				}
+			}
+		}
+		clear(t.arrivals)
+	}
+}
+
+// getArrivalFromEntries returns the theoretical arrival time of the GCRA
+// algorithm after the given failed requests.
+func getArrivalFromEntries(entries []throttleEntry) time.Time {
+	var tat time.Time
+	for _, entry := range entries {
+		if entry.ts.After(tat) {
+			tat = entry.ts
+		}
+		tat = tat.Add(gcraEmissionInterval)
+	}
+	return tat
+}
+
+// getEntriesFromArrival returns failed requests that are equivalent to the
+// given theoretical arrival time of the GCRA algorithm.
+func getEntriesFromArrival(tat time.Time, now time.Time) []throttleEntry {
+	count := getArrivalCount(tat, now)
+	if count == 0 {
+		return nil
+	}
+
+	entries := make([]throttleEntry, 0, count)
+	for i := count; i > 0; i-- {
+		ts := tat.Add(-time.Duration(i) * gcraEmissionInterval)
+		if ts.After(now) {
+			ts = now
+		}
+		entries = append(entries, throttleEntry{
+			ts: ts,
+		})
+	}
+	return entries
+}
+
+// getArrivalCount returns the number of failed requests that are not
+// "forgotten" yet by the GCRA algorithm.
+func getArrivalCount(tat time.Time, now time.Time) int {
+	if !tat.After(now) {
+		return 0
+	}
+
+	delta := tat.Sub(now)
+	return int((delta + gcraEmissionInterval - 1) / gcraEmissionInterval)
+}
+
 func intPow(n, m int) int {
 	if m == 0 {
 		return 1
@@ -227,10 +385,50 @@ func (t *memoryThrottler) filterEntries(entries []throttleEntry, now time.Time)
 	return entries
 }
 
+func (t *memoryThrottler) getArrival(client string, action string) time.Time {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	toThrottle := getThrottleIp(client)
+	return t.arrivals[toThrottle][action]
+}
+
+func (t *memoryThrottler) addArrival(client string, action string, now time.Time) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	toThrottle := getThrottleIp(client)
+	actions, found := t.arrivals[toThrottle]
+	if !found {
+		actions = make(map[string]time.Time)
+		t.arrivals[toThrottle] = actions
+	}
+
+	tat := actions[action]
+	if tat.Before(now) {
+		tat = now
+	}
+	tat = tat.Add(gcraEmissionInterval)
+	actions[action] = tat
+	return getArrivalCount(tat, now)
+}
+
 func (t *memoryThrottler) cleanup(now time.Time) {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	for client, actions := range t.arrivals {
+		for action, tat := range actions {
+			if !tat.After(now) {
+				delete(actions, action)
+			}
+		}
+
+		if len(actions) == 0 {
+			delete(t.arrivals, client)
+		}
+	}
+
 	for client, actions := range t.clients {
 		for action, entries := range actions {
 			newEntries := t.filterEntries(entries, now)
@@ -267,6 +465,17 @@ func (t *memoryThrottler) CheckBruteforce(ctx context.Context, client string, ac
 		t.throttle(ctx, client, action, now)
 	}
 
+	if t.getAlgorithm() == ThrottleAlgorithmGCRA {
+		tat := t.getArrival(client, action)
+		if tat.Sub(now) > gcraBurstTolerance {
+			hubLog.Eventf(RecentEventTypeBan, slog.LevelInfo, "Detected bruteforce attempt on \"%s\" from %s", action, client)
+			statsThrottleBruteforceTotal.WithLabelValues(action).Inc()
+			return doThrottle, ErrBruteforceDetected
+		}
+
+		return doThrottle, nil
+	}
+
 	entries := t.getEntries(client, action)
 	l := len(entries)
 	if l == 0 {
@@ -276,7 +485,7 @@ func (t *memoryThrottler) CheckBruteforce(ctx context.Context, client string, ac
 	if l >= maxBruteforceAttempts {
 		delta := now.Sub(entries[l-maxBruteforceAttempts].ts)
 		if delta <= maxBruteforceDurationThreshold {
-			log.Printf("Detected bruteforce attempt on \"%s\" from %s", action, client)
+			hubLog.Eventf(RecentEventTypeBan, slog.LevelInfo, "Detected bruteforce attempt on \"%s\" from %s", action, client)
 			statsThrottleBruteforceTotal.WithLabelValues(action).Inc()
 			return doThrottle, ErrBruteforceDetected
 		}
@@ -295,12 +504,17 @@ func (t *memoryThrottler) CheckBruteforce(ctx context.Context, client string, ac
 }
 
 func (t *memoryThrottler) throttle(ctx context.Context, client string, action string, now time.Time) {
-	entry := throttleEntry{
-		ts: now,
+	var count int
+	if t.getAlgorithm() == ThrottleAlgorithmGCRA {
+		count = t.addArrival(client, action, now)
+	} else {
+		entry := throttleEntry{
+			ts: now,
+		}
+		count = t.addEntry(client, action, entry)
 	}
-	count := t.addEntry(client, action, entry)
 	delay := t.getDelay(count - 1)
-	log.Printf("Failed attempt on \"%s\" from %s, throttling by %s", action, client, delay)
+	hubLog.Eventf(RecentEventTypeBan, slog.LevelError, "Failed attempt on \"%s\" from %s, throttling by %s", action, client, delay)
 	statsThrottleDelayedTotal.WithLabelValues(action, strconv.FormatInt(delay.Milliseconds(), 10)).Inc()
 	t.doDelay(ctx, delay)
 }
@@ -311,3 +525,58 @@ func (t *memoryThrottler) delay(ctx context.Context, duration time.Duration) {
 
 	<-c.Done()
 }
+
+// rateLimiter allows a number of events per period using the generic cell rate
+// algorithm. Up to "limit" events may happen in a burst. A nil rateLimiter
+// allows all events.
+type rateLimiter struct {
+	limit    int
+	interval time.Duration
+	burst    time.Duration
+
+	mu  sync.Mutex
+	tat time.Time
+}
+
+func newRateLimiter(limit int, period time.Duration) *rateLimiter {
+	if limit <= 0 {
+		return nil
+	}
+
+	interval := period / time.Duration(limit)
+	return &rateLimiter{
+		limit:    limit,
+		interval: interval,
+		burst:    period - interval,
+	}
+}
+
+// Limit returns the number of allowed events per period, zero if unlimited.
+func (l *rateLimiter) Limit() int {
+	if l == nil {
+		return 0
+	}
+
+	return l.limit
+}
+
+// Allow returns true if an event may happen at the given time.
+func (l *rateLimiter) Allow(now time.Time) bool {
+	if l == nil {
+		return true
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	tat := l.tat
+	if tat.Before(now) {
+		tat = now
+	}
+	if tat.Sub(now) > l.burst {
+		return false
+	}
+
+	l.tat = tat.Add(l.interval)
+	return true
+}
@@ -26,13 +26,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dlintw/goconf"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func newMemoryThrottlerForTest(t *testing.T) Throttler {
 	t.Helper()
-	result, err := NewMemoryThrottler()
+	result, err := NewMemoryThrottler(goconf.NewConfigFile())
 	require.NoError(t, err)
 
 	t.Cleanup(func() {
@@ -300,3 +301,144 @@ func TestThrottler_Negative(t *testing.T) {
 		}
 	})
 }
+
+func newMemoryThrottlerWithAlgorithmForTest(t *testing.T, algorithm ThrottleAlgorithm) *memoryThrottler {
+	t.Helper()
+	config := goconf.NewConfigFile()
+	config.AddOption("app", "throttlealgorithm", string(algorithm))
+	result, err := NewMemoryThrottler(config)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		result.Close()
+	})
+
+	return result.(*memoryThrottler)
+}
+
+func TestThrottler_InvalidAlgorithm(t *testing.T) {
+	t.Parallel()
+	config := goconf.NewConfigFile()
+	config.AddOption("app", "throttlealgorithm", "invalid")
+	_, err := NewMemoryThrottler(config)
+	assert.Error(t, err)
+}
+
+func TestThrottler_GCRA(t *testing.T) {
+	SynctestTest(t, func(t *testing.T) {
+		assert := assert.New(t)
+		th := newMemoryThrottlerWithAlgorithmForTest(t, ThrottleAlgorithmGCRA)
+
+		ctx := context.Background()
+
+		delay := 100 * time.Millisecond
+		for range maxBruteforceAttempts {
+			throttle, err := th.CheckBruteforce(ctx, "192.168.0.1", "action1")
+			assert.NoError(err)
+			expectDelay(t, func() {
+				throttle(ctx)
+			}, delay)
+			delay = min(delay*2, maxThrottleDelay)
+		}
+
+		_, err := th.CheckBruteforce(ctx, "192.168.0.1", "action1")
+		assert.ErrorIs(err, ErrBruteforceDetected)
+
+		// Other clients are not affected.
+		_, err = th.CheckBruteforce(ctx, "192.168.0.2", "action1")
+		assert.NoError(err)
+
+		// Failed attempts are forgotten one at a time, so a burst at the
+		// boundary of a window is not possible.
+		time.Sleep(gcraEmissionInterval)
+		throttle, err := th.CheckBruteforce(ctx, "192.168.0.1", "action1")
+		assert.NoError(err)
+		throttle(ctx)
+
+		_, err = th.CheckBruteforce(ctx, "192.168.0.1", "action1")
+		assert.ErrorIs(err, ErrBruteforceDetected)
+
+		th.cleanup(time.Now().Add(maxBruteforceDurationThreshold))
+		assert.True(th.getArrival("192.168.0.1", "action1").IsZero())
+	})
+}
+
+func TestThrottler_SwitchAlgorithm(t *testing.T) {
+	SynctestTest(t, func(t *testing.T) {
+		assert := assert.New(t)
+		th := newMemoryThrottlerWithAlgorithmForTest(t, ThrottleAlgorithmWindow)
+
+		ctx := context.Background()
+
+		for range maxBruteforceAttempts {
+			throttle, err := th.CheckBruteforce(ctx, "192.168.0.1", "action1")
+			assert.NoError(err)
+			throttle(ctx)
+		}
+		throttle, err := th.CheckBruteforce(ctx, "192.168.0.2", "action1")
+		assert.NoError(err)
+		throttle(ctx)
+
+		_, err = th.CheckBruteforce(ctx, "192.168.0.1", "action1")
+		assert.ErrorIs(err, ErrBruteforceDetected)
+
+		// Existing failed attempts are migrated when switching the algorithm.
+		config := goconf.NewConfigFile()
+		config.AddOption("app", "throttlealgorithm", string(ThrottleAlgorithmGCRA))
+		th.Reload(config)
+		assert.Empty(th.getEntries("192.168.0.1", "action1"))
+
+		_, err = th.CheckBruteforce(ctx, "192.168.0.1", "action1")
+		assert.ErrorIs(err, ErrBruteforceDetected)
+
+		throttle, err = th.CheckBruteforce(ctx, "192.168.0.2", "action1")
+		assert.NoError(err)
+		expectDelay(t, func() {
+			throttle(ctx)
+		}, 200*time.Millisecond)
+
+		config.AddOption("app", "throttlealgorithm", string(ThrottleAlgorithmWindow))
+		th.Reload(config)
+		assert.True(th.getArrival("192.168.0.1", "action1").IsZero())
+		assert.Len(th.getEntries("192.168.0.2", "action1"), 2)
+
+		_, err = th.CheckBruteforce(ctx, "192.168.0.1", "action1")
+		assert.ErrorIs(err, ErrBruteforceDetected)
+
+		// Invalid algorithms are ignored on reload.
+		config.AddOption("app", "throttlealgorithm", "invalid")
+		th.Reload(config)
+		assert.Equal(ThrottleAlgorithmWindow, th.getAlgorithm())
+	})
+}
+
+func TestRateLimiter(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	var unlimited *rateLimiter
+	assert.Equal(0, unlimited.Limit())
+	assert.True(unlimited.Allow(time.Now()))
+	assert.Nil(newRateLimiter(0, time.Second))
+
+	limiter := newRateLimiter(5, time.Second)
+	assert.Equal(5, limiter.Limit())
+	now := time.Now()
+	// The full limit may be used in a burst.
+	for i := range 5 {
+		assert.True(limiter.Allow(now), "should allow event %d", i)
+	}
+	assert.False(limiter.Allow(now))
+	assert.False(limiter.Allow(now.Add(100 * time.Millisecond)))
+
+	// One event is allowed again after each interval.
+	assert.True(limiter.Allow(now.Add(200 * time.Millisecond)))
+	assert.False(limiter.Allow(now.Add(200 * time.Millisecond)))
+
+	// Unused events are not accumulated beyond the limit.
+	now = now.Add(time.Hour)
+	for i := range 5 {
+		assert.True(limiter.Allow(now), "should allow event %d", i)
+	}
+	assert.False(limiter.Allow(now))
+}
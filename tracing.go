@@ -0,0 +1,217 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dlintw/goconf"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName = "github.com/strukturag/nextcloud-spreed-signaling"
+)
+
+var (
+	tracer = otel.Tracer(tracerName)
+)
+
+func init() {
+	// Always propagate incoming trace ids, even if no exporter is configured
+	// locally, so traces of other components are not interrupted.
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+}
+
+// Tracing exports spans to an OTLP collector.
+type Tracing struct {
+	provider *sdktrace.TracerProvider
+}
+
+// NewTracing creates the global tracer provider from the "[tracing]" section
+// of the configuration. Returns nil if tracing is not enabled.
+func NewTracing(ctx context.Context, config *goconf.ConfigFile, serviceName string, version string) (*Tracing, error) {
+	if enabled, _ := config.GetBool("tracing", "enabled"); !enabled {
+		appLog.Infof("Tracing is disabled")
+		return nil, nil
+	}
+
+	var opts []otlptracegrpc.Option
+	endpoint, _ := GetStringOptionWithEnv(config, "tracing", "endpoint")
+	if endpoint != "" {
+		opts = append(opts, otlptracegrpc.WithEndpoint(endpoint))
+	}
+	if insecure, _ := config.GetBool("tracing", "insecure"); insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+
+	ratio := 1.0
+	if value, _ := config.GetString("tracing", "samplingratio"); value != "" {
+		var err error
+		if ratio, err = strconv.ParseFloat(value, 64); err != nil {
+			return nil, fmt.Errorf("invalid sampling ratio %s: %w", value, err)
+		} else if ratio < 0 || ratio > 1 {
+			return nil, fmt.Errorf("sampling ratio must be between 0 and 1, got %f", ratio)
+		}
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("could not create OTLP exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(version),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("could not create tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+	otel.SetTracerProvider(provider)
+	if endpoint == "" {
+		appLog.Infof("Exporting traces to default OTLP endpoint (sampling ratio %.2f)", ratio)
+	} else {
+		appLog.Infof("Exporting traces to %s (sampling ratio %.2f)", endpoint, ratio)
+	}
+	return &Tracing{
+		provider: provider,
+	}, nil
+}
+
+// Close flushes pending spans and stops the exporter.
+func (t *Tracing) Close(ctx context.Context) {
+	if t == nil {
+		return
+	}
+
+	if err := t.provider.Shutdown(ctx); err != nil {
+		appLog.Errorf("Error shutting down tracing: %s", err)
+	}
+}
+
+// endSpan records the error (if any) on the span and ends it.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// injectTraceHeaders adds the headers to propagate the trace of the context
+// to the receiver of the request.
+func injectTraceHeaders(ctx context.Context, header http.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// extractTraceHeaders returns a context that continues the trace from the
+// headers of a received request.
+func extractTraceHeaders(ctx context.Context, header http.Header) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// injectAsyncTraceContext stores the trace of the context in the message, so
+// the servers receiving it through the async events can continue the trace.
+func injectAsyncTraceContext(ctx context.Context, message *AsyncMessage) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return
+	}
+
+	carrier := make(propagation.MapCarrier)
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+	message.TraceContext = carrier
+}
+
+// startAsyncEventSpan starts a span for processing a message received through
+// the async events. Messages without a trace get a span that is not recorded.
+func startAsyncEventSpan(message *AsyncMessage, name string) trace.Span {
+	if len(message.TraceContext) == 0 {
+		return trace.SpanFromContext(context.Background())
+	}
+
+	ctx := otel.GetTextMapPropagator().Extract(context.Background(), propagation.MapCarrier(message.TraceContext))
+	_, span := tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("signaling.message.type", message.Type),
+			attribute.String("signaling.sender", message.SendServerId),
+		),
+	)
+	return span
+}
+
+// observeWithExemplar adds the value to the histogram. If the span of the
+// context is exported, its trace id is attached as exemplar so the trace can be
+// found from the metrics.
+func observeWithExemplar(ctx context.Context, observer prometheus.Observer, value float64) {
+	if span := trace.SpanFromContext(ctx); span.IsRecording() && span.SpanContext().IsSampled() {
+		if eo, ok := observer.(prometheus.ExemplarObserver); ok {
+			eo.ObserveWithExemplar(value, prometheus.Labels{
+				"trace_id": span.SpanContext().TraceID().String(),
+			})
+			return
+		}
+	}
+
+	observer.Observe(value)
+}
+
+// startMcuOperation starts a span for an operation of the MCU. The returned
+// function must be called with the result of the operation to end the span
+// and record its duration.
+func startMcuOperation(ctx context.Context, operation string, id PublicSessionId, streamType StreamType) (context.Context, func(error)) {
+	start := time.Now()
+	ctx, span := tracer.Start(ctx, "mcu."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("signaling.session", string(id)),
+			attribute.String("signaling.streamtype", string(streamType)),
+		),
+	)
+	return ctx, func(err error) {
+		observeWithExemplar(ctx, statsMcuOperationDuration.WithLabelValues(operation, string(streamType)), time.Since(start).Seconds())
+		endSpan(span, err)
+	}
+}
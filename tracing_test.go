@@ -0,0 +1,193 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/dlintw/goconf"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTracing_Disabled(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	tracing, err := NewTracing(context.Background(), goconf.NewConfigFile(), "test", "0.0")
+	require.NoError(err)
+	require.Nil(tracing)
+	// Closing a disabled tracing is a no-op.
+	tracing.Close(context.Background())
+}
+
+func TestTracing_InvalidSamplingRatio(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	for _, ratio := range []string{"foo", "-1", "1.5"} {
+		config := goconf.NewConfigFile()
+		config.AddOption("tracing", "enabled", "true")
+		config.AddOption("tracing", "samplingratio", ratio)
+		_, err := NewTracing(context.Background(), config, "test", "0.0")
+		assert.Error(t, err, "ratio %s should fail", ratio)
+	}
+}
+
+func TestTracing_PropagateHeaders(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10},
+		SpanID:     trace.SpanID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext)
+
+	header := make(http.Header)
+	injectTraceHeaders(ctx, header)
+	assert.Equal("00-0102030405060708090a0b0c0d0e0f10-0102030405060708-01", header.Get("traceparent"))
+
+	extracted := trace.SpanContextFromContext(extractTraceHeaders(context.Background(), header))
+	assert.Equal(spanContext.TraceID(), extracted.TraceID())
+	assert.Equal(spanContext.SpanID(), extracted.SpanID())
+
+	// No headers are added without a trace.
+	header = make(http.Header)
+	injectTraceHeaders(context.Background(), header)
+	assert.Empty(header.Get("traceparent"))
+}
+
+func TestTracing_AsyncTraceContext(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// No trace context is added without a trace.
+	message := &AsyncMessage{
+		Type: "message",
+	}
+	injectAsyncTraceContext(context.Background(), message)
+	assert.Nil(message.TraceContext)
+	assert.False(startAsyncEventSpan(message, "test").SpanContext().IsValid())
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10},
+		SpanID:     trace.SpanID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+		TraceFlags: trace.FlagsSampled,
+	})
+	injectAsyncTraceContext(trace.ContextWithSpanContext(context.Background(), spanContext), message)
+	assert.Equal("00-0102030405060708090a0b0c0d0e0f10-0102030405060708-01", message.TraceContext["traceparent"])
+
+	// The trace is continued by the receiver of the serialized message.
+	data, err := json.Marshal(message)
+	require.NoError(err)
+	var received AsyncMessage
+	require.NoError(json.Unmarshal(data, &received))
+	span := startAsyncEventSpan(&received, "test")
+	defer span.End()
+	assert.Equal(spanContext.TraceID(), span.SpanContext().TraceID())
+}
+
+func TestTracing_BackendRequest(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	traceId := trace.TraceID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10}
+	r := mux.NewRouter()
+	r.HandleFunc("/ocs/v2.php/test", func(w http.ResponseWriter, r *http.Request) {
+		spanContext := trace.SpanContextFromContext(extractTraceHeaders(r.Context(), r.Header))
+		assert.Equal(t, traceId, spanContext.TraceID())
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(err)
+		returnOCS(t, w, body)
+	})
+
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	u, err := url.Parse(server.URL + "/ocs/v2.php/test")
+	require.NoError(err)
+
+	config := goconf.NewConfigFile()
+	config.AddOption("backend", "allowed", u.Host)
+	config.AddOption("backend", "secret", string(testBackendSecret))
+	config.AddOption("backend", "allowhttp", "true")
+	client, err := NewBackendClient(config, 1, "0.0", nil)
+	require.NoError(err)
+
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceId,
+		SpanID:     trace.SpanID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08},
+		TraceFlags: trace.FlagsSampled,
+	}))
+	request := map[string]string{
+		"foo": "bar",
+	}
+	var response map[string]string
+	require.NoError(client.PerformJSONRequest(ctx, u, request, &response))
+	assert.Equal(t, request, response)
+}
+
+func TestTracing_Exemplars(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+	provider := sdktrace.NewTracerProvider()
+	defer provider.Shutdown(context.Background()) // nolint
+
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "test_duration",
+		Buckets: []float64{1, 2},
+	})
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(histogram)
+
+	// No exemplar without a recorded span.
+	observeWithExemplar(context.Background(), histogram, 0.5)
+	ctx, span := provider.Tracer("test").Start(context.Background(), "test")
+	observeWithExemplar(ctx, histogram, 1.5)
+	span.End()
+
+	families, err := registry.Gather()
+	require.NoError(err)
+	require.Len(families, 1)
+	buckets := families[0].GetMetric()[0].GetHistogram().GetBucket()
+	require.Len(buckets, 2)
+	assert.Nil(buckets[0].GetExemplar())
+	if exemplar := buckets[1].GetExemplar(); assert.NotNil(exemplar) && assert.Len(exemplar.GetLabel(), 1) {
+		assert.Equal("trace_id", exemplar.GetLabel()[0].GetName())
+		assert.Equal(span.SpanContext().TraceID().String(), exemplar.GetLabel()[0].GetValue())
+		assert.InDelta(1.5, exemplar.GetValue(), 0.0001)
+	}
+}
@@ -0,0 +1,183 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dlintw/goconf"
+)
+
+const (
+	// Interim captions of a speaker are sent at most once per interval.
+	defaultCaptionInterval = 250 * time.Millisecond
+
+	// Time to wait for forwarded audio to be stopped when a transcription
+	// client disconnects.
+	stopTranscriptionForwardTimeout = 10 * time.Second
+)
+
+type transcriptionForward struct {
+	publisher McuRemoteAwarePublisher
+	hostname  string
+	port      int
+	rtcpPort  int
+}
+
+func getTranscriptionForwardKey(publisherId PublicSessionId, hostname string, port int, rtcpPort int) string {
+	return fmt.Sprintf("%s@%s:%d:%d", publisherId, hostname, port, rtcpPort)
+}
+
+// TranscriptionManager keeps track of the audio that is forwarded to
+// transcription clients and throttles the captions they send.
+type TranscriptionManager struct {
+	captionInterval atomic.Int64
+
+	mu sync.Mutex
+	// Forwarded audio per transcription client.
+	forwards map[*ClientSession]map[string]*transcriptionForward
+	// Time of the last interim caption per transcription client and speaker.
+	lastCaption map[*ClientSession]map[PublicSessionId]time.Time
+}
+
+func NewTranscriptionManager(config *goconf.ConfigFile) *TranscriptionManager {
+	result := &TranscriptionManager{
+		forwards:    make(map[*ClientSession]map[string]*transcriptionForward),
+		lastCaption: make(map[*ClientSession]map[PublicSessionId]time.Time),
+	}
+	result.Reload(config)
+	return result
+}
+
+func (m *TranscriptionManager) Reload(config *goconf.ConfigFile) {
+	interval := defaultCaptionInterval
+	if value, err := config.GetInt("transcription", "captioninterval"); err == nil && value >= 0 {
+		interval = time.Duration(value) * time.Millisecond
+	}
+	m.captionInterval.Store(int64(interval))
+}
+
+// Forward starts forwarding the audio of the publisher to the transcription
+// client.
+func (m *TranscriptionManager) Forward(ctx context.Context, session *ClientSession, publisher McuRemoteAwarePublisher, hostname string, port int, rtcpPort int) error {
+	key := getTranscriptionForwardKey(publisher.PublisherId(), hostname, port, rtcpPort)
+	m.mu.Lock()
+	if _, found := m.forwards[session][key]; found {
+		m.mu.Unlock()
+		return nil
+	}
+	m.mu.Unlock()
+
+	if err := publisher.PublishRemote(ctx, session.PublicId(), hostname, port, rtcpPort); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	forwards, found := m.forwards[session]
+	if !found {
+		forwards = make(map[string]*transcriptionForward)
+		m.forwards[session] = forwards
+	}
+	forwards[key] = &transcriptionForward{
+		publisher: publisher,
+		hostname:  hostname,
+		port:      port,
+		rtcpPort:  rtcpPort,
+	}
+	hubLog.Infof("Forwarding audio of %s to transcription client %s at %s:%d", publisher.PublisherId(), session.PublicId(), hostname, port)
+	return nil
+}
+
+// StopForward stops forwarding the audio of the publisher to the
+// transcription client.
+func (m *TranscriptionManager) StopForward(ctx context.Context, session *ClientSession, publisher McuRemoteAwarePublisher, hostname string, port int, rtcpPort int) error {
+	key := getTranscriptionForwardKey(publisher.PublisherId(), hostname, port, rtcpPort)
+	m.mu.Lock()
+	forward, found := m.forwards[session][key]
+	if found {
+		delete(m.forwards[session], key)
+		if len(m.forwards[session]) == 0 {
+			delete(m.forwards, session)
+		}
+	}
+	m.mu.Unlock()
+	if !found {
+		return nil
+	}
+
+	return forward.publisher.UnpublishRemote(ctx, session.PublicId(), forward.hostname, forward.port, forward.rtcpPort)
+}
+
+// AllowCaption returns true if a caption of the speaker may be sent to the
+// room. Final captions are always allowed.
+func (m *TranscriptionManager) AllowCaption(session *ClientSession, speaker PublicSessionId, final bool, now time.Time) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	last, found := m.lastCaption[session]
+	if final {
+		if found {
+			delete(last, speaker)
+		}
+		return true
+	}
+
+	if !found {
+		last = make(map[PublicSessionId]time.Time)
+		m.lastCaption[session] = last
+	} else if prev, found := last[speaker]; found && now.Sub(prev) < time.Duration(m.captionInterval.Load()) {
+		return false
+	}
+
+	last[speaker] = now
+	return true
+}
+
+// RemoveSession stops forwarding audio to a transcription client that
+// disconnected.
+func (m *TranscriptionManager) RemoveSession(session *ClientSession) {
+	m.mu.Lock()
+	forwards := m.forwards[session]
+	delete(m.forwards, session)
+	delete(m.lastCaption, session)
+	m.mu.Unlock()
+
+	if len(forwards) == 0 {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), stopTranscriptionForwardTimeout)
+		defer cancel()
+
+		for _, forward := range forwards {
+			if err := forward.publisher.UnpublishRemote(ctx, session.PublicId(), forward.hostname, forward.port, forward.rtcpPort); err != nil {
+				hubLog.Warnf("Could not stop forwarding audio of %s to transcription client %s: %s", forward.publisher.PublisherId(), session.PublicId(), err)
+			}
+		}
+	}()
+}
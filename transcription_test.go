@@ -0,0 +1,233 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dlintw/goconf"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranscriptionInternalClientMessage(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	valid := []*TranscriptionInternalClientMessage{
+		{Type: "forward", RoomId: "room", SessionId: "session", Hostname: "127.0.0.1", Port: 5000},
+		{Type: "stopforward", RoomId: "room", SessionId: "session", Hostname: "127.0.0.1", Port: 5000, RtcpPort: 5001},
+		{Type: "caption", RoomId: "room", SessionId: "session", Caption: &TranscriptionCaption{Text: "Hello"}},
+	}
+	for _, msg := range valid {
+		assert.NoError(msg.CheckValid(), "expected %+v to be valid", msg)
+	}
+
+	invalid := []*TranscriptionInternalClientMessage{
+		{Type: "forward", SessionId: "session", Hostname: "127.0.0.1", Port: 5000},
+		{Type: "forward", RoomId: "room", Hostname: "127.0.0.1", Port: 5000},
+		{RoomId: "room", SessionId: "session"},
+		{Type: "unknown", RoomId: "room", SessionId: "session"},
+		{Type: "forward", RoomId: "room", SessionId: "session", Port: 5000},
+		{Type: "forward", RoomId: "room", SessionId: "session", Hostname: "127.0.0.1"},
+		{Type: "forward", RoomId: "room", SessionId: "session", Hostname: "127.0.0.1", Port: 65536},
+		{Type: "forward", RoomId: "room", SessionId: "session", Hostname: "127.0.0.1", Port: 5000, RtcpPort: -1},
+		{Type: "caption", RoomId: "room", SessionId: "session"},
+		{Type: "caption", RoomId: "room", SessionId: "session", Caption: &TranscriptionCaption{}},
+		{Type: "caption", RoomId: "room", SessionId: "session", Caption: &TranscriptionCaption{Text: strings.Repeat("x", maxCaptionLength+1)}},
+	}
+	for _, msg := range invalid {
+		assert.Error(msg.CheckValid(), "expected %+v to be invalid", msg)
+	}
+}
+
+func TestTranscriptionManager_AllowCaption(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	config := goconf.NewConfigFile()
+	config.AddOption("transcription", "captioninterval", "100")
+	manager := NewTranscriptionManager(config)
+
+	session1 := &ClientSession{}
+	session2 := &ClientSession{}
+	now := time.Now()
+	assert.True(manager.AllowCaption(session1, "speaker1", false, now))
+	assert.False(manager.AllowCaption(session1, "speaker1", false, now.Add(50*time.Millisecond)))
+	// Other speakers and transcription clients are throttled separately.
+	assert.True(manager.AllowCaption(session1, "speaker2", false, now.Add(50*time.Millisecond)))
+	assert.True(manager.AllowCaption(session2, "speaker1", false, now.Add(50*time.Millisecond)))
+	// Final captions are always sent.
+	assert.True(manager.AllowCaption(session1, "speaker1", true, now.Add(60*time.Millisecond)))
+	assert.True(manager.AllowCaption(session1, "speaker1", false, now.Add(70*time.Millisecond)))
+	assert.False(manager.AllowCaption(session1, "speaker1", false, now.Add(150*time.Millisecond)))
+	assert.True(manager.AllowCaption(session1, "speaker1", false, now.Add(170*time.Millisecond)))
+
+	manager.RemoveSession(session1)
+	assert.True(manager.AllowCaption(session1, "speaker1", false, now.Add(180*time.Millisecond)))
+
+	config.AddOption("transcription", "captioninterval", "0")
+	manager.Reload(config)
+	assert.True(manager.AllowCaption(session1, "speaker1", false, now.Add(180*time.Millisecond)))
+}
+
+func TestTranscription(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	hub, _, _, server := CreateHubForTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	mcu, err := NewTestMCU()
+	require.NoError(err)
+	require.NoError(mcu.Start(ctx))
+	defer mcu.Stop()
+
+	hub.SetMcu(mcu)
+
+	client, hello := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId)
+	roomId := "test-room"
+	roomMsg := MustSucceed2(t, client.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+	client.RunUntilJoined(ctx, hello.Hello)
+
+	require.NoError(client.SendMessage(MessageClientMessageRecipient{
+		Type:      "session",
+		SessionId: hello.Hello.SessionId,
+	}, MessageClientMessageData{
+		Type:     "offer",
+		Sid:      "54321",
+		RoomType: "video",
+		Payload: StringMap{
+			"sdp": MockSdpOfferAudioOnly,
+		},
+	}))
+	client.RunUntilAnswer(ctx, MockSdpAnswerAudioOnly)
+
+	transcriber := NewTestClient(t, server, hub)
+	defer transcriber.CloseWithBye()
+	require.NoError(transcriber.SendHelloInternalWithFeatures([]string{ClientFeatureTranscription}))
+	helloTranscriber := MustSucceed1(t, transcriber.RunUntilHello, ctx)
+
+	sendTranscription := func(msg *TranscriptionInternalClientMessage) {
+		require.NoError(transcriber.WriteJSON(&ClientMessage{
+			Id:   "transcription",
+			Type: "internal",
+			Internal: &InternalClientMessage{
+				Type:          "transcription",
+				Transcription: msg,
+			},
+		}))
+	}
+
+	// Unknown sessions can't be forwarded.
+	sendTranscription(&TranscriptionInternalClientMessage{
+		Type:      "forward",
+		RoomId:    roomId,
+		SessionId: "unknown-session",
+		Hostname:  "127.0.0.1",
+		Port:      5000,
+	})
+	msg := MustSucceed1(t, transcriber.RunUntilMessage, ctx)
+	assert.True(checkMessageError(t, msg, "no_such_session"))
+
+	sendTranscription(&TranscriptionInternalClientMessage{
+		Type:      "forward",
+		RoomId:    roomId,
+		SessionId: hello.Hello.SessionId,
+		Hostname:  "127.0.0.1",
+		Port:      5000,
+		RtcpPort:  5001,
+	})
+
+	publisher := mcu.GetPublisher(hello.Hello.SessionId)
+	require.NotNil(publisher)
+	remoteId := getPublisherRemoteId(hello.Hello.SessionId, helloTranscriber.Hello.SessionId, "127.0.0.1", 5000, 5001)
+	assert.Eventually(func() bool {
+		remotes := publisher.getRemotes()
+		return len(remotes) == 1 && remotes[0] == remoteId
+	}, testTimeout, time.Millisecond)
+
+	// Interim captions are throttled, final captions are always sent.
+	for _, caption := range []TranscriptionCaption{
+		{Text: "Hello", Language: "en"},
+		{Text: "Hello wor", Language: "en"},
+		{Text: "Hello world", Language: "en", Final: true},
+	} {
+		sendTranscription(&TranscriptionInternalClientMessage{
+			Type:      "caption",
+			RoomId:    roomId,
+			SessionId: hello.Hello.SessionId,
+			Caption:   &caption,
+		})
+	}
+
+	for _, expected := range []TranscriptionCaption{
+		{Text: "Hello", Language: "en"},
+		{Text: "Hello world", Language: "en", Final: true},
+	} {
+		msg := MustSucceed1(t, client.RunUntilMessage, ctx)
+		if checkMessageType(t, msg, "event") &&
+			assert.Equal("room", msg.Event.Target) &&
+			assert.Equal("caption", msg.Event.Type) &&
+			assert.NotNil(msg.Event.Caption) {
+			assert.Equal(roomId, msg.Event.Caption.RoomId)
+			assert.Equal(hello.Hello.SessionId, msg.Event.Caption.SessionId)
+			assert.Equal(testDefaultUserId, msg.Event.Caption.UserId)
+			assert.Equal(expected, msg.Event.Caption.TranscriptionCaption)
+		}
+	}
+
+	sendTranscription(&TranscriptionInternalClientMessage{
+		Type:      "stopforward",
+		RoomId:    roomId,
+		SessionId: hello.Hello.SessionId,
+		Hostname:  "127.0.0.1",
+		Port:      5000,
+		RtcpPort:  5001,
+	})
+	assert.Eventually(func() bool {
+		return len(publisher.getRemotes()) == 0
+	}, testTimeout, time.Millisecond)
+
+	// Forwarding is stopped if the transcription client disconnects.
+	sendTranscription(&TranscriptionInternalClientMessage{
+		Type:      "forward",
+		RoomId:    roomId,
+		SessionId: hello.Hello.SessionId,
+		Hostname:  "127.0.0.1",
+		Port:      5000,
+	})
+	assert.Eventually(func() bool {
+		return len(publisher.getRemotes()) == 1
+	}, testTimeout, time.Millisecond)
+
+	transcriber.CloseWithBye()
+	assert.Eventually(func() bool {
+		return len(publisher.getRemotes()) == 0
+	}, testTimeout, time.Millisecond)
+}
@@ -22,22 +22,126 @@
 package signaling
 
 import (
+	"container/heap"
 	"maps"
 	"reflect"
+	"strings"
 	"sync"
 	"time"
 )
 
+const (
+	// Keys in this namespace can only be modified by sessions that may control
+	// the room (e.g. moderators).
+	TransientNamespaceModerator = "moderator/"
+
+	// Keys "private/<sessionid>/..." are only visible to and can only be
+	// modified by the session with the given public session id.
+	TransientNamespacePrivate = "private/"
+)
+
+// getTransientKeyOwner returns the public session id of the owner of a key in
+// the private namespace.
+func getTransientKeyOwner(key string) (PublicSessionId, bool) {
+	rest, found := strings.CutPrefix(key, TransientNamespacePrivate)
+	if !found {
+		return "", false
+	}
+
+	owner, _, _ := strings.Cut(rest, "/")
+	return PublicSessionId(owner), true
+}
+
+// IsTransientKeyVisible returns true if the key may be sent to the session
+// with the given public id.
+func IsTransientKeyVisible(key string, sessionId PublicSessionId) bool {
+	owner, found := getTransientKeyOwner(key)
+	return !found || owner == sessionId
+}
+
+// filterTransientData returns the entries of the data that are visible to the
+// session with the given public id.
+func filterTransientData(data StringMap, sessionId PublicSessionId) StringMap {
+	var result StringMap
+	for key := range data {
+		if !IsTransientKeyVisible(key, sessionId) {
+			if result == nil {
+				result = maps.Clone(data)
+			}
+			delete(result, key)
+		}
+	}
+	if result == nil {
+		return data
+	}
+	return result
+}
+
+// TransientEntry is a value of the transient data with an optional
+// expiration time.
+type TransientEntry struct {
+	Value   any       `json:"value"`
+	Expires time.Time `json:"expires,omitzero"`
+}
+
 type TransientListener interface {
 	SendMessage(message *ServerMessage) bool
 }
 
+// transientExpiration is an entry with a TTL in the expiration heap of the
+// transient data.
+type transientExpiration struct {
+	key     string
+	value   any
+	expires time.Time
+	index   int
+}
+
+// transientExpirationHeap is a min-heap of expirations ordered by their time.
+type transientExpirationHeap []*transientExpiration
+
+func (h transientExpirationHeap) Len() int {
+	return len(h)
+}
+
+func (h transientExpirationHeap) Less(i, j int) bool {
+	return h[i].expires.Before(h[j].expires)
+}
+
+func (h transientExpirationHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *transientExpirationHeap) Push(x any) {
+	e := x.(*transientExpiration)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *transientExpirationHeap) Pop() any {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	e.index = -1
+	*h = old[:n-1]
+	return e
+}
+
 type TransientData struct {
 	mu        sync.Mutex
 	data      StringMap
 	listeners map[TransientListener]bool
-	timers    map[string]*time.Timer
 	ttlCh     chan<- struct{}
+
+	// Entries with a TTL are expired by a single timer that fires for the
+	// earliest expiration in the heap.
+	expirations    map[string]*transientExpiration
+	expirationHeap transientExpirationHeap
+	timer          *time.Timer
+	timerExpires   time.Time
 }
 
 // NewTransientData creates a new transient data container.
@@ -112,7 +216,7 @@ func (t *TransientData) RemoveListener(listener TransientListener) {
 
 func (t *TransientData) updateTTL(key string, value any, ttl time.Duration) {
 	if ttl <= 0 {
-		delete(t.timers, key)
+		t.removeExpiration(key)
 	} else {
 		t.removeAfterTTL(key, value, ttl)
 	}
@@ -120,29 +224,82 @@ func (t *TransientData) updateTTL(key string, value any, ttl time.Duration) {
 
 func (t *TransientData) removeAfterTTL(key string, value any, ttl time.Duration) {
 	if ttl <= 0 {
+		t.removeExpiration(key)
+		return
+	}
+
+	expires := time.Now().Add(ttl)
+	if e, found := t.expirations[key]; found {
+		e.value = value
+		e.expires = expires
+		heap.Fix(&t.expirationHeap, e.index)
+	} else {
+		e = &transientExpiration{
+			key:     key,
+			value:   value,
+			expires: expires,
+		}
+		if t.expirations == nil {
+			t.expirations = make(map[string]*transientExpiration)
+		}
+		t.expirations[key] = e
+		heap.Push(&t.expirationHeap, e)
+	}
+	t.scheduleExpiration()
+}
+
+func (t *TransientData) removeExpiration(key string) {
+	e, found := t.expirations[key]
+	if !found {
+		return
+	}
+
+	delete(t.expirations, key)
+	heap.Remove(&t.expirationHeap, e.index)
+	t.scheduleExpiration()
+}
+
+// scheduleExpiration updates the timer to fire for the earliest expiration.
+func (t *TransientData) scheduleExpiration() {
+	if len(t.expirationHeap) == 0 {
+		if t.timer != nil {
+			t.timer.Stop()
+			t.timerExpires = time.Time{}
+		}
 		return
 	}
 
-	if old, found := t.timers[key]; found {
-		old.Stop()
+	next := t.expirationHeap[0].expires
+	if next.Equal(t.timerExpires) {
+		return
 	}
 
-	timer := time.AfterFunc(ttl, func() {
-		t.mu.Lock()
-		defer t.mu.Unlock()
+	t.timerExpires = next
+	if t.timer == nil {
+		t.timer = time.AfterFunc(time.Until(next), t.processExpirations)
+	} else {
+		t.timer.Reset(time.Until(next))
+	}
+}
+
+func (t *TransientData) processExpirations() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.timerExpires = time.Time{}
+	for len(t.expirationHeap) > 0 && !t.expirationHeap[0].expires.After(time.Now()) {
+		e := heap.Pop(&t.expirationHeap).(*transientExpiration)
+		delete(t.expirations, e.key)
 
-		t.compareAndRemove(key, value)
+		t.compareAndRemove(e.key, e.value)
 		if t.ttlCh != nil {
 			select {
 			case t.ttlCh <- struct{}{}:
 			default:
 			}
 		}
-	})
-	if t.timers == nil {
-		t.timers = make(map[string]*time.Timer)
 	}
-	t.timers[key] = timer
+	t.scheduleExpiration()
 }
 
 func (t *TransientData) doSet(key string, value any, prev any, ttl time.Duration) {
@@ -210,10 +367,7 @@ func (t *TransientData) CompareAndSetTTL(key string, old, value any, ttl time.Du
 
 func (t *TransientData) doRemove(key string, prev any) {
 	delete(t.data, key)
-	if old, found := t.timers[key]; found {
-		old.Stop()
-		delete(t.timers, key)
-	}
+	t.removeExpiration(key)
 	t.notifyDeleted(key, prev)
 }
 
@@ -260,3 +414,49 @@ func (t *TransientData) GetData() StringMap {
 	maps.Copy(result, t.data)
 	return result
 }
+
+// GetEntries returns a copy of the internal data together with the expiration
+// times of entries that have a TTL.
+func (t *TransientData) GetEntries() map[string]*TransientEntry {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	result := make(map[string]*TransientEntry, len(t.data))
+	for key, value := range t.data {
+		entry := &TransientEntry{
+			Value: value,
+		}
+		if e, found := t.expirations[key]; found {
+			entry.Expires = e.expires
+		}
+		result[key] = entry
+	}
+	return result
+}
+
+// MergeEntries adds entries that don't exist yet (e.g. received from another
+// server) and notifies listeners about them. Expired entries are ignored.
+func (t *TransientData) MergeEntries(entries map[string]*TransientEntry, now time.Time) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	count := 0
+	for key, entry := range entries {
+		if entry.Value == nil {
+			continue
+		} else if _, found := t.data[key]; found {
+			continue
+		}
+
+		var ttl time.Duration
+		if !entry.Expires.IsZero() {
+			if ttl = entry.Expires.Sub(now); ttl <= 0 {
+				continue
+			}
+		}
+
+		t.doSet(key, entry.Value, nil, ttl)
+		count++
+	}
+	return count
+}
@@ -23,6 +23,9 @@ package signaling
 
 import (
 	"context"
+	"maps"
+	"runtime"
+	"strconv"
 	"sync"
 	"testing"
 	"time"
@@ -85,6 +88,37 @@ func Test_TransientData(t *testing.T) {
 	assert.Nil(data.GetData()["test"])
 }
 
+func Test_TransientDataExpirations(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	data := NewTransientData()
+	ttlCh := make(chan struct{}, 10)
+	data.SetTTLChannel(ttlCh)
+
+	assert.True(data.SetTTL("a", "1", 3*time.Millisecond))
+	assert.True(data.SetTTL("b", "2", time.Millisecond))
+	assert.True(data.SetTTL("c", "3", time.Hour))
+	assert.True(data.SetTTL("d", "4", 2*time.Millisecond))
+	// Removing the TTL keeps the value.
+	assert.False(data.SetTTL("d", "4", 0))
+	data.mu.Lock()
+	assert.Len(data.expirationHeap, 3)
+	data.mu.Unlock()
+
+	<-ttlCh
+	<-ttlCh
+	assert.Equal(StringMap{
+		"c": "3",
+		"d": "4",
+	}, data.GetData())
+
+	assert.True(data.Remove("c"))
+	data.mu.Lock()
+	defer data.mu.Unlock()
+	assert.Empty(data.expirationHeap)
+	assert.Empty(data.expirations)
+}
+
 type MockTransientListener struct {
 	mu      sync.Mutex
 	sending chan struct{}
@@ -256,3 +290,277 @@ func Test_TransientMessages(t *testing.T) {
 		checkMessageTransientRemove(t, msg, "abc", data)
 	}
 }
+
+func Test_TransientDataVisibility(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	assert.True(IsTransientKeyVisible("foo", "session1"))
+	assert.True(IsTransientKeyVisible("moderator/foo", "session1"))
+	assert.True(IsTransientKeyVisible("private/session1/foo", "session1"))
+	assert.True(IsTransientKeyVisible("private/session1", "session1"))
+	assert.False(IsTransientKeyVisible("private/session2/foo", "session1"))
+	assert.False(IsTransientKeyVisible("private/", "session1"))
+
+	data := StringMap{
+		"foo":                  "bar",
+		"private/session1/foo": "bar",
+	}
+	assert.Equal(data, filterTransientData(data, "session1"))
+	assert.Equal(StringMap{
+		"foo": "bar",
+	}, filterTransientData(data, "session2"))
+	// The original data must not be modified.
+	assert.Len(data, 2)
+}
+
+func Test_TransientNamespaces(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	hub, _, _, server := CreateHubForTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client1, hello1 := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId+"1")
+	client2, hello2 := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId+"2")
+
+	roomId := "test-room"
+	roomMsg := MustSucceed2(t, client1.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+
+	// Give message processing some time.
+	time.Sleep(10 * time.Millisecond)
+
+	roomMsg = MustSucceed2(t, client2.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+
+	WaitForUsersJoined(ctx, t, client1, hello1, client2, hello2)
+
+	session1 := hub.GetSessionByPublicId(hello1.Hello.SessionId).(*ClientSession)
+	require.NotNil(session1, "Session %s does not exist", hello1.Hello.SessionId)
+	session2 := hub.GetSessionByPublicId(hello2.Hello.SessionId).(*ClientSession)
+	require.NotNil(session2, "Session %s does not exist", hello2.Hello.SessionId)
+
+	// Client 1 is a moderator, client 2 may only modify regular keys.
+	session1.SetPermissions([]Permission{PERMISSION_TRANSIENT_DATA, PERMISSION_MAY_CONTROL})
+	session2.SetPermissions([]Permission{PERMISSION_TRANSIENT_DATA})
+
+	require.NoError(client2.SetTransientData("moderator/state", "vandalized", 0))
+	if msg, ok := client2.RunUntilMessage(ctx); ok {
+		checkMessageError(t, msg, "not_allowed")
+	}
+
+	require.NoError(client1.SetTransientData("moderator/state", "started", 0))
+	if msg, ok := client1.RunUntilMessage(ctx); ok {
+		checkMessageTransientSet(t, msg, "moderator/state", "started", nil)
+	}
+	if msg, ok := client2.RunUntilMessage(ctx); ok {
+		checkMessageTransientSet(t, msg, "moderator/state", "started", nil)
+	}
+
+	// Private keys can only be modified by their owner.
+	privateKey1 := "private/" + string(hello1.Hello.SessionId) + "/draft"
+	require.NoError(client2.SetTransientData(privateKey1, "vandalized", 0))
+	if msg, ok := client2.RunUntilMessage(ctx); ok {
+		checkMessageError(t, msg, "not_allowed")
+	}
+
+	// Private keys are only visible to their owner.
+	privateKey2 := "private/" + string(hello2.Hello.SessionId) + "/draft"
+	require.NoError(client2.SetTransientData(privateKey2, "secret", 0))
+	if msg, ok := client2.RunUntilMessage(ctx); ok {
+		checkMessageTransientSet(t, msg, privateKey2, "secret", nil)
+	}
+
+	ctx2, cancel2 := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel2()
+
+	client1.RunUntilErrorIs(ctx2, ErrNoMessageReceived, context.DeadlineExceeded)
+
+	client3, hello3 := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId+"3")
+	roomMsg = MustSucceed2(t, client3.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+
+	_, ignored, ok := client3.RunUntilJoinedAndReturn(ctx, hello1.Hello, hello2.Hello, hello3.Hello)
+	require.True(ok)
+
+	var msg *ServerMessage
+	if len(ignored) == 0 {
+		msg = MustSucceed1(t, client3.RunUntilMessage, ctx)
+	} else if len(ignored) == 1 {
+		msg = ignored[0]
+	} else {
+		require.LessOrEqual(len(ignored), 1, "Received too many messages: %+v", ignored)
+	}
+
+	checkMessageTransientInitial(t, msg, StringMap{
+		"moderator/state": "started",
+	})
+}
+
+func Test_TransientDataEntries(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	data := NewTransientData()
+	assert.True(data.Set("foo", "bar"))
+	assert.True(data.SetTTL("abc", "def", time.Minute))
+
+	entries := data.GetEntries()
+	if assert.Len(entries, 2) {
+		assert.Equal("bar", entries["foo"].Value)
+		assert.True(entries["foo"].Expires.IsZero())
+		assert.Equal("def", entries["abc"].Value)
+		assert.WithinDuration(time.Now().Add(time.Minute), entries["abc"].Expires, time.Second)
+	}
+
+	// Removing the TTL also clears the expiration.
+	assert.False(data.SetTTL("abc", "def", 0))
+	assert.True(data.GetEntries()["abc"].Expires.IsZero())
+
+	now := time.Now()
+	other := NewTransientData()
+	assert.True(other.Set("foo", "local"))
+	assert.Equal(2, other.MergeEntries(map[string]*TransientEntry{
+		"foo": {Value: "remote"},
+		"bar": {Value: "baz"},
+		"ttl": {Value: "value", Expires: now.Add(time.Minute)},
+		"old": {Value: "value", Expires: now.Add(-time.Second)},
+	}, now))
+	// Existing values are not overwritten, expired values are ignored.
+	assert.Equal(StringMap{
+		"foo": "local",
+		"bar": "baz",
+		"ttl": "value",
+	}, other.GetData())
+	assert.WithinDuration(now.Add(time.Minute), other.GetEntries()["ttl"].Expires, time.Second)
+}
+
+func Test_TransientDataClustered(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	hub1, hub2, server1, server2 := CreateClusteredHubsForTest(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	roomId := "test-room"
+	client1, hello1 := NewTestClientWithHello(ctx, t, server1, hub1, testDefaultUserId+"1")
+	roomMsg := MustSucceed2(t, client1.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+	client1.RunUntilJoined(ctx, hello1.Hello)
+
+	require.NoError(client1.SetTransientData("foo", "bar", 0))
+	if msg, ok := client1.RunUntilMessage(ctx); ok {
+		checkMessageTransientSet(t, msg, "foo", "bar", nil)
+	}
+
+	// The data is received from the other server when the room is created.
+	client2, hello2 := NewTestClientWithHello(ctx, t, server2, hub2, testDefaultUserId+"2")
+	roomMsg = MustSucceed2(t, client2.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+	_, additional, ok := client2.RunUntilJoinedAndReturn(ctx, hello1.Hello, hello2.Hello)
+	require.True(ok)
+	client1.RunUntilJoined(ctx, hello2.Hello)
+
+	var msg *ServerMessage
+	if len(additional) == 0 {
+		msg = MustSucceed1(t, client2.RunUntilMessage, ctx)
+	} else {
+		require.Len(additional, 1, "Received too many messages: %+v", additional)
+		msg = additional[0]
+	}
+	if msg.TransientData != nil && msg.TransientData.Type == "initial" {
+		checkMessageTransientInitial(t, msg, StringMap{
+			"foo": "bar",
+		})
+	} else {
+		checkMessageTransientSet(t, msg, "foo", "bar", nil)
+	}
+
+	// Changes are distributed to all servers.
+	require.NoError(client2.SetTransientData("abc", "def", 0))
+	if msg, ok := client1.RunUntilMessage(ctx); ok {
+		checkMessageTransientSet(t, msg, "abc", "def", nil)
+	}
+	if msg, ok := client2.RunUntilMessage(ctx); ok {
+		checkMessageTransientSet(t, msg, "abc", "def", nil)
+	}
+
+	// The data survives if the room is closed on the first server.
+	client1.CloseWithBye()
+	require.NoError(client1.WaitForClientRemoved(ctx))
+	assert.Eventually(func() bool {
+		hub1.ru.RLock()
+		defer hub1.ru.RUnlock()
+		return len(hub1.rooms) == 0
+	}, testTimeout, time.Millisecond)
+
+	client3, hello3 := NewTestClientWithHello(ctx, t, server1, hub1, testDefaultUserId+"3")
+	roomMsg = MustSucceed2(t, client3.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+	_, additional, ok = client3.RunUntilJoinedAndReturn(ctx, hello2.Hello, hello3.Hello)
+	require.True(ok)
+
+	// The data is received from the second server.
+	received := make(StringMap)
+	for len(received) < 2 {
+		var msg *ServerMessage
+		if len(additional) > 0 {
+			msg = additional[0]
+			additional = additional[1:]
+		} else {
+			msg = MustSucceed1(t, client3.RunUntilMessage, ctx)
+		}
+		if !checkMessageType(t, msg, "transient") {
+			break
+		}
+
+		switch msg.TransientData.Type {
+		case "initial":
+			maps.Copy(received, msg.TransientData.Data)
+		case "set":
+			received[msg.TransientData.Key] = msg.TransientData.Value
+		default:
+			require.Fail("unexpected transient message", "received %+v", msg)
+		}
+	}
+	assert.Equal(StringMap{
+		"foo": "bar",
+		"abc": "def",
+	}, received)
+}
+
+func BenchmarkTransientData_SetTTL(b *testing.B) {
+	data := NewTransientData()
+	keys := make([]string, 1000)
+	for i := range keys {
+		keys[i] = "key-" + strconv.Itoa(i)
+	}
+
+	b.ReportAllocs()
+	for i := 0; b.Loop(); i++ {
+		data.SetTTL(keys[i%len(keys)], i, time.Minute)
+	}
+}
+
+func BenchmarkTransientData_ShortLived(b *testing.B) {
+	data := NewTransientData()
+	keys := make([]string, 1000)
+	for i := range keys {
+		keys[i] = "key-" + strconv.Itoa(i)
+	}
+
+	b.ReportAllocs()
+	goroutines := 0
+	for i := 0; b.Loop(); i++ {
+		data.SetTTL(keys[i%len(keys)], i, time.Millisecond)
+		if i%len(keys) == 0 {
+			goroutines = max(goroutines, runtime.NumGoroutine())
+		}
+	}
+	b.ReportMetric(float64(goroutines), "max-goroutines")
+}
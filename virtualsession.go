@@ -24,8 +24,8 @@ package signaling
 import (
 	"context"
 	"encoding/json"
-	"log"
 	"net/url"
+	"sync"
 	"sync/atomic"
 )
 
@@ -49,6 +49,10 @@ type VirtualSession struct {
 	inCall    Flags
 	flags     Flags
 	options   *AddSessionOptions
+	degraded  atomic.Bool
+
+	mu          sync.Mutex
+	permissions map[Permission]bool
 
 	parseUserData func() (StringMap, error)
 }
@@ -72,18 +76,21 @@ func NewVirtualSession(session *ClientSession, privateId PrivateSessionId, publi
 		options:       msg.Options,
 	}
 
+	if msg.Permissions != nil {
+		result.permissions = makePermissionsMap(*msg.Permissions)
+	}
+
 	if err := session.events.RegisterSessionListener(publicId, session.Backend(), result); err != nil {
 		return nil, err
 	}
 
 	if msg.InCall != nil {
 		result.SetInCall(*msg.InCall)
-	} else if !session.HasFeature(ClientFeatureInternalInCall) {
+	} else if !session.HasNegotiatedFeature(ClientFeatureInternalInCall) {
 		result.SetInCall(FlagInCall | FlagWithPhone)
 	}
-	if msg.Flags != 0 {
-		result.SetFlags(msg.Flags)
-	}
+	// Always set the flags so listen-only sessions are marked as muted.
+	result.SetFlags(msg.Flags)
 
 	return result, nil
 }
@@ -104,6 +111,14 @@ func (s *VirtualSession) ClientType() ClientType {
 	return HelloClientTypeVirtual
 }
 
+func makePermissionsMap(permissions []Permission) map[Permission]bool {
+	result := make(map[Permission]bool, len(permissions))
+	for _, permission := range permissions {
+		result[permission] = true
+	}
+	return result
+}
+
 func (s *VirtualSession) GetInCall() int {
 	return int(s.inCall.Get())
 }
@@ -113,6 +128,12 @@ func (s *VirtualSession) SetInCall(inCall int) bool {
 		inCall = 0
 	}
 
+	if !s.mayPublishAudio() {
+		inCall &^= FlagWithAudio
+	}
+	if !s.mayPublishVideo() {
+		inCall &^= FlagWithVideo
+	}
 	return s.inCall.Set(uint32(inCall))
 }
 
@@ -152,7 +173,7 @@ func (s *VirtualSession) SetRoom(room *Room) {
 	s.room.Store(room)
 	if room != nil {
 		if err := s.hub.roomSessions.SetRoomSession(s, RoomSessionId(s.PublicId())); err != nil {
-			log.Printf("Error adding virtual room session %s: %s", s.PublicId(), err)
+			hubLog.Errorf("Error adding virtual room session %s: %s", s.PublicId(), err)
 		}
 	} else {
 		s.hub.roomSessions.DeleteRoomSession(s)
@@ -201,7 +222,7 @@ func (s *VirtualSession) notifyBackendRemoved(room *Room, session Session, messa
 		var response BackendClientResponse
 		if err := s.hub.backend.PerformJSONRequest(ctx, s.ParsedBackendOcsUrl(), request, &response); err != nil {
 			virtualSessionId := GetVirtualSessionId(s.session, s.PublicId())
-			log.Printf("Could not leave virtual session %s at backend %s: %s", virtualSessionId, s.BackendUrl(), err)
+			hubLog.Errorf("Could not leave virtual session %s at backend %s: %s", virtualSessionId, s.BackendUrl(), err)
 			if session != nil && message != nil {
 				reply := message.NewErrorServerMessage(NewError("remove_failed", "Could not remove virtual session from backend."))
 				session.SendMessage(reply)
@@ -212,7 +233,7 @@ func (s *VirtualSession) notifyBackendRemoved(room *Room, session Session, messa
 		if response.Type == "error" {
 			virtualSessionId := GetVirtualSessionId(s.session, s.PublicId())
 			if session != nil && message != nil && (response.Error == nil || response.Error.Code != "no_such_room") {
-				log.Printf("Could not leave virtual session %s at backend %s: %+v", virtualSessionId, s.BackendUrl(), response.Error)
+				hubLog.Errorf("Could not leave virtual session %s at backend %s: %+v", virtualSessionId, s.BackendUrl(), response.Error)
 				reply := message.NewErrorServerMessage(NewError("remove_failed", response.Error.Error()))
 				session.SendMessage(reply)
 			}
@@ -226,7 +247,7 @@ func (s *VirtualSession) notifyBackendRemoved(room *Room, session Session, messa
 		var response BackendClientSessionResponse
 		err := s.hub.backend.PerformJSONRequest(ctx, s.ParsedBackendOcsUrl(), request, &response)
 		if err != nil {
-			log.Printf("Could not remove virtual session %s from backend %s: %s", s.PublicId(), s.BackendUrl(), err)
+			hubLog.Errorf("Could not remove virtual session %s from backend %s: %s", s.PublicId(), s.BackendUrl(), err)
 			if session != nil && message != nil {
 				reply := message.NewErrorServerMessage(NewError("remove_failed", "Could not remove virtual session from backend."))
 				session.SendMessage(reply)
@@ -235,8 +256,53 @@ func (s *VirtualSession) notifyBackendRemoved(room *Room, session Session, messa
 	}
 }
 
+// HasPermission checks if the virtual session has the passed permission.
+// Virtual sessions without explicit permissions have all permissions.
 func (s *VirtualSession) HasPermission(permission Permission) bool {
-	return true
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.permissions == nil {
+		return true
+	}
+
+	return s.permissions[permission]
+}
+
+func (s *VirtualSession) mayPublishAudio() bool {
+	return s.HasPermission(PERMISSION_MAY_PUBLISH_MEDIA) || s.HasPermission(PERMISSION_MAY_PUBLISH_AUDIO)
+}
+
+func (s *VirtualSession) mayPublishVideo() bool {
+	return s.HasPermission(PERMISSION_MAY_PUBLISH_MEDIA) || s.HasPermission(PERMISSION_MAY_PUBLISH_VIDEO)
+}
+
+// SetPermissions updates the permissions of the virtual session. In-call
+// and participant flags that are no longer allowed are removed, the returned
+// value contains the resulting changes.
+func (s *VirtualSession) SetPermissions(permissions []Permission) SessionChangeFlag {
+	s.mu.Lock()
+	s.permissions = makePermissionsMap(permissions)
+	s.mu.Unlock()
+	hubLog.Infof("Permissions of virtual session %s changed: %s", s.PublicId(), permissions)
+
+	var changed SessionChangeFlag
+	if s.SetFlags(s.Flags()) {
+		changed |= SessionChangeFlags
+	}
+	if s.SetInCall(s.GetInCall()) {
+		changed |= SessionChangeInCall
+	}
+	return changed
+}
+
+// filterFlags returns the participant flags that are allowed for the
+// permissions of the virtual session.
+func (s *VirtualSession) filterFlags(flags uint32) uint32 {
+	if !s.mayPublishAudio() {
+		// Listen-only sessions are always muted and can't talk.
+		flags = (flags &^ FLAG_TALKING) | FLAG_MUTED_SPEAKING
+	}
+	return flags
 }
 
 func (s *VirtualSession) Session() *ClientSession {
@@ -248,21 +314,35 @@ func (s *VirtualSession) SessionId() PublicSessionId {
 }
 
 func (s *VirtualSession) AddFlags(flags uint32) bool {
-	return s.flags.Add(flags)
+	return s.flags.Add(s.filterFlags(flags))
 }
 
 func (s *VirtualSession) RemoveFlags(flags uint32) bool {
+	if !s.mayPublishAudio() {
+		flags &^= FLAG_MUTED_SPEAKING
+	}
 	return s.flags.Remove(flags)
 }
 
 func (s *VirtualSession) SetFlags(flags uint32) bool {
-	return s.flags.Set(flags)
+	return s.flags.Set(s.filterFlags(flags))
 }
 
 func (s *VirtualSession) Flags() uint32 {
 	return s.flags.Get()
 }
 
+// IsDegraded returns true if the internal client of the virtual session
+// stopped sending heartbeats.
+func (s *VirtualSession) IsDegraded() bool {
+	return s.degraded.Load()
+}
+
+// SetDegraded changes the degraded state and returns true if it was modified.
+func (s *VirtualSession) SetDegraded(degraded bool) bool {
+	return s.degraded.CompareAndSwap(!degraded, degraded)
+}
+
 func (s *VirtualSession) Options() *AddSessionOptions {
 	return s.options
 }
@@ -275,6 +355,11 @@ func (s *VirtualSession) ProcessAsyncSessionMessage(message *AsyncMessage) {
 				message.Message.Message.Recipient != nil &&
 				message.Message.Message.Recipient.Type == "session" &&
 				message.Message.Message.Recipient.SessionId == s.PublicId() {
+				if !s.HasPermission(PERMISSION_MAY_CHAT) {
+					hubLog.Debugf("Virtual session %s may not receive messages, ignoring %+v", s.PublicId(), message.Message.Message)
+					return
+				}
+
 				// The client should see his session id as recipient.
 				message.Message.Message.Recipient = &MessageClientMessageRecipient{
 					Type:      "session",
@@ -285,11 +370,32 @@ func (s *VirtualSession) ProcessAsyncSessionMessage(message *AsyncMessage) {
 			}
 		case "event":
 			if room := s.GetRoom(); room != nil &&
+				message.Message.Event.Target == "session" &&
+				message.Message.Event.Type == "dtmf" &&
+				message.Message.Event.Dtmf != nil &&
+				message.Message.Event.Dtmf.SessionId == s.PublicId() &&
+				message.Message.Event.Dtmf.RoomId == room.Id() {
+				// The SIP bridge should see the session id it used for the virtual session.
+				dtmf := *message.Message.Event.Dtmf
+				dtmf.SessionId = s.SessionId()
+				s.session.ProcessAsyncSessionMessage(&AsyncMessage{
+					Type:     "message",
+					SendTime: message.SendTime,
+					Message: &ServerMessage{
+						Type: "event",
+						Event: &EventServerMessage{
+							Target: "session",
+							Type:   "dtmf",
+							Dtmf:   &dtmf,
+						},
+					},
+				})
+			} else if room := s.GetRoom(); room != nil &&
 				message.Message.Event.Target == "roomlist" &&
 				message.Message.Event.Type == "disinvite" &&
 				message.Message.Event.Disinvite != nil &&
 				message.Message.Event.Disinvite.RoomId == room.Id() {
-				log.Printf("Virtual session %s was disinvited from room %s, hanging up", s.PublicId(), room.Id())
+				hubLog.Infof("Virtual session %s was disinvited from room %s, hanging up", s.PublicId(), room.Id())
 				payload := StringMap{
 					"type": "hangup",
 					"hangup": map[string]string{
@@ -298,7 +404,7 @@ func (s *VirtualSession) ProcessAsyncSessionMessage(message *AsyncMessage) {
 				}
 				data, err := json.Marshal(payload)
 				if err != nil {
-					log.Printf("could not marshal control payload %+v: %s", payload, err)
+					hubLog.Errorf("could not marshal control payload %+v: %s", payload, err)
 					return
 				}
 
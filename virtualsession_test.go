@@ -26,6 +26,7 @@ import (
 	"encoding/json"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -567,6 +568,170 @@ func TestVirtualSessionCustomInCall(t *testing.T) {
 	}
 }
 
+func TestVirtualSessionPermissions(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	hub, _, _, server := CreateHubForTest(t)
+
+	roomId := "the-room-id"
+	emptyProperties := json.RawMessage("{}")
+	backend := &Backend{
+		id: "compat",
+	}
+	room, err := hub.createRoom(roomId, emptyProperties, backend)
+	require.NoError(err)
+	defer room.Close()
+
+	clientInternal := NewTestClient(t, server, hub)
+	defer clientInternal.CloseWithBye()
+	features := []string{
+		ClientFeatureInternalInCall,
+	}
+	require.NoError(clientInternal.SendHelloInternalWithFeatures(features))
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client, hello := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId)
+
+	helloInternal := MustSucceed1(t, clientInternal.RunUntilHello, ctx)
+	roomMsg := MustSucceed3(t, clientInternal.JoinRoomWithRoomSession, ctx, roomId, "")
+	require.Equal(roomId, roomMsg.Room.RoomId)
+
+	roomMsg = MustSucceed2(t, client.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+
+	if _, additional, ok := clientInternal.RunUntilJoinedAndReturn(ctx, helloInternal.Hello, hello.Hello); ok {
+		if assert.Len(additional, 1) {
+			assert.True(checkMessageType(t, additional[0], "event"))
+		}
+	}
+	client.RunUntilJoined(ctx, helloInternal.Hello, hello.Hello)
+
+	// A listen-only virtual session that may not chat.
+	internalSessionId := PublicSessionId("session1")
+	userId := "user1"
+	inCall := FlagInCall | FlagWithAudio | FlagWithPhone
+	msgAdd := &ClientMessage{
+		Type: "internal",
+		Internal: &InternalClientMessage{
+			Type: "addsession",
+			AddSession: &AddSessionInternalClientMessage{
+				CommonSessionInternalClientMessage: CommonSessionInternalClientMessage{
+					SessionId: internalSessionId,
+					RoomId:    roomId,
+				},
+				UserId:      userId,
+				Flags:       FLAG_TALKING,
+				InCall:      &inCall,
+				Permissions: &[]Permission{},
+			},
+		},
+	}
+	require.NoError(clientInternal.WriteJSON(msgAdd))
+
+	msg1 := MustSucceed1(t, client.RunUntilMessage, ctx)
+	require.True(client.checkMessageJoinedSession(msg1, "", userId))
+	sessionId := msg1.Event.Join[0].SessionId
+	session, ok := hub.GetSessionByPublicId(sessionId).(*VirtualSession)
+	require.True(ok, "expected virtual session for %s", sessionId)
+	assert.False(session.HasPermission(PERMISSION_MAY_PUBLISH_AUDIO))
+	assert.False(session.HasPermission(PERMISSION_MAY_CHAT))
+
+	msg2 := MustSucceed1(t, client.RunUntilMessage, ctx)
+	if updateMsg, ok := checkMessageParticipantsInCall(t, msg2); ok {
+		checkHasEntryWithInCall(t, updateMsg, sessionId, "virtual", FlagInCall|FlagWithPhone)
+	}
+
+	msg3 := MustSucceed1(t, client.RunUntilMessage, ctx)
+	if flagsMsg, ok := checkMessageParticipantFlags(t, msg3); ok {
+		assert.Equal(sessionId, flagsMsg.SessionId)
+		assert.EqualValues(FLAG_MUTED_SPEAKING, flagsMsg.Flags)
+	}
+
+	// The internal client also receives the events for the virtual session.
+	for _, target := range []string{"room", "participants", "participants"} {
+		msg := MustSucceed1(t, clientInternal.RunUntilMessage, ctx)
+		if checkMessageType(t, msg, "event") {
+			assert.Equal(target, msg.Event.Target)
+		}
+	}
+
+	// Listen-only sessions can't be unmuted.
+	assert.False(session.RemoveFlags(FLAG_MUTED_SPEAKING))
+	assert.False(session.AddFlags(FLAG_TALKING))
+	assert.EqualValues(FLAG_MUTED_SPEAKING, session.Flags())
+
+	// Messages are not forwarded to the virtual session.
+	recipient := MessageClientMessageRecipient{
+		Type:      "session",
+		SessionId: sessionId,
+	}
+	require.NoError(client.SendMessage(recipient, "not-delivered"))
+
+	ctx2, cancel2 := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer cancel2()
+	clientInternal.RunUntilErrorIs(ctx2, ErrNoMessageReceived, context.DeadlineExceeded)
+
+	// The permissions can be changed later.
+	newFlags := uint32(FLAG_TALKING)
+	msgUpdate := &ClientMessage{
+		Type: "internal",
+		Internal: &InternalClientMessage{
+			Type: "updatesession",
+			UpdateSession: &UpdateSessionInternalClientMessage{
+				CommonSessionInternalClientMessage: CommonSessionInternalClientMessage{
+					SessionId: internalSessionId,
+					RoomId:    roomId,
+				},
+				Flags: &newFlags,
+				Permissions: &[]Permission{
+					PERMISSION_MAY_PUBLISH_AUDIO,
+					PERMISSION_MAY_CHAT,
+				},
+			},
+		},
+	}
+	require.NoError(clientInternal.WriteJSON(msgUpdate))
+
+	msg4 := MustSucceed1(t, client.RunUntilMessage, ctx)
+	if flagsMsg, ok := checkMessageParticipantFlags(t, msg4); ok {
+		assert.Equal(sessionId, flagsMsg.SessionId)
+		assert.EqualValues(FLAG_TALKING, flagsMsg.Flags)
+	}
+
+	msgFlags := MustSucceed1(t, clientInternal.RunUntilMessage, ctx)
+	checkMessageParticipantFlags(t, msgFlags)
+
+	data := "delivered"
+	require.NoError(client.SendMessage(recipient, data))
+
+	msg5 := MustSucceed1(t, clientInternal.RunUntilMessage, ctx)
+	require.True(checkMessageType(t, msg5, "message"))
+	if assert.NotNil(msg5.Message.Recipient) {
+		assert.Equal(internalSessionId, msg5.Message.Recipient.SessionId)
+	}
+	var payload string
+	if err := json.Unmarshal(msg5.Message.Data, &payload); assert.NoError(err) {
+		assert.Equal(data, payload)
+	}
+
+	// Removing the audio permission mutes the session again.
+	msgUpdate.Internal.UpdateSession.Flags = nil
+	msgUpdate.Internal.UpdateSession.Permissions = &[]Permission{
+		PERMISSION_MAY_CHAT,
+	}
+	require.NoError(clientInternal.WriteJSON(msgUpdate))
+
+	msg6 := MustSucceed1(t, client.RunUntilMessage, ctx)
+	if flagsMsg, ok := checkMessageParticipantFlags(t, msg6); ok {
+		assert.Equal(sessionId, flagsMsg.SessionId)
+		assert.EqualValues(FLAG_MUTED_SPEAKING, flagsMsg.Flags)
+	}
+}
+
 func TestVirtualSessionCleanup(t *testing.T) {
 	t.Parallel()
 	CatchLogForTest(t)
@@ -657,3 +822,227 @@ func TestVirtualSessionCleanup(t *testing.T) {
 		client.checkMessageRoomLeaveSession(msg2, sessionId)
 	}
 }
+
+func TestVirtualSessionDtmf(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	hub, _, _, server := CreateHubForTest(t)
+
+	roomId := "the-room-id"
+	emptyProperties := json.RawMessage("{}")
+	backend := &Backend{
+		id: "compat",
+	}
+	room, err := hub.createRoom(roomId, emptyProperties, backend)
+	require.NoError(err)
+	defer room.Close()
+
+	clientInternal := NewTestClient(t, server, hub)
+	defer clientInternal.CloseWithBye()
+	require.NoError(clientInternal.SendHelloInternal())
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	MustSucceed1(t, clientInternal.RunUntilHello, ctx)
+	client, hello := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId)
+
+	roomMsg := MustSucceed2(t, client.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+
+	// Ignore "join" events.
+	assert.NoError(client.DrainMessages(ctx))
+
+	internalSessionId := PublicSessionId("session1")
+	userId := "user1"
+	msgAdd := &ClientMessage{
+		Type: "internal",
+		Internal: &InternalClientMessage{
+			Type: "addsession",
+			AddSession: &AddSessionInternalClientMessage{
+				CommonSessionInternalClientMessage: CommonSessionInternalClientMessage{
+					SessionId: internalSessionId,
+					RoomId:    roomId,
+				},
+				UserId: userId,
+				Flags:  FLAG_MUTED_SPEAKING,
+			},
+		},
+	}
+	require.NoError(clientInternal.WriteJSON(msgAdd))
+
+	msg1 := MustSucceed1(t, client.RunUntilMessage, ctx)
+	require.True(client.checkMessageJoinedSession(msg1, "", userId))
+	sessionId := msg1.Event.Join[0].SessionId
+
+	// Ignore participants update and flags events.
+	MustSucceed1(t, client.RunUntilMessage, ctx)
+	MustSucceed1(t, client.RunUntilMessage, ctx)
+
+	// Digits from the dial-in participant are sent to the room.
+	require.NoError(clientInternal.WriteJSON(&ClientMessage{
+		Type: "internal",
+		Internal: &InternalClientMessage{
+			Type: "dtmf",
+			Dtmf: &DtmfInternalClientMessage{
+				CommonSessionInternalClientMessage: CommonSessionInternalClientMessage{
+					SessionId: internalSessionId,
+					RoomId:    roomId,
+				},
+				Digits: "1234#",
+			},
+		},
+	}))
+
+	if msg := MustSucceed1(t, client.RunUntilMessage, ctx); checkMessageType(t, msg, "event") {
+		assert.Equal("room", msg.Event.Target)
+		assert.Equal("dtmf", msg.Event.Type)
+		if assert.NotNil(msg.Event.Dtmf) {
+			assert.Equal(roomId, msg.Event.Dtmf.RoomId)
+			assert.Equal(sessionId, msg.Event.Dtmf.SessionId)
+			assert.Equal("1234#", msg.Event.Dtmf.Digits)
+			assert.Empty(msg.Event.Dtmf.Sender)
+		}
+	}
+
+	session := hub.GetSessionByPublicId(hello.Hello.SessionId).(*ClientSession)
+	require.NotNil(session)
+
+	// Only moderators may send digits to the dial-in participant.
+	session.SetPermissions([]Permission{PERMISSION_MAY_PUBLISH_AUDIO})
+	dtmf := &ClientMessage{
+		Id:   "12345",
+		Type: "dtmf",
+		Dtmf: &DtmfClientMessage{
+			SessionId: sessionId,
+			Digits:    "*6",
+		},
+	}
+	require.NoError(client.WriteJSON(dtmf))
+	if msg := MustSucceed1(t, client.RunUntilMessage, ctx); checkMessageType(t, msg, "error") {
+		assert.Equal("12345", msg.Id)
+		assert.Equal("not_allowed", msg.Error.Code)
+	}
+
+	session.SetPermissions([]Permission{PERMISSION_MAY_CONTROL})
+
+	require.NoError(client.WriteJSON(dtmf))
+	if msg := MustSucceed1(t, clientInternal.RunUntilMessage, ctx); checkMessageType(t, msg, "event") {
+		assert.Equal("session", msg.Event.Target)
+		assert.Equal("dtmf", msg.Event.Type)
+		if assert.NotNil(msg.Event.Dtmf) {
+			assert.Equal(roomId, msg.Event.Dtmf.RoomId)
+			// The SIP bridge receives the session id it used for the virtual session.
+			assert.Equal(internalSessionId, msg.Event.Dtmf.SessionId)
+			assert.Equal("*6", msg.Event.Dtmf.Digits)
+			assert.Equal(hello.Hello.SessionId, msg.Event.Dtmf.Sender)
+		}
+	}
+}
+
+func TestVirtualSessionsBulk(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+	hub, _, _, server := CreateHubForTest(t)
+
+	roomId := "the-room-id"
+	emptyProperties := json.RawMessage("{}")
+	backend := &Backend{
+		id: "compat",
+	}
+	room, err := hub.createRoom(roomId, emptyProperties, backend)
+	require.NoError(err)
+	defer room.Close()
+
+	clientInternal := NewTestClient(t, server, hub)
+	defer clientInternal.CloseWithBye()
+	require.NoError(clientInternal.SendHelloInternal())
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	if hello, ok := clientInternal.RunUntilHello(ctx); ok {
+		assert.Contains(hello.Hello.Server.Features, ServerFeatureInternalVirtualSessionsBulk)
+	}
+	client, _ := NewTestClientWithHello(ctx, t, server, hub, testDefaultUserId)
+
+	roomMsg := MustSucceed2(t, client.JoinRoom, ctx, roomId)
+	require.Equal(roomId, roomMsg.Room.RoomId)
+
+	// Ignore "join" events.
+	assert.NoError(client.DrainMessages(ctx))
+
+	internalSessionIds := []PublicSessionId{"session1", "session2", "session3"}
+	msgAdd := &ClientMessage{
+		Type: "internal",
+		Internal: &InternalClientMessage{
+			Type: "addsessions",
+			AddSessions: &AddSessionsInternalClientMessage{
+				RoomId: roomId,
+			},
+		},
+	}
+	for _, sessionId := range internalSessionIds {
+		msgAdd.Internal.AddSessions.Sessions = append(msgAdd.Internal.AddSessions.Sessions, &AddSessionInternalClientMessage{
+			CommonSessionInternalClientMessage: CommonSessionInternalClientMessage{
+				SessionId: sessionId,
+			},
+			UserId: "user-" + string(sessionId),
+		})
+	}
+	require.NoError(clientInternal.WriteJSON(msgAdd))
+
+	// All sessions are notified with a single event.
+	var sessionIds []PublicSessionId
+	msg1 := MustSucceed1(t, client.RunUntilMessage, ctx)
+	if checkMessageType(t, msg1, "event") &&
+		assert.Equal("room", msg1.Event.Target) &&
+		assert.Equal("join", msg1.Event.Type) &&
+		assert.Len(msg1.Event.Join, len(internalSessionIds)) {
+		for idx, entry := range msg1.Event.Join {
+			assert.Equal("user-"+string(internalSessionIds[idx]), entry.UserId)
+			if session := hub.GetSessionByPublicId(entry.SessionId); assert.NotNil(session) {
+				assert.Equal(internalSessionIds[idx], session.(*VirtualSession).SessionId())
+			}
+			sessionIds = append(sessionIds, entry.SessionId)
+		}
+	}
+
+	msg2 := MustSucceed1(t, client.RunUntilMessage, ctx)
+	if updateMsg, ok := checkMessageParticipantsInCall(t, msg2); ok {
+		assert.Equal(roomId, updateMsg.RoomId)
+		assert.Len(updateMsg.Users, len(internalSessionIds))
+	}
+
+	msgRemove := &ClientMessage{
+		Type: "internal",
+		Internal: &InternalClientMessage{
+			Type: "removesessions",
+			RemoveSessions: &RemoveSessionsInternalClientMessage{
+				RoomId:     roomId,
+				SessionIds: internalSessionIds,
+			},
+		},
+	}
+	require.NoError(clientInternal.WriteJSON(msgRemove))
+
+	msg3 := MustSucceed1(t, client.RunUntilMessage, ctx)
+	if checkMessageType(t, msg3, "event") &&
+		assert.Equal("room", msg3.Event.Target) &&
+		assert.Equal("leave", msg3.Event.Type) {
+		assert.ElementsMatch(sessionIds, msg3.Event.Leave)
+	}
+
+	for _, sessionId := range sessionIds {
+		assert.Nil(hub.GetSessionByPublicId(sessionId), "session %s should have been removed", sessionId)
+	}
+
+	// No additional events for the individual sessions.
+	ctx2, cancel2 := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer cancel2()
+	client.RunUntilErrorIs(ctx2, ErrNoMessageReceived, context.DeadlineExceeded)
+}
@@ -0,0 +1,133 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/dlintw/goconf"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	defaultWebsocketCompressionLevel     = 1
+	defaultWebsocketCompressionThreshold = 512
+)
+
+// WebsocketCompression controls the "permessage-deflate" extension for client
+// connections, see RFC 7692.
+type WebsocketCompression struct {
+	enabled    bool
+	level      int
+	threshold  int
+	maxClients int
+
+	current atomic.Int32
+}
+
+func NewWebsocketCompression(config *goconf.ConfigFile) (*WebsocketCompression, error) {
+	result := &WebsocketCompression{}
+	result.enabled, _ = config.GetBool("clients", "compression")
+	if !result.enabled {
+		hubLog.Infof("Websocket compression is disabled")
+		return result, nil
+	}
+
+	level, err := config.GetInt("clients", "compressionlevel")
+	if err != nil {
+		level = defaultWebsocketCompressionLevel
+	} else if level < -2 || level > 9 {
+		return nil, fmt.Errorf("invalid websocket compression level %d", level)
+	}
+	threshold, err := config.GetInt("clients", "compressionthreshold")
+	if err != nil {
+		threshold = defaultWebsocketCompressionThreshold
+	} else if threshold < 0 {
+		return nil, fmt.Errorf("invalid websocket compression threshold %d", threshold)
+	}
+	maxClients, _ := config.GetInt("clients", "compressionmaxclients")
+
+	result.level = level
+	result.threshold = threshold
+	result.maxClients = max(maxClients, 0)
+	if result.maxClients > 0 {
+		hubLog.Infof("Websocket compression is enabled for up to %d clients (level %d, messages from %d bytes)", result.maxClients, level, threshold)
+	} else {
+		hubLog.Infof("Websocket compression is enabled (level %d, messages from %d bytes)", level, threshold)
+	}
+	return result, nil
+}
+
+func (c *WebsocketCompression) configureUpgrader(upgrader *websocket.Upgrader) {
+	upgrader.EnableCompression = c.enabled
+}
+
+// isWebsocketCompressionRequested returns true if the client offered the
+// "permessage-deflate" extension in the websocket handshake.
+func isWebsocketCompressionRequested(r *http.Request) bool {
+	for _, value := range r.Header.Values("Sec-Websocket-Extensions") {
+		for extension := range strings.SplitSeq(value, ",") {
+			name, _, _ := strings.Cut(extension, ";")
+			if strings.EqualFold(strings.TrimSpace(name), "permessage-deflate") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Acquire returns true if messages to the client of the given request may be
+// compressed. In this case, "Release" must be called once the client is gone.
+func (c *WebsocketCompression) Acquire(r *http.Request) bool {
+	if !c.enabled || !isWebsocketCompressionRequested(r) {
+		return false
+	}
+
+	if current := c.current.Add(1); c.maxClients > 0 && int(current) > c.maxClients {
+		c.current.Add(-1)
+		return false
+	}
+
+	statsClientWebsocketCompressedCurrent.Inc()
+	return true
+}
+
+func (c *WebsocketCompression) Release() {
+	c.current.Add(-1)
+	statsClientWebsocketCompressedCurrent.Dec()
+}
+
+// Configure sets up compression of outgoing messages for the given client.
+func (c *WebsocketCompression) Configure(client *Client, conn *websocket.Conn, compress bool) {
+	if !compress {
+		// The extension might have been negotiated nevertheless, so messages
+		// from the client can still be compressed.
+		conn.EnableWriteCompression(false)
+		return
+	}
+
+	conn.SetCompressionLevel(c.level) // nolint
+	client.SetCompressionThreshold(c.threshold)
+}
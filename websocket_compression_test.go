@@ -0,0 +1,142 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dlintw/goconf"
+	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebsocketCompressionRequested(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+
+	testcases := map[string]bool{
+		"":                   false,
+		"foo":                false,
+		"permessage-deflate": true,
+		"permessage-deflate; client_max_window_bits": true,
+		"foo, permessage-deflate":                    true,
+		"permessage-deflate-foo":                     false,
+	}
+	for value, expected := range testcases {
+		r := &http.Request{
+			Header: http.Header{},
+		}
+		if value != "" {
+			r.Header.Set("Sec-WebSocket-Extensions", value)
+		}
+		assert.Equal(expected, isWebsocketCompressionRequested(r), "failed for %s", value)
+	}
+}
+
+func TestWebsocketCompressionConfig(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	require := require.New(t)
+
+	config := goconf.NewConfigFile()
+	compression, err := NewWebsocketCompression(config)
+	require.NoError(err)
+	assert.False(compression.enabled)
+
+	config.AddOption("clients", "compression", "true")
+	compression, err = NewWebsocketCompression(config)
+	require.NoError(err)
+	assert.True(compression.enabled)
+	assert.Equal(defaultWebsocketCompressionLevel, compression.level)
+	assert.Equal(defaultWebsocketCompressionThreshold, compression.threshold)
+	assert.Equal(0, compression.maxClients)
+
+	config.AddOption("clients", "compressionlevel", "10")
+	_, err = NewWebsocketCompression(config)
+	assert.Error(err)
+
+	config.AddOption("clients", "compressionlevel", "9")
+	config.AddOption("clients", "compressionthreshold", "-1")
+	_, err = NewWebsocketCompression(config)
+	assert.Error(err)
+}
+
+func TestWebsocketCompression(t *testing.T) {
+	CatchLogForTest(t)
+	require := require.New(t)
+	assert := assert.New(t)
+
+	_, _, _, server := CreateHubForTestWithConfig(t, func(server *httptest.Server) (*goconf.ConfigFile, error) {
+		config, err := getTestConfig(server)
+		if err != nil {
+			return nil, err
+		}
+
+		config.AddOption("clients", "compression", "true")
+		config.AddOption("clients", "compressionthreshold", "0")
+		config.AddOption("clients", "compressionmaxclients", "1")
+		return config, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	initial := testutil.ToFloat64(statsClientWebsocketCompressedCurrent)
+	dialer := websocket.Dialer{
+		EnableCompression: true,
+	}
+	conn1, response, err := dialer.DialContext(ctx, getWebsocketUrl(server.URL), nil)
+	require.NoError(err)
+	defer conn1.Close()
+	assert.Contains(response.Header.Get("Sec-WebSocket-Extensions"), "permessage-deflate")
+
+	var message ServerMessage
+	require.NoError(conn1.ReadJSON(&message))
+	assert.Equal("welcome", message.Type)
+	checkStatsValue(t, statsClientWebsocketCompressedCurrent, initial+1)
+
+	// Additional clients will not receive compressed messages.
+	conn2, _, err := dialer.DialContext(ctx, getWebsocketUrl(server.URL), nil)
+	require.NoError(err)
+	defer conn2.Close()
+	require.NoError(conn2.ReadJSON(&message))
+	assert.Equal("welcome", message.Type)
+	checkStatsValue(t, statsClientWebsocketCompressedCurrent, initial+1)
+
+	conn1.Close()
+	for testutil.ToFloat64(statsClientWebsocketCompressedCurrent) != initial {
+		select {
+		case <-ctx.Done():
+			require.NoError(ctx.Err(), "compressed client was not released")
+		default:
+			time.Sleep(time.Millisecond)
+		}
+	}
+	checkStatsValue(t, statsClientWebsocketCompressedCurrent, initial)
+}
@@ -0,0 +1,364 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
+	"github.com/quic-go/webtransport-go"
+)
+
+const (
+	// Number of messages that can be queued for a WebTransport client.
+	webtransportClientMessageQueue = 256
+
+	// Maximum time to wait for the client to open the stream for messages.
+	webtransportStreamTimeout = 10 * time.Second
+
+	// Size of the length prefix of messages.
+	webtransportLengthSize = 4
+)
+
+var (
+	errWebTransportMessageTooLarge = errors.New("message too large")
+)
+
+// readWebTransportMessage reads a message that is prefixed with its length as
+// 32bit unsigned integer in network byte order.
+func readWebTransportMessage(r io.Reader) ([]byte, error) {
+	var header [webtransportLengthSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+
+	length := binary.BigEndian.Uint32(header[:])
+	if length > maxMessageSize {
+		return nil, errWebTransportMessageTooLarge
+	}
+
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// writeWebTransportMessage writes a message prefixed with its length.
+func writeWebTransportMessage(w io.Writer, data []byte) error {
+	buf := make([]byte, webtransportLengthSize, webtransportLengthSize+len(data))
+	binary.BigEndian.PutUint32(buf, uint32(len(data)))
+	buf = append(buf, data...)
+	_, err := w.Write(buf)
+	return err
+}
+
+// webtransportClient is a client that exchanges messages through a stream of
+// a WebTransport session. As WebTransport is based on QUIC, connections can
+// be established faster and survive changes of the network of the client.
+type webtransportClient struct {
+	hub    *Hub
+	ctx    context.Context
+	addr   string
+	agent  string
+	stream *webtransport.Stream
+
+	countryOnce sync.Once
+	country     string
+
+	session atomic.Pointer[Session]
+
+	closeCtx  context.Context
+	closeFunc context.CancelFunc
+
+	messages chan WritableClientMessage
+}
+
+func newWebTransportClient(ctx context.Context, hub *Hub, addr string, agent string, stream *webtransport.Stream) *webtransportClient {
+	addr = strings.TrimSpace(addr)
+	if addr == "" {
+		addr = "unknown remote address"
+	}
+	agent = strings.TrimSpace(agent)
+	if agent == "" {
+		agent = "unknown user agent"
+	}
+
+	closeCtx, closeFunc := context.WithCancel(context.Background())
+	return &webtransportClient{
+		hub:    hub,
+		ctx:    ctx,
+		addr:   addr,
+		agent:  agent,
+		stream: stream,
+
+		closeCtx:  closeCtx,
+		closeFunc: closeFunc,
+
+		messages: make(chan WritableClientMessage, webtransportClientMessageQueue),
+	}
+}
+
+func (c *webtransportClient) Context() context.Context {
+	return c.ctx
+}
+
+func (c *webtransportClient) RemoteAddr() string {
+	return c.addr
+}
+
+func (c *webtransportClient) UserAgent() string {
+	return c.agent
+}
+
+func (c *webtransportClient) Country() string {
+	c.countryOnce.Do(func() {
+		c.country = c.hub.OnLookupCountry(c)
+	})
+	return c.country
+}
+
+func (c *webtransportClient) IsConnected() bool {
+	return c.closeCtx.Err() == nil
+}
+
+func (c *webtransportClient) IsAuthenticated() bool {
+	return c.GetSession() != nil
+}
+
+func (c *webtransportClient) GetSession() Session {
+	session := c.session.Load()
+	if session == nil {
+		return nil
+	}
+
+	return *session
+}
+
+func (c *webtransportClient) SetSession(session Session) {
+	if session == nil {
+		c.session.Store(nil)
+	} else {
+		c.session.Store(&session)
+	}
+}
+
+func (c *webtransportClient) SendError(e *Error) bool {
+	message := &ServerMessage{
+		Type:  "error",
+		Error: e,
+	}
+	return c.SendMessage(message)
+}
+
+func (c *webtransportClient) SendByeResponse(message *ClientMessage) bool {
+	return c.SendByeResponseWithReason(message, "")
+}
+
+func (c *webtransportClient) SendByeResponseWithReason(message *ClientMessage, reason string) bool {
+	response := &ServerMessage{
+		Type: "bye",
+	}
+	if message != nil {
+		response.Id = message.Id
+	}
+	response.Bye = NewByeServerMessage(reason)
+	return c.SendMessage(response)
+}
+
+func (c *webtransportClient) SendMessage(message WritableClientMessage) bool {
+	if !c.IsConnected() {
+		return false
+	}
+
+	select {
+	case c.messages <- message:
+		return true
+	default:
+		hubLog.Warnf("Message queue for WebTransport client from %s is full, not sending %+v", c.addr, message)
+		return false
+	}
+}
+
+func (c *webtransportClient) Close() {
+	c.closeFunc()
+}
+
+// readPump processes messages from the client until the stream is closed.
+func (c *webtransportClient) readPump() error {
+	for {
+		data, err := readWebTransportMessage(c.stream)
+		if err != nil {
+			if errors.Is(err, io.EOF) || !c.IsConnected() {
+				return nil
+			}
+
+			return err
+		}
+
+		c.hub.OnMessageReceived(c, data)
+	}
+}
+
+// writePump writes queued messages to the client until it is closed.
+func (c *webtransportClient) writePump() error {
+	for {
+		select {
+		case <-c.ctx.Done():
+			return nil
+		case <-c.closeCtx.Done():
+			return nil
+		case message := <-c.messages:
+			data, err := json.Marshal(message)
+			if err != nil {
+				hubLog.Errorf("Could not marshal message %+v for WebTransport client from %s: %s", message, c.addr, err)
+				continue
+			}
+
+			c.stream.SetWriteDeadline(time.Now().Add(writeWait)) // nolint
+			if err := writeWebTransportMessage(c.stream, data); err != nil {
+				return err
+			}
+
+			if session := c.GetSession(); message.CloseAfterSend(session) {
+				if session != nil {
+					go session.Close()
+				}
+				return nil
+			}
+		}
+	}
+}
+
+// WebTransportServer accepts WebTransport sessions over HTTP/3 and handles
+// them like websocket connections to "/spreed".
+type WebTransportServer struct {
+	hub    *Hub
+	server *webtransport.Server
+}
+
+func NewWebTransportServer(hub *Hub, getCertificate func(*tls.ClientHelloInfo) (*tls.Certificate, error)) *WebTransportServer {
+	result := &WebTransportServer{
+		hub: hub,
+	}
+
+	r := mux.NewRouter()
+	r.HandleFunc("/spreed", result.serve).Methods(http.MethodConnect)
+	result.server = &webtransport.Server{
+		H3: &http3.Server{
+			Handler: r,
+			TLSConfig: http3.ConfigureTLSConfig(&tls.Config{
+				GetCertificate: getCertificate,
+			}),
+			QUICConfig: &quic.Config{
+				// Keep the NAT bindings of clients alive.
+				KeepAlivePeriod: hub.keepalive.pingInterval,
+			},
+		},
+		CheckOrigin: hub.checkOrigin,
+	}
+	webtransport.ConfigureHTTP3Server(result.server.H3)
+	hub.enableWebTransport()
+	return result
+}
+
+// Serve accepts WebTransport sessions on the given connection until the
+// server is closed.
+func (s *WebTransportServer) Serve(conn net.PacketConn) error {
+	return s.server.Serve(conn)
+}
+
+func (s *WebTransportServer) Close() error {
+	return s.server.Close()
+}
+
+func (s *WebTransportServer) serve(w http.ResponseWriter, r *http.Request) {
+	h := s.hub
+	addr := h.getRealUserIP(r)
+	agent := r.Header.Get("User-Agent")
+
+	w.Header().Set("Server", "nextcloud-spreed-signaling/"+h.version)
+	w.Header().Set("X-Spreed-Signaling-Features", strings.Join(h.info.Features, ", "))
+	session, err := s.server.Upgrade(w, r)
+	if err != nil {
+		hubLog.Errorf("Could not upgrade WebTransport request from %s: %s", addr, err)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	defer session.CloseWithError(0, "") // nolint
+
+	// The client opens a bidirectional stream that is used to exchange the
+	// same messages as on websocket connections.
+	ctx, cancel := context.WithTimeout(session.Context(), webtransportStreamTimeout)
+	stream, err := session.AcceptStream(ctx)
+	cancel()
+	if err != nil {
+		hubLog.Errorf("No WebTransport stream opened by %s: %s", addr, err)
+		return
+	}
+
+	client := newWebTransportClient(session.Context(), h, addr, agent, stream)
+	h.processNewClient(client)
+	go func() {
+		defer reportPanic(LogSubsystemHub, client.GetSession)
+		err := client.writePump()
+		client.Close()
+		if err != nil {
+			hubLog.Errorf("Error writing to WebTransport client from %s: %s", addr, err)
+			// Closing the session also stops reading.
+			session.CloseWithError(0, "") // nolint
+			return
+		}
+
+		// Give the client some time to receive the pending messages before
+		// closing the session.
+		stream.Close() // nolint
+		time.AfterFunc(writeWait, func() {
+			session.CloseWithError(0, "") // nolint
+		})
+	}()
+
+	defer reportPanic(LogSubsystemHub, client.GetSession)
+	if err := client.readPump(); err != nil {
+		if session := client.GetSession(); session != nil {
+			hubLog.Errorf("Error reading from WebTransport client %s: %s", session.PublicId(), err)
+		} else {
+			hubLog.Errorf("Error reading from WebTransport client from %s: %s", addr, err)
+		}
+	}
+
+	client.Close()
+	h.OnClosed(client)
+}
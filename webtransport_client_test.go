@@ -0,0 +1,196 @@
+/**
+ * Standalone signaling server for the Nextcloud Spreed app.
+ * Copyright (C) 2025 struktur AG
+ *
+ * @author Joachim Bauch <bauch@struktur.de>
+ *
+ * @license GNU AGPL version 3 or any later version
+ *
+ * This program is free software: you can redistribute it and/or modify
+ * it under the terms of the GNU Affero General Public License as published by
+ * the Free Software Foundation, either version 3 of the License, or
+ * (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful,
+ * but WITHOUT ANY WARRANTY; without even the implied warranty of
+ * MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+ * GNU Affero General Public License for more details.
+ *
+ * You should have received a copy of the GNU Affero General Public License
+ * along with this program.  If not, see <http://www.gnu.org/licenses/>.
+ */
+package signaling
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/quic-go/webtransport-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testWebTransportClient struct {
+	t      *testing.T
+	server string
+	stream *webtransport.Stream
+}
+
+func startWebTransportServerForTest(t *testing.T, hub *Hub) (string, *x509.CertPool) {
+	require := require.New(t)
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(err)
+	certData := GenerateSelfSignedCertificateForTesting(t, 1024, "WebTransport", key)
+	keyData := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+	cert, err := tls.X509KeyPair(certData, keyData)
+	require.NoError(err)
+
+	pool := x509.NewCertPool()
+	require.True(pool.AppendCertsFromPEM(certData))
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(err)
+
+	server := NewWebTransportServer(hub, func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+		return &cert, nil
+	})
+	go server.Serve(conn) // nolint
+	t.Cleanup(func() {
+		assert.NoError(t, server.Close())
+		conn.Close()
+	})
+	return fmt.Sprintf("https://%s/spreed", conn.LocalAddr()), pool
+}
+
+func newTestWebTransportClient(ctx context.Context, t *testing.T, url string, pool *x509.CertPool, backendUrl string) *testWebTransportClient {
+	require := require.New(t)
+	dialer := &webtransport.Dialer{
+		TLSClientConfig: &tls.Config{
+			RootCAs: pool,
+		},
+	}
+	t.Cleanup(func() {
+		dialer.Close() // nolint
+	})
+
+	res, session, err := dialer.Dial(ctx, url, nil)
+	require.NoError(err)
+	require.Equal(http.StatusOK, res.StatusCode)
+	assert.Contains(t, res.Header.Get("X-Spreed-Signaling-Features"), ServerFeatureWebTransport)
+	t.Cleanup(func() {
+		session.CloseWithError(0, "") // nolint
+	})
+
+	stream, err := session.OpenStreamSync(ctx)
+	require.NoError(err)
+	return &testWebTransportClient{
+		t:      t,
+		server: backendUrl,
+		stream: stream,
+	}
+}
+
+func (c *testWebTransportClient) ReadMessage() *ServerMessage {
+	data, err := readWebTransportMessage(c.stream)
+	require.NoError(c.t, err)
+	var message ServerMessage
+	require.NoError(c.t, json.Unmarshal(data, &message))
+	return &message
+}
+
+func (c *testWebTransportClient) Send(message *ClientMessage) {
+	data, err := json.Marshal(message)
+	require.NoError(c.t, err)
+	require.NoError(c.t, writeWebTransportMessage(c.stream, data))
+}
+
+func (c *testWebTransportClient) SendHello(userId string) {
+	params, err := json.Marshal(TestBackendClientAuthParams{
+		UserId: userId,
+	})
+	require.NoError(c.t, err)
+	c.Send(&ClientMessage{
+		Id:   "1234",
+		Type: "hello",
+		Hello: &HelloClientMessage{
+			Version: HelloVersionV1,
+			Auth: &HelloClientMessageAuth{
+				Url:    c.server,
+				Params: params,
+			},
+		},
+	})
+}
+
+func TestWebTransportMessageFraming(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+
+	var buf bytes.Buffer
+	require.NoError(writeWebTransportMessage(&buf, []byte("hello")))
+	require.NoError(writeWebTransportMessage(&buf, []byte{}))
+	assert.Equal([]byte{0, 0, 0, 5, 'h', 'e', 'l', 'l', 'o', 0, 0, 0, 0}, buf.Bytes())
+
+	data, err := readWebTransportMessage(&buf)
+	require.NoError(err)
+	assert.Equal([]byte("hello"), data)
+	data, err = readWebTransportMessage(&buf)
+	require.NoError(err)
+	assert.Empty(data)
+
+	_, err = readWebTransportMessage(bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff}))
+	assert.ErrorIs(err, errWebTransportMessageTooLarge)
+	_, err = readWebTransportMessage(bytes.NewReader([]byte{0, 0, 0, 5, 'h'}))
+	assert.Error(err)
+}
+
+func TestWebTransportClient(t *testing.T) {
+	t.Parallel()
+	CatchLogForTest(t)
+	assert := assert.New(t)
+	require := require.New(t)
+	hub, _, _, server := CreateHubForTest(t)
+	url, pool := startWebTransportServerForTest(t, hub)
+
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+
+	client := newTestWebTransportClient(ctx, t, url, pool, server.URL)
+	// The server only notices the stream after the client sent data, so the
+	// welcome message is received after sending the hello.
+	client.SendHello(testDefaultUserId)
+	if message := client.ReadMessage(); checkMessageType(t, message, "welcome") {
+		assert.Contains(message.Welcome.Features, ServerFeatureWebTransport)
+	}
+	message := client.ReadMessage()
+	require.True(checkMessageType(t, message, "hello"))
+	assert.Equal(testDefaultUserId, message.Hello.UserId)
+	assert.NotNil(hub.GetSessionByPublicId(message.Hello.SessionId))
+
+	client.Send(&ClientMessage{
+		Id:   "9876",
+		Type: "bye",
+		Bye:  &ByeClientMessage{},
+	})
+	if message := client.ReadMessage(); checkMessageType(t, message, "bye") {
+		assert.Equal("9876", message.Id)
+	}
+
+	// The server closed the session.
+	_, err := readWebTransportMessage(client.stream)
+	require.Error(err)
+}